@@ -17,12 +17,18 @@
 package accounts
 
 import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
 )
 
 // DefaultRootDerivationPath is the root path to which custom derivation endpoints
@@ -178,3 +184,59 @@ func LedgerLiveIterator(base DerivationPath) func() DerivationPath {
 		return path
 	}
 }
+
+// MnemonicSeed derives the BIP-39 seed of a mnemonic sentence, protected by an
+// optional passphrase. The words are not checked against the BIP-39 wordlist.
+func MnemonicSeed(mnemonic string, passphrase string) []byte {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase), 2048, 64, sha512.New)
+}
+
+// DeriveKey derives the private key at the given BIP-32 path from a seed.
+func DeriveKey(seed []byte, path DerivationPath) (*ecdsa.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	var (
+		n         = crypto.S256().Params().N
+		key       = new(big.Int).SetBytes(sum[:32])
+		chainCode = sum[32:]
+	)
+	if key.Sign() == 0 || key.Cmp(n) >= 0 {
+		return nil, errors.New("invalid master key")
+	}
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0}, key.FillBytes(make([]byte, 32))...)
+		} else {
+			data = crypto.CompressPubkey(&mustToECDSA(key).PublicKey)
+		}
+		data = append(data, byte(index>>24), byte(index>>16), byte(index>>8), byte(index))
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(n) >= 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		key = tweak.Add(tweak, key).Mod(tweak, n)
+		if key.Sign() == 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		chainCode = sum[32:]
+	}
+	return mustToECDSA(key), nil
+}
+
+// mustToECDSA converts a scalar known to be in the valid range into a private key.
+func mustToECDSA(key *big.Int) *ecdsa.PrivateKey {
+	priv, err := crypto.ToECDSA(key.FillBytes(make([]byte, 32)))
+	if err != nil {
+		panic(err)
+	}
+	return priv
+}
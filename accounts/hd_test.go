@@ -20,6 +20,9 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // Tests that HD derivation paths can be correctly parsed into our internal binary
@@ -118,3 +121,31 @@ func TestHdPathIteration(t *testing.T) {
 			"m/44'/60'/8'/0/0", "m/44'/60'/9'/0/0",
 		})
 }
+
+// Tests that keys are derived from mnemonics like the common wallets and
+// development tools do.
+func TestDeriveKey(t *testing.T) {
+	seed := MnemonicSeed("test test test test test test test test test test test junk", "")
+
+	tests := []struct {
+		path string
+		addr string
+	}{
+		{"m/44'/60'/0'/0/0", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{"m/44'/60'/0'/0/1", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{"m/44'/60'/0'/0/9", "0xa0Ee7A142d267C1f36714E4a8F75612F20a79720"},
+	}
+	for _, tt := range tests {
+		path, err := ParseDerivationPath(tt.path)
+		if err != nil {
+			t.Fatalf("%s: failed to parse path: %v", tt.path, err)
+		}
+		key, err := DeriveKey(seed, path)
+		if err != nil {
+			t.Fatalf("%s: failed to derive key: %v", tt.path, err)
+		}
+		if addr := crypto.PubkeyToAddress(key.PublicKey); addr != common.HexToAddress(tt.addr) {
+			t.Errorf("%s: address mismatch: have %v, want %s", tt.path, addr, tt.addr)
+		}
+	}
+}
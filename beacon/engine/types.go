@@ -159,6 +159,15 @@ type PayloadStatusV1 struct {
 	ValidationError *string        `json:"validationError"`
 }
 
+// BuilderPayloadStatusV1 is the result of submitting an externally built payload.
+type BuilderPayloadStatusV1 struct {
+	Status          string       `json:"status"`
+	Value           *hexutil.Big `json:"value,omitempty"`
+	Selected        bool         `json:"selected"`
+	Decision        string       `json:"decision,omitempty"`
+	ValidationError *string      `json:"validationError"`
+}
+
 type TransitionConfigurationV1 struct {
 	TerminalTotalDifficulty *hexutil.Big   `json:"terminalTotalDifficulty"`
 	TerminalBlockHash       common.Hash    `json:"terminalBlockHash"`
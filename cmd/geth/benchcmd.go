@@ -0,0 +1,154 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	benchRunsFlag = &cli.IntFlag{
+		Name:  "runs",
+		Usage: "Number of measured block building runs",
+		Value: 10,
+	}
+	benchWarmupFlag = &cli.IntFlag{
+		Name:  "warmup",
+		Usage: "Number of unmeasured block building runs before the measured ones",
+		Value: 1,
+	}
+	benchRecordFlag = &cli.StringFlag{
+		Name:  "record",
+		Usage: "JSON file containing the payload record to build, instead of reading it from the database",
+	}
+
+	benchCommand = &cli.Command{
+		Name:  "bench",
+		Usage: "A set of commands for benchmarking the node",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "payload",
+				Usage:     "Benchmark the block building on a recorded txpool snapshot",
+				ArgsUsage: "[<blockhash>]",
+				Action:    benchPayload,
+				Flags: slices.Concat([]cli.Flag{
+					benchRunsFlag,
+					benchWarmupFlag,
+					benchRecordFlag,
+					utils.MinerGasLimitFlag,
+					utils.MinerEffectiveGasLimitFlag,
+					utils.MinerGasPriceFlag,
+					utils.MinerExtraDataFlag,
+					utils.MinerRecommitIntervalFlag,
+					utils.MinerCommitPolicyFlag,
+				}, utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth bench payload [--record <file>] [<blockhash>]
+builds a payload from its recorded inputs repeatedly and reports the latency
+and throughput distribution of the block building.
+
+The payload record captures the parent block, the payload attributes and the
+txpool snapshot offered to the block building. It is read from the database for
+the locally built block with the given hash (see --miner.recordpayloads), or
+from a JSON file. The parent state must be available in the database.
+
+The block is built with the miner settings given on the command line rather
+than the recorded ones, so that changes to them or to the miner itself can be
+compared on identical inputs. The prioritized senders are taken from the record.
+`,
+			},
+		},
+	}
+)
+
+// benchBackend provides the miner with the chain, without a txpool.
+type benchBackend struct {
+	chain *core.BlockChain
+	db    ethdb.Database
+}
+
+func (b *benchBackend) BlockChain() *core.BlockChain { return b.chain }
+func (b *benchBackend) TxPool() *txpool.TxPool       { return nil }
+func (b *benchBackend) ChainDb() ethdb.Database      { return b.db }
+
+func benchPayload(ctx *cli.Context) error {
+	if ctx.NArg() > 1 || (ctx.NArg() == 1) == ctx.IsSet(benchRecordFlag.Name) {
+		return errors.New("expected either a block hash or a record file")
+	}
+	stack, cfg := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, true)
+	defer db.Close()
+	defer chain.Stop()
+
+	m := miner.New(&benchBackend{chain: chain, db: db}, cfg.Eth.Miner, chain.Engine())
+	defer m.Close()
+
+	var (
+		record *miner.PayloadRecord
+		err    error
+	)
+	if file := ctx.String(benchRecordFlag.Name); file != "" {
+		blob, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		record = new(miner.PayloadRecord)
+		if err := json.Unmarshal(blob, record); err != nil {
+			return fmt.Errorf("invalid payload record: %w", err)
+		}
+	} else {
+		hash := common.HexToHash(ctx.Args().First())
+		if record, err = m.PayloadRecord(hash); err != nil {
+			return err
+		}
+	}
+	m.SetPrioAddresses(record.Prio)
+
+	result, err := m.BenchPayload(record, ctx.Int(benchRunsFlag.Name), ctx.Int(benchWarmupFlag.Name))
+	if err != nil {
+		return err
+	}
+	var (
+		first        = result.Runs[0]
+		gasps, txsps = result.Throughput()
+	)
+	fmt.Printf("Parent:       %#x\n", result.Parent)
+	fmt.Printf("Candidates:   %d transactions\n", result.Candidates)
+	fmt.Printf("Built block:  %d transactions, %d gas\n", first.Txs, first.GasUsed)
+	if !result.Deterministic() {
+		fmt.Printf("WARNING:      runs built different blocks, the building was likely interrupted\n")
+	}
+	fmt.Printf("Runs:         %d (%d warmup)\n", len(result.Runs), ctx.Int(benchWarmupFlag.Name))
+	fmt.Printf("Latency:      min %v, p50 %v, p90 %v, p99 %v, max %v\n",
+		result.Latency(0), result.Latency(50), result.Latency(90), result.Latency(99), result.Latency(100))
+	fmt.Printf("Throughput:   %.2f Mgas/s, %.2f txs/s\n", gasps/1e6, txsps)
+	return nil
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/genesis"
 	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -72,6 +73,33 @@ It expects the genesis file as argument.`,
 		Description: `
 The dumpgenesis command prints the genesis configuration of the network preset
 if one is set.  Otherwise it prints the genesis from the datadir.`,
+	}
+	buildGenesisCommand = &cli.Command{
+		Action:    buildGenesis,
+		Name:      "buildgenesis",
+		Usage:     "Composes a genesis JSON configuration from a manifest and prints it to stdout",
+		ArgsUsage: "<manifest>",
+		Description: `
+The buildgenesis command composes a genesis specification out of a base genesis
+file, plain and deterministically derived account allocations and predeployed
+contracts, as described by the given JSON manifest:
+
+  {
+    "base": "genesis.json",
+    "accounts": {"0x...": {"balance": "0x..."}},
+    "derive": [{"seed": "devnet", "count": 10, "balance": "1000000000000000000"}],
+    "predeploys": {
+      "0x...": {
+        "artifact": "out/Token.sol/Token.json",
+        "storage": {"owner": "0x...", "totalSupply": "1000000"}
+      }
+    }
+  }
+
+Paths are relative to the manifest. Predeploys take the runtime bytecode of the
+Foundry, Hardhat or solc artifact, and state variables are set by label using
+the storage layout of the artifact. The private key of the i-th derived account
+is keccak256(seed || i), with i encoded as 8 byte big endian.`,
 	}
 	importCommand = &cli.Command{
 		Action:    importChain,
@@ -184,9 +212,37 @@ It's deprecated, please use "geth db import" instead.
 			utils.IncludeIncompletesFlag,
 			utils.StartKeyFlag,
 			utils.DumpLimitFlag,
+			utils.DumpStreamFlag,
+			utils.DumpChunkSizeFlag,
 		}, utils.DatabaseFlags),
 		Description: `
 This command dumps out the state for a given block (or latest, if none provided).
+
+With --stream, the state is printed as a sequence of chunks, one JSON object per
+line. Each chunk holds a range of accounts with their code and storage, and the
+Merkle proof of the range against the state root, so it can be verified on its
+own. The "next" field of a chunk is the start of the following one: an
+interrupted dump is resumed by passing it to --start. In this mode, --limit is
+the maximum number of chunks to print.
+`,
+	}
+
+	importStateCommand = &cli.Command{
+		Action:    importState,
+		Name:      "import-state",
+		Usage:     "Import a state from a streaming state dump",
+		ArgsUsage: "<dumpfile> [<dumpfile> ...]",
+		Flags:     slices.Concat([]cli.Flag{utils.CacheFlag}, utils.DatabaseFlags),
+		Description: `
+The import-state command reconstructs a state from the chunks of a streaming dump
+(geth dump --stream), reading the files in order. Every chunk is verified against
+the state root before being written, and the command fails unless the chunks
+cover the whole state. The trie nodes, code and preimages of the state are written
+to the database and the state root is printed, for use in regenesis or chain
+surgery workflows. The chain itself is left untouched.
+
+With the path based state scheme, which holds a single persistent state, the
+database must not hold any state yet.
 `,
 	}
 
@@ -295,6 +351,20 @@ func dumpGenesis(ctx *cli.Context) error {
 	return nil
 }
 
+func buildGenesis(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("need manifest as the only argument")
+	}
+	spec, err := genesis.Build(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("failed to build genesis: %v", err)
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(spec); err != nil {
+		utils.Fatalf("could not encode genesis: %s", err)
+	}
+	return nil
+}
+
 func importChain(ctx *cli.Context) error {
 	if ctx.Args().Len() < 1 {
 		utils.Fatalf("This command requires an argument.")
@@ -604,6 +674,9 @@ func dump(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if ctx.Bool(utils.DumpStreamFlag.Name) {
+		return dumpStream(ctx, state, common.BytesToHash(conf.Start))
+	}
 	if ctx.Bool(utils.IterativeOutputFlag.Name) {
 		state.IterativeDump(conf, json.NewEncoder(os.Stdout))
 	} else {
@@ -612,6 +685,86 @@ func dump(ctx *cli.Context) error {
 	return nil
 }
 
+// dumpStream prints the state as a stream of chunks, from the given account hash.
+func dumpStream(ctx *cli.Context, statedb *state.StateDB, start common.Hash) error {
+	var (
+		out    = json.NewEncoder(os.Stdout)
+		limit  = ctx.Uint64(utils.DumpLimitFlag.Name)
+		chunks uint64
+	)
+	errLimit := errors.New("chunk limit reached")
+	err := statedb.DumpChunks(start, ctx.Int(utils.DumpChunkSizeFlag.Name), func(chunk *state.StateChunk) error {
+		if err := out.Encode(chunk); err != nil {
+			return err
+		}
+		if chunks++; limit > 0 && chunks >= limit && chunk.Next != nil {
+			log.Info("Chunk limit reached, resume with --start", "next", *chunk.Next)
+			return errLimit
+		}
+		return nil
+	})
+	if errors.Is(err, errLimit) {
+		return nil
+	}
+	return err
+}
+
+func importState(ctx *cli.Context) error {
+	if ctx.Args().Len() < 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	scheme, err := rawdb.ParseStateScheme(ctx.String(utils.StateSchemeFlag.Name), db)
+	if err != nil {
+		return err
+	}
+	if scheme == rawdb.PathScheme && len(rawdb.ReadAccountTrieNode(db, nil)) > 0 {
+		return errors.New("database already holds a path based state")
+	}
+	var (
+		start = time.Now()
+		imp   = state.NewStateImporter(db, scheme)
+	)
+	for _, path := range ctx.Args().Slice() {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		dec := json.NewDecoder(file)
+		for dec.More() {
+			chunk := new(state.StateChunk)
+			if err := dec.Decode(chunk); err != nil {
+				file.Close()
+				return fmt.Errorf("invalid chunk in %s: %w", path, err)
+			}
+			if err := imp.Import(chunk); err != nil {
+				file.Close()
+				return fmt.Errorf("failed to import chunk at %x from %s: %w", chunk.Start, path, err)
+			}
+		}
+		file.Close()
+	}
+	root, err := imp.Commit()
+	if err != nil {
+		return err
+	}
+	if scheme == rawdb.PathScheme {
+		// Make the imported state the persistent state of the path database.
+		triedb := utils.MakeTrieDatabase(ctx, db, false, false, false)
+		defer triedb.Close()
+		if err := triedb.Enable(root); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Imported state %x in %v\n", root, time.Since(start))
+	return nil
+}
+
 // hashish returns true for strings that look like hashes.
 func hashish(x string) bool {
 	_, err := strconv.Atoi(x)
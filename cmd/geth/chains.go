@@ -0,0 +1,299 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/catalyst"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli/v2"
+)
+
+var chainsFlag = &cli.StringSliceFlag{
+	Name:     "chains",
+	Usage:    "Additional chains hosted in the process, as name=config.toml pairs (each with its own caches and p2p server)",
+	Category: flags.EthCategory,
+}
+
+// hostedChainMetricsInterval is the interval of refreshing the metrics of the
+// hosted chains.
+const hostedChainMetricsInterval = 3 * time.Second
+
+// chainNameRegexp restricts the chain names to the ones usable as RPC
+// namespace prefixes.
+var chainNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// hostedChain is an independent chain instance running in the process of the
+// primary node, with its own data directory and chain configuration.
+type hostedChain struct {
+	name  string
+	stack *node.Node
+	eth   *eth.Ethereum
+}
+
+// metric returns the name of a metric of the hosted chain, namespaced by the
+// chain name.
+func (c *hostedChain) metric(name string) string {
+	return "chains/" + c.name + "/" + name
+}
+
+// hostedChainGauges are the gauges reported for every hosted chain.
+var hostedChainGauges = []string{"chain/head/block", "chain/head/finalized", "p2p/peers", "txpool/pending", "txpool/queued"}
+
+// updateMetrics refreshes the namespaced metrics of the hosted chain.
+func (c *hostedChain) updateMetrics() {
+	var (
+		chain           = c.eth.BlockChain()
+		finalized       uint64
+		pending, queued = c.eth.TxPool().Stats()
+	)
+	if block := chain.CurrentFinalBlock(); block != nil {
+		finalized = block.Number.Uint64()
+	}
+	metrics.GetOrRegisterGauge(c.metric("chain/head/block"), nil).Update(chain.CurrentBlock().Number.Int64())
+	metrics.GetOrRegisterGauge(c.metric("chain/head/finalized"), nil).Update(int64(finalized))
+	metrics.GetOrRegisterGauge(c.metric("p2p/peers"), nil).Update(int64(c.stack.Server().PeerCount()))
+	metrics.GetOrRegisterGauge(c.metric("txpool/pending"), nil).Update(int64(pending))
+	metrics.GetOrRegisterGauge(c.metric("txpool/queued"), nil).Update(int64(queued))
+}
+
+// unregisterMetrics removes the namespaced metrics of the hosted chain.
+func (c *hostedChain) unregisterMetrics() {
+	for _, name := range hostedChainGauges {
+		metrics.Unregister(c.metric(name))
+	}
+}
+
+// loadHostedChainConfig loads the configuration of a hosted chain. Unlike the
+// primary node, a hosted chain doesn't connect to the p2p network unless its
+// configuration asks for it.
+func loadHostedChainConfig(file string) (gethConfig, error) {
+	cfg := gethConfig{
+		Eth:     ethconfig.Defaults,
+		Node:    defaultNodeConfig(),
+		Metrics: metrics.DefaultConfig,
+	}
+	cfg.Node.P2P.ListenAddr = ""
+	cfg.Node.P2P.NoDiscovery = true
+	cfg.Node.P2P.MaxPeers = 0
+
+	if err := loadConfig(file, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// makeHostedChains creates the chains requested to be hosted in the process of
+// the given primary node. Their unauthenticated APIs are served by the primary
+// node under namespaces prefixed with the chain name, e.g. devnet1.eth, while
+// the authenticated ones, e.g. the engine API, are only served on their own
+// authenticated endpoints.
+//
+// Apart from the process and the primary RPC endpoints, the hosted chains share
+// nothing: each has its own database and caches, sized by its own
+// configuration, and its own p2p server. The memory used by a hosted chain adds
+// up to the one of the primary node.
+//
+// The main metrics of every hosted chain are reported under the chains/<name>/
+// prefix. The metrics of the subsystems are registered process-wide, so they
+// aggregate all the chains of the process.
+func makeHostedChains(ctx *cli.Context, stack *node.Node) error {
+	return hostChains(stack, ctx.StringSlice(chainsFlag.Name))
+}
+
+// hostChains creates the hosted chains of the given name=config.toml specs and
+// registers them with the primary node.
+func hostChains(stack *node.Node, specs []string) error {
+	var (
+		primary = stack.Config()
+		chains  []*hostedChain
+		names   = make(map[string]bool)
+		datadir = map[string]string{filepath.Clean(primary.DataDir): "primary"}
+		auth    = map[int]string{primary.AuthPort: "primary"}
+	)
+	for _, spec := range specs {
+		name, file, ok := strings.Cut(spec, "=")
+		if !ok || file == "" {
+			return fmt.Errorf("invalid hosted chain %q, want name=config.toml", spec)
+		}
+		if !chainNameRegexp.MatchString(name) {
+			return fmt.Errorf("invalid hosted chain name %q, must be lowercase alphanumeric", name)
+		}
+		if names[name] {
+			return fmt.Errorf("duplicate hosted chain %q", name)
+		}
+		names[name] = true
+
+		cfg, err := loadHostedChainConfig(file)
+		if err != nil {
+			return err
+		}
+		if cfg.Node.DataDir == "" {
+			return fmt.Errorf("hosted chain %q has no data directory", name)
+		}
+		if other, ok := datadir[filepath.Clean(cfg.Node.DataDir)]; ok {
+			return fmt.Errorf("hosted chain %q shares its data directory with %s", name, other)
+		}
+		datadir[filepath.Clean(cfg.Node.DataDir)] = name
+
+		if other, ok := auth[cfg.Node.AuthPort]; ok {
+			return fmt.Errorf("hosted chain %q shares its authenticated RPC port %d with %s", name, cfg.Node.AuthPort, other)
+		}
+		auth[cfg.Node.AuthPort] = name
+
+		chain, err := makeHostedChain(name, cfg)
+		if err != nil {
+			return err
+		}
+		chains = append(chains, chain)
+	}
+	if len(chains) == 0 {
+		return nil
+	}
+	// Serve the APIs of the hosted chains on the primary node, enabling the
+	// prefixed namespaces of the modules enabled on the primary endpoints.
+	for _, chain := range chains {
+		var apis []rpc.API
+		for _, api := range chain.stack.APIs() {
+			if api.Authenticated {
+				continue
+			}
+			api.Namespace = chain.name + "." + api.Namespace
+			apis = append(apis, api)
+		}
+		stack.RegisterAPIs(apis)
+	}
+	primary.HTTPModules = hostedChainModules(chains, primary.HTTPModules)
+	primary.WSModules = hostedChainModules(chains, primary.WSModules)
+
+	stack.RegisterLifecycle(&chainHost{chains: chains, quit: make(chan struct{})})
+	return nil
+}
+
+// makeHostedChain creates the node of a hosted chain with the Ethereum backend
+// and the engine API registered.
+func makeHostedChain(name string, cfg gethConfig) (*hostedChain, error) {
+	stack, err := node.New(&cfg.Node)
+	if err != nil {
+		return nil, fmt.Errorf("hosted chain %q: %v", name, err)
+	}
+	backend, eth := utils.RegisterEthService(stack, &cfg.Eth)
+	utils.RegisterFilterAPI(stack, backend, &cfg.Eth)
+	if err := catalyst.Register(stack, eth); err != nil {
+		stack.Close()
+		return nil, fmt.Errorf("hosted chain %q: %v", name, err)
+	}
+	return &hostedChain{name: name, stack: stack, eth: eth}, nil
+}
+
+// hostedChainModules extends the given list of RPC modules with the same
+// modules of the hosted chains.
+func hostedChainModules(chains []*hostedChain, modules []string) []string {
+	extended := slices.Clone(modules)
+	for _, chain := range chains {
+		for _, module := range modules {
+			extended = append(extended, chain.name+"."+module)
+		}
+	}
+	return extended
+}
+
+// chainHost is the lifecycle starting and stopping the hosted chains along with
+// the primary node.
+type chainHost struct {
+	chains []*hostedChain
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// Start implements node.Lifecycle, starting the hosted chains.
+func (h *chainHost) Start() error {
+	for i, chain := range h.chains {
+		if err := chain.stack.Start(); err != nil {
+			for _, started := range h.chains[:i] {
+				started.stack.Close()
+			}
+			return fmt.Errorf("hosted chain %q: %v", chain.name, err)
+		}
+		log.Info("Started hosted chain", "name", chain.name, "datadir", chain.stack.DataDir())
+	}
+	h.wg.Add(1)
+	go h.metricsLoop()
+	return nil
+}
+
+// metricsLoop periodically refreshes the metrics of the hosted chains.
+func (h *chainHost) metricsLoop() {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(hostedChainMetricsInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, chain := range h.chains {
+			chain.updateMetrics()
+		}
+		select {
+		case <-ticker.C:
+		case <-h.quit:
+			return
+		}
+	}
+}
+
+// DrainPhases implements node.Drainer, returning the drain phases of the hosted
+// chains, so a graceful shutdown of the primary node drains them too.
+func (h *chainHost) DrainPhases() []node.DrainPhase {
+	var phases []node.DrainPhase
+	for _, chain := range h.chains {
+		for _, phase := range chain.stack.DrainPhases() {
+			phase.Name = chain.name + "/" + phase.Name
+			phases = append(phases, phase)
+		}
+	}
+	return phases
+}
+
+// Stop implements node.Lifecycle, stopping the hosted chains.
+func (h *chainHost) Stop() error {
+	close(h.quit)
+	h.wg.Wait()
+
+	var errs []error
+	for _, chain := range h.chains {
+		chain.unregisterMetrics()
+		if err := chain.stack.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("hosted chain %q: %v", chain.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
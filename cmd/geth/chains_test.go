@@ -0,0 +1,122 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestHostedChainConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "chain.toml")
+	config := `
+[Eth]
+NetworkId = 4242
+
+[Node]
+DataDir = "/data/devnet1"
+AuthPort = 8561
+`
+	if err := os.WriteFile(file, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadHostedChainConfig(file)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Eth.NetworkId != 4242 || cfg.Node.DataDir != "/data/devnet1" || cfg.Node.AuthPort != 8561 {
+		t.Fatalf("config not loaded: network %d, datadir %s, auth port %d", cfg.Eth.NetworkId, cfg.Node.DataDir, cfg.Node.AuthPort)
+	}
+	// Hosted chains stay off the p2p network unless configured otherwise
+	if cfg.Node.P2P.ListenAddr != "" || !cfg.Node.P2P.NoDiscovery || cfg.Node.P2P.MaxPeers != 0 {
+		t.Fatalf("hosted chain connects to the network: %+v", cfg.Node.P2P)
+	}
+}
+
+func TestHostedChainModules(t *testing.T) {
+	chains := []*hostedChain{{name: "devnet1"}, {name: "devnet2"}}
+	have := hostedChainModules(chains, []string{"eth", "net"})
+	want := []string{"eth", "net", "devnet1.eth", "devnet1.net", "devnet2.eth", "devnet2.net"}
+	if !slices.Equal(have, want) {
+		t.Fatalf("wrong modules: have %v, want %v", have, want)
+	}
+}
+
+// Tests that a hosted chain is started and stopped along with the primary node,
+// serving its APIs, reporting its metrics and draining on shutdown.
+func TestHostedChainLifecycle(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		file = filepath.Join(dir, "devnet1.toml")
+	)
+	config := fmt.Sprintf("[Node]\nDataDir = %q\nAuthPort = 0\nIPCPath = \"\"\n", filepath.Join(dir, "devnet1"))
+	if err := os.WriteFile(file, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stack, err := node.New(&node.Config{DataDir: filepath.Join(dir, "primary"), AuthPort: 1})
+	if err != nil {
+		t.Fatalf("failed to create primary node: %v", err)
+	}
+	defer stack.Close()
+
+	if err := hostChains(stack, []string{"devnet1=" + file}); err != nil {
+		t.Fatalf("failed to host chain: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start primary node: %v", err)
+	}
+	var chainID hexutil.Big
+	if err := stack.Attach().Call(&chainID, "devnet1.eth_chainId"); err != nil {
+		t.Fatalf("failed to call hosted chain: %v", err)
+	}
+	if chainID.ToInt().Cmp(params.MainnetChainConfig.ChainID) != 0 {
+		t.Fatalf("wrong hosted chain ID: have %v, want %v", chainID.ToInt(), params.MainnetChainConfig.ChainID)
+	}
+	// The metrics of the hosted chain are namespaced by its name
+	gauge := "chains/devnet1/chain/head/block"
+	for deadline := time.Now().Add(5 * time.Second); metrics.DefaultRegistry.Get(gauge) == nil; {
+		if time.Now().After(deadline) {
+			t.Fatal("hosted chain metrics not reported")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// The drain phases of the hosted chain run with the ones of the primary
+	var drained bool
+	for _, phase := range stack.DrainPhases() {
+		if phase.Name == "devnet1/state" {
+			drained = true
+		}
+	}
+	if !drained {
+		t.Fatal("hosted chain not drained on shutdown")
+	}
+	if err := stack.Shutdown(time.Second); err != nil {
+		t.Fatalf("failed to shut down primary node: %v", err)
+	}
+	if metrics.DefaultRegistry.Get(gauge) != nil {
+		t.Fatal("hosted chain metrics not unregistered")
+	}
+}
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -38,11 +39,13 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethstats"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/ethereum/go-ethereum/internal/version"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/remoteconfig"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/naoina/toml"
 	"github.com/urfave/cli/v2"
@@ -100,15 +103,12 @@ var deprecatedConfigFields = map[string]bool{
 	"ethconfig.Config.LightNoSyncServe":        true,
 }
 
-type ethstatsConfig struct {
-	URL string `toml:",omitempty"`
-}
-
 type gethConfig struct {
-	Eth      ethconfig.Config
-	Node     node.Config
-	Ethstats ethstatsConfig
-	Metrics  metrics.Config
+	Eth          ethconfig.Config
+	Node         node.Config
+	Ethstats     ethstats.Config
+	RemoteConfig remoteconfig.Config
+	Metrics      metrics.Config
 }
 
 func loadConfig(file string, cfg *gethConfig) error {
@@ -142,9 +142,10 @@ func defaultNodeConfig() node.Config {
 func loadBaseConfig(ctx *cli.Context) gethConfig {
 	// Load defaults.
 	cfg := gethConfig{
-		Eth:     ethconfig.Defaults,
-		Node:    defaultNodeConfig(),
-		Metrics: metrics.DefaultConfig,
+		Eth:          ethconfig.Defaults,
+		Node:         defaultNodeConfig(),
+		RemoteConfig: remoteconfig.DefaultConfig,
+		Metrics:      metrics.DefaultConfig,
 	}
 
 	// Load config file.
@@ -172,9 +173,8 @@ func makeConfigNode(ctx *cli.Context) (*node.Node, gethConfig) {
 	}
 
 	utils.SetEthConfig(ctx, stack, &cfg.Eth)
-	if ctx.IsSet(utils.EthStatsURLFlag.Name) {
-		cfg.Ethstats.URL = ctx.String(utils.EthStatsURLFlag.Name)
-	}
+	applyEthstatsConfig(ctx, &cfg)
+	applyRemoteConfig(ctx, &cfg)
 	applyMetricConfig(ctx, &cfg)
 
 	return stack, cfg
@@ -259,9 +259,17 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 	if ctx.IsSet(utils.GraphQLEnabledFlag.Name) {
 		utils.RegisterGraphQLService(stack, backend, filterSystem, &cfg.Node)
 	}
+	// Configure the proof server if requested.
+	if ctx.IsSet(utils.ProofServerEnabledFlag.Name) {
+		utils.RegisterProofServer(ctx, stack, backend, &cfg.Node)
+	}
 	// Add the Ethereum Stats daemon if requested.
 	if cfg.Ethstats.URL != "" {
-		utils.RegisterEthStatsService(stack, backend, cfg.Ethstats.URL)
+		utils.RegisterEthStatsService(stack, backend, cfg.Ethstats)
+	}
+	// Poll the remote configuration if requested.
+	if cfg.RemoteConfig.URL != "" {
+		utils.RegisterRemoteConfigService(ctx, stack, backend, eth, &cfg.Eth, cfg.RemoteConfig)
 	}
 	// Configure full-sync tester service if requested
 	if ctx.IsSet(utils.SyncTargetFlag.Name) {
@@ -280,6 +288,24 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 		}
 		catalyst.RegisterSimulatedBeaconAPIs(stack, simBeacon)
 		stack.RegisterLifecycle(simBeacon)
+	} else if ctx.Bool(utils.RollupSequencerFlag.Name) {
+		// Start the built-in sequencer.
+		seqcfg := catalyst.SequencerConfig{
+			BlockTime:    ctx.Uint64(utils.RollupSequencerBlockTimeFlag.Name),
+			FeeRecipient: cfg.Eth.Miner.PendingFeeRecipient,
+		}
+		if url := ctx.String(utils.RollupSequencerOriginFlag.Name); url != "" {
+			origin, err := catalyst.NewRPCOrigin(context.Background(), url)
+			if err != nil {
+				utils.Fatalf("failed to dial L1 origin node: %v", err)
+			}
+			seqcfg.Origin = origin
+		}
+		sequencer, err := catalyst.NewSequencer(eth, seqcfg)
+		if err != nil {
+			utils.Fatalf("failed to register built-in sequencer: %v", err)
+		}
+		stack.RegisterLifecycle(sequencer)
 	} else if ctx.IsSet(utils.BeaconApiFlag.Name) {
 		// Start blsync mode.
 		srv := rpc.NewServer()
@@ -294,6 +320,10 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 			utils.Fatalf("failed to register catalyst service: %v", err)
 		}
 	}
+	// Create the additional chains hosted in the process if requested
+	if err := makeHostedChains(ctx, stack); err != nil {
+		utils.Fatalf("Failed to create hosted chains: %v", err)
+	}
 	return stack
 }
 
@@ -326,6 +356,54 @@ func dumpConfig(ctx *cli.Context) error {
 	return nil
 }
 
+func applyEthstatsConfig(ctx *cli.Context, cfg *gethConfig) {
+	if ctx.IsSet(utils.EthStatsURLFlag.Name) {
+		cfg.Ethstats.URL = ctx.String(utils.EthStatsURLFlag.Name)
+	}
+	if ctx.IsSet(utils.EthStatsProtocolFlag.Name) {
+		cfg.Ethstats.Protocol = ctx.Int(utils.EthStatsProtocolFlag.Name)
+	}
+	if ctx.IsSet(utils.EthStatsTokenFlag.Name) {
+		cfg.Ethstats.Token = ctx.String(utils.EthStatsTokenFlag.Name)
+	}
+	if ctx.IsSet(utils.EthStatsIntervalFlag.Name) {
+		cfg.Ethstats.ReportInterval = ctx.Duration(utils.EthStatsIntervalFlag.Name)
+	}
+	if ctx.IsSet(utils.EthStatsCACertFlag.Name) {
+		cfg.Ethstats.CACert = ctx.String(utils.EthStatsCACertFlag.Name)
+	}
+	if ctx.IsSet(utils.EthStatsClientCertFlag.Name) {
+		cfg.Ethstats.ClientCert = ctx.String(utils.EthStatsClientCertFlag.Name)
+	}
+	if ctx.IsSet(utils.EthStatsClientKeyFlag.Name) {
+		cfg.Ethstats.ClientKey = ctx.String(utils.EthStatsClientKeyFlag.Name)
+	}
+	if ctx.IsSet(utils.EthStatsMetricsFlag.Name) {
+		cfg.Ethstats.Metrics = ctx.StringSlice(utils.EthStatsMetricsFlag.Name)
+	}
+}
+
+func applyRemoteConfig(ctx *cli.Context, cfg *gethConfig) {
+	if ctx.IsSet(utils.RemoteConfigURLFlag.Name) {
+		cfg.RemoteConfig.URL = ctx.String(utils.RemoteConfigURLFlag.Name)
+	}
+	if ctx.IsSet(utils.RemoteConfigSignersFlag.Name) {
+		cfg.RemoteConfig.Signers = nil
+		for _, signer := range utils.SplitAndTrim(ctx.String(utils.RemoteConfigSignersFlag.Name)) {
+			if !common.IsHexAddress(signer) {
+				utils.Fatalf("Invalid remote configuration signer: %s", signer)
+			}
+			cfg.RemoteConfig.Signers = append(cfg.RemoteConfig.Signers, common.HexToAddress(signer))
+		}
+	}
+	if ctx.IsSet(utils.RemoteConfigIntervalFlag.Name) {
+		cfg.RemoteConfig.Interval = ctx.Duration(utils.RemoteConfigIntervalFlag.Name)
+	}
+	if ctx.IsSet(utils.RemoteConfigPinnedFlag.Name) {
+		cfg.RemoteConfig.Pinned = utils.SplitAndTrim(ctx.String(utils.RemoteConfigPinnedFlag.Name))
+	}
+}
+
 func applyMetricConfig(ctx *cli.Context, cfg *gethConfig) {
 	if ctx.IsSet(utils.MetricsEnabledFlag.Name) {
 		cfg.Metrics.Enabled = ctx.Bool(utils.MetricsEnabledFlag.Name)
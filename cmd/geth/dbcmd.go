@@ -41,6 +41,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/pathdb"
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli/v2"
 )
@@ -83,6 +84,7 @@ Remove blockchain and state databases`,
 			dbMetadataCmd,
 			dbCheckStateContentCmd,
 			dbInspectHistoryCmd,
+			dbCompactHistoryCmd,
 		},
 	}
 	dbInspectCmd = &cli.Command{
@@ -207,6 +209,16 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		}, utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command queries the history of the account or storage slot within the specified block range",
 	}
+	dbCompactHistoryCmd = &cli.Command{
+		Action: dbCompactHistory,
+		Name:   "compact-history",
+		Usage:  "Rewrite the state histories deduplicated and delta encoded",
+		Flags:  slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command rewrites the existing state histories in the compressed format,
+as written with --history.state.compress. It is only relevant in path scheme and
+requires the node to be stopped. The histories are written aside and swapped in
+once complete, an aborted compaction leaves them untouched.`,
+	}
 )
 
 func removeDB(ctx *cli.Context) error {
@@ -907,3 +919,26 @@ func inspectHistory(ctx *cli.Context) error {
 	}
 	return inspectStorage(triedb, start, end, address, slot, ctx.Bool("raw"))
 }
+
+func dbCompactHistory(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	if scheme := rawdb.ReadStateScheme(db); scheme != rawdb.PathScheme {
+		db.Close()
+		return fmt.Errorf("state histories are only kept in path scheme, database is in %q", scheme)
+	}
+	ancient, err := db.AncientDatadir()
+	db.Close()
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	stats, err := pathdb.CompactHistory(ancient, false)
+	if err != nil {
+		return err
+	}
+	log.Info("Compacted state histories", "histories", stats.Histories, "before", stats.SizeBefore, "after", stats.SizeAfter, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
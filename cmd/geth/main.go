@@ -42,6 +42,7 @@ import (
 	_ "github.com/ethereum/go-ethereum/eth/tracers/js"
 	_ "github.com/ethereum/go-ethereum/eth/tracers/live"
 	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/wasm"
 
 	"github.com/urfave/cli/v2"
 )
@@ -58,6 +59,7 @@ var (
 		utils.PasswordFileFlag,
 		utils.BootnodesFlag,
 		utils.MinFreeDiskSpaceFlag,
+		utils.ShutdownDrainFlag,
 		utils.KeyStoreDirFlag,
 		utils.ExternalSignerFlag,
 		utils.NoUSBFlag, // deprecated
@@ -81,16 +83,40 @@ var (
 		utils.TxPoolRejournalFlag,
 		utils.TxPoolPriceLimitFlag,
 		utils.TxPoolPriceBumpFlag,
+		utils.TxPoolTipBumpFlag,
+		utils.TxPoolConditionalPriceBumpFlag,
+		utils.TxPoolConditionalTipBumpFlag,
 		utils.TxPoolAccountSlotsFlag,
 		utils.TxPoolGlobalSlotsFlag,
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.TxPoolReconcileFlag,
+		utils.TxFetchWaitFlag,
+		utils.TxFetchBatchFlag,
+		utils.TxFetchBytesFlag,
+		utils.TxFetchRequestsFlag,
+		utils.TxAnnounceSizeFlag,
+		utils.TxPoolTenantSlotsFlag,
+		utils.TxPoolTenantGasFlag,
+		utils.TxPoolRevertCheckFlag,
+		utils.TxPoolRevertCheckGasFlag,
+		utils.TxPoolSpamThresholdFlag,
+		utils.TxPoolSpamTipStepFlag,
+		utils.TxPoolSpamDecayFlag,
+		utils.TxPoolAddressPolicyFlag,
+		utils.TxPoolAddressPolicyListFlag,
+		utils.TxPoolAddressPolicyAuditFlag,
 		utils.BlobPoolDataDirFlag,
 		utils.BlobPoolDataCapFlag,
 		utils.BlobPoolPriceBumpFlag,
+		utils.BlobPoolTipBumpFlag,
+		utils.BlobPoolProjectionFlag,
+		utils.BlobPoolAccountBlobsFlag,
 		utils.SyncModeFlag,
 		utils.SyncTargetFlag,
+		utils.SyncCheckpointFlag,
+		utils.SyncPivotFlag,
 		utils.ExitWhenSyncedFlag,
 		utils.GCModeFlag,
 		utils.SnapshotFlag,
@@ -101,6 +127,47 @@ var (
 		utils.LogNoHistoryFlag,
 		utils.LogExportCheckpointsFlag,
 		utils.StateHistoryFlag,
+		utils.StateHistoryCompressFlag,
+		utils.StateWriteAheadLogFlag,
+		utils.StateLegacyDirFlag,
+		utils.StateRentFlag,
+		utils.StateRentRateFlag,
+		utils.StateRentTouchFeeFlag,
+		utils.StateRentAccountSizeFlag,
+		utils.StateRentSlotSizeFlag,
+		utils.StateRentAccountsFlag,
+		utils.SenderIndexFlag,
+		utils.SenderIndexHistoryFlag,
+		utils.TransferIndexFlag,
+		utils.TransferIndexHistoryFlag,
+		utils.InternalTxIndexFlag,
+		utils.InternalTxIndexHistoryFlag,
+		utils.CreationIndexFlag,
+		utils.CodeChangeIndexFlag,
+		utils.CodeChangeIndexHistoryFlag,
+		utils.BalanceChangesFlag,
+		utils.SupplyFlag,
+		utils.ChainAuditFlag,
+		utils.ChainAuditFromFlag,
+		utils.ChainAuditToFlag,
+		utils.ChainAuditRateFlag,
+		utils.ChainAuditIntervalFlag,
+		utils.HeadAttestFlag,
+		utils.HeadAttestSignersFlag,
+		utils.HeadAttestQuorumFlag,
+		utils.WatchdogFlag,
+		utils.WatchdogReferencesFlag,
+		utils.WatchdogMaxLagFlag,
+		utils.WatchdogTimeoutFlag,
+		utils.WatchdogIntervalFlag,
+		utils.WatchdogRotateFlag,
+		utils.CrossCheckReferenceFlag,
+		utils.CrossCheckHaltFlag,
+		utils.CrossCheckIntervalFlag,
+		utils.StateReaderFlag,
+		utils.StateReaderIPCPathFlag,
+		utils.StateReaderRateLimitFlag,
+		utils.ContractABISourcifyFlag,
 		utils.LightServeFlag,    // deprecated
 		utils.LightIngressFlag,  // deprecated
 		utils.LightEgressFlag,   // deprecated
@@ -117,6 +184,9 @@ var (
 		utils.CacheTrieRejournalFlag, // deprecated
 		utils.CacheGCFlag,
 		utils.CacheSnapshotFlag,
+		utils.CacheCodeFlag,
+		utils.CacheAdaptiveFlag,
+		utils.MemoryLimitFlag,
 		utils.CacheNoPrefetchFlag,
 		utils.CachePreimagesFlag,
 		utils.CacheLogSizeFlag,
@@ -126,6 +196,10 @@ var (
 		utils.DiscoveryPortFlag,
 		utils.MaxPeersFlag,
 		utils.MaxPendingPeersFlag,
+		utils.BandwidthCapFlag,
+		utils.ProtocolBandwidthCapsFlag,
+		utils.IngressBandwidthCapFlag,
+		utils.IngressProtocolBandwidthCapsFlag,
 		utils.MiningEnabledFlag, // deprecated
 		utils.MinerGasLimitFlag,
 		utils.MinerEffectiveGasLimitFlag,
@@ -134,24 +208,57 @@ var (
 		utils.MinerExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
 		utils.MinerPendingFeeRecipientFlag,
+		utils.MinerRecordPayloadsFlag,
+		utils.MinerRecordAccessListsFlag,
+		utils.MinerCommitPolicyFlag,
+		utils.MinerBuilderPayloadsFlag,
+		utils.MinerBuilderMinDeltaFlag,
+		utils.MinerBuilderAllowFlag,
+		utils.MinerBuilderInclusionFlag,
+		utils.MinerFeeRecipientsFlag,
+		utils.MinerFeeRecipientsPeriodFlag,
+		utils.MinerGasTuningMinFlag,
+		utils.MinerGasTuningMaxFlag,
+		utils.MinerGasTuningLatencyFlag,
+		utils.MinerSealDelayFlag,
+		utils.MinerSealJitterFlag,
+		utils.MinerStandbyFlag,
 		utils.MinerNewPayloadTimeoutFlag, // deprecated
 		utils.NATFlag,
+		utils.NATFallbackFlag,
 		utils.NoDiscoverFlag,
 		utils.DiscoveryV4Flag,
 		utils.DiscoveryV5Flag,
 		utils.LegacyDiscoveryV5Flag, // deprecated
+		utils.DiscoveryTopicFlag,
 		utils.NetrestrictFlag,
 		utils.NodeKeyFileFlag,
 		utils.NodeKeyHexFlag,
 		utils.DNSDiscoveryFlag,
+		utils.SlimBlocksFlag,
 		utils.DeveloperFlag,
 		utils.DeveloperGasLimitFlag,
+		utils.DeveloperFixtureFlag,
 		utils.DeveloperPeriodFlag,
 		utils.VMEnableDebugFlag,
 		utils.VMTraceFlag,
+		utils.VMCodeAnalysisFlag,
+		utils.VMCodeAnalysisShadowFlag,
 		utils.VMTraceJsonConfigFlag,
+		utils.BadBlockDirFlag,
 		utils.NetworkIdFlag,
 		utils.EthStatsURLFlag,
+		utils.EthStatsProtocolFlag,
+		utils.EthStatsTokenFlag,
+		utils.EthStatsIntervalFlag,
+		utils.EthStatsCACertFlag,
+		utils.EthStatsClientCertFlag,
+		utils.EthStatsClientKeyFlag,
+		utils.EthStatsMetricsFlag,
+		utils.RemoteConfigURLFlag,
+		utils.RemoteConfigSignersFlag,
+		utils.RemoteConfigIntervalFlag,
+		utils.RemoteConfigPinnedFlag,
 		utils.GpoBlocksFlag,
 		utils.GpoPercentileFlag,
 		utils.GpoMaxGasPriceFlag,
@@ -164,12 +271,18 @@ var (
 		utils.RollupHistoricalRPCTimeoutFlag,
 		utils.RollupInteropRPCFlag,
 		utils.RollupInteropMempoolFilteringFlag,
+		utils.RollupInteropFailurePolicyFlag,
+		utils.RollupInteropCacheTTLFlag,
 		utils.RollupDisableTxPoolGossipFlag,
 		utils.RollupEnableTxPoolAdmissionFlag,
 		utils.RollupComputePendingBlock,
 		utils.RollupHaltOnIncompatibleProtocolVersionFlag,
 		utils.RollupSuperchainUpgradesFlag,
+		utils.RollupSequencerFlag,
+		utils.RollupSequencerBlockTimeFlag,
+		utils.RollupSequencerOriginFlag,
 		configFileFlag,
+		chainsFlag,
 		utils.LogDebugFlag,
 		utils.LogBacktraceAtFlag,
 		utils.BeaconApiFlag,
@@ -191,25 +304,45 @@ var (
 		utils.AuthListenFlag,
 		utils.AuthPortFlag,
 		utils.AuthVirtualHostsFlag,
+		utils.AuthApiFlag,
 		utils.JWTSecretFlag,
+		utils.JWTSecretsFlag,
+		utils.AuthTLSCertFlag,
+		utils.AuthTLSKeyFlag,
+		utils.AuthTLSClientCAFlag,
+		utils.AuthTLSClientPinsFlag,
+		utils.AuthTLSClientNamesFlag,
+		utils.AuthJWTDisabledFlag,
 		utils.HTTPVirtualHostsFlag,
 		utils.GraphQLEnabledFlag,
 		utils.GraphQLCORSDomainFlag,
 		utils.GraphQLVirtualHostsFlag,
+		utils.ProofServerEnabledFlag,
+		utils.ProofServerJWTSecretFlag,
 		utils.HTTPApiFlag,
 		utils.HTTPPathPrefixFlag,
+		utils.HTTPTLSCertFlag,
+		utils.HTTPTLSKeyFlag,
+		utils.HTTPH2CFlag,
 		utils.WSEnabledFlag,
 		utils.WSListenAddrFlag,
 		utils.WSPortFlag,
 		utils.WSApiFlag,
 		utils.WSAllowedOriginsFlag,
 		utils.WSPathPrefixFlag,
+		utils.WSSubscriptionBufferFlag,
+		utils.WSSubscriptionPolicyFlag,
 		utils.IPCDisabledFlag,
 		utils.IPCPathFlag,
+		utils.IPCPermissionsFlag,
 		utils.InsecureUnlockAllowedFlag,
 		utils.RPCGlobalGasCapFlag,
 		utils.RPCGlobalEVMTimeoutFlag,
 		utils.RPCGlobalTxFeeCapFlag,
+		utils.RPCPrefetchLearnFlag,
+		utils.RPCReexecLimitFlag,
+		utils.RPCReexecCacheFlag,
+		utils.RPCOverlaysFlag,
 		utils.AllowUnprotectedTxs,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
@@ -248,7 +381,9 @@ func init() {
 		importPreimagesCommand,
 		removedbCommand,
 		dumpCommand,
+		importStateCommand,
 		dumpGenesisCommand,
+		buildGenesisCommand,
 		pruneCommand,
 		// See accountcmd.go:
 		accountCommand,
@@ -271,6 +406,10 @@ func init() {
 		snapshotCommand,
 		// See verkle.go
 		verkleCommand,
+		// See benchcmd.go
+		benchCommand,
+		// See testcmd.go
+		testCommand,
 	}
 	if logTestCommand != nil {
 		app.Commands = append(app.Commands, logTestCommand)
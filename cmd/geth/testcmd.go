@@ -0,0 +1,369 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	vectorsFromFlag = &cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "First block of the range to generate test vectors for",
+		Required: true,
+	}
+	vectorsToFlag = &cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Last block of the range to generate test vectors for (default = from)",
+	}
+	vectorsOutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "File to write the test vectors to (default = stdout)",
+	}
+
+	testCommand = &cli.Command{
+		Name:  "test",
+		Usage: "A set of commands for cross-checking the node against other implementations",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "gen-vectors",
+				Usage:  "Generate execution test vectors from the blocks of the local chain",
+				Action: genVectors,
+				Flags: slices.Concat([]cli.Flag{
+					vectorsFromFlag,
+					vectorsToFlag,
+					vectorsOutputFlag,
+				}, utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth test gen-vectors --from <number> [--to <number>] [--output <file>]
+re-executes the blocks of the given range and emits a transition test vector
+for each of them, keyed by the block number.
+
+The vectors follow the layout of the evm t8n tool inputs and outputs: the block
+environment, the pre-state, the transactions, and the expected post-state and
+receipts. They are self-contained, so they can be fed to other implementations
+to validate the custom fork changes of the chain, whose configuration is part
+of each vector.
+
+The pre-state only holds the accounts and storage slots accessed by the block,
+and the post-state their values after it. The state root of the partial
+pre-state thus differs from the one of the block. The parent states of the
+range must be available in the database.
+`,
+			},
+		},
+	}
+)
+
+// vectorEnv is the execution environment of a block in a test vector, named
+// after the t8n tool environment fields.
+type vectorEnv struct {
+	Coinbase              common.Address                      `json:"currentCoinbase"`
+	Difficulty            *math.HexOrDecimal256               `json:"currentDifficulty"`
+	Random                *common.Hash                        `json:"currentRandom,omitempty"`
+	GasLimit              math.HexOrDecimal64                 `json:"currentGasLimit"`
+	Number                math.HexOrDecimal64                 `json:"currentNumber"`
+	Timestamp             math.HexOrDecimal64                 `json:"currentTimestamp"`
+	BaseFee               *math.HexOrDecimal256               `json:"currentBaseFee,omitempty"`
+	ExcessBlobGas         *math.HexOrDecimal64                `json:"currentExcessBlobGas,omitempty"`
+	ParentBeaconBlockRoot *common.Hash                        `json:"parentBeaconBlockRoot,omitempty"`
+	ParentHash            common.Hash                         `json:"parentHash"`
+	BlockHashes           map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
+	Withdrawals           []*types.Withdrawal                 `json:"withdrawals,omitempty"`
+	ExtraData             string                              `json:"extraData,omitempty"`
+}
+
+// vectorResult is the expected outcome of a block in a test vector, besides
+// the post-state.
+type vectorResult struct {
+	BlockHash    common.Hash         `json:"blockHash"`
+	TxRoot       common.Hash         `json:"txRoot"`
+	ReceiptsRoot common.Hash         `json:"receiptsRoot"`
+	LogsBloom    types.Bloom         `json:"logsBloom"`
+	GasUsed      math.HexOrDecimal64 `json:"gasUsed"`
+	Receipts     []*types.Receipt    `json:"receipts"`
+}
+
+// testVector is the execution test vector of a block.
+type testVector struct {
+	Network string               `json:"network"`
+	Config  *params.ChainConfig  `json:"config"`
+	Env     vectorEnv            `json:"env"`
+	Pre     types.GenesisAlloc   `json:"pre"`
+	Txs     []*types.Transaction `json:"txs"`
+	Post    types.GenesisAlloc   `json:"post"`
+	Result  vectorResult         `json:"result"`
+}
+
+// recordingDatabase is a state database recording the accounts and storage
+// slots read through its readers, i.e. the pre-state accessed by the execution.
+type recordingDatabase struct {
+	state.Database
+
+	accounts map[common.Address]map[common.Hash]struct{}
+	lock     sync.Mutex
+}
+
+func newRecordingDatabase(db state.Database) *recordingDatabase {
+	return &recordingDatabase{
+		Database: db,
+		accounts: make(map[common.Address]map[common.Hash]struct{}),
+	}
+}
+
+// record marks an account, and optionally a storage slot of it, as accessed.
+func (db *recordingDatabase) record(addr common.Address, slot *common.Hash) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	slots := db.accounts[addr]
+	if slots == nil {
+		slots = make(map[common.Hash]struct{})
+		db.accounts[addr] = slots
+	}
+	if slot != nil {
+		slots[*slot] = struct{}{}
+	}
+}
+
+// Reader implements state.Database, wrapping the readers to record the reads.
+func (db *recordingDatabase) Reader(root common.Hash) (state.Reader, error) {
+	reader, err := db.Database.Reader(root)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingReader{Reader: reader, db: db}, nil
+}
+
+type recordingReader struct {
+	state.Reader
+	db *recordingDatabase
+}
+
+func (r *recordingReader) Account(addr common.Address) (*types.StateAccount, error) {
+	r.db.record(addr, nil)
+	return r.Reader.Account(addr)
+}
+
+func (r *recordingReader) Storage(addr common.Address, slot common.Hash) (common.Hash, error) {
+	r.db.record(addr, &slot)
+	return r.Reader.Storage(addr, slot)
+}
+
+// vectorNetwork returns the name of the latest fork active in the given block.
+func vectorNetwork(config *params.ChainConfig, header *types.Header) string {
+	if config.IsOptimism() {
+		for _, fork := range []struct {
+			name   string
+			active func(uint64) bool
+		}{
+			{"Interop", config.IsInterop},
+			{"Jovian", config.IsJovian},
+			{"Isthmus", config.IsIsthmus},
+			{"Holocene", config.IsHolocene},
+			{"Granite", config.IsGranite},
+			{"Fjord", config.IsFjord},
+			{"Ecotone", config.IsEcotone},
+			{"Canyon", config.IsCanyon},
+		} {
+			if fork.active(header.Time) {
+				return fork.name
+			}
+		}
+		return "Bedrock"
+	}
+	if !config.IsLondon(header.Number) {
+		return "Berlin" // earliest fork test vectors are generated for
+	}
+	if header.Difficulty.Sign() != 0 {
+		return "London"
+	}
+	return config.LatestFork(header.Time).String()
+}
+
+// vectorAlloc collects the given accounts and storage slots from the state.
+// Non-existent accounts and empty slots are left out.
+func vectorAlloc(statedb *state.StateDB, accounts map[common.Address]map[common.Hash]struct{}) types.GenesisAlloc {
+	alloc := make(types.GenesisAlloc)
+	for addr, slots := range accounts {
+		if !statedb.Exist(addr) {
+			continue
+		}
+		account := types.Account{
+			Balance: statedb.GetBalance(addr).ToBig(),
+			Nonce:   statedb.GetNonce(addr),
+			Code:    statedb.GetCode(addr),
+		}
+		for slot := range slots {
+			if value := statedb.GetState(addr, slot); value != (common.Hash{}) {
+				if account.Storage == nil {
+					account.Storage = make(map[common.Hash]common.Hash)
+				}
+				account.Storage[slot] = value
+			}
+		}
+		alloc[addr] = account
+	}
+	return alloc
+}
+
+// generateVector re-executes the block with the given number on top of its
+// parent state, recording the state accessed, and assembles its test vector.
+func generateVector(chain *core.BlockChain, number uint64) (*testVector, error) {
+	block := chain.GetBlockByNumber(number)
+	if block == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+	if number == 0 {
+		return nil, errors.New("genesis is not executable")
+	}
+	parent := chain.GetHeader(block.ParentHash(), number-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent of block #%d not found", number)
+	}
+	db := newRecordingDatabase(chain.StateCache())
+	statedb, err := state.New(parent.Root, db)
+	if err != nil {
+		return nil, fmt.Errorf("parent state of block #%d unavailable: %w", number, err)
+	}
+	var (
+		config = chain.Config()
+		env    = vectorEnv{
+			Coinbase:              block.Coinbase(),
+			Difficulty:            (*math.HexOrDecimal256)(block.Difficulty()),
+			GasLimit:              math.HexOrDecimal64(block.GasLimit()),
+			Number:                math.HexOrDecimal64(number),
+			Timestamp:             math.HexOrDecimal64(block.Time()),
+			BaseFee:               (*math.HexOrDecimal256)(block.BaseFee()),
+			ExcessBlobGas:         (*math.HexOrDecimal64)(block.ExcessBlobGas()),
+			ParentBeaconBlockRoot: block.BeaconRoot(),
+			ParentHash:            block.ParentHash(),
+			BlockHashes:           make(map[math.HexOrDecimal64]common.Hash),
+			Withdrawals:           block.Withdrawals(),
+		}
+		hooks = &tracing.Hooks{
+			// Storage written to accounts created during the block is not read
+			// through the database, track it explicitly.
+			OnStorageChange: func(addr common.Address, slot common.Hash, prev, new common.Hash) {
+				db.record(addr, &slot)
+			},
+			OnBlockHashRead: func(number uint64, hash common.Hash) {
+				env.BlockHashes[math.HexOrDecimal64(number)] = hash
+			},
+		}
+	)
+	if block.Difficulty().Sign() == 0 {
+		random := block.MixDigest()
+		env.Random = &random
+	}
+	if len(block.Extra()) > 0 {
+		env.ExtraData = fmt.Sprintf("%#x", block.Extra())
+	}
+	result, err := core.NewStateProcessor(config, chain.HeaderChain()).Process(block, statedb, vm.Config{Tracer: hooks})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute block #%d: %w", number, err)
+	}
+	// Only emit vectors of executions reproducing the canonical block
+	if root := statedb.IntermediateRoot(config.IsEIP158(block.Number())); root != block.Root() {
+		return nil, fmt.Errorf("block #%d state root mismatch: have %x, want %x", number, root, block.Root())
+	}
+	if root := types.DeriveSha(types.Receipts(result.Receipts), trie.NewStackTrie(nil)); root != block.ReceiptHash() {
+		return nil, fmt.Errorf("block #%d receipt root mismatch: have %x, want %x", number, root, block.ReceiptHash())
+	}
+	pre, err := state.New(parent.Root, chain.StateCache())
+	if err != nil {
+		return nil, err
+	}
+	receipts := result.Receipts
+	if receipts == nil {
+		receipts = []*types.Receipt{}
+	}
+	txs := block.Transactions()
+	if txs == nil {
+		txs = types.Transactions{}
+	}
+	return &testVector{
+		Network: vectorNetwork(config, block.Header()),
+		Config:  config,
+		Env:     env,
+		Pre:     vectorAlloc(pre, db.accounts),
+		Txs:     txs,
+		Post:    vectorAlloc(statedb, db.accounts),
+		Result: vectorResult{
+			BlockHash:    block.Hash(),
+			TxRoot:       block.TxHash(),
+			ReceiptsRoot: block.ReceiptHash(),
+			LogsBloom:    block.Bloom(),
+			GasUsed:      math.HexOrDecimal64(result.GasUsed),
+			Receipts:     receipts,
+		},
+	}, nil
+}
+
+func genVectors(ctx *cli.Context) error {
+	from := ctx.Uint64(vectorsFromFlag.Name)
+	to := from
+	if ctx.IsSet(vectorsToFlag.Name) {
+		to = ctx.Uint64(vectorsToFlag.Name)
+	}
+	if from > to {
+		return fmt.Errorf("invalid range: from %d > to %d", from, to)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, true)
+	defer db.Close()
+	defer chain.Stop()
+
+	vectors := make(map[string]*testVector)
+	for number := from; number <= to; number++ {
+		vector, err := generateVector(chain, number)
+		if err != nil {
+			return err
+		}
+		vectors[fmt.Sprintf("block_%d", number)] = vector
+		log.Info("Generated test vector", "number", number, "txs", len(vector.Txs), "accounts", len(vector.Pre))
+	}
+	blob, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return err
+	}
+	if file := ctx.String(vectorsOutputFlag.Name); file != "" {
+		return os.WriteFile(file, append(blob, '\n'), 0644)
+	}
+	_, err = fmt.Println(string(blob))
+	return err
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the test vectors of a block hold the state it accesses, before
+// and after its execution, along with the block hashes it reads.
+func TestGenerateVector(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender   = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0de")
+		gspec    = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender: {Balance: big.NewInt(params.Ether)},
+				// Reads the hash of the parent block and slot 0, stores the
+				// block number in slot 1
+				contract: {Code: []byte{
+					byte(vm.PUSH1), 0x01, byte(vm.NUMBER), byte(vm.SUB), byte(vm.BLOCKHASH), byte(vm.POP),
+					byte(vm.PUSH0), byte(vm.SLOAD), byte(vm.POP),
+					byte(vm.NUMBER), byte(vm.PUSH1), 0x01, byte(vm.SSTORE),
+				}},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = beacon.New(ethash.NewFaker())
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 2, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), contract, nil, 100000, gen.BaseFee(), nil), signer, key)
+		gen.AddTx(tx)
+	})
+	chain, _ := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil)
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	vector, err := generateVector(chain, 2)
+	if err != nil {
+		t.Fatalf("failed to generate vector: %v", err)
+	}
+	if _, err := json.Marshal(vector); err != nil {
+		t.Fatalf("failed to encode vector: %v", err)
+	}
+	if len(vector.Txs) != 1 || len(vector.Result.Receipts) != 1 {
+		t.Fatalf("transaction count mismatch: have %d txs, %d receipts", len(vector.Txs), len(vector.Result.Receipts))
+	}
+	if vector.Result.Receipts[0].Status != types.ReceiptStatusSuccessful {
+		t.Errorf("transaction failed")
+	}
+	if hash := vector.Env.BlockHashes[math.HexOrDecimal64(1)]; hash != blocks[0].Hash() {
+		t.Errorf("parent block hash mismatch: have %x, want %x", hash, blocks[0].Hash())
+	}
+	if _, ok := vector.Pre[sender]; !ok {
+		t.Errorf("sender missing from pre-state")
+	}
+	if nonce := vector.Post[sender].Nonce; nonce != 2 {
+		t.Errorf("sender post nonce mismatch: have %d, want 2", nonce)
+	}
+	slot := common.BigToHash(big.NewInt(1))
+	if value := vector.Pre[contract].Storage[slot]; value != common.BigToHash(big.NewInt(1)) {
+		t.Errorf("contract pre-state slot mismatch: have %x", value)
+	}
+	if value := vector.Post[contract].Storage[slot]; value != common.BigToHash(big.NewInt(2)) {
+		t.Errorf("contract post-state slot mismatch: have %x", value)
+	}
+	if len(vector.Pre[contract].Code) == 0 {
+		t.Errorf("contract code missing from pre-state")
+	}
+	// Genesis has no parent state to execute on
+	if _, err := generateVector(chain, 0); err == nil {
+		t.Error("generated vector for the genesis block")
+	}
+}
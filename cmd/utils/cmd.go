@@ -99,7 +99,7 @@ func StartNode(ctx *cli.Context, stack *node.Node, isConsole bool) {
 
 		shutdown := func() {
 			log.Info("Got interrupt, shutting down...")
-			go stack.Close()
+			go stack.Shutdown(stack.Config().ShutdownDrain)
 			for i := 10; i > 0; i-- {
 				<-sigc
 				if i > 1 {
@@ -43,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -53,11 +54,13 @@ import (
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/interop"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/remotedb"
 	"github.com/ethereum/go-ethereum/ethstats"
 	"github.com/ethereum/go-ethereum/graphql"
+	"github.com/ethereum/go-ethereum/internal/debug"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/ethereum/go-ethereum/log"
@@ -71,6 +74,8 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/proofserver"
+	"github.com/ethereum/go-ethereum/remoteconfig"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/superchain"
 	"github.com/ethereum/go-ethereum/triedb"
@@ -98,7 +103,7 @@ var (
 	}
 	RemoteDBFlag = &cli.StringFlag{
 		Name:     "remotedb",
-		Usage:    "URL for remote database",
+		Usage:    "URL for remote database (IPC path or authenticated RPC endpoint)",
 		Category: flags.LoggingCategory,
 	}
 	DBEngineFlag = &cli.StringFlag{
@@ -186,6 +191,11 @@ var (
 		Value:    11500000,
 		Category: flags.DevCategory,
 	}
+	DeveloperFixtureFlag = &cli.StringFlag{
+		Name:     "dev.fixture",
+		Usage:    "JSON manifest of mnemonic-derived accounts and contracts to seed into the developer genesis",
+		Category: flags.DevCategory,
+	}
 
 	IdentityFlag = &cli.StringFlag{
 		Name:     "identity",
@@ -226,6 +236,15 @@ var (
 		Usage: "Max number of elements (0 = no limit)",
 		Value: 0,
 	}
+	DumpStreamFlag = &cli.BoolFlag{
+		Name:  "stream",
+		Usage: "Dump the state as verifiable chunks with range proofs, delimited by newlines",
+	}
+	DumpChunkSizeFlag = &cli.IntFlag{
+		Name:  "chunksize",
+		Usage: "Number of accounts and storage slots per chunk of a streaming dump",
+		Value: 10000,
+	}
 
 	SnapshotFlag = &cli.BoolFlag{
 		Name:     "snapshot",
@@ -322,6 +341,56 @@ var (
 		Value:    ethconfig.Defaults.StateHistory,
 		Category: flags.StateCategory,
 	}
+	StateHistoryCompressFlag = &cli.BoolFlag{
+		Name:     "history.state.compress",
+		Usage:    "Write the state histories deduplicated and delta encoded, only relevant in state.scheme=path",
+		Category: flags.StateCategory,
+	}
+	StateWriteAheadLogFlag = &cli.BoolFlag{
+		Name:     "state.wal",
+		Usage:    "Record the unflushed state layers to replay them after a crash instead of re-executing the blocks, only relevant in state.scheme=path",
+		Category: flags.StateCategory,
+	}
+	StateLegacyDirFlag = &cli.StringFlag{
+		Name:     "state.legacydir",
+		Usage:    "Read-only hash scheme database left by the migration to state.scheme=path, serving the historical state up to its head block",
+		Category: flags.StateCategory,
+	}
+	StateRentFlag = &cli.BoolFlag{
+		Name:     "state.rent",
+		Usage:    "Account the hypothetical state rent charged to the touched accounts (experimental, no consensus change)",
+		Category: flags.StateCategory,
+	}
+	StateRentRateFlag = &cli.Uint64Flag{
+		Name:     "state.rent.rate",
+		Usage:    "Hypothetical state rent in wei per byte of state per block",
+		Value:    ethconfig.Defaults.StateRentConfig.Rate,
+		Category: flags.StateCategory,
+	}
+	StateRentTouchFeeFlag = &cli.Uint64Flag{
+		Name:     "state.rent.touchfee",
+		Usage:    "Hypothetical flat fee in wei per account touched in a block",
+		Value:    ethconfig.Defaults.StateRentConfig.TouchFee,
+		Category: flags.StateCategory,
+	}
+	StateRentAccountSizeFlag = &cli.Uint64Flag{
+		Name:     "state.rent.accountsize",
+		Usage:    "Bytes of state charged the hypothetical state rent for an account",
+		Value:    ethconfig.Defaults.StateRentConfig.AccountSize,
+		Category: flags.StateCategory,
+	}
+	StateRentSlotSizeFlag = &cli.Uint64Flag{
+		Name:     "state.rent.slotsize",
+		Usage:    "Bytes of state charged the hypothetical state rent for an accessed storage slot",
+		Value:    ethconfig.Defaults.StateRentConfig.SlotSize,
+		Category: flags.StateCategory,
+	}
+	StateRentAccountsFlag = &cli.IntFlag{
+		Name:     "state.rent.accounts",
+		Usage:    "Maximum number of accounts kept in the hypothetical state rent ledger, the least recently touched are evicted",
+		Value:    ethconfig.Defaults.StateRentConfig.MaxAccounts,
+		Category: flags.StateCategory,
+	}
 	TransactionHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.transactions",
 		Usage:    "Number of recent blocks to maintain transactions index for (default = about one year, 0 = entire chain)",
@@ -351,6 +420,187 @@ var (
 		Category: flags.StateCategory,
 		Value:    "",
 	}
+	SenderIndexFlag = &cli.BoolFlag{
+		Name:     "index.senders",
+		Usage:    "Maintain a sender address to transaction index (eth_getTransactionsBySender)",
+		Category: flags.StateCategory,
+	}
+	SenderIndexHistoryFlag = &cli.Uint64Flag{
+		Name:     "index.senders.history",
+		Usage:    "Number of recent blocks to maintain the sender transaction index for (0 = all blocks since enabled)",
+		Value:    ethconfig.Defaults.SenderIndexHistory,
+		Category: flags.StateCategory,
+	}
+	TransferIndexFlag = &cli.BoolFlag{
+		Name:     "index.transfers",
+		Usage:    "Maintain an account to ERC-20/ERC-721 token transfer index (ext_getTokenTransfers)",
+		Category: flags.StateCategory,
+	}
+	TransferIndexHistoryFlag = &cli.Uint64Flag{
+		Name:     "index.transfers.history",
+		Usage:    "Number of recent blocks to maintain the token transfer index for (0 = all blocks since enabled)",
+		Value:    ethconfig.Defaults.TransferIndexHistory,
+		Category: flags.StateCategory,
+	}
+	InternalTxIndexFlag = &cli.BoolFlag{
+		Name:     "index.internaltxs",
+		Usage:    "Trace the processed blocks and maintain an account to internal transaction index (ext_getInternalTransactions)",
+		Category: flags.StateCategory,
+	}
+	InternalTxIndexHistoryFlag = &cli.Uint64Flag{
+		Name:     "index.internaltxs.history",
+		Usage:    "Number of recent blocks to maintain the internal transaction index for (0 = all blocks since enabled)",
+		Value:    ethconfig.Defaults.InternalTxIndexHistory,
+		Category: flags.StateCategory,
+	}
+	CreationIndexFlag = &cli.BoolFlag{
+		Name:     "index.creations",
+		Usage:    "Trace the processed blocks and maintain a contract to creation transaction index (ext_getContractCreation)",
+		Category: flags.StateCategory,
+	}
+	CodeChangeIndexFlag = &cli.BoolFlag{
+		Name:     "index.codechanges",
+		Usage:    "Trace the processed blocks and maintain an account to code change index (ext_getCodeChanges)",
+		Category: flags.StateCategory,
+	}
+	CodeChangeIndexHistoryFlag = &cli.Uint64Flag{
+		Name:     "index.codechanges.history",
+		Usage:    "Number of recent blocks to maintain the code change index for (0 = all blocks since enabled)",
+		Value:    ethconfig.Defaults.CodeChangeIndexHistory,
+		Category: flags.StateCategory,
+	}
+	BalanceChangesFlag = &cli.BoolFlag{
+		Name:     "history.balancechanges",
+		Usage:    "Record the balance and nonce changes of the processed blocks (debug_getBalanceChanges)",
+		Category: flags.StateCategory,
+	}
+	SupplyFlag = &cli.BoolFlag{
+		Name:     "history.supply",
+		Usage:    "Track the native token supply changes of the processed blocks (debug_totalSupply)",
+		Category: flags.StateCategory,
+	}
+	ChainAuditFlag = &cli.BoolFlag{
+		Name:     "history.audit",
+		Usage:    "Periodically re-verify the integrity of the stored chain data in the background",
+		Category: flags.StateCategory,
+	}
+	ChainAuditFromFlag = &cli.Uint64Flag{
+		Name:     "history.audit.from",
+		Usage:    "First block of the chain range verified by the auditor",
+		Value:    ethconfig.Defaults.ChainAuditConfig.From,
+		Category: flags.StateCategory,
+	}
+	ChainAuditToFlag = &cli.Uint64Flag{
+		Name:     "history.audit.to",
+		Usage:    "Last block of the chain range verified by the auditor (0 = chain head)",
+		Value:    ethconfig.Defaults.ChainAuditConfig.To,
+		Category: flags.StateCategory,
+	}
+	ChainAuditRateFlag = &cli.Uint64Flag{
+		Name:     "history.audit.rate",
+		Usage:    "Maximum number of blocks verified by the auditor per second (0 = unlimited)",
+		Value:    ethconfig.Defaults.ChainAuditConfig.Rate,
+		Category: flags.StateCategory,
+	}
+	ChainAuditIntervalFlag = &cli.DurationFlag{
+		Name:     "history.audit.interval",
+		Usage:    "Time to wait between two consecutive chain audit passes",
+		Value:    ethconfig.Defaults.ChainAuditConfig.Interval,
+		Category: flags.StateCategory,
+	}
+	// Head attestation settings
+	HeadAttestFlag = &cli.BoolFlag{
+		Name:     "attest",
+		Usage:    "Track the chain head attested by a quorum of trusted keys, without verifying execution",
+		Category: flags.RollupCategory,
+	}
+	HeadAttestSignersFlag = &cli.StringFlag{
+		Name:     "attest.signers",
+		Usage:    "Comma separated addresses of the sequencer and verifier keys trusted to attest the chain head",
+		Category: flags.RollupCategory,
+	}
+	HeadAttestQuorumFlag = &cli.IntFlag{
+		Name:     "attest.quorum",
+		Usage:    "Number of distinct trusted keys required to attest a chain head",
+		Value:    1,
+		Category: flags.RollupCategory,
+	}
+	// Chain head watchdog settings
+	WatchdogFlag = &cli.BoolFlag{
+		Name:     "watchdog",
+		Usage:    "Detect a chain head stalled behind the network and recover by rotating peers and restarting the sync",
+		Category: flags.NetworkingCategory,
+	}
+	WatchdogReferencesFlag = &cli.StringFlag{
+		Name:     "watchdog.references",
+		Usage:    "Comma separated RPC endpoints of the reference nodes the chain head is compared against (default = peers)",
+		Category: flags.NetworkingCategory,
+	}
+	WatchdogMaxLagFlag = &cli.Uint64Flag{
+		Name:     "watchdog.maxlag",
+		Usage:    "Number of blocks the chain head may trail the network",
+		Value:    ethconfig.Defaults.WatchdogConfig.MaxLag,
+		Category: flags.NetworkingCategory,
+	}
+	WatchdogTimeoutFlag = &cli.DurationFlag{
+		Name:     "watchdog.timeout",
+		Usage:    "Time the chain head may trail the network without progress before it is considered stalled",
+		Value:    ethconfig.Defaults.WatchdogConfig.Timeout,
+		Category: flags.NetworkingCategory,
+	}
+	WatchdogIntervalFlag = &cli.DurationFlag{
+		Name:     "watchdog.interval",
+		Usage:    "Time between two consecutive chain head checks",
+		Value:    ethconfig.Defaults.WatchdogConfig.Interval,
+		Category: flags.NetworkingCategory,
+	}
+	WatchdogRotateFlag = &cli.IntFlag{
+		Name:     "watchdog.rotate",
+		Usage:    "Number of peers dropped on every attempt to recover a stalled chain head",
+		Value:    ethconfig.Defaults.WatchdogConfig.Rotate,
+		Category: flags.NetworkingCategory,
+	}
+	// Execution cross-check settings
+	CrossCheckReferenceFlag = &cli.StringFlag{
+		Name:     "crosscheck.reference",
+		Usage:    "RPC endpoint of the reference node the state and receipts roots of the imported blocks are verified against",
+		Category: flags.VMCategory,
+	}
+	CrossCheckHaltFlag = &cli.BoolFlag{
+		Name:     "crosscheck.halt",
+		Usage:    "Halt the import of blocks when the execution diverges from the reference node, until resumed with admin_resumeChain",
+		Category: flags.VMCategory,
+	}
+	CrossCheckIntervalFlag = &cli.DurationFlag{
+		Name:     "crosscheck.interval",
+		Usage:    "Time between two polls of the reference node for the imported blocks",
+		Value:    ethconfig.Defaults.CrossCheckConfig.Interval,
+		Category: flags.VMCategory,
+	}
+	// State reader settings
+	StateReaderFlag = &cli.BoolFlag{
+		Name:     "statereader",
+		Usage:    "Serve consistent state snapshots to external processes on a dedicated IPC endpoint",
+		Category: flags.APICategory,
+	}
+	StateReaderIPCPathFlag = &cli.StringFlag{
+		Name:     "statereader.ipcpath",
+		Usage:    "Filename for the state reader IPC socket/pipe within the datadir (explicit paths escape it)",
+		Value:    ethconfig.Defaults.StateReaderConfig.IPCPath,
+		Category: flags.APICategory,
+	}
+	StateReaderRateLimitFlag = &cli.IntFlag{
+		Name:     "statereader.ratelimit",
+		Usage:    "Maximum number of accounts and storage slots served by the state reader per second (0 = unlimited)",
+		Value:    ethconfig.Defaults.StateReaderConfig.RateLimit,
+		Category: flags.APICategory,
+	}
+	// Contract ABI registry settings
+	ContractABISourcifyFlag = &cli.StringFlag{
+		Name:     "abi.sourcify",
+		Usage:    "Sourcify server URL to fetch the contract ABIs from with admin_fetchContractABI (e.g. https://sourcify.dev/server)",
+		Category: flags.APICategory,
+	}
 	// Beacon client light sync settings
 	BeaconApiFlag = &cli.StringSliceFlag{
 		Name:     "beacon.api",
@@ -448,6 +698,21 @@ var (
 		Value:    ethconfig.Defaults.TxPool.PriceBump,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolTipBumpFlag = &cli.Uint64Flag{
+		Name:     "txpool.tipbump",
+		Usage:    "Tip bump percentage to replace an already existing transaction (default = price bump)",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolConditionalPriceBumpFlag = &cli.Uint64Flag{
+		Name:     "txpool.conditionalpricebump",
+		Usage:    "Price bump percentage to replace a transaction if either has conditionals (default = price bump)",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolConditionalTipBumpFlag = &cli.Uint64Flag{
+		Name:     "txpool.conditionaltipbump",
+		Usage:    "Tip bump percentage to replace a transaction if either has conditionals (default = conditional price bump)",
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolAccountSlotsFlag = &cli.Uint64Flag{
 		Name:     "txpool.accountslots",
 		Usage:    "Minimum number of executable transaction slots guaranteed per account",
@@ -478,6 +743,98 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolReconcileFlag = &cli.DurationFlag{
+		Name:     "txpool.reconcile",
+		Usage:    "Interval of the reconciliation of the pending transactions with the trusted peers (0 = disabled)",
+		Category: flags.TxPoolCategory,
+	}
+	TxFetchWaitFlag = &cli.DurationFlag{
+		Name:     "txpool.fetchwait",
+		Usage:    "Time to wait for an announced transaction to be broadcast before requesting it",
+		Value:    ethconfig.Defaults.TxFetcher.ArriveTimeout,
+		Category: flags.TxPoolCategory,
+	}
+	TxFetchBatchFlag = &cli.IntFlag{
+		Name:     "txpool.fetchbatch",
+		Usage:    "Maximum number of announced transactions to request from a peer at once",
+		Value:    ethconfig.Defaults.TxFetcher.MaxRetrievals,
+		Category: flags.TxPoolCategory,
+	}
+	TxFetchBytesFlag = &cli.Uint64Flag{
+		Name:     "txpool.fetchbytes",
+		Usage:    "Maximum announced size of the transactions to request from a peer at once",
+		Value:    ethconfig.Defaults.TxFetcher.MaxRetrievalSize,
+		Category: flags.TxPoolCategory,
+	}
+	TxFetchRequestsFlag = &cli.IntFlag{
+		Name:     "txpool.fetchrequests",
+		Usage:    "Maximum number of concurrent transaction requests across all peers (0 = one per peer)",
+		Value:    ethconfig.Defaults.TxFetcher.MaxRequests,
+		Category: flags.TxPoolCategory,
+	}
+	TxAnnounceSizeFlag = &cli.Uint64Flag{
+		Name:     "txpool.announcesize",
+		Usage:    "Size above which transactions are only announced to peers, never broadcast",
+		Value:    ethconfig.Defaults.TxAnnounceSize,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolTenantSlotsFlag = &cli.Uint64Flag{
+		Name:     "txpool.tenantslots",
+		Usage:    "Maximum number of transaction slots used by a single authenticated RPC client (0 = unlimited)",
+		Value:    ethconfig.Defaults.TxPool.TenantSlots,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolTenantGasFlag = &cli.Uint64Flag{
+		Name:     "txpool.tenantgas",
+		Usage:    "Maximum total gas limit of the transactions of a single authenticated RPC client (0 = unlimited)",
+		Value:    ethconfig.Defaults.TxPool.TenantGas,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolRevertCheckFlag = &cli.StringFlag{
+		Name:     "txpool.revertcheck",
+		Usage:    "Simulate incoming transactions and tag or reject the ones that would revert or run out of gas (tag, reject)",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolRevertCheckGasFlag = &cli.Uint64Flag{
+		Name:     "txpool.revertcheck.gas",
+		Usage:    "Maximum gas of incoming transactions simulated per second by --txpool.revertcheck",
+		Value:    ethconfig.Defaults.TxPool.RevertCheckGas,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSpamThresholdFlag = &cli.Uint64Flag{
+		Name:     "txpool.spamthreshold",
+		Usage:    "Spam score of a sender (replacements, cancellations and failed transactions) per raise of its minimum tip, locals exempt (0 = disabled)",
+		Value:    ethconfig.Defaults.TxPool.SpamThreshold,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSpamTipStepFlag = &cli.Uint64Flag{
+		Name:     "txpool.spamtipstep",
+		Usage:    "Minimum tip raise (in wei) required from a sender per --txpool.spamthreshold of its spam score",
+		Value:    ethconfig.Defaults.TxPool.SpamTipStep,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSpamDecayFlag = &cli.DurationFlag{
+		Name:     "txpool.spamdecay",
+		Usage:    "Time for the spam score of a sender to halve",
+		Value:    ethconfig.Defaults.TxPool.SpamDecay,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolAddressPolicyFlag = &cli.StringFlag{
+		Name:     "txpool.addresspolicy",
+		Usage:    "Refuse transactions from or to the listed addresses (block), or any other address (allow), in the pool and in built blocks",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolAddressPolicyListFlag = &cli.StringFlag{
+		Name:     "txpool.addresspolicy.list",
+		Usage:    "Comma separated addresses of the address policy, updatable at runtime through the authenticated policy RPC API",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolAddressPolicyAuditFlag = &cli.StringFlag{
+		Name:     "txpool.addresspolicy.audit",
+		Usage:    "Signed audit log of the address policy enforcement (relative to the data directory)",
+		Value:    ethconfig.Defaults.AddressPolicyAudit,
+		Category: flags.TxPoolCategory,
+	}
 	// Blob transaction pool settings
 	BlobPoolDataDirFlag = &cli.StringFlag{
 		Name:     "blobpool.datadir",
@@ -497,6 +854,23 @@ var (
 		Value:    ethconfig.Defaults.BlobPool.PriceBump,
 		Category: flags.BlobPoolCategory,
 	}
+	BlobPoolTipBumpFlag = &cli.Uint64Flag{
+		Name:     "blobpool.tipbump",
+		Usage:    "Tip bump percentage to replace an already existing blob transaction (default = price bump)",
+		Category: flags.BlobPoolCategory,
+	}
+	BlobPoolProjectionFlag = &cli.Uint64Flag{
+		Name:     "blobpool.projection",
+		Usage:    "Number of full blob blocks the blob fee is projected over when evicting from a full pool (0 = current fee only)",
+		Value:    ethconfig.Defaults.BlobPool.ProjectionBlocks,
+		Category: flags.BlobPoolCategory,
+	}
+	BlobPoolAccountBlobsFlag = &cli.Uint64Flag{
+		Name:     "blobpool.accountblobs",
+		Usage:    "Maximum number of blobs pooled per account (0 = unlimited)",
+		Value:    ethconfig.Defaults.BlobPool.MaxAccountBlobs,
+		Category: flags.BlobPoolCategory,
+	}
 	// Performance tuning settings
 	CacheFlag = &cli.IntFlag{
 		Name:     "cache",
@@ -528,6 +902,22 @@ var (
 		Value:    10,
 		Category: flags.PerfCategory,
 	}
+	CacheCodeFlag = &cli.IntFlag{
+		Name:     "cache.code",
+		Usage:    "Megabytes of memory allocated to contract code caching, shared by block processing and RPC calls",
+		Value:    ethconfig.Defaults.CodeCache,
+		Category: flags.PerfCategory,
+	}
+	CacheAdaptiveFlag = &cli.BoolFlag{
+		Name:     "cache.adaptive",
+		Usage:    "Repartition the trie, snapshot and code cache allowances at runtime based on their hit rates and the workload (the percentages set the initial split)",
+		Category: flags.PerfCategory,
+	}
+	MemoryLimitFlag = &cli.IntFlag{
+		Name:     "memory.limit",
+		Usage:    "Megabytes of memory the node stays under by shedding traces and large RPC responses and shrinking caches (0 = unlimited)",
+		Category: flags.PerfCategory,
+	}
 	CacheNoPrefetchFlag = &cli.BoolFlag{
 		Name:     "cache.noprefetch",
 		Usage:    "Disable heuristic state prefetch during block import (less CPU and disk IO, more time waiting for data)",
@@ -591,6 +981,83 @@ var (
 		Usage:    "0x prefixed public address for the pending block producer (not used for actual block production)",
 		Category: flags.MinerCategory,
 	}
+	MinerRecordPayloadsFlag = &cli.BoolFlag{
+		Name:     "miner.recordpayloads",
+		Usage:    "Record the txpool snapshot and attributes of locally built payloads, allowing to rebuild them with debug_rebuildPayload",
+		Category: flags.MinerCategory,
+	}
+	MinerRecordAccessListsFlag = &cli.BoolFlag{
+		Name:     "miner.recordaccesslists",
+		Usage:    "Record the access lists of the transactions of locally built blocks, retrievable with debug_getBlockAccessList",
+		Category: flags.MinerCategory,
+	}
+	MinerCommitPolicyFlag = &cli.BoolFlag{
+		Name:     "miner.commitpolicy",
+		Usage:    "Commit to the transaction ordering policy in the block extra-data (not supported on OP Stack chains)",
+		Category: flags.MinerCategory,
+	}
+	MinerBuilderPayloadsFlag = &cli.BoolFlag{
+		Name:     "miner.builderpayloads",
+		Usage:    "Accept payloads built by external block builders over the authenticated engine API (engine_submitBuilderPayloadV1)",
+		Category: flags.MinerCategory,
+	}
+	MinerBuilderMinDeltaFlag = &flags.BigFlag{
+		Name:     "miner.builderpayloads.mindelta",
+		Usage:    "Fees in wei a builder payload has to pay on top of the local payload to be selected",
+		Category: flags.MinerCategory,
+	}
+	MinerBuilderAllowFlag = &cli.StringFlag{
+		Name:     "miner.builderpayloads.allow",
+		Usage:    "Comma separated list of the credentials of the builders allowed to submit payloads (jwt:<secret id> or tls:<client certificate name>)",
+		Category: flags.MinerCategory,
+	}
+	MinerBuilderInclusionFlag = &cli.BoolFlag{
+		Name:     "miner.builderpayloads.inclusion",
+		Usage:    "Reject builder payloads omitting transactions of the prioritized senders (--txpool.locals) included by the local payload",
+		Category: flags.MinerCategory,
+	}
+	MinerFeeRecipientsFlag = &cli.StringFlag{
+		Name:     "miner.feerecipients",
+		Usage:    "Comma separated list of address[:weight] fee recipients rotated per period instead of the requested one (not supported on OP Stack chains)",
+		Category: flags.MinerCategory,
+	}
+	MinerFeeRecipientsPeriodFlag = &cli.Uint64Flag{
+		Name:     "miner.feerecipients.period",
+		Usage:    "Number of consecutive blocks per fee recipient period",
+		Value:    1,
+		Category: flags.MinerCategory,
+	}
+	MinerGasTuningMinFlag = &cli.Uint64Flag{
+		Name:     "miner.gastuning.min",
+		Usage:    "Lowest gas ceiling set by the automatic tuning (requires --miner.gastuning.max)",
+		Category: flags.MinerCategory,
+	}
+	MinerGasTuningMaxFlag = &cli.Uint64Flag{
+		Name:     "miner.gastuning.max",
+		Usage:    "Highest gas ceiling set by the automatic tuning, enabling the tuning based on the build latency and block fullness",
+		Category: flags.MinerCategory,
+	}
+	MinerGasTuningLatencyFlag = &cli.DurationFlag{
+		Name:     "miner.gastuning.latency",
+		Usage:    "Target payload build latency of the automatic gas ceiling tuning",
+		Value:    miner.DefaultGasCeilTuningLatency,
+		Category: flags.MinerCategory,
+	}
+	MinerSealDelayFlag = &cli.DurationFlag{
+		Name:     "miner.sealdelay",
+		Usage:    "Offset into the slot locally built payloads are sealed at, at the earliest, to include more transactions (keep below the getPayload deadline)",
+		Category: flags.MinerCategory,
+	}
+	MinerSealJitterFlag = &cli.DurationFlag{
+		Name:     "miner.sealjitter",
+		Usage:    "Upper bound of the random delay added to the sealing offset",
+		Category: flags.MinerCategory,
+	}
+	MinerStandbyFlag = &cli.BoolFlag{
+		Name:     "miner.standby",
+		Usage:    "Follow the chain as a hot standby, without building payloads or gossiping transactions until promoted with admin_promote",
+		Category: flags.MinerCategory,
+	}
 
 	// Account settings
 	PasswordFileFlag = &cli.PathFlag{
@@ -611,6 +1078,16 @@ var (
 		Usage:    "Record information useful for VM and contract debugging",
 		Category: flags.VMCategory,
 	}
+	VMCodeAnalysisFlag = &cli.BoolFlag{
+		Name:     "vm.experimental.analysis",
+		Usage:    "Execute contracts from cached gas block and jump fusion analysis (experimental)",
+		Category: flags.VMCategory,
+	}
+	VMCodeAnalysisShadowFlag = &cli.BoolFlag{
+		Name:     "vm.experimental.shadow",
+		Usage:    "Cross-check analyzed contract executions against the plain execution, at twice the cost",
+		Category: flags.VMCategory,
+	}
 	VMTraceFlag = &cli.StringFlag{
 		Name:     "vmtrace",
 		Usage:    "Name of tracer which should record internal VM operations (costly)",
@@ -622,6 +1099,11 @@ var (
 		Value:    "{}",
 		Category: flags.VMCategory,
 	}
+	BadBlockDirFlag = &cli.StringFlag{
+		Name:     "badblocks.dir",
+		Usage:    "Directory to capture the RLP and re-execution trace of the blocks failing validation in (default = inside the datadir, \"\" to disable)",
+		Category: flags.VMCategory,
+	}
 	// API options.
 	RPCGlobalGasCapFlag = &cli.Uint64Flag{
 		Name:     "rpc.gascap",
@@ -641,6 +1123,28 @@ var (
 		Value:    ethconfig.Defaults.RPCTxFeeCap,
 		Category: flags.APICategory,
 	}
+	RPCPrefetchLearnFlag = &cli.BoolFlag{
+		Name:     "rpc.prefetch.learn",
+		Usage:    "Learn the state accessed by eth_call per contract and method, and prefetch it for later calls",
+		Category: flags.APICategory,
+	}
+	RPCReexecLimitFlag = &cli.IntFlag{
+		Name:     "rpc.reexec.limit",
+		Usage:    "Maximum number of historical states regenerated concurrently for the tracing APIs",
+		Value:    ethconfig.Defaults.RPCReexecLimit,
+		Category: flags.APICategory,
+	}
+	RPCReexecCacheFlag = &cli.IntFlag{
+		Name:     "rpc.reexec.cache",
+		Usage:    "Number of regenerated historical states cached for the tracing APIs (0 = disabled)",
+		Value:    ethconfig.Defaults.RPCReexecCache,
+		Category: flags.APICategory,
+	}
+	RPCOverlaysFlag = &cli.IntFlag{
+		Name:     "rpc.overlays",
+		Usage:    "Maximum number of ephemeral overlay chains for what-if simulations (0 = overlay API disabled)",
+		Category: flags.APICategory,
+	}
 	// Authenticated RPC HTTP settings
 	AuthListenFlag = &cli.StringFlag{
 		Name:     "authrpc.addr",
@@ -660,11 +1164,52 @@ var (
 		Value:    strings.Join(node.DefaultConfig.AuthVirtualHosts, ","),
 		Category: flags.APICategory,
 	}
+	AuthApiFlag = &cli.StringFlag{
+		Name:     "authrpc.api",
+		Usage:    "Comma separated list of public namespaces (e.g. admin, txpool) whose authenticated-only APIs to serve on the authenticated endpoints",
+		Value:    "",
+		Category: flags.APICategory,
+	}
 	JWTSecretFlag = &flags.DirectoryFlag{
 		Name:     "authrpc.jwtsecret",
 		Usage:    "Path to a JWT secret to use for authenticated RPC endpoints",
 		Category: flags.APICategory,
 	}
+	JWTSecretsFlag = &cli.StringFlag{
+		Name:     "authrpc.jwtsecrets",
+		Usage:    "Comma separated additional JWT secrets accepted by authenticated RPC endpoints (id=path,...)",
+		Category: flags.APICategory,
+	}
+	AuthTLSCertFlag = &flags.DirectoryFlag{
+		Name:     "authrpc.tlscert",
+		Usage:    "Path to a TLS certificate to serve authenticated APIs over HTTPS with",
+		Category: flags.APICategory,
+	}
+	AuthTLSKeyFlag = &flags.DirectoryFlag{
+		Name:     "authrpc.tlskey",
+		Usage:    "Path to the private key of the authenticated APIs TLS certificate",
+		Category: flags.APICategory,
+	}
+	AuthTLSClientCAFlag = &flags.DirectoryFlag{
+		Name:     "authrpc.tlsclientca",
+		Usage:    "Path to a CA bundle verifying the TLS client certificates required by authenticated APIs",
+		Category: flags.APICategory,
+	}
+	AuthTLSClientPinsFlag = &cli.StringFlag{
+		Name:     "authrpc.tlsclientpins",
+		Usage:    "Comma separated SHA-256 fingerprints of the TLS client certificates accepted by authenticated APIs",
+		Category: flags.APICategory,
+	}
+	AuthTLSClientNamesFlag = &cli.StringFlag{
+		Name:     "authrpc.tlsclientnames",
+		Usage:    "Comma separated subject alternative names of the TLS client certificates authorized on authenticated APIs",
+		Category: flags.APICategory,
+	}
+	AuthJWTDisabledFlag = &cli.BoolFlag{
+		Name:     "authrpc.nojwt",
+		Usage:    "Disable JWT authentication on authenticated APIs, relying on TLS client certificates",
+		Category: flags.APICategory,
+	}
 
 	// Logging and debug settings
 	EthStatsURLFlag = &cli.StringFlag{
@@ -672,6 +1217,66 @@ var (
 		Usage:    "Reporting URL of a ethstats service (nodename:secret@host:port)",
 		Category: flags.MetricsCategory,
 	}
+	EthStatsProtocolFlag = &cli.IntFlag{
+		Name:     "ethstats.protocol",
+		Usage:    "Version of the ethstats reporting protocol (1 = legacy, 2 = token auth and metrics payload)",
+		Value:    1,
+		Category: flags.MetricsCategory,
+	}
+	EthStatsTokenFlag = &cli.StringFlag{
+		Name:     "ethstats.token",
+		Usage:    "Bearer token authenticating the node at the ethstats service (protocol 2)",
+		Category: flags.MetricsCategory,
+	}
+	EthStatsIntervalFlag = &cli.DurationFlag{
+		Name:     "ethstats.interval",
+		Usage:    "Interval of full ethstats reports",
+		Value:    15 * time.Second,
+		Category: flags.MetricsCategory,
+	}
+	EthStatsCACertFlag = &cli.StringFlag{
+		Name:     "ethstats.tls.ca",
+		Usage:    "PEM file of CA certificates to verify the ethstats service with",
+		Category: flags.MetricsCategory,
+	}
+	EthStatsClientCertFlag = &cli.StringFlag{
+		Name:     "ethstats.tls.cert",
+		Usage:    "PEM file of the client certificate presented to the ethstats service",
+		Category: flags.MetricsCategory,
+	}
+	EthStatsClientKeyFlag = &cli.StringFlag{
+		Name:     "ethstats.tls.key",
+		Usage:    "PEM file of the client certificate key",
+		Category: flags.MetricsCategory,
+	}
+	EthStatsMetricsFlag = &cli.StringSliceFlag{
+		Name:     "ethstats.metrics",
+		Usage:    "Names of additional metrics to include in ethstats reports (protocol 2)",
+		Category: flags.MetricsCategory,
+	}
+
+	// Remote configuration settings
+	RemoteConfigURLFlag = &cli.StringFlag{
+		Name:     "remoteconfig.url",
+		Usage:    "URL of a signed remote configuration document to poll for runtime settings",
+		Category: flags.MiscCategory,
+	}
+	RemoteConfigSignersFlag = &cli.StringFlag{
+		Name:     "remoteconfig.signers",
+		Usage:    "Comma separated accounts authorized to sign the remote configuration",
+		Category: flags.MiscCategory,
+	}
+	RemoteConfigIntervalFlag = &cli.DurationFlag{
+		Name:     "remoteconfig.interval",
+		Usage:    "Time between two consecutive fetches of the remote configuration",
+		Value:    remoteconfig.DefaultConfig.Interval,
+		Category: flags.MiscCategory,
+	}
+	RemoteConfigPinnedFlag = &cli.StringFlag{
+		Name:     "remoteconfig.pinned",
+		Usage:    "Comma separated settings which always keep their local value",
+		Category: flags.MiscCategory,
+	}
 	NoCompactionFlag = &cli.BoolFlag{
 		Name:     "nocompaction",
 		Usage:    "Disables db compaction after import",
@@ -679,12 +1284,28 @@ var (
 	}
 
 	// MISC settings
+	SyncCheckpointFlag = &cli.StringFlag{
+		Name:     "synccheckpoint",
+		Usage:    `Trusted block to anchor the snap sync pivot to, as "number:hash:stateroot" (headers below it are still downloaded)`,
+		Category: flags.StateCategory,
+	}
+	SyncPivotFlag = &cli.StringFlag{
+		Name:     "syncpivot",
+		Usage:    `Snap sync pivot selection ("latest", "finalized", "offset:<blocks>" or "pinned:<hash>")`,
+		Category: flags.StateCategory,
+	}
 	SyncTargetFlag = &cli.StringFlag{
 		Name:      "synctarget",
 		Usage:     `Hash of the block to full sync to (dev testing feature)`,
 		TakesFile: true,
 		Category:  flags.MiscCategory,
 	}
+	ShutdownDrainFlag = &cli.DurationFlag{
+		Name:     "shutdown.drain",
+		Usage:    "Time allowed to finish in-flight work, such as a payload being built, on shutdown (0 = no waiting)",
+		Value:    node.DefaultConfig.ShutdownDrain,
+		Category: flags.MiscCategory,
+	}
 
 	// RPC settings
 	IPCDisabledFlag = &cli.BoolFlag{
@@ -697,6 +1318,11 @@ var (
 		Usage:    "Filename for IPC socket/pipe within the datadir (explicit paths escape it)",
 		Category: flags.APICategory,
 	}
+	IPCPermissionsFlag = &cli.StringFlag{
+		Name:     "ipcperm",
+		Usage:    "File permissions of the IPC socket, in octal (default owner only)",
+		Category: flags.APICategory,
+	}
 	HTTPEnabledFlag = &cli.BoolFlag{
 		Name:     "http",
 		Usage:    "Enable the HTTP-RPC server",
@@ -738,6 +1364,23 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	HTTPTLSCertFlag = &cli.StringFlag{
+		Name:     "http.tlscert",
+		Usage:    "TLS certificate file to serve HTTPS (and HTTP/2) on the HTTP-RPC server",
+		Value:    "",
+		Category: flags.APICategory,
+	}
+	HTTPTLSKeyFlag = &cli.StringFlag{
+		Name:     "http.tlskey",
+		Usage:    "TLS private key file of the HTTP-RPC server certificate",
+		Value:    "",
+		Category: flags.APICategory,
+	}
+	HTTPH2CFlag = &cli.BoolFlag{
+		Name:     "http.h2c",
+		Usage:    "Enable HTTP/2 over cleartext connections (h2c) on the HTTP-RPC server",
+		Category: flags.APICategory,
+	}
 	GraphQLEnabledFlag = &cli.BoolFlag{
 		Name:     "graphql",
 		Usage:    "Enable GraphQL on the HTTP-RPC server. Note that GraphQL can only be started if an HTTP server is started as well.",
@@ -755,6 +1398,16 @@ var (
 		Value:    strings.Join(node.DefaultConfig.GraphQLVirtualHosts, ","),
 		Category: flags.APICategory,
 	}
+	ProofServerEnabledFlag = &cli.BoolFlag{
+		Name:     "proofs",
+		Usage:    "Enable the authenticated state and receipt proof server on the HTTP-RPC server",
+		Category: flags.APICategory,
+	}
+	ProofServerJWTSecretFlag = &flags.DirectoryFlag{
+		Name:     "proofs.jwtsecret",
+		Usage:    "Path to a JWT secret to use for the proof server (generated if missing)",
+		Category: flags.APICategory,
+	}
 	WSEnabledFlag = &cli.BoolFlag{
 		Name:     "ws",
 		Usage:    "Enable the WS-RPC server",
@@ -790,6 +1443,18 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	WSSubscriptionBufferFlag = &cli.IntFlag{
+		Name:     "ws.subscription-buffer",
+		Usage:    "Number of notifications queued per websocket subscription for slow clients (0 = send synchronously)",
+		Value:    node.DefaultConfig.WSSubscriptionBuffer,
+		Category: flags.APICategory,
+	}
+	WSSubscriptionPolicyFlag = &cli.StringFlag{
+		Name:     "ws.subscription-policy",
+		Usage:    "Handling of websocket subscriptions with a full queue (disconnect, drop-oldest, pause)",
+		Value:    node.DefaultConfig.WSSubscriptionPolicy,
+		Category: flags.APICategory,
+	}
 	ExecFlag = &cli.StringFlag{
 		Name:     "exec",
 		Usage:    "Execute JavaScript statement",
@@ -831,6 +1496,26 @@ var (
 		Value:    node.DefaultConfig.P2P.MaxPendingPeers,
 		Category: flags.NetworkingCategory,
 	}
+	BandwidthCapFlag = &cli.IntFlag{
+		Name:     "bandwidth.cap",
+		Usage:    "Maximum egress bandwidth of the p2p protocols in KB/s (0 = unlimited)",
+		Category: flags.NetworkingCategory,
+	}
+	ProtocolBandwidthCapsFlag = &cli.StringFlag{
+		Name:     "bandwidth.protocaps",
+		Usage:    "Comma separated maximum egress bandwidth per p2p protocol in KB/s (e.g. snap=1024)",
+		Category: flags.NetworkingCategory,
+	}
+	IngressBandwidthCapFlag = &cli.IntFlag{
+		Name:     "bandwidth.ingresscap",
+		Usage:    "Maximum ingress bandwidth of the p2p protocols in KB/s (0 = unlimited)",
+		Category: flags.NetworkingCategory,
+	}
+	IngressProtocolBandwidthCapsFlag = &cli.StringFlag{
+		Name:     "bandwidth.ingressprotocaps",
+		Usage:    "Comma separated maximum ingress bandwidth per p2p protocol in KB/s (e.g. snap=1024)",
+		Category: flags.NetworkingCategory,
+	}
 	ListenPortFlag = &cli.IntFlag{
 		Name:     "port",
 		Usage:    "Network listening port",
@@ -859,6 +1544,11 @@ var (
 		Value:    "any",
 		Category: flags.NetworkingCategory,
 	}
+	NATFallbackFlag = &cli.StringFlag{
+		Name:     "nat.fallback",
+		Usage:    "External IP detection mechanism used when the NAT port mapping fails to provide it (none|stun|stun:<IP:PORT>)",
+		Category: flags.NetworkingCategory,
+	}
 	NoDiscoverFlag = &cli.BoolFlag{
 		Name:     "nodiscover",
 		Usage:    "Disables the peer discovery mechanism (manual peer addition)",
@@ -878,6 +1568,11 @@ var (
 		Category: flags.NetworkingCategory,
 		Value:    true,
 	}
+	DiscoveryTopicFlag = &cli.BoolFlag{
+		Name:     "discovery.topic",
+		Usage:    "Advertises and searches the nodes of the chain under a V5 discovery topic, and serves the topic registrations of other nodes",
+		Category: flags.NetworkingCategory,
+	}
 	NetrestrictFlag = &cli.StringFlag{
 		Name:     "netrestrict",
 		Usage:    "Restricts network communication to the given IP networks (CIDR masks)",
@@ -894,6 +1589,11 @@ var (
 		Value:    30303,
 		Category: flags.NetworkingCategory,
 	}
+	SlimBlocksFlag = &cli.BoolFlag{
+		Name:     "slimblocks",
+		Usage:    "Exchange blocks with trusted peers without the derivable fields (falls back for other peers)",
+		Category: flags.NetworkingCategory,
+	}
 
 	// Console
 	JSpathFlag = &flags.DirectoryFlag{
@@ -973,6 +1673,20 @@ var (
 		Category: flags.RollupCategory,
 	}
 
+	RollupInteropFailurePolicyFlag = &cli.StringFlag{
+		Name:     "rollup.interopfailurepolicy",
+		Usage:    "Outcome of the interop message checks when the supervisor is unreachable: \"strict\" rejects the transactions, \"optimistic\" accepts them (experimental).",
+		Value:    string(interop.Strict),
+		Category: flags.RollupCategory,
+	}
+
+	RollupInteropCacheTTLFlag = &cli.DurationFlag{
+		Name:     "rollup.interopcachettl",
+		Usage:    "Time the interop message check outcomes are cached for, 0 to disable the cache (experimental).",
+		Value:    ethconfig.Defaults.InteropCacheTTL,
+		Category: flags.RollupCategory,
+	}
+
 	RollupDisableTxPoolGossipFlag = &cli.BoolFlag{
 		Name:     "rollup.disabletxpoolgossip",
 		Usage:    "Disable transaction pool gossip.",
@@ -1012,6 +1726,22 @@ var (
 		Category: flags.RollupCategory,
 		Value:    5000,
 	}
+	RollupSequencerFlag = &cli.BoolFlag{
+		Name:     "rollup.sequencer",
+		Usage:    "Sequence blocks with the built-in sequencing driver instead of an external rollup node (devnets only)",
+		Category: flags.RollupCategory,
+	}
+	RollupSequencerBlockTimeFlag = &cli.Uint64Flag{
+		Name:     "rollup.sequencer.blocktime",
+		Usage:    "Seconds between two blocks of the built-in sequencer",
+		Value:    2,
+		Category: flags.RollupCategory,
+	}
+	RollupSequencerOriginFlag = &cli.StringFlag{
+		Name:     "rollup.sequencer.origin",
+		Usage:    "RPC endpoint of the L1 node the blocks of the built-in sequencer refer to (a static synthetic L1 block if unset)",
+		Category: flags.RollupCategory,
+	}
 
 	// Metrics flags
 	MetricsEnabledFlag = &cli.BoolFlag{
@@ -1277,6 +2007,13 @@ func setNAT(ctx *cli.Context, cfg *p2p.Config) {
 		}
 		cfg.NAT = natif
 	}
+	if ctx.IsSet(NATFallbackFlag.Name) {
+		natif, err := nat.Parse(ctx.String(NATFallbackFlag.Name))
+		if err != nil {
+			Fatalf("Option %s: %v", NATFallbackFlag.Name, err)
+		}
+		cfg.NATFallback = natif
+	}
 }
 
 // SplitAndTrim splits input separated by a comma
@@ -1319,6 +2056,10 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 		cfg.AuthVirtualHosts = SplitAndTrim(ctx.String(AuthVirtualHostsFlag.Name))
 	}
 
+	if ctx.IsSet(AuthApiFlag.Name) {
+		cfg.AuthModules = SplitAndTrim(ctx.String(AuthApiFlag.Name))
+	}
+
 	if ctx.IsSet(HTTPCORSDomainFlag.Name) {
 		cfg.HTTPCors = SplitAndTrim(ctx.String(HTTPCORSDomainFlag.Name))
 	}
@@ -1334,6 +2075,15 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(HTTPPathPrefixFlag.Name) {
 		cfg.HTTPPathPrefix = ctx.String(HTTPPathPrefixFlag.Name)
 	}
+	if ctx.IsSet(HTTPTLSCertFlag.Name) {
+		cfg.HTTPTLSCert = ctx.String(HTTPTLSCertFlag.Name)
+	}
+	if ctx.IsSet(HTTPTLSKeyFlag.Name) {
+		cfg.HTTPTLSKey = ctx.String(HTTPTLSKeyFlag.Name)
+	}
+	if ctx.IsSet(HTTPH2CFlag.Name) {
+		cfg.HTTPH2C = ctx.Bool(HTTPH2CFlag.Name)
+	}
 	if ctx.IsSet(AllowUnprotectedTxs.Name) {
 		cfg.AllowUnprotectedTxs = ctx.Bool(AllowUnprotectedTxs.Name)
 	}
@@ -1384,6 +2134,14 @@ func setWS(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(WSPathPrefixFlag.Name) {
 		cfg.WSPathPrefix = ctx.String(WSPathPrefixFlag.Name)
 	}
+
+	if ctx.IsSet(WSSubscriptionBufferFlag.Name) {
+		cfg.WSSubscriptionBuffer = ctx.Int(WSSubscriptionBufferFlag.Name)
+	}
+
+	if ctx.IsSet(WSSubscriptionPolicyFlag.Name) {
+		cfg.WSSubscriptionPolicy = ctx.String(WSSubscriptionPolicyFlag.Name)
+	}
 }
 
 // setIPC creates an IPC path configuration from the set command line flags,
@@ -1396,6 +2154,9 @@ func setIPC(ctx *cli.Context, cfg *node.Config) {
 	case ctx.IsSet(IPCPathFlag.Name):
 		cfg.IPCPath = ctx.String(IPCPathFlag.Name)
 	}
+	if ctx.IsSet(IPCPermissionsFlag.Name) {
+		cfg.IPCPermissions = ctx.String(IPCPermissionsFlag.Name)
+	}
 }
 
 // setLes shows the deprecation warnings for LES flags.
@@ -1467,6 +2228,21 @@ func setEtherbase(ctx *cli.Context, cfg *ethconfig.Config) {
 	cfg.Miner.PendingFeeRecipient = common.BytesToAddress(b)
 }
 
+// parseProtocolBandwidthCaps parses the per-protocol bandwidth caps of the given
+// flag, converting them from KB/s to bytes per second.
+func parseProtocolBandwidthCaps(ctx *cli.Context, flag *cli.StringFlag) map[string]int {
+	caps := make(map[string]int)
+	for _, entry := range SplitAndTrim(ctx.String(flag.Name)) {
+		name, limit, ok := strings.Cut(entry, "=")
+		kb, err := strconv.Atoi(limit)
+		if !ok || err != nil || kb < 0 {
+			Fatalf("Option %s: invalid protocol cap %q", flag.Name, entry)
+		}
+		caps[name] = kb * 1024
+	}
+	return caps
+}
+
 func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 	setNodeKey(ctx, cfg)
 	setNAT(ctx, cfg)
@@ -1483,6 +2259,18 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 	if ctx.IsSet(MaxPendingPeersFlag.Name) {
 		cfg.MaxPendingPeers = ctx.Int(MaxPendingPeersFlag.Name)
 	}
+	if ctx.IsSet(BandwidthCapFlag.Name) {
+		cfg.BandwidthCap = ctx.Int(BandwidthCapFlag.Name) * 1024
+	}
+	if ctx.IsSet(ProtocolBandwidthCapsFlag.Name) {
+		cfg.ProtocolBandwidthCaps = parseProtocolBandwidthCaps(ctx, ProtocolBandwidthCapsFlag)
+	}
+	if ctx.IsSet(IngressBandwidthCapFlag.Name) {
+		cfg.IngressBandwidthCap = ctx.Int(IngressBandwidthCapFlag.Name) * 1024
+	}
+	if ctx.IsSet(IngressProtocolBandwidthCapsFlag.Name) {
+		cfg.IngressProtocolBandwidthCaps = parseProtocolBandwidthCaps(ctx, IngressProtocolBandwidthCapsFlag)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.NoDiscovery = true
 	}
@@ -1491,6 +2279,7 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 	flags.CheckExclusive(ctx, DiscoveryV5Flag, NoDiscoverFlag)
 	cfg.DiscoveryV4 = ctx.Bool(DiscoveryV4Flag.Name)
 	cfg.DiscoveryV5 = ctx.Bool(DiscoveryV5Flag.Name)
+	cfg.DiscoveryV5Topics = ctx.Bool(DiscoveryTopicFlag.Name)
 
 	if netrestrict := ctx.String(NetrestrictFlag.Name); netrestrict != "" {
 		list, err := netutil.ParseNetlist(netrestrict)
@@ -1524,6 +2313,37 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(JWTSecretFlag.Name) {
 		cfg.JWTSecret = ctx.String(JWTSecretFlag.Name)
 	}
+	if ctx.IsSet(AuthTLSCertFlag.Name) {
+		cfg.AuthTLSCert = ctx.String(AuthTLSCertFlag.Name)
+	}
+	if ctx.IsSet(AuthTLSKeyFlag.Name) {
+		cfg.AuthTLSKey = ctx.String(AuthTLSKeyFlag.Name)
+	}
+	if ctx.IsSet(AuthTLSClientCAFlag.Name) {
+		cfg.AuthTLSClientCA = ctx.String(AuthTLSClientCAFlag.Name)
+	}
+	if ctx.IsSet(AuthTLSClientPinsFlag.Name) {
+		cfg.AuthTLSClientPins = SplitAndTrim(ctx.String(AuthTLSClientPinsFlag.Name))
+	}
+	if ctx.IsSet(AuthTLSClientNamesFlag.Name) {
+		cfg.AuthTLSClientNames = SplitAndTrim(ctx.String(AuthTLSClientNamesFlag.Name))
+	}
+	if ctx.IsSet(AuthJWTDisabledFlag.Name) {
+		cfg.AuthJWTDisabled = ctx.Bool(AuthJWTDisabledFlag.Name)
+	}
+	if ctx.IsSet(JWTSecretsFlag.Name) {
+		cfg.JWTSecrets = make(map[string]string)
+		for _, entry := range SplitAndTrim(ctx.String(JWTSecretsFlag.Name)) {
+			id, path, ok := strings.Cut(entry, "=")
+			if !ok || id == "" || path == "" {
+				Fatalf("Invalid JWT secret %q, want id=path", entry)
+			}
+			cfg.JWTSecrets[id] = path
+		}
+	}
+	if ctx.IsSet(ShutdownDrainFlag.Name) {
+		cfg.ShutdownDrain = ctx.Duration(ShutdownDrainFlag.Name)
+	}
 	if ctx.IsSet(EnablePersonal.Name) {
 		log.Warn(fmt.Sprintf("Option --%s is deprecated. The 'personal' RPC namespace has been removed.", EnablePersonal.Name))
 	}
@@ -1622,6 +2442,31 @@ func setGPO(ctx *cli.Context, cfg *gasprice.Config) {
 	}
 }
 
+// setAddressPolicy applies the address policy flags to the config.
+func setAddressPolicy(ctx *cli.Context, cfg *ethconfig.Config) {
+	if ctx.IsSet(TxPoolAddressPolicyFlag.Name) {
+		switch mode := ctx.String(TxPoolAddressPolicyFlag.Name); mode {
+		case txpool.AddressPolicyBlock, txpool.AddressPolicyAllow:
+			cfg.AddressPolicy = mode
+		default:
+			Fatalf("Invalid --%s mode: %s", TxPoolAddressPolicyFlag.Name, mode)
+		}
+	}
+	if ctx.IsSet(TxPoolAddressPolicyListFlag.Name) {
+		cfg.AddressPolicyList = nil
+		for _, account := range strings.Split(ctx.String(TxPoolAddressPolicyListFlag.Name), ",") {
+			if trimmed := strings.TrimSpace(account); !common.IsHexAddress(trimmed) {
+				Fatalf("Invalid address in --%s: %s", TxPoolAddressPolicyListFlag.Name, trimmed)
+			} else {
+				cfg.AddressPolicyList = append(cfg.AddressPolicyList, common.HexToAddress(trimmed))
+			}
+		}
+	}
+	if ctx.IsSet(TxPoolAddressPolicyAuditFlag.Name) {
+		cfg.AddressPolicyAudit = ctx.String(TxPoolAddressPolicyAuditFlag.Name)
+	}
+}
+
 func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolLocalsFlag.Name) {
 		locals := strings.Split(ctx.String(TxPoolLocalsFlag.Name), ",")
@@ -1651,6 +2496,15 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolPriceBumpFlag.Name) {
 		cfg.PriceBump = ctx.Uint64(TxPoolPriceBumpFlag.Name)
 	}
+	if ctx.IsSet(TxPoolTipBumpFlag.Name) {
+		cfg.TipBump = ctx.Uint64(TxPoolTipBumpFlag.Name)
+	}
+	if ctx.IsSet(TxPoolConditionalPriceBumpFlag.Name) {
+		cfg.ConditionalPriceBump = ctx.Uint64(TxPoolConditionalPriceBumpFlag.Name)
+	}
+	if ctx.IsSet(TxPoolConditionalTipBumpFlag.Name) {
+		cfg.ConditionalTipBump = ctx.Uint64(TxPoolConditionalTipBumpFlag.Name)
+	}
 	if ctx.IsSet(TxPoolAccountSlotsFlag.Name) {
 		cfg.AccountSlots = ctx.Uint64(TxPoolAccountSlotsFlag.Name)
 	}
@@ -1666,6 +2520,32 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.Duration(TxPoolLifetimeFlag.Name)
 	}
+	if ctx.IsSet(TxPoolTenantSlotsFlag.Name) {
+		cfg.TenantSlots = ctx.Uint64(TxPoolTenantSlotsFlag.Name)
+	}
+	if ctx.IsSet(TxPoolTenantGasFlag.Name) {
+		cfg.TenantGas = ctx.Uint64(TxPoolTenantGasFlag.Name)
+	}
+	if ctx.IsSet(TxPoolRevertCheckFlag.Name) {
+		switch mode := ctx.String(TxPoolRevertCheckFlag.Name); mode {
+		case txpool.RevertCheckTag, txpool.RevertCheckReject:
+			cfg.RevertCheck = mode
+		default:
+			Fatalf("Invalid --txpool.revertcheck mode: %s", mode)
+		}
+	}
+	if ctx.IsSet(TxPoolRevertCheckGasFlag.Name) {
+		cfg.RevertCheckGas = ctx.Uint64(TxPoolRevertCheckGasFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSpamThresholdFlag.Name) {
+		cfg.SpamThreshold = ctx.Uint64(TxPoolSpamThresholdFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSpamTipStepFlag.Name) {
+		cfg.SpamTipStep = ctx.Uint64(TxPoolSpamTipStepFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSpamDecayFlag.Name) {
+		cfg.SpamDecay = ctx.Duration(TxPoolSpamDecayFlag.Name)
+	}
 	if ctx.IsSet(MinerEffectiveGasLimitFlag.Name) {
 		// While technically this is a miner config parameter, we also want the txpool to enforce
 		// it to avoid accepting transactions that can never be included in a block.
@@ -1683,6 +2563,15 @@ func setBlobPool(ctx *cli.Context, cfg *blobpool.Config) {
 	if ctx.IsSet(BlobPoolPriceBumpFlag.Name) {
 		cfg.PriceBump = ctx.Uint64(BlobPoolPriceBumpFlag.Name)
 	}
+	if ctx.IsSet(BlobPoolTipBumpFlag.Name) {
+		cfg.TipBump = ctx.Uint64(BlobPoolTipBumpFlag.Name)
+	}
+	if ctx.IsSet(BlobPoolProjectionFlag.Name) {
+		cfg.ProjectionBlocks = ctx.Uint64(BlobPoolProjectionFlag.Name)
+	}
+	if ctx.IsSet(BlobPoolAccountBlobsFlag.Name) {
+		cfg.MaxAccountBlobs = ctx.Uint64(BlobPoolAccountBlobsFlag.Name)
+	}
 }
 
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
@@ -1711,6 +2600,79 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(RollupComputePendingBlock.Name) {
 		cfg.RollupComputePendingBlock = ctx.Bool(RollupComputePendingBlock.Name)
 	}
+	if ctx.IsSet(MinerRecordPayloadsFlag.Name) {
+		cfg.RecordPayloads = ctx.Bool(MinerRecordPayloadsFlag.Name)
+	}
+	if ctx.IsSet(MinerRecordAccessListsFlag.Name) {
+		cfg.RecordAccessLists = ctx.Bool(MinerRecordAccessListsFlag.Name)
+	}
+	if ctx.IsSet(MinerCommitPolicyFlag.Name) {
+		cfg.CommitOrderingPolicy = ctx.Bool(MinerCommitPolicyFlag.Name)
+	}
+	if ctx.IsSet(MinerBuilderPayloadsFlag.Name) {
+		cfg.BuilderPayloads = ctx.Bool(MinerBuilderPayloadsFlag.Name)
+	}
+	if ctx.IsSet(MinerBuilderMinDeltaFlag.Name) || ctx.IsSet(MinerBuilderAllowFlag.Name) || ctx.IsSet(MinerBuilderInclusionFlag.Name) {
+		policy := &miner.BuilderPolicy{
+			MinDelta:  flags.GlobalBig(ctx, MinerBuilderMinDeltaFlag.Name),
+			Inclusion: ctx.Bool(MinerBuilderInclusionFlag.Name),
+		}
+		for _, id := range strings.Split(ctx.String(MinerBuilderAllowFlag.Name), ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				policy.Builders = append(policy.Builders, id)
+			}
+		}
+		if err := policy.Validate(); err != nil {
+			Fatalf("Invalid builder payload policy: %v", err)
+		}
+		cfg.BuilderPolicy = policy
+	}
+	if ctx.IsSet(MinerFeeRecipientsFlag.Name) {
+		schedule := &miner.FeeRecipientSchedule{Period: ctx.Uint64(MinerFeeRecipientsPeriodFlag.Name)}
+		for _, entry := range strings.Split(ctx.String(MinerFeeRecipientsFlag.Name), ",") {
+			addr, weight, found := strings.Cut(strings.TrimSpace(entry), ":")
+			if !common.IsHexAddress(addr) {
+				Fatalf("Invalid fee recipient address %q", addr)
+			}
+			share := miner.FeeRecipientShare{Address: common.HexToAddress(addr), Weight: 1}
+			if found {
+				w, err := strconv.ParseUint(weight, 10, 64)
+				if err != nil {
+					Fatalf("Invalid fee recipient weight %q: %v", weight, err)
+				}
+				share.Weight = w
+			}
+			schedule.Recipients = append(schedule.Recipients, share)
+		}
+		if err := schedule.Validate(); err != nil {
+			Fatalf("Invalid fee recipient schedule: %v", err)
+		}
+		cfg.FeeRecipients = schedule
+	}
+	if ctx.IsSet(MinerGasTuningMaxFlag.Name) || ctx.IsSet(MinerGasTuningMinFlag.Name) {
+		if !ctx.IsSet(MinerGasTuningMaxFlag.Name) || !ctx.IsSet(MinerGasTuningMinFlag.Name) {
+			Fatalf("Flags --%s and --%s must be set together", MinerGasTuningMinFlag.Name, MinerGasTuningMaxFlag.Name)
+		}
+		tuning := &miner.GasCeilTuning{
+			Min:           ctx.Uint64(MinerGasTuningMinFlag.Name),
+			Max:           ctx.Uint64(MinerGasTuningMaxFlag.Name),
+			TargetLatency: ctx.Duration(MinerGasTuningLatencyFlag.Name),
+		}
+		if err := tuning.Validate(); err != nil {
+			Fatalf("Invalid gas ceiling tuning: %v", err)
+		}
+		cfg.GasCeilTuning = tuning
+	}
+	if ctx.IsSet(MinerSealDelayFlag.Name) || ctx.IsSet(MinerSealJitterFlag.Name) {
+		timing := &miner.SealTiming{
+			Delay:  ctx.Duration(MinerSealDelayFlag.Name),
+			Jitter: ctx.Duration(MinerSealJitterFlag.Name),
+		}
+		if err := timing.Validate(); err != nil {
+			Fatalf("Invalid sealing timing: %v", err)
+		}
+		cfg.SealTiming = timing
+	}
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1751,8 +2713,33 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	setEtherbase(ctx, cfg)
 	setGPO(ctx, &cfg.GPO)
 	setTxPool(ctx, &cfg.TxPool)
+	setAddressPolicy(ctx, cfg)
 	setBlobPool(ctx, &cfg.BlobPool)
+	if ctx.IsSet(TxPoolReconcileFlag.Name) {
+		cfg.TxPoolReconcile = ctx.Duration(TxPoolReconcileFlag.Name)
+	}
+	if ctx.IsSet(TxFetchWaitFlag.Name) {
+		cfg.TxFetcher.ArriveTimeout = ctx.Duration(TxFetchWaitFlag.Name)
+	}
+	if ctx.IsSet(TxFetchBatchFlag.Name) {
+		cfg.TxFetcher.MaxRetrievals = ctx.Int(TxFetchBatchFlag.Name)
+	}
+	if ctx.IsSet(TxFetchBytesFlag.Name) {
+		cfg.TxFetcher.MaxRetrievalSize = ctx.Uint64(TxFetchBytesFlag.Name)
+	}
+	if ctx.IsSet(TxFetchRequestsFlag.Name) {
+		cfg.TxFetcher.MaxRequests = ctx.Int(TxFetchRequestsFlag.Name)
+	}
+	if ctx.IsSet(TxAnnounceSizeFlag.Name) {
+		cfg.TxAnnounceSize = ctx.Uint64(TxAnnounceSizeFlag.Name)
+	}
+	if ctx.IsSet(SlimBlocksFlag.Name) {
+		cfg.SlimBlocks = ctx.Bool(SlimBlocksFlag.Name)
+	}
 	setMiner(ctx, &cfg.Miner)
+	if ctx.IsSet(MinerStandbyFlag.Name) {
+		cfg.Standby = ctx.Bool(MinerStandbyFlag.Name)
+	}
 	setRequiredBlocks(ctx, cfg)
 	setLes(ctx, cfg)
 
@@ -1785,6 +2772,19 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		}
 	}
 
+	if ctx.IsSet(SyncCheckpointFlag.Name) {
+		cfg.SyncCheckpoint = new(ethconfig.SyncCheckpoint)
+		if err = cfg.SyncCheckpoint.UnmarshalText([]byte(ctx.String(SyncCheckpointFlag.Name))); err != nil {
+			Fatalf("--%v: %v", SyncCheckpointFlag.Name, err)
+		}
+	}
+	if ctx.IsSet(SyncPivotFlag.Name) {
+		cfg.SyncPivot = new(ethconfig.SyncPivot)
+		if err = cfg.SyncPivot.UnmarshalText([]byte(ctx.String(SyncPivotFlag.Name))); err != nil {
+			Fatalf("--%v: %v", SyncPivotFlag.Name, err)
+		}
+	}
+
 	if ctx.IsSet(ChainHistoryFlag.Name) {
 		value := ctx.String(ChainHistoryFlag.Name)
 		if err = cfg.HistoryMode.UnmarshalText([]byte(value)); err != nil {
@@ -1824,6 +2824,33 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(StateSchemeFlag.Name) {
 		cfg.StateScheme = ctx.String(StateSchemeFlag.Name)
 	}
+	if ctx.IsSet(StateHistoryCompressFlag.Name) {
+		cfg.StateHistoryCompress = ctx.Bool(StateHistoryCompressFlag.Name)
+	}
+	if ctx.IsSet(StateWriteAheadLogFlag.Name) {
+		cfg.StateWriteAheadLog = ctx.Bool(StateWriteAheadLogFlag.Name)
+	}
+	if ctx.IsSet(StateLegacyDirFlag.Name) {
+		cfg.LegacyStateDir = ctx.String(StateLegacyDirFlag.Name)
+	}
+	if ctx.IsSet(StateRentFlag.Name) {
+		cfg.StateRent = ctx.Bool(StateRentFlag.Name)
+	}
+	if ctx.IsSet(StateRentRateFlag.Name) {
+		cfg.StateRentConfig.Rate = ctx.Uint64(StateRentRateFlag.Name)
+	}
+	if ctx.IsSet(StateRentTouchFeeFlag.Name) {
+		cfg.StateRentConfig.TouchFee = ctx.Uint64(StateRentTouchFeeFlag.Name)
+	}
+	if ctx.IsSet(StateRentAccountSizeFlag.Name) {
+		cfg.StateRentConfig.AccountSize = ctx.Uint64(StateRentAccountSizeFlag.Name)
+	}
+	if ctx.IsSet(StateRentSlotSizeFlag.Name) {
+		cfg.StateRentConfig.SlotSize = ctx.Uint64(StateRentSlotSizeFlag.Name)
+	}
+	if ctx.IsSet(StateRentAccountsFlag.Name) {
+		cfg.StateRentConfig.MaxAccounts = ctx.Int(StateRentAccountsFlag.Name)
+	}
 	// Parse transaction history flag, if user is still using legacy config
 	// file with 'TxLookupLimit' configured, copy the value to 'TransactionHistory'.
 	if cfg.TransactionHistory == ethconfig.Defaults.TransactionHistory && cfg.TxLookupLimit != ethconfig.Defaults.TxLookupLimit {
@@ -1856,6 +2883,109 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(LogExportCheckpointsFlag.Name) {
 		cfg.LogExportCheckpoints = ctx.String(LogExportCheckpointsFlag.Name)
 	}
+	if ctx.IsSet(SenderIndexFlag.Name) {
+		cfg.SenderIndex = ctx.Bool(SenderIndexFlag.Name)
+	}
+	if ctx.IsSet(SenderIndexHistoryFlag.Name) {
+		cfg.SenderIndexHistory = ctx.Uint64(SenderIndexHistoryFlag.Name)
+	}
+	if ctx.IsSet(TransferIndexFlag.Name) {
+		cfg.TransferIndex = ctx.Bool(TransferIndexFlag.Name)
+	}
+	if ctx.IsSet(TransferIndexHistoryFlag.Name) {
+		cfg.TransferIndexHistory = ctx.Uint64(TransferIndexHistoryFlag.Name)
+	}
+	if ctx.IsSet(InternalTxIndexFlag.Name) {
+		cfg.InternalTxIndex = ctx.Bool(InternalTxIndexFlag.Name)
+	}
+	if ctx.IsSet(InternalTxIndexHistoryFlag.Name) {
+		cfg.InternalTxIndexHistory = ctx.Uint64(InternalTxIndexHistoryFlag.Name)
+	}
+	if ctx.IsSet(CreationIndexFlag.Name) {
+		cfg.CreationIndex = ctx.Bool(CreationIndexFlag.Name)
+	}
+	if ctx.IsSet(CodeChangeIndexFlag.Name) {
+		cfg.CodeChangeIndex = ctx.Bool(CodeChangeIndexFlag.Name)
+	}
+	if ctx.IsSet(CodeChangeIndexHistoryFlag.Name) {
+		cfg.CodeChangeIndexHistory = ctx.Uint64(CodeChangeIndexHistoryFlag.Name)
+	}
+	if ctx.IsSet(BalanceChangesFlag.Name) {
+		cfg.BalanceChanges = ctx.Bool(BalanceChangesFlag.Name)
+	}
+	if ctx.IsSet(SupplyFlag.Name) {
+		cfg.Supply = ctx.Bool(SupplyFlag.Name)
+	}
+	if ctx.IsSet(ChainAuditFlag.Name) {
+		cfg.ChainAudit = ctx.Bool(ChainAuditFlag.Name)
+	}
+	if ctx.IsSet(ChainAuditFromFlag.Name) {
+		cfg.ChainAuditConfig.From = ctx.Uint64(ChainAuditFromFlag.Name)
+	}
+	if ctx.IsSet(ChainAuditToFlag.Name) {
+		cfg.ChainAuditConfig.To = ctx.Uint64(ChainAuditToFlag.Name)
+	}
+	if ctx.IsSet(ChainAuditRateFlag.Name) {
+		cfg.ChainAuditConfig.Rate = ctx.Uint64(ChainAuditRateFlag.Name)
+	}
+	if ctx.IsSet(ChainAuditIntervalFlag.Name) {
+		cfg.ChainAuditConfig.Interval = ctx.Duration(ChainAuditIntervalFlag.Name)
+	}
+	if ctx.IsSet(HeadAttestFlag.Name) {
+		cfg.HeadAttest = ctx.Bool(HeadAttestFlag.Name)
+		cfg.HeadAttestConfig.Quorum = ctx.Int(HeadAttestQuorumFlag.Name)
+		for _, addr := range SplitAndTrim(ctx.String(HeadAttestSignersFlag.Name)) {
+			if !common.IsHexAddress(addr) {
+				Fatalf("Invalid attestation signer address: %q", addr)
+			}
+			cfg.HeadAttestConfig.Signers = append(cfg.HeadAttestConfig.Signers, common.HexToAddress(addr))
+		}
+	}
+	if ctx.IsSet(WatchdogFlag.Name) {
+		cfg.Watchdog = ctx.Bool(WatchdogFlag.Name)
+	}
+	if ctx.IsSet(WatchdogReferencesFlag.Name) {
+		cfg.WatchdogConfig.References = SplitAndTrim(ctx.String(WatchdogReferencesFlag.Name))
+	}
+	if ctx.IsSet(WatchdogMaxLagFlag.Name) {
+		cfg.WatchdogConfig.MaxLag = ctx.Uint64(WatchdogMaxLagFlag.Name)
+	}
+	if ctx.IsSet(WatchdogTimeoutFlag.Name) {
+		cfg.WatchdogConfig.Timeout = ctx.Duration(WatchdogTimeoutFlag.Name)
+	}
+	if ctx.IsSet(WatchdogIntervalFlag.Name) {
+		cfg.WatchdogConfig.Interval = ctx.Duration(WatchdogIntervalFlag.Name)
+	}
+	if ctx.IsSet(WatchdogRotateFlag.Name) {
+		cfg.WatchdogConfig.Rotate = ctx.Int(WatchdogRotateFlag.Name)
+	}
+	if ctx.IsSet(CrossCheckReferenceFlag.Name) {
+		cfg.CrossCheck = true
+		cfg.CrossCheckConfig.Reference = ctx.String(CrossCheckReferenceFlag.Name)
+	}
+	if ctx.IsSet(CrossCheckHaltFlag.Name) {
+		cfg.CrossCheckConfig.Halt = ctx.Bool(CrossCheckHaltFlag.Name)
+	}
+	if ctx.IsSet(CrossCheckIntervalFlag.Name) {
+		cfg.CrossCheckConfig.Interval = ctx.Duration(CrossCheckIntervalFlag.Name)
+	}
+	if ctx.IsSet(StateReaderFlag.Name) {
+		cfg.StateReader = ctx.Bool(StateReaderFlag.Name)
+	}
+	if ctx.IsSet(StateReaderIPCPathFlag.Name) {
+		cfg.StateReaderConfig.IPCPath = ctx.String(StateReaderIPCPathFlag.Name)
+	}
+	if cfg.StateReader {
+		// Resolve the endpoint the same way as the node IPC endpoint
+		endpoint := &node.Config{DataDir: stack.DataDir(), IPCPath: cfg.StateReaderConfig.IPCPath}
+		cfg.StateReaderConfig.IPCPath = endpoint.IPCEndpoint()
+	}
+	if ctx.IsSet(StateReaderRateLimitFlag.Name) {
+		cfg.StateReaderConfig.RateLimit = ctx.Int(StateReaderRateLimitFlag.Name)
+	}
+	if ctx.IsSet(ContractABISourcifyFlag.Name) {
+		cfg.ContractABISourcify = ctx.String(ContractABISourcifyFlag.Name)
+	}
 	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheTrieFlag.Name) {
 		cfg.TrieCleanCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheTrieFlag.Name) / 100
 	}
@@ -1883,10 +3013,29 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 			cfg.SnapshotCache = 0 // Disabled
 		}
 	}
+	if ctx.IsSet(CacheCodeFlag.Name) {
+		cfg.CodeCache = ctx.Int(CacheCodeFlag.Name)
+	}
+	if ctx.IsSet(CacheAdaptiveFlag.Name) {
+		cfg.AdaptiveCache = ctx.Bool(CacheAdaptiveFlag.Name)
+	}
+	if ctx.IsSet(MemoryLimitFlag.Name) {
+		cfg.MemoryLimit = ctx.Int(MemoryLimitFlag.Name)
+	}
 	if ctx.IsSet(VMEnableDebugFlag.Name) {
 		// TODO(fjl): force-enable this in --dev mode
 		cfg.EnablePreimageRecording = ctx.Bool(VMEnableDebugFlag.Name)
 	}
+	if ctx.IsSet(VMCodeAnalysisFlag.Name) {
+		cfg.VMCodeAnalysis = ctx.Bool(VMCodeAnalysisFlag.Name)
+	}
+	if ctx.IsSet(VMCodeAnalysisShadowFlag.Name) {
+		cfg.VMCodeAnalysisShadow = ctx.Bool(VMCodeAnalysisShadowFlag.Name)
+		if cfg.VMCodeAnalysisShadow && !cfg.VMCodeAnalysis {
+			log.Warn("Shadow execution requires the analyzed execution, enabling it", "flag", VMCodeAnalysisFlag.Name)
+			cfg.VMCodeAnalysis = true
+		}
+	}
 
 	if ctx.IsSet(RPCGlobalGasCapFlag.Name) {
 		cfg.RPCGasCap = ctx.Uint64(RPCGlobalGasCapFlag.Name)
@@ -1902,6 +3051,18 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.IsSet(RPCPrefetchLearnFlag.Name) {
+		cfg.RPCPrefetchLearn = ctx.Bool(RPCPrefetchLearnFlag.Name)
+	}
+	if ctx.IsSet(RPCReexecLimitFlag.Name) {
+		cfg.RPCReexecLimit = ctx.Int(RPCReexecLimitFlag.Name)
+	}
+	if ctx.IsSet(RPCReexecCacheFlag.Name) {
+		cfg.RPCReexecCache = ctx.Int(RPCReexecCacheFlag.Name)
+	}
+	if ctx.IsSet(RPCOverlaysFlag.Name) {
+		cfg.RPCOverlays = ctx.Int(RPCOverlaysFlag.Name)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.EthDiscoveryURLs, cfg.SnapDiscoveryURLs = []string{}, []string{}
 	} else if ctx.IsSet(DNSDiscoveryFlag.Name) {
@@ -1912,6 +3073,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 			cfg.EthDiscoveryURLs = SplitAndTrim(urls)
 		}
 	}
+	if ctx.IsSet(DiscoveryTopicFlag.Name) {
+		cfg.DiscoveryTopic = ctx.Bool(DiscoveryTopicFlag.Name)
+	}
 	// Only configure sequencer http flag if we're running in verifier mode i.e. --mine is disabled.
 	if ctx.IsSet(RollupSequencerHTTPFlag.Name) && !ctx.IsSet(MiningEnabledFlag.Name) {
 		cfg.RollupSequencerHTTP = ctx.String(RollupSequencerHTTPFlag.Name)
@@ -1928,6 +3092,16 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(RollupInteropMempoolFilteringFlag.Name) {
 		cfg.InteropMempoolFiltering = ctx.Bool(RollupInteropMempoolFilteringFlag.Name)
 	}
+	if ctx.IsSet(RollupInteropFailurePolicyFlag.Name) {
+		policy, err := interop.ParseFailurePolicy(ctx.String(RollupInteropFailurePolicyFlag.Name))
+		if err != nil {
+			Fatalf("%v", err)
+		}
+		cfg.InteropFailurePolicy = string(policy)
+	}
+	if ctx.IsSet(RollupInteropCacheTTLFlag.Name) {
+		cfg.InteropCacheTTL = ctx.Duration(RollupInteropCacheTTLFlag.Name)
+	}
 	cfg.RollupDisableTxPoolGossip = ctx.Bool(RollupDisableTxPoolGossipFlag.Name)
 	cfg.RollupDisableTxPoolAdmission = cfg.RollupSequencerHTTP != "" && !ctx.Bool(RollupEnableTxPoolAdmissionFlag.Name)
 	cfg.RollupHaltOnIncompatibleProtocolVersion = ctx.String(RollupHaltOnIncompatibleProtocolVersionFlag.Name)
@@ -2016,6 +3190,16 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 
 		// Create a new developer genesis block or reuse existing one
 		cfg.Genesis = core.DeveloperGenesisBlock(ctx.Uint64(DeveloperGasLimitFlag.Name), &developer.Address)
+		if path := ctx.String(DeveloperFixtureFlag.Name); path != "" {
+			fixture, err := core.LoadDevFixture(path)
+			if err != nil {
+				Fatalf("Failed to load developer fixture: %v", err)
+			}
+			if err := fixture.ApplyGenesis(cfg.Genesis.Alloc); err != nil {
+				Fatalf("Failed to apply developer fixture: %v", err)
+			}
+			log.Info("Seeded developer fixture", "path", path, "accounts", fixture.Accounts, "contracts", len(fixture.Contracts))
+		}
 		if ctx.IsSet(DataDirFlag.Name) {
 			chaindb := tryMakeReadOnlyDatabase(ctx, stack)
 			if rawdb.ReadCanonicalHash(chaindb, 0) != (common.Hash{}) {
@@ -2072,6 +3256,12 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 			cfg.VMTraceJsonConfig = ctx.String(VMTraceJsonConfigFlag.Name)
 		}
 	}
+	// Bad block artifacts are captured into the datadir unless overridden.
+	if ctx.IsSet(BadBlockDirFlag.Name) {
+		cfg.BadBlockArtifacts = ctx.String(BadBlockDirFlag.Name)
+	} else {
+		cfg.BadBlockArtifacts = stack.ResolvePath("badblocks")
+	}
 }
 
 // MakeBeaconLightConfig constructs a beacon light client config based on the
@@ -2191,12 +3381,61 @@ func RegisterEthService(stack *node.Node, cfg *ethconfig.Config) (*eth.EthAPIBac
 }
 
 // RegisterEthStatsService configures the Ethereum Stats daemon and adds it to the node.
-func RegisterEthStatsService(stack *node.Node, backend *eth.EthAPIBackend, url string) {
-	if err := ethstats.New(stack, backend, backend.Engine(), url); err != nil {
+func RegisterEthStatsService(stack *node.Node, backend *eth.EthAPIBackend, config ethstats.Config) {
+	if err := ethstats.New(stack, backend, backend.Engine(), config); err != nil {
 		Fatalf("Failed to register the Ethereum Stats service: %v", err)
 	}
 }
 
+// RegisterRemoteConfigService adds the remote configuration service to the node,
+// controlling the log levels, the RPC serving limits and the transaction
+// propagation policy. Settings given on the command line or in the config file
+// are local overrides, pinned to their local values.
+func RegisterRemoteConfigService(ctx *cli.Context, stack *node.Node, backend *eth.EthAPIBackend, ethereum *eth.Ethereum, cfg *ethconfig.Config, config remoteconfig.Config) {
+	var (
+		gascapSet     = ctx.IsSet(RPCGlobalGasCapFlag.Name) || cfg.RPCGasCap != ethconfig.Defaults.RPCGasCap
+		evmtimeoutSet = ctx.IsSet(RPCGlobalEVMTimeoutFlag.Name) || cfg.RPCEVMTimeout != ethconfig.Defaults.RPCEVMTimeout
+		gossipSet     = ctx.IsSet(RollupDisableTxPoolGossipFlag.Name) || cfg.RollupDisableTxPoolGossip
+	)
+	config.ChainID = ethereum.BlockChain().Config().ChainID.Uint64()
+	settings := []remoteconfig.Setting{
+		remoteconfig.NewSetting("log.verbosity", ctx.Int("verbosity"), ctx.IsSet("verbosity"), func(level int) error {
+			if level < 0 || level > 5 {
+				return fmt.Errorf("invalid verbosity %d", level)
+			}
+			debug.Handler.Verbosity(level)
+			return nil
+		}),
+		remoteconfig.NewSetting("log.vmodule", ctx.String("log.vmodule"), ctx.IsSet("log.vmodule"), debug.Handler.Vmodule),
+		remoteconfig.NewSetting("rpc.gascap", backend.RPCGasCap(), gascapSet, func(cap uint64) error {
+			backend.SetRPCGasCap(cap)
+			return nil
+		}),
+		remoteconfig.NewSetting("rpc.evmtimeout", backend.RPCEVMTimeout().String(), evmtimeoutSet, func(value string) error {
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			if timeout < 0 {
+				return fmt.Errorf("negative timeout %v", timeout)
+			}
+			backend.SetRPCEVMTimeout(timeout)
+			return nil
+		}),
+		remoteconfig.NewSetting("txpool.propagation", ethereum.TxPropagation().String(), gossipSet, func(name string) error {
+			policy, err := eth.ParseTxPropagation(name)
+			if err != nil {
+				return err
+			}
+			ethereum.SetTxPropagation(policy)
+			return nil
+		}),
+	}
+	if err := remoteconfig.New(stack, config, settings); err != nil {
+		Fatalf("Failed to register the remote configuration service: %v", err)
+	}
+}
+
 // RegisterGraphQLService adds the GraphQL API to the node.
 func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, cfg *node.Config) {
 	err := graphql.New(stack, backend, filterSystem, cfg.GraphQLCors, cfg.GraphQLVirtualHosts)
@@ -2205,6 +3444,26 @@ func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, filterSyst
 	}
 }
 
+// RegisterProofServer adds the authenticated proof server to the node.
+func RegisterProofServer(ctx *cli.Context, stack *node.Node, backend ethapi.Backend, cfg *node.Config) {
+	fileName := ctx.String(ProofServerJWTSecretFlag.Name)
+	if fileName == "" {
+		fileName = stack.ResolvePath("proofs.jwtsecret")
+	}
+	secret, err := node.ObtainJWTSecret(fileName)
+	if err != nil {
+		Fatalf("Failed to load the proof server JWT secret: %v", err)
+	}
+	err = proofserver.New(stack, backend, proofserver.Config{
+		JWTSecret: secret,
+		Cors:      cfg.HTTPCors,
+		Vhosts:    cfg.HTTPVirtualHosts,
+	})
+	if err != nil {
+		Fatalf("Failed to register the proof server: %v", err)
+	}
+}
+
 // RegisterFilterAPI adds the eth log filtering RPC API to the node.
 func RegisterFilterAPI(stack *node.Node, backend ethapi.Backend, ethcfg *ethconfig.Config) *filters.FilterSystem {
 	filterSystem := filters.NewFilterSystem(backend, filters.Config{
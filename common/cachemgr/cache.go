@@ -0,0 +1,190 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package cachemgr implements memory caches whose capacity can be changed at
+// runtime, and a manager partitioning a memory budget between them based on
+// their observed efficiency and the workload of the node.
+package cachemgr
+
+import (
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+
+	"github.com/VictoriaMetrics/fastcache"
+)
+
+// cacheStripes is the number of key stripes serializing the writes of a key
+// with its promotion from the cache replaced by the last resize.
+const cacheStripes = 64
+
+// Cache is a fastcache.Cache whose capacity can be changed at runtime. As the
+// capacity of a fastcache is fixed, resizing replaces it by an empty one. When
+// growing, the replaced cache is kept as a read-only fallback until the next
+// resize: the entries found in it are promoted to the new cache, so the hot
+// entries survive the resize. When shrinking, the memory is handed over to
+// another cache, so the replaced one is released at once along with its
+// entries.
+type Cache struct {
+	cur  *fastcache.Cache // Cache receiving the new entries
+	prev *fastcache.Cache // Cache replaced by the last resize, nil if none
+	size int              // Capacity of the current cache in bytes
+
+	lock    sync.RWMutex // Lock protecting the caches from being replaced
+	seed    maphash.Seed // Seed of the hash selecting the stripe of a key
+	stripes [cacheStripes]sync.Mutex
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewCache creates a cache holding up to the given number of bytes.
+func NewCache(size int) *Cache {
+	return &Cache{cur: fastcache.New(size), size: size, seed: maphash.MakeSeed()}
+}
+
+// stripe returns the lock serializing the writes of the given key.
+func (c *Cache) stripe(k []byte) *sync.Mutex {
+	return &c.stripes[maphash.Bytes(c.seed, k)%cacheStripes]
+}
+
+// HasGet appends the value of the key to dst, returning whether it was found.
+func (c *Cache) HasGet(dst, k []byte) ([]byte, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if v, ok := c.cur.HasGet(dst, k); ok {
+		c.hits.Add(1)
+		return v, true
+	}
+	if c.prev != nil {
+		// Promote the entry under the key lock, rechecking that a concurrent
+		// write did not invalidate it in the meantime.
+		lock := c.stripe(k)
+		lock.Lock()
+		v, ok := c.prev.HasGet(dst, k)
+		if ok {
+			c.cur.Set(k, v[len(dst):])
+		}
+		lock.Unlock()
+
+		if ok {
+			c.hits.Add(1)
+			return v, true
+		}
+	}
+	c.misses.Add(1)
+	return dst, false
+}
+
+// Get appends the value of the key to dst, or returns dst if not found.
+func (c *Cache) Get(dst, k []byte) []byte {
+	v, _ := c.HasGet(dst, k)
+	return v
+}
+
+// Set stores the key-value pair in the cache.
+func (c *Cache) Set(k, v []byte) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.prev == nil {
+		c.cur.Set(k, v)
+		return
+	}
+	lock := c.stripe(k)
+	lock.Lock()
+	c.cur.Set(k, v)
+	c.prev.Del(k)
+	lock.Unlock()
+}
+
+// Del deletes the value of the key from the cache.
+func (c *Cache) Del(k []byte) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.prev == nil {
+		c.cur.Del(k)
+		return
+	}
+	lock := c.stripe(k)
+	lock.Lock()
+	c.cur.Del(k)
+	c.prev.Del(k)
+	lock.Unlock()
+}
+
+// Reset removes all the entries from the cache, releasing its memory.
+func (c *Cache) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.cur.Reset()
+	if c.prev != nil {
+		c.prev.Reset()
+		c.prev = nil
+	}
+}
+
+// Resize changes the capacity of the cache to the given number of bytes. The
+// cache replaced by the previous resize is released, and so is the current one
+// if the capacity shrinks.
+func (c *Cache) Resize(size int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if size == c.size {
+		return
+	}
+	if c.prev != nil {
+		c.prev.Reset()
+		c.prev = nil
+	}
+	if size < c.size {
+		c.cur.Reset()
+	} else {
+		c.prev = c.cur
+	}
+	c.cur, c.size = fastcache.New(size), size
+}
+
+// Size returns the capacity of the cache in bytes.
+func (c *Cache) Size() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.size
+}
+
+// allocated returns the number of bytes held by the current and the replaced
+// caches.
+func (c *Cache) allocated() uint64 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var stats fastcache.Stats
+	c.cur.UpdateStats(&stats)
+	if c.prev != nil {
+		c.prev.UpdateStats(&stats)
+	}
+	return stats.BytesSize
+}
+
+// Stats returns the number of lookups which found and missed their key.
+func (c *Cache) Stats() (hits, misses uint64) {
+	return c.hits.Load(), c.misses.Load()
+}
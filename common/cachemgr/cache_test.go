@@ -0,0 +1,89 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package cachemgr
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Tests that the entries survive a resize through promotion, and that writes
+// after a resize invalidate the stale entries of the replaced cache.
+func TestCacheResize(t *testing.T) {
+	cache := NewCache(1024 * 1024)
+	cache.Set([]byte("a"), []byte("1"))
+	cache.Set([]byte("b"), []byte("2"))
+	cache.Set([]byte("c"), []byte("3"))
+
+	cache.Resize(2 * 1024 * 1024)
+	if size := cache.Size(); size != 2*1024*1024 {
+		t.Fatalf("size mismatch: have %d, want %d", size, 2*1024*1024)
+	}
+	if v, ok := cache.HasGet(nil, []byte("a")); !ok || !bytes.Equal(v, []byte("1")) {
+		t.Fatalf("entry lost by resize: have %q, %v", v, ok)
+	}
+	cache.Set([]byte("b"), []byte("4"))
+	cache.Del([]byte("c"))
+
+	// A second resize releases the replaced cache: only the promoted and the
+	// rewritten entries are left
+	cache.Resize(4 * 1024 * 1024)
+	if v := cache.Get([]byte("x"), []byte("a")); !bytes.Equal(v, []byte("x1")) {
+		t.Errorf("promoted entry mismatch: have %q, want %q", v, "x1")
+	}
+	if v := cache.Get(nil, []byte("b")); !bytes.Equal(v, []byte("4")) {
+		t.Errorf("rewritten entry mismatch: have %q, want %q", v, "4")
+	}
+	if _, ok := cache.HasGet(nil, []byte("c")); ok {
+		t.Error("deleted entry resurrected")
+	}
+	if hits, misses := cache.Stats(); hits != 3 || misses != 1 {
+		t.Errorf("stats mismatch: have %d/%d, want 3/1", hits, misses)
+	}
+	cache.Reset()
+	if _, ok := cache.HasGet(nil, []byte("a")); ok {
+		t.Error("entry survived reset")
+	}
+}
+
+// Tests that shrinking a cache releases the memory of the replaced one at once,
+// while growing keeps it as a fallback until the next resize.
+func TestCacheShrinkReleases(t *testing.T) {
+	cache := NewCache(64 * 1024 * 1024)
+	value := make([]byte, 1024)
+	for i := 0; i < 16*1024; i++ {
+		cache.Set([]byte{byte(i >> 8), byte(i)}, value)
+	}
+	held := cache.allocated()
+	if held < 16*1024*1024 {
+		t.Fatalf("cache not filled: %d bytes held", held)
+	}
+	cache.Resize(128 * 1024 * 1024)
+	if have := cache.allocated(); have != held {
+		t.Fatalf("grown cache memory mismatch: have %d, want %d", have, held)
+	}
+	if _, ok := cache.HasGet(nil, []byte{0, 1}); !ok {
+		t.Fatal("entry lost by growing resize")
+	}
+	cache.Resize(32 * 1024 * 1024)
+	if have := cache.allocated(); have >= held {
+		t.Fatalf("shrunk cache memory not released: have %d, was %d", have, held)
+	}
+	if _, ok := cache.HasGet(nil, []byte{0, 2}); ok {
+		t.Fatal("entry of released cache served")
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package cachemgr
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Workload is the kind of work a node is currently doing, which determines
+// which caches are the most valuable.
+type Workload int
+
+const (
+	WorkloadServe Workload = iota // Following the chain and serving requests
+	WorkloadSync                  // Importing the chain in bulk
+	WorkloadBuild                 // Building blocks
+)
+
+// String implements fmt.Stringer.
+func (w Workload) String() string {
+	switch w {
+	case WorkloadServe:
+		return "serve"
+	case WorkloadSync:
+		return "sync"
+	case WorkloadBuild:
+		return "build"
+	default:
+		return fmt.Sprintf("workload(%d)", int(w))
+	}
+}
+
+// Weights are the relative values of a cache in the different workloads.
+type Weights struct {
+	Serve float64
+	Sync  float64
+	Build float64
+}
+
+// of returns the weight of the cache in the given workload.
+func (w Weights) of(load Workload) float64 {
+	switch load {
+	case WorkloadSync:
+		return w.Sync
+	case WorkloadBuild:
+		return w.Build
+	default:
+		return w.Serve
+	}
+}
+
+// Partition is a cache whose memory allowance is managed by a Manager.
+type Partition interface {
+	// Resize changes the capacity of the cache to the given number of bytes.
+	Resize(size int)
+
+	// Stats returns the number of lookups which found and missed their key
+	// since the creation of the cache.
+	Stats() (hits, misses uint64)
+}
+
+// Config contains the settings of the cache manager.
+type Config struct {
+	Interval  time.Duration   // Interval between two reallocations of the budget
+	MinShare  float64         // Minimum share of the budget allocated to every cache
+	Threshold float64         // Minimum share of the budget moved by a reallocation
+	Workload  func() Workload // Classifier of the current workload, serving if nil
+}
+
+// DefaultConfig contains the default settings of the cache manager.
+var DefaultConfig = Config{
+	Interval:  time.Minute,
+	MinShare:  0.1,
+	Threshold: 0.05,
+}
+
+// partition is a cache registered in the manager.
+type partition struct {
+	name    string
+	cache   Partition
+	weights Weights
+	size    int
+
+	hits   uint64 // Lookup hits at the last reallocation
+	misses uint64 // Lookup misses at the last reallocation
+
+	sizeGauge    *metrics.Gauge
+	hitRateGauge *metrics.GaugeFloat64
+}
+
+// Manager periodically partitions a memory budget between a set of caches. The
+// budget is the sum of their initial sizes. Beyond a minimum share, each cache
+// is allocated memory in proportion of the lookups it missed since the last
+// reallocation, weighted by its value in the current workload. Allocations
+// move halfway to their target at every step, and only when a significant
+// share of the budget moves, as resizing a cache discards part of its content.
+type Manager struct {
+	config  Config
+	parts   []*partition
+	budget  int        // Memory currently partitioned between the caches
+	initial int        // Sum of the initial sizes of the caches
+	lock    sync.Mutex // Lock protecting the allocations
+
+	workloadGauge *metrics.Gauge
+	resizeMeter   *metrics.Meter
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewManager creates a cache manager with the given settings.
+func NewManager(config Config) *Manager {
+	if config.Interval <= 0 {
+		config.Interval = DefaultConfig.Interval
+	}
+	return &Manager{
+		config:        config,
+		workloadGauge: metrics.GetOrRegisterGauge("cache/adaptive/workload", nil),
+		resizeMeter:   metrics.GetOrRegisterMeter("cache/adaptive/resize", nil),
+		quit:          make(chan struct{}),
+	}
+}
+
+// Register adds a cache of the given initial size in bytes to the managed
+// ones, extending the budget by its size. It must be called before Start.
+func (m *Manager) Register(name string, cache Partition, size int, weights Weights) {
+	hits, misses := cache.Stats()
+	p := &partition{
+		name:         name,
+		cache:        cache,
+		weights:      weights,
+		size:         size,
+		hits:         hits,
+		misses:       misses,
+		sizeGauge:    metrics.GetOrRegisterGauge("cache/adaptive/"+name+"/size", nil),
+		hitRateGauge: metrics.GetOrRegisterGaugeFloat64("cache/adaptive/"+name+"/hitrate", nil),
+	}
+	p.sizeGauge.Update(int64(size))
+	m.parts = append(m.parts, p)
+	m.budget += size
+	m.initial += size
+}
+
+// Start starts the periodic reallocation of the budget.
+func (m *Manager) Start() {
+	m.wg.Add(1)
+	go m.loop()
+}
+
+// Stop terminates the periodic reallocation of the budget.
+func (m *Manager) Stop() {
+	close(m.quit)
+	m.wg.Wait()
+}
+
+func (m *Manager) loop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.rebalance()
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// Sizes returns the current allocation of every cache by name.
+func (m *Manager) Sizes() map[string]int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sizes := make(map[string]int, len(m.parts))
+	for _, p := range m.parts {
+		sizes[p.name] = p.size
+	}
+	return sizes
+}
+
+// Scale sets the budget to the given ratio of the sum of the initial sizes of
+// the caches, resizing them proportionally. It is meant to shrink the caches
+// under memory pressure, and to restore them afterwards.
+func (m *Manager) Scale(ratio float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	budget := int(float64(m.initial) * ratio)
+	if budget == m.budget || m.budget == 0 || len(m.parts) == 0 {
+		return
+	}
+	var sum int
+	for i, p := range m.parts {
+		size := budget - sum
+		if i < len(m.parts)-1 {
+			size = int(int64(p.size) * int64(budget) / int64(m.budget))
+			sum += size
+		}
+		p.cache.Resize(size)
+		p.size = size
+		p.sizeGauge.Update(int64(size))
+		m.resizeMeter.Mark(1)
+	}
+	log.Info("Scaled cache memory", "ratio", ratio, "budget", common.StorageSize(budget))
+	m.budget = budget
+}
+
+// rebalance reallocates the budget based on the lookups since the last call.
+func (m *Manager) rebalance() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if len(m.parts) < 2 {
+		return
+	}
+	load := WorkloadServe
+	if m.config.Workload != nil {
+		load = m.config.Workload()
+	}
+	m.workloadGauge.Update(int64(load))
+
+	// Score the caches by their weighted misses, falling back to the weights
+	// alone if the caches were idle
+	var (
+		scores = make([]float64, len(m.parts))
+		total  float64
+	)
+	for i, p := range m.parts {
+		hits, misses := p.cache.Stats()
+		dhits, dmisses := hits-p.hits, misses-p.misses
+		if hits < p.hits || misses < p.misses {
+			dhits, dmisses = hits, misses // Cache recreated, counters restarted
+		}
+		p.hits, p.misses = hits, misses
+
+		if dhits+dmisses > 0 {
+			p.hitRateGauge.Update(float64(dhits) / float64(dhits+dmisses))
+		}
+		scores[i] = p.weights.of(load) * float64(dmisses)
+		total += scores[i]
+	}
+	if total == 0 {
+		for i, p := range m.parts {
+			scores[i] = p.weights.of(load)
+			total += scores[i]
+		}
+		if total == 0 {
+			return
+		}
+	}
+	// Move the allocations halfway to their targets, the last cache absorbing
+	// the rounding errors
+	floor := int(float64(m.budget) * m.config.MinShare)
+	if floor*len(m.parts) > m.budget {
+		floor = m.budget / len(m.parts)
+	}
+	var (
+		spare   = m.budget - floor*len(m.parts)
+		targets = make([]int, len(m.parts))
+		sum     int
+		moved   int
+	)
+	for i, p := range m.parts {
+		target := floor + int(float64(spare)*scores[i]/total)
+		targets[i] = p.size + (target-p.size)/2
+		if i < len(m.parts)-1 {
+			sum += targets[i]
+		}
+	}
+	targets[len(targets)-1] = m.budget - sum
+
+	for i, p := range m.parts {
+		if targets[i] > p.size {
+			moved += targets[i] - p.size
+		}
+	}
+	if moved == 0 || moved < int(float64(m.budget)*m.config.Threshold) {
+		return
+	}
+	ctx := []interface{}{"workload", load}
+	for i, p := range m.parts {
+		if targets[i] != p.size {
+			p.cache.Resize(targets[i])
+			p.size = targets[i]
+			p.sizeGauge.Update(int64(p.size))
+			m.resizeMeter.Mark(1)
+		}
+		ctx = append(ctx, p.name, common.StorageSize(p.size))
+	}
+	log.Info("Reallocated cache memory", ctx...)
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package cachemgr
+
+import "testing"
+
+// testPartition is a cache with scripted lookup statistics.
+type testPartition struct {
+	size   int
+	hits   uint64
+	misses uint64
+}
+
+func (p *testPartition) Resize(size int)              { p.size = size }
+func (p *testPartition) Stats() (hits, misses uint64) { return p.hits, p.misses }
+
+// Tests that the budget moves towards the caches missing the most lookups,
+// weighted by the current workload, without starving any cache.
+func TestManagerRebalance(t *testing.T) {
+	var (
+		trie = &testPartition{size: 400}
+		snap = &testPartition{size: 400}
+		code = &testPartition{size: 200}
+		load = WorkloadServe
+	)
+	manager := NewManager(Config{MinShare: 0.1, Threshold: 0.05, Workload: func() Workload { return load }})
+	manager.Register("trie", trie, trie.size, Weights{Serve: 1, Sync: 1, Build: 1})
+	manager.Register("snapshot", snap, snap.size, Weights{Serve: 1, Sync: 1, Build: 1})
+	manager.Register("code", code, code.size, Weights{Serve: 1, Sync: 0, Build: 1})
+
+	// The code cache misses the most lookups while serving, it must grow
+	trie.hits, trie.misses = 1000, 10
+	snap.hits, snap.misses = 1000, 10
+	code.hits, code.misses = 1000, 980
+	manager.rebalance()
+	if code.size <= 200 || trie.size >= 400 || snap.size >= 400 {
+		t.Fatalf("allocation did not move towards the code cache: %v", manager.Sizes())
+	}
+	if total := trie.size + snap.size + code.size; total != 1000 {
+		t.Fatalf("budget not preserved: have %d, want 1000", total)
+	}
+	// Syncing makes the code cache worthless, but never below the minimum share
+	for i := 0; i < 10; i++ {
+		load = WorkloadSync
+		trie.misses += 100
+		snap.misses += 100
+		code.misses += 1000
+		manager.rebalance()
+	}
+	if code.size < 100 || code.size >= 200 {
+		t.Errorf("code cache size mismatch: have %d, want [100, 200)", code.size)
+	}
+	// Small changes must not trigger a resize
+	sizes := manager.Sizes()
+	trie.misses += 100
+	snap.misses += 101
+	manager.rebalance()
+	if trie.size != sizes["trie"] || snap.size != sizes["snapshot"] {
+		t.Errorf("insignificant change applied: have %v, want %v", manager.Sizes(), sizes)
+	}
+}
+
+// Tests that scaling the budget resizes the caches proportionally, and that
+// the rebalancing then partitions the scaled budget.
+func TestManagerScale(t *testing.T) {
+	var (
+		trie = &testPartition{size: 600}
+		code = &testPartition{size: 400}
+	)
+	manager := NewManager(DefaultConfig)
+	manager.Register("trie", trie, trie.size, Weights{Serve: 1})
+	manager.Register("code", code, code.size, Weights{Serve: 1})
+
+	manager.Scale(0.5)
+	if trie.size != 300 || code.size != 200 {
+		t.Fatalf("scaled sizes mismatch: have %d/%d, want 300/200", trie.size, code.size)
+	}
+	trie.misses = 1000
+	manager.rebalance()
+	if total := trie.size + code.size; total != 500 || trie.size <= 300 {
+		t.Fatalf("rebalanced sizes mismatch: have %d/%d", trie.size, code.size)
+	}
+	manager.Scale(1)
+	if total := trie.size + code.size; total != 1000 {
+		t.Fatalf("restored budget mismatch: have %d, want 1000", total)
+	}
+}
@@ -82,3 +82,19 @@ func (c *SizeConstrainedCache[K, V]) Get(key K) (V, bool) {
 
 	return c.lru.Get(key)
 }
+
+// SetMaxSize changes the capacity of the cache, evicting the oldest items until
+// the new size constraint is met.
+func (c *SizeConstrainedCache[K, V]) SetMaxSize(maxSize uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.maxSize = maxSize
+	for c.size > c.maxSize {
+		_, v, ok := c.lru.RemoveOldest()
+		if !ok {
+			break
+		}
+		c.size -= uint64(len(v))
+	}
+}
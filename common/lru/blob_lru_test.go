@@ -153,3 +153,26 @@ func TestSizeConstrainedCacheEmpties(t *testing.T) {
 		}
 	}
 }
+
+// This test checks that shrinking the cache evicts the oldest items.
+func TestSizeConstrainedCacheSetMaxSize(t *testing.T) {
+	lru := NewSizeConstrainedCache[testKey, []byte](100)
+	for i := 0; i < 10; i++ {
+		lru.Add(mkKey(i), []byte(fmt.Sprintf("value-%04d", i)))
+	}
+	lru.SetMaxSize(45)
+	if lru.size != 40 {
+		t.Fatalf("size wrong, have %d want 40", lru.size)
+	}
+	for i := 0; i < 10; i++ {
+		if _, ok := lru.Get(mkKey(i)); ok != (i >= 6) {
+			t.Fatalf("item %d: presence mismatch: have %v, want %v", i, ok, i >= 6)
+		}
+	}
+	// Growing the cache again must not evict anything
+	lru.SetMaxSize(100)
+	lru.Add(mkKey(10), []byte("value-0010"))
+	if lru.size != 50 {
+		t.Fatalf("size wrong, have %d want 50", lru.size)
+	}
+}
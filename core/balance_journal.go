@@ -0,0 +1,89 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// balanceJournalTracer collects the balance and nonce changes of a block as it
+// is processed. The changes made within a call frame are dropped again if the
+// frame reverts, so that only the changes persisted in the post state remain.
+type balanceJournalTracer struct {
+	*frameJournal[*types.BalanceChange]
+}
+
+// newBalanceJournalTracer creates a balance change collector, chained after the
+// given hooks if non-nil.
+func newBalanceJournalTracer(hooks *tracing.Hooks) (*balanceJournalTracer, *tracing.Hooks) {
+	journal, wrapped := newFrameJournal[*types.BalanceChange](hooks)
+	t := &balanceJournalTracer{journal}
+
+	var (
+		onBalanceChange = wrapped.OnBalanceChange
+		onNonceChange   = wrapped.OnNonceChange
+		onNonceChangeV2 = wrapped.OnNonceChangeV2
+	)
+	wrapped.OnBalanceChange = func(addr common.Address, prev, post *big.Int, reason tracing.BalanceChangeReason) {
+		t.recordChange(addr, false, prev, post, byte(reason))
+		if onBalanceChange != nil {
+			onBalanceChange(addr, prev, post, reason)
+		}
+	}
+	// The state only emits the nonce change with reason if the hook is set, so
+	// forward to whichever version the wrapped hooks implement.
+	wrapped.OnNonceChangeV2 = func(addr common.Address, prev, post uint64, reason tracing.NonceChangeReason) {
+		t.recordChange(addr, true, new(big.Int).SetUint64(prev), new(big.Int).SetUint64(post), byte(reason))
+		// The creator nonce is bumped within the creation frame, but before its
+		// snapshot is taken, so the change persists even if the frame reverts.
+		if reason == tracing.NonceChangeContractCreator {
+			t.keep()
+		}
+		if onNonceChangeV2 != nil {
+			onNonceChangeV2(addr, prev, post, reason)
+		} else if onNonceChange != nil {
+			onNonceChange(addr, prev, post)
+		}
+	}
+	return t, wrapped
+}
+
+// recordChange appends a balance or nonce change to the journal, unless it
+// leaves the value as it was.
+func (t *balanceJournalTracer) recordChange(addr common.Address, nonce bool, prev, post *big.Int, reason byte) {
+	if prev == nil {
+		prev = new(big.Int)
+	}
+	if post == nil {
+		post = new(big.Int)
+	}
+	if prev.Cmp(post) == 0 {
+		return
+	}
+	t.record(&types.BalanceChange{
+		TxIndex: t.txIndex(),
+		Address: addr,
+		Nonce:   nonce,
+		Prev:    new(big.Int).Set(prev),
+		New:     new(big.Int).Set(post),
+		Reason:  reason,
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestBalanceJournal(t *testing.T) {
+	var (
+		key, _    = crypto.GenerateKey()
+		addr      = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.Address{0xaa}
+		forwarder = common.Address{0xf0}
+		reverter  = common.Address{0xf1}
+		gspec     = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr:      {Balance: big.NewInt(params.Ether)},
+				forwarder: {Code: forwarderCode(recipient, false)},
+				reverter:  {Code: forwarderCode(recipient, true)},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = ethash.NewFaker()
+	)
+	// Forward value in block 1, forward and revert in block 2 and revert a
+	// contract creation in block 3, which still bumps the sender nonce
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 3, func(i int, gen *BlockGen) {
+		if i == 2 {
+			revert := []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.REVERT)}
+			tx, _ := types.SignTx(types.NewContractCreation(gen.TxNonce(addr), common.Big0, 100000, gen.BaseFee(), revert), signer, key)
+			gen.AddTx(tx)
+			return
+		}
+		to, value := forwarder, int64(5)
+		if i == 1 {
+			to, value = reverter, 7
+		}
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, big.NewInt(value), 100000, gen.BaseFee(), nil), signer, key)
+		gen.AddTx(tx)
+	})
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.BalanceChanges = true
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for i, block := range blocks {
+		changes := rawdb.ReadBalanceChanges(chain.db, block.Hash(), block.NumberU64())
+		if len(changes) == 0 {
+			t.Fatalf("block %d: no balance changes recorded", i+1)
+		}
+		// The last change of every account must match its post state
+		statedb, err := chain.StateAt(block.Root())
+		if err != nil {
+			t.Fatalf("block %d: failed to open state: %v", i+1, err)
+		}
+		var (
+			balances = make(map[common.Address]*big.Int)
+			nonces   = make(map[common.Address]uint64)
+			received bool
+		)
+		for _, change := range changes {
+			if change.Nonce {
+				nonces[change.Address] = change.New.Uint64()
+			} else {
+				balances[change.Address] = change.New
+			}
+			if change.Address == recipient {
+				received = true
+			}
+			if change.TxIndex != 0 && change.TxIndex != math.MaxUint32 {
+				t.Errorf("block %d: invalid transaction index %d", i+1, change.TxIndex)
+			}
+		}
+		for account, balance := range balances {
+			if have := statedb.GetBalance(account).ToBig(); have.Cmp(balance) != 0 {
+				t.Errorf("block %d: balance mismatch of %x: journal %v, state %v", i+1, account, balance, have)
+			}
+		}
+		if have := statedb.GetNonce(addr); nonces[addr] != have {
+			t.Errorf("block %d: nonce mismatch: journal %d, state %d", i+1, nonces[addr], have)
+		}
+		for account, nonce := range nonces {
+			if have := statedb.GetNonce(account); have != nonce {
+				t.Errorf("block %d: nonce mismatch of %x: journal %d, state %d", i+1, account, nonce, have)
+			}
+		}
+		// The reverted transfer must not show up in the journal
+		if want := i == 0; received != want {
+			t.Errorf("block %d: recipient change mismatch: have %v, want %v", i+1, received, want)
+		}
+		if change := changes[0]; change.Address != addr || change.Reason != byte(tracing.BalanceDecreaseGasBuy) {
+			t.Errorf("block %d: first change mismatch: %x reason %d", i+1, change.Address, change.Reason)
+		}
+	}
+}
@@ -32,11 +32,13 @@ import (
 //
 // BlockValidator implements Validator.
 type BlockValidator struct {
-	config *params.ChainConfig // Chain configuration options
+	config *params.ChainConfig // Chain configuration options, nil to follow the chain's
 	bc     *BlockChain         // Canonical block chain
 }
 
-// NewBlockValidator returns a new block validator which is safe for re-use
+// NewBlockValidator returns a new block validator which is safe for re-use. If
+// no config is given, the one of the block chain is used, picking up forks
+// scheduled at runtime.
 func NewBlockValidator(config *params.ChainConfig, blockchain *BlockChain) *BlockValidator {
 	validator := &BlockValidator{
 		config: config,
@@ -45,6 +47,14 @@ func NewBlockValidator(config *params.ChainConfig, blockchain *BlockChain) *Bloc
 	return validator
 }
 
+// chainConfig returns the chain configuration to validate blocks with.
+func (v *BlockValidator) chainConfig() *params.ChainConfig {
+	if v.config != nil {
+		return v.config
+	}
+	return v.bc.Config()
+}
+
 // ValidateBody validates the given block's uncles and verifies the block
 // header's transaction and uncle roots. The headers are assumed to be already
 // validated at this point.
@@ -73,7 +83,7 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 		if block.Withdrawals() == nil {
 			return errors.New("missing withdrawals in block body")
 		}
-		if v.config.IsOptimismIsthmus(header.Time) {
+		if v.chainConfig().IsOptimismIsthmus(header.Time) {
 			if len(block.Withdrawals()) > 0 {
 				return errors.New("no withdrawal block-operations allowed, withdrawalsRoot is set to storage root")
 			}
@@ -163,10 +173,11 @@ func (v *BlockValidator) ValidateState(block *types.Block, statedb *state.StateD
 	}
 	// Validate the state root against the received state root and throw
 	// an error if they don't match.
-	if root := statedb.IntermediateRoot(v.config.IsEIP158(header.Number)); header.Root != root {
+	config := v.chainConfig()
+	if root := statedb.IntermediateRoot(config.IsEIP158(header.Number)); header.Root != root {
 		return fmt.Errorf("invalid merkle root (remote: %x local: %x) dberr: %w", header.Root, root, statedb.Error())
 	}
-	if v.config.IsOptimismIsthmus(block.Time()) {
+	if config.IsOptimismIsthmus(block.Time()) {
 		if header.WithdrawalsHash == nil {
 			return errors.New("expected withdrawals root in OP-Stack post-Isthmus block header")
 		}
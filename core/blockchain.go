@@ -65,6 +65,8 @@ var (
 	headFinalizedBlockGauge = metrics.NewRegisteredGauge("chain/head/finalized", nil)
 	headSafeBlockGauge      = metrics.NewRegisteredGauge("chain/head/safe", nil)
 
+	chainPausedGauge = metrics.NewRegisteredGauge("chain/paused", nil)
+
 	chainInfoGauge = metrics.NewRegisteredGaugeInfo("chain/info", nil)
 
 	accountReadTimer   = metrics.NewRegisteredResettingTimer("chain/account/reads", nil)
@@ -95,10 +97,16 @@ var (
 	blockPrefetchExecuteTimer   = metrics.NewRegisteredTimer("chain/prefetch/executes", nil)
 	blockPrefetchInterruptMeter = metrics.NewRegisteredMeter("chain/prefetch/interrupts", nil)
 
+	badBlockDropMeter = metrics.NewRegisteredMeter("chain/badblocks/dropped", nil)
+
 	errInsertionInterrupted = errors.New("insertion is interrupted")
 	errChainStopped         = errors.New("blockchain is stopped")
 	errInvalidOldChain      = errors.New("invalid old chain")
 	errInvalidNewChain      = errors.New("invalid new chain")
+
+	// ErrChainPaused is returned when blocks are imported or the head, safe or
+	// finalized block is changed while the chain is paused for maintenance.
+	ErrChainPaused = errors.New("chain is paused")
 )
 
 var (
@@ -110,6 +118,7 @@ const (
 	blockCacheLimit    = 256
 	receiptsCacheLimit = 32
 	txLookupCacheLimit = 1024
+	badBlockQueueLimit = 16 // Bad block events waiting for delivery before dropping
 
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	//
@@ -149,15 +158,24 @@ const (
 // CacheConfig contains the configuration values for the trie database
 // and state snapshot these are resident in a blockchain.
 type CacheConfig struct {
-	TrieCleanLimit      int           // Memory allowance (MB) to use for caching trie nodes in memory
-	TrieCleanNoPrefetch bool          // Whether to disable heuristic state prefetching for followup blocks
-	TrieDirtyLimit      int           // Memory limit (MB) at which to start flushing dirty trie nodes to disk
-	TrieDirtyDisabled   bool          // Whether to disable trie write caching and GC altogether (archive node)
-	TrieTimeLimit       time.Duration // Time limit after which to flush the current in-memory trie to disk
-	SnapshotLimit       int           // Memory allowance (MB) to use for caching snapshot entries in memory
-	Preimages           bool          // Whether to store preimage of trie key to the disk
-	StateHistory        uint64        // Number of blocks from head whose state histories are reserved.
-	StateScheme         string        // Scheme used to store ethereum states and merkle tree nodes on top
+	TrieCleanLimit       int              // Memory allowance (MB) to use for caching trie nodes in memory
+	TrieCleanNoPrefetch  bool             // Whether to disable heuristic state prefetching for followup blocks
+	TrieDirtyLimit       int              // Memory limit (MB) at which to start flushing dirty trie nodes to disk
+	TrieDirtyDisabled    bool             // Whether to disable trie write caching and GC altogether (archive node)
+	TrieTimeLimit        time.Duration    // Time limit after which to flush the current in-memory trie to disk
+	SnapshotLimit        int              // Memory allowance (MB) to use for caching snapshot entries in memory
+	CodeCacheLimit       int              // Memory allowance (MB) to use for caching contract code in memory
+	Preimages            bool             // Whether to store preimage of trie key to the disk
+	StateHistory         uint64           // Number of blocks from head whose state histories are reserved.
+	StateScheme          string           // Scheme used to store ethereum states and merkle tree nodes on top
+	StateWriteAheadLog   bool             // Whether to record the unflushed state layers for crash recovery (path scheme only)
+	StateHistoryCompress bool             // Whether to write the state histories compressed (path scheme only)
+	StateRent            *StateRentConfig // Hypothetical state rent scheme to account, nil if disabled
+	InternalTxs          bool             // Whether to trace and store the internal transactions of the processed blocks
+	CreationSalts        bool             // Whether to also trace the salts of the CREATE2 internal transactions
+	BalanceChanges       bool             // Whether to trace and store the balance and nonce changes of the processed blocks
+	Supply               bool             // Whether to track the native token supply changes of the processed blocks
+	CodeChanges          bool             // Whether to trace and store the code changes of the processed blocks
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
@@ -183,6 +201,8 @@ func (c *CacheConfig) triedbConfig(isVerkle bool) *triedb.Config {
 			StateHistory:    c.StateHistory,
 			CleanCacheSize:  c.TrieCleanLimit * 1024 * 1024,
 			WriteBufferSize: c.TrieDirtyLimit * 1024 * 1024,
+			WriteAheadLog:   c.StateWriteAheadLog,
+			CompressHistory: c.StateHistoryCompress,
 		}
 	}
 	return config
@@ -195,6 +215,7 @@ var defaultCacheConfig = &CacheConfig{
 	TrieDirtyLimit: 256,
 	TrieTimeLimit:  5 * time.Minute,
 	SnapshotLimit:  256,
+	CodeCacheLimit: 64,
 	SnapshotWait:   true,
 	StateScheme:    rawdb.HashScheme,
 }
@@ -229,8 +250,8 @@ type txLookup struct {
 // included in the canonical one where as GetBlockByNumber always represents the
 // canonical chain.
 type BlockChain struct {
-	chainConfig *params.ChainConfig // Chain & network configuration
-	cacheConfig *CacheConfig        // Cache configuration for pruning
+	chainConfig atomic.Pointer[params.ChainConfig] // Chain & network configuration, swapped on runtime fork scheduling
+	cacheConfig *CacheConfig                       // Cache configuration for pruning
 
 	db            ethdb.Database                   // Low level persistent database to store final content in
 	snaps         *snapshot.Tree                   // Snapshot tree for fast trie leaf access
@@ -240,6 +261,7 @@ type BlockChain struct {
 	flushInterval atomic.Int64                     // Time interval (processing time) after which to flush a state
 	triedb        *triedb.Database                 // The database handler for maintaining trie nodes.
 	statedb       *state.CachingDB                 // State database to reuse between imports (contains state cache)
+	codeCache     *state.CodeCache                 // Contract code cache shared by all state databases of the chain
 	txIndexer     *txIndexer                       // Transaction indexer, might be nil if not enabled
 
 	hc               *HeaderChain
@@ -248,6 +270,8 @@ type BlockChain struct {
 	chainHeadFeed    event.Feed
 	logsFeed         event.Feed
 	blockProcFeed    event.Feed
+	badBlockFeed     event.Feed
+	badBlockCh       chan BadBlockEvent // Bad block events handed off to the feed
 	blockProcCounter int32
 	scope            event.SubscriptionScope
 	genesisBlock     *types.Block
@@ -270,9 +294,13 @@ type BlockChain struct {
 	txLookupLock  sync.RWMutex
 	txLookupCache *lru.Cache[common.Hash, txLookup]
 
+	blockStats *lru.Cache[common.Hash, *BlockStats] // Execution statistics of recently imported blocks
+	rentLedger *StateRentLedger                     // Hypothetical state rent ledger, nil if disabled
+
 	quit          chan struct{} // shutdown signal, closed in Stop.
 	stopping      atomic.Bool   // false if chain is running, true when stopped
 	procInterrupt atomic.Bool   // interrupt signaler for block processing
+	paused        atomic.Bool   // true if block import is paused for maintenance
 
 	engine     consensus.Engine
 	validator  Validator // Block and state validator interface
@@ -319,31 +347,41 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	}
 
 	bc := &BlockChain{
-		chainConfig:   chainConfig,
 		cacheConfig:   cacheConfig,
 		db:            db,
 		triedb:        triedb,
 		triegc:        prque.New[int64, common.Hash](nil),
 		quit:          make(chan struct{}),
+		badBlockCh:    make(chan BadBlockEvent, badBlockQueueLimit),
 		chainmu:       syncx.NewClosableMutex(),
 		bodyCache:     lru.NewCache[common.Hash, *types.Body](bodyCacheLimit),
 		bodyRLPCache:  lru.NewCache[common.Hash, rlp.RawValue](bodyCacheLimit),
 		receiptsCache: lru.NewCache[common.Hash, []*types.Receipt](receiptsCacheLimit),
 		blockCache:    lru.NewCache[common.Hash, *types.Block](blockCacheLimit),
 		txLookupCache: lru.NewCache[common.Hash, txLookup](txLookupCacheLimit),
+		blockStats:    lru.NewCache[common.Hash, *BlockStats](blockStatsLimit),
 		engine:        engine,
 		vmConfig:      vmConfig,
 		logger:        vmConfig.Tracer,
 	}
+	bc.chainConfig.Store(chainConfig)
 	bc.hc, err = NewHeaderChain(db, chainConfig, engine, bc.insertStopped)
 	if err != nil {
 		return nil, err
 	}
 	bc.flushInterval.Store(int64(cacheConfig.TrieTimeLimit))
-	bc.statedb = state.NewDatabase(bc.triedb, nil)
-	bc.validator = NewBlockValidator(chainConfig, bc)
-	bc.prefetcher = newStatePrefetcher(chainConfig, bc.hc)
-	bc.processor = NewStateProcessor(chainConfig, bc.hc)
+	if cacheConfig.StateRent != nil {
+		bc.rentLedger = NewStateRentLedger(*cacheConfig.StateRent)
+	}
+	codeCacheLimit := cacheConfig.CodeCacheLimit
+	if codeCacheLimit <= 0 {
+		codeCacheLimit = defaultCacheConfig.CodeCacheLimit
+	}
+	bc.codeCache = state.NewCodeCache(uint64(codeCacheLimit) * 1024 * 1024)
+	bc.statedb = state.NewDatabaseWithCodeCache(bc.triedb, nil, bc.codeCache)
+	bc.validator = NewBlockValidator(nil, bc)
+	bc.prefetcher = newStatePrefetcher(nil, bc.hc)
+	bc.processor = NewStateProcessor(nil, bc.hc)
 
 	genesisHeader := bc.GetHeaderByNumber(0)
 	if genesisHeader == nil {
@@ -357,7 +395,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	bc.currentSafeBlock.Store(nil)
 
 	// Update chain info data metrics
-	chainInfoGauge.Update(metrics.GaugeInfoValue{"chain_id": bc.chainConfig.ChainID.String()})
+	chainInfoGauge.Update(metrics.GaugeInfoValue{"chain_id": chainConfig.ChainID.String()})
 
 	// If Geth is initialized with an external ancient store, re-initialize the
 	// missing chain indexes and chain flags. This procedure can survive crash
@@ -477,7 +515,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		bc.snaps, _ = snapshot.New(snapconfig, bc.db, bc.triedb, head.Root)
 
 		// Re-initialize the state database with snapshot
-		bc.statedb = state.NewDatabase(bc.triedb, bc.snaps)
+		bc.statedb = state.NewDatabaseWithCodeCache(bc.triedb, bc.snaps, bc.codeCache)
 	}
 
 	// Rewind the chain in case of an incompatible config upgrade.
@@ -501,6 +539,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	if txLookupLimit != nil {
 		bc.txIndexer = newTxIndexer(*txLookupLimit, bc)
 	}
+	go bc.badBlockLoop()
 	return bc, nil
 }
 
@@ -709,8 +748,17 @@ func (bc *BlockChain) SetHeadWithTimestamp(timestamp uint64) error {
 	return nil
 }
 
-// SetFinalized sets the finalized block.
-func (bc *BlockChain) SetFinalized(header *types.Header) {
+// SetFinalized sets the finalized block. It fails while the chain is paused.
+func (bc *BlockChain) SetFinalized(header *types.Header) error {
+	if bc.paused.Load() {
+		return ErrChainPaused
+	}
+	bc.setFinalized(header)
+	return nil
+}
+
+// setFinalized sets the finalized block, regardless of the chain being paused.
+func (bc *BlockChain) setFinalized(header *types.Header) {
 	bc.currentFinalBlock.Store(header)
 	if header != nil {
 		rawdb.WriteFinalizedBlockHash(bc.db, header.Hash())
@@ -721,8 +769,17 @@ func (bc *BlockChain) SetFinalized(header *types.Header) {
 	}
 }
 
-// SetSafe sets the safe block.
-func (bc *BlockChain) SetSafe(header *types.Header) {
+// SetSafe sets the safe block. It fails while the chain is paused.
+func (bc *BlockChain) SetSafe(header *types.Header) error {
+	if bc.paused.Load() {
+		return ErrChainPaused
+	}
+	bc.setSafe(header)
+	return nil
+}
+
+// setSafe sets the safe block, regardless of the chain being paused.
+func (bc *BlockChain) setSafe(header *types.Header) {
 	bc.currentSafeBlock.Store(header)
 	if header != nil {
 		headSafeBlockGauge.Update(int64(header.Number.Uint64()))
@@ -915,6 +972,10 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, time uint64, root common.Ha
 	}
 	defer bc.chainmu.Unlock()
 
+	if bc.paused.Load() {
+		return 0, ErrChainPaused
+	}
+
 	var (
 		// Track the block number of the requested root hash
 		rootNumber uint64 // (no root == always 0)
@@ -1029,11 +1090,11 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, time uint64, root common.Ha
 	// Clear safe block, finalized block if needed
 	if safe := bc.CurrentSafeBlock(); safe != nil && head < safe.Number.Uint64() {
 		log.Warn("SetHead invalidated safe block")
-		bc.SetSafe(nil)
+		bc.setSafe(nil)
 	}
 	if finalized := bc.CurrentFinalBlock(); finalized != nil && head < finalized.Number.Uint64() {
 		log.Error("SetHead invalidated finalized block")
-		bc.SetFinalized(nil)
+		bc.setFinalized(nil)
 	}
 	return rootNumber, bc.loadLastState()
 }
@@ -1282,6 +1343,29 @@ func (bc *BlockChain) insertStopped() bool {
 	return bc.procInterrupt.Load()
 }
 
+// SetPaused pauses or resumes the import of blocks and the updates of the head,
+// leaving the chain readable at the frozen head. Pausing waits for the running
+// import, if any, to complete.
+func (bc *BlockChain) SetPaused(paused bool) error {
+	if !bc.chainmu.TryLock() {
+		return errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+
+	bc.paused.Store(paused)
+	if paused {
+		chainPausedGauge.Update(1)
+	} else {
+		chainPausedGauge.Update(0)
+	}
+	return nil
+}
+
+// Paused returns whether the import of blocks is paused.
+func (bc *BlockChain) Paused() bool {
+	return bc.paused.Load()
+}
+
 // WriteStatus status of write
 type WriteStatus byte
 
@@ -1511,6 +1595,19 @@ func (bc *BlockChain) writeKnownBlock(block *types.Block) error {
 	return nil
 }
 
+// txFees collects the fee breakdowns of the given receipts, or returns nil if
+// any of them is missing.
+func txFees(receipts []*types.Receipt) []*types.TxFees {
+	fees := make([]*types.TxFees, len(receipts))
+	for i, receipt := range receipts {
+		if receipt.Fees == nil {
+			return nil
+		}
+		fees[i] = receipt.Fees
+	}
+	return fees
+}
+
 // writeBlockWithState writes block, metadata and corresponding state data to the
 // database.
 func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, statedb *state.StateDB) error {
@@ -1524,12 +1621,15 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	blockBatch := bc.db.NewBatch()
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
+	if fees := txFees(receipts); fees != nil {
+		rawdb.WriteTxFees(blockBatch, block.Hash(), block.NumberU64(), fees)
+	}
 	rawdb.WritePreimages(blockBatch, statedb.Preimages())
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
 	}
 	// Commit all cached state changes into underlying memory database.
-	root, err := statedb.Commit(block.NumberU64(), bc.chainConfig.IsEIP158(block.Number()), bc.chainConfig.IsCancun(block.Number(), block.Time()))
+	root, err := statedb.Commit(block.NumberU64(), bc.Config().IsEIP158(block.Number()), bc.Config().IsCancun(block.Number(), block.Time()))
 	if err != nil {
 		return err
 	}
@@ -1657,6 +1757,9 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 	}
 	defer bc.chainmu.Unlock()
 
+	if bc.paused.Load() {
+		return 0, ErrChainPaused
+	}
 	_, n, err := bc.insertChain(chain, true, false) // No witness collection for mass inserts (would get super large)
 	return n, err
 }
@@ -1685,7 +1788,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 	}()
 
 	// Start a parallel signature recovery (signer will fluke on fork transition, minimal perf loss)
-	SenderCacher().RecoverFromBlocks(types.MakeSigner(bc.chainConfig, chain[0].Number(), chain[0].Time()), chain)
+	SenderCacher().RecoverFromBlocks(types.MakeSigner(bc.Config(), chain[0].Number(), chain[0].Time()), chain)
 
 	var (
 		stats     = insertStats{startTime: mclock.Now()}
@@ -1795,7 +1898,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 		// snapshot layer is missing, forcibly rerun the execution to build it.
 		if bc.skipBlock(err, it) {
 			logger := log.Debug
-			if bc.chainConfig.Clique == nil {
+			if bc.Config().Clique == nil {
 				logger = log.Warn
 			}
 			logger("Inserted known block", "number", block.Number(), "hash", block.Hash(),
@@ -1846,7 +1949,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 		// If we are past Byzantium, enable prefetching to pull in trie node paths
 		// while processing transactions. Before Byzantium the prefetcher is mostly
 		// useless due to the intermediate root hashing after each transaction.
-		if bc.chainConfig.IsByzantium(block.Number()) {
+		if bc.Config().IsByzantium(block.Number()) {
 			// Generate witnesses either if we're self-testing, or if it's the
 			// only block being inserted. A bit crude, but witnesses are huge,
 			// so we refuse to make an entire chain of them.
@@ -1963,9 +2066,25 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 		}()
 	}
 
-	// Process block using the parent state as reference point
+	// Process block using the parent state as reference point, collecting the
+	// internal transactions, balance and code changes along the way if requested
+	var (
+		vmConfig  = bc.vmConfig
+		collector *internalTxTracer
+		journal   *balanceJournalTracer
+		codes     *codeChangeTracer
+	)
+	if bc.cacheConfig.InternalTxs {
+		collector, vmConfig.Tracer = newInternalTxTracer(vmConfig.Tracer, bc.cacheConfig.CreationSalts)
+	}
+	if bc.cacheConfig.BalanceChanges || bc.cacheConfig.Supply {
+		journal, vmConfig.Tracer = newBalanceJournalTracer(vmConfig.Tracer)
+	}
+	if bc.cacheConfig.CodeChanges {
+		codes, vmConfig.Tracer = newCodeChangeTracer(vmConfig.Tracer)
+	}
 	pstart := time.Now()
-	res, err := bc.processor.Process(block, statedb, bc.vmConfig)
+	res, err := bc.processor.Process(block, statedb, vmConfig)
 	if err != nil {
 		bc.reportBlock(block, res, err)
 		return nil, err
@@ -1996,7 +2115,7 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 		task := types.NewBlockWithHeader(context).WithBody(*block.Body())
 
 		// Run the stateless self-cross-validation
-		crossStateRoot, crossReceiptRoot, err := ExecuteStateless(bc.chainConfig, bc.vmConfig, task, witness)
+		crossStateRoot, crossReceiptRoot, err := ExecuteStateless(bc.Config(), bc.vmConfig, task, witness)
 		if err != nil {
 			return nil, fmt.Errorf("stateless self-validation failed: %v", err)
 		}
@@ -2028,6 +2147,28 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 	blockValidationTimer.Update(vtime - (triehash + trieUpdate))                      // The time spent on block validation
 	blockCrossValidationTimer.Update(xvtime)                                          // The time spent on stateless cross validation
 
+	// Gather the execution statistics before the commit resets the counters
+	stats := newBlockStats(block, statedb, ptime, vtime)
+	if bc.rentLedger != nil {
+		bc.rentLedger.record(block.NumberU64(), statedb.AccessedState())
+	}
+	// Store the internal transactions, balance and code changes ahead of the
+	// block, a crash in between only leaves unreferenced data behind.
+	if collector != nil {
+		rawdb.WriteInternalTxs(bc.db, block.Hash(), block.NumberU64(), collector.changes)
+	}
+	if bc.cacheConfig.BalanceChanges {
+		rawdb.WriteBalanceChanges(bc.db, block.Hash(), block.NumberU64(), journal.changes)
+	}
+	if codes != nil {
+		rawdb.WriteCodeChanges(bc.db, block.Hash(), block.NumberU64(), codes.changes)
+	}
+	var supply *types.Supply
+	if bc.cacheConfig.Supply {
+		supply = blockSupply(bc.Config(), block.Header(), journal.changes, bc.parentSupply(block.Header()))
+		rawdb.WriteSupply(bc.db, block.Hash(), block.NumberU64(), supply)
+	}
+
 	// Write the block to the chain and get the status.
 	var (
 		wstart = time.Now()
@@ -2042,6 +2183,9 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 	if err != nil {
 		return nil, err
 	}
+	if supply != nil && status == CanonStatTy {
+		reportSupply(supply)
+	}
 	// Update the metrics touched during block commit
 	accountCommitTimer.Update(statedb.AccountCommits)   // Account commits are complete, we can mark them
 	storageCommitTimer.Update(statedb.StorageCommits)   // Storage commits are complete, we can mark them
@@ -2051,6 +2195,11 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 	blockWriteTimer.Update(time.Since(wstart) - max(statedb.AccountCommits, statedb.StorageCommits) /* concurrent */ - statedb.SnapshotCommits - statedb.TrieDBCommits)
 	blockInsertTimer.UpdateSince(start)
 
+	stats.Commit = time.Since(wstart)
+	stats.Total = time.Since(start)
+	stats.TrieNodesUpdated, stats.TrieNodesDeleted = statedb.TrieNodesUpdated, statedb.TrieNodesDeleted
+	bc.blockStats.Add(block.Hash(), stats)
+
 	return &blockProcessingResult{usedGas: res.GasUsed, procTime: proctime, status: status}, nil
 }
 
@@ -2217,10 +2366,10 @@ func (bc *BlockChain) recoverAncestors(block *types.Block, makeWitness bool) (co
 func (bc *BlockChain) collectLogs(b *types.Block, removed bool) []*types.Log {
 	var blobGasPrice *big.Int
 	if b.ExcessBlobGas() != nil {
-		blobGasPrice = eip4844.CalcBlobFee(bc.chainConfig, b.Header())
+		blobGasPrice = eip4844.CalcBlobFee(bc.Config(), b.Header())
 	}
 	receipts := rawdb.ReadRawReceipts(bc.db, b.Hash(), b.NumberU64())
-	if err := receipts.DeriveFields(bc.chainConfig, b.Hash(), b.NumberU64(), b.Time(), b.BaseFee(), blobGasPrice, b.Transactions()); err != nil {
+	if err := receipts.DeriveFields(bc.Config(), b.Hash(), b.NumberU64(), b.Time(), b.BaseFee(), blobGasPrice, b.Transactions()); err != nil {
 		log.Error("Failed to derive block receipts fields", "hash", b.Hash(), "number", b.NumberU64(), "err", err)
 	}
 	var logs []*types.Log
@@ -2431,6 +2580,9 @@ func (bc *BlockChain) InsertBlockWithoutSetHead(block *types.Block, makeWitness
 	}
 	defer bc.chainmu.Unlock()
 
+	if bc.paused.Load() {
+		return nil, ErrChainPaused
+	}
 	witness, _, err := bc.insertChain(types.Blocks{block}, false, makeWitness)
 	return witness, err
 }
@@ -2444,6 +2596,9 @@ func (bc *BlockChain) SetCanonical(head *types.Block) (common.Hash, error) {
 	}
 	defer bc.chainmu.Unlock()
 
+	if bc.paused.Load() {
+		return common.Hash{}, ErrChainPaused
+	}
 	// Re-execute the reorged chain in case the head state is missing.
 	if !bc.HasState(head.Root()) {
 		if latestValidHash, err := bc.recoverAncestors(head, false); err != nil {
@@ -2530,6 +2685,28 @@ func (bc *BlockChain) reportBlock(block *types.Block, res *ProcessResult, err er
 	}
 	rawdb.WriteBadBlock(bc.db, block)
 	log.Error(summarizeBadBlock(block, receipts, bc.Config(), err))
+
+	// Hand the event off without blocking the import, subscribers may take
+	// long to process it
+	select {
+	case bc.badBlockCh <- BadBlockEvent{Block: block, Receipts: receipts, Err: err}:
+	default:
+		badBlockDropMeter.Mark(1)
+		log.Warn("Dropped bad block event, subscribers lagging", "number", block.Number(), "hash", block.Hash())
+	}
+}
+
+// badBlockLoop delivers the reported bad blocks to the subscribers, decoupled
+// from the chain import.
+func (bc *BlockChain) badBlockLoop() {
+	for {
+		select {
+		case ev := <-bc.badBlockCh:
+			bc.badBlockFeed.Send(ev)
+		case <-bc.quit:
+			return
+		}
+	}
 }
 
 // logForkReadiness will write a log when a future fork is scheduled, but not
@@ -226,7 +226,7 @@ func (bc *BlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
 	if header == nil {
 		return nil
 	}
-	receipts := rawdb.ReadReceipts(bc.db, hash, *number, header.Time, bc.chainConfig)
+	receipts := rawdb.ReadReceipts(bc.db, hash, *number, header.Time, bc.Config())
 	if receipts == nil {
 		return nil
 	}
@@ -371,7 +371,7 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
 }
 
 // Config retrieves the chain's fork configuration.
-func (bc *BlockChain) Config() *params.ChainConfig { return bc.chainConfig }
+func (bc *BlockChain) Config() *params.ChainConfig { return bc.chainConfig.Load() }
 
 // Engine retrieves the blockchain's consensus engine.
 func (bc *BlockChain) Engine() consensus.Engine { return bc.engine }
@@ -396,6 +396,12 @@ func (bc *BlockChain) StateCache() state.Database {
 	return bc.statedb
 }
 
+// CodeCache returns the contract code cache shared by the state databases of
+// the chain.
+func (bc *BlockChain) CodeCache() *state.CodeCache {
+	return bc.codeCache
+}
+
 // GasLimit returns the gas limit of the current HEAD block.
 func (bc *BlockChain) GasLimit() uint64 {
 	return bc.CurrentBlock().GasLimit
@@ -459,6 +465,11 @@ func (bc *BlockChain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscript
 	return bc.scope.Track(bc.logsFeed.Subscribe(ch))
 }
 
+// SubscribeBadBlockEvent registers a subscription of BadBlockEvent.
+func (bc *BlockChain) SubscribeBadBlockEvent(ch chan<- BadBlockEvent) event.Subscription {
+	return bc.scope.Track(bc.badBlockFeed.Subscribe(ch))
+}
+
 // SubscribeBlockProcessingEvent registers a subscription of bool where true means
 // block processing has started while false means it has stopped.
 func (bc *BlockChain) SubscribeBlockProcessingEvent(ch chan<- bool) event.Subscription {
@@ -0,0 +1,124 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// blockStatsLimit is the number of recently imported blocks whose execution
+// statistics are retained in memory.
+const blockStatsLimit = 1024
+
+// BlockStats contains the execution statistics gathered while importing a
+// single block. All durations are measured in nanoseconds.
+type BlockStats struct {
+	Number  uint64      `json:"number"`
+	Hash    common.Hash `json:"hash"`
+	Txs     int         `json:"txs"`
+	GasUsed uint64      `json:"gasUsed"`
+
+	Execution  time.Duration `json:"execution"`  // Time spent on EVM execution, excluding state reads
+	StateRead  time.Duration `json:"stateRead"`  // Time spent on reading accounts and storage slots
+	StateHash  time.Duration `json:"stateHash"`  // Time spent on updating and hashing the tries
+	Validation time.Duration `json:"validation"` // Time spent on block validation, excluding trie hashing
+	Commit     time.Duration `json:"commit"`     // Time spent on committing the state and writing the block
+	Total      time.Duration `json:"total"`      // Total time spent on importing the block
+
+	AccountLoaded    int `json:"accountLoaded"`    // Number of accounts loaded from the database
+	AccountUpdated   int `json:"accountUpdated"`   // Number of accounts updated
+	AccountDeleted   int `json:"accountDeleted"`   // Number of accounts deleted
+	StorageLoaded    int `json:"storageLoaded"`    // Number of storage slots loaded from the database
+	StorageUpdated   int `json:"storageUpdated"`   // Number of storage slots updated
+	StorageDeleted   int `json:"storageDeleted"`   // Number of storage slots deleted
+	TrieNodesUpdated int `json:"trieNodesUpdated"` // Number of trie nodes written by the commit
+	TrieNodesDeleted int `json:"trieNodesDeleted"` // Number of trie nodes deleted by the commit
+
+	AccountFlatReads int64 `json:"accountFlatReads"` // Number of account reads served by the flat state
+	AccountTrieReads int64 `json:"accountTrieReads"` // Number of account reads served by the trie
+	StorageFlatReads int64 `json:"storageFlatReads"` // Number of storage reads served by the flat state
+	StorageTrieReads int64 `json:"storageTrieReads"` // Number of storage reads served by the trie
+	CodeCacheHits    int64 `json:"codeCacheHits"`    // Number of code reads served by the code cache
+	CodeCacheMisses  int64 `json:"codeCacheMisses"`  // Number of code reads served by the database
+
+	FlatHitRate float64 `json:"flatHitRate"` // Ratio of state reads served by the flat state
+	CodeHitRate float64 `json:"codeHitRate"` // Ratio of code reads served by the code cache
+}
+
+// newBlockStats assembles the execution statistics of a block from the state
+// gathered during processing and validation. It must be called before the
+// state is committed, as the commit resets the state access counters.
+func newBlockStats(block *types.Block, statedb *state.StateDB, ptime, vtime time.Duration) *BlockStats {
+	var (
+		reads   = statedb.AccountReads + statedb.StorageReads
+		hashing = statedb.AccountHashes + statedb.AccountUpdates + statedb.StorageUpdates
+		rstats  = statedb.ReaderStats()
+	)
+	stats := &BlockStats{
+		Number:           block.NumberU64(),
+		Hash:             block.Hash(),
+		Txs:              len(block.Transactions()),
+		GasUsed:          block.GasUsed(),
+		Execution:        ptime - reads,
+		StateRead:        reads,
+		StateHash:        hashing,
+		Validation:       vtime - hashing,
+		AccountLoaded:    statedb.AccountLoaded,
+		AccountUpdated:   statedb.AccountUpdated,
+		AccountDeleted:   statedb.AccountDeleted,
+		StorageLoaded:    statedb.StorageLoaded,
+		StorageUpdated:   int(statedb.StorageUpdated.Load()),
+		StorageDeleted:   int(statedb.StorageDeleted.Load()),
+		AccountFlatReads: rstats.AccountFlat,
+		AccountTrieReads: rstats.AccountTrie,
+		StorageFlatReads: rstats.StorageFlat,
+		StorageTrieReads: rstats.StorageTrie,
+		CodeCacheHits:    rstats.CodeHit,
+		CodeCacheMisses:  rstats.CodeMiss,
+	}
+	if total := rstats.AccountFlat + rstats.AccountTrie + rstats.StorageFlat + rstats.StorageTrie; total > 0 {
+		stats.FlatHitRate = float64(rstats.AccountFlat+rstats.StorageFlat) / float64(total)
+	}
+	if total := rstats.CodeHit + rstats.CodeMiss; total > 0 {
+		stats.CodeHitRate = float64(rstats.CodeHit) / float64(total)
+	}
+	return stats
+}
+
+// BlockStats retrieves the execution statistics of the canonical blocks in the
+// given inclusive range. Blocks whose statistics are not retained, e.g. because
+// they were imported before the last restart or evicted, are skipped.
+func (bc *BlockChain) BlockStats(from, to uint64) []*BlockStats {
+	var stats []*BlockStats
+	for number := from; number <= to; number++ {
+		hash := bc.GetCanonicalHash(number)
+		if hash == (common.Hash{}) {
+			break
+		}
+		if s, ok := bc.blockStats.Get(hash); ok {
+			stats = append(stats, s)
+		}
+		if number == to { // avoid overflowing on the upper bound
+			break
+		}
+	}
+	return stats
+}
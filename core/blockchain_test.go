@@ -115,12 +115,12 @@ func testFork(t *testing.T, blockchain *BlockChain, i, n int, full bool, scheme
 		headerChainB []*types.Header
 	)
 	if full {
-		blockChainB = makeBlockChain(blockchain2.chainConfig, blockchain2.GetBlockByHash(blockchain2.CurrentBlock().Hash()), n, ethash.NewFaker(), genDb, forkSeed)
+		blockChainB = makeBlockChain(blockchain2.Config(), blockchain2.GetBlockByHash(blockchain2.CurrentBlock().Hash()), n, ethash.NewFaker(), genDb, forkSeed)
 		if _, err := blockchain2.InsertChain(blockChainB); err != nil {
 			t.Fatalf("failed to insert forking chain: %v", err)
 		}
 	} else {
-		headerChainB = makeHeaderChain(blockchain2.chainConfig, blockchain2.CurrentHeader(), n, ethash.NewFaker(), genDb, forkSeed)
+		headerChainB = makeHeaderChain(blockchain2.Config(), blockchain2.CurrentHeader(), n, ethash.NewFaker(), genDb, forkSeed)
 		if _, err := blockchain2.InsertHeaderChain(headerChainB); err != nil {
 			t.Fatalf("failed to insert forking chain: %v", err)
 		}
@@ -202,7 +202,7 @@ func testLastBlock(t *testing.T, scheme string) {
 	}
 	defer blockchain.Stop()
 
-	blocks := makeBlockChain(blockchain.chainConfig, blockchain.GetBlockByHash(blockchain.CurrentBlock().Hash()), 1, ethash.NewFullFaker(), genDb, 0)
+	blocks := makeBlockChain(blockchain.Config(), blockchain.GetBlockByHash(blockchain.CurrentBlock().Hash()), 1, ethash.NewFullFaker(), genDb, 0)
 	if _, err := blockchain.InsertChain(blocks); err != nil {
 		t.Fatalf("Failed to insert block: %v", err)
 	}
@@ -236,7 +236,7 @@ func testInsertAfterMerge(t *testing.T, blockchain *BlockChain, i, n int, full b
 
 	// Extend the newly created chain
 	if full {
-		blockChainB := makeBlockChain(blockchain2.chainConfig, blockchain2.GetBlockByHash(blockchain2.CurrentBlock().Hash()), n, ethash.NewFaker(), genDb, forkSeed)
+		blockChainB := makeBlockChain(blockchain2.Config(), blockchain2.GetBlockByHash(blockchain2.CurrentBlock().Hash()), n, ethash.NewFaker(), genDb, forkSeed)
 		if _, err := blockchain2.InsertChain(blockChainB); err != nil {
 			t.Fatalf("failed to insert forking chain: %v", err)
 		}
@@ -247,7 +247,7 @@ func testInsertAfterMerge(t *testing.T, blockchain *BlockChain, i, n int, full b
 			t.Fatalf("failed to reorg to the given chain")
 		}
 	} else {
-		headerChainB := makeHeaderChain(blockchain2.chainConfig, blockchain2.CurrentHeader(), n, ethash.NewFaker(), genDb, forkSeed)
+		headerChainB := makeHeaderChain(blockchain2.Config(), blockchain2.CurrentHeader(), n, ethash.NewFaker(), genDb, forkSeed)
 		if _, err := blockchain2.InsertHeaderChain(headerChainB); err != nil {
 			t.Fatalf("failed to insert forking chain: %v", err)
 		}
@@ -509,12 +509,12 @@ func testBrokenChain(t *testing.T, full bool, scheme string) {
 
 	// Create a forked chain, and try to insert with a missing link
 	if full {
-		chain := makeBlockChain(blockchain.chainConfig, blockchain.GetBlockByHash(blockchain.CurrentBlock().Hash()), 5, ethash.NewFaker(), genDb, forkSeed)[1:]
+		chain := makeBlockChain(blockchain.Config(), blockchain.GetBlockByHash(blockchain.CurrentBlock().Hash()), 5, ethash.NewFaker(), genDb, forkSeed)[1:]
 		if err := testBlockChainImport(chain, blockchain); err == nil {
 			t.Errorf("broken block chain not reported")
 		}
 	} else {
-		chain := makeHeaderChain(blockchain.chainConfig, blockchain.CurrentHeader(), 5, ethash.NewFaker(), genDb, forkSeed)[1:]
+		chain := makeHeaderChain(blockchain.Config(), blockchain.CurrentHeader(), 5, ethash.NewFaker(), genDb, forkSeed)[1:]
 		if err := testHeaderChainImport(chain, blockchain); err == nil {
 			t.Errorf("broken header chain not reported")
 		}
@@ -644,7 +644,7 @@ func testInsertNonceError(t *testing.T, full bool, scheme string) {
 			failNum uint64
 		)
 		if full {
-			blocks := makeBlockChain(blockchain.chainConfig, blockchain.GetBlockByHash(blockchain.CurrentBlock().Hash()), i, ethash.NewFaker(), genDb, 0)
+			blocks := makeBlockChain(blockchain.Config(), blockchain.GetBlockByHash(blockchain.CurrentBlock().Hash()), i, ethash.NewFaker(), genDb, 0)
 
 			failAt = rand.Int() % len(blocks)
 			failNum = blocks[failAt].NumberU64()
@@ -652,7 +652,7 @@ func testInsertNonceError(t *testing.T, full bool, scheme string) {
 			blockchain.engine = ethash.NewFakeFailer(failNum)
 			failRes, err = blockchain.InsertChain(blocks)
 		} else {
-			headers := makeHeaderChain(blockchain.chainConfig, blockchain.CurrentHeader(), i, ethash.NewFaker(), genDb, 0)
+			headers := makeHeaderChain(blockchain.Config(), blockchain.CurrentHeader(), i, ethash.NewFaker(), genDb, 0)
 
 			failAt = rand.Int() % len(headers)
 			failNum = headers[failAt].Number.Uint64()
@@ -4390,3 +4390,160 @@ func testInsertChainWithCutoff(t *testing.T, cutoff uint64, ancientLimit uint64,
 		}
 	}
 }
+
+func TestBlockStats(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0de")
+		gspec    = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Ether)},
+				// Increments storage slot 0: PUSH1 0 SLOAD PUSH1 1 ADD PUSH1 0 SSTORE
+				contract: {Code: common.FromHex("0x6000546001016000556000")},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, func(i int, b *BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &contract,
+			Gas:      50000,
+			GasPrice: b.header.BaseFee,
+		}))
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	stats := chain.BlockStats(0, 10)
+	if len(stats) != len(blocks) {
+		t.Fatalf("wrong number of block stats: have %d, want %d", len(stats), len(blocks))
+	}
+	for i, s := range stats {
+		block := blocks[i]
+		if s.Number != block.NumberU64() || s.Hash != block.Hash() {
+			t.Fatalf("block %d: wrong block: have %d/%x, want %d/%x", i, s.Number, s.Hash, block.NumberU64(), block.Hash())
+		}
+		if s.Txs != 1 || s.GasUsed != block.GasUsed() {
+			t.Errorf("block %d: wrong txs/gas: have %d/%d, want 1/%d", i, s.Txs, s.GasUsed, block.GasUsed())
+		}
+		if s.AccountLoaded == 0 || s.StorageLoaded != 1 || s.StorageUpdated != 1 {
+			t.Errorf("block %d: wrong state access: accounts %d, slots loaded %d, slots updated %d", i, s.AccountLoaded, s.StorageLoaded, s.StorageUpdated)
+		}
+		if s.AccountFlatReads+s.AccountTrieReads != int64(s.AccountLoaded) {
+			t.Errorf("block %d: account reads mismatch: flat %d, trie %d, loaded %d", i, s.AccountFlatReads, s.AccountTrieReads, s.AccountLoaded)
+		}
+		if s.CodeCacheHits+s.CodeCacheMisses == 0 {
+			t.Errorf("block %d: no code reads recorded", i)
+		}
+		if s.TrieNodesUpdated == 0 || s.Total == 0 || s.Total < s.Commit {
+			t.Errorf("block %d: wrong commit stats: nodes %d, total %v, commit %v", i, s.TrieNodesUpdated, s.Total, s.Commit)
+		}
+	}
+	if stats := chain.BlockStats(2, 2); len(stats) != 1 || stats[0].Number != 2 {
+		t.Fatalf("wrong single block stats: %v", stats)
+	}
+}
+
+func TestPausedChain(t *testing.T) {
+	gspec := &Genesis{Config: params.TestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 4, nil)
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks[:2]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if err := chain.SetPaused(true); err != nil {
+		t.Fatalf("failed to pause chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks[2:]); !errors.Is(err, ErrChainPaused) {
+		t.Fatalf("unexpected insert error: have %v, want %v", err, ErrChainPaused)
+	}
+	if _, err := chain.InsertBlockWithoutSetHead(blocks[2], false); !errors.Is(err, ErrChainPaused) {
+		t.Fatalf("unexpected insert error: have %v, want %v", err, ErrChainPaused)
+	}
+	if _, err := chain.SetCanonical(blocks[0]); !errors.Is(err, ErrChainPaused) {
+		t.Fatalf("unexpected set head error: have %v, want %v", err, ErrChainPaused)
+	}
+	if err := chain.SetHead(0); !errors.Is(err, ErrChainPaused) {
+		t.Fatalf("unexpected rewind error: have %v, want %v", err, ErrChainPaused)
+	}
+	if err := chain.SetHeadWithTimestamp(0); !errors.Is(err, ErrChainPaused) {
+		t.Fatalf("unexpected rewind error: have %v, want %v", err, ErrChainPaused)
+	}
+	if err := chain.SetFinalized(blocks[0].Header()); !errors.Is(err, ErrChainPaused) {
+		t.Fatalf("unexpected set finalized error: have %v, want %v", err, ErrChainPaused)
+	}
+	if err := chain.SetSafe(blocks[0].Header()); !errors.Is(err, ErrChainPaused) {
+		t.Fatalf("unexpected set safe error: have %v, want %v", err, ErrChainPaused)
+	}
+	if chain.CurrentFinalBlock() != nil || chain.CurrentSafeBlock() != nil {
+		t.Fatal("finalized or safe block updated while paused")
+	}
+	// The chain stays readable at the frozen head
+	if head := chain.CurrentBlock(); head.Hash() != blocks[1].Hash() {
+		t.Fatalf("wrong head: have %d, want %d", head.Number, blocks[1].Number())
+	}
+	if err := chain.SetPaused(false); err != nil {
+		t.Fatalf("failed to resume chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks[2:]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if head := chain.CurrentBlock(); head.Hash() != blocks[3].Hash() {
+		t.Fatalf("wrong head: have %d, want %d", head.Number, blocks[3].Number())
+	}
+}
+
+// Tests that reporting bad blocks never blocks on lagging subscribers, and that
+// the events overflowing the queue are dropped.
+func TestBadBlockHandoff(t *testing.T) {
+	gspec := &Genesis{Config: params.TestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 1, nil)
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	events := make(chan BadBlockEvent) // never read until the end
+	sub := chain.SubscribeBadBlockEvent(events)
+	defer sub.Unsubscribe()
+
+	dropped := badBlockDropMeter.Snapshot().Count()
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*badBlockQueueLimit+2; i++ {
+			chain.reportBlock(blocks[0], nil, errors.New("bad"))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reporting bad blocks blocked on the subscriber")
+	}
+	if n := badBlockDropMeter.Snapshot().Count() - dropped; n == 0 {
+		t.Fatal("no bad block events dropped")
+	}
+	select {
+	case ev := <-events:
+		if ev.Block.Hash() != blocks[0].Hash() {
+			t.Fatalf("wrong bad block delivered: %x", ev.Block.Hash())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("bad block event not delivered")
+	}
+}
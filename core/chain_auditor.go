@@ -0,0 +1,306 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	auditBlockMeter     = metrics.NewRegisteredMeter("chain/audit/blocks", nil)
+	auditFailureCounter = metrics.NewRegisteredCounter("chain/audit/failures", nil)
+	auditPassCounter    = metrics.NewRegisteredCounter("chain/audit/passes", nil)
+	auditPositionGauge  = metrics.NewRegisteredGauge("chain/audit/position", nil)
+)
+
+// maxAuditFailures is the number of most recent integrity failures retained
+// for reporting. Older ones are only visible in the logs and metrics.
+const maxAuditFailures = 128
+
+// Kinds of integrity failures detected by the chain auditor.
+const (
+	AuditHeader   = "header"   // canonical header missing or not matching its hash
+	AuditChain    = "chain"    // header does not link to its canonical parent
+	AuditBody     = "body"     // body missing or not matching the header roots
+	AuditReceipts = "receipts" // receipts missing or not matching the header root
+	AuditTxIndex  = "txindex"  // transaction lookup entry missing or wrong
+)
+
+// ChainAuditConfig contains the settings of the background chain auditor.
+type ChainAuditConfig struct {
+	From     uint64        // First block of the audited range
+	To       uint64        // Last block of the audited range, 0 means the current head
+	Rate     uint64        // Maximum number of blocks verified per second, 0 means unlimited
+	Interval time.Duration // Pause between two consecutive audit passes
+}
+
+// DefaultChainAuditConfig contains the default settings of the chain auditor.
+var DefaultChainAuditConfig = ChainAuditConfig{
+	Rate:     100,
+	Interval: time.Hour,
+}
+
+// ChainAuditFailure describes a single integrity violation found by the auditor.
+type ChainAuditFailure struct {
+	Number uint64
+	Hash   common.Hash
+	Kind   string
+	Reason string
+	Time   time.Time
+}
+
+// ChainAuditReport is a snapshot of the auditor progress and findings.
+type ChainAuditReport struct {
+	From     uint64              // First block of the current (or last) pass
+	To       uint64              // Last block of the current (or last) pass
+	Position uint64              // Next block to be verified in the current pass
+	Passes   uint64              // Number of finished audit passes
+	Checked  uint64              // Total number of blocks verified
+	Failed   uint64              // Total number of integrity failures found
+	LastPass time.Time           // Completion time of the last finished pass
+	Failures []ChainAuditFailure // Most recent integrity failures
+}
+
+// ChainAuditor is a low-priority background job which repeatedly re-verifies
+// the locally stored chain: header linkage, bodies and receipts against the
+// header roots and the consistency of the transaction index.
+type ChainAuditor struct {
+	config ChainAuditConfig
+	chain  *BlockChain
+
+	lock   sync.Mutex
+	report ChainAuditReport
+
+	quit   chan struct{}
+	closed chan struct{}
+}
+
+// NewChainAuditor creates the chain auditor and starts its background loop.
+func NewChainAuditor(chain *BlockChain, config ChainAuditConfig) *ChainAuditor {
+	a := &ChainAuditor{
+		config: config,
+		chain:  chain,
+		quit:   make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+	go a.loop()
+
+	to := "head"
+	if config.To != 0 {
+		to = fmt.Sprintf("#%d", config.To)
+	}
+	log.Info("Started chain auditor", "from", config.From, "to", to, "rate", config.Rate, "interval", config.Interval)
+	return a
+}
+
+// Report returns a snapshot of the auditor progress and recent findings.
+func (a *ChainAuditor) Report() ChainAuditReport {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	report := a.report
+	report.Failures = append([]ChainAuditFailure(nil), a.report.Failures...)
+	return report
+}
+
+// Close terminates the background loop. Safe to be called multiple times.
+func (a *ChainAuditor) Close() {
+	select {
+	case <-a.quit:
+	default:
+		close(a.quit)
+	}
+	<-a.closed
+}
+
+// loop runs audit passes over the configured range until the auditor is closed.
+func (a *ChainAuditor) loop() {
+	defer close(a.closed)
+
+	for {
+		if !a.pass() {
+			return
+		}
+		select {
+		case <-time.After(a.config.Interval):
+		case <-a.quit:
+			return
+		}
+	}
+}
+
+// pass verifies every block in the configured range once. It returns false
+// if the auditor was closed in the meantime.
+func (a *ChainAuditor) pass() bool {
+	head := a.chain.CurrentBlock().Number.Uint64()
+	to := a.config.To
+	if to == 0 || to > head {
+		to = head
+	}
+	from := a.config.From
+	if from > to {
+		return true
+	}
+	a.lock.Lock()
+	a.report.From, a.report.To, a.report.Position = from, to, from
+	a.lock.Unlock()
+
+	var (
+		throttle *time.Ticker
+		parent   common.Hash
+		start    = time.Now()
+		logged   = time.Now()
+	)
+	if a.config.Rate > 0 {
+		throttle = time.NewTicker(time.Second / time.Duration(a.config.Rate))
+		defer throttle.Stop()
+	}
+	for number := from; number <= to; number++ {
+		if throttle != nil {
+			select {
+			case <-throttle.C:
+			case <-a.quit:
+				return false
+			}
+		} else {
+			select {
+			case <-a.quit:
+				return false
+			default:
+			}
+		}
+		parent = a.verify(number, parent)
+
+		auditBlockMeter.Mark(1)
+		auditPositionGauge.Update(int64(number))
+
+		a.lock.Lock()
+		a.report.Position = number + 1
+		a.report.Checked++
+		a.lock.Unlock()
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Auditing chain data", "number", number, "to", to, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	auditPassCounter.Inc(1)
+
+	a.lock.Lock()
+	a.report.Passes++
+	a.report.LastPass = time.Now()
+	failed := a.report.Failed
+	a.lock.Unlock()
+
+	log.Info("Finished chain audit pass", "from", from, "to", to, "failures", failed, "elapsed", common.PrettyDuration(time.Since(start)))
+	return true
+}
+
+// verify checks the integrity of a single canonical block. The parent is the
+// canonical hash of the previous block, or the zero hash if unknown. The
+// canonical hash of the verified block is returned.
+func (a *ChainAuditor) verify(number uint64, parent common.Hash) common.Hash {
+	db := a.chain.db
+
+	hash := rawdb.ReadCanonicalHash(db, number)
+	if hash == (common.Hash{}) {
+		a.fail(number, hash, AuditHeader, "canonical hash missing")
+		return hash
+	}
+	header := rawdb.ReadHeader(db, hash, number)
+	if header == nil {
+		a.fail(number, hash, AuditHeader, "header missing")
+		return hash
+	}
+	if have := header.Hash(); have != hash {
+		a.fail(number, hash, AuditHeader, fmt.Sprintf("header hash mismatch (have %x)", have))
+		return hash
+	}
+	// The canonical chain might have been reorged between the two lookups,
+	// only report broken links if the parent is still canonical.
+	if parent != (common.Hash{}) && header.ParentHash != parent && rawdb.ReadCanonicalHash(db, number-1) == parent {
+		a.fail(number, hash, AuditChain, fmt.Sprintf("parent hash mismatch (have %x, want %x)", header.ParentHash, parent))
+	}
+	// Bodies and receipts below the history cutoff are legitimately missing.
+	if cutoff, _ := a.chain.HistoryPruningCutoff(); number < cutoff {
+		return hash
+	}
+	body := rawdb.ReadBody(db, hash, number)
+	if body == nil {
+		a.fail(number, hash, AuditBody, "body missing")
+		return hash
+	}
+	if root := types.DeriveSha(types.Transactions(body.Transactions), trie.NewStackTrie(nil)); root != header.TxHash {
+		a.fail(number, hash, AuditBody, fmt.Sprintf("transaction root mismatch (have %x, want %x)", root, header.TxHash))
+	}
+	if root := types.CalcUncleHash(body.Uncles); root != header.UncleHash {
+		a.fail(number, hash, AuditBody, fmt.Sprintf("uncle root mismatch (have %x, want %x)", root, header.UncleHash))
+	}
+	if header.WithdrawalsHash != nil && !a.chain.Config().IsOptimismIsthmus(header.Time) {
+		if root := types.DeriveSha(types.Withdrawals(body.Withdrawals), trie.NewStackTrie(nil)); root != *header.WithdrawalsHash {
+			a.fail(number, hash, AuditBody, fmt.Sprintf("withdrawals root mismatch (have %x, want %x)", root, *header.WithdrawalsHash))
+		}
+	}
+	receipts := rawdb.ReadReceipts(db, hash, number, header.Time, a.chain.Config())
+	if receipts == nil && len(body.Transactions) > 0 {
+		a.fail(number, hash, AuditReceipts, "receipts missing")
+	} else if root := types.DeriveSha(receipts, trie.NewStackTrie(nil)); root != header.ReceiptHash {
+		a.fail(number, hash, AuditReceipts, fmt.Sprintf("receipt root mismatch (have %x, want %x)", root, header.ReceiptHash))
+	}
+	// Transaction lookups are only expected within the indexed range.
+	if tail := rawdb.ReadTxIndexTail(db); tail != nil && number >= *tail {
+		for _, tx := range body.Transactions {
+			entry := rawdb.ReadTxLookupEntry(db, tx.Hash())
+			if entry == nil {
+				a.fail(number, hash, AuditTxIndex, fmt.Sprintf("lookup entry missing for %x", tx.Hash()))
+			} else if *entry != number {
+				a.fail(number, hash, AuditTxIndex, fmt.Sprintf("lookup entry of %x points to #%d", tx.Hash(), *entry))
+			}
+		}
+	}
+	return hash
+}
+
+// fail records an integrity failure.
+func (a *ChainAuditor) fail(number uint64, hash common.Hash, kind string, reason string) {
+	log.Error("Chain integrity failure", "number", number, "hash", hash, "kind", kind, "reason", reason)
+	auditFailureCounter.Inc(1)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.report.Failed++
+	a.report.Failures = append(a.report.Failures, ChainAuditFailure{
+		Number: number,
+		Hash:   hash,
+		Kind:   kind,
+		Reason: reason,
+		Time:   time.Now(),
+	})
+	if len(a.report.Failures) > maxAuditFailures {
+		a.report.Failures = a.report.Failures[len(a.report.Failures)-maxAuditFailures:]
+	}
+}
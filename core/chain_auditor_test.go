@@ -0,0 +1,108 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// waitAuditPass runs an auditor over the given range until it finishes a pass.
+func waitAuditPass(t *testing.T, chain *BlockChain, from, to uint64) ChainAuditReport {
+	auditor := NewChainAuditor(chain, ChainAuditConfig{From: from, To: to, Interval: time.Hour})
+	defer auditor.Close()
+
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		if report := auditor.Report(); report.Passes > 0 {
+			return report
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("chain audit pass timed out")
+	return ChainAuditReport{}
+}
+
+func TestChainAuditor(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		gspec   = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 16, func(i int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(address), common.Address{0xaa}, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, key)
+		gen.AddTx(tx)
+	})
+	limit := uint64(0)
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, &limit)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for {
+		progress, err := chain.TxIndexProgress()
+		if err == nil && progress.Done() && rawdb.ReadTxIndexTail(chain.db) != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// A healthy chain should pass the audit without failures.
+	report := waitAuditPass(t, chain, 0, 0)
+	if report.Failed != 0 {
+		t.Fatalf("unexpected failures on healthy chain: %v", report.Failures)
+	}
+	if report.To != 16 || report.Checked != 17 {
+		t.Fatalf("wrong audit range: to %d, checked %d", report.To, report.Checked)
+	}
+	// Corrupt a transaction lookup and a block body, both should be reported.
+	rawdb.DeleteTxLookupEntry(chain.db, blocks[4].Transactions()[0].Hash())
+	rawdb.WriteBody(chain.db, blocks[6].Hash(), blocks[6].NumberU64(), blocks[7].Body())
+
+	report = waitAuditPass(t, chain, 4, 8)
+	kinds := make(map[uint64][]string)
+	for _, f := range report.Failures {
+		kinds[f.Number] = append(kinds[f.Number], f.Kind)
+	}
+	if len(kinds[5]) != 1 || kinds[5][0] != AuditTxIndex {
+		t.Fatalf("missing tx index failure for block 5: %v", report.Failures)
+	}
+	if len(kinds[7]) == 0 || kinds[7][0] != AuditBody {
+		t.Fatalf("missing body failure for block 7: %v", report.Failures)
+	}
+	for number := range kinds {
+		if number != 5 && number != 7 {
+			t.Fatalf("unexpected failure for block %d: %v", number, report.Failures)
+		}
+	}
+}
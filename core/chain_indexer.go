@@ -0,0 +1,328 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// chainIndexBackend is the block processing logic of an optional chain index.
+type chainIndexBackend interface {
+	// update adds the given canonical block to the index, or removes it if
+	// add is false.
+	update(batch ethdb.KeyValueWriter, block *types.Block, add bool)
+}
+
+// chainIndexer is the driver of the optional indexes of the canonical chain.
+// It feeds the canonical blocks to the index backend as they are imported,
+// unindexes the blocks reorged out and prunes the blocks falling out of the
+// configured retention window.
+//
+// The indexed range is tracked by the index head and tail metadata, so that
+// the index can be resumed after a restart. Since a crash might leave dangling
+// entries behind, the readers of an index must cross check every entry against
+// the canonical chain.
+type chainIndexer struct {
+	name    string // Name of the index, used to derive its metadata keys
+	desc    string // Human readable description of the index for logging
+	backend chainIndexBackend
+
+	// limit is the maximum number of blocks from head covered by the index:
+	//  * 0: means all blocks since the index was enabled are indexed
+	//  * N: means the latest N blocks [HEAD-N+1, HEAD] are indexed
+	limit uint64
+
+	chain  *BlockChain
+	db     ethdb.Database
+	term   chan chan struct{}
+	closed chan struct{}
+}
+
+// newChainIndexer initializes a chain index driver and starts its background
+// loop.
+func newChainIndexer(chain *BlockChain, name string, desc string, limit uint64, backend chainIndexBackend) *chainIndexer {
+	indexer := &chainIndexer{
+		name:    name,
+		desc:    desc,
+		backend: backend,
+		limit:   limit,
+		chain:   chain,
+		db:      chain.db,
+		term:    make(chan chan struct{}),
+		closed:  make(chan struct{}),
+	}
+	go indexer.loop()
+
+	msg := "since activation"
+	if limit != 0 {
+		msg = fmt.Sprintf("last %d blocks", limit)
+	}
+	log.Info("Initialized chain indexer", "index", desc, "range", msg)
+	return indexer
+}
+
+// loop is the scheduler of the indexer, assigning indexing tasks on every
+// chain head change.
+func (indexer *chainIndexer) loop() {
+	defer close(indexer.closed)
+
+	var (
+		stop   chan struct{} // Non-nil if background routine is active
+		done   chan struct{} // Non-nil if background routine is active
+		head   = indexer.chain.CurrentBlock()
+		queued *types.Header // Latest head received while the routine was active
+
+		headCh = make(chan ChainHeadEvent)
+		sub    = indexer.chain.SubscribeChainHeadEvent(headCh)
+	)
+	defer sub.Unsubscribe()
+
+	start := func(head *types.Header) {
+		stop = make(chan struct{})
+		done = make(chan struct{})
+		go indexer.run(head, stop, done)
+	}
+	start(head)
+
+	for {
+		select {
+		case h := <-headCh:
+			if done == nil {
+				start(h.Header)
+			} else {
+				queued = h.Header
+			}
+
+		case <-done:
+			stop, done = nil, nil
+			if queued != nil {
+				start(queued)
+				queued = nil
+			}
+
+		case ch := <-indexer.term:
+			if stop != nil {
+				close(stop)
+			}
+			if done != nil {
+				log.Info("Waiting background chain indexer to exit", "index", indexer.desc)
+				<-done
+			}
+			close(ch)
+			return
+		}
+	}
+}
+
+// run brings the index in line with the given chain head: it removes the
+// entries of blocks reorged out since the last run, indexes the new canonical
+// blocks and drops the blocks falling out of the retention window. If the stop
+// channel is closed, the task terminates as soon as possible, leaving the index
+// in a consistent state to be continued later. The done channel is closed once
+// the task is complete.
+func (indexer *chainIndexer) run(head *types.Header, stop chan struct{}, done chan struct{}) {
+	defer close(done)
+
+	var (
+		number = head.Number.Uint64()
+		from   uint64
+		tail   = rawdb.ReadChainIndexTail(indexer.db, indexer.name)
+		last   *types.Header
+	)
+	if hash := rawdb.ReadChainIndexHead(indexer.db, indexer.name); hash != (common.Hash{}) {
+		last = indexer.chain.GetHeaderByHash(hash)
+	}
+	if tail == nil || last == nil {
+		// No index yet (or the last indexed block is gone), start afresh
+		// according to the configured retention window.
+		if indexer.limit != 0 && number >= indexer.limit {
+			from = number - indexer.limit + 1
+		}
+		rawdb.WriteChainIndexTail(indexer.db, indexer.name, from)
+	} else {
+		// Unindex the blocks reorged out since the last run and resume the
+		// indexing from the common ancestor.
+		ancestor, ok := indexer.unwind(last, head, stop)
+		if !ok {
+			return
+		}
+		from = ancestor + 1
+		if from < *tail {
+			rawdb.WriteChainIndexTail(indexer.db, indexer.name, from)
+		}
+	}
+	if !indexer.index(from, number, stop) {
+		return
+	}
+	// Drop the blocks which fell out of the retention window.
+	if tail = rawdb.ReadChainIndexTail(indexer.db, indexer.name); tail != nil && indexer.limit != 0 && number >= indexer.limit {
+		if limit := number - indexer.limit + 1; *tail < limit {
+			indexer.prune(*tail, limit, stop)
+		}
+	}
+}
+
+// unwind removes the index entries of the blocks between the last indexed block
+// and the common ancestor with the new head. The number of the common ancestor
+// is returned, along with a flag whether the operation was finished.
+func (indexer *chainIndexer) unwind(last *types.Header, head *types.Header, stop chan struct{}) (uint64, bool) {
+	batch := indexer.db.NewBatch()
+	for {
+		number := last.Number.Uint64()
+		if number <= head.Number.Uint64() && rawdb.ReadCanonicalHash(indexer.db, number) == last.Hash() {
+			break
+		}
+		select {
+		case <-stop:
+			return 0, false
+		default:
+		}
+		if block := indexer.chain.GetBlock(last.Hash(), last.Number.Uint64()); block != nil {
+			indexer.backend.update(batch, block, false)
+		}
+		parent := indexer.chain.GetHeader(last.ParentHash, number-1)
+		if number == 0 || parent == nil {
+			break
+		}
+		last = parent
+		rawdb.WriteChainIndexHead(batch, indexer.name, last.Hash())
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed to unindex blocks", "index", indexer.desc, "err", err)
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to unindex blocks", "index", indexer.desc, "err", err)
+	}
+	return last.Number.Uint64(), true
+}
+
+// index adds the canonical blocks in range [from, to] to the index. It returns
+// whether the operation was finished.
+func (indexer *chainIndexer) index(from uint64, to uint64, stop chan struct{}) bool {
+	var (
+		batch  = indexer.db.NewBatch()
+		start  = time.Now()
+		logged = time.Now()
+	)
+	for number := from; number <= to; number++ {
+		select {
+		case <-stop:
+			return false
+		default:
+		}
+		hash := rawdb.ReadCanonicalHash(indexer.db, number)
+		if hash == (common.Hash{}) {
+			break
+		}
+		if block := indexer.chain.GetBlock(hash, number); block != nil {
+			indexer.backend.update(batch, block, true)
+		}
+		rawdb.WriteChainIndexHead(batch, indexer.name, hash)
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed to index blocks", "index", indexer.desc, "err", err)
+			}
+			batch.Reset()
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Indexing blocks", "index", indexer.desc, "number", number, "to", to, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to index blocks", "index", indexer.desc, "err", err)
+	}
+	return true
+}
+
+// prune removes the canonical blocks in range [from, to) from the index and
+// moves the tail accordingly.
+func (indexer *chainIndexer) prune(from uint64, to uint64, stop chan struct{}) {
+	batch := indexer.db.NewBatch()
+	for number := from; number < to; number++ {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		if block := indexer.chain.GetBlockByNumber(number); block != nil {
+			indexer.backend.update(batch, block, false)
+		}
+		rawdb.WriteChainIndexTail(batch, indexer.name, number+1)
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed to prune blocks", "index", indexer.desc, "err", err)
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to prune blocks", "index", indexer.desc, "err", err)
+	}
+}
+
+// Range returns the first and last block numbers currently covered by the
+// index, or false if nothing was indexed yet.
+func (indexer *chainIndexer) Range() (uint64, uint64, bool) {
+	tail := rawdb.ReadChainIndexTail(indexer.db, indexer.name)
+	if tail == nil {
+		return 0, 0, false
+	}
+	head := rawdb.ReadHeaderNumber(indexer.db, rawdb.ReadChainIndexHead(indexer.db, indexer.name))
+	if head == nil || *head < *tail {
+		return 0, 0, false
+	}
+	return *tail, *head, true
+}
+
+// isCanonicalTx reports whether the transaction with the given hash is at the
+// given position of the canonical chain, for cross checking the entries of an
+// index. The canonical bodies read are cached in the given map.
+func isCanonicalTx(db ethdb.Reader, number uint64, index uint32, hash common.Hash, bodies map[uint64]*types.Body) bool {
+	body, ok := bodies[number]
+	if !ok {
+		canonical := rawdb.ReadCanonicalHash(db, number)
+		if canonical != (common.Hash{}) {
+			body = rawdb.ReadBody(db, canonical, number)
+		}
+		bodies[number] = body
+	}
+	if body == nil || int(index) >= len(body.Transactions) {
+		return false
+	}
+	return body.Transactions[index].Hash() == hash
+}
+
+// Close shuts down the indexer. Safe to be called multiple times.
+func (indexer *chainIndexer) Close() {
+	ch := make(chan struct{})
+	select {
+	case indexer.term <- ch:
+		<-ch
+	case <-indexer.closed:
+	}
+}
@@ -144,7 +144,8 @@ func (b *BlockGen) addTx(bc *BlockChain, vmConfig vm.Config, tx *types.Transacti
 // of the range created by GenerateChain.
 func (b *BlockGen) AddTx(tx *types.Transaction) {
 	// Wrap the chain config in an empty BlockChain object to satisfy ChainContext.
-	bc := &BlockChain{chainConfig: b.cm.config}
+	bc := &BlockChain{}
+	bc.chainConfig.Store(b.cm.config)
 	b.addTx(bc, vm.Config{}, tx)
 }
 
@@ -151,6 +151,8 @@ func TestGeneratePOSChain(t *testing.T) {
 			if r.Logs == nil {
 				r.Logs = []*types.Log{}
 			}
+			// fee breakdowns are not part of the stored receipts
+			r.Fees = nil
 		}
 		blockchainReceipts := blockchain.GetReceiptsByHash(block.Hash())
 		if !reflect.DeepEqual(genBlockReceipts, blockchainReceipts) {
@@ -0,0 +1,198 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// codeChangeTracer collects the code changes of a block as it is processed. The
+// changes made within a call frame are dropped again if the frame reverts, so
+// that only the changes persisted in the post state remain.
+type codeChangeTracer struct {
+	*frameJournal[*types.CodeChange]
+}
+
+// newCodeChangeTracer creates a code change collector, chained after the given
+// hooks if non-nil.
+func newCodeChangeTracer(hooks *tracing.Hooks) (*codeChangeTracer, *tracing.Hooks) {
+	journal, wrapped := newFrameJournal[*types.CodeChange](hooks)
+	t := &codeChangeTracer{journal}
+
+	onCodeChange := wrapped.OnCodeChange
+	wrapped.OnCodeChange = func(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+		t.recordChange(addr, prevCodeHash, prevCode, codeHash, code)
+		if onCodeChange != nil {
+			onCodeChange(addr, prevCodeHash, prevCode, codeHash, code)
+		}
+	}
+	return t, wrapped
+}
+
+// recordChange appends a code change to the journal, classifying it by the
+// previous and the new code.
+func (t *codeChangeTracer) recordChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+	if prevCodeHash == codeHash {
+		return
+	}
+	change := &types.CodeChange{
+		TxIndex:  t.txIndex(),
+		Address:  addr,
+		PrevHash: prevCodeHash,
+		Hash:     codeHash,
+	}
+	_, undelegated := types.ParseDelegation(prevCode)
+	if delegate, ok := types.ParseDelegation(code); ok {
+		change.Kind, change.Delegate = types.CodeDelegated, delegate
+	} else if len(code) == 0 {
+		change.Kind = types.CodeDestructed
+		if undelegated {
+			change.Kind = types.CodeUndelegated
+		}
+	} else if len(prevCode) == 0 {
+		change.Kind = types.CodeDeployed
+	} else {
+		change.Kind = types.CodeReplaced
+	}
+	t.record(change)
+}
+
+// CodeChangeIndexer is the module maintaining the account -> code change index
+// of the canonical chain, within the configured retention window. The code
+// changes are traced when the blocks are processed, which requires
+// CacheConfig.CodeChanges, and indexed under the changed account as the blocks
+// become canonical.
+//
+// Since a crash might leave dangling entries behind, readers must use
+// CodeChanges which cross checks every entry against the canonical chain.
+type CodeChangeIndexer struct {
+	*chainIndexer
+
+	chain *BlockChain
+	db    ethdb.Database
+}
+
+// IndexedCodeChange is a code change retrieved from the index.
+type IndexedCodeChange struct {
+	*types.CodeChange
+	Number    uint64      // Number of the block containing the code change
+	BlockHash common.Hash // Hash of the block containing the code change
+	Position  uint32      // Position of the code change within the block
+	TxHash    common.Hash // Hash of the transaction changing the code, zero outside of transactions
+}
+
+// NewCodeChangeIndexer initializes the code change indexer and starts its
+// background loop.
+func NewCodeChangeIndexer(chain *BlockChain, limit uint64) *CodeChangeIndexer {
+	indexer := &CodeChangeIndexer{
+		chain: chain,
+		db:    chain.db,
+	}
+	indexer.chainIndexer = newChainIndexer(chain, rawdb.CodeChangeIndexName, "code changes", limit, indexer)
+	return indexer
+}
+
+// CodeChanges retrieves at most limit canonical code changes of the given
+// account, in chain order, starting at the given block number and position
+// (inclusive) and ending with the block to (inclusive). The position to
+// continue the iteration from is returned too, or nil if there are no more
+// code changes.
+func (indexer *CodeChangeIndexer) CodeChanges(address common.Address, number uint64, position uint32, to uint64, limit int) ([]*IndexedCodeChange, *rawdb.CodeChangeEntry) {
+	var (
+		blocks  = make(map[uint64][]*IndexedCodeChange)
+		results []*IndexedCodeChange
+	)
+	for {
+		entries := rawdb.ReadCodeChangeEntries(indexer.db, address, number, position, limit)
+		for _, entry := range entries {
+			if entry.Number > to {
+				return results, nil
+			}
+			changes, ok := blocks[entry.Number]
+			if !ok {
+				if rawdb.ReadCanonicalHash(indexer.db, entry.Number) == entry.Hash {
+					changes = BlockCodeChanges(indexer.db, entry.Hash, entry.Number)
+				}
+				blocks[entry.Number] = changes
+			}
+			if int(entry.Position) >= len(changes) {
+				continue
+			}
+			if len(results) == limit {
+				return results, &entry
+			}
+			results = append(results, changes[entry.Position])
+		}
+		if len(entries) < limit {
+			return results, nil
+		}
+		// Continue right after the last retrieved entry
+		last := entries[len(entries)-1]
+		number, position = last.Number, last.Position+1
+		if position == 0 {
+			number++
+		}
+	}
+}
+
+// BlockCodeChanges retrieves the code changes of the given block, as traced
+// when it was processed. Nil is returned if the block wasn't traced.
+func BlockCodeChanges(db ethdb.Reader, hash common.Hash, number uint64) []*IndexedCodeChange {
+	if !rawdb.HasCodeChanges(db, hash, number) {
+		return nil
+	}
+	body := rawdb.ReadBody(db, hash, number)
+	if body == nil {
+		return nil
+	}
+	var (
+		changes = rawdb.ReadCodeChanges(db, hash, number)
+		results = make([]*IndexedCodeChange, 0, len(changes))
+	)
+	for i, change := range changes {
+		result := &IndexedCodeChange{
+			CodeChange: change,
+			Number:     number,
+			BlockHash:  hash,
+			Position:   uint32(i),
+		}
+		if int(change.TxIndex) < len(body.Transactions) {
+			result.TxHash = body.Transactions[change.TxIndex].Hash()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// update adds or removes the code changes of the given block to the index.
+func (indexer *CodeChangeIndexer) update(batch ethdb.KeyValueWriter, block *types.Block, add bool) {
+	var (
+		number = block.NumberU64()
+		hash   = block.Hash()
+	)
+	for i, change := range rawdb.ReadCodeChanges(indexer.db, hash, number) {
+		if add {
+			rawdb.WriteCodeChangeEntry(batch, change.Address, number, uint32(i), hash)
+		} else {
+			rawdb.DeleteCodeChangeEntry(batch, change.Address, number, uint32(i))
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// deployerCode returns the code of a contract deploying the given init code of
+// at most 32 bytes with CREATE, reverting afterwards if requested.
+func deployerCode(initcode []byte, revert bool) []byte {
+	code := []byte{byte(vm.PUSH32)}
+	code = append(code, common.LeftPadBytes(initcode, 32)...)
+	code = append(code,
+		byte(vm.PUSH1), 0x00, byte(vm.MSTORE),
+		byte(vm.PUSH1), byte(len(initcode)), byte(vm.PUSH1), byte(32-len(initcode)), byte(vm.PUSH1), 0x00,
+		byte(vm.CREATE), byte(vm.POP),
+	)
+	if revert {
+		return append(code, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.REVERT))
+	}
+	return append(code, byte(vm.STOP))
+}
+
+func TestCodeChangeIndexer(t *testing.T) {
+	var (
+		key, _    = crypto.GenerateKey()
+		addr      = crypto.PubkeyToAddress(key.PublicKey)
+		eoaKey, _ = crypto.GenerateKey()
+		eoa       = crypto.PubkeyToAddress(eoaKey.PublicKey)
+		initcode  = common.FromHex("600060005360016000f3") // Deploys a single STOP
+		deployer  = common.Address{0xd0}
+		reverter  = common.Address{0xd1}
+		gspec     = &Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr:     {Balance: big.NewInt(params.Ether)},
+				deployer: {Code: deployerCode(initcode, false)},
+				reverter: {Code: deployerCode(initcode, true)},
+			},
+		}
+		signer   = types.LatestSigner(gspec.Config)
+		engine   = beacon.New(ethash.NewFaker())
+		deployed = crypto.CreateAddress(addr, 0)
+	)
+	delegate := func(gen *BlockGen, target common.Address, nonce uint64) {
+		auth, _ := types.SignSetCode(eoaKey, types.SetCodeAuthorization{
+			ChainID: *uint256.MustFromBig(gspec.Config.ChainID),
+			Address: target,
+			Nonce:   nonce,
+		})
+		gen.AddTx(types.MustSignNewTx(key, signer, &types.SetCodeTx{
+			ChainID:   uint256.MustFromBig(gspec.Config.ChainID),
+			Nonce:     gen.TxNonce(addr),
+			To:        eoa,
+			Gas:       100000,
+			GasFeeCap: uint256.MustFromBig(gen.BaseFee()),
+			AuthList:  []types.SetCodeAuthorization{auth},
+		}))
+	}
+	// Deploy contracts in block 1, both at the top level and from a contract,
+	// along with a reverted deployment. Delegate an account in block 2 and
+	// clear the delegation in block 3.
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 3, func(i int, gen *BlockGen) {
+		switch i {
+		case 0:
+			gen.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: gen.TxNonce(addr), Gas: 100000, GasPrice: gen.BaseFee(), Data: initcode}))
+			gen.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: gen.TxNonce(addr), To: &reverter, Gas: 100000, GasPrice: gen.BaseFee()}))
+			gen.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: gen.TxNonce(addr), To: &deployer, Gas: 100000, GasPrice: gen.BaseFee()}))
+		case 1:
+			delegate(gen, deployed, 0)
+		case 2:
+			delegate(gen, common.Address{}, 1)
+		}
+	})
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.CodeChanges = true
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	indexer := NewCodeChangeIndexer(chain, 0)
+	defer indexer.Close()
+	waitChainIndex(t, chain, rawdb.CodeChangeIndexName)
+
+	// The reverted deployment must not show up in the journal
+	changes := BlockCodeChanges(chain.db, blocks[0].Hash(), 1)
+	if len(changes) != 2 {
+		t.Fatalf("wrong number of code changes in block 1: have %d, want 2", len(changes))
+	}
+	code := crypto.Keccak256Hash([]byte{byte(vm.STOP)})
+	if change := changes[0]; change.Address != deployed || change.Kind != types.CodeDeployed || change.TxIndex != 0 || change.Hash != code {
+		t.Fatalf("wrong top level deployment: %+v", change.CodeChange)
+	}
+	if change := changes[1]; change.Address != crypto.CreateAddress(deployer, 0) || change.Kind != types.CodeDeployed || change.TxIndex != 2 {
+		t.Fatalf("wrong internal deployment: %+v", change.CodeChange)
+	}
+	// The delegation and its removal are indexed under the delegating account
+	changes, next := indexer.CodeChanges(eoa, 0, 0, math.MaxUint64, 10)
+	if len(changes) != 2 || next != nil {
+		t.Fatalf("wrong number of code changes of the delegating account: have %d, want 2", len(changes))
+	}
+	if change := changes[0]; change.Number != 2 || change.Kind != types.CodeDelegated || change.Delegate != deployed || change.TxHash != blocks[1].Transactions()[0].Hash() {
+		t.Fatalf("wrong delegation: %+v", change.CodeChange)
+	}
+	if change := changes[1]; change.Number != 3 || change.Kind != types.CodeUndelegated || change.Hash != types.EmptyCodeHash {
+		t.Fatalf("wrong undelegation: %+v", change.CodeChange)
+	}
+	// Paginate one by one
+	changes, next = indexer.CodeChanges(eoa, 0, 0, math.MaxUint64, 1)
+	if len(changes) != 1 || next == nil || next.Number != 3 {
+		t.Fatalf("wrong first page: %d code changes, next %+v", len(changes), next)
+	}
+	// Rewinding the chain drops the code changes of the removed blocks
+	if err := chain.SetHead(2); err != nil {
+		t.Fatalf("failed to rewind chain: %v", err)
+	}
+	waitChainIndex(t, chain, rawdb.CodeChangeIndexName)
+	if changes, _ := indexer.CodeChanges(eoa, 0, 0, math.MaxUint64, 10); len(changes) != 1 {
+		t.Fatalf("wrong number of code changes after rewind: have %d, want 1", len(changes))
+	}
+}
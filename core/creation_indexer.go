@@ -0,0 +1,126 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// CreationIndexer is the module maintaining the contract address -> creation
+// index of the canonical chain. The contracts created by the top level call of
+// a transaction are derived from the stored receipts, while the ones created
+// below it are taken from the internal transactions traced when the blocks are
+// processed, which requires CacheConfig.InternalTxs. Blocks processed without
+// tracing only have their top level creations indexed.
+//
+// Since a crash might leave dangling entries behind, readers must use Creation
+// which cross checks every entry against the canonical chain.
+type CreationIndexer struct {
+	*chainIndexer
+
+	chain *BlockChain
+	db    ethdb.Database
+}
+
+// NewCreationIndexer initializes the contract creation indexer and starts its
+// background loop. All blocks are indexed, as contracts outlive any retention
+// window.
+func NewCreationIndexer(chain *BlockChain) *CreationIndexer {
+	indexer := &CreationIndexer{
+		chain: chain,
+		db:    chain.db,
+	}
+	indexer.chainIndexer = newChainIndexer(chain, rawdb.CreationIndexName, "contract creations", 0, indexer)
+	return indexer
+}
+
+// Creation retrieves the latest canonical creation of the contract at the given
+// address, or nil if it's unknown. An address may only be created more than
+// once after self-destructing, in which case the current code is from the
+// latest creation.
+func (indexer *CreationIndexer) Creation(address common.Address) *rawdb.ContractCreationEntry {
+	entries := rawdb.ReadContractCreationEntries(indexer.db, address)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if rawdb.ReadCanonicalHash(indexer.db, entries[i].Number) == entries[i].BlockHash {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// update adds or removes the contract creations of the given block to the
+// index.
+func (indexer *CreationIndexer) update(batch ethdb.KeyValueWriter, block *types.Block, add bool) {
+	var (
+		number   = block.NumberU64()
+		hash     = block.Hash()
+		txs      = block.Transactions()
+		position uint32
+	)
+	store := func(address common.Address, entry *rawdb.ContractCreationEntry) {
+		entry.Number, entry.Position, entry.BlockHash = number, position, hash
+		if add {
+			rawdb.WriteContractCreationEntry(batch, address, entry)
+		} else {
+			rawdb.DeleteContractCreationEntry(batch, address, number, position)
+		}
+		position++
+	}
+	// Index the creations in execution order: the top level one of every
+	// transaction first, followed by the internal ones it performed
+	var (
+		receipts = rawdb.ReadReceipts(indexer.db, hash, number, block.Time(), indexer.chain.Config())
+		internal = rawdb.ReadInternalTxs(indexer.db, hash, number)
+		signer   = types.MakeSigner(indexer.chain.Config(), block.Number(), block.Time())
+	)
+	for i, tx := range txs {
+		if tx.To() == nil && i < len(receipts) && receipts[i].Status == types.ReceiptStatusSuccessful {
+			sender, err := types.Sender(signer, tx)
+			if err != nil {
+				log.Warn("Failed to derive transaction sender", "number", number, "index", i, "err", err)
+			} else {
+				store(receipts[i].ContractAddress, &rawdb.ContractCreationEntry{
+					TxIndex: uint32(i),
+					TxHash:  tx.Hash(),
+					Creator: sender,
+					Op:      byte(vm.CREATE),
+				})
+			}
+		}
+		for len(internal) > 0 && internal[0].TxIndex == uint32(i) {
+			itx := internal[0]
+			internal = internal[1:]
+
+			if op := vm.OpCode(itx.Op); (op != vm.CREATE && op != vm.CREATE2) || itx.Reverted {
+				continue
+			}
+			store(itx.To, &rawdb.ContractCreationEntry{
+				TxIndex: uint32(i),
+				TxHash:  tx.Hash(),
+				Creator: itx.From,
+				Op:      itx.Op,
+				Depth:   itx.Depth,
+				Salt:    itx.Salt,
+			})
+		}
+	}
+}
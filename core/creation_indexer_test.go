@@ -0,0 +1,131 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// factoryCode returns the code of a contract deploying an empty contract with
+// CREATE2 and the given salt, reverting afterwards if requested.
+func factoryCode(salt byte, revert bool) []byte {
+	code := []byte{
+		byte(vm.PUSH1), salt, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00,
+		byte(vm.CREATE2), byte(vm.POP),
+	}
+	if revert {
+		return append(code, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.REVERT))
+	}
+	return append(code, byte(vm.STOP))
+}
+
+func TestCreationIndexer(t *testing.T) {
+	var (
+		key, _   = crypto.GenerateKey()
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		factory  = common.Address{0xf0}
+		reverter = common.Address{0xf1}
+		gspec    = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr:     {Balance: big.NewInt(params.Ether)},
+				factory:  {Code: factoryCode(0x42, false)},
+				reverter: {Code: factoryCode(0x43, true)},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = ethash.NewFaker()
+	)
+	// Deploy a contract in block 1, call the factory in block 2 and the
+	// reverting factory in block 3.
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 3, func(i int, gen *BlockGen) {
+		var tx *types.Transaction
+		switch i {
+		case 0:
+			tx = types.NewContractCreation(gen.TxNonce(addr), nil, 100000, gen.BaseFee(), nil)
+		case 1:
+			tx = types.NewTransaction(gen.TxNonce(addr), factory, nil, 100000, gen.BaseFee(), nil)
+		case 2:
+			tx = types.NewTransaction(gen.TxNonce(addr), reverter, nil, 100000, gen.BaseFee(), nil)
+		}
+		tx, _ = types.SignTx(tx, signer, key)
+		gen.AddTx(tx)
+	})
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.InternalTxs = true
+	cacheConfig.CreationSalts = true
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	indexer := NewCreationIndexer(chain)
+	defer indexer.Close()
+	waitChainIndex(t, chain, rawdb.CreationIndexName)
+
+	// The contract deployed by the top level call is created by the sender
+	deployed := crypto.CreateAddress(addr, 0)
+	creation := indexer.Creation(deployed)
+	if creation == nil {
+		t.Fatal("missing creation of the deployed contract")
+	}
+	if creation.Number != 1 || creation.TxHash != blocks[0].Transactions()[0].Hash() || creation.Creator != addr || vm.OpCode(creation.Op) != vm.CREATE || creation.Salt != nil {
+		t.Fatalf("wrong creation of the deployed contract: %+v", creation)
+	}
+	// The contract deployed by the factory is created by it, along with the salt
+	created := crypto.CreateAddress2(factory, common.Hash{31: 0x42}, crypto.Keccak256(nil))
+	creation = indexer.Creation(created)
+	if creation == nil {
+		t.Fatal("missing creation of the factory contract")
+	}
+	if creation.Number != 2 || creation.TxHash != blocks[1].Transactions()[0].Hash() || creation.Creator != factory || vm.OpCode(creation.Op) != vm.CREATE2 || creation.Depth != 1 {
+		t.Fatalf("wrong creation of the factory contract: %+v", creation)
+	}
+	if creation.Salt == nil || *creation.Salt != (common.Hash{31: 0x42}) {
+		t.Fatalf("wrong salt of the factory contract: %v", creation.Salt)
+	}
+	// Reverted creations are not indexed
+	if creation := indexer.Creation(crypto.CreateAddress2(reverter, common.Hash{31: 0x43}, crypto.Keccak256(nil))); creation != nil {
+		t.Fatalf("reverted creation indexed: %+v", creation)
+	}
+	// Rewinding the chain drops the creations of the removed blocks
+	if err := chain.SetHead(1); err != nil {
+		t.Fatalf("failed to rewind chain: %v", err)
+	}
+	waitChainIndex(t, chain, rawdb.CreationIndexName)
+	if creation := indexer.Creation(created); creation != nil {
+		t.Fatalf("creation of a rewound block still indexed: %+v", creation)
+	}
+	if creation := indexer.Creation(deployed); creation == nil {
+		t.Fatal("missing creation of the deployed contract after rewind")
+	}
+}
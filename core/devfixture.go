@@ -0,0 +1,172 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DevFixture is a manifest of accounts and contracts seeded into a developer
+// network, either in its genesis or on demand through its faucet.
+type DevFixture struct {
+	Mnemonic   string                `json:"mnemonic"`   // Mnemonic the accounts are derived from
+	Passphrase string                `json:"passphrase"` // Optional BIP-39 passphrase of the mnemonic
+	Path       string                `json:"path"`       // Base derivation path, m/44'/60'/0'/0/0 if unset
+	Accounts   uint64                `json:"accounts"`   // Number of accounts derived from the mnemonic
+	Balance    *math.HexOrDecimal256 `json:"balance"`    // Balance of each derived account
+	Contracts  []*DevContract        `json:"contracts"`  // Contracts to deploy, with their storage
+}
+
+// DevContract is a contract deployed by a developer fixture.
+type DevContract struct {
+	Name    string                      `json:"name"`
+	Address *common.Address             `json:"address"` // Location of the contract, only honoured at genesis
+	Code    hexutil.Bytes               `json:"code"`    // Runtime code of the contract
+	Storage map[common.Hash]common.Hash `json:"storage"`
+	Balance *math.HexOrDecimal256       `json:"balance"`
+}
+
+// LoadDevFixture reads a developer fixture manifest from a JSON file.
+func LoadDevFixture(path string) (*DevFixture, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fixture := new(DevFixture)
+	if err := json.Unmarshal(blob, fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// DeriveAccounts returns the addresses of the accounts derived from the mnemonic
+// of the fixture, incrementing the last component of the base derivation path.
+func (f *DevFixture) DeriveAccounts() ([]common.Address, error) {
+	if f.Accounts == 0 {
+		return nil, nil
+	}
+	if f.Mnemonic == "" {
+		return nil, errors.New("fixture accounts require a mnemonic")
+	}
+	base := accounts.DefaultBaseDerivationPath
+	if f.Path != "" {
+		path, err := accounts.ParseDerivationPath(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		base = path
+	}
+	var (
+		seed  = accounts.MnemonicSeed(f.Mnemonic, f.Passphrase)
+		next  = accounts.DefaultIterator(base)
+		addrs = make([]common.Address, 0, f.Accounts)
+	)
+	for i := uint64(0); i < f.Accounts; i++ {
+		key, err := accounts.DeriveKey(seed, next())
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return addrs, nil
+}
+
+// ApplyGenesis seeds the fixture into a genesis allocation: the derived accounts
+// are funded and the contracts placed at their addresses with their storage.
+func (f *DevFixture) ApplyGenesis(alloc types.GenesisAlloc) error {
+	addrs, err := f.DeriveAccounts()
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		account := alloc[addr]
+		account.Balance = (*big.Int)(f.Balance)
+		if account.Balance == nil {
+			account.Balance = new(big.Int)
+		}
+		alloc[addr] = account
+	}
+	for _, contract := range f.Contracts {
+		if contract.Address == nil {
+			return fmt.Errorf("contract %q has no genesis address", contract.Name)
+		}
+		if _, ok := alloc[*contract.Address]; ok {
+			return fmt.Errorf("contract %q address %v already allocated", contract.Name, *contract.Address)
+		}
+		balance := (*big.Int)(contract.Balance)
+		if balance == nil {
+			balance = new(big.Int)
+		}
+		alloc[*contract.Address] = types.Account{
+			Code:    contract.Code,
+			Storage: contract.Storage,
+			Balance: balance,
+			Nonce:   1,
+		}
+	}
+	return nil
+}
+
+// InitCode returns the creation code deploying the contract with its storage,
+// for seeding it through a transaction: the storage slots are written in key
+// order, after which the runtime code is returned.
+func (c *DevContract) InitCode() []byte {
+	keys := make([]common.Hash, 0, len(c.Storage))
+	for key := range c.Storage {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b common.Hash) int { return bytes.Compare(a[:], b[:]) })
+
+	// Every slot takes 2 PUSH32 and an SSTORE, the code copy and return 21 bytes
+	var (
+		code   = new(bytes.Buffer)
+		offset = uint32(len(keys)*67 + 21)
+		size   = uint32(len(c.Code))
+	)
+	for _, key := range keys {
+		value := c.Storage[key]
+		code.WriteByte(byte(vm.PUSH32))
+		code.Write(value[:])
+		code.WriteByte(byte(vm.PUSH32))
+		code.Write(key[:])
+		code.WriteByte(byte(vm.SSTORE))
+	}
+	push4 := func(v uint32) {
+		code.Write([]byte{byte(vm.PUSH4), byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)})
+	}
+	push4(size)
+	push4(offset)
+	code.Write([]byte{byte(vm.PUSH1), 0, byte(vm.CODECOPY)})
+	push4(size)
+	code.Write([]byte{byte(vm.PUSH1), 0, byte(vm.RETURN)})
+	code.Write(c.Code)
+	return code.Bytes()
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that a developer fixture seeds the same accounts and contracts in the
+// genesis as when deployed through transactions.
+func TestDevFixture(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		faucet   = crypto.PubkeyToAddress(key.PublicKey)
+		location = common.HexToAddress("0xc0de")
+		contract = &DevContract{
+			Name:    "store",
+			Address: &location,
+			Code:    []byte{byte(vm.PUSH1), 0x01, byte(vm.SLOAD), byte(vm.STOP)},
+			Storage: map[common.Hash]common.Hash{
+				common.HexToHash("0x01"): common.HexToHash("0xdead"),
+				common.HexToHash("0x02"): common.HexToHash("0xbeef"),
+			},
+			Balance: (*math.HexOrDecimal256)(big.NewInt(7)),
+		}
+		fixture = &DevFixture{
+			Mnemonic:  "test test test test test test test test test test test junk",
+			Accounts:  2,
+			Balance:   (*math.HexOrDecimal256)(big.NewInt(params.Ether)),
+			Contracts: []*DevContract{contract},
+		}
+		gspec = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{faucet: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+	addrs, err := fixture.DeriveAccounts()
+	if err != nil {
+		t.Fatalf("failed to derive accounts: %v", err)
+	}
+	if len(addrs) != 2 || addrs[1] != common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8") {
+		t.Fatalf("derived accounts mismatch: %v", addrs)
+	}
+	if err := fixture.ApplyGenesis(gspec.Alloc); err != nil {
+		t.Fatalf("failed to apply fixture: %v", err)
+	}
+	signer := types.LatestSigner(gspec.Config)
+	db, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 1, func(i int, gen *BlockGen) {
+		tx, _ := types.SignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    gen.TxNonce(faucet),
+			Value:    (*big.Int)(contract.Balance),
+			Gas:      500000,
+			GasPrice: gen.BaseFee(),
+			Data:     contract.InitCode(),
+		})
+		gen.AddTx(tx)
+	})
+	chain, _ := NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	statedb, _ := chain.State()
+	for _, addr := range addrs {
+		if balance := statedb.GetBalance(addr); balance.ToBig().Cmp((*big.Int)(fixture.Balance)) != 0 {
+			t.Errorf("account %v balance mismatch: have %v", addr, balance)
+		}
+	}
+	deployed := crypto.CreateAddress(faucet, 0)
+	for _, addr := range []common.Address{location, deployed} {
+		if code := statedb.GetCode(addr); !bytes.Equal(code, contract.Code) {
+			t.Errorf("contract %v code mismatch: have %x, want %x", addr, code, contract.Code)
+		}
+		for key, want := range contract.Storage {
+			if have := statedb.GetState(addr, key); have != want {
+				t.Errorf("contract %v slot %x mismatch: have %x, want %x", addr, key, have, want)
+			}
+		}
+		if balance := statedb.GetBalance(addr); balance.Uint64() != 7 {
+			t.Errorf("contract %v balance mismatch: have %v, want 7", addr, balance)
+		}
+	}
+}
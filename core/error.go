@@ -142,4 +142,8 @@ var (
 
 	// ErrSystemTxNotSupported is returned for any deposit tx with IsSystemTx=true after the Regolith fork
 	ErrSystemTxNotSupported = errors.New("system tx not supported")
+
+	// ErrInvalidSystemTx is returned if a system transaction fails the checks
+	// of its type.
+	ErrInvalidSystemTx = errors.New("invalid system transaction")
 )
@@ -33,3 +33,10 @@ type ChainEvent struct {
 type ChainHeadEvent struct {
 	Header *types.Header
 }
+
+// BadBlockEvent is posted when a block fails validation.
+type BadBlockEvent struct {
+	Block    *types.Block
+	Receipts types.Receipts // Receipts of the failed processing, nil if not processed
+	Err      error
+}
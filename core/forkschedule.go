@@ -0,0 +1,156 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ForkSchedule is a set of timestamp based fork activations scheduled at
+// runtime, keyed by fork name. Only the forks which can be overridden from the
+// command line can be scheduled.
+type ForkSchedule map[string]uint64
+
+// LoadForkSchedule reads the fork schedule persisted to the given file. A
+// missing file is an empty schedule.
+func LoadForkSchedule(path string) (ForkSchedule, error) {
+	blob, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ForkSchedule{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	schedule := make(ForkSchedule)
+	if err := json.Unmarshal(blob, &schedule); err != nil {
+		return nil, fmt.Errorf("invalid fork schedule %s: %w", path, err)
+	}
+	return schedule, nil
+}
+
+// Save atomically persists the fork schedule to the given file.
+func (s ForkSchedule) Save(path string) error {
+	blob, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(tmp, blob, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Apply sets the scheduled forks in the given chain overrides, taking precedence
+// over the ones configured on the command line.
+func (s ForkSchedule) Apply(o *ChainOverrides) error {
+	for name, time := range s {
+		if err := o.setFork(name, time); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFork sets the activation time of the named fork.
+func (o *ChainOverrides) setFork(name string, time uint64) error {
+	var fork **uint64
+	switch name {
+	case "prague":
+		fork = &o.OverridePrague
+	case "verkle":
+		fork = &o.OverrideVerkle
+	case "canyon":
+		fork = &o.OverrideOptimismCanyon
+	case "ecotone":
+		fork = &o.OverrideOptimismEcotone
+	case "fjord":
+		fork = &o.OverrideOptimismFjord
+	case "granite":
+		fork = &o.OverrideOptimismGranite
+	case "holocene":
+		fork = &o.OverrideOptimismHolocene
+	case "isthmus":
+		fork = &o.OverrideOptimismIsthmus
+	case "jovian":
+		fork = &o.OverrideOptimismJovian
+	case "interop":
+		fork = &o.OverrideOptimismInterop
+	default:
+		return fmt.Errorf("unknown fork %q", name)
+	}
+	if *fork != nil && **fork != time {
+		log.Warn("Overriding configured fork time", "fork", name, "configured", **fork, "scheduled", time)
+	}
+	*fork = &time
+	return nil
+}
+
+// ForkConfig returns a copy of the chain configuration with the named fork
+// scheduled at the given time, or an error if the fork is unknown or the new
+// configuration conflicts with the current chain, e.g. because the fork would
+// activate (or has already activated) at or before the current head.
+func (bc *BlockChain) ForkConfig(name string, time uint64) (*params.ChainConfig, error) {
+	var overrides ChainOverrides
+	if err := overrides.setFork(name, time); err != nil {
+		return nil, err
+	}
+	current := bc.Config()
+	config := *current
+	if config.Optimism != nil {
+		optimism := *config.Optimism
+		config.Optimism = &optimism
+	}
+	if err := overrides.apply(&config); err != nil {
+		return nil, err
+	}
+	var (
+		head    = bc.CurrentBlock()
+		genesis = bc.genesisBlock.Time()
+	)
+	if head.Time >= time {
+		return nil, fmt.Errorf("fork time %d not after head time %d", time, head.Time)
+	}
+	if err := current.CheckCompatible(&config, head.Number.Uint64(), head.Time, &genesis); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// SetChainConfig swaps in a new chain configuration and persists it to the
+// database. The previous configuration is left untouched, readers holding on to
+// it keep a consistent view, while every later Config call sees the new one.
+//
+// Modules must retrieve the config through the chain instead of caching it to
+// pick up the change. Forks validated by ForkConfig activate after the current
+// head, so blocks already in flight are not affected by the swap.
+func (bc *BlockChain) SetChainConfig(config *params.ChainConfig) {
+	bc.chainConfig.Store(config)
+	bc.hc.config.Store(config)
+	rawdb.WriteChainConfig(bc.db, bc.genesisBlock.Hash(), config)
+}
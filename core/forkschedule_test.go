@@ -0,0 +1,147 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestScheduleFork(t *testing.T) {
+	config := *params.MergedTestChainConfig
+	config.PragueTime = nil
+
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &Genesis{Config: &config, BaseFee: big.NewInt(params.InitialBaseFee), Timestamp: 100}
+	)
+	chain, err := NewBlockChain(db, nil, gspec, nil, beacon.New(ethash.NewFaker()), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.ForkConfig("prague", 100); err == nil {
+		t.Fatal("fork at head time accepted")
+	}
+	if _, err := chain.ForkConfig("london", 200); err == nil {
+		t.Fatal("unsupported fork accepted")
+	}
+	forked, err := chain.ForkConfig("prague", 200)
+	if err != nil {
+		t.Fatalf("failed to schedule fork: %v", err)
+	}
+	if forked.PragueTime == nil || *forked.PragueTime != 200 {
+		t.Fatalf("fork not scheduled: %v", forked.PragueTime)
+	}
+	if chain.Config().PragueTime != nil {
+		t.Fatal("chain config modified before applying")
+	}
+	chain.SetChainConfig(forked)
+	if !chain.Config().IsPrague(common.Big0, 200) || chain.Config().IsPrague(common.Big0, 199) {
+		t.Fatal("scheduled fork not applied")
+	}
+	if stored := rawdb.ReadChainConfig(db, chain.Genesis().Hash()); stored.PragueTime == nil || *stored.PragueTime != 200 {
+		t.Fatal("scheduled fork not persisted")
+	}
+	// Forks not activated yet can be rescheduled
+	if _, err := chain.ForkConfig("prague", 300); err != nil {
+		t.Fatalf("failed to reschedule pending fork: %v", err)
+	}
+}
+
+// Tests that forks can be rescheduled while blocks are being imported. Run it
+// with the race detector to catch unsynchronized config accesses.
+func TestScheduleForkWhileImporting(t *testing.T) {
+	config := *params.MergedTestChainConfig
+	config.PragueTime = nil
+
+	gspec := &Genesis{Config: &config, BaseFee: big.NewInt(params.InitialBaseFee)}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, beacon.New(ethash.NewFaker()), 64, func(i int, b *BlockGen) {})
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, beacon.New(ethash.NewFaker()), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		for _, block := range blocks {
+			if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+				errc <- err
+				return
+			}
+		}
+		errc <- nil
+	}()
+	for i := uint64(0); ; i++ {
+		select {
+		case err := <-errc:
+			if err != nil {
+				t.Fatalf("failed to import blocks: %v", err)
+			}
+			if head := chain.CurrentBlock().Number.Uint64(); head != uint64(len(blocks)) {
+				t.Fatalf("head mismatch: have %d, want %d", head, len(blocks))
+			}
+			return
+		default:
+		}
+		forked, err := chain.ForkConfig("prague", 1<<40+i)
+		if err != nil {
+			t.Fatalf("failed to schedule fork: %v", err)
+		}
+		chain.SetChainConfig(forked)
+	}
+}
+
+func TestForkScheduleFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "forkschedule.json")
+
+	schedule, err := LoadForkSchedule(path)
+	if err != nil || len(schedule) != 0 {
+		t.Fatalf("missing schedule not empty: %v, %v", schedule, err)
+	}
+	schedule["isthmus"] = 1000
+	schedule["jovian"] = 2000
+	if err := schedule.Save(path); err != nil {
+		t.Fatalf("failed to save schedule: %v", err)
+	}
+	if schedule, err = LoadForkSchedule(path); err != nil {
+		t.Fatalf("failed to load schedule: %v", err)
+	}
+	overrides := ChainOverrides{OverrideOptimismIsthmus: new(uint64)}
+	if err := schedule.Apply(&overrides); err != nil {
+		t.Fatalf("failed to apply schedule: %v", err)
+	}
+	if *overrides.OverrideOptimismIsthmus != 1000 || *overrides.OverrideOptimismJovian != 2000 {
+		t.Fatalf("schedule not applied: isthmus %d, jovian %d", *overrides.OverrideOptimismIsthmus, *overrides.OverrideOptimismJovian)
+	}
+	schedule["london"] = 1
+	if err := schedule.Apply(&overrides); err == nil {
+		t.Fatal("unsupported fork applied")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// frameJournal is the change log shared by the tracers collecting data about a
+// block as it is processed. It tracks the transaction being executed and the
+// open call frames, so that the changes recorded within a frame can be undone
+// if the frame reverts.
+type frameJournal[T any] struct {
+	changes []T
+	frames  []int // Positions of the first change of the open frames
+	tx      int   // Index of the transaction being executed, -1 outside of transactions
+
+	// revert is invoked with the changes of a reverted frame. If nil, the
+	// changes are dropped from the journal.
+	revert func(changes []T)
+
+	// enter is invoked after a frame is opened, if set.
+	enter func(depth int, typ byte, from common.Address, to common.Address, value *big.Int)
+}
+
+// newFrameJournal creates a change journal, chained after the given hooks if
+// non-nil. The tracer owning the journal installs its recording hooks on the
+// returned ones.
+func newFrameJournal[T any](hooks *tracing.Hooks) (*frameJournal[T], *tracing.Hooks) {
+	j := &frameJournal[T]{tx: -1}
+
+	var wrapped tracing.Hooks
+	if hooks != nil {
+		wrapped = *hooks
+	}
+	var (
+		onTxStart = wrapped.OnTxStart
+		onTxEnd   = wrapped.OnTxEnd
+		onEnter   = wrapped.OnEnter
+		onExit    = wrapped.OnExit
+		count     int
+	)
+	wrapped.OnTxStart = func(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+		j.tx, count = count, count+1
+		j.frames = j.frames[:0]
+		if onTxStart != nil {
+			onTxStart(env, tx, from)
+		}
+	}
+	wrapped.OnTxEnd = func(receipt *types.Receipt, err error) {
+		j.tx = -1
+		if onTxEnd != nil {
+			onTxEnd(receipt, err)
+		}
+	}
+	wrapped.OnEnter = func(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+		j.frames = append(j.frames, len(j.changes))
+		if j.enter != nil {
+			j.enter(depth, typ, from, to, value)
+		}
+		if onEnter != nil {
+			onEnter(depth, typ, from, to, input, gas, value)
+		}
+	}
+	wrapped.OnExit = func(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+		j.exit(reverted)
+		if onExit != nil {
+			onExit(depth, output, gasUsed, err, reverted)
+		}
+	}
+	return j, &wrapped
+}
+
+// txIndex returns the index of the transaction being executed, or
+// math.MaxUint32 outside of transactions.
+func (j *frameJournal[T]) txIndex() uint32 {
+	if j.tx < 0 {
+		return math.MaxUint32
+	}
+	return uint32(j.tx)
+}
+
+// record appends a change to the journal.
+func (j *frameJournal[T]) record(change T) {
+	j.changes = append(j.changes, change)
+}
+
+// keep moves the start of the innermost open frame after the changes recorded
+// so far, so that they persist even if the frame reverts. This is needed for
+// the changes made within a frame before its state snapshot is taken, such as
+// the nonce bump of a contract creator.
+func (j *frameJournal[T]) keep() {
+	if len(j.frames) > 0 {
+		j.frames[len(j.frames)-1] = len(j.changes)
+	}
+}
+
+func (j *frameJournal[T]) exit(reverted bool) {
+	if len(j.frames) == 0 {
+		return
+	}
+	start := j.frames[len(j.frames)-1]
+	j.frames = j.frames[:len(j.frames)-1]
+	if !reverted {
+		return
+	}
+	if j.revert != nil {
+		j.revert(j.changes[start:])
+	} else {
+		j.changes = j.changes[:start]
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Tests that the frame journal undoes the changes of reverted frames, both by
+// dropping and by flagging them, and that kept changes survive the revert.
+func TestFrameJournal(t *testing.T) {
+	var (
+		flagged []string
+		tests   = []struct {
+			flag bool
+			want []string
+		}{
+			{false, []string{"sys", "a", "b-nonce", "e"}},
+			{true, []string{"sys", "a", "b-nonce", "b", "c", "d", "e"}},
+		}
+	)
+	for _, tt := range tests {
+		journal, hooks := newFrameJournal[string](nil)
+		if tt.flag {
+			flagged = flagged[:0]
+			journal.revert = func(changes []string) {
+				flagged = append(flagged, changes...)
+			}
+		}
+		// A change outside of transactions, in a system call
+		hooks.OnEnter(0, 0, common.Address{}, common.Address{}, nil, 0, nil)
+		journal.record("sys")
+		if have := journal.txIndex(); have != math.MaxUint32 {
+			t.Fatalf("tx index outside of transactions mismatch: have %d, want %d", have, uint32(math.MaxUint32))
+		}
+		hooks.OnExit(0, nil, 0, nil, false)
+
+		hooks.OnTxStart(nil, nil, common.Address{})
+		if have := journal.txIndex(); have != 0 {
+			t.Fatalf("tx index mismatch: have %d, want 0", have)
+		}
+		hooks.OnEnter(0, 0, common.Address{}, common.Address{}, nil, 0, nil)
+		journal.record("a")
+
+		// A reverted frame with a change made before its snapshot
+		hooks.OnEnter(1, 0, common.Address{}, common.Address{}, nil, 0, nil)
+		journal.record("b-nonce")
+		journal.keep()
+		journal.record("b")
+
+		// A successful frame nested in the reverted one
+		hooks.OnEnter(2, 0, common.Address{}, common.Address{}, nil, 0, nil)
+		journal.record("c")
+		hooks.OnExit(2, nil, 0, nil, false)
+
+		journal.record("d")
+		hooks.OnExit(1, nil, 0, nil, true)
+
+		journal.record("e")
+		hooks.OnExit(0, nil, 0, nil, false)
+		hooks.OnTxEnd(nil, nil)
+
+		if !reflect.DeepEqual(journal.changes, tt.want) {
+			t.Errorf("flag %v: changes mismatch: have %v, want %v", tt.flag, journal.changes, tt.want)
+		}
+		if tt.flag {
+			if want := []string{"b", "c", "d"}; !reflect.DeepEqual(flagged, want) {
+				t.Errorf("flagged changes mismatch: have %v, want %v", flagged, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// Artifact is a compiled contract to predeploy.
+type Artifact struct {
+	Code   []byte         // Runtime bytecode of the contract
+	Layout *StorageLayout // Storage layout of the contract, nil if not available
+}
+
+// artifactJSON covers the artifact formats of the common toolchains: Foundry
+// and Hardhat artifacts, and the per-contract output of solc standard JSON.
+type artifactJSON struct {
+	DeployedBytecode json.RawMessage `json:"deployedBytecode"`
+	EVM              *struct {
+		DeployedBytecode json.RawMessage `json:"deployedBytecode"`
+	} `json:"evm"`
+	StorageLayout *StorageLayout `json:"storageLayout"`
+}
+
+// LoadArtifact reads a compiled contract artifact from the given file.
+func LoadArtifact(path string) (*Artifact, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var dec artifactJSON
+	if err := json.Unmarshal(blob, &dec); err != nil {
+		return nil, fmt.Errorf("invalid artifact %s: %w", path, err)
+	}
+	bytecode := dec.DeployedBytecode
+	if bytecode == nil && dec.EVM != nil {
+		bytecode = dec.EVM.DeployedBytecode
+	}
+	code, err := decodeBytecode(bytecode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact %s: %w", path, err)
+	}
+	return &Artifact{Code: code, Layout: dec.StorageLayout}, nil
+}
+
+// decodeBytecode decodes the deployed bytecode of an artifact, which is either
+// a hex string or an object with the hex string in its "object" field.
+func decodeBytecode(raw json.RawMessage) ([]byte, error) {
+	if raw == nil {
+		return nil, errors.New("missing deployed bytecode")
+	}
+	var object string
+	if err := json.Unmarshal(raw, &object); err != nil {
+		var dec struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(raw, &dec); err != nil {
+			return nil, fmt.Errorf("invalid deployed bytecode: %w", err)
+		}
+		object = dec.Object
+	}
+	if strings.Contains(object, "__") {
+		return nil, errors.New("deployed bytecode has unlinked library references")
+	}
+	code := common.FromHex(object)
+	if len(code) == 0 {
+		return nil, errors.New("empty deployed bytecode")
+	}
+	return code, nil
+}
+
+// StorageLayout is the storage layout of a contract as emitted by solc.
+type StorageLayout struct {
+	Storage []StorageVariable      `json:"storage"`
+	Types   map[string]StorageType `json:"types"`
+}
+
+// StorageVariable is a state variable of a contract.
+type StorageVariable struct {
+	Label  string `json:"label"`
+	Offset int    `json:"offset"` // Offset in bytes within the slot, from the right
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+// StorageType describes the encoding of a state variable type.
+type StorageType struct {
+	Encoding      string `json:"encoding"`
+	Label         string `json:"label"`
+	NumberOfBytes string `json:"numberOfBytes"`
+}
+
+// Set encodes the value of the labelled state variable into the given storage,
+// merging it with the variables packed in the same slot. Only the value types
+// stored in place are supported: addresses, contracts, booleans, enums, signed
+// and unsigned integers and fixed size byte arrays.
+func (l *StorageLayout) Set(storage map[common.Hash]common.Hash, label string, value string) error {
+	var variable *StorageVariable
+	for i := range l.Storage {
+		if l.Storage[i].Label == label {
+			variable = &l.Storage[i]
+			break
+		}
+	}
+	if variable == nil {
+		return fmt.Errorf("unknown state variable %q", label)
+	}
+	typ, ok := l.Types[variable.Type]
+	if !ok {
+		return fmt.Errorf("unknown type %q of state variable %q", variable.Type, label)
+	}
+	if typ.Encoding != "inplace" {
+		return fmt.Errorf("unsupported %s encoding of state variable %q", typ.Encoding, label)
+	}
+	size, err := strconv.Atoi(typ.NumberOfBytes)
+	if err != nil || size < 1 || size > 32 || variable.Offset < 0 || variable.Offset+size > 32 {
+		return fmt.Errorf("invalid size of state variable %q", label)
+	}
+	slot, ok := math.ParseBig256(variable.Slot)
+	if !ok {
+		return fmt.Errorf("invalid slot %q of state variable %q", variable.Slot, label)
+	}
+	encoded, err := encodeValue(typ.Label, size, value)
+	if err != nil {
+		return fmt.Errorf("invalid value of state variable %q: %w", label, err)
+	}
+	key := common.BigToHash(slot)
+	word := storage[key]
+	copy(word[32-variable.Offset-size:32-variable.Offset], encoded)
+	storage[key] = word
+	return nil
+}
+
+// encodeValue encodes a value of the given type into its big endian storage
+// representation of the given size.
+func encodeValue(typ string, size int, value string) ([]byte, error) {
+	switch {
+	case typ == "address" || typ == "address payable" || strings.HasPrefix(typ, "contract "):
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address %q", value)
+		}
+		return common.HexToAddress(value).Bytes(), nil
+
+	case typ == "bool":
+		switch value {
+		case "true":
+			return []byte{1}, nil
+		case "false":
+			return []byte{0}, nil
+		}
+		return nil, fmt.Errorf("invalid boolean %q", value)
+
+	case strings.HasPrefix(typ, "uint") || strings.HasPrefix(typ, "enum "):
+		n, ok := math.ParseBig256(value)
+		if !ok || n.BitLen() > size*8 {
+			return nil, fmt.Errorf("invalid %d byte unsigned integer %q", size, value)
+		}
+		return math.PaddedBigBytes(n, size), nil
+
+	case strings.HasPrefix(typ, "int"):
+		negative := strings.HasPrefix(value, "-")
+		n, ok := math.ParseBig256(strings.TrimPrefix(value, "-"))
+		if !ok || n.BitLen() > size*8-1 && !(negative && n.Cmp(new(big.Int).Lsh(common.Big1, uint(size*8-1))) == 0) {
+			return nil, fmt.Errorf("invalid %d byte signed integer %q", size, value)
+		}
+		if negative && n.Sign() != 0 {
+			// Two's complement within the size of the type
+			n = new(big.Int).Sub(new(big.Int).Lsh(common.Big1, uint(size*8)), n)
+		}
+		return math.PaddedBigBytes(n, size), nil
+
+	case strings.HasPrefix(typ, "bytes"):
+		b, err := hexutil.Decode(value)
+		if err != nil || len(b) > size {
+			return nil, fmt.Errorf("invalid %d byte array %q", size, value)
+		}
+		return common.RightPadBytes(b, size), nil
+	}
+	return nil, fmt.Errorf("unsupported type %q", typ)
+}
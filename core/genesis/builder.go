@@ -0,0 +1,205 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package genesis implements a builder composing genesis specifications out of
+// a base genesis, compiled contract artifacts and deterministic allocations.
+package genesis
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Builder composes the allocation of a genesis specification.
+type Builder struct {
+	genesis *core.Genesis
+}
+
+// NewBuilder creates a builder extending the given base genesis, which is left
+// unmodified.
+func NewBuilder(base *core.Genesis) *Builder {
+	genesis := *base
+	genesis.Alloc = make(types.GenesisAlloc, len(base.Alloc))
+	for addr, account := range base.Alloc {
+		genesis.Alloc[addr] = account
+	}
+	return &Builder{genesis: &genesis}
+}
+
+// AddAccount allocates the given account, failing if the address is already
+// allocated.
+func (b *Builder) AddAccount(addr common.Address, account types.Account) error {
+	if _, ok := b.genesis.Alloc[addr]; ok {
+		return fmt.Errorf("account %v allocated twice", addr)
+	}
+	if account.Balance == nil {
+		account.Balance = new(big.Int)
+	}
+	b.genesis.Alloc[addr] = account
+	return nil
+}
+
+// Predeploy allocates a contract with the runtime code of the given artifact,
+// initializing its state variables to the given values, keyed by label.
+func (b *Builder) Predeploy(addr common.Address, artifact *Artifact, variables map[string]string, balance *big.Int, nonce uint64) error {
+	account := types.Account{
+		Code:    artifact.Code,
+		Balance: balance,
+		Nonce:   nonce,
+	}
+	if len(variables) > 0 {
+		if artifact.Layout == nil {
+			return fmt.Errorf("predeploy %v: artifact has no storage layout", addr)
+		}
+		account.Storage = make(map[common.Hash]common.Hash)
+		for label, value := range variables {
+			if err := artifact.Layout.Set(account.Storage, label, value); err != nil {
+				return fmt.Errorf("predeploy %v: %w", addr, err)
+			}
+		}
+	}
+	return b.AddAccount(addr, account)
+}
+
+// DeriveAccounts allocates count accounts with the given balance, derived from
+// the seed. The private key of the i-th account is keccak256(seed || i), with
+// i encoded as 8 byte big endian. The derived addresses are returned in order.
+func (b *Builder) DeriveAccounts(seed string, count int, balance *big.Int) ([]common.Address, error) {
+	addrs := make([]common.Address, 0, count)
+	for i := 0; i < count; i++ {
+		key, err := DeriveKey(seed, uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		if err := b.AddAccount(addr, types.Account{Balance: new(big.Int).Set(balance)}); err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// DeriveKey returns the private key of the index-th account derived from seed.
+func DeriveKey(seed string, index uint64) (*ecdsa.PrivateKey, error) {
+	return crypto.ToECDSA(crypto.Keccak256([]byte(seed), binary.BigEndian.AppendUint64(nil, index)))
+}
+
+// Genesis returns the composed genesis specification.
+func (b *Builder) Genesis() *core.Genesis {
+	return b.genesis
+}
+
+// Manifest describes a genesis specification to compose. Relative paths are
+// resolved against the directory of the manifest.
+type Manifest struct {
+	Base       string                              `json:"base"` // Genesis file to extend
+	Accounts   map[common.Address]ManifestAccount  `json:"accounts"`
+	Derive     []ManifestDerivation                `json:"derive"`
+	Predeploys map[common.Address]ManifestContract `json:"predeploys"`
+}
+
+// ManifestAccount is a plain account allocation of a manifest.
+type ManifestAccount struct {
+	Balance *math.HexOrDecimal256 `json:"balance"`
+	Nonce   uint64                `json:"nonce"`
+}
+
+// ManifestDerivation is a set of deterministically derived accounts of a manifest.
+type ManifestDerivation struct {
+	Seed    string                `json:"seed"`
+	Count   int                   `json:"count"`
+	Balance *math.HexOrDecimal256 `json:"balance"`
+}
+
+// ManifestContract is a predeployed contract of a manifest.
+type ManifestContract struct {
+	Artifact string                `json:"artifact"` // Compiled contract artifact
+	Storage  map[string]string     `json:"storage"`  // State variable values, by label
+	Balance  *math.HexOrDecimal256 `json:"balance"`
+	Nonce    uint64                `json:"nonce"`
+}
+
+// Build composes the genesis specification described by the manifest file.
+func Build(path string) (*core.Genesis, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(blob, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	if manifest.Base == "" {
+		return nil, errors.New("manifest has no base genesis")
+	}
+	dir := filepath.Dir(path)
+	resolve := func(file string) string {
+		if filepath.IsAbs(file) {
+			return file
+		}
+		return filepath.Join(dir, file)
+	}
+	blob, err = os.ReadFile(resolve(manifest.Base))
+	if err != nil {
+		return nil, err
+	}
+	base := new(core.Genesis)
+	if err := json.Unmarshal(blob, base); err != nil {
+		return nil, fmt.Errorf("invalid base genesis %s: %w", manifest.Base, err)
+	}
+	builder := NewBuilder(base)
+	for addr, account := range manifest.Accounts {
+		if err := builder.AddAccount(addr, types.Account{Balance: toBig(account.Balance), Nonce: account.Nonce}); err != nil {
+			return nil, err
+		}
+	}
+	for _, derive := range manifest.Derive {
+		if _, err := builder.DeriveAccounts(derive.Seed, derive.Count, toBig(derive.Balance)); err != nil {
+			return nil, err
+		}
+	}
+	for addr, contract := range manifest.Predeploys {
+		artifact, err := LoadArtifact(resolve(contract.Artifact))
+		if err != nil {
+			return nil, err
+		}
+		if err := builder.Predeploy(addr, artifact, contract.Storage, toBig(contract.Balance), contract.Nonce); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Genesis(), nil
+}
+
+// toBig converts an optional manifest amount, defaulting to zero.
+func toBig(n *math.HexOrDecimal256) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set((*big.Int)(n))
+}
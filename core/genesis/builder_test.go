@@ -0,0 +1,108 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package genesis
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestBuild(t *testing.T) {
+	genesis, err := Build("testdata/manifest.json")
+	if err != nil {
+		t.Fatalf("failed to build genesis: %v", err)
+	}
+	if genesis.Config == nil || genesis.Config.ChainID.Uint64() != 1337 {
+		t.Fatal("base genesis config not carried over")
+	}
+	if len(genesis.Alloc) != 6 {
+		t.Fatalf("wrong number of allocations: have %d, want 6", len(genesis.Alloc))
+	}
+	if account := genesis.Alloc[common.HexToAddress("0x2000000000000000000000000000000000000002")]; account.Balance.Uint64() != 1000 || account.Nonce != 1 {
+		t.Fatalf("wrong plain account: balance %v, nonce %d", account.Balance, account.Nonce)
+	}
+	for i := uint64(0); i < 3; i++ {
+		key, _ := DeriveKey("test", i)
+		account, ok := genesis.Alloc[crypto.PubkeyToAddress(key.PublicKey)]
+		if !ok || account.Balance.Cmp(big.NewInt(1e18)) != 0 {
+			t.Fatalf("derived account %d not allocated", i)
+		}
+	}
+	token := genesis.Alloc[common.HexToAddress("0x4200000000000000000000000000000000000042")]
+	if !bytes.Equal(token.Code, common.FromHex("0x6080604052600080fd")) {
+		t.Fatalf("wrong predeploy code: %x", token.Code)
+	}
+	want := map[common.Hash]common.Hash{
+		// decimals (18) | paused (true) | owner
+		common.BigToHash(big.NewInt(0)): common.HexToHash("0x0000000000000000000012013000000000000000000000000000000000000003"),
+		common.BigToHash(big.NewInt(1)): common.HexToHash("0x100"),
+		// tag | delta (-1)
+		common.BigToHash(big.NewInt(2)): common.HexToHash("0x00000000000000000000000012345678ffffffffffffffffffffffffffffffff"),
+	}
+	if len(token.Storage) != len(want) {
+		t.Fatalf("wrong number of storage slots: have %d, want %d", len(token.Storage), len(want))
+	}
+	for slot, value := range want {
+		if token.Storage[slot] != value {
+			t.Errorf("slot %x: have %x, want %x", slot, token.Storage[slot], value)
+		}
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	base := &core.Genesis{Alloc: types.GenesisAlloc{
+		common.HexToAddress("0x01"): {Balance: big.NewInt(1)},
+	}}
+	builder := NewBuilder(base)
+	if err := builder.AddAccount(common.HexToAddress("0x01"), types.Account{}); err == nil {
+		t.Fatal("duplicate allocation accepted")
+	}
+	if err := builder.AddAccount(common.HexToAddress("0x02"), types.Account{}); err != nil {
+		t.Fatalf("failed to add account: %v", err)
+	}
+	if len(base.Alloc) != 1 {
+		t.Fatal("base genesis modified")
+	}
+	artifact, err := LoadArtifact("testdata/token.json")
+	if err != nil {
+		t.Fatalf("failed to load artifact: %v", err)
+	}
+	for _, tt := range []struct {
+		label, value string
+	}{
+		{"balances", "0x01"}, // mapping
+		{"unknown", "0x01"},  // not in layout
+		{"decimals", "256"},  // overflows uint8
+		{"delta", "0x" + "80000000000000000000000000000000"}, // overflows int128
+		{"paused", "yes"},
+		{"owner", "0x1234"},
+		{"tag", "0x1234567890"}, // overflows bytes4
+	} {
+		if err := artifact.Layout.Set(make(map[common.Hash]common.Hash), tt.label, tt.value); err == nil {
+			t.Errorf("%s = %s: value accepted", tt.label, tt.value)
+		}
+	}
+	if err := builder.Predeploy(common.HexToAddress("0x03"), &Artifact{Code: []byte{0x00}}, map[string]string{"owner": "0x01"}, nil, 0); err == nil {
+		t.Fatal("storage set without layout")
+	}
+}
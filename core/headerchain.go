@@ -55,7 +55,7 @@ const (
 // It is not thread safe, the encapsulating chain structures should do the
 // necessary mutex locking/unlocking.
 type HeaderChain struct {
-	config        *params.ChainConfig
+	config        atomic.Pointer[params.ChainConfig]
 	chainDb       ethdb.Database
 	genesisHeader *types.Header
 
@@ -73,13 +73,13 @@ type HeaderChain struct {
 // to the parent's interrupt semaphore.
 func NewHeaderChain(chainDb ethdb.Database, config *params.ChainConfig, engine consensus.Engine, procInterrupt func() bool) (*HeaderChain, error) {
 	hc := &HeaderChain{
-		config:        config,
 		chainDb:       chainDb,
 		headerCache:   lru.NewCache[common.Hash, *types.Header](headerCacheLimit),
 		numberCache:   lru.NewCache[common.Hash, uint64](numberCacheLimit),
 		procInterrupt: procInterrupt,
 		engine:        engine,
 	}
+	hc.config.Store(config)
 	hc.genesisHeader = hc.GetHeaderByNumber(0)
 	if hc.genesisHeader == nil {
 		return nil, ErrNoGenesis
@@ -614,7 +614,7 @@ func (hc *HeaderChain) SetGenesis(head *types.Header) {
 }
 
 // Config retrieves the header chain's chain configuration.
-func (hc *HeaderChain) Config() *params.ChainConfig { return hc.config }
+func (hc *HeaderChain) Config() *params.ChainConfig { return hc.config.Load() }
 
 // Engine retrieves the header chain's consensus engine.
 func (hc *HeaderChain) Engine() consensus.Engine { return hc.engine }
@@ -0,0 +1,225 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// internalTxTracer collects the internal transactions of a block as it is
+// processed. It only records the call frames below the top level call of the
+// transactions, leaving out the system calls made outside of them. The internal
+// transactions of reverted frames are kept, but flagged as reverted.
+type internalTxTracer struct {
+	*frameJournal[*types.InternalTx]
+	salt *common.Hash // Salt of the CREATE2 being entered, if salts are traced
+}
+
+// newInternalTxTracer creates an internal transaction collector, chained after
+// the given hooks if non-nil. The salts of the CREATE2 frames are only traced
+// if requested, as they need the opcode hook.
+func newInternalTxTracer(hooks *tracing.Hooks, salts bool) (*internalTxTracer, *tracing.Hooks) {
+	journal, wrapped := newFrameJournal[*types.InternalTx](hooks)
+
+	t := &internalTxTracer{frameJournal: journal}
+	journal.enter = t.onEnter
+	journal.revert = func(txs []*types.InternalTx) {
+		for _, tx := range txs {
+			tx.Reverted = true
+		}
+	}
+	if salts {
+		onOpcode := wrapped.OnOpcode
+		wrapped.OnOpcode = func(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+			if vm.OpCode(op) == vm.CREATE2 && t.tx >= 0 && err == nil {
+				if stack := scope.StackData(); len(stack) >= 4 {
+					salt := common.Hash(stack[len(stack)-4].Bytes32())
+					t.salt = &salt
+				}
+			}
+			if onOpcode != nil {
+				onOpcode(pc, op, gas, cost, scope, rData, depth, err)
+			}
+		}
+	}
+	return t, wrapped
+}
+
+func (t *internalTxTracer) onEnter(depth int, typ byte, from common.Address, to common.Address, value *big.Int) {
+	if t.tx < 0 || depth == 0 {
+		return
+	}
+	switch vm.OpCode(typ) {
+	case vm.CALL, vm.CALLCODE:
+		if value == nil || value.Sign() == 0 {
+			return
+		}
+	case vm.CREATE, vm.CREATE2, vm.SELFDESTRUCT:
+	default:
+		return
+	}
+	if value == nil {
+		value = new(big.Int)
+	}
+	tx := &types.InternalTx{
+		TxIndex: uint32(t.tx),
+		Op:      typ,
+		From:    from,
+		To:      to,
+		Value:   new(big.Int).Set(value),
+		Depth:   uint32(depth),
+	}
+	if vm.OpCode(typ) == vm.CREATE2 {
+		tx.Salt, t.salt = t.salt, nil
+	}
+	t.record(tx)
+}
+
+// InternalTxIndexer is the module maintaining the account -> internal
+// transaction index of the canonical chain, within the configured retention
+// window. The internal transactions are traced when the blocks are processed,
+// which requires CacheConfig.InternalTxs, and indexed under both the sender and
+// the recipient as the blocks become canonical.
+//
+// Since a crash might leave dangling entries behind, readers must use
+// InternalTxs which cross checks every entry against the canonical chain.
+type InternalTxIndexer struct {
+	*chainIndexer
+
+	chain *BlockChain
+	db    ethdb.Database
+}
+
+// IndexedInternalTx is an internal transaction retrieved from the index.
+type IndexedInternalTx struct {
+	*types.InternalTx
+	Number    uint64      // Number of the block containing the internal transaction
+	BlockHash common.Hash // Hash of the block containing the internal transaction
+	Position  uint32      // Position of the internal transaction within the block
+	TxHash    common.Hash // Hash of the transaction performing the internal transaction
+}
+
+// NewInternalTxIndexer initializes the internal transaction indexer and starts
+// its background loop.
+func NewInternalTxIndexer(chain *BlockChain, limit uint64) *InternalTxIndexer {
+	indexer := &InternalTxIndexer{
+		chain: chain,
+		db:    chain.db,
+	}
+	indexer.chainIndexer = newChainIndexer(chain, rawdb.InternalTxIndexName, "internal transactions", limit, indexer)
+	return indexer
+}
+
+// InternalTxs retrieves at most limit canonical internal transactions of the
+// given account, in chain order, starting at the given block number and
+// position (inclusive) and ending with the block to (inclusive). The position
+// to continue the iteration from is returned too, or nil if there are no more
+// internal transactions.
+func (indexer *InternalTxIndexer) InternalTxs(address common.Address, number uint64, position uint32, to uint64, limit int) ([]*IndexedInternalTx, *rawdb.InternalTxEntry) {
+	var (
+		blocks  = make(map[uint64][]*IndexedInternalTx)
+		results []*IndexedInternalTx
+	)
+	for {
+		entries := rawdb.ReadInternalTxEntries(indexer.db, address, number, position, limit)
+		for _, entry := range entries {
+			if entry.Number > to {
+				return results, nil
+			}
+			txs, ok := blocks[entry.Number]
+			if !ok {
+				if rawdb.ReadCanonicalHash(indexer.db, entry.Number) == entry.Hash {
+					txs = BlockInternalTxs(indexer.db, entry.Hash, entry.Number)
+				}
+				blocks[entry.Number] = txs
+			}
+			if int(entry.Position) >= len(txs) {
+				continue
+			}
+			if len(results) == limit {
+				return results, &entry
+			}
+			results = append(results, txs[entry.Position])
+		}
+		if len(entries) < limit {
+			return results, nil
+		}
+		// Continue right after the last retrieved entry
+		last := entries[len(entries)-1]
+		number, position = last.Number, last.Position+1
+		if position == 0 {
+			number++
+		}
+	}
+}
+
+// BlockInternalTxs retrieves the internal transactions of the given block, as
+// traced when it was processed. Nil is returned if the block wasn't traced.
+func BlockInternalTxs(db ethdb.Reader, hash common.Hash, number uint64) []*IndexedInternalTx {
+	if !rawdb.HasInternalTxs(db, hash, number) {
+		return nil
+	}
+	body := rawdb.ReadBody(db, hash, number)
+	if body == nil {
+		return nil
+	}
+	var (
+		txs     = rawdb.ReadInternalTxs(db, hash, number)
+		results = make([]*IndexedInternalTx, 0, len(txs))
+	)
+	for i, tx := range txs {
+		result := &IndexedInternalTx{
+			InternalTx: tx,
+			Number:     number,
+			BlockHash:  hash,
+			Position:   uint32(i),
+		}
+		if int(tx.TxIndex) < len(body.Transactions) {
+			result.TxHash = body.Transactions[tx.TxIndex].Hash()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// update adds or removes the internal transactions of the given block to the
+// index.
+func (indexer *InternalTxIndexer) update(batch ethdb.KeyValueWriter, block *types.Block, add bool) {
+	var (
+		number = block.NumberU64()
+		hash   = block.Hash()
+	)
+	for i, tx := range rawdb.ReadInternalTxs(indexer.db, hash, number) {
+		position := uint32(i)
+		if add {
+			rawdb.WriteInternalTxEntry(batch, tx.From, number, position, hash)
+			if tx.To != tx.From {
+				rawdb.WriteInternalTxEntry(batch, tx.To, number, position, hash)
+			}
+		} else {
+			rawdb.DeleteInternalTxEntry(batch, tx.From, number, position)
+			rawdb.DeleteInternalTxEntry(batch, tx.To, number, position)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// forwarderCode returns the code of a contract forwarding the call value to
+// the given recipient, reverting afterwards if requested.
+func forwarderCode(recipient common.Address, revert bool) []byte {
+	code := []byte{
+		byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00,
+		byte(vm.CALLVALUE), byte(vm.PUSH20),
+	}
+	code = append(code, recipient.Bytes()...)
+	code = append(code, byte(vm.GAS), byte(vm.CALL), byte(vm.POP))
+	if revert {
+		return append(code, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.REVERT))
+	}
+	return append(code, byte(vm.STOP))
+}
+
+func TestInternalTxIndexer(t *testing.T) {
+	var (
+		key, _    = crypto.GenerateKey()
+		addr      = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.Address{0xaa}
+		forwarder = common.Address{0xf0}
+		reverter  = common.Address{0xf1}
+		gspec     = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr:      {Balance: big.NewInt(params.Ether)},
+				forwarder: {Code: forwarderCode(recipient, false)},
+				reverter:  {Code: forwarderCode(recipient, true)},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = ethash.NewFaker()
+	)
+	// Forward value in block 1, forward and revert in block 2 and forward no
+	// value at all in block 3.
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 3, func(i int, gen *BlockGen) {
+		to, value := forwarder, int64(5)
+		switch i {
+		case 1:
+			to, value = reverter, 7
+		case 2:
+			value = 0
+		}
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, big.NewInt(value), 100000, gen.BaseFee(), nil), signer, key)
+		gen.AddTx(tx)
+	})
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.InternalTxs = true
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	indexer := NewInternalTxIndexer(chain, 0)
+	defer indexer.Close()
+	waitChainIndex(t, chain, rawdb.InternalTxIndexName)
+
+	txs, next := indexer.InternalTxs(recipient, 0, 0, math.MaxUint64, 10)
+	if len(txs) != 2 || next != nil {
+		t.Fatalf("wrong number of internal transactions: have %d, want 2", len(txs))
+	}
+	first, second := txs[0], txs[1]
+	if first.Number != 1 || first.From != forwarder || first.To != recipient || first.Value.Int64() != 5 || vm.OpCode(first.Op) != vm.CALL || first.Depth != 1 || first.Reverted {
+		t.Fatalf("wrong first internal transaction: %+v", first.InternalTx)
+	}
+	if first.TxHash != blocks[0].Transactions()[0].Hash() {
+		t.Fatalf("wrong transaction hash: have %x, want %x", first.TxHash, blocks[0].Transactions()[0].Hash())
+	}
+	if second.Number != 2 || second.From != reverter || second.Value.Int64() != 7 || !second.Reverted {
+		t.Fatalf("wrong second internal transaction: %+v", second.InternalTx)
+	}
+	// Paginate one by one
+	txs, next = indexer.InternalTxs(recipient, 0, 0, math.MaxUint64, 1)
+	if len(txs) != 1 || next == nil || next.Number != 2 {
+		t.Fatalf("wrong first page: %d internal transactions, next %+v", len(txs), next)
+	}
+	if txs, _ := indexer.InternalTxs(forwarder, 0, 0, math.MaxUint64, 10); len(txs) != 1 {
+		t.Fatalf("wrong number of internal transactions of the forwarder: have %d, want 1", len(txs))
+	}
+	if txs, _ := indexer.InternalTxs(recipient, 0, 0, 1, 10); len(txs) != 1 {
+		t.Fatalf("wrong number of internal transactions up to block 1: have %d, want 1", len(txs))
+	}
+	// Blocks without internal transactions are traced nonetheless
+	if txs := BlockInternalTxs(chain.db, blocks[2].Hash(), 3); txs == nil || len(txs) != 0 {
+		t.Fatalf("wrong internal transactions of block 3: %v", txs)
+	}
+	// Rewinding the chain drops the internal transactions of the removed blocks
+	if err := chain.SetHead(1); err != nil {
+		t.Fatalf("failed to rewind chain: %v", err)
+	}
+	waitChainIndex(t, chain, rawdb.InternalTxIndexName)
+	if txs, _ := indexer.InternalTxs(recipient, 0, 0, math.MaxUint64, 10); len(txs) != 1 {
+		t.Fatalf("wrong number of internal transactions after rewind: have %d, want 1", len(txs))
+	}
+}
@@ -627,6 +627,234 @@ func DeleteReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	}
 }
 
+// ReadTxFees retrieves the fee breakdowns of the transactions of a block, as
+// computed when the block was processed. Nil is returned for blocks which were
+// not processed locally, such as snap synced ones.
+func ReadTxFees(db ethdb.KeyValueReader, hash common.Hash, number uint64) []*types.TxFees {
+	data, _ := db.Get(blockFeesKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var fees []*types.TxFees
+	if err := rlp.DecodeBytes(data, &fees); err != nil {
+		log.Error("Invalid transaction fees RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return fees
+}
+
+// WriteTxFees stores the fee breakdowns of the transactions of a block.
+func WriteTxFees(db ethdb.KeyValueWriter, hash common.Hash, number uint64, fees []*types.TxFees) {
+	data, err := rlp.EncodeToBytes(fees)
+	if err != nil {
+		log.Crit("Failed to encode transaction fees", "err", err)
+	}
+	if err := db.Put(blockFeesKey(number, hash), data); err != nil {
+		log.Crit("Failed to store transaction fees", "err", err)
+	}
+}
+
+// DeleteTxFees removes the fee breakdowns of the transactions of a block.
+func DeleteTxFees(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockFeesKey(number, hash)); err != nil {
+		log.Crit("Failed to delete transaction fees", "err", err)
+	}
+}
+
+// ReadInternalTxs retrieves the internal transactions of a block, as traced
+// when the block was processed. Nil is returned for blocks which were not
+// traced, such as the ones processed before the tracing was enabled.
+func ReadInternalTxs(db ethdb.KeyValueReader, hash common.Hash, number uint64) []*types.InternalTx {
+	data, _ := db.Get(blockInternalKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var txs []*types.InternalTx
+	if err := rlp.DecodeBytes(data, &txs); err != nil {
+		log.Error("Invalid internal transactions RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return txs
+}
+
+// HasInternalTxs verifies the existence of the internal transactions of a block.
+func HasInternalTxs(db ethdb.KeyValueReader, hash common.Hash, number uint64) bool {
+	has, _ := db.Has(blockInternalKey(number, hash))
+	return has
+}
+
+// WriteInternalTxs stores the internal transactions of a block.
+func WriteInternalTxs(db ethdb.KeyValueWriter, hash common.Hash, number uint64, txs []*types.InternalTx) {
+	data, err := rlp.EncodeToBytes(txs)
+	if err != nil {
+		log.Crit("Failed to encode internal transactions", "err", err)
+	}
+	if err := db.Put(blockInternalKey(number, hash), data); err != nil {
+		log.Crit("Failed to store internal transactions", "err", err)
+	}
+}
+
+// DeleteInternalTxs removes the internal transactions of a block.
+func DeleteInternalTxs(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockInternalKey(number, hash)); err != nil {
+		log.Crit("Failed to delete internal transactions", "err", err)
+	}
+}
+
+// ReadBalanceChanges retrieves the balance and nonce changes of a block, as
+// traced when the block was processed. Nil is returned for blocks which were
+// not traced, such as the ones processed before the tracing was enabled.
+func ReadBalanceChanges(db ethdb.KeyValueReader, hash common.Hash, number uint64) []*types.BalanceChange {
+	data, _ := db.Get(blockBalancesKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var changes []*types.BalanceChange
+	if err := rlp.DecodeBytes(data, &changes); err != nil {
+		log.Error("Invalid balance changes RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return changes
+}
+
+// HasBalanceChanges verifies the existence of the balance changes of a block.
+func HasBalanceChanges(db ethdb.KeyValueReader, hash common.Hash, number uint64) bool {
+	has, _ := db.Has(blockBalancesKey(number, hash))
+	return has
+}
+
+// WriteBalanceChanges stores the balance and nonce changes of a block.
+func WriteBalanceChanges(db ethdb.KeyValueWriter, hash common.Hash, number uint64, changes []*types.BalanceChange) {
+	data, err := rlp.EncodeToBytes(changes)
+	if err != nil {
+		log.Crit("Failed to encode balance changes", "err", err)
+	}
+	if err := db.Put(blockBalancesKey(number, hash), data); err != nil {
+		log.Crit("Failed to store balance changes", "err", err)
+	}
+}
+
+// DeleteBalanceChanges removes the balance and nonce changes of a block.
+func DeleteBalanceChanges(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockBalancesKey(number, hash)); err != nil {
+		log.Crit("Failed to delete balance changes", "err", err)
+	}
+}
+
+// ReadSupply retrieves the native token supply change of a block, as tracked
+// when the block was processed. Nil is returned for blocks which were not
+// tracked, such as the ones processed before the tracking was enabled.
+func ReadSupply(db ethdb.KeyValueReader, hash common.Hash, number uint64) *types.Supply {
+	data, _ := db.Get(blockSupplyKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	supply := new(types.Supply)
+	if err := rlp.DecodeBytes(data, supply); err != nil {
+		log.Error("Invalid supply RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return supply
+}
+
+// WriteSupply stores the native token supply change of a block.
+func WriteSupply(db ethdb.KeyValueWriter, hash common.Hash, number uint64, supply *types.Supply) {
+	data, err := rlp.EncodeToBytes(supply)
+	if err != nil {
+		log.Crit("Failed to encode supply", "err", err)
+	}
+	if err := db.Put(blockSupplyKey(number, hash), data); err != nil {
+		log.Crit("Failed to store supply", "err", err)
+	}
+}
+
+// DeleteSupply removes the native token supply change of a block.
+func DeleteSupply(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockSupplyKey(number, hash)); err != nil {
+		log.Crit("Failed to delete supply", "err", err)
+	}
+}
+
+// ReadCodeChanges retrieves the code changes of a block, as traced when the
+// block was processed. Nil is returned for blocks which were not traced, such
+// as the ones processed before the tracing was enabled.
+func ReadCodeChanges(db ethdb.KeyValueReader, hash common.Hash, number uint64) []*types.CodeChange {
+	data, _ := db.Get(blockCodeKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var changes []*types.CodeChange
+	if err := rlp.DecodeBytes(data, &changes); err != nil {
+		log.Error("Invalid code changes RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return changes
+}
+
+// HasCodeChanges verifies the existence of the code changes of a block.
+func HasCodeChanges(db ethdb.KeyValueReader, hash common.Hash, number uint64) bool {
+	has, _ := db.Has(blockCodeKey(number, hash))
+	return has
+}
+
+// WriteCodeChanges stores the code changes of a block.
+func WriteCodeChanges(db ethdb.KeyValueWriter, hash common.Hash, number uint64, changes []*types.CodeChange) {
+	data, err := rlp.EncodeToBytes(changes)
+	if err != nil {
+		log.Crit("Failed to encode code changes", "err", err)
+	}
+	if err := db.Put(blockCodeKey(number, hash), data); err != nil {
+		log.Crit("Failed to store code changes", "err", err)
+	}
+}
+
+// DeleteCodeChanges removes the code changes of a block.
+func DeleteCodeChanges(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockCodeKey(number, hash)); err != nil {
+		log.Crit("Failed to delete code changes", "err", err)
+	}
+}
+
+// ReadPayloadRecord retrieves the encoded build record of a locally built block.
+func ReadPayloadRecord(db ethdb.KeyValueReader, hash common.Hash) []byte {
+	data, _ := db.Get(payloadRecordKey(hash))
+	return data
+}
+
+// WritePayloadRecord stores the encoded build record of a locally built block.
+func WritePayloadRecord(db ethdb.KeyValueWriter, hash common.Hash, record []byte) {
+	if err := db.Put(payloadRecordKey(hash), record); err != nil {
+		log.Crit("Failed to store payload record", "err", err)
+	}
+}
+
+// DeletePayloadRecord removes the build record of a locally built block.
+func DeletePayloadRecord(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(payloadRecordKey(hash)); err != nil {
+		log.Crit("Failed to delete payload record", "err", err)
+	}
+}
+
+// ReadBlockAccessList retrieves the encoded access lists of a locally built block.
+func ReadBlockAccessList(db ethdb.KeyValueReader, hash common.Hash) []byte {
+	data, _ := db.Get(accessListKey(hash))
+	return data
+}
+
+// WriteBlockAccessList stores the encoded access lists of a locally built block.
+func WriteBlockAccessList(db ethdb.KeyValueWriter, hash common.Hash, lists []byte) {
+	if err := db.Put(accessListKey(hash), lists); err != nil {
+		log.Crit("Failed to store block access list", "err", err)
+	}
+}
+
+// DeleteBlockAccessList removes the access lists of a locally built block.
+func DeleteBlockAccessList(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(accessListKey(hash)); err != nil {
+		log.Crit("Failed to delete block access list", "err", err)
+	}
+}
+
 // storedReceiptRLP is the storage encoding of a receipt.
 // Re-definition in core/types/receipt.go.
 // TODO: Re-use the existing definition.
@@ -772,6 +1000,13 @@ func WriteAncientHeaderChain(db ethdb.AncientWriter, headers []*types.Header) (i
 // DeleteBlock removes all block data associated with a hash.
 func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
+	DeleteTxFees(db, hash, number)
+	DeleteInternalTxs(db, hash, number)
+	DeleteBalanceChanges(db, hash, number)
+	DeleteSupply(db, hash, number)
+	DeleteCodeChanges(db, hash, number)
+	DeletePayloadRecord(db, hash)
+	DeleteBlockAccessList(db, hash)
 	DeleteHeader(db, hash, number)
 	DeleteBody(db, hash, number)
 }
@@ -128,6 +128,313 @@ func DeleteAllTxLookupEntries(db ethdb.KeyValueStore, condition func(common.Hash
 	}
 }
 
+// Names of the optional chain indexes, used to derive their metadata keys.
+const (
+	SenderIndexName     = "Sender"
+	TransferIndexName   = "Transfer"
+	InternalTxIndexName = "InternalTx"
+	CreationIndexName   = "ContractCreation"
+	CodeChangeIndexName = "CodeChange"
+)
+
+// SenderTxEntry is a single entry of the sender transaction index.
+type SenderTxEntry struct {
+	Number uint64      // Number of the block containing the transaction
+	Index  uint32      // Position of the transaction within the block
+	Hash   common.Hash // Hash of the transaction
+}
+
+// TransferEntry is a single entry of the token transfer index. Every transfer
+// is indexed under both the sender and the recipient account.
+type TransferEntry struct {
+	Number   uint64         `rlp:"-"` // Number of the block containing the transfer
+	LogIndex uint32         `rlp:"-"` // Position of the Transfer log within the block
+	TxIndex  uint32         // Position of the emitting transaction within the block
+	TxHash   common.Hash    // Hash of the emitting transaction
+	Token    common.Address // Address of the token contract
+	From     common.Address // Sender of the tokens
+	To       common.Address // Recipient of the tokens
+	Value    []byte         // Amount (ERC-20) or token id (ERC-721), big endian
+	NFT      bool           // Whether the transfer is of an ERC-721 token
+}
+
+// ReadSenderTxEntries retrieves at most limit entries of the sender transaction
+// index of the given account, in chain order, starting at the given block number
+// and transaction position (inclusive).
+func ReadSenderTxEntries(db ethdb.Iteratee, sender common.Address, number uint64, index uint32, limit int) []SenderTxEntry {
+	prefix := append(append([]byte{}, senderTxPrefix...), sender.Bytes()...)
+	start := senderTxKey(sender, number, index)[len(prefix):]
+
+	it := db.NewIterator(prefix, start)
+	defer it.Release()
+
+	var entries []SenderTxEntry
+	for len(entries) < limit && it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+4 || len(it.Value()) != common.HashLength {
+			continue
+		}
+		entries = append(entries, SenderTxEntry{
+			Number: binary.BigEndian.Uint64(key[len(prefix):]),
+			Index:  binary.BigEndian.Uint32(key[len(prefix)+8:]),
+			Hash:   common.BytesToHash(it.Value()),
+		})
+	}
+	return entries
+}
+
+// WriteSenderTxEntry stores a sender transaction index entry.
+func WriteSenderTxEntry(db ethdb.KeyValueWriter, sender common.Address, number uint64, index uint32, hash common.Hash) {
+	if err := db.Put(senderTxKey(sender, number, index), hash.Bytes()); err != nil {
+		log.Crit("Failed to store sender transaction entry", "err", err)
+	}
+}
+
+// DeleteSenderTxEntry removes a sender transaction index entry.
+func DeleteSenderTxEntry(db ethdb.KeyValueWriter, sender common.Address, number uint64, index uint32) {
+	if err := db.Delete(senderTxKey(sender, number, index)); err != nil {
+		log.Crit("Failed to delete sender transaction entry", "err", err)
+	}
+}
+
+// ReadTokenTransferEntries retrieves at most limit entries of the token transfer
+// index of the given account, in chain order, starting at the given block number
+// and log position (inclusive).
+func ReadTokenTransferEntries(db ethdb.Iteratee, address common.Address, number uint64, index uint32, limit int) []TransferEntry {
+	prefix := append(append([]byte{}, tokenTransferPrefix...), address.Bytes()...)
+	start := tokenTransferKey(address, number, index)[len(prefix):]
+
+	it := db.NewIterator(prefix, start)
+	defer it.Release()
+
+	var entries []TransferEntry
+	for len(entries) < limit && it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+4 {
+			continue
+		}
+		var entry TransferEntry
+		if err := rlp.DecodeBytes(it.Value(), &entry); err != nil {
+			log.Error("Invalid token transfer entry RLP", "address", address, "err", err)
+			continue
+		}
+		entry.Number = binary.BigEndian.Uint64(key[len(prefix):])
+		entry.LogIndex = binary.BigEndian.Uint32(key[len(prefix)+8:])
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// WriteTokenTransferEntry stores a token transfer index entry of the given account.
+func WriteTokenTransferEntry(db ethdb.KeyValueWriter, address common.Address, entry *TransferEntry) {
+	data, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		log.Crit("Failed to RLP encode token transfer entry", "err", err)
+	}
+	if err := db.Put(tokenTransferKey(address, entry.Number, entry.LogIndex), data); err != nil {
+		log.Crit("Failed to store token transfer entry", "err", err)
+	}
+}
+
+// DeleteTokenTransferEntry removes a token transfer index entry of the given account.
+func DeleteTokenTransferEntry(db ethdb.KeyValueWriter, address common.Address, number uint64, index uint32) {
+	if err := db.Delete(tokenTransferKey(address, number, index)); err != nil {
+		log.Crit("Failed to delete token transfer entry", "err", err)
+	}
+}
+
+// InternalTxEntry is a single entry of the internal transaction index. Every
+// internal transaction is indexed under both its sender and its recipient.
+type InternalTxEntry struct {
+	Number   uint64      // Number of the block containing the internal transaction
+	Position uint32      // Position of the internal transaction within the block
+	Hash     common.Hash // Hash of the block containing the internal transaction
+}
+
+// ReadInternalTxEntries retrieves at most limit entries of the internal
+// transaction index of the given account, in chain order, starting at the given
+// block number and position (inclusive).
+func ReadInternalTxEntries(db ethdb.Iteratee, address common.Address, number uint64, position uint32, limit int) []InternalTxEntry {
+	prefix := append(append([]byte{}, internalTxPrefix...), address.Bytes()...)
+	start := internalTxKey(address, number, position)[len(prefix):]
+
+	it := db.NewIterator(prefix, start)
+	defer it.Release()
+
+	var entries []InternalTxEntry
+	for len(entries) < limit && it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+4 || len(it.Value()) != common.HashLength {
+			continue
+		}
+		entries = append(entries, InternalTxEntry{
+			Number:   binary.BigEndian.Uint64(key[len(prefix):]),
+			Position: binary.BigEndian.Uint32(key[len(prefix)+8:]),
+			Hash:     common.BytesToHash(it.Value()),
+		})
+	}
+	return entries
+}
+
+// WriteInternalTxEntry stores an internal transaction index entry of the given
+// account.
+func WriteInternalTxEntry(db ethdb.KeyValueWriter, address common.Address, number uint64, position uint32, hash common.Hash) {
+	if err := db.Put(internalTxKey(address, number, position), hash.Bytes()); err != nil {
+		log.Crit("Failed to store internal transaction entry", "err", err)
+	}
+}
+
+// DeleteInternalTxEntry removes an internal transaction index entry of the
+// given account.
+func DeleteInternalTxEntry(db ethdb.KeyValueWriter, address common.Address, number uint64, position uint32) {
+	if err := db.Delete(internalTxKey(address, number, position)); err != nil {
+		log.Crit("Failed to delete internal transaction entry", "err", err)
+	}
+}
+
+// CodeChangeEntry is a single entry of the code change index.
+type CodeChangeEntry struct {
+	Number   uint64      // Number of the block changing the code
+	Position uint32      // Position of the code change within the block
+	Hash     common.Hash // Hash of the block changing the code
+}
+
+// ReadCodeChangeEntries retrieves at most limit entries of the code change
+// index of the given account, in chain order, starting at the given block
+// number and position (inclusive).
+func ReadCodeChangeEntries(db ethdb.Iteratee, address common.Address, number uint64, position uint32, limit int) []CodeChangeEntry {
+	prefix := append(append([]byte{}, codeChangePrefix...), address.Bytes()...)
+	start := codeChangeKey(address, number, position)[len(prefix):]
+
+	it := db.NewIterator(prefix, start)
+	defer it.Release()
+
+	var entries []CodeChangeEntry
+	for len(entries) < limit && it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+4 || len(it.Value()) != common.HashLength {
+			continue
+		}
+		entries = append(entries, CodeChangeEntry{
+			Number:   binary.BigEndian.Uint64(key[len(prefix):]),
+			Position: binary.BigEndian.Uint32(key[len(prefix)+8:]),
+			Hash:     common.BytesToHash(it.Value()),
+		})
+	}
+	return entries
+}
+
+// WriteCodeChangeEntry stores a code change index entry of the given account.
+func WriteCodeChangeEntry(db ethdb.KeyValueWriter, address common.Address, number uint64, position uint32, hash common.Hash) {
+	if err := db.Put(codeChangeKey(address, number, position), hash.Bytes()); err != nil {
+		log.Crit("Failed to store code change entry", "err", err)
+	}
+}
+
+// DeleteCodeChangeEntry removes a code change index entry of the given account.
+func DeleteCodeChangeEntry(db ethdb.KeyValueWriter, address common.Address, number uint64, position uint32) {
+	if err := db.Delete(codeChangeKey(address, number, position)); err != nil {
+		log.Crit("Failed to delete code change entry", "err", err)
+	}
+}
+
+// ContractCreationEntry is a single entry of the contract creation index. An
+// address may be created more than once, after self-destructing.
+type ContractCreationEntry struct {
+	Number    uint64         `rlp:"-"` // Number of the block creating the contract
+	Position  uint32         `rlp:"-"` // Position of the creation within the block
+	BlockHash common.Hash    // Hash of the block creating the contract
+	TxIndex   uint32         // Position of the creating transaction within the block
+	TxHash    common.Hash    // Hash of the creating transaction
+	Creator   common.Address // Sender of the transaction, or the creating contract
+	Op        byte           // Opcode of the creation, CREATE or CREATE2
+	Depth     uint32         // Call depth of the creation, the top level call being 0
+	Salt      *common.Hash   `rlp:"optional"` // Salt of a CREATE2, if traced
+}
+
+// ReadContractCreationEntries retrieves all the entries of the contract creation
+// index of the given address, in chain order.
+func ReadContractCreationEntries(db ethdb.Iteratee, address common.Address) []ContractCreationEntry {
+	prefix := append(append([]byte{}, contractCreationPrefix...), address.Bytes()...)
+
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	var entries []ContractCreationEntry
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+4 {
+			continue
+		}
+		var entry ContractCreationEntry
+		if err := rlp.DecodeBytes(it.Value(), &entry); err != nil {
+			log.Error("Invalid contract creation entry RLP", "address", address, "err", err)
+			continue
+		}
+		entry.Number = binary.BigEndian.Uint64(key[len(prefix):])
+		entry.Position = binary.BigEndian.Uint32(key[len(prefix)+8:])
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// WriteContractCreationEntry stores a contract creation index entry of the
+// given address.
+func WriteContractCreationEntry(db ethdb.KeyValueWriter, address common.Address, entry *ContractCreationEntry) {
+	data, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		log.Crit("Failed to RLP encode contract creation entry", "err", err)
+	}
+	if err := db.Put(contractCreationKey(address, entry.Number, entry.Position), data); err != nil {
+		log.Crit("Failed to store contract creation entry", "err", err)
+	}
+}
+
+// DeleteContractCreationEntry removes a contract creation index entry of the
+// given address.
+func DeleteContractCreationEntry(db ethdb.KeyValueWriter, address common.Address, number uint64, position uint32) {
+	if err := db.Delete(contractCreationKey(address, number, position)); err != nil {
+		log.Crit("Failed to delete contract creation entry", "err", err)
+	}
+}
+
+// ReadChainIndexTail retrieves the number of the oldest block covered by the
+// named chain index.
+func ReadChainIndexTail(db ethdb.KeyValueReader, name string) *uint64 {
+	data, _ := db.Get(chainIndexTailKey(name))
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteChainIndexTail stores the number of the oldest block covered by the
+// named chain index.
+func WriteChainIndexTail(db ethdb.KeyValueWriter, name string, number uint64) {
+	if err := db.Put(chainIndexTailKey(name), encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the chain index tail", "index", name, "err", err)
+	}
+}
+
+// ReadChainIndexHead retrieves the hash of the latest block covered by the
+// named chain index.
+func ReadChainIndexHead(db ethdb.KeyValueReader, name string) common.Hash {
+	data, _ := db.Get(chainIndexHeadKey(name))
+	if len(data) != common.HashLength {
+		return common.Hash{}
+	}
+	return common.BytesToHash(data)
+}
+
+// WriteChainIndexHead stores the hash of the latest block covered by the
+// named chain index.
+func WriteChainIndexHead(db ethdb.KeyValueWriter, name string, hash common.Hash) {
+	if err := db.Put(chainIndexHeadKey(name), hash.Bytes()); err != nil {
+		log.Crit("Failed to store the chain index head", "index", name, "err", err)
+	}
+}
+
 // ReadTransaction retrieves a specific transaction from the database, along with
 // its added positional metadata.
 func ReadTransaction(db ethdb.Reader, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64) {
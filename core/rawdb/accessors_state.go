@@ -99,6 +99,29 @@ func DeleteCode(db ethdb.KeyValueWriter, hash common.Hash) {
 	}
 }
 
+// ReadContractABI retrieves the JSON ABI registered for the contracts with the
+// given code hash.
+func ReadContractABI(db ethdb.KeyValueReader, codeHash common.Hash) []byte {
+	data, _ := db.Get(contractABIKey(codeHash))
+	return data
+}
+
+// WriteContractABI stores the JSON ABI of the contracts with the given code
+// hash.
+func WriteContractABI(db ethdb.KeyValueWriter, codeHash common.Hash, abi []byte) {
+	if err := db.Put(contractABIKey(codeHash), abi); err != nil {
+		log.Crit("Failed to store contract ABI", "err", err)
+	}
+}
+
+// DeleteContractABI removes the JSON ABI of the contracts with the given code
+// hash.
+func DeleteContractABI(db ethdb.KeyValueWriter, codeHash common.Hash) {
+	if err := db.Delete(contractABIKey(codeHash)); err != nil {
+		log.Crit("Failed to delete contract ABI", "err", err)
+	}
+}
+
 // ReadStateID retrieves the state id with the provided state root.
 func ReadStateID(db ethdb.KeyValueReader, root common.Hash) *uint64 {
 	data, err := db.Get(stateIDKey(root))
@@ -164,6 +187,39 @@ func DeleteTrieJournal(db ethdb.KeyValueWriter) {
 	}
 }
 
+// WriteTrieWALEntry stores a layer of the path trie write-ahead log with the
+// given sequence number.
+func WriteTrieWALEntry(db ethdb.KeyValueWriter, seq uint64, blob []byte) {
+	if err := db.Put(trieWALKey(seq), blob); err != nil {
+		log.Crit("Failed to store trie write-ahead log entry", "err", err)
+	}
+}
+
+// DeleteTrieWALEntry deletes a layer of the path trie write-ahead log.
+func DeleteTrieWALEntry(db ethdb.KeyValueWriter, seq uint64) {
+	if err := db.Delete(trieWALKey(seq)); err != nil {
+		log.Crit("Failed to remove trie write-ahead log entry", "err", err)
+	}
+}
+
+// IterateTrieWAL calls fn with the layers of the path trie write-ahead log in
+// sequence order, stopping at the first error.
+func IterateTrieWAL(db ethdb.Iteratee, fn func(seq uint64, blob []byte) error) error {
+	it := db.NewIterator(trieWALPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(trieWALPrefix)+8 {
+			continue
+		}
+		if err := fn(binary.BigEndian.Uint64(key[len(trieWALPrefix):]), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 // ReadStateHistoryMeta retrieves the metadata corresponding to the specified
 // state history. Compute the position of state history in freezer by minus
 // one since the id of first state history starts from one(zero for initial
@@ -268,3 +324,27 @@ func WriteStateHistory(db ethdb.AncientWriter, id uint64, meta []byte, accountIn
 		return nil
 	})
 }
+
+// SkipStateHistory makes the state histories of an empty freezer start at the
+// given id, by appending empty placeholders for the previous ones and pruning
+// them.
+func SkipStateHistory(db ethdb.AncientStore, id uint64) error {
+	if id <= 1 {
+		return nil
+	}
+	_, err := db.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		for item := uint64(0); item < id-1; item++ {
+			for _, kind := range []string{stateHistoryMeta, stateHistoryAccountIndex, stateHistoryStorageIndex, stateHistoryAccountData, stateHistoryStorageData} {
+				if err := op.AppendRaw(kind, item, nil); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.TruncateTail(id - 1)
+	return err
+}
@@ -95,11 +95,20 @@ func NewStateFreezer(ancientDir string, verkle bool, readOnly bool) (ethdb.Reset
 	if ancientDir == "" {
 		return NewMemoryFreezer(readOnly, stateFreezerTableConfigs), nil
 	}
-	var name string
+	return NewStateFreezerAt(StateFreezerDir(ancientDir, verkle), readOnly)
+}
+
+// StateFreezerDir returns the directory of the file-based ancient store for
+// state history in the given ancient directory.
+func StateFreezerDir(ancientDir string, verkle bool) string {
 	if verkle {
-		name = filepath.Join(ancientDir, VerkleStateFreezerName)
-	} else {
-		name = filepath.Join(ancientDir, MerkleStateFreezerName)
+		return filepath.Join(ancientDir, VerkleStateFreezerName)
 	}
-	return newResettableFreezer(name, "eth/db/state", readOnly, stateHistoryTableSize, stateFreezerTableConfigs)
+	return filepath.Join(ancientDir, MerkleStateFreezerName)
+}
+
+// NewStateFreezerAt initializes the file-based ancient store for state history
+// in the given directory.
+func NewStateFreezerAt(dir string, readOnly bool) (ethdb.ResettableAncientStore, error) {
+	return newResettableFreezer(dir, "eth/db/state", readOnly, stateHistoryTableSize, stateFreezerTableConfigs)
 }
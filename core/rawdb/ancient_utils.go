@@ -19,6 +19,8 @@ package rawdb
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -112,6 +114,49 @@ func inspectFreezers(db ethdb.Database) ([]freezerInfo, error) {
 	return infos, nil
 }
 
+// FreezerTableStat contains the storage size of a single freezer table.
+type FreezerTableStat struct {
+	Name string
+	Size common.StorageSize
+}
+
+// FreezerStat contains the item range and per-table storage sizes of a freezer.
+type FreezerStat struct {
+	Name   string
+	Head   uint64
+	Tail   uint64
+	Items  uint64
+	Size   common.StorageSize
+	Tables []FreezerTableStat
+}
+
+// InspectFreezers returns the statistics of all freezers registered in the
+// system. The tables of each freezer are sorted by name.
+func InspectFreezers(db ethdb.Database) ([]FreezerStat, error) {
+	infos, err := inspectFreezers(db)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]FreezerStat, 0, len(infos))
+	for _, info := range infos {
+		stat := FreezerStat{
+			Name:  info.name,
+			Head:  info.head,
+			Tail:  info.tail,
+			Items: info.count(),
+			Size:  info.size(),
+		}
+		for _, table := range info.sizes {
+			stat.Tables = append(stat.Tables, FreezerTableStat{Name: table.name, Size: table.size})
+		}
+		slices.SortFunc(stat.Tables, func(a, b FreezerTableStat) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
 // InspectFreezerTable dumps out the index of a specific freezer table. The passed
 // ancient indicates the path of root ancient directory where the chain freezer can
 // be opened. Start and end specify the range for dumping out indexes.
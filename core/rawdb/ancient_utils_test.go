@@ -0,0 +1,69 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestInspectFreezers(t *testing.T) {
+	db, err := NewDatabaseWithFreezer(NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database with ancient backend: %v", err)
+	}
+	defer db.Close()
+
+	var blocks []*types.Block
+	for i := 0; i < 3; i++ {
+		blocks = append(blocks, types.NewBlockWithHeader(&types.Header{
+			Number:      big.NewInt(int64(i)),
+			Extra:       []byte("test block"),
+			UncleHash:   types.EmptyUncleHash,
+			TxHash:      types.EmptyTxsHash,
+			ReceiptHash: types.EmptyReceiptsHash,
+		}))
+	}
+	if _, err := WriteAncientBlocks(db, blocks, make([]types.Receipts, len(blocks))); err != nil {
+		t.Fatalf("failed to write ancient blocks: %v", err)
+	}
+	stats, err := InspectFreezers(db)
+	if err != nil {
+		t.Fatalf("failed to inspect freezers: %v", err)
+	}
+	idx := slices.IndexFunc(stats, func(s FreezerStat) bool { return s.Name == ChainFreezerName })
+	if idx < 0 {
+		t.Fatalf("chain freezer missing from stats")
+	}
+	chain := stats[idx]
+	if chain.Head != 2 || chain.Tail != 0 || chain.Items != 3 {
+		t.Fatalf("wrong chain freezer range: head %d, tail %d, items %d", chain.Head, chain.Tail, chain.Items)
+	}
+	if len(chain.Tables) != len(chainFreezerTableConfigs) {
+		t.Fatalf("wrong table count: have %d, want %d", len(chain.Tables), len(chainFreezerTableConfigs))
+	}
+	if !slices.IsSortedFunc(chain.Tables, func(a, b FreezerTableStat) int { return strings.Compare(a.Name, b.Name) }) {
+		t.Fatalf("tables are not sorted: %v", chain.Tables)
+	}
+	if chain.Size == 0 {
+		t.Fatalf("chain freezer reported as empty")
+	}
+}
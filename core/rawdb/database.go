@@ -368,6 +368,15 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		headers            stat
 		bodies             stat
 		receipts           stat
+		txFees             stat
+		internalTxs        stat
+		balanceChanges     stat
+		supplies           stat
+		codeChanges        stat
+		payloadRecords     stat
+		accessLists        stat
+		trieWAL            stat
+		contractABIs       stat
 		tds                stat
 		numHashPairings    stat
 		hashNumPairings    stat
@@ -377,6 +386,11 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		storageTries       stat
 		codes              stat
 		txLookups          stat
+		senderTxs          stat
+		tokenTransfers     stat
+		internalTxEntries  stat
+		creationEntries    stat
+		codeChangeEntries  stat
 		accountSnaps       stat
 		storageSnaps       stat
 		preimages          stat
@@ -417,6 +431,24 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 			bodies.Add(size)
 		case bytes.HasPrefix(key, blockReceiptsPrefix) && len(key) == (len(blockReceiptsPrefix)+8+common.HashLength):
 			receipts.Add(size)
+		case bytes.HasPrefix(key, blockFeesPrefix) && len(key) == (len(blockFeesPrefix)+8+common.HashLength):
+			txFees.Add(size)
+		case bytes.HasPrefix(key, blockInternalPrefix) && len(key) == (len(blockInternalPrefix)+8+common.HashLength):
+			internalTxs.Add(size)
+		case bytes.HasPrefix(key, blockBalancesPrefix) && len(key) == (len(blockBalancesPrefix)+8+common.HashLength):
+			balanceChanges.Add(size)
+		case bytes.HasPrefix(key, blockSupplyPrefix) && len(key) == (len(blockSupplyPrefix)+8+common.HashLength):
+			supplies.Add(size)
+		case bytes.HasPrefix(key, blockCodePrefix) && len(key) == (len(blockCodePrefix)+8+common.HashLength):
+			codeChanges.Add(size)
+		case bytes.HasPrefix(key, payloadRecordPrefix) && len(key) == (len(payloadRecordPrefix)+common.HashLength):
+			payloadRecords.Add(size)
+		case bytes.HasPrefix(key, accessListPrefix) && len(key) == (len(accessListPrefix)+common.HashLength):
+			accessLists.Add(size)
+		case bytes.HasPrefix(key, trieWALPrefix) && len(key) == len(trieWALPrefix)+8:
+			trieWAL.Add(size)
+		case bytes.HasPrefix(key, contractABIPrefix) && len(key) == len(contractABIPrefix)+common.HashLength:
+			contractABIs.Add(size)
 		case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerTDSuffix):
 			tds.Add(size)
 		case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerHashSuffix):
@@ -435,6 +467,16 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 			codes.Add(size)
 		case bytes.HasPrefix(key, txLookupPrefix) && len(key) == (len(txLookupPrefix)+common.HashLength):
 			txLookups.Add(size)
+		case bytes.HasPrefix(key, senderTxPrefix) && len(key) == (len(senderTxPrefix)+common.AddressLength+8+4):
+			senderTxs.Add(size)
+		case bytes.HasPrefix(key, tokenTransferPrefix) && len(key) == (len(tokenTransferPrefix)+common.AddressLength+8+4):
+			tokenTransfers.Add(size)
+		case bytes.HasPrefix(key, internalTxPrefix) && len(key) == (len(internalTxPrefix)+common.AddressLength+8+4):
+			internalTxEntries.Add(size)
+		case bytes.HasPrefix(key, contractCreationPrefix) && len(key) == (len(contractCreationPrefix)+common.AddressLength+8+4):
+			creationEntries.Add(size)
+		case bytes.HasPrefix(key, codeChangePrefix) && len(key) == (len(codeChangePrefix)+common.AddressLength+8+4):
+			codeChangeEntries.Add(size)
 		case bytes.HasPrefix(key, SnapshotAccountPrefix) && len(key) == (len(SnapshotAccountPrefix)+common.HashLength):
 			accountSnaps.Add(size)
 		case bytes.HasPrefix(key, SnapshotStoragePrefix) && len(key) == (len(SnapshotStoragePrefix)+2*common.HashLength):
@@ -476,6 +518,8 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 				metadata.Add(size)
 			case bytes.Equal(remain, trieJournalKey):
 				metadata.Add(size)
+			case bytes.HasPrefix(remain, trieWALPrefix) && len(remain) == len(trieWALPrefix)+8:
+				trieWAL.Add(size)
 			case bytes.Equal(remain, snapSyncStatusFlagKey):
 				metadata.Add(size)
 			default:
@@ -506,10 +550,24 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		{"Key-Value store", "Headers", headers.Size(), headers.Count()},
 		{"Key-Value store", "Bodies", bodies.Size(), bodies.Count()},
 		{"Key-Value store", "Receipt lists", receipts.Size(), receipts.Count()},
+		{"Key-Value store", "Transaction fees", txFees.Size(), txFees.Count()},
+		{"Key-Value store", "Internal transactions", internalTxs.Size(), internalTxs.Count()},
+		{"Key-Value store", "Balance changes", balanceChanges.Size(), balanceChanges.Count()},
+		{"Key-Value store", "Supply changes", supplies.Size(), supplies.Count()},
+		{"Key-Value store", "Code changes", codeChanges.Size(), codeChanges.Count()},
+		{"Key-Value store", "Payload records", payloadRecords.Size(), payloadRecords.Count()},
+		{"Key-Value store", "Block access lists", accessLists.Size(), accessLists.Count()},
+		{"Key-Value store", "Path trie write-ahead log", trieWAL.Size(), trieWAL.Count()},
+		{"Key-Value store", "Contract ABIs", contractABIs.Size(), contractABIs.Count()},
 		{"Key-Value store", "Difficulties (deprecated)", tds.Size(), tds.Count()},
 		{"Key-Value store", "Block number->hash", numHashPairings.Size(), numHashPairings.Count()},
 		{"Key-Value store", "Block hash->number", hashNumPairings.Size(), hashNumPairings.Count()},
 		{"Key-Value store", "Transaction index", txLookups.Size(), txLookups.Count()},
+		{"Key-Value store", "Sender transaction index", senderTxs.Size(), senderTxs.Count()},
+		{"Key-Value store", "Token transfer index", tokenTransfers.Size(), tokenTransfers.Count()},
+		{"Key-Value store", "Internal transaction index", internalTxEntries.Size(), internalTxEntries.Count()},
+		{"Key-Value store", "Contract creation index", creationEntries.Size(), creationEntries.Count()},
+		{"Key-Value store", "Code change index", codeChangeEntries.Size(), codeChangeEntries.Count()},
 		{"Key-Value store", "Log index filter-map rows", filterMapRows.Size(), filterMapRows.Count()},
 		{"Key-Value store", "Log index last-block-of-map", filterMapLastBlock.Size(), filterMapLastBlock.Count()},
 		{"Key-Value store", "Log index block-lv", filterMapBlockLV.Size(), filterMapBlockLV.Count()},
@@ -564,6 +622,8 @@ var knownMetadataKeys = [][]byte{
 	databaseVersionKey, headHeaderKey, headBlockKey, headFastBlockKey, headFinalizedBlockKey,
 	lastPivotKey, fastTrieProgressKey, snapshotDisabledKey, SnapshotRootKey, snapshotJournalKey,
 	snapshotGeneratorKey, snapshotRecoveryKey, txIndexTailKey, fastTxLookupLimitKey,
+	chainIndexTailKey(SenderIndexName), chainIndexHeadKey(SenderIndexName),
+	chainIndexTailKey(TransferIndexName), chainIndexHeadKey(TransferIndexName),
 	uncleanShutdownKey, badBlockKey, transitionStatusKey, skeletonSyncStatusKey,
 	persistentStateIDKey, trieJournalKey, snapshotSyncStatusKey, snapSyncStatusFlagKey,
 	filterMapsRangeKey,
@@ -104,19 +104,37 @@ var (
 
 	blockBodyPrefix     = []byte("b") // blockBodyPrefix + num (uint64 big endian) + hash -> block body
 	blockReceiptsPrefix = []byte("r") // blockReceiptsPrefix + num (uint64 big endian) + hash -> block receipts
-
-	txLookupPrefix        = []byte("l") // txLookupPrefix + hash -> transaction/receipt lookup metadata
-	bloomBitsPrefix       = []byte("B") // bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
-	SnapshotAccountPrefix = []byte("a") // SnapshotAccountPrefix + account hash -> account trie value
-	SnapshotStoragePrefix = []byte("o") // SnapshotStoragePrefix + account hash + storage hash -> storage trie value
-	CodePrefix            = []byte("c") // CodePrefix + code hash -> account code
-	skeletonHeaderPrefix  = []byte("S") // skeletonHeaderPrefix + num (uint64 big endian) -> header
+	blockFeesPrefix     = []byte("F") // blockFeesPrefix + num (uint64 big endian) + hash -> transaction fee breakdowns
+	blockInternalPrefix = []byte("N") // blockInternalPrefix + num (uint64 big endian) + hash -> internal transactions
+	blockBalancesPrefix = []byte("J") // blockBalancesPrefix + num (uint64 big endian) + hash -> balance and nonce changes
+	blockSupplyPrefix   = []byte("U") // blockSupplyPrefix + num (uint64 big endian) + hash -> native token supply change
+	blockCodePrefix     = []byte("Q") // blockCodePrefix + num (uint64 big endian) + hash -> code changes
+	payloadRecordPrefix = []byte("P") // payloadRecordPrefix + hash -> record of a locally built payload
+	accessListPrefix    = []byte("Z") // accessListPrefix + hash -> access lists of a locally built block
+
+	txLookupPrefix         = []byte("l") // txLookupPrefix + hash -> transaction/receipt lookup metadata
+	bloomBitsPrefix        = []byte("B") // bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
+	SnapshotAccountPrefix  = []byte("a") // SnapshotAccountPrefix + account hash -> account trie value
+	SnapshotStoragePrefix  = []byte("o") // SnapshotStoragePrefix + account hash + storage hash -> storage trie value
+	CodePrefix             = []byte("c") // CodePrefix + code hash -> account code
+	skeletonHeaderPrefix   = []byte("S") // skeletonHeaderPrefix + num (uint64 big endian) -> header
+	senderTxPrefix         = []byte("X") // senderTxPrefix + address + num (uint64 big endian) + index (uint32 big endian) -> transaction hash
+	tokenTransferPrefix    = []byte("E") // tokenTransferPrefix + address + num (uint64 big endian) + log index (uint32 big endian) -> RLP(TransferEntry)
+	internalTxPrefix       = []byte("Y") // internalTxPrefix + address + num (uint64 big endian) + position (uint32 big endian) -> block hash
+	contractCreationPrefix = []byte("K") // contractCreationPrefix + address + num (uint64 big endian) + position (uint32 big endian) -> RLP(ContractCreationEntry)
+	codeChangePrefix       = []byte("W") // codeChangePrefix + address + num (uint64 big endian) + position (uint32 big endian) -> block hash
 
 	// Path-based storage scheme of merkle patricia trie.
 	TrieNodeAccountPrefix = []byte("A") // TrieNodeAccountPrefix + hexPath -> trie node
 	TrieNodeStoragePrefix = []byte("O") // TrieNodeStoragePrefix + accountHash + hexPath -> trie node
 	stateIDPrefix         = []byte("L") // stateIDPrefix + state root -> state id
 
+	// trieWALPrefix + seq (uint64 big endian) -> layer of the path trie write-ahead log
+	trieWALPrefix = []byte("TrieWAL-")
+
+	// contractABIPrefix + code hash -> JSON ABI of the contracts with the code
+	contractABIPrefix = []byte("ContractABI-")
+
 	// VerklePrefix is the database prefix for Verkle trie data, which includes:
 	// (a) Trie nodes
 	// (b) In-memory trie node journal
@@ -194,11 +212,111 @@ func blockReceiptsKey(number uint64, hash common.Hash) []byte {
 	return append(append(blockReceiptsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
 }
 
+// blockFeesKey = blockFeesPrefix + num (uint64 big endian) + hash
+func blockFeesKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockFeesPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// blockInternalKey = blockInternalPrefix + num (uint64 big endian) + hash
+func blockInternalKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockInternalPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// blockBalancesKey = blockBalancesPrefix + num (uint64 big endian) + hash
+func blockBalancesKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockBalancesPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// blockSupplyKey = blockSupplyPrefix + num (uint64 big endian) + hash
+func blockSupplyKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockSupplyPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// blockCodeKey = blockCodePrefix + num (uint64 big endian) + hash
+func blockCodeKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockCodePrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// contractABIKey = contractABIPrefix + code hash
+func contractABIKey(codeHash common.Hash) []byte {
+	return append(append([]byte{}, contractABIPrefix...), codeHash.Bytes()...)
+}
+
+// payloadRecordKey = payloadRecordPrefix + hash
+func payloadRecordKey(hash common.Hash) []byte {
+	return append(payloadRecordPrefix, hash.Bytes()...)
+}
+
+// accessListKey = accessListPrefix + hash
+func accessListKey(hash common.Hash) []byte {
+	return append(accessListPrefix, hash.Bytes()...)
+}
+
 // txLookupKey = txLookupPrefix + hash
 func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
 }
 
+// senderTxKey = senderTxPrefix + address + num (uint64 big endian) + index (uint32 big endian)
+func senderTxKey(sender common.Address, number uint64, index uint32) []byte {
+	key := make([]byte, len(senderTxPrefix)+common.AddressLength+8+4)
+	copy(key, senderTxPrefix)
+	copy(key[len(senderTxPrefix):], sender.Bytes())
+	binary.BigEndian.PutUint64(key[len(senderTxPrefix)+common.AddressLength:], number)
+	binary.BigEndian.PutUint32(key[len(senderTxPrefix)+common.AddressLength+8:], index)
+	return key
+}
+
+// tokenTransferKey = tokenTransferPrefix + address + num (uint64 big endian) + log index (uint32 big endian)
+func tokenTransferKey(address common.Address, number uint64, index uint32) []byte {
+	key := make([]byte, len(tokenTransferPrefix)+common.AddressLength+8+4)
+	copy(key, tokenTransferPrefix)
+	copy(key[len(tokenTransferPrefix):], address.Bytes())
+	binary.BigEndian.PutUint64(key[len(tokenTransferPrefix)+common.AddressLength:], number)
+	binary.BigEndian.PutUint32(key[len(tokenTransferPrefix)+common.AddressLength+8:], index)
+	return key
+}
+
+// internalTxKey = internalTxPrefix + address + num (uint64 big endian) + position (uint32 big endian)
+func internalTxKey(address common.Address, number uint64, position uint32) []byte {
+	key := make([]byte, len(internalTxPrefix)+common.AddressLength+8+4)
+	copy(key, internalTxPrefix)
+	copy(key[len(internalTxPrefix):], address.Bytes())
+	binary.BigEndian.PutUint64(key[len(internalTxPrefix)+common.AddressLength:], number)
+	binary.BigEndian.PutUint32(key[len(internalTxPrefix)+common.AddressLength+8:], position)
+	return key
+}
+
+// contractCreationKey = contractCreationPrefix + address + num (uint64 big endian) + position (uint32 big endian)
+func contractCreationKey(address common.Address, number uint64, position uint32) []byte {
+	key := make([]byte, len(contractCreationPrefix)+common.AddressLength+8+4)
+	copy(key, contractCreationPrefix)
+	copy(key[len(contractCreationPrefix):], address.Bytes())
+	binary.BigEndian.PutUint64(key[len(contractCreationPrefix)+common.AddressLength:], number)
+	binary.BigEndian.PutUint32(key[len(contractCreationPrefix)+common.AddressLength+8:], position)
+	return key
+}
+
+// codeChangeKey = codeChangePrefix + address + num (uint64 big endian) + position (uint32 big endian)
+func codeChangeKey(address common.Address, number uint64, position uint32) []byte {
+	key := make([]byte, len(codeChangePrefix)+common.AddressLength+8+4)
+	copy(key, codeChangePrefix)
+	copy(key[len(codeChangePrefix):], address.Bytes())
+	binary.BigEndian.PutUint64(key[len(codeChangePrefix)+common.AddressLength:], number)
+	binary.BigEndian.PutUint32(key[len(codeChangePrefix)+common.AddressLength+8:], position)
+	return key
+}
+
+// chainIndexTailKey = name + "IndexTail"
+func chainIndexTailKey(name string) []byte {
+	return []byte(name + "IndexTail")
+}
+
+// chainIndexHeadKey = name + "IndexHead"
+func chainIndexHeadKey(name string) []byte {
+	return []byte(name + "IndexHead")
+}
+
 // accountSnapshotKey = SnapshotAccountPrefix + hash
 func accountSnapshotKey(hash common.Hash) []byte {
 	return append(SnapshotAccountPrefix, hash.Bytes()...)
@@ -257,6 +375,11 @@ func stateIDKey(root common.Hash) []byte {
 	return append(stateIDPrefix, root.Bytes()...)
 }
 
+// trieWALKey = trieWALPrefix + seq (uint64 big endian)
+func trieWALKey(seq uint64) []byte {
+	return append(append([]byte{}, trieWALPrefix...), encodeBlockNumber(seq)...)
+}
+
 // accountTrieNodeKey = TrieNodeAccountPrefix + nodePath.
 func accountTrieNodeKey(path []byte) []byte {
 	return append(TrieNodeAccountPrefix, path...)
@@ -0,0 +1,99 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// SenderIndexer is the module maintaining the sender address -> transaction
+// index of the canonical chain, within the configured retention window.
+//
+// Index entries are written as blocks become canonical and removed when they
+// are reorged out or fall out of the retention window. Since a crash might
+// leave dangling entries behind, readers must use Transactions which cross
+// checks every entry against the canonical chain.
+type SenderIndexer struct {
+	*chainIndexer
+
+	chain *BlockChain
+	db    ethdb.Database
+}
+
+// NewSenderIndexer initializes the sender indexer and starts its background
+// loop.
+func NewSenderIndexer(chain *BlockChain, limit uint64) *SenderIndexer {
+	indexer := &SenderIndexer{
+		chain: chain,
+		db:    chain.db,
+	}
+	indexer.chainIndexer = newChainIndexer(chain, rawdb.SenderIndexName, "sender transactions", limit, indexer)
+	return indexer
+}
+
+// Transactions retrieves at most limit canonical transactions sent by the given
+// account, in chain order, starting at the given block number and transaction
+// position (inclusive). The position to continue the iteration from is returned
+// too, or nil if there are no more transactions.
+func (indexer *SenderIndexer) Transactions(sender common.Address, number uint64, index uint32, limit int) ([]rawdb.SenderTxEntry, *rawdb.SenderTxEntry) {
+	var (
+		bodies  = make(map[uint64]*types.Body)
+		results []rawdb.SenderTxEntry
+	)
+	for {
+		entries := rawdb.ReadSenderTxEntries(indexer.db, sender, number, index, limit)
+		for _, entry := range entries {
+			if !isCanonicalTx(indexer.db, entry.Number, entry.Index, entry.Hash, bodies) {
+				continue
+			}
+			if len(results) == limit {
+				return results, &entry
+			}
+			results = append(results, entry)
+		}
+		if len(entries) < limit {
+			return results, nil
+		}
+		// Continue right after the last retrieved entry
+		last := entries[len(entries)-1]
+		number, index = last.Number, last.Index+1
+		if index == 0 {
+			number++
+		}
+	}
+}
+
+// update adds or removes the transactions of the given block to the index.
+func (indexer *SenderIndexer) update(batch ethdb.KeyValueWriter, block *types.Block, add bool) {
+	signer := types.MakeSigner(indexer.chain.Config(), block.Number(), block.Time())
+	for i, tx := range block.Transactions() {
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			log.Warn("Failed to derive transaction sender", "number", block.NumberU64(), "index", i, "err", err)
+			continue
+		}
+		if add {
+			rawdb.WriteSenderTxEntry(batch, sender, block.NumberU64(), uint32(i), tx.Hash())
+		} else {
+			rawdb.DeleteSenderTxEntry(batch, sender, block.NumberU64(), uint32(i))
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// waitChainIndex waits until the named chain index caught up with the chain head.
+func waitChainIndex(t *testing.T, chain *BlockChain, name string) {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		if rawdb.ReadChainIndexHead(chain.db, name) == chain.CurrentBlock().Hash() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s index did not catch up with the chain head", name)
+}
+
+// senderTxNumbers returns the block numbers of all indexed transactions of
+// the given sender, iterating page by page.
+func senderTxNumbers(indexer *SenderIndexer, sender common.Address, page int) []uint64 {
+	var (
+		numbers []uint64
+		number  uint64
+		index   uint32
+	)
+	for {
+		entries, next := indexer.Transactions(sender, number, index, page)
+		for _, entry := range entries {
+			numbers = append(numbers, entry.Number)
+		}
+		if next == nil {
+			return numbers
+		}
+		number, index = next.Number, next.Index
+	}
+}
+
+func TestSenderIndexer(t *testing.T) {
+	var (
+		key1, _ = crypto.GenerateKey()
+		key2, _ = crypto.GenerateKey()
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr1: {Balance: big.NewInt(params.Ether)},
+				addr2: {Balance: big.NewInt(params.Ether)},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = ethash.NewFaker()
+	)
+	// Sender 1 sends a transaction in every block, sender 2 in every other one.
+	genDb, blocks, _ := GenerateChainWithGenesis(gspec, engine, 20, func(i int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr1), common.Address{0xaa}, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, key1)
+		gen.AddTx(tx)
+		if i%2 == 0 {
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr2), common.Address{0xaa}, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, key2)
+			gen.AddTx(tx)
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks[:10]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	indexer := NewSenderIndexer(chain, 0)
+	defer indexer.Close()
+
+	// Blocks imported before and after starting the indexer are both indexed.
+	if _, err := chain.InsertChain(blocks[10:]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	waitChainIndex(t, chain, rawdb.SenderIndexName)
+
+	if numbers := senderTxNumbers(indexer, addr1, 3); len(numbers) != 20 || numbers[0] != 1 || numbers[19] != 20 {
+		t.Fatalf("wrong transactions of sender 1: %v", numbers)
+	}
+	if numbers := senderTxNumbers(indexer, addr2, 4); len(numbers) != 10 || numbers[0] != 1 || numbers[9] != 19 {
+		t.Fatalf("wrong transactions of sender 2: %v", numbers)
+	}
+	// Reorg the last blocks to a longer side chain without any transactions of
+	// sender 2, its reorged transactions should disappear from the index.
+	fork, _ := GenerateChain(gspec.Config, blocks[14], engine, genDb, 8, func(i int, gen *BlockGen) {
+		gen.SetCoinbase(common.Address{0xbb})
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr1), common.Address{0xaa}, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, key1)
+		gen.AddTx(tx)
+	})
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	waitChainIndex(t, chain, rawdb.SenderIndexName)
+
+	if numbers := senderTxNumbers(indexer, addr1, 5); len(numbers) != 23 || numbers[22] != 23 {
+		t.Fatalf("wrong transactions of sender 1 after reorg: %v", numbers)
+	}
+	if numbers := senderTxNumbers(indexer, addr2, 5); len(numbers) != 8 || numbers[7] != 15 {
+		t.Fatalf("wrong transactions of sender 2 after reorg: %v", numbers)
+	}
+	if entries := rawdb.ReadSenderTxEntries(chain.db, addr2, 16, 0, 10); len(entries) != 0 {
+		t.Fatalf("reorged entries not removed: %v", entries)
+	}
+	indexer.Close()
+
+	// Restart with a retention window, old blocks should be pruned.
+	indexer = NewSenderIndexer(chain, 5)
+	defer indexer.Close()
+
+	for deadline := time.Now().Add(10 * time.Second); ; {
+		if tail := rawdb.ReadChainIndexTail(chain.db, rawdb.SenderIndexName); tail != nil && *tail == 19 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("sender index not pruned, tail %v", rawdb.ReadChainIndexTail(chain.db, rawdb.SenderIndexName))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if numbers := senderTxNumbers(indexer, addr1, 100); len(numbers) != 5 || numbers[0] != 19 {
+		t.Fatalf("wrong transactions of sender 1 after pruning: %v", numbers)
+	}
+	if entries := rawdb.ReadSenderTxEntries(chain.db, addr1, 0, 0, 100); len(entries) != 5 {
+		t.Fatalf("pruned entries not removed: %d left", len(entries))
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type accessList struct {
@@ -52,6 +53,21 @@ func (al *accessList) Contains(address common.Address, slot common.Hash) (addres
 	return true, slotPresent
 }
 
+// list returns the accounts and storage slots in the access list.
+func (al *accessList) list() types.AccessList {
+	list := make(types.AccessList, 0, len(al.addresses))
+	for addr, idx := range al.addresses {
+		tuple := types.AccessTuple{Address: addr, StorageKeys: []common.Hash{}}
+		if idx >= 0 {
+			for slot := range al.slots[idx] {
+				tuple.StorageKeys = append(tuple.StorageKeys, slot)
+			}
+		}
+		list = append(list, tuple)
+	}
+	return list
+}
+
 // newAccessList creates a new accessList.
 func newAccessList() *accessList {
 	return &accessList{
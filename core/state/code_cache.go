@@ -0,0 +1,96 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// codeCacheShards is the number of independently locked shards of the code
+// cache. Code hashes are uniformly distributed, so the first byte selects it.
+const codeCacheShards = 16
+
+var (
+	codeCacheHitMeter  = metrics.NewRegisteredMeter("state/codecache/hit", nil)
+	codeCacheMissMeter = metrics.NewRegisteredMeter("state/codecache/miss", nil)
+)
+
+// CodeCache is a thread-safe cache of contract codes and code sizes keyed by
+// code hash. It is sharded to reduce lock contention between concurrent
+// executions, and meant to be shared by all the state databases of a chain:
+// block import, block building and RPC calls all read the same popular code.
+type CodeCache struct {
+	codes [codeCacheShards]*lru.SizeConstrainedCache[common.Hash, []byte]
+	sizes [codeCacheShards]*lru.Cache[common.Hash, int]
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewCodeCache creates a code cache holding up to the given number of bytes
+// of code.
+func NewCodeCache(size uint64) *CodeCache {
+	c := new(CodeCache)
+	for i := range c.codes {
+		c.codes[i] = lru.NewSizeConstrainedCache[common.Hash, []byte](size / codeCacheShards)
+		c.sizes[i] = lru.NewCache[common.Hash, int](codeSizeCacheSize / codeCacheShards)
+	}
+	return c
+}
+
+// Code returns the cached code with the given hash, or nil if not cached.
+func (c *CodeCache) Code(hash common.Hash) []byte {
+	code, _ := c.codes[hash[0]%codeCacheShards].Get(hash)
+	if len(code) > 0 {
+		c.hits.Add(1)
+		codeCacheHitMeter.Mark(1)
+	} else {
+		c.misses.Add(1)
+		codeCacheMissMeter.Mark(1)
+	}
+	return code
+}
+
+// CodeSize returns the cached size of the code with the given hash.
+func (c *CodeCache) CodeSize(hash common.Hash) (int, bool) {
+	return c.sizes[hash[0]%codeCacheShards].Get(hash)
+}
+
+// Add caches the code with the given hash and its size. The code must not be
+// modified afterwards.
+func (c *CodeCache) Add(hash common.Hash, code []byte) {
+	shard := hash[0] % codeCacheShards
+	c.codes[shard].Add(hash, code)
+	c.sizes[shard].Add(hash, len(code))
+}
+
+// Resize changes the capacity of the cache to the given number of bytes of
+// code, evicting the least recently used codes if shrinking.
+func (c *CodeCache) Resize(size int) {
+	for _, shard := range c.codes {
+		shard.SetMaxSize(uint64(size) / codeCacheShards)
+	}
+}
+
+// Stats returns the number of code lookups which hit and missed the cache.
+func (c *CodeCache) Stats() (hits, misses uint64) {
+	return c.hits.Load(), c.misses.Load()
+}
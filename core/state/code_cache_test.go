@@ -0,0 +1,87 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// Tests that state databases sharing a code cache serve each other's code
+// reads from memory.
+func TestSharedCodeCache(t *testing.T) {
+	var (
+		disk  = rawdb.NewMemoryDatabase()
+		cache = NewCodeCache(1024 * 1024)
+		db1   = NewDatabaseWithCodeCache(triedb.NewDatabase(disk, nil), nil, cache)
+		db2   = NewDatabaseWithCodeCache(triedb.NewDatabase(disk, nil), nil, cache)
+		code  = []byte{0x60, 0x01, 0x60, 0x02, 0x01}
+		hash  = crypto.Keccak256Hash(code)
+	)
+	rawdb.WriteCode(disk, hash, code)
+
+	r1 := newCachingCodeReader(db1.disk, db1.codeCache)
+	if have, _ := r1.Code(common.Address{}, hash); !bytes.Equal(have, code) {
+		t.Fatalf("wrong code: have %x, want %x", have, code)
+	}
+	if r1.hits.Load() != 0 || r1.misses.Load() != 1 {
+		t.Fatalf("wrong first read stats: %d hits, %d misses", r1.hits.Load(), r1.misses.Load())
+	}
+	// Once deleted from disk, the code is still served from the shared cache.
+	rawdb.DeleteCode(disk, hash)
+
+	r2 := newCachingCodeReader(db2.disk, db2.codeCache)
+	if have, _ := r2.Code(common.Address{}, hash); !bytes.Equal(have, code) {
+		t.Fatalf("wrong cached code: have %x, want %x", have, code)
+	}
+	if size, _ := r2.CodeSize(common.Address{}, hash); size != len(code) {
+		t.Fatalf("wrong cached code size: have %d, want %d", size, len(code))
+	}
+	if r2.hits.Load() != 1 || r2.misses.Load() != 0 {
+		t.Fatalf("wrong shared read stats: %d hits, %d misses", r2.hits.Load(), r2.misses.Load())
+	}
+}
+
+// Tests that the code cache stays within its size allowance.
+func TestCodeCacheLimit(t *testing.T) {
+	cache := NewCodeCache(codeCacheShards * 1000)
+
+	var hashes []common.Hash
+	for i := 0; i < 1000; i++ {
+		code := bytes.Repeat([]byte{byte(i), byte(i >> 8)}, 50)
+		hash := crypto.Keccak256Hash(code)
+		cache.Add(hash, code)
+		hashes = append(hashes, hash)
+	}
+	var cached int
+	for _, hash := range hashes {
+		if code := cache.Code(hash); code != nil {
+			cached += len(code)
+		}
+	}
+	if cached > codeCacheShards*1000 || cached == 0 {
+		t.Fatalf("wrong cached code size: %d, limit %d", cached, codeCacheShards*1000)
+	}
+	if cache.Code(hashes[len(hashes)-1]) == nil {
+		t.Fatal("most recent code evicted")
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -36,7 +35,7 @@ const (
 	// Number of codehash->size associations to keep.
 	codeSizeCacheSize = 100000
 
-	// Cache size granted for caching clean code.
+	// Cache size granted for caching clean code, if no shared cache is given.
 	codeCacheSize = 64 * 1024 * 1024
 
 	// Number of address->curve point associations to keep.
@@ -145,23 +144,27 @@ type Trie interface {
 // state snapshot to provide functionalities for state access. It's meant to be a
 // long-live object and has a few caches inside for sharing between blocks.
 type CachingDB struct {
-	disk          ethdb.KeyValueStore
-	triedb        *triedb.Database
-	snap          *snapshot.Tree
-	codeCache     *lru.SizeConstrainedCache[common.Hash, []byte]
-	codeSizeCache *lru.Cache[common.Hash, int]
-	pointCache    *utils.PointCache
+	disk       ethdb.KeyValueStore
+	triedb     *triedb.Database
+	snap       *snapshot.Tree
+	codeCache  *CodeCache
+	pointCache *utils.PointCache
 }
 
 // NewDatabase creates a state database with the provided data sources.
 func NewDatabase(triedb *triedb.Database, snap *snapshot.Tree) *CachingDB {
+	return NewDatabaseWithCodeCache(triedb, snap, NewCodeCache(codeCacheSize))
+}
+
+// NewDatabaseWithCodeCache creates a state database with the provided data
+// sources, sharing the given code cache with other databases.
+func NewDatabaseWithCodeCache(triedb *triedb.Database, snap *snapshot.Tree, codeCache *CodeCache) *CachingDB {
 	return &CachingDB{
-		disk:          triedb.Disk(),
-		triedb:        triedb,
-		snap:          snap,
-		codeCache:     lru.NewSizeConstrainedCache[common.Hash, []byte](codeCacheSize),
-		codeSizeCache: lru.NewCache[common.Hash, int](codeSizeCacheSize),
-		pointCache:    utils.NewPointCache(pointCacheSize),
+		disk:       triedb.Disk(),
+		triedb:     triedb,
+		snap:       snap,
+		codeCache:  codeCache,
+		pointCache: utils.NewPointCache(pointCacheSize),
 	}
 }
 
@@ -205,7 +208,7 @@ func (db *CachingDB) Reader(stateRoot common.Hash) (Reader, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newReader(newCachingCodeReader(db.disk, db.codeCache, db.codeSizeCache), combined), nil
+	return newReader(newCachingCodeReader(db.disk, db.codeCache), combined), nil
 }
 
 // OpenTrie opens the main account trie at a specific root hash.
@@ -238,14 +241,13 @@ func (db *CachingDB) OpenStorageTrie(stateRoot common.Hash, address common.Addre
 // ContractCode retrieves a particular contract's code. Returns nil if not found.
 // OP-Stack diff: used to serve snap-sync from legacy DB code storage scheme.
 func (db *CachingDB) ContractCode(address common.Address, codeHash common.Hash) []byte {
-	code, _ := db.codeCache.Get(codeHash)
+	code := db.codeCache.Code(codeHash)
 	if len(code) > 0 {
 		return code
 	}
 	code = rawdb.ReadCode(db.disk, codeHash) // tries prefix first, then reads non-prefixed key if not found
 	if len(code) > 0 {
 		db.codeCache.Add(codeHash, code)
-		db.codeSizeCache.Add(codeHash, len(code))
 		return code
 	}
 	return nil
@@ -255,14 +257,13 @@ func (db *CachingDB) ContractCode(address common.Address, codeHash common.Hash)
 // code can't be found in the cache, then check the existence with **new**
 // db scheme.
 func (db *CachingDB) ContractCodeWithPrefix(address common.Address, codeHash common.Hash) []byte {
-	code, _ := db.codeCache.Get(codeHash)
+	code := db.codeCache.Code(codeHash)
 	if len(code) > 0 {
 		return code
 	}
 	code = rawdb.ReadCodeWithPrefix(db.disk, codeHash)
 	if len(code) > 0 {
 		db.codeCache.Add(codeHash, code)
-		db.codeSizeCache.Add(codeHash, len(code))
 	}
 	return code
 }
@@ -0,0 +1,386 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/holiman/uint256"
+)
+
+// StateChunk is a contiguous range of accounts of the state, with their code
+// and storage, along with the Merkle proof of the range. A chunk can be verified
+// on its own against the state root. Chunks are the unit of the streaming state
+// dump: the chunk following a given one starts at its Next hash, so a dump can
+// be resumed from the last chunk written.
+type StateChunk struct {
+	Root     common.Hash     `json:"root"`
+	Start    common.Hash     `json:"start"`          // Account hash the range starts at
+	Next     *common.Hash    `json:"next,omitempty"` // Account hash the next range starts at, nil for the last chunk
+	Accounts []ChunkAccount  `json:"accounts"`       // Accounts of the range, ordered by hash
+	Proof    []hexutil.Bytes `json:"proof"`          // Proof of the range start and of the last account
+}
+
+// ChunkAccount is an account of a state chunk.
+type ChunkAccount struct {
+	Hash     common.Hash     `json:"hash"`
+	Address  *common.Address `json:"address,omitempty"` // Preimage of the hash, if known
+	Nonce    uint64          `json:"nonce"`
+	Balance  *hexutil.U256   `json:"balance"`
+	Root     common.Hash     `json:"root"`
+	CodeHash common.Hash     `json:"codeHash"`
+	Code     hexutil.Bytes   `json:"code,omitempty"`
+	Storage  []ChunkSlot     `json:"storage,omitempty"` // Storage slots, ordered by hash
+}
+
+// ChunkSlot is a storage slot of a chunk account.
+type ChunkSlot struct {
+	Hash  common.Hash   `json:"hash"`
+	Key   *common.Hash  `json:"key,omitempty"` // Preimage of the hash, if known
+	Value hexutil.Bytes `json:"value"`
+}
+
+// DumpChunk returns the chunk of the state starting at the given account hash.
+// Accounts are added to the chunk until it holds at least limit items, counting
+// both accounts and storage slots. The storage of an account is never split, so
+// a chunk holds at least one account, whatever its size.
+func (s *StateDB) DumpChunk(start common.Hash, limit int) (*StateChunk, error) {
+	if s.db.TrieDB().IsVerkle() {
+		return nil, errors.New("chunked dumps are not supported for verkle state")
+	}
+	chunk := &StateChunk{
+		Root:     s.trie.Hash(),
+		Start:    start,
+		Accounts: []ChunkAccount{},
+		Proof:    []hexutil.Bytes{},
+	}
+	nodeIt, err := s.trie.NodeIterator(start.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		it    = trie.NewIterator(nodeIt)
+		items int
+	)
+	for it.Next() {
+		var data types.StateAccount
+		if err := rlp.DecodeBytes(it.Value, &data); err != nil {
+			return nil, err
+		}
+		account := ChunkAccount{
+			Hash:     common.BytesToHash(it.Key),
+			Nonce:    data.Nonce,
+			Balance:  (*hexutil.U256)(data.Balance),
+			Root:     data.Root,
+			CodeHash: common.BytesToHash(data.CodeHash),
+		}
+		var addr common.Address
+		if preimage := s.trie.GetKey(it.Key); preimage != nil {
+			addr = common.BytesToAddress(preimage)
+			account.Address = &addr
+		}
+		obj := newObject(s, addr, &data)
+		account.Code = obj.Code()
+		if data.Root != types.EmptyRootHash {
+			// Open the storage trie by account hash, the address may be unknown
+			tr, err := trie.NewStateTrie(trie.StorageTrieID(s.originalRoot, account.Hash, data.Root), s.db.TrieDB())
+			if err != nil {
+				return nil, err
+			}
+			storageIt, err := tr.NodeIterator(nil)
+			if err != nil {
+				return nil, err
+			}
+			slots := trie.NewIterator(storageIt)
+			for slots.Next() {
+				_, content, _, err := rlp.Split(slots.Value)
+				if err != nil {
+					return nil, err
+				}
+				slot := ChunkSlot{Hash: common.BytesToHash(slots.Key), Value: common.CopyBytes(content)}
+				if preimage := s.trie.GetKey(slots.Key); preimage != nil {
+					key := common.BytesToHash(preimage)
+					slot.Key = &key
+				}
+				account.Storage = append(account.Storage, slot)
+			}
+			if slots.Err != nil {
+				return nil, slots.Err
+			}
+		}
+		chunk.Accounts = append(chunk.Accounts, account)
+
+		if items += 1 + len(account.Storage); items >= limit {
+			if it.Next() {
+				next := incHash(account.Hash)
+				chunk.Next = &next
+			}
+			break
+		}
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+	proof := trienode.NewProofSet()
+	if err := s.trie.Prove(start.Bytes(), proof); err != nil {
+		return nil, err
+	}
+	if n := len(chunk.Accounts); n > 0 {
+		if err := s.trie.Prove(chunk.Accounts[n-1].Hash.Bytes(), proof); err != nil {
+			return nil, err
+		}
+	}
+	for _, node := range proof.List() {
+		chunk.Proof = append(chunk.Proof, node)
+	}
+	return chunk, nil
+}
+
+// DumpChunks streams the state from the given account hash as a sequence of
+// chunks of the given size to the callback, until the end of the state or an
+// error returned by the callback.
+func (s *StateDB) DumpChunks(start common.Hash, limit int, onChunk func(*StateChunk) error) error {
+	var (
+		accounts int
+		begin    = time.Now()
+		logged   = time.Now()
+	)
+	log.Info("Chunked state dump started", "root", s.trie.Hash(), "start", start)
+	for {
+		chunk, err := s.DumpChunk(start, limit)
+		if err != nil {
+			return err
+		}
+		if err := onChunk(chunk); err != nil {
+			return err
+		}
+		accounts += len(chunk.Accounts)
+		if chunk.Next == nil {
+			break
+		}
+		start = *chunk.Next
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Chunked state dump in progress", "next", start, "accounts", accounts, "elapsed", common.PrettyDuration(time.Since(begin)))
+			logged = time.Now()
+		}
+	}
+	log.Info("Chunked state dump complete", "accounts", accounts, "elapsed", common.PrettyDuration(time.Since(begin)))
+	return nil
+}
+
+// VerifyChunk checks the chunk against its state root: the accounts must be
+// the complete range proven by the chunk proof, and the code and storage of
+// each account must match its code hash and storage root.
+func VerifyChunk(chunk *StateChunk) error {
+	return verifyChunk(chunk, nil)
+}
+
+// verifyChunk checks the chunk against its state root, passing the trie nodes
+// of the storage tries to the callback, if any.
+func verifyChunk(chunk *StateChunk, onStorageNode func(owner common.Hash, path []byte, hash common.Hash, blob []byte)) error {
+	var (
+		keys   = make([][]byte, len(chunk.Accounts))
+		values = make([][]byte, len(chunk.Accounts))
+	)
+	for i, account := range chunk.Accounts {
+		if account.Address != nil && crypto.Keccak256Hash(account.Address.Bytes()) != account.Hash {
+			return fmt.Errorf("account %x: invalid address preimage", account.Hash)
+		}
+		if account.Balance == nil {
+			return fmt.Errorf("account %x: missing balance", account.Hash)
+		}
+		blob, err := rlp.EncodeToBytes(&types.StateAccount{
+			Nonce:    account.Nonce,
+			Balance:  (*uint256.Int)(account.Balance),
+			Root:     account.Root,
+			CodeHash: account.CodeHash.Bytes(),
+		})
+		if err != nil {
+			return err
+		}
+		keys[i], values[i] = account.Hash.Bytes(), blob
+	}
+	if chunk.Root == types.EmptyRootHash {
+		if len(chunk.Accounts) > 0 || chunk.Next != nil {
+			return errors.New("non-empty chunk of empty state")
+		}
+	} else {
+		proof := make(trienode.ProofList, len(chunk.Proof))
+		for i, node := range chunk.Proof {
+			proof[i] = []byte(node)
+		}
+		more, err := trie.VerifyRangeProof(chunk.Root, chunk.Start.Bytes(), keys, values, proof.Set())
+		if err != nil {
+			return fmt.Errorf("invalid range proof: %w", err)
+		}
+		if more != (chunk.Next != nil) {
+			return fmt.Errorf("invalid range continuation: more accounts %t, next %v", more, chunk.Next)
+		}
+		if more && *chunk.Next != incHash(chunk.Accounts[len(chunk.Accounts)-1].Hash) {
+			return fmt.Errorf("invalid next account hash %x", *chunk.Next)
+		}
+	}
+	for _, account := range chunk.Accounts {
+		if crypto.Keccak256Hash(account.Code) != account.CodeHash {
+			return fmt.Errorf("account %x: code hash mismatch", account.Hash)
+		}
+		var onNode trie.OnTrieNode
+		if onStorageNode != nil {
+			owner := account.Hash
+			onNode = func(path []byte, hash common.Hash, blob []byte) {
+				onStorageNode(owner, path, hash, blob)
+			}
+		}
+		storage := trie.NewStackTrie(onNode)
+		for i, slot := range account.Storage {
+			if i > 0 && slot.Hash.Cmp(account.Storage[i-1].Hash) <= 0 {
+				return fmt.Errorf("account %x: storage not ordered", account.Hash)
+			}
+			if slot.Key != nil && crypto.Keccak256Hash(slot.Key.Bytes()) != slot.Hash {
+				return fmt.Errorf("account %x: invalid preimage of slot %x", account.Hash, slot.Hash)
+			}
+			value, err := rlp.EncodeToBytes([]byte(slot.Value))
+			if err != nil {
+				return err
+			}
+			if err := storage.Update(slot.Hash.Bytes(), value); err != nil {
+				return err
+			}
+		}
+		if root := storage.Hash(); root != account.Root {
+			return fmt.Errorf("account %x: storage root mismatch: have %x, want %x", account.Hash, root, account.Root)
+		}
+	}
+	return nil
+}
+
+// StateImporter reconstructs a state from the chunks of a streaming dump,
+// writing its trie nodes, code and preimages to a database. Chunks must be
+// imported in order, from the first to the last one of the state.
+type StateImporter struct {
+	db       ethdb.KeyValueStore
+	scheme   string
+	batch    ethdb.Batch
+	accounts *trie.StackTrie
+
+	root  common.Hash  // State root of the chunks imported
+	next  *common.Hash // Account hash the next chunk must start at, nil after the last chunk
+	count int          // Number of accounts imported
+}
+
+// NewStateImporter creates an importer writing the trie nodes to the database
+// with the given node scheme.
+func NewStateImporter(db ethdb.KeyValueStore, scheme string) *StateImporter {
+	imp := &StateImporter{
+		db:     db,
+		scheme: scheme,
+		batch:  db.NewBatch(),
+		next:   new(common.Hash),
+	}
+	imp.accounts = trie.NewStackTrie(func(path []byte, hash common.Hash, blob []byte) {
+		rawdb.WriteTrieNode(imp.batch, common.Hash{}, path, hash, blob, imp.scheme)
+	})
+	return imp
+}
+
+// Import verifies the chunk and writes its content to the database.
+func (imp *StateImporter) Import(chunk *StateChunk) error {
+	if imp.next == nil {
+		return errors.New("state already complete")
+	}
+	if imp.root != (common.Hash{}) && chunk.Root != imp.root {
+		return fmt.Errorf("state root mismatch: have %x, want %x", chunk.Root, imp.root)
+	}
+	if chunk.Start != *imp.next {
+		return fmt.Errorf("chunk out of order: starts at %x, want %x", chunk.Start, *imp.next)
+	}
+	err := verifyChunk(chunk, func(owner common.Hash, path []byte, hash common.Hash, blob []byte) {
+		rawdb.WriteTrieNode(imp.batch, owner, path, hash, blob, imp.scheme)
+	})
+	if err != nil {
+		return err
+	}
+	preimages := make(map[common.Hash][]byte)
+	for _, account := range chunk.Accounts {
+		blob, _ := rlp.EncodeToBytes(&types.StateAccount{
+			Nonce:    account.Nonce,
+			Balance:  (*uint256.Int)(account.Balance),
+			Root:     account.Root,
+			CodeHash: account.CodeHash.Bytes(),
+		})
+		if err := imp.accounts.Update(account.Hash.Bytes(), blob); err != nil {
+			return err
+		}
+		if len(account.Code) > 0 {
+			rawdb.WriteCode(imp.batch, account.CodeHash, account.Code)
+		}
+		if account.Address != nil {
+			preimages[account.Hash] = account.Address.Bytes()
+		}
+		for _, slot := range account.Storage {
+			if slot.Key != nil {
+				preimages[slot.Hash] = slot.Key.Bytes()
+			}
+		}
+	}
+	rawdb.WritePreimages(imp.batch, preimages)
+	if imp.batch.ValueSize() > ethdb.IdealBatchSize {
+		if err := imp.batch.Write(); err != nil {
+			return err
+		}
+		imp.batch.Reset()
+	}
+	imp.root, imp.next = chunk.Root, chunk.Next
+	imp.count += len(chunk.Accounts)
+	return nil
+}
+
+// Commit checks that the whole state has been imported, flushes the pending
+// writes to the database and returns the state root.
+func (imp *StateImporter) Commit() (common.Hash, error) {
+	if imp.next != nil {
+		return common.Hash{}, fmt.Errorf("state incomplete, next chunk starts at %x", *imp.next)
+	}
+	if root := imp.accounts.Hash(); root != imp.root {
+		return common.Hash{}, fmt.Errorf("state root mismatch: have %x, want %x", root, imp.root)
+	}
+	if err := imp.batch.Write(); err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Imported state", "root", imp.root, "accounts", imp.count)
+	return imp.root, nil
+}
+
+// incHash returns the hash following the given one.
+func incHash(h common.Hash) common.Hash {
+	var n uint256.Int
+	n.SetBytes(h[:])
+	n.AddUint64(&n, 1)
+	return n.Bytes32()
+}
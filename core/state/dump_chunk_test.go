@@ -0,0 +1,169 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+)
+
+func TestDumpChunks(t *testing.T) {
+	var (
+		tdb      = NewDatabase(triedb.NewDatabase(rawdb.NewMemoryDatabase(), &triedb.Config{Preimages: true}), nil)
+		state, _ = New(types.EmptyRootHash, tdb)
+	)
+	for i := byte(1); i <= 20; i++ {
+		addr := common.BytesToAddress([]byte{i})
+		state.SetBalance(addr, uint256.NewInt(uint64(i)), tracing.BalanceChangeUnspecified)
+		state.SetNonce(addr, uint64(i), tracing.NonceChangeUnspecified)
+		if i%3 == 0 {
+			state.SetCode(addr, []byte{i, i, i})
+			for j := byte(1); j <= i; j++ {
+				state.SetState(addr, common.Hash{j}, common.Hash{i, j})
+			}
+		}
+	}
+	root, err := state.Commit(0, false, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	state, _ = New(root, tdb)
+
+	var chunks []*StateChunk
+	err = state.DumpChunks(common.Hash{}, 8, func(chunk *StateChunk) error {
+		// Round trip the chunk through its serialized form
+		blob, err := json.Marshal(chunk)
+		if err != nil {
+			return err
+		}
+		dec := new(StateChunk)
+		if err := json.Unmarshal(blob, dec); err != nil {
+			return err
+		}
+		chunks = append(chunks, dec)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to dump state: %v", err)
+	}
+	if len(chunks) < 3 {
+		t.Fatalf("state not chunked: %d chunks", len(chunks))
+	}
+	var accounts int
+	for i, chunk := range chunks {
+		if err := VerifyChunk(chunk); err != nil {
+			t.Fatalf("chunk %d: invalid: %v", i, err)
+		}
+		accounts += len(chunk.Accounts)
+	}
+	if accounts != 20 {
+		t.Fatalf("wrong number of accounts dumped: have %d, want 20", accounts)
+	}
+	// Resuming a dump must yield the same chunk.
+	resumed, err := state.DumpChunk(*chunks[0].Next, 8)
+	if err != nil {
+		t.Fatalf("failed to resume dump: %v", err)
+	}
+	have, _ := json.Marshal(resumed)
+	want, _ := json.Marshal(chunks[1])
+	if string(have) != string(want) {
+		t.Fatal("resumed chunk mismatch")
+	}
+	// Import the state into a fresh database and check its content.
+	db := rawdb.NewMemoryDatabase()
+	imp := NewStateImporter(db, rawdb.HashScheme)
+	if err := imp.Import(chunks[1]); err == nil {
+		t.Fatal("out of order chunk imported")
+	}
+	for i, chunk := range chunks[:len(chunks)-1] {
+		if err := imp.Import(chunk); err != nil {
+			t.Fatalf("chunk %d: failed to import: %v", i, err)
+		}
+	}
+	if _, err := imp.Commit(); err == nil {
+		t.Fatal("incomplete state committed")
+	}
+	if err := imp.Import(chunks[len(chunks)-1]); err != nil {
+		t.Fatalf("failed to import last chunk: %v", err)
+	}
+	if have, err := imp.Commit(); err != nil || have != root {
+		t.Fatalf("wrong imported root: have %x, want %x (%v)", have, root, err)
+	}
+	imported, err := New(root, NewDatabase(triedb.NewDatabase(db, &triedb.Config{Preimages: true}), nil))
+	if err != nil {
+		t.Fatalf("failed to open imported state: %v", err)
+	}
+	for i := byte(1); i <= 20; i++ {
+		addr := common.BytesToAddress([]byte{i})
+		if balance := imported.GetBalance(addr); balance.Uint64() != uint64(i) {
+			t.Fatalf("account %d: wrong balance %v", i, balance)
+		}
+		if i%3 == 0 {
+			if code := imported.GetCode(addr); len(code) != 3 || code[0] != i {
+				t.Fatalf("account %d: wrong code %x", i, code)
+			}
+			if value := imported.GetState(addr, common.Hash{i}); value != (common.Hash{i, i}) {
+				t.Fatalf("account %d: wrong storage %x", i, value)
+			}
+		}
+	}
+	if have := imported.Dump(nil); string(have) != string(state.Dump(nil)) {
+		t.Fatal("imported state dump mismatch")
+	}
+}
+
+func TestVerifyChunkTampered(t *testing.T) {
+	tdb := NewDatabaseForTesting()
+	state, _ := New(types.EmptyRootHash, tdb)
+	for i := byte(1); i <= 10; i++ {
+		addr := common.BytesToAddress([]byte{i})
+		state.SetBalance(addr, uint256.NewInt(uint64(i)), tracing.BalanceChangeUnspecified)
+		state.SetState(addr, common.Hash{i}, common.Hash{i})
+	}
+	root, _ := state.Commit(0, false, false)
+	state, _ = New(root, tdb)
+
+	tests := map[string]func(*StateChunk){
+		"balance": func(c *StateChunk) { c.Accounts[1].Balance = (*hexutil.U256)(uint256.NewInt(1000)) },
+		"dropped": func(c *StateChunk) { c.Accounts = append(c.Accounts[:1], c.Accounts[2:]...) },
+		"storage": func(c *StateChunk) { c.Accounts[0].Storage[0].Value = []byte{0xff} },
+		"code":    func(c *StateChunk) { c.Accounts[0].Code = []byte{0x01} },
+		"next":    func(c *StateChunk) { c.Next = nil },
+		"proof":   func(c *StateChunk) { c.Proof = c.Proof[1:] },
+	}
+	for name, tamper := range tests {
+		chunk, err := state.DumpChunk(common.Hash{}, 6)
+		if err != nil {
+			t.Fatalf("failed to dump chunk: %v", err)
+		}
+		if err := VerifyChunk(chunk); err != nil {
+			t.Fatalf("valid chunk rejected: %v", err)
+		}
+		tamper(chunk)
+		if err := VerifyChunk(chunk); err == nil {
+			t.Errorf("%s: tampered chunk accepted", name)
+		}
+	}
+}
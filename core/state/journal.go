@@ -217,6 +217,10 @@ func (j *journal) touchChange(address common.Address) {
 	}
 }
 
+func (j *journal) precompileCall(addr common.Address) {
+	j.append(precompileCallChange{address: addr})
+}
+
 func (j *journal) accessListAddAccount(addr common.Address) {
 	j.append(accessListAddAccountChange{addr})
 }
@@ -273,6 +277,9 @@ type (
 	touchChange struct {
 		account common.Address
 	}
+	precompileCallChange struct {
+		address common.Address
+	}
 
 	// Changes to the access list
 	accessListAddAccountChange struct {
@@ -461,6 +468,22 @@ func (ch addLogChange) copy() journalEntry {
 	}
 }
 
+func (ch precompileCallChange) revert(s *StateDB) {
+	if s.precompileCalls[ch.address]--; s.precompileCalls[ch.address] == 0 {
+		delete(s.precompileCalls, ch.address)
+	}
+}
+
+func (ch precompileCallChange) dirtied() *common.Address {
+	return nil
+}
+
+func (ch precompileCallChange) copy() journalEntry {
+	return precompileCallChange{
+		address: ch.address,
+	}
+}
+
 func (ch accessListAddAccountChange) revert(s *StateDB) {
 	/*
 		One important invariant here, is that whenever a (addr, slot) is added, if the
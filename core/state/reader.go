@@ -18,9 +18,9 @@ package state
 
 import (
 	"errors"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -80,32 +80,34 @@ type Reader interface {
 type cachingCodeReader struct {
 	db ethdb.KeyValueReader
 
-	// These caches could be shared by multiple code reader instances,
-	// they are natively thread-safe.
-	codeCache     *lru.SizeConstrainedCache[common.Hash, []byte]
-	codeSizeCache *lru.Cache[common.Hash, int]
+	// The cache could be shared by multiple code reader instances, it is
+	// natively thread-safe.
+	codeCache *CodeCache
+
+	hits   atomic.Int64 // Number of code reads served by the code cache
+	misses atomic.Int64 // Number of code reads served by the database
 }
 
 // newCachingCodeReader constructs the code reader.
-func newCachingCodeReader(db ethdb.KeyValueReader, codeCache *lru.SizeConstrainedCache[common.Hash, []byte], codeSizeCache *lru.Cache[common.Hash, int]) *cachingCodeReader {
+func newCachingCodeReader(db ethdb.KeyValueReader, codeCache *CodeCache) *cachingCodeReader {
 	return &cachingCodeReader{
-		db:            db,
-		codeCache:     codeCache,
-		codeSizeCache: codeSizeCache,
+		db:        db,
+		codeCache: codeCache,
 	}
 }
 
 // Code implements ContractCodeReader, retrieving a particular contract's code.
 // If the contract code doesn't exist, no error will be returned.
 func (r *cachingCodeReader) Code(addr common.Address, codeHash common.Hash) ([]byte, error) {
-	code, _ := r.codeCache.Get(codeHash)
+	code := r.codeCache.Code(codeHash)
 	if len(code) > 0 {
+		r.hits.Add(1)
 		return code, nil
 	}
+	r.misses.Add(1)
 	code = rawdb.ReadCode(r.db, codeHash)
 	if len(code) > 0 {
 		r.codeCache.Add(codeHash, code)
-		r.codeSizeCache.Add(codeHash, len(code))
 	}
 	return code, nil
 }
@@ -113,7 +115,7 @@ func (r *cachingCodeReader) Code(addr common.Address, codeHash common.Hash) ([]b
 // CodeSize implements ContractCodeReader, retrieving a particular contracts code's size.
 // If the contract code doesn't exist, no error will be returned.
 func (r *cachingCodeReader) CodeSize(addr common.Address, codeHash common.Hash) (int, error) {
-	if cached, ok := r.codeSizeCache.Get(codeHash); ok {
+	if cached, ok := r.codeCache.CodeSize(codeHash); ok {
 		return cached, nil
 	}
 	code, err := r.Code(addr, codeHash)
@@ -295,6 +297,11 @@ func (r *trieReader) Storage(addr common.Address, key common.Hash) (common.Hash,
 // is determined by the position in the reader list.
 type multiStateReader struct {
 	readers []StateReader // List of state readers, sorted by checking priority
+
+	accountFlat atomic.Int64 // Number of account reads served by the flat state
+	accountTrie atomic.Int64 // Number of account reads served by the trie
+	storageFlat atomic.Int64 // Number of storage reads served by the flat state
+	storageTrie atomic.Int64 // Number of storage reads served by the trie
 }
 
 // newMultiStateReader constructs a multiStateReader instance with the given
@@ -320,6 +327,11 @@ func (r *multiStateReader) Account(addr common.Address) (*types.StateAccount, er
 	for _, reader := range r.readers {
 		acct, err := reader.Account(addr)
 		if err == nil {
+			if _, ok := reader.(*trieReader); ok {
+				r.accountTrie.Add(1)
+			} else {
+				r.accountFlat.Add(1)
+			}
 			return acct, nil
 		}
 		errs = append(errs, err)
@@ -338,6 +350,11 @@ func (r *multiStateReader) Storage(addr common.Address, slot common.Hash) (commo
 	for _, reader := range r.readers {
 		slot, err := reader.Storage(addr, slot)
 		if err == nil {
+			if _, ok := reader.(*trieReader); ok {
+				r.storageTrie.Add(1)
+			} else {
+				r.storageFlat.Add(1)
+			}
 			return slot, nil
 		}
 		errs = append(errs, err)
@@ -358,3 +375,27 @@ func newReader(codeReader ContractCodeReader, stateReader StateReader) *reader {
 		StateReader:        stateReader,
 	}
 }
+
+// ReaderStats contains the statistics of the state reads served by a reader.
+type ReaderStats struct {
+	AccountFlat int64 // Number of account reads served by the flat state
+	AccountTrie int64 // Number of account reads served by the trie
+	StorageFlat int64 // Number of storage reads served by the flat state
+	StorageTrie int64 // Number of storage reads served by the trie
+	CodeHit     int64 // Number of code reads served by the code cache
+	CodeMiss    int64 // Number of code reads served by the database
+}
+
+// stats returns the read statistics gathered by the reader so far. Readers
+// not tracking statistics contribute nothing.
+func (r *reader) stats() ReaderStats {
+	var stats ReaderStats
+	if cr, ok := r.ContractCodeReader.(*cachingCodeReader); ok {
+		stats.CodeHit, stats.CodeMiss = cr.hits.Load(), cr.misses.Load()
+	}
+	if sr, ok := r.StateReader.(*multiStateReader); ok {
+		stats.AccountFlat, stats.AccountTrie = sr.accountFlat.Load(), sr.accountTrie.Load()
+		stats.StorageFlat, stats.StorageTrie = sr.storageFlat.Load(), sr.storageTrie.Load()
+	}
+	return stats
+}
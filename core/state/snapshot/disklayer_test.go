@@ -20,8 +20,8 @@ import (
 	"bytes"
 	"testing"
 
-	"github.com/VictoriaMetrics/fastcache"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/cachemgr"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -102,7 +102,7 @@ func TestDiskMerge(t *testing.T) {
 		layers: map[common.Hash]snapshot{
 			baseRoot: &diskLayer{
 				diskdb: db,
-				cache:  fastcache.New(500 * 1024),
+				cache:  cachemgr.NewCache(500 * 1024),
 				root:   baseRoot,
 			},
 		},
@@ -300,7 +300,7 @@ func TestDiskPartialMerge(t *testing.T) {
 			layers: map[common.Hash]snapshot{
 				baseRoot: &diskLayer{
 					diskdb: db,
-					cache:  fastcache.New(500 * 1024),
+					cache:  cachemgr.NewCache(500 * 1024),
 					root:   baseRoot,
 				},
 			},
@@ -464,7 +464,7 @@ func TestDiskGeneratorPersistence(t *testing.T) {
 		layers: map[common.Hash]snapshot{
 			baseRoot: &diskLayer{
 				diskdb:    db,
-				cache:     fastcache.New(500 * 1024),
+				cache:     cachemgr.NewCache(500 * 1024),
 				root:      baseRoot,
 				genMarker: genMarker,
 			},
@@ -547,7 +547,7 @@ func TestDiskSeek(t *testing.T) {
 		layers: map[common.Hash]snapshot{
 			baseRoot: &diskLayer{
 				diskdb: db,
-				cache:  fastcache.New(500 * 1024),
+				cache:  cachemgr.NewCache(500 * 1024),
 				root:   baseRoot,
 			},
 		},
@@ -840,6 +840,30 @@ func (t *Tree) DiskRoot() common.Hash {
 	return t.diskRoot()
 }
 
+// ResizeCache changes the capacity of the disk layer cache to the given number
+// of bytes, also applied to the layers regenerated afterwards.
+func (t *Tree) ResizeCache(size int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.config.CacheSize = size / (1024 * 1024)
+	if dl := t.disklayer(); dl != nil {
+		dl.cache.Resize(size)
+	}
+}
+
+// CacheStats returns the number of lookups which hit and missed the disk layer
+// cache. The counters restart if the snapshot is regenerated.
+func (t *Tree) CacheStats() (hits, misses uint64) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if dl := t.disklayer(); dl != nil {
+		return dl.cache.Stats()
+	}
+	return 0, 0
+}
+
 // Size returns the memory usage of the diff layers above the disk layer and the
 // dirty nodes buffered in the disk layer. Currently, the implementation uses a
 // special diff layer (the first) as an aggregator simulating a dirty buffer, so
@@ -130,6 +130,9 @@ type StateDB struct {
 	// Transient storage
 	transientStorage transientStorage
 
+	// Invocations of the capped precompiles in the scope of block
+	precompileCalls map[common.Address]uint64
+
 	// Journal of state modifications. This is the backbone of
 	// Snapshot and RevertToSnapshot.
 	journal *journal
@@ -155,6 +158,9 @@ type StateDB struct {
 	StorageUpdated atomic.Int64 // Number of storage slots updated during the state transition
 	StorageDeleted atomic.Int64 // Number of storage slots deleted during the state transition
 
+	TrieNodesUpdated int // Number of trie nodes updated by the last commit
+	TrieNodesDeleted int // Number of trie nodes deleted by the last commit
+
 	// singlethreaded avoids creation of additional threads when set to true for compatibility with cannon.
 	singlethreaded bool
 }
@@ -240,6 +246,15 @@ func (s *StateDB) Error() error {
 	return s.dbErr
 }
 
+// ReaderStats returns the statistics of the state reads served by the
+// underlying reader since the state was opened.
+func (s *StateDB) ReaderStats() ReaderStats {
+	if r, ok := s.reader.(interface{ stats() ReaderStats }); ok {
+		return r.stats()
+	}
+	return ReaderStats{}
+}
+
 func (s *StateDB) AddLog(log *types.Log) {
 	s.journal.logChange(s.thash)
 
@@ -706,6 +721,7 @@ func (s *StateDB) Copy() *StateDB {
 		logs:                 make(map[common.Hash][]*types.Log, len(s.logs)),
 		logSize:              s.logSize,
 		preimages:            maps.Clone(s.preimages),
+		precompileCalls:      maps.Clone(s.precompileCalls),
 
 		// Do we need to copy the access list and transient storage?
 		// In practice: No. At the start of a transaction, these two lists are empty.
@@ -1281,6 +1297,8 @@ func (s *StateDB) commit(deleteEmptyObjects bool, noStorageWiping bool) (*stateU
 	accountTrieDeletedMeter.Mark(int64(accountTrieNodesDeleted))
 	storageTriesUpdatedMeter.Mark(int64(storageTrieNodesUpdated))
 	storageTriesDeletedMeter.Mark(int64(storageTrieNodesDeleted))
+	s.TrieNodesUpdated = accountTrieNodesUpdated + storageTrieNodesUpdated
+	s.TrieNodesDeleted = accountTrieNodesDeleted + storageTrieNodesDeleted
 
 	// Clear the metric markers
 	s.AccountLoaded, s.AccountUpdated, s.AccountDeleted = 0, 0, 0
@@ -1432,6 +1450,12 @@ func (s *StateDB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
 	}
 }
 
+// AccessList returns the accounts and storage slots in the access list of the
+// current transaction, in no particular order.
+func (s *StateDB) AccessList() types.AccessList {
+	return s.accessList.list()
+}
+
 // AddressInAccessList returns true if the given address is in the access list.
 func (s *StateDB) AddressInAccessList(addr common.Address) bool {
 	return s.accessList.ContainsAddress(addr)
@@ -1480,3 +1504,41 @@ func (s *StateDB) Witness() *stateless.Witness {
 func (s *StateDB) AccessEvents() *AccessEvents {
 	return s.accessEvents
 }
+
+// PrecompileCalls returns the number of invocations of the given precompile
+// so far in the block, see params.PrecompileCap.
+func (s *StateDB) PrecompileCalls(addr common.Address) uint64 {
+	return s.precompileCalls[addr]
+}
+
+// AddPrecompileCall counts an invocation of the given precompile. The count is
+// journaled, invocations in reverted scopes don't count against the cap.
+func (s *StateDB) AddPrecompileCall(addr common.Address) {
+	if s.precompileCalls == nil {
+		s.precompileCalls = make(map[common.Address]uint64)
+	}
+	s.journal.precompileCall(addr)
+	s.precompileCalls[addr]++
+}
+
+// AccessedAccount is the footprint of an account accessed in the state.
+type AccessedAccount struct {
+	Slots    int // Number of storage slots accessed
+	CodeSize int // Size of the contract code, zero if the account is destructed
+}
+
+// AccessedState returns the accounts accessed so far in the current block,
+// including the destructed ones, along with their accessed footprint.
+func (s *StateDB) AccessedState() map[common.Address]AccessedAccount {
+	accessed := make(map[common.Address]AccessedAccount, len(s.stateObjects)+len(s.stateObjectsDestruct))
+	for addr, obj := range s.stateObjectsDestruct {
+		accessed[addr] = AccessedAccount{Slots: len(obj.originStorage)}
+	}
+	for addr, obj := range s.stateObjects {
+		account := accessed[addr]
+		account.Slots += len(obj.originStorage)
+		account.CodeSize = obj.CodeSize()
+		accessed[addr] = account
+	}
+	return accessed
+}
@@ -161,6 +161,14 @@ func (s *hookedStateDB) AccessEvents() *AccessEvents {
 	return s.inner.AccessEvents()
 }
 
+func (s *hookedStateDB) PrecompileCalls(addr common.Address) uint64 {
+	return s.inner.PrecompileCalls(addr)
+}
+
+func (s *hookedStateDB) AddPrecompileCall(addr common.Address) {
+	s.inner.AddPrecompileCall(addr)
+}
+
 func (s *hookedStateDB) SubBalance(addr common.Address, amount *uint256.Int, reason tracing.BalanceChangeReason) uint256.Int {
 	prev := s.inner.SubBalance(addr, amount, reason)
 	if s.hooks.OnBalanceChange != nil && !amount.IsZero() {
@@ -29,7 +29,7 @@ import (
 // of an arbitrary state with the goal of prefetching potentially useful state
 // data from disk before the main block processor start executing.
 type statePrefetcher struct {
-	config *params.ChainConfig // Chain configuration options
+	config *params.ChainConfig // Chain configuration options, nil to follow the chain's
 	chain  *HeaderChain        // Canonical block chain
 }
 
@@ -41,19 +41,28 @@ func newStatePrefetcher(config *params.ChainConfig, chain *HeaderChain) *statePr
 	}
 }
 
+// chainConfig returns the chain configuration to execute blocks with.
+func (p *statePrefetcher) chainConfig() *params.ChainConfig {
+	if p.config != nil {
+		return p.config
+	}
+	return p.chain.Config()
+}
+
 // Prefetch processes the state changes according to the Ethereum rules by running
 // the transaction messages using the statedb, but any changes are discarded. The
 // only goal is to pre-cache transaction signatures and state trie nodes.
 func (p *statePrefetcher) Prefetch(block *types.Block, statedb *state.StateDB, cfg vm.Config, interrupt *atomic.Bool) {
 	var (
+		config       = p.chainConfig()
 		header       = block.Header()
 		gaspool      = new(GasPool).AddGas(block.GasLimit())
-		blockContext = NewEVMBlockContext(header, p.chain, nil, p.config, statedb)
-		evm          = vm.NewEVM(blockContext, statedb, p.config, cfg)
-		signer       = types.MakeSigner(p.config, header.Number, header.Time)
+		blockContext = NewEVMBlockContext(header, p.chain, nil, config, statedb)
+		evm          = vm.NewEVM(blockContext, statedb, config, cfg)
+		signer       = types.MakeSigner(config, header.Number, header.Time)
 	)
 	// Iterate over and process the individual transactions
-	byzantium := p.config.IsByzantium(block.Number())
+	byzantium := config.IsByzantium(block.Number())
 	for i, tx := range block.Transactions() {
 		// If block precaching was interrupted, abort
 		if interrupt != nil && interrupt.Load() {
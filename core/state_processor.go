@@ -35,11 +35,12 @@ import (
 //
 // StateProcessor implements Processor.
 type StateProcessor struct {
-	config *params.ChainConfig // Chain configuration options
+	config *params.ChainConfig // Chain configuration options, nil to follow the chain's
 	chain  *HeaderChain        // Canonical header chain
 }
 
-// NewStateProcessor initialises a new StateProcessor.
+// NewStateProcessor initialises a new StateProcessor. If no config is given, the
+// one of the header chain is used, picking up forks scheduled at runtime.
 func NewStateProcessor(config *params.ChainConfig, chain *HeaderChain) *StateProcessor {
 	return &StateProcessor{
 		config: config,
@@ -47,6 +48,14 @@ func NewStateProcessor(config *params.ChainConfig, chain *HeaderChain) *StatePro
 	}
 }
 
+// chainConfig returns the chain configuration to process blocks with.
+func (p *StateProcessor) chainConfig() *params.ChainConfig {
+	if p.config != nil {
+		return p.config
+	}
+	return p.chain.Config()
+}
+
 // Process processes the state changes according to the Ethereum rules by running
 // the transaction messages using the statedb and applying any rewards to both
 // the processor (coinbase) and any included uncles.
@@ -63,29 +72,30 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		blockNumber = block.Number()
 		allLogs     []*types.Log
 		gp          = new(GasPool).AddGas(block.GasLimit())
+		config      = p.chainConfig()
 	)
 
 	// Mutate the block and state according to any hard-fork specs
-	if p.config.DAOForkSupport && p.config.DAOForkBlock != nil && p.config.DAOForkBlock.Cmp(block.Number()) == 0 {
+	if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(block.Number()) == 0 {
 		misc.ApplyDAOHardFork(statedb)
 	}
-	misc.EnsureCreate2Deployer(p.config, block.Time(), statedb)
+	misc.EnsureCreate2Deployer(config, block.Time(), statedb)
 	var (
 		context vm.BlockContext
-		signer  = types.MakeSigner(p.config, header.Number, header.Time)
+		signer  = types.MakeSigner(config, header.Number, header.Time)
 	)
 	// Apply pre-execution system calls.
 	tracingStateDB := vm.StateDB(statedb)
 	if hooks := cfg.Tracer; hooks != nil {
 		tracingStateDB = state.NewHookedState(statedb, hooks)
 	}
-	context = NewEVMBlockContext(header, p.chain, nil, p.config, statedb)
-	evm := vm.NewEVM(context, tracingStateDB, p.config, cfg)
+	context = NewEVMBlockContext(header, p.chain, nil, config, statedb)
+	evm := vm.NewEVM(context, tracingStateDB, config, cfg)
 
 	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
 		ProcessBeaconBlockRoot(*beaconRoot, evm)
 	}
-	if p.config.IsPrague(block.Number(), block.Time()) || p.config.IsVerkle(block.Number(), block.Time()) {
+	if config.IsPrague(block.Number(), block.Time()) || config.IsVerkle(block.Number(), block.Time()) {
 		ProcessParentBlockHash(block.ParentHash(), evm)
 	}
 
@@ -105,14 +115,14 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		allLogs = append(allLogs, receipt.Logs...)
 	}
 
-	isIsthmus := p.config.IsIsthmus(block.Time())
+	isIsthmus := config.IsIsthmus(block.Time())
 
 	// Read requests if Prague is enabled.
 	var requests [][]byte
-	if p.config.IsPrague(block.Number(), block.Time()) && !isIsthmus {
+	if config.IsPrague(block.Number(), block.Time()) && !isIsthmus {
 		requests = [][]byte{}
 		// EIP-6110
-		if err := ParseDepositLogs(&requests, allLogs, p.config); err != nil {
+		if err := ParseDepositLogs(&requests, allLogs, config); err != nil {
 			return nil, err
 		}
 		// EIP-7002
@@ -157,6 +167,11 @@ func ApplyTransactionWithEVM(msg *Message, gp *GasPool, statedb *state.StateDB,
 	if msg.IsDepositTx && evm.ChainConfig().IsOptimismRegolith(evm.Context.Time) {
 		nonce = statedb.GetNonce(msg.From)
 	}
+	if st := tx.SystemTxType(); st != nil {
+		if err := st.Validate(evm.ChainConfig(), evm.Context.Time, tx); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSystemTx, err)
+		}
+	}
 
 	// Apply the transaction to the current state (included in the env).
 	result, err := ApplyMessage(evm, msg, gp)
@@ -178,7 +193,52 @@ func ApplyTransactionWithEVM(msg *Message, gp *GasPool, statedb *state.StateDB,
 		statedb.AccessEvents().Merge(evm.AccessEvents)
 	}
 
-	return MakeReceipt(evm, result, statedb, blockNumber, blockHash, tx, *usedGas, root, evm.ChainConfig(), nonce), nil
+	receipt = MakeReceipt(evm, result, statedb, blockNumber, blockHash, tx, *usedGas, root, evm.ChainConfig(), nonce)
+	receipt.Fees = makeTxFees(evm, msg, result, receipt)
+	return receipt, nil
+}
+
+// makeTxFees computes the breakdown of the fees paid by a transaction, the same
+// way they are charged by the state transition.
+func makeTxFees(evm *vm.EVM, msg *Message, result *ExecutionResult, receipt *types.Receipt) *types.TxFees {
+	fees := &types.TxFees{
+		GasRefund:   result.RefundedGas,
+		Refund:      new(big.Int),
+		Tip:         new(big.Int),
+		BaseFee:     new(big.Int),
+		BlobFee:     new(big.Int),
+		L1Fee:       new(big.Int),
+		OperatorFee: new(big.Int),
+	}
+	// Deposits are paid for on L1
+	if msg.IsDepositTx {
+		return fees
+	}
+	var (
+		config  = evm.ChainConfig()
+		gasUsed = new(big.Int).SetUint64(result.UsedGas)
+		tip     = msg.GasPrice
+	)
+	fees.Refund.Mul(new(big.Int).SetUint64(result.RefundedGas), msg.GasPrice)
+	if config.IsLondon(evm.Context.BlockNumber) {
+		fees.BaseFee.Mul(gasUsed, evm.Context.BaseFee)
+		tip = new(big.Int).Sub(msg.GasPrice, evm.Context.BaseFee)
+	}
+	fees.Tip.Mul(gasUsed, tip)
+	if receipt.BlobGasUsed > 0 {
+		fees.BlobFee.Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), evm.Context.BlobBaseFee)
+	}
+	if config.Optimism != nil && config.IsOptimismBedrock(evm.Context.BlockNumber) {
+		if evm.Context.L1CostFunc != nil {
+			if l1Cost := evm.Context.L1CostFunc(msg.RollupCostData, evm.Context.Time); l1Cost != nil {
+				fees.L1Fee.Set(l1Cost)
+			}
+		}
+		if config.IsOptimismIsthmus(evm.Context.Time) && evm.Context.OperatorCostFunc != nil {
+			fees.OperatorFee = evm.Context.OperatorCostFunc(result.UsedGas, evm.Context.Time).ToBig()
+		}
+	}
+	return fees
 }
 
 // MakeReceipt generates the receipt object for a transaction given its execution result.
@@ -194,16 +254,8 @@ func MakeReceipt(evm *vm.EVM, result *ExecutionResult, statedb *state.StateDB, b
 	receipt.TxHash = tx.Hash()
 	receipt.GasUsed = result.UsedGas
 
-	if tx.IsDepositTx() && config.IsOptimismRegolith(evm.Context.Time) {
-		// The actual nonce for deposit transactions is only recorded from Regolith onwards and
-		// otherwise must be nil.
-		receipt.DepositNonce = &nonce
-		// The DepositReceiptVersion for deposit transactions is only recorded from Canyon onwards
-		// and otherwise must be nil.
-		if config.IsOptimismCanyon(evm.Context.Time) {
-			receipt.DepositReceiptVersion = new(uint64)
-			*receipt.DepositReceiptVersion = types.CanyonDepositReceiptVersion
-		}
+	if st := tx.SystemTxType(); st != nil {
+		st.ProcessReceipt(config, evm.Context.Time, tx, receipt, nonce)
 	}
 	if tx.Type() == types.BlobTxType {
 		receipt.BlobGasUsed = uint64(len(tx.BlobHashes()) * params.BlobTxBlobGasPerBlob)
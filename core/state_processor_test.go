@@ -423,3 +423,62 @@ func GenerateBadBlock(parent *types.Block, engine consensus.Engine, txs types.Tr
 	}
 	return types.NewBlock(header, body, receipts, trie.NewStackTrie(nil), config)
 }
+
+// TestTxFees tests that the fee breakdown of the processed transactions, in
+// particular the gas refund, is stored along with the receipts.
+func TestTxFees(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0de")
+		gspec    = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Ether)},
+				// Clears storage slot 0: PUSH1 0 PUSH1 0 SSTORE
+				contract: {Code: common.FromHex("0x6000600055"), Storage: map[common.Hash]common.Hash{{}: {1}}},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		tip    = big.NewInt(params.GWei)
+	)
+	db, blocks, receipts := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 1, func(i int, b *BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			To:        &contract,
+			Gas:       50000,
+			GasTipCap: tip,
+			GasFeeCap: new(big.Int).Add(b.BaseFee(), tip),
+		}))
+	})
+	blockchain, _ := NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	defer blockchain.Stop()
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	fees := rawdb.ReadTxFees(db, blocks[0].Hash(), 1)
+	if len(fees) != 1 {
+		t.Fatalf("wrong number of stored fees: have %d, want 1", len(fees))
+	}
+	var (
+		gasUsed = receipts[0][0].GasUsed
+		refund  = params.SstoreClearsScheduleRefundEIP3529
+		used    = new(big.Int).SetUint64(gasUsed)
+	)
+	if fees[0].GasRefund != refund {
+		t.Fatalf("wrong gas refund: have %d, want %d", fees[0].GasRefund, refund)
+	}
+	if want := new(big.Int).Mul(used, tip); fees[0].Tip.Cmp(want) != 0 {
+		t.Errorf("wrong tip: have %v, want %v", fees[0].Tip, want)
+	}
+	if want := new(big.Int).Mul(used, blocks[0].BaseFee()); fees[0].BaseFee.Cmp(want) != 0 {
+		t.Errorf("wrong base fee: have %v, want %v", fees[0].BaseFee, want)
+	}
+	price := new(big.Int).Add(blocks[0].BaseFee(), tip)
+	if want := new(big.Int).Mul(new(big.Int).SetUint64(refund), price); fees[0].Refund.Cmp(want) != 0 {
+		t.Errorf("wrong refund: have %v, want %v", fees[0].Refund, want)
+	}
+	if fees[0].BlobFee.Sign() != 0 || fees[0].L1Fee.Sign() != 0 || fees[0].OperatorFee.Sign() != 0 {
+		t.Errorf("unexpected fees: blob %v, l1 %v, operator %v", fees[0].BlobFee, fees[0].L1Fee, fees[0].OperatorFee)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/state"
+)
+
+// StateRentConfig is the hypothetical state rent scheme modeled by the state
+// rent ledger. It has no effect on consensus.
+type StateRentConfig struct {
+	Rate        uint64 // Rent in wei per byte of state per block
+	TouchFee    uint64 // Flat fee in wei per account touched in a block
+	AccountSize uint64 // Bytes of state charged for an account
+	SlotSize    uint64 // Bytes of state charged for an accessed storage slot
+	MaxAccounts int    // Accounts kept in the ledger, the least recently touched are evicted (0 = default)
+}
+
+// defaultStateRentAccounts is the number of accounts kept in the ledger if not
+// configured, roughly 200MB of memory.
+const defaultStateRentAccounts = 1_000_000
+
+// DefaultStateRentConfig is the default state rent scheme, charging the
+// approximate size of the account and storage trie leaves.
+var DefaultStateRentConfig = StateRentConfig{
+	Rate:        1,
+	AccountSize: 100,
+	SlotSize:    64,
+	MaxAccounts: defaultStateRentAccounts,
+}
+
+// StateRentAccount is the rent accounted to a single account.
+type StateRentAccount struct {
+	Address      common.Address
+	FirstTouched uint64   // Block the account was first touched in
+	LastTouched  uint64   // Block the account was last touched in
+	Touches      uint64   // Number of blocks the account was touched in
+	Charged      *big.Int // Rent and touch fees charged in total
+}
+
+// StateRentLedger records the rent the accounts would be charged under a
+// hypothetical state rent scheme, as the blocks are executed. When an account
+// is touched, it is charged the touch fee and the rent of its footprint for
+// the blocks elapsed since it was last touched. The footprint is the account
+// itself, its code and the storage slots accessed in the block. No rent is
+// charged when an account is first touched, as its history is unknown.
+//
+// The ledger is kept in memory and starts over on restart. It holds at most
+// MaxAccounts accounts: the least recently touched ones are evicted, and start
+// over like untouched accounts if touched again. The totals keep the charges
+// of the evicted accounts. Blocks executed more than once, e.g. reorged ones,
+// are accounted each time.
+type StateRentLedger struct {
+	config StateRentConfig
+
+	accounts lru.BasicLRU[common.Address, *StateRentAccount]
+	evicted  uint64   // Number of accounts evicted from the ledger
+	blocks   uint64   // Number of blocks accounted
+	charged  *big.Int // Rent and touch fees charged in total
+	lock     sync.RWMutex
+}
+
+// NewStateRentLedger creates an empty state rent ledger for the given scheme.
+func NewStateRentLedger(config StateRentConfig) *StateRentLedger {
+	if config.MaxAccounts <= 0 {
+		config.MaxAccounts = defaultStateRentAccounts
+	}
+	return &StateRentLedger{
+		config:   config,
+		accounts: lru.NewBasicLRU[common.Address, *StateRentAccount](config.MaxAccounts),
+		charged:  new(big.Int),
+	}
+}
+
+// Config returns the state rent scheme modeled by the ledger.
+func (l *StateRentLedger) Config() StateRentConfig {
+	return l.config
+}
+
+// record charges the accounts accessed in the execution of the given block.
+func (l *StateRentLedger) record(number uint64, accessed map[common.Address]state.AccessedAccount) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var (
+		rate   = new(big.Int).SetUint64(l.config.Rate)
+		charge = new(big.Int)
+	)
+	for addr, footprint := range accessed {
+		account, ok := l.accounts.Get(addr)
+		if !ok {
+			account = &StateRentAccount{
+				Address:      addr,
+				FirstTouched: number,
+				LastTouched:  number,
+				Charged:      new(big.Int),
+			}
+			if l.accounts.Add(addr, account) {
+				l.evicted++
+			}
+		}
+		charge.SetUint64(l.config.TouchFee)
+		if number > account.LastTouched {
+			size := l.config.AccountSize + uint64(footprint.CodeSize) + l.config.SlotSize*uint64(footprint.Slots)
+			rent := new(big.Int).SetUint64(size)
+			rent.Mul(rent, rate)
+			rent.Mul(rent, new(big.Int).SetUint64(number-account.LastTouched))
+			charge.Add(charge, rent)
+			account.LastTouched = number
+		}
+		account.Touches++
+		account.Charged.Add(account.Charged, charge)
+		l.charged.Add(l.charged, charge)
+	}
+	l.blocks++
+}
+
+// Account returns the rent accounted to the given account, or nil if it was
+// never touched.
+func (l *StateRentLedger) Account(addr common.Address) *StateRentAccount {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	account, ok := l.accounts.Peek(addr)
+	if !ok {
+		return nil
+	}
+	return copyStateRentAccount(account)
+}
+
+// Totals returns the number of blocks accounted and of accounts in the ledger,
+// along with the rent and touch fees charged in total.
+func (l *StateRentLedger) Totals() (blocks uint64, accounts int, charged *big.Int) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.blocks, l.accounts.Len(), new(big.Int).Set(l.charged)
+}
+
+// Evicted returns the number of accounts evicted from the ledger.
+func (l *StateRentLedger) Evicted() uint64 {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.evicted
+}
+
+// Top returns at most limit accounts charged the most, in decreasing order.
+func (l *StateRentLedger) Top(limit int) []*StateRentAccount {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	accounts := make([]*StateRentAccount, 0, l.accounts.Len())
+	for _, addr := range l.accounts.Keys() {
+		account, _ := l.accounts.Peek(addr)
+		accounts = append(accounts, account)
+	}
+	slices.SortFunc(accounts, func(a, b *StateRentAccount) int {
+		if c := b.Charged.Cmp(a.Charged); c != 0 {
+			return c
+		}
+		return a.Address.Cmp(b.Address)
+	})
+	if len(accounts) > limit {
+		accounts = accounts[:limit]
+	}
+	for i, account := range accounts {
+		accounts[i] = copyStateRentAccount(account)
+	}
+	return accounts
+}
+
+func copyStateRentAccount(account *StateRentAccount) *StateRentAccount {
+	cpy := *account
+	cpy.Charged = new(big.Int).Set(account.Charged)
+	return &cpy
+}
+
+// StateRentLedger returns the hypothetical state rent ledger of the chain, or
+// nil if disabled.
+func (bc *BlockChain) StateRentLedger() *StateRentLedger {
+	return bc.rentLedger
+}
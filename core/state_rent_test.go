@@ -0,0 +1,143 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestStateRentLedger(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0de")
+		code     = common.FromHex("0x6000546001016000556000") // Increments storage slot 0
+		gspec    = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr:     {Balance: big.NewInt(params.Ether)},
+				contract: {Code: code},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		config = StateRentConfig{Rate: 2, TouchFee: 10, AccountSize: 100, SlotSize: 64}
+	)
+	// The contract is touched in blocks 1, 2 and 4
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 4, func(i int, b *BlockGen) {
+		if i == 2 {
+			return
+		}
+		b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    b.TxNonce(addr),
+			To:       &contract,
+			Gas:      50000,
+			GasPrice: b.header.BaseFee,
+		}))
+	})
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.StateRent = &config
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	ledger := chain.StateRentLedger()
+
+	// The first touch is only charged the touch fee, the later ones the rent
+	// of the account, its code and the accessed slot since the last touch.
+	var (
+		size = config.AccountSize + uint64(len(code)) + config.SlotSize
+		want = 3*config.TouchFee + config.Rate*size*1 + config.Rate*size*2
+	)
+	account := ledger.Account(contract)
+	if account == nil {
+		t.Fatal("contract not accounted")
+	}
+	if account.FirstTouched != 1 || account.LastTouched != 4 || account.Touches != 3 {
+		t.Fatalf("wrong touches: first %d, last %d, count %d", account.FirstTouched, account.LastTouched, account.Touches)
+	}
+	if account.Charged.Uint64() != want {
+		t.Fatalf("wrong charge: have %v, want %d", account.Charged, want)
+	}
+	if ledger.Account(common.HexToAddress("0xdead")) != nil {
+		t.Fatal("untouched account accounted")
+	}
+	blocksAccounted, accounts, charged := ledger.Totals()
+	if blocksAccounted != uint64(len(blocks)) {
+		t.Fatalf("wrong number of blocks: have %d, want %d", blocksAccounted, len(blocks))
+	}
+	top := ledger.Top(accounts)
+	if len(top) != accounts {
+		t.Fatalf("wrong number of top accounts: have %d, want %d", len(top), accounts)
+	}
+	total := new(big.Int)
+	for i, account := range top {
+		if i > 0 && account.Charged.Cmp(top[i-1].Charged) > 0 {
+			t.Fatalf("top accounts not sorted at %d", i)
+		}
+		total.Add(total, account.Charged)
+	}
+	if total.Cmp(charged) != 0 {
+		t.Fatalf("wrong total charge: have %v, want %v", charged, total)
+	}
+	if top := ledger.Top(1); len(top) != 1 {
+		t.Fatalf("wrong number of top accounts: have %d, want 1", len(top))
+	}
+}
+
+// Tests that the ledger evicts the least recently touched accounts beyond its
+// capacity, keeping their charges in the totals.
+func TestStateRentLedgerEviction(t *testing.T) {
+	ledger := NewStateRentLedger(StateRentConfig{Rate: 1, TouchFee: 1, MaxAccounts: 2})
+
+	var (
+		a = common.Address{0x0a}
+		b = common.Address{0x0b}
+		c = common.Address{0x0c}
+	)
+	ledger.record(1, map[common.Address]state.AccessedAccount{a: {}, b: {}})
+	ledger.record(2, map[common.Address]state.AccessedAccount{a: {}})
+	ledger.record(3, map[common.Address]state.AccessedAccount{c: {}})
+
+	if ledger.Account(b) != nil {
+		t.Fatal("least recently touched account not evicted")
+	}
+	if ledger.Account(a) == nil || ledger.Account(c) == nil {
+		t.Fatal("recently touched account evicted")
+	}
+	_, accounts, charged := ledger.Totals()
+	if accounts != 2 || ledger.Evicted() != 1 {
+		t.Fatalf("wrong ledger size: have %d accounts and %d evicted, want 2 and 1", accounts, ledger.Evicted())
+	}
+	if charged.Uint64() != 4 {
+		t.Fatalf("wrong total charge: have %v, want 4", charged)
+	}
+}
@@ -34,10 +34,11 @@ import (
 // ExecutionResult includes all output after executing given evm
 // message no matter the execution itself is successful or not.
 type ExecutionResult struct {
-	UsedGas    uint64 // Total used gas, not including the refunded gas
-	MaxUsedGas uint64 // Maximum gas consumed during execution, excluding gas refunds.
-	Err        error  // Any error encountered during the execution(listed in core/vm/errors.go)
-	ReturnData []byte // Returned data from evm(function result or data supplied with revert opcode)
+	UsedGas     uint64 // Total used gas, not including the refunded gas
+	MaxUsedGas  uint64 // Maximum gas consumed during execution, excluding gas refunds.
+	RefundedGas uint64 // Gas refunded at the end of execution, net of the data floor
+	Err         error  // Any error encountered during the execution(listed in core/vm/errors.go)
+	ReturnData  []byte // Returned data from evm(function result or data supplied with revert opcode)
 }
 
 // Unwrap returns the internal evm error which allows us for further
@@ -166,7 +167,7 @@ type Message struct {
 	SkipFromEOACheck bool
 
 	IsSystemTx     bool                 // IsSystemTx indicates the message, if also a deposit, does not emit gas usage.
-	IsDepositTx    bool                 // IsDepositTx indicates the message is force-included and can persist a mint.
+	IsDepositTx    bool                 // IsDepositTx indicates the message is a deposit or another system transaction: force-included, free of fees and can persist a mint.
 	Mint           *big.Int             // Mint is the amount to mint before EVM processing, or nil if there is no minting.
 	RollupCostData types.RollupCostData // RollupCostData caches data to compute the fee we charge for data availability
 }
@@ -190,7 +191,7 @@ func TransactionToMessage(tx *types.Transaction, s types.Signer, baseFee *big.In
 		BlobGasFeeCap:         tx.BlobGasFeeCap(),
 
 		IsSystemTx:     tx.IsSystemTx(),
-		IsDepositTx:    tx.IsDepositTx(),
+		IsDepositTx:    tx.SystemTxType() != nil,
 		Mint:           tx.Mint(),
 		RollupCostData: tx.RollupCostData(),
 	}
@@ -632,6 +633,10 @@ func (st *stateTransition) innerExecute() (*ExecutionResult, error) {
 			peakGasUsed = floorDataGas
 		}
 	}
+	var refundedGas uint64
+	if st.gasUsed() < peakGasUsed {
+		refundedGas = peakGasUsed - st.gasUsed()
+	}
 	st.returnGas()
 
 	// OP-Stack: Note for deposit tx there is no ETH refunded for unused gas, but that's taken care of by the fact that gasPrice
@@ -640,10 +645,11 @@ func (st *stateTransition) innerExecute() (*ExecutionResult, error) {
 	if st.msg.IsDepositTx && rules.IsOptimismRegolith {
 		// Skip coinbase payments for deposit tx in Regolith
 		return &ExecutionResult{
-			UsedGas:    st.gasUsed(),
-			MaxUsedGas: peakGasUsed,
-			Err:        vmerr,
-			ReturnData: ret,
+			UsedGas:     st.gasUsed(),
+			MaxUsedGas:  peakGasUsed,
+			RefundedGas: refundedGas,
+			Err:         vmerr,
+			ReturnData:  ret,
 		}, nil
 	}
 
@@ -697,10 +703,11 @@ func (st *stateTransition) innerExecute() (*ExecutionResult, error) {
 	}
 
 	return &ExecutionResult{
-		UsedGas:    st.gasUsed(),
-		MaxUsedGas: peakGasUsed,
-		Err:        vmerr,
-		ReturnData: ret,
+		UsedGas:     st.gasUsed(),
+		MaxUsedGas:  peakGasUsed,
+		RefundedGas: refundedGas,
+		Err:         vmerr,
+		ReturnData:  ret,
 	}, nil
 }
 
@@ -57,11 +57,11 @@ func ExecuteStateless(config *params.ChainConfig, vmconfig vm.Config, block *typ
 	}
 	// Create a blockchain that is idle, but can be used to access headers through
 	chain := &HeaderChain{
-		config:      config,
 		chainDb:     memdb,
 		headerCache: lru.NewCache[common.Hash, *types.Header](256),
 		engine:      beacon.New(ethash.NewFaker()),
 	}
+	chain.config.Store(config)
 	processor := NewStateProcessor(config, chain)
 	validator := NewBlockValidator(config, nil) // No chain, we only validate the state, not the block
 
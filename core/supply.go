@@ -0,0 +1,144 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	supplyTotalGauge    = metrics.NewRegisteredGaugeFloat64("chain/supply/total", nil)
+	supplyIssuanceGauge = metrics.NewRegisteredGaugeFloat64("chain/supply/issuance", nil)
+	supplyBurnGauge     = metrics.NewRegisteredGaugeFloat64("chain/supply/burn", nil)
+)
+
+// blockSupply computes the supply change of a block from its balance changes,
+// along with the total supply after it if the one after the parent is known.
+//
+// The net of all balance changes is the supply change. Issuance is attributed
+// by the reason of the changes, blob fees are derived from the header and the
+// remainder of the burn is attributed to the fees, as the base fee burn is not
+// visible as a balance change of its own.
+func blockSupply(config *params.ChainConfig, header *types.Header, changes []*types.BalanceChange, parent *big.Int) *types.Supply {
+	supply := &types.Supply{
+		Reward:       new(big.Int),
+		Withdrawals:  new(big.Int),
+		Deposits:     new(big.Int),
+		Fees:         new(big.Int),
+		Blob:         new(big.Int),
+		Selfdestruct: new(big.Int),
+	}
+	delta := new(big.Int)
+	for _, change := range changes {
+		if change.Nonce {
+			continue
+		}
+		diff := new(big.Int).Sub(change.New, change.Prev)
+		delta.Add(delta, diff)
+
+		switch tracing.BalanceChangeReason(change.Reason) {
+		case tracing.BalanceIncreaseRewardMineBlock, tracing.BalanceIncreaseRewardMineUncle:
+			supply.Reward.Add(supply.Reward, diff)
+		case tracing.BalanceIncreaseWithdrawal:
+			supply.Withdrawals.Add(supply.Withdrawals, diff)
+		case tracing.BalanceMint:
+			supply.Deposits.Add(supply.Deposits, diff)
+		case tracing.BalanceIncreaseSelfdestruct, tracing.BalanceDecreaseSelfdestruct, tracing.BalanceDecreaseSelfdestructBurn:
+			// Self-destructs move the balance to the beneficiary, burning it
+			// if that is the destructed contract itself
+			supply.Selfdestruct.Sub(supply.Selfdestruct, diff)
+		}
+	}
+	if header.BlobGasUsed != nil && header.ExcessBlobGas != nil {
+		supply.Blob.Mul(eip4844.CalcBlobFee(config, header), new(big.Int).SetUint64(*header.BlobGasUsed))
+	}
+	supply.Fees.Sub(supply.Issuance(), delta)
+	supply.Fees.Sub(supply.Fees, supply.Blob)
+	supply.Fees.Sub(supply.Fees, supply.Selfdestruct)
+
+	if parent != nil {
+		supply.Total = new(big.Int).Add(parent, delta)
+	}
+	return supply
+}
+
+// parentSupply returns the total supply after the parent of the given block,
+// or nil if unknown. The supply of the genesis block is derived from its
+// allocation, and recorded on first use.
+func (bc *BlockChain) parentSupply(header *types.Header) *big.Int {
+	if supply := rawdb.ReadSupply(bc.db, header.ParentHash, header.Number.Uint64()-1); supply != nil {
+		return supply.Total
+	}
+	if header.Number.Uint64() != 1 {
+		return nil
+	}
+	// Only decode the balances, the accounts may omit them
+	var (
+		genesis = bc.genesisBlock
+		alloc   map[string]struct {
+			Balance *math.HexOrDecimal256 `json:"balance"`
+		}
+	)
+	if err := json.Unmarshal(rawdb.ReadGenesisStateSpec(bc.db, genesis.Hash()), &alloc); err != nil {
+		return nil
+	}
+	// Genesis states loaded by root carry no allocation to sum up
+	if len(alloc) == 0 && genesis.Root() != types.EmptyRootHash {
+		return nil
+	}
+	total := new(big.Int)
+	for _, account := range alloc {
+		if account.Balance != nil {
+			total.Add(total, (*big.Int)(account.Balance))
+		}
+	}
+	rawdb.WriteSupply(bc.db, genesis.Hash(), 0, &types.Supply{
+		Reward:       new(big.Int),
+		Withdrawals:  new(big.Int),
+		Deposits:     new(big.Int),
+		Fees:         new(big.Int),
+		Blob:         new(big.Int),
+		Selfdestruct: new(big.Int),
+		Total:        total,
+	})
+	return total
+}
+
+// reportSupply updates the supply metrics with the change of a new head block.
+func reportSupply(supply *types.Supply) {
+	supplyIssuanceGauge.Update(weiToEther(supply.Issuance()))
+	supplyBurnGauge.Update(weiToEther(supply.Burn()))
+	if supply.Total != nil {
+		supplyTotalGauge.Update(weiToEther(supply.Total))
+	}
+}
+
+// weiToEther converts a wei amount to a floating point ether amount, for use in
+// the metrics.
+func weiToEther(wei *big.Int) float64 {
+	ether, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.Ether)).Float64()
+	return ether
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSupplyTracking(t *testing.T) {
+	var (
+		key, _    = crypto.GenerateKey()
+		addr      = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.Address{0xaa}
+		reverter  = common.Address{0xf1}
+		gspec     = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr:     {Balance: big.NewInt(params.Ether)},
+				reverter: {Code: forwarderCode(recipient, true)},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = ethash.NewFaker()
+	)
+	// Transfer value in block 1 and revert a transfer in block 2
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, func(i int, gen *BlockGen) {
+		to := recipient
+		if i == 1 {
+			to = reverter
+		}
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, big.NewInt(5), 100000, gen.BaseFee(), nil), signer, key)
+		gen.AddTx(tx)
+	})
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.Supply = true
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	genesis := rawdb.ReadSupply(chain.db, chain.Genesis().Hash(), 0)
+	if genesis == nil || genesis.Total.Cmp(big.NewInt(params.Ether)) != 0 {
+		t.Fatalf("genesis supply mismatch: %v", genesis)
+	}
+	prev := genesis.Total
+	for i, block := range blocks {
+		supply := rawdb.ReadSupply(chain.db, block.Hash(), block.NumberU64())
+		if supply == nil || supply.Total == nil {
+			t.Fatalf("block %d: supply not tracked", i+1)
+		}
+		// The base fee of the transaction is the only burn
+		burn := new(big.Int).Mul(block.BaseFee(), new(big.Int).SetUint64(block.GasUsed()))
+		if supply.Fees.Cmp(burn) != 0 || supply.Burn().Cmp(burn) != 0 {
+			t.Errorf("block %d: burn mismatch: have %v, want %v", i+1, supply.Burn(), burn)
+		}
+		want := new(big.Int).Add(prev, supply.Issuance())
+		want.Sub(want, burn)
+		if supply.Total.Cmp(want) != 0 {
+			t.Errorf("block %d: total supply mismatch: have %v, want %v", i+1, supply.Total, want)
+		}
+		// The total supply must match the balances of all the accounts
+		statedb, _ := chain.StateAt(block.Root())
+		balances := new(big.Int)
+		for _, account := range []common.Address{addr, recipient, reverter, block.Coinbase()} {
+			balances.Add(balances, statedb.GetBalance(account).ToBig())
+		}
+		if supply.Total.Cmp(balances) != 0 {
+			t.Errorf("block %d: total supply mismatch with state: have %v, want %v", i+1, supply.Total, balances)
+		}
+		prev = supply.Total
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// TransferEventTopic is the topic of the Transfer(address,address,uint256)
+// event emitted by both ERC-20 and ERC-721 token contracts.
+var TransferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// transferScanLimit is the maximum number of index entries visited by a single
+// Transfers call.
+var transferScanLimit = 10000
+
+// TransferIndexer is the module maintaining the account -> token transfer index
+// of the canonical chain, within the configured retention window. The ERC-20
+// and ERC-721 Transfer events are decoded from the stored receipt logs as the
+// blocks become canonical and indexed under both the sender and the recipient.
+//
+// Since a crash might leave dangling entries behind, readers must use Transfers
+// which cross checks every entry against the canonical chain.
+type TransferIndexer struct {
+	*chainIndexer
+
+	chain *BlockChain
+	db    ethdb.Database
+}
+
+// NewTransferIndexer initializes the token transfer indexer and starts its
+// background loop.
+func NewTransferIndexer(chain *BlockChain, limit uint64) *TransferIndexer {
+	indexer := &TransferIndexer{
+		chain: chain,
+		db:    chain.db,
+	}
+	indexer.chainIndexer = newChainIndexer(chain, rawdb.TransferIndexName, "token transfers", limit, indexer)
+	return indexer
+}
+
+// Transfers retrieves at most limit canonical token transfers of the given
+// account, in chain order, starting at the given block number and log position
+// (inclusive) and ending with the block to (inclusive). Only the transfers
+// accepted by the match function are returned, if it's non-nil. The position
+// to continue the iteration from is returned too, or nil if there are no more
+// transfers.
+//
+// At most transferScanLimit index entries are visited per call, so a selective
+// match function can't make a call scan the whole index: once reached, the
+// position of the next entry is returned even if fewer transfers matched.
+func (indexer *TransferIndexer) Transfers(address common.Address, number uint64, index uint32, to uint64, limit int, match func(*rawdb.TransferEntry) bool) ([]rawdb.TransferEntry, *rawdb.TransferEntry) {
+	var (
+		bodies  = make(map[uint64]*types.Body)
+		results []rawdb.TransferEntry
+		scanned int
+	)
+	for {
+		entries := rawdb.ReadTokenTransferEntries(indexer.db, address, number, index, limit)
+		for _, entry := range entries {
+			if entry.Number > to {
+				return results, nil
+			}
+			if scanned == transferScanLimit {
+				return results, &entry
+			}
+			scanned++
+
+			if match != nil && !match(&entry) {
+				continue
+			}
+			if !isCanonicalTx(indexer.db, entry.Number, entry.TxIndex, entry.TxHash, bodies) {
+				continue
+			}
+			if len(results) == limit {
+				return results, &entry
+			}
+			results = append(results, entry)
+		}
+		if len(entries) < limit {
+			return results, nil
+		}
+		// Continue right after the last retrieved entry
+		last := entries[len(entries)-1]
+		number, index = last.Number, last.LogIndex+1
+		if index == 0 {
+			number++
+		}
+	}
+}
+
+// update adds or removes the token transfers of the given block to the index.
+func (indexer *TransferIndexer) update(batch ethdb.KeyValueWriter, block *types.Block, add bool) {
+	var (
+		number = block.NumberU64()
+		txs    = block.Transactions()
+		index  uint32
+	)
+	for i, logs := range rawdb.ReadLogs(indexer.db, block.Hash(), number) {
+		for _, log := range logs {
+			if entry := decodeTransfer(log); entry != nil && i < len(txs) {
+				entry.Number, entry.LogIndex = number, index
+				entry.TxIndex, entry.TxHash = uint32(i), txs[i].Hash()
+
+				if add {
+					rawdb.WriteTokenTransferEntry(batch, entry.From, entry)
+					if entry.To != entry.From {
+						rawdb.WriteTokenTransferEntry(batch, entry.To, entry)
+					}
+				} else {
+					rawdb.DeleteTokenTransferEntry(batch, entry.From, number, index)
+					rawdb.DeleteTokenTransferEntry(batch, entry.To, number, index)
+				}
+			}
+			index++
+		}
+	}
+}
+
+// decodeTransfer decodes an ERC-20 or ERC-721 Transfer event, returning nil
+// if the log is not one. The two standards share the same event signature,
+// but ERC-721 indexes the token id while ERC-20 stores the amount as data.
+func decodeTransfer(log *types.Log) *rawdb.TransferEntry {
+	if len(log.Topics) < 3 || log.Topics[0] != TransferEventTopic {
+		return nil
+	}
+	entry := &rawdb.TransferEntry{
+		Token: log.Address,
+		From:  common.BytesToAddress(log.Topics[1].Bytes()),
+		To:    common.BytesToAddress(log.Topics[2].Bytes()),
+	}
+	switch {
+	case len(log.Topics) == 3 && len(log.Data) == 32:
+		entry.Value = new(big.Int).SetBytes(log.Data).Bytes()
+	case len(log.Topics) == 4 && len(log.Data) == 0:
+		entry.Value = new(big.Int).SetBytes(log.Topics[3].Bytes()).Bytes()
+		entry.NFT = true
+	default:
+		return nil
+	}
+	return entry
+}
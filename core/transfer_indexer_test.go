@@ -0,0 +1,159 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// transferTokenCode returns the code of a minimal token contract emitting a
+// Transfer event from the caller to the first calldata word. The second word
+// is the amount (ERC-20) or the token id (ERC-721).
+func transferTokenCode(nft bool) []byte {
+	var code []byte
+	if nft {
+		code = []byte{
+			byte(vm.PUSH1), 0x20, byte(vm.CALLDATALOAD), // topic3: token id
+			byte(vm.PUSH1), 0x00, byte(vm.CALLDATALOAD), // topic2: recipient
+			byte(vm.CALLER), // topic1: sender
+			byte(vm.PUSH32),
+		}
+		code = append(code, TransferEventTopic.Bytes()...)
+		return append(code, byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.LOG4), byte(vm.STOP))
+	}
+	code = []byte{
+		byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x00, byte(vm.CALLDATACOPY), // data: amount
+		byte(vm.PUSH1), 0x00, byte(vm.CALLDATALOAD), // topic2: recipient
+		byte(vm.CALLER), // topic1: sender
+		byte(vm.PUSH32),
+	}
+	code = append(code, TransferEventTopic.Bytes()...)
+	return append(code, byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x00, byte(vm.LOG3), byte(vm.STOP))
+}
+
+// tokenTransfers returns all indexed transfers of the given account up to the
+// given block, iterating page by page.
+func tokenTransfers(indexer *TransferIndexer, address common.Address, to uint64, page int, match func(*rawdb.TransferEntry) bool) []rawdb.TransferEntry {
+	var (
+		transfers []rawdb.TransferEntry
+		number    uint64
+		index     uint32
+	)
+	for {
+		entries, next := indexer.Transfers(address, number, index, to, page, match)
+		transfers = append(transfers, entries...)
+		if next == nil {
+			return transfers
+		}
+		number, index = next.Number, next.LogIndex
+	}
+}
+
+func TestTransferIndexer(t *testing.T) {
+	var (
+		key1, _ = crypto.GenerateKey()
+		key2, _ = crypto.GenerateKey()
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+		erc20   = common.Address{0x20}
+		erc721  = common.Address{0x72}
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr1:  {Balance: big.NewInt(params.Ether)},
+				addr2:  {Balance: big.NewInt(params.Ether)},
+				erc20:  {Code: transferTokenCode(false)},
+				erc721: {Code: transferTokenCode(true)},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = ethash.NewFaker()
+	)
+	// Account 1 sends fungible tokens to account 2 in every block, account 2
+	// sends an NFT back in every other one.
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 20, func(i int, gen *BlockGen) {
+		data := append(common.LeftPadBytes(addr2.Bytes(), 32), common.LeftPadBytes(big.NewInt(int64(i+1)).Bytes(), 32)...)
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr1), erc20, nil, 100000, gen.BaseFee(), data), signer, key1)
+		gen.AddTx(tx)
+		if i%2 == 0 {
+			data := append(common.LeftPadBytes(addr1.Bytes(), 32), common.LeftPadBytes(big.NewInt(int64(i)).Bytes(), 32)...)
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr2), erc721, nil, 100000, gen.BaseFee(), data), signer, key2)
+			gen.AddTx(tx)
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	indexer := NewTransferIndexer(chain, 0)
+	defer indexer.Close()
+	waitChainIndex(t, chain, rawdb.TransferIndexName)
+
+	transfers := tokenTransfers(indexer, addr1, math.MaxUint64, 7, nil)
+	if len(transfers) != 30 {
+		t.Fatalf("wrong number of transfers of account 1: have %d, want 30", len(transfers))
+	}
+	first, second := transfers[0], transfers[1]
+	if first.Number != 1 || first.LogIndex != 0 || first.Token != erc20 || first.From != addr1 || first.To != addr2 || first.NFT || new(big.Int).SetBytes(first.Value).Uint64() != 1 {
+		t.Fatalf("wrong first fungible transfer: %+v", first)
+	}
+	if second.Number != 1 || second.LogIndex != 1 || second.TxIndex != 1 || second.Token != erc721 || second.From != addr2 || second.To != addr1 || !second.NFT {
+		t.Fatalf("wrong first NFT transfer: %+v", second)
+	}
+	if second.TxHash != blocks[0].Transactions()[1].Hash() {
+		t.Fatalf("wrong transaction hash: have %x, want %x", second.TxHash, blocks[0].Transactions()[1].Hash())
+	}
+	if transfers := tokenTransfers(indexer, addr2, math.MaxUint64, 4, nil); len(transfers) != 30 {
+		t.Fatalf("wrong number of transfers of account 2: have %d, want 30", len(transfers))
+	}
+	// Filter by token and limit the block range.
+	nft := func(entry *rawdb.TransferEntry) bool { return entry.NFT }
+	if transfers := tokenTransfers(indexer, addr1, math.MaxUint64, 3, nft); len(transfers) != 10 {
+		t.Fatalf("wrong number of NFT transfers: have %d, want 10", len(transfers))
+	}
+	// Selective matches are paginated by the scan limit too.
+	defer func(limit int) { transferScanLimit = limit }(transferScanLimit)
+	transferScanLimit = 4
+	if entries, next := indexer.Transfers(addr1, 0, 0, math.MaxUint64, 100, nft); len(entries) != 1 || next == nil {
+		t.Fatalf("scan limit not applied: %d transfers, next %v", len(entries), next)
+	}
+	if transfers := tokenTransfers(indexer, addr1, math.MaxUint64, 100, nft); len(transfers) != 10 {
+		t.Fatalf("wrong number of NFT transfers with scan limit: have %d, want 10", len(transfers))
+	}
+	if transfers := tokenTransfers(indexer, addr1, 5, 2, nil); len(transfers) != 8 || transfers[7].Number != 5 {
+		t.Fatalf("wrong transfers up to block 5: %v", transfers)
+	}
+	if transfers := tokenTransfers(indexer, common.Address{0xaa}, math.MaxUint64, 10, nil); len(transfers) != 0 {
+		t.Fatalf("unexpected transfers of unrelated account: %v", transfers)
+	}
+}
@@ -0,0 +1,200 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Modes of the address policy.
+const (
+	AddressPolicyBlock = "block" // Transactions from or to a listed address are refused
+	AddressPolicyAllow = "allow" // Only transactions from and to listed addresses are accepted
+)
+
+// Stages at which the address policy is enforced, recorded in the audit log.
+const (
+	PolicyStageAdmission = "admission" // Transaction admission into the pool
+	PolicyStageInclusion = "inclusion" // Transaction inclusion into a built block
+	policyStageUpdate    = "update"    // Change of the listed addresses
+)
+
+// auditedRefusals is the number of refused transactions remembered per stage,
+// to record each in the audit log once, not at every re-gossip or rebuild.
+const auditedRefusals = 4096
+
+var (
+	// ErrAddressPolicy is returned if a transaction is refused by the address
+	// policy.
+	ErrAddressPolicy = errors.New("address refused by policy")
+
+	addressPolicyAdmissionMeter = metrics.NewRegisteredMeter("txpool/addresspolicy/admission", nil)
+	addressPolicyInclusionMeter = metrics.NewRegisteredMeter("txpool/addresspolicy/inclusion", nil)
+)
+
+// AddressPolicy refuses the transactions sent from or to a configured set of
+// addresses (block mode), or any other address (allow mode), both from being
+// admitted into the pool and from being included in blocks built by the node.
+// Every time the policy triggers or the set changes, a signed entry is
+// appended to the audit log.
+//
+// Contract creations have no recipient, only their sender is checked.
+type AddressPolicy struct {
+	mode   string
+	signer types.Signer
+	audit  *AuditLog
+
+	lock    sync.RWMutex
+	addrs   map[common.Address]struct{}
+	refused *lru.Cache[refusal, struct{}] // Refusals already recorded in the audit log
+}
+
+// refusal identifies the refusal of a transaction at a stage.
+type refusal struct {
+	stage string
+	hash  common.Hash
+}
+
+// NewAddressPolicy creates an address policy in the given mode for the given
+// addresses, recording its enforcement in the audit log.
+func NewAddressPolicy(mode string, addrs []common.Address, signer types.Signer, audit *AuditLog) (*AddressPolicy, error) {
+	if mode != AddressPolicyBlock && mode != AddressPolicyAllow {
+		return nil, fmt.Errorf("invalid address policy mode %q", mode)
+	}
+	p := &AddressPolicy{
+		mode:    mode,
+		signer:  signer,
+		audit:   audit,
+		addrs:   make(map[common.Address]struct{}),
+		refused: lru.NewCache[refusal, struct{}](2 * auditedRefusals),
+	}
+	for _, addr := range addrs {
+		p.addrs[addr] = struct{}{}
+	}
+	return p, nil
+}
+
+// Mode returns the mode of the policy.
+func (p *AddressPolicy) Mode() string {
+	return p.mode
+}
+
+// Addresses returns the listed addresses, sorted.
+func (p *AddressPolicy) Addresses() []common.Address {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	addrs := make([]common.Address, 0, len(p.addrs))
+	for addr := range p.addrs {
+		addrs = append(addrs, addr)
+	}
+	slices.SortFunc(addrs, common.Address.Cmp)
+	return addrs
+}
+
+// Add lists the given addresses, returning the number of newly listed ones.
+func (p *AddressPolicy) Add(addrs []common.Address) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var added []common.Address
+	for _, addr := range addrs {
+		if _, ok := p.addrs[addr]; !ok {
+			p.addrs[addr] = struct{}{}
+			added = append(added, addr)
+		}
+	}
+	if len(added) == 0 {
+		return 0, nil
+	}
+	p.refused.Purge()
+	return len(added), p.audit.Append(AuditEntry{Event: policyStageUpdate, Detail: fmt.Sprintf("added %v", added)})
+}
+
+// Remove unlists the given addresses, returning the number of removed ones.
+func (p *AddressPolicy) Remove(addrs []common.Address) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var removed []common.Address
+	for _, addr := range addrs {
+		if _, ok := p.addrs[addr]; ok {
+			delete(p.addrs, addr)
+			removed = append(removed, addr)
+		}
+	}
+	if len(removed) == 0 {
+		return 0, nil
+	}
+	p.refused.Purge()
+	return len(removed), p.audit.Append(AuditEntry{Event: policyStageUpdate, Detail: fmt.Sprintf("removed %v", removed)})
+}
+
+// check returns the address refused by the policy among the sender and the
+// recipient of a transaction, if any.
+func (p *AddressPolicy) check(from common.Address, to *common.Address) (common.Address, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	refused := func(addr common.Address) bool {
+		_, listed := p.addrs[addr]
+		return listed == (p.mode == AddressPolicyBlock)
+	}
+	if refused(from) {
+		return from, true
+	}
+	if to != nil && refused(*to) {
+		return *to, true
+	}
+	return common.Address{}, false
+}
+
+// Enforce checks the transaction of the given sender against the policy at
+// the given stage, recording it in the audit log if refused.
+func (p *AddressPolicy) Enforce(stage string, tx *types.Transaction, from common.Address) error {
+	addr, refused := p.check(from, tx.To())
+	if !refused {
+		return nil
+	}
+	hash := tx.Hash()
+	switch stage {
+	case PolicyStageAdmission:
+		addressPolicyAdmissionMeter.Mark(1)
+	case PolicyStageInclusion:
+		addressPolicyInclusionMeter.Mark(1)
+	}
+	if p.refused.Contains(refusal{stage, hash}) {
+		return ErrAddressPolicy
+	}
+	p.refused.Add(refusal{stage, hash}, struct{}{})
+	err := p.audit.Append(AuditEntry{
+		Event:  stage,
+		Tx:     &hash,
+		From:   &from,
+		To:     tx.To(),
+		Detail: fmt.Sprintf("%s mode, refused %s", p.mode, addr),
+	})
+	if err != nil {
+		log.Error("Failed to write address policy audit log", "err", err)
+	}
+	log.Debug("Transaction refused by address policy", "stage", stage, "hash", hash, "address", addr)
+	return ErrAddressPolicy
+}
+
+// FilterTx implements IngressFilter.FilterTx
+// it refuses the transactions from or to addresses refused by the policy
+func (p *AddressPolicy) FilterTx(ctx context.Context, tx *types.Transaction) bool {
+	from, err := types.Sender(p.signer, tx)
+	if err != nil {
+		return false
+	}
+	return p.Enforce(PolicyStageAdmission, tx, from) == nil
+}
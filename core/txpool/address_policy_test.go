@@ -0,0 +1,102 @@
+package txpool
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressPolicy(t *testing.T) {
+	var (
+		nodeKey, _ = crypto.GenerateKey()
+		key, _     = crypto.GenerateKey()
+		sender     = crypto.PubkeyToAddress(key.PublicKey)
+		listed     = common.HexToAddress("0xbad")
+		other      = common.HexToAddress("0x0c")
+		signer     = types.LatestSigner(params.TestChainConfig)
+		path       = filepath.Join(t.TempDir(), "audit.log")
+	)
+	makeTx := func(nonce uint64, to *common.Address) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        to,
+			Gas:       params.TxGas,
+			GasFeeCap: big.NewInt(params.GWei),
+		})
+	}
+	audit, err := OpenAuditLog(path, nodeKey)
+	require.NoError(t, err)
+
+	t.Run("Block mode refuses listed recipients", func(t *testing.T) {
+		policy, err := NewAddressPolicy(AddressPolicyBlock, []common.Address{listed}, signer, audit)
+		require.NoError(t, err)
+
+		require.True(t, policy.FilterTx(context.Background(), makeTx(0, &other)))
+		require.True(t, policy.FilterTx(context.Background(), makeTx(0, nil)))
+		require.False(t, policy.FilterTx(context.Background(), makeTx(0, &listed)))
+
+		// Refusals at inclusion are recorded once per transaction.
+		tx := makeTx(1, &listed)
+		require.ErrorIs(t, policy.Enforce(PolicyStageInclusion, tx, sender), ErrAddressPolicy)
+		require.ErrorIs(t, policy.Enforce(PolicyStageInclusion, tx, sender), ErrAddressPolicy)
+	})
+	t.Run("Allow mode refuses unlisted senders and recipients", func(t *testing.T) {
+		policy, err := NewAddressPolicy(AddressPolicyAllow, []common.Address{listed}, signer, audit)
+		require.NoError(t, err)
+		require.False(t, policy.FilterTx(context.Background(), makeTx(0, &listed)))
+
+		n, err := policy.Add([]common.Address{sender, listed})
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Equal(t, []common.Address{listed, sender}, policy.Addresses())
+
+		require.True(t, policy.FilterTx(context.Background(), makeTx(0, &listed)))
+		require.True(t, policy.FilterTx(context.Background(), makeTx(0, nil)))
+		require.False(t, policy.FilterTx(context.Background(), makeTx(0, &other)))
+
+		n, err = policy.Remove([]common.Address{sender, other})
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.False(t, policy.FilterTx(context.Background(), makeTx(0, &listed)))
+	})
+	t.Run("Invalid mode", func(t *testing.T) {
+		_, err := NewAddressPolicy("deny", nil, signer, audit)
+		require.Error(t, err)
+	})
+	require.NoError(t, audit.Close())
+
+	// Reopening the log continues the hash chain.
+	audit, err = OpenAuditLog(path, nodeKey)
+	require.NoError(t, err)
+	require.NoError(t, audit.Append(AuditEntry{Event: "test", Detail: "reopened"}))
+	require.NoError(t, audit.Close())
+
+	t.Run("Audit log verifies", func(t *testing.T) {
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		defer file.Close()
+
+		// 2 refusals in block mode, 3 refusals and 2 updates in allow mode and
+		// the reopening
+		n, err := VerifyAuditLog(file, &nodeKey.PublicKey)
+		require.NoError(t, err)
+		require.Equal(t, 8, n)
+	})
+	t.Run("Audit log is signed by the node key", func(t *testing.T) {
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		defer file.Close()
+
+		_, err = VerifyAuditLog(file, &key.PublicKey)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,140 @@
+package txpool
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// AuditEntry is a record of the audit log. Each entry commits to the previous
+// one by hash, and is signed by the key of the node writing the log, so that
+// entries cannot be altered, removed or reordered unnoticed.
+type AuditEntry struct {
+	Time   uint64          `json:"time"`           // Unix time of the event
+	Event  string          `json:"event"`          // Kind of the event, e.g. "admission"
+	Tx     *common.Hash    `json:"tx,omitempty"`   // Transaction concerned, if any
+	From   *common.Address `json:"from,omitempty"` // Sender of the transaction, if any
+	To     *common.Address `json:"to,omitempty"`   // Recipient of the transaction, if any
+	Detail string          `json:"detail"`         // Human readable description of the event
+	Prev   common.Hash     `json:"prev"`           // Hash of the previous entry, zero for the first
+	Sig    hexutil.Bytes   `json:"sig"`            // Signature of the entry hash
+}
+
+// Hash returns the hash of the entry, excluding the signature.
+func (e *AuditEntry) Hash() common.Hash {
+	cpy := *e
+	cpy.Sig = nil
+	blob, _ := json.Marshal(&cpy) // cannot fail
+	return crypto.Keccak256Hash(blob)
+}
+
+// AuditLog is an append-only log of signed, hash-chained entries, stored as
+// one JSON object per line.
+type AuditLog struct {
+	key  *ecdsa.PrivateKey
+	lock sync.Mutex
+	file *os.File
+	last common.Hash // Hash of the last entry written
+}
+
+// OpenAuditLog opens the audit log at the given path, creating it if needed,
+// and continues the hash chain of the entries already in it.
+func OpenAuditLog(path string, key *ecdsa.PrivateKey) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	var last common.Hash
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("corrupt audit log %s: %v", path, err)
+		}
+		last = entry.Hash()
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &AuditLog{key: key, file: file, last: last}, nil
+}
+
+// Append signs the entry, chains it to the previous one and writes it out.
+// The time and the chaining fields of the entry are filled in.
+func (l *AuditLog) Append(entry AuditEntry) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	entry.Time = uint64(time.Now().Unix())
+	entry.Prev = l.last
+	entry.Sig = nil
+
+	hash := entry.Hash()
+	sig, err := crypto.Sign(hash[:], l.key)
+	if err != nil {
+		return err
+	}
+	entry.Sig = sig
+
+	blob, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	if _, err := l.file.Write(append(blob, '\n')); err != nil {
+		return err
+	}
+	l.last = hash
+	return nil
+}
+
+// Close closes the underlying file of the audit log.
+func (l *AuditLog) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.file.Close()
+}
+
+// VerifyAuditLog checks that the audit log entries read from r are correctly
+// chained and signed by the given key, returning the number of entries.
+func VerifyAuditLog(r io.Reader, pub *ecdsa.PublicKey) (int, error) {
+	var (
+		scanner = bufio.NewScanner(r)
+		signer  = crypto.PubkeyToAddress(*pub)
+		prev    common.Hash
+		count   int
+	)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return count, fmt.Errorf("entry %d: %v", count, err)
+		}
+		if entry.Prev != prev {
+			return count, fmt.Errorf("entry %d: broken chain, have prev %x, want %x", count, entry.Prev, prev)
+		}
+		hash := entry.Hash()
+		key, err := crypto.SigToPub(hash[:], entry.Sig)
+		if err != nil {
+			return count, fmt.Errorf("entry %d: %v", count, err)
+		}
+		if crypto.PubkeyToAddress(*key) != signer {
+			return count, fmt.Errorf("entry %d: invalid signature", count)
+		}
+		prev = hash
+		count++
+	}
+	return count, scanner.Err()
+}
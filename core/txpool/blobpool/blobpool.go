@@ -18,6 +18,7 @@
 package blobpool
 
 import (
+	"cmp"
 	"container/heap"
 	"errors"
 	"fmt"
@@ -313,6 +314,15 @@ type BlobPool struct {
 	state  *state.StateDB // Current state at the head of the chain
 	gasTip *uint256.Int   // Currently accepted minimum gas tip
 
+	basefee          *uint256.Int // Base fee of the block after the head
+	blobfee          *uint256.Int // Blob fee of the head block
+	projectedBlobfee *uint256.Int // Worst case blob fee over the projection window
+
+	evicted     uint64 // Transactions evicted due to the storage cap
+	replaced    uint64 // Transactions replaced by a higher paying one
+	underpriced uint64 // Transactions rejected below the projected blob fee
+	overcapped  uint64 // Transactions rejected above the per-account blob cap
+
 	lookup *lookup                          // Lookup table mapping blobs to txs and txs to billy entries
 	index  map[common.Address][]*blobTxMeta // Blob transactions grouped by accounts, sorted by nonce
 	spent  map[common.Address]*uint256.Int  // Expenditure tracking for individual accounts
@@ -418,14 +428,12 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserver txpool.Reser
 	for addr := range p.index {
 		p.recheck(addr, nil)
 	}
-	var (
-		basefee = uint256.MustFromBig(eip1559.CalcBaseFee(p.chain.Config(), p.head, p.head.Time+1))
-		blobfee = uint256.NewInt(params.BlobTxMinBlobGasprice)
-	)
-	if p.head.ExcessBlobGas != nil {
-		blobfee = uint256.MustFromBig(eip4844.CalcBlobFee(p.chain.Config(), p.head))
-	}
-	p.evict = newPriceHeap(basefee, blobfee, p.index)
+	basefee, blobfee, projected := p.currentFees(p.head)
+	p.basefee, p.blobfee, p.projectedBlobfee = basefee, blobfee, projected
+
+	// The eviction heap ranks the blob fee caps against the projected blob fee,
+	// so transactions not surviving the projection window are evicted first.
+	p.evict = newPriceHeap(basefee, projected, p.index)
 
 	// Pool initialized, attach the blob limbo to it to track blobs included
 	// recently but not yet finalized
@@ -437,6 +445,7 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserver txpool.Reser
 	// Set the configured gas tip, triggering a filtering of anything just loaded
 	basefeeGauge.Update(int64(basefee.Uint64()))
 	blobfeeGauge.Update(int64(blobfee.Uint64()))
+	projectedBlobfeeGauge.Update(int64(projected.Uint64()))
 
 	p.SetGasTip(new(big.Int).SetUint64(gasTip))
 
@@ -838,17 +847,13 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 		p.limbo.finalize(p.chain.CurrentFinalBlock())
 	}
 	// Reset the price heap for the new set of basefee/blobfee pairs
-	var (
-		basefee = uint256.MustFromBig(eip1559.CalcBaseFee(p.chain.Config(), newHead, newHead.Time+1))
-		blobfee = uint256.MustFromBig(big.NewInt(params.BlobTxMinBlobGasprice))
-	)
-	if newHead.ExcessBlobGas != nil {
-		blobfee = uint256.MustFromBig(eip4844.CalcBlobFee(p.chain.Config(), newHead))
-	}
-	p.evict.reinit(basefee, blobfee, false)
+	basefee, blobfee, projected := p.currentFees(newHead)
+	p.basefee, p.blobfee, p.projectedBlobfee = basefee, blobfee, projected
+	p.evict.reinit(basefee, projected, false)
 
 	basefeeGauge.Update(int64(basefee.Uint64()))
 	blobfeeGauge.Update(int64(blobfee.Uint64()))
+	projectedBlobfeeGauge.Update(int64(projected.Uint64()))
 	p.updateStorageMetrics()
 }
 
@@ -1140,6 +1145,25 @@ func (p *BlobPool) checkDelegationLimit(tx *types.Transaction) error {
 	return txpool.ErrInflightTxLimitReached
 }
 
+// Validate checks whether a transaction would be accepted by the pool, running
+// the checks of Add without admitting it.
+func (p *BlobPool) Validate(tx *types.Transaction) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.lookup.exists(tx.Hash()) {
+		return txpool.ErrAlreadyKnown
+	}
+	if err := p.validateTx(tx); err != nil {
+		return err
+	}
+	from, _ := types.Sender(p.signer, tx) // already validated above
+	if _, ok := p.index[from]; !ok && p.reserver.Has(from) {
+		return txpool.ErrAlreadyReserved
+	}
+	return nil
+}
+
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (p *BlobPool) validateTx(tx *types.Transaction) error {
@@ -1183,14 +1207,33 @@ func (p *BlobPool) validateTx(tx *types.Transaction) error {
 	if err := p.checkDelegationLimit(tx); err != nil {
 		return err
 	}
-	// If the transaction replaces an existing one, ensure that price bumps are
-	// adhered to.
 	var (
 		from, _ = types.Sender(p.signer, tx) // already validated above
 		next    = p.state.GetNonce(from)
+		prev    *blobTxMeta
 	)
 	if uint64(len(p.index[from])) > tx.Nonce()-next {
-		prev := p.index[from][int(tx.Nonce()-next)]
+		prev = p.index[from][int(tx.Nonce()-next)]
+	}
+	// Ensure the account stays within its blob allowance, counting a replaced
+	// transaction's blobs as freed.
+	if limit := p.config.MaxAccountBlobs; limit > 0 {
+		blobs := countBlobs(p.index[from]) + uint64(len(tx.BlobHashes()))
+		if prev != nil {
+			blobs -= uint64(len(prev.vhashes))
+		}
+		if blobs > limit {
+			return fmt.Errorf("%w: %d blobs, limit %d", errAccountBlobLimit, blobs, limit)
+		}
+	}
+	// If the pool is full, anything below the projected blob fee would be the
+	// first to be evicted again, so don't even bother storing it.
+	if p.config.ProjectionBlocks > 0 && p.stored+tx.Size() > p.config.Datacap && tx.BlobGasFeeCapIntCmp(p.projectedBlobfee.ToBig()) < 0 {
+		return fmt.Errorf("%w: %v < %v in %d blocks", errBelowProjectedBlobFee, tx.BlobGasFeeCap(), p.projectedBlobfee, p.config.ProjectionBlocks)
+	}
+	// If the transaction replaces an existing one, ensure that price bumps are
+	// adhered to.
+	if prev != nil {
 		// Ensure the transaction is different than the one tracked locally
 		if prev.hash == tx.Hash() {
 			return txpool.ErrAlreadyKnown
@@ -1205,18 +1248,20 @@ func (p *BlobPool) validateTx(tx *types.Transaction) error {
 			return fmt.Errorf("%w: new tx blob gas fee cap %v <= %v queued", txpool.ErrReplaceUnderpriced, tx.BlobGasFeeCap(), prev.blobFeeCap)
 		}
 		var (
-			multiplier = uint256.NewInt(100 + p.config.PriceBump)
-			onehundred = uint256.NewInt(100)
+			tipBump       = cmp.Or(p.config.TipBump, p.config.PriceBump)
+			multiplier    = uint256.NewInt(100 + p.config.PriceBump)
+			tipMultiplier = uint256.NewInt(100 + tipBump)
+			onehundred    = uint256.NewInt(100)
 
 			minGasFeeCap     = new(uint256.Int).Div(new(uint256.Int).Mul(multiplier, prev.execFeeCap), onehundred)
-			minGasTipCap     = new(uint256.Int).Div(new(uint256.Int).Mul(multiplier, prev.execTipCap), onehundred)
+			minGasTipCap     = new(uint256.Int).Div(new(uint256.Int).Mul(tipMultiplier, prev.execTipCap), onehundred)
 			minBlobGasFeeCap = new(uint256.Int).Div(new(uint256.Int).Mul(multiplier, prev.blobFeeCap), onehundred)
 		)
 		switch {
 		case tx.GasFeeCapIntCmp(minGasFeeCap.ToBig()) < 0:
 			return fmt.Errorf("%w: new tx gas fee cap %v < %v queued + %d%% replacement penalty", txpool.ErrReplaceUnderpriced, tx.GasFeeCap(), prev.execFeeCap, p.config.PriceBump)
 		case tx.GasTipCapIntCmp(minGasTipCap.ToBig()) < 0:
-			return fmt.Errorf("%w: new tx gas tip cap %v < %v queued + %d%% replacement penalty", txpool.ErrReplaceUnderpriced, tx.GasTipCap(), prev.execTipCap, p.config.PriceBump)
+			return fmt.Errorf("%w: new tx gas tip cap %v < %v queued + %d%% replacement penalty", txpool.ErrReplaceUnderpriced, tx.GasTipCap(), prev.execTipCap, tipBump)
 		case tx.BlobGasFeeCapIntCmp(minBlobGasFeeCap.ToBig()) < 0:
 			return fmt.Errorf("%w: new tx blob gas fee cap %v < %v queued + %d%% replacement penalty", txpool.ErrReplaceUnderpriced, tx.BlobGasFeeCap(), prev.blobFeeCap, p.config.PriceBump)
 		}
@@ -1393,6 +1438,12 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 	if err := p.validateTx(tx); err != nil {
 		log.Trace("Transaction validation failed", "hash", tx.Hash(), "err", err)
 		switch {
+		case errors.Is(err, errBelowProjectedBlobFee):
+			addUnderpricedMeter.Mark(1)
+			p.underpriced++
+		case errors.Is(err, errAccountBlobLimit):
+			addOvercappedMeter.Mark(1)
+			p.overcapped++
 		case errors.Is(err, txpool.ErrUnderpriced):
 			addUnderpricedMeter.Mark(1)
 		case errors.Is(err, txpool.ErrTxGasPriceTooLow):
@@ -1458,6 +1509,7 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 	if len(p.index[from]) > offset {
 		// Transaction replaces a previously queued one
 		dropReplacedMeter.Mark(1)
+		p.replaced++
 
 		prev := p.index[from][offset]
 		if err := p.store.Delete(prev.id); err != nil {
@@ -1589,6 +1641,7 @@ func (p *BlobPool) drop() {
 	// Remove the transaction from the data store
 	log.Debug("Evicting overflown blob transaction", "from", from, "evicted", drop.nonce, "id", drop.id)
 	dropOverflownMeter.Mark(1)
+	p.evicted++
 
 	if err := p.store.Delete(drop.id); err != nil {
 		log.Error("Failed to drop evicted transaction", "id", drop.id, "err", err)
@@ -1772,6 +1825,18 @@ func (p *BlobPool) Stats() (int, int) {
 	return pending, 0 // No non-executable txs in the blob pool
 }
 
+// ReplacementRules returns the price bumps required to replace a blob
+// transaction.
+func (p *BlobPool) ReplacementRules() map[string]txpool.ReplacementRule {
+	return map[string]txpool.ReplacementRule{
+		"blob": {
+			FeeCapBump:     p.config.PriceBump,
+			TipBump:        cmp.Or(p.config.TipBump, p.config.PriceBump),
+			BlobFeeCapBump: p.config.PriceBump,
+		},
+	}
+}
+
 // Content retrieves the data content of the transaction pool, returning all the
 // pending as well as queued transactions, grouped by account and sorted by nonce.
 //
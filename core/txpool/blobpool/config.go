@@ -25,6 +25,10 @@ type Config struct {
 	Datadir   string // Data directory containing the currently executable blobs
 	Datacap   uint64 // Soft-cap of database storage (hard cap is larger due to overhead)
 	PriceBump uint64 // Minimum price bump percentage to replace an already existing nonce
+	TipBump   uint64 // Minimum tip bump percentage to replace an already existing nonce, PriceBump if zero
+
+	ProjectionBlocks uint64 // Number of full blob blocks the blob fee is projected over for eviction (0 = current fee only)
+	MaxAccountBlobs  uint64 // Maximum number of blobs pooled per account (0 = unlimited)
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -0,0 +1,146 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+var (
+	// errAccountBlobLimit is returned if a transaction would take an account
+	// above its allowance of pooled blobs.
+	errAccountBlobLimit = fmt.Errorf("%w: account blob limit", txpool.ErrAccountLimitExceeded)
+
+	// errBelowProjectedBlobFee is returned if a transaction is added to a full
+	// pool with a blob fee cap below the projected blob fee.
+	errBelowProjectedBlobFee = fmt.Errorf("%w: blob fee cap below projected blob fee", txpool.ErrUnderpriced)
+)
+
+// PoolStatus is a snapshot of the capacity and fee market of the blob pool.
+type PoolStatus struct {
+	Datacap  uint64 // Configured storage cap of the pool
+	Stored   uint64 // Useful data size of the pooled transactions
+	Txs      int    // Number of pooled transactions
+	Blobs    int    // Number of pooled blobs
+	Accounts int    // Number of accounts with pooled transactions
+
+	Basefee          *uint256.Int // Base fee of the next block
+	Blobfee          *uint256.Int // Blob fee of the head block
+	ProjectedBlobfee *uint256.Int // Blob fee after ProjectionBlocks full blob blocks
+	ProjectionBlocks uint64       // Number of blocks the blob fee is projected over
+	GasTip           *uint256.Int // Minimum gas tip accepted into the pool
+
+	// Fee caps of the transaction next in line for eviction, nil if the pool
+	// is empty. Transactions paying less will not displace anything.
+	EvictExecTipCap *uint256.Int
+	EvictExecFeeCap *uint256.Int
+	EvictBlobFeeCap *uint256.Int
+
+	Evicted     uint64 // Transactions evicted due to the storage cap since startup
+	Replaced    uint64 // Transactions replaced since startup
+	Underpriced uint64 // Transactions rejected below the projected blob fee since startup
+	Overcapped  uint64 // Transactions rejected above the per-account blob cap since startup
+}
+
+// currentFees returns the base fee of the block after the given head, the blob
+// fee of the head and the blob fee projected over the configured number of
+// blocks after it.
+func (p *BlobPool) currentFees(head *types.Header) (basefee, blobfee, projected *uint256.Int) {
+	basefee = uint256.MustFromBig(eip1559.CalcBaseFee(p.chain.Config(), head, head.Time+1))
+	blobfee = uint256.NewInt(params.BlobTxMinBlobGasprice)
+	if head.ExcessBlobGas != nil {
+		blobfee = uint256.MustFromBig(eip4844.CalcBlobFee(p.chain.Config(), head))
+	}
+	projected = blobfee
+	if p.config.ProjectionBlocks > 0 && head.ExcessBlobGas != nil && !p.chain.Config().IsOptimism() {
+		projected = uint256.MustFromBig(projectBlobFee(p.chain.Config(), head, p.config.ProjectionBlocks))
+	}
+	return basefee, blobfee, projected
+}
+
+// projectBlobFee calculates the worst case blob fee after the given number of
+// blocks on top of head, assuming all of them are full of blobs. Block times are
+// assumed to be one second, which only matters around fork boundaries.
+func projectBlobFee(config *params.ChainConfig, head *types.Header, blocks uint64) *big.Int {
+	parent := head
+	if parent.BlobGasUsed == nil {
+		parent = types.CopyHeader(head)
+		parent.BlobGasUsed = new(uint64)
+	}
+	for i := uint64(0); i < blocks; i++ {
+		var (
+			time   = parent.Time + 1
+			excess = eip4844.CalcExcessBlobGas(config, parent, time)
+			used   = eip4844.MaxBlobGasPerBlock(config, time)
+		)
+		parent = &types.Header{Time: time, ExcessBlobGas: &excess, BlobGasUsed: &used}
+	}
+	return eip4844.CalcBlobFee(config, parent)
+}
+
+// PoolStatus returns a snapshot of the capacity and fee market of the pool.
+func (p *BlobPool) PoolStatus() *PoolStatus {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	status := &PoolStatus{
+		Datacap:          p.config.Datacap,
+		Stored:           p.stored,
+		Accounts:         len(p.index),
+		Basefee:          p.basefee,
+		Blobfee:          p.blobfee,
+		ProjectedBlobfee: p.projectedBlobfee,
+		ProjectionBlocks: p.config.ProjectionBlocks,
+		GasTip:           p.gasTip,
+		Evicted:          p.evicted,
+		Replaced:         p.replaced,
+		Underpriced:      p.underpriced,
+		Overcapped:       p.overcapped,
+	}
+	for _, txs := range p.index {
+		status.Txs += len(txs)
+		for _, tx := range txs {
+			status.Blobs += len(tx.vhashes)
+		}
+	}
+	if p.evict != nil && p.evict.Len() > 0 {
+		txs := p.index[p.evict.addrs[0]]
+		last := txs[len(txs)-1]
+
+		status.EvictExecTipCap = last.evictionExecTip
+		status.EvictExecFeeCap = last.execFeeCap
+		status.EvictBlobFeeCap = last.blobFeeCap
+	}
+	return status
+}
+
+// countBlobs returns the number of blobs in a set of pooled transactions.
+func countBlobs(txs []*blobTxMeta) uint64 {
+	var blobs uint64
+	for _, tx := range txs {
+		blobs += uint64(len(tx.vhashes))
+	}
+	return blobs
+}
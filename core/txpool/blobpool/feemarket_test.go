@@ -0,0 +1,104 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the blob fee projection rises with every full blob block after the
+// next one, whose fee is already determined by the head.
+func TestProjectBlobFee(t *testing.T) {
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+	}
+	head := chain.CurrentBlock()
+
+	if have, want := projectBlobFee(chain.config, head, 0), eip4844.CalcBlobFee(chain.config, head); have.Cmp(want) != 0 {
+		t.Fatalf("empty projection mismatch: have %v, want %v", have, want)
+	}
+	prev := projectBlobFee(chain.config, head, 1)
+	for blocks := uint64(2); blocks <= 16; blocks++ {
+		fee := projectBlobFee(chain.config, head, blocks)
+		if fee.Cmp(prev) <= 0 {
+			t.Fatalf("projection over %d blocks not rising: %v <= %v", blocks, fee, prev)
+		}
+		prev = fee
+	}
+}
+
+// Tests that the per-account blob cap is enforced, counting the blobs of a
+// replaced transaction as freed, and that the pool status reports it.
+func TestAccountBlobLimit(t *testing.T) {
+	var (
+		key, _     = crypto.GenerateKey()
+		addr       = crypto.PubkeyToAddress(key.PublicKey)
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	)
+	statedb.AddBalance(addr, uint256.NewInt(1_000_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+		statedb: statedb,
+	}
+	pool := New(Config{Datadir: t.TempDir(), MaxAccountBlobs: 3, ProjectionBlocks: 4}, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	if err := pool.add(makeMultiBlobTx(0, 1, 1100, 200, 2, key)); err != nil {
+		t.Fatalf("failed to add first transaction: %v", err)
+	}
+	err := pool.add(makeMultiBlobTx(1, 1, 1100, 200, 2, key))
+	if !errors.Is(err, txpool.ErrAccountLimitExceeded) {
+		t.Fatalf("wrong error above the blob cap: %v", err)
+	}
+	if err := pool.add(makeMultiBlobTx(0, 2, 2200, 400, 3, key)); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	verifyPoolInternals(t, pool)
+
+	status := pool.PoolStatus()
+	if status.Txs != 1 || status.Blobs != 3 || status.Accounts != 1 {
+		t.Errorf("wrong pool content: %d txs, %d blobs, %d accounts", status.Txs, status.Blobs, status.Accounts)
+	}
+	if status.Overcapped != 1 || status.Replaced != 1 || status.Evicted != 0 {
+		t.Errorf("wrong pool stats: overcapped %d, replaced %d, evicted %d", status.Overcapped, status.Replaced, status.Evicted)
+	}
+	if status.ProjectedBlobfee.Cmp(status.Blobfee) <= 0 {
+		t.Errorf("projected blob fee %v not above current %v", status.ProjectedBlobfee, status.Blobfee)
+	}
+	if status.EvictBlobFeeCap == nil || status.EvictBlobFeeCap.Uint64() != 400 {
+		t.Errorf("wrong eviction threshold: %v", status.EvictBlobFeeCap)
+	}
+}
@@ -60,6 +60,10 @@ var (
 	basefeeGauge = metrics.NewRegisteredGauge("blobpool/basefee", nil)
 	blobfeeGauge = metrics.NewRegisteredGauge("blobpool/blobfee", nil)
 
+	// projectedBlobfeeGauge tracks the worst case blob fee over the configured
+	// projection window, which the eviction order is based on.
+	projectedBlobfeeGauge = metrics.NewRegisteredGauge("blobpool/blobfee/projected", nil)
+
 	// pooltipGauge is the configurable miner tip to permit a transaction into
 	// the pool.
 	pooltipGauge = metrics.NewRegisteredGauge("blobpool/pooltip", nil)
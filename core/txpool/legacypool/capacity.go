@@ -0,0 +1,268 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// flowSmoothing is the weight of the latest sample in the smoothed slot flows.
+const flowSmoothing = 0.25
+
+var (
+	// tipBuckets are the lower bounds, in gwei, of the effective tip buckets of
+	// the pool composition. Transactions unable to pay the base fee are counted
+	// as underpaid.
+	tipBuckets = []struct {
+		name  string
+		bound *big.Int
+	}{
+		{"100", big.NewInt(100 * params.GWei)},
+		{"10", big.NewInt(10 * params.GWei)},
+		{"1", big.NewInt(params.GWei)},
+		{"0.1", big.NewInt(params.GWei / 10)},
+		{"0.01", big.NewInt(params.GWei / 100)},
+		{"0", new(big.Int)},
+	}
+
+	// ageBuckets are the upper bounds of the age buckets of the pool composition.
+	ageBuckets = []struct {
+		name  string
+		bound time.Duration
+	}{
+		{"1m", time.Minute},
+		{"10m", 10 * time.Minute},
+		{"1h", time.Hour},
+		{"3h", 3 * time.Hour},
+	}
+
+	// typeNames are the names of the transaction types of the pool composition.
+	typeNames = map[byte]string{
+		types.LegacyTxType:     "legacy",
+		types.AccessListTxType: "accesslist",
+		types.DynamicFeeTxType: "dynamic",
+		types.SetCodeTxType:    "setcode",
+	}
+
+	// forecastHorizons are the time spans the eviction thresholds are forecast for.
+	forecastHorizons = []time.Duration{time.Minute, 10 * time.Minute, time.Hour}
+)
+
+// Composition breaks the transactions of the pool down by type, effective tip,
+// age and conditionality.
+type Composition struct {
+	Types       map[string]int // Transactions by type
+	Tips        map[string]int // Transactions by lower bound of their effective tip in gwei, or "underpaid"
+	Ages        map[string]int // Transactions by upper bound of their age, or "older"
+	Conditional int            // Transactions with inclusion conditions
+	Regular     int            // Transactions without inclusion conditions
+}
+
+// CapacityForecast is the predicted state of the pool after a time span, if the
+// current inflow continues.
+type CapacityForecast struct {
+	Horizon   time.Duration
+	Evicted   uint64   // Slots predicted to be evicted to make room
+	Threshold *big.Int // Effective tip predicted to be needed to stay in the pool, nil if no eviction
+}
+
+// CapacityReport is a snapshot of the capacity use of the pool along with the
+// forecasts of its eviction thresholds.
+type CapacityReport struct {
+	Capacity  uint64   // Total slots of the pool
+	Used      uint64   // Slots in use
+	Inflow    float64  // Smoothed rate of the slots admitted, per second
+	Growth    float64  // Smoothed rate the slots in use would grow at without evictions, per second
+	Threshold *big.Int // Lowest effective tip in the pool, which incoming transactions must beat once full
+
+	Forecasts   []CapacityForecast
+	Composition Composition
+}
+
+// poolFlow tracks the smoothed rates of the slot flows of the pool.
+type poolFlow struct {
+	time     time.Time
+	used     uint64
+	admitted uint64
+	evicted  uint64
+
+	inflow float64 // Slots admitted per second
+	growth float64 // Slots the pool would grow by per second without evictions
+}
+
+// update samples the slot counters of the pool.
+func (f *poolFlow) update(now time.Time, used, admitted, evicted uint64) {
+	if !f.time.IsZero() {
+		if elapsed := now.Sub(f.time).Seconds(); elapsed > 0 {
+			var (
+				inflow = float64(admitted-f.admitted) / elapsed
+				growth = (float64(used) - float64(f.used) + float64(evicted-f.evicted)) / elapsed
+			)
+			f.inflow += flowSmoothing * (inflow - f.inflow)
+			f.growth += flowSmoothing * (growth - f.growth)
+		}
+	}
+	f.time, f.used, f.admitted, f.evicted = now, used, admitted, evicted
+}
+
+// pricedSlots is the number of slots used at an effective tip.
+type pricedSlots struct {
+	tip   *big.Int
+	slots uint64
+}
+
+// composition breaks down the transactions of the pool, returning them along
+// with the slots used at each effective tip, cheapest first.
+func (pool *LegacyPool) composition(baseFee *big.Int) (Composition, []pricedSlots) {
+	comp := Composition{
+		Types: make(map[string]int),
+		Tips:  make(map[string]int),
+		Ages:  make(map[string]int),
+	}
+	var priced []pricedSlots
+
+	now := time.Now()
+	pool.all.Range(func(hash common.Hash, tx *types.Transaction) bool {
+		name, ok := typeNames[tx.Type()]
+		if !ok {
+			name = fmt.Sprintf("type%d", tx.Type())
+		}
+		comp.Types[name]++
+
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil {
+			comp.Tips["underpaid"]++
+			tip = new(big.Int)
+		} else {
+			for _, bucket := range tipBuckets {
+				if tip.Cmp(bucket.bound) >= 0 {
+					comp.Tips[bucket.name]++
+					break
+				}
+			}
+		}
+		priced = append(priced, pricedSlots{tip: tip, slots: uint64(numSlots(tx))})
+
+		age := "older"
+		for _, bucket := range ageBuckets {
+			if now.Sub(tx.Time()) < bucket.bound {
+				age = bucket.name
+				break
+			}
+		}
+		comp.Ages[age]++
+
+		if tx.Conditional() != nil {
+			comp.Conditional++
+		} else {
+			comp.Regular++
+		}
+		return true
+	})
+	slices.SortFunc(priced, func(a, b pricedSlots) int { return a.tip.Cmp(b.tip) })
+	return comp, priced
+}
+
+// CapacityReport returns the capacity use of the pool and forecasts the
+// effective tips needed to stay in the pool if the current inflow continues.
+func (pool *LegacyPool) CapacityReport() *CapacityReport {
+	pool.flowMu.Lock()
+	flow := pool.flow
+	pool.flowMu.Unlock()
+
+	var baseFee *big.Int
+	if head := pool.currentHead.Load(); head != nil {
+		baseFee = head.BaseFee
+	}
+	comp, priced := pool.composition(baseFee)
+
+	report := &CapacityReport{
+		Capacity:    pool.config.GlobalSlots + pool.config.GlobalQueue,
+		Inflow:      flow.inflow,
+		Growth:      flow.growth,
+		Composition: comp,
+	}
+	for _, p := range priced {
+		report.Used += p.slots
+	}
+	if len(priced) > 0 {
+		report.Threshold = priced[0].tip
+	}
+	for _, horizon := range forecastHorizons {
+		forecast := CapacityForecast{Horizon: horizon}
+
+		demand := float64(report.Used) + flow.growth*horizon.Seconds()
+		if excess := demand - float64(report.Capacity); excess > 0 {
+			forecast.Evicted = uint64(excess)
+
+			// The threshold is the tip of the cheapest transaction left after
+			// the excess slots are evicted, cheapest first
+			var evicted uint64
+			for _, p := range priced {
+				if evicted >= forecast.Evicted {
+					forecast.Threshold = p.tip
+					break
+				}
+				evicted += p.slots
+			}
+			if forecast.Threshold == nil && len(priced) > 0 {
+				forecast.Threshold = priced[len(priced)-1].tip // everything evicted
+			}
+		}
+		report.Forecasts = append(report.Forecasts, forecast)
+	}
+	return report
+}
+
+// reportComposition samples the slot flows and updates the composition metrics
+// of the pool.
+func (pool *LegacyPool) reportComposition() {
+	pool.flowMu.Lock()
+	pool.flow.update(time.Now(), uint64(pool.all.Slots()), pool.admittedSlots.Load(), pool.evictedSlots.Load())
+	pool.flowMu.Unlock()
+
+	if !metrics.Enabled() {
+		return
+	}
+	var baseFee *big.Int
+	if head := pool.currentHead.Load(); head != nil {
+		baseFee = head.BaseFee
+	}
+	comp, _ := pool.composition(baseFee)
+
+	for _, name := range typeNames {
+		metrics.GetOrRegisterGauge("txpool/composition/type/"+name, nil).Update(int64(comp.Types[name]))
+	}
+	for _, bucket := range tipBuckets {
+		metrics.GetOrRegisterGauge("txpool/composition/tip/"+bucket.name, nil).Update(int64(comp.Tips[bucket.name]))
+	}
+	metrics.GetOrRegisterGauge("txpool/composition/tip/underpaid", nil).Update(int64(comp.Tips["underpaid"]))
+	for _, bucket := range ageBuckets {
+		metrics.GetOrRegisterGauge("txpool/composition/age/"+bucket.name, nil).Update(int64(comp.Ages[bucket.name]))
+	}
+	metrics.GetOrRegisterGauge("txpool/composition/age/older", nil).Update(int64(comp.Ages["older"]))
+	metrics.GetOrRegisterGauge("txpool/composition/conditional", nil).Update(int64(comp.Conditional))
+	metrics.GetOrRegisterGauge("txpool/composition/regular", nil).Update(int64(comp.Regular))
+}
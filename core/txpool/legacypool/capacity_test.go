@@ -0,0 +1,92 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestPoolFlow(t *testing.T) {
+	var (
+		flow  poolFlow
+		start = time.Now()
+	)
+	flow.update(start, 100, 1000, 0)
+	if flow.inflow != 0 || flow.growth != 0 {
+		t.Fatalf("flows estimated from a single sample: inflow %v, growth %v", flow.inflow, flow.growth)
+	}
+	// 40 slots admitted in 10 seconds, 20 of them making room by eviction and
+	// 10 of them being included
+	flow.update(start.Add(10*time.Second), 110, 1040, 20)
+	if want := flowSmoothing * 4; flow.inflow != want {
+		t.Errorf("wrong inflow: have %v, want %v", flow.inflow, want)
+	}
+	if want := flowSmoothing * 3; flow.growth != want {
+		t.Errorf("wrong growth: have %v, want %v", flow.growth, want)
+	}
+}
+
+func TestCapacityReport(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(params.Ether))
+	for i := uint64(0); i < 10; i++ {
+		tx := pricedTransaction(i, 100000, big.NewInt(int64(i+1)*params.GWei), key)
+		if err := pool.addRemoteSync(tx); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	pool.config.GlobalSlots, pool.config.GlobalQueue = 8, 2
+
+	// The pool is full and grows by 3 slots a minute: 3 slots are evicted in a
+	// minute and all of them in 10 minutes
+	pool.flowMu.Lock()
+	pool.flow.growth = 3.0 / 60
+	pool.flowMu.Unlock()
+
+	report := pool.CapacityReport()
+	if report.Capacity != 10 || report.Used != 10 {
+		t.Fatalf("wrong capacity use: %d of %d slots", report.Used, report.Capacity)
+	}
+	if report.Threshold.Cmp(big.NewInt(params.GWei)) != 0 {
+		t.Fatalf("wrong eviction threshold: %v", report.Threshold)
+	}
+	if len(report.Forecasts) != len(forecastHorizons) {
+		t.Fatalf("wrong number of forecasts: %d", len(report.Forecasts))
+	}
+	if f := report.Forecasts[0]; f.Horizon != time.Minute || f.Evicted != 3 || f.Threshold.Cmp(big.NewInt(4*params.GWei)) != 0 {
+		t.Errorf("wrong 1m forecast: evicted %d, threshold %v", f.Evicted, f.Threshold)
+	}
+	if f := report.Forecasts[1]; f.Evicted != 30 || f.Threshold.Cmp(big.NewInt(10*params.GWei)) != 0 {
+		t.Errorf("wrong 10m forecast: evicted %d, threshold %v", f.Evicted, f.Threshold)
+	}
+	comp := report.Composition
+	if comp.Types["legacy"] != 10 || comp.Regular != 10 || comp.Conditional != 0 || comp.Ages["1m"] != 10 {
+		t.Errorf("wrong composition: %+v", comp)
+	}
+	if comp.Tips["1"] != 9 || comp.Tips["10"] != 1 {
+		t.Errorf("wrong tip composition: %v", comp.Tips)
+	}
+}
@@ -18,6 +18,7 @@
 package legacypool
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"maps"
@@ -76,6 +77,14 @@ var (
 	// ErrFutureReplacePending is returned if a future transaction replaces a pending
 	// one. Future transactions should only be able to replace other future transactions.
 	ErrFutureReplacePending = errors.New("future transaction tries to replace pending")
+
+	// ErrTenantQuota is returned if a transaction would make its tenant exceed
+	// the configured slot or gas quota.
+	ErrTenantQuota = errors.New("tenant quota exceeded")
+
+	// ErrTenantOverflow is returned if the transaction pool is full and the
+	// tenant of the transaction already uses more than its fair share of it.
+	ErrTenantOverflow = errors.New("txpool is full and tenant exceeds its fair share")
 )
 
 var (
@@ -103,6 +112,7 @@ var (
 	invalidTxMeter     = metrics.NewRegisteredMeter("txpool/invalid", nil)
 	underpricedTxMeter = metrics.NewRegisteredMeter("txpool/underpriced", nil)
 	overflowedTxMeter  = metrics.NewRegisteredMeter("txpool/overflowed", nil)
+	tenantTxMeter      = metrics.NewRegisteredMeter("txpool/tenant/rejected", nil) // Dropped due to tenant quotas
 
 	// throttleTxMeter counts how many transactions are rejected due to too-many-changes between
 	// txpool reorgs.
@@ -150,6 +160,13 @@ type Config struct {
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
 
+	// Replacement rules overriding the price bump. A zero tip bump falls back
+	// to the fee cap bump of the rule, and the rule of the transactions with
+	// conditionals to the default one.
+	TipBump              uint64 // Minimum tip bump percentage to replace an already existing transaction
+	ConditionalPriceBump uint64 // Minimum price bump percentage if either transaction has conditionals
+	ConditionalTipBump   uint64 // Minimum tip bump percentage if either transaction has conditionals
+
 	AccountSlots uint64 // Number of executable transaction slots guaranteed per account
 	GlobalSlots  uint64 // Maximum number of executable transaction slots for all accounts
 	AccountQueue uint64 // Maximum number of non-executable transaction slots permitted per account
@@ -157,6 +174,25 @@ type Config struct {
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
 
+	// Quotas of the transactions submitted by authenticated RPC clients, grouped
+	// by the identity of the client (tenant). Zero disables the quota.
+	TenantSlots uint64 // Maximum number of transaction slots a single tenant may use
+	TenantGas   uint64 // Maximum total gas limit of the transactions of a single tenant
+
+	// Pre-check of incoming transactions by simulating them against the state
+	// of the chain head, see txpool.NewRevertFilter.
+	RevertCheck    string // Handling of transactions that would fail: "" (no check), "tag" or "reject"
+	RevertCheckGas uint64 // Maximum gas simulated per second
+
+	// Spam scoring of the senders. Replacing transactions, having transactions
+	// dropped without inclusion and having pooled transactions fail on chain
+	// raise the score of a sender, which halves every SpamDecay. The minimum tip
+	// required from a sender is raised by SpamTipStep for every SpamThreshold
+	// points of its score. Zero threshold disables the scoring.
+	SpamThreshold uint64
+	SpamTipStep   uint64
+	SpamDecay     time.Duration
+
 	EffectiveGasCeil uint64 // OP-Stack: if non-zero, a gas ceiling to enforce independent of the header's gaslimit value
 }
 
@@ -174,6 +210,26 @@ var DefaultConfig = Config{
 	GlobalQueue:  1024,
 
 	Lifetime: 3 * time.Hour,
+
+	RevertCheckGas: 50_000_000,
+
+	SpamTipStep: params.GWei,
+	SpamDecay:   10 * time.Minute,
+}
+
+// replacementRules returns the replacement rules set by the configuration.
+func (config *Config) replacementRules() replacementRules {
+	var (
+		tip  = cmp.Or(config.TipBump, config.PriceBump)
+		cond = cmp.Or(config.ConditionalPriceBump, config.PriceBump)
+	)
+	return replacementRules{
+		plain: txpool.ReplacementRule{FeeCapBump: config.PriceBump, TipBump: tip},
+		conditional: txpool.ReplacementRule{
+			FeeCapBump: cond,
+			TipBump:    cmp.Or(config.ConditionalTipBump, config.ConditionalPriceBump, tip),
+		},
+	}
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -208,6 +264,10 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
 		conf.Lifetime = DefaultConfig.Lifetime
 	}
+	if conf.SpamThreshold != 0 && conf.SpamDecay < 1 {
+		log.Warn("Sanitizing invalid txpool spam decay", "provided", conf.SpamDecay, "updated", DefaultConfig.SpamDecay)
+		conf.SpamDecay = DefaultConfig.SpamDecay
+	}
 	return conf
 }
 
@@ -234,7 +294,7 @@ func (config *Config) sanitize() Config {
 // transactions.
 type LegacyPool struct {
 	config      Config
-	chainconfig *params.ChainConfig
+	replacement replacementRules // Price bumps required to replace a transaction
 	chain       BlockChain
 	gasTip      atomic.Pointer[uint256.Int]
 	txFeed      event.Feed
@@ -246,11 +306,13 @@ type LegacyPool struct {
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
 	reserver      txpool.Reserver              // Address reserver to ensure exclusivity across subpools
 
-	pending map[common.Address]*list     // All currently processable transactions
-	queue   map[common.Address]*list     // Queued but non-processable transactions
-	beats   map[common.Address]time.Time // Last heartbeat from each known account
-	all     *lookup                      // All transactions to allow lookups
-	priced  *pricedList                  // All transactions sorted by price
+	pending map[common.Address]*list      // All currently processable transactions
+	queue   map[common.Address]*list      // Queued but non-processable transactions
+	beats   map[common.Address]time.Time  // Last heartbeat from each known account
+	spam    map[common.Address]*spamScore // Spam scores of the senders, if enabled
+	locals  map[common.Address]struct{}   // Local senders, exempt from spam scoring
+	all     *lookup                       // All transactions to allow lookups
+	priced  *pricedList                   // All transactions sorted by price
 
 	reqResetCh      chan *txpoolResetRequest
 	reqPromoteCh    chan *accountSet
@@ -262,6 +324,11 @@ type LegacyPool struct {
 
 	changesSinceReorg int // A counter for how many drops we've performed in-between reorg.
 
+	admittedSlots atomic.Uint64 // Slots of the transactions admitted to the pool
+	evictedSlots  atomic.Uint64 // Slots of the transactions evicted to make room
+	flow          poolFlow      // Smoothed slot flows, sampled at every stats report
+	flowMu        sync.Mutex
+
 	rollupCostFn txpool.RollupCostFunc // Additional rollup cost function, optional field, may be nil.
 
 	ingressFilters []txpool.IngressFilter // Filters to apply to incoming transactions
@@ -282,12 +349,14 @@ func New(config Config, chain BlockChain) *LegacyPool {
 	// Create the transaction pool with its initial settings
 	pool := &LegacyPool{
 		config:          config,
+		replacement:     config.replacementRules(),
 		chain:           chain,
-		chainconfig:     chain.Config(),
 		signer:          types.LatestSigner(chain.Config()),
 		pending:         make(map[common.Address]*list),
 		queue:           make(map[common.Address]*list),
 		beats:           make(map[common.Address]time.Time),
+		spam:            make(map[common.Address]*spamScore),
+		locals:          make(map[common.Address]struct{}, len(config.Locals)),
 		all:             newLookup(),
 		reqResetCh:      make(chan *txpoolResetRequest),
 		reqPromoteCh:    make(chan *accountSet),
@@ -296,6 +365,9 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		reorgShutdownCh: make(chan struct{}),
 		initDoneCh:      make(chan struct{}),
 	}
+	for _, addr := range config.Locals {
+		pool.locals[addr] = struct{}{}
+	}
 	pool.priced = newPricedList(pool.all)
 
 	return pool
@@ -382,6 +454,7 @@ func (pool *LegacyPool) loop() {
 				log.Debug("Transaction pool status report", "executable", pending, "queued", queued, "stales", stales)
 				prevPending, prevQueued, prevStales = pending, queued, stales
 			}
+			pool.reportComposition()
 
 		// Handle inactive account transaction eviction
 		case <-evict.C:
@@ -396,6 +469,9 @@ func (pool *LegacyPool) loop() {
 					queuedEvictionMeter.Mark(int64(len(list)))
 				}
 			}
+			if pool.spamScoring() {
+				pool.pruneSpamScores()
+			}
 			pool.mu.Unlock()
 		}
 	}
@@ -473,6 +549,28 @@ func (pool *LegacyPool) Stats() (int, int) {
 	return pool.stats()
 }
 
+// ReplacementRules returns the price bumps required to replace a transaction,
+// for plain transactions and for transactions with conditionals.
+func (pool *LegacyPool) ReplacementRules() map[string]txpool.ReplacementRule {
+	return map[string]txpool.ReplacementRule{
+		"default":     pool.replacement.plain,
+		"conditional": pool.replacement.conditional,
+	}
+}
+
+// Evict removes a transaction from the pool, demoting the subsequent ones of
+// its sender, and reports whether it was found.
+func (pool *LegacyPool) Evict(hash common.Hash) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.all.Get(hash) == nil {
+		return false
+	}
+	pool.removeTx(hash, true, true)
+	return true
+}
+
 // stats retrieves the current pool stats, namely the number of pending and the
 // number of queued (non-executable) transactions.
 func (pool *LegacyPool) stats() (int, int) {
@@ -628,7 +726,7 @@ func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address]
 // and does not require the pool mutex to be held.
 func (pool *LegacyPool) ValidateTxBasics(tx *types.Transaction) error {
 	opts := &txpool.ValidationOptions{
-		Config: pool.chainconfig,
+		Config: pool.chain.Config(),
 		Accept: 0 |
 			1<<types.LegacyTxType |
 			1<<types.AccessListTxType |
@@ -641,6 +739,50 @@ func (pool *LegacyPool) ValidateTxBasics(tx *types.Transaction) error {
 	return txpool.ValidateTransaction(tx, pool.currentHead.Load(), pool.signer, opts)
 }
 
+// Validate checks whether a transaction would be accepted by the pool, running
+// the checks of Add without admitting it.
+func (pool *LegacyPool) Validate(tx *types.Transaction) error {
+	if pool.all.Get(tx.Hash()) != nil {
+		return txpool.ErrAlreadyKnown
+	}
+	if err := pool.ValidateTxBasics(tx); err != nil {
+		return err
+	}
+	for _, filter := range pool.ingressFilters {
+		if !filter.FilterTx(pool.filterCtx, tx) {
+			return core.ErrTxFilteredOut
+		}
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if err := pool.validateTx(tx); err != nil {
+		return err
+	}
+	from, _ := types.Sender(pool.signer, tx) // already validated
+	if err := pool.checkTenantQuota(from, tx); err != nil {
+		return err
+	}
+	if err := pool.checkSpamTip(from, tx); err != nil {
+		return err
+	}
+	if pool.pending[from] == nil && pool.queue[from] == nil && pool.reserver.Has(from) {
+		return txpool.ErrAlreadyReserved
+	}
+	if old := pool.pooledTx(from, tx.Nonce()); old != nil && !pool.replacement.replaceable(old, tx) {
+		return txpool.ErrReplaceUnderpriced
+	}
+	if uint64(pool.all.Slots()+numSlots(tx)) > pool.config.GlobalSlots+pool.config.GlobalQueue {
+		if pool.priced.Underpriced(tx) {
+			return txpool.ErrUnderpriced
+		}
+		if pool.exceedsTenantShare(tx) {
+			return ErrTenantOverflow
+		}
+	}
+	return nil
+}
+
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (pool *LegacyPool) validateTx(tx *types.Transaction) error {
@@ -759,6 +901,21 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 	// already validated by this point
 	from, _ := types.Sender(pool.signer, tx)
 
+	// If the transaction would exceed the quotas of its tenant, discard it
+	if err := pool.checkTenantQuota(from, tx); err != nil {
+		log.Trace("Discarding transaction exceeding tenant quota", "hash", hash, "tenant", tx.Tenant(), "err", err)
+		tenantTxMeter.Mark(1)
+		return false, err
+	}
+
+	// If the sender is scored as a spammer and the transaction doesn't pay the
+	// raised tip, discard it
+	if err := pool.checkSpamTip(from, tx); err != nil {
+		log.Trace("Discarding transaction below raised tip", "hash", hash, "from", from, "err", err)
+		spamRejectMeter.Mark(1)
+		return false, err
+	}
+
 	// If the address is not yet known, request exclusivity to track the account
 	// only by this subpool until all transactions are evicted
 	var (
@@ -799,6 +956,14 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 			return false, ErrTxPoolOverflow
 		}
 
+		// Tenants using more than their fair share of the pool can't evict the
+		// transactions of others to make room for more of their own.
+		if pool.exceedsTenantShare(tx) {
+			log.Trace("Discarding transaction exceeding tenant share", "hash", hash, "tenant", tx.Tenant())
+			tenantTxMeter.Mark(1)
+			return false, ErrTenantOverflow
+		}
+
 		// New transaction is better than our worse ones, make room for it.
 		// If we can't make enough room for new one, abort the operation.
 		drop, success := pool.priced.Discard(pool.all.Slots() - int(pool.config.GlobalSlots+pool.config.GlobalQueue) + numSlots(tx))
@@ -834,6 +999,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 		for _, tx := range drop {
 			log.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
 			underpricedTxMeter.Mark(1)
+			pool.evictedSlots.Add(uint64(numSlots(tx)))
 
 			sender, _ := types.Sender(pool.signer, tx)
 			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
@@ -845,7 +1011,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 	// Try to replace an existing transaction in the pending pool
 	if list := pool.pending[from]; list != nil && list.Contains(tx.Nonce()) {
 		// Nonce already pending, check if required price bump is met
-		inserted, old := list.Add(tx, pool.config.PriceBump)
+		inserted, old := list.Add(tx, pool.replacement)
 		if !inserted {
 			pendingDiscardMeter.Mark(1)
 			return false, txpool.ErrReplaceUnderpriced
@@ -855,6 +1021,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.scoreReplace(from, tx)
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
@@ -870,6 +1037,9 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 	if err != nil {
 		return false, err
 	}
+	if replaced {
+		pool.scoreReplace(from, tx)
+	}
 
 	log.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replaced, nil
@@ -908,7 +1078,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, addAl
 	if pool.queue[from] == nil {
 		pool.queue[from] = newRollupList(false, pool)
 	}
-	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump)
+	inserted, old := pool.queue[from].Add(tx, pool.replacement)
 	if !inserted {
 		// An older transaction was better, discard this
 		queuedDiscardMeter.Mark(1)
@@ -950,7 +1120,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 	}
 	list := pool.pending[addr]
 
-	inserted, old := list.Add(tx, pool.config.PriceBump)
+	inserted, old := list.Add(tx, pool.replacement)
 	if !inserted {
 		// An older transaction was better, discard this
 		pool.all.Remove(hash)
@@ -1079,6 +1249,9 @@ func (pool *LegacyPool) addTxsLocked(txs []*types.Transaction) ([]error, *accoun
 		if err == nil && !replaced {
 			dirty.addTx(tx)
 		}
+		if err == nil {
+			pool.admittedSlots.Add(uint64(numSlots(tx)))
+		}
 	}
 	validTxMeter.Mark(int64(len(dirty.accounts)))
 	return errs, dirty
@@ -1369,8 +1542,8 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	if reset != nil {
 		pool.demoteUnexecutables()
 		if reset.newHead != nil {
-			if pool.chainconfig.IsLondon(new(big.Int).Add(reset.newHead.Number, big.NewInt(1))) {
-				pendingBaseFee := eip1559.CalcBaseFee(pool.chainconfig, reset.newHead, reset.newHead.Time+1)
+			if pool.chain.Config().IsLondon(new(big.Int).Add(reset.newHead.Number, big.NewInt(1))) {
+				pendingBaseFee := eip1559.CalcBaseFee(pool.chain.Config(), reset.newHead, reset.newHead.Time+1)
 				pool.priced.SetBaseFee(pendingBaseFee)
 			} else {
 				pool.priced.Reheap()
@@ -1412,6 +1585,11 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 // reset retrieves the current state of the blockchain and ensures the content
 // of the transaction pool is valid with regard to the chain state.
 func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
+	// Score the senders of the pooled transactions which failed on chain,
+	// before they are dropped as included
+	if pool.spamScoring() && oldHead != nil && newHead != nil {
+		pool.scoreReverts(oldHead, newHead)
+	}
 	// If we're reorging an old state, reinject all dropped transactions
 	var reinject types.Transactions
 
@@ -1512,7 +1690,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 }
 
 func (pool *LegacyPool) resetRollupCostFn(ts uint64, statedb *state.StateDB) {
-	if costFn := types.NewTotalRollupCostFunc(pool.chainconfig, statedb); costFn != nil {
+	if costFn := types.NewTotalRollupCostFunc(pool.chain.Config(), statedb); costFn != nil {
 		pool.rollupCostFn = func(tx types.RollupTransaction) *uint256.Int {
 			return costFn(tx, ts)
 		}
@@ -1527,7 +1705,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 	var promoted []*types.Transaction
 
 	// Iterate over all accounts and promote any executable transactions
-	gasLimit := txpool.EffectiveGasLimit(pool.chainconfig, pool.currentHead.Load().GasLimit, pool.config.EffectiveGasCeil)
+	gasLimit := txpool.EffectiveGasLimit(pool.chain.Config(), pool.currentHead.Load().GasLimit, pool.config.EffectiveGasCeil)
 	for _, addr := range accounts {
 		list := pool.queue[addr]
 		if list == nil {
@@ -1567,6 +1745,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 			log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 		}
 		queuedRateLimitMeter.Mark(int64(len(caps)))
+
 		// Mark all the items dropped as removed
 		pool.priced.Removed(len(forwards) + len(drops) + len(caps))
 		queuedGauge.Dec(int64(len(forwards) + len(drops) + len(caps)))
@@ -1717,7 +1896,7 @@ func (pool *LegacyPool) truncateQueue() {
 // to trigger a re-heap is this function
 func (pool *LegacyPool) demoteUnexecutables() {
 	// Iterate over all accounts and demote any non-executable transactions
-	gasLimit := txpool.EffectiveGasLimit(pool.chainconfig, pool.currentHead.Load().GasLimit, pool.config.EffectiveGasCeil)
+	gasLimit := txpool.EffectiveGasLimit(pool.chain.Config(), pool.currentHead.Load().GasLimit, pool.config.EffectiveGasCeil)
 	for addr, list := range pool.pending {
 		nonce := pool.currentState.GetNonce(addr)
 
@@ -1854,14 +2033,16 @@ type lookup struct {
 	lock  sync.RWMutex
 	txs   map[common.Hash]*types.Transaction
 
-	auths map[common.Address][]common.Hash // All accounts with a pooled authorization
+	auths   map[common.Address][]common.Hash // All accounts with a pooled authorization
+	tenants map[string]*tenantUsage          // Resources used by the labelled transactions, per tenant
 }
 
 // newLookup returns a new lookup structure.
 func newLookup() *lookup {
 	return &lookup{
-		txs:   make(map[common.Hash]*types.Transaction),
-		auths: make(map[common.Address][]common.Hash),
+		txs:     make(map[common.Hash]*types.Transaction),
+		auths:   make(map[common.Address][]common.Hash),
+		tenants: make(map[string]*tenantUsage),
 	}
 }
 
@@ -1913,6 +2094,7 @@ func (t *lookup) Add(tx *types.Transaction) {
 
 	t.txs[tx.Hash()] = tx
 	t.addAuthorities(tx)
+	t.addTenant(tx)
 }
 
 // Remove removes a transaction from the lookup.
@@ -1926,6 +2108,7 @@ func (t *lookup) Remove(hash common.Hash) {
 		return
 	}
 	t.removeAuthorities(tx)
+	t.removeTenant(tx)
 	t.slots -= numSlots(tx)
 	slotsGauge.Update(int64(t.slots))
 
@@ -1940,6 +2123,10 @@ func (t *lookup) Clear() {
 	t.slots = 0
 	t.txs = make(map[common.Hash]*types.Transaction)
 	t.auths = make(map[common.Address][]common.Hash)
+	for tenant := range t.tenants {
+		unregisterTenant(tenant)
+	}
+	t.tenants = make(map[string]*tenantUsage)
 }
 
 // TxsBelowTip finds all remote transactions below the given tip threshold.
@@ -38,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
@@ -533,7 +534,7 @@ func TestChainFork(t *testing.T) {
 		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
 		statedb.AddBalance(addr, uint256.NewInt(100000000000000), tracing.BalanceChangeUnspecified)
 
-		pool.chain = newTestBlockChain(pool.chainconfig, 1000000, statedb, new(event.Feed))
+		pool.chain = newTestBlockChain(pool.chain.Config(), 1000000, statedb, new(event.Feed))
 		<-pool.requestReset(nil, nil)
 	}
 	resetState()
@@ -562,7 +563,7 @@ func TestDoubleNonce(t *testing.T) {
 		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
 		statedb.AddBalance(addr, uint256.NewInt(100000000000000), tracing.BalanceChangeUnspecified)
 
-		pool.chain = newTestBlockChain(pool.chainconfig, 1000000, statedb, new(event.Feed))
+		pool.chain = newTestBlockChain(pool.chain.Config(), 1000000, statedb, new(event.Feed))
 		<-pool.requestReset(nil, nil)
 	}
 	resetState()
@@ -1384,6 +1385,128 @@ func TestPendingMinimumAllowance(t *testing.T) {
 	}
 }
 
+// tenantTransaction creates a priced transaction labelled with the given tenant.
+func tenantTransaction(tenant string, nonce uint64, gaslimit uint64, gasprice *big.Int, key *ecdsa.PrivateKey) *types.Transaction {
+	tx := pricedTransaction(nonce, gaslimit, gasprice, key)
+	tx.SetTenant(tenant)
+	return tx
+}
+
+// Tests that the slot and gas quotas of the tenants are enforced, without
+// affecting the transactions of other tenants or unlabelled ones.
+func TestTenantQuotas(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.TenantSlots = 3
+	config.TenantGas = 250000
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(params.Ether))
+	}
+	// Fill the slot quota of the first tenant, the next transaction is rejected
+	for i := uint64(0); i < 3; i++ {
+		if err := pool.addRemoteSync(tenantTransaction("alice", i, 50000, big.NewInt(1), keys[0])); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	if err := pool.addRemoteSync(tenantTransaction("alice", 3, 50000, big.NewInt(1), keys[0])); !errors.Is(err, ErrTenantQuota) {
+		t.Fatalf("slot quota not enforced: have %v, want %v", err, ErrTenantQuota)
+	}
+	// Replacements reuse the resources of the replaced transaction
+	if err := pool.addRemoteSync(tenantTransaction("alice", 2, 50000, big.NewInt(2), keys[0])); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	if slots, gas := pool.all.TenantUsage("alice"); slots != 3 || gas != 150000 {
+		t.Fatalf("wrong tenant usage: have %d slots and %d gas, want 3 and 150000", slots, gas)
+	}
+	// The gas quota is enforced independently of the slot one
+	if err := pool.addRemoteSync(tenantTransaction("carol", 0, 200000, big.NewInt(1), keys[1])); err != nil {
+		t.Fatalf("failed to add transaction of other tenant: %v", err)
+	}
+	if err := pool.addRemoteSync(tenantTransaction("carol", 1, 100000, big.NewInt(1), keys[1])); !errors.Is(err, ErrTenantQuota) {
+		t.Fatalf("gas quota not enforced: have %v, want %v", err, ErrTenantQuota)
+	}
+	// Unlabelled transactions are not subject to any quota
+	for i := uint64(0); i < 5; i++ {
+		if err := pool.addRemoteSync(pricedTransaction(i, 100000, big.NewInt(1), keys[2])); err != nil {
+			t.Fatalf("failed to add unlabelled transaction %d: %v", i, err)
+		}
+	}
+	// Dropping the transactions of a tenant releases its quota
+	pool.mu.Lock()
+	pool.removeTx(pool.pending[crypto.PubkeyToAddress(keys[1].PublicKey)].txs.Get(0).Hash(), true, true)
+	pool.mu.Unlock()
+
+	if slots, gas := pool.all.TenantUsage("carol"); slots != 0 || gas != 0 {
+		t.Fatalf("tenant usage not released: have %d slots and %d gas", slots, gas)
+	}
+	if tenants := pool.all.Tenants(); tenants != 1 {
+		t.Fatalf("wrong number of tenants: have %d, want 1", tenants)
+	}
+	if metrics.DefaultRegistry.Get(tenantMetric("carol", "slots")) != nil {
+		t.Fatalf("gauges of evicted tenant still registered")
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that a tenant exceeding its fair share of a full pool can't evict the
+// transactions of others, while tenants below it still can.
+func TestTenantFairness(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.GlobalSlots = 4
+	config.GlobalQueue = 4
+	config.TenantSlots = 100
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(params.Ether))
+	}
+	// Fill up the pool with cheap transactions of a single tenant
+	for i := uint64(0); i < 4; i++ {
+		for _, key := range keys[:2] {
+			if err := pool.addRemoteSync(tenantTransaction("alice", i, 100000, big.NewInt(1), key)); err != nil {
+				t.Fatalf("failed to add transaction: %v", err)
+			}
+		}
+	}
+	// A new tenant is below its fair share, so it may evict
+	if err := pool.addRemoteSync(tenantTransaction("bob", 0, 100000, big.NewInt(10), keys[2])); err != nil {
+		t.Fatalf("failed to add transaction of new tenant: %v", err)
+	}
+	// The first tenant is above its fair share, so it may not
+	if err := pool.addRemoteSync(tenantTransaction("alice", 0, 100000, big.NewInt(10), keys[3])); !errors.Is(err, ErrTenantOverflow) {
+		t.Fatalf("fair share not enforced: have %v, want %v", err, ErrTenantOverflow)
+	}
+	if slots, _ := pool.all.TenantUsage("alice"); slots != 7 {
+		t.Fatalf("wrong tenant usage: have %d slots, want 7", slots)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Tests that setting the transaction pool gas price to a higher value correctly
 // discards everything cheaper than that and moves any gapped transactions back
 // from the pending pool to the queue.
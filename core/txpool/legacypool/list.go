@@ -344,27 +344,11 @@ func (l *list) Contains(nonce uint64) bool {
 //
 // If the new transaction is accepted into the list, the lists' cost and gas
 // thresholds are also potentially updated.
-func (l *list) Add(tx *types.Transaction, priceBump uint64) (bool, *types.Transaction) {
+func (l *list) Add(tx *types.Transaction, rules replacementRules) (bool, *types.Transaction) {
 	// If there's an older better transaction, abort
 	old := l.txs.Get(tx.Nonce())
 	if old != nil {
-		if old.GasFeeCapCmp(tx) >= 0 || old.GasTipCapCmp(tx) >= 0 {
-			return false, nil
-		}
-		// thresholdFeeCap = oldFC  * (100 + priceBump) / 100
-		a := big.NewInt(100 + int64(priceBump))
-		aFeeCap := new(big.Int).Mul(a, old.GasFeeCap())
-		aTip := a.Mul(a, old.GasTipCap())
-
-		// thresholdTip    = oldTip * (100 + priceBump) / 100
-		b := big.NewInt(100)
-		thresholdFeeCap := aFeeCap.Div(aFeeCap, b)
-		thresholdTip := aTip.Div(aTip, b)
-
-		// We have to ensure that both the new fee cap and tip are higher than the
-		// old ones as well as checking the percentage threshold to ensure that
-		// this is accurate for low (Wei-level) gas price replacements.
-		if tx.GasFeeCapIntCmp(thresholdFeeCap) < 0 || tx.GasTipCapIntCmp(thresholdTip) < 0 {
+		if !rules.replaceable(old, tx) {
 			return false, nil
 		}
 		// Old is being replaced, subtract old cost
@@ -388,6 +372,39 @@ func (l *list) Add(tx *types.Transaction, priceBump uint64) (bool, *types.Transa
 	return true, old
 }
 
+// replacementRules are the price bumps a transaction must pay to replace
+// another one.
+type replacementRules struct {
+	plain       txpool.ReplacementRule
+	conditional txpool.ReplacementRule // Applied if either transaction has conditionals
+}
+
+// replaceable checks whether tx pays enough more than old to replace it, given
+// the minimum price bump percentages.
+func (rules replacementRules) replaceable(old, tx *types.Transaction) bool {
+	if old.GasFeeCapCmp(tx) >= 0 || old.GasTipCapCmp(tx) >= 0 {
+		return false
+	}
+	rule := rules.plain
+	if old.Conditional() != nil || tx.Conditional() != nil {
+		rule = rules.conditional
+	}
+	// thresholdFeeCap = oldFC  * (100 + feeCapBump) / 100
+	aFeeCap := new(big.Int).Mul(big.NewInt(100+int64(rule.FeeCapBump)), old.GasFeeCap())
+
+	// thresholdTip    = oldTip * (100 + tipBump) / 100
+	aTip := new(big.Int).Mul(big.NewInt(100+int64(rule.TipBump)), old.GasTipCap())
+
+	b := big.NewInt(100)
+	thresholdFeeCap := aFeeCap.Div(aFeeCap, b)
+	thresholdTip := aTip.Div(aTip, b)
+
+	// We have to ensure that both the new fee cap and tip are higher than the
+	// old ones as well as checking the percentage threshold to ensure that
+	// this is accurate for low (Wei-level) gas price replacements.
+	return tx.GasFeeCapIntCmp(thresholdFeeCap) >= 0 && tx.GasTipCapIntCmp(thresholdTip) >= 0
+}
+
 // Forward removes all transactions from the list with a nonce lower than the
 // provided threshold. Every removed transaction is returned for any post-removal
 // maintenance.
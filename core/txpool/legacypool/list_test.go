@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/holiman/uint256"
@@ -40,7 +41,7 @@ func TestStrictListAdd(t *testing.T) {
 	// Insert the transactions in a random order
 	list := newList(true)
 	for _, v := range rand.Perm(len(txs)) {
-		list.Add(txs[v], DefaultConfig.PriceBump)
+		list.Add(txs[v], DefaultConfig.replacementRules())
 	}
 	// Verify internal state
 	if len(list.txs.items) != len(txs) {
@@ -64,7 +65,34 @@ func TestListAddVeryExpensive(t *testing.T) {
 		gaslimit := uint64(i)
 		tx, _ := types.SignTx(types.NewTransaction(uint64(i), common.Address{}, value, gaslimit, gasprice, nil), types.HomesteadSigner{}, key)
 		t.Logf("cost: %x bitlen: %d\n", tx.Cost(), tx.Cost().BitLen())
-		list.Add(tx, DefaultConfig.PriceBump)
+		list.Add(tx, DefaultConfig.replacementRules())
+	}
+}
+
+// Tests that replacements are checked against the tip bump and the rule of the
+// transactions with conditionals.
+func TestReplacementRules(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	rules := (&Config{PriceBump: 10, TipBump: 50, ConditionalPriceBump: 100}).replacementRules()
+
+	old := dynamicFeeTx(0, 100000, big.NewInt(100), big.NewInt(100), key)
+	if rules.replaceable(old, dynamicFeeTx(0, 100000, big.NewInt(110), big.NewInt(149), key)) {
+		t.Fatal("replacement below the tip bump accepted")
+	}
+	if !rules.replaceable(old, dynamicFeeTx(0, 100000, big.NewInt(110), big.NewInt(150), key)) {
+		t.Fatal("replacement meeting the bumps refused")
+	}
+	// The conditional tip bump falls back to the conditional price bump
+	if rules.conditional != (txpool.ReplacementRule{FeeCapBump: 100, TipBump: 100}) {
+		t.Fatalf("wrong conditional rule: %+v", rules.conditional)
+	}
+	cond := dynamicFeeTx(0, 100000, big.NewInt(100), big.NewInt(100), key)
+	cond.SetConditional(&types.TransactionConditional{})
+	if rules.replaceable(cond, dynamicFeeTx(0, 100000, big.NewInt(150), big.NewInt(150), key)) {
+		t.Fatal("replacement of a conditional transaction below its bump accepted")
+	}
+	if !rules.replaceable(cond, dynamicFeeTx(0, 100000, big.NewInt(200), big.NewInt(200), key)) {
+		t.Fatal("replacement of a conditional transaction meeting its bump refused")
 	}
 }
 
@@ -82,7 +110,7 @@ func BenchmarkListAdd(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		list := newList(true)
 		for _, v := range rand.Perm(len(txs)) {
-			list.Add(txs[v], DefaultConfig.PriceBump)
+			list.Add(txs[v], DefaultConfig.replacementRules())
 			list.Filter(priceLimit, DefaultConfig.PriceBump)
 		}
 	}
@@ -102,7 +130,7 @@ func BenchmarkListCapOneTx(b *testing.B) {
 		list := newList(true)
 		// Insert the transactions in a random order
 		for _, v := range rand.Perm(len(txs)) {
-			list.Add(txs[v], DefaultConfig.PriceBump)
+			list.Add(txs[v], DefaultConfig.replacementRules())
 		}
 		b.StartTimer()
 		list.Cap(list.Len() - 1)
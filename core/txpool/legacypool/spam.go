@@ -0,0 +1,256 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/holiman/uint256"
+)
+
+const (
+	spamReplaceWeight = 1 // Score of a transaction replacing a pooled one
+	spamCancelWeight  = 1 // Score of a transaction cancelling a pooled one
+	spamRevertWeight  = 2 // Score of a pooled transaction included but failed
+
+	// spamMaxLevel is the maximum number of tip steps required from a sender.
+	spamMaxLevel = 16
+
+	// spamForgetScore is the score below which a sender is forgotten.
+	spamForgetScore = 0.1
+
+	// spamMaxRevertBlocks is the maximum number of blocks checked for failed
+	// transactions on a pool reset.
+	spamMaxRevertBlocks = 64
+)
+
+var (
+	spamSendersGauge = metrics.NewRegisteredGauge("txpool/spam/senders", nil) // Senders with a score
+	spamRaisedGauge  = metrics.NewRegisteredGauge("txpool/spam/raised", nil)  // Senders required a higher tip
+
+	spamReplaceMeter = metrics.NewRegisteredMeter("txpool/spam/replace", nil)
+	spamCancelMeter  = metrics.NewRegisteredMeter("txpool/spam/cancel", nil)
+	spamRevertMeter  = metrics.NewRegisteredMeter("txpool/spam/revert", nil)
+	spamRejectMeter  = metrics.NewRegisteredMeter("txpool/spam/rejected", nil) // Dropped due to the raised tip
+)
+
+// receiptChain is the optional chain capability needed to score the senders of
+// failed transactions.
+type receiptChain interface {
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
+
+// spamScore is the spam score of a sender, halving every configured decay period.
+type spamScore struct {
+	value   float64
+	updated time.Time
+}
+
+// at returns the score decayed until the given time.
+func (s *spamScore) at(now time.Time, halflife time.Duration) float64 {
+	return s.value * math.Exp2(-float64(now.Sub(s.updated))/float64(halflife))
+}
+
+// SpamScore is the spam score of a sender and the minimum tip required from it.
+type SpamScore struct {
+	Address common.Address
+	Score   float64
+	Level   uint64 // Number of tip steps required on top of the pool minimum
+	MinTip  *uint256.Int
+}
+
+// spamScoring reports whether the spam scoring of the senders is enabled.
+func (pool *LegacyPool) spamScoring() bool {
+	return pool.config.SpamThreshold != 0
+}
+
+// spamExempt reports whether the given sender is local, and thus never scored.
+func (pool *LegacyPool) spamExempt(addr common.Address) bool {
+	_, ok := pool.locals[addr]
+	return ok
+}
+
+// scoreSpam raises the spam score of the given sender by the given amount.
+// The caller must hold the pool lock.
+func (pool *LegacyPool) scoreSpam(addr common.Address, amount float64) {
+	if !pool.spamScoring() || amount == 0 || pool.spamExempt(addr) {
+		return
+	}
+	now := time.Now()
+	score := pool.spam[addr]
+	if score == nil {
+		score = new(spamScore)
+		pool.spam[addr] = score
+		spamSendersGauge.Update(int64(len(pool.spam)))
+	}
+	score.value = score.at(now, pool.config.SpamDecay) + amount
+	score.updated = now
+}
+
+// scoreReplace raises the spam score of a sender replacing a pooled transaction
+// with the given one. Only churn caused by the sender itself is scored: pool
+// pressure evictions, expirations and unfunded drops are not the sender's doing.
+// The caller must hold the pool lock.
+func (pool *LegacyPool) scoreReplace(addr common.Address, tx *types.Transaction) {
+	if !pool.spamScoring() || pool.spamExempt(addr) {
+		return
+	}
+	if isCancellation(addr, tx) {
+		pool.scoreSpam(addr, spamCancelWeight)
+		spamCancelMeter.Mark(1)
+		return
+	}
+	pool.scoreSpam(addr, spamReplaceWeight)
+	spamReplaceMeter.Mark(1)
+}
+
+// isCancellation reports whether the given replacement transaction cancels the
+// one it replaces, being an empty value transfer by the sender to itself.
+func isCancellation(from common.Address, tx *types.Transaction) bool {
+	to := tx.To()
+	return to != nil && *to == from && tx.Value().Sign() == 0 && len(tx.Data()) == 0
+}
+
+// spamLevel returns the number of tip steps required from the given sender.
+// The caller must hold the pool lock.
+func (pool *LegacyPool) spamLevel(addr common.Address, now time.Time) (float64, uint64) {
+	score := pool.spam[addr]
+	if score == nil {
+		return 0, 0
+	}
+	value := score.at(now, pool.config.SpamDecay)
+	return value, min(uint64(value)/pool.config.SpamThreshold, spamMaxLevel)
+}
+
+// spamMinTip returns the minimum tip required from a sender at the given level.
+func (pool *LegacyPool) spamMinTip(level uint64) *uint256.Int {
+	tip := new(uint256.Int).Mul(uint256.NewInt(pool.config.SpamTipStep), uint256.NewInt(level))
+	return tip.Add(tip, pool.gasTip.Load())
+}
+
+// checkSpamTip checks whether the supplied tx pays the minimum tip raised for
+// its sender by its spam score. The caller must hold the pool lock.
+func (pool *LegacyPool) checkSpamTip(from common.Address, tx *types.Transaction) error {
+	if !pool.spamScoring() || pool.spamExempt(from) {
+		return nil
+	}
+	score, level := pool.spamLevel(from, time.Now())
+	if level == 0 {
+		return nil
+	}
+	if minTip := pool.spamMinTip(level); tx.GasTipCapIntCmp(minTip.ToBig()) < 0 {
+		return fmt.Errorf("%w: sender spam score %.1f requires tip %v, have %v", txpool.ErrUnderpriced, score, minTip, tx.GasTipCap())
+	}
+	return nil
+}
+
+// scoreReverts raises the spam scores of the senders of the pooled transactions
+// which failed in the blocks added on top of the old head, up to the new head.
+// The caller must hold the pool lock.
+func (pool *LegacyPool) scoreReverts(oldHead, newHead *types.Header) {
+	chain, ok := pool.chain.(receiptChain)
+	if !ok {
+		return
+	}
+	var (
+		number = newHead.Number.Uint64()
+		hash   = newHead.Hash()
+	)
+	for i := 0; i < spamMaxRevertBlocks && number > oldHead.Number.Uint64(); i++ {
+		block := pool.chain.GetBlock(hash, number)
+		if block == nil {
+			return
+		}
+		receipts := chain.GetReceiptsByHash(hash)
+		if len(receipts) != len(block.Transactions()) {
+			return
+		}
+		for j, tx := range block.Transactions() {
+			if receipts[j].Status != types.ReceiptStatusFailed || pool.all.Get(tx.Hash()) == nil {
+				continue
+			}
+			from, _ := types.Sender(pool.signer, tx) // already validated
+			pool.scoreSpam(from, spamRevertWeight)
+			spamRevertMeter.Mark(1)
+		}
+		hash, number = block.ParentHash(), number-1
+	}
+}
+
+// pruneSpamScores forgets the senders whose score decayed away, and reports the
+// number of senders with a score. The caller must hold the pool lock.
+func (pool *LegacyPool) pruneSpamScores() {
+	var (
+		now    = time.Now()
+		raised int
+	)
+	for addr, score := range pool.spam {
+		value := score.at(now, pool.config.SpamDecay)
+		if value < spamForgetScore {
+			delete(pool.spam, addr)
+			continue
+		}
+		if uint64(value) >= pool.config.SpamThreshold {
+			raised++
+		}
+	}
+	spamSendersGauge.Update(int64(len(pool.spam)))
+	spamRaisedGauge.Update(int64(raised))
+}
+
+// SpamScores returns the senders with a spam score, ordered by decreasing score.
+func (pool *LegacyPool) SpamScores() []SpamScore {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	now := time.Now()
+	scores := make([]SpamScore, 0, len(pool.spam))
+	for addr := range pool.spam {
+		value, level := pool.spamLevel(addr, now)
+		scores = append(scores, SpamScore{
+			Address: addr,
+			Score:   value,
+			Level:   level,
+			MinTip:  pool.spamMinTip(level),
+		})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	return scores
+}
+
+// SpamScoreOf returns the spam score of the given sender.
+func (pool *LegacyPool) SpamScoreOf(addr common.Address) SpamScore {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	value, level := pool.spamLevel(addr, time.Now())
+	return SpamScore{
+		Address: addr,
+		Score:   value,
+		Level:   level,
+		MinTip:  pool.spamMinTip(level),
+	}
+}
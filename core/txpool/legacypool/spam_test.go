@@ -0,0 +1,197 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// receiptTestChain is a test chain serving a single block along with its receipts.
+type receiptTestChain struct {
+	*testBlockChain
+	block    *types.Block
+	receipts types.Receipts
+}
+
+func (bc *receiptTestChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	if hash == bc.block.Hash() {
+		return bc.block
+	}
+	return nil
+}
+
+func (bc *receiptTestChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return bc.receipts
+}
+
+// Tests that replacing transactions raises the minimum tip required from the
+// sender, until the score decays away.
+func TestSpamTip(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	pool.config.SpamThreshold, pool.config.SpamTipStep, pool.config.SpamDecay = 2, params.GWei, time.Hour
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, addr, big.NewInt(params.Ether))
+
+	for i, price := range []int64{1, 2, 3, 4} {
+		if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(price*params.GWei), key)); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	score := pool.SpamScoreOf(addr)
+	if score.Level != 1 || score.MinTip.Uint64() != params.GWei+1 {
+		t.Fatalf("wrong spam score: level %d, min tip %v", score.Level, score.MinTip)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(1, 100000, big.NewInt(params.GWei), key)); !errors.Is(err, txpool.ErrUnderpriced) {
+		t.Fatalf("wrong error for transaction below raised tip: have %v, want %v", err, txpool.ErrUnderpriced)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(1, 100000, big.NewInt(2*params.GWei), key)); err != nil {
+		t.Fatalf("failed to add transaction above raised tip: %v", err)
+	}
+	// Other senders are unaffected
+	other, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(other.PublicKey), big.NewInt(params.Ether))
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1), other)); err != nil {
+		t.Fatalf("failed to add transaction of other sender: %v", err)
+	}
+	if scores := pool.SpamScores(); len(scores) != 1 || scores[0].Address != addr {
+		t.Fatalf("wrong spam scores: %+v", scores)
+	}
+	// Once the score halved, the sender pays the pool minimum again
+	pool.mu.Lock()
+	pool.spam[addr].updated = pool.spam[addr].updated.Add(-time.Hour)
+	pool.mu.Unlock()
+
+	if err := pool.addRemoteSync(pricedTransaction(2, 100000, big.NewInt(1), key)); err != nil {
+		t.Fatalf("failed to add transaction after decay: %v", err)
+	}
+	pool.mu.Lock()
+	pool.spam[addr].updated = pool.spam[addr].updated.Add(-10 * time.Hour)
+	pool.pruneSpamScores()
+	pool.mu.Unlock()
+
+	if scores := pool.SpamScores(); len(scores) != 0 {
+		t.Fatalf("decayed spam scores not pruned: %+v", scores)
+	}
+}
+
+// Tests that the senders of pooled transactions failing on chain are scored.
+func TestSpamReverts(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	pool.config.SpamThreshold, pool.config.SpamTipStep, pool.config.SpamDecay = 1, params.GWei, time.Hour
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, addr, big.NewInt(params.Ether))
+
+	var (
+		pooled   = pricedTransaction(0, 100000, big.NewInt(1), key)
+		unpooled = pricedTransaction(1, 100000, big.NewInt(1), key)
+	)
+	if err := pool.addRemoteSync(pooled); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	head := &types.Header{Number: big.NewInt(1), Difficulty: common.Big0}
+	block := types.NewBlock(head, &types.Body{Transactions: types.Transactions{pooled, unpooled}}, nil, trie.NewStackTrie(nil), params.TestChainConfig)
+	chain := &receiptTestChain{
+		testBlockChain: pool.chain.(*testBlockChain),
+		block:          block,
+		receipts: types.Receipts{
+			{Status: types.ReceiptStatusFailed},
+			{Status: types.ReceiptStatusFailed},
+		},
+	}
+	pool.mu.Lock()
+	pool.chain = chain
+	pool.scoreReverts(&types.Header{Number: common.Big0}, block.Header())
+	pool.mu.Unlock()
+
+	if score := pool.SpamScoreOf(addr); score.Score > spamRevertWeight || score.Score < spamRevertWeight-0.01 || score.Level != 1 {
+		t.Fatalf("wrong spam score: score %v, level %d", score.Score, score.Level)
+	}
+}
+
+// Tests that only the churn caused by a remote sender itself is scored: pool
+// pressure evictions are not, cancellations are, and local senders never are.
+func TestSpamChurn(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	pool.config.SpamThreshold, pool.config.SpamTipStep, pool.config.SpamDecay = 1, params.GWei, time.Hour
+	pool.config.GlobalSlots, pool.config.GlobalQueue = 1, 0
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, addr, big.NewInt(params.Ether))
+
+	// Evicting the sender's transaction for a better paying one isn't scored
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1), key)); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	other, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(other.PublicKey), big.NewInt(params.Ether))
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(2), other)); err != nil {
+		t.Fatalf("failed to add better paying transaction: %v", err)
+	}
+	if pool.all.Count() != 1 {
+		t.Fatalf("underpriced transaction not evicted")
+	}
+	if score := pool.SpamScoreOf(addr); score.Score != 0 {
+		t.Fatalf("evicted sender scored: %v", score.Score)
+	}
+	// Cancelling a pooled transaction is scored
+	pool.config.GlobalSlots = 4
+
+	cancel, _ := types.SignTx(types.NewTransaction(0, crypto.PubkeyToAddress(other.PublicKey), new(big.Int), 21000, big.NewInt(3), nil), types.HomesteadSigner{}, other)
+	if err := pool.addRemoteSync(cancel); err != nil {
+		t.Fatalf("failed to add cancellation: %v", err)
+	}
+	if score := pool.SpamScoreOf(crypto.PubkeyToAddress(other.PublicKey)); score.Score < spamCancelWeight-0.01 {
+		t.Fatalf("cancelling sender not scored: %v", score.Score)
+	}
+	// Local senders are never scored nor required a raised tip
+	pool.mu.Lock()
+	pool.locals[addr] = struct{}{}
+	pool.mu.Unlock()
+
+	for i, price := range []int64{1, 2, 3} {
+		if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(price), key)); err != nil {
+			t.Fatalf("failed to add local transaction %d: %v", i, err)
+		}
+	}
+	if score := pool.SpamScoreOf(addr); score.Score != 0 {
+		t.Fatalf("local sender scored: %v", score.Score)
+	}
+}
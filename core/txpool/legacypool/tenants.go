@@ -0,0 +1,170 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// tenantUsage is the amount of pool resources used by the transactions of a
+// single tenant, i.e. an authenticated RPC client.
+type tenantUsage struct {
+	slots int
+	gas   uint64
+
+	slotsGauge *metrics.Gauge
+	gasGauge   *metrics.Gauge
+}
+
+// tenantMetric returns the name of a per-tenant metric.
+func tenantMetric(tenant, name string) string {
+	return "txpool/tenant/" + tenant + "/" + name
+}
+
+// unregisterTenant drops the gauges of a tenant without pooled transactions, so
+// the metrics don't grow with every tenant ever seen.
+func unregisterTenant(tenant string) {
+	metrics.Unregister(tenantMetric(tenant, "slots"))
+	metrics.Unregister(tenantMetric(tenant, "gas"))
+}
+
+// addTenant accounts the resources of the supplied tx to its tenant, if any.
+// The caller must hold the lookup lock.
+func (t *lookup) addTenant(tx *types.Transaction) {
+	tenant := tx.Tenant()
+	if tenant == "" {
+		return
+	}
+	usage := t.tenants[tenant]
+	if usage == nil {
+		usage = &tenantUsage{
+			slotsGauge: metrics.GetOrRegisterGauge(tenantMetric(tenant, "slots"), nil),
+			gasGauge:   metrics.GetOrRegisterGauge(tenantMetric(tenant, "gas"), nil),
+		}
+		t.tenants[tenant] = usage
+	}
+	usage.slots += numSlots(tx)
+	usage.gas += tx.Gas()
+
+	usage.slotsGauge.Update(int64(usage.slots))
+	usage.gasGauge.Update(int64(usage.gas))
+}
+
+// removeTenant releases the resources of the supplied tx from its tenant, if
+// any. The caller must hold the lookup lock.
+func (t *lookup) removeTenant(tx *types.Transaction) {
+	tenant := tx.Tenant()
+	if tenant == "" {
+		return
+	}
+	usage := t.tenants[tenant]
+	if usage == nil {
+		return
+	}
+	usage.slots -= numSlots(tx)
+	usage.gas -= tx.Gas()
+
+	usage.slotsGauge.Update(int64(usage.slots))
+	usage.gasGauge.Update(int64(usage.gas))
+
+	if usage.slots <= 0 {
+		delete(t.tenants, tenant)
+		unregisterTenant(tenant)
+	}
+}
+
+// TenantUsage returns the number of slots and the total gas limit used by the
+// transactions of the given tenant.
+func (t *lookup) TenantUsage(tenant string) (int, uint64) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if usage := t.tenants[tenant]; usage != nil {
+		return usage.slots, usage.gas
+	}
+	return 0, 0
+}
+
+// Tenants returns the number of tenants with transactions in the lookup.
+func (t *lookup) Tenants() int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return len(t.tenants)
+}
+
+// tenantQuotas reports whether the per-tenant quotas are enabled.
+func (pool *LegacyPool) tenantQuotas() bool {
+	return pool.config.TenantSlots != 0 || pool.config.TenantGas != 0
+}
+
+// checkTenantQuota checks whether adding the supplied tx would make its tenant
+// exceed the configured slot or gas quota. The resources of a transaction of
+// the same tenant being replaced are available to its replacement.
+func (pool *LegacyPool) checkTenantQuota(from common.Address, tx *types.Transaction) error {
+	tenant := tx.Tenant()
+	if tenant == "" || !pool.tenantQuotas() {
+		return nil
+	}
+	slots, gas := pool.all.TenantUsage(tenant)
+	if old := pool.pooledTx(from, tx.Nonce()); old != nil && old.Tenant() == tenant {
+		slots -= numSlots(old)
+		gas -= old.Gas()
+	}
+	if limit := pool.config.TenantSlots; limit != 0 && uint64(slots+numSlots(tx)) > limit {
+		return fmt.Errorf("%w: tenant %s uses %d of %d slots", ErrTenantQuota, tenant, slots, limit)
+	}
+	if limit := pool.config.TenantGas; limit != 0 && gas+tx.Gas() > limit {
+		return fmt.Errorf("%w: tenant %s uses %d of %d gas", ErrTenantQuota, tenant, gas, limit)
+	}
+	return nil
+}
+
+// exceedsTenantShare reports whether adding the supplied tx to a full pool would
+// make its tenant use more than its fair share of the pool, i.e. the capacity of
+// the pool evenly divided between the tenants with pooled transactions.
+func (pool *LegacyPool) exceedsTenantShare(tx *types.Transaction) bool {
+	tenant := tx.Tenant()
+	if tenant == "" || !pool.tenantQuotas() {
+		return false
+	}
+	slots, _ := pool.all.TenantUsage(tenant)
+	tenants := pool.all.Tenants()
+	if slots == 0 {
+		tenants++
+	}
+	share := (pool.config.GlobalSlots + pool.config.GlobalQueue) / uint64(tenants)
+	return uint64(slots+numSlots(tx)) > share
+}
+
+// pooledTx returns the pending or queued transaction of the given account with
+// the given nonce, or nil if there is none.
+func (pool *LegacyPool) pooledTx(from common.Address, nonce uint64) *types.Transaction {
+	if list := pool.pending[from]; list != nil {
+		if tx := list.txs.Get(nonce); tx != nil {
+			return tx
+		}
+	}
+	if list := pool.queue[from]; list != nil {
+		return list.txs.Get(nonce)
+	}
+	return nil
+}
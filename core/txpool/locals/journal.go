@@ -17,6 +17,7 @@
 package locals
 
 import (
+	"context"
 	"errors"
 	"io"
 	"io/fs"
@@ -184,3 +185,22 @@ func (journal *journal) close() error {
 	}
 	return err
 }
+
+// flushJournal requests a journal rotation from the loop of a journaling
+// service, returning its result. Nothing is done if the service is stopped.
+func flushJournal(ctx context.Context, flushCh chan chan error, shutdownCh chan struct{}) error {
+	ch := make(chan error, 1)
+	select {
+	case flushCh <- ch:
+	case <-shutdownCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-ch:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -17,6 +17,7 @@
 package locals
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -35,6 +36,7 @@ type PoolJournaler struct {
 	rejournal time.Duration  // How often to rotate journal
 	pool      *txpool.TxPool // The tx pool to interact with
 
+	flushCh    chan chan error
 	shutdownCh chan struct{}
 	wg         sync.WaitGroup
 }
@@ -44,6 +46,7 @@ func NewPoolJournaler(journalPath string, journalTime time.Duration, pool *txpoo
 		journal:    newTxJournal(journalPath),
 		rejournal:  journalTime,
 		pool:       pool,
+		flushCh:    make(chan chan error),
 		shutdownCh: make(chan struct{}),
 	}
 }
@@ -66,6 +69,12 @@ func (pj *PoolJournaler) Stop() error {
 	return nil
 }
 
+// Flush journals the transaction pool, returning once it's written or the
+// context is canceled.
+func (pj *PoolJournaler) Flush(ctx context.Context) error {
+	return flushJournal(ctx, pj.flushCh, pj.shutdownCh)
+}
+
 func (pj *PoolJournaler) loop() {
 	defer log.Info("PoolJournaler: Stopped")
 	defer pj.wg.Done()
@@ -86,14 +95,15 @@ func (pj *PoolJournaler) loop() {
 	ticker := time.NewTicker(pj.rejournal)
 	defer ticker.Stop()
 
-	journal := func() {
+	journal := func() error {
 		start := time.Now()
 		tojournal := pj.pool.ToJournal()
 		if err := pj.journal.rotate(tojournal); err != nil {
 			log.Error("PoolJournaler: Transaction journal rotation failed", "err", err)
-		} else {
-			log.Debug("PoolJournaler: Transaction journal rotated", "count", len(tojournal), "duration", time.Since(start))
+			return err
 		}
+		log.Debug("PoolJournaler: Transaction journal rotated", "count", len(tojournal), "duration", time.Since(start))
+		return nil
 	}
 
 	for {
@@ -101,6 +111,8 @@ func (pj *PoolJournaler) loop() {
 		case <-pj.shutdownCh:
 			journal()
 			return
+		case ch := <-pj.flushCh:
+			ch <- journal()
 		case <-ticker.C:
 			journal()
 		}
@@ -18,6 +18,7 @@
 package locals
 
 import (
+	"context"
 	"slices"
 	"sync"
 	"time"
@@ -34,6 +35,12 @@ import (
 var (
 	recheckInterval = time.Minute
 	localGauge      = metrics.GetOrRegisterGauge("txpool/local", nil)
+
+	// localOldestGauge is the time the oldest tracked transaction has been
+	// pending for, in seconds, and localPendingTimer the time the transactions
+	// were pending for until their inclusion.
+	localOldestGauge  = metrics.GetOrRegisterGauge("txpool/local/oldest", nil)
+	localPendingTimer = metrics.GetOrRegisterTimer("txpool/local/pending", nil)
 )
 
 // TxTracker is a struct used to track priority transactions; it will check from
@@ -45,12 +52,14 @@ var (
 type TxTracker struct {
 	all    map[common.Hash]*types.Transaction       // All tracked transactions
 	byAddr map[common.Address]*legacypool.SortedMap // Transactions by address
+	since  map[common.Hash]time.Time                // Time the transactions were first tracked
 
 	journal   *journal       // Journal of local transaction to back up to disk
 	rejournal time.Duration  // How often to rotate journal
 	pool      *txpool.TxPool // The tx pool to interact with
 	signer    types.Signer
 
+	flushCh    chan chan error
 	shutdownCh chan struct{}
 	mu         sync.Mutex
 	wg         sync.WaitGroup
@@ -61,7 +70,9 @@ func New(journalPath string, journalTime time.Duration, chainConfig *params.Chai
 	pool := &TxTracker{
 		all:        make(map[common.Hash]*types.Transaction),
 		byAddr:     make(map[common.Address]*legacypool.SortedMap),
+		since:      make(map[common.Hash]time.Time),
 		signer:     types.LatestSigner(chainConfig),
+		flushCh:    make(chan chan error),
 		shutdownCh: make(chan struct{}),
 		pool:       next,
 	}
@@ -101,6 +112,7 @@ func (tracker *TxTracker) TrackAll(txs []*types.Transaction) {
 			continue
 		}
 		tracker.all[tx.Hash()] = tx
+		tracker.since[tx.Hash()] = time.Now()
 		if tracker.byAddr[addr] == nil {
 			tracker.byAddr[addr] = legacypool.NewSortedMap()
 		}
@@ -113,6 +125,55 @@ func (tracker *TxTracker) TrackAll(txs []*types.Transaction) {
 	localGauge.Update(int64(len(tracker.all)))
 }
 
+// Tracked returns the tracked transactions which are in the pool, of the given
+// account or of all accounts if nil, along with the time they were first
+// tracked at.
+func (tracker *TxTracker) Tracked(addr *common.Address) (types.Transactions, []time.Time) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	var (
+		txs   types.Transactions
+		times []time.Time
+	)
+	for sender, list := range tracker.byAddr {
+		if addr != nil && sender != *addr {
+			continue
+		}
+		for _, tx := range list.Flatten() {
+			if tracker.pool.Has(tx.Hash()) {
+				txs = append(txs, tx)
+				times = append(times, tracker.since[tx.Hash()])
+			}
+		}
+	}
+	return txs, times
+}
+
+// Untrack stops tracking a transaction, so that it's not resubmitted to the
+// pool anymore. It's dropped from the journal on its next rotation. The method
+// reports whether the transaction was tracked.
+func (tracker *TxTracker) Untrack(hash common.Hash) bool {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	tx := tracker.all[hash]
+	if tx == nil {
+		return false
+	}
+	addr, _ := types.Sender(tracker.signer, tx) // already recovered when tracked
+	if list := tracker.byAddr[addr]; list != nil {
+		list.Remove(tx.Nonce())
+		if list.Len() == 0 {
+			delete(tracker.byAddr, addr)
+		}
+	}
+	delete(tracker.all, hash)
+	delete(tracker.since, hash)
+	localGauge.Update(int64(len(tracker.all)))
+	return true
+}
+
 // recheck checks and returns any transactions that needs to be resubmitted.
 func (tracker *TxTracker) recheck(journalCheck bool) (resubmits []*types.Transaction, rejournal map[common.Address]types.Transactions) {
 	tracker.mu.Lock()
@@ -127,6 +188,8 @@ func (tracker *TxTracker) recheck(journalCheck bool) (resubmits []*types.Transac
 		stales := txs.Forward(tracker.pool.Nonce(sender))
 		for _, tx := range stales {
 			delete(tracker.all, tx.Hash())
+			localPendingTimer.UpdateSince(tracker.since[tx.Hash()])
+			delete(tracker.since, tx.Hash())
 		}
 		numStales += len(stales)
 
@@ -154,7 +217,12 @@ func (tracker *TxTracker) recheck(journalCheck bool) (resubmits []*types.Transac
 			})
 		}
 	}
+	var oldest time.Duration
+	for _, since := range tracker.since {
+		oldest = max(oldest, time.Since(since))
+	}
 	localGauge.Update(int64(len(tracker.all)))
+	localOldestGauge.Update(int64(oldest / time.Second))
 	log.Debug("Tx tracker status", "need-resubmit", len(resubmits), "stale", numStales, "ok", numOk)
 	return resubmits, rejournal
 }
@@ -177,6 +245,15 @@ func (tracker *TxTracker) Stop() error {
 	return nil
 }
 
+// Flush rotates the journal with the tracked transactions, if journaling is
+// enabled, returning once it's written or the context is canceled.
+func (tracker *TxTracker) Flush(ctx context.Context) error {
+	if tracker.journal == nil {
+		return nil
+	}
+	return flushJournal(ctx, tracker.flushCh, tracker.shutdownCh)
+}
+
 func (tracker *TxTracker) loop() {
 	defer tracker.wg.Done()
 
@@ -191,10 +268,21 @@ func (tracker *TxTracker) loop() {
 		lastJournal = time.Now()
 		timer       = time.NewTimer(10 * time.Second) // Do initial check after 10 seconds, do rechecks more seldom.
 	)
+	rotate := func(rejournal map[common.Address]types.Transactions) error {
+		// Lock to prevent journal.rotate <-> journal.insert (via TrackAll) conflicts
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+
+		lastJournal = time.Now()
+		return tracker.journal.rotate(rejournal)
+	}
 	for {
 		select {
 		case <-tracker.shutdownCh:
 			return
+		case ch := <-tracker.flushCh:
+			_, rejournal := tracker.recheck(true)
+			ch <- rotate(rejournal)
 		case <-timer.C:
 			checkJournal := tracker.journal != nil && time.Since(lastJournal) > tracker.rejournal
 			resubmits, rejournal := tracker.recheck(checkJournal)
@@ -202,13 +290,9 @@ func (tracker *TxTracker) loop() {
 				tracker.pool.Add(resubmits, false)
 			}
 			if checkJournal {
-				// Lock to prevent journal.rotate <-> journal.insert (via TrackAll) conflicts
-				tracker.mu.Lock()
-				lastJournal = time.Now()
-				if err := tracker.journal.rotate(rejournal); err != nil {
+				if err := rotate(rejournal); err != nil {
 					log.Warn("Transaction journal rotation failed", "err", err)
 				}
-				tracker.mu.Unlock()
 			}
 			timer.Reset(recheckInterval)
 		}
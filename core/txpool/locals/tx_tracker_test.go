@@ -164,3 +164,43 @@ func TestResubmit(t *testing.T) {
 		t.Fatalf("Unexpected transactions being tracked, got: %d, want: %d", len(all[address]), len(txs))
 	}
 }
+
+func TestUntrackEvict(t *testing.T) {
+	env := newTestEnv(t, 10, 0, "")
+	defer env.close()
+
+	txs := env.makeTxs(3)
+	env.pool.Add(txs[:2], true)
+	env.tracker.TrackAll(txs)
+
+	// Only the tracked transactions in the pool are returned
+	tracked, since := env.tracker.Tracked(nil)
+	if len(tracked) != 2 || len(since) != 2 || since[0].IsZero() {
+		t.Fatalf("unexpected tracked transactions: %d, times %v", len(tracked), since)
+	}
+	other := common.Address{0x01}
+	if tracked, _ := env.tracker.Tracked(&other); len(tracked) != 0 {
+		t.Fatalf("unexpected tracked transactions of other account: %d", len(tracked))
+	}
+	// Evicting a transaction demotes the next ones, untracking it prevents its
+	// resubmission
+	if !env.tracker.Untrack(txs[0].Hash()) {
+		t.Fatal("tracked transaction not untracked")
+	}
+	if env.tracker.Untrack(txs[0].Hash()) {
+		t.Fatal("untracked transaction untracked again")
+	}
+	if !env.pool.Evict(txs[0].Hash()) {
+		t.Fatal("pooled transaction not evicted")
+	}
+	if env.pool.Evict(txs[0].Hash()) {
+		t.Fatal("evicted transaction evicted again")
+	}
+	if pending, queued := env.pool.ContentFrom(address); len(pending) != 0 || len(queued) != 1 {
+		t.Fatalf("unexpected txpool content: %d, %d", len(pending), len(queued))
+	}
+	resubmit, _ := env.tracker.recheck(false)
+	if len(resubmit) != 1 || resubmit[0].Hash() != txs[2].Hash() {
+		t.Fatalf("unexpected transactions to resubmit: %d", len(resubmit))
+	}
+}
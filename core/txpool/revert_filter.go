@@ -0,0 +1,148 @@
+package txpool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Modes of the may-revert pre-check of incoming transactions.
+const (
+	RevertCheckTag    = "tag"    // Admit transactions that would fail, marking them as such
+	RevertCheckReject = "reject" // Reject transactions that would fail
+)
+
+var (
+	revertCheckSimulatedMeter = metrics.NewRegisteredMeter("txpool/revertcheck/simulated", nil)
+	revertCheckFailedMeter    = metrics.NewRegisteredMeter("txpool/revertcheck/failed", nil)
+	revertCheckSkippedMeter   = metrics.NewRegisteredMeter("txpool/revertcheck/skipped", nil)
+)
+
+// RevertFilterChain defines the minimal set of chain methods needed to simulate
+// incoming transactions.
+type RevertFilterChain interface {
+	core.ChainContext
+	BlockChain
+}
+
+type revertFilter struct {
+	chain  RevertFilterChain
+	reject bool
+	budget uint64 // Gas simulated per second
+
+	lock      sync.Mutex
+	available uint64         // Gas left to simulate
+	refilled  time.Time      // Time the budget was last refilled
+	head      common.Hash    // Head block the cached state belongs to
+	state     *state.StateDB // Cached state of the head block
+}
+
+// NewRevertFilter creates a new IngressFilter that executes incoming
+// transactions against the state of the chain head and detects the ones which
+// would revert or run out of gas. Depending on reject, such transactions are
+// rejected, or admitted and tagged as possibly reverting. At most budget gas worth of
+// transactions is simulated per second, the ones above it are admitted
+// unchecked.
+//
+// Only transactions executable right away, i.e. with the next nonce of their
+// sender, are simulated; the outcome of the others depends on the transactions
+// preceding them.
+func NewRevertFilter(chain RevertFilterChain, reject bool, budget uint64) IngressFilter {
+	return &revertFilter{
+		chain:     chain,
+		reject:    reject,
+		budget:    budget,
+		available: budget,
+		refilled:  time.Now(),
+	}
+}
+
+// FilterTx implements IngressFilter.FilterTx
+// it admits the transaction unless it would fail to execute and the filter
+// rejects such transactions. Failing transactions admitted in tag mode are
+// marked, see types.Transaction.MayRevert.
+func (f *revertFilter) FilterTx(ctx context.Context, tx *types.Transaction) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	header, statedb := f.prepare(tx)
+	if statedb == nil {
+		return true
+	}
+	config := f.chain.Config()
+	msg, err := core.TransactionToMessage(tx, types.LatestSigner(config), header.BaseFee)
+	if err != nil {
+		return true
+	}
+	if statedb.GetNonce(msg.From) != tx.Nonce() {
+		revertCheckSkippedMeter.Mark(1)
+		return true
+	}
+	revertCheckSimulatedMeter.Mark(1)
+
+	evm := vm.NewEVM(core.NewEVMBlockContext(header, f.chain, nil, config, statedb), statedb, config, vm.Config{})
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.GasLimit))
+	if err != nil {
+		// The transaction is invalid rather than failing, leave it to the
+		// validation of the pool.
+		return true
+	}
+	if !result.Failed() {
+		return true
+	}
+	revertCheckFailedMeter.Mark(1)
+	log.Debug("Incoming transaction would fail", "hash", tx.Hash(), "from", msg.From, "err", result.Err, "reject", f.reject)
+	if f.reject {
+		return false
+	}
+	tx.SetMayRevert()
+	return true
+}
+
+// prepare takes the gas of the given transaction from the simulation budget and
+// returns the chain head along with a private copy of its state to simulate the
+// transaction on, or a nil state if the transaction is not to be simulated. The
+// lock is only held here, not across the execution.
+func (f *revertFilter) prepare(tx *types.Transaction) (*types.Header, *state.StateDB) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if !f.reserve(tx.Gas()) {
+		revertCheckSkippedMeter.Mark(1)
+		return nil, nil
+	}
+	header := f.chain.CurrentBlock()
+	if f.state == nil || f.head != header.Hash() {
+		statedb, err := f.chain.StateAt(header.Root)
+		if err != nil {
+			log.Debug("Failed to retrieve state for transaction pre-check", "root", header.Root, "err", err)
+			return nil, nil
+		}
+		f.head, f.state = header.Hash(), statedb
+	}
+	return header, f.state.Copy()
+}
+
+// reserve refills the simulation budget for the time passed since the last
+// refill, and takes the given amount of gas from it if available.
+func (f *revertFilter) reserve(gas uint64) bool {
+	now := time.Now()
+	if elapsed := now.Sub(f.refilled); elapsed >= time.Second {
+		f.available, f.refilled = f.budget, now
+	} else if refill := uint64(elapsed.Seconds() * float64(f.budget)); refill > 0 {
+		f.available, f.refilled = min(f.budget, f.available+refill), now
+	}
+	if gas > f.available {
+		return false
+	}
+	f.available -= gas
+	return true
+}
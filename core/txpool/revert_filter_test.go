@@ -0,0 +1,93 @@
+package txpool
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevertFilter(t *testing.T) {
+	var (
+		key, _   = crypto.GenerateKey()
+		sender   = crypto.PubkeyToAddress(key.PublicKey)
+		reverter = common.HexToAddress("0xdead")
+		gspec    = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender:   {Balance: big.NewInt(params.Ether)},
+				reverter: {Code: []byte{byte(vm.PUSH0), byte(vm.PUSH0), byte(vm.REVERT)}},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	require.NoError(t, err)
+	defer chain.Stop()
+
+	makeTx := func(nonce uint64, to common.Address, gas uint64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     nonce,
+			To:        &to,
+			Gas:       gas,
+			GasFeeCap: big.NewInt(params.GWei),
+		})
+	}
+	var (
+		transfer = makeTx(0, common.Address{0x01}, params.TxGas)
+		revert   = makeTx(0, reverter, 50000)
+		oog      = makeTx(0, reverter, params.TxGas+1)
+		future   = makeTx(1, reverter, 50000)
+	)
+	ctx := context.Background()
+
+	t.Run("Tag mode admits and tags failing transactions", func(t *testing.T) {
+		filter := NewRevertFilter(chain, false, 1_000_000)
+		require.True(t, filter.FilterTx(ctx, transfer))
+		require.False(t, transfer.MayRevert())
+
+		tagged := makeTx(0, reverter, 50000)
+		require.True(t, filter.FilterTx(ctx, tagged))
+		require.True(t, tagged.MayRevert())
+	})
+	t.Run("Reject mode rejects failing transactions", func(t *testing.T) {
+		filter := NewRevertFilter(chain, true, 1_000_000)
+		require.True(t, filter.FilterTx(ctx, transfer))
+		require.False(t, filter.FilterTx(ctx, revert))
+		require.False(t, filter.FilterTx(ctx, oog))
+	})
+	t.Run("Transactions with future nonces are not simulated", func(t *testing.T) {
+		filter := NewRevertFilter(chain, true, 1_000_000)
+		require.True(t, filter.FilterTx(ctx, future))
+	})
+	t.Run("Transactions above the budget are not simulated", func(t *testing.T) {
+		filter := NewRevertFilter(chain, true, 60000)
+		require.False(t, filter.FilterTx(ctx, revert))
+		require.True(t, filter.FilterTx(ctx, revert))
+	})
+	t.Run("Concurrent simulations", func(t *testing.T) {
+		filter := NewRevertFilter(chain, true, 1_000_000)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if filter.FilterTx(ctx, revert) {
+					t.Error("failing transaction admitted")
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
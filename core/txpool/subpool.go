@@ -61,6 +61,14 @@ func (ltx *LazyTransaction) Resolve() *types.Transaction {
 	return ltx.Pool.Get(ltx.Hash)
 }
 
+// ReplacementRule is the minimum price bump, in percents, a transaction must
+// pay over the one it replaces, for each of the fees.
+type ReplacementRule struct {
+	FeeCapBump     uint64 // Bump of the gas fee cap
+	TipBump        uint64 // Bump of the gas tip cap
+	BlobFeeCapBump uint64 // Bump of the blob gas fee cap, zero if not applicable
+}
+
 // LazyResolver is a minimal interface needed for a transaction pool to satisfy
 // resolving lazy transactions. It's mostly a helper to avoid the entire sub-
 // pool being injected into the lazy transaction.
@@ -152,6 +160,10 @@ type SubPool interface {
 	// pool mutex.
 	ValidateTxBasics(tx *types.Transaction) error
 
+	// Validate checks whether a transaction would be accepted by the pool, running
+	// the static, stateful and pool specific checks of Add without admitting it.
+	Validate(tx *types.Transaction) error
+
 	// Add enqueues a batch of transactions into the pool if they are valid. Due
 	// to the large transaction churn, add may postpone fully integrating the tx
 	// to a later point to batch multiple ones together.
@@ -376,6 +376,17 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 	return errs
 }
 
+// Validate checks whether a transaction would be accepted by the pool, without
+// admitting it.
+func (p *TxPool) Validate(tx *types.Transaction) error {
+	for _, subpool := range p.subpools {
+		if subpool.Filter(tx) {
+			return subpool.Validate(tx)
+		}
+	}
+	return fmt.Errorf("%w: received type %d", core.ErrTxTypeNotSupported, tx.Type())
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 //
@@ -497,6 +508,35 @@ func (p *TxPool) ToJournal() map[common.Address]types.Transactions {
 	return nil
 }
 
+// ReplacementRules returns the replacement rules enforced by the subpools, by
+// kind of transaction.
+func (p *TxPool) ReplacementRules() map[string]ReplacementRule {
+	rules := make(map[string]ReplacementRule)
+	for _, subpool := range p.subpools {
+		if pool, ok := subpool.(interface {
+			ReplacementRules() map[string]ReplacementRule
+		}); ok {
+			for kind, rule := range pool.ReplacementRules() {
+				rules[kind] = rule
+			}
+		}
+	}
+	return rules
+}
+
+// Evict forcibly drops a transaction from the subpool holding it, reporting
+// whether it was found. Subpools not supporting the eviction are skipped.
+func (p *TxPool) Evict(hash common.Hash) bool {
+	for _, subpool := range p.subpools {
+		if pool, ok := subpool.(interface {
+			Evict(hash common.Hash) bool
+		}); ok && pool.Evict(hash) {
+			return true
+		}
+	}
+	return false
+}
+
 // Sync is a helper method for unit tests or simulator runs where the chain events
 // are arriving in quick succession, without any time in between them to run the
 // internal background reset operations. This method will run an explicit reset
@@ -80,10 +80,10 @@ type ValidationFunction func(tx *types.Transaction, head *types.Header, signer t
 // This check is public to allow different transaction pools to check the basic
 // rules without duplicating code and running the risk of missed updates.
 func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types.Signer, opts *ValidationOptions) error {
-	// No unauthenticated deposits allowed in the transaction pool.
-	// This is for spam protection, not consensus,
+	// No unauthenticated deposits, nor other system transactions, allowed in
+	// the transaction pool. This is for spam protection, not consensus,
 	// as the external engine-API user authenticates deposits.
-	if tx.Type() == types.DepositTxType {
+	if tx.SystemTxType() != nil {
 		return core.ErrTxTypeNotSupported
 	}
 	if opts.Config.IsOptimism() && tx.Type() == types.BlobTxType {
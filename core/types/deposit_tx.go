@@ -21,6 +21,8 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -117,3 +119,44 @@ func (tx *Transaction) From() common.Address {
 	}
 	return tx.inner.(interface{ from() common.Address }).from()
 }
+
+// depositTxType is the SystemTxType of the deposits.
+type depositTxType struct{}
+
+func (depositTxType) NewTxData() TxData { return new(DepositTx) }
+
+func (depositTxType) Sender(tx *Transaction) (common.Address, error) {
+	return tx.From(), nil
+}
+
+func (depositTxType) Validate(config *params.ChainConfig, time uint64, tx *Transaction) error {
+	return nil // checked by the state transition
+}
+
+func (depositTxType) ProcessReceipt(config *params.ChainConfig, time uint64, tx *Transaction, receipt *Receipt, nonce uint64) {
+	if !config.IsOptimismRegolith(time) {
+		return
+	}
+	// The actual nonce for deposit transactions is only recorded from Regolith onwards and
+	// otherwise must be nil.
+	receipt.DepositNonce = &nonce
+	// The DepositReceiptVersion for deposit transactions is only recorded from Canyon onwards
+	// and otherwise must be nil.
+	if config.IsOptimismCanyon(time) {
+		receipt.DepositReceiptVersion = new(uint64)
+		*receipt.DepositReceiptVersion = CanyonDepositReceiptVersion
+	}
+}
+
+func (depositTxType) RPCTxFields(tx *Transaction, receipt *Receipt, fields map[string]interface{}) {
+	// The deposit fields are part of the RPC transaction
+}
+
+func (depositTxType) RPCReceiptFields(tx *Transaction, receipt *Receipt, fields map[string]interface{}) {
+	if receipt.DepositNonce != nil {
+		fields["depositNonce"] = hexutil.Uint64(*receipt.DepositNonce)
+		if receipt.DepositReceiptVersion != nil {
+			fields["depositReceiptVersion"] = hexutil.Uint64(*receipt.DepositReceiptVersion)
+		}
+	}
+}
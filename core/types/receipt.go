@@ -94,6 +94,113 @@ type Receipt struct {
 	L1BlobBaseFeeScalar *uint64    `json:"l1BlobBaseFeeScalar,omitempty"` // Always nil prior to the Ecotone hardfork
 	OperatorFeeScalar   *uint64    `json:"operatorFeeScalar,omitempty"`   // Always nil prior to the Isthmus hardfork
 	OperatorFeeConstant *uint64    `json:"operatorFeeConstant,omitempty"` // Always nil prior to the Isthmus hardfork
+
+	// Fees is the breakdown of the fees paid by the transaction, set when the
+	// transaction is processed. It is stored separately from the receipt.
+	Fees *TxFees `json:"-"`
+}
+
+// TxFees is the breakdown of the fees paid by a transaction, as charged when
+// its block was processed.
+type TxFees struct {
+	GasRefund   uint64   // Gas refunded at the end of execution, already deducted from the gas used
+	Refund      *big.Int // Value of the refunded gas at the effective gas price
+	Tip         *big.Int // Priority fee paid to the fee recipient
+	BaseFee     *big.Int // Base fee burned, or paid to the base fee vault on OP chains
+	BlobFee     *big.Int // Blob fee burned
+	L1Fee       *big.Int // L1 data fee, zero outside of OP chains
+	OperatorFee *big.Int // Operator fee, zero outside of OP chains
+}
+
+// InternalTx is a value transfer, contract creation or self-destruct performed
+// by a transaction below its top level call, as traced when its block was
+// processed.
+type InternalTx struct {
+	TxIndex  uint32         // Position of the transaction within the block
+	Op       byte           // Opcode of the call frame, CALL, CALLCODE, CREATE, CREATE2 or SELFDESTRUCT
+	From     common.Address // Caller, or the destructed contract
+	To       common.Address // Callee, the created contract or the beneficiary
+	Value    *big.Int       // Value transferred
+	Depth    uint32         // Call depth of the frame, the top level call being 0
+	Reverted bool           // Whether the frame or one of its callers was reverted
+	Salt     *common.Hash   `rlp:"optional"` // Salt of a CREATE2, if traced
+}
+
+// BalanceChange is a change of the balance or the nonce of an account made
+// while processing a block, as traced when the block was processed. Changes
+// undone by reverted call frames are not recorded.
+type BalanceChange struct {
+	TxIndex uint32         // Position of the transaction within the block, math.MaxUint32 outside of transactions
+	Address common.Address // Account changed
+	Nonce   bool           // Whether the nonce changed rather than the balance
+	Prev    *big.Int       // Balance or nonce before the change
+	New     *big.Int       // Balance or nonce after the change
+	Reason  byte           // Reason of the change, a tracing.BalanceChangeReason or tracing.NonceChangeReason
+}
+
+// CodeChangeKind is the kind of a change of the code of an account.
+type CodeChangeKind byte
+
+const (
+	CodeDeployed    CodeChangeKind = iota // Code set on an account without code
+	CodeDestructed                        // Code removed by a self-destruct
+	CodeDelegated                         // EIP-7702 delegation set or replaced
+	CodeUndelegated                       // EIP-7702 delegation cleared
+	CodeReplaced                          // Code replaced otherwise, such as by a network upgrade
+)
+
+// String implements fmt.Stringer.
+func (k CodeChangeKind) String() string {
+	switch k {
+	case CodeDeployed:
+		return "deployment"
+	case CodeDestructed:
+		return "selfdestruct"
+	case CodeDelegated:
+		return "delegation"
+	case CodeUndelegated:
+		return "undelegation"
+	case CodeReplaced:
+		return "replacement"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(k))
+	}
+}
+
+// CodeChange is a change of the code of an account made while processing a
+// block, as traced when the block was processed. Changes undone by reverted
+// call frames are not recorded.
+type CodeChange struct {
+	TxIndex  uint32         // Position of the transaction within the block, math.MaxUint32 outside of transactions
+	Address  common.Address // Account changed
+	Kind     CodeChangeKind // Kind of the change
+	PrevHash common.Hash    // Hash of the code before the change
+	Hash     common.Hash    // Hash of the code after the change
+	Delegate common.Address // Target of an EIP-7702 delegation, zero for other changes
+}
+
+// Supply is the change of the native token supply made by a block, split by
+// source, along with the resulting total supply if known.
+type Supply struct {
+	Reward       *big.Int // Block and uncle rewards issued
+	Withdrawals  *big.Int // Beacon chain withdrawals credited
+	Deposits     *big.Int // Value minted by deposit transactions, zero outside of OP chains
+	Fees         *big.Int // Base fees burned, along with any other fee not paid to an account
+	Blob         *big.Int // Blob fees burned
+	Selfdestruct *big.Int // Value burned by self-destructs, such as the L2 to L1 withdrawals of OP chains
+	Total        *big.Int `rlp:"optional"` // Total supply after the block, nil if the supply of an ancestor is unknown
+}
+
+// Issuance returns the value issued by the block.
+func (s *Supply) Issuance() *big.Int {
+	issuance := new(big.Int).Add(s.Reward, s.Withdrawals)
+	return issuance.Add(issuance, s.Deposits)
+}
+
+// Burn returns the value burned by the block.
+func (s *Supply) Burn() *big.Int {
+	burn := new(big.Int).Add(s.Fees, s.Blob)
+	return burn.Add(burn, s.Selfdestruct)
 }
 
 type receiptMarshaling struct {
@@ -352,7 +459,16 @@ func (r *Receipt) decodeTyped(b []byte) error {
 		r.DepositReceiptVersion = data.DepositReceiptVersion
 		return r.setFromRLP(receiptRLP{data.PostStateOrStatus, data.CumulativeGasUsed, data.Bloom, data.Logs})
 	default:
-		return ErrTxTypeNotSupported
+		// System transaction receipts use the standard encoding
+		if LookupSystemTxType(b[0]) == nil {
+			return ErrTxTypeNotSupported
+		}
+		var data receiptRLP
+		if err := rlp.DecodeBytes(b[1:], &data); err != nil {
+			return err
+		}
+		r.Type = b[0]
+		return r.setFromRLP(data)
 	}
 }
 
@@ -517,6 +633,11 @@ func (rs Receipts) EncodeIndex(i int, w *bytes.Buffer) {
 			rlp.Encode(w, data)
 		}
 	default:
+		// System transaction receipts use the standard encoding
+		if LookupSystemTxType(r.Type) != nil {
+			rlp.Encode(w, data)
+			return
+		}
 		// For unsupported types, write nothing. Since this is for
 		// DeriveSha, the error will be caught matching the derived hash
 		// to the block.
@@ -585,7 +706,7 @@ func (rs Receipts) DeriveFields(config *params.ChainConfig, hash common.Hash, nu
 			return err
 		}
 		for i := 0; i < len(rs); i++ {
-			if txs[i].IsDepositTx() {
+			if txs[i].SystemTxType() != nil {
 				continue
 			}
 			rs[i].L1GasPrice = gasParams.l1BaseFee
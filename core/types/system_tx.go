@@ -0,0 +1,98 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// SystemTxType defines a type of system transactions: transactions injected by
+// the protocol rather than submitted by users, which pay no fees. The deposits
+// are the built-in system transaction type.
+//
+// A fork adds its own type by implementing, in this package, TxData for the
+// payload and this interface for the type specific rules, and registering it
+// at init with RegisterSystemTxType. The system transactions are then decoded, refused by
+// the transaction pool and executed like the deposits: gas is free and not
+// refunded, the fee recipient earns nothing and failed transactions are still
+// included. Their receipts use the standard encoding.
+type SystemTxType interface {
+	// NewTxData returns an empty payload of the type, to decode into.
+	NewTxData() TxData
+
+	// Sender returns the sender of the transaction. System transactions are
+	// not signed, the sender is part of the payload.
+	Sender(tx *Transaction) (common.Address, error)
+
+	// Validate checks the transaction before it is executed in a block.
+	Validate(config *params.ChainConfig, time uint64, tx *Transaction) error
+
+	// ProcessReceipt completes the receipt of the executed transaction, given
+	// the nonce of the sender before the execution.
+	ProcessReceipt(config *params.ChainConfig, time uint64, tx *Transaction, receipt *Receipt, nonce uint64)
+
+	// RPCTxFields adds the type specific fields of the RPC representation of
+	// the transaction, given its receipt if available.
+	RPCTxFields(tx *Transaction, receipt *Receipt, fields map[string]interface{})
+
+	// RPCReceiptFields adds the type specific fields of the RPC representation
+	// of the receipt of the transaction.
+	RPCReceiptFields(tx *Transaction, receipt *Receipt, fields map[string]interface{})
+}
+
+// SystemTxJSON is implemented by the system transaction types with a JSON
+// encoding of their own. The deposits are encoded natively.
+type SystemTxJSON interface {
+	MarshalTxJSON(tx *Transaction) ([]byte, error)
+	UnmarshalTxJSON(input []byte) (TxData, error)
+}
+
+// systemTxTypes are the registered system transaction types by type byte.
+var systemTxTypes = map[byte]SystemTxType{
+	DepositTxType: depositTxType{},
+}
+
+// RegisterSystemTxType registers a system transaction type. It must be called
+// at init, as the registry is not safe for concurrent modification.
+func RegisterSystemTxType(typ byte, t SystemTxType) {
+	switch typ {
+	case LegacyTxType, AccessListTxType, DynamicFeeTxType, BlobTxType, SetCodeTxType:
+		panic(fmt.Sprintf("system transaction type %#x conflicts with a standard type", typ))
+	}
+	if typ > 0x7f {
+		panic(fmt.Sprintf("system transaction type %#x out of the typed transaction range", typ))
+	}
+	if _, ok := systemTxTypes[typ]; ok {
+		panic(fmt.Sprintf("system transaction type %#x already registered", typ))
+	}
+	systemTxTypes[typ] = t
+}
+
+// LookupSystemTxType returns the system transaction type with the given type
+// byte, or nil if there is none.
+func LookupSystemTxType(typ byte) SystemTxType {
+	return systemTxTypes[typ]
+}
+
+// SystemTxType returns the system transaction type of the transaction, or nil
+// if it is a regular transaction.
+func (tx *Transaction) SystemTxType() SystemTxType {
+	return systemTxTypes[tx.Type()]
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const testSystemTxType = 0x7d
+
+// testSystemTx is the payload of a minimal system transaction type.
+type testSystemTx struct {
+	From common.Address
+	To   *common.Address `rlp:"nil"`
+	Gas  uint64          `json:"gasLimit"`
+	Data []byte
+}
+
+func (tx *testSystemTx) copy() TxData {
+	cpy := *tx
+	cpy.Data = common.CopyBytes(tx.Data)
+	return &cpy
+}
+
+func (tx *testSystemTx) txType() byte           { return testSystemTxType }
+func (tx *testSystemTx) chainID() *big.Int      { return common.Big0 }
+func (tx *testSystemTx) accessList() AccessList { return nil }
+func (tx *testSystemTx) data() []byte           { return tx.Data }
+func (tx *testSystemTx) gas() uint64            { return tx.Gas }
+func (tx *testSystemTx) gasFeeCap() *big.Int    { return new(big.Int) }
+func (tx *testSystemTx) gasTipCap() *big.Int    { return new(big.Int) }
+func (tx *testSystemTx) gasPrice() *big.Int     { return new(big.Int) }
+func (tx *testSystemTx) value() *big.Int        { return new(big.Int) }
+func (tx *testSystemTx) nonce() uint64          { return 0 }
+func (tx *testSystemTx) to() *common.Address    { return tx.To }
+func (tx *testSystemTx) isSystemTx() bool       { return false }
+
+func (tx *testSystemTx) effectiveGasPrice(dst *big.Int, baseFee *big.Int) *big.Int {
+	return dst.SetUint64(0)
+}
+func (tx *testSystemTx) sigHash(*big.Int) common.Hash { panic("system tx cannot be signed") }
+func (tx *testSystemTx) rawSignatureValues() (v, r, s *big.Int) {
+	return common.Big0, common.Big0, common.Big0
+}
+func (tx *testSystemTx) setSignatureValues(chainID, v, r, s *big.Int) {}
+func (tx *testSystemTx) encode(b *bytes.Buffer) error                 { return rlp.Encode(b, tx) }
+func (tx *testSystemTx) decode(input []byte) error                    { return rlp.DecodeBytes(input, tx) }
+
+// testSystemTxRules implements the rules of the test system transactions.
+type testSystemTxRules struct{}
+
+func (testSystemTxRules) NewTxData() TxData { return new(testSystemTx) }
+
+func (testSystemTxRules) Sender(tx *Transaction) (common.Address, error) {
+	return tx.inner.(*testSystemTx).From, nil
+}
+
+func (testSystemTxRules) Validate(config *params.ChainConfig, time uint64, tx *Transaction) error {
+	return nil
+}
+
+func (testSystemTxRules) ProcessReceipt(config *params.ChainConfig, time uint64, tx *Transaction, receipt *Receipt, nonce uint64) {
+}
+
+func (testSystemTxRules) RPCTxFields(tx *Transaction, receipt *Receipt, fields map[string]interface{}) {
+}
+
+func (testSystemTxRules) RPCReceiptFields(tx *Transaction, receipt *Receipt, fields map[string]interface{}) {
+}
+
+func (testSystemTxRules) MarshalTxJSON(tx *Transaction) ([]byte, error) {
+	return json.Marshal(struct {
+		*testSystemTx
+		Type hexutil.Uint64 `json:"type"`
+	}{tx.inner.(*testSystemTx), testSystemTxType})
+}
+
+func (testSystemTxRules) UnmarshalTxJSON(input []byte) (TxData, error) {
+	inner := new(testSystemTx)
+	return inner, json.Unmarshal(input, inner)
+}
+
+func TestSystemTxType(t *testing.T) {
+	RegisterSystemTxType(testSystemTxType, testSystemTxRules{})
+	defer delete(systemTxTypes, testSystemTxType)
+
+	to := common.Address{0x02}
+	tx := NewTx(&testSystemTx{From: common.Address{0x01}, To: &to, Gas: 21000, Data: []byte{0xca, 0xfe}})
+	if tx.SystemTxType() == nil {
+		t.Fatal("system transaction type not found")
+	}
+	// The transactions are decoded and carry their sender
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	dec := new(Transaction)
+	if err := dec.UnmarshalBinary(enc); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if dec.Hash() != tx.Hash() {
+		t.Fatalf("hash mismatch: have %x, want %x", dec.Hash(), tx.Hash())
+	}
+	from, err := Sender(LatestSignerForChainID(big.NewInt(1)), dec)
+	if err != nil || from != (common.Address{0x01}) {
+		t.Fatalf("wrong sender: %v, %v", from, err)
+	}
+	if tip, _ := dec.EffectiveGasTip(big.NewInt(1)); tip.Sign() != 0 {
+		t.Fatalf("system transaction pays a tip: %v", tip)
+	}
+	// The JSON encoding of the type is used
+	blob, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	dec = new(Transaction)
+	if err := json.Unmarshal(blob, dec); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if dec.Hash() != tx.Hash() {
+		t.Fatalf("hash mismatch after JSON round trip: have %x, want %x", dec.Hash(), tx.Hash())
+	}
+	// The receipts use the standard encoding
+	receipt := &Receipt{Type: testSystemTxType, Status: ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*Log{}}
+	if enc, err = receipt.MarshalBinary(); err != nil {
+		t.Fatalf("failed to encode receipt: %v", err)
+	}
+	decReceipt := new(Receipt)
+	if err := decReceipt.UnmarshalBinary(enc); err != nil {
+		t.Fatalf("failed to decode receipt: %v", err)
+	}
+	if decReceipt.Type != testSystemTxType || decReceipt.CumulativeGasUsed != 21000 {
+		t.Fatalf("wrong decoded receipt: %+v", decReceipt)
+	}
+	var buf bytes.Buffer
+	Receipts{receipt}.EncodeIndex(0, &buf)
+	if !bytes.Equal(buf.Bytes(), enc) {
+		t.Fatal("receipt encoded differently for the receipt root")
+	}
+	// Standard and already registered types are refused
+	for _, typ := range []byte{DynamicFeeTxType, DepositTxType, 0x80} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("type %#x registered", typ)
+				}
+			}()
+			RegisterSystemTxType(typ, testSystemTxRules{})
+		}()
+	}
+}
@@ -70,6 +70,12 @@ type Transaction struct {
 
 	// an indicator if this transaction is rejected during block building
 	rejected atomic.Bool
+
+	// an indicator if this transaction failed the pre-check on pool admission
+	mayRevert atomic.Bool
+
+	// label of the authenticated client which submitted the transaction
+	tenant atomic.Pointer[string]
 }
 
 // NewTx creates a new transaction.
@@ -222,10 +228,12 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 		inner = new(BlobTx)
 	case SetCodeTxType:
 		inner = new(SetCodeTx)
-	case DepositTxType:
-		inner = new(DepositTx)
 	default:
-		return nil, ErrTxTypeNotSupported
+		st := LookupSystemTxType(b[0])
+		if st == nil {
+			return nil, ErrTxTypeNotSupported
+		}
+		inner = st.NewTxData()
 	}
 	err := inner.decode(b[1:])
 	return inner, err
@@ -389,7 +397,7 @@ func (tx *Transaction) Cost() *big.Int {
 
 // RollupCostData caches the information needed to efficiently compute the data availability fee
 func (tx *Transaction) RollupCostData() RollupCostData {
-	if tx.Type() == DepositTxType {
+	if tx.SystemTxType() != nil {
 		return RollupCostData{}
 	}
 	if v := tx.rollupCostData.Load(); v != nil {
@@ -424,6 +432,33 @@ func (tx *Transaction) Rejected() bool {
 	return tx.rejected.Load()
 }
 
+// SetMayRevert marks this transaction as failing when simulated on admission
+// to the pool.
+func (tx *Transaction) SetMayRevert() {
+	tx.mayRevert.Store(true)
+}
+
+// MayRevert returns whether this transaction failed when simulated on admission
+// to the pool.
+func (tx *Transaction) MayRevert() bool {
+	return tx.mayRevert.Load()
+}
+
+// Tenant returns the label of the authenticated client which submitted the
+// transaction, or an empty string if unknown.
+func (tx *Transaction) Tenant() string {
+	if tenant := tx.tenant.Load(); tenant != nil {
+		return *tenant
+	}
+	return ""
+}
+
+// SetTenant attaches the label of the authenticated client which submitted
+// the transaction.
+func (tx *Transaction) SetTenant(tenant string) {
+	tx.tenant.Store(&tenant)
+}
+
 // RawSignatureValues returns the V, R, S signature values of the transaction.
 // The return values should not be modified by the caller.
 // The return values may be nil or zero, if the transaction is unsigned.
@@ -455,7 +490,7 @@ func (tx *Transaction) GasTipCapIntCmp(other *big.Int) int {
 // Note: if the effective gasTipCap is negative, this method returns both error
 // the actual negative value, _and_ ErrGasFeeCapTooLow
 func (tx *Transaction) EffectiveGasTip(baseFee *big.Int) (*big.Int, error) {
-	if tx.Type() == DepositTxType {
+	if tx.SystemTxType() != nil {
 		return new(big.Int), nil
 	}
 	if baseFee == nil {
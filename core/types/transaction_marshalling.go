@@ -88,6 +88,9 @@ func (tx *txJSON) yParityValue() (*big.Int, error) {
 
 // MarshalJSON marshals as JSON with a hash.
 func (tx *Transaction) MarshalJSON() ([]byte, error) {
+	if st, ok := tx.SystemTxType().(SystemTxJSON); ok {
+		return st.MarshalTxJSON(tx)
+	}
 	var enc txJSON
 	// These are set for all tx types.
 	enc.Hash = tx.Hash()
@@ -216,6 +219,17 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 	if err != nil {
 		return err
 	}
+	if dec.Type > 0xff {
+		return ErrTxTypeNotSupported
+	}
+	if st, ok := LookupSystemTxType(byte(dec.Type)).(SystemTxJSON); ok {
+		inner, err := st.UnmarshalTxJSON(input)
+		if err != nil {
+			return err
+		}
+		tx.setDecoded(inner, 0)
+		return nil
+	}
 
 	// Decode / verify fields according to transaction type.
 	var inner TxData
@@ -247,14 +247,10 @@ func (s *modernSigner) supportsType(txtype byte) bool {
 func (s *modernSigner) Sender(tx *Transaction) (common.Address, error) {
 	tt := tx.Type()
 
-	// OP-Stack: deposit transactions store the sender directly as the From field
-	if tt == DepositTxType {
-		switch tx.inner.(type) {
-		case *DepositTx:
-			return tx.inner.(*DepositTx).From, nil
-		case *depositTxWithNonce:
-			return tx.inner.(*depositTxWithNonce).From, nil
-		}
+	// OP-Stack: system transactions, like the deposits, are not signed and
+	// carry their sender
+	if st := LookupSystemTxType(tt); st != nil {
+		return st.Sender(tx)
 	}
 
 	if !s.supportsType(tt) {
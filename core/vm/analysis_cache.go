@@ -0,0 +1,150 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// analysisCacheLimit is the number of analyzed contracts retained across all
+// EVM instances.
+const analysisCacheLimit = 8192
+
+var (
+	analysisCache = lru.NewCache[analysisKey, *codeAnalysis](analysisCacheLimit)
+
+	analysisHitMeter      = metrics.NewRegisteredMeter("vm/analysis/hit", nil)
+	analysisMissMeter     = metrics.NewRegisteredMeter("vm/analysis/miss", nil)
+	analysisShadowMeter   = metrics.NewRegisteredMeter("vm/analysis/shadow", nil)
+	analysisMismatchMeter = metrics.NewRegisteredMeter("vm/analysis/mismatch", nil)
+)
+
+// analysisKey identifies an analysis: the static gas costs depend on the
+// instruction set of the fork the code is analyzed for.
+type analysisKey struct {
+	hash  common.Hash
+	table *JumpTable
+}
+
+// codeAnalysis is the lowered form of a contract's code, used by the
+// experimental analyzed execution (see Config.CodeAnalysis):
+//
+//   - The code is split into gas blocks, straight-line instruction sequences
+//     whose static gas is charged once on entry instead of per instruction. A
+//     block ends at any instruction with dynamic gas, any instruction reading
+//     or changing the program counter flow or the remaining gas, and before
+//     every JUMPDEST, so it can only be entered at its start.
+//   - PUSH followed by JUMP to a constant, valid destination is fused into a
+//     single direct jump.
+type codeAnalysis struct {
+	bitmap   bitvec            // Code and data locations, as the JUMPDEST analysis
+	blockGas []uint32          // Static gas plus one of the block starting at each pc, zero if no block starts there
+	fused    bitvec            // PUSH locations fused with a following JUMP
+	jumps    map[uint64]uint64 // Destinations of the fused jumps
+}
+
+// isFused reports whether the instruction at pc is a PUSH fused with a JUMP.
+func (a *codeAnalysis) isFused(pc uint64) bool {
+	return !a.fused.codeSegment(pc)
+}
+
+// endsBlock reports whether the instruction ends a gas block.
+func endsBlock(op OpCode, operation *operation) bool {
+	if operation.dynamicGas != nil || operation.undefined {
+		return true
+	}
+	switch op {
+	case STOP, JUMP, JUMPI, GAS, RETURN, REVERT, INVALID, SELFDESTRUCT:
+		return true
+	}
+	return false
+}
+
+// analyzeCode lowers the given code for the given instruction set.
+func analyzeCode(code []byte, table *JumpTable) *codeAnalysis {
+	a := &codeAnalysis{
+		bitmap:   codeBitmap(code),
+		blockGas: make([]uint32, len(code)),
+		fused:    make(bitvec, len(code)/8+1),
+		jumps:    make(map[uint64]uint64),
+	}
+	var (
+		start = uint64(0)
+		gas   = uint64(0)
+		open  = false
+	)
+	closeBlock := func() {
+		if open {
+			a.blockGas[start] = uint32(gas + 1)
+			open = false
+		}
+	}
+	for pc := uint64(0); pc < uint64(len(code)); pc++ {
+		if !a.bitmap.codeSegment(pc) {
+			continue
+		}
+		op := OpCode(code[pc])
+		if op == JUMPDEST {
+			closeBlock()
+		}
+		operation := table[op]
+		if open && gas+operation.constantGas >= math.MaxUint32 {
+			closeBlock()
+		}
+		if !open {
+			start, gas, open = pc, 0, true
+		}
+		gas += operation.constantGas
+
+		if op.IsPush() && op != PUSH0 && op <= PUSH8 {
+			next := pc + uint64(op-PUSH1) + 2
+			if next < uint64(len(code)) && OpCode(code[next]) == JUMP && gas+table[JUMP].constantGas < math.MaxUint32 {
+				var dest uint64
+				for _, b := range code[pc+1 : next] {
+					dest = dest<<8 | uint64(b)
+				}
+				if dest < uint64(len(code)) && OpCode(code[dest]) == JUMPDEST && a.bitmap.codeSegment(dest) {
+					a.fused.set1(pc)
+					a.jumps[pc] = dest
+				}
+			}
+		}
+		if endsBlock(op, operation) {
+			closeBlock()
+		}
+	}
+	closeBlock()
+	return a
+}
+
+// lookupAnalysis returns the cached analysis of the contract's code for the
+// given instruction set, analyzing it on a miss.
+func lookupAnalysis(contract *Contract, table *JumpTable) *codeAnalysis {
+	key := analysisKey{hash: contract.CodeHash, table: table}
+	if a, ok := analysisCache.Get(key); ok {
+		analysisHitMeter.Mark(1)
+		return a
+	}
+	analysisMissMeter.Mark(1)
+	a := analyzeCode(contract.Code, table)
+	analysisCache.Add(key, a)
+	return a
+}
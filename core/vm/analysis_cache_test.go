@@ -0,0 +1,113 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+var analysisTests = []string{
+	// Count down from 10 in a loop, jump over invalid code with a fused jump,
+	// store a value and return the remaining gas:
+	//   push1 10, jumpdest, push1 1, swap1, sub, dup1, push1 2, jumpi,
+	//   push1 16, jump, invalid, invalid, jumpdest, push1 42, push1 0, sstore,
+	//   gas, push1 0, mstore, push1 32, push1 0, return
+	"600a5b6001900380600257601056fefe5b602a6000555a60005260206000f3",
+	// Store a value and revert with data
+	"602a600055600160005260206000fd",
+	// Stack underflow in the middle of a block
+	"6001600101",
+	// Fused jump to a location inside push data, rejected at runtime
+	"600456615b005b00",
+}
+
+// Tests that executing analyzed code has the same outcome as the plain
+// execution for any amount of gas, including running out in every block.
+func TestAnalyzedExecution(t *testing.T) {
+	address := common.BytesToAddress([]byte("contract"))
+
+	execute := func(code []byte, gas uint64, config Config) (string, common.Hash) {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		statedb.CreateAccount(address)
+		statedb.SetCode(address, code)
+		statedb.Finalise(true)
+
+		evm := NewEVM(BlockContext{
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+		}, statedb, params.AllEthashProtocolChanges, config)
+
+		ret, left, err := evm.Call(common.Address{}, address, nil, gas, new(uint256.Int))
+		return fmt.Sprintf("ret %x, gas %d, err %v", ret, left, err), statedb.GetState(address, common.Hash{})
+	}
+	for i, tt := range analysisTests {
+		code := common.FromHex(tt)
+		for gas := uint64(0); gas < 25000; gas += 1 + gas/50 {
+			want, wantSlot := execute(code, gas, Config{})
+			have, haveSlot := execute(code, gas, Config{CodeAnalysis: true})
+			if have != want || haveSlot != wantSlot {
+				t.Fatalf("test %d, gas %d: analyzed execution mismatch\nhave %s, slot %x\nwant %s, slot %x", i, gas, have, haveSlot, want, wantSlot)
+			}
+			shadow, shadowSlot := execute(code, gas, Config{CodeAnalysis: true, CodeAnalysisShadow: true})
+			if shadow != want || shadowSlot != wantSlot {
+				t.Fatalf("test %d, gas %d: shadow execution mismatch\nhave %s, slot %x\nwant %s, slot %x", i, gas, shadow, shadowSlot, want, wantSlot)
+			}
+		}
+	}
+}
+
+// Tests the gas blocks and fused jumps of the code analysis.
+func TestAnalyzeCode(t *testing.T) {
+	var (
+		code  = common.FromHex(analysisTests[0])
+		table = &pragueInstructionSet
+		a     = analyzeCode(code, table)
+	)
+	// Blocks start at 0, the loop JUMPDEST, after the JUMPI, at each INVALID,
+	// the second JUMPDEST and after SSTORE, GAS and MSTORE.
+	var starts []int
+	for pc, gas := range a.blockGas {
+		if gas != 0 {
+			starts = append(starts, pc)
+		}
+	}
+	if want := []int{0, 2, 11, 14, 15, 16, 22, 23, 26}; fmt.Sprint(starts) != fmt.Sprint(want) {
+		t.Fatalf("wrong block starts: have %v, want %v", starts, want)
+	}
+	// jumpdest, push1 1, swap1, sub, dup1, push1 2, jumpi
+	if have, want := a.blockGas[2]-1, uint32(1+3+3+3+3+3+10); have != want {
+		t.Errorf("wrong loop block gas: have %d, want %d", have, want)
+	}
+	if !a.isFused(11) || a.jumps[11] != 16 || a.isFused(8) {
+		t.Errorf("wrong fused jumps: %v", a.jumps)
+	}
+	// Jumps into push data are not fused.
+	if a := analyzeCode(common.FromHex(analysisTests[3]), table); len(a.jumps) != 0 {
+		t.Errorf("jump into push data fused: %v", a.jumps)
+	}
+	if !bytes.Equal(a.bitmap, codeBitmap(code)) {
+		t.Error("code bitmap mismatch")
+	}
+}
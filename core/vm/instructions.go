@@ -520,6 +520,9 @@ func opSload(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]by
 	hash := common.Hash(loc.Bytes32())
 	val := interpreter.evm.StateDB.GetState(scope.Contract.Address(), hash)
 	loc.SetBytes(val.Bytes())
+	if interpreter.evm.Config.ResourceLimits != nil {
+		interpreter.evm.usage.SLoads++
+	}
 	return nil, nil
 }
 
@@ -99,6 +99,10 @@ type StateDB interface {
 
 	AccessEvents() *state.AccessEvents
 
+	// PrecompileCalls returns the invocations of a capped precompile in the block.
+	PrecompileCalls(common.Address) uint64
+	AddPrecompileCall(common.Address)
+
 	// Finalise must be invoked at the end of a transaction
 	Finalise(bool)
 }
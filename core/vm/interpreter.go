@@ -17,6 +17,7 @@
 package vm
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -44,6 +45,11 @@ type Config struct {
 	PrecompileOverrides PrecompileOverrides                   // Precompiles can be swapped / changed / wrapped as needed
 	NoMaxCodeSize       bool                                  // Ignore Max code size and max init code size limits
 	CallerOverride      func(v common.Address) common.Address // Swap the caller as needed, for VM prank functionality.
+
+	CodeAnalysis       bool // Experimental: execute contracts from cached gas block and jump fusion analysis
+	CodeAnalysisShadow bool // Experimental: cross-check every analyzed top-level execution against the plain one
+
+	ResourceLimits *ResourceLimits // Tighter resource limits with usage accounting, for simulations only
 }
 
 // ScopeContext contains the things that are per-call, such as stack and memory,
@@ -108,6 +114,7 @@ type EVMInterpreter struct {
 
 	readOnly   bool   // Whether to throw on stateful modifications
 	returnData []byte // Last CALL's return data for subsequent reuse
+	plain      bool   // Whether to bypass the code analysis, during shadow execution
 }
 
 // NewEVMInterpreter returns a new instance of the Interpreter.
@@ -169,10 +176,66 @@ func NewEVMInterpreter(evm *EVM) *EVMInterpreter {
 // considered a revert-and-consume-all-gas operation except for
 // ErrExecutionReverted which means revert-and-keep-gas-left.
 func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (ret []byte, err error) {
+	if in.evm.Config.CodeAnalysisShadow && in.evm.depth == 0 && in.analyzed(contract) {
+		return in.runShadow(contract, input, readOnly)
+	}
+	return in.run(contract, input, readOnly)
+}
+
+// analyzed reports whether the contract is executed from its cached code
+// analysis. Tracing and verkle code chunk charging require plain execution,
+// and so do custom instruction sets, which would not share the cache.
+func (in *EVMInterpreter) analyzed(contract *Contract) bool {
+	return in.evm.Config.CodeAnalysis && !in.plain && in.evm.Config.Tracer == nil &&
+		contract.CodeHash != (common.Hash{}) && !in.evm.chainRules.IsEIP4762 && len(in.evm.Config.ExtraEips) == 0
+}
+
+// runShadow executes the contract both from its code analysis and plainly,
+// reverting the state changes of the first execution in between, and reports
+// any difference. The outcome of the plain execution is returned.
+func (in *EVMInterpreter) runShadow(contract *Contract, input []byte, readOnly bool) ([]byte, error) {
+	analysisShadowMeter.Mark(1)
+
+	var (
+		snapshot = in.evm.StateDB.Snapshot()
+		gas      = contract.Gas
+	)
+	ret, err := in.run(contract, input, readOnly)
+	var (
+		analyzedRet    = common.CopyBytes(ret)
+		analyzedGas    = contract.Gas
+		analyzedRefund = in.evm.StateDB.GetRefund()
+	)
+	in.evm.StateDB.RevertToSnapshot(snapshot)
+	contract.Gas = gas
+
+	in.plain = true
+	ret, plainErr := in.run(contract, input, readOnly)
+	in.plain = false
+
+	// Failed executions consume all gas, their remaining gas is irrelevant
+	mismatch := fmt.Sprint(err) != fmt.Sprint(plainErr) || !bytes.Equal(analyzedRet, ret)
+	if plainErr == nil || plainErr == ErrExecutionReverted {
+		mismatch = mismatch || analyzedGas != contract.Gas || analyzedRefund != in.evm.StateDB.GetRefund()
+	}
+	if mismatch {
+		analysisMismatchMeter.Mark(1)
+		log.Error("Analyzed execution diverged", "address", contract.Address(), "codehash", contract.CodeHash,
+			"err", err, "plainerr", plainErr, "gas", analyzedGas, "plaingas", contract.Gas)
+	}
+	return ret, plainErr
+}
+
+// run executes the contract, see Run.
+func (in *EVMInterpreter) run(contract *Contract, input []byte, readOnly bool) (ret []byte, err error) {
 	// Increment the call depth which is restricted to 1024
 	in.evm.depth++
 	defer func() { in.evm.depth-- }()
 
+	if in.evm.Config.ResourceLimits != nil {
+		in.evm.enterFrame()
+	}
+
 	// Make sure the readOnly is only set if we aren't in readOnly yet.
 	// This also makes sure that the readOnly flag isn't removed for child calls.
 	if readOnly && !in.readOnly {
@@ -209,7 +272,14 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		logged  bool   // deferred EVMLogger should ignore already logged steps
 		res     []byte // result of the opcode execution function
 		debug   = in.evm.Config.Tracer != nil
+
+		analysis *codeAnalysis // code analysis, nil if executing plainly
+		charged  bool          // whether the static gas of the current block was charged
 	)
+	if in.analyzed(contract) {
+		analysis = lookupAnalysis(contract, in.table)
+		contract.analysis = analysis.bitmap
+	}
 	// Don't move this deferred function, it's placed before the OnOpcode-deferred method,
 	// so that it gets executed _after_: the OnOpcode needs the stacks before
 	// they are returned to the pools
@@ -254,6 +324,21 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		op = contract.GetOp(pc)
 		operation := in.table[op]
 		cost = operation.constantGas // For tracing
+
+		// When executing analyzed code, charge the static gas of a block on
+		// entry. If that is not affordable, fall back to charging per operation
+		// until the next block, so the out-of-gas point stays exact.
+		if analysis != nil && pc < uint64(len(analysis.blockGas)) {
+			if gas := analysis.blockGas[pc]; gas != 0 {
+				if charged = contract.Gas >= uint64(gas-1); charged {
+					contract.Gas -= uint64(gas - 1)
+				}
+			}
+			if charged && analysis.isFused(pc) && stack.len() <= operation.maxStack && !in.evm.abort.Load() {
+				pc = analysis.jumps[pc] // fused PUSH and JUMP, the destination was validated
+				continue
+			}
+		}
 		// Validate stack
 		if sLen := stack.len(); sLen < operation.minStack {
 			return nil, &ErrStackUnderflow{stackLen: sLen, required: operation.minStack}
@@ -261,10 +346,12 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 			return nil, &ErrStackOverflow{stackLen: sLen, limit: operation.maxStack}
 		}
 		// for tracing: this gas consumption event is emitted below in the debug section.
-		if contract.Gas < cost {
-			return nil, ErrOutOfGas
-		} else {
-			contract.Gas -= cost
+		if !charged {
+			if contract.Gas < cost {
+				return nil, ErrOutOfGas
+			} else {
+				contract.Gas -= cost
+			}
 		}
 
 		// All ops with a dynamic memory usage also has a dynamic gas cost.
@@ -312,6 +399,11 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 			}
 		}
 		if memorySize > 0 {
+			if in.evm.Config.ResourceLimits != nil {
+				if err = in.evm.expandMemory(max(memorySize, uint64(mem.Len()))); err != nil {
+					return nil, err
+				}
+			}
 			mem.Resize(memorySize)
 		}
 
@@ -0,0 +1,91 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// precompileMetrics are the metrics tracked for a single precompile.
+type precompileMetrics struct {
+	calls    *metrics.Meter // Invocations of the precompile
+	failures *metrics.Meter // Invocations failed, out of gas included
+	capped   *metrics.Meter // Invocations rejected by the per-block cap
+	gas      *metrics.Meter // Gas consumed by the precompile
+	timer    *metrics.Timer // Execution time of the precompile
+}
+
+// precompileMetricsSet caches the metrics of the precompiles by address, so
+// the registry isn't looked up on every invocation.
+var precompileMetricsSet sync.Map // common.Address -> *precompileMetrics
+
+// getPrecompileMetrics returns the metrics of the precompile at the given
+// address, registering them on first use. The metrics are named after the
+// address without leading zeroes, e.g. vm/precompile/0x1/calls.
+func getPrecompileMetrics(addr common.Address) *precompileMetrics {
+	if m, ok := precompileMetricsSet.Load(addr); ok {
+		return m.(*precompileMetrics)
+	}
+	prefix := "vm/precompile/" + hexutil.EncodeBig(addr.Big()) + "/"
+	m, _ := precompileMetricsSet.LoadOrStore(addr, &precompileMetrics{
+		calls:    metrics.GetOrRegisterMeter(prefix+"calls", nil),
+		failures: metrics.GetOrRegisterMeter(prefix+"failures", nil),
+		capped:   metrics.GetOrRegisterMeter(prefix+"capped", nil),
+		gas:      metrics.GetOrRegisterMeter(prefix+"gas", nil),
+		timer:    metrics.GetOrRegisterTimer(prefix+"time", nil),
+	})
+	return m.(*precompileMetrics)
+}
+
+// runPrecompile runs the precompile at the given address, enforcing its
+// per-block invocation cap if the chain config sets one, and tracking its
+// metrics. The invocations are counted in the state, so they are carried
+// across the transactions of the block and undone along with a reverted call.
+func (evm *EVM) runPrecompile(p PrecompiledContract, addr common.Address, input []byte, gas uint64) ([]byte, uint64, error) {
+	if limit, ok := evm.chainConfig.PrecompileInvocationCap(addr, evm.Context.Time); ok {
+		if evm.StateDB.PrecompileCalls(addr) >= limit {
+			if metrics.Enabled() {
+				getPrecompileMetrics(addr).capped.Mark(1)
+			}
+			if evm.Config.Tracer != nil && evm.Config.Tracer.OnGasChange != nil {
+				evm.Config.Tracer.OnGasChange(gas, 0, tracing.GasChangeCallFailedExecution)
+			}
+			return nil, 0, ErrPrecompileCapExceeded
+		}
+		evm.StateDB.AddPrecompileCall(addr)
+	}
+	if !metrics.Enabled() {
+		return RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+	}
+	start := time.Now()
+	ret, remaining, err := RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+
+	m := getPrecompileMetrics(addr)
+	m.timer.UpdateSince(start)
+	m.calls.Mark(1)
+	m.gas.Mark(int64(gas - remaining))
+	if err != nil {
+		m.failures.Mark(1)
+	}
+	return ret, remaining, err
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the per-block invocation caps of the precompiles are enforced from
+// their activation, and that the invocations are tracked in the state.
+func TestPrecompileInvocationCap(t *testing.T) {
+	var (
+		sha256Addr = common.BytesToAddress([]byte{2})
+		config     = *params.AllEthashProtocolChanges
+	)
+	config.PrecompileCaps = []params.PrecompileCap{
+		{Address: sha256Addr, Time: 10, Limit: 5},
+		{Address: sha256Addr, Time: 20, Limit: 2},
+	}
+	newState := func() *state.StateDB {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		return statedb
+	}
+	newEVMWithState := func(time uint64, statedb StateDB, tracer *tracing.Hooks) *EVM {
+		return NewEVM(BlockContext{
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+			Time:        time,
+		}, statedb, &config, Config{Tracer: tracer})
+	}
+	newEVM := func(time uint64) *EVM {
+		return newEVMWithState(time, newState(), nil)
+	}
+	// count returns the number of successful invocations out of ten
+	count := func(evm *EVM) int {
+		var n int
+		for i := 0; i < 10; i++ {
+			_, _, err := evm.StaticCall(common.Address{}, sha256Addr, nil, 1000)
+			switch {
+			case err == nil:
+				n++
+			case !errors.Is(err, ErrPrecompileCapExceeded):
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		return n
+	}
+	if n := count(newEVM(5)); n != 10 {
+		t.Errorf("invocations before the cap: have %d, want 10", n)
+	}
+	if n := count(newEVM(15)); n != 5 {
+		t.Errorf("invocations with the first cap: have %d, want 5", n)
+	}
+	if n := count(newEVM(25)); n != 2 {
+		t.Errorf("invocations with the second cap: have %d, want 2", n)
+	}
+	// Invocations reverted from the state don't count against the cap
+	statedb := newState()
+	evm := newEVMWithState(25, statedb, nil)
+	snap := statedb.Snapshot()
+	count(evm)
+	statedb.RevertToSnapshot(snap)
+	if n := count(evm); n != 2 {
+		t.Errorf("invocations after revert: have %d, want 2", n)
+	}
+	// Invocations are carried across transactions and to other EVMs executing
+	// on the same state, e.g. when tracing a transaction of the block
+	statedb.Finalise(true)
+	if n := count(newEVMWithState(25, statedb.Copy(), nil)); n != 0 {
+		t.Errorf("invocations on the block state: have %d, want 0", n)
+	}
+	// Rejected invocations consume the gas of the call, which is reported to
+	// the tracer
+	var changes [][2]uint64
+	tracer := &tracing.Hooks{
+		OnGasChange: func(old, new uint64, reason tracing.GasChangeReason) {
+			if reason == tracing.GasChangeCallFailedExecution {
+				changes = append(changes, [2]uint64{old, new})
+			}
+		},
+	}
+	evm = newEVMWithState(25, state.NewHookedState(statedb, tracer), tracer)
+	if _, _, err := evm.StaticCall(common.Address{}, sha256Addr, nil, 1000); !errors.Is(err, ErrPrecompileCapExceeded) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) == 0 || changes[0] != [2]uint64{1000, 0} {
+		t.Errorf("gas changes of the rejected invocation: have %v, want [[1000 0]]", changes)
+	}
+	// Uncapped precompiles are unaffected
+	if _, _, err := evm.StaticCall(common.Address{}, common.BytesToAddress([]byte{4}), nil, 1000); err != nil {
+		t.Errorf("uncapped precompile failed: %v", err)
+	}
+}
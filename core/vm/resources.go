@@ -0,0 +1,73 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ResourceLimits are limits tighter than the protocol ones, enforced on the
+// simulated calls to profile their worst-case resource usage. Setting them
+// also enables the accounting of the resource usage of the EVM.
+type ResourceLimits struct {
+	MaxMemory    uint64 // Maximum memory of a call frame in bytes, zero for no limit
+	MaxCallDepth int    // Maximum depth of the call frames, zero for the protocol limit
+}
+
+// ResourceUsage is the peak resource usage of the calls executed by an EVM
+// with resource limits.
+type ResourceUsage struct {
+	MaxMemory    uint64 // Largest memory of a call frame in bytes
+	MaxCallDepth int    // Deepest call frame, the top level frame being 1
+	SLoads       uint64 // Number of storage reads
+}
+
+// ResourceUsage returns the resource usage of the calls executed since the
+// last reset, if resource limits are set.
+func (evm *EVM) ResourceUsage() ResourceUsage {
+	return evm.usage
+}
+
+// ResetResourceUsage clears the resource usage accounted so far.
+func (evm *EVM) ResetResourceUsage() {
+	evm.usage = ResourceUsage{}
+}
+
+// depthExceeded reports whether entering a new call frame would exceed the
+// call depth limit.
+func (evm *EVM) depthExceeded() bool {
+	if evm.depth > int(params.CallCreateDepth) {
+		return true
+	}
+	limits := evm.Config.ResourceLimits
+	return limits != nil && limits.MaxCallDepth > 0 && evm.depth >= limits.MaxCallDepth
+}
+
+// enterFrame accounts a new call frame at the current depth.
+func (evm *EVM) enterFrame() {
+	evm.usage.MaxCallDepth = max(evm.usage.MaxCallDepth, evm.depth)
+}
+
+// expandMemory accounts the expansion of the memory of a call frame to the
+// given size, failing if it exceeds the memory limit.
+func (evm *EVM) expandMemory(size uint64) error {
+	if limit := evm.Config.ResourceLimits.MaxMemory; limit > 0 && size > limit {
+		return ErrMemoryLimitExceeded
+	}
+	evm.usage.MaxMemory = max(evm.usage.MaxMemory, size)
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestResourceLimits(t *testing.T) {
+	address := common.BytesToAddress([]byte("contract"))
+
+	execute := func(code []byte, limits *ResourceLimits) (ResourceUsage, error) {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		statedb.CreateAccount(address)
+		statedb.SetCode(address, code)
+		statedb.Finalise(true)
+
+		evm := NewEVM(BlockContext{
+			BlockNumber: new(big.Int),
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+		}, statedb, params.AllEthashProtocolChanges, Config{ResourceLimits: limits})
+
+		_, _, err := evm.Call(common.Address{}, address, nil, 10_000_000, new(uint256.Int))
+		return evm.ResourceUsage(), err
+	}
+	var (
+		mstore  = common.FromHex("600061100052600054506001545000")    // MSTORE at 0x1000, then SLOAD twice
+		recurse = common.FromHex("6000600060006000600030" + "5af100") // CALL itself with all gas
+	)
+	tests := []struct {
+		code   []byte
+		limits *ResourceLimits
+		usage  ResourceUsage
+		err    error
+	}{
+		{mstore, nil, ResourceUsage{}, nil},
+		{mstore, &ResourceLimits{}, ResourceUsage{MaxMemory: 0x1020, MaxCallDepth: 1, SLoads: 2}, nil},
+		{mstore, &ResourceLimits{MaxMemory: 0x1020}, ResourceUsage{MaxMemory: 0x1020, MaxCallDepth: 1, SLoads: 2}, nil},
+		{mstore, &ResourceLimits{MaxMemory: 0x1000}, ResourceUsage{MaxCallDepth: 1}, ErrMemoryLimitExceeded},
+		{recurse, nil, ResourceUsage{}, nil},
+		{recurse, &ResourceLimits{MaxCallDepth: 3}, ResourceUsage{MaxCallDepth: 3}, nil},
+		{recurse, &ResourceLimits{MaxCallDepth: 1}, ResourceUsage{MaxCallDepth: 1}, nil},
+	}
+	for i, tt := range tests {
+		usage, err := execute(tt.code, tt.limits)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if usage != tt.usage {
+			t.Errorf("test %d: usage mismatch: have %+v, want %+v", i, usage, tt.usage)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abiregistry
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Decoded is a contract call or event log decoded with the contract ABI.
+type Decoded struct {
+	Name      string `json:"name"`
+	Signature string `json:"signature"`
+	Args      []Arg  `json:"args"`
+}
+
+// Arg is a decoded argument of a call or event. Integers are rendered as
+// decimal strings and byte arrays as hex strings.
+type Arg struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// DecodeCall decodes the input of a call to the contract, returning nil if it
+// doesn't match any function of the ABI.
+func DecodeCall(contract *abi.ABI, input []byte) *Decoded {
+	if contract == nil || len(input) < 4 {
+		return nil
+	}
+	method, err := contract.MethodById(input[:4])
+	if err != nil {
+		return nil
+	}
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil
+	}
+	decoded := &Decoded{Name: method.RawName, Signature: method.Sig, Args: make([]Arg, len(values))}
+	for i, value := range values {
+		decoded.Args[i] = newArg(method.Inputs[i], value)
+	}
+	return decoded
+}
+
+// DecodeLog decodes an event log emitted by the contract, returning nil if it
+// doesn't match any event of the ABI. Indexed arguments of dynamic types are
+// only known by their hash.
+func DecodeLog(contract *abi.ABI, topics []common.Hash, data []byte) *Decoded {
+	if contract == nil || len(topics) == 0 {
+		return nil
+	}
+	event, err := contract.EventByID(topics[0])
+	if err != nil {
+		return nil
+	}
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexed) != len(topics)-1 {
+		return nil
+	}
+	values := make(map[string]any)
+	if err := abi.ParseTopicsIntoMap(values, indexed, topics[1:]); err != nil {
+		return nil
+	}
+	if err := event.Inputs.UnpackIntoMap(values, data); err != nil {
+		return nil
+	}
+	decoded := &Decoded{Name: event.RawName, Signature: event.Sig, Args: make([]Arg, len(event.Inputs))}
+	for i, input := range event.Inputs {
+		decoded.Args[i] = newArg(input, values[input.Name])
+	}
+	return decoded
+}
+
+func newArg(arg abi.Argument, value any) Arg {
+	return Arg{Name: arg.Name, Type: arg.Type.String(), Value: formatValue(value)}
+}
+
+// formatValue converts a decoded ABI value into its JSON representation.
+func formatValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *big.Int:
+		return v.String()
+	case common.Address, common.Hash, bool, string:
+		return v
+	case []byte:
+		return hexutil.Bytes(v)
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(value)
+	case reflect.Array, reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Bytes(b)
+		}
+		values := make([]any, rv.Len())
+		for i := range values {
+			values[i] = formatValue(rv.Index(i).Interface())
+		}
+		return values
+	case reflect.Struct:
+		values := make(map[string]any, rv.NumField())
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" {
+				name = field.Name
+			}
+			values[name] = formatValue(rv.Field(i).Interface())
+		}
+		return values
+	}
+	return value
+}
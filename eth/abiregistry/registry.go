@@ -0,0 +1,159 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package abiregistry implements the on-node registry of contract ABIs, used to
+// decode the calls and event logs of the contracts in traces and receipts.
+//
+// The ABIs are registered by code hash, so every contract deployed with the
+// same code shares its ABI. They are either registered by the operator or
+// fetched from a Sourcify server.
+package abiregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+const (
+	// cachedABIs is the number of parsed ABIs kept in memory.
+	cachedABIs = 256
+
+	// maxABISize is the maximum size of a registered ABI.
+	maxABISize = 1024 * 1024
+
+	// sourcifyTimeout is the time allowed for a Sourcify lookup.
+	sourcifyTimeout = 10 * time.Second
+)
+
+var (
+	errSourcifyDisabled = errors.New("sourcify lookup is not configured")
+	errNotVerified      = errors.New("contract is not verified on sourcify")
+)
+
+// Registry maps contract code hashes to their ABIs. The ABIs are persisted in
+// the chain database and the parsed ones cached in memory.
+type Registry struct {
+	db       ethdb.KeyValueStore
+	cache    *lru.Cache[common.Hash, *abi.ABI]
+	sourcify string // Sourcify server URL, empty if lookups are disabled
+	chainID  uint64
+	client   *http.Client
+}
+
+// New creates a contract ABI registry backed by the given database. The ABIs
+// are looked up on the given Sourcify server for the given chain, unless the
+// server URL is empty.
+func New(db ethdb.KeyValueStore, sourcify string, chainID uint64) *Registry {
+	return &Registry{
+		db:       db,
+		cache:    lru.NewCache[common.Hash, *abi.ABI](cachedABIs),
+		sourcify: strings.TrimSuffix(sourcify, "/"),
+		chainID:  chainID,
+		client:   &http.Client{Timeout: sourcifyTimeout},
+	}
+}
+
+// Register stores the JSON ABI of the contracts with the given code hash,
+// replacing the previous one if any.
+func (r *Registry) Register(codeHash common.Hash, data []byte) error {
+	if len(data) > maxABISize {
+		return fmt.Errorf("contract ABI too large, %d > %d bytes", len(data), maxABISize)
+	}
+	parsed, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid contract ABI: %v", err)
+	}
+	rawdb.WriteContractABI(r.db, codeHash, data)
+	r.cache.Add(codeHash, &parsed)
+	return nil
+}
+
+// Remove deletes the ABI of the contracts with the given code hash, reporting
+// whether one was registered.
+func (r *Registry) Remove(codeHash common.Hash) bool {
+	if len(rawdb.ReadContractABI(r.db, codeHash)) == 0 {
+		return false
+	}
+	rawdb.DeleteContractABI(r.db, codeHash)
+	r.cache.Remove(codeHash)
+	return true
+}
+
+// ContractABI returns the ABI of the contracts with the given code hash, or nil
+// if none is registered.
+func (r *Registry) ContractABI(codeHash common.Hash) *abi.ABI {
+	if parsed, ok := r.cache.Get(codeHash); ok {
+		return parsed
+	}
+	data := rawdb.ReadContractABI(r.db, codeHash)
+	if len(data) == 0 {
+		return nil
+	}
+	parsed, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	r.cache.Add(codeHash, &parsed)
+	return &parsed
+}
+
+// Fetch looks up the JSON ABI of the contract at the given address on the
+// configured Sourcify server.
+func (r *Registry) Fetch(ctx context.Context, address common.Address) ([]byte, error) {
+	if r.sourcify == "" {
+		return nil, errSourcifyDisabled
+	}
+	url := fmt.Sprintf("%s/v2/contract/%d/%s?fields=abi", r.sourcify, r.chainID, address.Hex())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return nil, errNotVerified
+	case res.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("sourcify lookup failed: %s", res.Status)
+	}
+	var contract struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxABISize)).Decode(&contract); err != nil {
+		return nil, fmt.Errorf("invalid sourcify response: %v", err)
+	}
+	if len(contract.ABI) == 0 || string(contract.ABI) == "null" {
+		return nil, errNotVerified
+	}
+	return contract.ABI, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abiregistry
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const tokenABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func TestRegistry(t *testing.T) {
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		registry = New(db, "", 1)
+		codeHash = common.HexToHash("0x01")
+	)
+	if registry.ContractABI(codeHash) != nil {
+		t.Fatal("unregistered ABI found")
+	}
+	if err := registry.Register(codeHash, []byte("not an abi")); err == nil {
+		t.Fatal("invalid ABI registered")
+	}
+	if err := registry.Register(codeHash, []byte(tokenABI)); err != nil {
+		t.Fatalf("failed to register ABI: %v", err)
+	}
+	// The ABI is persisted across registry instances
+	contract := New(db, "", 1).ContractABI(codeHash)
+	if contract == nil {
+		t.Fatal("registered ABI not found")
+	}
+	var (
+		to     = common.HexToAddress("0xbeef")
+		amount = big.NewInt(1000)
+	)
+	input, err := contract.Pack("transfer", to, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := DecodeCall(contract, input)
+	if call == nil || call.Name != "transfer" || call.Signature != "transfer(address,uint256)" || len(call.Args) != 2 {
+		t.Fatalf("wrong decoded call: %+v", call)
+	}
+	if call.Args[0].Value != to || call.Args[1].Value != "1000" || call.Args[1].Type != "uint256" {
+		t.Fatalf("wrong decoded arguments: %+v", call.Args)
+	}
+	if DecodeCall(contract, []byte{0xde, 0xad, 0xbe, 0xef}) != nil {
+		t.Fatal("unknown function decoded")
+	}
+	from := common.HexToAddress("0xcafe")
+	topics := []common.Hash{
+		crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+		common.BytesToHash(from.Bytes()),
+		common.BytesToHash(to.Bytes()),
+	}
+	event := DecodeLog(contract, topics, common.BigToHash(amount).Bytes())
+	if event == nil || event.Name != "Transfer" || len(event.Args) != 3 {
+		t.Fatalf("wrong decoded event: %+v", event)
+	}
+	if event.Args[0].Value != from || event.Args[1].Value != to || event.Args[2].Value != "1000" {
+		t.Fatalf("wrong decoded event arguments: %+v", event.Args)
+	}
+	if DecodeLog(contract, topics[:2], nil) != nil {
+		t.Fatal("event with missing topics decoded")
+	}
+	if !registry.Remove(codeHash) || registry.ContractABI(codeHash) != nil {
+		t.Fatal("ABI not removed")
+	}
+	if registry.Remove(codeHash) {
+		t.Fatal("removed ABI removed again")
+	}
+}
+
+func TestRegistryFetch(t *testing.T) {
+	var (
+		verified   = common.HexToAddress("0x01")
+		unverified = common.HexToAddress("0x02")
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf("/v2/contract/10/%s", verified.Hex()) || r.URL.Query().Get("fields") != "abi" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"abi":%s,"match":"exact_match"}`, tokenABI)
+	}))
+	defer server.Close()
+
+	registry := New(rawdb.NewMemoryDatabase(), server.URL+"/", 10)
+	data, err := registry.Fetch(context.Background(), verified)
+	if err != nil {
+		t.Fatalf("failed to fetch ABI: %v", err)
+	}
+	if err := registry.Register(common.Hash{0x01}, data); err != nil {
+		t.Fatalf("failed to register fetched ABI: %v", err)
+	}
+	if _, err := registry.Fetch(context.Background(), unverified); err != errNotVerified {
+		t.Fatalf("wrong error for unverified contract: %v", err)
+	}
+	if _, err := New(rawdb.NewMemoryDatabase(), "", 10).Fetch(context.Background(), verified); err != errSourcifyDisabled {
+		t.Fatalf("wrong error without sourcify: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/abiregistry"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ContractABIAPI provides the management of the contract ABI registry, in the
+// admin namespace. It is only served on the authenticated RPC endpoints.
+type ContractABIAPI struct {
+	eth *Ethereum
+}
+
+// NewContractABIAPI creates a new ContractABIAPI instance.
+func NewContractABIAPI(eth *Ethereum) *ContractABIAPI {
+	return &ContractABIAPI{eth: eth}
+}
+
+// RegisterContractABI registers the JSON ABI of the contract at the given
+// address, which applies to every contract with the same code. The code hash
+// the ABI is registered for is returned.
+func (api *ContractABIAPI) RegisterContractABI(address common.Address, abi json.RawMessage) (common.Hash, error) {
+	codeHash, err := api.eth.contractCodeHash(address)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.eth.abiRegistry.Register(codeHash, abi); err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Registered contract ABI", "address", address, "codehash", codeHash)
+	return codeHash, nil
+}
+
+// FetchContractABI looks up the ABI of the contract at the given address on the
+// configured Sourcify server and registers it. The code hash the ABI is
+// registered for is returned.
+func (api *ContractABIAPI) FetchContractABI(ctx context.Context, address common.Address) (common.Hash, error) {
+	codeHash, err := api.eth.contractCodeHash(address)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	abi, err := api.eth.abiRegistry.Fetch(ctx, address)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.eth.abiRegistry.Register(codeHash, abi); err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Registered contract ABI from sourcify", "address", address, "codehash", codeHash)
+	return codeHash, nil
+}
+
+// RemoveContractABI removes the ABI registered for the code of the contract at
+// the given address, reporting whether there was one.
+func (api *ContractABIAPI) RemoveContractABI(address common.Address) (bool, error) {
+	codeHash, err := api.eth.contractCodeHash(address)
+	if err != nil {
+		return false, err
+	}
+	return api.eth.abiRegistry.Remove(codeHash), nil
+}
+
+// contractCodeHash returns the hash of the code of the contract at the given
+// address in the current state.
+func (s *Ethereum) contractCodeHash(address common.Address) (common.Hash, error) {
+	statedb, err := s.blockchain.State()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	codeHash := statedb.GetCodeHash(address)
+	if codeHash == (common.Hash{}) || codeHash == types.EmptyCodeHash {
+		return common.Hash{}, fmt.Errorf("no contract code at %v", address)
+	}
+	return codeHash, nil
+}
+
+// DecodeAPI provides the decoding of the chain data with the contract ABI
+// registry, in the ext namespace.
+type DecodeAPI struct {
+	eth *Ethereum
+}
+
+// NewDecodeAPI creates a new DecodeAPI instance.
+func NewDecodeAPI(eth *Ethereum) *DecodeAPI {
+	return &DecodeAPI{eth: eth}
+}
+
+// DecodedLog is an event log returned by ext_getDecodedLogs.
+type DecodedLog struct {
+	Address  common.Address       `json:"address"`
+	Topics   []common.Hash        `json:"topics"`
+	Data     hexutil.Bytes        `json:"data"`
+	LogIndex hexutil.Uint         `json:"logIndex"`
+	Decoded  *abiregistry.Decoded `json:"decoded"` // Nil if the ABI of the emitter is unknown
+}
+
+// GetDecodedLogs returns the logs of the receipt of the given transaction, with
+// the events decoded with the registered contract ABIs. The ABIs are resolved
+// with the code of the emitters in the current state, so the logs of contracts
+// which no longer exist are left undecoded.
+func (api *DecodeAPI) GetDecodedLogs(hash common.Hash) ([]DecodedLog, error) {
+	lookup, _ := api.eth.blockchain.GetTransactionLookup(hash)
+	if lookup == nil {
+		return nil, errors.New("transaction not found")
+	}
+	receipts := api.eth.blockchain.GetReceiptsByHash(lookup.BlockHash)
+	if int(lookup.Index) >= len(receipts) {
+		return nil, errors.New("receipt not found")
+	}
+	statedb, err := api.eth.blockchain.State()
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]DecodedLog, 0, len(receipts[lookup.Index].Logs))
+	for _, l := range receipts[lookup.Index].Logs {
+		contract := api.eth.abiRegistry.ContractABI(statedb.GetCodeHash(l.Address))
+		logs = append(logs, DecodedLog{
+			Address:  l.Address,
+			Topics:   l.Topics,
+			Data:     l.Data,
+			LogIndex: hexutil.Uint(l.Index),
+			Decoded:  abiregistry.DecodeLog(contract, l.Topics, l.Data),
+		})
+	}
+	return logs, nil
+}
@@ -21,11 +21,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -141,3 +147,136 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// ScheduledFork is the result of admin_scheduleFork.
+type ScheduledFork struct {
+	Name        string         `json:"name"`
+	Timestamp   hexutil.Uint64 `json:"timestamp"`
+	ForkHash    hexutil.Bytes  `json:"forkHash"`    // Local fork hash with the fork scheduled
+	ForkNext    hexutil.Uint64 `json:"forkNext"`    // Next local fork with the fork scheduled
+	Peers       int            `json:"peers"`       // Number of peers checked for compatibility
+	Unscheduled []string       `json:"unscheduled"` // Peers which advertised a different fork ID
+}
+
+// forkChain overrides the configuration of a chain, to check fork IDs against a
+// configuration which is not applied yet.
+type forkChain struct {
+	*core.BlockChain
+	config *params.ChainConfig
+}
+
+func (c *forkChain) Config() *params.ChainConfig { return c.config }
+
+// ScheduleFork sets the activation timestamp of a fork supported by the binary,
+// replacing the configured one if any. The fork must activate after the current
+// head. The schedule is refused if a connected peer would become incompatible,
+// and the peers which did not advertise the same fork ID in their handshake are
+// reported. The schedule is persisted to the data directory and reapplied over
+// the command line overrides on restart.
+func (api *AdminAPI) ScheduleFork(name string, timestamp hexutil.Uint64) (*ScheduledFork, error) {
+	api.eth.lock.Lock()
+	defer api.eth.lock.Unlock()
+
+	chain := api.eth.blockchain
+	config, err := chain.ForkConfig(name, uint64(timestamp))
+	if err != nil {
+		return nil, err
+	}
+	var (
+		head   = chain.CurrentHeader()
+		id     = forkid.NewID(config, chain.Genesis(), head.Number.Uint64(), head.Time)
+		filter = forkid.NewFilter(&forkChain{BlockChain: chain, config: config})
+		res    = &ScheduledFork{
+			Name:        name,
+			Timestamp:   timestamp,
+			ForkHash:    id.Hash[:],
+			ForkNext:    hexutil.Uint64(id.Next),
+			Unscheduled: []string{},
+		}
+	)
+	for _, peer := range api.eth.handler.peers.all() {
+		remote := peer.ForkID()
+		if err := filter(remote); err != nil {
+			return nil, fmt.Errorf("fork schedule incompatible with peer %s: %v", peer.ID(), err)
+		}
+		if remote != id {
+			res.Unscheduled = append(res.Unscheduled, peer.ID())
+		}
+		res.Peers++
+	}
+	// Persist the schedule before applying it, so it survives restarts
+	if api.eth.forkScheduleFile != "" {
+		schedule := maps.Clone(api.eth.forkSchedule)
+		schedule[name] = uint64(timestamp)
+		if err := schedule.Save(api.eth.forkScheduleFile); err != nil {
+			return nil, err
+		}
+		api.eth.forkSchedule = schedule
+	}
+	chain.SetChainConfig(config)
+	api.eth.updateIdentity()
+	log.Info("Scheduled fork", "name", name, "time", uint64(timestamp), "forkid", fmt.Sprintf("%#x", id.Hash), "next", id.Next)
+	return res, nil
+}
+
+// Promote takes over as the active node from standby, building payloads and
+// gossiping transactions from now on.
+func (api *AdminAPI) Promote() (bool, error) {
+	api.eth.lock.Lock()
+	defer api.eth.lock.Unlock()
+
+	if !api.eth.miner.Standby() {
+		return false, errors.New("node is not on standby")
+	}
+	api.eth.miner.SetStandby(false)
+	api.eth.handler.promote()
+
+	head := api.eth.blockchain.CurrentHeader()
+	log.Info("Promoted standby node", "number", head.Number, "hash", head.Hash())
+	return true, nil
+}
+
+// AttachLegacyState serves the historical state from the read-only hash scheme
+// database in the given directory, left by the migration of the node to the
+// path scheme, replacing the previously attached one if any.
+func (api *AdminAPI) AttachLegacyState(dir string) (*LegacyStateInfo, error) {
+	api.eth.lock.Lock()
+	defer api.eth.lock.Unlock()
+
+	return api.eth.attachLegacyState(dir)
+}
+
+// DetachLegacyState stops serving the historical state from the legacy hash
+// scheme database, once it is no longer needed.
+func (api *AdminAPI) DetachLegacyState() (bool, error) {
+	api.eth.lock.Lock()
+	defer api.eth.lock.Unlock()
+
+	if !api.eth.detachLegacyState() {
+		return false, errors.New("no legacy state attached")
+	}
+	return true, nil
+}
+
+// SyncPivot returns the strategy used to select the snap sync pivot.
+func (api *AdminAPI) SyncPivot() ethconfig.SyncPivot {
+	return api.eth.handler.downloader.PivotStrategy()
+}
+
+// SetSyncPivot changes the strategy used to select the snap sync pivot. The
+// change takes effect the next time the pivot is selected or moved.
+func (api *AdminAPI) SetSyncPivot(pivot ethconfig.SyncPivot) (bool, error) {
+	if err := api.eth.handler.downloader.SetPivotStrategy(pivot); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// LegacyState returns the attached legacy state, nil if none.
+func (api *AdminAPI) LegacyState() *LegacyStateInfo {
+	legacy := api.eth.legacyState.Load()
+	if legacy == nil {
+		return nil
+	}
+	return &LegacyStateInfo{Dir: legacy.dir, MigrationBlock: hexutil.Uint64(legacy.head)}
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -54,6 +55,7 @@ type EthAPIBackend struct {
 	disableTxPool       bool
 	eth                 *Ethereum
 	gpo                 *gasprice.Oracle
+	callPrefetcher      *ethapi.CallPrefetcher
 }
 
 // ChainConfig returns the active chain configuration.
@@ -65,9 +67,9 @@ func (b *EthAPIBackend) CurrentBlock() *types.Header {
 	return b.eth.blockchain.CurrentBlock()
 }
 
-func (b *EthAPIBackend) SetHead(number uint64) {
+func (b *EthAPIBackend) SetHead(number uint64) error {
 	b.eth.handler.downloader.Cancel()
-	b.eth.blockchain.SetHead(number)
+	return b.eth.blockchain.SetHead(number)
 }
 
 func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
@@ -239,10 +241,11 @@ func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 	if header == nil {
 		return nil, nil, fmt.Errorf("header %w", ethereum.NotFound)
 	}
-	stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+	stateDb, err := b.eth.stateAt(header)
 	if err != nil {
 		return nil, nil, err
 	}
+	trackStateReads(ctx, stateDb)
 	return stateDb, header, nil
 }
 
@@ -261,10 +264,11 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 		if blockNrOrHash.RequireCanonical && b.eth.blockchain.GetCanonicalHash(header.Number.Uint64()) != hash {
 			return nil, nil, errors.New("hash is not currently canonical")
 		}
-		stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+		stateDb, err := b.eth.stateAt(header)
 		if err != nil {
 			return nil, nil, err
 		}
+		trackStateReads(ctx, stateDb)
 		return stateDb, header, nil
 	}
 	return nil, nil, errors.New("invalid arguments; neither block nor hash specified")
@@ -275,11 +279,21 @@ func (b *EthAPIBackend) HistoryPruningCutoff() uint64 {
 	return bn
 }
 
+// trackStateReads accounts the state loaded from the database through the given
+// state database to the RPC call of the context.
+func trackStateReads(ctx context.Context, statedb *state.StateDB) {
+	rpc.TrackDBReads(ctx, func() int {
+		return statedb.AccountLoaded + statedb.StorageLoaded
+	})
+}
+
 func (b *EthAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
+	rpc.AddDBReads(ctx, 1)
 	return b.eth.blockchain.GetReceiptsByHash(hash), nil
 }
 
 func (b *EthAPIBackend) GetLogs(ctx context.Context, hash common.Hash, number uint64) ([][]*types.Log, error) {
+	rpc.AddDBReads(ctx, 1)
 	return rawdb.ReadLogs(b.eth.chainDb, hash, number), nil
 }
 
@@ -313,6 +327,9 @@ func (b *EthAPIBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscri
 }
 
 func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
+	if b.eth.draining.Load() {
+		return errors.New("node is shutting down")
+	}
 	if b.ChainConfig().IsOptimism() && signedTx.Type() == types.BlobTxType {
 		return types.ErrTxTypeNotSupported
 	}
@@ -435,6 +452,10 @@ func (b *EthAPIBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	return b.gpo.SuggestTipCap(ctx)
 }
 
+func (b *EthAPIBackend) SuggestGasTipCapFor(ctx context.Context, blocks uint64) (*big.Int, error) {
+	return b.gpo.SuggestTipCapFor(ctx, blocks)
+}
+
 func (b *EthAPIBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (firstBlock *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, baseFeePerBlobGas []*big.Int, blobGasUsedRatio []float64, err error) {
 	return b.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
@@ -446,6 +467,18 @@ func (b *EthAPIBackend) BlobBaseFee(ctx context.Context) *big.Int {
 	return nil
 }
 
+// ContractABIs returns the contract ABI registry, which the tracers decode the
+// calls and logs with.
+func (b *EthAPIBackend) ContractABIs() tracers.ContractABIs {
+	return b.eth.abiRegistry
+}
+
+// ReserveTrace implements the memory budget of the tracers, shedding the traces
+// under memory pressure.
+func (b *EthAPIBackend) ReserveTrace(heavy bool) (func(), int, error) {
+	return b.eth.reserveTrace(heavy)
+}
+
 func (b *EthAPIBackend) ChainDb() ethdb.Database {
 	return b.eth.ChainDb()
 }
@@ -463,11 +496,25 @@ func (b *EthAPIBackend) UnprotectedAllowed() bool {
 }
 
 func (b *EthAPIBackend) RPCGasCap() uint64 {
-	return b.eth.config.RPCGasCap
+	return b.eth.rpcGasCap.Load()
+}
+
+// SetRPCGasCap changes the gas cap of RPC calls at runtime.
+func (b *EthAPIBackend) SetRPCGasCap(cap uint64) {
+	b.eth.rpcGasCap.Store(cap)
 }
 
 func (b *EthAPIBackend) RPCEVMTimeout() time.Duration {
-	return b.eth.config.RPCEVMTimeout
+	return time.Duration(b.eth.rpcEVMTimeout.Load())
+}
+
+// SetRPCEVMTimeout changes the EVM timeout of RPC calls at runtime.
+func (b *EthAPIBackend) SetRPCEVMTimeout(timeout time.Duration) {
+	b.eth.rpcEVMTimeout.Store(int64(timeout))
+}
+
+func (b *EthAPIBackend) CallPrefetcher() *ethapi.CallPrefetcher {
+	return b.callPrefetcher
 }
 
 func (b *EthAPIBackend) RPCTxFeeCap() float64 {
@@ -495,11 +542,19 @@ func (b *EthAPIBackend) CurrentHeader() *types.Header {
 }
 
 func (b *EthAPIBackend) StateAtBlock(ctx context.Context, block *types.Block, reexec uint64, base *state.StateDB, readOnly bool, preferDisk bool) (*state.StateDB, tracers.StateReleaseFunc, error) {
-	return b.eth.stateAtBlock(ctx, block, reexec, base, readOnly, preferDisk)
+	statedb, release, err := b.eth.stateAtBlock(ctx, block, reexec, base, readOnly, preferDisk)
+	if err == nil {
+		trackStateReads(ctx, statedb)
+	}
+	return statedb, release, err
 }
 
 func (b *EthAPIBackend) StateAtTransaction(ctx context.Context, block *types.Block, txIndex int, reexec uint64) (*types.Transaction, vm.BlockContext, *state.StateDB, tracers.StateReleaseFunc, error) {
-	return b.eth.stateAtTransaction(ctx, block, txIndex, reexec)
+	tx, blockCtx, statedb, release, err := b.eth.stateAtTransaction(ctx, block, txIndex, reexec)
+	if err == nil {
+		trackStateReads(ctx, statedb)
+	}
+	return tx, blockCtx, statedb, release, err
 }
 
 func (b *EthAPIBackend) HistoricalRPCService() *rpc.Client {
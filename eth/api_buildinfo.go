@@ -0,0 +1,127 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"runtime"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/forkid"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/internal/version"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// BuildInfo describes the build of the node and the identity of the chain it
+// runs, to tell apart nodes running incompatible forks of either.
+type BuildInfo struct {
+	Version      string         `json:"version"`
+	Commit       string         `json:"commit"`
+	CommitDate   string         `json:"commitDate"`
+	Dirty        bool           `json:"dirty"`
+	GoVersion    string         `json:"goVersion"`
+	BuildTags    []string       `json:"buildTags"`
+	Experimental []string       `json:"experimental"` // Experimental features enabled
+	ChainID      *hexutil.Big   `json:"chainId"`
+	NetworkID    hexutil.Uint64 `json:"networkId"`
+	GenesisHash  common.Hash    `json:"genesisHash"`
+	ConfigHash   common.Hash    `json:"chainConfigHash"`
+	ForkHash     hexutil.Bytes  `json:"forkHash"` // EIP-2124 fork hash at the current head
+	ForkNext     hexutil.Uint64 `json:"forkNext"`
+
+	// PeerConfigMismatches counts the peers seen running the same genesis with a
+	// different chain config hash since startup.
+	PeerConfigMismatches hexutil.Uint64 `json:"peerConfigMismatches"`
+}
+
+// experimentalFeatures returns the flags of the experimental features enabled
+// in the given configuration.
+func experimentalFeatures(config *ethconfig.Config) []string {
+	features := []string{}
+	if config.VMCodeAnalysis {
+		features = append(features, "vm.experimental.analysis")
+	}
+	if config.VMCodeAnalysisShadow {
+		features = append(features, "vm.experimental.shadow")
+	}
+	if config.StateRent {
+		features = append(features, "state.rent")
+	}
+	if config.InteropMessageRPC != "" {
+		features = append(features, "rollup.interoprpc")
+	}
+	if config.InteropMempoolFiltering {
+		features = append(features, "rollup.interopmempoolfiltering")
+	}
+	return features
+}
+
+// updateIdentity advertises the current build and chain identity, both in the
+// devp2p handshake and in the local node record. It needs to be called whenever
+// the chain config changes.
+func (s *Ethereum) updateIdentity() {
+	identity := eth.NewIdentity(s.blockchain)
+	blob, _ := rlp.EncodeToBytes(identity) // cannot fail
+	s.p2pServer.SetIdentity(blob)
+	if ln := s.p2pServer.LocalNode(); ln != nil {
+		ln.Set(identity)
+	}
+}
+
+// BuildInfoAPI provides an API to access the build and chain identity of the
+// node.
+type BuildInfoAPI struct {
+	eth *Ethereum
+}
+
+// NewBuildInfoAPI creates a new build info API.
+func NewBuildInfoAPI(eth *Ethereum) *BuildInfoAPI {
+	return &BuildInfoAPI{eth: eth}
+}
+
+// BuildInfo returns the build of the node and the identity of its chain.
+func (api *BuildInfoAPI) BuildInfo() *BuildInfo {
+	var (
+		chain  = api.eth.blockchain
+		head   = chain.CurrentHeader()
+		vcs, _ = version.VCS()
+		tags   = version.BuildTags()
+		forkID = forkid.NewID(chain.Config(), chain.Genesis(), head.Number.Uint64(), head.Time)
+	)
+	if tags == nil {
+		tags = []string{}
+	}
+	return &BuildInfo{
+		Version:      version.WithMeta,
+		Commit:       vcs.Commit,
+		CommitDate:   vcs.Date,
+		Dirty:        vcs.Dirty,
+		GoVersion:    runtime.Version(),
+		BuildTags:    tags,
+		Experimental: experimentalFeatures(api.eth.config),
+		ChainID:      (*hexutil.Big)(chain.Config().ChainID),
+		NetworkID:    hexutil.Uint64(api.eth.networkID),
+		GenesisHash:  chain.Genesis().Hash(),
+		ConfigHash:   eth.ConfigHash(chain.Config()),
+		ForkHash:     forkID.Hash[:],
+		ForkNext:     hexutil.Uint64(forkID.Next),
+
+		PeerConfigMismatches: hexutil.Uint64(api.eth.handler.configMismatches.Load()),
+	}
+}
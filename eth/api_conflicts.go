@@ -0,0 +1,333 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+const (
+	// conflictGraphReexec is the number of blocks debug_blockConflictGraph
+	// re-executes at most to regenerate the parent state of the block.
+	conflictGraphReexec = 128
+
+	// conflictGraphHotspots is the maximum number of contended state items
+	// reported by debug_blockConflictGraph.
+	conflictGraphHotspots = 32
+)
+
+// stateKey is an item of the state accessed by a transaction: the fields of an
+// account (balance, nonce and code) if root is set, a storage slot otherwise.
+type stateKey struct {
+	addr common.Address
+	slot common.Hash
+	root bool // Whether the key is the account itself rather than a slot
+}
+
+// accessSet is the set of state items accessed by a transaction.
+type accessSet map[stateKey]struct{}
+
+// list converts the set to the RPC representation, sorted by address and slot.
+func (set accessSet) list() []*StateAccess {
+	byAddr := make(map[common.Address]*StateAccess)
+	for key := range set {
+		access := byAddr[key.addr]
+		if access == nil {
+			access = &StateAccess{Address: key.addr}
+			byAddr[key.addr] = access
+		}
+		if key.root {
+			access.Account = true
+		} else {
+			access.Slots = append(access.Slots, key.slot)
+		}
+	}
+	list := make([]*StateAccess, 0, len(byAddr))
+	for _, access := range byAddr {
+		slices.SortFunc(access.Slots, func(a, b common.Hash) int { return bytes.Compare(a[:], b[:]) })
+		list = append(list, access)
+	}
+	slices.SortFunc(list, func(a, b *StateAccess) int { return bytes.Compare(a.Address[:], b.Address[:]) })
+	return list
+}
+
+// intersect returns the items of the set also contained in the other one.
+func (set accessSet) intersect(other accessSet) accessSet {
+	var res accessSet
+	for key := range set {
+		if _, ok := other[key]; ok {
+			if res == nil {
+				res = make(accessSet)
+			}
+			res[key] = struct{}{}
+		}
+	}
+	return res
+}
+
+// conflictTracer records the read and write sets of the transactions of a block.
+type conflictTracer struct {
+	reads  []accessSet
+	writes []accessSet
+	index  int // Index of the executing transaction, -1 outside of transactions
+}
+
+func newConflictTracer() *conflictTracer {
+	return &conflictTracer{index: -1}
+}
+
+func (t *conflictTracer) hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnTxStart:       t.onTxStart,
+		OnTxEnd:         func(*types.Receipt, error) { t.index = -1 },
+		OnOpcode:        t.onOpcode,
+		OnBalanceChange: t.onBalanceChange,
+		OnNonceChange:   func(addr common.Address, prev, new uint64) { t.write(stateKey{addr: addr, root: true}) },
+		OnCodeChange: func(addr common.Address, prevCodeHash common.Hash, prev []byte, codeHash common.Hash, code []byte) {
+			t.write(stateKey{addr: addr, root: true})
+		},
+		OnStorageChange: func(addr common.Address, slot common.Hash, prev, new common.Hash) {
+			t.write(stateKey{addr: addr, slot: slot})
+		},
+	}
+}
+
+func (t *conflictTracer) read(key stateKey) {
+	if t.index >= 0 {
+		t.reads[t.index][key] = struct{}{}
+	}
+}
+
+func (t *conflictTracer) write(key stateKey) {
+	if t.index >= 0 {
+		t.writes[t.index][key] = struct{}{}
+	}
+}
+
+func (t *conflictTracer) onTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.index = len(t.reads)
+	t.reads = append(t.reads, make(accessSet))
+	t.writes = append(t.writes, make(accessSet))
+
+	t.read(stateKey{addr: from, root: true})
+	if to := tx.To(); to != nil {
+		t.read(stateKey{addr: *to, root: true})
+	}
+}
+
+// onBalanceChange records the balance changes as writes, except for the fee
+// payments: they commute, so do not order the transactions crediting them.
+func (t *conflictTracer) onBalanceChange(addr common.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+	if reason == tracing.BalanceIncreaseRewardTransactionFee {
+		return
+	}
+	t.write(stateKey{addr: addr, root: true})
+}
+
+func (t *conflictTracer) onOpcode(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if err != nil || t.index < 0 {
+		return
+	}
+	var (
+		stack = scope.StackData()
+		size  = len(stack)
+	)
+	switch op := vm.OpCode(opcode); {
+	case size >= 1 && (op == vm.SLOAD || op == vm.SSTORE):
+		t.read(stateKey{addr: scope.Address(), slot: common.Hash(stack[size-1].Bytes32())})
+	case size >= 1 && (op == vm.BALANCE || op == vm.EXTCODESIZE || op == vm.EXTCODEHASH || op == vm.EXTCODECOPY):
+		t.read(stateKey{addr: common.Address(stack[size-1].Bytes20()), root: true})
+	case op == vm.SELFBALANCE:
+		t.read(stateKey{addr: scope.Address(), root: true})
+	case size >= 2 && (op == vm.CALL || op == vm.CALLCODE || op == vm.DELEGATECALL || op == vm.STATICCALL):
+		t.read(stateKey{addr: common.Address(stack[size-2].Bytes20()), root: true})
+	}
+}
+
+// StateAccess is the state of an account accessed by a transaction.
+type StateAccess struct {
+	Address common.Address `json:"address"`
+	Account bool           `json:"account"` // Whether the balance, nonce or code was accessed
+	Slots   []common.Hash  `json:"slots,omitempty"`
+}
+
+// TxAccessSets are the state items read and written by a transaction.
+type TxAccessSets struct {
+	Index  hexutil.Uint   `json:"index"`
+	TxHash common.Hash    `json:"txHash"`
+	Depth  hexutil.Uint   `json:"depth"` // Length of the longest dependency chain ending at the transaction
+	Reads  []*StateAccess `json:"reads"`
+	Writes []*StateAccess `json:"writes"`
+}
+
+// AccessConflict is a dependency of a transaction on an earlier one of the block,
+// either reading ("read-after-write") or overwriting ("write-after-write") the
+// state written by it.
+type AccessConflict struct {
+	From hexutil.Uint   `json:"from"`
+	To   hexutil.Uint   `json:"to"`
+	Kind string         `json:"kind"`
+	Keys []*StateAccess `json:"keys"`
+}
+
+// AccessHotspot is a state item accessed by several transactions of a block.
+type AccessHotspot struct {
+	Address common.Address `json:"address"`
+	Slot    *common.Hash   `json:"slot"` // Nil for the account itself
+	Readers hexutil.Uint   `json:"readers"`
+	Writers hexutil.Uint   `json:"writers"`
+}
+
+// BlockConflictGraph is the state access conflict graph of the transactions of
+// a block.
+type BlockConflictGraph struct {
+	Number       hexutil.Uint64    `json:"number"`
+	Hash         common.Hash       `json:"hash"`
+	Transactions []*TxAccessSets   `json:"transactions"`
+	Conflicts    []*AccessConflict `json:"conflicts"`
+	Hotspots     []*AccessHotspot  `json:"hotspots"`     // Most contended state items
+	CriticalPath hexutil.Uint      `json:"criticalPath"` // Length of the longest dependency chain
+}
+
+// BlockConflictGraph re-executes the block with the given hash, recording the
+// state read and written by each transaction, and reports the conflicts between
+// them: the dependencies constraining their parallel execution. Fee payments to
+// the block producer and the fee vaults are not considered conflicting.
+func (api *DebugAPI) BlockConflictGraph(ctx context.Context, hash common.Hash) (*BlockConflictGraph, error) {
+	chain := api.eth.blockchain
+	block := chain.GetBlockByHash(hash)
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", hash.Hex())
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not executable")
+	}
+	parent := chain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %s not found", block.ParentHash().Hex())
+	}
+	statedb, release, err := api.eth.stateAtBlock(ctx, parent, conflictGraphReexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	tracer := newConflictTracer()
+	if _, err := core.NewStateProcessor(chain.Config(), chain.HeaderChain()).Process(block, statedb, vm.Config{Tracer: tracer.hooks()}); err != nil {
+		return nil, err
+	}
+	return newBlockConflictGraph(block, tracer.reads, tracer.writes), nil
+}
+
+// newBlockConflictGraph assembles the conflict graph of the block from the read
+// and write sets of its transactions.
+func newBlockConflictGraph(block *types.Block, reads, writes []accessSet) *BlockConflictGraph {
+	graph := &BlockConflictGraph{
+		Number:       hexutil.Uint64(block.NumberU64()),
+		Hash:         block.Hash(),
+		Transactions: make([]*TxAccessSets, len(reads)),
+		Conflicts:    []*AccessConflict{},
+		Hotspots:     []*AccessHotspot{},
+	}
+	var (
+		txs      = block.Transactions()
+		depths   = make([]uint, len(reads))
+		readers  = make(map[stateKey]uint)
+		writers  = make(map[stateKey]uint)
+		hotspots []stateKey
+	)
+	for j := range reads {
+		for i := 0; i < j; i++ {
+			raw, waw := writes[i].intersect(reads[j]), writes[i].intersect(writes[j])
+			if raw != nil {
+				graph.Conflicts = append(graph.Conflicts, &AccessConflict{From: hexutil.Uint(i), To: hexutil.Uint(j), Kind: "read-after-write", Keys: raw.list()})
+			}
+			if waw != nil {
+				graph.Conflicts = append(graph.Conflicts, &AccessConflict{From: hexutil.Uint(i), To: hexutil.Uint(j), Kind: "write-after-write", Keys: waw.list()})
+			}
+			if (raw != nil || waw != nil) && depths[i] > depths[j] {
+				depths[j] = depths[i]
+			}
+		}
+		depths[j]++
+		if depths[j] > uint(graph.CriticalPath) {
+			graph.CriticalPath = hexutil.Uint(depths[j])
+		}
+		graph.Transactions[j] = &TxAccessSets{
+			Index:  hexutil.Uint(j),
+			Depth:  hexutil.Uint(depths[j]),
+			Reads:  reads[j].list(),
+			Writes: writes[j].list(),
+		}
+		if j < len(txs) {
+			graph.Transactions[j].TxHash = txs[j].Hash()
+		}
+		for key := range reads[j] {
+			if _, ok := writes[j][key]; !ok {
+				readers[key]++
+			}
+		}
+		for key := range writes[j] {
+			writers[key]++
+		}
+	}
+	// Report the state items accessed by the most transactions, as long as one
+	// of them at least writes it
+	for key, count := range writers {
+		if count+readers[key] > 1 {
+			hotspots = append(hotspots, key)
+		}
+	}
+	sort.Slice(hotspots, func(i, j int) bool {
+		a, b := hotspots[i], hotspots[j]
+		if ca, cb := readers[a]+writers[a], readers[b]+writers[b]; ca != cb {
+			return ca > cb
+		}
+		if c := bytes.Compare(a.addr[:], b.addr[:]); c != 0 {
+			return c < 0
+		}
+		if a.root != b.root {
+			return a.root
+		}
+		return bytes.Compare(a.slot[:], b.slot[:]) < 0
+	})
+	if len(hotspots) > conflictGraphHotspots {
+		hotspots = hotspots[:conflictGraphHotspots]
+	}
+	for _, key := range hotspots {
+		spot := &AccessHotspot{Address: key.addr, Readers: hexutil.Uint(readers[key]), Writers: hexutil.Uint(writers[key])}
+		if !key.root {
+			slot := key.slot
+			spot.Slot = &slot
+		}
+		graph.Hotspots = append(graph.Hotspots, spot)
+	}
+	return graph
+}
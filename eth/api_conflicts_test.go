@@ -0,0 +1,112 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that transactions incrementing the same counter are reported as
+// conflicting, while an unrelated transfer is left independent.
+func TestBlockConflictGraph(t *testing.T) {
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		key1, _  = crypto.GenerateKey()
+		key2, _  = crypto.GenerateKey()
+		addr1    = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2    = crypto.PubkeyToAddress(key2.PublicKey)
+		counter  = common.HexToAddress("0xc0de")
+		receiver = common.HexToAddress("0xdead")
+
+		// slot0 = slot0 + 1
+		code = []byte{byte(vm.PUSH1), 1, byte(vm.PUSH0), byte(vm.SLOAD), byte(vm.ADD), byte(vm.PUSH0), byte(vm.SSTORE), byte(vm.STOP)}
+
+		gspec = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				testAddr: {Balance: big.NewInt(params.Ether)},
+				addr1:    {Balance: big.NewInt(params.Ether)},
+				addr2:    {Balance: big.NewInt(params.Ether)},
+				counter:  {Code: code},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = beacon.New(ethash.NewFaker())
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 1, func(i int, gen *core.BlockGen) {
+		tx1, _ := types.SignTx(types.NewTransaction(0, counter, nil, 100000, gen.BaseFee(), nil), signer, testKey)
+		tx2, _ := types.SignTx(types.NewTransaction(0, counter, nil, 100000, gen.BaseFee(), nil), signer, key1)
+		tx3, _ := types.SignTx(types.NewTransaction(0, receiver, big.NewInt(1), 21000, gen.BaseFee(), nil), signer, key2)
+		gen.AddTx(tx1)
+		gen.AddTx(tx2)
+		gen.AddTx(tx3)
+	})
+	chain, _ := core.NewBlockChain(db, nil, gspec, nil, engine, vm.Config{}, nil)
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	api := NewDebugAPI(&Ethereum{blockchain: chain, chainDb: db})
+
+	graph, err := api.BlockConflictGraph(context.Background(), blocks[0].Hash())
+	if err != nil {
+		t.Fatalf("failed to build conflict graph: %v", err)
+	}
+	if len(graph.Transactions) != 3 {
+		t.Fatalf("transaction count mismatch: have %d, want 3", len(graph.Transactions))
+	}
+	for i, tx := range blocks[0].Transactions() {
+		if graph.Transactions[i].TxHash != tx.Hash() {
+			t.Errorf("tx %d: hash mismatch: have %x, want %x", i, graph.Transactions[i].TxHash, tx.Hash())
+		}
+	}
+	// Only the counter increments conflict, both reading and writing the slot
+	kinds := make(map[string]bool)
+	for _, c := range graph.Conflicts {
+		if c.From != 0 || c.To != 1 {
+			t.Fatalf("unexpected conflict %d -> %d", c.From, c.To)
+		}
+		if len(c.Keys) != 1 || c.Keys[0].Address != counter || len(c.Keys[0].Slots) != 1 || c.Keys[0].Slots[0] != (common.Hash{}) {
+			t.Errorf("%s conflict keys mismatch: %+v", c.Kind, c.Keys)
+		}
+		kinds[c.Kind] = true
+	}
+	if !kinds["read-after-write"] || !kinds["write-after-write"] {
+		t.Errorf("conflict kinds mismatch: have %v", kinds)
+	}
+	if graph.CriticalPath != 2 {
+		t.Errorf("critical path mismatch: have %d, want 2", graph.CriticalPath)
+	}
+	if depth := graph.Transactions[2].Depth; depth != 1 {
+		t.Errorf("transfer depth mismatch: have %d, want 1", depth)
+	}
+	if len(graph.Hotspots) != 1 || graph.Hotspots[0].Address != counter || graph.Hotspots[0].Writers != 2 {
+		t.Errorf("hotspots mismatch: %+v", graph.Hotspots)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -28,13 +30,20 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/crosscheck"
+	"github.com/ethereum/go-ethereum/eth/watchdog"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
 )
 
 // DebugAPI is the collection of Ethereum full node APIs for debugging the
@@ -89,6 +98,29 @@ func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
 	return stateDb.RawDump(opts), nil
 }
 
+// DumpChunkMaxItems is the maximum number of accounts and storage slots of a
+// state chunk returned over RPC.
+const DumpChunkMaxItems = 10000
+
+// DumpBlockChunk returns the chunk of the state at the given block starting at
+// the given account hash, holding at least maxItems accounts and storage slots
+// unless the end of the state is reached. The chunk carries the Merkle proof of
+// its range and the start of the next chunk, so the whole state can be streamed
+// and verified chunk by chunk.
+func (api *DebugAPI) DumpBlockChunk(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, start common.Hash, maxItems int) (*state.StateChunk, error) {
+	if number, ok := blockNrOrHash.Number(); ok && number == rpc.PendingBlockNumber {
+		return nil, errors.New("pending state cannot be dumped in chunks")
+	}
+	stateDb, _, err := api.eth.APIBackend.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if maxItems > DumpChunkMaxItems || maxItems <= 0 {
+		maxItems = DumpChunkMaxItems
+	}
+	return stateDb.DumpChunk(start, maxItems)
+}
+
 // Preimage is a debug API function that returns the preimage for a sha3 hash, if known.
 func (api *DebugAPI) Preimage(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
 	if preimage := rawdb.ReadPreimage(api.eth.ChainDb(), hash); preimage != nil {
@@ -134,6 +166,31 @@ func (api *DebugAPI) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error)
 	return results, nil
 }
 
+// BadBlockArtifact is the result of debug_getBadBlockArtifacts.
+type BadBlockArtifact struct {
+	BadBlockInfo
+	Path  string        `json:"path"`            // Directory of the artifact files
+	Block hexutil.Bytes `json:"block,omitempty"` // RLP of the block
+	Trace string        `json:"trace,omitempty"` // Re-execution trace, one JSON object per line
+}
+
+// GetBadBlockArtifacts returns the artifacts captured for the last blocks which
+// failed validation, newest first. Without a hash only the descriptions are
+// returned, with a hash the artifacts of that block are exported in full.
+func (api *DebugAPI) GetBadBlockArtifacts(hash *common.Hash) ([]*BadBlockArtifact, error) {
+	if api.eth.badBlocks == nil {
+		return nil, errors.New("bad block capture is not enabled")
+	}
+	artifacts, err := api.eth.badBlocks.artifacts(hash, hash != nil)
+	if err != nil {
+		return nil, err
+	}
+	if hash != nil && len(artifacts) == 0 {
+		return nil, fmt.Errorf("no artifacts for bad block %#x", *hash)
+	}
+	return artifacts, nil
+}
+
 // AccountRangeMaxResults is the maximum number of results to be returned per call
 const AccountRangeMaxResults = 256
 
@@ -425,6 +482,122 @@ func (api *DebugAPI) GetAccessibleState(from, to rpc.BlockNumber) (uint64, error
 	return 0, errors.New("no state found")
 }
 
+// BlockStatsMaxRange is the maximum number of blocks whose execution statistics
+// can be requested per call.
+const BlockStatsMaxRange = 1024
+
+// BlockStats returns the execution statistics of the canonical blocks in the
+// given inclusive range, as gathered while the blocks were imported. Only the
+// recently imported blocks are retained, blocks without statistics are omitted.
+func (api *DebugAPI) BlockStats(from, to rpc.BlockNumber) ([]*core.BlockStats, error) {
+	resolveNum := func(num rpc.BlockNumber) uint64 {
+		// Statistics only exist for imported blocks, treat tags as latest
+		if num.Int64() < 0 {
+			return api.eth.blockchain.CurrentBlock().Number.Uint64()
+		}
+		return uint64(num.Int64())
+	}
+	start, end := resolveNum(from), resolveNum(to)
+	if start > end {
+		return nil, fmt.Errorf("invalid range: from %d > to %d", start, end)
+	}
+	if end-start >= BlockStatsMaxRange {
+		return nil, fmt.Errorf("range too large: %d blocks, max %d", end-start+1, BlockStatsMaxRange)
+	}
+	stats := api.eth.blockchain.BlockStats(start, end)
+	if stats == nil {
+		stats = []*core.BlockStats{}
+	}
+	return stats, nil
+}
+
+// stateRentDefaultLimit is the number of accounts returned by debug_stateRent
+// if no limit is requested.
+const stateRentDefaultLimit = 100
+
+// errStateRentDisabled is returned when the state rent ledger is requested
+// without being enabled.
+var errStateRentDisabled = errors.New("state rent ledger is disabled")
+
+// StateRentAccount is the rent accounted to an account in the state rent
+// ledger.
+type StateRentAccount struct {
+	Address      common.Address `json:"address"`
+	FirstTouched hexutil.Uint64 `json:"firstTouched"`
+	LastTouched  hexutil.Uint64 `json:"lastTouched"`
+	Touches      hexutil.Uint64 `json:"touches"`
+	Charged      *hexutil.Big   `json:"charged"`
+}
+
+func newStateRentAccount(account *core.StateRentAccount) *StateRentAccount {
+	return &StateRentAccount{
+		Address:      account.Address,
+		FirstTouched: hexutil.Uint64(account.FirstTouched),
+		LastTouched:  hexutil.Uint64(account.LastTouched),
+		Touches:      hexutil.Uint64(account.Touches),
+		Charged:      (*hexutil.Big)(account.Charged),
+	}
+}
+
+// StateRentResult is the result of debug_stateRent.
+type StateRentResult struct {
+	Rate        hexutil.Uint64      `json:"rate"`
+	TouchFee    hexutil.Uint64      `json:"touchFee"`
+	AccountSize hexutil.Uint64      `json:"accountSize"`
+	SlotSize    hexutil.Uint64      `json:"slotSize"`
+	Blocks      hexutil.Uint64      `json:"blocks"`
+	Accounts    hexutil.Uint64      `json:"accounts"`
+	Evicted     hexutil.Uint64      `json:"evicted"` // Accounts evicted from the ledger, their charges are kept
+	Charged     *hexutil.Big        `json:"charged"`
+	Top         []*StateRentAccount `json:"top"`
+}
+
+// StateRent returns the totals of the hypothetical state rent ledger, along
+// with the accounts charged the most, 100 by default.
+func (api *DebugAPI) StateRent(limit *hexutil.Uint) (*StateRentResult, error) {
+	ledger := api.eth.blockchain.StateRentLedger()
+	if ledger == nil {
+		return nil, errStateRentDisabled
+	}
+	n := stateRentDefaultLimit
+	if limit != nil {
+		n = int(*limit)
+	}
+	var (
+		config                    = ledger.Config()
+		blocks, accounts, charged = ledger.Totals()
+		res                       = &StateRentResult{
+			Rate:        hexutil.Uint64(config.Rate),
+			TouchFee:    hexutil.Uint64(config.TouchFee),
+			AccountSize: hexutil.Uint64(config.AccountSize),
+			SlotSize:    hexutil.Uint64(config.SlotSize),
+			Blocks:      hexutil.Uint64(blocks),
+			Accounts:    hexutil.Uint64(accounts),
+			Evicted:     hexutil.Uint64(ledger.Evicted()),
+			Charged:     (*hexutil.Big)(charged),
+			Top:         []*StateRentAccount{},
+		}
+	)
+	for _, account := range ledger.Top(n) {
+		res.Top = append(res.Top, newStateRentAccount(account))
+	}
+	return res, nil
+}
+
+// StateRentAccount returns the rent accounted to the given account in the
+// hypothetical state rent ledger, or null if the account was never touched.
+func (api *DebugAPI) StateRentAccount(address common.Address) (*StateRentAccount, error) {
+	ledger := api.eth.blockchain.StateRentLedger()
+	if ledger == nil {
+		return nil, errStateRentDisabled
+	}
+	account := ledger.Account(address)
+	if account == nil {
+		return nil, nil
+	}
+	return newStateRentAccount(account), nil
+}
+
 // SetTrieFlushInterval configures how often in-memory tries are persisted
 // to disk. The value is in terms of block processing time, not wall clock.
 // If the value is shorter than the block generation time, or even 0 or negative,
@@ -449,6 +622,79 @@ func (api *DebugAPI) GetTrieFlushInterval() (string, error) {
 	return api.eth.blockchain.GetTrieFlushInterval().String(), nil
 }
 
+// IntegrityFailure is a single integrity violation found by the chain auditor.
+type IntegrityFailure struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	Kind   string         `json:"kind"`
+	Reason string         `json:"reason"`
+	Time   time.Time      `json:"time"`
+}
+
+// IntegrityReport is the result of debug_integrityReport.
+type IntegrityReport struct {
+	From     hexutil.Uint64     `json:"from"`
+	To       hexutil.Uint64     `json:"to"`
+	Position hexutil.Uint64     `json:"position"`
+	Passes   hexutil.Uint64     `json:"passes"`
+	Checked  hexutil.Uint64     `json:"checked"`
+	Failed   hexutil.Uint64     `json:"failed"`
+	LastPass *time.Time         `json:"lastPass"`
+	Failures []IntegrityFailure `json:"failures"`
+}
+
+// IntegrityReport returns the progress and the most recent findings of the
+// background chain data auditor.
+func (api *DebugAPI) IntegrityReport() (*IntegrityReport, error) {
+	auditor := api.eth.ChainAuditor()
+	if auditor == nil {
+		return nil, errors.New("chain auditor is not enabled")
+	}
+	report := auditor.Report()
+	res := &IntegrityReport{
+		From:     hexutil.Uint64(report.From),
+		To:       hexutil.Uint64(report.To),
+		Position: hexutil.Uint64(report.Position),
+		Passes:   hexutil.Uint64(report.Passes),
+		Checked:  hexutil.Uint64(report.Checked),
+		Failed:   hexutil.Uint64(report.Failed),
+		Failures: make([]IntegrityFailure, 0, len(report.Failures)),
+	}
+	if !report.LastPass.IsZero() {
+		res.LastPass = &report.LastPass
+	}
+	for _, f := range report.Failures {
+		res.Failures = append(res.Failures, IntegrityFailure{
+			Number: hexutil.Uint64(f.Number),
+			Hash:   f.Hash,
+			Kind:   f.Kind,
+			Reason: f.Reason,
+			Time:   f.Time,
+		})
+	}
+	return res, nil
+}
+
+// HeadWatchdog returns the state of the chain head watchdog, comparing the
+// local head against the network.
+func (api *DebugAPI) HeadWatchdog() (*watchdog.Status, error) {
+	wd := api.eth.Watchdog()
+	if wd == nil {
+		return nil, errors.New("chain head watchdog is not enabled")
+	}
+	return wd.Status(), nil
+}
+
+// CrossCheck returns the progress of the execution cross-check against the
+// reference node, along with the last divergence detected.
+func (api *DebugAPI) CrossCheck() (*crosscheck.Status, error) {
+	cc := api.eth.CrossChecker()
+	if cc == nil {
+		return nil, errors.New("execution cross-check is not enabled")
+	}
+	return cc.Status(), nil
+}
+
 func (api *DebugAPI) ExecutionWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*stateless.ExecutionWitness, error) {
 	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
 	if err != nil {
@@ -491,3 +737,326 @@ func generateWitness(blockchain *core.BlockChain, block *types.Block) (*stateles
 
 	return witness, nil
 }
+
+// TransactionValidation is the verdict of debug_validateTransaction. Besides the
+// outcome, it reports the figures the transaction pool validates against, as
+// far as the validation got.
+type TransactionValidation struct {
+	Valid           bool            `json:"valid"`
+	Stage           string          `json:"stage,omitempty"` // Validation step rejecting the transaction
+	Error           string          `json:"error,omitempty"`
+	Hash            common.Hash     `json:"hash"`
+	From            *common.Address `json:"from,omitempty"`
+	Nonce           hexutil.Uint64  `json:"nonce"`
+	StateNonce      hexutil.Uint64  `json:"stateNonce"`
+	PoolNonce       hexutil.Uint64  `json:"poolNonce"`
+	Gas             hexutil.Uint64  `json:"gas"`
+	IntrinsicGas    hexutil.Uint64  `json:"intrinsicGas"`
+	Balance         *hexutil.Big    `json:"balance,omitempty"`
+	Cost            *hexutil.Big    `json:"cost,omitempty"`       // Maximum cost of the transaction, including the rollup cost
+	RollupCost      *hexutil.Big    `json:"rollupCost,omitempty"` // L1 data and operator fee, on rollups
+	ConditionalCost *hexutil.Uint64 `json:"conditionalCost,omitempty"`
+}
+
+// reject marks the transaction invalid for the given reason.
+func (v *TransactionValidation) reject(stage string, err error) *TransactionValidation {
+	v.Valid, v.Stage, v.Error = false, stage, err.Error()
+	return v
+}
+
+// ValidateTransaction runs the checks a signed transaction would go through when
+// submitted with eth_sendRawTransaction, or eth_sendRawTransactionConditional if
+// a conditional is given, against the current head without admitting it into the
+// pool. The first failing check is reported along with the figures it relies on.
+func (api *DebugAPI) ValidateTransaction(ctx context.Context, input hexutil.Bytes, conditional *types.TransactionConditional) (*TransactionValidation, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return nil, err
+	}
+	var (
+		config = api.eth.blockchain.Config()
+		head   = api.eth.blockchain.CurrentBlock()
+		res    = &TransactionValidation{
+			Valid: true,
+			Hash:  tx.Hash(),
+			Nonce: hexutil.Uint64(tx.Nonce()),
+			Gas:   hexutil.Uint64(tx.Gas()),
+		}
+	)
+	statedb, err := api.eth.blockchain.StateAt(head.Root)
+	if err != nil {
+		return nil, err
+	}
+	rules := config.Rules(head.Number, true, head.Time)
+	if gas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations(), tx.To() == nil, true, rules.IsIstanbul, rules.IsShanghai); err == nil {
+		res.IntrinsicGas = hexutil.Uint64(gas)
+	}
+	var rollupCostFn txpool.RollupCostFunc
+	if costFn := types.NewTotalRollupCostFunc(config, statedb); costFn != nil {
+		rollupCostFn = func(tx types.RollupTransaction) *uint256.Int {
+			return costFn(tx, head.Time)
+		}
+		if cost := rollupCostFn(tx); cost != nil {
+			res.RollupCost = (*hexutil.Big)(cost.ToBig())
+		}
+	}
+	if cost, overflow := txpool.TotalTxCost(tx, rollupCostFn); !overflow {
+		res.Cost = (*hexutil.Big)(cost.ToBig())
+	}
+	from, err := types.Sender(types.LatestSigner(config), tx)
+	if err != nil {
+		return res.reject("signature", err), nil
+	}
+	res.From = &from
+	res.Balance = (*hexutil.Big)(statedb.GetBalance(from).ToBig())
+	res.StateNonce = hexutil.Uint64(statedb.GetNonce(from))
+	res.PoolNonce = hexutil.Uint64(api.eth.txPool.PoolNonce(from))
+
+	// Run the checks of the RPC endpoints before handing over to the pool
+	if err := ethapi.CheckTxFee(tx.GasPrice(), tx.Gas(), api.eth.APIBackend.RPCTxFeeCap()); err != nil {
+		return res.reject("rpc", err), nil
+	}
+	if !api.eth.APIBackend.UnprotectedAllowed() && !tx.Protected() {
+		return res.reject("rpc", errors.New("only replay-protected (EIP-155) transactions allowed over RPC")), nil
+	}
+	if conditional != nil {
+		cost := hexutil.Uint64(conditional.Cost())
+		res.ConditionalCost = &cost
+
+		if err := conditional.Validate(); err != nil {
+			return res.reject("conditional", err), nil
+		}
+		if cost > params.TransactionConditionalMaxCost {
+			return res.reject("conditional", fmt.Errorf("conditional cost, %d, exceeded max: %d", cost, params.TransactionConditionalMaxCost)), nil
+		}
+		if err := head.CheckTransactionConditional(conditional); err != nil {
+			return res.reject("conditional", fmt.Errorf("failed header check: %w", err)), nil
+		}
+		if err := statedb.CheckTransactionConditional(conditional); err != nil {
+			return res.reject("conditional", fmt.Errorf("failed state check: %w", err)), nil
+		}
+	}
+	if err := api.eth.txPool.Validate(tx); err != nil {
+		return res.reject("txpool", err), nil
+	}
+	return res, nil
+}
+
+// PayloadRebuild is the outcome of rebuilding a locally built block.
+type PayloadRebuild struct {
+	Hash           common.Hash    `json:"hash"`                 // Hash of the original block
+	RebuiltHash    common.Hash    `json:"rebuiltHash"`          // Hash of the rebuilt block
+	Match          bool           `json:"match"`                // Whether the rebuilt block is identical to the original one
+	Interrupted    bool           `json:"interrupted"`          // Whether the original building was interrupted before exhausting the txpool
+	Txs            hexutil.Uint64 `json:"txs"`                  // Number of transactions in the original block
+	RebuiltTxs     hexutil.Uint64 `json:"rebuiltTxs"`           // Number of transactions in the rebuilt block
+	GasUsed        hexutil.Uint64 `json:"gasUsed"`              // Gas used by the original block
+	RebuiltGasUsed hexutil.Uint64 `json:"rebuiltGasUsed"`       // Gas used by the rebuilt block
+	Divergence     *hexutil.Uint  `json:"divergence,omitempty"` // Index of the first differing transaction
+	Missing        []common.Hash  `json:"missing,omitempty"`    // Transactions of the original block not included by the rebuild
+	Unexpected     []common.Hash  `json:"unexpected,omitempty"` // Transactions included by the rebuild but not the original block
+}
+
+// RebuildPayload re-runs the block building algorithm for a locally built block
+// with its recorded payload attributes and txpool snapshot, reporting where the
+// rebuilt block diverges from the original one. The payloads are only recorded
+// if the node runs with --miner.recordpayloads.
+func (api *DebugAPI) RebuildPayload(ctx context.Context, hash common.Hash) (*PayloadRebuild, error) {
+	res, err := api.eth.Miner().RebuildPayload(hash)
+	if err != nil {
+		return nil, err
+	}
+	rebuild := &PayloadRebuild{
+		Hash:           res.Original.Hash(),
+		RebuiltHash:    res.Rebuilt.Hash(),
+		Match:          res.Original.Hash() == res.Rebuilt.Hash(),
+		Interrupted:    res.Interrupted,
+		Txs:            hexutil.Uint64(len(res.Original.Transactions())),
+		RebuiltTxs:     hexutil.Uint64(len(res.Rebuilt.Transactions())),
+		GasUsed:        hexutil.Uint64(res.Original.GasUsed()),
+		RebuiltGasUsed: hexutil.Uint64(res.Rebuilt.GasUsed()),
+		Missing:        res.Missing,
+		Unexpected:     res.Unexpected,
+	}
+	if res.Divergence >= 0 {
+		divergence := hexutil.Uint(res.Divergence)
+		rebuild.Divergence = &divergence
+	}
+	return rebuild, nil
+}
+
+// GetBlockAccessList retrieves the access lists of the transactions of a locally
+// built block, recorded while executing them during the block building. The
+// access lists are only recorded if the node runs with --miner.recordaccesslists.
+func (api *DebugAPI) GetBlockAccessList(ctx context.Context, hash common.Hash) (*miner.BlockAccessList, error) {
+	return api.eth.Miner().BlockAccessList(hash)
+}
+
+// BalanceChangeResult is a change of the balance or the nonce of an account made
+// while processing a block.
+type BalanceChangeResult struct {
+	TxIndex    *hexutil.Uint  `json:"transactionIndex"` // Nil for changes outside of transactions
+	TxHash     *common.Hash   `json:"transactionHash"`
+	Address    common.Address `json:"address"`
+	Kind       string         `json:"kind"` // "balance" or "nonce"
+	Prev       *hexutil.Big   `json:"prev"`
+	New        *hexutil.Big   `json:"new"`
+	Reason     hexutil.Uint   `json:"reason"`
+	ReasonName string         `json:"reasonName"`
+}
+
+// GetBalanceChanges retrieves the balance and nonce changes of a block in the
+// order they were made, leaving out the ones undone by reverted calls. The
+// changes are only recorded if the node runs with --history.balancechanges.
+func (api *DebugAPI) GetBalanceChanges(ctx context.Context, hash common.Hash) ([]*BalanceChangeResult, error) {
+	block := api.eth.blockchain.GetBlockByHash(hash)
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", hash.Hex())
+	}
+	if !rawdb.HasBalanceChanges(api.eth.chainDb, hash, block.NumberU64()) {
+		return nil, fmt.Errorf("balance changes of block %s not recorded", hash.Hex())
+	}
+	var (
+		changes = rawdb.ReadBalanceChanges(api.eth.chainDb, hash, block.NumberU64())
+		txs     = block.Transactions()
+		results = make([]*BalanceChangeResult, 0, len(changes))
+	)
+	for _, change := range changes {
+		result := &BalanceChangeResult{
+			Address: change.Address,
+			Kind:    "balance",
+			Prev:    (*hexutil.Big)(change.Prev),
+			New:     (*hexutil.Big)(change.New),
+			Reason:  hexutil.Uint(change.Reason),
+		}
+		if change.Nonce {
+			result.Kind = "nonce"
+			result.ReasonName = tracing.NonceChangeReason(change.Reason).String()
+		} else {
+			result.ReasonName = tracing.BalanceChangeReason(change.Reason).String()
+		}
+		if int(change.TxIndex) < len(txs) {
+			index, hash := hexutil.Uint(change.TxIndex), txs[change.TxIndex].Hash()
+			result.TxIndex, result.TxHash = &index, &hash
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// SupplyIssuance is the value issued by a block, split by source.
+type SupplyIssuance struct {
+	Reward      *hexutil.Big `json:"reward"`
+	Withdrawals *hexutil.Big `json:"withdrawals"`
+	Deposits    *hexutil.Big `json:"deposits"`
+}
+
+// SupplyBurn is the value burned by a block, split by source.
+type SupplyBurn struct {
+	Fees         *hexutil.Big `json:"fees"`
+	Blob         *hexutil.Big `json:"blob"`
+	Selfdestruct *hexutil.Big `json:"selfdestruct"`
+}
+
+// SupplyResult is the native token supply after a block, along with the change
+// the block made to it.
+type SupplyResult struct {
+	Number      hexutil.Uint64 `json:"number"`
+	Hash        common.Hash    `json:"hash"`
+	TotalSupply *hexutil.Big   `json:"totalSupply"` // Nil if the supply of an ancestor is unknown
+	Delta       *hexutil.Big   `json:"delta"`
+	Issuance    SupplyIssuance `json:"issuance"`
+	Burn        SupplyBurn     `json:"burn"`
+}
+
+// TotalSupply retrieves the native token supply after the given block, tracked
+// as the blocks are imported. The supply is only tracked if the node runs with
+// --history.supply, and the total is only known if it ran so since genesis.
+func (api *DebugAPI) TotalSupply(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*SupplyResult, error) {
+	header, err := api.eth.APIBackend.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve block: %w", err)
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block not found: %s", blockNrOrHash.String())
+	}
+	supply := rawdb.ReadSupply(api.eth.chainDb, header.Hash(), header.Number.Uint64())
+	if supply == nil {
+		return nil, fmt.Errorf("supply of block %s not tracked", header.Hash().Hex())
+	}
+	return &SupplyResult{
+		Number:      hexutil.Uint64(header.Number.Uint64()),
+		Hash:        header.Hash(),
+		TotalSupply: (*hexutil.Big)(supply.Total),
+		Delta:       (*hexutil.Big)(new(big.Int).Sub(supply.Issuance(), supply.Burn())),
+		Issuance: SupplyIssuance{
+			Reward:      (*hexutil.Big)(supply.Reward),
+			Withdrawals: (*hexutil.Big)(supply.Withdrawals),
+			Deposits:    (*hexutil.Big)(supply.Deposits),
+		},
+		Burn: SupplyBurn{
+			Fees:         (*hexutil.Big)(supply.Fees),
+			Blob:         (*hexutil.Big)(supply.Blob),
+			Selfdestruct: (*hexutil.Big)(supply.Selfdestruct),
+		},
+	}, nil
+}
+
+// OrderingPolicyArgs is a declared transaction ordering policy to verify blocks
+// against.
+type OrderingPolicyArgs struct {
+	ID     hexutil.Uint64   `json:"id"`
+	Prio   []common.Address `json:"prio"`
+	MinTip *hexutil.Big     `json:"minTip"`
+}
+
+// OrderingPolicyResult is the outcome of verifying a block against a declared
+// transaction ordering policy.
+type OrderingPolicyResult struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	Valid  bool           `json:"valid"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// VerifyOrderingPolicy checks the canonical blocks in the given inclusive range
+// against the declared transaction ordering policy: each block must commit to
+// the policy in its extra-data and its transactions must adhere to it.
+//
+// OP Stack blocks never carry the commitment, their extra-data is derived.
+func (api *DebugAPI) VerifyOrderingPolicy(from, to rpc.BlockNumber, args OrderingPolicyArgs) ([]*OrderingPolicyResult, error) {
+	if api.eth.blockchain.Config().Optimism != nil {
+		return nil, errors.New("ordering policy commitment is not supported on OP Stack chains")
+	}
+	if args.ID > math.MaxUint8 {
+		return nil, fmt.Errorf("invalid policy id %d", args.ID)
+	}
+	policy := &miner.OrderingPolicy{ID: uint8(args.ID), Prio: args.Prio, MinTip: (*big.Int)(args.MinTip)}
+
+	resolveNum := func(num rpc.BlockNumber) uint64 {
+		if num.Int64() < 0 {
+			return api.eth.blockchain.CurrentBlock().Number.Uint64()
+		}
+		return uint64(num.Int64())
+	}
+	start, end := resolveNum(from), resolveNum(to)
+	if start > end {
+		return nil, fmt.Errorf("invalid range: from %d > to %d", start, end)
+	}
+	if end-start >= BlockStatsMaxRange {
+		return nil, fmt.Errorf("range too large: %d blocks, max %d", end-start+1, BlockStatsMaxRange)
+	}
+	var results []*OrderingPolicyResult
+	for number := start; number <= end; number++ {
+		block := api.eth.blockchain.GetBlockByNumber(number)
+		if block == nil {
+			break
+		}
+		res := &OrderingPolicyResult{Number: hexutil.Uint64(number), Hash: block.Hash(), Valid: true}
+		if err := miner.VerifyOrderingPolicy(api.eth.blockchain.Config(), block, policy); err != nil {
+			res.Valid, res.Error = false, err.Error()
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
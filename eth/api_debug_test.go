@@ -18,6 +18,7 @@ package eth
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -32,9 +33,11 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/holiman/uint256"
@@ -257,3 +260,63 @@ func TestExecutionWitness(t *testing.T) {
 	_, _, err = core.ExecuteStateless(params.TestChainConfig, *chain.GetVMConfig(), block, witness)
 	require.NoError(t, err)
 }
+
+func TestValidateTransaction(t *testing.T) {
+	t.Parallel()
+
+	b := initBackend(false)
+	b.eth.config = &ethconfig.Config{RPCTxFeeCap: 1} // 1 ether
+	b.eth.APIBackend = b
+	defer b.eth.txPool.Close()
+
+	var (
+		api  = NewDebugAPI(b.eth)
+		ctx  = context.Background()
+		tx   = makeTx(0, nil, nil, key)
+		cost = new(big.Int).Add(big.NewInt(1000), new(big.Int).Mul(big.NewInt(int64(params.TxGas)), big.NewInt(params.GWei)))
+	)
+	validate := func(tx *types.Transaction) *TransactionValidation {
+		input, _ := tx.MarshalBinary()
+		res, err := api.ValidateTransaction(ctx, input, nil)
+		if err != nil {
+			t.Fatalf("failed to validate transaction: %v", err)
+		}
+		return res
+	}
+	res := validate(tx)
+	if !res.Valid || res.Error != "" {
+		t.Fatalf("valid transaction rejected at %s: %s", res.Stage, res.Error)
+	}
+	if res.From == nil || *res.From != address || uint64(res.IntrinsicGas) != params.TxGas || res.Cost.ToInt().Cmp(cost) != 0 || res.Balance.ToInt().Cmp(funds) != 0 {
+		t.Fatalf("wrong validation figures: %+v", res)
+	}
+	if b.eth.txPool.Has(tx.Hash()) {
+		t.Fatal("validated transaction admitted into the pool")
+	}
+	// Transactions failing the RPC and the pool checks
+	tests := []struct {
+		tx    *types.Transaction
+		stage string
+		err   error
+	}{
+		{makeTx(0, big.NewInt(params.Ether), nil, key), "rpc", nil},
+		{makeTx(0, nil, funds, key), "txpool", core.ErrInsufficientFunds},
+		{tx, "txpool", txpool.ErrAlreadyKnown},
+		{makeTx(0, big.NewInt(params.GWei+1), nil, key), "txpool", txpool.ErrReplaceUnderpriced},
+	}
+	if err := b.eth.txPool.Add([]*types.Transaction{tx}, true)[0]; err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	for i, tt := range tests {
+		res := validate(tt.tx)
+		if res.Valid || res.Stage != tt.stage {
+			t.Errorf("test %d: wrong verdict: valid %v, stage %q, error %q", i, res.Valid, res.Stage, res.Error)
+		}
+		if tt.err != nil && !strings.Contains(res.Error, tt.err.Error()) {
+			t.Errorf("test %d: wrong error: have %q, want %q", i, res.Error, tt.err)
+		}
+	}
+	if res := validate(makeTx(1, nil, nil, key)); !res.Valid || res.PoolNonce != 1 {
+		t.Fatalf("next transaction rejected: valid %v, pool nonce %d, error %q", res.Valid, res.PoolNonce, res.Error)
+	}
+}
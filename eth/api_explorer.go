@@ -0,0 +1,340 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// explorerMaxBlocks is the maximum number of blocks summarized by a single
+	// explorer_latestBlocks call.
+	explorerMaxBlocks = 100
+
+	// explorerMaxTxCountRange is the maximum block range of explorer_txCount,
+	// which reads the block bodies.
+	explorerMaxTxCountRange = 4096
+
+	// explorerMaxGasRange is the maximum block range of explorer_topGasConsumers,
+	// which reads the block receipts.
+	explorerMaxGasRange = 256
+
+	// explorerMaxConsumers is the maximum number of accounts returned per list
+	// by explorer_topGasConsumers.
+	explorerMaxConsumers = 100
+
+	// explorerMaxActivity is the maximum number of index entries per index
+	// scanned by explorer_addressSummary.
+	explorerMaxActivity = 10000
+)
+
+// ExplorerAPI provides read-only aggregate chain queries for lightweight block
+// explorers, in the explorer namespace. All queries are bounded and computed
+// from the stored headers, bodies, receipts and the optional chain indexes.
+type ExplorerAPI struct {
+	eth *Ethereum
+}
+
+// NewExplorerAPI creates a new ExplorerAPI instance.
+func NewExplorerAPI(eth *Ethereum) *ExplorerAPI {
+	return &ExplorerAPI{eth: eth}
+}
+
+// BlockSummary is the overview of a block returned by explorer_latestBlocks.
+type BlockSummary struct {
+	Number       hexutil.Uint64 `json:"number"`
+	Hash         common.Hash    `json:"hash"`
+	Timestamp    hexutil.Uint64 `json:"timestamp"`
+	Miner        common.Address `json:"miner"`
+	Transactions hexutil.Uint   `json:"transactions"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	GasLimit     hexutil.Uint64 `json:"gasLimit"`
+	BaseFee      *hexutil.Big   `json:"baseFeePerGas,omitempty"`
+}
+
+// LatestBlocks returns the summaries of the latest canonical blocks, newest
+// first. The count defaults to 10 and is capped at 100.
+func (api *ExplorerAPI) LatestBlocks(count *hexutil.Uint64) []*BlockSummary {
+	n := uint64(10)
+	if count != nil {
+		n = min(uint64(*count), explorerMaxBlocks)
+	}
+	var (
+		chain   = api.eth.BlockChain()
+		head    = chain.CurrentBlock()
+		results = make([]*BlockSummary, 0, n)
+	)
+	for header := head; header != nil && uint64(len(results)) < n; {
+		summary := &BlockSummary{
+			Number:    hexutil.Uint64(header.Number.Uint64()),
+			Hash:      header.Hash(),
+			Timestamp: hexutil.Uint64(header.Time),
+			Miner:     header.Coinbase,
+			GasUsed:   hexutil.Uint64(header.GasUsed),
+			GasLimit:  hexutil.Uint64(header.GasLimit),
+		}
+		if header.BaseFee != nil {
+			summary.BaseFee = (*hexutil.Big)(header.BaseFee)
+		}
+		if body := chain.GetBody(header.Hash()); body != nil {
+			summary.Transactions = hexutil.Uint(len(body.Transactions))
+		}
+		results = append(results, summary)
+
+		if header.Number.Sign() == 0 {
+			break
+		}
+		header = chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	}
+	return results
+}
+
+// TxCount is the transaction count of a block range returned by
+// explorer_txCount.
+type TxCount struct {
+	From         hexutil.Uint64 `json:"from"`
+	To           hexutil.Uint64 `json:"to"`
+	Transactions hexutil.Uint64 `json:"transactions"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Empty        hexutil.Uint64 `json:"emptyBlocks"`
+}
+
+// TxCount returns the number of transactions and the gas used in the given
+// canonical block range (inclusive), which may span up to 4096 blocks.
+func (api *ExplorerAPI) TxCount(from, to hexutil.Uint64) (*TxCount, error) {
+	if err := api.checkRange(uint64(from), uint64(to), explorerMaxTxCountRange); err != nil {
+		return nil, err
+	}
+	var (
+		chain = api.eth.BlockChain()
+		res   = &TxCount{From: from, To: to}
+	)
+	for number := uint64(from); number <= uint64(to); number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		body := chain.GetBody(header.Hash())
+		if body == nil {
+			return nil, fmt.Errorf("block #%d body not available", number)
+		}
+		res.Transactions += hexutil.Uint64(len(body.Transactions))
+		res.GasUsed += hexutil.Uint64(header.GasUsed)
+		if len(body.Transactions) == 0 {
+			res.Empty++
+		}
+	}
+	return res, nil
+}
+
+// GasConsumer is the gas used by an account in a block range.
+type GasConsumer struct {
+	Address      common.Address `json:"address"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Transactions hexutil.Uint64 `json:"transactions"`
+}
+
+// GasConsumers is the result of explorer_topGasConsumers.
+type GasConsumers struct {
+	From       hexutil.Uint64 `json:"from"`
+	To         hexutil.Uint64 `json:"to"`
+	Senders    []*GasConsumer `json:"senders"`    // Accounts paying for the gas
+	Recipients []*GasConsumer `json:"recipients"` // Accounts called or created
+}
+
+// TopGasConsumers returns the accounts sending and receiving the transactions
+// using the most gas in the given canonical block range (inclusive), which may
+// span up to 256 blocks. The limit defaults to 10 accounts per list.
+func (api *ExplorerAPI) TopGasConsumers(from, to hexutil.Uint64, limit *hexutil.Uint64) (*GasConsumers, error) {
+	if err := api.checkRange(uint64(from), uint64(to), explorerMaxGasRange); err != nil {
+		return nil, err
+	}
+	n := 10
+	if limit != nil {
+		n = int(min(uint64(*limit), explorerMaxConsumers))
+	}
+	var (
+		chain      = api.eth.BlockChain()
+		senders    = make(map[common.Address]*GasConsumer)
+		recipients = make(map[common.Address]*GasConsumer)
+	)
+	account := func(set map[common.Address]*GasConsumer, addr common.Address, gas uint64) {
+		consumer := set[addr]
+		if consumer == nil {
+			consumer = &GasConsumer{Address: addr}
+			set[addr] = consumer
+		}
+		consumer.GasUsed += hexutil.Uint64(gas)
+		consumer.Transactions++
+	}
+	for number := uint64(from); number <= uint64(to); number++ {
+		block := chain.GetBlockByNumber(number)
+		if block == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		receipts := chain.GetReceiptsByHash(block.Hash())
+		if len(receipts) != len(block.Transactions()) {
+			return nil, fmt.Errorf("block #%d receipts not available", number)
+		}
+		signer := types.MakeSigner(chain.Config(), block.Number(), block.Time())
+		for i, tx := range block.Transactions() {
+			gas := receipts[i].GasUsed
+			if sender, err := types.Sender(signer, tx); err == nil {
+				account(senders, sender, gas)
+			}
+			if to := tx.To(); to != nil {
+				account(recipients, *to, gas)
+			} else if receipts[i].ContractAddress != (common.Address{}) {
+				account(recipients, receipts[i].ContractAddress, gas)
+			}
+		}
+	}
+	return &GasConsumers{
+		From:       from,
+		To:         to,
+		Senders:    topGasConsumers(senders, n),
+		Recipients: topGasConsumers(recipients, n),
+	}, nil
+}
+
+// topGasConsumers returns the n accounts using the most gas, sorted by the gas
+// used in descending order.
+func topGasConsumers(set map[common.Address]*GasConsumer, n int) []*GasConsumer {
+	list := make([]*GasConsumer, 0, len(set))
+	for _, consumer := range set {
+		list = append(list, consumer)
+	}
+	slices.SortFunc(list, func(a, b *GasConsumer) int {
+		if c := cmp.Compare(b.GasUsed, a.GasUsed); c != 0 {
+			return c
+		}
+		return a.Address.Cmp(b.Address)
+	})
+	if len(list) > n {
+		list = list[:n]
+	}
+	return list
+}
+
+// checkRange validates a canonical block range query.
+func (api *ExplorerAPI) checkRange(from, to uint64, limit uint64) error {
+	if from > to {
+		return fmt.Errorf("invalid block range %d > %d", from, to)
+	}
+	if to-from >= limit {
+		return fmt.Errorf("block range too large: %d > %d", to-from+1, limit)
+	}
+	if head := api.eth.BlockChain().CurrentBlock().Number.Uint64(); to > head {
+		return fmt.Errorf("block #%d beyond head #%d", to, head)
+	}
+	return nil
+}
+
+// IndexActivity is the activity of an account found in a chain index.
+type IndexActivity struct {
+	IndexFrom  hexutil.Uint64  `json:"indexFrom"` // First block covered by the index
+	IndexTo    hexutil.Uint64  `json:"indexTo"`   // Last block covered by the index
+	Count      hexutil.Uint64  `json:"count"`
+	Incoming   *hexutil.Uint64 `json:"incoming,omitempty"`
+	Outgoing   *hexutil.Uint64 `json:"outgoing,omitempty"`
+	FirstBlock *hexutil.Uint64 `json:"firstBlock"`
+	LastBlock  *hexutil.Uint64 `json:"lastBlock"`
+	Truncated  bool            `json:"truncated"` // Whether the scan hit the entry limit
+}
+
+// AddressSummary is the result of explorer_addressSummary.
+type AddressSummary struct {
+	Address        common.Address `json:"address"`
+	Balance        *hexutil.Big   `json:"balance"`
+	Nonce          hexutil.Uint64 `json:"nonce"`
+	Contract       bool           `json:"contract"`
+	Transactions   *IndexActivity `json:"transactions"`   // Sent transactions, nil if not indexed
+	TokenTransfers *IndexActivity `json:"tokenTransfers"` // Token transfers, nil if not indexed
+}
+
+// AddressSummary returns the current balance and nonce of an account, along
+// with its sent transactions and token transfers within the windows of the
+// sender and token transfer indexes, if enabled. At most 10000 entries are
+// scanned per index.
+func (api *ExplorerAPI) AddressSummary(address common.Address) (*AddressSummary, error) {
+	statedb, err := api.eth.BlockChain().State()
+	if err != nil {
+		return nil, err
+	}
+	res := &AddressSummary{
+		Address:  address,
+		Balance:  (*hexutil.Big)(statedb.GetBalance(address).ToBig()),
+		Nonce:    hexutil.Uint64(statedb.GetNonce(address)),
+		Contract: statedb.GetCodeSize(address) > 0,
+	}
+	if indexer := api.eth.SenderIndexer(); indexer != nil {
+		activity := new(IndexActivity)
+		if first, last, ok := indexer.Range(); ok {
+			activity.IndexFrom, activity.IndexTo = hexutil.Uint64(first), hexutil.Uint64(last)
+		}
+		entries, next := indexer.Transactions(address, 0, 0, explorerMaxActivity)
+		for _, entry := range entries {
+			activity.observe(entry.Number)
+		}
+		activity.Truncated = next != nil
+		res.Transactions = activity
+	}
+	if indexer := api.eth.TransferIndexer(); indexer != nil {
+		var (
+			activity      = new(IndexActivity)
+			incoming      hexutil.Uint64
+			outgoing      hexutil.Uint64
+			first, last   uint64
+			ok            bool
+			entries, next = indexer.Transfers(address, 0, 0, math.MaxUint64, explorerMaxActivity, nil)
+		)
+		if first, last, ok = indexer.Range(); ok {
+			activity.IndexFrom, activity.IndexTo = hexutil.Uint64(first), hexutil.Uint64(last)
+		}
+		for _, entry := range entries {
+			activity.observe(entry.Number)
+			if entry.To == address {
+				incoming++
+			}
+			if entry.From == address {
+				outgoing++
+			}
+		}
+		activity.Incoming, activity.Outgoing = &incoming, &outgoing
+		activity.Truncated = next != nil
+		res.TokenTransfers = activity
+	}
+	return res, nil
+}
+
+// observe accounts an index entry of the given block, which are iterated in
+// chain order.
+func (activity *IndexActivity) observe(number uint64) {
+	n := hexutil.Uint64(number)
+	if activity.FirstBlock == nil {
+		activity.FirstBlock = &n
+	}
+	activity.LastBlock = &n
+	activity.Count++
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests the aggregate queries of the explorer namespace against a short chain
+// with a known transaction layout.
+func TestExplorerAPI(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{testAddr: {Balance: big.NewInt(params.Ether)}},
+		}
+		recipient = common.HexToAddress("0xdeadbeef")
+		signer    = types.LatestSigner(gspec.Config)
+	)
+	// Block n carries n-1 plain transfers, so 1+2+3+4 = 10 transactions.
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 5, func(i int, gen *core.BlockGen) {
+		for j := 0; j < i; j++ {
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(testAddr), recipient, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, testKey)
+			gen.AddTx(tx)
+		}
+	})
+	chain, _ := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	api := NewExplorerAPI(&Ethereum{blockchain: chain, chainDb: db})
+
+	count := hexutil.Uint64(3)
+	latest := api.LatestBlocks(&count)
+	if len(latest) != 3 || latest[0].Number != 5 || latest[2].Number != 3 || latest[0].Transactions != 4 {
+		t.Fatalf("wrong latest blocks: %+v", latest)
+	}
+	if all := api.LatestBlocks(nil); len(all) != 6 {
+		t.Fatalf("wrong default latest blocks: have %d, want 6", len(all))
+	}
+	txs, err := api.TxCount(0, 5)
+	if err != nil {
+		t.Fatalf("failed to count transactions: %v", err)
+	}
+	if txs.Transactions != 10 || txs.GasUsed != hexutil.Uint64(10*params.TxGas) || txs.Empty != 2 {
+		t.Fatalf("wrong transaction count: %+v", txs)
+	}
+	if _, err := api.TxCount(0, 6); err == nil {
+		t.Fatal("range beyond head accepted")
+	}
+	if _, err := api.TopGasConsumers(0, explorerMaxGasRange, nil); err == nil {
+		t.Fatal("oversized range accepted")
+	}
+	top, err := api.TopGasConsumers(3, 5, nil)
+	if err != nil {
+		t.Fatalf("failed to query gas consumers: %v", err)
+	}
+	if len(top.Senders) != 1 || top.Senders[0].Address != testAddr || top.Senders[0].Transactions != 9 || top.Senders[0].GasUsed != hexutil.Uint64(9*params.TxGas) {
+		t.Fatalf("wrong senders: %+v", top.Senders)
+	}
+	if len(top.Recipients) != 1 || top.Recipients[0].Address != recipient {
+		t.Fatalf("wrong recipients: %+v", top.Recipients)
+	}
+	// Without chain indexes, only the state is summarized.
+	summary, err := api.AddressSummary(testAddr)
+	if err != nil {
+		t.Fatalf("failed to summarize address: %v", err)
+	}
+	if summary.Nonce != 10 || summary.Contract || summary.Transactions != nil || summary.TokenTransfers != nil {
+		t.Fatalf("wrong address summary: %+v", summary)
+	}
+}
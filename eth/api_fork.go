@@ -0,0 +1,208 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// SimulateForkMaxRange is the maximum number of blocks debug_simulateFork
+	// re-executes in a single call.
+	SimulateForkMaxRange = 128
+
+	// simulateForkReexec is the number of blocks debug_simulateFork re-executes
+	// at most to regenerate the state the simulation starts from.
+	simulateForkReexec = 128
+)
+
+// simulatedForks are the timestamp based forks whose execution semantics are
+// implemented, and can thus be simulated, mapped to their activation field.
+var simulatedForks = map[string]struct {
+	optimism bool
+	time     func(c *params.ChainConfig) **uint64
+}{
+	"shanghai": {time: func(c *params.ChainConfig) **uint64 { return &c.ShanghaiTime }},
+	"cancun":   {time: func(c *params.ChainConfig) **uint64 { return &c.CancunTime }},
+	"prague":   {time: func(c *params.ChainConfig) **uint64 { return &c.PragueTime }},
+	"osaka":    {time: func(c *params.ChainConfig) **uint64 { return &c.OsakaTime }},
+	"canyon":   {optimism: true, time: func(c *params.ChainConfig) **uint64 { return &c.CanyonTime }},
+	"ecotone":  {optimism: true, time: func(c *params.ChainConfig) **uint64 { return &c.EcotoneTime }},
+	"fjord":    {optimism: true, time: func(c *params.ChainConfig) **uint64 { return &c.FjordTime }},
+	"granite":  {optimism: true, time: func(c *params.ChainConfig) **uint64 { return &c.GraniteTime }},
+	"holocene": {optimism: true, time: func(c *params.ChainConfig) **uint64 { return &c.HoloceneTime }},
+	"isthmus":  {optimism: true, time: func(c *params.ChainConfig) **uint64 { return &c.IsthmusTime }},
+	"jovian":   {optimism: true, time: func(c *params.ChainConfig) **uint64 { return &c.JovianTime }},
+}
+
+// simulatedForkConfig returns a copy of the chain config with the named fork
+// activated at the given timestamp.
+func simulatedForkConfig(config *params.ChainConfig, name string, timestamp uint64) (*params.ChainConfig, error) {
+	fork, ok := simulatedForks[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported fork %q", name)
+	}
+	if fork.optimism && !config.IsOptimism() {
+		return nil, fmt.Errorf("fork %q only applies to optimism chains", name)
+	}
+	cpy := *config
+	*fork.time(&cpy) = &timestamp
+	if err := cpy.CheckConfigForkOrder(); err != nil {
+		return nil, err
+	}
+	return &cpy, nil
+}
+
+// ForkSimulationRange is the inclusive range of blocks to simulate a fork over.
+type ForkSimulationRange struct {
+	From rpc.BlockNumber `json:"from"`
+	To   rpc.BlockNumber `json:"to"`
+}
+
+// ReceiptDivergence is a transaction whose receipt differs when re-executed with
+// the simulated fork.
+type ReceiptDivergence struct {
+	Index            hexutil.Uint   `json:"index"`
+	TxHash           common.Hash    `json:"txHash"`
+	Status           hexutil.Uint64 `json:"status"`
+	SimulatedStatus  hexutil.Uint64 `json:"simulatedStatus"`
+	GasUsed          hexutil.Uint64 `json:"gasUsed"`
+	SimulatedGasUsed hexutil.Uint64 `json:"simulatedGasUsed"`
+	Logs             hexutil.Uint   `json:"logs"`
+	SimulatedLogs    hexutil.Uint   `json:"simulatedLogs"`
+}
+
+// ForkSimulationResult is the outcome of re-executing a block with a simulated
+// fork, on top of the state produced by the simulation of the previous blocks.
+type ForkSimulationResult struct {
+	Number           hexutil.Uint64       `json:"number"`
+	Hash             common.Hash          `json:"hash"`
+	Active           bool                 `json:"active"` // Whether the fork was active in the block
+	Root             common.Hash          `json:"root"`
+	SimulatedRoot    common.Hash          `json:"simulatedRoot"`
+	GasUsed          hexutil.Uint64       `json:"gasUsed"`
+	SimulatedGasUsed hexutil.Uint64       `json:"simulatedGasUsed"`
+	Receipts         []*ReceiptDivergence `json:"receipts,omitempty"`
+	Error            string               `json:"error,omitempty"` // Failure to execute the block with the fork
+}
+
+// SimulateFork re-executes the canonical blocks in the given range with the named
+// fork activated at the given timestamp, reporting the receipts and state roots
+// diverging from the canonical ones. The simulation runs on a shadow copy of the
+// state, so the divergences of a block carry over to the following ones. The
+// simulation stops at the first block failing to execute.
+func (api *DebugAPI) SimulateFork(ctx context.Context, name string, timestamp hexutil.Uint64, blockRange ForkSimulationRange) ([]*ForkSimulationResult, error) {
+	chain := api.eth.blockchain
+	config, err := simulatedForkConfig(chain.Config(), name, uint64(timestamp))
+	if err != nil {
+		return nil, err
+	}
+	resolveNum := func(num rpc.BlockNumber) uint64 {
+		if num.Int64() < 0 {
+			return chain.CurrentBlock().Number.Uint64()
+		}
+		return uint64(num.Int64())
+	}
+	start, end := resolveNum(blockRange.From), resolveNum(blockRange.To)
+	if start > end {
+		return nil, fmt.Errorf("invalid range: from %d > to %d", start, end)
+	}
+	if end-start >= SimulateForkMaxRange {
+		return nil, fmt.Errorf("range too large: %d blocks, max %d", end-start+1, SimulateForkMaxRange)
+	}
+	if start == 0 {
+		return nil, errors.New("genesis is not executable")
+	}
+	parent := chain.GetBlockByNumber(start - 1)
+	if parent == nil {
+		return nil, fmt.Errorf("block #%d not found", start-1)
+	}
+	statedb, release, err := api.eth.stateAtBlock(ctx, parent, simulateForkReexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var (
+		processor = core.NewStateProcessor(config, chain.HeaderChain())
+		results   []*ForkSimulationResult
+	)
+	for number := start; number <= end; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block := chain.GetBlockByNumber(number)
+		if block == nil {
+			break
+		}
+		res := &ForkSimulationResult{
+			Number:  hexutil.Uint64(number),
+			Hash:    block.Hash(),
+			Active:  block.Time() >= uint64(timestamp),
+			Root:    block.Root(),
+			GasUsed: hexutil.Uint64(block.GasUsed()),
+		}
+		results = append(results, res)
+
+		simulated, err := processor.Process(block, statedb, vm.Config{})
+		if err != nil {
+			res.Error = err.Error()
+			break
+		}
+		res.SimulatedRoot = statedb.IntermediateRoot(config.IsEIP158(block.Number()))
+		res.SimulatedGasUsed = hexutil.Uint64(simulated.GasUsed)
+		res.Receipts = receiptDivergences(chain.GetReceiptsByHash(block.Hash()), simulated.Receipts)
+	}
+	return results, nil
+}
+
+// receiptDivergences compares the canonical receipts of a block to the simulated
+// ones, returning the transactions whose execution outcome differs.
+func receiptDivergences(receipts, simulated types.Receipts) []*ReceiptDivergence {
+	var divergences []*ReceiptDivergence
+	for i, receipt := range receipts {
+		if i >= len(simulated) {
+			break
+		}
+		sim := simulated[i]
+		if receipt.Status == sim.Status && receipt.GasUsed == sim.GasUsed && receipt.Bloom == sim.Bloom && len(receipt.Logs) == len(sim.Logs) {
+			continue
+		}
+		divergences = append(divergences, &ReceiptDivergence{
+			Index:            hexutil.Uint(i),
+			TxHash:           receipt.TxHash,
+			Status:           hexutil.Uint64(receipt.Status),
+			SimulatedStatus:  hexutil.Uint64(sim.Status),
+			GasUsed:          hexutil.Uint64(receipt.GasUsed),
+			SimulatedGasUsed: hexutil.Uint64(sim.GasUsed),
+			Logs:             hexutil.Uint(len(receipt.Logs)),
+			SimulatedLogs:    hexutil.Uint(len(sim.Logs)),
+		})
+	}
+	return divergences
+}
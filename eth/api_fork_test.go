@@ -0,0 +1,105 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that simulating Cancun over a pre-Cancun chain reports the transactions
+// relying on the transient storage opcodes as diverging, from the simulated
+// activation onwards.
+func TestSimulateFork(t *testing.T) {
+	config := *params.MergedTestChainConfig
+	config.CancunTime = nil
+	config.PragueTime = nil
+
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		contract = common.HexToAddress("0xc0de")
+		gspec    = &core.Genesis{
+			Config: &config,
+			Alloc: types.GenesisAlloc{
+				testAddr: {Balance: big.NewInt(params.Ether)},
+				contract: {Code: []byte{byte(vm.PUSH0), byte(vm.TLOAD), byte(vm.STOP)}},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = beacon.New(ethash.NewFaker())
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 4, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(testAddr), contract, nil, 100000, gen.BaseFee(), nil), signer, testKey)
+		gen.AddTx(tx)
+	})
+	chain, _ := core.NewBlockChain(db, nil, gspec, nil, engine, vm.Config{}, nil)
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	api := NewDebugAPI(&Ethereum{blockchain: chain, chainDb: db})
+
+	results, err := api.SimulateFork(context.Background(), "Cancun", 30, ForkSimulationRange{From: 1, To: rpc.LatestBlockNumber})
+	if err != nil {
+		t.Fatalf("failed to simulate fork: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("result count mismatch: have %d, want 4", len(results))
+	}
+	for i, res := range results {
+		if res.Error != "" {
+			t.Fatalf("block %d: simulation failed: %s", res.Number, res.Error)
+		}
+		active := blocks[i].Time() >= 30
+		if res.Active != active {
+			t.Errorf("block %d: activation mismatch: have %v, want %v", res.Number, res.Active, active)
+		}
+		if !active {
+			if res.SimulatedRoot != res.Root || len(res.Receipts) != 0 {
+				t.Errorf("block %d: diverged before activation", res.Number)
+			}
+			continue
+		}
+		if res.SimulatedRoot == res.Root {
+			t.Errorf("block %d: state root did not diverge", res.Number)
+		}
+		if len(res.Receipts) != 1 {
+			t.Fatalf("block %d: receipt divergence count mismatch: have %d, want 1", res.Number, len(res.Receipts))
+		}
+		if d := res.Receipts[0]; uint64(d.Status) != types.ReceiptStatusFailed || uint64(d.SimulatedStatus) != types.ReceiptStatusSuccessful {
+			t.Errorf("block %d: status mismatch: have %d -> %d", res.Number, d.Status, d.SimulatedStatus)
+		}
+	}
+	// Forks without implemented semantics or of another chain type must be rejected
+	if _, err := api.SimulateFork(context.Background(), "verkle", 30, ForkSimulationRange{From: 1, To: 1}); err == nil {
+		t.Error("simulated unsupported fork")
+	}
+	if _, err := api.SimulateFork(context.Background(), "holocene", 30, ForkSimulationRange{From: 1, To: 1}); err == nil {
+		t.Error("simulated optimism fork on a non-optimism chain")
+	}
+}
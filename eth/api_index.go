@@ -0,0 +1,586 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// senderTxPageSize is the maximum number of transactions returned by a
+	// single eth_getTransactionsBySender call.
+	senderTxPageSize = 100
+
+	// transferPageSize is the maximum number of transfers returned by a single
+	// ext_getTokenTransfers call.
+	transferPageSize = 100
+
+	// internalTxPageSize is the maximum number of internal transactions of an
+	// account returned by a single ext_getInternalTransactions call.
+	internalTxPageSize = 100
+
+	// codeChangePageSize is the maximum number of code changes returned by a
+	// single ext_getCodeChanges call.
+	codeChangePageSize = 100
+
+	// maxCodeChangeBlockRange is the maximum number of blocks scanned by a single
+	// ext_getCodeChanges call without an address.
+	maxCodeChangeBlockRange = 10000
+)
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// IndexAPI provides access to the optional chain indexes maintained by the
+// node, in the eth namespace.
+type IndexAPI struct {
+	eth *Ethereum
+}
+
+// NewIndexAPI creates a new IndexAPI instance.
+func NewIndexAPI(eth *Ethereum) *IndexAPI {
+	return &IndexAPI{eth: eth}
+}
+
+// SenderTransaction is a transaction reference returned by
+// eth_getTransactionsBySender.
+type SenderTransaction struct {
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex hexutil.Uint   `json:"transactionIndex"`
+	Hash             common.Hash    `json:"hash"`
+}
+
+// SenderTransactions is a page of transactions sent by an account.
+type SenderTransactions struct {
+	Transactions  []SenderTransaction `json:"transactions"`
+	NextPageToken *hexutil.Bytes      `json:"nextPageToken"`
+}
+
+// GetTransactionsBySender returns the canonical transactions sent by the given
+// account in chain order, one page at a time. The first page is requested with
+// no token, the following ones with the nextPageToken of the previous page.
+func (api *IndexAPI) GetTransactionsBySender(address common.Address, pageToken *hexutil.Bytes) (*SenderTransactions, error) {
+	indexer := api.eth.SenderIndexer()
+	if indexer == nil {
+		return nil, errors.New("sender transaction index is not enabled")
+	}
+	var (
+		number uint64
+		index  uint32
+	)
+	if pageToken != nil {
+		if len(*pageToken) != 12 {
+			return nil, errInvalidPageToken
+		}
+		number = binary.BigEndian.Uint64((*pageToken)[:8])
+		index = binary.BigEndian.Uint32((*pageToken)[8:])
+	}
+	entries, next := indexer.Transactions(address, number, index, senderTxPageSize)
+
+	res := &SenderTransactions{Transactions: make([]SenderTransaction, 0, len(entries))}
+	for _, entry := range entries {
+		res.Transactions = append(res.Transactions, SenderTransaction{
+			BlockNumber:      hexutil.Uint64(entry.Number),
+			TransactionIndex: hexutil.Uint(entry.Index),
+			Hash:             entry.Hash,
+		})
+	}
+	if next != nil {
+		res.NextPageToken = encodePageToken(next.Number, next.Index)
+	}
+	return res, nil
+}
+
+// TokenIndexAPI provides access to the optional token transfer index maintained
+// by the node, in the ext namespace.
+type TokenIndexAPI struct {
+	eth *Ethereum
+}
+
+// NewTokenIndexAPI creates a new TokenIndexAPI instance.
+func NewTokenIndexAPI(eth *Ethereum) *TokenIndexAPI {
+	return &TokenIndexAPI{eth: eth}
+}
+
+// TokenTransferOptions are the query options of ext_getTokenTransfers.
+type TokenTransferOptions struct {
+	FromBlock *hexutil.Uint64  `json:"fromBlock"`
+	ToBlock   *hexutil.Uint64  `json:"toBlock"`
+	Tokens    []common.Address `json:"tokens"`    // Token contracts to include, all if empty
+	Direction string           `json:"direction"` // "in", "out" or empty for both
+	PageToken *hexutil.Bytes   `json:"pageToken"`
+	Limit     *hexutil.Uint64  `json:"limit"`
+}
+
+// TokenTransfer is an ERC-20 or ERC-721 transfer returned by
+// ext_getTokenTransfers.
+type TokenTransfer struct {
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex hexutil.Uint   `json:"transactionIndex"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	LogIndex         hexutil.Uint   `json:"logIndex"`
+	Token            common.Address `json:"token"`
+	From             common.Address `json:"from"`
+	To               common.Address `json:"to"`
+	Value            *hexutil.Big   `json:"value,omitempty"`
+	TokenID          *hexutil.Big   `json:"tokenId,omitempty"`
+}
+
+// TokenTransfers is a page of token transfers of an account.
+type TokenTransfers struct {
+	Transfers     []TokenTransfer `json:"transfers"`
+	NextPageToken *hexutil.Bytes  `json:"nextPageToken"`
+}
+
+// GetTokenTransfers returns the canonical ERC-20 and ERC-721 transfers sent or
+// received by the given account in chain order, one page at a time. The first
+// page is requested with no token, the following ones with the nextPageToken
+// of the previous page and otherwise unchanged options. As the number of index
+// entries scanned per call is bounded, a page filtered by token or direction
+// may hold fewer transfers than the limit, or none, and still have a token.
+func (api *TokenIndexAPI) GetTokenTransfers(address common.Address, options *TokenTransferOptions) (*TokenTransfers, error) {
+	indexer := api.eth.TransferIndexer()
+	if indexer == nil {
+		return nil, errors.New("token transfer index is not enabled")
+	}
+	if options == nil {
+		options = new(TokenTransferOptions)
+	}
+	var (
+		number uint64
+		index  uint32
+		to     = uint64(math.MaxUint64)
+		limit  = transferPageSize
+	)
+	if options.FromBlock != nil {
+		number = uint64(*options.FromBlock)
+	}
+	if options.ToBlock != nil {
+		to = uint64(*options.ToBlock)
+	}
+	if options.Limit != nil && *options.Limit > 0 && *options.Limit < transferPageSize {
+		limit = int(*options.Limit)
+	}
+	if options.Direction != "" && options.Direction != "in" && options.Direction != "out" {
+		return nil, fmt.Errorf("invalid transfer direction %q", options.Direction)
+	}
+	if options.PageToken != nil {
+		if len(*options.PageToken) != 12 {
+			return nil, errInvalidPageToken
+		}
+		number = binary.BigEndian.Uint64((*options.PageToken)[:8])
+		index = binary.BigEndian.Uint32((*options.PageToken)[8:])
+	}
+	tokens := make(map[common.Address]struct{})
+	for _, token := range options.Tokens {
+		tokens[token] = struct{}{}
+	}
+	match := func(entry *rawdb.TransferEntry) bool {
+		if len(tokens) > 0 {
+			if _, ok := tokens[entry.Token]; !ok {
+				return false
+			}
+		}
+		switch options.Direction {
+		case "in":
+			return entry.To == address
+		case "out":
+			return entry.From == address
+		}
+		return true
+	}
+	entries, next := indexer.Transfers(address, number, index, to, limit, match)
+
+	res := &TokenTransfers{Transfers: make([]TokenTransfer, 0, len(entries))}
+	for _, entry := range entries {
+		transfer := TokenTransfer{
+			BlockNumber:      hexutil.Uint64(entry.Number),
+			TransactionIndex: hexutil.Uint(entry.TxIndex),
+			TransactionHash:  entry.TxHash,
+			LogIndex:         hexutil.Uint(entry.LogIndex),
+			Token:            entry.Token,
+			From:             entry.From,
+			To:               entry.To,
+		}
+		value := (*hexutil.Big)(new(big.Int).SetBytes(entry.Value))
+		if entry.NFT {
+			transfer.TokenID = value
+		} else {
+			transfer.Value = value
+		}
+		res.Transfers = append(res.Transfers, transfer)
+	}
+	if next != nil {
+		res.NextPageToken = encodePageToken(next.Number, next.LogIndex)
+	}
+	return res, nil
+}
+
+// encodePageToken encodes the position of an index entry as page token.
+func encodePageToken(number uint64, index uint32) *hexutil.Bytes {
+	token := make(hexutil.Bytes, 12)
+	binary.BigEndian.PutUint64(token[:8], number)
+	binary.BigEndian.PutUint32(token[8:], index)
+	return &token
+}
+
+// InternalTxIndexAPI provides access to the optional internal transaction index
+// maintained by the node, in the ext namespace.
+type InternalTxIndexAPI struct {
+	eth *Ethereum
+}
+
+// NewInternalTxIndexAPI creates a new InternalTxIndexAPI instance.
+func NewInternalTxIndexAPI(eth *Ethereum) *InternalTxIndexAPI {
+	return &InternalTxIndexAPI{eth: eth}
+}
+
+// InternalTxTarget selects the internal transactions returned by
+// ext_getInternalTransactions, either the ones of an account or of a block. It
+// is given as an address, or as a block number, tag or hash.
+type InternalTxTarget struct {
+	Address *common.Address
+	Block   *rpc.BlockNumberOrHash
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *InternalTxTarget) UnmarshalJSON(data []byte) error {
+	var input string
+	if err := json.Unmarshal(data, &input); err == nil && len(input) == 2+2*common.AddressLength && common.IsHexAddress(input) {
+		address := common.HexToAddress(input)
+		t.Address = &address
+		return nil
+	}
+	var block rpc.BlockNumberOrHash
+	if err := json.Unmarshal(data, &block); err != nil {
+		return fmt.Errorf("invalid internal transaction target, want address or block: %v", err)
+	}
+	t.Block = &block
+	return nil
+}
+
+// InternalTxOptions are the options of ext_getInternalTransactions. They only
+// apply to the internal transactions of an account, the ones of a block are
+// returned all at once.
+type InternalTxOptions struct {
+	FromBlock *hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   *hexutil.Uint64 `json:"toBlock"`
+	PageToken *hexutil.Bytes  `json:"pageToken"`
+	Limit     *hexutil.Uint64 `json:"limit"`
+}
+
+// InternalTransaction is an internal transaction returned by
+// ext_getInternalTransactions.
+type InternalTransaction struct {
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	TransactionIndex hexutil.Uint   `json:"transactionIndex"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	Position         hexutil.Uint   `json:"position"`
+	Type             string         `json:"type"`
+	From             common.Address `json:"from"`
+	To               common.Address `json:"to"`
+	Value            *hexutil.Big   `json:"value"`
+	Depth            hexutil.Uint   `json:"depth"`
+	Reverted         bool           `json:"reverted"`
+	Salt             *common.Hash   `json:"salt,omitempty"`
+}
+
+// InternalTransactions is a page of internal transactions.
+type InternalTransactions struct {
+	Transactions  []InternalTransaction `json:"transactions"`
+	NextPageToken *hexutil.Bytes        `json:"nextPageToken"`
+}
+
+// GetInternalTransactions returns the value transfers, contract creations and
+// self-destructs performed by transactions below their top level call, either
+// the canonical ones sent or received by an account in chain order, one page at
+// a time, or all the ones of a block. Reverted internal transactions are
+// included and marked as such.
+func (api *InternalTxIndexAPI) GetInternalTransactions(ctx context.Context, target InternalTxTarget, options *InternalTxOptions) (*InternalTransactions, error) {
+	indexer := api.eth.InternalTxIndexer()
+	if indexer == nil {
+		return nil, errors.New("internal transaction index is not enabled")
+	}
+	if target.Block != nil {
+		header, err := api.eth.APIBackend.HeaderByNumberOrHash(ctx, *target.Block)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, errors.New("block not found")
+		}
+		txs := core.BlockInternalTxs(api.eth.chainDb, header.Hash(), header.Number.Uint64())
+		if txs == nil {
+			return nil, errors.New("block internal transactions not traced")
+		}
+		return newInternalTransactions(txs), nil
+	}
+	if options == nil {
+		options = new(InternalTxOptions)
+	}
+	var (
+		number   uint64
+		position uint32
+		to       = uint64(math.MaxUint64)
+		limit    = internalTxPageSize
+	)
+	if options.FromBlock != nil {
+		number = uint64(*options.FromBlock)
+	}
+	if options.ToBlock != nil {
+		to = uint64(*options.ToBlock)
+	}
+	if options.Limit != nil && *options.Limit > 0 && *options.Limit < internalTxPageSize {
+		limit = int(*options.Limit)
+	}
+	if options.PageToken != nil {
+		if len(*options.PageToken) != 12 {
+			return nil, errInvalidPageToken
+		}
+		number = binary.BigEndian.Uint64((*options.PageToken)[:8])
+		position = binary.BigEndian.Uint32((*options.PageToken)[8:])
+	}
+	txs, next := indexer.InternalTxs(*target.Address, number, position, to, limit)
+
+	res := newInternalTransactions(txs)
+	if next != nil {
+		res.NextPageToken = encodePageToken(next.Number, next.Position)
+	}
+	return res, nil
+}
+
+func newInternalTransactions(txs []*core.IndexedInternalTx) *InternalTransactions {
+	res := &InternalTransactions{Transactions: make([]InternalTransaction, 0, len(txs))}
+	for _, tx := range txs {
+		res.Transactions = append(res.Transactions, InternalTransaction{
+			BlockNumber:      hexutil.Uint64(tx.Number),
+			BlockHash:        tx.BlockHash,
+			TransactionIndex: hexutil.Uint(tx.TxIndex),
+			TransactionHash:  tx.TxHash,
+			Position:         hexutil.Uint(tx.Position),
+			Type:             vm.OpCode(tx.Op).String(),
+			From:             tx.From,
+			To:               tx.To,
+			Value:            (*hexutil.Big)(tx.Value),
+			Depth:            hexutil.Uint(tx.Depth),
+			Reverted:         tx.Reverted,
+			Salt:             tx.Salt,
+		})
+	}
+	return res
+}
+
+// CreationIndexAPI provides access to the optional contract creation index
+// maintained by the node, in the ext namespace.
+type CreationIndexAPI struct {
+	eth *Ethereum
+}
+
+// NewCreationIndexAPI creates a new CreationIndexAPI instance.
+func NewCreationIndexAPI(eth *Ethereum) *CreationIndexAPI {
+	return &CreationIndexAPI{eth: eth}
+}
+
+// ContractCreation is the creation of a contract returned by
+// ext_getContractCreation.
+type ContractCreation struct {
+	Address          common.Address `json:"address"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	TransactionIndex hexutil.Uint   `json:"transactionIndex"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	Creator          common.Address `json:"creator"`
+	Type             string         `json:"type"`
+	Depth            hexutil.Uint   `json:"depth"`
+	Salt             *common.Hash   `json:"salt,omitempty"`
+}
+
+// GetContractCreation returns the transaction, creator and block which created
+// the contract at the given address, or null if the creation is not indexed.
+// The creator is the transaction sender for contracts created by the top level
+// call, or the creating contract otherwise.
+func (api *CreationIndexAPI) GetContractCreation(address common.Address) (*ContractCreation, error) {
+	indexer := api.eth.CreationIndexer()
+	if indexer == nil {
+		return nil, errors.New("contract creation index is not enabled")
+	}
+	entry := indexer.Creation(address)
+	if entry == nil {
+		return nil, nil
+	}
+	return &ContractCreation{
+		Address:          address,
+		BlockNumber:      hexutil.Uint64(entry.Number),
+		BlockHash:        entry.BlockHash,
+		TransactionIndex: hexutil.Uint(entry.TxIndex),
+		TransactionHash:  entry.TxHash,
+		Creator:          entry.Creator,
+		Type:             vm.OpCode(entry.Op).String(),
+		Depth:            hexutil.Uint(entry.Depth),
+		Salt:             entry.Salt,
+	}, nil
+}
+
+// CodeChangeIndexAPI provides access to the optional code change index
+// maintained by the node, in the ext namespace.
+type CodeChangeIndexAPI struct {
+	eth *Ethereum
+}
+
+// NewCodeChangeIndexAPI creates a new CodeChangeIndexAPI instance.
+func NewCodeChangeIndexAPI(eth *Ethereum) *CodeChangeIndexAPI {
+	return &CodeChangeIndexAPI{eth: eth}
+}
+
+// CodeChangeOptions are the options of ext_getCodeChanges. The code changes of
+// a single account are returned if an address is given, or the ones of all
+// accounts in the block range otherwise.
+type CodeChangeOptions struct {
+	Address   *common.Address `json:"address"`
+	FromBlock *hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   *hexutil.Uint64 `json:"toBlock"`
+	PageToken *hexutil.Bytes  `json:"pageToken"`
+	Limit     *hexutil.Uint64 `json:"limit"`
+}
+
+// CodeChange is a code change returned by ext_getCodeChanges.
+type CodeChange struct {
+	BlockNumber      hexutil.Uint64  `json:"blockNumber"`
+	BlockHash        common.Hash     `json:"blockHash"`
+	TransactionIndex *hexutil.Uint   `json:"transactionIndex"` // Nil for changes outside of transactions
+	TransactionHash  *common.Hash    `json:"transactionHash"`
+	Position         hexutil.Uint    `json:"position"`
+	Address          common.Address  `json:"address"`
+	Kind             string          `json:"kind"`
+	PrevCodeHash     common.Hash     `json:"prevCodeHash"`
+	CodeHash         common.Hash     `json:"codeHash"`
+	Delegate         *common.Address `json:"delegate,omitempty"`
+}
+
+// CodeChanges is a page of code changes.
+type CodeChanges struct {
+	Changes       []CodeChange   `json:"changes"`
+	NextPageToken *hexutil.Bytes `json:"nextPageToken"`
+}
+
+// GetCodeChanges returns the canonical deployments, self-destructs, EIP-7702
+// delegations and other code replacements in chain order, one page at a time,
+// either of an account or of all accounts within a block range. The range
+// defaults to the single block toBlock, or the head block, when no address is
+// given. Changes undone
+// by reverted calls are not included.
+func (api *CodeChangeIndexAPI) GetCodeChanges(options CodeChangeOptions) (*CodeChanges, error) {
+	indexer := api.eth.CodeChangeIndexer()
+	if indexer == nil {
+		return nil, errors.New("code change index is not enabled")
+	}
+	var (
+		head     = api.eth.blockchain.CurrentBlock().Number.Uint64()
+		number   uint64
+		position uint32
+		to       = uint64(math.MaxUint64)
+		limit    = codeChangePageSize
+	)
+	if options.ToBlock != nil {
+		to = uint64(*options.ToBlock)
+	}
+	if options.Address == nil {
+		to = min(to, head)
+		number = to
+	}
+	if options.FromBlock != nil {
+		number = uint64(*options.FromBlock)
+	}
+	if options.Limit != nil && *options.Limit > 0 && *options.Limit < codeChangePageSize {
+		limit = int(*options.Limit)
+	}
+	if options.PageToken != nil {
+		if len(*options.PageToken) != 12 {
+			return nil, errInvalidPageToken
+		}
+		number = binary.BigEndian.Uint64((*options.PageToken)[:8])
+		position = binary.BigEndian.Uint32((*options.PageToken)[8:])
+	}
+	if options.Address != nil {
+		changes, next := indexer.CodeChanges(*options.Address, number, position, to, limit)
+
+		res := newCodeChanges(changes)
+		if next != nil {
+			res.NextPageToken = encodePageToken(next.Number, next.Position)
+		}
+		return res, nil
+	}
+	if to >= number && to-number >= maxCodeChangeBlockRange {
+		return nil, fmt.Errorf("block range too large, max %d blocks", maxCodeChangeBlockRange)
+	}
+	var changes []*core.IndexedCodeChange
+	for ; number <= to; number, position = number+1, 0 {
+		hash := rawdb.ReadCanonicalHash(api.eth.chainDb, number)
+		if hash == (common.Hash{}) {
+			break
+		}
+		block := core.BlockCodeChanges(api.eth.chainDb, hash, number)
+		for ; int(position) < len(block); position++ {
+			if len(changes) == limit {
+				res := newCodeChanges(changes)
+				res.NextPageToken = encodePageToken(number, position)
+				return res, nil
+			}
+			changes = append(changes, block[position])
+		}
+	}
+	return newCodeChanges(changes), nil
+}
+
+func newCodeChanges(changes []*core.IndexedCodeChange) *CodeChanges {
+	res := &CodeChanges{Changes: make([]CodeChange, 0, len(changes))}
+	for _, change := range changes {
+		result := CodeChange{
+			BlockNumber:  hexutil.Uint64(change.Number),
+			BlockHash:    change.BlockHash,
+			Position:     hexutil.Uint(change.Position),
+			Address:      change.Address,
+			Kind:         change.Kind.String(),
+			PrevCodeHash: change.PrevHash,
+			CodeHash:     change.Hash,
+		}
+		if change.TxHash != (common.Hash{}) {
+			index, hash := hexutil.Uint(change.TxIndex), change.TxHash
+			result.TransactionIndex, result.TransactionHash = &index, &hash
+		}
+		if change.Kind == types.CodeDelegated {
+			delegate := change.Delegate
+			result.Delegate = &delegate
+		}
+		res.Changes = append(res.Changes, result)
+	}
+	return res
+}
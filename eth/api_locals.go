@@ -0,0 +1,107 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errNoLocalTracking is returned if the local transactions aren't tracked.
+var errNoLocalTracking = errors.New("local transaction tracking disabled")
+
+// LocalTxAdminAPI provides the rescue of stuck local transactions, by
+// rebroadcasting or evicting them. It is only served on the authenticated RPC
+// endpoints, as it makes the node spam its peers and the sequencer on demand.
+type LocalTxAdminAPI struct {
+	eth *Ethereum
+}
+
+// NewLocalTxAdminAPI creates a new instance of LocalTxAdminAPI.
+func NewLocalTxAdminAPI(eth *Ethereum) *LocalTxAdminAPI {
+	return &LocalTxAdminAPI{eth: eth}
+}
+
+// RebroadcastTx is a local transaction re-announced by txpool_rebroadcast.
+type RebroadcastTx struct {
+	Hash    common.Hash    `json:"hash"`
+	Pending hexutil.Uint64 `json:"pending"` // Seconds since the transaction was submitted
+}
+
+// RebroadcastResult is the result of txpool_rebroadcast.
+type RebroadcastResult struct {
+	Transactions []RebroadcastTx `json:"transactions"`
+	Peers        hexutil.Uint    `json:"peers"`     // Number of peers the transactions were announced to
+	Forwarded    hexutil.Uint    `json:"forwarded"` // Number of transactions forwarded to the sequencer
+}
+
+// Rebroadcast re-announces the local transactions in the pool, of the given
+// account or of all accounts, to all connected peers, including the ones which
+// already received them. The transactions are forwarded again to the sequencer
+// if one is configured.
+func (api *LocalTxAdminAPI) Rebroadcast(ctx context.Context, addr *common.Address) (*RebroadcastResult, error) {
+	if api.eth.localTxTracker == nil {
+		return nil, errNoLocalTracking
+	}
+	txs, since := api.eth.localTxTracker.Tracked(addr)
+
+	res := &RebroadcastResult{
+		Transactions: make([]RebroadcastTx, len(txs)),
+		Peers:        hexutil.Uint(api.eth.handler.rebroadcastTransactions(txs)),
+	}
+	for i, tx := range txs {
+		res.Transactions[i] = RebroadcastTx{
+			Hash:    tx.Hash(),
+			Pending: hexutil.Uint64(time.Since(since[i]) / time.Second),
+		}
+		if api.eth.seqRPCService == nil {
+			continue
+		}
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		if err := api.eth.seqRPCService.CallContext(ctx, nil, "eth_sendRawTransaction", hexutil.Encode(data)); err != nil {
+			log.Debug("Failed to forward local transaction to sequencer", "hash", tx.Hash(), "err", err)
+			continue
+		}
+		res.Forwarded++
+	}
+	log.Info("Rebroadcast local transactions", "count", len(txs), "peers", res.Peers, "forwarded", res.Forwarded)
+	return res, nil
+}
+
+// Evict stops tracking a local transaction and drops it from the pool, so that
+// it's neither resubmitted nor included anymore. The subsequent transactions of
+// its sender become non-executable until the nonce gap is filled. It returns
+// whether the transaction was still in the pool.
+func (api *LocalTxAdminAPI) Evict(hash common.Hash) (bool, error) {
+	if api.eth.localTxTracker == nil {
+		return false, errNoLocalTracking
+	}
+	if !api.eth.localTxTracker.Untrack(hash) {
+		return false, errors.New("not a local transaction")
+	}
+	evicted := api.eth.txPool.Evict(hash)
+	log.Warn("Evicted local transaction", "hash", hash, "pooled", evicted)
+	return evicted, nil
+}
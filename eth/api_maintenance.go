@@ -0,0 +1,148 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// defaultChainPauseTimeout is the time after which a paused chain is resumed
+	// if no timeout is requested.
+	defaultChainPauseTimeout = 10 * time.Minute
+
+	// maxChainPauseTimeout is the longest time a chain can be paused for, so a
+	// forgotten pause doesn't leave the node behind indefinitely.
+	maxChainPauseTimeout = 24 * time.Hour
+)
+
+// MaintenanceAPI provides the pausing of the chain for planned maintenance or
+// incident response, in the admin namespace. It is only served on the
+// authenticated RPC endpoints.
+type MaintenanceAPI struct {
+	eth *Ethereum
+}
+
+// NewMaintenanceAPI creates a new MaintenanceAPI instance.
+func NewMaintenanceAPI(eth *Ethereum) *MaintenanceAPI {
+	return &MaintenanceAPI{eth: eth}
+}
+
+// ChainPause is the result of admin_pauseChain.
+type ChainPause struct {
+	Number hexutil.Uint64 `json:"number"` // Number of the frozen head
+	Hash   common.Hash    `json:"hash"`   // Hash of the frozen head
+	Resume time.Time      `json:"resume"` // Time the chain is resumed automatically
+}
+
+// PauseChain stops the import of blocks and the building of payloads, keeping
+// the chain readable at the frozen head. The chain is resumed automatically
+// after the given number of seconds, 10 minutes by default and 24 hours at
+// most. Pausing a paused chain resets the timeout.
+func (api *MaintenanceAPI) PauseChain(timeout *uint64) (*ChainPause, error) {
+	duration := defaultChainPauseTimeout
+	if timeout != nil {
+		duration = time.Duration(*timeout) * time.Second
+	}
+	if duration <= 0 || duration > maxChainPauseTimeout {
+		return nil, fmt.Errorf("invalid pause timeout %v, must be in (0, %v]", duration, maxChainPauseTimeout)
+	}
+	api.eth.lock.Lock()
+	defer api.eth.lock.Unlock()
+
+	chain := api.eth.blockchain
+	if err := chain.SetPaused(true); err != nil {
+		return nil, err
+	}
+	if api.eth.pauseTimer != nil {
+		api.eth.pauseTimer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(duration, func() {
+		api.eth.lock.Lock()
+		defer api.eth.lock.Unlock()
+
+		// Ignore the timer if it was replaced in the meantime
+		if api.eth.pauseTimer != timer {
+			return
+		}
+		log.Warn("Chain pause timed out", "timeout", common.PrettyDuration(duration))
+		api.eth.resumeChain()
+	})
+	api.eth.pauseTimer = timer
+
+	head := chain.CurrentBlock()
+	res := &ChainPause{
+		Number: hexutil.Uint64(head.Number.Uint64()),
+		Hash:   head.Hash(),
+		Resume: time.Now().Add(duration),
+	}
+	log.Warn("Paused chain for maintenance", "number", head.Number, "hash", res.Hash, "timeout", common.PrettyDuration(duration))
+	return res, nil
+}
+
+// ResumeChain resumes the import of blocks and the building of payloads of a
+// paused chain.
+func (api *MaintenanceAPI) ResumeChain() (bool, error) {
+	api.eth.lock.Lock()
+	defer api.eth.lock.Unlock()
+
+	if !api.eth.blockchain.Paused() {
+		return false, errors.New("chain is not paused")
+	}
+	if err := api.eth.resumeChain(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// haltChain pauses the chain without timeout, until resumed explicitly by an
+// operator. It's used to stop the import of blocks on execution divergences.
+func (s *Ethereum) haltChain() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.pauseTimer != nil {
+		s.pauseTimer.Stop()
+		s.pauseTimer = nil
+	}
+	return s.blockchain.SetPaused(true)
+}
+
+// resumeChain resumes the paused chain. The lock must be held by the caller.
+func (s *Ethereum) resumeChain() error {
+	if s.pauseTimer != nil {
+		s.pauseTimer.Stop()
+		s.pauseTimer = nil
+	}
+
+	if err := s.blockchain.SetPaused(false); err != nil {
+		return err
+	}
+	if s.crossCheck != nil {
+		s.crossCheck.Resume()
+	}
+	head := s.blockchain.CurrentBlock()
+	log.Info("Resumed chain", "number", head.Number, "hash", head.Hash())
+	return nil
+}
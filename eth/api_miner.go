@@ -17,11 +17,22 @@
 package eth
 
 import (
+	"context"
+	"errors"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi/override"
+	"github.com/ethereum/go-ethereum/miner"
 )
 
+// maxBundleSize is the maximum number of transactions accepted by
+// miner_simulateBundle.
+const maxBundleSize = 256
+
 // MinerAPI provides an API to control the miner.
 type MinerAPI struct {
 	e *Ethereum
@@ -63,3 +74,124 @@ func (api *MinerAPI) SetMaxDASize(maxTxSize hexutil.Big, maxBlockSize hexutil.Bi
 	api.e.Miner().SetMaxDASize(maxTxSize.ToInt(), maxBlockSize.ToInt())
 	return true
 }
+
+// FeeRecipientSchedule returns the schedule rotating the fee recipient of the
+// built blocks, or nil if the requested fee recipients are used.
+func (api *MinerAPI) FeeRecipientSchedule() *miner.FeeRecipientSchedule {
+	return api.e.Miner().FeeRecipientSchedule()
+}
+
+// SetFeeRecipientSchedule replaces the schedule rotating the fee recipient of
+// the built blocks. A nil schedule restores the requested fee recipients.
+func (api *MinerAPI) SetFeeRecipientSchedule(schedule *miner.FeeRecipientSchedule) (bool, error) {
+	if err := api.e.Miner().SetFeeRecipientSchedule(schedule); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// FeeRecipientAt returns the fee recipient the current schedule selects for
+// the block with the given number.
+func (api *MinerAPI) FeeRecipientAt(number hexutil.Uint64) (common.Address, error) {
+	schedule := api.e.Miner().FeeRecipientSchedule()
+	if schedule == nil {
+		return common.Address{}, errors.New("no fee recipient schedule configured")
+	}
+	return schedule.Recipient(uint64(number)), nil
+}
+
+// PayloadReport returns the revenue breakdown of a recently built payload,
+// identified either by its 8 byte payload ID or by its 32 byte block hash.
+func (api *MinerAPI) PayloadReport(id hexutil.Bytes) (*miner.PayloadReport, error) {
+	var report *miner.PayloadReport
+	switch len(id) {
+	case len(engine.PayloadID{}):
+		report = api.e.Miner().PayloadReport(engine.PayloadID(id))
+	case common.HashLength:
+		report = api.e.Miner().BlockReport(common.BytesToHash(id))
+	default:
+		return nil, errors.New("expected a payload ID or a block hash")
+	}
+	if report == nil {
+		return nil, errors.New("payload report not found")
+	}
+	return report, nil
+}
+
+// SimulatedBundleTx is the outcome of a single transaction of a bundle
+// simulated by miner_simulateBundle.
+type SimulatedBundleTx struct {
+	TxHash       common.Hash    `json:"txHash"`
+	Receipt      *types.Receipt `json:"receipt"`
+	Error        string         `json:"error,omitempty"`
+	CoinbaseDiff *hexutil.Big   `json:"coinbaseDiff"`
+}
+
+// SimulatedBundle is the result of miner_simulateBundle.
+type SimulatedBundle struct {
+	BlockNumber  hexutil.Uint64      `json:"blockNumber"`
+	Timestamp    hexutil.Uint64      `json:"timestamp"`
+	BaseFee      *hexutil.Big        `json:"baseFeePerGas,omitempty"`
+	Coinbase     common.Address      `json:"coinbase"`
+	GasUsed      hexutil.Uint64      `json:"gasUsed"`
+	CoinbaseDiff *hexutil.Big        `json:"coinbaseDiff"`
+	Results      []SimulatedBundleTx `json:"results"`
+}
+
+// SimulateBundle executes the given signed transactions through the block
+// building code path, on top of the pending block, and returns the receipts
+// and the fee recipient profit of every transaction. Only the time, gasLimit,
+// feeRecipient and prevRandao block overrides are supported.
+func (api *MinerAPI) SimulateBundle(ctx context.Context, txs []hexutil.Bytes, blockOverrides *override.BlockOverrides) (*SimulatedBundle, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("empty bundle")
+	}
+	if len(txs) > maxBundleSize {
+		return nil, errors.New("bundle too large")
+	}
+	bundle := make(types.Transactions, len(txs))
+	for i, input := range txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return nil, err
+		}
+		bundle[i] = tx
+	}
+	var overrides *miner.BundleOverrides
+	if o := blockOverrides; o != nil {
+		if o.Number != nil || o.Difficulty != nil || o.BaseFeePerGas != nil || o.BlobBaseFee != nil || o.BeaconRoot != nil || o.Withdrawals != nil {
+			return nil, errors.New("only time, gasLimit, feeRecipient and prevRandao can be overridden")
+		}
+		overrides = &miner.BundleOverrides{
+			Timestamp:    (*uint64)(o.Time),
+			FeeRecipient: o.FeeRecipient,
+			GasLimit:     (*uint64)(o.GasLimit),
+			Random:       o.PrevRandao,
+		}
+	}
+	res, err := api.e.Miner().SimulateBundle(ctx, bundle, overrides)
+	if err != nil {
+		return nil, err
+	}
+	result := &SimulatedBundle{
+		BlockNumber:  hexutil.Uint64(res.Header.Number.Uint64()),
+		Timestamp:    hexutil.Uint64(res.Header.Time),
+		BaseFee:      (*hexutil.Big)(res.Header.BaseFee),
+		Coinbase:     res.Coinbase,
+		GasUsed:      hexutil.Uint64(res.GasUsed),
+		CoinbaseDiff: (*hexutil.Big)(res.CoinbaseDiff),
+		Results:      make([]SimulatedBundleTx, 0, len(res.Txs)),
+	}
+	for _, tx := range res.Txs {
+		simulated := SimulatedBundleTx{
+			TxHash:       tx.Tx.Hash(),
+			Receipt:      tx.Receipt,
+			CoinbaseDiff: (*hexutil.Big)(tx.CoinbaseDiff),
+		}
+		if tx.Err != nil {
+			simulated.Error = tx.Err.Error()
+		}
+		result.Results = append(result.Results, simulated)
+	}
+	return result, nil
+}
@@ -0,0 +1,595 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/internal/ethapi/override"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// OverlayMaxBlocks is the maximum number of blocks an overlay chain can be
+	// advanced by in total.
+	OverlayMaxBlocks = 256
+
+	// overlayReexec is the number of blocks re-executed at most to regenerate
+	// the state an overlay chain is forked from.
+	overlayReexec = 128
+)
+
+var (
+	errOverlayNotFound = errors.New("overlay not found")
+	errOverlayStale    = errors.New("overlay state no longer available")
+)
+
+// overlayTx is a transaction queued for inclusion in the next overlay block.
+type overlayTx struct {
+	tx   *types.Transaction
+	args ethapi.TransactionArgs
+}
+
+// overlayChain is an ephemeral chain forked off a block of the local chain. Its
+// state is a shadow copy backed by the live database: reads fall through to the
+// state of the fork point while writes are held in memory and never committed.
+//
+// The state of the fork point is only pinned in the hash scheme. In the path
+// scheme it stays available as long as it's within the in-memory layers, so the
+// overlay becomes stale once the local chain advances past them.
+type overlayChain struct {
+	config  *params.ChainConfig
+	chain   *core.BlockChain
+	base    *types.Header
+	state   *state.StateDB
+	release tracers.StateReleaseFunc
+
+	blocks   []*types.Block                     // Blocks mined on top of the fork point
+	receipts map[common.Hash]*types.Receipt     // Receipts of the mined transactions
+	txs      map[common.Hash]*types.Transaction // Mined transactions
+	failed   map[common.Hash]string             // Transactions failing to be included
+	pending  []*overlayTx                       // Transactions queued for the next block
+
+	used time.Time // Last time the overlay was accessed, for eviction
+	err  error     // Failure of the state, the overlay is unusable afterwards
+	lock sync.Mutex
+}
+
+// Config implements consensus.ChainHeaderReader.
+func (o *overlayChain) Config() *params.ChainConfig { return o.config }
+
+// Engine implements core.ChainContext.
+func (o *overlayChain) Engine() consensus.Engine { return o.chain.Engine() }
+
+// CurrentHeader implements consensus.ChainHeaderReader, returning the overlay head.
+func (o *overlayChain) CurrentHeader() *types.Header {
+	if len(o.blocks) == 0 {
+		return o.base
+	}
+	return o.blocks[len(o.blocks)-1].Header()
+}
+
+// GetHeader implements consensus.ChainHeaderReader, resolving the overlay blocks
+// before falling back to the local chain.
+func (o *overlayChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if block := o.block(number); block != nil {
+		if block.Hash() == hash {
+			return block.Header()
+		}
+		return nil
+	}
+	return o.chain.GetHeader(hash, number)
+}
+
+// GetHeaderByNumber implements consensus.ChainHeaderReader. Below the fork point
+// only canonical headers are resolved.
+func (o *overlayChain) GetHeaderByNumber(number uint64) *types.Header {
+	if block := o.block(number); block != nil {
+		return block.Header()
+	}
+	if number == o.base.Number.Uint64() {
+		return o.base
+	}
+	return o.chain.GetHeaderByNumber(number)
+}
+
+// GetHeaderByHash implements consensus.ChainHeaderReader.
+func (o *overlayChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	for _, block := range o.blocks {
+		if block.Hash() == hash {
+			return block.Header()
+		}
+	}
+	return o.chain.GetHeaderByHash(hash)
+}
+
+// fail marks the overlay as stale after its state failed to be read, returning
+// the error to report.
+func (o *overlayChain) fail(err error) error {
+	o.err = fmt.Errorf("%w: %v", errOverlayStale, err)
+	return o.err
+}
+
+// block returns the overlay block with the given number, if mined.
+func (o *overlayChain) block(number uint64) *types.Block {
+	base := o.base.Number.Uint64()
+	if number <= base || number-base > uint64(len(o.blocks)) {
+		return nil
+	}
+	return o.blocks[number-base-1]
+}
+
+// nextHeader assembles the header of the next overlay block, the given number
+// of seconds after the current head.
+func (o *overlayChain) nextHeader(interval uint64) (*types.Header, error) {
+	parent := o.CurrentHeader()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Coinbase:   parent.Coinbase,
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + interval,
+		Extra:      parent.Extra,
+		MixDigest:  parent.MixDigest,
+	}
+	if err := o.Engine().Prepare(o, header); err != nil {
+		return nil, err
+	}
+	if o.config.IsLondon(header.Number) {
+		header.BaseFee = eip1559.CalcBaseFee(o.config, parent, header.Time)
+	}
+	if o.config.IsCancun(header.Number, header.Time) {
+		excessBlobGas := eip4844.CalcExcessBlobGas(o.config, parent, header.Time)
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+		header.ParentBeaconRoot = new(common.Hash)
+	}
+	return header, nil
+}
+
+// mine executes the queued transactions in a new block on top of the overlay
+// head. Transactions not fitting in the block stay queued for the next one,
+// while the ones failing to execute are dropped.
+func (o *overlayChain) mine(interval uint64) (*types.Block, error) {
+	header, err := o.nextHeader(interval)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		vmConfig = vm.Config{NoBaseFee: true}
+		context  = core.NewEVMBlockContext(header, o, nil, o.config, o.state)
+		evm      = vm.NewEVM(context, o.state, o.config, vmConfig)
+		gp       = new(core.GasPool).AddGas(header.GasLimit)
+		usedGas  uint64
+		body     types.Body
+		receipts []*types.Receipt
+		queued   []*overlayTx
+	)
+	if header.ParentBeaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, evm)
+	}
+	if o.config.IsPrague(header.Number, header.Time) {
+		core.ProcessParentBlockHash(header.ParentHash, evm)
+	}
+	for _, ptx := range o.pending {
+		msg := ptx.args.ToMessage(header.BaseFee, true, true)
+		o.state.SetTxContext(ptx.tx.Hash(), len(body.Transactions))
+
+		receipt, err := core.ApplyTransactionWithEVM(msg, gp, o.state, header.Number, common.Hash{}, ptx.tx, &usedGas, evm)
+		if err := o.state.Error(); err != nil {
+			return nil, o.fail(err)
+		}
+		if errors.Is(err, core.ErrGasLimitReached) && len(body.Transactions) > 0 {
+			queued = append(queued, ptx)
+			continue
+		}
+		if err != nil {
+			o.failed[ptx.tx.Hash()] = err.Error()
+			continue
+		}
+		body.Transactions = append(body.Transactions, ptx.tx)
+		receipts = append(receipts, receipt)
+	}
+	header.GasUsed = usedGas
+
+	block, err := o.Engine().FinalizeAndAssemble(o, header, o.state, &body, receipts)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.state.Error(); err != nil {
+		return nil, o.fail(err)
+	}
+	for i, receipt := range receipts {
+		receipt.BlockHash = block.Hash()
+		for _, log := range receipt.Logs {
+			log.BlockHash = block.Hash()
+		}
+		o.receipts[receipt.TxHash] = receipt
+		o.txs[receipt.TxHash] = body.Transactions[i]
+	}
+	o.blocks = append(o.blocks, block)
+	o.pending = queued
+	return block, nil
+}
+
+// OverlayAPI provides an API to run ephemeral "what-if" chains forked off a block
+// of the local chain, for example to simulate the execution of governance
+// proposals over several blocks. Transactions are impersonated, thus need no
+// signature, and overlay chains never affect the local chain.
+type OverlayAPI struct {
+	eth      *Ethereum
+	max      int
+	overlays map[rpc.ID]*overlayChain
+	lock     sync.Mutex
+}
+
+// NewOverlayAPI creates a new overlay API, keeping at most max overlay chains
+// alive at the same time.
+func NewOverlayAPI(eth *Ethereum, max int) *OverlayAPI {
+	return &OverlayAPI{
+		eth:      eth,
+		max:      max,
+		overlays: make(map[rpc.ID]*overlayChain),
+	}
+}
+
+// overlay retrieves the overlay chain with the given id, locked. Overlays whose
+// fork point state is gone from the database are reported as stale.
+func (api *OverlayAPI) overlay(id rpc.ID) (*overlayChain, error) {
+	api.lock.Lock()
+	o := api.overlays[id]
+	api.lock.Unlock()
+
+	if o == nil {
+		return nil, errOverlayNotFound
+	}
+	o.lock.Lock()
+	if o.state == nil {
+		o.lock.Unlock()
+		return nil, errOverlayNotFound // discarded concurrently
+	}
+	if o.err == nil && !o.chain.HasState(o.base.Root) {
+		o.err = errOverlayStale
+	}
+	if o.err != nil {
+		o.lock.Unlock()
+		return nil, o.err
+	}
+	o.used = time.Now()
+	return o, nil
+}
+
+// Create forks a new overlay chain off the given block, returning its id. If
+// the maximum number of overlays is reached, the least recently used one is
+// discarded.
+func (api *OverlayAPI) Create(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (rpc.ID, error) {
+	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return "", err
+	}
+	if block == nil {
+		return "", fmt.Errorf("block %s not found", blockNrOrHash.String())
+	}
+	statedb, release, err := api.eth.stateAtBlock(ctx, block, overlayReexec, nil, true, false)
+	if err != nil {
+		return "", err
+	}
+	config := *api.eth.blockchain.Config()
+	o := &overlayChain{
+		config:   &config,
+		chain:    api.eth.blockchain,
+		base:     block.Header(),
+		state:    statedb,
+		release:  release,
+		receipts: make(map[common.Hash]*types.Receipt),
+		txs:      make(map[common.Hash]*types.Transaction),
+		failed:   make(map[common.Hash]string),
+		used:     time.Now(),
+	}
+	id := rpc.NewID()
+
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	for len(api.overlays) >= api.max {
+		var (
+			oldest rpc.ID
+			used   time.Time
+		)
+		for id, o := range api.overlays {
+			if oldest == "" || o.used.Before(used) {
+				oldest, used = id, o.used
+			}
+		}
+		log.Debug("Evicting overlay chain", "id", oldest)
+		api.discard(oldest)
+	}
+	api.overlays[id] = o
+	log.Debug("Created overlay chain", "id", id, "number", block.NumberU64(), "hash", block.Hash())
+	return id, nil
+}
+
+// discard removes an overlay chain, releasing the state it holds on to. It
+// assumes the API lock is held.
+func (api *OverlayAPI) discard(id rpc.ID) {
+	o := api.overlays[id]
+	delete(api.overlays, id)
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.release()
+	o.state = nil
+}
+
+// Delete discards the overlay chain with the given id.
+func (api *OverlayAPI) Delete(id rpc.ID) bool {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	if _, ok := api.overlays[id]; !ok {
+		return false
+	}
+	api.discard(id)
+	return true
+}
+
+// SendTransaction queues a transaction for inclusion in the next block of the
+// overlay chain. The sender is impersonated, so no signature is needed, and the
+// nonce defaults to the next one of the sender in the overlay.
+func (api *OverlayAPI) SendTransaction(id rpc.ID, args ethapi.TransactionArgs) (common.Hash, error) {
+	o, err := api.overlay(id)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer o.lock.Unlock()
+
+	if args.From == nil {
+		return common.Hash{}, errors.New("missing transaction sender")
+	}
+	if args.Gas == nil {
+		gas := hexutil.Uint64(o.CurrentHeader().GasLimit)
+		args.Gas = &gas
+	}
+	if args.Nonce == nil {
+		nonce := o.state.GetNonce(*args.From)
+		if err := o.state.Error(); err != nil {
+			return common.Hash{}, o.fail(err)
+		}
+		for _, ptx := range o.pending {
+			if *ptx.args.From == *args.From {
+				nonce++
+			}
+		}
+		args.Nonce = (*hexutil.Uint64)(&nonce)
+	}
+	head := o.CurrentHeader()
+	if err := args.CallDefaults(0, head.BaseFee, o.config.ChainID); err != nil {
+		return common.Hash{}, err
+	}
+	txType := types.LegacyTxType
+	if head.BaseFee != nil {
+		txType = types.DynamicFeeTxType
+	}
+	tx := args.ToTransaction(txType)
+	o.pending = append(o.pending, &overlayTx{tx: tx, args: args})
+	return tx.Hash(), nil
+}
+
+// OverlayMineArgs are the options to advance an overlay chain with.
+type OverlayMineArgs struct {
+	Blocks   *hexutil.Uint64 `json:"blocks"`   // Number of blocks to mine, 1 if unset
+	Interval *hexutil.Uint64 `json:"interval"` // Seconds between the blocks, that of the fork point if unset
+}
+
+// OverlayBlock is a block mined on an overlay chain.
+type OverlayBlock struct {
+	Number       hexutil.Uint64 `json:"number"`
+	Hash         common.Hash    `json:"hash"`
+	Root         common.Hash    `json:"stateRoot"`
+	Timestamp    hexutil.Uint64 `json:"timestamp"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Transactions []common.Hash  `json:"transactions"`
+}
+
+func newOverlayBlock(block *types.Block) *OverlayBlock {
+	res := &OverlayBlock{
+		Number:       hexutil.Uint64(block.NumberU64()),
+		Hash:         block.Hash(),
+		Root:         block.Root(),
+		Timestamp:    hexutil.Uint64(block.Time()),
+		GasUsed:      hexutil.Uint64(block.GasUsed()),
+		Transactions: make([]common.Hash, 0, len(block.Transactions())),
+	}
+	for _, tx := range block.Transactions() {
+		res.Transactions = append(res.Transactions, tx.Hash())
+	}
+	return res
+}
+
+// Mine advances the overlay chain by the requested number of blocks, including
+// the queued transactions in them.
+func (api *OverlayAPI) Mine(ctx context.Context, id rpc.ID, args *OverlayMineArgs) ([]*OverlayBlock, error) {
+	o, err := api.overlay(id)
+	if err != nil {
+		return nil, err
+	}
+	defer o.lock.Unlock()
+
+	var (
+		count    = uint64(1)
+		interval = uint64(1)
+	)
+	if parent := o.chain.GetHeader(o.base.ParentHash, o.base.Number.Uint64()-1); parent != nil && o.base.Time > parent.Time {
+		interval = o.base.Time - parent.Time
+	}
+	if args != nil && args.Blocks != nil {
+		count = uint64(*args.Blocks)
+	}
+	if args != nil && args.Interval != nil {
+		interval = uint64(*args.Interval)
+	}
+	if count == 0 || interval == 0 {
+		return nil, errors.New("block count and interval must be positive")
+	}
+	if uint64(len(o.blocks))+count > OverlayMaxBlocks {
+		return nil, fmt.Errorf("overlay length exceeded: %d blocks mined, max %d", len(o.blocks), OverlayMaxBlocks)
+	}
+	var results []*OverlayBlock
+	for i := uint64(0); i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := o.mine(interval)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, newOverlayBlock(block))
+	}
+	return results, nil
+}
+
+// Head returns the head block of the overlay chain.
+func (api *OverlayAPI) Head(id rpc.ID) (*OverlayBlock, error) {
+	o, err := api.overlay(id)
+	if err != nil {
+		return nil, err
+	}
+	defer o.lock.Unlock()
+
+	if len(o.blocks) == 0 {
+		return newOverlayBlock(types.NewBlockWithHeader(o.base)), nil
+	}
+	return newOverlayBlock(o.blocks[len(o.blocks)-1]), nil
+}
+
+// OverlayReceipt is the receipt of a transaction on an overlay chain.
+type OverlayReceipt struct {
+	TxHash          common.Hash     `json:"transactionHash"`
+	BlockHash       common.Hash     `json:"blockHash"`
+	BlockNumber     hexutil.Uint64  `json:"blockNumber"`
+	Status          hexutil.Uint64  `json:"status"`
+	GasUsed         hexutil.Uint64  `json:"gasUsed"`
+	ContractAddress *common.Address `json:"contractAddress"`
+	Logs            []*types.Log    `json:"logs"`
+}
+
+// GetTransactionReceipt returns the receipt of a transaction mined on the overlay
+// chain, or the reason it failed to be included.
+func (api *OverlayAPI) GetTransactionReceipt(id rpc.ID, hash common.Hash) (*OverlayReceipt, error) {
+	o, err := api.overlay(id)
+	if err != nil {
+		return nil, err
+	}
+	defer o.lock.Unlock()
+
+	if reason, ok := o.failed[hash]; ok {
+		return nil, fmt.Errorf("transaction not included: %s", reason)
+	}
+	receipt := o.receipts[hash]
+	if receipt == nil {
+		return nil, nil
+	}
+	res := &OverlayReceipt{
+		TxHash:      receipt.TxHash,
+		BlockHash:   receipt.BlockHash,
+		BlockNumber: hexutil.Uint64(receipt.BlockNumber.Uint64()),
+		Status:      hexutil.Uint64(receipt.Status),
+		GasUsed:     hexutil.Uint64(receipt.GasUsed),
+		Logs:        receipt.Logs,
+	}
+	if res.Logs == nil {
+		res.Logs = []*types.Log{}
+	}
+	if o.txs[hash].To() == nil {
+		res.ContractAddress = &receipt.ContractAddress
+	}
+	return res, nil
+}
+
+// Call executes a message on top of the overlay head, without altering the
+// overlay state. State overrides only apply to the call.
+func (api *OverlayAPI) Call(ctx context.Context, id rpc.ID, args ethapi.TransactionArgs, overrides *override.StateOverride) (hexutil.Bytes, error) {
+	o, err := api.overlay(id)
+	if err != nil {
+		return nil, err
+	}
+	defer o.lock.Unlock()
+
+	header, err := o.nextHeader(1)
+	if err != nil {
+		return nil, err
+	}
+	if args.Gas == nil {
+		gas := hexutil.Uint64(header.GasLimit)
+		args.Gas = &gas
+	}
+	if err := args.CallDefaults(api.eth.rpcGasCap.Load(), header.BaseFee, o.config.ChainID); err != nil {
+		return nil, err
+	}
+	// The copy reopens the state of the fork point, check that it's still there
+	// only afterwards as the local chain may advance concurrently.
+	statedb := o.state.Copy()
+	if !o.chain.HasState(o.base.Root) {
+		o.err = errOverlayStale
+		return nil, o.err
+	}
+	var (
+		context = core.NewEVMBlockContext(header, o, nil, o.config, statedb)
+		rules   = o.config.Rules(header.Number, context.Random != nil, header.Time)
+	)
+	precompiles := vm.ActivePrecompiledContracts(rules)
+	if err := overrides.Apply(statedb, precompiles); err != nil {
+		return nil, err
+	}
+	evm := vm.NewEVM(context, statedb, o.config, vm.Config{NoBaseFee: true})
+	evm.SetPrecompiles(precompiles)
+
+	msg := args.ToMessage(header.BaseFee, true, true)
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64))
+	if err := statedb.Error(); err != nil {
+		return nil, o.fail(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if errors.Is(result.Err, vm.ErrExecutionReverted) {
+		if reason, err := abi.UnpackRevert(result.Revert()); err == nil {
+			return nil, fmt.Errorf("%w: %v", vm.ErrExecutionReverted, reason)
+		}
+	}
+	return result.Return(), result.Err
+}
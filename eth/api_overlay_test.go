@@ -0,0 +1,189 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that overlay chains fork off the requested block, advance independently
+// of the local chain and are evicted once the limit is reached.
+func TestOverlayChain(t *testing.T) {
+	var (
+		db        = rawdb.NewMemoryDatabase()
+		recipient = common.HexToAddress("0xdead")
+		contract  = common.HexToAddress("0xc0de")
+		gspec     = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				testAddr: {Balance: big.NewInt(params.Ether)},
+				contract: {Code: []byte{byte(vm.NUMBER), byte(vm.PUSH0), byte(vm.MSTORE), byte(vm.PUSH1), 0x20, byte(vm.PUSH0), byte(vm.RETURN)}},
+			},
+		}
+		engine = beacon.New(ethash.NewFaker())
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 4, nil)
+	chain, _ := core.NewBlockChain(db, nil, gspec, nil, engine, vm.Config{}, nil)
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	eth := &Ethereum{blockchain: chain, chainDb: db}
+	eth.APIBackend = &EthAPIBackend{eth: eth}
+	api := NewOverlayAPI(eth, 1)
+
+	ctx := context.Background()
+	id, err := api.Create(ctx, rpc.BlockNumberOrHashWithNumber(2))
+	if err != nil {
+		t.Fatalf("failed to create overlay: %v", err)
+	}
+	// Queue an impersonated transfer and advance the overlay past the local head
+	value := big.NewInt(params.GWei)
+	hash, err := api.SendTransaction(id, ethapi.TransactionArgs{From: &testAddr, To: &recipient, Value: (*hexutil.Big)(value)})
+	if err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+	mined, err := api.Mine(ctx, id, &OverlayMineArgs{Blocks: (*hexutil.Uint64)(new(uint64))})
+	if err == nil {
+		t.Fatal("mined zero blocks")
+	}
+	count := hexutil.Uint64(3)
+	if mined, err = api.Mine(ctx, id, &OverlayMineArgs{Blocks: &count}); err != nil {
+		t.Fatalf("failed to mine overlay blocks: %v", err)
+	}
+	if len(mined) != 3 {
+		t.Fatalf("mined block count mismatch: have %d, want 3", len(mined))
+	}
+	if mined[0].Number != 3 || len(mined[0].Transactions) != 1 || mined[0].Transactions[0] != hash {
+		t.Fatalf("first overlay block mismatch: number %d, transactions %v", mined[0].Number, mined[0].Transactions)
+	}
+	if mined[0].Hash == blocks[2].Hash() {
+		t.Error("overlay block matches the local one")
+	}
+	if head, err := api.Head(id); err != nil || head.Number != 5 || head.Hash != mined[2].Hash {
+		t.Errorf("overlay head mismatch: have %v, err %v", head, err)
+	}
+	receipt, err := api.GetTransactionReceipt(id, hash)
+	if err != nil || receipt == nil {
+		t.Fatalf("failed to retrieve receipt: %v", err)
+	}
+	if receipt.Status != hexutil.Uint64(types.ReceiptStatusSuccessful) || receipt.BlockHash != mined[0].Hash {
+		t.Errorf("receipt mismatch: status %d, block %x", receipt.Status, receipt.BlockHash)
+	}
+	// The overlay must see the transfer, but the local chain must not
+	if balance := api.overlays[id].state.GetBalance(recipient); balance.ToBig().Cmp(value) != 0 {
+		t.Errorf("overlay balance mismatch: have %v, want %v", balance, value)
+	}
+	statedb, err := chain.StateAt(chain.CurrentBlock().Root)
+	if err != nil {
+		t.Fatalf("failed to retrieve local state: %v", err)
+	}
+	if balance := statedb.GetBalance(recipient); !balance.IsZero() {
+		t.Errorf("local chain altered by overlay: balance %v", balance)
+	}
+	// Calls must execute on top of the overlay head
+	out, err := api.Call(ctx, id, ethapi.TransactionArgs{To: &contract}, nil)
+	if err != nil {
+		t.Fatalf("failed to call overlay: %v", err)
+	}
+	if number := new(big.Int).SetBytes(out); number.Uint64() != 6 {
+		t.Errorf("call block number mismatch: have %d, want 6", number)
+	}
+	// Creating a new overlay over the limit must evict the old one
+	evicter, err := api.Create(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	if err != nil {
+		t.Fatalf("failed to create overlay: %v", err)
+	}
+	if _, err := api.Head(id); err != errOverlayNotFound {
+		t.Errorf("evicted overlay still accessible: %v", err)
+	}
+	if !api.Delete(evicter) || api.Delete(evicter) {
+		t.Error("overlay deletion mismatch")
+	}
+}
+
+// Tests that overlays forked off a path-scheme state are reported stale once the
+// local chain advances past the in-memory layers holding their fork point.
+func TestOverlayStale(t *testing.T) {
+	var (
+		db        = rawdb.NewMemoryDatabase()
+		recipient = common.HexToAddress("0xdead")
+		gspec     = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc:  types.GenesisAlloc{testAddr: {Balance: big.NewInt(params.Ether)}},
+		}
+		engine = beacon.New(ethash.NewFaker())
+		signer = types.LatestSigner(gspec.Config)
+	)
+	// Every block needs a distinct state root to get a layer of its own
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 2*state.TriesInMemory, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignNewTx(testKey, signer, &types.LegacyTx{
+			Nonce:    b.TxNonce(testAddr),
+			To:       &recipient,
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		})
+		b.AddTx(tx)
+	})
+	chain, _ := core.NewBlockChain(db, core.DefaultCacheConfigWithScheme(rawdb.PathScheme), gspec, nil, engine, vm.Config{}, nil)
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks[:2]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	eth := &Ethereum{blockchain: chain, chainDb: db}
+	eth.APIBackend = &EthAPIBackend{eth: eth}
+	api := NewOverlayAPI(eth, 1)
+
+	ctx := context.Background()
+	id, err := api.Create(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	if err != nil {
+		t.Fatalf("failed to create overlay: %v", err)
+	}
+	if _, err := api.Mine(ctx, id, nil); err != nil {
+		t.Fatalf("failed to mine overlay block: %v", err)
+	}
+	// Advance the local chain until the fork point is flushed out of memory
+	if _, err := chain.InsertChain(blocks[2:]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if _, err := api.Mine(ctx, id, nil); !errors.Is(err, errOverlayStale) {
+		t.Errorf("mining on stale overlay: have %v, want %v", err, errOverlayStale)
+	}
+	if _, err := api.Call(ctx, id, ethapi.TransactionArgs{To: &recipient}, nil); !errors.Is(err, errOverlayStale) {
+		t.Errorf("calling stale overlay: have %v, want %v", err, errOverlayStale)
+	}
+	if !api.Delete(id) {
+		t.Error("failed to delete stale overlay")
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool"
+)
+
+// AddressPolicyAPI provides the runtime management of the transaction address
+// policy, in the policy namespace. It is only served on the authenticated RPC
+// endpoints.
+type AddressPolicyAPI struct {
+	policy *txpool.AddressPolicy
+}
+
+// NewAddressPolicyAPI creates a new AddressPolicyAPI instance.
+func NewAddressPolicyAPI(policy *txpool.AddressPolicy) *AddressPolicyAPI {
+	return &AddressPolicyAPI{policy}
+}
+
+// AddressPolicyStatus is the result of policy_status.
+type AddressPolicyStatus struct {
+	Mode      string           `json:"mode"`
+	Addresses []common.Address `json:"addresses"`
+}
+
+// Status returns the mode of the policy and the listed addresses.
+func (api *AddressPolicyAPI) Status() *AddressPolicyStatus {
+	return &AddressPolicyStatus{
+		Mode:      api.policy.Mode(),
+		Addresses: api.policy.Addresses(),
+	}
+}
+
+// Add lists the given addresses, returning the number of newly listed ones.
+func (api *AddressPolicyAPI) Add(addrs []common.Address) (hexutil.Uint, error) {
+	n, err := api.policy.Add(addrs)
+	return hexutil.Uint(n), err
+}
+
+// Remove unlists the given addresses, returning the number of removed ones.
+func (api *AddressPolicyAPI) Remove(addrs []common.Address) (hexutil.Uint, error) {
+	n, err := api.policy.Remove(addrs)
+	return hexutil.Uint(n), err
+}
@@ -0,0 +1,255 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/holiman/uint256"
+)
+
+// BlobPoolAPI provides an API to inspect the blob transaction pool.
+type BlobPoolAPI struct {
+	eth *Ethereum
+}
+
+// NewBlobPoolAPI creates a new instance of BlobPoolAPI.
+func NewBlobPoolAPI(eth *Ethereum) *BlobPoolAPI {
+	return &BlobPoolAPI{eth: eth}
+}
+
+// BlobPoolStatus is the capacity and fee market snapshot returned by
+// txpool_blobStatus.
+type BlobPoolStatus struct {
+	Datacap  hexutil.Uint64 `json:"datacap"`
+	Stored   hexutil.Uint64 `json:"stored"`
+	Txs      hexutil.Uint   `json:"txs"`
+	Blobs    hexutil.Uint   `json:"blobs"`
+	Accounts hexutil.Uint   `json:"accounts"`
+
+	BaseFee          *hexutil.Big   `json:"baseFee"`
+	BlobFee          *hexutil.Big   `json:"blobFee"`
+	ProjectedBlobFee *hexutil.Big   `json:"projectedBlobFee"`
+	ProjectionBlocks hexutil.Uint64 `json:"projectionBlocks"`
+	MinGasTip        *hexutil.Big   `json:"minGasTip"`
+
+	EvictionThreshold *BlobPoolThreshold `json:"evictionThreshold"`
+
+	Evicted     hexutil.Uint64 `json:"evicted"`
+	Replaced    hexutil.Uint64 `json:"replaced"`
+	Underpriced hexutil.Uint64 `json:"underpriced"`
+	Overcapped  hexutil.Uint64 `json:"overcapped"`
+}
+
+// BlobPoolThreshold contains the fee caps of the transaction next in line for
+// eviction from the blob pool.
+type BlobPoolThreshold struct {
+	GasTipCap  *hexutil.Big `json:"maxPriorityFeePerGas"`
+	GasFeeCap  *hexutil.Big `json:"maxFeePerGas"`
+	BlobFeeCap *hexutil.Big `json:"maxFeePerBlobGas"`
+}
+
+// BlobStatus returns the capacity, fee thresholds and eviction statistics of
+// the blob transaction pool.
+func (api *BlobPoolAPI) BlobStatus() (*BlobPoolStatus, error) {
+	if api.eth.blobPool == nil {
+		return nil, errors.New("blob pool disabled")
+	}
+	status := api.eth.blobPool.PoolStatus()
+	res := &BlobPoolStatus{
+		Datacap:          hexutil.Uint64(status.Datacap),
+		Stored:           hexutil.Uint64(status.Stored),
+		Txs:              hexutil.Uint(status.Txs),
+		Blobs:            hexutil.Uint(status.Blobs),
+		Accounts:         hexutil.Uint(status.Accounts),
+		BaseFee:          toHexBig(status.Basefee),
+		BlobFee:          toHexBig(status.Blobfee),
+		ProjectedBlobFee: toHexBig(status.ProjectedBlobfee),
+		ProjectionBlocks: hexutil.Uint64(status.ProjectionBlocks),
+		MinGasTip:        toHexBig(status.GasTip),
+		Evicted:          hexutil.Uint64(status.Evicted),
+		Replaced:         hexutil.Uint64(status.Replaced),
+		Underpriced:      hexutil.Uint64(status.Underpriced),
+		Overcapped:       hexutil.Uint64(status.Overcapped),
+	}
+	if status.EvictBlobFeeCap != nil {
+		res.EvictionThreshold = &BlobPoolThreshold{
+			GasTipCap:  toHexBig(status.EvictExecTipCap),
+			GasFeeCap:  toHexBig(status.EvictExecFeeCap),
+			BlobFeeCap: toHexBig(status.EvictBlobFeeCap),
+		}
+	}
+	return res, nil
+}
+
+// PoolCapacityAPI provides an API to plan the capacity of the transaction pool.
+type PoolCapacityAPI struct {
+	eth *Ethereum
+}
+
+// NewPoolCapacityAPI creates a new instance of PoolCapacityAPI.
+func NewPoolCapacityAPI(eth *Ethereum) *PoolCapacityAPI {
+	return &PoolCapacityAPI{eth: eth}
+}
+
+// CapacityForecast is the predicted eviction from the transaction pool after a
+// time span, if the current inflow continues.
+type CapacityForecast struct {
+	Horizon   hexutil.Uint64 `json:"horizon"`   // Seconds
+	Evicted   hexutil.Uint64 `json:"evicted"`   // Slots evicted to make room
+	Threshold *hexutil.Big   `json:"threshold"` // Effective tip needed to stay in the pool, nil if no eviction
+}
+
+// CapacityReport is the capacity use and eviction forecast returned by
+// txpool_capacityReport.
+type CapacityReport struct {
+	Capacity  hexutil.Uint64 `json:"capacity"`
+	Used      hexutil.Uint64 `json:"used"`
+	Inflow    float64        `json:"inflow"` // Slots admitted per second
+	Growth    float64        `json:"growth"` // Slots per second the pool would grow by without evictions
+	Threshold *hexutil.Big   `json:"threshold"`
+
+	Forecasts   []CapacityForecast `json:"forecasts"`
+	Types       map[string]int     `json:"types"`
+	Tips        map[string]int     `json:"tips"` // By lower bound of the effective tip in gwei
+	Ages        map[string]int     `json:"ages"` // By upper bound of the age
+	Conditional int                `json:"conditional"`
+	Regular     int                `json:"regular"`
+}
+
+// CapacityReport returns the capacity use and the composition of the legacy
+// transaction pool, and forecasts the effective tips needed to stay in the
+// pool if the current inflow continues.
+func (api *PoolCapacityAPI) CapacityReport() *CapacityReport {
+	report := api.eth.legacyPool.CapacityReport()
+
+	res := &CapacityReport{
+		Capacity:    hexutil.Uint64(report.Capacity),
+		Used:        hexutil.Uint64(report.Used),
+		Inflow:      report.Inflow,
+		Growth:      report.Growth,
+		Threshold:   (*hexutil.Big)(report.Threshold),
+		Types:       report.Composition.Types,
+		Tips:        report.Composition.Tips,
+		Ages:        report.Composition.Ages,
+		Conditional: report.Composition.Conditional,
+		Regular:     report.Composition.Regular,
+	}
+	for _, forecast := range report.Forecasts {
+		res.Forecasts = append(res.Forecasts, CapacityForecast{
+			Horizon:   hexutil.Uint64(forecast.Horizon / time.Second),
+			Evicted:   hexutil.Uint64(forecast.Evicted),
+			Threshold: (*hexutil.Big)(forecast.Threshold),
+		})
+	}
+	return res
+}
+
+// ReplacementPolicyAPI provides an API to query the replacement rules of the
+// transaction pool.
+type ReplacementPolicyAPI struct {
+	eth *Ethereum
+}
+
+// NewReplacementPolicyAPI creates a new instance of ReplacementPolicyAPI.
+func NewReplacementPolicyAPI(eth *Ethereum) *ReplacementPolicyAPI {
+	return &ReplacementPolicyAPI{eth: eth}
+}
+
+// ReplacementRule is the minimal fee bump, in percent, a transaction has to pay
+// to replace a pooled one with the same sender and nonce.
+type ReplacementRule struct {
+	FeeCapBump     hexutil.Uint64  `json:"feeCapBump"`
+	TipBump        hexutil.Uint64  `json:"tipBump"`
+	BlobFeeCapBump *hexutil.Uint64 `json:"blobFeeCapBump,omitempty"`
+}
+
+// ReplacementRules returns the effective replacement rules of the transaction
+// pool, keyed by the kind of transaction they apply to: "default" and
+// "conditional" for the legacy pool, "blob" for the blob pool.
+func (api *ReplacementPolicyAPI) ReplacementRules() map[string]ReplacementRule {
+	res := make(map[string]ReplacementRule)
+	for kind, rule := range api.eth.txPool.ReplacementRules() {
+		r := ReplacementRule{
+			FeeCapBump: hexutil.Uint64(rule.FeeCapBump),
+			TipBump:    hexutil.Uint64(rule.TipBump),
+		}
+		if rule.BlobFeeCapBump != 0 {
+			bump := hexutil.Uint64(rule.BlobFeeCapBump)
+			r.BlobFeeCapBump = &bump
+		}
+		res[kind] = r
+	}
+	return res
+}
+
+// SpamScoreAPI provides an API to inspect the spam scores of the senders
+// using the transaction pool.
+type SpamScoreAPI struct {
+	eth *Ethereum
+}
+
+// NewSpamScoreAPI creates a new instance of SpamScoreAPI.
+func NewSpamScoreAPI(eth *Ethereum) *SpamScoreAPI {
+	return &SpamScoreAPI{eth: eth}
+}
+
+// SpamScore is the spam score of a sender and the minimum tip required from it
+// by the legacy transaction pool.
+type SpamScore struct {
+	Address common.Address `json:"address"`
+	Score   float64        `json:"score"`
+	Level   hexutil.Uint64 `json:"level"`
+	MinTip  *hexutil.Big   `json:"minTip"`
+}
+
+func newSpamScore(score legacypool.SpamScore) *SpamScore {
+	return &SpamScore{
+		Address: score.Address,
+		Score:   score.Score,
+		Level:   hexutil.Uint64(score.Level),
+		MinTip:  toHexBig(score.MinTip),
+	}
+}
+
+// SpamScores returns the senders with a spam score, ordered by decreasing score.
+func (api *SpamScoreAPI) SpamScores() []*SpamScore {
+	scores := api.eth.legacyPool.SpamScores()
+	res := make([]*SpamScore, len(scores))
+	for i, score := range scores {
+		res[i] = newSpamScore(score)
+	}
+	return res
+}
+
+// SpamScore returns the spam score of the given sender and the minimum tip
+// required from it.
+func (api *SpamScoreAPI) SpamScore(addr common.Address) *SpamScore {
+	return newSpamScore(api.eth.legacyPool.SpamScoreOf(addr))
+}
+
+// toHexBig converts a possibly nil uint256 into its RPC representation.
+func toHexBig(n *uint256.Int) *hexutil.Big {
+	if n == nil {
+		return nil
+	}
+	return (*hexutil.Big)(n.ToBig())
+}
@@ -20,14 +20,17 @@ package eth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/cachemgr"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core"
@@ -40,16 +43,23 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool/locals"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/abiregistry"
+	"github.com/ethereum/go-ethereum/eth/crosscheck"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/headattest"
 	"github.com/ethereum/go-ethereum/eth/interop"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
+	"github.com/ethereum/go-ethereum/eth/protocols/txrec"
+	"github.com/ethereum/go-ethereum/eth/statereader"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/watchdog"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/internal/membudget"
 	"github.com/ethereum/go-ethereum/internal/sequencerapi"
 	"github.com/ethereum/go-ethereum/internal/shutdowncheck"
 	"github.com/ethereum/go-ethereum/internal/version"
@@ -70,13 +80,21 @@ import (
 // Deprecated: use ethconfig.Config instead.
 type Config = ethconfig.Config
 
+// forkScheduleFile is the file in the instance directory the forks scheduled at
+// runtime are persisted to.
+const forkScheduleFile = "forkschedule.json"
+
 // Ethereum implements the Ethereum full node service.
 type Ethereum struct {
 	// core protocol objects
 	config         *ethconfig.Config
 	txPool         *txpool.TxPool
+	legacyPool     *legacypool.LegacyPool // Legacy transaction subpool
+	blobPool       *blobpool.BlobPool     // Blob transaction subpool, nil if disabled
 	localTxTracker *locals.TxTracker
+	poolJournaler  *locals.PoolJournaler // Journaler of the whole pool, nil if unused
 	blockchain     *core.BlockChain
+	reexec         *reexecService // Historical state regenerator of the tracing APIs
 
 	handler *handler
 	discmix *enode.FairMix
@@ -92,6 +110,35 @@ type Ethereum struct {
 	filterMaps      *filtermaps.FilterMaps
 	closeFilterMaps chan chan struct{}
 
+	senderIndexer   *core.SenderIndexer      // Sender transaction indexer, nil if disabled
+	transferIndexer *core.TransferIndexer    // Token transfer indexer, nil if disabled
+	internalIndexer *core.InternalTxIndexer  // Internal transaction indexer, nil if disabled
+	creationIndexer *core.CreationIndexer    // Contract creation indexer, nil if disabled
+	codeIndexer     *core.CodeChangeIndexer  // Code change indexer, nil if disabled
+	auditor         *core.ChainAuditor       // Background chain data auditor, nil if disabled
+	headAttest      *headattest.Tracker      // Attested chain head tracker, nil if disabled
+	watchdog        *watchdog.Watchdog       // Chain head watchdog, nil if disabled
+	badBlocks       *badBlockRecorder        // Bad block artifact recorder, nil if disabled
+	crossCheck      *crosscheck.CrossChecker // Execution cross-checker, nil if disabled
+	cacheManager    *cachemgr.Manager        // Cache partitioning manager, nil if neither adaptive nor budgeted
+	memBudget       *membudget.Budget        // Node-wide memory budget, nil if disabled
+	traceAccount    *membudget.Account       // Memory reserved by the running traces, nil if no budget
+	stateReader     *statereader.Server      // State reader endpoint, nil if disabled
+	addrPolicy      *txpool.AddressPolicy    // Transaction address policy, nil if disabled
+	abiRegistry     *abiregistry.Registry    // Contract ABI registry for decoding traces and logs
+	policyAudit     *txpool.AuditLog         // Audit log of the address policy, nil if disabled
+
+	forkSchedule     core.ForkSchedule // Forks scheduled at runtime through the admin API
+	forkScheduleFile string            // File the fork schedule is persisted to, empty if ephemeral
+
+	stack       *node.Node                  // Node the service runs in, to open the legacy state
+	legacyState atomic.Pointer[legacyState] // Hash scheme state left by a migration, nil if not attached
+
+	pauseTimer *time.Timer // Resumes the chain paused for maintenance, nil if not paused
+
+	rpcGasCap     atomic.Uint64 // Gas cap of RPC calls, adjustable at runtime
+	rpcEVMTimeout atomic.Int64  // EVM timeout of RPC calls, adjustable at runtime
+
 	APIBackend *EthAPIBackend
 
 	miner    *miner.Miner
@@ -106,11 +153,15 @@ type Ethereum struct {
 
 	shutdownTracker *shutdowncheck.ShutdownTracker // Tracks if and when the node has shutdown ungracefully
 
+	draining  atomic.Bool // Whether transaction submissions are refused due to a shutdown
+	chainStop sync.Once   // Stops the chain processing once, in a drain or a stop
+
 	// OP-Stack additions
 	seqRPCService        *rpc.Client
 	historicalRPCService *rpc.Client
 
-	interopRPC *interop.InteropClient
+	interopRPC       *interop.InteropClient
+	interopValidator *interop.Validator // Caching and failure policy on top of interopRPC
 
 	nodeCloser func() error
 }
@@ -183,10 +234,13 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		p2pServer:       stack.Server(),
 		discmix:         enode.NewFairMix(0),
 		shutdownTracker: shutdowncheck.NewShutdownTracker(chainDb),
+		stack:           stack,
 
 		// OP-Stack addition
 		nodeCloser: stack.Close,
 	}
+	eth.rpcGasCap.Store(config.RPCGasCap)
+	eth.rpcEVMTimeout.Store(int64(config.RPCEVMTimeout))
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
 	dbVer := "<nil>"
 	if bcVersion != nil {
@@ -207,20 +261,44 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	var (
 		vmConfig = vm.Config{
 			EnablePreimageRecording: config.EnablePreimageRecording,
+			CodeAnalysis:            config.VMCodeAnalysis,
+			CodeAnalysisShadow:      config.VMCodeAnalysisShadow,
 		}
 		cacheConfig = &core.CacheConfig{
-			TrieCleanLimit:      config.TrieCleanCache,
-			TrieCleanNoPrefetch: config.NoPrefetch,
-			TrieDirtyLimit:      config.TrieDirtyCache,
-			TrieDirtyDisabled:   config.NoPruning,
-			TrieTimeLimit:       config.TrieTimeout,
-			SnapshotLimit:       config.SnapshotCache,
-			Preimages:           config.Preimages,
-			StateHistory:        config.StateHistory,
-			StateScheme:         scheme,
-			ChainHistoryMode:    config.HistoryMode,
+			TrieCleanLimit:       config.TrieCleanCache,
+			TrieCleanNoPrefetch:  config.NoPrefetch,
+			TrieDirtyLimit:       config.TrieDirtyCache,
+			TrieDirtyDisabled:    config.NoPruning,
+			TrieTimeLimit:        config.TrieTimeout,
+			SnapshotLimit:        config.SnapshotCache,
+			CodeCacheLimit:       config.CodeCache,
+			Preimages:            config.Preimages,
+			StateHistory:         config.StateHistory,
+			StateScheme:          scheme,
+			StateWriteAheadLog:   config.StateWriteAheadLog,
+			StateHistoryCompress: config.StateHistoryCompress,
+			ChainHistoryMode:     config.HistoryMode,
 		}
 	)
+	if config.StateRent {
+		cacheConfig.StateRent = &config.StateRentConfig
+	}
+	if config.InternalTxIndex {
+		cacheConfig.InternalTxs = true
+	}
+	if config.CreationIndex {
+		cacheConfig.InternalTxs = true
+		cacheConfig.CreationSalts = true
+	}
+	if config.CodeChangeIndex {
+		cacheConfig.CodeChanges = true
+	}
+	if config.BalanceChanges {
+		cacheConfig.BalanceChanges = true
+	}
+	if config.Supply {
+		cacheConfig.Supply = true
+	}
 	if config.VMTrace != "" {
 		traceConfig := json.RawMessage("{}")
 		if config.VMTraceJsonConfig != "" {
@@ -266,10 +344,22 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	}
 	overrides.ApplySuperchainUpgrades = config.ApplySuperchainUpgrades
 
+	// Apply the forks scheduled at runtime on top of the configured ones
+	eth.forkSchedule = make(core.ForkSchedule)
+	if eth.forkScheduleFile = stack.ResolvePath(forkScheduleFile); eth.forkScheduleFile != "" {
+		if eth.forkSchedule, err = core.LoadForkSchedule(eth.forkScheduleFile); err != nil {
+			return nil, err
+		}
+		if err := eth.forkSchedule.Apply(&overrides); err != nil {
+			return nil, err
+		}
+	}
+
 	eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, config.Genesis, &overrides, eth.engine, vmConfig, &config.TransactionHistory)
 	if err != nil {
 		return nil, err
 	}
+	eth.reexec = newReexecService(eth, config.RPCReexecLimit, config.RPCReexecCache)
 
 	if chainConfig := eth.blockchain.Config(); chainConfig.Optimism != nil { // config.Genesis.Config.ChainID cannot be used because it's based on CLI flags only, thus default to mainnet L1
 		config.NetworkId = chainConfig.ChainID.Uint64() // optimism defaults eth network ID to chain ID
@@ -277,6 +367,34 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	}
 	log.Info("Initialising Ethereum protocol", "network", config.NetworkId, "dbversion", dbVer)
 
+	var chainID uint64
+	if id := eth.blockchain.Config().ChainID; id != nil {
+		chainID = id.Uint64()
+	}
+	eth.abiRegistry = abiregistry.New(chainDb, config.ContractABISourcify, chainID)
+
+	// Serve the historical state from the hash scheme database left by a migration
+	if config.LegacyStateDir != "" {
+		if _, err := eth.attachLegacyState(config.LegacyStateDir); err != nil {
+			return nil, fmt.Errorf("failed to attach legacy state: %v", err)
+		}
+	}
+
+	// Capture the artifacts of the blocks failing validation.
+	if config.BadBlockArtifacts != "" {
+		if eth.badBlocks, err = newBadBlockRecorder(eth, config.BadBlockArtifacts); err != nil {
+			return nil, err
+		}
+	}
+
+	// Track the heads attested by the configured sequencer and verifier keys.
+	if config.HeadAttest {
+		eth.headAttest, err = headattest.NewTracker(eth.blockchain.Config().ChainID, config.HeadAttestConfig, eth.blockchain.HasBlockAndState)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize filtermaps log index.
 	fmConfig := filtermaps.Config{
 		History:        config.LogHistory,
@@ -298,6 +416,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
+	eth.legacyPool = legacyPool
 
 	if config.BlobPool.Datadir != "" {
 		config.BlobPool.Datadir = stack.ResolvePath(config.BlobPool.Datadir)
@@ -305,8 +424,8 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 
 	txPools := []txpool.SubPool{legacyPool}
 	if !eth.BlockChain().Config().IsOptimism() {
-		blobPool := blobpool.New(config.BlobPool, eth.blockchain, legacyPool.HasPendingAuth)
-		txPools = append(txPools, blobPool)
+		eth.blobPool = blobpool.New(config.BlobPool, eth.blockchain, legacyPool.HasPendingAuth)
+		txPools = append(txPools, eth.blobPool)
 	}
 	// if interop is enabled, establish an Interop Filter connected to this Ethereum instance's
 	// simulated logs and message safety check functions
@@ -314,6 +433,25 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if config.InteropMessageRPC != "" && config.InteropMempoolFiltering {
 		poolFilters = append(poolFilters, txpool.NewInteropFilter(eth))
 	}
+	if config.AddressPolicy != "" {
+		path := stack.ResolvePath(config.AddressPolicyAudit)
+		if path == "" {
+			return nil, errors.New("address policy audit log requires a data directory")
+		}
+		eth.policyAudit, err = txpool.OpenAuditLog(path, stack.Config().NodeKey())
+		if err != nil {
+			return nil, err
+		}
+		eth.addrPolicy, err = txpool.NewAddressPolicy(config.AddressPolicy, config.AddressPolicyList, types.LatestSigner(eth.blockchain.Config()), eth.policyAudit)
+		if err != nil {
+			return nil, err
+		}
+		poolFilters = append(poolFilters, eth.addrPolicy)
+		log.Info("Enabled transaction address policy", "mode", config.AddressPolicy, "addresses", len(config.AddressPolicyList), "audit", path)
+	}
+	if config.TxPool.RevertCheck != "" {
+		poolFilters = append(poolFilters, txpool.NewRevertFilter(eth.blockchain, config.TxPool.RevertCheck == txpool.RevertCheckReject, config.TxPool.RevertCheckGas))
+	}
 	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, txPools, poolFilters)
 	if err != nil {
 		return nil, err
@@ -331,8 +469,8 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		eth.localTxTracker = locals.New(config.TxPool.Journal, rejournal, eth.blockchain.Config(), eth.txPool)
 		stack.RegisterLifecycle(eth.localTxTracker)
 	} else if config.TxPool.JournalRemote {
-		pj := locals.NewPoolJournaler(config.TxPool.Journal, rejournal, eth.txPool)
-		stack.RegisterLifecycle(pj)
+		eth.poolJournaler = locals.NewPoolJournaler(config.TxPool.Journal, rejournal, eth.txPool)
+		stack.RegisterLifecycle(eth.poolJournaler)
 	}
 
 	// Permit the downloader to use the trie cache allowance during fast sync
@@ -344,21 +482,55 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		TxPool:         eth.txPool,
 		Network:        networkID,
 		Sync:           config.SyncMode,
+		Checkpoint:     config.SyncCheckpoint,
+		Pivot:          config.SyncPivot,
 		BloomCache:     uint64(cacheLimit),
 		EventMux:       eth.eventMux,
 		RequiredBlocks: config.RequiredBlocks,
 		NoTxGossip:     config.RollupDisableTxPoolGossip,
+		Standby:        config.Standby,
+		TxFetcher:      config.TxFetcher,
+		TxAnnounceSize: config.TxAnnounceSize,
 	}); err != nil {
 		return nil, err
 	}
 
 	eth.dropper = newDropper(eth.p2pServer.MaxDialedConns(), eth.p2pServer.MaxInboundConns())
 
+	// Watch the chain head for stalls behind the network.
+	if config.Watchdog {
+		eth.watchdog, err = watchdog.New(config.WatchdogConfig, &watchdogBackend{h: eth.handler})
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Verify the execution of the imported blocks against the reference node.
+	if config.CrossCheck {
+		eth.crossCheck, err = crosscheck.New(config.CrossCheckConfig, eth.blockchain, eth.haltChain)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Serve the state to external snapshot consumers.
+	if config.StateReader {
+		eth.stateReader = statereader.NewServer(config.StateReaderConfig, eth.blockchain)
+	}
+
 	eth.miner = miner.New(eth, config.Miner, eth.engine)
+	eth.miner.SetStandby(config.Standby)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 	eth.miner.SetPrioAddresses(config.TxPool.Locals)
+	if config.AdaptiveCache || config.MemoryLimit > 0 {
+		eth.cacheManager = newCacheManager(eth)
+	}
+	if config.MemoryLimit > 0 {
+		eth.memBudget = newMemoryBudget(eth, stack)
+	}
+	if eth.addrPolicy != nil {
+		eth.miner.SetAddressPolicy(eth.addrPolicy)
+	}
 
-	eth.APIBackend = &EthAPIBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, config.RollupDisableTxPoolAdmission, eth, nil}
+	eth.APIBackend = &EthAPIBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, config.RollupDisableTxPoolAdmission, eth, nil, ethapi.NewCallPrefetcher(config.RPCPrefetchLearn)}
 	if eth.APIBackend.allowUnprotectedTxs {
 		log.Info("Unprotected transactions allowed")
 	}
@@ -385,12 +557,22 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	}
 
 	if config.InteropMessageRPC != "" {
+		policy := interop.Strict
+		if config.InteropFailurePolicy != "" {
+			if policy, err = interop.ParseFailurePolicy(config.InteropFailurePolicy); err != nil {
+				return nil, err
+			}
+		}
 		eth.interopRPC = interop.NewInteropClient(config.InteropMessageRPC)
+		eth.interopValidator = interop.NewValidator(eth.interopRPC, policy, config.InteropCacheTTL)
 	}
 
 	// Start the RPC service
 	eth.netRPCService = ethapi.NewNetAPI(eth.p2pServer, networkID)
 
+	// Advertise the build and chain identity in the devp2p handshake
+	eth.updateIdentity()
+
 	// Register the backend on the node
 	stack.RegisterAPIs(eth.APIs())
 	stack.RegisterProtocols(eth.Protocols())
@@ -433,6 +615,42 @@ func (s *Ethereum) APIs() []rpc.API {
 		costRateLimit := rate.Limit(s.config.RollupSequencerTxConditionalCostRateLimit)
 		apis = append(apis, sequencerapi.GetSendRawTxConditionalAPI(s.APIBackend, s.seqRPCService, costRateLimit))
 	}
+	// Append the attested head API if running as an attestation follower
+	if s.headAttest != nil {
+		apis = append(apis, rpc.API{
+			Namespace: "attest",
+			Service:   headattest.NewAPI(s.headAttest),
+		})
+	}
+	// Append the address policy management API, for authenticated clients only
+	if s.addrPolicy != nil {
+		apis = append(apis, rpc.API{
+			Namespace:     "policy",
+			Service:       NewAddressPolicyAPI(s.addrPolicy),
+			Authenticated: true,
+		})
+	}
+	// Append the what-if overlay chain API if enabled
+	if s.config.RPCOverlays > 0 {
+		apis = append(apis, rpc.API{
+			Namespace: "overlay",
+			Service:   NewOverlayAPI(s, s.config.RPCOverlays),
+		})
+	}
+	// Append the chain pausing API, for authenticated clients only
+	apis = append(apis, rpc.API{
+		Namespace:     "admin",
+		Service:       NewMaintenanceAPI(s),
+		Authenticated: true,
+	}, rpc.API{
+		Namespace:     "admin",
+		Service:       NewContractABIAPI(s),
+		Authenticated: true,
+	}, rpc.API{
+		Namespace:     "txpool",
+		Service:       NewLocalTxAdminAPI(s),
+		Authenticated: true,
+	})
 
 	// Append all the local APIs and return
 	return append(apis, []rpc.API{
@@ -442,6 +660,39 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "eth",
 			Service:   downloader.NewDownloaderAPI(s.handler.downloader, s.blockchain, s.eventMux),
+		}, {
+			Namespace: "txpool",
+			Service:   NewBlobPoolAPI(s),
+		}, {
+			Namespace: "txpool",
+			Service:   NewReplacementPolicyAPI(s),
+		}, {
+			Namespace: "txpool",
+			Service:   NewPoolCapacityAPI(s),
+		}, {
+			Namespace: "txpool",
+			Service:   NewSpamScoreAPI(s),
+		}, {
+			Namespace: "eth",
+			Service:   NewIndexAPI(s),
+		}, {
+			Namespace: "ext",
+			Service:   NewTokenIndexAPI(s),
+		}, {
+			Namespace: "ext",
+			Service:   NewInternalTxIndexAPI(s),
+		}, {
+			Namespace: "ext",
+			Service:   NewCreationIndexAPI(s),
+		}, {
+			Namespace: "ext",
+			Service:   NewCodeChangeIndexAPI(s),
+		}, {
+			Namespace: "ext",
+			Service:   NewDecodeAPI(s),
+		}, {
+			Namespace: "explorer",
+			Service:   NewExplorerAPI(s),
 		}, {
 			Namespace: "admin",
 			Service:   NewAdminAPI(s),
@@ -451,6 +702,9 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "net",
 			Service:   s.netRPCService,
+		}, {
+			Namespace: "web3",
+			Service:   NewBuildInfoAPI(s),
 		},
 	}...)
 }
@@ -461,16 +715,34 @@ func (s *Ethereum) ResetWithGenesisBlock(gb *types.Block) {
 
 func (s *Ethereum) Miner() *miner.Miner { return s.miner }
 
-func (s *Ethereum) AccountManager() *accounts.Manager  { return s.accountManager }
-func (s *Ethereum) BlockChain() *core.BlockChain       { return s.blockchain }
-func (s *Ethereum) TxPool() *txpool.TxPool             { return s.txPool }
-func (s *Ethereum) Engine() consensus.Engine           { return s.engine }
-func (s *Ethereum) ChainDb() ethdb.Database            { return s.chainDb }
-func (s *Ethereum) IsListening() bool                  { return true } // Always listening
-func (s *Ethereum) Downloader() *downloader.Downloader { return s.handler.downloader }
-func (s *Ethereum) Synced() bool                       { return s.handler.synced.Load() }
-func (s *Ethereum) SetSynced()                         { s.handler.enableSyncedFeatures() }
-func (s *Ethereum) ArchiveMode() bool                  { return s.config.NoPruning }
+func (s *Ethereum) AccountManager() *accounts.Manager          { return s.accountManager }
+func (s *Ethereum) BlockChain() *core.BlockChain               { return s.blockchain }
+func (s *Ethereum) SenderIndexer() *core.SenderIndexer         { return s.senderIndexer }
+func (s *Ethereum) TransferIndexer() *core.TransferIndexer     { return s.transferIndexer }
+func (s *Ethereum) InternalTxIndexer() *core.InternalTxIndexer { return s.internalIndexer }
+func (s *Ethereum) CreationIndexer() *core.CreationIndexer     { return s.creationIndexer }
+func (s *Ethereum) CodeChangeIndexer() *core.CodeChangeIndexer { return s.codeIndexer }
+func (s *Ethereum) ChainAuditor() *core.ChainAuditor           { return s.auditor }
+func (s *Ethereum) Watchdog() *watchdog.Watchdog               { return s.watchdog }
+func (s *Ethereum) CrossChecker() *crosscheck.CrossChecker     { return s.crossCheck }
+func (s *Ethereum) TxPool() *txpool.TxPool                     { return s.txPool }
+func (s *Ethereum) Engine() consensus.Engine                   { return s.engine }
+func (s *Ethereum) ChainDb() ethdb.Database                    { return s.chainDb }
+func (s *Ethereum) IsListening() bool                          { return true } // Always listening
+func (s *Ethereum) Downloader() *downloader.Downloader         { return s.handler.downloader }
+func (s *Ethereum) Synced() bool                               { return s.handler.synced.Load() }
+func (s *Ethereum) SetSynced()                                 { s.handler.enableSyncedFeatures() }
+func (s *Ethereum) ArchiveMode() bool                          { return s.config.NoPruning }
+
+// TxPropagation returns the policy of propagating pool transactions to peers.
+func (s *Ethereum) TxPropagation() TxPropagation {
+	return TxPropagation(s.handler.txPropagation.Load())
+}
+
+// SetTxPropagation changes the policy of propagating pool transactions to peers.
+func (s *Ethereum) SetTxPropagation(policy TxPropagation) {
+	s.handler.txPropagation.Store(uint32(policy))
+}
 
 // Protocols returns all the currently configured
 // network protocols to start.
@@ -479,6 +751,12 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 	if s.config.SnapshotCache > 0 {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler))...)
 	}
+	if s.config.TxPoolReconcile > 0 {
+		protos = append(protos, txrec.MakeProtocols((*txrecHandler)(s.handler), s.config.TxPoolReconcile)...)
+	}
+	if s.config.SlimBlocks {
+		protos = append(protos, eth.MakeSlimProtocols()...)
+	}
 	return protos
 }
 
@@ -497,10 +775,50 @@ func (s *Ethereum) Start() error {
 
 	// Start the connection manager
 	s.dropper.Start(s.p2pServer, func() bool { return !s.Synced() })
+	if s.watchdog != nil {
+		s.watchdog.Start()
+	}
+	if s.badBlocks != nil {
+		s.badBlocks.start()
+	}
+	if s.crossCheck != nil {
+		s.crossCheck.Start()
+	}
+	if s.config.AdaptiveCache {
+		s.cacheManager.Start()
+	}
+	if s.memBudget != nil {
+		s.memBudget.Start()
+	}
+	if s.stateReader != nil {
+		if err := s.stateReader.Start(); err != nil {
+			return err
+		}
+	}
 
 	// start log indexer
 	s.filterMaps.Start()
 	go s.updateFilterMapsHeads()
+
+	// Start the optional chain indexers and the chain data auditor
+	if s.config.SenderIndex {
+		s.senderIndexer = core.NewSenderIndexer(s.blockchain, s.config.SenderIndexHistory)
+	}
+	if s.config.TransferIndex {
+		s.transferIndexer = core.NewTransferIndexer(s.blockchain, s.config.TransferIndexHistory)
+	}
+	if s.config.InternalTxIndex {
+		s.internalIndexer = core.NewInternalTxIndexer(s.blockchain, s.config.InternalTxIndexHistory)
+	}
+	if s.config.CreationIndex {
+		s.creationIndexer = core.NewCreationIndexer(s.blockchain)
+	}
+	if s.config.CodeChangeIndex {
+		s.codeIndexer = core.NewCodeChangeIndexer(s.blockchain, s.config.CodeChangeIndexHistory)
+	}
+	if s.config.ChainAudit {
+		s.auditor = core.NewChainAuditor(s.blockchain, s.config.ChainAuditConfig)
+	}
 	return nil
 }
 
@@ -586,9 +904,14 @@ func (s *Ethereum) setupDiscovery() error {
 
 	// Add DHT nodes from discv5.
 	if s.p2pServer.DiscoveryV5() != nil {
-		filter := eth.NewNodeFilter(s.blockchain)
-		iter := enode.Filter(s.p2pServer.DiscoveryV5().RandomNodes(), filter)
+		iter := eth.NewDHTIterator(s.blockchain, s.p2pServer.DiscoveryV5().RandomNodes())
 		s.discmix.AddSource(iter)
+
+		// Add nodes advertised under the chain topic.
+		if s.config.DiscoveryTopic {
+			eth.StartTopicAdvertiser(s.blockchain, s.p2pServer.DiscoveryV5())
+			s.discmix.AddSource(eth.NewTopicIterator(s.blockchain, s.p2pServer.DiscoveryV5()))
+		}
 	}
 
 	return nil
@@ -597,18 +920,7 @@ func (s *Ethereum) setupDiscovery() error {
 // Stop implements node.Lifecycle, terminating all internal goroutines used by the
 // Ethereum protocol.
 func (s *Ethereum) Stop() error {
-	// Stop all the peer-related stuff first.
-	s.discmix.Close()
-	s.dropper.Stop()
-	s.handler.Stop()
-
-	// Then stop everything else.
-	ch := make(chan struct{})
-	s.closeFilterMaps <- ch
-	<-ch
-	s.filterMaps.Stop()
-	s.txPool.Close()
-	s.blockchain.Stop()
+	s.stopChain()
 	s.engine.Close()
 	if s.seqRPCService != nil {
 		s.seqRPCService.Close()
@@ -619,6 +931,7 @@ func (s *Ethereum) Stop() error {
 	if s.interopRPC != nil {
 		s.interopRPC.Close()
 	}
+	s.detachLegacyState()
 	if s.miner != nil {
 		s.miner.Close()
 	}
@@ -632,6 +945,98 @@ func (s *Ethereum) Stop() error {
 	return nil
 }
 
+// DrainPhases implements node.Drainer, returning the phases of a graceful
+// shutdown: refusing transaction submissions over RPC, waiting for the payloads
+// being built, flushing the transaction journal and committing the state.
+func (s *Ethereum) DrainPhases() []node.DrainPhase {
+	return []node.DrainPhase{
+		{Name: "rpc-writes", Run: func(ctx context.Context) error {
+			s.draining.Store(true)
+			return nil
+		}},
+		{Name: "payload", Run: s.miner.Drain},
+		{Name: "txpool-journal", Run: func(ctx context.Context) error {
+			switch {
+			case s.localTxTracker != nil:
+				return s.localTxTracker.Flush(ctx)
+			case s.poolJournaler != nil:
+				return s.poolJournaler.Flush(ctx)
+			}
+			return nil
+		}},
+		{Name: "state", Run: func(ctx context.Context) error {
+			s.stopChain()
+			return nil
+		}},
+	}
+}
+
+// stopChain stops the networking and all the services importing or indexing
+// the chain, then the chain itself, committing its state. It only runs once,
+// either in the state phase of a drain or when the service is stopped.
+func (s *Ethereum) stopChain() {
+	s.chainStop.Do(func() {
+		// Stop all the peer-related stuff first.
+		s.discmix.Close()
+		s.dropper.Stop()
+		if s.watchdog != nil {
+			s.watchdog.Stop()
+		}
+		s.handler.Stop()
+		if s.config.AdaptiveCache {
+			s.cacheManager.Stop()
+		}
+		if s.memBudget != nil {
+			s.memBudget.Stop()
+		}
+
+		// Then stop everything else.
+		ch := make(chan struct{})
+		s.closeFilterMaps <- ch
+		<-ch
+		s.filterMaps.Stop()
+		if s.senderIndexer != nil {
+			s.senderIndexer.Close()
+		}
+		if s.transferIndexer != nil {
+			s.transferIndexer.Close()
+		}
+		if s.internalIndexer != nil {
+			s.internalIndexer.Close()
+		}
+		if s.creationIndexer != nil {
+			s.creationIndexer.Close()
+		}
+		if s.codeIndexer != nil {
+			s.codeIndexer.Close()
+		}
+		if s.auditor != nil {
+			s.auditor.Close()
+		}
+		if s.policyAudit != nil {
+			s.policyAudit.Close()
+		}
+		if s.badBlocks != nil {
+			s.badBlocks.stop()
+		}
+		if s.crossCheck != nil {
+			s.crossCheck.Stop()
+		}
+		if s.stateReader != nil {
+			s.stateReader.Stop()
+		}
+		s.txPool.Close()
+
+		s.lock.Lock()
+		if s.pauseTimer != nil {
+			s.pauseTimer.Stop()
+			s.pauseTimer = nil
+		}
+		s.lock.Unlock()
+		s.blockchain.Stop()
+	})
+}
+
 // SyncMode retrieves the current sync mode, either explicitly set, or derived
 // from the chain status.
 func (s *Ethereum) SyncMode() ethconfig.SyncMode {
@@ -0,0 +1,335 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/version"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// maxBadBlockArtifacts is the number of bad block artifacts kept on disk,
+	// the oldest ones are deleted first.
+	maxBadBlockArtifacts = 10
+
+	// badBlockTraceLimit is the maximum size of a bad block re-execution trace,
+	// the rest of the trace is dropped.
+	badBlockTraceLimit = 256 * 1024 * 1024
+
+	// badBlockReexec is the number of blocks re-executed to regenerate the
+	// parent state of a bad block if it's not available.
+	badBlockReexec = 128
+
+	// Names of the files in a bad block artifact directory.
+	badBlockRLPFile   = "block.rlp"
+	badBlockInfoFile  = "info.json"
+	badBlockTraceFile = "trace.jsonl"
+)
+
+// BadBlockInfo describes a block which failed validation, captured along with
+// the block RLP and the trace of its re-execution.
+type BadBlockInfo struct {
+	Number     hexutil.Uint64 `json:"number"`
+	Hash       common.Hash    `json:"hash"`
+	ParentHash common.Hash    `json:"parentHash"`
+	Error      string         `json:"error"`
+	Time       time.Time      `json:"time"`
+	Head       hexutil.Uint64 `json:"head"` // Local chain head when the block was rejected
+	Version    string         `json:"version"`
+	Platform   string         `json:"platform"`
+
+	// Receipts produced by the failed processing, if it got that far
+	Receipts json.RawMessage `json:"receipts,omitempty"`
+
+	// Availability of the parent when the block was rejected
+	ParentKnown bool `json:"parentKnown"`
+	ParentState bool `json:"parentState"`
+
+	// Outcome of the re-execution against the parent state
+	Traced         bool         `json:"traced"`
+	TraceTruncated bool         `json:"traceTruncated,omitempty"`
+	ExecutionError string       `json:"executionError,omitempty"`
+	ComputedRoot   *common.Hash `json:"computedRoot,omitempty"` // State root computed by the re-execution
+}
+
+// badBlockRecorder captures the artifacts needed to reproduce the blocks
+// failing validation into a directory, one sub-directory per block.
+type badBlockRecorder struct {
+	eth *Ethereum
+	dir string
+
+	events chan core.BadBlockEvent
+	sub    event.Subscription
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	lock   sync.Mutex // Lock protecting the artifact directory
+}
+
+// newBadBlockRecorder creates a recorder capturing the bad block artifacts
+// into the given directory.
+func newBadBlockRecorder(eth *Ethereum, dir string) (*badBlockRecorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create bad block directory: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &badBlockRecorder{
+		eth:    eth,
+		dir:    dir,
+		events: make(chan core.BadBlockEvent, 16),
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+// start subscribes to the bad blocks of the chain.
+func (r *badBlockRecorder) start() {
+	r.sub = r.eth.blockchain.SubscribeBadBlockEvent(r.events)
+	r.wg.Add(1)
+	go r.loop()
+}
+
+// stop terminates the recorder, aborting the capture in progress.
+func (r *badBlockRecorder) stop() {
+	r.cancel()
+	if r.sub != nil {
+		r.sub.Unsubscribe()
+	}
+	r.wg.Wait()
+}
+
+func (r *badBlockRecorder) loop() {
+	defer r.wg.Done()
+
+	for {
+		select {
+		case ev := <-r.events:
+			if err := r.capture(ev); err != nil {
+				log.Error("Failed to capture bad block artifacts", "number", ev.Block.Number(), "hash", ev.Block.Hash(), "err", err)
+			}
+		case <-r.sub.Err():
+			return
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// artifactDir returns the directory of the artifacts of a block.
+func (r *badBlockRecorder) artifactDir(block *types.Block) string {
+	return filepath.Join(r.dir, fmt.Sprintf("%d-%s", block.NumberU64(), block.Hash().Hex()))
+}
+
+// capture writes the artifacts of a bad block. Blocks rejected repeatedly are
+// only captured once.
+func (r *badBlockRecorder) capture(ev core.BadBlockEvent) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	block := ev.Block
+	dir := r.artifactDir(block)
+	if _, err := os.Stat(filepath.Join(dir, badBlockInfoFile)); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	enc, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, badBlockRLPFile), enc, 0644); err != nil {
+		return err
+	}
+	vsn, vcs := version.Info()
+	if vcs != "" {
+		vsn += "-" + vcs
+	}
+	var (
+		chain = r.eth.blockchain
+		info  = &BadBlockInfo{
+			Number:     hexutil.Uint64(block.NumberU64()),
+			Hash:       block.Hash(),
+			ParentHash: block.ParentHash(),
+			Time:       time.Now(),
+			Head:       hexutil.Uint64(chain.CurrentBlock().Number.Uint64()),
+			Version:    vsn,
+			Platform:   fmt.Sprintf("%s %s %s", runtime.Version(), runtime.GOARCH, runtime.GOOS),
+		}
+	)
+	if ev.Receipts != nil {
+		if info.Receipts, err = json.Marshal(ev.Receipts); err != nil {
+			return err
+		}
+	}
+	if ev.Err != nil {
+		info.Error = ev.Err.Error()
+	}
+	if block.NumberU64() > 0 {
+		if parent := chain.GetBlock(block.ParentHash(), block.NumberU64()-1); parent != nil {
+			info.ParentKnown = true
+			info.ParentState = chain.HasState(parent.Root())
+			if err := r.trace(block, parent, filepath.Join(dir, badBlockTraceFile), info); err != nil {
+				log.Warn("Failed to trace bad block", "number", block.Number(), "hash", block.Hash(), "err", err)
+			}
+		}
+	}
+	blob, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, badBlockInfoFile), blob, 0644); err != nil {
+		return err
+	}
+	log.Warn("Captured bad block artifacts", "number", block.Number(), "hash", block.Hash(), "dir", dir)
+	r.prune()
+	return nil
+}
+
+// trace re-executes the block against the parent state, writing the opcode
+// level trace into the given file and the outcome into the info.
+func (r *badBlockRecorder) trace(block *types.Block, parent *types.Block, path string, info *BadBlockInfo) error {
+	statedb, release, err := r.eth.stateAtBlock(r.ctx, parent, badBlockReexec, nil, true, false)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var (
+		buf    = bufio.NewWriter(file)
+		out    = &limitWriter{w: buf, left: badBlockTraceLimit}
+		chain  = r.eth.blockchain
+		tracer = logger.NewJSONLoggerWithCallFrames(&logger.Config{EnableReturnData: true}, out)
+	)
+	processor := core.NewStateProcessor(chain.Config(), chain.HeaderChain())
+	if _, err := processor.Process(block, statedb, vm.Config{Tracer: tracer}); err != nil {
+		info.ExecutionError = err.Error()
+	} else {
+		root := statedb.IntermediateRoot(chain.Config().IsEIP158(block.Number()))
+		info.ComputedRoot = &root
+	}
+	info.Traced, info.TraceTruncated = true, out.truncated
+	return buf.Flush()
+}
+
+// prune deletes the oldest artifacts beyond the retention limit.
+func (r *badBlockRecorder) prune() {
+	infos, err := r.read(nil, false)
+	if err != nil || len(infos) <= maxBadBlockArtifacts {
+		return
+	}
+	for _, info := range infos[maxBadBlockArtifacts:] {
+		dir := filepath.Join(r.dir, fmt.Sprintf("%d-%s", info.Number, info.Hash.Hex()))
+		if err := os.RemoveAll(dir); err != nil {
+			log.Warn("Failed to delete bad block artifacts", "dir", dir, "err", err)
+		}
+	}
+}
+
+// artifacts returns the captured artifacts, newest first, optionally filtered
+// by block hash. The block RLP and the trace are only loaded if requested.
+func (r *badBlockRecorder) artifacts(hash *common.Hash, full bool) ([]*BadBlockArtifact, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.read(hash, full)
+}
+
+func (r *badBlockRecorder) read(hash *common.Hash, full bool) ([]*BadBlockArtifact, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, err
+	}
+	var artifacts []*BadBlockArtifact
+	for _, entry := range entries {
+		if !entry.IsDir() || (hash != nil && !strings.HasSuffix(entry.Name(), "-"+hash.Hex())) {
+			continue
+		}
+		dir := filepath.Join(r.dir, entry.Name())
+		blob, err := os.ReadFile(filepath.Join(dir, badBlockInfoFile))
+		if err != nil {
+			continue // Capture in progress or aborted
+		}
+		artifact := &BadBlockArtifact{Path: dir}
+		if err := json.Unmarshal(blob, &artifact.BadBlockInfo); err != nil {
+			log.Warn("Corrupt bad block artifacts", "dir", dir, "err", err)
+			continue
+		}
+		if full {
+			if artifact.Block, err = os.ReadFile(filepath.Join(dir, badBlockRLPFile)); err != nil {
+				return nil, err
+			}
+			if artifact.Traced {
+				trace, err := os.ReadFile(filepath.Join(dir, badBlockTraceFile))
+				if err != nil {
+					return nil, err
+				}
+				artifact.Trace = string(trace)
+			}
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	slices.SortFunc(artifacts, func(a, b *BadBlockArtifact) int {
+		return b.Time.Compare(a.Time)
+	})
+	return artifacts, nil
+}
+
+// limitWriter is a writer silently dropping everything from the first write
+// exceeding a size limit, so the trace is cut at a line boundary.
+type limitWriter struct {
+	w         io.Writer
+	left      int
+	truncated bool
+}
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	if w.truncated || len(p) > w.left {
+		w.truncated = true
+		return len(p), nil
+	}
+	n, err := w.w.Write(p)
+	w.left -= n
+	return n, err
+}
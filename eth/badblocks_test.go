@@ -0,0 +1,163 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that a block failing validation gets its RLP, description and
+// re-execution trace captured, and that the artifacts can be exported.
+func TestBadBlockArtifacts(t *testing.T) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{testAddr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(testAddr), common.Address{0xde, 0xad}, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, testKey)
+		gen.AddTx(tx)
+	})
+	chain, _ := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks[:1]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	recorder, err := newBadBlockRecorder(&Ethereum{blockchain: chain, chainDb: db}, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create recorder: %v", err)
+	}
+	recorder.start()
+	defer recorder.stop()
+
+	// Corrupt the state root of the second block and import it
+	header := blocks[1].Header()
+	header.Root = common.Hash{0x01}
+	bad := blocks[1].WithSeal(header)
+	if _, err := chain.InsertChain(types.Blocks{bad}); err == nil {
+		t.Fatal("bad block imported")
+	}
+	var artifacts []*BadBlockArtifact
+	for start := time.Now(); len(artifacts) == 0; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("bad block artifacts not captured")
+		}
+		if artifacts, err = recorder.artifacts(nil, false); err != nil {
+			t.Fatalf("failed to list artifacts: %v", err)
+		}
+	}
+	info := artifacts[0]
+	if info.Hash != bad.Hash() || uint64(info.Number) != 2 || info.Error == "" {
+		t.Fatalf("artifact description mismatch: %+v", info.BadBlockInfo)
+	}
+	if len(info.Receipts) == 0 {
+		t.Fatal("receipts of the failed processing missing")
+	}
+	if !info.ParentKnown || !info.ParentState || !info.Traced || info.ExecutionError != "" {
+		t.Fatalf("artifact parent or trace mismatch: %+v", info.BadBlockInfo)
+	}
+	if info.ComputedRoot == nil || *info.ComputedRoot != blocks[1].Root() {
+		t.Fatalf("computed root mismatch: have %v, want %x", info.ComputedRoot, blocks[1].Root())
+	}
+	if info.Block != nil || info.Trace != "" {
+		t.Fatal("artifact contents listed without being requested")
+	}
+	// Export the artifacts of the block and check the contents
+	hash := bad.Hash()
+	artifacts, err = recorder.artifacts(&hash, true)
+	if err != nil || len(artifacts) != 1 {
+		t.Fatalf("failed to export artifacts: %v, %d", err, len(artifacts))
+	}
+	var block types.Block
+	if err := rlp.DecodeBytes(artifacts[0].Block, &block); err != nil {
+		t.Fatalf("failed to decode block RLP: %v", err)
+	}
+	if block.Hash() != hash {
+		t.Fatalf("block RLP mismatch: have %x, want %x", block.Hash(), hash)
+	}
+	scanner := bufio.NewScanner(bytes.NewBufferString(artifacts[0].Trace))
+	var lines int
+	for ; scanner.Scan(); lines++ {
+		if !json.Valid(scanner.Bytes()) {
+			t.Fatalf("invalid trace line: %s", scanner.Text())
+		}
+	}
+	if lines == 0 {
+		t.Fatal("empty re-execution trace")
+	}
+	// Reporting the block again must not capture it twice
+	if _, err := chain.InsertChain(types.Blocks{bad}); err == nil {
+		t.Fatal("bad block imported")
+	}
+	entries, _ := os.ReadDir(recorder.dir)
+	if len(entries) != 1 {
+		t.Fatalf("artifact directory count mismatch: have %d, want 1", len(entries))
+	}
+}
+
+// Tests that only the newest artifacts are retained.
+func TestBadBlockArtifactsPruning(t *testing.T) {
+	recorder := &badBlockRecorder{dir: t.TempDir()}
+	for i := 0; i < maxBadBlockArtifacts+3; i++ {
+		info := BadBlockInfo{
+			Number: 1,
+			Hash:   common.Hash{byte(i)},
+			Time:   time.Unix(int64(i), 0),
+		}
+		dir := filepath.Join(recorder.dir, fmt.Sprintf("%d-%s", info.Number, info.Hash.Hex()))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		blob, _ := json.Marshal(info)
+		if err := os.WriteFile(filepath.Join(dir, badBlockInfoFile), blob, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	recorder.prune()
+
+	artifacts, err := recorder.artifacts(nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != maxBadBlockArtifacts {
+		t.Fatalf("artifact count mismatch: have %d, want %d", len(artifacts), maxBadBlockArtifacts)
+	}
+	if artifacts[0].Hash != (common.Hash{maxBadBlockArtifacts + 2}) || artifacts[len(artifacts)-1].Hash != (common.Hash{3}) {
+		t.Fatalf("wrong artifacts retained: newest %x, oldest %x", artifacts[0].Hash, artifacts[len(artifacts)-1].Hash)
+	}
+}
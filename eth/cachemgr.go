@@ -0,0 +1,96 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/cachemgr"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+const (
+	// cacheSyncLag is the age of the chain head beyond which the node is
+	// considered to be importing the chain in bulk.
+	cacheSyncLag = time.Minute
+
+	// cacheBuildWindow is the time since the last payload build request within
+	// which the node is considered to be building blocks.
+	cacheBuildWindow = time.Minute
+)
+
+// The relative values of the caches in the different workloads: hashing the
+// state root of imported and built blocks walks the trie, while serving calls
+// reads accounts and storage through the snapshot and runs popular code.
+var (
+	trieCacheWeights = cachemgr.Weights{Serve: 1, Sync: 2, Build: 2}
+	snapCacheWeights = cachemgr.Weights{Serve: 2, Sync: 1, Build: 1.5}
+	codeCacheWeights = cachemgr.Weights{Serve: 1.5, Sync: 0.5, Build: 1}
+)
+
+// trieCachePartition adapts the clean trie node cache to the cache manager.
+type trieCachePartition struct{ db *triedb.Database }
+
+func (p trieCachePartition) Resize(size int)              { p.db.ResizeCleanCache(size) }
+func (p trieCachePartition) Stats() (hits, misses uint64) { return p.db.CleanCacheStats() }
+
+// snapCachePartition adapts the snapshot disk layer cache to the cache manager.
+type snapCachePartition struct{ tree *snapshot.Tree }
+
+func (p snapCachePartition) Resize(size int)              { p.tree.ResizeCache(size) }
+func (p snapCachePartition) Stats() (hits, misses uint64) { return p.tree.CacheStats() }
+
+// newCacheManager creates the manager partitioning the memory allowances of the
+// clean trie, snapshot and code caches. If adaptive, the percentage flags only
+// set their initial sizes; the memory budget may also scale them.
+func newCacheManager(s *Ethereum) *cachemgr.Manager {
+	config := cachemgr.DefaultConfig
+	config.Workload = s.cacheWorkload
+
+	manager := cachemgr.NewManager(config)
+	if s.config.TrieCleanCache > 0 {
+		manager.Register("trie", trieCachePartition{s.blockchain.TrieDB()}, s.config.TrieCleanCache*1024*1024, trieCacheWeights)
+	}
+	if snaps := s.blockchain.Snapshots(); snaps != nil && s.config.SnapshotCache > 0 {
+		manager.Register("snapshot", snapCachePartition{snaps}, s.config.SnapshotCache*1024*1024, snapCacheWeights)
+	}
+	if s.config.CodeCache > 0 {
+		manager.Register("code", s.blockchain.CodeCache(), s.config.CodeCache*1024*1024, codeCacheWeights)
+	}
+	if s.config.AdaptiveCache {
+		log.Info("Enabled adaptive cache partitioning", "sizes", manager.Sizes())
+	}
+	return manager
+}
+
+// cacheWorkload classifies the current workload of the node for the cache
+// manager: importing in bulk until the chain head is recent, building blocks
+// if payloads were recently requested, and serving otherwise.
+func (s *Ethereum) cacheWorkload() cachemgr.Workload {
+	if !s.Synced() {
+		return cachemgr.WorkloadSync
+	}
+	if head := s.blockchain.CurrentBlock(); time.Since(time.Unix(int64(head.Time), 0)) > cacheSyncLag {
+		return cachemgr.WorkloadSync
+	}
+	if time.Since(s.miner.LastBuild()) < cacheBuildWindow {
+		return cachemgr.WorkloadBuild
+	}
+	return cachemgr.WorkloadServe
+}
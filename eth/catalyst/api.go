@@ -19,6 +19,7 @@ package catalyst
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -113,6 +114,7 @@ var caps = []string{
 	"engine_getPayloadBodiesByRangeV1",
 	"engine_getPayloadBodiesByRangeV2",
 	"engine_getClientVersionV1",
+	"engine_submitBuilderPayloadV1",
 }
 
 type ConsensusAPI struct {
@@ -321,6 +323,13 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 	api.lastForkchoiceUpdate = time.Now()
 	api.lastForkchoiceLock.Unlock()
 
+	// While the chain is paused for maintenance, neither the head is updated
+	// nor payloads are built. Report syncing so the beacon client retries.
+	if api.eth.BlockChain().Paused() {
+		log.Debug("Ignoring forkchoice update, chain is paused", "head", update.HeadBlockHash)
+		return engine.STATUS_SYNCING, nil
+	}
+
 	// Check whether we have the block yet in our database or not. If not, we'll
 	// need to either trigger a sync, or to reject this forkchoice update for a
 	// reason.
@@ -409,7 +418,10 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 			return engine.STATUS_INVALID, engine.InvalidForkChoiceState.With(errors.New("final block not in canonical chain"))
 		}
 		// Set the finalized block
-		api.eth.BlockChain().SetFinalized(finalBlock.Header())
+		if err := api.eth.BlockChain().SetFinalized(finalBlock.Header()); err != nil {
+			log.Debug("Ignoring finalized block update, chain is paused", "hash", update.FinalizedBlockHash)
+			return engine.STATUS_SYNCING, nil
+		}
 	}
 	// Check if the safe block hash is in our canonical tree, if not something is wrong
 	if update.SafeBlockHash != (common.Hash{}) {
@@ -423,7 +435,10 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 			return engine.STATUS_INVALID, engine.InvalidForkChoiceState.With(errors.New("safe block not in canonical chain"))
 		}
 		// Set the safe block
-		api.eth.BlockChain().SetSafe(safeBlock.Header())
+		if err := api.eth.BlockChain().SetSafe(safeBlock.Header()); err != nil {
+			log.Debug("Ignoring safe block update, chain is paused", "hash", update.SafeBlockHash)
+			return engine.STATUS_SYNCING, nil
+		}
 	}
 	// If payload generation was requested, create a new block to be potentially
 	// sealed by the beacon client. The payload will be requested later, and we
@@ -557,6 +572,44 @@ func (api *ConsensusAPI) getPayload(payloadID engine.PayloadID, full bool) (*eng
 	return data, nil
 }
 
+// SubmitBuilderPayloadV1 submits a payload built by an external block builder
+// for a payload being built locally. The payload is validated against the
+// attributes of the local payload and executed, then selected for delivery by
+// engine_getPayload if it pays more fees than the local one, subject to the
+// builder policy of the miner.
+func (api *ConsensusAPI) SubmitBuilderPayloadV1(ctx context.Context, payloadID engine.PayloadID, params engine.ExecutableData, versionedHashes []common.Hash, executionRequests []hexutil.Bytes) (engine.BuilderPayloadStatusV1, error) {
+	log.Trace("Engine API request received", "method", "SubmitBuilderPayload", "id", payloadID, "number", params.Number, "hash", params.BlockHash)
+	payload := api.localBlocks.payload(payloadID)
+	if payload == nil {
+		return engine.BuilderPayloadStatusV1{}, engine.UnknownPayload
+	}
+	invalid := func(err error) engine.BuilderPayloadStatusV1 {
+		log.Debug("Rejected builder payload", "id", payloadID, "hash", params.BlockHash, "builder", rpc.PeerInfoFromContext(ctx).AuthCredentials, "err", err)
+		msg := err.Error()
+		return engine.BuilderPayloadStatusV1{Status: engine.INVALID, ValidationError: &msg}
+	}
+	requests := convertRequests(executionRequests)
+	if err := validateRequests(requests); err != nil {
+		return engine.BuilderPayloadStatusV1{}, engine.InvalidParams.With(err)
+	}
+	block, err := engine.ExecutableDataToBlock(params, versionedHashes, requests, api.eth.BlockChain().Config())
+	if err != nil {
+		return invalid(err), nil
+	}
+	res, err := api.eth.Miner().SubmitBuilderPayload(payload, block, rpc.PeerInfoFromContext(ctx).AuthCredentials)
+	if errors.Is(err, miner.ErrBuilderPayloadsDisabled) || errors.Is(err, miner.ErrBuilderUnauthorized) {
+		return engine.BuilderPayloadStatusV1{}, err
+	} else if err != nil {
+		return invalid(err), nil
+	}
+	return engine.BuilderPayloadStatusV1{
+		Status:   engine.VALID,
+		Value:    (*hexutil.Big)(res.Value),
+		Selected: res.Decision == miner.BuilderSelected,
+		Decision: res.Decision,
+	}, nil
+}
+
 // GetBlobsV1 returns a blob from the transaction pool.
 func (api *ConsensusAPI) GetBlobsV1(hashes []common.Hash) ([]*engine.BlobAndProofV1, error) {
 	if len(hashes) > 128 {
@@ -925,6 +978,12 @@ func (api *ConsensusAPI) newPayload(params engine.ExecutableData, versionedHashe
 	if res := api.checkInvalidAncestor(block.Hash(), block.Hash()); res != nil {
 		return *res, nil
 	}
+	// If the chain is paused for maintenance, the payload is not executed. It
+	// is delivered again by the beacon client after the chain is resumed.
+	if api.eth.BlockChain().Paused() {
+		log.Debug("Ignoring new payload, chain is paused", "number", params.Number, "hash", params.BlockHash)
+		return engine.PayloadStatusV1{Status: engine.SYNCING}, nil
+	}
 	// If the parent is missing, we - in theory - could trigger a sync, but that
 	// would also entail a reorg. That is problematic if multiple sibling blocks
 	// are being fed to us, and even more so, if some semi-distant uncle shortens
@@ -953,6 +1012,9 @@ func (api *ConsensusAPI) newPayload(params engine.ExecutableData, versionedHashe
 	}
 	log.Trace("Inserting block without sethead", "hash", block.Hash(), "number", block.Number())
 	proofs, err := api.eth.BlockChain().InsertBlockWithoutSetHead(block, witness)
+	if errors.Is(err, core.ErrChainPaused) {
+		return engine.PayloadStatusV1{Status: engine.SYNCING}, nil
+	}
 	if err != nil {
 		log.Warn("NewPayload: inserting block failed", "error", err)
 
@@ -0,0 +1,409 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// originTimeout is the maximum time spent fetching the L1 origin of a block.
+	originTimeout = 5 * time.Second
+
+	// l1InfoDepositGas is the gas limit of the L1 attributes deposit.
+	l1InfoDepositGas = 1_000_000
+
+	// L1 fee parameters reported in the L1 attributes deposit, the ones of OP
+	// mainnet, as there is no system config on L1 to read them from.
+	l1FeeOverhead       = 188
+	l1FeeScalar         = 684_000
+	l1BaseFeeScalar     = 1368
+	l1BlobBaseFeeScalar = 810_949
+)
+
+// l1InfoDepositor is the sender of the L1 attributes deposits.
+var l1InfoDepositor = common.HexToAddress("0xDeaDDEaDDeAdDeAdDEAdDEaddeAddEAdDEAd0001")
+
+// L1Origin is the L1 block a sequenced block refers to.
+type L1Origin struct {
+	Header      *types.Header
+	BlobBaseFee *big.Int
+}
+
+// OriginSource provides the L1 origins of the sequenced blocks.
+type OriginSource interface {
+	// L1Origin returns the latest L1 block.
+	L1Origin(ctx context.Context) (*L1Origin, error)
+}
+
+// staticOrigin is an origin source always returning the same synthetic L1 block,
+// for networks without an L1.
+type staticOrigin struct {
+	origin *L1Origin
+}
+
+// NewStaticOrigin creates an origin source referring every block to the same
+// synthetic L1 block.
+func NewStaticOrigin() OriginSource {
+	return &staticOrigin{origin: &L1Origin{
+		Header: &types.Header{
+			Number:     new(big.Int),
+			Difficulty: new(big.Int),
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+		},
+		BlobBaseFee: big.NewInt(1),
+	}}
+}
+
+func (s *staticOrigin) L1Origin(ctx context.Context) (*L1Origin, error) {
+	return s.origin, nil
+}
+
+// rpcOrigin is an origin source following the head of an L1 node.
+type rpcOrigin struct {
+	client *rpc.Client
+}
+
+// NewRPCOrigin creates an origin source following the head of the L1 node at
+// the given RPC endpoint.
+func NewRPCOrigin(ctx context.Context, url string) (OriginSource, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcOrigin{client: client}, nil
+}
+
+func (s *rpcOrigin) L1Origin(ctx context.Context) (*L1Origin, error) {
+	var (
+		header      *types.Header
+		blobBaseFee hexutil.Big
+	)
+	batch := []rpc.BatchElem{
+		{Method: "eth_getBlockByNumber", Args: []any{"latest", false}, Result: &header},
+		{Method: "eth_blobBaseFee", Result: &blobBaseFee},
+	}
+	if err := s.client.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+	if batch[0].Error != nil {
+		return nil, batch[0].Error
+	}
+	if header == nil {
+		return nil, errors.New("L1 head not found")
+	}
+	origin := &L1Origin{Header: header, BlobBaseFee: big.NewInt(1)}
+	if header.BaseFee == nil {
+		origin.Header.BaseFee = new(big.Int)
+	}
+	// L1 nodes before Cancun don't serve the blob base fee, which is left at
+	// the minimum then.
+	if batch[1].Error == nil {
+		origin.BlobBaseFee = blobBaseFee.ToInt()
+	}
+	return origin, nil
+}
+
+// SequencerConfig contains the settings of the built-in sequencer.
+type SequencerConfig struct {
+	BlockTime    uint64         // Seconds between two blocks
+	FeeRecipient common.Address // Recipient of the fees of the sequenced blocks
+	Origin       OriginSource   // Source of the L1 origins, a static one if nil
+}
+
+// Sequencer is a built-in sequencing driver. It produces a block every block
+// time through the engine API, so that single-node devnets and test networks
+// can run without an external rollup node. The blocks are neither derived
+// from nor submitted to L1: they are safe and final once sealed.
+type Sequencer struct {
+	eth          *eth.Ethereum
+	engineAPI    *ConsensusAPI
+	blockTime    uint64
+	feeRecipient common.Address
+	source       OriginSource
+
+	origin   *L1Origin // L1 origin of the last sealed block
+	sequence uint64    // Number of the last sealed block in the epoch of its origin
+
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewSequencer creates a built-in sequencer for the given OP-stack chain.
+func NewSequencer(eth *eth.Ethereum, config SequencerConfig) (*Sequencer, error) {
+	if eth.BlockChain().Config().Optimism == nil {
+		return nil, errors.New("built-in sequencer requires an OP-stack chain")
+	}
+	if config.BlockTime == 0 {
+		return nil, errors.New("built-in sequencer requires a non-zero block time")
+	}
+	source := config.Origin
+	if source == nil {
+		source = NewStaticOrigin()
+	}
+	return &Sequencer{
+		eth:          eth,
+		engineAPI:    newConsensusAPIWithoutHeartbeat(eth),
+		blockTime:    config.BlockTime,
+		feeRecipient: config.FeeRecipient,
+		source:       source,
+		shutdownCh:   make(chan struct{}),
+	}, nil
+}
+
+// Start implements node.Lifecycle, starting the block production.
+func (s *Sequencer) Start() error {
+	s.wg.Add(1)
+	go s.loop()
+
+	log.Info("Started built-in sequencer", "blocktime", s.blockTime)
+	return nil
+}
+
+// Stop implements node.Lifecycle, halting the block production.
+func (s *Sequencer) Stop() error {
+	close(s.shutdownCh)
+	s.wg.Wait()
+	return nil
+}
+
+// loop seals a block every block time, until the sequencer is stopped.
+func (s *Sequencer) loop() {
+	defer s.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-timer.C:
+		}
+		timestamp := s.nextTimestamp(s.eth.BlockChain().CurrentBlock().Time, uint64(time.Now().Unix()))
+		if wait := time.Until(time.Unix(int64(timestamp), 0)); wait > 0 {
+			timer.Reset(wait)
+			continue
+		}
+		if err := s.sealBlock(timestamp); err != nil {
+			log.Warn("Failed to sequence block", "err", err)
+			timer.Reset(time.Duration(s.blockTime) * time.Second)
+			continue
+		}
+		timer.Reset(0)
+	}
+}
+
+// nextTimestamp returns the timestamp of the block following a parent with the
+// given timestamp. If the chain fell behind, the missed slots are skipped
+// instead of being filled in a burst of blocks, keeping the timestamps aligned
+// on the block time.
+func (s *Sequencer) nextTimestamp(parent uint64, now uint64) uint64 {
+	next := parent + s.blockTime
+	if now > next {
+		next += (now - next) / s.blockTime * s.blockTime
+	}
+	return next
+}
+
+// nextOrigin returns the L1 origin of the block with the given timestamp and its
+// number in the epoch of the origin. The previous origin is kept if the L1 head
+// didn't change, is ahead of the block or can't be fetched.
+func (s *Sequencer) nextOrigin(timestamp uint64) (*L1Origin, uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), originTimeout)
+	defer cancel()
+
+	origin, err := s.source.L1Origin(ctx)
+	if err != nil {
+		if s.origin == nil {
+			return nil, 0, fmt.Errorf("failed to fetch L1 origin: %w", err)
+		}
+		log.Warn("Failed to fetch L1 origin, keeping the previous one", "err", err)
+		origin = s.origin
+	}
+	if s.origin != nil && (origin.Header.Hash() == s.origin.Header.Hash() || origin.Header.Time > timestamp) {
+		return s.origin, s.sequence + 1, nil
+	}
+	return origin, 0, nil
+}
+
+// sealBlock builds a block on top of the current head with the given timestamp,
+// and makes it the new head.
+func (s *Sequencer) sealBlock(timestamp uint64) error {
+	var (
+		chain  = s.eth.BlockChain()
+		config = chain.Config()
+		parent = chain.CurrentBlock()
+		number = new(big.Int).Add(parent.Number, common.Big1)
+	)
+	origin, sequence, err := s.nextOrigin(timestamp)
+	if err != nil {
+		return err
+	}
+	deposit, err := l1InfoDeposit(config, origin, sequence, timestamp).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	var random common.Hash
+	rand.Read(random[:])
+
+	gasLimit := parent.GasLimit
+	attrs := &engine.PayloadAttributes{
+		Timestamp:             timestamp,
+		Random:                random,
+		SuggestedFeeRecipient: s.feeRecipient,
+		Transactions:          [][]byte{deposit},
+		GasLimit:              &gasLimit,
+	}
+	if config.IsShanghai(number, timestamp) {
+		attrs.Withdrawals = make([]*types.Withdrawal, 0)
+	}
+	if config.IsCancun(number, timestamp) {
+		attrs.BeaconRoot = new(common.Hash)
+		if origin.Header.ParentBeaconRoot != nil {
+			*attrs.BeaconRoot = *origin.Header.ParentBeaconRoot
+		}
+	}
+	if config.IsHolocene(timestamp) {
+		// Zero parameters select the EIP-1559 settings of the chain config
+		attrs.EIP1559Params = make([]byte, 8)
+	}
+	version := payloadVersion(config, timestamp)
+	state := engine.ForkchoiceStateV1{
+		HeadBlockHash:      parent.Hash(),
+		SafeBlockHash:      parent.Hash(),
+		FinalizedBlockHash: parent.Hash(),
+	}
+	fcResponse, err := s.engineAPI.forkchoiceUpdated(state, attrs, version, false)
+	if err != nil {
+		return err
+	}
+	if fcResponse.PayloadID == nil {
+		return fmt.Errorf("payload building not started, status %s", fcResponse.PayloadStatus.Status)
+	}
+	if err := s.engineAPI.localBlocks.waitFull(*fcResponse.PayloadID); err != nil {
+		return err
+	}
+	envelope, err := s.engineAPI.getPayload(*fcResponse.PayloadID, true)
+	if err != nil {
+		return err
+	}
+	payload := envelope.ExecutionPayload
+
+	var (
+		blobHashes []common.Hash
+		requests   [][]byte
+	)
+	if version > engine.PayloadV2 {
+		blobHashes = make([]common.Hash, 0)
+		requests = envelope.Requests
+	}
+	status, err := s.engineAPI.newPayload(*payload, blobHashes, requests, false)
+	if err != nil {
+		return err
+	}
+	if status.Status != engine.VALID {
+		return fmt.Errorf("sequenced payload not valid, status %s", status.Status)
+	}
+	state = engine.ForkchoiceStateV1{
+		HeadBlockHash:      payload.BlockHash,
+		SafeBlockHash:      payload.BlockHash,
+		FinalizedBlockHash: payload.BlockHash,
+	}
+	if _, err := s.engineAPI.forkchoiceUpdated(state, nil, version, false); err != nil {
+		return err
+	}
+	s.origin, s.sequence = origin, sequence
+
+	log.Debug("Sequenced block", "number", payload.Number, "hash", payload.BlockHash, "txs", len(payload.Transactions), "origin", origin.Header.Number, "sequence", sequence)
+	return nil
+}
+
+// l1InfoDeposit creates the L1 attributes deposit opening a block, in the
+// format of the active fork.
+//
+// Note, the upgrade transactions of forks activated after genesis are not
+// included, the forks of the chains run by the sequencer must be active from
+// genesis.
+func l1InfoDeposit(config *params.ChainConfig, origin *L1Origin, sequence uint64, timestamp uint64) *types.Transaction {
+	var (
+		header = origin.Header
+		data   []byte
+	)
+	switch {
+	case config.IsEcotone(timestamp):
+		selector := types.EcotoneL1AttributesSelector
+		if config.IsIsthmus(timestamp) {
+			selector = types.IsthmusL1AttributesSelector
+		}
+		data = append(data, selector...)
+		data = binary.BigEndian.AppendUint32(data, l1BaseFeeScalar)
+		data = binary.BigEndian.AppendUint32(data, l1BlobBaseFeeScalar)
+		data = binary.BigEndian.AppendUint64(data, sequence)
+		data = binary.BigEndian.AppendUint64(data, header.Time)
+		data = binary.BigEndian.AppendUint64(data, header.Number.Uint64())
+		data = append(data, common.BigToHash(header.BaseFee).Bytes()...)
+		data = append(data, common.BigToHash(origin.BlobBaseFee).Bytes()...)
+		data = append(data, header.Hash().Bytes()...)
+		data = append(data, common.Hash{}.Bytes()...) // batcher hash
+		if config.IsIsthmus(timestamp) {
+			data = binary.BigEndian.AppendUint32(data, 0) // operator fee scalar
+			data = binary.BigEndian.AppendUint64(data, 0) // operator fee constant
+		}
+	default:
+		data = append(data, types.BedrockL1AttributesSelector...)
+		data = append(data, common.BigToHash(header.Number).Bytes()...)
+		data = append(data, common.BigToHash(new(big.Int).SetUint64(header.Time)).Bytes()...)
+		data = append(data, common.BigToHash(header.BaseFee).Bytes()...)
+		data = append(data, header.Hash().Bytes()...)
+		data = append(data, common.BigToHash(new(big.Int).SetUint64(sequence)).Bytes()...)
+		data = append(data, common.Hash{}.Bytes()...) // batcher hash
+		data = append(data, common.BigToHash(big.NewInt(l1FeeOverhead)).Bytes()...)
+		data = append(data, common.BigToHash(big.NewInt(l1FeeScalar)).Bytes()...)
+	}
+	// The source hash of L1 attributes deposits is in domain 1
+	source := crypto.Keccak256Hash(
+		common.BigToHash(common.Big1).Bytes(),
+		crypto.Keccak256(header.Hash().Bytes(), common.BigToHash(new(big.Int).SetUint64(sequence)).Bytes()),
+	)
+	return types.NewTx(&types.DepositTx{
+		SourceHash:          source,
+		From:                l1InfoDepositor,
+		To:                  &types.L1BlockAddr,
+		Value:               new(big.Int),
+		Gas:                 l1InfoDepositGas,
+		IsSystemTransaction: !config.IsRegolith(timestamp),
+		Data:                data,
+	})
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testOrigin is an origin source returning the L1 blocks set by the test.
+type testOrigin struct {
+	origin *L1Origin
+	err    error
+}
+
+func (s *testOrigin) L1Origin(ctx context.Context) (*L1Origin, error) {
+	return s.origin, s.err
+}
+
+func newTestL1Origin(number uint64, time uint64) *L1Origin {
+	return &L1Origin{
+		Header: &types.Header{
+			Number:     new(big.Int).SetUint64(number),
+			Time:       time,
+			Difficulty: new(big.Int),
+			BaseFee:    big.NewInt(params.GWei),
+		},
+		BlobBaseFee: big.NewInt(1),
+	}
+}
+
+func startSequencerEthService(t *testing.T, genesis *core.Genesis) (*node.Node, *eth.Ethereum) {
+	t.Helper()
+
+	n, err := node.New(&node.Config{
+		P2P: p2p.Config{
+			ListenAddr:  "127.0.0.1:0",
+			NoDiscovery: true,
+			MaxPeers:    0,
+		},
+	})
+	if err != nil {
+		t.Fatal("can't create node:", err)
+	}
+	ethcfg := &ethconfig.Config{Genesis: genesis, SyncMode: ethconfig.FullSync, TrieTimeout: time.Minute, TrieDirtyCache: 256, TrieCleanCache: 256, Miner: miner.DefaultConfig}
+	ethservice, err := eth.New(n, ethcfg)
+	if err != nil {
+		t.Fatal("can't create eth service:", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatal("can't start node:", err)
+	}
+	return n, ethservice
+}
+
+func TestSequencerSealBlock(t *testing.T) {
+	// Blocks are sealed before Prague: payload headers are assembled without a
+	// parent beacon root, which the requests hash can't be encoded without.
+	config := *params.OptimismTestConfig
+	config.PragueTime, config.IsthmusTime = nil, nil
+
+	genesis := &core.Genesis{
+		Config:    &config,
+		GasLimit:  30_000_000,
+		BaseFee:   big.NewInt(params.InitialBaseFee),
+		Timestamp: uint64(time.Now().Unix()) - 100,
+		ExtraData: append([]byte{0}, eip1559.EncodeHolocene1559Params(250, 50)...),
+	}
+	n, ethservice := startSequencerEthService(t, genesis)
+	defer n.Close()
+
+	source := &testOrigin{origin: newTestL1Origin(10, genesis.Timestamp)}
+	sequencer, err := NewSequencer(ethservice, SequencerConfig{BlockTime: 2, Origin: source})
+	if err != nil {
+		t.Fatal("can't create sequencer:", err)
+	}
+	check := func(number uint64, timestamp uint64, origin *L1Origin, sequence uint64) {
+		t.Helper()
+
+		if err := sequencer.sealBlock(timestamp); err != nil {
+			t.Fatalf("block %d: failed to seal: %v", number, err)
+		}
+		block := ethservice.BlockChain().CurrentBlock()
+		if block.Number.Uint64() != number || block.Time != timestamp {
+			t.Fatalf("block %d: wrong head: number %d, time %d", number, block.Number, block.Time)
+		}
+		if final := ethservice.BlockChain().CurrentFinalBlock(); final == nil || final.Hash() != block.Hash() {
+			t.Fatalf("block %d: head not finalized", number)
+		}
+		txs := ethservice.BlockChain().GetBlockByHash(block.Hash()).Transactions()
+		if len(txs) != 1 || !txs[0].IsDepositTx() || *txs[0].To() != types.L1BlockAddr {
+			t.Fatalf("block %d: L1 attributes deposit missing", number)
+		}
+		if want := l1InfoDeposit(genesis.Config, origin, sequence, timestamp); txs[0].Hash() != want.Hash() {
+			t.Fatalf("block %d: wrong L1 attributes deposit", number)
+		}
+	}
+	start := genesis.Timestamp
+	check(1, start+2, source.origin, 0)
+	check(2, start+4, source.origin, 1)
+
+	// A new L1 head starts a new epoch, unless it's ahead of the block
+	first := source.origin
+	source.origin = newTestL1Origin(11, start+8)
+	check(3, start+6, first, 2)
+	check(4, start+8, source.origin, 0)
+
+	// The previous origin is kept if the L1 node fails
+	second := source.origin
+	source.origin, source.err = nil, errors.New("unavailable")
+	check(5, start+10, second, 1)
+}
+
+func TestSequencerNextTimestamp(t *testing.T) {
+	s := &Sequencer{blockTime: 2}
+	for i, tt := range []struct {
+		parent, now, want uint64
+	}{
+		{100, 90, 102},  // ahead of time
+		{100, 101, 102}, // on time
+		{100, 102, 102},
+		{100, 103, 102},
+		{100, 104, 104}, // behind, missed slot skipped
+		{100, 111, 110},
+	} {
+		if have := s.nextTimestamp(tt.parent, tt.now); have != tt.want {
+			t.Errorf("test %d: wrong timestamp: have %d, want %d", i, have, tt.want)
+		}
+	}
+}
+
+func TestL1InfoDepositFormats(t *testing.T) {
+	var (
+		origin  = newTestL1Origin(5, 100)
+		bedrock = *params.OptimismTestConfig
+		ecotone = *params.OptimismTestConfig
+	)
+	bedrock.EcotoneTime, bedrock.FjordTime, bedrock.GraniteTime, bedrock.HoloceneTime, bedrock.IsthmusTime = nil, nil, nil, nil, nil
+	ecotone.IsthmusTime = nil
+
+	for _, tt := range []struct {
+		config   *params.ChainConfig
+		selector []byte
+		size     int
+	}{
+		{&bedrock, types.BedrockL1AttributesSelector, 4 + 8*32},
+		{&ecotone, types.EcotoneL1AttributesSelector, 164},
+		{params.OptimismTestConfig, types.IsthmusL1AttributesSelector, 176},
+	} {
+		tx := l1InfoDeposit(tt.config, origin, 3, 200)
+		if data := tx.Data(); len(data) != tt.size || !bytes.Equal(data[:4], tt.selector) {
+			t.Errorf("selector %x: wrong deposit data: %x", tt.selector, data)
+		}
+		if tx.IsSystemTx() {
+			t.Errorf("selector %x: system transaction after Regolith", tt.selector)
+		}
+	}
+}
@@ -96,6 +96,7 @@ type SimulatedBeacon struct {
 	engineAPI          *ConsensusAPI
 	curForkchoiceState engine.ForkchoiceStateV1
 	lastBlockTime      uint64
+	lock               sync.Mutex // lock gates block production against chain archive dumps and loads
 }
 
 func payloadVersion(config *params.ChainConfig, time uint64) engine.PayloadVersion {
@@ -167,6 +168,9 @@ func (c *SimulatedBeacon) Stop() error {
 // sealBlock initiates payload building for a new block and creates a new block
 // with the completed payload.
 func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp uint64) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	if timestamp <= c.lastBlockTime {
 		timestamp = c.lastBlockTime + 1
 	}
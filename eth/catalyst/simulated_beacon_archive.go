@@ -0,0 +1,197 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// chainArchiveVersion is the version of the devnet chain archive format.
+const chainArchiveVersion = 1
+
+// chainArchiveBatch is the number of blocks imported at once when restoring
+// a chain archive.
+const chainArchiveBatch = 2500
+
+// chainArchiveHeader is the leading item of a devnet chain archive, followed by
+// the canonical blocks from the first one after the genesis up to the head. The
+// archive is a gzip compressed RLP stream.
+type chainArchiveHeader struct {
+	Version uint64
+	Genesis common.Hash
+	Head    uint64
+	Txs     []*types.Transaction // Pending and queued transactions of the pool
+}
+
+// ChainArchive describes a devnet chain archive dumped or loaded.
+type ChainArchive struct {
+	Head   common.Hash    `json:"head"`
+	Number hexutil.Uint64 `json:"number"`
+	Txs    hexutil.Uint   `json:"txs"`
+}
+
+// dumpChain writes the canonical chain and the txpool content into an archive.
+func (c *SimulatedBeacon) dumpChain(w io.Writer) (*ChainArchive, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var (
+		chain  = c.eth.BlockChain()
+		head   = chain.CurrentBlock()
+		header = chainArchiveHeader{
+			Version: chainArchiveVersion,
+			Genesis: chain.Genesis().Hash(),
+			Head:    head.Number.Uint64(),
+		}
+	)
+	pending, queued := c.eth.TxPool().Content()
+	for _, txs := range pending {
+		header.Txs = append(header.Txs, txs...)
+	}
+	for _, txs := range queued {
+		header.Txs = append(header.Txs, txs...)
+	}
+	out := gzip.NewWriter(w)
+	if err := rlp.Encode(out, &header); err != nil {
+		return nil, err
+	}
+	if header.Head > 0 {
+		if err := chain.ExportN(out, 1, header.Head); err != nil {
+			return nil, err
+		}
+	}
+	if err := out.Close(); err != nil {
+		return nil, err
+	}
+	return &ChainArchive{
+		Head:   head.Hash(),
+		Number: hexutil.Uint64(header.Head),
+		Txs:    hexutil.Uint(len(header.Txs)),
+	}, nil
+}
+
+// loadChain restores the chain and the txpool content from an archive. The node
+// must run with the genesis the archive was dumped from. The blocks are imported
+// and the head of the archive made canonical, discarding any local block beyond
+// it, after which the transactions of the archive replace the txpool content.
+func (c *SimulatedBeacon) loadChain(r io.Reader) (*ChainArchive, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	in, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		stream = rlp.NewStream(in, 0)
+		chain  = c.eth.BlockChain()
+		header chainArchiveHeader
+	)
+	if err := stream.Decode(&header); err != nil {
+		return nil, fmt.Errorf("invalid archive header: %w", err)
+	}
+	if header.Version != chainArchiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", header.Version)
+	}
+	if genesis := chain.Genesis().Hash(); header.Genesis != genesis {
+		return nil, fmt.Errorf("genesis mismatch: archive %x, local %x", header.Genesis, genesis)
+	}
+	head := chain.Genesis()
+	for number := uint64(1); number <= header.Head; {
+		blocks := make(types.Blocks, 0, chainArchiveBatch)
+		for ; number <= header.Head && len(blocks) < chainArchiveBatch; number++ {
+			block := new(types.Block)
+			if err := stream.Decode(block); err != nil {
+				return nil, fmt.Errorf("block %d: failed to parse: %w", number, err)
+			}
+			if block.NumberU64() != number {
+				return nil, fmt.Errorf("block %d: unexpected number %d", number, block.NumberU64())
+			}
+			blocks = append(blocks, block)
+		}
+		if n, err := chain.InsertChain(blocks); err != nil {
+			return nil, fmt.Errorf("block %d: failed to import: %w", blocks[n].NumberU64(), err)
+		}
+		head = blocks[len(blocks)-1]
+	}
+	if chain.CurrentBlock().Hash() != head.Hash() {
+		if _, err := chain.SetCanonical(head); err != nil {
+			return nil, err
+		}
+	}
+	c.setCurrentState(head.Hash(), *c.finalizedBlockHash(head.NumberU64()))
+	c.lastBlockTime = head.Time()
+
+	// Replace the txpool content by the archived transactions
+	pool := c.eth.TxPool()
+	pool.Clear()
+
+	var added int
+	for i, err := range pool.Add(header.Txs, true) {
+		if err != nil {
+			log.Debug("Dropped archived transaction", "hash", header.Txs[i].Hash(), "err", err)
+			continue
+		}
+		added++
+	}
+	log.Info("Loaded devnet chain archive", "number", head.NumberU64(), "hash", head.Hash(), "txs", added)
+	return &ChainArchive{
+		Head:   head.Hash(),
+		Number: hexutil.Uint64(head.NumberU64()),
+		Txs:    hexutil.Uint(added),
+	}, nil
+}
+
+// DumpChain captures the devnet chain, its state and the txpool content into an
+// archive at the given path, which must not exist yet. The state is not stored
+// but regenerated from the blocks when the archive is loaded.
+func (a *simulatedBeaconAPI) DumpChain(path string) (*ChainArchive, error) {
+	if _, err := os.Stat(path); err == nil {
+		// Allowing overwrite could be a DoS vector, since the path may point
+		// to arbitrary files on the drive.
+		return nil, errors.New("location would overwrite an existing file")
+	}
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+
+	return a.sim.dumpChain(out)
+}
+
+// LoadChain restores the devnet chain, its state and the txpool content from an
+// archive at the given path, previously captured by dev_dumpChain.
+func (a *simulatedBeaconAPI) LoadChain(path string) (*ChainArchive, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	return a.sim.loadChain(in)
+}
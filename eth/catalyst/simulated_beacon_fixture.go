@@ -0,0 +1,133 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// FixtureResult is the outcome of applying a developer fixture on demand.
+type FixtureResult struct {
+	Accounts  []common.Address          `json:"accounts"`  // Accounts derived from the mnemonic
+	Contracts map[string]common.Address `json:"contracts"` // Addresses of the contracts by name
+	Txs       []common.Hash             `json:"txs"`       // Faucet transactions seeding the fixture
+}
+
+// applyFixture seeds a developer fixture into the chain through transactions
+// of the faucet: the derived accounts are topped up to the fixture balance and
+// the contracts missing from the chain are deployed with their storage. As the
+// contracts are created by the faucet, their genesis addresses are not honoured.
+func (c *SimulatedBeacon) applyFixture(ctx context.Context, fixture *core.DevFixture) (*FixtureResult, error) {
+	addrs, err := fixture.DeriveAccounts()
+	if err != nil {
+		return nil, err
+	}
+	c.feeRecipientLock.Lock()
+	faucet := accounts.Account{Address: c.feeRecipient}
+	c.feeRecipientLock.Unlock()
+
+	wallet, err := c.eth.AccountManager().Find(faucet)
+	if err != nil {
+		return nil, fmt.Errorf("faucet %v unavailable: %w", faucet.Address, err)
+	}
+	var (
+		chain   = c.eth.BlockChain()
+		config  = chain.Config()
+		head    = chain.CurrentBlock()
+		backend = c.eth.APIBackend
+		nonce   = c.eth.TxPool().Nonce(faucet.Address)
+		res     = &FixtureResult{Accounts: addrs, Contracts: make(map[string]common.Address)}
+	)
+	statedb, err := chain.StateAt(head.Root)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	feeCap := new(big.Int).Add(tip, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+
+	send := func(to *common.Address, value *big.Int, gas uint64, data []byte) error {
+		tx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     nonce,
+			GasTipCap: tip,
+			GasFeeCap: feeCap,
+			Gas:       gas,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		})
+		signed, err := wallet.SignTx(faucet, tx, config.ChainID)
+		if err != nil {
+			return err
+		}
+		if err := backend.SendTx(ctx, signed); err != nil {
+			return err
+		}
+		res.Txs = append(res.Txs, signed.Hash())
+		nonce++
+		return nil
+	}
+	if fixture.Balance != nil {
+		for _, addr := range addrs {
+			missing := new(big.Int).Sub((*big.Int)(fixture.Balance), statedb.GetBalance(addr).ToBig())
+			if missing.Sign() <= 0 {
+				continue
+			}
+			if err := send(&addr, missing, params.TxGas, nil); err != nil {
+				return nil, fmt.Errorf("failed to fund account %v: %w", addr, err)
+			}
+		}
+	}
+	for _, contract := range fixture.Contracts {
+		// Contracts seeded at genesis are already in place
+		if contract.Address != nil && len(statedb.GetCode(*contract.Address)) > 0 {
+			res.Contracts[contract.Name] = *contract.Address
+			continue
+		}
+		code := contract.InitCode()
+		gas, err := core.IntrinsicGas(code, nil, nil, true, true, true, true)
+		if err != nil {
+			return nil, err
+		}
+		gas += uint64(len(contract.Storage))*(params.SstoreSetGasEIP2200+params.ColdSloadCostEIP2929) + uint64(len(contract.Code))*params.CreateDataGas + 50_000
+
+		address := crypto.CreateAddress(faucet.Address, nonce)
+		if err := send(nil, (*big.Int)(contract.Balance), gas, code); err != nil {
+			return nil, fmt.Errorf("failed to deploy contract %q: %w", contract.Name, err)
+		}
+		res.Contracts[contract.Name] = address
+	}
+	return res, nil
+}
+
+// ApplyFixture seeds a developer fixture into the chain on demand, through
+// transactions of the faucet account.
+func (a *simulatedBeaconAPI) ApplyFixture(ctx context.Context, fixture core.DevFixture) (*FixtureResult, error) {
+	return a.sim.applyFixture(ctx, &fixture)
+}
@@ -0,0 +1,329 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package crosscheck implements the shadow verification of the imported blocks
+// against a reference node, to catch divergences of the local execution from
+// the rest of the network early.
+//
+// Every canonical block imported by the node is compared against the block of
+// the same number served by the reference node. A block hash, state root or
+// receipts root mismatch is reported, and optionally halts the import of
+// further blocks until an operator resumes the chain.
+package crosscheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// maxPending is the maximum number of imported blocks waiting to be
+	// verified. The oldest ones are skipped if the reference falls behind.
+	maxPending = 4096
+
+	// requestTimeout is the time allowed for the reference node to serve a block.
+	requestTimeout = 5 * time.Second
+)
+
+var (
+	verifiedGauge   = metrics.NewRegisteredGauge("crosscheck/verified", nil)
+	pendingGauge    = metrics.NewRegisteredGauge("crosscheck/pending", nil)
+	divergedGauge   = metrics.NewRegisteredGauge("crosscheck/diverged", nil)
+	divergenceMeter = metrics.NewRegisteredMeter("crosscheck/divergences", nil)
+	skippedMeter    = metrics.NewRegisteredMeter("crosscheck/skipped", nil)
+	errorMeter      = metrics.NewRegisteredMeter("crosscheck/errors", nil)
+)
+
+// Config contains the settings of the cross-checker.
+type Config struct {
+	Reference string        // RPC endpoint of the reference node
+	Halt      bool          // Whether to halt the chain on divergence
+	Interval  time.Duration // Time between two polls of the reference node
+}
+
+// DefaultConfig contains the default settings of the cross-checker.
+var DefaultConfig = Config{
+	Interval: 2 * time.Second,
+}
+
+// Chain is the local chain verified by the cross-checker.
+type Chain interface {
+	// SubscribeChainEvent subscribes to the new canonical blocks.
+	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
+
+	// GetHeaderByNumber returns the canonical header with the given number.
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// Roots are the commitments of a block compared against the reference.
+type Roots struct {
+	Hash         common.Hash `json:"hash"`
+	StateRoot    common.Hash `json:"stateRoot"`
+	ReceiptsRoot common.Hash `json:"receiptsRoot"`
+}
+
+// Divergence is a block whose commitments differ from the reference.
+type Divergence struct {
+	Number    hexutil.Uint64 `json:"number"`
+	Local     Roots          `json:"local"`
+	Reference Roots          `json:"reference"`
+	Time      time.Time      `json:"time"`
+}
+
+// Status is a snapshot of the cross-checker progress.
+type Status struct {
+	Verified   hexutil.Uint64 `json:"verified"` // Number of the last verified block
+	Pending    int            `json:"pending"`  // Number of blocks waiting for verification
+	Divergence *Divergence    `json:"divergence"`
+	Halted     bool           `json:"halted"` // Whether the chain was halted on divergence
+}
+
+// CrossChecker verifies the imported blocks against a reference node.
+type CrossChecker struct {
+	config Config
+	chain  Chain
+	client *rpc.Client
+	halt   func() error // Callback halting the chain, nil if halting is disabled
+
+	mu         sync.Mutex
+	pending    []uint64 // Numbers of the imported blocks waiting for verification
+	verified   uint64
+	divergence *Divergence
+	halted     bool
+
+	sub     event.Subscription
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New creates a cross-checker of the given chain against the configured
+// reference node. The halt callback is invoked on divergence if enabled.
+func New(config Config, chain Chain, halt func() error) (*CrossChecker, error) {
+	if config.Reference == "" {
+		return nil, errors.New("no cross-check reference configured")
+	}
+	if config.Interval <= 0 {
+		return nil, fmt.Errorf("invalid cross-check interval %v", config.Interval)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	client, err := rpc.DialContext(ctx, config.Reference)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial cross-check reference: %v", err)
+	}
+	c := &CrossChecker{
+		config:  config,
+		chain:   chain,
+		client:  client,
+		closeCh: make(chan struct{}),
+	}
+	if config.Halt {
+		c.halt = halt
+	}
+	return c, nil
+}
+
+// Start launches the verification of the imported blocks.
+func (c *CrossChecker) Start() {
+	events := make(chan core.ChainEvent, 64)
+	c.sub = c.chain.SubscribeChainEvent(events)
+
+	c.wg.Add(2)
+	go c.eventLoop(events)
+	go c.verifyLoop()
+}
+
+// Stop terminates the verification and closes the reference connection.
+func (c *CrossChecker) Stop() {
+	close(c.closeCh)
+	if c.sub != nil {
+		c.sub.Unsubscribe()
+	}
+	c.wg.Wait()
+	c.client.Close()
+}
+
+// Status returns the current progress of the cross-checker.
+func (c *CrossChecker) Status() *Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &Status{
+		Verified:   hexutil.Uint64(c.verified),
+		Pending:    len(c.pending),
+		Divergence: c.divergence,
+		Halted:     c.halted,
+	}
+}
+
+// Resume clears the halted state once the chain was resumed by an operator, so
+// a later divergence halts the chain again. The last divergence is kept for
+// inspection.
+func (c *CrossChecker) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.halted = false
+	divergedGauge.Update(0)
+}
+
+// eventLoop queues the imported blocks for verification. It's kept separate
+// from the verification so a slow reference never blocks the chain import.
+func (c *CrossChecker) eventLoop(events chan core.ChainEvent) {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case ev := <-events:
+			c.enqueue(ev.Header.Number.Uint64())
+		case <-c.sub.Err():
+			return
+		case <-c.closeCh:
+			return
+		}
+	}
+}
+
+// verifyLoop periodically verifies the queued blocks against the reference.
+func (c *CrossChecker) verifyLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.verify()
+		case <-c.closeCh:
+			return
+		}
+	}
+}
+
+// enqueue schedules an imported block for verification.
+func (c *CrossChecker) enqueue(number uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending = append(c.pending, number)
+	if len(c.pending) > maxPending {
+		skipped := len(c.pending) - maxPending
+		c.pending = c.pending[skipped:]
+		skippedMeter.Mark(int64(skipped))
+	}
+	pendingGauge.Update(int64(len(c.pending)))
+}
+
+// verify compares the pending blocks against the reference in import order,
+// until the reference doesn't have the next one yet.
+func (c *CrossChecker) verify() {
+	for {
+		select {
+		case <-c.closeCh:
+			return
+		default:
+		}
+		c.mu.Lock()
+		if len(c.pending) == 0 {
+			c.mu.Unlock()
+			return
+		}
+		number := c.pending[0]
+		c.mu.Unlock()
+
+		// Compare the current canonical block, the imported one may have been
+		// reorged out in the meantime
+		if header := c.chain.GetHeaderByNumber(number); header != nil {
+			remote, err := c.reference(number)
+			if err != nil {
+				errorMeter.Mark(1)
+				log.Debug("Failed to retrieve cross-check reference block", "number", number, "err", err)
+				return
+			}
+			if remote == nil {
+				return // Reference is behind, retry later
+			}
+			local := Roots{Hash: header.Hash(), StateRoot: header.Root, ReceiptsRoot: header.ReceiptHash}
+			if local != *remote {
+				c.diverged(number, local, *remote)
+			}
+		}
+		c.mu.Lock()
+		if len(c.pending) > 0 && c.pending[0] == number {
+			c.pending = c.pending[1:]
+		}
+		c.verified = max(c.verified, number)
+		pendingGauge.Update(int64(len(c.pending)))
+		verifiedGauge.Update(int64(c.verified))
+		c.mu.Unlock()
+	}
+}
+
+// reference retrieves the commitments of a block from the reference node, or
+// nil if the reference doesn't have it yet.
+func (c *CrossChecker) reference(number uint64) (*Roots, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	var roots *Roots
+	if err := c.client.CallContext(ctx, &roots, "eth_getBlockByNumber", hexutil.EncodeUint64(number), false); err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
+
+// diverged reports a block differing from the reference, and halts the chain
+// if configured to.
+func (c *CrossChecker) diverged(number uint64, local, remote Roots) {
+	divergenceMeter.Mark(1)
+	divergedGauge.Update(1)
+
+	log.Error("Block diverged from the cross-check reference", "number", number,
+		"hash", local.Hash, "refhash", remote.Hash,
+		"root", local.StateRoot, "refroot", remote.StateRoot,
+		"receipts", local.ReceiptsRoot, "refreceipts", remote.ReceiptsRoot)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.divergence = &Divergence{
+		Number:    hexutil.Uint64(number),
+		Local:     local,
+		Reference: remote,
+		Time:      time.Now(),
+	}
+	if c.halt != nil && !c.halted {
+		if err := c.halt(); err != nil {
+			log.Error("Failed to halt the chain on cross-check divergence", "err", err)
+			return
+		}
+		c.halted = true
+		log.Error("Halted the chain on cross-check divergence, resume with admin_resumeChain", "number", number)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package crosscheck
+
+import (
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testChain is a local chain with a fixed set of canonical headers.
+type testChain struct {
+	feed    event.Feed
+	headers map[uint64]*types.Header
+}
+
+func (c *testChain) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+func (c *testChain) GetHeaderByNumber(number uint64) *types.Header {
+	return c.headers[number]
+}
+
+// referenceService serves the block commitments of the reference node.
+type referenceService struct {
+	blocks map[uint64]*Roots
+}
+
+func (s *referenceService) GetBlockByNumber(number hexutil.Uint64, full bool) (*Roots, error) {
+	return s.blocks[uint64(number)], nil
+}
+
+// newTestChains creates a local chain and a matching reference of the given
+// length.
+func newTestChains(n int) (*testChain, *referenceService) {
+	var (
+		chain = &testChain{headers: make(map[uint64]*types.Header)}
+		ref   = &referenceService{blocks: make(map[uint64]*Roots)}
+	)
+	for i := 0; i < n; i++ {
+		header := &types.Header{
+			Number:      big.NewInt(int64(i)),
+			Root:        common.Hash{byte(i), 0x01},
+			ReceiptHash: common.Hash{byte(i), 0x02},
+		}
+		chain.headers[uint64(i)] = header
+		ref.blocks[uint64(i)] = &Roots{Hash: header.Hash(), StateRoot: header.Root, ReceiptsRoot: header.ReceiptHash}
+	}
+	return chain, ref
+}
+
+func newTestCrossChecker(t *testing.T, chain *testChain, ref *referenceService, halt func() error) *CrossChecker {
+	t.Helper()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", ref); err != nil {
+		t.Fatalf("failed to register reference service: %v", err)
+	}
+	http := httptest.NewServer(server)
+	t.Cleanup(http.Close)
+
+	config := DefaultConfig
+	config.Reference = http.URL
+	config.Halt = halt != nil
+	c, err := New(config, chain, halt)
+	if err != nil {
+		t.Fatalf("failed to create cross-checker: %v", err)
+	}
+	t.Cleanup(func() { c.client.Close() })
+	return c
+}
+
+// Tests that matching blocks are verified, and that the verification waits for
+// a reference which is behind.
+func TestCrossCheckVerify(t *testing.T) {
+	chain, ref := newTestChains(8)
+	delete(ref.blocks, 6)
+	delete(ref.blocks, 7)
+
+	c := newTestCrossChecker(t, chain, ref, nil)
+	for i := uint64(1); i < 8; i++ {
+		c.enqueue(i)
+	}
+	c.verify()
+
+	status := c.Status()
+	if status.Verified != 5 || status.Pending != 2 || status.Divergence != nil {
+		t.Fatalf("unexpected status: verified %d, pending %d, divergence %v", status.Verified, status.Pending, status.Divergence)
+	}
+	// Catch up the reference and verify the rest
+	_, full := newTestChains(8)
+	ref.blocks[6], ref.blocks[7] = full.blocks[6], full.blocks[7]
+	c.verify()
+
+	status = c.Status()
+	if status.Verified != 7 || status.Pending != 0 || status.Divergence != nil {
+		t.Fatalf("unexpected status: verified %d, pending %d, divergence %v", status.Verified, status.Pending, status.Divergence)
+	}
+}
+
+// Tests that a state root divergence is reported and halts the chain once.
+func TestCrossCheckDivergence(t *testing.T) {
+	chain, ref := newTestChains(8)
+	ref.blocks[3].StateRoot = common.Hash{0xff}
+	ref.blocks[5].ReceiptsRoot = common.Hash{0xff}
+
+	var halts int
+	c := newTestCrossChecker(t, chain, ref, func() error {
+		halts++
+		return nil
+	})
+	for i := uint64(1); i < 8; i++ {
+		c.enqueue(i)
+	}
+	c.verify()
+
+	status := c.Status()
+	if !status.Halted || halts != 1 {
+		t.Fatalf("unexpected halt: halted %v, calls %d", status.Halted, halts)
+	}
+	if status.Divergence == nil || status.Divergence.Number != 5 {
+		t.Fatalf("unexpected divergence: %v", status.Divergence)
+	}
+	if status.Divergence.Reference.ReceiptsRoot != (common.Hash{0xff}) {
+		t.Fatalf("unexpected reference receipts root: %x", status.Divergence.Reference.ReceiptsRoot)
+	}
+	// Once resumed, a later divergence halts the chain again
+	c.Resume()
+	if c.Status().Halted || divergedGauge.Snapshot().Value() != 0 {
+		t.Fatalf("halted state not cleared on resume")
+	}
+	c.enqueue(3)
+	c.verify()
+	if status := c.Status(); !status.Halted || halts != 2 {
+		t.Fatalf("unexpected halt after resume: halted %v, calls %d", status.Halted, halts)
+	}
+}
+
+// Tests that a failing halt is retried on the next divergence.
+func TestCrossCheckHaltFailure(t *testing.T) {
+	chain, ref := newTestChains(4)
+	ref.blocks[1].StateRoot = common.Hash{0xff}
+	ref.blocks[2].StateRoot = common.Hash{0xff}
+
+	var calls int
+	c := newTestCrossChecker(t, chain, ref, func() error {
+		if calls++; calls == 1 {
+			return errors.New("chain stopped")
+		}
+		return nil
+	})
+	for i := uint64(1); i < 4; i++ {
+		c.enqueue(i)
+	}
+	c.verify()
+
+	if status := c.Status(); !status.Halted || calls != 2 {
+		t.Fatalf("unexpected halt: halted %v, calls %d", status.Halted, calls)
+	}
+}
+
+// Tests that the oldest blocks are skipped if too many are waiting.
+func TestCrossCheckPendingLimit(t *testing.T) {
+	chain, ref := newTestChains(1)
+	c := newTestCrossChecker(t, chain, ref, nil)
+
+	for i := uint64(0); i < maxPending+10; i++ {
+		c.enqueue(i)
+	}
+	if len(c.pending) != maxPending || c.pending[0] != 10 {
+		t.Fatalf("unexpected pending blocks: have %d from %d, want %d from 10", len(c.pending), c.pending[0], maxPending)
+	}
+}
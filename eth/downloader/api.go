@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -156,6 +157,26 @@ func (api *DownloaderAPI) Syncing(ctx context.Context) (*rpc.Subscription, error
 	return rpcSub, nil
 }
 
+// SyncingDetails returns the progress of the synchronisation broken down by
+// stage, with rate and time estimates, and the contribution of the connected
+// peers. Unlike eth_syncing, the details are also returned once synced.
+func (api *DownloaderAPI) SyncingDetails() *SyncDetails {
+	details := api.d.DetailedProgress()
+	if prog, err := api.chain.TxIndexProgress(); err == nil {
+		total := prog.Indexed + prog.Remaining
+		details.Stages = append(details.Stages, &SyncStage{
+			Name:     "txindex",
+			Current:  hexutil.Uint64(prog.Indexed),
+			Highest:  hexutil.Uint64(total),
+			Progress: fraction(prog.Indexed, total),
+		})
+		if prog.Remaining > 0 {
+			details.Syncing = true
+		}
+	}
+	return details
+}
+
 // SyncingResult provides information about the current synchronisation status for this node.
 type SyncingResult struct {
 	Syncing bool                  `json:"syncing"`
@@ -147,6 +147,13 @@ func (d *Downloader) SetBadBlockCallback(onBadBlock badBlockFn) {
 	d.badBlock = onBadBlock
 }
 
+// SetCheckpoint sets the trusted block which the snap sync pivot is anchored
+// to. This method is not thread safe and should be set only once on startup
+// before any sync is started.
+func (d *Downloader) SetCheckpoint(cp *ethconfig.SyncCheckpoint) {
+	d.checkpoint = cp
+}
+
 // BeaconSync is the post-merge version of the chain synchronization, where the
 // chain is not downloaded from genesis onward, rather from trusted head announces
 // backwards.
@@ -321,15 +328,13 @@ func (d *Downloader) fetchHeaders(from uint64) error {
 		if err != nil {
 			return err
 		}
-		// If the pivot became stale (older than 2*64-8 (bit of wiggle room)),
-		// move it ahead to HEAD-64
+		// If the pivot became stale, move it ahead according to the configured
+		// pivot strategy (HEAD-64 by default)
 		d.pivotLock.Lock()
 		if d.pivotHeader != nil {
-			if head.Number.Uint64() > d.pivotHeader.Number.Uint64()+2*uint64(fsMinFullBlocks)-8 {
+			if number, ok := d.stalePivotNumber(d.pivotHeader.Number.Uint64(), head.Number.Uint64()); ok {
 				// Retrieve the next pivot header, either from skeleton chain
 				// or the filled chain
-				number := head.Number.Uint64() - uint64(fsMinFullBlocks)
-
 				log.Warn("Pivot seemingly stale, moving", "old", d.pivotHeader.Number, "new", number)
 				if d.pivotHeader = d.skeleton.Header(number); d.pivotHeader == nil {
 					if number < tail.Number.Uint64() {
@@ -27,6 +27,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -68,6 +69,7 @@ var (
 	errCancelContentProcessing = errors.New("content processing canceled (requested)")
 	errCanceled                = errors.New("syncing canceled (requested)")
 	errNoPivotHeader           = errors.New("pivot header is not found")
+	errCheckpointMismatch      = errors.New("beacon chain conflicts with sync checkpoint")
 )
 
 // SyncMode defines the sync method of the downloader.
@@ -109,6 +111,9 @@ type Downloader struct {
 	syncStatsChainHeight uint64       // Highest block number known when syncing started
 	syncStatsLock        sync.RWMutex // Lock protecting the sync stats fields
 
+	stageMeters     map[string]*stageMeter // Rate meters of the sync stages, for the detailed progress
+	stageMetersLock sync.Mutex             // Lock protecting the stage meters
+
 	blockchain BlockChain
 
 	// Callbacks
@@ -127,6 +132,16 @@ type Downloader struct {
 	chainCutoffNumber uint64
 	chainCutoffHash   common.Hash
 
+	// Trusted block which the snap sync pivot is anchored to, nil if none
+	// was configured by the operator.
+	checkpoint *ethconfig.SyncCheckpoint
+
+	// Operator selected strategy for choosing the snap sync pivot, and the
+	// block resolved for a pinned pivot.
+	pivotConf     ethconfig.SyncPivot
+	pinnedPivot   *types.Header
+	pivotConfLock sync.RWMutex
+
 	// Channels
 	headerProcCh chan *headerTask // Channel to feed the header processor new tasks
 
@@ -436,33 +451,23 @@ func (d *Downloader) syncToHead() (err error) {
 	}(time.Now())
 
 	// Look up the sync boundaries: the common ancestor and the target block
-	var latest, pivot, final *types.Header
-	latest, _, final, err = d.skeleton.Bounds()
+	var latest, oldest, pivot, final *types.Header
+	latest, oldest, final, err = d.skeleton.Bounds()
 	if err != nil {
 		return err
 	}
-	if latest.Number.Uint64() > uint64(fsMinFullBlocks) {
-		number := latest.Number.Uint64() - uint64(fsMinFullBlocks)
-
-		// Retrieve the pivot header from the skeleton chain segment but
-		// fallback to local chain if it's not found in skeleton space.
-		if pivot = d.skeleton.Header(number); pivot == nil {
-			_, oldest, _, _ := d.skeleton.Bounds() // error is already checked
-			if number < oldest.Number.Uint64() {
-				count := int(oldest.Number.Uint64() - number) // it's capped by fsMinFullBlocks
-				headers := d.readHeaderRange(oldest, count)
-				if len(headers) == count {
-					pivot = headers[len(headers)-1]
-					log.Warn("Retrieved pivot header from local", "number", pivot.Number, "hash", pivot.Hash(), "latest", latest.Number, "oldest", oldest.Number)
-				}
-			}
+	// Select the pivot header according to the configured strategy, it's only
+	// needed for snap sync.
+	if mode == ethconfig.SnapSync {
+		if pivot, err = d.selectPivot(latest, oldest, final); err != nil {
+			return err
 		}
-		// Print an error log and return directly in case the pivot header
-		// is still not found. It means the skeleton chain is not linked
-		// correctly with local chain.
-		if pivot == nil {
-			log.Error("Pivot header is not found", "number", number)
-			return errNoPivotHeader
+	}
+	// If a trusted checkpoint is configured and not yet passed locally, ensure
+	// the beacon chain contains it and anchor the pivot to it.
+	if mode == ethconfig.SnapSync {
+		if pivot, err = d.checkpointPivot(latest, pivot); err != nil {
+			return err
 		}
 	}
 	// If no pivot block was returned, the head is below the min full block
@@ -585,6 +590,40 @@ func (d *Downloader) syncToHead() (err error) {
 	return d.spawnSync(fetchers)
 }
 
+// checkpointPivot cross-checks the beacon chain against the configured sync
+// checkpoint and returns the pivot to use. If the checkpoint is below the
+// default pivot, it is used instead so that the state is retrieved around
+// the trusted state root. Otherwise the default pivot is returned as is.
+//
+// TODO: skip the header download below the checkpoint and backfill it in the
+// background once the chain can start from a non-genesis block. This remains
+// open on cpchain-network/cp-geth#synth-103.
+func (d *Downloader) checkpointPivot(latest *types.Header, pivot *types.Header) (*types.Header, error) {
+	cp := d.checkpoint
+	if cp == nil || d.blockchain.CurrentSnapBlock().Number.Uint64() >= cp.Number {
+		return pivot, nil
+	}
+	if latest.Number.Uint64() < cp.Number {
+		log.Warn("Beacon head below sync checkpoint", "head", latest.Number, "checkpoint", cp.Number)
+		return pivot, nil
+	}
+	header := d.skeleton.Header(cp.Number)
+	if header == nil {
+		log.Error("Sync checkpoint header is not found", "number", cp.Number)
+		return nil, errNoPivotHeader
+	}
+	if header.Hash() != cp.Hash || header.Root != cp.Root {
+		log.Error("Beacon chain conflicts with sync checkpoint", "number", cp.Number,
+			"hash", header.Hash(), "want", cp.Hash, "root", header.Root, "wantroot", cp.Root)
+		return nil, errCheckpointMismatch
+	}
+	if pivot == nil || pivot.Number.Uint64() > cp.Number {
+		log.Info("Anchoring sync pivot to checkpoint", "number", cp.Number, "hash", cp.Hash, "root", cp.Root)
+		pivot = header
+	}
+	return pivot, nil
+}
+
 // spawnSync runs d.process and all given fetcher functions to completion in
 // separate goroutines, returning the first error that appears.
 func (d *Downloader) spawnSync(fetchers []func() error) error {
@@ -829,6 +868,9 @@ func (d *Downloader) importBlockResults(results []*fetchResult) error {
 	// transition. Because the downloaded chain is guided by the
 	// consensus-layer.
 	if index, err := d.blockchain.InsertChain(blocks); err != nil {
+		if errors.Is(err, core.ErrChainPaused) {
+			return err
+		}
 		if index < len(results) {
 			log.Debug("Downloaded item processing failed", "number", results[index].Header.Number, "hash", results[index].Header.Hash(), "err", err)
 
@@ -941,17 +983,11 @@ func (d *Downloader) processSnapSyncContent() error {
 		}
 		// Split around the pivot block and process the two sides via snap/full sync
 		if !d.committed.Load() {
-			latest := results[len(results)-1].Header
-			// If the height is above the pivot block by 2 sets, it means the pivot
-			// become stale in the network, and it was garbage collected, move to a
-			// new pivot.
-			//
-			// Note, we have `reorgProtHeaderDelay` number of blocks withheld, Those
-			// need to be taken into account, otherwise we're detecting the pivot move
-			// late and will drop peers due to unavailable state!!!
-			if height := latest.Number.Uint64(); height >= pivot.Number.Uint64()+2*uint64(fsMinFullBlocks)-uint64(reorgProtHeaderDelay) {
-				log.Warn("Pivot became stale, moving", "old", pivot.Number.Uint64(), "new", height-uint64(fsMinFullBlocks)+uint64(reorgProtHeaderDelay))
-				pivot = results[len(results)-1-fsMinFullBlocks+reorgProtHeaderDelay].Header // must exist as lower old pivot is uncommitted
+			// Move the pivot if it became stale in the network and was likely
+			// garbage collected by the peers.
+			if next := d.nextPivot(pivot, results); next != nil {
+				log.Warn("Pivot became stale, moving", "old", pivot.Number.Uint64(), "new", next.Number.Uint64())
+				pivot = next
 
 				d.pivotLock.Lock()
 				d.pivotHeader = pivot
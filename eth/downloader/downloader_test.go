@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/event"
@@ -678,6 +679,201 @@ func testBeaconSync(t *testing.T, protocol uint, mode SyncMode) {
 	}
 }
 
+// Tests that snap sync accepts beacon chains containing the configured sync
+// checkpoint and refuses the ones conflicting with it.
+func TestBeaconSyncCheckpoint(t *testing.T) {
+	chain := testChainBase.shorten(blockCacheMaxItems - 15)
+	anchor := chain.blocks[len(chain.blocks)/2]
+
+	var cases = []struct {
+		name       string
+		checkpoint ethconfig.SyncCheckpoint
+		success    bool
+	}{
+		{"matching checkpoint", ethconfig.SyncCheckpoint{Number: anchor.NumberU64(), Hash: anchor.Hash(), Root: anchor.Root()}, true},
+		{"conflicting hash", ethconfig.SyncCheckpoint{Number: anchor.NumberU64(), Hash: common.Hash{0x01}, Root: anchor.Root()}, false},
+		{"conflicting root", ethconfig.SyncCheckpoint{Number: anchor.NumberU64(), Hash: anchor.Hash(), Root: common.Hash{0x01}}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			success := make(chan struct{})
+			tester := newTesterWithNotification(t, func() {
+				close(success)
+			})
+			defer tester.terminate()
+
+			tester.downloader.SetCheckpoint(&c.checkpoint)
+			tester.newPeer("peer", eth.ETH68, chain.blocks[1:])
+			if err := tester.downloader.BeaconSync(SnapSync, chain.blocks[len(chain.blocks)-1].Header(), nil); err != nil {
+				t.Fatalf("failed to beacon sync chain: %v", err)
+			}
+			select {
+			case <-success:
+				if !c.success {
+					t.Fatalf("sync succeeded despite conflicting checkpoint")
+				}
+				if bs := int(tester.chain.CurrentBlock().Number.Uint64()) + 1; bs != len(chain.blocks) {
+					t.Fatalf("synchronised blocks mismatch: have %v, want %v", bs, len(chain.blocks))
+				}
+			case <-time.NewTimer(time.Second * 3).C:
+				if c.success {
+					t.Fatalf("failed to sync chain in three seconds")
+				}
+				if head := tester.chain.CurrentSnapBlock().Number.Uint64(); head != 0 {
+					t.Fatalf("chain imported despite conflicting checkpoint: head %d", head)
+				}
+			}
+		})
+	}
+}
+
+// Tests that snap sync selects the pivot block according to the configured
+// strategy, and refuses to sync if the pinned pivot is not in the beacon chain.
+//
+// Note, the test peers only serve the state of the recent blocks, so all the
+// selected pivots need to be close to the head.
+func TestBeaconSyncPivotStrategy(t *testing.T) {
+	var (
+		chain  = testChainBase.shorten(blockCacheMaxItems - 15)
+		head   = chain.blocks[len(chain.blocks)-1]
+		final  = chain.blocks[len(chain.blocks)-110]
+		stale  = chain.blocks[len(chain.blocks)-130]
+		pinned = chain.blocks[len(chain.blocks)-120]
+	)
+	var cases = []struct {
+		name    string
+		pivot   ethconfig.SyncPivot
+		final   *types.Header
+		want    uint64
+		success bool
+	}{
+		{"latest", ethconfig.SyncPivot{Strategy: ethconfig.PivotLatest}, nil, head.NumberU64() - uint64(fsMinFullBlocks), true},
+		{"finalized", ethconfig.SyncPivot{Strategy: ethconfig.PivotFinalized}, final.Header(), final.NumberU64(), true},
+		{"finalized unknown", ethconfig.SyncPivot{Strategy: ethconfig.PivotFinalized}, nil, head.NumberU64() - uint64(fsMinFullBlocks), true},
+		{"finalized unserved", ethconfig.SyncPivot{Strategy: ethconfig.PivotFinalized}, stale.Header(), head.NumberU64() - uint64(fsMinFullBlocks), true},
+		{"offset", ethconfig.SyncPivot{Strategy: ethconfig.PivotOffset, Offset: 100}, nil, head.NumberU64() - 100, true},
+		{"pinned", ethconfig.SyncPivot{Strategy: ethconfig.PivotPinned, Hash: pinned.Hash()}, nil, pinned.NumberU64(), true},
+		{"pinned unknown", ethconfig.SyncPivot{Strategy: ethconfig.PivotPinned, Hash: common.Hash{0x01}}, nil, 0, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			success := make(chan struct{})
+			tester := newTesterWithNotification(t, func() {
+				close(success)
+			})
+			defer tester.terminate()
+
+			if err := tester.downloader.SetPivotStrategy(c.pivot); err != nil {
+				t.Fatalf("failed to set pivot strategy: %v", err)
+			}
+			if err := tester.downloader.BeaconSync(SnapSync, head.Header(), nil); err != nil {
+				t.Fatalf("failed to beacon sync chain: %v", err)
+			}
+			// The finalized block of the initial announcement is ignored by the
+			// skeleton syncer, re-announce the head before any header is synced.
+			if c.final != nil {
+				if err := tester.downloader.BeaconSync(SnapSync, head.Header(), c.final); err != nil {
+					t.Fatalf("failed to announce finalized block: %v", err)
+				}
+			}
+			tester.newPeer("peer", eth.ETH68, chain.blocks[1:])
+
+			select {
+			case <-success:
+				if !c.success {
+					t.Fatalf("sync succeeded despite unknown pinned pivot")
+				}
+				if bs := int(tester.chain.CurrentBlock().Number.Uint64()) + 1; bs != len(chain.blocks) {
+					t.Fatalf("synchronised blocks mismatch: have %v, want %v", bs, len(chain.blocks))
+				}
+				if pivot := rawdb.ReadLastPivotNumber(tester.downloader.stateDB); pivot == nil {
+					t.Fatalf("pivot missing")
+				} else if *pivot != c.want {
+					t.Fatalf("pivot mismatch: have %d, want %d", *pivot, c.want)
+				}
+			case <-time.NewTimer(time.Second * 3).C:
+				if c.success {
+					t.Fatalf("failed to sync chain in three seconds")
+				}
+				if head := tester.chain.CurrentSnapBlock().Number.Uint64(); head != 0 {
+					t.Fatalf("chain imported despite unknown pinned pivot: head %d", head)
+				}
+			}
+		})
+	}
+}
+
+// Tests that pivots are moved once their state is no longer served, whatever
+// their distance from the head, and that finalized pivots without a usable
+// finalized block fall back to the default distance.
+func TestStalePivotNumber(t *testing.T) {
+	tester := newTester(t)
+	defer tester.terminate()
+
+	var cases = []struct {
+		pivot ethconfig.SyncPivot
+		head  uint64
+		want  uint64
+		stale bool
+	}{
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotLatest}, 1000 + pivotServedWindow(), 0, false},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotLatest}, 1001 + pivotServedWindow(), 1001 + pivotServedWindow() - uint64(fsMinFullBlocks), true},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotOffset, Offset: 100}, 1000 + pivotServedWindow(), 0, false},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotOffset, Offset: 100}, 1001 + pivotServedWindow(), 901 + pivotServedWindow(), true},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotFinalized}, 1001 + pivotServedWindow(), 1001 + pivotServedWindow() - uint64(fsMinFullBlocks), true},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotPinned, Hash: common.Hash{0x01}}, 2000, 0, false},
+	}
+	for i, c := range cases {
+		if err := tester.downloader.SetPivotStrategy(c.pivot); err != nil {
+			t.Fatalf("case %d: failed to set pivot strategy: %v", i, err)
+		}
+		number, stale := tester.downloader.stalePivotNumber(1000, c.head)
+		if stale != c.stale || number != c.want {
+			t.Errorf("case %d (%v): stale pivot mismatch: have %d %v, want %d %v", i, c.pivot, number, stale, c.want, c.stale)
+		}
+	}
+}
+
+// Tests that invalid pivot strategies are rejected.
+func TestPivotStrategyValidation(t *testing.T) {
+	tester := newTester(t)
+	defer tester.terminate()
+
+	var cases = []struct {
+		pivot ethconfig.SyncPivot
+		valid bool
+	}{
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotLatest}, true},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotFinalized}, true},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotOffset, Offset: uint64(fsMinFullBlocks)}, true},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotOffset, Offset: uint64(fsMinFullBlocks) - 1}, false},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotOffset, Offset: pivotServedWindow()}, true},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotOffset, Offset: pivotServedWindow() + 1}, false},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotPinned, Hash: common.Hash{0x01}}, true},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotPinned}, false},
+		{ethconfig.SyncPivot{Strategy: ethconfig.PivotPinned + 1}, false},
+	}
+	for i, c := range cases {
+		err := tester.downloader.SetPivotStrategy(c.pivot)
+		if c.valid && err != nil {
+			t.Errorf("case %d (%v): unexpected error: %v", i, c.pivot, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("case %d (%v): invalid strategy accepted", i, c.pivot)
+		}
+	}
+	// Ensure the text form round trips through the parser
+	for _, pivot := range []string{"latest", "finalized", "offset:100", "pinned:0x0100000000000000000000000000000000000000000000000000000000000000"} {
+		var parsed ethconfig.SyncPivot
+		if err := parsed.UnmarshalText([]byte(pivot)); err != nil {
+			t.Fatalf("failed to parse pivot %q: %v", pivot, err)
+		}
+		if parsed.String() != pivot {
+			t.Errorf("pivot text mismatch: have %q, want %q", parsed.String(), pivot)
+		}
+	}
+}
+
 // Tests that synchronisation progress (origin block number, current block number
 // and highest block number) is tracked and updated correctly.
 func TestSyncProgress68Full(t *testing.T) { testSyncProgress(t, eth.ETH68, FullSync) }
@@ -22,6 +22,7 @@ package downloader
 import (
 	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -47,6 +48,10 @@ type peerConnection struct {
 	rates   *msgrate.Tracker         // Tracker to hone in on the number of items retrievable per second
 	lacking map[common.Hash]struct{} // Set of hashes not to request (didn't have previously)
 
+	headers  atomic.Uint64 // Number of headers delivered by the peer
+	bodies   atomic.Uint64 // Number of block bodies delivered by the peer
+	receipts atomic.Uint64 // Number of block receipt sets delivered by the peer
+
 	peer Peer
 
 	version uint       // Eth protocol version number to switch strategies
@@ -86,18 +91,21 @@ func (p *peerConnection) Reset() {
 // the current measurement.
 func (p *peerConnection) UpdateHeaderRate(delivered int, elapsed time.Duration) {
 	p.rates.Update(eth.BlockHeadersMsg, elapsed, delivered)
+	p.headers.Add(uint64(delivered))
 }
 
 // UpdateBodyRate updates the peer's estimated body retrieval throughput with the
 // current measurement.
 func (p *peerConnection) UpdateBodyRate(delivered int, elapsed time.Duration) {
 	p.rates.Update(eth.BlockBodiesMsg, elapsed, delivered)
+	p.bodies.Add(uint64(delivered))
 }
 
 // UpdateReceiptRate updates the peer's estimated receipt retrieval throughput
 // with the current measurement.
 func (p *peerConnection) UpdateReceiptRate(delivered int, elapsed time.Duration) {
 	p.rates.Update(eth.ReceiptsMsg, elapsed, delivered)
+	p.receipts.Add(uint64(delivered))
 }
 
 // HeaderCapacity retrieves the peer's header download allowance based on its
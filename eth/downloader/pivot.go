@@ -0,0 +1,262 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// pivotServedWindow returns the number of blocks below the head whose state is
+// still served by the network, leaving a bit of wiggle room. Pivots older than
+// that are stale and need to be moved.
+func pivotServedWindow() uint64 {
+	return uint64(2*fsMinFullBlocks - 8)
+}
+
+// errPinnedPivotNotFound is returned if the operator pinned the snap sync pivot
+// to a block which is not part of the beacon chain being synced.
+var errPinnedPivotNotFound = errors.New("pinned pivot is not in the beacon chain")
+
+// SetPivotStrategy validates and sets the strategy used to select the snap sync
+// pivot. A change takes effect the next time the pivot is selected or moved.
+func (d *Downloader) SetPivotStrategy(pivot ethconfig.SyncPivot) error {
+	switch pivot.Strategy {
+	case ethconfig.PivotLatest, ethconfig.PivotFinalized:
+	case ethconfig.PivotOffset:
+		if pivot.Offset < uint64(fsMinFullBlocks) || pivot.Offset > pivotServedWindow() {
+			return fmt.Errorf("pivot offset %d out of range [%d, %d]", pivot.Offset, fsMinFullBlocks, pivotServedWindow())
+		}
+	case ethconfig.PivotPinned:
+		if pivot.Hash == (common.Hash{}) {
+			return errors.New("pinned pivot hash missing")
+		}
+	default:
+		return fmt.Errorf("unknown pivot strategy %d", pivot.Strategy)
+	}
+	d.pivotConfLock.Lock()
+	defer d.pivotConfLock.Unlock()
+
+	d.pivotConf = pivot
+	d.pinnedPivot = nil
+	log.Info("Updated snap sync pivot strategy", "pivot", pivot)
+	return nil
+}
+
+// PivotStrategy returns the strategy used to select the snap sync pivot.
+func (d *Downloader) PivotStrategy() ethconfig.SyncPivot {
+	d.pivotConfLock.RLock()
+	defer d.pivotConfLock.RUnlock()
+
+	return d.pivotConf
+}
+
+// pivotDistance returns the number of blocks the pivot is kept below the head
+// of the chain by the distance based strategies.
+func pivotDistance(pivot ethconfig.SyncPivot) uint64 {
+	if pivot.Strategy == ethconfig.PivotOffset {
+		return pivot.Offset
+	}
+	return uint64(fsMinFullBlocks)
+}
+
+// selectPivot picks the initial snap sync pivot according to the configured
+// strategy, falling back to the distance based selection if the strategy has
+// no suitable block. A nil pivot is returned if the chain is too short to have
+// one.
+func (d *Downloader) selectPivot(latest, tail, final *types.Header) (*types.Header, error) {
+	conf := d.PivotStrategy()
+	switch conf.Strategy {
+	case ethconfig.PivotFinalized:
+		// Use the finalized block if it leaves enough blocks to be fully
+		// retrieved above it and its state is still served, otherwise fall
+		// back to the default pivot
+		if final != nil && finalizedPivotUsable(final.Number.Uint64(), latest.Number.Uint64()) {
+			log.Info("Anchoring sync pivot to finalized block", "number", final.Number, "hash", final.Hash())
+			return final, nil
+		}
+		log.Debug("No finalized block to pivot on, using default", "latest", latest.Number)
+
+	case ethconfig.PivotPinned:
+		pivot, err := d.findPinnedPivot(latest, tail, conf.Hash)
+		if err != nil || pivot != nil {
+			return pivot, err
+		}
+	}
+	distance := pivotDistance(conf)
+	if latest.Number.Uint64() <= distance {
+		return nil, nil
+	}
+	number := latest.Number.Uint64() - distance
+
+	// Retrieve the pivot header from the skeleton chain segment but
+	// fallback to local chain if it's not found in skeleton space.
+	pivot := d.skeleton.Header(number)
+	if pivot == nil {
+		if number < tail.Number.Uint64() {
+			count := int(tail.Number.Uint64() - number) // it's capped by the pivot distance
+			headers := d.readHeaderRange(tail, count)
+			if len(headers) == count {
+				pivot = headers[len(headers)-1]
+				log.Warn("Retrieved pivot header from local", "number", pivot.Number, "hash", pivot.Hash(), "latest", latest.Number, "oldest", tail.Number)
+			}
+		}
+	}
+	// Print an error log and return directly in case the pivot header
+	// is still not found. It means the skeleton chain is not linked
+	// correctly with local chain.
+	if pivot == nil {
+		log.Error("Pivot header is not found", "number", number)
+		return nil, errNoPivotHeader
+	}
+	return pivot, nil
+}
+
+// finalizedPivotUsable reports whether the finalized block can be pivoted on,
+// given the head of the chain: it must leave enough blocks to be fully retrieved
+// above it, and its state must still be served.
+func finalizedPivotUsable(final, head uint64) bool {
+	return final+uint64(fsMinFullBlocks) <= head && head-final <= pivotServedWindow()
+}
+
+// findPinnedPivot resolves the operator pinned pivot block from the skeleton
+// chain. Nil is returned without an error if the local chain already passed
+// the pinned block, so there's nothing left to pin.
+func (d *Downloader) findPinnedPivot(latest, tail *types.Header, hash common.Hash) (*types.Header, error) {
+	if header := d.blockchain.GetHeaderByHash(hash); header != nil {
+		if d.blockchain.CurrentSnapBlock().Number.Uint64() >= header.Number.Uint64() {
+			return nil, nil
+		}
+	}
+	// Reuse the previous resolution if the skeleton still contains it
+	d.pivotConfLock.RLock()
+	pinned := d.pinnedPivot
+	d.pivotConfLock.RUnlock()
+
+	if pinned != nil && pinned.Hash() == hash {
+		if header := d.skeleton.Header(pinned.Number.Uint64()); header != nil && header.Hash() == hash {
+			return header, nil
+		}
+	}
+	// Walk the skeleton chain backwards looking for the pinned block. The
+	// search is bounded as the state of older blocks is not served anyway.
+	var (
+		number = latest.Number.Uint64()
+		oldest = tail.Number.Uint64()
+	)
+	if number > fullMaxForkAncestry && number-fullMaxForkAncestry > oldest {
+		oldest = number - fullMaxForkAncestry
+	}
+	for ; number >= oldest && number > 0; number-- {
+		header := d.skeleton.Header(number)
+		if header == nil {
+			break
+		}
+		if header.Hash() == hash {
+			d.pivotConfLock.Lock()
+			d.pinnedPivot = header
+			d.pivotConfLock.Unlock()
+
+			log.Info("Pinning sync pivot to configured block", "number", header.Number, "hash", hash, "root", header.Root)
+			return header, nil
+		}
+	}
+	log.Error("Pinned sync pivot is not in the beacon chain", "hash", hash, "latest", latest.Number, "oldest", oldest)
+	return nil, errPinnedPivotNotFound
+}
+
+// stalePivotNumber checks whether the pivot became stale as the skeleton head
+// advanced (its state no longer served, see pivotServedWindow), returning the
+// number of the block to move it to. The same rules as for nextPivot apply.
+func (d *Downloader) stalePivotNumber(pivot, head uint64) (uint64, bool) {
+	conf := d.PivotStrategy()
+	if conf.Strategy == ethconfig.PivotPinned {
+		return 0, false
+	}
+	if head <= pivot+pivotServedWindow() {
+		return 0, false
+	}
+	if conf.Strategy == ethconfig.PivotFinalized {
+		_, _, final, err := d.skeleton.Bounds()
+		if err == nil && final != nil && final.Number.Uint64() > pivot && finalizedPivotUsable(final.Number.Uint64(), head) {
+			return final.Number.Uint64(), true
+		}
+	}
+	return head - pivotDistance(conf), true
+}
+
+// nextPivot checks whether the current pivot became stale, given the newest
+// batch of downloaded results, and returns the block to move it to, or nil if
+// it should be kept.
+//
+// Pinned pivots are never moved. Finalized pivots are moved to a newer finalized
+// block, or to the default distance from the head if there's no finalized block
+// with served state. Other strategies move the pivot to keep its distance from
+// the head.
+func (d *Downloader) nextPivot(pivot *types.Header, results []*fetchResult) *types.Header {
+	conf := d.PivotStrategy()
+	if conf.Strategy == ethconfig.PivotPinned {
+		return nil
+	}
+	// If the height is above the pivot block by 2 sets, it means the pivot
+	// become stale in the network, and it was garbage collected, move to a
+	// new pivot.
+	//
+	// Note, we have `reorgProtHeaderDelay` number of blocks withheld, Those
+	// need to be taken into account, otherwise we're detecting the pivot move
+	// late and will drop peers due to unavailable state!!!
+	var (
+		distance = pivotDistance(conf)
+		height   = results[len(results)-1].Header.Number.Uint64()
+	)
+	if height < pivot.Number.Uint64()+2*uint64(fsMinFullBlocks)-uint64(reorgProtHeaderDelay) {
+		return nil
+	}
+	if conf.Strategy == ethconfig.PivotFinalized {
+		if next := finalizedResult(d.skeleton, pivot, results); next != nil {
+			return next
+		}
+		log.Debug("No finalized block to move pivot to, using default", "height", height)
+	}
+	return results[len(results)-1-int(distance)+reorgProtHeaderDelay].Header // must exist as lower old pivot is uncommitted
+}
+
+// finalizedResult returns the header of the finalized block among the results,
+// if it's newer than the pivot and usable as one.
+func finalizedResult(skeleton *skeleton, pivot *types.Header, results []*fetchResult) *types.Header {
+	_, _, final, err := skeleton.Bounds()
+	if err != nil || final == nil {
+		return nil
+	}
+	var (
+		first  = results[0].Header.Number.Uint64()
+		height = results[len(results)-1].Header.Number.Uint64()
+		number = final.Number.Uint64()
+	)
+	if number <= pivot.Number.Uint64() || number < first || number > height || height-number > pivotServedWindow() {
+		return nil
+	}
+	if next := results[number-first].Header; next.Hash() == final.Hash() {
+		return next
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+)
+
+// stageRateWindow is the time span over which the rates of the sync stages are
+// averaged.
+const stageRateWindow = 10 * time.Minute
+
+// stageSampleInterval is the minimum time between two retained samples of a
+// sync stage, bounding the samples kept per stage regardless of how often the
+// detailed progress is polled.
+const stageSampleInterval = 10 * time.Second
+
+// coverageScale converts the snap sync coverage fraction into a counter, so it
+// can be metered like the other stages.
+const coverageScale = 1_000_000
+
+// SyncStage is the progress of a single stage of the synchronisation.
+type SyncStage struct {
+	Name     string          `json:"name"`
+	Start    hexutil.Uint64  `json:"start"`    // Position of the stage when the sync cycle started
+	Current  hexutil.Uint64  `json:"current"`  // Current position of the stage
+	Highest  hexutil.Uint64  `json:"highest"`  // Target of the stage, estimated for the state stages
+	Pending  hexutil.Uint64  `json:"pending"`  // Number of items scheduled for retrieval
+	Progress float64         `json:"progress"` // Fraction of the stage done, between 0 and 1
+	Rate     float64         `json:"rate"`     // Items processed per second
+	ETA      *hexutil.Uint64 `json:"eta"`      // Estimated seconds until the stage is done, nil if unknown
+
+	covered bool // Whether the progress is the covered account space, metered instead of the position
+}
+
+// SyncPeer is the contribution of a connected peer to the synchronisation.
+type SyncPeer struct {
+	ID       string         `json:"id"`
+	Headers  hexutil.Uint64 `json:"headers"`  // Number of headers delivered
+	Bodies   hexutil.Uint64 `json:"bodies"`   // Number of block bodies delivered
+	Receipts hexutil.Uint64 `json:"receipts"` // Number of block receipt sets delivered
+	Share    float64        `json:"share"`    // Fraction of all the items delivered by connected peers
+}
+
+// SyncDetails is the detailed progress of the synchronisation, broken down by
+// stage and by peer.
+type SyncDetails struct {
+	Syncing       bool            `json:"syncing"`
+	Mode          string          `json:"mode"`
+	StartingBlock hexutil.Uint64  `json:"startingBlock"`
+	CurrentBlock  hexutil.Uint64  `json:"currentBlock"`
+	HighestBlock  hexutil.Uint64  `json:"highestBlock"`
+	Pivot         *hexutil.Uint64 `json:"pivot"` // Block the state is snap synced at, nil in full sync
+	Stages        []*SyncStage    `json:"stages"`
+	Peers         []*SyncPeer     `json:"peers"`
+	ETA           *hexutil.Uint64 `json:"eta"` // Estimated seconds until the slowest stage is done
+}
+
+// stageSample is the position of a sync stage at a point in time.
+type stageSample struct {
+	time  time.Time
+	value uint64
+}
+
+// stageMeter estimates the rate of a sync stage from the positions sampled
+// whenever the detailed progress is retrieved.
+type stageMeter struct {
+	samples []stageSample
+}
+
+// update adds a new sample and returns the rate averaged over the window. A
+// sample taken within stageSampleInterval of the one before the latest replaces
+// the latest instead of being appended.
+func (m *stageMeter) update(now time.Time, value uint64) float64 {
+	// A stage moving backwards belongs to a new sync cycle, start over
+	if n := len(m.samples); n > 0 && value < m.samples[n-1].value {
+		m.samples = m.samples[:0]
+	}
+	sample := stageSample{time: now, value: value}
+	if n := len(m.samples); n >= 2 && now.Sub(m.samples[n-2].time) < stageSampleInterval {
+		m.samples[n-1] = sample
+	} else {
+		m.samples = append(m.samples, sample)
+	}
+	for len(m.samples) > 2 && now.Sub(m.samples[1].time) >= stageRateWindow {
+		m.samples = m.samples[1:]
+	}
+	first := m.samples[0]
+	elapsed := now.Sub(first.time)
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(value-first.value) / elapsed.Seconds()
+}
+
+// DetailedProgress retrieves the progress of every stage of the running sync,
+// along with the contribution of the connected peers. The rates and the time
+// estimates are averaged over the calls made in the last few minutes, so they
+// are only available from the second call on.
+func (d *Downloader) DetailedProgress() *SyncDetails {
+	var (
+		progress  = d.Progress()
+		mode      = d.getMode()
+		header    = d.blockchain.CurrentHeader().Number.Uint64()
+		block     = d.blockchain.CurrentBlock().Number.Uint64()
+		snapBlock = d.blockchain.CurrentSnapBlock().Number.Uint64()
+		origin    = progress.StartingBlock
+		height    = progress.HighestBlock
+	)
+	details := &SyncDetails{
+		Syncing:       !progress.Done(),
+		Mode:          mode.String(),
+		StartingBlock: hexutil.Uint64(origin),
+		CurrentBlock:  hexutil.Uint64(progress.CurrentBlock),
+		HighestBlock:  hexutil.Uint64(height),
+	}
+	// Gather the positions of the block stages
+	stages := []*SyncStage{
+		blockStage("headers", origin, header, height, 0),
+	}
+	switch mode {
+	case ethconfig.SnapSync:
+		stages = append(stages,
+			blockStage("bodies", origin, snapBlock, height, d.queue.PendingBodies()),
+			blockStage("receipts", origin, snapBlock, height, d.queue.PendingReceipts()),
+		)
+		// Gather the state stages, estimated from the covered account space
+		coverage := d.SnapSyncer.Coverage()
+		for _, stage := range []struct {
+			name   string
+			synced uint64
+		}{
+			{"accounts", progress.SyncedAccounts},
+			{"storage", progress.SyncedStorage},
+			{"bytecodes", progress.SyncedBytecodes},
+		} {
+			stages = append(stages, stateStage(stage.name, stage.synced, coverage))
+		}
+		healed := progress.HealedTrienodes + progress.HealedBytecodes
+		healing := progress.HealingTrienodes + progress.HealingBytecode
+		stages = append(stages, &SyncStage{
+			Name:     "healing",
+			Current:  hexutil.Uint64(healed),
+			Highest:  hexutil.Uint64(healed + healing),
+			Pending:  hexutil.Uint64(healing),
+			Progress: fraction(healed, healed+healing),
+		})
+		// Once the pivot state is in place, the rest of the chain is executed
+		d.pivotLock.RLock()
+		pivot := d.pivotHeader
+		d.pivotLock.RUnlock()
+		if pivot != nil {
+			number := pivot.Number.Uint64()
+			details.Pivot = (*hexutil.Uint64)(&number)
+			stages = append(stages, blockStage("execution", number, max(block, number), height, 0))
+		}
+	default:
+		stages = append(stages,
+			blockStage("bodies", origin, block, height, d.queue.PendingBodies()),
+			blockStage("execution", origin, block, height, 0),
+		)
+	}
+	// Meter the stages and estimate the time until each is done
+	now := time.Now()
+
+	d.stageMetersLock.Lock()
+	if d.stageMeters == nil {
+		d.stageMeters = make(map[string]*stageMeter)
+	}
+	for _, stage := range stages {
+		meter := d.stageMeters[stage.Name]
+		if meter == nil {
+			meter = new(stageMeter)
+			d.stageMeters[stage.Name] = meter
+		}
+		if !stage.covered {
+			// Block and healing stages are metered by their position
+			stage.Rate = meter.update(now, uint64(stage.Current))
+			if stage.Rate > 0 && stage.Highest > stage.Current {
+				stage.ETA = eta(float64(stage.Highest-stage.Current) / stage.Rate)
+			}
+		} else {
+			// State stages are metered by the covered account space, the item
+			// rate is derived from the items synced per covered fraction
+			rate := meter.update(now, uint64(stage.Progress*coverageScale))
+			if rate > 0 && stage.Progress > 0 {
+				stage.Rate = rate / coverageScale * float64(stage.Current) / stage.Progress
+				if stage.Progress < 1 {
+					stage.ETA = eta((1 - stage.Progress) * coverageScale / rate)
+				}
+			}
+		}
+		if stage.ETA != nil && (details.ETA == nil || *stage.ETA > *details.ETA) {
+			details.ETA = stage.ETA
+		}
+	}
+	d.stageMetersLock.Unlock()
+	details.Stages = stages
+
+	// Gather the contribution of the connected peers
+	var total uint64
+	for _, p := range d.peers.AllPeers() {
+		peer := &SyncPeer{
+			ID:       p.id,
+			Headers:  hexutil.Uint64(p.headers.Load()),
+			Bodies:   hexutil.Uint64(p.bodies.Load()),
+			Receipts: hexutil.Uint64(p.receipts.Load()),
+		}
+		total += uint64(peer.Headers + peer.Bodies + peer.Receipts)
+		details.Peers = append(details.Peers, peer)
+	}
+	for _, peer := range details.Peers {
+		peer.Share = fraction(uint64(peer.Headers+peer.Bodies+peer.Receipts), total)
+	}
+	slices.SortFunc(details.Peers, func(a, b *SyncPeer) int {
+		if c := cmp.Compare(b.Share, a.Share); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return details
+}
+
+// blockStage creates a stage progressing through the block range [start, highest].
+func blockStage(name string, start, current, highest uint64, pending int) *SyncStage {
+	highest = max(highest, start)
+	current = max(start, min(current, highest))
+	return &SyncStage{
+		Name:     name,
+		Start:    hexutil.Uint64(start),
+		Current:  hexutil.Uint64(current),
+		Highest:  hexutil.Uint64(highest),
+		Pending:  hexutil.Uint64(pending),
+		Progress: fraction(current-start, highest-start),
+	}
+}
+
+// stateStage creates a snap sync state stage, with the target estimated from
+// the fraction of the account space already covered.
+func stateStage(name string, synced uint64, coverage float64) *SyncStage {
+	stage := &SyncStage{
+		Name:     name,
+		Current:  hexutil.Uint64(synced),
+		Progress: coverage,
+		covered:  true,
+	}
+	if coverage > 0 {
+		stage.Highest = hexutil.Uint64(float64(synced) / coverage)
+	}
+	return stage
+}
+
+// fraction returns done/total, or 1 if there's nothing to do.
+func fraction(done, total uint64) float64 {
+	if total == 0 {
+		return 1
+	}
+	return float64(done) / float64(total)
+}
+
+// eta converts a number of seconds into a time estimate.
+func eta(seconds float64) *hexutil.Uint64 {
+	eta := hexutil.Uint64(math.Ceil(seconds))
+	return &eta
+}
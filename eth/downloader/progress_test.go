@@ -0,0 +1,117 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+)
+
+// Tests that the stage meter averages the rate over the window, and restarts
+// when the stage moves backwards.
+func TestStageMeter(t *testing.T) {
+	var (
+		meter stageMeter
+		start = time.Unix(0, 0)
+	)
+	if rate := meter.update(start, 100); rate != 0 {
+		t.Fatalf("rate mismatch on first sample: have %v, want 0", rate)
+	}
+	if rate := meter.update(start.Add(10*time.Second), 200); rate != 10 {
+		t.Fatalf("rate mismatch: have %v, want 10", rate)
+	}
+	// Samples beyond the window are dropped
+	if rate := meter.update(start.Add(stageRateWindow+20*time.Second), 200+uint64(stageRateWindow/time.Second)+10); rate != 1 {
+		t.Fatalf("windowed rate mismatch: have %v, want 1", rate)
+	}
+	// A new cycle starts over
+	if rate := meter.update(start.Add(stageRateWindow+30*time.Second), 50); rate != 0 {
+		t.Fatalf("rate mismatch after reset: have %v, want 0", rate)
+	}
+	// Frequent polling keeps the samples bounded
+	now := start.Add(stageRateWindow + 30*time.Second)
+	for i := 0; i < 10000; i++ {
+		now = now.Add(100 * time.Millisecond)
+		meter.update(now, 50+uint64(i))
+	}
+	if limit := int(stageRateWindow/stageSampleInterval) + 3; len(meter.samples) > limit {
+		t.Fatalf("samples not bounded: have %d, want at most %d", len(meter.samples), limit)
+	}
+	if rate := meter.update(now.Add(100*time.Millisecond), 50+10000); rate < 9.9 || rate > 10.1 {
+		t.Fatalf("rate mismatch under polling: have %v, want 10", rate)
+	}
+}
+
+// Tests that the detailed progress reports the stages of a finished sync as
+// done, and attributes the delivered data to the serving peer.
+func TestDetailedProgress68Full(t *testing.T) { testDetailedProgress(t, eth.ETH68, FullSync) }
+func TestDetailedProgress68Snap(t *testing.T) { testDetailedProgress(t, eth.ETH68, SnapSync) }
+
+func testDetailedProgress(t *testing.T, protocol uint, mode SyncMode) {
+	success := make(chan struct{})
+	tester := newTesterWithNotification(t, func() {
+		close(success)
+	})
+	defer tester.terminate()
+
+	chain := testChainBase.shorten(blockCacheMaxItems - 15)
+	tester.newPeer("peer", protocol, chain.blocks[1:])
+
+	if err := tester.downloader.BeaconSync(mode, chain.blocks[len(chain.blocks)-1].Header(), nil); err != nil {
+		t.Fatalf("failed to beacon-sync chain: %v", err)
+	}
+	select {
+	case <-success:
+	case <-time.NewTimer(time.Second * 3).C:
+		t.Fatalf("Failed to sync chain in three seconds")
+	}
+	details := tester.downloader.DetailedProgress()
+	if details.Mode != mode.String() {
+		t.Fatalf("mode mismatch: have %s, want %s", details.Mode, mode)
+	}
+	stages := make(map[string]*SyncStage)
+	for _, stage := range details.Stages {
+		stages[stage.Name] = stage
+	}
+	want := []string{"headers", "bodies", "execution"}
+	if mode == SnapSync {
+		want = []string{"headers", "bodies", "receipts", "accounts", "storage", "bytecodes", "healing", "execution"}
+	}
+	if len(stages) != len(want) {
+		t.Fatalf("stage count mismatch: have %d, want %d", len(stages), len(want))
+	}
+	for _, name := range want {
+		stage, ok := stages[name]
+		if !ok {
+			t.Fatalf("stage %s missing", name)
+		}
+		if stage.Progress != 1 {
+			t.Errorf("stage %s progress mismatch: have %v, want 1", name, stage.Progress)
+		}
+	}
+	if head := uint64(len(chain.blocks) - 1); uint64(stages["headers"].Current) != head {
+		t.Errorf("header stage mismatch: have %d, want %d", stages["headers"].Current, head)
+	}
+	if len(details.Peers) != 1 {
+		t.Fatalf("peer count mismatch: have %d, want 1", len(details.Peers))
+	}
+	if peer := details.Peers[0]; peer.ID != "peer" || peer.Headers == 0 || peer.Bodies == 0 || peer.Share != 1 {
+		t.Errorf("peer contribution mismatch: %+v", peer)
+	}
+}
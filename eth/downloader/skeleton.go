@@ -801,6 +801,7 @@ func (s *skeleton) executeTask(peer *peerConnection, req *headerRequest) {
 
 		headerReqTimer.Update(time.Since(start))
 		s.peers.rates.Update(peer.id, eth.BlockHeadersMsg, res.Time, len(headers))
+		peer.headers.Add(uint64(len(headers)))
 
 		// Cross validate the headers with the requests
 		switch {
@@ -30,7 +30,12 @@ import (
 	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/eth/crosscheck"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/headattest"
+	"github.com/ethereum/go-ethereum/eth/statereader"
+	"github.com/ethereum/go-ethereum/eth/watchdog"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/miner"
@@ -57,19 +62,31 @@ var Defaults = Config{
 	TransactionHistory: 2350000,
 	LogHistory:         2350000,
 	StateHistory:       params.FullImmutabilityThreshold,
+	ChainAuditConfig:   core.DefaultChainAuditConfig,
+	StateRentConfig:    core.DefaultStateRentConfig,
+	WatchdogConfig:     watchdog.DefaultConfig,
+	CrossCheckConfig:   crosscheck.DefaultConfig,
+	StateReaderConfig:  statereader.DefaultConfig,
 	DatabaseCache:      512,
 	TrieCleanCache:     154,
 	TrieDirtyCache:     256,
 	TrieTimeout:        60 * time.Minute,
 	SnapshotCache:      102,
+	CodeCache:          256,
 	FilterLogCacheSize: 32,
 	Miner:              miner.DefaultConfig,
 	TxPool:             legacypool.DefaultConfig,
 	BlobPool:           blobpool.DefaultConfig,
+	TxFetcher:          fetcher.DefaultTxFetcherConfig,
+	TxAnnounceSize:     4096,
+	AddressPolicyAudit: "address-policy-audit.log",
 	RPCGasCap:          50000000,
 	RPCEVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
 	RPCTxFeeCap:        1, // 1 ether
+	RPCReexecLimit:     4,
+	RPCReexecCache:     16,
+	InteropCacheTTL:    2 * time.Second,
 }
 
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
@@ -85,6 +102,14 @@ type Config struct {
 	NetworkId uint64
 	SyncMode  SyncMode
 
+	// SyncCheckpoint is an optional trusted block that snap sync anchors its
+	// initial pivot to. Beacon chains not containing it are refused.
+	SyncCheckpoint *SyncCheckpoint `toml:",omitempty"`
+
+	// SyncPivot selects how the snap sync pivot block is chosen, defaulting to
+	// a fixed distance below the chain head if unset.
+	SyncPivot *SyncPivot `toml:",omitempty"`
+
 	// HistoryMode configures chain history retention.
 	HistoryMode history.HistoryMode
 
@@ -93,6 +118,10 @@ type Config struct {
 	EthDiscoveryURLs  []string
 	SnapDiscoveryURLs []string
 
+	// DiscoveryTopic enables advertising and searching the nodes of the chain
+	// under a discv5 topic derived from the chain ID and fork ID.
+	DiscoveryTopic bool `toml:",omitempty"`
+
 	// State options.
 	NoPruning  bool // Whether to disable pruning and flush everything to disk
 	NoPrefetch bool // Whether to disable prefetching and only load state on demand
@@ -111,6 +140,106 @@ type Config struct {
 	// consistent with persistent state.
 	StateScheme string `toml:",omitempty"`
 
+	// StateWriteAheadLog enables recording every state layer until it's flushed
+	// to disk, so that after a crash the layers can be replayed instead of
+	// rewinding to the persisted state and re-executing the blocks. Only
+	// relevant in path scheme.
+	StateWriteAheadLog bool `toml:",omitempty"`
+
+	// StateHistoryCompress enables writing the state histories deduplicated
+	// and delta encoded against the previous ones. Only relevant in path scheme.
+	StateHistoryCompress bool `toml:",omitempty"`
+
+	// LegacyStateDir is the directory of a read-only hash scheme database left
+	// by the migration of the node to the path scheme. The state of the blocks
+	// up to its head is read from it when the path scheme does not have it.
+	LegacyStateDir string `toml:",omitempty"`
+
+	// BadBlockArtifacts is the directory the RLP, the parent state availability
+	// and the re-execution trace of the blocks failing validation are captured
+	// in. Empty disables the capture.
+	BadBlockArtifacts string `toml:",omitempty"`
+
+	// State rent options. If enabled, the rent the touched accounts would be
+	// charged under the configured hypothetical scheme is accounted as the
+	// blocks are executed. It has no effect on consensus.
+	StateRent       bool                 `toml:",omitempty"`
+	StateRentConfig core.StateRentConfig `toml:",omitempty"`
+
+	// Sender transaction index options. If enabled, the transactions of the
+	// last SenderIndexHistory blocks (0 = all since enabling) are indexed by
+	// sender address.
+	SenderIndex        bool   `toml:",omitempty"`
+	SenderIndexHistory uint64 `toml:",omitempty"`
+
+	// Token transfer index options. If enabled, the ERC-20 and ERC-721 transfers
+	// of the last TransferIndexHistory blocks (0 = all since enabling) are
+	// indexed by sender and recipient address.
+	TransferIndex        bool   `toml:",omitempty"`
+	TransferIndexHistory uint64 `toml:",omitempty"`
+
+	// Internal transaction index options. If enabled, the value transfers,
+	// contract creations and self-destructs below the top level calls are
+	// traced when the blocks are processed, and the ones of the last
+	// InternalTxIndexHistory blocks (0 = all since enabling) are indexed by
+	// sender and recipient address.
+	InternalTxIndex        bool   `toml:",omitempty"`
+	InternalTxIndexHistory uint64 `toml:",omitempty"`
+
+	// Contract creation index option. If enabled, the creation transaction,
+	// creator and block of every contract are indexed by address. The internal
+	// transactions and CREATE2 salts are traced and stored for the creations
+	// below the top level calls, which are only indexed for the blocks
+	// processed since enabling.
+	CreationIndex bool `toml:",omitempty"`
+
+	// Code change index options. If enabled, the deployments, self-destructs,
+	// EIP-7702 delegations and other code replacements are traced when the
+	// blocks are processed, and the ones of the last CodeChangeIndexHistory
+	// blocks (0 = all since enabling) are indexed by account.
+	CodeChangeIndex        bool   `toml:",omitempty"`
+	CodeChangeIndexHistory uint64 `toml:",omitempty"`
+
+	// BalanceChanges enables recording the balance and nonce changes of every
+	// processed block, retrievable via debug_getBalanceChanges.
+	BalanceChanges bool `toml:",omitempty"`
+
+	// Supply enables tracking the native token supply changes of every
+	// processed block, retrievable via debug_totalSupply and the metrics.
+	Supply bool `toml:",omitempty"`
+
+	// Chain auditor options. If enabled, the stored chain data in the configured
+	// range is periodically re-verified in the background.
+	ChainAudit       bool                  `toml:",omitempty"`
+	ChainAuditConfig core.ChainAuditConfig `toml:",omitempty"`
+
+	// Head attestation options. If enabled, the node tracks the chain head from
+	// headers signed by a quorum of the configured keys and serves it over the
+	// attest RPC namespace, labelled with its local execution status.
+	HeadAttest       bool              `toml:",omitempty"`
+	HeadAttestConfig headattest.Config `toml:",omitempty"`
+
+	// Chain head watchdog options. If enabled, the head is periodically compared
+	// against the reference nodes or the peers, and a stalled head is recovered
+	// by rotating peers and restarting the sync.
+	Watchdog       bool            `toml:",omitempty"`
+	WatchdogConfig watchdog.Config `toml:",omitempty"`
+
+	// Execution cross-check options. If enabled, the state and receipts roots of
+	// every imported block are compared against the configured reference node,
+	// and the chain is optionally halted on divergence.
+	CrossCheck       bool              `toml:",omitempty"`
+	CrossCheckConfig crosscheck.Config `toml:",omitempty"`
+
+	// State reader options. If enabled, consistent snapshots of the state are
+	// served to external processes on a dedicated IPC endpoint.
+	StateReader       bool               `toml:",omitempty"`
+	StateReaderConfig statereader.Config `toml:",omitempty"`
+
+	// ContractABISourcify is the URL of the Sourcify server the contract ABIs
+	// are fetched from by admin_fetchContractABI, empty to disable lookups.
+	ContractABISourcify string `toml:",omitempty"`
+
 	// RequiredBlocks is a set of block number -> hash mappings which must be in the
 	// canonical chain of all remote peers. Setting the option makes geth verify the
 	// presence of these blocks for every new peer connection.
@@ -126,6 +255,9 @@ type Config struct {
 	TrieDirtyCache int
 	TrieTimeout    time.Duration
 	SnapshotCache  int
+	CodeCache      int  // Megabytes of contract code cached, shared by all state readers
+	AdaptiveCache  bool // Whether to repartition the trie, snapshot and code caches at runtime
+	MemoryLimit    int  // Megabytes of memory the node sheds load to stay under, zero if unlimited
 	Preimages      bool
 
 	// This is the number of blocks for which logs will be cached in the filter system.
@@ -134,10 +266,36 @@ type Config struct {
 	// Mining options
 	Miner miner.Config
 
+	// Standby makes the node follow the chain and keep its transaction pool
+	// warm, without building payloads or gossiping transactions until it is
+	// promoted through admin_promote.
+	Standby bool `toml:",omitempty"`
+
 	// Transaction pool options
 	TxPool   legacypool.Config
 	BlobPool blobpool.Config
 
+	// TxPoolReconcile enables the reconciliation of the pending transactions
+	// with the trusted peers over the `txrec` protocol, at the given interval.
+	TxPoolReconcile time.Duration `toml:",omitempty"`
+
+	// Transaction propagation options. Transactions larger than TxAnnounceSize
+	// are only ever announced to the peers, never broadcast in full.
+	TxFetcher      fetcher.TxFetcherConfig
+	TxAnnounceSize uint64
+
+	// SlimBlocks enables exchanging blocks with the trusted peers without the
+	// fields they can derive, negotiated over the `slim` capability.
+	SlimBlocks bool `toml:",omitempty"`
+
+	// AddressPolicy enables refusing the transactions from or to the addresses
+	// in AddressPolicyList ("block") or any other address ("allow"), both from
+	// the transaction pool and from built blocks. Enforcement is recorded in
+	// the audit log at AddressPolicyAudit, signed with the node key.
+	AddressPolicy      string           `toml:",omitempty"`
+	AddressPolicyList  []common.Address `toml:",omitempty"`
+	AddressPolicyAudit string           `toml:",omitempty"`
+
 	// Gas Price Oracle options
 	GPO gasprice.Config
 
@@ -148,12 +306,31 @@ type Config struct {
 	VMTrace           string
 	VMTraceJsonConfig string
 
+	// Enables the experimental analyzed contract execution, optionally shadowed
+	// by the plain execution to cross-check it
+	VMCodeAnalysis       bool `toml:",omitempty"`
+	VMCodeAnalysisShadow bool `toml:",omitempty"`
+
 	// RPCGasCap is the global gas cap for eth-call variants.
 	RPCGasCap uint64
 
 	// RPCEVMTimeout is the global timeout for eth-call.
 	RPCEVMTimeout time.Duration
 
+	// RPCPrefetchLearn enables learning the access lists of eth_call requests
+	// per called contract and method, to prefetch them for later calls.
+	RPCPrefetchLearn bool `toml:",omitempty"`
+
+	// RPCReexecLimit is the number of historical states the tracing APIs may
+	// regenerate concurrently, and RPCReexecCache the number of regenerated
+	// states kept around for later requests.
+	RPCReexecLimit int
+	RPCReexecCache int
+
+	// RPCOverlays is the maximum number of ephemeral overlay chains kept alive
+	// for the overlay API, zero disabling the API.
+	RPCOverlays int `toml:",omitempty"`
+
 	// RPCTxFeeCap is the global transaction fee(price * gaslimit) cap for
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
@@ -192,8 +369,10 @@ type Config struct {
 	RollupDisableTxPoolAdmission              bool
 	RollupHaltOnIncompatibleProtocolVersion   string
 
-	InteropMessageRPC       string `toml:",omitempty"`
-	InteropMempoolFiltering bool   `toml:",omitempty"`
+	InteropMessageRPC       string        `toml:",omitempty"`
+	InteropMempoolFiltering bool          `toml:",omitempty"`
+	InteropFailurePolicy    string        `toml:",omitempty"` // Outcome of the unreachable supervisor checks, "strict" (default) or "optimistic"
+	InteropCacheTTL         time.Duration `toml:",omitempty"` // Lifetime of the cached supervisor verdicts, zero disables caching
 }
 
 // CreateConsensusEngine creates a consensus engine for the given chain config.
@@ -10,7 +10,12 @@ import (
 	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/eth/crosscheck"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/headattest"
+	"github.com/ethereum/go-ethereum/eth/statereader"
+	"github.com/ethereum/go-ethereum/eth/watchdog"
 	"github.com/ethereum/go-ethereum/miner"
 )
 
@@ -20,9 +25,12 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Genesis                                   *core.Genesis `toml:",omitempty"`
 		NetworkId                                 uint64
 		SyncMode                                  SyncMode
+		SyncCheckpoint                            *SyncCheckpoint `toml:",omitempty"`
+		SyncPivot                                 *SyncPivot      `toml:",omitempty"`
 		HistoryMode                               history.HistoryMode
 		EthDiscoveryURLs                          []string
 		SnapDiscoveryURLs                         []string
+		DiscoveryTopic                            bool `toml:",omitempty"`
 		NoPruning                                 bool
 		NoPrefetch                                bool
 		TxLookupLimit                             uint64 `toml:",omitempty"`
@@ -32,6 +40,34 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		LogExportCheckpoints                      string
 		StateHistory                              uint64                 `toml:",omitempty"`
 		StateScheme                               string                 `toml:",omitempty"`
+		StateWriteAheadLog                        bool                   `toml:",omitempty"`
+		StateHistoryCompress                      bool                   `toml:",omitempty"`
+		LegacyStateDir                            string                 `toml:",omitempty"`
+		BadBlockArtifacts                         string                 `toml:",omitempty"`
+		StateRent                                 bool                   `toml:",omitempty"`
+		StateRentConfig                           core.StateRentConfig   `toml:",omitempty"`
+		SenderIndex                               bool                   `toml:",omitempty"`
+		SenderIndexHistory                        uint64                 `toml:",omitempty"`
+		TransferIndex                             bool                   `toml:",omitempty"`
+		TransferIndexHistory                      uint64                 `toml:",omitempty"`
+		InternalTxIndex                           bool                   `toml:",omitempty"`
+		InternalTxIndexHistory                    uint64                 `toml:",omitempty"`
+		CreationIndex                             bool                   `toml:",omitempty"`
+		CodeChangeIndex                           bool                   `toml:",omitempty"`
+		CodeChangeIndexHistory                    uint64                 `toml:",omitempty"`
+		BalanceChanges                            bool                   `toml:",omitempty"`
+		Supply                                    bool                   `toml:",omitempty"`
+		ChainAudit                                bool                   `toml:",omitempty"`
+		ChainAuditConfig                          core.ChainAuditConfig  `toml:",omitempty"`
+		HeadAttest                                bool                   `toml:",omitempty"`
+		HeadAttestConfig                          headattest.Config      `toml:",omitempty"`
+		Watchdog                                  bool                   `toml:",omitempty"`
+		WatchdogConfig                            watchdog.Config        `toml:",omitempty"`
+		CrossCheck                                bool                   `toml:",omitempty"`
+		CrossCheckConfig                          crosscheck.Config      `toml:",omitempty"`
+		StateReader                               bool                   `toml:",omitempty"`
+		StateReaderConfig                         statereader.Config     `toml:",omitempty"`
+		ContractABISourcify                       string                 `toml:",omitempty"`
 		RequiredBlocks                            map[uint64]common.Hash `toml:"-"`
 		SkipBcVersionCheck                        bool                   `toml:"-"`
 		DatabaseHandles                           int                    `toml:"-"`
@@ -41,17 +77,34 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		TrieDirtyCache                            int
 		TrieTimeout                               time.Duration
 		SnapshotCache                             int
+		CodeCache                                 int
+		AdaptiveCache                             bool
+		MemoryLimit                               int
 		Preimages                                 bool
 		FilterLogCacheSize                        int
 		Miner                                     miner.Config
+		Standby                                   bool `toml:",omitempty"`
 		TxPool                                    legacypool.Config
 		BlobPool                                  blobpool.Config
+		TxPoolReconcile                           time.Duration `toml:",omitempty"`
+		TxFetcher                                 fetcher.TxFetcherConfig
+		TxAnnounceSize                            uint64
+		SlimBlocks                                bool             `toml:",omitempty"`
+		AddressPolicy                             string           `toml:",omitempty"`
+		AddressPolicyList                         []common.Address `toml:",omitempty"`
+		AddressPolicyAudit                        string           `toml:",omitempty"`
 		GPO                                       gasprice.Config
 		EnablePreimageRecording                   bool
 		VMTrace                                   string
 		VMTraceJsonConfig                         string
+		VMCodeAnalysis                            bool `toml:",omitempty"`
+		VMCodeAnalysisShadow                      bool `toml:",omitempty"`
 		RPCGasCap                                 uint64
 		RPCEVMTimeout                             time.Duration
+		RPCPrefetchLearn                          bool `toml:",omitempty"`
+		RPCReexecLimit                            int
+		RPCReexecCache                            int
+		RPCOverlays                               int `toml:",omitempty"`
 		RPCTxFeeCap                               float64
 		OverridePrague                            *uint64 `toml:",omitempty"`
 		OverrideVerkle                            *uint64 `toml:",omitempty"`
@@ -72,16 +125,21 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RollupDisableTxPoolGossip                 bool
 		RollupDisableTxPoolAdmission              bool
 		RollupHaltOnIncompatibleProtocolVersion   string
-		InteropMessageRPC                         string `toml:",omitempty"`
-		InteropMempoolFiltering                   bool   `toml:",omitempty"`
+		InteropMessageRPC                         string        `toml:",omitempty"`
+		InteropMempoolFiltering                   bool          `toml:",omitempty"`
+		InteropFailurePolicy                      string        `toml:",omitempty"`
+		InteropCacheTTL                           time.Duration `toml:",omitempty"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
 	enc.NetworkId = c.NetworkId
 	enc.SyncMode = c.SyncMode
+	enc.SyncCheckpoint = c.SyncCheckpoint
+	enc.SyncPivot = c.SyncPivot
 	enc.HistoryMode = c.HistoryMode
 	enc.EthDiscoveryURLs = c.EthDiscoveryURLs
 	enc.SnapDiscoveryURLs = c.SnapDiscoveryURLs
+	enc.DiscoveryTopic = c.DiscoveryTopic
 	enc.NoPruning = c.NoPruning
 	enc.NoPrefetch = c.NoPrefetch
 	enc.TxLookupLimit = c.TxLookupLimit
@@ -91,6 +149,34 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.LogExportCheckpoints = c.LogExportCheckpoints
 	enc.StateHistory = c.StateHistory
 	enc.StateScheme = c.StateScheme
+	enc.StateWriteAheadLog = c.StateWriteAheadLog
+	enc.StateHistoryCompress = c.StateHistoryCompress
+	enc.LegacyStateDir = c.LegacyStateDir
+	enc.BadBlockArtifacts = c.BadBlockArtifacts
+	enc.StateRent = c.StateRent
+	enc.StateRentConfig = c.StateRentConfig
+	enc.SenderIndex = c.SenderIndex
+	enc.SenderIndexHistory = c.SenderIndexHistory
+	enc.TransferIndex = c.TransferIndex
+	enc.TransferIndexHistory = c.TransferIndexHistory
+	enc.InternalTxIndex = c.InternalTxIndex
+	enc.InternalTxIndexHistory = c.InternalTxIndexHistory
+	enc.CreationIndex = c.CreationIndex
+	enc.CodeChangeIndex = c.CodeChangeIndex
+	enc.CodeChangeIndexHistory = c.CodeChangeIndexHistory
+	enc.BalanceChanges = c.BalanceChanges
+	enc.Supply = c.Supply
+	enc.ChainAudit = c.ChainAudit
+	enc.ChainAuditConfig = c.ChainAuditConfig
+	enc.HeadAttest = c.HeadAttest
+	enc.HeadAttestConfig = c.HeadAttestConfig
+	enc.Watchdog = c.Watchdog
+	enc.WatchdogConfig = c.WatchdogConfig
+	enc.CrossCheck = c.CrossCheck
+	enc.CrossCheckConfig = c.CrossCheckConfig
+	enc.StateReader = c.StateReader
+	enc.StateReaderConfig = c.StateReaderConfig
+	enc.ContractABISourcify = c.ContractABISourcify
 	enc.RequiredBlocks = c.RequiredBlocks
 	enc.SkipBcVersionCheck = c.SkipBcVersionCheck
 	enc.DatabaseHandles = c.DatabaseHandles
@@ -100,17 +186,34 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.TrieDirtyCache = c.TrieDirtyCache
 	enc.TrieTimeout = c.TrieTimeout
 	enc.SnapshotCache = c.SnapshotCache
+	enc.CodeCache = c.CodeCache
+	enc.AdaptiveCache = c.AdaptiveCache
+	enc.MemoryLimit = c.MemoryLimit
 	enc.Preimages = c.Preimages
 	enc.FilterLogCacheSize = c.FilterLogCacheSize
 	enc.Miner = c.Miner
+	enc.Standby = c.Standby
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.TxPoolReconcile = c.TxPoolReconcile
+	enc.TxFetcher = c.TxFetcher
+	enc.TxAnnounceSize = c.TxAnnounceSize
+	enc.SlimBlocks = c.SlimBlocks
+	enc.AddressPolicy = c.AddressPolicy
+	enc.AddressPolicyList = c.AddressPolicyList
+	enc.AddressPolicyAudit = c.AddressPolicyAudit
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.VMTrace = c.VMTrace
 	enc.VMTraceJsonConfig = c.VMTraceJsonConfig
+	enc.VMCodeAnalysis = c.VMCodeAnalysis
+	enc.VMCodeAnalysisShadow = c.VMCodeAnalysisShadow
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
+	enc.RPCPrefetchLearn = c.RPCPrefetchLearn
+	enc.RPCReexecLimit = c.RPCReexecLimit
+	enc.RPCReexecCache = c.RPCReexecCache
+	enc.RPCOverlays = c.RPCOverlays
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
 	enc.OverridePrague = c.OverridePrague
 	enc.OverrideVerkle = c.OverrideVerkle
@@ -133,6 +236,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RollupHaltOnIncompatibleProtocolVersion = c.RollupHaltOnIncompatibleProtocolVersion
 	enc.InteropMessageRPC = c.InteropMessageRPC
 	enc.InteropMempoolFiltering = c.InteropMempoolFiltering
+	enc.InteropFailurePolicy = c.InteropFailurePolicy
+	enc.InteropCacheTTL = c.InteropCacheTTL
 	return &enc, nil
 }
 
@@ -142,9 +247,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Genesis                                   *core.Genesis `toml:",omitempty"`
 		NetworkId                                 *uint64
 		SyncMode                                  *SyncMode
+		SyncCheckpoint                            *SyncCheckpoint `toml:",omitempty"`
+		SyncPivot                                 *SyncPivot      `toml:",omitempty"`
 		HistoryMode                               *history.HistoryMode
 		EthDiscoveryURLs                          []string
 		SnapDiscoveryURLs                         []string
+		DiscoveryTopic                            *bool `toml:",omitempty"`
 		NoPruning                                 *bool
 		NoPrefetch                                *bool
 		TxLookupLimit                             *uint64 `toml:",omitempty"`
@@ -154,6 +262,34 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		LogExportCheckpoints                      *string
 		StateHistory                              *uint64                `toml:",omitempty"`
 		StateScheme                               *string                `toml:",omitempty"`
+		StateWriteAheadLog                        *bool                  `toml:",omitempty"`
+		StateHistoryCompress                      *bool                  `toml:",omitempty"`
+		LegacyStateDir                            *string                `toml:",omitempty"`
+		BadBlockArtifacts                         *string                `toml:",omitempty"`
+		StateRent                                 *bool                  `toml:",omitempty"`
+		StateRentConfig                           *core.StateRentConfig  `toml:",omitempty"`
+		SenderIndex                               *bool                  `toml:",omitempty"`
+		SenderIndexHistory                        *uint64                `toml:",omitempty"`
+		TransferIndex                             *bool                  `toml:",omitempty"`
+		TransferIndexHistory                      *uint64                `toml:",omitempty"`
+		InternalTxIndex                           *bool                  `toml:",omitempty"`
+		InternalTxIndexHistory                    *uint64                `toml:",omitempty"`
+		CreationIndex                             *bool                  `toml:",omitempty"`
+		CodeChangeIndex                           *bool                  `toml:",omitempty"`
+		CodeChangeIndexHistory                    *uint64                `toml:",omitempty"`
+		BalanceChanges                            *bool                  `toml:",omitempty"`
+		Supply                                    *bool                  `toml:",omitempty"`
+		ChainAudit                                *bool                  `toml:",omitempty"`
+		ChainAuditConfig                          *core.ChainAuditConfig `toml:",omitempty"`
+		HeadAttest                                *bool                  `toml:",omitempty"`
+		HeadAttestConfig                          *headattest.Config     `toml:",omitempty"`
+		Watchdog                                  *bool                  `toml:",omitempty"`
+		WatchdogConfig                            *watchdog.Config       `toml:",omitempty"`
+		CrossCheck                                *bool                  `toml:",omitempty"`
+		CrossCheckConfig                          *crosscheck.Config     `toml:",omitempty"`
+		StateReader                               *bool                  `toml:",omitempty"`
+		StateReaderConfig                         *statereader.Config    `toml:",omitempty"`
+		ContractABISourcify                       *string                `toml:",omitempty"`
 		RequiredBlocks                            map[uint64]common.Hash `toml:"-"`
 		SkipBcVersionCheck                        *bool                  `toml:"-"`
 		DatabaseHandles                           *int                   `toml:"-"`
@@ -163,17 +299,34 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		TrieDirtyCache                            *int
 		TrieTimeout                               *time.Duration
 		SnapshotCache                             *int
+		CodeCache                                 *int
+		AdaptiveCache                             *bool
+		MemoryLimit                               *int
 		Preimages                                 *bool
 		FilterLogCacheSize                        *int
 		Miner                                     *miner.Config
+		Standby                                   *bool `toml:",omitempty"`
 		TxPool                                    *legacypool.Config
 		BlobPool                                  *blobpool.Config
+		TxPoolReconcile                           *time.Duration `toml:",omitempty"`
+		TxFetcher                                 *fetcher.TxFetcherConfig
+		TxAnnounceSize                            *uint64
+		SlimBlocks                                *bool            `toml:",omitempty"`
+		AddressPolicy                             *string          `toml:",omitempty"`
+		AddressPolicyList                         []common.Address `toml:",omitempty"`
+		AddressPolicyAudit                        *string          `toml:",omitempty"`
 		GPO                                       *gasprice.Config
 		EnablePreimageRecording                   *bool
 		VMTrace                                   *string
 		VMTraceJsonConfig                         *string
+		VMCodeAnalysis                            *bool `toml:",omitempty"`
+		VMCodeAnalysisShadow                      *bool `toml:",omitempty"`
 		RPCGasCap                                 *uint64
 		RPCEVMTimeout                             *time.Duration
+		RPCPrefetchLearn                          *bool `toml:",omitempty"`
+		RPCReexecLimit                            *int
+		RPCReexecCache                            *int
+		RPCOverlays                               *int `toml:",omitempty"`
 		RPCTxFeeCap                               *float64
 		OverridePrague                            *uint64 `toml:",omitempty"`
 		OverrideVerkle                            *uint64 `toml:",omitempty"`
@@ -194,8 +347,10 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RollupDisableTxPoolGossip                 *bool
 		RollupDisableTxPoolAdmission              *bool
 		RollupHaltOnIncompatibleProtocolVersion   *string
-		InteropMessageRPC                         *string `toml:",omitempty"`
-		InteropMempoolFiltering                   *bool   `toml:",omitempty"`
+		InteropMessageRPC                         *string        `toml:",omitempty"`
+		InteropMempoolFiltering                   *bool          `toml:",omitempty"`
+		InteropFailurePolicy                      *string        `toml:",omitempty"`
+		InteropCacheTTL                           *time.Duration `toml:",omitempty"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -210,6 +365,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SyncMode != nil {
 		c.SyncMode = *dec.SyncMode
 	}
+	if dec.SyncCheckpoint != nil {
+		c.SyncCheckpoint = dec.SyncCheckpoint
+	}
+	if dec.SyncPivot != nil {
+		c.SyncPivot = dec.SyncPivot
+	}
 	if dec.HistoryMode != nil {
 		c.HistoryMode = *dec.HistoryMode
 	}
@@ -219,6 +380,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SnapDiscoveryURLs != nil {
 		c.SnapDiscoveryURLs = dec.SnapDiscoveryURLs
 	}
+	if dec.DiscoveryTopic != nil {
+		c.DiscoveryTopic = *dec.DiscoveryTopic
+	}
 	if dec.NoPruning != nil {
 		c.NoPruning = *dec.NoPruning
 	}
@@ -246,6 +410,90 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.StateScheme != nil {
 		c.StateScheme = *dec.StateScheme
 	}
+	if dec.StateWriteAheadLog != nil {
+		c.StateWriteAheadLog = *dec.StateWriteAheadLog
+	}
+	if dec.StateHistoryCompress != nil {
+		c.StateHistoryCompress = *dec.StateHistoryCompress
+	}
+	if dec.LegacyStateDir != nil {
+		c.LegacyStateDir = *dec.LegacyStateDir
+	}
+	if dec.BadBlockArtifacts != nil {
+		c.BadBlockArtifacts = *dec.BadBlockArtifacts
+	}
+	if dec.StateRent != nil {
+		c.StateRent = *dec.StateRent
+	}
+	if dec.StateRentConfig != nil {
+		c.StateRentConfig = *dec.StateRentConfig
+	}
+	if dec.SenderIndex != nil {
+		c.SenderIndex = *dec.SenderIndex
+	}
+	if dec.SenderIndexHistory != nil {
+		c.SenderIndexHistory = *dec.SenderIndexHistory
+	}
+	if dec.TransferIndex != nil {
+		c.TransferIndex = *dec.TransferIndex
+	}
+	if dec.TransferIndexHistory != nil {
+		c.TransferIndexHistory = *dec.TransferIndexHistory
+	}
+	if dec.InternalTxIndex != nil {
+		c.InternalTxIndex = *dec.InternalTxIndex
+	}
+	if dec.InternalTxIndexHistory != nil {
+		c.InternalTxIndexHistory = *dec.InternalTxIndexHistory
+	}
+	if dec.CreationIndex != nil {
+		c.CreationIndex = *dec.CreationIndex
+	}
+	if dec.CodeChangeIndex != nil {
+		c.CodeChangeIndex = *dec.CodeChangeIndex
+	}
+	if dec.CodeChangeIndexHistory != nil {
+		c.CodeChangeIndexHistory = *dec.CodeChangeIndexHistory
+	}
+	if dec.BalanceChanges != nil {
+		c.BalanceChanges = *dec.BalanceChanges
+	}
+	if dec.Supply != nil {
+		c.Supply = *dec.Supply
+	}
+	if dec.ChainAudit != nil {
+		c.ChainAudit = *dec.ChainAudit
+	}
+	if dec.ChainAuditConfig != nil {
+		c.ChainAuditConfig = *dec.ChainAuditConfig
+	}
+	if dec.HeadAttest != nil {
+		c.HeadAttest = *dec.HeadAttest
+	}
+	if dec.HeadAttestConfig != nil {
+		c.HeadAttestConfig = *dec.HeadAttestConfig
+	}
+	if dec.Watchdog != nil {
+		c.Watchdog = *dec.Watchdog
+	}
+	if dec.WatchdogConfig != nil {
+		c.WatchdogConfig = *dec.WatchdogConfig
+	}
+	if dec.CrossCheck != nil {
+		c.CrossCheck = *dec.CrossCheck
+	}
+	if dec.CrossCheckConfig != nil {
+		c.CrossCheckConfig = *dec.CrossCheckConfig
+	}
+	if dec.StateReader != nil {
+		c.StateReader = *dec.StateReader
+	}
+	if dec.StateReaderConfig != nil {
+		c.StateReaderConfig = *dec.StateReaderConfig
+	}
+	if dec.ContractABISourcify != nil {
+		c.ContractABISourcify = *dec.ContractABISourcify
+	}
 	if dec.RequiredBlocks != nil {
 		c.RequiredBlocks = dec.RequiredBlocks
 	}
@@ -273,6 +521,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SnapshotCache != nil {
 		c.SnapshotCache = *dec.SnapshotCache
 	}
+	if dec.CodeCache != nil {
+		c.CodeCache = *dec.CodeCache
+	}
+	if dec.AdaptiveCache != nil {
+		c.AdaptiveCache = *dec.AdaptiveCache
+	}
+	if dec.MemoryLimit != nil {
+		c.MemoryLimit = *dec.MemoryLimit
+	}
 	if dec.Preimages != nil {
 		c.Preimages = *dec.Preimages
 	}
@@ -282,12 +539,36 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Miner != nil {
 		c.Miner = *dec.Miner
 	}
+	if dec.Standby != nil {
+		c.Standby = *dec.Standby
+	}
 	if dec.TxPool != nil {
 		c.TxPool = *dec.TxPool
 	}
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.TxPoolReconcile != nil {
+		c.TxPoolReconcile = *dec.TxPoolReconcile
+	}
+	if dec.TxFetcher != nil {
+		c.TxFetcher = *dec.TxFetcher
+	}
+	if dec.TxAnnounceSize != nil {
+		c.TxAnnounceSize = *dec.TxAnnounceSize
+	}
+	if dec.SlimBlocks != nil {
+		c.SlimBlocks = *dec.SlimBlocks
+	}
+	if dec.AddressPolicy != nil {
+		c.AddressPolicy = *dec.AddressPolicy
+	}
+	if dec.AddressPolicyList != nil {
+		c.AddressPolicyList = dec.AddressPolicyList
+	}
+	if dec.AddressPolicyAudit != nil {
+		c.AddressPolicyAudit = *dec.AddressPolicyAudit
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
@@ -300,12 +581,30 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.VMTraceJsonConfig != nil {
 		c.VMTraceJsonConfig = *dec.VMTraceJsonConfig
 	}
+	if dec.VMCodeAnalysis != nil {
+		c.VMCodeAnalysis = *dec.VMCodeAnalysis
+	}
+	if dec.VMCodeAnalysisShadow != nil {
+		c.VMCodeAnalysisShadow = *dec.VMCodeAnalysisShadow
+	}
 	if dec.RPCGasCap != nil {
 		c.RPCGasCap = *dec.RPCGasCap
 	}
 	if dec.RPCEVMTimeout != nil {
 		c.RPCEVMTimeout = *dec.RPCEVMTimeout
 	}
+	if dec.RPCPrefetchLearn != nil {
+		c.RPCPrefetchLearn = *dec.RPCPrefetchLearn
+	}
+	if dec.RPCReexecLimit != nil {
+		c.RPCReexecLimit = *dec.RPCReexecLimit
+	}
+	if dec.RPCReexecCache != nil {
+		c.RPCReexecCache = *dec.RPCReexecCache
+	}
+	if dec.RPCOverlays != nil {
+		c.RPCOverlays = *dec.RPCOverlays
+	}
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
@@ -372,5 +671,11 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.InteropMempoolFiltering != nil {
 		c.InteropMempoolFiltering = *dec.InteropMempoolFiltering
 	}
+	if dec.InteropFailurePolicy != nil {
+		c.InteropFailurePolicy = *dec.InteropFailurePolicy
+	}
+	if dec.InteropCacheTTL != nil {
+		c.InteropCacheTTL = *dec.InteropCacheTTL
+	}
 	return nil
 }
@@ -16,7 +16,13 @@
 
 package ethconfig
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 // SyncMode represents the synchronisation mode of the downloader.
 // It is a uint32 as it is used with atomic operations.
@@ -65,3 +71,119 @@ func (mode *SyncMode) UnmarshalText(text []byte) error {
 	}
 	return nil
 }
+
+// SyncCheckpoint is an operator-provided trusted block ("weak subjectivity
+// checkpoint") that the snap sync anchors its initial pivot to. Its text form
+// is "number:hash:root".
+//
+// The checkpoint only pins the pivot and rejects conflicting beacon chains;
+// headers below it are still downloaded by the skeleton syncer up front, as
+// starting the chain from a non-genesis block is not supported. Skipping them
+// and backfilling them in the background is a pending follow-up.
+type SyncCheckpoint struct {
+	Number uint64      // Block number of the trusted block
+	Hash   common.Hash // Block hash of the trusted block
+	Root   common.Hash // State root of the trusted block
+}
+
+// String implements the stringer interface.
+func (cp SyncCheckpoint) String() string {
+	return fmt.Sprintf("%d:%s:%s", cp.Number, cp.Hash.Hex(), cp.Root.Hex())
+}
+
+func (cp SyncCheckpoint) MarshalText() ([]byte, error) {
+	return []byte(cp.String()), nil
+}
+
+func (cp *SyncCheckpoint) UnmarshalText(text []byte) error {
+	parts := strings.Split(string(text), ":")
+	if len(parts) != 3 {
+		return fmt.Errorf(`invalid sync checkpoint %q, want "number:hash:root"`, text)
+	}
+	number, err := strconv.ParseUint(parts[0], 0, 64)
+	if err != nil {
+		return fmt.Errorf("invalid sync checkpoint number %q: %v", parts[0], err)
+	}
+	var hash, root common.Hash
+	if err := hash.UnmarshalText([]byte(parts[1])); err != nil {
+		return fmt.Errorf("invalid sync checkpoint hash %q: %v", parts[1], err)
+	}
+	if err := root.UnmarshalText([]byte(parts[2])); err != nil {
+		return fmt.Errorf("invalid sync checkpoint root %q: %v", parts[2], err)
+	}
+	*cp = SyncCheckpoint{Number: number, Hash: hash, Root: root}
+	return nil
+}
+
+// PivotStrategy defines how the snap sync pivot block is selected.
+type PivotStrategy uint32
+
+const (
+	PivotLatest    PivotStrategy = iota // Pivot a fixed number of blocks below the chain head
+	PivotFinalized                      // Pivot at the latest finalized block
+	PivotOffset                         // Pivot an operator chosen number of blocks below the chain head
+	PivotPinned                         // Pivot at an operator chosen block
+)
+
+// SyncPivot is the operator-selected strategy for choosing the snap sync pivot.
+// Its text form is one of "latest", "finalized", "offset:<blocks>" or
+// "pinned:<hash>".
+type SyncPivot struct {
+	Strategy PivotStrategy // Strategy used to select the pivot block
+	Offset   uint64        // Distance of the pivot from the chain head, only for PivotOffset
+	Hash     common.Hash   // Hash of the pivot block, only for PivotPinned
+}
+
+// String implements the stringer interface.
+func (p SyncPivot) String() string {
+	switch p.Strategy {
+	case PivotLatest:
+		return "latest"
+	case PivotFinalized:
+		return "finalized"
+	case PivotOffset:
+		return fmt.Sprintf("offset:%d", p.Offset)
+	case PivotPinned:
+		return fmt.Sprintf("pinned:%s", p.Hash.Hex())
+	default:
+		return "unknown"
+	}
+}
+
+func (p SyncPivot) MarshalText() ([]byte, error) {
+	if p.Strategy > PivotPinned {
+		return nil, fmt.Errorf("unknown sync pivot strategy %d", p.Strategy)
+	}
+	return []byte(p.String()), nil
+}
+
+func (p *SyncPivot) UnmarshalText(text []byte) error {
+	name, arg, hasArg := strings.Cut(string(text), ":")
+	switch name {
+	case "latest":
+		if hasArg {
+			return fmt.Errorf("sync pivot %q takes no argument", name)
+		}
+		*p = SyncPivot{Strategy: PivotLatest}
+	case "finalized":
+		if hasArg {
+			return fmt.Errorf("sync pivot %q takes no argument", name)
+		}
+		*p = SyncPivot{Strategy: PivotFinalized}
+	case "offset":
+		offset, err := strconv.ParseUint(arg, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid sync pivot offset %q: %v", arg, err)
+		}
+		*p = SyncPivot{Strategy: PivotOffset, Offset: offset}
+	case "pinned":
+		var hash common.Hash
+		if err := hash.UnmarshalText([]byte(arg)); err != nil {
+			return fmt.Errorf("invalid sync pivot hash %q: %v", arg, err)
+		}
+		*p = SyncPivot{Strategy: PivotPinned, Hash: hash}
+	default:
+		return fmt.Errorf(`unknown sync pivot %q, want "latest", "finalized", "offset:<blocks>" or "pinned:<hash>"`, text)
+	}
+	return nil
+}
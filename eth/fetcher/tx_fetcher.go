@@ -80,6 +80,36 @@ var (
 	txFetchTimeout = 5 * time.Second
 )
 
+// TxFetcherConfig contains the tunables of the transaction fetcher.
+type TxFetcherConfig struct {
+	ArriveTimeout    time.Duration // Time allowance for a broadcast before an announced transaction is requested
+	MaxRetrievals    int           // Maximum number of transactions to request in one go
+	MaxRetrievalSize uint64        // Maximum announced size of the transactions requested in one go
+	MaxRequests      int           // Maximum number of concurrent requests across all peers (0 = one per peer)
+}
+
+// DefaultTxFetcherConfig contains the default transaction fetcher tunables.
+var DefaultTxFetcherConfig = TxFetcherConfig{
+	ArriveTimeout:    txArriveTimeout,
+	MaxRetrievals:    maxTxRetrievals,
+	MaxRetrievalSize: maxTxRetrievalSize,
+}
+
+// validate checks the tunables for values the fetcher cannot operate with.
+func (c TxFetcherConfig) validate() error {
+	switch {
+	case c.ArriveTimeout < 0:
+		return fmt.Errorf("negative arrival timeout %v", c.ArriveTimeout)
+	case c.MaxRetrievals <= 0 || c.MaxRetrievals > maxTxAnnounces:
+		return fmt.Errorf("retrieval count %d out of range [1, %d]", c.MaxRetrievals, maxTxAnnounces)
+	case c.MaxRetrievalSize == 0:
+		return errors.New("zero retrieval size")
+	case c.MaxRequests < 0:
+		return fmt.Errorf("negative request concurrency %d", c.MaxRequests)
+	}
+	return nil
+}
+
 var (
 	txAnnounceInMeter          = metrics.NewRegisteredMeter("eth/fetcher/transaction/announces/in", nil)
 	txAnnounceKnownMeter       = metrics.NewRegisteredMeter("eth/fetcher/transaction/announces/known", nil)
@@ -101,6 +131,9 @@ var (
 	txReplyUnderpricedMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/underpriced", nil)
 	txReplyOtherRejectMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/otherreject", nil)
 
+	txBroadcastWastedMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/broadcasts/wasted", nil)
+	txReplyWastedMeter     = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/wasted", nil)
+
 	txFetcherWaitingPeers   = metrics.NewRegisteredGauge("eth/fetcher/transaction/waiting/peers", nil)
 	txFetcherWaitingHashes  = metrics.NewRegisteredGauge("eth/fetcher/transaction/waiting/hashes", nil)
 	txFetcherQueueingPeers  = metrics.NewRegisteredGauge("eth/fetcher/transaction/queueing/peers", nil)
@@ -178,6 +211,7 @@ type TxFetcher struct {
 	drop    chan *txDrop
 	quit    chan struct{}
 
+	config      TxFetcherConfig                    // Tunables of the fetcher, not to be changed after start
 	txSeq       uint64                             // Unique transaction sequence number
 	underpriced *lru.Cache[common.Hash, time.Time] // Transactions discarded as too cheap (don't re-fetch)
 
@@ -236,6 +270,7 @@ func NewTxFetcherForTests(
 		requests:    make(map[string]*txRequest),
 		alternates:  make(map[common.Hash]map[string]struct{}),
 		underpriced: lru.NewCache[common.Hash, time.Time](maxTxUnderpricedSetSize),
+		config:      DefaultTxFetcherConfig,
 		hasTx:       hasTx,
 		addTxs:      addTxs,
 		fetchTxs:    fetchTxs,
@@ -246,6 +281,16 @@ func NewTxFetcherForTests(
 	}
 }
 
+// SetConfig overrides the tunables of the fetcher. It must be called before the
+// fetcher is started.
+func (f *TxFetcher) SetConfig(config TxFetcherConfig) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+	f.config = config
+	return nil
+}
+
 // Notify announces the fetcher of the potential availability of a new batch of
 // transactions in the network.
 func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []common.Hash) error {
@@ -315,12 +360,14 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 		knownMeter       = txReplyKnownMeter
 		underpricedMeter = txReplyUnderpricedMeter
 		otherRejectMeter = txReplyOtherRejectMeter
+		wastedMeter      = txReplyWastedMeter
 	)
 	if !direct {
 		inMeter = txBroadcastInMeter
 		knownMeter = txBroadcastKnownMeter
 		underpricedMeter = txBroadcastUnderpricedMeter
 		otherRejectMeter = txBroadcastOtherRejectMeter
+		wastedMeter = txBroadcastWastedMeter
 	}
 	// Keep track of all the propagated transactions
 	inMeter.Mark(int64(len(txs)))
@@ -341,6 +388,7 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 			duplicate   int64
 			underpriced int64
 			otherreject int64
+			wasted      int64 // Bytes of the transactions we already had
 		)
 		batch := txs[i:end]
 
@@ -357,6 +405,7 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 
 			case errors.Is(err, txpool.ErrAlreadyKnown):
 				duplicate++
+				wasted += int64(batch[j].Size())
 
 			case errors.Is(err, txpool.ErrUnderpriced) || errors.Is(err, txpool.ErrReplaceUnderpriced) || errors.Is(err, txpool.ErrTxGasPriceTooLow):
 				underpriced++
@@ -373,6 +422,7 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 		knownMeter.Mark(duplicate)
 		underpricedMeter.Mark(underpriced)
 		otherRejectMeter.Mark(otherreject)
+		wastedMeter.Mark(wasted)
 
 		// If 'other reject' is >25% of the deliveries in any batch, sleep a bit.
 		if otherreject > addTxsBatchSize/4 {
@@ -536,7 +586,7 @@ func (f *TxFetcher) loop() {
 					f.waittime[hash] = f.clock.Now()
 				} else {
 					hasBlob = true
-					f.waittime[hash] = f.clock.Now() - mclock.AbsTime(txArriveTimeout)
+					f.waittime[hash] = f.clock.Now() - mclock.AbsTime(f.config.ArriveTimeout)
 				}
 				if waitslots := f.waitslots[ann.origin]; waitslots != nil {
 					waitslots[hash] = &txMetadataWithSeq{
@@ -567,7 +617,7 @@ func (f *TxFetcher) loop() {
 			// ones into the retrieval queues
 			actives := make(map[string]struct{})
 			for hash, instance := range f.waittime {
-				if time.Duration(f.clock.Now()-instance)+txGatherSlack > txArriveTimeout {
+				if time.Duration(f.clock.Now()-instance)+txGatherSlack > f.config.ArriveTimeout {
 					// Transaction expired without propagation, schedule for retrieval
 					if f.announced[hash] != nil {
 						panic("announce tracker already contains waitlist item")
@@ -859,12 +909,12 @@ func (f *TxFetcher) rescheduleWait(timer *mclock.Timer, trigger chan struct{}) {
 	for _, instance := range f.waittime {
 		if earliest > instance {
 			earliest = instance
-			if txArriveTimeout-time.Duration(now-earliest) < txGatherSlack {
+			if f.config.ArriveTimeout-time.Duration(now-earliest) < txGatherSlack {
 				break
 			}
 		}
 	}
-	*timer = f.clock.AfterFunc(txArriveTimeout-time.Duration(now-earliest), func() {
+	*timer = f.clock.AfterFunc(f.config.ArriveTimeout-time.Duration(now-earliest), func() {
 		trigger <- struct{}{}
 	})
 }
@@ -927,11 +977,14 @@ func (f *TxFetcher) scheduleFetches(timer *mclock.Timer, timeout chan struct{},
 		if f.requests[peer] != nil {
 			return // continue in the for-each
 		}
+		if f.config.MaxRequests > 0 && len(f.requests) >= f.config.MaxRequests {
+			return // continue in the for-each, rescheduled when a request finishes
+		}
 		if len(f.announces[peer]) == 0 {
 			return // continue in the for-each
 		}
 		var (
-			hashes = make([]common.Hash, 0, maxTxRetrievals)
+			hashes = make([]common.Hash, 0, f.config.MaxRetrievals)
 			bytes  uint64
 		)
 		f.forEachAnnounce(f.announces[peer], func(hash common.Hash, meta txMetadata) bool {
@@ -950,11 +1003,11 @@ func (f *TxFetcher) scheduleFetches(timer *mclock.Timer, timeout chan struct{},
 
 			// Accumulate the hash and stop if the limit was reached
 			hashes = append(hashes, hash)
-			if len(hashes) >= maxTxRetrievals {
+			if len(hashes) >= f.config.MaxRetrievals {
 				return false // break in the for-each
 			}
 			bytes += uint64(meta.size)
-			return bytes < maxTxRetrievalSize
+			return bytes < f.config.MaxRetrievalSize
 		})
 		// If any hashes were allocated, request them from the peer
 		if len(hashes) > 0 {
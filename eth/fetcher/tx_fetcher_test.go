@@ -1139,6 +1139,92 @@ func TestTransactionFetcherBandwidthLimiting(t *testing.T) {
 	})
 }
 
+// Tests that the configured retrieval limits override the defaults, capping both
+// the transactions requested from a peer and the concurrent requests overall.
+func TestTransactionFetcherConfigLimits(t *testing.T) {
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			f := NewTxFetcher(
+				func(common.Hash) bool { return false },
+				nil,
+				func(string, []common.Hash) error { return nil },
+				nil,
+			)
+			if err := f.SetConfig(TxFetcherConfig{
+				ArriveTimeout:    txArriveTimeout / 2,
+				MaxRetrievals:    2,
+				MaxRetrievalSize: maxTxRetrievalSize,
+				MaxRequests:      1,
+			}); err != nil {
+				panic(err)
+			}
+			return f
+		},
+		steps: []interface{}{
+			// Announce a few transactions from A and ensure only the configured
+			// number of them gets requested after the shortened arrival timeout
+			doTxNotify{peer: "A",
+				hashes: []common.Hash{{0x01}, {0x02}, {0x03}},
+				types:  []byte{types.LegacyTxType, types.LegacyTxType, types.LegacyTxType},
+				sizes:  []uint32{111, 222, 333},
+			},
+			doWait{time: txArriveTimeout / 2, step: true},
+			isWaiting(nil),
+			isScheduled{
+				tracking: map[string][]announce{
+					"A": {
+						{common.Hash{0x01}, types.LegacyTxType, 111},
+						{common.Hash{0x02}, types.LegacyTxType, 222},
+						{common.Hash{0x03}, types.LegacyTxType, 333},
+					},
+				},
+				fetching: map[string][]common.Hash{
+					"A": {{0x01}, {0x02}},
+				},
+			},
+			// Announce a transaction from B and ensure it's not requested while
+			// the request to A is in flight
+			doTxNotify{peer: "B", hashes: []common.Hash{{0x04}}, types: []byte{types.LegacyTxType}, sizes: []uint32{444}},
+			doWait{time: txArriveTimeout / 2, step: true},
+			isWaiting(nil),
+			isScheduled{
+				tracking: map[string][]announce{
+					"A": {
+						{common.Hash{0x01}, types.LegacyTxType, 111},
+						{common.Hash{0x02}, types.LegacyTxType, 222},
+						{common.Hash{0x03}, types.LegacyTxType, 333},
+					},
+					"B": {
+						{common.Hash{0x04}, types.LegacyTxType, 444},
+					},
+				},
+				fetching: map[string][]common.Hash{
+					"A": {{0x01}, {0x02}},
+				},
+			},
+		},
+	})
+}
+
+// Tests that invalid fetcher tunables are rejected.
+func TestTransactionFetcherConfigValidation(t *testing.T) {
+	f := NewTxFetcher(nil, nil, nil, nil)
+	for i, config := range []TxFetcherConfig{
+		{ArriveTimeout: -1, MaxRetrievals: 1, MaxRetrievalSize: 1},
+		{MaxRetrievals: 0, MaxRetrievalSize: 1},
+		{MaxRetrievals: maxTxAnnounces + 1, MaxRetrievalSize: 1},
+		{MaxRetrievals: 1, MaxRetrievalSize: 0},
+		{MaxRetrievals: 1, MaxRetrievalSize: 1, MaxRequests: -1},
+	} {
+		if err := f.SetConfig(config); err == nil {
+			t.Errorf("config %d: expected error for %+v", i, config)
+		}
+	}
+	if err := f.SetConfig(TxFetcherConfig{MaxRetrievals: 1, MaxRetrievalSize: 1}); err != nil {
+		t.Errorf("valid config rejected: %v", err)
+	}
+}
+
 // Tests that then number of transactions a peer is allowed to announce and/or
 // request at the same time is hard capped.
 func TestTransactionFetcherDoSProtection(t *testing.T) {
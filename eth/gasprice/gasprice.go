@@ -79,6 +79,10 @@ type Oracle struct {
 
 	historyCache *lru.Cache[cacheKey, processedFees]
 
+	targetPrices    map[uint64]targetPrice            // Last suggestions per inclusion target
+	thresholdCache  *lru.Cache[common.Hash, *big.Int] // Inclusion thresholds of the recent blocks
+	inclusionChecks []inclusionCheck                  // Suggestions awaiting their accuracy check
+
 	minSuggestedPriorityFee *big.Int // for Optimism fee suggestion
 }
 
@@ -154,6 +158,8 @@ func NewOracle(backend OracleBackend, params Config, startPrice *big.Int) *Oracl
 		maxHeaderHistory: maxHeaderHistory,
 		maxBlockHistory:  maxBlockHistory,
 		historyCache:     cache,
+		targetPrices:     make(map[uint64]targetPrice),
+		thresholdCache:   lru.NewCache[common.Hash, *big.Int](1024),
 	}
 
 	if backend.ChainConfig().IsOptimism() {
@@ -35,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/holiman/uint256"
@@ -292,3 +293,60 @@ func TestSuggestTipCap(t *testing.T) {
 		}
 	}
 }
+
+func TestSuggestTipCapFor(t *testing.T) {
+	backend := newTestBackend(t, big.NewInt(0), nil, false, false)
+	defer backend.teardown()
+	oracle := NewOracle(backend, Config{Blocks: 3, Percentile: 60}, big.NewInt(params.GWei))
+
+	// Block n includes a single transaction tipping n gwei, the windows of the
+	// last 3 are tipped [30, 31, 32] for the next block, [28, 29, 30] within 3
+	// blocks and [21, 22, 23] within 10 blocks
+	for _, c := range []struct {
+		target uint64
+		expect int64
+	}{
+		{1, 31},
+		{3, 29},
+		{10, 22},
+	} {
+		got, err := oracle.SuggestTipCapFor(context.Background(), c.target)
+		if err != nil {
+			t.Fatalf("target %d: failed to suggest tip cap: %v", c.target, err)
+		}
+		if want := big.NewInt(c.expect * params.GWei); got.Cmp(want) != 0 {
+			t.Fatalf("target %d: tip cap mismatch, want %d, got %d", c.target, want, got)
+		}
+	}
+	for _, target := range []uint64{0, maxInclusionTarget + 1} {
+		if _, err := oracle.SuggestTipCapFor(context.Background(), target); err == nil {
+			t.Fatalf("target %d: suggestion made", target)
+		}
+	}
+}
+
+func TestCheckInclusion(t *testing.T) {
+	oracle := &Oracle{}
+	hits := metrics.GetOrRegisterMeter("eth/gasprice/target/2/hit", nil)
+	misses := metrics.GetOrRegisterMeter("eth/gasprice/target/2/miss", nil)
+	hit, miss := hits.Snapshot().Count(), misses.Snapshot().Count()
+
+	oracle.inclusionChecks = []inclusionCheck{
+		{target: 2, number: 7, tip: big.NewInt(5)},  // blocks 8 and 9 need 6 and 5: hit
+		{target: 2, number: 6, tip: big.NewInt(5)},  // blocks 7 and 8 need 7 and 6: miss
+		{target: 2, number: 8, tip: big.NewInt(5)},  // block 10 not known yet
+		{target: 2, number: 1, tip: big.NewInt(50)}, // blocks 2 and 3 not known anymore
+	}
+	// Thresholds of blocks 9 down to 5
+	oracle.checkInclusion(9, []*big.Int{big.NewInt(5), big.NewInt(6), big.NewInt(7), big.NewInt(8), big.NewInt(9)})
+
+	if have := hits.Snapshot().Count() - hit; have != 1 {
+		t.Errorf("wrong number of hits: have %d, want 1", have)
+	}
+	if have := misses.Snapshot().Count() - miss; have != 1 {
+		t.Errorf("wrong number of misses: have %d, want 1", have)
+	}
+	if len(oracle.inclusionChecks) != 1 || oracle.inclusionChecks[0].number != 8 {
+		t.Errorf("wrong pending checks: %v", oracle.inclusionChecks)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxInclusionTarget is the longest inclusion window, in blocks, tip cap
+// suggestions can target.
+const maxInclusionTarget = 64
+
+// targetPrice is the last tip cap suggested for an inclusion target.
+type targetPrice struct {
+	head  common.Hash
+	price *big.Int
+}
+
+// inclusionCheck is a suggestion whose accuracy is checked once the blocks of
+// its inclusion window are known.
+type inclusionCheck struct {
+	target uint64   // Number of blocks the suggestion targeted inclusion within
+	number uint64   // Number of the head the suggestion was made at
+	tip    *big.Int // Suggested tip cap
+}
+
+// SuggestTipCapFor returns a tip cap with a high chance of inclusion within the
+// given number of blocks.
+//
+// The inclusion threshold of a block is the lowest effective tip it included,
+// or zero if it had room for more transactions, and a tip is included within a
+// window of blocks if it meets the threshold of any of them. The suggestion is
+// the configured percentile of the thresholds of the recent windows of the
+// target length, so waiting longer allows for a lower tip.
+//
+// Suggestions are checked against the blocks of their window once known, and
+// counted under eth/gasprice/target/<blocks>/hit or miss.
+func (oracle *Oracle) SuggestTipCapFor(ctx context.Context, target uint64) (*big.Int, error) {
+	if target < 1 || target > maxInclusionTarget {
+		return nil, fmt.Errorf("invalid inclusion target %d, must be in [1, %d] blocks", target, maxInclusionTarget)
+	}
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if head == nil {
+		return nil, err
+	}
+	headHash := head.Hash()
+
+	oracle.fetchLock.Lock()
+	defer oracle.fetchLock.Unlock()
+
+	if last, ok := oracle.targetPrices[target]; ok && last.head == headHash {
+		return new(big.Int).Set(last.price), nil
+	}
+	// Gather the inclusion thresholds of the recent blocks, newest first
+	var (
+		number     = head.Number.Uint64()
+		count      = min(uint64(oracle.checkBlocks)+target-1, number)
+		thresholds = make([]*big.Int, 0, count)
+	)
+	for i := uint64(0); i < count; i++ {
+		threshold, err := oracle.inclusionThreshold(ctx, number-i)
+		if err != nil {
+			return nil, err
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	oracle.checkInclusion(number, thresholds)
+
+	// Compute the thresholds of the windows and pick the percentile of them
+	var windows []*big.Int
+	for i := 0; i+int(target) <= len(thresholds) && len(windows) < oracle.checkBlocks; i++ {
+		windows = append(windows, slices.MinFunc(thresholds[i:i+int(target)], func(a, b *big.Int) int { return a.Cmp(b) }))
+	}
+	price := new(big.Int)
+	if len(windows) > 0 {
+		slices.SortFunc(windows, func(a, b *big.Int) int { return a.Cmp(b) })
+		price.Set(windows[(len(windows)-1)*oracle.percentile/100])
+	}
+	if oracle.minSuggestedPriorityFee != nil && price.Cmp(oracle.minSuggestedPriorityFee) < 0 {
+		price.Set(oracle.minSuggestedPriorityFee)
+	}
+	if price.Cmp(oracle.maxPrice) > 0 {
+		price.Set(oracle.maxPrice)
+	}
+	oracle.targetPrices[target] = targetPrice{head: headHash, price: price}
+	oracle.inclusionChecks = append(oracle.inclusionChecks, inclusionCheck{target: target, number: number, tip: price})
+
+	return new(big.Int).Set(price), nil
+}
+
+// inclusionThreshold returns the lowest tip a transaction needed to be included
+// in a block.
+func (oracle *Oracle) inclusionThreshold(ctx context.Context, number uint64) (*big.Int, error) {
+	block, err := oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(number))
+	if block == nil {
+		if err == nil {
+			err = fmt.Errorf("block %d not found", number)
+		}
+		return nil, err
+	}
+	if threshold, ok := oracle.thresholdCache.Get(block.Hash()); ok {
+		return threshold, nil
+	}
+	threshold := new(big.Int)
+
+	// On chains with a single, known block builder, the tip doesn't matter unless
+	// the block is at capacity, see SuggestOptimismPriorityFee.
+	full := true
+	if oracle.backend.ChainConfig().IsOptimism() {
+		receipts, err := oracle.backend.GetReceipts(ctx, block.Hash())
+		if err != nil {
+			return nil, err
+		}
+		var maxTxGasUsed uint64
+		for _, receipt := range receipts {
+			maxTxGasUsed = max(maxTxGasUsed, receipt.GasUsed)
+		}
+		full = block.GasUsed()+maxTxGasUsed > block.GasLimit()
+	}
+	if full {
+		result := make(chan results, 1)
+		oracle.getBlockValues(ctx, number, 1, oracle.ignorePrice, result, nil)
+		if res := <-result; res.err != nil {
+			return nil, res.err
+		} else if len(res.values) > 0 {
+			threshold = res.values[0]
+		}
+	}
+	oracle.thresholdCache.Add(block.Hash(), threshold)
+	return threshold, nil
+}
+
+// checkInclusion records the accuracy of the past suggestions whose inclusion
+// window is covered by the given thresholds of the blocks up to head, newest
+// first. Suggestions whose window is not covered anymore are dropped.
+func (oracle *Oracle) checkInclusion(head uint64, thresholds []*big.Int) {
+	oldest := head + 1 - uint64(len(thresholds))
+
+	pending := oracle.inclusionChecks[:0]
+	for _, check := range oracle.inclusionChecks {
+		switch {
+		case check.number+check.target > head:
+			pending = append(pending, check) // window not complete yet
+
+		case check.number+1 >= oldest:
+			// A tip is included within the window if it meets the threshold of
+			// any of its blocks
+			var hit bool
+			for number := check.number + 1; number <= check.number+check.target; number++ {
+				if check.tip.Cmp(thresholds[head-number]) >= 0 {
+					hit = true
+					break
+				}
+			}
+			if hit {
+				metrics.GetOrRegisterMeter(fmt.Sprintf("eth/gasprice/target/%d/hit", check.target), nil).Mark(1)
+			} else {
+				metrics.GetOrRegisterMeter(fmt.Sprintf("eth/gasprice/target/%d/miss", check.target), nil).Mark(1)
+			}
+		}
+	}
+	oracle.inclusionChecks = pending
+}
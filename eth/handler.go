@@ -18,6 +18,7 @@ package eth
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"sync"
@@ -48,9 +49,9 @@ const (
 	// The number is referenced from the size of tx pool.
 	txChanSize = 4096
 
-	// txMaxBroadcastSize is the max size of a transaction that will be broadcasted.
-	// All transactions with a higher size will be announced and need to be fetched
-	// by the peer.
+	// txMaxBroadcastSize is the default max size of a transaction that will be
+	// broadcasted. All transactions with a higher size will be announced and need
+	// to be fetched by the peer.
 	txMaxBroadcastSize = 4096
 )
 
@@ -88,25 +89,66 @@ type txPool interface {
 	SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription
 }
 
+// TxPropagation is the policy of propagating pool transactions to peers.
+type TxPropagation uint32
+
+const (
+	TxPropagationFull     TxPropagation = iota // Send to a subset of the peers, announce to the rest
+	TxPropagationAnnounce                      // Only announce transaction hashes
+	TxPropagationNone                          // Don't propagate pool transactions at all
+)
+
+// String implements fmt.Stringer.
+func (p TxPropagation) String() string {
+	switch p {
+	case TxPropagationFull:
+		return "full"
+	case TxPropagationAnnounce:
+		return "announce"
+	case TxPropagationNone:
+		return "none"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint32(p))
+	}
+}
+
+// ParseTxPropagation parses the name of a transaction propagation policy.
+func ParseTxPropagation(name string) (TxPropagation, error) {
+	switch name {
+	case "full":
+		return TxPropagationFull, nil
+	case "announce":
+		return TxPropagationAnnounce, nil
+	case "none":
+		return TxPropagationNone, nil
+	default:
+		return 0, fmt.Errorf("unknown transaction propagation policy %q", name)
+	}
+}
+
 // handlerConfig is the collection of initialization parameters to create a full
 // node network handler.
 type handlerConfig struct {
-	NodeID         enode.ID               // P2P node ID used for tx propagation topology
-	Database       ethdb.Database         // Database for direct sync insertions
-	Chain          *core.BlockChain       // Blockchain to serve data from
-	TxPool         txPool                 // Transaction pool to propagate from
-	Network        uint64                 // Network identifier to advertise
-	Sync           ethconfig.SyncMode     // Whether to snap or full sync
-	BloomCache     uint64                 // Megabytes to alloc for snap sync bloom
-	EventMux       *event.TypeMux         // Legacy event mux, deprecate for `feed`
-	RequiredBlocks map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
-	NoTxGossip     bool                   // Disable P2P transaction gossip
+	NodeID         enode.ID                  // P2P node ID used for tx propagation topology
+	Database       ethdb.Database            // Database for direct sync insertions
+	Chain          *core.BlockChain          // Blockchain to serve data from
+	TxPool         txPool                    // Transaction pool to propagate from
+	Network        uint64                    // Network identifier to advertise
+	Sync           ethconfig.SyncMode        // Whether to snap or full sync
+	Checkpoint     *ethconfig.SyncCheckpoint // Trusted block to anchor snap sync to
+	Pivot          *ethconfig.SyncPivot      // Strategy for selecting the snap sync pivot
+	BloomCache     uint64                    // Megabytes to alloc for snap sync bloom
+	EventMux       *event.TypeMux            // Legacy event mux, deprecate for `feed`
+	RequiredBlocks map[uint64]common.Hash    // Hard coded map of required block hashes for sync challenges
+	NoTxGossip     bool                      // Disable P2P transaction gossip
+	Standby        bool                      // Hold back P2P transaction gossip until promoted
+	TxFetcher      fetcher.TxFetcherConfig   // Tunables of the transaction fetcher (zero = defaults)
+	TxAnnounceSize uint64                    // Size above which transactions are only announced (0 = default)
 }
 
 type handler struct {
-	nodeID     enode.ID
-	networkID  uint64
-	forkFilter forkid.Filter // Fork ID filter, constant across the lifetime of the node
+	nodeID    enode.ID
+	networkID uint64
 
 	snapSync atomic.Bool // Flag whether snap sync is enabled (gets disabled if we already have blocks)
 	synced   atomic.Bool // Flag whether we're considered synchronised (enables transaction processing)
@@ -116,7 +158,14 @@ type handler struct {
 	chain    *core.BlockChain
 	maxPeers int
 
-	noTxGossip bool
+	configMismatches atomic.Uint64 // Number of peers seen running a differing chain config
+	mismatchLogged   atomic.Int64  // Time of the last reported differing peer, unix nanos
+
+	noTxGossip    bool
+	standby       atomic.Bool   // Whether transaction gossip is held back until promoted
+	txPropagation atomic.Uint32 // Policy of propagating pool transactions, a TxPropagation
+
+	txAnnounceSize uint64 // Size above which transactions are only announced
 
 	downloader *downloader.Downloader
 	txFetcher  *fetcher.TxFetcher
@@ -146,7 +195,6 @@ func newHandler(config *handlerConfig) (*handler, error) {
 	h := &handler{
 		nodeID:         config.NodeID,
 		networkID:      config.Network,
-		forkFilter:     forkid.NewFilter(config.Chain),
 		eventMux:       config.EventMux,
 		database:       config.Database,
 		txpool:         config.TxPool,
@@ -158,6 +206,10 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		handlerDoneCh:  make(chan struct{}),
 		handlerStartCh: make(chan struct{}),
 	}
+	h.standby.Store(config.Standby)
+	if h.txAnnounceSize = config.TxAnnounceSize; h.txAnnounceSize == 0 {
+		h.txAnnounceSize = txMaxBroadcastSize
+	}
 	if config.Sync == ethconfig.FullSync {
 		// The database seems empty as the current block is the genesis. Yet the snap
 		// block is ahead, so snap sync was enabled for this node at a certain point.
@@ -199,6 +251,14 @@ func newHandler(config *handlerConfig) (*handler, error) {
 	}
 	// Construct the downloader (long sync)
 	h.downloader = downloader.New(config.Database, h.eventMux, h.chain, h.removePeer, h.enableSyncedFeatures, chainID)
+	if config.Checkpoint != nil {
+		h.downloader.SetCheckpoint(config.Checkpoint)
+	}
+	if config.Pivot != nil {
+		if err := h.downloader.SetPivotStrategy(*config.Pivot); err != nil {
+			return nil, err
+		}
+	}
 
 	fetchTx := func(peer string, hashes []common.Hash) error {
 		p := h.peers.peer(peer)
@@ -211,6 +271,11 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		return h.txpool.Add(txs, false)
 	}
 	h.txFetcher = fetcher.NewTxFetcher(h.txpool.Has, addTxs, fetchTx, h.removePeer)
+	if config.TxFetcher != (fetcher.TxFetcherConfig{}) {
+		if err := h.txFetcher.SetConfig(config.TxFetcher); err != nil {
+			return nil, fmt.Errorf("invalid transaction fetcher config: %v", err)
+		}
+	}
 	return h, nil
 }
 
@@ -273,11 +338,25 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 		hash    = head.Hash()
 		number  = head.Number.Uint64()
 	)
+	// The fork filter is recreated for every peer to account for the forks
+	// scheduled at runtime.
 	forkID := forkid.NewID(h.chain.Config(), genesis, number, head.Time)
-	if err := peer.Handshake(h.networkID, hash, genesis.Hash(), forkID, h.forkFilter); err != nil {
+	if err := peer.Handshake(h.networkID, hash, genesis.Hash(), forkID, forkid.NewFilter(h.chain)); err != nil {
 		peer.Log().Debug("Ethereum handshake failed", "err", err)
 		return err
 	}
+	// Peers running the same chain with a different configuration may fail on
+	// a diverging block later on. Report them, but leave compatibility to the
+	// fork ID: differing configs are expected midway through rolling upgrades.
+	// The local identity is rebuilt to account for forks scheduled at runtime.
+	if err := peer.CheckIdentity(eth.NewIdentity(h.chain)); err != nil {
+		h.configMismatches.Add(1)
+		if last := h.mismatchLogged.Load(); time.Since(time.Unix(0, last)) > 8*time.Second && h.mismatchLogged.CompareAndSwap(last, time.Now().UnixNano()) {
+			peer.Log().Warn("Peer runs a different chain config", "name", peer.Fullname(), "err", err)
+		} else {
+			peer.Log().Debug("Peer runs a different chain config", "err", err)
+		}
+	}
 	reject := false // reserved peer slots
 	if h.snapSync.Load() {
 		if snap == nil {
@@ -504,10 +583,10 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		switch {
 		case tx.Type() == types.BlobTxType:
 			blobTxs++
-		case tx.Size() > txMaxBroadcastSize:
+		case tx.Size() > h.txAnnounceSize:
 			largeTxs++
 		default:
-			maybeDirect = true
+			maybeDirect = TxPropagation(h.txPropagation.Load()) != TxPropagationAnnounce
 		}
 		// Send the transaction (if it's small enough) directly to a subset of
 		// the peers that have not received it yet, ensuring that the flow of
@@ -556,6 +635,9 @@ func (h *handler) txBroadcastLoop() {
 	for {
 		select {
 		case event := <-h.txsCh:
+			if h.standby.Load() || TxPropagation(h.txPropagation.Load()) == TxPropagationNone {
+				continue
+			}
 			h.BroadcastTransactions(event.Txs)
 		case <-h.txsSub.Err():
 			return
@@ -563,6 +645,35 @@ func (h *handler) txBroadcastLoop() {
 	}
 }
 
+// rebroadcastTransactions announces the given transactions to all connected
+// peers, including the ones already known to have them, which might have dropped
+// them since. It returns the number of peers announced to.
+func (h *handler) rebroadcastTransactions(txs types.Transactions) int {
+	if len(txs) == 0 || h.noTxGossip || h.standby.Load() || TxPropagation(h.txPropagation.Load()) == TxPropagationNone {
+		return 0
+	}
+	hashes := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash()
+	}
+	peers := h.peers.all()
+	for _, peer := range peers {
+		peer.AsyncSendPooledTransactionHashes(hashes)
+	}
+	return len(peers)
+}
+
+// promote starts the transaction gossip held back by a standby node, announcing
+// the pending transactions to the connected peers.
+func (h *handler) promote() {
+	if !h.standby.CompareAndSwap(true, false) {
+		return
+	}
+	for _, peer := range h.peers.all() {
+		h.syncTransactions(peer.Peer)
+	}
+}
+
 // enableSyncedFeatures enables the post-sync functionalities when the initial
 // sync is finished.
 func (h *handler) enableSyncedFeatures() {
@@ -43,7 +43,7 @@ func (n NilPool) GetRLP(common.Hash) []byte                       { return nil }
 func (n NilPool) GetMetadata(hash common.Hash) *txpool.TxMetadata { return nil }
 
 func (h *ethHandler) TxPool() eth.TxPool {
-	if h.noTxGossip {
+	if h.noTxGossip || h.standby.Load() {
 		return &NilPool{}
 	}
 	return h.txpool
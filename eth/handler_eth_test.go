@@ -365,6 +365,75 @@ func testSendTransactions(t *testing.T, protocol uint) {
 	}
 }
 
+// Tests that a standby node holds back transaction gossip until promoted, then
+// announces its pending transactions.
+func TestStandbyTransactions(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler()
+	defer handler.close()
+	handler.handler.standby.Store(true)
+
+	insert := make([]*types.Transaction, 16)
+	for nonce := range insert {
+		tx := types.NewTransaction(uint64(nonce), common.Address{}, big.NewInt(0), 100000, big.NewInt(0), nil)
+		tx, _ = types.SignTx(tx, types.HomesteadSigner{}, testKey)
+		insert[nonce] = tx
+	}
+	// Create a source handler to send messages through and a sink peer to receive them
+	p2pSrc, p2pSink := p2p.MsgPipe()
+	defer p2pSrc.Close()
+	defer p2pSink.Close()
+
+	src := eth.NewPeer(eth.ETH68, p2p.NewPeerPipe(enode.ID{1}, "", nil, p2pSrc), p2pSrc, handler.txpool)
+	sink := eth.NewPeer(eth.ETH68, p2p.NewPeerPipe(enode.ID{2}, "", nil, p2pSink), p2pSink, handler.txpool)
+	defer src.Close()
+	defer sink.Close()
+
+	go handler.handler.runEthPeer(src, func(peer *eth.Peer) error {
+		return eth.Handle((*ethHandler)(handler.handler), peer)
+	})
+	var (
+		genesis = handler.chain.Genesis()
+		head    = handler.chain.CurrentBlock()
+	)
+	if err := sink.Handshake(1, head.Hash(), genesis.Hash(), forkid.NewIDWithChain(handler.chain), forkid.NewFilter(handler.chain)); err != nil {
+		t.Fatalf("failed to run protocol handshake")
+	}
+	backend := new(testEthHandler)
+
+	anns := make(chan []common.Hash)
+	annSub := backend.txAnnounces.Subscribe(anns)
+	defer annSub.Unsubscribe()
+
+	go eth.Handle(backend, sink)
+
+	// Wait for the peer to be registered, then insert the transactions
+	for handler.handler.peers.len() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	handler.txpool.Add(insert, false)
+
+	select {
+	case hashes := <-anns:
+		t.Fatalf("transactions announced on standby: %d", len(hashes))
+	case <-time.After(250 * time.Millisecond):
+	}
+	handler.handler.promote()
+
+	seen := make(map[common.Hash]struct{})
+	for len(seen) < len(insert) {
+		select {
+		case hashes := <-anns:
+			for _, hash := range hashes {
+				seen[hash] = struct{}{}
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("transactions not announced after promotion: have %d, want %d", len(seen), len(insert))
+		}
+	}
+}
+
 // Tests that transactions get propagated to all attached peers, either via direct
 // broadcasts or via announcements/retrievals.
 func TestTransactionPropagation68(t *testing.T) { testTransactionPropagation(t, eth.ETH68) }
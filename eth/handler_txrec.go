@@ -0,0 +1,67 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/txrec"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// txrecHandler implements the txrec.Backend interface to reconcile the
+// transaction pool with the trusted peers.
+type txrecHandler handler
+
+// PooledHashes returns the hashes of the pending transactions of the pool.
+func (h *txrecHandler) PooledHashes() []common.Hash {
+	var hashes []common.Hash
+	for _, txs := range h.txpool.Pending(txpool.PendingFilter{}) {
+		for _, tx := range txs {
+			hashes = append(hashes, tx.Hash)
+		}
+	}
+	return hashes
+}
+
+func (h *txrecHandler) Has(hash common.Hash) bool      { return h.txpool.Has(hash) }
+func (h *txrecHandler) GetRLP(hash common.Hash) []byte { return h.txpool.GetRLP(hash) }
+
+// AddTransactions adds the transactions received from a peer to the pool, once
+// the node is synced.
+func (h *txrecHandler) AddTransactions(peer *txrec.Peer, txs []*types.Transaction) {
+	if !h.synced.Load() {
+		return
+	}
+	h.txpool.Add(txs, false)
+}
+
+// RunPeer is invoked when a peer joins on the `txrec` protocol.
+func (h *txrecHandler) RunPeer(peer *txrec.Peer, hand txrec.Handler) error {
+	if !(*handler)(h).incHandlers() {
+		return p2p.DiscQuitting
+	}
+	defer (*handler)(h).decHandlers()
+
+	peer.Log().Debug("Reconciling transaction pool with trusted peer")
+	return hand(peer)
+}
+
+// PeerInfo retrieves all known `txrec` information about a peer.
+func (h *txrecHandler) PeerInfo(id enode.ID) interface{} { return nil }
@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package headattest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var errNoHead = errors.New("no attested head")
+
+// API exposes the attested chain head over RPC.
+type API struct {
+	tracker *Tracker
+}
+
+// NewAPI creates the RPC API of the given tracker.
+func NewAPI(tracker *Tracker) *API {
+	return &API{tracker: tracker}
+}
+
+// SubmitAttestation verifies a head attestation and makes it the new head.
+func (api *API) SubmitAttestation(att Attestation) (*AttestedHead, error) {
+	return api.tracker.Submit(&att)
+}
+
+// Head returns the current attested head.
+func (api *API) Head() (*AttestedHead, error) {
+	if head := api.tracker.Head(); head != nil {
+		return head, nil
+	}
+	return nil, errNoHead
+}
+
+// BlockNumber returns the number of the current attested head.
+func (api *API) BlockNumber() (hexutil.Uint64, error) {
+	head := api.tracker.Head()
+	if head == nil {
+		return 0, errNoHead
+	}
+	return hexutil.Uint64(head.Header.Number.Uint64()), nil
+}
+
+// GetHeadByNumber returns a recent attested head by number, or nil if the
+// head is unknown.
+func (api *API) GetHeadByNumber(number hexutil.Uint64) *AttestedHead {
+	return api.tracker.HeadByNumber(uint64(number))
+}
+
+// GetHeadByHash returns a recent attested head by hash, or nil if the head is
+// unknown.
+func (api *API) GetHeadByHash(hash common.Hash) *AttestedHead {
+	return api.tracker.HeadByHash(hash)
+}
+
+// GetHeaderByNumber returns the header of a recent attested head in the format
+// of eth_getHeaderByNumber, "latest" and "pending" resolving to the current
+// attested head. The response is labelled with the local execution status
+// and the keys attesting it, or nil if the head is unknown.
+func (api *API) GetHeaderByNumber(number rpc.BlockNumber) (map[string]interface{}, error) {
+	var head *AttestedHead
+	switch number {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		head = api.tracker.Head()
+	case rpc.SafeBlockNumber, rpc.FinalizedBlockNumber, rpc.EarliestBlockNumber:
+		return nil, fmt.Errorf("%s block not attested", number)
+	default:
+		head = api.tracker.HeadByNumber(uint64(number))
+	}
+	return marshalHead(head), nil
+}
+
+// GetHeaderByHash returns the header of a recent attested head in the format
+// of eth_getHeaderByHash, labelled like GetHeaderByNumber, or nil if the head
+// is unknown.
+func (api *API) GetHeaderByHash(hash common.Hash) map[string]interface{} {
+	return marshalHead(api.tracker.HeadByHash(hash))
+}
+
+// marshalHead converts an attested head into the RPC header format, labelled
+// with its execution status and signers.
+func marshalHead(head *AttestedHead) map[string]interface{} {
+	if head == nil {
+		return nil
+	}
+	fields := ethapi.RPCMarshalHeader(head.Header)
+	fields["executionVerified"] = head.ExecutionVerified
+	fields["attestedBy"] = head.Signers
+	return fields
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package headattest implements a light follower mode, which tracks the chain
+// head from headers signed by a quorum of trusted sequencer or verifier keys
+// instead of executing the blocks.
+//
+// The attested heads are only as trustworthy as the configured keys: the
+// execution of the blocks is not verified, which is why every head served by
+// the follower is labelled with the local execution status.
+//
+// The follower serves header data at the attested head, through the attest
+// namespace in the format of the eth header methods. It doesn't serve state
+// at that head: queries depending on the state (balances, calls, receipts)
+// are only answered by the eth namespace once the block has been executed
+// locally, which the executionVerified label reports.
+package headattest
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// retainedHeads is the number of recent attested heads kept for lookups.
+const retainedHeads = 1024
+
+// signingDomain separates the attestation signatures from other signed data.
+var signingDomain = []byte("cpchain head attestation")
+
+var (
+	attestAcceptedMeter = metrics.NewRegisteredMeter("headattest/accepted", nil)
+	attestRejectedMeter = metrics.NewRegisteredMeter("headattest/rejected", nil)
+	attestHeadGauge     = metrics.NewRegisteredGauge("headattest/head", nil)
+)
+
+var (
+	errNoHeader    = errors.New("attestation without header")
+	errNoQuorum    = errors.New("attestation quorum not reached")
+	errStaleHead   = errors.New("attested head is not newer than the current head")
+	errConflicting = errors.New("conflicting attestation for the current head")
+)
+
+// Config contains the settings of the attestation tracker.
+type Config struct {
+	Signers []common.Address // Keys trusted to attest the chain head
+	Quorum  int              // Number of distinct signers required per head
+}
+
+// Attestation is a chain head signed by a set of trusted keys.
+type Attestation struct {
+	Header     *types.Header   `json:"header"`
+	Signatures []hexutil.Bytes `json:"signatures"`
+}
+
+// AttestedHead is a head accepted by the tracker.
+type AttestedHead struct {
+	Header            *types.Header    `json:"header"`
+	Signers           []common.Address `json:"signers"`
+	ExecutionVerified bool             `json:"executionVerified"` // Whether the block was executed locally
+}
+
+// SigningHash returns the hash signed by the attesters of a head.
+func SigningHash(chainID *big.Int, head common.Hash) common.Hash {
+	return crypto.Keccak256Hash(signingDomain, common.BigToHash(chainID).Bytes(), head.Bytes())
+}
+
+// Tracker verifies the attestations and tracks the attested chain head.
+type Tracker struct {
+	chainID  *big.Int
+	quorum   int
+	signers  map[common.Address]struct{}
+	executed func(hash common.Hash, number uint64) bool
+
+	mu       sync.RWMutex
+	head     *AttestedHead
+	byHash   map[common.Hash]*AttestedHead
+	byNumber map[uint64]*AttestedHead
+}
+
+// NewTracker creates a tracker accepting the heads signed by the given quorum.
+// The executed callback reports whether a block was executed locally.
+func NewTracker(chainID *big.Int, config Config, executed func(common.Hash, uint64) bool) (*Tracker, error) {
+	signers := make(map[common.Address]struct{}, len(config.Signers))
+	for _, addr := range config.Signers {
+		signers[addr] = struct{}{}
+	}
+	if config.Quorum <= 0 || config.Quorum > len(signers) {
+		return nil, fmt.Errorf("invalid attestation quorum %d of %d signers", config.Quorum, len(signers))
+	}
+	return &Tracker{
+		chainID:  chainID,
+		quorum:   config.Quorum,
+		signers:  signers,
+		executed: executed,
+		byHash:   make(map[common.Hash]*AttestedHead),
+		byNumber: make(map[uint64]*AttestedHead),
+	}, nil
+}
+
+// Submit verifies an attestation and makes its header the new head.
+func (t *Tracker) Submit(att *Attestation) (*AttestedHead, error) {
+	head, err := t.verify(att)
+	if err != nil {
+		attestRejectedMeter.Mark(1)
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	number := head.Header.Number.Uint64()
+	if t.head != nil {
+		current := t.head.Header
+		if number == current.Number.Uint64() && head.Header.Hash() != current.Hash() {
+			attestRejectedMeter.Mark(1)
+			log.Error("Conflicting head attestations", "number", number, "current", current.Hash(), "attested", head.Header.Hash())
+			return nil, errConflicting
+		}
+		if number <= current.Number.Uint64() {
+			attestRejectedMeter.Mark(1)
+			return nil, errStaleHead
+		}
+	}
+	t.head = head
+	t.byHash[head.Header.Hash()] = head
+	t.byNumber[number] = head
+	if number >= retainedHeads && len(t.byNumber) > retainedHeads {
+		for n, old := range t.byNumber {
+			if n <= number-retainedHeads {
+				delete(t.byHash, old.Header.Hash())
+				delete(t.byNumber, n)
+			}
+		}
+	}
+	attestAcceptedMeter.Mark(1)
+	attestHeadGauge.Update(int64(number))
+	log.Debug("Accepted head attestation", "number", number, "hash", head.Header.Hash(), "signers", len(head.Signers))
+	return t.label(head), nil
+}
+
+// verify checks that the attestation is signed by a quorum of distinct signers.
+func (t *Tracker) verify(att *Attestation) (*AttestedHead, error) {
+	if att == nil || att.Header == nil || att.Header.Number == nil {
+		return nil, errNoHeader
+	}
+	var (
+		hash    = SigningHash(t.chainID, att.Header.Hash())
+		seen    = make(map[common.Address]struct{})
+		signers []common.Address
+	)
+	for _, sig := range att.Signatures {
+		pubkey, err := crypto.SigToPub(hash[:], sig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attestation signature: %v", err)
+		}
+		addr := crypto.PubkeyToAddress(*pubkey)
+		if _, ok := t.signers[addr]; !ok {
+			continue
+		}
+		if _, ok := seen[addr]; ok {
+			continue
+		}
+		seen[addr] = struct{}{}
+		signers = append(signers, addr)
+	}
+	if len(signers) < t.quorum {
+		return nil, fmt.Errorf("%w: have %d, want %d", errNoQuorum, len(signers), t.quorum)
+	}
+	return &AttestedHead{Header: types.CopyHeader(att.Header), Signers: signers}, nil
+}
+
+// Head returns the current attested head, or nil if none was accepted yet.
+func (t *Tracker) Head() *AttestedHead {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.label(t.head)
+}
+
+// HeadByNumber returns a recent attested head by number.
+func (t *Tracker) HeadByNumber(number uint64) *AttestedHead {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.label(t.byNumber[number])
+}
+
+// HeadByHash returns a recent attested head by hash.
+func (t *Tracker) HeadByHash(hash common.Hash) *AttestedHead {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.label(t.byHash[hash])
+}
+
+// label returns a copy of the head with the current local execution status.
+func (t *Tracker) label(head *AttestedHead) *AttestedHead {
+	if head == nil {
+		return nil
+	}
+	cpy := *head
+	if t.executed != nil {
+		cpy.ExecutionVerified = t.executed(head.Header.Hash(), head.Header.Number.Uint64())
+	}
+	return &cpy
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package headattest
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var testChainID = big.NewInt(1337)
+
+func attest(header *types.Header, keys ...*ecdsa.PrivateKey) *Attestation {
+	att := &Attestation{Header: header}
+	hash := SigningHash(testChainID, header.Hash())
+	for _, key := range keys {
+		sig, err := crypto.Sign(hash[:], key)
+		if err != nil {
+			panic(err)
+		}
+		att.Signatures = append(att.Signatures, hexutil.Bytes(sig))
+	}
+	return att
+}
+
+// Tests that heads are only accepted with a quorum of distinct trusted signers,
+// and that stale or conflicting heads are rejected.
+func TestTrackerQuorum(t *testing.T) {
+	var (
+		keys    = make([]*ecdsa.PrivateKey, 4)
+		signers []common.Address
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		if i < 3 {
+			signers = append(signers, crypto.PubkeyToAddress(keys[i].PublicKey))
+		}
+	}
+	executed := common.Hash{}
+	tracker, err := NewTracker(testChainID, Config{Signers: signers, Quorum: 2}, func(hash common.Hash, number uint64) bool {
+		return hash == executed
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewTracker(testChainID, Config{Signers: signers, Quorum: 4}, nil); err == nil {
+		t.Fatal("quorum above the signer count accepted")
+	}
+	header := &types.Header{Number: big.NewInt(10), Difficulty: common.Big0, Extra: []byte("a")}
+
+	// Duplicate and untrusted signatures don't count towards the quorum.
+	if _, err := tracker.Submit(attest(header, keys[0], keys[0], keys[3])); !errors.Is(err, errNoQuorum) {
+		t.Fatalf("wrong error without quorum: %v", err)
+	}
+	head, err := tracker.Submit(attest(header, keys[0], keys[3], keys[2]))
+	if err != nil {
+		t.Fatalf("failed to submit attestation: %v", err)
+	}
+	if head.ExecutionVerified || len(head.Signers) != 2 {
+		t.Fatalf("wrong attested head: verified %v, %d signers", head.ExecutionVerified, len(head.Signers))
+	}
+	// The execution label follows the local chain.
+	executed = header.Hash()
+	if head := tracker.HeadByNumber(10); head == nil || !head.ExecutionVerified {
+		t.Fatal("executed head not labelled as verified")
+	}
+	// Heads signed for another chain are rejected.
+	other := &types.Header{Number: big.NewInt(11), Difficulty: common.Big0}
+	sig, _ := crypto.Sign(SigningHash(big.NewInt(1), other.Hash()).Bytes(), keys[0])
+	sig2, _ := crypto.Sign(SigningHash(big.NewInt(1), other.Hash()).Bytes(), keys[1])
+	if _, err := tracker.Submit(&Attestation{Header: other, Signatures: []hexutil.Bytes{sig, sig2}}); !errors.Is(err, errNoQuorum) {
+		t.Fatalf("wrong error for other chain: %v", err)
+	}
+	// Conflicting and stale heads are rejected.
+	conflict := &types.Header{Number: big.NewInt(10), Difficulty: common.Big0, Extra: []byte("b")}
+	if _, err := tracker.Submit(attest(conflict, keys[0], keys[1])); err != errConflicting {
+		t.Fatalf("wrong error for conflicting head: %v", err)
+	}
+	stale := &types.Header{Number: big.NewInt(9), Difficulty: common.Big0}
+	if _, err := tracker.Submit(attest(stale, keys[0], keys[1])); err != errStaleHead {
+		t.Fatalf("wrong error for stale head: %v", err)
+	}
+	if _, err := tracker.Submit(attest(other, keys[1], keys[2])); err != nil {
+		t.Fatalf("failed to advance head: %v", err)
+	}
+	if head := tracker.Head(); head.Header.Hash() != other.Hash() || head.ExecutionVerified {
+		t.Fatal("wrong head after advancing")
+	}
+	if tracker.HeadByHash(header.Hash()) == nil {
+		t.Fatal("previous head not retained")
+	}
+}
+
+// Tests that the API serves the attested heads as labelled headers.
+func TestAPIHeaders(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	tracker, err := NewTracker(testChainID, Config{Signers: []common.Address{signer}, Quorum: 1}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := NewAPI(tracker)
+	if head, err := api.GetHeaderByNumber(rpc.LatestBlockNumber); err != nil || head != nil {
+		t.Fatalf("header served without attestation: %v, %v", head, err)
+	}
+	header := &types.Header{Number: big.NewInt(5), Difficulty: common.Big0}
+	if _, err := tracker.Submit(attest(header, key)); err != nil {
+		t.Fatalf("failed to submit attestation: %v", err)
+	}
+	for _, head := range []map[string]interface{}{
+		must(api.GetHeaderByNumber(rpc.LatestBlockNumber)),
+		must(api.GetHeaderByNumber(5)),
+		api.GetHeaderByHash(header.Hash()),
+	} {
+		if head == nil || head["hash"] != header.Hash() {
+			t.Fatalf("wrong attested header: %v", head)
+		}
+		if head["executionVerified"] != false || !reflect.DeepEqual(head["attestedBy"], []common.Address{signer}) {
+			t.Fatalf("wrong attested header labels: %v, %v", head["executionVerified"], head["attestedBy"])
+		}
+	}
+	if _, err := api.GetHeaderByNumber(rpc.FinalizedBlockNumber); err == nil {
+		t.Fatal("finalized header served")
+	}
+}
+
+func must(head map[string]interface{}, err error) map[string]interface{} {
+	if err != nil {
+		panic(err)
+	}
+	return head
+}
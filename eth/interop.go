@@ -11,10 +11,10 @@ import (
 )
 
 func (s *Ethereum) CheckAccessList(ctx context.Context, inboxEntries []common.Hash, minSafety interoptypes.SafetyLevel, execDesc interoptypes.ExecutingDescriptor) error {
-	if s.interopRPC == nil {
+	if s.interopValidator == nil {
 		return errors.New("cannot check interop access list, no RPC available")
 	}
-	return s.interopRPC.CheckAccessList(ctx, inboxEntries, minSafety, execDesc)
+	return s.interopValidator.CheckAccessList(ctx, inboxEntries, minSafety, execDesc)
 }
 
 // CurrentInteropBlockTime returns the current block time,
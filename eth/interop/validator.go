@@ -0,0 +1,154 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package interop
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types/interoptypes"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// cachedChecks is the number of access list checks kept in the cache.
+const cachedChecks = 4096
+
+var (
+	cacheHitMeter     = metrics.NewRegisteredMeter("interop/check/cache/hit", nil)
+	cacheMissMeter    = metrics.NewRegisteredMeter("interop/check/cache/miss", nil)
+	unavailableMeter  = metrics.NewRegisteredMeter("interop/check/unavailable", nil)
+	optimisticCounter = metrics.NewRegisteredCounter("interop/check/optimistic", nil)
+)
+
+// FailurePolicy decides the outcome of the interop message checks when the
+// supervisor cannot be reached.
+type FailurePolicy string
+
+const (
+	// Strict rejects the messages which cannot be checked.
+	Strict FailurePolicy = "strict"
+
+	// Optimistic accepts the messages which cannot be checked. Note, blocks
+	// built optimistically may include invalid messages and be reorged out.
+	Optimistic FailurePolicy = "optimistic"
+)
+
+// ParseFailurePolicy parses the name of a failure policy.
+func ParseFailurePolicy(name string) (FailurePolicy, error) {
+	switch policy := FailurePolicy(name); policy {
+	case Strict, Optimistic:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown interop failure policy %q, expected %q or %q", name, Strict, Optimistic)
+}
+
+// Checker checks the inbox entries of interop messages against the supervisor.
+// It is the extension point to plug alternative message validation backends.
+type Checker interface {
+	CheckAccessList(ctx context.Context, inboxEntries []common.Hash, minSafety interoptypes.SafetyLevel, executingDescriptor interoptypes.ExecutingDescriptor) error
+}
+
+// checkResult is a cached outcome of an access list check.
+type checkResult struct {
+	err     error // Rejection of the supervisor, nil if the messages are valid
+	expires time.Time
+}
+
+// Validator wraps a Checker with the caching of the outcomes for a short time,
+// as the txpool admission and every rebuild of a payload check the same
+// messages, and applies the failure policy when the checker is unavailable.
+//
+// Only the verdicts of the supervisor are cached, failures to reach it are not.
+// Rejected messages are checked again once their entry expires, as their
+// initiating messages may have been seen in the meantime.
+type Validator struct {
+	checker Checker
+	policy  FailurePolicy
+	ttl     time.Duration // Lifetime of the cached outcomes, zero disables caching
+	cache   *lru.Cache[common.Hash, checkResult]
+}
+
+// NewValidator creates a validator on top of the given checker.
+func NewValidator(checker Checker, policy FailurePolicy, ttl time.Duration) *Validator {
+	return &Validator{
+		checker: checker,
+		policy:  policy,
+		ttl:     ttl,
+		cache:   lru.NewCache[common.Hash, checkResult](cachedChecks),
+	}
+}
+
+// CheckAccessList implements Checker.
+func (v *Validator) CheckAccessList(ctx context.Context, inboxEntries []common.Hash, minSafety interoptypes.SafetyLevel, executingDescriptor interoptypes.ExecutingDescriptor) error {
+	key := checkKey(inboxEntries, minSafety, executingDescriptor)
+	if v.ttl > 0 {
+		if res, ok := v.cache.Get(key); ok && time.Now().Before(res.expires) {
+			cacheHitMeter.Mark(1)
+			return res.err
+		}
+		cacheMissMeter.Mark(1)
+	}
+	err := v.checker.CheckAccessList(ctx, inboxEntries, minSafety, executingDescriptor)
+	if err != nil && !isVerdict(err) {
+		if ctx.Err() != nil {
+			return err // the caller gave up, the supervisor is not at fault
+		}
+		unavailableMeter.Mark(1)
+		if v.policy == Optimistic {
+			optimisticCounter.Inc(1)
+			log.Debug("Accepting unchecked interop messages", "entries", len(inboxEntries), "err", err)
+			return nil
+		}
+		return err
+	}
+	if v.ttl > 0 {
+		v.cache.Add(key, checkResult{err: err, expires: time.Now().Add(v.ttl)})
+	}
+	return err
+}
+
+// isVerdict reports whether the error is a rejection by the supervisor, as
+// opposed to a failure to reach it.
+func isVerdict(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr)
+}
+
+// checkKey derives the cache key of an access list check. The whole executing
+// descriptor is part of it, as the verdict of the supervisor depends on the
+// timestamp the messages are executed at.
+func checkKey(inboxEntries []common.Hash, minSafety interoptypes.SafetyLevel, executingDescriptor interoptypes.ExecutingDescriptor) common.Hash {
+	hasher := crypto.NewKeccakState()
+	for _, entry := range inboxEntries {
+		hasher.Write(entry[:])
+	}
+	hasher.Write([]byte(minSafety))
+	hasher.Write(binary.BigEndian.AppendUint64(nil, executingDescriptor.Timestamp))
+	hasher.Write(binary.BigEndian.AppendUint64(nil, executingDescriptor.Timeout))
+
+	var key common.Hash
+	hasher.Read(key[:])
+	return key
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package interop
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types/interoptypes"
+)
+
+// rejection is a supervisor verdict, as returned over JSON-RPC.
+type rejection struct{}
+
+func (rejection) Error() string  { return "conflicting data" }
+func (rejection) ErrorCode() int { return -320600 }
+
+// testChecker is a Checker returning a fixed outcome and counting the calls.
+type testChecker struct {
+	err   error
+	calls int
+}
+
+func (c *testChecker) CheckAccessList(ctx context.Context, inboxEntries []common.Hash, minSafety interoptypes.SafetyLevel, executingDescriptor interoptypes.ExecutingDescriptor) error {
+	c.calls++
+	return c.err
+}
+
+func TestValidator(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		entries = []common.Hash{{0x01}}
+		desc    = interoptypes.ExecutingDescriptor{Timestamp: 1, Timeout: 0}
+	)
+	// Verdicts are cached, for both valid and rejected messages
+	checker := new(testChecker)
+	validator := NewValidator(checker, Strict, time.Minute)
+	for i := 0; i < 2; i++ {
+		if err := validator.CheckAccessList(ctx, entries, interoptypes.CrossUnsafe, desc); err != nil {
+			t.Fatalf("valid messages rejected: %v", err)
+		}
+	}
+	if checker.calls != 1 {
+		t.Fatalf("valid verdict not cached: %d calls", checker.calls)
+	}
+	// Checks with a different timeout are not served from the cache
+	if err := validator.CheckAccessList(ctx, entries, interoptypes.CrossUnsafe, interoptypes.ExecutingDescriptor{Timestamp: 1, Timeout: 100}); err != nil || checker.calls != 2 {
+		t.Fatalf("wrong outcome for different timeout: err %v, %d calls", err, checker.calls)
+	}
+	// Neither are checks at a different timestamp
+	if err := validator.CheckAccessList(ctx, entries, interoptypes.CrossUnsafe, interoptypes.ExecutingDescriptor{Timestamp: 2, Timeout: 0}); err != nil || checker.calls != 3 {
+		t.Fatalf("wrong outcome for different timestamp: err %v, %d calls", err, checker.calls)
+	}
+	checker = &testChecker{err: rejection{}}
+	validator = NewValidator(checker, Optimistic, time.Minute)
+	for i := 0; i < 2; i++ {
+		if err := validator.CheckAccessList(ctx, entries, interoptypes.CrossUnsafe, desc); !errors.Is(err, rejection{}) {
+			t.Fatalf("rejection not reported with the optimistic policy: %v", err)
+		}
+	}
+	if checker.calls != 1 {
+		t.Fatalf("rejection not cached: %d calls", checker.calls)
+	}
+	// Unreachable supervisors are subject to the failure policy and never cached
+	unreachable := errors.New("connection refused")
+	checker = &testChecker{err: unreachable}
+	if err := NewValidator(checker, Strict, time.Minute).CheckAccessList(ctx, entries, interoptypes.CrossUnsafe, desc); err != unreachable {
+		t.Fatalf("unchecked messages accepted with the strict policy: %v", err)
+	}
+	validator = NewValidator(checker, Optimistic, time.Minute)
+	for i := 0; i < 2; i++ {
+		if err := validator.CheckAccessList(ctx, entries, interoptypes.CrossUnsafe, desc); err != nil {
+			t.Fatalf("unchecked messages rejected with the optimistic policy: %v", err)
+		}
+	}
+	if checker.calls != 3 {
+		t.Fatalf("unavailability cached: %d calls", checker.calls)
+	}
+	// Cancelled checks are not accepted optimistically
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := validator.CheckAccessList(cancelled, entries, interoptypes.CrossUnsafe, desc); err == nil {
+		t.Fatal("cancelled check accepted with the optimistic policy")
+	}
+	if _, err := ParseFailurePolicy("lenient"); err == nil {
+		t.Fatal("unknown failure policy accepted")
+	}
+}
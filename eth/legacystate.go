@@ -0,0 +1,138 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// legacyStateCache is the database cache allowance of the legacy state, in
+// megabytes. The legacy state only serves reads, it does not need much.
+const legacyStateCache = 64
+
+// legacyState is a read-only hash scheme database left over by the migration of
+// the node to the path scheme. It serves the historical state the path scheme
+// does not keep, for the blocks up to the migration point.
+type legacyState struct {
+	dir   string
+	db    ethdb.Database
+	state state.Database
+	head  uint64 // Migration point, number of the head block of the legacy database
+}
+
+// openLegacyState opens the hash scheme database in the given directory, read
+// only. The migration point is the head block of the database.
+func openLegacyState(stack *node.Node, dir string) (*legacyState, error) {
+	db, err := stack.OpenDatabaseWithFreezer(dir, legacyStateCache, 0, "", "eth/db/legacystate/", true)
+	if err != nil {
+		return nil, err
+	}
+	if scheme := rawdb.ReadStateScheme(db); scheme != rawdb.HashScheme {
+		db.Close()
+		return nil, fmt.Errorf("legacy state in %s is not in hash scheme (%q)", dir, scheme)
+	}
+	hash := rawdb.ReadHeadBlockHash(db)
+	head := rawdb.ReadHeaderNumber(db, hash)
+	if head == nil {
+		db.Close()
+		return nil, fmt.Errorf("legacy state in %s has no head block", dir)
+	}
+	tdb := triedb.NewDatabase(db, &triedb.Config{HashDB: triedb.HashDefaults.HashDB})
+	return &legacyState{
+		dir:   dir,
+		db:    db,
+		state: state.NewDatabase(tdb, nil),
+		head:  *head,
+	}, nil
+}
+
+// stateAt returns the state of the given block, if it precedes the migration
+// point and is in the legacy database.
+func (l *legacyState) stateAt(header *types.Header) (*state.StateDB, error) {
+	if header.Number.Uint64() > l.head {
+		return nil, fmt.Errorf("block %d after the state scheme migration at %d", header.Number, l.head)
+	}
+	return state.New(header.Root, l.state)
+}
+
+// close releases the legacy database. Reads still in flight fail.
+func (l *legacyState) close() {
+	l.state.TrieDB().Close()
+	l.db.Close()
+}
+
+// stateAt returns the state with the given root, falling back to the legacy
+// hash scheme state, if attached, when the chain does not have it anymore.
+func (s *Ethereum) stateAt(header *types.Header) (*state.StateDB, error) {
+	statedb, err := s.blockchain.StateAt(header.Root)
+	if err == nil {
+		return statedb, nil
+	}
+	legacy := s.legacyState.Load()
+	if legacy == nil {
+		return nil, err
+	}
+	if statedb, lerr := legacy.stateAt(header); lerr == nil {
+		return statedb, nil
+	}
+	return nil, err
+}
+
+// LegacyStateInfo describes the attached legacy state.
+type LegacyStateInfo struct {
+	Dir            string         `json:"dir"`
+	MigrationBlock hexutil.Uint64 `json:"migrationBlock"` // Last block served from the legacy state
+}
+
+// attachLegacyState opens the legacy state in the given directory and serves
+// the historical reads from it, replacing the previously attached one if any.
+func (s *Ethereum) attachLegacyState(dir string) (*LegacyStateInfo, error) {
+	if s.blockchain.TrieDB().Scheme() != rawdb.PathScheme {
+		return nil, errors.New("legacy state only supported in path scheme")
+	}
+	legacy, err := openLegacyState(s.stack, dir)
+	if err != nil {
+		return nil, err
+	}
+	if prev := s.legacyState.Swap(legacy); prev != nil {
+		prev.close()
+	}
+	log.Info("Attached legacy state", "dir", dir, "migration", legacy.head)
+	return &LegacyStateInfo{Dir: dir, MigrationBlock: hexutil.Uint64(legacy.head)}, nil
+}
+
+// detachLegacyState stops serving the historical reads from the legacy state
+// and closes it. It reports whether a legacy state was attached.
+func (s *Ethereum) detachLegacyState() bool {
+	legacy := s.legacyState.Swap(nil)
+	if legacy == nil {
+		return false
+	}
+	legacy.close()
+	log.Info("Detached legacy state", "dir", legacy.dir)
+	return true
+}
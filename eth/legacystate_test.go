@@ -0,0 +1,85 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/pathdb"
+)
+
+func TestLegacyState(t *testing.T) {
+	stack, err := node.New(&node.Config{DataDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	defer stack.Close()
+
+	// Write a hash scheme chain, as left by a migration
+	addr := common.Address{0x01}
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(1000)}},
+	}
+	db, err := stack.OpenDatabaseWithFreezer("legacy", 16, 16, "", "", false)
+	if err != nil {
+		t.Fatalf("failed to create legacy database: %v", err)
+	}
+	genesis := gspec.MustCommit(db, triedb.NewDatabase(db, triedb.HashDefaults))
+	db.Close()
+
+	legacy, err := openLegacyState(stack, "legacy")
+	if err != nil {
+		t.Fatalf("failed to open legacy state: %v", err)
+	}
+	defer legacy.close()
+
+	statedb, err := legacy.stateAt(genesis.Header())
+	if err != nil {
+		t.Fatalf("failed to read legacy state: %v", err)
+	}
+	if balance := statedb.GetBalance(addr); balance.Uint64() != 1000 {
+		t.Fatalf("wrong legacy balance: have %v, want %v", balance, 1000)
+	}
+	// Blocks after the migration point are not served
+	next := types.CopyHeader(genesis.Header())
+	next.Number = big.NewInt(1)
+	if _, err := legacy.stateAt(next); err == nil {
+		t.Fatal("state after the migration point served")
+	}
+	// Path scheme databases are refused
+	db, err = stack.OpenDatabaseWithFreezer("path", 16, 16, "", "", false)
+	if err != nil {
+		t.Fatalf("failed to create path database: %v", err)
+	}
+	tdb := triedb.NewDatabase(db, &triedb.Config{PathDB: pathdb.Defaults})
+	gspec.MustCommit(db, tdb)
+	tdb.Close()
+	db.Close()
+
+	if legacy, err := openLegacyState(stack, "path"); err == nil {
+		legacy.close()
+		t.Fatal("path scheme database accepted as legacy state")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/internal/membudget"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+)
+
+const (
+	// txMemoryEstimate is the estimated memory held by a pooled transaction,
+	// including the indexes of the pool.
+	txMemoryEstimate = 2048
+
+	// traceHeavyShare is the share of the memory ceiling reserved by a heavy
+	// trace, which also caps the output of the struct logger.
+	traceHeavyShare = 32
+
+	// traceLightReserve is the memory reserved by a light trace.
+	traceLightReserve = 256 * 1024
+)
+
+// errMemoryPressure is returned when a trace is refused to relieve memory.
+var errMemoryPressure = errors.New("trace refused due to memory pressure")
+
+// cacheScales are the ratios of the initial cache allowances kept at every
+// memory pressure level.
+var cacheScales = map[membudget.Level]float64{
+	membudget.LevelNormal: 1,
+	membudget.LevelSoft:   0.5,
+	membudget.LevelHard:   0.25,
+}
+
+// responseLimiter accounts the RPC responses in a memory budget account.
+type responseLimiter struct{ account *membudget.Account }
+
+func (l responseLimiter) AcquireResponse(size int) bool { return l.account.Reserve(uint64(size)) }
+func (l responseLimiter) ReleaseResponse(size int)      { l.account.Release(uint64(size)) }
+
+// newMemoryBudget creates the memory budget of the node, tracking the caches,
+// the transaction pool, the RPC responses and the trace buffers against the
+// configured ceiling, and shrinking the caches under pressure.
+func newMemoryBudget(s *Ethereum, stack *node.Node) *membudget.Budget {
+	config := membudget.DefaultConfig
+	config.Limit = uint64(s.config.MemoryLimit) * 1024 * 1024
+
+	budget := membudget.New(config)
+	budget.Track("caches", func() uint64 {
+		var total int
+		for _, size := range s.cacheManager.Sizes() {
+			total += size
+		}
+		return uint64(total)
+	})
+	budget.Track("database", func() uint64 {
+		return uint64(s.config.DatabaseCache) * 1024 * 1024
+	})
+	budget.Track("trie", func() uint64 {
+		diffs, nodes, preimages := s.blockchain.TrieDB().Size()
+		return uint64(diffs + nodes + preimages)
+	})
+	budget.Track("txpool", func() uint64 {
+		pending, queued := s.txPool.Stats()
+		return uint64(pending+queued) * txMemoryEstimate
+	})
+	stack.SetRPCResponseLimiter(responseLimiter{budget.Account("rpc")})
+	s.traceAccount = budget.Account("tracers")
+
+	budget.OnPressure(func(level membudget.Level) {
+		s.cacheManager.Scale(cacheScales[level])
+	})
+	log.Info("Enabled memory budget", "limit", common.StorageSize(config.Limit))
+	return budget
+}
+
+// reserveTrace reserves the memory of a trace from the budget, returning the
+// release function and the cap of the struct logger output.
+func (s *Ethereum) reserveTrace(heavy bool) (func(), int, error) {
+	if s.memBudget == nil {
+		return func() {}, 0, nil
+	}
+	size, limit := uint64(traceLightReserve), 0
+	if heavy {
+		size = s.memBudget.Limit() / traceHeavyShare
+		limit = int(size)
+	}
+	if !s.traceAccount.Reserve(size) {
+		return nil, 0, errMemoryPressure
+	}
+	return func() { s.traceAccount.Release(size) }, limit, nil
+}
@@ -17,12 +17,26 @@
 package eth
 
 import (
+	"sync"
+
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/forkid"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+var (
+	// The discovery efficiency meters track the number of nodes yielded by the
+	// discovery sources and the number of them matching the local chain.
+	dhtSeenMeter      = metrics.NewRegisteredMeter("eth/discovery/dht/seen", nil)
+	dhtMatchedMeter   = metrics.NewRegisteredMeter("eth/discovery/dht/matched", nil)
+	topicSeenMeter    = metrics.NewRegisteredMeter("eth/discovery/topic/seen", nil)
+	topicMatchedMeter = metrics.NewRegisteredMeter("eth/discovery/topic/matched", nil)
+)
+
 // enrEntry is the ENR entry which advertises `eth` protocol on the discovery.
 type enrEntry struct {
 	ForkID forkid.ID // Fork identifier per EIP-2124
@@ -68,6 +82,10 @@ func currentENREntry(chain *core.BlockChain) *enrEntry {
 
 // NewNodeFilter returns a filtering function that returns whether the provided
 // enode advertises a forkid compatible with the current chain.
+//
+// The chain identity is deliberately not checked: differing configurations are
+// expected during rolling upgrades, and incompatible fork schedules are already
+// caught by the fork ID.
 func NewNodeFilter(chain *core.BlockChain) func(*enode.Node) bool {
 	filter := forkid.NewFilter(chain)
 	return func(n *enode.Node) bool {
@@ -79,3 +97,126 @@ func NewNodeFilter(chain *core.BlockChain) func(*enode.Node) bool {
 		return err == nil
 	}
 }
+
+// meteredFilter wraps a node filter, tracking the number of checked and matched
+// nodes in the given meters.
+func meteredFilter(filter func(*enode.Node) bool, seen, matched *metrics.Meter) func(*enode.Node) bool {
+	return func(n *enode.Node) bool {
+		seen.Mark(1)
+		if !filter(n) {
+			return false
+		}
+		matched.Mark(1)
+		return true
+	}
+}
+
+// NewDHTIterator returns an iterator of the nodes found by random walks of the
+// discovery DHT, which advertise a forkid compatible with the current chain.
+func NewDHTIterator(chain *core.BlockChain, it enode.Iterator) enode.Iterator {
+	return enode.Filter(it, meteredFilter(NewNodeFilter(chain), dhtSeenMeter, dhtMatchedMeter))
+}
+
+// TopicDiscovery is the discovery mechanism advertising and searching nodes
+// by topic.
+type TopicDiscovery interface {
+	RegisterTopic(topic enode.ID) (stop func())
+	TopicNodes(topic enode.ID) enode.Iterator
+}
+
+// Topic returns the discovery topic of the nodes running the current fork of
+// the chain, derived from the chain ID and the fork ID.
+func Topic(chain *core.BlockChain) enode.ID {
+	entry := currentENREntry(chain)
+	blob, _ := rlp.EncodeToBytes([]interface{}{chain.Config().ChainID, entry.ForkID.Hash}) // cannot fail
+	return enode.ID(crypto.Keccak256Hash(blob))
+}
+
+// StartTopicAdvertiser starts advertising the local node under the topic of the
+// chain, switching the topic whenever a fork is passed.
+func StartTopicAdvertiser(chain *core.BlockChain, disc TopicDiscovery) {
+	var newHead = make(chan core.ChainHeadEvent, 10)
+	sub := chain.SubscribeChainHeadEvent(newHead)
+
+	topic := Topic(chain)
+	stop := disc.RegisterTopic(topic)
+	log.Info("Advertising chain discovery topic", "topic", topic)
+	go func() {
+		defer func() {
+			sub.Unsubscribe()
+			stop()
+		}()
+		for {
+			select {
+			case <-newHead:
+				if next := Topic(chain); next != topic {
+					stop()
+					topic, stop = next, disc.RegisterTopic(next)
+					log.Info("Switched chain discovery topic", "topic", topic)
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+}
+
+// NewTopicIterator returns an iterator of the nodes advertised under the topic
+// of the chain, which advertise a forkid compatible with the current chain. The
+// search follows the topic changes caused by passing forks.
+func NewTopicIterator(chain *core.BlockChain, disc TopicDiscovery) enode.Iterator {
+	it := &topicIterator{
+		chain:  chain,
+		disc:   disc,
+		filter: meteredFilter(NewNodeFilter(chain), topicSeenMeter, topicMatchedMeter),
+	}
+	it.topic = Topic(chain)
+	it.inner = enode.Filter(disc.TopicNodes(it.topic), it.filter)
+	return it
+}
+
+// topicIterator iterates over the nodes advertised under the current topic of
+// the chain.
+type topicIterator struct {
+	chain  *core.BlockChain
+	disc   TopicDiscovery
+	filter func(*enode.Node) bool
+
+	mu     sync.Mutex
+	topic  enode.ID
+	inner  enode.Iterator
+	closed bool
+}
+
+// Next moves to the next node, switching the search to the new topic if a fork
+// was passed.
+func (it *topicIterator) Next() bool {
+	it.mu.Lock()
+	if it.closed {
+		it.mu.Unlock()
+		return false
+	}
+	if topic := Topic(it.chain); topic != it.topic {
+		it.inner.Close()
+		it.topic, it.inner = topic, enode.Filter(it.disc.TopicNodes(topic), it.filter)
+	}
+	inner := it.inner
+	it.mu.Unlock()
+
+	return inner.Next()
+}
+
+// Node returns the current node.
+func (it *topicIterator) Node() *enode.Node {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.inner.Node()
+}
+
+// Close ends the iterator.
+func (it *topicIterator) Close() {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.closed = true
+	it.inner.Close()
+}
@@ -120,7 +120,7 @@ func MakeProtocols(backend Backend, network uint64, disc enode.Iterator) []p2p.P
 				return backend.PeerInfo(id)
 			},
 			DialCandidates: disc,
-			Attributes:     []enr.Entry{currentENREntry(backend.Chain())},
+			Attributes:     []enr.Entry{currentENREntry(backend.Chain()), NewIdentity(backend.Chain())},
 		})
 	}
 	return protocols
@@ -249,13 +249,19 @@ func handleGetReceipts(backend Backend, msg Decoder, peer *Peer) error {
 	if err := msg.Decode(&query); err != nil {
 		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 	}
-	response := ServiceGetReceiptsQuery(backend.Chain(), query.GetReceiptsRequest)
+	response := serviceGetReceiptsQuery(backend.Chain(), query.GetReceiptsRequest, peer.slim())
 	return peer.ReplyReceiptsRLP(query.RequestId, response)
 }
 
 // ServiceGetReceiptsQuery assembles the response to a receipt query. It is
 // exposed to allow external packages to test protocol behavior.
 func ServiceGetReceiptsQuery(chain *core.BlockChain, query GetReceiptsRequest) []rlp.RawValue {
+	return serviceGetReceiptsQuery(chain, query, false)
+}
+
+// serviceGetReceiptsQuery assembles the response to a receipt query, encoding
+// the receipts in the slim encoding if requested.
+func serviceGetReceiptsQuery(chain *core.BlockChain, query GetReceiptsRequest, slim bool) []rlp.RawValue {
 	// Gather state data until the fetch or network limits is reached
 	var (
 		bytes    int
@@ -274,7 +280,16 @@ func ServiceGetReceiptsQuery(chain *core.BlockChain, query GetReceiptsRequest) [
 			}
 		}
 		// If known, encode and queue for response packet
-		if encoded, err := rlp.EncodeToBytes(results); err != nil {
+		var (
+			encoded []byte
+			err     error
+		)
+		if slim {
+			encoded, err = encodeSlimReceipts(results)
+		} else {
+			encoded, err = rlp.EncodeToBytes(results)
+		}
+		if err != nil {
 			log.Error("Failed to encode receipt", "err", err)
 		} else {
 			receipts = append(receipts, encoded)
@@ -343,8 +358,8 @@ func handleBlockBodies(backend Backend, msg Decoder, peer *Peer) error {
 
 func handleReceipts(backend Backend, msg Decoder, peer *Peer) error {
 	// A batch of receipts arrived to one of our previous requests
-	res := new(ReceiptsPacket)
-	if err := msg.Decode(res); err != nil {
+	res, err := decodeReceiptsPacket(msg)
+	if err != nil {
 		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 	}
 	metadata := func() interface{} {
@@ -69,6 +69,7 @@ func (p *Peer) Handshake(network uint64, head common.Hash, genesis common.Hash,
 			return p2p.DiscReadTimeout
 		}
 	}
+	p.forkID = status.ForkID
 	return nil
 }
 
@@ -118,6 +119,8 @@ func markError(p *Peer, err error) {
 		m.genesisMismatch.Mark(1)
 	case errForkIDRejected:
 		m.forkidRejected.Mark(1)
+	case errConfigMismatch:
+		m.configMismatch.Mark(1)
 	case p2p.DiscReadTimeout:
 		m.timeoutError.Mark(1)
 	default:
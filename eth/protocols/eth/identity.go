@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/version"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// errConfigMismatch is returned when a peer runs the same genesis with a
+// different chain configuration, e.g. a fork of the software or a node midway
+// through a rolling upgrade.
+var errConfigMismatch = errors.New("chain config mismatch")
+
+// Identity describes the build and chain configuration of a node. It is
+// advertised both in the devp2p handshake and as the `chain` ENR entry, so that
+// nodes running differing chain configurations can be spotted up front instead
+// of only on the first diverging block. It is purely informational, peers are
+// accepted or rejected by their fork ID.
+type Identity struct {
+	Genesis common.Hash // Hash of the genesis block
+	Config  common.Hash // Hash of the chain configuration, see ConfigHash
+	Commit  []byte      // VCS commit of the build, truncated to 4 bytes
+
+	// Ignore additional fields (for forward compatibility).
+	Rest []rlp.RawValue `rlp:"tail"`
+}
+
+// ENRKey implements enr.Entry.
+func (id Identity) ENRKey() string {
+	return "chain"
+}
+
+// ConfigHash returns the hash identifying a chain configuration, the keccak256
+// of its JSON encoding.
+func ConfigHash(config *params.ChainConfig) common.Hash {
+	blob, err := json.Marshal(config)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode chain config: %v", err)) // cannot happen
+	}
+	return crypto.Keccak256Hash(blob)
+}
+
+// NewIdentity creates the identity of the local node running the given chain.
+func NewIdentity(chain *core.BlockChain) *Identity {
+	id := &Identity{
+		Genesis: chain.Genesis().Hash(),
+		Config:  ConfigHash(chain.Config()),
+	}
+	if vcs, ok := version.VCS(); ok {
+		commit := common.FromHex(vcs.Commit)
+		if len(commit) > 4 {
+			commit = commit[:4]
+		}
+		id.Commit = commit
+	}
+	return id
+}
+
+// Check returns an error if the remote identity runs the same chain with a
+// different chain configuration. Identities of other chains are left to the
+// genesis and fork ID checks.
+func (id *Identity) Check(remote *Identity) error {
+	if remote.Genesis != id.Genesis || remote.Config == id.Config {
+		return nil
+	}
+	return fmt.Errorf("%w: %x (!= %x), commit %x", errConfigMismatch, remote.Config, id.Config, remote.Commit)
+}
+
+// CheckIdentity compares the identity advertised by the peer in the devp2p
+// handshake against the local one, metering and returning any mismatch. Peers
+// not advertising an identity, or an undecodable one, are never reported.
+func (p *Peer) CheckIdentity(local *Identity) error {
+	raw := p.Peer.Identity()
+	if len(raw) == 0 {
+		return nil
+	}
+	var remote Identity
+	if err := rlp.DecodeBytes(raw, &remote); err != nil {
+		return nil
+	}
+	if err := local.Check(&remote); err != nil {
+		markError(p, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that identities of the same chain with a different chain configuration
+// are reported by the handshake check, but not filtered out on discovery.
+func TestIdentityCheck(t *testing.T) {
+	backend := newTestBackend(1)
+	defer backend.close()
+
+	var (
+		local   = NewIdentity(backend.chain)
+		config  = ConfigHash(params.MergedTestChainConfig)
+		foreign = &Identity{Genesis: common.Hash{0x01}, Config: config, Commit: []byte{0xc0, 0xde}}
+		forked  = &Identity{Genesis: local.Genesis, Config: config, Commit: []byte{0xc0, 0xde}}
+	)
+	if local.Config != ConfigHash(backend.chain.Config()) {
+		t.Fatalf("local config hash mismatch")
+	}
+	if local.Config == config {
+		t.Fatalf("differing chain configs share a hash")
+	}
+	if err := local.Check(local); err != nil {
+		t.Errorf("own identity rejected: %v", err)
+	}
+	if err := local.Check(foreign); err != nil {
+		t.Errorf("identity of another chain rejected: %v", err)
+	}
+	if err := local.Check(forked); !errors.Is(err, errConfigMismatch) {
+		t.Errorf("forked identity error mismatch: have %v, want %v", err, errConfigMismatch)
+	}
+	// Nodes are filtered by their fork ID alone, whatever identity they advertise
+	// or even if not advertising any
+	filter := NewNodeFilter(backend.chain)
+	for i, test := range []struct {
+		identity *Identity
+		want     bool
+	}{
+		{nil, true},
+		{local, true},
+		{foreign, true},
+		{forked, true},
+	} {
+		key, _ := crypto.GenerateKey()
+
+		var r enr.Record
+		r.Set(enr.IPv4(net.IP{127, 0, 0, 1}))
+		r.Set(enr.TCP(30303))
+		r.Set(enr.UDP(30303))
+		r.Set(currentENREntry(backend.chain))
+		if test.identity != nil {
+			r.Set(test.identity)
+		}
+		if err := enode.SignV4(&r, key); err != nil {
+			t.Fatalf("test %d: failed to sign record: %v", i, err)
+		}
+		node, err := enode.New(enode.ValidSchemes, &r)
+		if err != nil {
+			t.Fatalf("test %d: failed to create node: %v", i, err)
+		}
+		if have := filter(node); have != test.want {
+			t.Errorf("test %d: filter mismatch: have %v, want %v", i, have, test.want)
+		}
+	}
+}
@@ -58,6 +58,9 @@ type hsMeters struct {
 
 	// forkidRejected measures the number of differing forkids.
 	forkidRejected *metrics.Meter
+
+	// configMismatch measures the number of differing chain configurations.
+	configMismatch *metrics.Meter
 }
 
 // newHandshakeMeters registers and returns handshake meters for the given
@@ -70,6 +73,7 @@ func newHandshakeMeters(base string) *hsMeters {
 		protocolVersionMismatch: metrics.NewRegisteredMeter(base+"error/version", nil),
 		genesisMismatch:         metrics.NewRegisteredMeter(base+"error/genesis", nil),
 		forkidRejected:          metrics.NewRegisteredMeter(base+"error/forkid", nil),
+		configMismatch:          metrics.NewRegisteredMeter(base+"error/config", nil),
 	}
 }
 
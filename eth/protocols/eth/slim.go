@@ -0,0 +1,193 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SlimProtocolName is the name of the devp2p capability advertised by nodes
+// willing to exchange blocks with their trusted peers without the fields the
+// receiver can derive itself. The capability carries no messages, it only
+// changes the encoding used by the `eth` protocol towards the peer.
+const SlimProtocolName = "slim"
+
+// slimProtocolVersions are the supported versions of the `slim` capability.
+var slimProtocolVersions = []uint{1}
+
+// MakeSlimProtocols constructs the P2P protocol definitions for `slim`.
+func MakeSlimProtocols() []p2p.Protocol {
+	protocols := make([]p2p.Protocol, len(slimProtocolVersions))
+	for i, version := range slimProtocolVersions {
+		protocols[i] = p2p.Protocol{
+			Name:    SlimProtocolName,
+			Version: version,
+			Length:  0,
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				// Nothing to exchange, wait until the peer disconnects
+				for {
+					msg, err := rw.ReadMsg()
+					if err != nil {
+						return err
+					}
+					msg.Discard()
+				}
+			},
+		}
+	}
+	return protocols
+}
+
+// slim reports whether blocks should be sent to the peer in the slim encoding,
+// i.e. both sides negotiated the `slim` capability and the peer is trusted.
+// Everyone else gets the standard `eth` encoding.
+func (p *Peer) slim() bool {
+	return p.Trusted() && p.RunningCap(SlimProtocolName, slimProtocolVersions)
+}
+
+// slimReceiptRLP is the slim network encoding of a receipt, leaving out the
+// logs bloom, which the receiver derives from the logs. The transaction type
+// is part of the list to keep typed and legacy receipts uniform.
+type slimReceiptRLP struct {
+	Type                  uint8
+	PostStateOrStatus     []byte
+	CumulativeGasUsed     uint64
+	Logs                  []*types.Log
+	DepositNonce          *uint64 `rlp:"optional"`
+	DepositReceiptVersion *uint64 `rlp:"optional"`
+}
+
+// encodeSlimReceipts encodes the receipts of a block in the slim encoding.
+func encodeSlimReceipts(receipts types.Receipts) ([]byte, error) {
+	list := make([]*slimReceiptRLP, len(receipts))
+	for i, r := range receipts {
+		enc := &slimReceiptRLP{
+			Type:              r.Type,
+			PostStateOrStatus: r.PostState,
+			CumulativeGasUsed: r.CumulativeGasUsed,
+			Logs:              r.Logs,
+		}
+		if len(r.PostState) == 0 {
+			enc.PostStateOrStatus = []byte{}
+			if r.Status == types.ReceiptStatusSuccessful {
+				enc.PostStateOrStatus = []byte{0x01}
+			}
+		}
+		if r.Type == types.DepositTxType {
+			enc.DepositNonce, enc.DepositReceiptVersion = r.DepositNonce, r.DepositReceiptVersion
+		}
+		list[i] = enc
+	}
+	return rlp.EncodeToBytes(list)
+}
+
+// decodeReceipt decodes a receipt from the network, accepting both the
+// consensus and the slim encoding.
+//
+// Typed receipts are strings in the consensus encoding, whereas legacy ones are
+// lists with the logs bloom as the third item. In the slim encoding, receipts
+// are always lists and the third item is the cumulative gas used.
+func decodeReceipt(raw rlp.RawValue) (*types.Receipt, error) {
+	receipt := new(types.Receipt)
+
+	kind, content, _, err := rlp.Split(raw)
+	if err != nil {
+		return nil, err
+	}
+	if kind == rlp.List {
+		rest := content
+		for i := 0; i < 2 && err == nil; i++ {
+			_, _, rest, err = rlp.Split(rest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		kind, third, _, err := rlp.Split(rest)
+		if err != nil {
+			return nil, err
+		}
+		if kind != rlp.String || len(third) != types.BloomByteLength {
+			return decodeSlimReceipt(raw)
+		}
+	}
+	if err := rlp.DecodeBytes(raw, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// decodeSlimReceipt decodes a receipt in the slim encoding, deriving the logs
+// bloom from the logs.
+func decodeSlimReceipt(raw rlp.RawValue) (*types.Receipt, error) {
+	var dec slimReceiptRLP
+	if err := rlp.DecodeBytes(raw, &dec); err != nil {
+		return nil, err
+	}
+	receipt := &types.Receipt{
+		Type:              dec.Type,
+		CumulativeGasUsed: dec.CumulativeGasUsed,
+		Logs:              dec.Logs,
+	}
+	switch {
+	case bytes.Equal(dec.PostStateOrStatus, []byte{0x01}):
+		receipt.Status = types.ReceiptStatusSuccessful
+	case len(dec.PostStateOrStatus) == 0:
+		receipt.Status = types.ReceiptStatusFailed
+	case len(dec.PostStateOrStatus) == common.HashLength:
+		receipt.PostState = dec.PostStateOrStatus
+	default:
+		return nil, fmt.Errorf("invalid receipt status %x", dec.PostStateOrStatus)
+	}
+	if dec.Type == types.DepositTxType {
+		receipt.DepositNonce, receipt.DepositReceiptVersion = dec.DepositNonce, dec.DepositReceiptVersion
+	}
+	receipt.Bloom = types.CreateBloom(receipt)
+	return receipt, nil
+}
+
+// decodeReceiptsPacket decodes a receipts response, accepting the receipts in
+// both the consensus and the slim encoding.
+func decodeReceiptsPacket(msg Decoder) (*ReceiptsPacket, error) {
+	var packet struct {
+		RequestId uint64
+		Receipts  [][]rlp.RawValue
+	}
+	if err := msg.Decode(&packet); err != nil {
+		return nil, err
+	}
+	res := &ReceiptsPacket{
+		RequestId:        packet.RequestId,
+		ReceiptsResponse: make(ReceiptsResponse, len(packet.Receipts)),
+	}
+	for i, list := range packet.Receipts {
+		res.ReceiptsResponse[i] = make([]*types.Receipt, len(list))
+		for j, raw := range list {
+			receipt, err := decodeReceipt(raw)
+			if err != nil {
+				return nil, fmt.Errorf("receipt %d of block %d: %v", j, i, err)
+			}
+			res.ReceiptsResponse[i][j] = receipt
+		}
+	}
+	return res, nil
+}
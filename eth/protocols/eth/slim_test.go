@@ -0,0 +1,90 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// Tests that receipts sent in the slim encoding decode into the same receipts
+// as the consensus encoding, and that both encodings are accepted alongside.
+func TestSlimReceiptsRoundTrip(t *testing.T) {
+	var (
+		nonce   = uint64(7)
+		version = types.CanyonDepositReceiptVersion
+		logs    = []*types.Log{{
+			Address: common.Address{0x11},
+			Topics:  []common.Hash{{0x22}, {0x33}},
+			Data:    []byte{0x01, 0x02, 0x03},
+		}}
+	)
+	receipts := types.Receipts{
+		{Type: types.LegacyTxType, Status: types.ReceiptStatusFailed, CumulativeGasUsed: 21000},
+		{Type: types.LegacyTxType, PostState: common.Hash{0x44}.Bytes(), CumulativeGasUsed: 42000, Logs: logs},
+		{Type: types.DynamicFeeTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 63000, Logs: logs},
+		{Type: types.DepositTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 84000, DepositNonce: &nonce, DepositReceiptVersion: &version},
+	}
+	for _, receipt := range receipts {
+		receipt.Bloom = types.CreateBloom(receipt)
+	}
+	full, err := rlp.EncodeToBytes(receipts)
+	if err != nil {
+		t.Fatalf("failed to encode receipts: %v", err)
+	}
+	slim, err := encodeSlimReceipts(receipts)
+	if err != nil {
+		t.Fatalf("failed to encode slim receipts: %v", err)
+	}
+	if len(slim) >= len(full) {
+		t.Errorf("slim encoding not smaller: have %d, full %d", len(slim), len(full))
+	}
+	packet, err := rlp.EncodeToBytes(&struct {
+		RequestId uint64
+		Receipts  []rlp.RawValue
+	}{RequestId: 1, Receipts: []rlp.RawValue{full, slim}})
+	if err != nil {
+		t.Fatalf("failed to encode packet: %v", err)
+	}
+	res, err := decodeReceiptsPacket(p2p.Msg{Code: ReceiptsMsg, Size: uint32(len(packet)), Payload: bytes.NewReader(packet)})
+	if err != nil {
+		t.Fatalf("failed to decode packet: %v", err)
+	}
+	if res.RequestId != 1 || len(res.ReceiptsResponse) != 2 {
+		t.Fatalf("packet mismatch: id %d, blocks %d", res.RequestId, len(res.ReceiptsResponse))
+	}
+	want := types.DeriveSha(receipts, trie.NewStackTrie(nil))
+	for i, block := range res.ReceiptsResponse {
+		if have := types.DeriveSha(types.Receipts(block), trie.NewStackTrie(nil)); have != want {
+			t.Errorf("block %d: receipt root mismatch: have %x, want %x", i, have, want)
+		}
+		for j, receipt := range block {
+			if receipt.Bloom != receipts[j].Bloom {
+				t.Errorf("block %d receipt %d: bloom mismatch", i, j)
+			}
+		}
+	}
+	if have := res.ReceiptsResponse[1][3]; *have.DepositNonce != nonce || *have.DepositReceiptVersion != version {
+		t.Errorf("deposit fields mismatch: nonce %d, version %d", *have.DepositNonce, *have.DepositReceiptVersion)
+	}
+}
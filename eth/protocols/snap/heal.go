@@ -0,0 +1,94 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// healCommitQueue is the maximum number of flushed healing batches that can
+	// be waiting for the database writer. Together with the batch currently being
+	// written, this bounds the healing data held outside of the sync scheduler.
+	healCommitQueue = 4
+)
+
+// healCommitter persists flushed healing batches on a background goroutine, so
+// the sync loop can keep feeding delivered trie nodes into the scheduler while
+// the previous batch is being written to disk.
+//
+// Batches are written strictly in the order they were queued. The queue is
+// bounded, so a slow database applies back-pressure on the sync loop instead of
+// letting the pending data grow unbounded.
+type healCommitter struct {
+	queue chan ethdb.Batch // Flushed batches waiting to be written
+	pend  sync.WaitGroup   // Tracks queued and in-flight batches
+	done  chan struct{}    // Closed when the writer goroutine exits
+}
+
+// newHealCommitter creates a committer and starts its writer goroutine.
+func newHealCommitter() *healCommitter {
+	c := &healCommitter{
+		queue: make(chan ethdb.Batch, healCommitQueue),
+		done:  make(chan struct{}),
+	}
+	go c.loop()
+	return c
+}
+
+// loop writes the queued batches to the database until the committer is closed.
+func (c *healCommitter) loop() {
+	defer close(c.done)
+
+	for batch := range c.queue {
+		start := time.Now()
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed to persist healing data", "err", err)
+		}
+		healCommitTimer.UpdateSince(start)
+		healCommitQueueGauge.Update(int64(len(c.queue)))
+
+		log.Debug("Persisted set of healing data", "type", "trienodes", "bytes", common.StorageSize(batch.ValueSize()))
+		c.pend.Done()
+	}
+}
+
+// commit schedules a batch for writing, blocking if the queue is full.
+func (c *healCommitter) commit(batch ethdb.Batch) {
+	c.pend.Add(1)
+
+	start := time.Now()
+	c.queue <- batch
+	healCommitWaitTimer.UpdateSince(start)
+	healCommitQueueGauge.Update(int64(len(c.queue)))
+}
+
+// wait blocks until all the batches scheduled so far are written to disk.
+func (c *healCommitter) wait() {
+	c.pend.Wait()
+}
+
+// close flushes all the pending batches and terminates the writer goroutine.
+func (c *healCommitter) close() {
+	close(c.queue)
+	<-c.done
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// blockingBatch is a database batch which doesn't write until released.
+type blockingBatch struct {
+	ethdb.Batch
+	release chan struct{}
+}
+
+func (b *blockingBatch) Write() error {
+	<-b.release
+	return b.Batch.Write()
+}
+
+// Tests that the heal committer persists batches in order and that a full
+// commit queue blocks the caller until the writer catches up.
+func TestHealCommitter(t *testing.T) {
+	var (
+		db        = rawdb.NewMemoryDatabase()
+		release   = make(chan struct{})
+		committer = newHealCommitter()
+	)
+	defer committer.close()
+
+	// Fill up the writer and the queue, all of which should succeed without
+	// blocking the caller
+	for i := 0; i < healCommitQueue+1; i++ {
+		batch := &blockingBatch{Batch: db.NewBatch(), release: release}
+		batch.Put([]byte("key"), []byte{byte(i)})
+		committer.commit(batch)
+	}
+	// The next batch must be blocked until the writer makes room
+	done := make(chan struct{})
+	go func() {
+		batch := db.NewBatch()
+		batch.Put([]byte("key"), []byte{byte(healCommitQueue + 1)})
+		committer.commit(batch)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("commit succeeded with full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	<-done
+
+	// Wait for everything to be flushed and ensure the last write won
+	committer.wait()
+	blob, err := db.Get([]byte("key"))
+	if err != nil {
+		t.Fatalf("failed to retrieve healed data: %v", err)
+	}
+	if !bytes.Equal(blob, []byte{byte(healCommitQueue + 1)}) {
+		t.Fatalf("healing batches written out of order: have %x, want %x", blob, []byte{byte(healCommitQueue + 1)})
+	}
+}
@@ -66,4 +66,11 @@ var (
 	// discarded during the snap sync.
 	largeStorageDiscardGauge = metrics.NewRegisteredGauge("eth/protocols/snap/sync/storage/chunk/discard", nil)
 	largeStorageResumedGauge = metrics.NewRegisteredGauge("eth/protocols/snap/sync/storage/chunk/resume", nil)
+
+	// healCommitTimer measures the time spent writing a flushed batch of healing
+	// data to disk, healCommitWaitTimer the time the sync loop was blocked on a
+	// full commit queue and healCommitQueueGauge the number of queued batches.
+	healCommitTimer      = metrics.NewRegisteredResettingTimer("eth/protocols/snap/sync/heal/commit", nil)
+	healCommitWaitTimer  = metrics.NewRegisteredResettingTimer("eth/protocols/snap/sync/heal/commit/wait", nil)
+	healCommitQueueGauge = metrics.NewRegisteredGauge("eth/protocols/snap/sync/heal/commit/queue", nil)
 )
@@ -369,7 +369,8 @@ type storageTask struct {
 
 // healTask represents the sync task for healing the snap-synced chunk boundaries.
 type healTask struct {
-	scheduler *trie.Sync // State trie sync scheduler defining the tasks
+	scheduler *trie.Sync     // State trie sync scheduler defining the tasks
+	committer *healCommitter // Background writer persisting the flushed healing data
 
 	trieTasks map[string]common.Hash   // Set of trie node tasks currently queued for retrieval, indexed by node path
 	codeTasks map[common.Hash]struct{} // Set of byte code tasks currently queued for retrieval, indexed by code hash
@@ -474,6 +475,7 @@ type Syncer struct {
 	storageBytes   common.StorageSize // Number of storage trie bytes persisted to disk
 
 	extProgress *SyncProgress // progress that can be exposed to external caller.
+	coverage    float64       // Fraction of the account hash space downloaded, for external callers
 
 	// Request tracking during healing phase
 	trienodeHealIdlers map[string]struct{} // Peers that aren't serving trie node requests
@@ -634,6 +636,12 @@ func (s *Syncer) Sync(root common.Hash, cancel chan struct{}) error {
 
 	log.Debug("Starting snapshot sync cycle", "root", root)
 
+	// Start the background writer for the healing data. It's terminated only
+	// after the final forced commit, so everything is on disk by the time the
+	// sync status is persisted.
+	s.healer.committer = newHealCommitter()
+	defer s.healer.committer.close()
+
 	// Flush out the last committed raw states
 	defer func() {
 		if s.stateWriter.ValueSize() > 0 {
@@ -711,6 +719,7 @@ func (s *Syncer) Sync(root common.Hash, cancel chan struct{}) error {
 			BytecodeHealSynced: s.bytecodeHealSynced,
 			BytecodeHealBytes:  s.bytecodeHealBytes,
 		}
+		s.coverage = s.accountCoverage()
 		s.lock.Unlock()
 		// Wait for something to happen
 		select {
@@ -933,6 +942,30 @@ func (s *Syncer) Progress() (*SyncProgress, *SyncPending) {
 	return s.extProgress, pending
 }
 
+// Coverage returns the fraction of the account hash space already downloaded
+// in the current sync cycle, between 0 and 1.
+func (s *Syncer) Coverage() float64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.coverage
+}
+
+// accountCoverage calculates the fraction of the account hash space already
+// downloaded, based on the remaining account tasks.
+func (s *Syncer) accountCoverage() float64 {
+	if len(s.tasks) == 0 {
+		return 1
+	}
+	gaps := new(big.Int)
+	for _, task := range s.tasks {
+		gaps.Add(gaps, new(big.Int).Sub(task.Last.Big(), task.Next.Big()))
+	}
+	fills, _ := new(big.Float).SetInt(new(big.Int).Sub(hashSpace, gaps)).Float64()
+	total, _ := new(big.Float).SetInt(hashSpace).Float64()
+	return fills / total
+}
+
 // cleanAccountTasks removes account range retrieval tasks that have already been
 // completed.
 func (s *Syncer) cleanAccountTasks() {
@@ -2272,12 +2305,12 @@ func (s *Syncer) processStorageResponse(res *storageResponse) {
 // into the healer tasks.
 func (s *Syncer) processTrienodeHealResponse(res *trienodeHealResponse) {
 	var (
-		start = time.Now()
-		fills int
+		start   = time.Now()
+		fills   int
+		results = make([]trie.NodeSyncResult, 0, len(res.hashes))
+		hashes  = make([]common.Hash, 0, len(res.hashes))
 	)
-	for i, hash := range res.hashes {
-		node := res.nodes[i]
-
+	for i, node := range res.nodes {
 		// If the trie node was not delivered, reschedule it
 		if node == nil {
 			res.task.trieTasks[res.paths[i]] = res.hashes[i]
@@ -2285,11 +2318,15 @@ func (s *Syncer) processTrienodeHealResponse(res *trienodeHealResponse) {
 		}
 		fills++
 
-		// Push the trie node into the state syncer
 		s.trienodeHealSynced++
 		s.trienodeHealBytes += common.StorageSize(len(node))
 
-		err := s.healer.scheduler.ProcessNode(trie.NodeSyncResult{Path: res.paths[i], Data: node})
+		results = append(results, trie.NodeSyncResult{Path: res.paths[i], Data: node})
+		hashes = append(hashes, res.hashes[i])
+	}
+	// Push the trie nodes into the state syncer. The nodes are decoded and
+	// cross-checked with the database concurrently.
+	for i, err := range s.healer.scheduler.ProcessNodes(results) {
 		switch err {
 		case nil:
 		case trie.ErrAlreadyProcessed:
@@ -2297,7 +2334,7 @@ func (s *Syncer) processTrienodeHealResponse(res *trienodeHealResponse) {
 		case trie.ErrNotRequested:
 			s.trienodeHealNops++
 		default:
-			log.Error("Invalid trienode processed", "hash", hash, "err", err)
+			log.Error("Invalid trienode processed", "hash", hashes[i], "err", err)
 		}
 	}
 	s.commitHealer(false)
@@ -2347,18 +2384,24 @@ func (s *Syncer) processTrienodeHealResponse(res *trienodeHealResponse) {
 	}
 }
 
+// commitHealer flushes the healing data accumulated in the scheduler once it
+// grows large enough (or unconditionally if forced) and hands it over to the
+// background committer. A forced commit also waits for every scheduled batch
+// to reach the disk.
 func (s *Syncer) commitHealer(force bool) {
 	if !force && s.healer.scheduler.MemSize() < ethdb.IdealBatchSize {
 		return
 	}
-	batch := s.db.NewBatch()
-	if err := s.healer.scheduler.Commit(batch); err != nil {
-		log.Crit("Failed to commit healing data", "err", err)
+	if s.healer.scheduler.MemSize() > 0 {
+		batch := s.db.NewBatch()
+		if err := s.healer.scheduler.Commit(batch); err != nil {
+			log.Crit("Failed to commit healing data", "err", err)
+		}
+		s.healer.committer.commit(batch)
 	}
-	if err := batch.Write(); err != nil {
-		log.Crit("Failed to persist healing data", "err", err)
+	if force {
+		s.healer.committer.wait()
 	}
-	log.Debug("Persisted set of healing data", "type", "trienodes", "bytes", common.StorageSize(batch.ValueSize()))
 }
 
 // processBytecodeHealResponse integrates an already validated bytecode response
@@ -0,0 +1,73 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txrec
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// numBuckets is the number of buckets the transaction hashes are split into by
+// their first byte. Only the hashes of the buckets whose digests differ are
+// exchanged, so that pools missing a few transactions converge with a fraction
+// of the hashes sent.
+const numBuckets = 256
+
+// bucketOf returns the index of the bucket of a transaction hash.
+func bucketOf(hash common.Hash) uint64 {
+	return uint64(hash[0])
+}
+
+// makeDigest computes the digests of the buckets of a set of hashes.
+func makeDigest(hashes []common.Hash) []BucketDigest {
+	digest := make([]BucketDigest, numBuckets)
+	for _, hash := range hashes {
+		bucket := &digest[bucketOf(hash)]
+		bucket.Count++
+		for i := range hash {
+			bucket.Xor[i] ^= hash[i]
+		}
+	}
+	return digest
+}
+
+// diffDigests returns the indices of the buckets differing between two digests.
+func diffDigests(local, remote []BucketDigest) []uint64 {
+	var diff []uint64
+	for i := range local {
+		if local[i] != remote[i] {
+			diff = append(diff, uint64(i))
+		}
+	}
+	return diff
+}
+
+// filterBuckets returns the hashes belonging to the given buckets.
+func filterBuckets(hashes []common.Hash, buckets []uint64) []common.Hash {
+	var set [numBuckets]bool
+	for _, bucket := range buckets {
+		if bucket < numBuckets {
+			set[bucket] = true
+		}
+	}
+	var filtered []common.Hash
+	for _, hash := range hashes {
+		if set[bucketOf(hash)] {
+			filtered = append(filtered, hash)
+		}
+	}
+	return filtered
+}
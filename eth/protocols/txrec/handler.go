@@ -0,0 +1,294 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txrec
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// softResponseLimit is the target maximum size of the transaction batches.
+	softResponseLimit = 2 * 1024 * 1024
+
+	// maxReconcileHashes is the maximum number of hashes sent in response to a
+	// digest. The pools converge over several rounds if they differ more.
+	maxReconcileHashes = 64 * 1024
+
+	// maxTxRequest is the maximum number of transactions requested at once.
+	maxTxRequest = 256
+
+	// firstRoundDelay is the time after the connection the first round of
+	// reconciliation is started, to converge quickly after restarts.
+	firstRoundDelay = 5 * time.Second
+)
+
+// Handler is a callback to invoke from an outside runner after the boilerplate
+// exchanges have passed.
+type Handler func(peer *Peer) error
+
+// Backend defines the transaction pool access needed to reconcile it with the
+// remote peers.
+type Backend interface {
+	// PooledHashes returns the hashes of the pending transactions of the pool.
+	PooledHashes() []common.Hash
+
+	// Has returns whether the pool holds a transaction.
+	Has(hash common.Hash) bool
+
+	// GetRLP retrieves the network encoding of a pooled transaction.
+	GetRLP(hash common.Hash) []byte
+
+	// AddTransactions adds the transactions received from a peer to the pool.
+	AddTransactions(peer *Peer, txs []*types.Transaction)
+
+	// RunPeer is invoked when a peer joins on the `txrec` protocol. Control is
+	// given back to the handler to reconcile with the peer going forward.
+	RunPeer(peer *Peer, handler Handler) error
+
+	// PeerInfo retrieves all known `txrec` information about a peer.
+	PeerInfo(id enode.ID) interface{}
+}
+
+// MakeProtocols constructs the P2P protocol definitions for `txrec`, running a
+// reconciliation round with each trusted peer at the given interval. Untrusted
+// peers are never reconciled with and their messages are ignored.
+func MakeProtocols(backend Backend, interval time.Duration) []p2p.Protocol {
+	protocols := make([]p2p.Protocol, len(ProtocolVersions))
+	for i, version := range ProtocolVersions {
+		protocols[i] = p2p.Protocol{
+			Name:    ProtocolName,
+			Version: version,
+			Length:  protocolLengths[version],
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				peer := NewPeer(version, p, rw)
+				if !p.Trusted() {
+					return idle(peer)
+				}
+				return backend.RunPeer(peer, func(peer *Peer) error {
+					return Handle(backend, peer, interval)
+				})
+			},
+			PeerInfo: func(id enode.ID) interface{} {
+				return backend.PeerInfo(id)
+			},
+		}
+	}
+	return protocols
+}
+
+// idle discards the messages of an untrusted peer until it disconnects.
+func idle(peer *Peer) error {
+	for {
+		msg, err := peer.rw.ReadMsg()
+		if err != nil {
+			return err
+		}
+		msg.Discard()
+	}
+}
+
+// Handle is the callback invoked to manage the life cycle of a `txrec` peer,
+// reconciling the pools periodically. When this function terminates, the peer
+// is disconnected.
+func Handle(backend Backend, peer *Peer, interval time.Duration) error {
+	quit := make(chan struct{})
+	defer close(quit)
+
+	go reconcileLoop(backend, peer, interval, quit)
+	for {
+		if err := HandleMessage(backend, peer); err != nil {
+			peer.Log().Debug("Message handling failed in `txrec`", "err", err)
+			return err
+		}
+	}
+}
+
+// reconcileLoop starts a reconciliation round with the peer at every interval.
+func reconcileLoop(backend Backend, peer *Peer, interval time.Duration, quit chan struct{}) {
+	timer := time.NewTimer(min(firstRoundDelay, interval))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if err := peer.SendDigest(makeDigest(backend.PooledHashes())); err != nil {
+				peer.Log().Debug("Failed to send pool digest", "err", err)
+			}
+			timer.Reset(interval)
+		case <-quit:
+			return
+		}
+	}
+}
+
+// HandleMessage is invoked whenever an inbound message is received from a
+// remote peer on the `txrec` protocol. The remote connection is torn down upon
+// returning any error.
+func HandleMessage(backend Backend, peer *Peer) error {
+	// Read the next message from the remote peer, and ensure it's fully consumed
+	msg, err := peer.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	if msg.Size > maxMessageSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
+	}
+	defer msg.Discard()
+
+	switch msg.Code {
+	case DigestMsg:
+		// A peer started a round, respond with our hashes in the differing buckets
+		var req DigestPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		if len(req.Buckets) != numBuckets {
+			return fmt.Errorf("%w: %d buckets", errBadDigest, len(req.Buckets))
+		}
+		local := backend.PooledHashes()
+		diff := diffDigests(makeDigest(local), req.Buckets)
+
+		hashes := filterBuckets(local, diff)
+		if len(hashes) > maxReconcileHashes {
+			hashes = hashes[:maxReconcileHashes]
+		}
+		return peer.SendReconcile(req.ID, diff, hashes)
+
+	case ReconcileMsg:
+		// Our round completed, fetch the transactions we miss and push the ones
+		// the peer misses
+		var res ReconcilePacket
+		if err := msg.Decode(&res); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		elapsed, ok := peer.finishRound(res.ID)
+		if !ok {
+			peer.Log().Debug("Dropping stale reconciliation", "id", res.ID)
+			return nil
+		}
+		roundMeter.Mark(1)
+		roundTimer.Update(elapsed)
+		if len(res.Buckets) == 0 {
+			inSyncMeter.Mark(1)
+			return nil
+		}
+		differMeter.Mark(int64(len(res.Buckets)))
+
+		// Exchange the transactions in the background, as pushing them from the
+		// message loop could deadlock with the peer serving our requests.
+		go func() {
+			if err := reconcile(backend, peer, res.Buckets, res.Hashes); err != nil {
+				peer.Log().Debug("Failed to reconcile transactions", "err", err)
+			}
+		}()
+		return nil
+
+	case GetTransactionsMsg:
+		var req GetTransactionsPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		txs, _ := collectTransactions(backend, req.Hashes)
+		return peer.SendTransactions(req.ID, txs)
+
+	case TransactionsMsg:
+		var res TransactionsPacket
+		if err := msg.Decode(&res); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		txs := make([]*types.Transaction, 0, len(res.Txs))
+		for i, enc := range res.Txs {
+			tx := new(types.Transaction)
+			if err := rlp.DecodeBytes(enc, tx); err != nil {
+				return fmt.Errorf("%w: transaction %d: %v", errDecode, i, err)
+			}
+			txs = append(txs, tx)
+		}
+		receivedMeter.Mark(int64(len(txs)))
+		backend.AddTransactions(peer, txs)
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %v", errInvalidMsgCode, msg.Code)
+	}
+}
+
+// reconcile requests the transactions of the peer missing from the local pool
+// and sends the local transactions of the differing buckets the peer misses.
+func reconcile(backend Backend, peer *Peer, buckets []uint64, remote []common.Hash) error {
+	var (
+		known   = make(map[common.Hash]struct{}, len(remote))
+		missing []common.Hash
+	)
+	for _, hash := range remote {
+		known[hash] = struct{}{}
+		if !backend.Has(hash) {
+			missing = append(missing, hash)
+		}
+	}
+	missingMeter.Mark(int64(len(missing)))
+	for len(missing) > 0 {
+		batch := missing[:min(len(missing), maxTxRequest)]
+		if err := peer.RequestTransactions(batch); err != nil {
+			return err
+		}
+		missing = missing[len(batch):]
+	}
+	var push []common.Hash
+	for _, hash := range filterBuckets(backend.PooledHashes(), buckets) {
+		if _, ok := known[hash]; !ok {
+			push = append(push, hash)
+		}
+	}
+	for len(push) > 0 {
+		txs, n := collectTransactions(backend, push)
+		if len(txs) > 0 {
+			pushedMeter.Mark(int64(len(txs)))
+			if err := peer.SendTransactions(0, txs); err != nil {
+				return err
+			}
+		}
+		push = push[n:]
+	}
+	return nil
+}
+
+// collectTransactions gathers the encoded pooled transactions with the given
+// hashes, up to the response size limit, returning them along with the number
+// of hashes processed. Transactions which were dropped from the pool meanwhile
+// are skipped.
+func collectTransactions(backend Backend, hashes []common.Hash) ([]rlp.RawValue, int) {
+	var (
+		txs  []rlp.RawValue
+		size int
+		n    int
+	)
+	for ; n < len(hashes) && size < softResponseLimit; n++ {
+		if enc := backend.GetRLP(hashes[n]); len(enc) > 0 {
+			txs = append(txs, enc)
+			size += len(enc)
+		}
+	}
+	return txs, n
+}
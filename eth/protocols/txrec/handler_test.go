@@ -0,0 +1,157 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txrec
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// testBackend is an in-memory transaction pool.
+type testBackend struct {
+	lock sync.Mutex
+	txs  map[common.Hash]*types.Transaction
+}
+
+func newTestBackend(txs []*types.Transaction) *testBackend {
+	b := &testBackend{txs: make(map[common.Hash]*types.Transaction)}
+	for _, tx := range txs {
+		b.txs[tx.Hash()] = tx
+	}
+	return b
+}
+
+func (b *testBackend) PooledHashes() []common.Hash {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	hashes := make([]common.Hash, 0, len(b.txs))
+	for hash := range b.txs {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+func (b *testBackend) Has(hash common.Hash) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.txs[hash] != nil
+}
+
+func (b *testBackend) GetRLP(hash common.Hash) []byte {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if tx := b.txs[hash]; tx != nil {
+		enc, _ := rlp.EncodeToBytes(tx)
+		return enc
+	}
+	return nil
+}
+
+func (b *testBackend) AddTransactions(peer *Peer, txs []*types.Transaction) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, tx := range txs {
+		b.txs[tx.Hash()] = tx
+	}
+}
+
+func (b *testBackend) RunPeer(peer *Peer, handler Handler) error { return handler(peer) }
+func (b *testBackend) PeerInfo(id enode.ID) interface{}          { return nil }
+
+func (b *testBackend) len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return len(b.txs)
+}
+
+func makeTestTxs(t *testing.T, n int) []*types.Transaction {
+	key, _ := crypto.GenerateKey()
+	signer := types.HomesteadSigner{}
+
+	txs := make([]*types.Transaction, n)
+	for i := range txs {
+		tx, err := types.SignTx(types.NewTransaction(uint64(i), common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil), signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs[i] = tx
+	}
+	return txs
+}
+
+func TestDigest(t *testing.T) {
+	txs := makeTestTxs(t, 100)
+	hashes := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash()
+	}
+	full, partial := makeDigest(hashes), makeDigest(hashes[1:])
+	if diff := diffDigests(full, full); len(diff) != 0 {
+		t.Fatalf("identical digests differ: %v", diff)
+	}
+	diff := diffDigests(full, partial)
+	if len(diff) != 1 || diff[0] != bucketOf(hashes[0]) {
+		t.Fatalf("wrong differing buckets: %v", diff)
+	}
+	filtered := filterBuckets(hashes, diff)
+	for _, hash := range filtered {
+		if bucketOf(hash) != diff[0] {
+			t.Fatalf("hash %x filtered into bucket %d", hash, diff[0])
+		}
+	}
+	if len(filtered) == 0 || filtered[0] != hashes[0] {
+		t.Fatal("hash of differing bucket not filtered")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	// Two pools sharing half of the transactions, each holding a quarter the
+	// other misses
+	var (
+		txs   = makeTestTxs(t, 200)
+		left  = newTestBackend(txs[:150])
+		right = newTestBackend(txs[50:])
+	)
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	defer net.Close()
+
+	go Handle(left, newPeer(TXREC1, "left-peer", nil, app), 20*time.Millisecond)
+	go Handle(right, newPeer(TXREC1, "right-peer", nil, net), time.Hour)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for left.len() != len(txs) || right.len() != len(txs) {
+		if time.Now().After(deadline) {
+			t.Fatalf("pools not reconciled: left %d, right %d, want %d", left.len(), right.len(), len(txs))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
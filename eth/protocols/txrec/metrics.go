@@ -0,0 +1,39 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txrec
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	// roundMeter counts the reconciliation rounds completed with the peers, and
+	// roundTimer the time they take until the differing buckets are known.
+	roundMeter = metrics.NewRegisteredMeter("eth/protocols/txrec/rounds", nil)
+	roundTimer = metrics.NewRegisteredTimer("eth/protocols/txrec/round", nil)
+
+	// inSyncMeter counts the rounds which found the pools already in sync, and
+	// differMeter the buckets found differing.
+	inSyncMeter = metrics.NewRegisteredMeter("eth/protocols/txrec/insync", nil)
+	differMeter = metrics.NewRegisteredMeter("eth/protocols/txrec/buckets/differ", nil)
+
+	// missingMeter counts the transactions requested from the peers, pushedMeter
+	// the ones sent to peers missing them and receivedMeter the ones received.
+	missingMeter  = metrics.NewRegisteredMeter("eth/protocols/txrec/missing", nil)
+	pushedMeter   = metrics.NewRegisteredMeter("eth/protocols/txrec/pushed", nil)
+	receivedMeter = metrics.NewRegisteredMeter("eth/protocols/txrec/received", nil)
+)
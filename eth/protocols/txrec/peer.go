@@ -0,0 +1,127 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txrec
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Peer is a collection of relevant information we have about a `txrec` peer.
+type Peer struct {
+	id string // Unique ID for the peer, cached
+
+	*p2p.Peer                   // The embedded P2P package peer
+	rw        p2p.MsgReadWriter // Input/output streams for txrec
+	version   uint              // Protocol version negotiated
+
+	lock       sync.Mutex
+	round      uint64    // ID of the reconciliation round in flight, zero if none
+	roundStart time.Time // Time the round in flight was started at
+	nextID     uint64    // ID of the next round or request
+
+	logger log.Logger // Contextual logger with the peer id injected
+}
+
+// NewPeer creates a wrapper for a network connection and negotiated protocol
+// version.
+func NewPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+	id := p.ID().String()
+	return newPeer(version, id, p, rw)
+}
+
+func newPeer(version uint, id string, p *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+	return &Peer{
+		id:      id,
+		Peer:    p,
+		rw:      rw,
+		version: version,
+		logger:  log.New("peer", id[:8]),
+	}
+}
+
+// ID retrieves the peer's unique identifier.
+func (p *Peer) ID() string {
+	return p.id
+}
+
+// Version retrieves the peer's negotiated `txrec` protocol version.
+func (p *Peer) Version() uint {
+	return p.version
+}
+
+// Log overrides the P2P logger with the higher level one containing only the id.
+func (p *Peer) Log() log.Logger {
+	return p.logger
+}
+
+// newID returns a fresh, non-zero round or request ID.
+func (p *Peer) newID() uint64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.nextID++
+	return p.nextID
+}
+
+// SendDigest starts a reconciliation round, sending the digest of the local
+// pool. A round still in flight is abandoned.
+func (p *Peer) SendDigest(digest []BucketDigest) error {
+	id := p.newID()
+
+	p.lock.Lock()
+	p.round, p.roundStart = id, time.Now()
+	p.lock.Unlock()
+
+	return p2p.Send(p.rw, DigestMsg, &DigestPacket{ID: id, Buckets: digest})
+}
+
+// finishRound ends the reconciliation round in flight if its ID matches,
+// returning its duration.
+func (p *Peer) finishRound(id uint64) (time.Duration, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if id == 0 || id != p.round {
+		return 0, false
+	}
+	p.round = 0
+	return time.Since(p.roundStart), true
+}
+
+// SendReconcile responds to a digest with the differing buckets and the local
+// hashes in them.
+func (p *Peer) SendReconcile(id uint64, buckets []uint64, hashes []common.Hash) error {
+	return p2p.Send(p.rw, ReconcileMsg, &ReconcilePacket{ID: id, Buckets: buckets, Hashes: hashes})
+}
+
+// RequestTransactions fetches a batch of pooled transactions.
+func (p *Peer) RequestTransactions(hashes []common.Hash) error {
+	p.logger.Trace("Fetching reconciled transactions", "count", len(hashes))
+	return p2p.Send(p.rw, GetTransactionsMsg, &GetTransactionsPacket{ID: p.newID(), Hashes: hashes})
+}
+
+// SendTransactions sends a batch of encoded transactions, in response to the
+// request with the given ID or pushed if zero.
+func (p *Peer) SendTransactions(id uint64, txs []rlp.RawValue) error {
+	return p2p.Send(p.rw, TransactionsMsg, &TransactionsPacket{ID: id, Txs: txs})
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txrec
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	TXREC1 = 1
+)
+
+// ProtocolName is the official short name of the `txrec` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "txrec"
+
+// ProtocolVersions are the supported versions of the `txrec` protocol (first
+// is primary).
+var ProtocolVersions = []uint{TXREC1}
+
+// protocolLengths are the number of implemented message corresponding to
+// different protocol versions.
+var protocolLengths = map[uint]uint64{TXREC1: 4}
+
+// maxMessageSize is the maximum cap on the size of a protocol message.
+const maxMessageSize = 10 * 1024 * 1024
+
+const (
+	DigestMsg          = 0x00
+	ReconcileMsg       = 0x01
+	GetTransactionsMsg = 0x02
+	TransactionsMsg    = 0x03
+)
+
+var (
+	errMsgTooLarge    = errors.New("message too long")
+	errDecode         = errors.New("invalid message")
+	errInvalidMsgCode = errors.New("invalid message code")
+	errBadDigest      = errors.New("bad digest")
+)
+
+// BucketDigest summarizes the transaction hashes of a bucket of the pool.
+type BucketDigest struct {
+	Count uint64      // Number of transactions in the bucket
+	Xor   common.Hash // Exclusive or of the hashes of the transactions
+}
+
+// DigestPacket starts a reconciliation round, carrying the digests of all the
+// buckets of the pool of the sender.
+type DigestPacket struct {
+	ID      uint64         // Round ID to match up the reconciliation with
+	Buckets []BucketDigest // Digests of the buckets, indexed by bucket
+}
+
+// ReconcilePacket is the response to a digest, carrying the indices of the
+// buckets that differ and the hashes of the transactions of the responder in
+// them. The initiator retrieves the transactions it misses and sends the ones
+// the responder misses.
+type ReconcilePacket struct {
+	ID      uint64        // ID of the round this is a response for
+	Buckets []uint64      // Indices of the differing buckets
+	Hashes  []common.Hash // Hashes of the transactions in the differing buckets
+}
+
+// GetTransactionsPacket requests pooled transactions by hash.
+type GetTransactionsPacket struct {
+	ID     uint64        // Request ID to match up responses with
+	Hashes []common.Hash // Hashes of the transactions to retrieve
+}
+
+// TransactionsPacket carries pooled transactions, either in response to a
+// request or pushed to a peer missing them.
+type TransactionsPacket struct {
+	ID  uint64         // ID of the request this is a response for, zero if pushed
+	Txs []rlp.RawValue // Transactions in their network encoding
+}
@@ -0,0 +1,313 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+var (
+	reexecQueuedGauge = metrics.NewRegisteredGauge("eth/reexec/queued", nil)
+	reexecActiveGauge = metrics.NewRegisteredGauge("eth/reexec/active", nil)
+	reexecCachedGauge = metrics.NewRegisteredGauge("eth/reexec/cached", nil)
+	reexecHitMeter    = metrics.NewRegisteredMeter("eth/reexec/hit", nil)
+	reexecDedupMeter  = metrics.NewRegisteredMeter("eth/reexec/dedup", nil)
+	reexecTimer       = metrics.NewRegisteredTimer("eth/reexec/duration", nil)
+)
+
+// reexecState is a historical state regenerated over an ephemeral trie database.
+// Its root is referenced once in the database on behalf of the service, which
+// is dropped after the state is evicted from the cache and no longer in use.
+type reexecState struct {
+	root     common.Hash
+	database state.Database
+	tdb      *triedb.Database
+
+	users   int  // Number of callers using the state
+	evicted bool // Whether the state is out of the cache
+}
+
+// reexecCall is an in-flight regeneration of a state, which the callers asking
+// for the same state wait for instead of regenerating it again.
+type reexecCall struct {
+	done    chan struct{}
+	state   *reexecState
+	err     error
+	waiters int // Number of callers waiting for the regeneration besides the initiator
+}
+
+// reexecService regenerates the historical states missing from the database for
+// the tracing APIs. The number of concurrent regenerations is limited, identical
+// in-flight requests are merged and the regenerated states are cached, so that
+// requests for nearby blocks can start from them instead of the disk.
+type reexecService struct {
+	eth   *Ethereum
+	slots chan struct{} // Semaphore limiting the concurrent regenerations
+
+	lock     sync.Mutex
+	cache    lru.BasicLRU[common.Hash, *reexecState]
+	size     int
+	inflight map[common.Hash]*reexecCall
+}
+
+// newReexecService creates a regeneration service running at most limit
+// re-executions at the same time and caching size regenerated states.
+func newReexecService(eth *Ethereum, limit int, size int) *reexecService {
+	if limit < 1 {
+		limit = 1
+	}
+	return &reexecService{
+		eth:      eth,
+		slots:    make(chan struct{}, limit),
+		cache:    lru.NewBasicLRU[common.Hash, *reexecState](size),
+		size:     size,
+		inflight: make(map[common.Hash]*reexecCall),
+	}
+}
+
+// state returns the state of the given block, regenerating it by re-executing
+// at most reexec blocks if it is neither cached nor being regenerated. The state
+// is only for reading, the release function must be invoked after use.
+func (s *reexecService) state(ctx context.Context, block *types.Block, reexec uint64) (*state.StateDB, tracers.StateReleaseFunc, error) {
+	hash := block.Hash()
+	for {
+		s.lock.Lock()
+		if entry := s.acquire(hash); entry != nil {
+			s.lock.Unlock()
+			reexecHitMeter.Mark(1)
+			return s.open(entry)
+		}
+		// The state is not cached, join the in-flight regeneration if there
+		// is one, otherwise start it.
+		if call, ok := s.inflight[hash]; ok {
+			call.waiters++
+			s.lock.Unlock()
+			reexecDedupMeter.Mark(1)
+
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				s.lock.Lock()
+				select {
+				case <-call.done:
+					// The regeneration finished meanwhile, hand back our share
+					if call.state != nil {
+						s.release(call.state)
+					}
+				default:
+					call.waiters--
+				}
+				s.lock.Unlock()
+				return nil, nil, ctx.Err()
+			}
+			if call.err != nil {
+				// The initiator gave up on the regeneration, retry it if we
+				// are still interested.
+				if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+					continue
+				}
+				return nil, nil, call.err
+			}
+			return s.open(call.state)
+		}
+		call := &reexecCall{done: make(chan struct{})}
+		s.inflight[hash] = call
+		s.lock.Unlock()
+
+		entry, err := s.run(ctx, block, reexec)
+
+		s.lock.Lock()
+		delete(s.inflight, hash)
+		if err == nil {
+			entry.users = 1 + call.waiters
+			s.insert(hash, entry)
+		}
+		call.state, call.err = entry, err
+		close(call.done)
+		s.lock.Unlock()
+
+		if err != nil {
+			return nil, nil, err
+		}
+		return s.open(entry)
+	}
+}
+
+// open creates a state on top of the given acquired entry, along with the
+// function to release it.
+func (s *reexecService) open(entry *reexecState) (*state.StateDB, tracers.StateReleaseFunc, error) {
+	statedb, err := state.New(entry.root, entry.database)
+	if err != nil {
+		s.lock.Lock()
+		s.release(entry)
+		s.lock.Unlock()
+		return nil, nil, err
+	}
+	var once sync.Once
+	return statedb, func() {
+		once.Do(func() {
+			s.lock.Lock()
+			s.release(entry)
+			s.lock.Unlock()
+		})
+	}, nil
+}
+
+// acquire retrieves the cached state of the given block and marks it in use.
+// The caller must hold the lock.
+func (s *reexecService) acquire(hash common.Hash) *reexecState {
+	if s.size == 0 {
+		return nil
+	}
+	entry, ok := s.cache.Get(hash)
+	if !ok {
+		return nil
+	}
+	entry.users++
+	return entry
+}
+
+// release marks the state no longer in use by a caller, dropping it from the
+// trie database if it's out of the cache as well. The caller must hold the lock.
+func (s *reexecService) release(entry *reexecState) {
+	entry.users--
+	if entry.users == 0 && entry.evicted {
+		entry.tdb.Dereference(entry.root)
+	}
+}
+
+// insert adds a regenerated state to the cache, evicting the least recently
+// used one if the cache is full. The caller must hold the lock.
+func (s *reexecService) insert(hash common.Hash, entry *reexecState) {
+	if s.size == 0 {
+		entry.evicted = true
+		return
+	}
+	if old, ok := s.cache.Peek(hash); ok {
+		s.cache.Remove(hash)
+		s.evict(old)
+	} else if s.cache.Len() >= s.size {
+		_, old, _ := s.cache.RemoveOldest()
+		s.evict(old)
+	}
+	s.cache.Add(hash, entry)
+	reexecCachedGauge.Update(int64(s.cache.Len()))
+}
+
+// evict marks a state out of the cache, dropping it from the trie database if
+// no caller uses it. The caller must hold the lock.
+func (s *reexecService) evict(entry *reexecState) {
+	entry.evicted = true
+	if entry.users == 0 {
+		entry.tdb.Dereference(entry.root)
+	}
+}
+
+// run waits for a free slot and regenerates the state of the given block.
+func (s *reexecService) run(ctx context.Context, block *types.Block, reexec uint64) (*reexecState, error) {
+	reexecQueuedGauge.Inc(1)
+	select {
+	case s.slots <- struct{}{}:
+		reexecQueuedGauge.Dec(1)
+	case <-ctx.Done():
+		reexecQueuedGauge.Dec(1)
+		return nil, ctx.Err()
+	}
+	reexecActiveGauge.Inc(1)
+	defer func() {
+		<-s.slots
+		reexecActiveGauge.Dec(1)
+	}()
+	start := time.Now()
+	entry, err := s.regenerate(ctx, block, reexec)
+	if err == nil {
+		reexecTimer.UpdateSince(start)
+	}
+	return entry, err
+}
+
+// regenerate re-executes the blocks up to the given one, starting from the
+// closest ancestor whose state is either cached or available on disk.
+func (s *reexecService) regenerate(ctx context.Context, block *types.Block, reexec uint64) (*reexecState, error) {
+	var (
+		current = block
+		statedb *state.StateDB
+		err     error
+
+		// Create an ephemeral trie.Database for isolating the live one. Otherwise
+		// the internal junks created by tracing will be persisted into the disk.
+		tdb                     = triedb.NewDatabase(s.eth.chainDb, triedb.HashDefaults)
+		database state.Database = state.NewDatabaseWithCodeCache(tdb, nil, s.eth.blockchain.CodeCache())
+	)
+	for i := uint64(0); i < reexec; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if current.NumberU64() == 0 {
+			return nil, errors.New("genesis state is missing")
+		}
+		parent := s.eth.blockchain.GetBlock(current.ParentHash(), current.NumberU64()-1)
+		if parent == nil {
+			return nil, fmt.Errorf("missing block %v %d", current.ParentHash(), current.NumberU64()-1)
+		}
+		current = parent
+
+		// Prefer a regenerated state over the disk, the blocks are re-executed
+		// into its trie database then.
+		s.lock.Lock()
+		base := s.acquire(current.Hash())
+		s.lock.Unlock()
+
+		if base != nil {
+			reexecHitMeter.Mark(1)
+			defer func() {
+				s.lock.Lock()
+				s.release(base)
+				s.lock.Unlock()
+			}()
+			database, tdb = base.database, base.tdb
+		}
+		statedb, err = state.New(current.Root(), database)
+		if err == nil || base != nil {
+			break
+		}
+	}
+	if statedb == nil {
+		var missing *trie.MissingNodeError
+		if err == nil || errors.As(err, &missing) {
+			return nil, fmt.Errorf("required historical state unavailable (reexec=%d)", reexec)
+		}
+		return nil, err
+	}
+	if _, err := s.eth.replayState(ctx, current, block.NumberU64(), statedb, database, tdb, true); err != nil {
+		return nil, err
+	}
+	return &reexecState{root: block.Root(), database: database, tdb: tdb}, nil
+}
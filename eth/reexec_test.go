@@ -0,0 +1,170 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newReexecTester creates a chain long enough for the states of its first
+// blocks to be garbage collected, with every block sending 1 wei more to
+// the recipient.
+func newReexecTester(t *testing.T, limit int, size int) (*Ethereum, common.Address) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{testAddr: {Balance: big.NewInt(params.Ether)}},
+		}
+		recipient = common.HexToAddress("0xdeadbeef")
+		signer    = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), state.TriesInMemory+16, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(testAddr), recipient, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, testKey)
+		gen.AddTx(tx)
+	})
+	chain, _ := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	t.Cleanup(chain.Stop)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	eth := &Ethereum{blockchain: chain, chainDb: db}
+	eth.reexec = newReexecService(eth, limit, size)
+	return eth, recipient
+}
+
+// checkReexecState retrieves the state of the given block and checks the
+// balance of the recipient in it.
+func checkReexecState(t *testing.T, eth *Ethereum, recipient common.Address, number uint64) *state.StateDB {
+	t.Helper()
+
+	statedb, release, err := eth.stateAtBlock(context.Background(), eth.blockchain.GetBlockByNumber(number), 64, nil, true, false)
+	if err != nil {
+		t.Fatalf("block %d: failed to retrieve state: %v", number, err)
+	}
+	t.Cleanup(release)
+	if have := statedb.GetBalance(recipient).Uint64(); have != number {
+		t.Fatalf("block %d: balance mismatch: have %d, want %d", number, have, number)
+	}
+	return statedb
+}
+
+// Tests that the regenerated states are cached, used as the base of the later
+// regenerations and only dropped once evicted and no longer in use.
+func TestReexecCache(t *testing.T) {
+	t.Parallel()
+
+	eth, recipient := newReexecTester(t, 1, 2)
+	if _, err := eth.blockchain.StateAt(eth.blockchain.GetBlockByNumber(10).Root()); err == nil {
+		t.Fatal("state of block 10 is still live")
+	}
+	held := checkReexecState(t, eth, recipient, 10)
+	checkReexecState(t, eth, recipient, 10)
+
+	entry, ok := eth.reexec.cache.Peek(eth.blockchain.GetBlockByNumber(10).Hash())
+	if !ok {
+		t.Fatal("state of block 10 not cached")
+	}
+	if entry.users != 2 {
+		t.Fatalf("users mismatch: have %d, want 2", entry.users)
+	}
+	// Block 12 is regenerated on top of block 10, sharing its trie database
+	checkReexecState(t, eth, recipient, 12)
+	if next, _ := eth.reexec.cache.Peek(eth.blockchain.GetBlockByNumber(12).Hash()); next.tdb != entry.tdb {
+		t.Fatal("state of block 12 not regenerated from block 10")
+	}
+	// Evict block 10, the held state must remain readable
+	checkReexecState(t, eth, recipient, 5)
+	if !entry.evicted {
+		t.Fatal("state of block 10 not evicted")
+	}
+	if have := held.GetBalance(recipient).Uint64(); have != 10 {
+		t.Fatalf("evicted state balance mismatch: have %d, want 10", have)
+	}
+}
+
+// Tests that concurrent requests for the same state are served by a single
+// regeneration.
+func TestReexecDedup(t *testing.T) {
+	t.Parallel()
+
+	eth, recipient := newReexecTester(t, 1, 4)
+	block := eth.blockchain.GetBlockByNumber(8)
+
+	// Occupy the only slot so that the requests pile up
+	eth.reexec.slots <- struct{}{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statedb, release, err := eth.stateAtBlock(context.Background(), block, 64, nil, true, false)
+			if err != nil {
+				t.Errorf("failed to retrieve state: %v", err)
+				return
+			}
+			defer release()
+			if have := statedb.GetBalance(recipient).Uint64(); have != 8 {
+				t.Errorf("balance mismatch: have %d, want 8", have)
+			}
+		}()
+	}
+	for {
+		eth.reexec.lock.Lock()
+		call := eth.reexec.inflight[block.Hash()]
+		waiters := 0
+		if call != nil {
+			waiters = call.waiters
+		}
+		eth.reexec.lock.Unlock()
+		if waiters == 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	<-eth.reexec.slots
+	wg.Wait()
+
+	if n := eth.reexec.cache.Len(); n != 1 {
+		t.Fatalf("cached states mismatch: have %d, want 1", n)
+	}
+	entry, _ := eth.reexec.cache.Peek(block.Hash())
+	if entry.users != 0 {
+		t.Fatalf("users mismatch: have %d, want 0", entry.users)
+	}
+	// A cancelled request must not wait for a free slot
+	eth.reexec.slots <- struct{}{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := eth.stateAtBlock(ctx, eth.blockchain.GetBlockByNumber(6), 64, nil, true, false); err != context.Canceled {
+		t.Fatalf("error mismatch: have %v, want %v", err, context.Canceled)
+	}
+}
@@ -59,6 +59,11 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 			}, nil
 		}
 	}
+	// The state is only for reading but missing from the live database, leave
+	// the regeneration to the service shared by the callers.
+	if readOnly && base == nil && eth.reexec != nil {
+		return eth.reexec.state(ctx, block, reexec)
+	}
 	// The state is both for reading and writing, or it's unavailable in disk,
 	// try to construct/recover the state over an ephemeral trie.Database for
 	// isolating the live one.
@@ -69,7 +74,7 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 			// TODO(rjl493456442), clean cache is disabled to prevent memory leak,
 			// please re-enable it for better performance.
 			tdb := triedb.NewDatabase(eth.chainDb, triedb.HashDefaults)
-			database = state.NewDatabase(tdb, nil)
+			database = state.NewDatabaseWithCodeCache(tdb, nil, eth.blockchain.CodeCache())
 			if statedb, err = state.New(block.Root(), database); err == nil {
 				log.Info("Found disk backend for state trie", "root", block.Root(), "number", block.Number())
 				return statedb, noopReleaser, nil
@@ -87,7 +92,7 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 		// TODO(rjl493456442), clean cache is disabled to prevent memory leak,
 		// please re-enable it for better performance.
 		tdb = triedb.NewDatabase(eth.chainDb, triedb.HashDefaults)
-		database = state.NewDatabase(tdb, nil)
+		database = state.NewDatabaseWithCodeCache(tdb, nil, eth.blockchain.CodeCache())
 
 		// If we didn't check the live database, do check state over ephemeral database,
 		// otherwise we would rewind past a persisted block (specific corner case is
@@ -128,14 +133,30 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 	}
 	// State is available at historical point, re-execute the blocks on top for
 	// the desired state.
+	if statedb, err = eth.replayState(ctx, current, origin, statedb, database, tdb, report); err != nil {
+		return nil, nil, err
+	}
+	return statedb, func() { tdb.Dereference(block.Root()) }, nil
+}
+
+// replayState re-executes the blocks on top of the given state, which belongs
+// to the block current, until the block numbered origin. The states of the
+// processed blocks are committed into the ephemeral tdb, with only the final
+// one left referenced. Nothing is left referenced if the re-execution fails.
+func (eth *Ethereum) replayState(ctx context.Context, current *types.Block, origin uint64, statedb *state.StateDB, database state.Database, tdb *triedb.Database, report bool) (_ *state.StateDB, err error) {
 	var (
 		start  = time.Now()
 		logged time.Time
 		parent common.Hash
 	)
+	defer func() {
+		if err != nil && parent != (common.Hash{}) {
+			tdb.Dereference(parent)
+		}
+	}()
 	for current.NumberU64() < origin {
 		if err := ctx.Err(); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		// Print progress logs if long enough time elapsed
 		if time.Since(logged) > 8*time.Second && report {
@@ -145,21 +166,20 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 		// Retrieve the next block to regenerate and process it
 		next := current.NumberU64() + 1
 		if current = eth.blockchain.GetBlockByNumber(next); current == nil {
-			return nil, nil, fmt.Errorf("block #%d not found", next)
+			return nil, fmt.Errorf("block #%d not found", next)
 		}
-		_, err := eth.blockchain.Processor().Process(current, statedb, vm.Config{})
-		if err != nil {
-			return nil, nil, fmt.Errorf("processing block %d failed: %v", current.NumberU64(), err)
+		if _, err := eth.blockchain.Processor().Process(current, statedb, vm.Config{}); err != nil {
+			return nil, fmt.Errorf("processing block %d failed: %v", current.NumberU64(), err)
 		}
 		// Finalize the state so any modifications are written to the trie
 		root, err := statedb.Commit(current.NumberU64(), eth.blockchain.Config().IsEIP158(current.Number()), eth.blockchain.Config().IsCancun(current.Number(), current.Time()))
 		if err != nil {
-			return nil, nil, fmt.Errorf("stateAtBlock commit failed, number %d root %v: %w",
+			return nil, fmt.Errorf("stateAtBlock commit failed, number %d root %v: %w",
 				current.NumberU64(), current.Root().Hex(), err)
 		}
 		statedb, err = state.New(root, database)
 		if err != nil {
-			return nil, nil, fmt.Errorf("state reset after block %d failed: %v", current.NumberU64(), err)
+			return nil, fmt.Errorf("state reset after block %d failed: %v", current.NumberU64(), err)
 		}
 		// Hold the state reference and also drop the parent state
 		// to prevent accumulating too many nodes in memory.
@@ -173,12 +193,12 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 		_, nodes, imgs := tdb.Size() // all memory is contained within the nodes return in hashdb
 		log.Info("Historical state regenerated", "block", current.NumberU64(), "elapsed", time.Since(start), "nodes", nodes, "preimages", imgs)
 	}
-	return statedb, func() { tdb.Dereference(block.Root()) }, nil
+	return statedb, nil
 }
 
 func (eth *Ethereum) pathState(block *types.Block) (*state.StateDB, func(), error) {
 	// Check if the requested state is available in the live chain.
-	statedb, err := eth.blockchain.StateAt(block.Root())
+	statedb, err := eth.stateAt(block.Header())
 	if err == nil {
 		return statedb, noopReleaser, nil
 	}
@@ -0,0 +1,197 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package statereader
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Source is a provider of state ranges, either a local Reader or a Client of a
+// remote one.
+type Source interface {
+	AccountRange(ctx context.Context, root common.Hash, start common.Hash, limit int) (*AccountRange, error)
+	StorageRange(ctx context.Context, root common.Hash, account common.Hash, start common.Hash, limit int) (*StorageRange, error)
+}
+
+// Client reads the state from the endpoint of a running node.
+type Client struct {
+	c *rpc.Client
+}
+
+// Dial connects to the state reader endpoint at the given IPC path.
+func Dial(ctx context.Context, endpoint string) (*Client, error) {
+	c, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(c), nil
+}
+
+// NewClient creates a state reader client using the given RPC client.
+func NewClient(c *rpc.Client) *Client {
+	return &Client{c: c}
+}
+
+// Close closes the underlying RPC connection.
+func (c *Client) Close() {
+	c.c.Close()
+}
+
+// AccountRange returns at most limit accounts of the state with the given root,
+// starting at the given account hash.
+func (c *Client) AccountRange(ctx context.Context, root common.Hash, start common.Hash, limit int) (*AccountRange, error) {
+	var res *AccountRange
+	if err := c.c.CallContext(ctx, &res, Namespace+"_accountRange", root, start, limit); err != nil {
+		return nil, remoteError(err, root, common.Hash{}, start)
+	}
+	return res, nil
+}
+
+// StorageRange returns at most limit storage slots of the given account in the
+// state with the given root, starting at the given slot hash.
+func (c *Client) StorageRange(ctx context.Context, root common.Hash, account common.Hash, start common.Hash, limit int) (*StorageRange, error) {
+	var res *StorageRange
+	if err := c.c.CallContext(ctx, &res, Namespace+"_storageRange", root, account, start, limit); err != nil {
+		return nil, remoteError(err, root, account, start)
+	}
+	return res, nil
+}
+
+// Code returns the contract code with the given hash.
+func (c *Client) Code(ctx context.Context, hash common.Hash) ([]byte, error) {
+	var res hexutil.Bytes
+	if err := c.c.CallContext(ctx, &res, Namespace+"_code", hash); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// remoteError converts the error of a range request back into the typed
+// StateUnavailableError if the server reported the state as gone.
+func remoteError(err error, root common.Hash, account common.Hash, start common.Hash) error {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == errCodeStateUnavailable {
+		return &StateUnavailableError{Root: root, Account: account, Start: start}
+	}
+	return err
+}
+
+// AccountIterator steps over the accounts of a state, fetching them range by
+// range from a source.
+type AccountIterator struct {
+	ctx   context.Context
+	src   Source
+	root  common.Hash
+	next  *common.Hash
+	limit int
+
+	items []Account
+	item  Account
+	err   error
+}
+
+// NewAccountIterator creates an iterator over the accounts of the state with
+// the given root, starting at the given account hash. The ranges are fetched
+// with the given size, or the maximum one if zero.
+func NewAccountIterator(ctx context.Context, src Source, root common.Hash, start common.Hash, limit int) *AccountIterator {
+	return &AccountIterator{ctx: ctx, src: src, root: root, next: &start, limit: limit}
+}
+
+// Next moves the iterator to the next account, returning false when the state
+// is exhausted or on error.
+func (it *AccountIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.err != nil || it.next == nil {
+			return false
+		}
+		res, err := it.src.AccountRange(it.ctx, it.root, *it.next, it.limit)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items, it.next = res.Accounts, res.Next
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Account returns the account the iterator is currently at.
+func (it *AccountIterator) Account() Account {
+	return it.item
+}
+
+// Err returns the error which stopped the iteration, if any. A
+// StateUnavailableError holds the hash to resume the iteration at.
+func (it *AccountIterator) Err() error {
+	return it.err
+}
+
+// StorageIterator steps over the storage slots of an account, fetching them
+// range by range from a source.
+type StorageIterator struct {
+	ctx     context.Context
+	src     Source
+	root    common.Hash
+	account common.Hash
+	next    *common.Hash
+	limit   int
+
+	items []Slot
+	item  Slot
+	err   error
+}
+
+// NewStorageIterator creates an iterator over the storage slots of the given
+// account in the state with the given root, starting at the given slot hash.
+// The ranges are fetched with the given size, or the maximum one if zero.
+func NewStorageIterator(ctx context.Context, src Source, root common.Hash, account common.Hash, start common.Hash, limit int) *StorageIterator {
+	return &StorageIterator{ctx: ctx, src: src, root: root, account: account, next: &start, limit: limit}
+}
+
+// Next moves the iterator to the next slot, returning false when the storage
+// is exhausted or on error.
+func (it *StorageIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.err != nil || it.next == nil {
+			return false
+		}
+		res, err := it.src.StorageRange(it.ctx, it.root, it.account, *it.next, it.limit)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items, it.next = res.Slots, res.Next
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Slot returns the storage slot the iterator is currently at.
+func (it *StorageIterator) Slot() Slot {
+	return it.item
+}
+
+// Err returns the error which stopped the iteration, if any. A
+// StateUnavailableError holds the hash to resume the iteration at.
+func (it *StorageIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package statereader serves consistent state snapshots to external processes,
+// so analytics jobs can iterate the accounts, storage and code of the state at
+// a given root while the node runs, instead of copying the whole datadir.
+//
+// The state is read from the snapshot, falling back to the trie if the snapshot
+// doesn't hold the requested root. It's served in ranges, each one starting at
+// the Next hash of the previous, through the Reader in-process or through a
+// dedicated IPC endpoint to a Client. The reads are rate limited, so a consumer
+// doesn't starve the block import of database bandwidth.
+//
+// Every range is read from a single state, but the node doesn't pin the root
+// between ranges: a root is only served while the node still holds it, which
+// for recent states is the in-memory layers of the last blocks. Once the root
+// is gone, the ranges fail with a StateUnavailableError carrying the hash the
+// failed range started at, so the consumer can either restart the iteration at
+// a newer root or resume it there, accepting a mix of both states.
+package statereader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"golang.org/x/time/rate"
+)
+
+// MaxRangeItems is the maximum number of accounts or storage slots returned
+// in a single range.
+const MaxRangeItems = 10000
+
+// Chain is the local chain the state is read from.
+type Chain interface {
+	// Snapshots returns the snapshot tree of the chain, nil if disabled.
+	Snapshots() *snapshot.Tree
+
+	// TrieDB returns the trie database of the chain.
+	TrieDB() *triedb.Database
+
+	// ContractCodeWithPrefix returns the contract code with the given hash.
+	ContractCodeWithPrefix(hash common.Hash) []byte
+}
+
+// Account is an account of the state.
+type Account struct {
+	Hash     common.Hash    `json:"hash"`
+	Nonce    hexutil.Uint64 `json:"nonce"`
+	Balance  *hexutil.U256  `json:"balance"`
+	Root     common.Hash    `json:"root"`
+	CodeHash common.Hash    `json:"codeHash"`
+}
+
+// AccountRange is a range of accounts of the state, ordered by hash.
+type AccountRange struct {
+	Root     common.Hash  `json:"root"`
+	Accounts []Account    `json:"accounts"`
+	Next     *common.Hash `json:"next,omitempty"` // Hash the next range starts at, nil for the last range
+}
+
+// Slot is a storage slot of an account.
+type Slot struct {
+	Hash  common.Hash   `json:"hash"`
+	Value hexutil.Bytes `json:"value"`
+}
+
+// StorageRange is a range of storage slots of an account, ordered by hash.
+type StorageRange struct {
+	Root    common.Hash  `json:"root"`
+	Account common.Hash  `json:"account"`
+	Slots   []Slot       `json:"slots"`
+	Next    *common.Hash `json:"next,omitempty"` // Hash the next range starts at, nil for the last range
+}
+
+// errCodeStateUnavailable is the JSON-RPC error code of a StateUnavailableError.
+const errCodeStateUnavailable = -32010
+
+// StateUnavailableError is returned when the requested state root isn't held
+// by the node, either because it was never known or because it has since been
+// pruned or flattened away. The iteration can be resumed at Start against a
+// root the node still holds.
+type StateUnavailableError struct {
+	Root    common.Hash `json:"root"`
+	Account common.Hash `json:"account"` // Account of the storage range, zero for account ranges
+	Start   common.Hash `json:"start"`
+}
+
+// Error implements error, returning the error message.
+func (e *StateUnavailableError) Error() string {
+	return fmt.Sprintf("state %x is not available", e.Root)
+}
+
+// ErrorCode implements rpc.Error, returning the JSON error code.
+func (e *StateUnavailableError) ErrorCode() int {
+	return errCodeStateUnavailable
+}
+
+// ErrorData implements rpc.DataError, returning the position to resume at.
+func (e *StateUnavailableError) ErrorData() interface{} {
+	return e
+}
+
+// unavailable converts the errors signalling that the state of the root has gone
+// away mid-read into a StateUnavailableError, passing through any other.
+func unavailable(err error, root common.Hash, account common.Hash, start common.Hash) error {
+	var missing *trie.MissingNodeError
+	if errors.Is(err, snapshot.ErrSnapshotStale) || errors.As(err, &missing) {
+		return &StateUnavailableError{Root: root, Account: account, Start: start}
+	}
+	return err
+}
+
+// Reader serves the state of the chain in ranges.
+type Reader struct {
+	chain   Chain
+	limiter *rate.Limiter // Limiter of the items read per second, nil if unlimited
+}
+
+// NewReader creates a state reader of the given chain, serving at most
+// rateLimit items per second, or unlimited if zero.
+func NewReader(chain Chain, rateLimit int) *Reader {
+	r := &Reader{chain: chain}
+	if rateLimit > 0 {
+		r.limiter = rate.NewLimiter(rate.Limit(rateLimit), max(rateLimit, MaxRangeItems))
+	}
+	return r
+}
+
+// AccountRange returns at most limit accounts of the state with the given root,
+// starting at the given account hash.
+func (r *Reader) AccountRange(ctx context.Context, root common.Hash, start common.Hash, limit int) (*AccountRange, error) {
+	limit = clampLimit(limit)
+
+	res := &AccountRange{Root: root, Accounts: []Account{}}
+	if snaps := r.chain.Snapshots(); snaps != nil {
+		if it, err := snaps.AccountIterator(root, start); err == nil {
+			defer it.Release()
+
+			for it.Next() {
+				if len(res.Accounts) == limit {
+					next := it.Hash()
+					res.Next = &next
+					break
+				}
+				data, err := types.FullAccount(it.Account())
+				if err != nil {
+					return nil, err
+				}
+				res.Accounts = append(res.Accounts, newAccount(it.Hash(), data))
+			}
+			if err := it.Error(); err != nil {
+				return nil, unavailable(err, root, common.Hash{}, start)
+			}
+			return res, r.throttle(ctx, len(res.Accounts))
+		}
+	}
+	// The snapshot doesn't hold the root, fall back to the trie
+	tr, err := trie.NewStateTrie(trie.StateTrieID(root), r.chain.TrieDB())
+	if err != nil {
+		return nil, &StateUnavailableError{Root: root, Start: start}
+	}
+	nodeIt, err := tr.NodeIterator(start.Bytes())
+	if err != nil {
+		return nil, unavailable(err, root, common.Hash{}, start)
+	}
+	it := trie.NewIterator(nodeIt)
+	for it.Next() {
+		if len(res.Accounts) == limit {
+			next := common.BytesToHash(it.Key)
+			res.Next = &next
+			break
+		}
+		var data types.StateAccount
+		if err := rlp.DecodeBytes(it.Value, &data); err != nil {
+			return nil, err
+		}
+		res.Accounts = append(res.Accounts, newAccount(common.BytesToHash(it.Key), &data))
+	}
+	if it.Err != nil {
+		return nil, unavailable(it.Err, root, common.Hash{}, start)
+	}
+	return res, r.throttle(ctx, len(res.Accounts))
+}
+
+// StorageRange returns at most limit storage slots of the given account in the
+// state with the given root, starting at the given slot hash.
+func (r *Reader) StorageRange(ctx context.Context, root common.Hash, account common.Hash, start common.Hash, limit int) (*StorageRange, error) {
+	limit = clampLimit(limit)
+
+	res := &StorageRange{Root: root, Account: account, Slots: []Slot{}}
+	if snaps := r.chain.Snapshots(); snaps != nil {
+		if it, err := snaps.StorageIterator(root, account, start); err == nil {
+			defer it.Release()
+
+			for it.Next() {
+				if len(res.Slots) == limit {
+					next := it.Hash()
+					res.Next = &next
+					break
+				}
+				slot, err := newSlot(it.Hash(), it.Slot())
+				if err != nil {
+					return nil, err
+				}
+				res.Slots = append(res.Slots, slot)
+			}
+			if err := it.Error(); err != nil {
+				return nil, unavailable(err, root, account, start)
+			}
+			return res, r.throttle(ctx, len(res.Slots))
+		}
+	}
+	// The snapshot doesn't hold the root, fall back to the trie
+	tr, err := trie.NewStateTrie(trie.StateTrieID(root), r.chain.TrieDB())
+	if err != nil {
+		return nil, &StateUnavailableError{Root: root, Account: account, Start: start}
+	}
+	data, err := tr.GetAccountByHash(account)
+	if err != nil {
+		return nil, unavailable(err, root, account, start)
+	}
+	if data == nil || data.Root == types.EmptyRootHash {
+		return res, nil
+	}
+	storage, err := trie.NewStateTrie(trie.StorageTrieID(root, account, data.Root), r.chain.TrieDB())
+	if err != nil {
+		return nil, unavailable(err, root, account, start)
+	}
+	nodeIt, err := storage.NodeIterator(start.Bytes())
+	if err != nil {
+		return nil, unavailable(err, root, account, start)
+	}
+	it := trie.NewIterator(nodeIt)
+	for it.Next() {
+		if len(res.Slots) == limit {
+			next := common.BytesToHash(it.Key)
+			res.Next = &next
+			break
+		}
+		slot, err := newSlot(common.BytesToHash(it.Key), it.Value)
+		if err != nil {
+			return nil, err
+		}
+		res.Slots = append(res.Slots, slot)
+	}
+	if it.Err != nil {
+		return nil, unavailable(it.Err, root, account, start)
+	}
+	return res, r.throttle(ctx, len(res.Slots))
+}
+
+// Code returns the contract code with the given hash.
+func (r *Reader) Code(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
+	code := r.chain.ContractCodeWithPrefix(hash)
+	if len(code) == 0 && hash != types.EmptyCodeHash {
+		return nil, fmt.Errorf("code %x not found", hash)
+	}
+	return code, r.throttle(ctx, 1)
+}
+
+// throttle charges the given number of items read against the rate limit,
+// holding back the response until the limit allows them.
+func (r *Reader) throttle(ctx context.Context, items int) error {
+	if r.limiter == nil || items == 0 {
+		return nil
+	}
+	return r.limiter.WaitN(ctx, items)
+}
+
+// clampLimit returns the range size to serve for the requested limit.
+func clampLimit(limit int) int {
+	if limit <= 0 || limit > MaxRangeItems {
+		return MaxRangeItems
+	}
+	return limit
+}
+
+func newAccount(hash common.Hash, data *types.StateAccount) Account {
+	return Account{
+		Hash:     hash,
+		Nonce:    hexutil.Uint64(data.Nonce),
+		Balance:  (*hexutil.U256)(data.Balance),
+		Root:     data.Root,
+		CodeHash: common.BytesToHash(data.CodeHash),
+	}
+}
+
+// newSlot decodes the RLP encoded value of a storage slot.
+func newSlot(hash common.Hash, enc []byte) (Slot, error) {
+	_, content, _, err := rlp.Split(enc)
+	if err != nil {
+		return Slot{}, err
+	}
+	return Slot{Hash: hash, Value: common.CopyBytes(content)}, nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package statereader
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// trieChain hides the snapshot of a chain, forcing the reads from the trie.
+type trieChain struct {
+	*core.BlockChain
+}
+
+func (c trieChain) Snapshots() *snapshot.Tree { return nil }
+
+// newTestChain creates a chain with a genesis state of the given number of
+// accounts, the first one being a contract with storage.
+func newTestChain(t *testing.T, accounts int, slots int) *core.BlockChain {
+	t.Helper()
+
+	alloc := make(types.GenesisAlloc)
+	for i := 0; i < accounts; i++ {
+		alloc[common.Address{byte(i + 1)}] = types.Account{Balance: big.NewInt(int64(i + 1))}
+	}
+	contract := alloc[common.Address{0x01}]
+	contract.Code = []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	contract.Storage = make(map[common.Hash]common.Hash)
+	for i := 0; i < slots; i++ {
+		contract.Storage[common.Hash{byte(i + 1)}] = common.Hash{0xff, byte(i + 1)}
+	}
+	alloc[common.Address{0x01}] = contract
+
+	config := core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	config.SnapshotWait = true
+	gspec := &core.Genesis{Config: params.TestChainConfig, Alloc: alloc}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), config, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	t.Cleanup(chain.Stop)
+	return chain
+}
+
+// Tests that the state is served identically from the snapshot and the trie,
+// range by range.
+func TestReaderRanges(t *testing.T) {
+	var (
+		chain    = newTestChain(t, 10, 7)
+		root     = chain.CurrentBlock().Root
+		contract = crypto.Keccak256Hash(common.Address{0x01}.Bytes())
+		ctx      = context.Background()
+	)
+	if _, err := chain.Snapshots().AccountIterator(root, common.Hash{}); err != nil {
+		t.Fatalf("snapshot not available: %v", err)
+	}
+	for _, limit := range []int{1, 3, 10, 0} {
+		var (
+			snap  = collectAccounts(t, NewReader(chain, 0), root, limit)
+			tries = collectAccounts(t, NewReader(trieChain{chain}, 0), root, limit)
+		)
+		if len(snap) != 10 {
+			t.Fatalf("limit %d: unexpected account count: have %d, want 10", limit, len(snap))
+		}
+		if !reflect.DeepEqual(snap, tries) {
+			t.Fatalf("limit %d: snapshot and trie accounts differ", limit)
+		}
+		var (
+			snapSlots = collectSlots(t, NewReader(chain, 0), root, contract, limit)
+			trieSlots = collectSlots(t, NewReader(trieChain{chain}, 0), root, contract, limit)
+		)
+		if len(snapSlots) != 7 {
+			t.Fatalf("limit %d: unexpected slot count: have %d, want 7", limit, len(snapSlots))
+		}
+		if !reflect.DeepEqual(snapSlots, trieSlots) {
+			t.Fatalf("limit %d: snapshot and trie slots differ", limit)
+		}
+	}
+	// Accounts without storage have empty ranges
+	res, err := NewReader(trieChain{chain}, 0).StorageRange(ctx, root, crypto.Keccak256Hash(common.Address{0x02}.Bytes()), common.Hash{}, 0)
+	if err != nil || len(res.Slots) != 0 || res.Next != nil {
+		t.Fatalf("unexpected storage of plain account: %v, %v", res, err)
+	}
+	// Unknown states are rejected with the position to resume at
+	var (
+		missing = common.Hash{0x01}
+		start   = common.Hash{0x02}
+		gone    *StateUnavailableError
+	)
+	for _, reader := range []*Reader{NewReader(chain, 0), NewReader(trieChain{chain}, 0)} {
+		_, err := reader.AccountRange(ctx, missing, start, 0)
+		if !errors.As(err, &gone) || gone.Root != missing || gone.Start != start {
+			t.Fatalf("unexpected error for unknown state: %v", err)
+		}
+		_, err = reader.StorageRange(ctx, missing, contract, start, 0)
+		if !errors.As(err, &gone) || gone.Account != contract || gone.Start != start {
+			t.Fatalf("unexpected storage error for unknown state: %v", err)
+		}
+	}
+}
+
+// Tests that the state is served over the IPC endpoint.
+func TestServerClient(t *testing.T) {
+	var (
+		chain  = newTestChain(t, 20, 30)
+		root   = chain.CurrentBlock().Root
+		ctx    = context.Background()
+		config = Config{IPCPath: filepath.Join(t.TempDir(), "statereader.ipc")}
+	)
+	server := NewServer(config, chain)
+	if err := server.Start(); err != nil {
+		t.Fatalf("failed to start server: %v", err)
+	}
+	defer server.Stop()
+
+	client, err := Dial(ctx, config.IPCPath)
+	if err != nil {
+		t.Fatalf("failed to dial server: %v", err)
+	}
+	defer client.Close()
+
+	var (
+		accounts = NewAccountIterator(ctx, client, root, common.Hash{}, 7)
+		count    int
+		slots    int
+	)
+	for accounts.Next() {
+		count++
+
+		account := accounts.Account()
+		if account.CodeHash == types.EmptyCodeHash {
+			continue
+		}
+		code, err := client.Code(ctx, account.CodeHash)
+		if err != nil {
+			t.Fatalf("failed to retrieve code: %v", err)
+		}
+		if crypto.Keccak256Hash(code) != account.CodeHash {
+			t.Fatalf("code hash mismatch: have %x, want %x", crypto.Keccak256Hash(code), account.CodeHash)
+		}
+		storage := NewStorageIterator(ctx, client, root, account.Hash, common.Hash{}, 4)
+		for storage.Next() {
+			slots++
+		}
+		if err := storage.Err(); err != nil {
+			t.Fatalf("failed to iterate storage: %v", err)
+		}
+	}
+	if err := accounts.Err(); err != nil {
+		t.Fatalf("failed to iterate accounts: %v", err)
+	}
+	if count != 20 || slots != 30 {
+		t.Fatalf("unexpected state: have %d accounts and %d slots, want 20 and 30", count, slots)
+	}
+	// Unknown states are reported as unavailable over the endpoint too
+	var (
+		missing = common.Hash{0x01}
+		start   = common.Hash{0x02}
+		gone    *StateUnavailableError
+	)
+	it := NewAccountIterator(ctx, client, missing, start, 0)
+	if it.Next() {
+		t.Fatal("unknown state served")
+	}
+	if err := it.Err(); !errors.As(err, &gone) || gone.Root != missing || gone.Start != start {
+		t.Fatalf("unexpected error for unknown state: %v", err)
+	}
+}
+
+func collectAccounts(t *testing.T, reader *Reader, root common.Hash, limit int) []Account {
+	t.Helper()
+
+	var (
+		it  = NewAccountIterator(context.Background(), reader, root, common.Hash{}, limit)
+		res []Account
+	)
+	for it.Next() {
+		res = append(res, it.Account())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("failed to iterate accounts: %v", err)
+	}
+	return res
+}
+
+func collectSlots(t *testing.T, reader *Reader, root common.Hash, account common.Hash, limit int) []Slot {
+	t.Helper()
+
+	var (
+		it  = NewStorageIterator(context.Background(), reader, root, account, common.Hash{}, limit)
+		res []Slot
+	)
+	for it.Next() {
+		res = append(res, it.Slot())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("failed to iterate storage: %v", err)
+	}
+	return res
+}
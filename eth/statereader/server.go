@@ -0,0 +1,80 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package statereader
+
+import (
+	"net"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Namespace is the RPC namespace the state reader is served under.
+const Namespace = "statereader"
+
+// Config contains the settings of the state reader endpoint.
+type Config struct {
+	IPCPath   string // Path of the IPC endpoint serving the state
+	RateLimit int    // Maximum number of items served per second, zero for unlimited
+}
+
+// DefaultConfig contains the default settings of the state reader endpoint.
+var DefaultConfig = Config{
+	IPCPath:   "statereader.ipc",
+	RateLimit: 100000,
+}
+
+// Server serves the state of the chain on a dedicated IPC endpoint, kept apart
+// from the node RPC endpoints so bulk reads never compete with regular calls.
+type Server struct {
+	config   Config
+	reader   *Reader
+	listener net.Listener
+	handler  *rpc.Server
+}
+
+// NewServer creates a state reader endpoint of the given chain.
+func NewServer(config Config, chain Chain) *Server {
+	return &Server{
+		config: config,
+		reader: NewReader(chain, config.RateLimit),
+	}
+}
+
+// Start opens the IPC endpoint.
+func (s *Server) Start() error {
+	listener, handler, err := rpc.StartIPCEndpoint(s.config.IPCPath, []rpc.API{{
+		Namespace: Namespace,
+		Service:   s.reader,
+	}})
+	if err != nil {
+		return err
+	}
+	s.listener, s.handler = listener, handler
+	log.Info("State reader endpoint opened", "url", s.config.IPCPath, "ratelimit", s.config.RateLimit)
+	return nil
+}
+
+// Stop closes the IPC endpoint and terminates the served connections.
+func (s *Server) Stop() {
+	if s.listener == nil {
+		return
+	}
+	s.listener.Close()
+	s.handler.Stop()
+	log.Info("State reader endpoint closed", "url", s.config.IPCPath)
+}
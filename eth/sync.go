@@ -24,6 +24,9 @@ import (
 
 // syncTransactions starts sending all currently pending transactions to the given peer.
 func (h *handler) syncTransactions(p *eth.Peer) {
+	if h.standby.Load() || TxPropagation(h.txPropagation.Load()) == TxPropagationNone {
+		return
+	}
 	var hashes []common.Hash
 	for _, batch := range h.txpool.Pending(txpool.PendingFilter{OnlyPlainTxs: true}) {
 		for _, tx := range batch {
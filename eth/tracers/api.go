@@ -67,6 +67,10 @@ const (
 	// for tracing. The creation of trace state will be paused if the unused
 	// trace states exceed this limit.
 	maximumPendingTraceStates = 128
+
+	// maximumTraceCalls is the maximum number of calls traced by a single
+	// TraceCallMany request.
+	maximumTraceCalls = 64
 )
 
 var errTxNotFound = errors.New("transaction not found")
@@ -93,6 +97,21 @@ type Backend interface {
 	HistoricalRPCService() *rpc.Client
 }
 
+// contractABIBackend is implemented by the backends maintaining a registry of
+// contract ABIs, which the tracers may decode the calls and logs with.
+type contractABIBackend interface {
+	ContractABIs() ContractABIs
+}
+
+// memoryBudgetBackend is implemented by the backends enforcing a memory budget,
+// which shed the tracing load under memory pressure.
+type memoryBudgetBackend interface {
+	// ReserveTrace reserves the memory of a trace, heavy ones covering entire
+	// blocks or logging every opcode. It returns the release function and the
+	// size limit of the struct logger output, zero if unlimited.
+	ReserveTrace(heavy bool) (func(), int, error)
+}
+
 // API is the collection of tracing APIs exposed over the private debugging endpoint.
 type API struct {
 	backend Backend
@@ -103,6 +122,15 @@ func NewAPI(backend Backend) *API {
 	return &API{backend: backend}
 }
 
+// reserveTrace reserves the memory of a trace if the backend enforces a memory
+// budget, returning the release function and the struct logger output limit.
+func (api *API) reserveTrace(heavy bool) (func(), int, error) {
+	if backend, ok := api.backend.(memoryBudgetBackend); ok {
+		return backend.ReserveTrace(heavy)
+	}
+	return func() {}, 0, nil
+}
+
 // chainContext constructs the context reader which is used by the evm for reading
 // the necessary chain context.
 func (api *API) chainContext(ctx context.Context) core.ChainContext {
@@ -616,6 +644,12 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	if block.NumberU64() == 0 {
 		return nil, errors.New("genesis is not traceable")
 	}
+	unreserve, _, err := api.reserveTrace(true)
+	if err != nil {
+		return nil, err
+	}
+	defer unreserve()
+
 	// Prepare base state
 	parent, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(block.NumberU64()-1), block.ParentHash())
 	if err != nil {
@@ -955,6 +989,55 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 // the trace will be conducted on the state after executing the specified transaction
 // within the specified block.
 func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) (interface{}, error) {
+	vmctx, statedb, precompiles, release, err := api.callEnv(ctx, blockNrOrHash, config)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return api.traceCall(ctx, args, new(Context), vmctx, statedb, config, precompiles)
+}
+
+// TraceCallMany lets you trace an ordered list of eth_calls. The calls are
+// executed one after the other on top of the provided block, in the same way as
+// TraceCall, each seeing the state changes made by the previous ones. A call
+// failing to execute, e.g. due to insufficient funds, is reported in its result
+// without changing the state, and the remaining calls are still traced.
+func (api *API) TraceCallMany(ctx context.Context, calls []ethapi.TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) ([]*txTraceResult, error) {
+	if len(calls) == 0 {
+		return nil, errors.New("empty call list")
+	}
+	if len(calls) > maximumTraceCalls {
+		return nil, fmt.Errorf("too many calls: %d, limit %d", len(calls), maximumTraceCalls)
+	}
+	vmctx, statedb, precompiles, release, err := api.callEnv(ctx, blockNrOrHash, config)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	results := make([]*txTraceResult, len(calls))
+	for i, args := range calls {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// Each call is traced as the i-th transaction of a virtual block, so
+		// the logs emitted by the calls are told apart.
+		res, err := api.traceCall(ctx, args, &Context{TxIndex: i}, vmctx, statedb, config, precompiles)
+		if err != nil {
+			results[i] = &txTraceResult{Error: err.Error()}
+			continue
+		}
+		results[i] = &txTraceResult{Result: res}
+	}
+	return results, nil
+}
+
+// callEnv retrieves the state and the block context to trace calls on, as
+// specified by the block and the optional trace call configuration, with the
+// block and state overrides applied. The returned release function must be
+// called once the state is no longer needed.
+func (api *API) callEnv(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) (vm.BlockContext, *state.StateDB, vm.PrecompiledContracts, StateReleaseFunc, error) {
 	// Try to retrieve the specified block
 	var (
 		err         error
@@ -972,18 +1055,18 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 			// more flexibility and stability than trying to trace on 'pending', since
 			// the contents of 'pending' is unstable and probably not a true representation
 			// of what the next actual block is likely to contain.
-			return nil, errors.New("tracing on top of pending is not supported")
+			return vm.BlockContext{}, nil, nil, nil, errors.New("tracing on top of pending is not supported")
 		}
 		block, err = api.blockByNumber(ctx, number)
 	} else {
-		return nil, errors.New("invalid arguments; neither block nor hash specified")
+		return vm.BlockContext{}, nil, nil, nil, errors.New("invalid arguments; neither block nor hash specified")
 	}
 	if err != nil {
-		return nil, err
+		return vm.BlockContext{}, nil, nil, nil, err
 	}
 
 	if api.backend.ChainConfig().IsOptimismPreBedrock(block.Number()) {
-		return nil, errors.New("l2geth does not have a debug_traceCall method")
+		return vm.BlockContext{}, nil, nil, nil, errors.New("l2geth does not have a debug_traceCall method")
 	}
 
 	// try to recompute the state
@@ -998,23 +1081,30 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 		statedb, release, err = api.backend.StateAtBlock(ctx, block, reexec, nil, true, false)
 	}
 	if err != nil {
-		return nil, err
+		return vm.BlockContext{}, nil, nil, nil, err
 	}
-	defer release()
 
 	vmctx := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil, api.backend.ChainConfig(), statedb)
 	// Apply the customization rules if required.
 	if config != nil {
-		if overrideErr := config.BlockOverrides.Apply(&vmctx); overrideErr != nil {
-			return nil, overrideErr
+		if err := config.BlockOverrides.Apply(&vmctx); err != nil {
+			release()
+			return vm.BlockContext{}, nil, nil, nil, err
 		}
 		rules := api.backend.ChainConfig().Rules(vmctx.BlockNumber, vmctx.Random != nil, vmctx.Time)
 
 		precompiles = vm.ActivePrecompiledContracts(rules)
 		if err := config.StateOverrides.Apply(statedb, precompiles); err != nil {
-			return nil, err
+			release()
+			return vm.BlockContext{}, nil, nil, nil, err
 		}
 	}
+	return vmctx, statedb, precompiles, release, nil
+}
+
+// traceCall traces a single call in the given environment, leaving its state
+// changes in the state database.
+func (api *API) traceCall(ctx context.Context, args ethapi.TransactionArgs, txctx *Context, vmctx vm.BlockContext, statedb *state.StateDB, config *TraceCallConfig, precompiles vm.PrecompiledContracts) (interface{}, error) {
 	// Execute the trace
 	if err := args.CallDefaults(api.backend.RPCGasCap(), vmctx.BaseFee, api.backend.ChainConfig().ChainID); err != nil {
 		return nil, err
@@ -1035,7 +1125,7 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 	if config != nil {
 		traceConfig = &config.TraceConfig
 	}
-	return api.traceTx(ctx, tx, msg, new(Context), vmctx, statedb, traceConfig, precompiles)
+	return api.traceTx(ctx, tx, msg, txctx, vmctx, statedb, traceConfig, precompiles)
 }
 
 // traceTx configures a new tracer according to the provided configuration, and
@@ -1051,15 +1141,41 @@ func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *cor
 	if config == nil {
 		config = &TraceConfig{}
 	}
-	// Default tracer is the struct logger
+	// Define a meaningful timeout of a single transaction trace
+	if config.Timeout != nil {
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+			return nil, err
+		}
+	}
+	unreserve, limit, err := api.reserveTrace(config.Tracer == nil)
+	if err != nil {
+		return nil, err
+	}
+	defer unreserve()
+
+	// Default tracer is the struct logger, its output capped by the budget
 	if config.Tracer == nil {
-		logger := logger.NewStructLogger(config.Config)
+		logConfig := config.Config
+		if limit > 0 && (logConfig == nil || logConfig.Limit == 0 || logConfig.Limit > limit) {
+			cpy := logger.Config{}
+			if logConfig != nil {
+				cpy = *logConfig
+			}
+			cpy.Limit = limit
+			logConfig = &cpy
+		}
+		logger := logger.NewStructLogger(logConfig)
 		tracer = &Tracer{
 			Hooks:     logger.Hooks(),
 			GetResult: logger.GetResult,
 			Stop:      logger.Stop,
 		}
 	} else {
+		if backend, ok := api.backend.(contractABIBackend); ok && txctx.ABIs == nil {
+			cpy := *txctx
+			cpy.ABIs = backend.ContractABIs()
+			txctx = &cpy
+		}
 		tracer, err = DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig, api.backend.ChainConfig())
 		if err != nil {
 			return nil, err
@@ -1071,12 +1187,6 @@ func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *cor
 		evm.SetPrecompiles(precompiles)
 	}
 
-	// Define a meaningful timeout of a single transaction trace
-	if config.Timeout != nil {
-		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-			return nil, err
-		}
-	}
 	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 	go func() {
 		<-deadlineCtx.Done()
@@ -1092,6 +1202,8 @@ func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *cor
 	statedb.SetTxContext(txctx.TxHash, txctx.TxIndex)
 	_, err = core.ApplyTransactionWithEVM(message, new(core.GasPool).AddGas(message.GasLimit), statedb, vmctx.BlockNumber, txctx.BlockHash, tx, &usedGas, evm)
 	if err != nil {
+		// Retrieving the result releases the resources held by the tracer
+		tracer.GetResult()
 		return nil, fmt.Errorf("tracing failed: %w", err)
 	}
 	return tracer.GetResult()
@@ -584,6 +584,69 @@ func TestTraceCall(t *testing.T) {
 	}
 }
 
+// Tests that the calls traced by TraceCallMany see the state changes of the
+// previous calls in the list.
+func TestTraceCallMany(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(2)
+		counter  = common.HexToAddress("0xc0ffee")
+		// Increment slot 0 and return the new value
+		code    = common.FromHex("6000546001018060005560005260206000f3")
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				counter:          {Code: code},
+			},
+		}
+	)
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.teardown()
+	api := NewAPI(backend)
+
+	var (
+		latest = rpc.BlockNumberOrHash{BlockNumber: new(rpc.BlockNumber)}
+		bump   = ethapi.TransactionArgs{From: &accounts[0].addr, To: &counter}
+		poor   = ethapi.TransactionArgs{From: &accounts[1].addr, To: &counter, Value: (*hexutil.Big)(big.NewInt(1))}
+	)
+	*latest.BlockNumber = rpc.LatestBlockNumber
+
+	for run := 0; run < 2; run++ {
+		results, err := api.TraceCallMany(context.Background(), []ethapi.TransactionArgs{bump, poor, bump, bump}, latest, nil)
+		if err != nil {
+			t.Fatalf("failed to trace calls: %v", err)
+		}
+		if len(results) != 4 {
+			t.Fatalf("wrong number of results: have %d, want 4", len(results))
+		}
+		if results[1].Error == "" || results[1].Result != nil {
+			t.Fatalf("unfunded call traced: %+v", results[1])
+		}
+		// Every request starts from the block state, and the failed call
+		// leaves no trace in it.
+		for i, want := range map[int]int64{0: 1, 2: 2, 3: 3} {
+			if results[i].Error != "" {
+				t.Fatalf("run %d, call %d: unexpected error: %v", run, i, results[i].Error)
+			}
+			var have *logger.ExecutionResult
+			if err := json.Unmarshal(results[i].Result.(json.RawMessage), &have); err != nil {
+				t.Fatalf("run %d, call %d: failed to unmarshal result: %v", run, i, err)
+			}
+			if new(big.Int).SetBytes(have.ReturnValue).Int64() != want {
+				t.Errorf("run %d, call %d: wrong counter: have %x, want %d", run, i, have.ReturnValue, want)
+			}
+		}
+	}
+	if _, err := api.TraceCallMany(context.Background(), nil, latest, nil); err == nil {
+		t.Error("empty call list traced")
+	}
+	if _, err := api.TraceCallMany(context.Background(), make([]ethapi.TransactionArgs, maximumTraceCalls+1), latest, nil); err == nil {
+		t.Error("call list above the limit traced")
+	}
+}
+
 func TestTraceTransaction(t *testing.T) {
 	t.Parallel()
 
@@ -1525,3 +1588,126 @@ func TestStandardTraceBlockToFile(t *testing.T) {
 		}
 	}
 }
+
+// budgetBackend is a test backend enforcing a memory budget refusing the heavy
+// traces and capping the struct logger output.
+type budgetBackend struct {
+	*testBackend
+	refuseHeavy bool
+	limit       int
+	reserved    int
+}
+
+func (b *budgetBackend) ReserveTrace(heavy bool) (func(), int, error) {
+	if heavy && b.refuseHeavy {
+		return nil, 0, errors.New("memory pressure")
+	}
+	b.reserved++
+	return func() { b.reserved-- }, b.limit, nil
+}
+
+// Tests that the traces are refused or capped by the memory budget of the
+// backend, and that their reservations are released.
+func TestTraceMemoryBudget(t *testing.T) {
+	DefaultDirectory.Register("budgetTracer", newStateTracer, false)
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			accounts[1].addr: {Code: []byte{byte(vm.PUSH1), 1, byte(vm.PUSH1), 2, byte(vm.ADD), byte(vm.POP), byte(vm.STOP)}},
+		},
+	}
+	var target common.Hash
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &accounts[1].addr,
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+		}), types.HomesteadSigner{}, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	defer backend.chain.Stop()
+
+	var (
+		budget = &budgetBackend{testBackend: backend, refuseHeavy: true}
+		api    = NewAPI(budget)
+		tracer = "budgetTracer"
+	)
+
+	// Struct logger and block traces are heavy, named tracers are not
+	if _, err := api.TraceTransaction(context.Background(), target, nil); err == nil {
+		t.Error("struct logger trace not refused")
+	}
+	if _, err := api.TraceBlockByNumber(context.Background(), 1, &TraceConfig{Tracer: &tracer}); err == nil {
+		t.Error("block trace not refused")
+	}
+	if _, err := api.TraceTransaction(context.Background(), target, &TraceConfig{Tracer: &tracer}); err != nil {
+		t.Errorf("light trace refused: %v", err)
+	}
+	// Without pressure, the struct logger output is capped by the budget
+	budget.refuseHeavy, budget.limit = false, 1
+	result, err := api.TraceTransaction(context.Background(), target, nil)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	var have *logger.ExecutionResult
+	if err := json.Unmarshal(result.(json.RawMessage), &have); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+	if len(have.StructLogs) != 1 {
+		t.Errorf("struct logs not capped: have %d, want 1", len(have.StructLogs))
+	}
+	if budget.reserved != 0 {
+		t.Errorf("reservations not released: %d", budget.reserved)
+	}
+}
+
+// releaseCounter counts the results retrieved from the releaseTracer.
+var releaseCounter atomic.Int32
+
+func newReleaseTracer(ctx *Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*Tracer, error) {
+	return &Tracer{
+		Hooks: &tracing.Hooks{},
+		GetResult: func() (json.RawMessage, error) {
+			releaseCounter.Add(1)
+			return json.RawMessage(`{}`), nil
+		},
+		Stop: func(err error) {},
+	}, nil
+}
+
+// Tests that the result of the tracer, releasing its resources, is retrieved
+// even if the traced transaction fails.
+func TestTraceFailureReleasesTracer(t *testing.T) {
+	DefaultDirectory.Register("releaseTracer", newReleaseTracer, false)
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.teardown()
+
+	tracer := "releaseTracer"
+	api := NewAPI(backend)
+	_, err := api.TraceCall(context.Background(), ethapi.TransactionArgs{
+		From:  &accounts[1].addr,
+		To:    &accounts[0].addr,
+		Value: (*hexutil.Big)(big.NewInt(params.Ether)),
+	}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), &TraceCallConfig{TraceConfig: TraceConfig{Tracer: &tracer}})
+	if err == nil {
+		t.Fatal("unfunded call traced")
+	}
+	if n := releaseCounter.Load(); n != 1 {
+		t.Fatalf("tracer results retrieved %d times, want 1", n)
+	}
+}
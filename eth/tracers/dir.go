@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/params"
@@ -28,10 +29,18 @@ import (
 // Context contains some contextual infos for a transaction execution that is not
 // available from within the EVM object.
 type Context struct {
-	BlockHash   common.Hash // Hash of the block the tx is contained within (zero if dangling tx or call)
-	BlockNumber *big.Int    // Number of the block the tx is contained within (zero if dangling tx or call)
-	TxIndex     int         // Index of the transaction within a block (zero if dangling tx or call)
-	TxHash      common.Hash // Hash of the transaction being traced (zero if dangling call)
+	BlockHash   common.Hash  // Hash of the block the tx is contained within (zero if dangling tx or call)
+	BlockNumber *big.Int     // Number of the block the tx is contained within (zero if dangling tx or call)
+	TxIndex     int          // Index of the transaction within a block (zero if dangling tx or call)
+	TxHash      common.Hash  // Hash of the transaction being traced (zero if dangling call)
+	ABIs        ContractABIs // Registry of the contract ABIs to decode the trace with (nil if unavailable)
+}
+
+// ContractABIs resolves the ABIs of the contracts by code hash.
+type ContractABIs interface {
+	// ContractABI returns the ABI of the contracts with the given code hash, or
+	// nil if it's unknown.
+	ContractABI(codeHash common.Hash) *abi.ABI
 }
 
 // Tracer represents the set of methods that must be exposed by a tracer
@@ -25,6 +25,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
@@ -265,6 +266,20 @@ func TestInternals(t *testing.T) {
 		}
 		return tr
 	}
+	pingCode := append([]byte{byte(vm.PUSH32)}, crypto.Keccak256([]byte("Ping(uint256)"))...)
+	pingCode = append(pingCode, byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x0, byte(vm.LOG1))
+	pingABI, err := abi.JSON(strings.NewReader(`[{"type":"event","name":"Ping","inputs":[{"name":"n","type":"uint256"}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mkDecodingTracer := func() *tracers.Tracer {
+		ctx := &tracers.Context{ABIs: testContractABIs{crypto.Keccak256Hash(pingCode): &pingABI}}
+		tr, err := tracers.DefaultDirectory.New("callTracer", ctx, json.RawMessage(`{ "withLog": true, "decode": true }`), config)
+		if err != nil {
+			t.Fatalf("failed to create call tracer: %v", err)
+		}
+		return tr
+	}
 
 	for _, tc := range []struct {
 		name   string
@@ -304,6 +319,12 @@ func TestInternals(t *testing.T) {
 			tracer: mkTracer("callTracer", json.RawMessage(`{ "withLog": true }`)),
 			want:   fmt.Sprintf(`{"from":"%s","gas":"0x13880","gasUsed":"0x5b9e","to":"0x00000000000000000000000000000000deadbeef","input":"0x","logs":[{"address":"0x00000000000000000000000000000000deadbeef","topics":[],"data":"0x000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","position":"0x0"}],"value":"0x0","type":"CALL"}`, originHex),
 		},
+		{
+			name:   "Decoded log",
+			code:   pingCode,
+			tracer: mkDecodingTracer(),
+			want:   fmt.Sprintf(`{"from":"%s","gas":"0x13880","gasUsed":"0x5602","to":"0x00000000000000000000000000000000deadbeef","input":"0x","logs":[{"address":"0x00000000000000000000000000000000deadbeef","topics":["0x48257dc961b6f792c2b78a080dacfed693b660960a702de21cee364e20270e2f"],"data":"0x0000000000000000000000000000000000000000000000000000000000000000","position":"0x0","decoded":{"name":"Ping","signature":"Ping(uint256)","args":[{"name":"n","type":"uint256","value":"0"}]}}],"value":"0x0","type":"CALL"}`, originHex),
+		},
 		{
 			// Leads to OOM on the prestate tracer
 			name: "Prestate-tracer - CREATE2 OOM",
@@ -391,3 +412,10 @@ func TestInternals(t *testing.T) {
 		})
 	}
 }
+
+// testContractABIs is a static contract ABI registry.
+type testContractABIs map[common.Hash]*abi.ABI
+
+func (abis testContractABIs) ContractABI(codeHash common.Hash) *abi.ABI {
+	return abis[codeHash]
+}
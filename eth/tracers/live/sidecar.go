@@ -0,0 +1,583 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.LiveDirectory.Register("sidecar", newSidecarTracer)
+}
+
+// sidecarVersion is the version of the sidecar event schema, bumped on any
+// incompatible change to the events below.
+const sidecarVersion = 1
+
+// Event categories a sidecar tracer can be configured to stream.
+const (
+	sidecarBlock  = "block"  // blockStart, blockEnd, skippedBlock, genesis
+	sidecarTx     = "tx"     // txStart, txEnd
+	sidecarCall   = "call"   // enter, exit
+	sidecarOpcode = "opcode" // opcode, fault
+	sidecarState  = "state"  // balance, nonce, code, storage
+	sidecarLog    = "log"    // log
+)
+
+var (
+	// sidecarCategories are all the event categories known to the tracer.
+	sidecarCategories = []string{sidecarBlock, sidecarTx, sidecarCall, sidecarOpcode, sidecarState, sidecarLog}
+
+	// sidecarDefaults are the event categories streamed if the config sets none,
+	// everything but the opcodes which slow down block processing considerably.
+	sidecarDefaults = []string{sidecarBlock, sidecarTx, sidecarCall, sidecarState, sidecarLog}
+)
+
+// The events below make up the schema of the sidecar protocol. A consumer
+// connects to the unix socket of the tracer and receives a stream of newline
+// delimited JSON objects, each carrying its kind in the type field. The stream
+// opens with a hello event and follows the chain from the moment the consumer
+// attaches. Quantities are hex encoded, as on the RPC API.
+
+// sidecarHello is the first event sent to a consumer.
+type sidecarHello struct {
+	Type    string       `json:"type"` // "hello"
+	Version int          `json:"version"`
+	ChainID *hexutil.Big `json:"chainId"`
+	Events  []string     `json:"events"` // Categories of the events streamed
+}
+
+// sidecarBlockEvent is sent when the processing of a block starts, or when a
+// block is skipped or written as genesis.
+type sidecarBlockEvent struct {
+	Type       string         `json:"type"` // "blockStart", "skippedBlock" or "genesis"
+	Number     hexutil.Uint64 `json:"number"`
+	Hash       common.Hash    `json:"hash"`
+	ParentHash common.Hash    `json:"parentHash"`
+	Time       hexutil.Uint64 `json:"timestamp"`
+	Finalized  *common.Hash   `json:"finalized,omitempty"`
+	Safe       *common.Hash   `json:"safe,omitempty"`
+}
+
+// sidecarBlockEndEvent is sent when the processing of a block ends.
+type sidecarBlockEndEvent struct {
+	Type  string `json:"type"` // "blockEnd"
+	Error string `json:"error,omitempty"`
+}
+
+// sidecarTxStartEvent is sent when the execution of a transaction starts.
+type sidecarTxStartEvent struct {
+	Type  string          `json:"type"` // "txStart"
+	Hash  common.Hash     `json:"hash"`
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Value *hexutil.Big    `json:"value"`
+	Gas   hexutil.Uint64  `json:"gas"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// sidecarTxEndEvent is sent when the execution of a transaction ends.
+type sidecarTxEndEvent struct {
+	Type    string          `json:"type"` // "txEnd"
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Status  *hexutil.Uint64 `json:"status,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// sidecarEnterEvent is sent when a call frame is entered.
+type sidecarEnterEvent struct {
+	Type     string         `json:"type"` // "enter"
+	Depth    int            `json:"depth"`
+	CallType string         `json:"callType"`
+	From     common.Address `json:"from"`
+	To       common.Address `json:"to"`
+	Input    hexutil.Bytes  `json:"input"`
+	Gas      hexutil.Uint64 `json:"gas"`
+	Value    *hexutil.Big   `json:"value"`
+}
+
+// sidecarExitEvent is sent when a call frame is exited.
+type sidecarExitEvent struct {
+	Type     string         `json:"type"` // "exit"
+	Depth    int            `json:"depth"`
+	Output   hexutil.Bytes  `json:"output"`
+	GasUsed  hexutil.Uint64 `json:"gasUsed"`
+	Reverted bool           `json:"reverted"`
+	Error    string         `json:"error,omitempty"`
+}
+
+// sidecarOpcodeEvent is sent before an opcode is executed, or when it faults.
+type sidecarOpcodeEvent struct {
+	Type  string         `json:"type"` // "opcode" or "fault"
+	PC    uint64         `json:"pc"`
+	Op    string         `json:"op"`
+	Gas   hexutil.Uint64 `json:"gas"`
+	Cost  hexutil.Uint64 `json:"cost"`
+	Depth int            `json:"depth"`
+	Error string         `json:"error,omitempty"`
+}
+
+// sidecarBalanceEvent is sent when the balance of an account changes.
+type sidecarBalanceEvent struct {
+	Type    string         `json:"type"` // "balance"
+	Address common.Address `json:"address"`
+	Prev    *hexutil.Big   `json:"prev"`
+	New     *hexutil.Big   `json:"new"`
+	Reason  string         `json:"reason"`
+}
+
+// sidecarNonceEvent is sent when the nonce of an account changes.
+type sidecarNonceEvent struct {
+	Type    string         `json:"type"` // "nonce"
+	Address common.Address `json:"address"`
+	Prev    hexutil.Uint64 `json:"prev"`
+	New     hexutil.Uint64 `json:"new"`
+}
+
+// sidecarCodeEvent is sent when the code of an account changes.
+type sidecarCodeEvent struct {
+	Type     string         `json:"type"` // "code"
+	Address  common.Address `json:"address"`
+	PrevHash common.Hash    `json:"prevHash"`
+	Hash     common.Hash    `json:"hash"`
+	Code     hexutil.Bytes  `json:"code"`
+}
+
+// sidecarStorageEvent is sent when a storage slot of an account changes.
+type sidecarStorageEvent struct {
+	Type    string         `json:"type"` // "storage"
+	Address common.Address `json:"address"`
+	Slot    common.Hash    `json:"slot"`
+	Prev    common.Hash    `json:"prev"`
+	New     common.Hash    `json:"new"`
+}
+
+// sidecarLogEvent is sent when a log is emitted.
+type sidecarLogEvent struct {
+	Type    string         `json:"type"` // "log"
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+type sidecarTracerConfig struct {
+	Path     string   `json:"path"`     // Path of the unix socket consumers connect to
+	Events   []string `json:"events"`   // Event categories to stream, sidecarDefaults if empty
+	Buffer   int      `json:"buffer"`   // Number of events queued per consumer, 8192 if unset
+	Lossless bool     `json:"lossless"` // Stall block processing on slow consumers instead of dropping them
+}
+
+// sidecarTracer is a live tracer streaming the execution events of the chain to
+// external processes over a unix socket. Consumers may attach and detach at any
+// time without restarting the node. A consumer falling behind is disconnected,
+// unless the tracer is lossless, in which case block processing waits for it.
+type sidecarTracer struct {
+	listener net.Listener
+	events   []string
+	buffer   int
+	lossless bool
+
+	chainID *big.Int
+	subs    map[*sidecarSub]struct{}
+	active  atomic.Int32 // Number of consumers, to skip encoding events nobody reads
+	lock    sync.RWMutex
+	wg      sync.WaitGroup
+}
+
+// sidecarSub is a consumer attached to a sidecar tracer.
+type sidecarSub struct {
+	conn  net.Conn
+	queue chan []byte
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newSidecarTracer(cfg json.RawMessage) (*tracing.Hooks, error) {
+	var config sidecarTracerConfig
+	if err := json.Unmarshal(cfg, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	if config.Path == "" {
+		return nil, errors.New("sidecar tracer socket path is required")
+	}
+	if len(config.Events) == 0 {
+		config.Events = sidecarDefaults
+	}
+	enabled := make(map[string]bool)
+	for _, event := range config.Events {
+		known := false
+		for _, category := range sidecarCategories {
+			known = known || event == category
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown sidecar event category %q", event)
+		}
+		enabled[event] = true
+	}
+	if config.Buffer <= 0 {
+		config.Buffer = 8192
+	}
+	// Remove the socket of a previous run, but never any other kind of file
+	if info, err := os.Lstat(config.Path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		os.Remove(config.Path)
+	}
+	listener, err := net.Listen("unix", config.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sidecar socket: %v", err)
+	}
+	t := &sidecarTracer{
+		listener: listener,
+		events:   config.Events,
+		buffer:   config.Buffer,
+		lossless: config.Lossless,
+		subs:     make(map[*sidecarSub]struct{}),
+	}
+	t.wg.Add(1)
+	go t.accept()
+
+	log.Info("Sidecar tracer listening", "path", config.Path, "events", config.Events)
+
+	// Only hook into the enabled categories, the others would be dropped anyway
+	hooks := &tracing.Hooks{
+		OnBlockchainInit: t.onBlockchainInit,
+		OnClose:          t.onClose,
+	}
+	if enabled[sidecarBlock] {
+		hooks.OnBlockStart = t.onBlockStart
+		hooks.OnBlockEnd = t.onBlockEnd
+		hooks.OnSkippedBlock = t.onSkippedBlock
+		hooks.OnGenesisBlock = t.onGenesisBlock
+	}
+	if enabled[sidecarTx] {
+		hooks.OnTxStart = t.onTxStart
+		hooks.OnTxEnd = t.onTxEnd
+	}
+	if enabled[sidecarCall] {
+		hooks.OnEnter = t.onEnter
+		hooks.OnExit = t.onExit
+	}
+	if enabled[sidecarOpcode] {
+		hooks.OnOpcode = t.onOpcode
+		hooks.OnFault = t.onFault
+	}
+	if enabled[sidecarState] {
+		hooks.OnBalanceChange = t.onBalanceChange
+		hooks.OnNonceChange = t.onNonceChange
+		hooks.OnCodeChange = t.onCodeChange
+		hooks.OnStorageChange = t.onStorageChange
+	}
+	if enabled[sidecarLog] {
+		hooks.OnLog = t.onLog
+	}
+	return hooks, nil
+}
+
+// accept attaches the consumers connecting to the socket until it is closed.
+func (t *sidecarTracer) accept() {
+	defer t.wg.Done()
+
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Warn("Sidecar tracer stopped accepting consumers", "err", err)
+			}
+			return
+		}
+		sub := &sidecarSub{
+			conn:  conn,
+			queue: make(chan []byte, t.buffer),
+			done:  make(chan struct{}),
+		}
+		t.lock.Lock()
+		hello, _ := json.Marshal(&sidecarHello{
+			Type:    "hello",
+			Version: sidecarVersion,
+			ChainID: (*hexutil.Big)(t.chainID),
+			Events:  t.events,
+		})
+		sub.queue <- append(hello, '\n')
+		t.subs[sub] = struct{}{}
+		t.active.Add(1)
+		t.lock.Unlock()
+
+		log.Info("Sidecar tracer consumer attached", "consumers", t.active.Load())
+		t.wg.Add(1)
+		go t.serve(sub)
+	}
+}
+
+// serve writes the queued events to a consumer until it detaches or is dropped.
+func (t *sidecarTracer) serve(sub *sidecarSub) {
+	defer t.wg.Done()
+	defer t.detach(sub, nil)
+
+	for {
+		select {
+		case blob := <-sub.queue:
+			if _, err := sub.conn.Write(blob); err != nil {
+				t.detach(sub, err)
+				return
+			}
+		case <-sub.done:
+			return
+		}
+	}
+}
+
+// detach disconnects a consumer and stops streaming events to it.
+func (t *sidecarTracer) detach(sub *sidecarSub, err error) {
+	sub.once.Do(func() {
+		// Release any lossless emitter blocked on the consumer before locking
+		close(sub.done)
+		sub.conn.Close()
+
+		t.lock.Lock()
+		delete(t.subs, sub)
+		t.active.Add(-1)
+		t.lock.Unlock()
+
+		log.Info("Sidecar tracer consumer detached", "consumers", t.active.Load(), "err", err)
+	})
+}
+
+// emit encodes an event and queues it to all attached consumers.
+func (t *sidecarTracer) emit(event any) {
+	if t.active.Load() == 0 {
+		return
+	}
+	blob, err := json.Marshal(event)
+	if err != nil {
+		log.Warn("Failed to encode sidecar event", "err", err)
+		return
+	}
+	blob = append(blob, '\n')
+
+	var slow []*sidecarSub
+	t.lock.RLock()
+	for sub := range t.subs {
+		if t.lossless {
+			select {
+			case sub.queue <- blob:
+			case <-sub.done:
+			}
+			continue
+		}
+		select {
+		case sub.queue <- blob:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	t.lock.RUnlock()
+
+	for _, sub := range slow {
+		t.detach(sub, errors.New("consumer too slow"))
+	}
+}
+
+func (t *sidecarTracer) onBlockchainInit(chainConfig *params.ChainConfig) {
+	t.lock.Lock()
+	t.chainID = chainConfig.ChainID
+	t.lock.Unlock()
+}
+
+func (t *sidecarTracer) onClose() {
+	t.listener.Close()
+
+	t.lock.RLock()
+	subs := make([]*sidecarSub, 0, len(t.subs))
+	for sub := range t.subs {
+		subs = append(subs, sub)
+	}
+	t.lock.RUnlock()
+
+	for _, sub := range subs {
+		t.detach(sub, nil)
+	}
+	t.wg.Wait()
+}
+
+func (t *sidecarTracer) blockEvent(typ string, ev tracing.BlockEvent) *sidecarBlockEvent {
+	event := &sidecarBlockEvent{
+		Type:       typ,
+		Number:     hexutil.Uint64(ev.Block.NumberU64()),
+		Hash:       ev.Block.Hash(),
+		ParentHash: ev.Block.ParentHash(),
+		Time:       hexutil.Uint64(ev.Block.Time()),
+	}
+	if ev.Finalized != nil {
+		hash := ev.Finalized.Hash()
+		event.Finalized = &hash
+	}
+	if ev.Safe != nil {
+		hash := ev.Safe.Hash()
+		event.Safe = &hash
+	}
+	return event
+}
+
+func (t *sidecarTracer) onBlockStart(ev tracing.BlockEvent) {
+	if t.active.Load() == 0 {
+		return
+	}
+	t.emit(t.blockEvent("blockStart", ev))
+}
+
+func (t *sidecarTracer) onBlockEnd(err error) {
+	t.emit(&sidecarBlockEndEvent{Type: "blockEnd", Error: errorString(err)})
+}
+
+func (t *sidecarTracer) onSkippedBlock(ev tracing.BlockEvent) {
+	if t.active.Load() == 0 {
+		return
+	}
+	t.emit(t.blockEvent("skippedBlock", ev))
+}
+
+func (t *sidecarTracer) onGenesisBlock(b *types.Block, alloc types.GenesisAlloc) {
+	t.emit(t.blockEvent("genesis", tracing.BlockEvent{Block: b}))
+}
+
+func (t *sidecarTracer) onTxStart(vm *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	if t.active.Load() == 0 {
+		return
+	}
+	t.emit(&sidecarTxStartEvent{
+		Type:  "txStart",
+		Hash:  tx.Hash(),
+		From:  from,
+		To:    tx.To(),
+		Value: (*hexutil.Big)(tx.Value()),
+		Gas:   hexutil.Uint64(tx.Gas()),
+		Input: tx.Data(),
+	})
+}
+
+func (t *sidecarTracer) onTxEnd(receipt *types.Receipt, err error) {
+	event := &sidecarTxEndEvent{Type: "txEnd", Error: errorString(err)}
+	if receipt != nil {
+		status := hexutil.Uint64(receipt.Status)
+		event.GasUsed = hexutil.Uint64(receipt.GasUsed)
+		event.Status = &status
+	}
+	t.emit(event)
+}
+
+func (t *sidecarTracer) onEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.emit(&sidecarEnterEvent{
+		Type:     "enter",
+		Depth:    depth,
+		CallType: vm.OpCode(typ).String(),
+		From:     from,
+		To:       to,
+		Input:    input,
+		Gas:      hexutil.Uint64(gas),
+		Value:    (*hexutil.Big)(value),
+	})
+}
+
+func (t *sidecarTracer) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	t.emit(&sidecarExitEvent{
+		Type:     "exit",
+		Depth:    depth,
+		Output:   output,
+		GasUsed:  hexutil.Uint64(gasUsed),
+		Reverted: reverted,
+		Error:    errorString(err),
+	})
+}
+
+func (t *sidecarTracer) onOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if t.active.Load() == 0 {
+		return
+	}
+	t.emit(&sidecarOpcodeEvent{
+		Type:  "opcode",
+		PC:    pc,
+		Op:    vm.OpCode(op).String(),
+		Gas:   hexutil.Uint64(gas),
+		Cost:  hexutil.Uint64(cost),
+		Depth: depth,
+		Error: errorString(err),
+	})
+}
+
+func (t *sidecarTracer) onFault(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+	if t.active.Load() == 0 {
+		return
+	}
+	t.emit(&sidecarOpcodeEvent{
+		Type:  "fault",
+		PC:    pc,
+		Op:    vm.OpCode(op).String(),
+		Gas:   hexutil.Uint64(gas),
+		Cost:  hexutil.Uint64(cost),
+		Depth: depth,
+		Error: errorString(err),
+	})
+}
+
+func (t *sidecarTracer) onBalanceChange(a common.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+	t.emit(&sidecarBalanceEvent{
+		Type:    "balance",
+		Address: a,
+		Prev:    (*hexutil.Big)(prev),
+		New:     (*hexutil.Big)(new),
+		Reason:  reason.String(),
+	})
+}
+
+func (t *sidecarTracer) onNonceChange(a common.Address, prev, new uint64) {
+	t.emit(&sidecarNonceEvent{Type: "nonce", Address: a, Prev: hexutil.Uint64(prev), New: hexutil.Uint64(new)})
+}
+
+func (t *sidecarTracer) onCodeChange(a common.Address, prevCodeHash common.Hash, prev []byte, codeHash common.Hash, code []byte) {
+	t.emit(&sidecarCodeEvent{Type: "code", Address: a, PrevHash: prevCodeHash, Hash: codeHash, Code: code})
+}
+
+func (t *sidecarTracer) onStorageChange(a common.Address, k, prev, new common.Hash) {
+	t.emit(&sidecarStorageEvent{Type: "storage", Address: a, Slot: k, Prev: prev, New: new})
+}
+
+func (t *sidecarTracer) onLog(l *types.Log) {
+	t.emit(&sidecarLogEvent{Type: "log", Address: l.Address, Topics: l.Topics, Data: l.Data})
+}
+
+// errorString returns the message of an error, or empty if there is none.
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func newSidecar(t *testing.T, config string) (*tracing.Hooks, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "sidecar.ipc")
+	cfg, _ := json.Marshal(map[string]any{"path": path})
+	if config != "" {
+		merged := make(map[string]any)
+		json.Unmarshal([]byte(config), &merged)
+		merged["path"] = path
+		cfg, _ = json.Marshal(merged)
+	}
+	hooks, err := newSidecarTracer(cfg)
+	if err != nil {
+		t.Fatalf("failed to create tracer: %v", err)
+	}
+	hooks.OnBlockchainInit(params.TestChainConfig)
+	t.Cleanup(hooks.OnClose)
+	return hooks, path
+}
+
+// attach connects a consumer to the tracer, returning it once the hello event
+// arrived and the consumer is thus receiving events.
+func attach(t *testing.T, path string) (net.Conn, *bufio.Scanner) {
+	t.Helper()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("failed to attach: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	stream := bufio.NewScanner(conn)
+	stream.Buffer(nil, 1024*1024)
+	if !stream.Scan() {
+		t.Fatalf("no hello received: %v", stream.Err())
+	}
+	var hello sidecarHello
+	if err := json.Unmarshal(stream.Bytes(), &hello); err != nil {
+		t.Fatalf("invalid hello: %v", err)
+	}
+	if hello.Type != "hello" || hello.Version != sidecarVersion || hello.ChainID.ToInt().Cmp(params.TestChainConfig.ChainID) != 0 {
+		t.Fatalf("hello mismatch: %s", stream.Bytes())
+	}
+	return conn, stream
+}
+
+// Tests that the events of the enabled categories are streamed to consumers.
+func TestSidecarTracer(t *testing.T) {
+	hooks, path := newSidecar(t, "")
+	if hooks.OnOpcode != nil {
+		t.Fatal("opcodes streamed by default")
+	}
+	// Events are only streamed to the consumers attached at the time
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+	hooks.OnBlockStart(tracing.BlockEvent{Block: block})
+
+	_, stream := attach(t, path)
+
+	var (
+		from = common.HexToAddress("0x01")
+		to   = common.HexToAddress("0x02")
+		tx   = types.NewTx(&types.LegacyTx{To: &to, Gas: 21000, Value: big.NewInt(1)})
+	)
+	hooks.OnBlockStart(tracing.BlockEvent{Block: block})
+	hooks.OnTxStart(nil, tx, from)
+	hooks.OnEnter(0, byte(vm.CALL), from, to, nil, 21000, big.NewInt(1))
+	hooks.OnBalanceChange(to, big.NewInt(0), big.NewInt(1), tracing.BalanceChangeTransfer)
+	hooks.OnExit(0, nil, 0, nil, false)
+	hooks.OnTxEnd(&types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: 21000}, nil)
+	hooks.OnBlockEnd(nil)
+
+	want := []string{
+		`{"type":"blockStart","number":"0x1","hash":"` + block.Hash().Hex() + `","parentHash":"0x0000000000000000000000000000000000000000000000000000000000000000","timestamp":"0x0"}`,
+		`{"type":"txStart","hash":"` + tx.Hash().Hex() + `","from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","value":"0x1","gas":"0x5208","input":"0x"}`,
+		`{"type":"enter","depth":0,"callType":"CALL","from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","input":"0x","gas":"0x5208","value":"0x1"}`,
+		`{"type":"balance","address":"0x0000000000000000000000000000000000000002","prev":"0x0","new":"0x1","reason":"Transfer"}`,
+		`{"type":"exit","depth":0,"output":"0x","gasUsed":"0x0","reverted":false}`,
+		`{"type":"txEnd","gasUsed":"0x5208","status":"0x1"}`,
+		`{"type":"blockEnd"}`,
+	}
+	for i, event := range want {
+		if !stream.Scan() {
+			t.Fatalf("event %d: missing: %v", i, stream.Err())
+		}
+		if have := stream.Text(); have != event {
+			t.Errorf("event %d: mismatch:\nhave %s\nwant %s", i, have, event)
+		}
+	}
+}
+
+// Tests that a consumer falling behind is dropped instead of stalling the chain.
+func TestSidecarTracerSlowConsumer(t *testing.T) {
+	hooks, path := newSidecar(t, `{"buffer":1}`)
+	conn, _ := attach(t, path)
+
+	// Stop reading and flood the consumer until its socket buffer fills up
+	data := make([]byte, 4096)
+	for i := 0; i < 1024; i++ {
+		hooks.OnLog(&types.Log{Data: data})
+	}
+	// Draining the stream should now reach its end instead of blocking
+	if _, err := io.Copy(io.Discard, conn); err != nil {
+		t.Fatalf("consumer not dropped: %v", err)
+	}
+}
+
+// Tests that closing the tracer detaches the consumers and that unknown event
+// categories are rejected.
+func TestSidecarTracerClose(t *testing.T) {
+	hooks, path := newSidecar(t, `{"events":["opcode"]}`)
+	if hooks.OnOpcode == nil || hooks.OnTxStart != nil {
+		t.Fatal("hooks not limited to the configured events")
+	}
+	conn, _ := attach(t, path)
+
+	hooks.OnClose()
+	if _, err := io.Copy(io.Discard, conn); err != nil {
+		t.Fatalf("consumer not detached: %v", err)
+	}
+	if _, err := net.Dial("unix", path); err == nil {
+		t.Fatal("socket still open")
+	}
+	cfg, _ := json.Marshal(map[string]any{"path": filepath.Join(t.TempDir(), "sidecar.ipc"), "events": []string{"memory"}})
+	if _, err := newSidecarTracer(cfg); err == nil {
+		t.Fatal("unknown event category accepted")
+	}
+}
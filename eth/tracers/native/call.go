@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/abiregistry"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/params"
 )
@@ -44,21 +45,23 @@ type callLog struct {
 	Data    hexutil.Bytes  `json:"data"`
 	// Position of the log relative to subcalls within the same trace
 	// See https://github.com/ethereum/go-ethereum/pull/28389 for details
-	Position hexutil.Uint `json:"position"`
+	Position hexutil.Uint         `json:"position"`
+	Decoded  *abiregistry.Decoded `json:"decoded,omitempty"`
 }
 
 type callFrame struct {
-	Type         vm.OpCode       `json:"-"`
-	From         common.Address  `json:"from"`
-	Gas          uint64          `json:"gas"`
-	GasUsed      uint64          `json:"gasUsed"`
-	To           *common.Address `json:"to,omitempty" rlp:"optional"`
-	Input        []byte          `json:"input" rlp:"optional"`
-	Output       []byte          `json:"output,omitempty" rlp:"optional"`
-	Error        string          `json:"error,omitempty" rlp:"optional"`
-	RevertReason string          `json:"revertReason,omitempty"`
-	Calls        []callFrame     `json:"calls,omitempty" rlp:"optional"`
-	Logs         []callLog       `json:"logs,omitempty" rlp:"optional"`
+	Type         vm.OpCode            `json:"-"`
+	From         common.Address       `json:"from"`
+	Gas          uint64               `json:"gas"`
+	GasUsed      uint64               `json:"gasUsed"`
+	To           *common.Address      `json:"to,omitempty" rlp:"optional"`
+	Input        []byte               `json:"input" rlp:"optional"`
+	Decoded      *abiregistry.Decoded `json:"decoded,omitempty" rlp:"-"`
+	Output       []byte               `json:"output,omitempty" rlp:"optional"`
+	Error        string               `json:"error,omitempty" rlp:"optional"`
+	RevertReason string               `json:"revertReason,omitempty"`
+	Calls        []callFrame          `json:"calls,omitempty" rlp:"optional"`
+	Logs         []callLog            `json:"logs,omitempty" rlp:"optional"`
 	// Placed at end on purpose. The RLP will be decoded to 0 instead of
 	// nil if there are non-empty elements after in the struct.
 	Value            *big.Int `json:"value,omitempty" rlp:"optional"`
@@ -113,6 +116,8 @@ type callFrameMarshaling struct {
 type callTracer struct {
 	callstack []callFrame
 	config    callTracerConfig
+	abis      tracers.ContractABIs
+	env       *tracing.VMContext
 	gasLimit  uint64
 	depth     int
 	interrupt atomic.Bool // Atomic flag to signal execution interruption
@@ -122,6 +127,7 @@ type callTracer struct {
 type callTracerConfig struct {
 	OnlyTopCall bool `json:"onlyTopCall"` // If true, call tracer won't collect any subcalls
 	WithLog     bool `json:"withLog"`     // If true, call tracer will collect event logs
+	Decode      bool `json:"decode"`      // If true, call tracer will decode calls and logs with the registered contract ABIs
 }
 
 // newCallTracer returns a native go tracer which tracks
@@ -151,7 +157,11 @@ func newCallTracerObject(ctx *tracers.Context, cfg json.RawMessage) (*callTracer
 	}
 	// First callframe contains tx context info
 	// and is populated on start and end.
-	return &callTracer{callstack: make([]callFrame, 0, 1), config: config}, nil
+	t := &callTracer{callstack: make([]callFrame, 0, 1), config: config}
+	if config.Decode && ctx != nil {
+		t.abis = ctx.ABIs
+	}
+	return t, nil
 }
 
 // OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
@@ -177,6 +187,11 @@ func (t *callTracer) OnEnter(depth int, typ byte, from common.Address, to common
 	if depth == 0 {
 		call.Gas = t.gasLimit
 	}
+	switch call.Type {
+	case vm.CREATE, vm.CREATE2, vm.SELFDESTRUCT:
+	default:
+		call.Decoded = abiregistry.DecodeCall(t.contractABI(to), input)
+	}
 	t.callstack = append(t.callstack, call)
 }
 
@@ -216,6 +231,7 @@ func (t *callTracer) captureEnd(output []byte, gasUsed uint64, err error, revert
 }
 
 func (t *callTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.env = env
 	t.gasLimit = tx.Gas()
 }
 
@@ -251,10 +267,20 @@ func (t *callTracer) OnLog(log *types.Log) {
 		Topics:   log.Topics,
 		Data:     log.Data,
 		Position: hexutil.Uint(len(t.callstack[len(t.callstack)-1].Calls)),
+		Decoded:  abiregistry.DecodeLog(t.contractABI(log.Address), log.Topics, log.Data),
 	}
 	t.callstack[len(t.callstack)-1].Logs = append(t.callstack[len(t.callstack)-1].Logs, l)
 }
 
+// contractABI returns the registered ABI of the contract at the given address,
+// or nil if decoding is disabled or the ABI unknown.
+func (t *callTracer) contractABI(address common.Address) *abi.ABI {
+	if t.abis == nil || t.env == nil || t.env.StateDB == nil {
+		return nil
+	}
+	return t.abis.ContractABI(t.env.StateDB.GetCodeHash(address))
+}
+
 // GetResult returns the json-encoded nested list of call traces, and any
 // error arising from the encoding or forceful termination (via `Stop`).
 func (t *callTracer) GetResult() (json.RawMessage, error) {
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/abiregistry"
 )
 
 var _ = (*callFrameMarshaling)(nil)
@@ -16,19 +17,20 @@ var _ = (*callFrameMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (c callFrame) MarshalJSON() ([]byte, error) {
 	type callFrame0 struct {
-		Type         vm.OpCode       `json:"-"`
-		From         common.Address  `json:"from"`
-		Gas          hexutil.Uint64  `json:"gas"`
-		GasUsed      hexutil.Uint64  `json:"gasUsed"`
-		To           *common.Address `json:"to,omitempty" rlp:"optional"`
-		Input        hexutil.Bytes   `json:"input" rlp:"optional"`
-		Output       hexutil.Bytes   `json:"output,omitempty" rlp:"optional"`
-		Error        string          `json:"error,omitempty" rlp:"optional"`
-		RevertReason string          `json:"revertReason,omitempty"`
-		Calls        []callFrame     `json:"calls,omitempty" rlp:"optional"`
-		Logs         []callLog       `json:"logs,omitempty" rlp:"optional"`
-		Value        *hexutil.Big    `json:"value,omitempty" rlp:"optional"`
-		TypeString   string          `json:"type"`
+		Type         vm.OpCode            `json:"-"`
+		From         common.Address       `json:"from"`
+		Gas          hexutil.Uint64       `json:"gas"`
+		GasUsed      hexutil.Uint64       `json:"gasUsed"`
+		To           *common.Address      `json:"to,omitempty" rlp:"optional"`
+		Input        hexutil.Bytes        `json:"input" rlp:"optional"`
+		Decoded      *abiregistry.Decoded `json:"decoded,omitempty" rlp:"-"`
+		Output       hexutil.Bytes        `json:"output,omitempty" rlp:"optional"`
+		Error        string               `json:"error,omitempty" rlp:"optional"`
+		RevertReason string               `json:"revertReason,omitempty"`
+		Calls        []callFrame          `json:"calls,omitempty" rlp:"optional"`
+		Logs         []callLog            `json:"logs,omitempty" rlp:"optional"`
+		Value        *hexutil.Big         `json:"value,omitempty" rlp:"optional"`
+		TypeString   string               `json:"type"`
 	}
 	var enc callFrame0
 	enc.Type = c.Type
@@ -37,6 +39,7 @@ func (c callFrame) MarshalJSON() ([]byte, error) {
 	enc.GasUsed = hexutil.Uint64(c.GasUsed)
 	enc.To = c.To
 	enc.Input = c.Input
+	enc.Decoded = c.Decoded
 	enc.Output = c.Output
 	enc.Error = c.Error
 	enc.RevertReason = c.RevertReason
@@ -50,18 +53,19 @@ func (c callFrame) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (c *callFrame) UnmarshalJSON(input []byte) error {
 	type callFrame0 struct {
-		Type         *vm.OpCode      `json:"-"`
-		From         *common.Address `json:"from"`
-		Gas          *hexutil.Uint64 `json:"gas"`
-		GasUsed      *hexutil.Uint64 `json:"gasUsed"`
-		To           *common.Address `json:"to,omitempty" rlp:"optional"`
-		Input        *hexutil.Bytes  `json:"input" rlp:"optional"`
-		Output       *hexutil.Bytes  `json:"output,omitempty" rlp:"optional"`
-		Error        *string         `json:"error,omitempty" rlp:"optional"`
-		RevertReason *string         `json:"revertReason,omitempty"`
-		Calls        []callFrame     `json:"calls,omitempty" rlp:"optional"`
-		Logs         []callLog       `json:"logs,omitempty" rlp:"optional"`
-		Value        *hexutil.Big    `json:"value,omitempty" rlp:"optional"`
+		Type         *vm.OpCode           `json:"-"`
+		From         *common.Address      `json:"from"`
+		Gas          *hexutil.Uint64      `json:"gas"`
+		GasUsed      *hexutil.Uint64      `json:"gasUsed"`
+		To           *common.Address      `json:"to,omitempty" rlp:"optional"`
+		Input        *hexutil.Bytes       `json:"input" rlp:"optional"`
+		Decoded      *abiregistry.Decoded `json:"decoded,omitempty" rlp:"-"`
+		Output       *hexutil.Bytes       `json:"output,omitempty" rlp:"optional"`
+		Error        *string              `json:"error,omitempty" rlp:"optional"`
+		RevertReason *string              `json:"revertReason,omitempty"`
+		Calls        []callFrame          `json:"calls,omitempty" rlp:"optional"`
+		Logs         []callLog            `json:"logs,omitempty" rlp:"optional"`
+		Value        *hexutil.Big         `json:"value,omitempty" rlp:"optional"`
 	}
 	var dec callFrame0
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -85,6 +89,9 @@ func (c *callFrame) UnmarshalJSON(input []byte) error {
 	if dec.Input != nil {
 		c.Input = *dec.Input
 	}
+	if dec.Decoded != nil {
+		c.Decoded = dec.Decoded
+	}
 	if dec.Output != nil {
 		c.Output = *dec.Output
 	}
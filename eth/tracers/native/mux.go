@@ -49,6 +49,10 @@ func newMuxTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params
 	for k, v := range config {
 		t, err := tracers.DefaultDirectory.New(k, ctx, v, chainConfig)
 		if err != nil {
+			// Release the resources held by the tracers created so far
+			for _, t := range objects {
+				t.GetResult()
+			}
 			return nil, err
 		}
 		objects = append(objects, t)
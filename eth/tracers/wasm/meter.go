@@ -0,0 +1,593 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	// fuelGlobal is the name under which the fuel counter injected into a
+	// tracer module is exported.
+	fuelGlobal = "__geth_fuel"
+
+	// refuelFunc is the name of the host function, imported from the geth
+	// module, refilling the fuel counter of a tracer module.
+	refuelFunc = "__refuel"
+)
+
+// Section identifiers of the WebAssembly binary format.
+const (
+	sectionCustom   = 0
+	sectionType     = 1
+	sectionImport   = 2
+	sectionFunction = 3
+	sectionTable    = 4
+	sectionMemory   = 5
+	sectionGlobal   = 6
+	sectionExport   = 7
+	sectionStart    = 8
+	sectionElement  = 9
+	sectionCode     = 10
+	sectionData     = 11
+	sectionDataCnt  = 12
+	sectionTag      = 13
+)
+
+// sectionOrder is the position of the known sections in a module, the tag
+// section going between the memory and global ones.
+var sectionOrder = map[byte]int{
+	sectionType: 1, sectionImport: 2, sectionFunction: 3, sectionTable: 4,
+	sectionMemory: 5, sectionTag: 6, sectionGlobal: 7, sectionExport: 8,
+	sectionStart: 9, sectionElement: 10, sectionDataCnt: 11, sectionCode: 12,
+	sectionData: 13,
+}
+
+var errMalformed = errors.New("malformed module")
+
+// reader decodes the primitive values of the WebAssembly binary format.
+type reader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (r *reader) eof() bool { return r.err != nil || r.pos >= len(r.buf) }
+
+func (r *reader) byte() byte {
+	if r.eof() {
+		r.err = errMalformed
+		return 0
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *reader) bytes(n uint64) []byte {
+	if r.err != nil || uint64(len(r.buf)-r.pos) < n {
+		r.err = errMalformed
+		return nil
+	}
+	b := r.buf[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b
+}
+
+// leb skips a LEB128 encoded integer, returning its value if unsigned.
+func (r *reader) leb() uint64 {
+	var value uint64
+	for shift := 0; shift < 70; shift += 7 {
+		b := r.byte()
+		if shift < 64 {
+			value |= uint64(b&0x7f) << shift
+		}
+		if b&0x80 == 0 {
+			return value
+		}
+	}
+	r.err = errMalformed
+	return 0
+}
+
+func (r *reader) vec() []byte { return r.bytes(r.leb()) }
+
+// appendLEB appends an unsigned LEB128 encoded integer.
+func appendLEB(b []byte, v uint64) []byte {
+	for {
+		c := byte(v & 0x7f)
+		if v >>= 7; v != 0 {
+			b = append(b, c|0x80)
+			continue
+		}
+		return append(b, c)
+	}
+}
+
+// appendSLEB appends a signed LEB128 encoded integer.
+func appendSLEB(b []byte, v int64) []byte {
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+// meter instruments a tracer module with a fuel counter: an exported mutable
+// i64 global charged for every instruction at the start of each straight-line
+// run of code. Loop headers start a run, so a module can't spin without being
+// charged. When the counter can't cover a run, the module calls the imported
+// refuel function with the cost of the run, giving the host the opportunity to
+// refill the counter from the budget of the tracer, or to abort the module if
+// out of fuel or interrupted.
+//
+// The refuel function is imported after the existing ones, shifting the index
+// of every function defined by the module by one. The references to them are
+// rewritten, and the name section, which would be stale, dropped.
+func meter(code []byte) ([]byte, error) {
+	if len(code) < 8 || !bytes.Equal(code[:4], []byte("\x00asm")) {
+		return nil, errMalformed
+	}
+	var (
+		sections []*section
+		r        = &reader{buf: code, pos: 8}
+	)
+	for !r.eof() {
+		id := r.byte()
+		body := r.vec()
+		sections = append(sections, &section{id, body})
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	// Add the sections to extend if missing, and count their entries
+	var m metering
+	for _, id := range []byte{sectionType, sectionImport, sectionGlobal, sectionExport} {
+		if slices.IndexFunc(sections, func(s *section) bool { return s.id == id }) < 0 {
+			pos := slices.IndexFunc(sections, func(s *section) bool {
+				return s.id != sectionCustom && sectionOrder[s.id] > sectionOrder[id]
+			})
+			if pos < 0 {
+				pos = len(sections)
+			}
+			sections = slices.Insert(sections, pos, &section{id, []byte{0}})
+		}
+	}
+	for _, s := range sections {
+		switch s.id {
+		case sectionType:
+			m.refuelType = (&reader{buf: s.body}).leb()
+		case sectionImport:
+			if err := m.countImports(s.body); err != nil {
+				return nil, err
+			}
+		case sectionGlobal:
+			m.fuel += (&reader{buf: s.body}).leb()
+		}
+	}
+	// Rewrite the module with the counter and the refuel import
+	out := append([]byte{}, code[:8]...)
+	for _, s := range sections {
+		var (
+			body = s.body
+			err  error
+		)
+		switch s.id {
+		case sectionCustom:
+			if name := (&reader{buf: body}).vec(); string(name) == "name" {
+				continue
+			}
+		case sectionType:
+			body, err = appendEntries(body, nil, []byte{0x60, 0x01, 0x7e, 0x00}) // (i64) -> ()
+		case sectionImport:
+			entry := appendName(appendName(nil, "geth"), refuelFunc)
+			body, err = appendEntries(body, nil, appendLEB(append(entry, 0x00), m.refuelType))
+		case sectionGlobal:
+			body, err = appendEntries(body, m.rewriteGlobal, []byte{0x7e, 0x01, 0x42, 0x00, 0x0b}) // mut i64 = 0
+		case sectionExport:
+			entry := appendName(nil, fuelGlobal)
+			body, err = appendEntries(body, m.rewriteExport, appendLEB(append(entry, 0x03), m.fuel))
+		case sectionStart:
+			r := &reader{buf: body}
+			body, err = appendLEB(nil, m.function(r.leb())), r.err
+		case sectionElement:
+			body, err = appendEntries(body, m.rewriteElement, nil)
+		case sectionCode:
+			body, err = appendEntries(body, m.rewriteBody, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s.id)
+		out = appendLEB(out, uint64(len(body)))
+		out = append(out, body...)
+	}
+	return out, nil
+}
+
+// section is a section of a WebAssembly module.
+type section struct {
+	id   byte
+	body []byte
+}
+
+// metering contains the indexes involved in instrumenting a module.
+type metering struct {
+	functions  uint64 // Number of imported functions, the index of the refuel one
+	refuelType uint64 // Type index of the refuel function
+	fuel       uint64 // Index of the fuel counter, after all other globals
+}
+
+// function returns the index of a function of the module after the import of
+// the refuel function.
+func (m *metering) function(index uint64) uint64 {
+	if index >= m.functions {
+		return index + 1
+	}
+	return index
+}
+
+// countImports counts the functions and globals imported by a module.
+func (m *metering) countImports(section []byte) error {
+	r := &reader{buf: section}
+	for n := r.leb(); n > 0 && r.err == nil; n-- {
+		r.vec() // module
+		r.vec() // name
+		switch r.byte() {
+		case 0x00: // function
+			r.leb()
+			m.functions++
+		case 0x01: // table
+			r.byte()
+			skipLimits(r)
+		case 0x02: // memory
+			skipLimits(r)
+		case 0x03: // global
+			r.byte()
+			r.byte()
+			m.fuel++
+		case 0x04: // tag
+			r.byte()
+			r.leb()
+		default:
+			return errMalformed
+		}
+	}
+	return r.err
+}
+
+// rewriteGlobal rewrites the initializer of a global.
+func (m *metering) rewriteGlobal(r *reader, out []byte) ([]byte, error) {
+	start := r.pos
+	r.byte() // type
+	r.byte() // mutability
+	return m.rewriteConst(r, append(out, r.buf[start:r.pos]...))
+}
+
+// rewriteExport rewrites the index of an exported function.
+func (m *metering) rewriteExport(r *reader, out []byte) ([]byte, error) {
+	name := r.vec()
+	kind := r.byte()
+	index := r.leb()
+	if kind == 0x00 {
+		index = m.function(index)
+	}
+	out = appendLEB(out, uint64(len(name)))
+	out = append(append(out, name...), kind)
+	return appendLEB(out, index), r.err
+}
+
+// rewriteElement rewrites the function indexes of an element segment.
+func (m *metering) rewriteElement(r *reader, out []byte) (_ []byte, err error) {
+	flags := r.leb()
+	out = appendLEB(out, flags)
+	if flags&0x02 != 0 && flags&0x01 == 0 { // explicit table index
+		out = appendLEB(out, r.leb())
+	}
+	if flags&0x01 == 0 { // active segment offset
+		if out, err = m.rewriteConst(r, out); err != nil {
+			return nil, err
+		}
+	}
+	if flags&0x03 != 0 { // element kind or reference type
+		out = append(out, r.byte())
+	}
+	n := r.leb()
+	out = appendLEB(out, n)
+	for ; n > 0 && r.err == nil; n-- {
+		if flags&0x04 == 0 {
+			out = appendLEB(out, m.function(r.leb()))
+		} else if out, err = m.rewriteConst(r, out); err != nil {
+			return nil, err
+		}
+	}
+	return out, r.err
+}
+
+// rewriteConst rewrites the function references of a constant expression.
+func (m *metering) rewriteConst(r *reader, out []byte) ([]byte, error) {
+	for r.err == nil {
+		start := r.pos
+		switch op := r.byte(); op {
+		case 0x0b: // end
+			return append(out, op), nil
+		case 0xd2: // ref.func
+			out = appendLEB(append(out, op), m.function(r.leb()))
+			continue
+		case 0x23, 0x41, 0x42: // global.get, i32.const, i64.const
+			r.leb()
+		case 0x43: // f32.const
+			r.bytes(4)
+		case 0x44: // f64.const
+			r.bytes(8)
+		case 0xd0: // ref.null
+			r.byte()
+		case 0x6a, 0x6b, 0x6c, 0x7c, 0x7d, 0x7e: // extended constant arithmetic
+		case 0xfd: // v128.const
+			if r.leb() != 12 {
+				return nil, errMalformed
+			}
+			r.bytes(16)
+		default:
+			return nil, fmt.Errorf("unsupported constant instruction 0x%x", op)
+		}
+		out = append(out, r.buf[start:r.pos]...)
+	}
+	return nil, r.err
+}
+
+// rewriteBody instruments a function body, splitting it into straight-line
+// runs of instructions and charging each of them upfront.
+func (m *metering) rewriteBody(r *reader, out []byte) ([]byte, error) {
+	body := &reader{buf: r.vec()}
+	if r.err != nil {
+		return nil, r.err
+	}
+	for n := body.leb(); n > 0 && body.err == nil; n-- {
+		body.leb()  // count
+		body.byte() // type
+	}
+	if body.err != nil {
+		return nil, body.err
+	}
+	code := append([]byte{}, body.buf[:body.pos]...)
+
+	var (
+		run   []byte // Instructions of the current run
+		cost  int64  // Number of instructions in the current run
+		depth = 1    // Nesting of the blocks, the function body being one
+	)
+	for depth > 0 {
+		if body.eof() {
+			return nil, errMalformed
+		}
+		start := body.pos
+		op := body.byte()
+		cost++
+
+		split, err := skipImmediates(body, op, m.fuel)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case 0x02, 0x03, 0x04: // block, loop, if
+			depth++
+		case 0x0b: // end
+			depth--
+		case 0x10, 0x12, 0xd2: // call, return_call, ref.func
+			index := (&reader{buf: body.buf, pos: start + 1}).leb()
+			run = appendLEB(append(run, op), m.function(index))
+			start = body.pos
+		}
+		run = append(run, body.buf[start:body.pos]...)
+
+		if split || depth == 0 {
+			code = m.appendCharge(code, cost)
+			code = append(code, run...)
+			run, cost = run[:0], 0
+		}
+	}
+	if !body.eof() {
+		return nil, errMalformed
+	}
+	out = appendLEB(out, uint64(len(code)))
+	return append(out, code...), nil
+}
+
+// appendCharge appends the code deducting the given cost from the fuel counter,
+// refueling first if it is insufficient.
+func (m *metering) appendCharge(b []byte, cost int64) []byte {
+	b = appendLEB(append(b, 0x23), m.fuel)       // global.get
+	b = appendSLEB(append(b, 0x42), cost)        // i64.const
+	b = append(b, 0x54, 0x04, 0x40)              // i64.lt_u, if
+	b = appendSLEB(append(b, 0x42), cost)        // i64.const
+	b = appendLEB(append(b, 0x10), m.functions)  // call refuel
+	b = append(b, 0x0b)                          // end
+	b = appendLEB(append(b, 0x23), m.fuel)       // global.get
+	b = appendSLEB(append(b, 0x42), cost)        // i64.const
+	b = appendLEB(append(b, 0x7d, 0x24), m.fuel) // i64.sub, global.set
+	return b
+}
+
+// appendEntries rewrites the entries of a vector section with the given
+// function, appending an extra entry if set.
+func appendEntries(section []byte, rewrite func(*reader, []byte) ([]byte, error), extra []byte) ([]byte, error) {
+	r := &reader{buf: section}
+	n := r.leb()
+	if r.err != nil {
+		return nil, r.err
+	}
+	var out []byte
+	if extra != nil {
+		out = appendLEB(out, n+1)
+	} else {
+		out = appendLEB(out, n)
+	}
+	if rewrite == nil {
+		out = append(out, section[r.pos:]...)
+	} else {
+		for ; n > 0; n-- {
+			var err error
+			if out, err = rewrite(r, out); err != nil {
+				return nil, err
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+		}
+		if !r.eof() {
+			return nil, errMalformed
+		}
+	}
+	return append(out, extra...), nil
+}
+
+// appendName appends a name to an encoded entry.
+func appendName(b []byte, name string) []byte {
+	return append(appendLEB(b, uint64(len(name))), name...)
+}
+
+// skipImmediates skips the immediates of an instruction, reporting whether a
+// new run of code starts after it. Instructions outside of the proposals
+// supported by the runtime are rejected, as well as any access to the fuel
+// global.
+func skipImmediates(r *reader, op byte, global uint64) (bool, error) {
+	switch {
+	case op == 0x02 || op == 0x03 || op == 0x04: // block, loop, if
+		skipBlockType(r)
+		return true, r.err
+	case op == 0x05 || op == 0x0b: // else, end
+		return true, nil
+	case op == 0x0c: // br
+		r.leb()
+	case op == 0x0d: // br_if
+		r.leb()
+		return true, r.err
+	case op == 0x0e: // br_table
+		for n := r.leb(); n > 0 && r.err == nil; n-- {
+			r.leb()
+		}
+		r.leb()
+	case op == 0x00 || op == 0x01 || op == 0x0f: // unreachable, nop, return
+	case op == 0x10 || op == 0x12: // call, return_call
+		r.leb()
+	case op == 0x11 || op == 0x13: // call_indirect, return_call_indirect
+		r.leb()
+		r.leb()
+	case op == 0x1a || op == 0x1b: // drop, select
+	case op == 0x1c: // select t*
+		r.vec()
+	case op >= 0x20 && op <= 0x22: // local.get, local.set, local.tee
+		r.leb()
+	case op == 0x23 || op == 0x24: // global.get, global.set
+		if r.leb() >= global {
+			return false, fmt.Errorf("invalid global access at %d", r.pos)
+		}
+	case op == 0x25 || op == 0x26: // table.get, table.set
+		r.leb()
+	case op >= 0x28 && op <= 0x3e: // loads and stores
+		skipMemArg(r)
+	case op == 0x3f || op == 0x40: // memory.size, memory.grow
+		r.leb()
+	case op == 0x41 || op == 0x42: // i32.const, i64.const
+		r.leb()
+	case op == 0x43: // f32.const
+		r.bytes(4)
+	case op == 0x44: // f64.const
+		r.bytes(8)
+	case op >= 0x45 && op <= 0xc4: // numeric
+	case op == 0xd0: // ref.null
+		r.byte()
+	case op == 0xd1: // ref.is_null
+	case op == 0xd2: // ref.func
+		r.leb()
+	case op == 0xfc:
+		switch sub := r.leb(); {
+		case sub <= 7: // saturating truncations
+		case sub == 8: // memory.init
+			r.leb()
+			r.leb()
+		case sub == 9 || sub == 11 || sub == 13 || (sub >= 15 && sub <= 17): // data.drop, memory.fill, elem.drop, table.grow/size/fill
+			r.leb()
+		case sub == 10 || sub == 12 || sub == 14: // memory.copy, table.init, table.copy
+			r.leb()
+			r.leb()
+		default:
+			return false, fmt.Errorf("unsupported instruction 0xfc %d", sub)
+		}
+	case op == 0xfd:
+		switch sub := r.leb(); {
+		case sub <= 11 || sub == 92 || sub == 93: // loads and stores
+			skipMemArg(r)
+		case sub == 12 || sub == 13: // v128.const, i8x16.shuffle
+			r.bytes(16)
+		case sub >= 21 && sub <= 34: // lane extractions and replacements
+			r.byte()
+		case sub >= 84 && sub <= 91: // lane loads and stores
+			skipMemArg(r)
+			r.byte()
+		case sub > 255:
+			return false, fmt.Errorf("unsupported instruction 0xfd %d", sub)
+		}
+	case op == 0xfe:
+		if sub := r.leb(); sub == 0x03 { // atomic.fence
+			r.byte()
+		} else {
+			skipMemArg(r)
+		}
+	default:
+		return false, fmt.Errorf("unsupported instruction 0x%x", op)
+	}
+	return false, r.err
+}
+
+// skipBlockType skips the type of a block, either empty, a value type or a
+// type index.
+func skipBlockType(r *reader) {
+	if r.eof() {
+		r.err = errMalformed
+		return
+	}
+	if b := r.buf[r.pos]; b&0xc0 == 0x40 { // single byte negative s33
+		r.pos++
+		return
+	}
+	r.leb()
+}
+
+// skipMemArg skips the alignment and offset of a memory access.
+func skipMemArg(r *reader) {
+	if r.leb()&0x40 != 0 { // multi-memory index
+		r.leb()
+	}
+	r.leb()
+}
+
+// skipLimits skips the limits of a table or memory.
+func skipLimits(r *reader) {
+	flags := r.byte()
+	r.leb()
+	if flags&0x01 != 0 {
+		r.leb()
+	}
+}
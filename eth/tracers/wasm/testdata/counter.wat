@@ -0,0 +1,46 @@
+;; Tracer counting the transactions, calls and steps it sees, along with the
+;; deepest EVM stack. The counters are reported as zero padded strings, which
+;; saves formatting JSON numbers by hand.
+(module
+  (import "geth" "stack_len" (func $stack_len (result i32)))
+  (memory (export "memory") 1)
+
+  (global $txs (mut i64) (i64.const 0))
+  (global $steps (mut i64) (i64.const 0))
+  (global $calls (mut i64) (i64.const 0))
+  (global $stack (mut i64) (i64.const 0))
+
+  (data (i32.const 0) "{\"txs\":\"00000000\",\"steps\":\"00000000\",\"calls\":\"00000000\",\"stack\":\"00000000\"}")
+
+  (func (export "buffer") (param $size i32) (result i32)
+    (i32.const 1024))
+
+  (func (export "tx_start") (param $gas i64) (param $ptr i32) (param $len i32)
+    (global.set $txs (i64.add (global.get $txs) (i64.const 1))))
+
+  (func (export "enter") (param $depth i32) (param $typ i32) (param $gas i64) (param $ptr i32) (param $len i32)
+    (global.set $calls (i64.add (global.get $calls) (i64.const 1))))
+
+  (func (export "step") (param $pc i64) (param $op i32) (param $gas i64) (param $cost i64) (param $depth i32)
+    (local $size i64)
+    (global.set $steps (i64.add (global.get $steps) (i64.const 1)))
+    (if (i64.gt_u (local.tee $size (i64.extend_i32_u (call $stack_len))) (global.get $stack))
+      (then (global.set $stack (local.get $size)))))
+
+  (func (export "result") (result i64)
+    (call $digits (i32.const 8) (global.get $txs))
+    (call $digits (i32.const 27) (global.get $steps))
+    (call $digits (i32.const 46) (global.get $calls))
+    (call $digits (i32.const 65) (global.get $stack))
+    (i64.const 75))
+
+  ;; digits writes the lowest 8 decimal digits of a value at the given address.
+  (func $digits (param $ptr i32) (param $value i64)
+    (local $i i32)
+    (local.set $i (i32.const 8))
+    (loop $next
+      (i64.store8
+        (i32.add (local.get $ptr) (local.tee $i (i32.sub (local.get $i) (i32.const 1))))
+        (i64.add (i64.rem_u (local.get $value) (i64.const 10)) (i64.const 48)))
+      (local.set $value (i64.div_u (local.get $value) (i64.const 10)))
+      (br_if $next (local.get $i)))))
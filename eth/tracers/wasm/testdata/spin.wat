@@ -0,0 +1,9 @@
+;; Tracer never returning from the end of a transaction.
+(module
+  (memory (export "memory") 1)
+
+  (func (export "tx_end") (param $gas i64) (param $status i32)
+    (loop $spin (br $spin)))
+
+  (func (export "result") (result i64)
+    (i64.const 0)))
@@ -0,0 +1,548 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package wasm implements user-defined tracers compiled to WebAssembly.
+//
+// A tracer module is instantiated for every traced transaction and driven
+// through the functions it exports, all of them optional except for memory
+// and result:
+//
+//	buffer(size i32) -> i32                                  scratch space for event payloads
+//	tx_start(gasLimit i64, ptr i32, len i32)                 hash, from, to, value (32 bytes), input
+//	tx_end(gasUsed i64, status i32)
+//	enter(depth i32, typ i32, gas i64, ptr i32, len i32)     from, to, value (32 bytes), input
+//	exit(depth i32, gasUsed i64, reverted i32, ptr i32, len i32)  output
+//	step(pc i64, op i32, gas i64, cost i64, depth i32)
+//	result() -> i64                                          JSON result, as ptr<<32 | len
+//
+// While stepping, the module may inspect the execution scope through the
+// functions imported from the "geth" module:
+//
+//	stack_len() -> i32
+//	stack_peek(n i32, ptr i32) -> i32                         n-th word from the top, 32 bytes
+//	memory_len() -> i32
+//	memory_read(offset i32, size i32, ptr i32) -> i32
+//	address(ptr i32) -> i32                                  20 bytes
+//	caller(ptr i32) -> i32                                   20 bytes
+//
+// The functions returning an i32 report 0 on success and 1 if the requested
+// data is unavailable. Modules built for WASI preview 1 are supported, without
+// access to the filesystem, environment or any other host resource.
+//
+// Tracers run with a fuel budget: every instruction executed, every host call
+// and every 32 bytes copied across consume one unit. A module running out of
+// fuel is stopped and the trace fails. The fuel is handed to the module in
+// chunks, between which it returns control to the host: stopping the tracer,
+// e.g. on the tracing timeout, interrupts the module at the end of its chunk.
+//
+// The module instance is released by GetResult, which has to be called even if
+// the trace fails.
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("wasmTracer", newWasmTracer, false)
+}
+
+const (
+	// defaultFuel is the fuel budget of a tracer if the config sets none.
+	defaultFuel = 100_000_000
+
+	// fuelChunk is the fuel handed to a module at once, bounding the number of
+	// instructions it runs before yielding to the host.
+	fuelChunk = 1 << 20
+
+	// memoryLimitPages caps the memory of a tracer module, 64KiB per page.
+	memoryLimitPages = 1024
+
+	// moduleCacheSize is the number of compiled tracer modules kept around, so
+	// that the tracers of a block do not recompile the module on every
+	// transaction.
+	moduleCacheSize = 16
+)
+
+var (
+	errOutOfFuel     = errors.New("wasm tracer out of fuel")
+	errNoResult      = errors.New("wasm tracer exports no result function")
+	errInvalidResult = errors.New("wasm tracer result is not valid JSON")
+)
+
+var (
+	engine     wazero.Runtime // Runtime shared by all tracers, with the host module
+	engineErr  error
+	engineOnce sync.Once
+
+	modules     = lru.NewBasicLRU[common.Hash, wazero.CompiledModule](moduleCacheSize)
+	modulesLock sync.Mutex
+
+	instances atomic.Int64 // Number of module instances not released yet
+)
+
+// tracerKey is the context key of the tracer a host function is called for.
+type tracerKey struct{}
+
+// compile returns the compiled module of the given code, instrumented with the
+// fuel counter, from the cache if it was compiled before.
+func compile(code []byte) (wazero.CompiledModule, error) {
+	engineOnce.Do(func() { engine, engineErr = newEngine() })
+	if engineErr != nil {
+		return nil, engineErr
+	}
+	hash := crypto.Keccak256Hash(code)
+
+	modulesLock.Lock()
+	defer modulesLock.Unlock()
+
+	if compiled, ok := modules.Get(hash); ok {
+		return compiled, nil
+	}
+	metered, err := meter(code)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := engine.CompileModule(context.Background(), metered)
+	if err != nil {
+		return nil, err
+	}
+	// Closing an evicted module is safe while instances of it are running
+	if modules.Len() >= moduleCacheSize {
+		if _, evicted, ok := modules.RemoveOldest(); ok {
+			evicted.Close(context.Background())
+		}
+	}
+	modules.Add(hash, compiled)
+	return compiled, nil
+}
+
+// newEngine creates the runtime executing the tracer modules, with the host
+// functions they may import.
+func newEngine() (wazero.Runtime, error) {
+	// Termination is ensured by the fuel counter instead of the runtime, which
+	// would spawn a goroutine watching for cancellation on every invocation.
+	ctx := context.Background()
+	config := wazero.NewRuntimeConfig().WithMemoryLimitPages(memoryLimitPages)
+
+	r := wazero.NewRuntimeWithConfig(ctx, config)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, r); err != nil {
+		return nil, err
+	}
+	var (
+		i32 = api.ValueTypeI32
+		mod = r.NewHostModuleBuilder("geth")
+	)
+	host := func(name string, fn func(t *wasmTracer, m api.Module, stack []uint64), params, results []api.ValueType) {
+		mod.NewFunctionBuilder().WithGoModuleFunction(api.GoModuleFunc(func(ctx context.Context, m api.Module, stack []uint64) {
+			t := ctx.Value(tracerKey{}).(*wasmTracer)
+			if !t.consume(1) {
+				panic(errOutOfFuel)
+			}
+			fn(t, m, stack)
+		}), params, results).Export(name)
+	}
+	host("stack_len", hostStackLen, nil, []api.ValueType{i32})
+	host("stack_peek", hostStackPeek, []api.ValueType{i32, i32}, []api.ValueType{i32})
+	host("memory_len", hostMemoryLen, nil, []api.ValueType{i32})
+	host("memory_read", hostMemoryRead, []api.ValueType{i32, i32, i32}, []api.ValueType{i32})
+	host("address", hostAddress, []api.ValueType{i32}, []api.ValueType{i32})
+	host("caller", hostCaller, []api.ValueType{i32}, []api.ValueType{i32})
+
+	mod.NewFunctionBuilder().WithGoModuleFunction(api.GoModuleFunc(func(ctx context.Context, m api.Module, stack []uint64) {
+		ctx.Value(tracerKey{}).(*wasmTracer).refuel(m, stack[0])
+	}), []api.ValueType{api.ValueTypeI64}, nil).Export(refuelFunc)
+
+	if _, err := mod.Instantiate(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// hostStackLen returns the depth of the stack of the current step.
+func hostStackLen(t *wasmTracer, m api.Module, stack []uint64) {
+	if t.scope == nil {
+		stack[0] = 0
+		return
+	}
+	stack[0] = uint64(len(t.scope.StackData()))
+}
+
+// hostStackPeek writes the n-th word from the top of the stack of the current
+// step into the module memory.
+func hostStackPeek(t *wasmTracer, m api.Module, stack []uint64) {
+	n, ptr := uint32(stack[0]), uint32(stack[1])
+	if t.scope == nil {
+		stack[0] = 1
+		return
+	}
+	data := t.scope.StackData()
+	if uint64(n) >= uint64(len(data)) {
+		stack[0] = 1
+		return
+	}
+	word := data[len(data)-1-int(n)].Bytes32()
+	stack[0] = writeStatus(m.Memory().Write(ptr, word[:]))
+}
+
+// hostMemoryLen returns the size of the memory of the current step.
+func hostMemoryLen(t *wasmTracer, m api.Module, stack []uint64) {
+	if t.scope == nil {
+		stack[0] = 0
+		return
+	}
+	stack[0] = uint64(len(t.scope.MemoryData()))
+}
+
+// hostMemoryRead copies a slice of the memory of the current step into the
+// module memory.
+func hostMemoryRead(t *wasmTracer, m api.Module, stack []uint64) {
+	offset, size, ptr := uint32(stack[0]), uint32(stack[1]), uint32(stack[2])
+	if t.scope == nil {
+		stack[0] = 1
+		return
+	}
+	data := t.scope.MemoryData()
+	if uint64(offset)+uint64(size) > uint64(len(data)) {
+		stack[0] = 1
+		return
+	}
+	if !t.consume(uint64(size) / 32) {
+		panic(errOutOfFuel)
+	}
+	stack[0] = writeStatus(m.Memory().Write(ptr, data[offset:offset+size]))
+}
+
+// hostAddress writes the address of the contract executing the current step
+// into the module memory.
+func hostAddress(t *wasmTracer, m api.Module, stack []uint64) {
+	if t.scope == nil {
+		stack[0] = 1
+		return
+	}
+	addr := t.scope.Address()
+	stack[0] = writeStatus(m.Memory().Write(uint32(stack[0]), addr[:]))
+}
+
+// hostCaller writes the caller of the contract executing the current step into
+// the module memory.
+func hostCaller(t *wasmTracer, m api.Module, stack []uint64) {
+	if t.scope == nil {
+		stack[0] = 1
+		return
+	}
+	addr := t.scope.Caller()
+	stack[0] = writeStatus(m.Memory().Write(uint32(stack[0]), addr[:]))
+}
+
+// writeStatus converts the outcome of a module memory write into the status
+// returned by the host functions.
+func writeStatus(ok bool) uint64 {
+	if ok {
+		return 0
+	}
+	return 1
+}
+
+// config are the settings of a wasm tracer.
+type config struct {
+	Code hexutil.Bytes `json:"code"` // WebAssembly module of the tracer
+	Fuel uint64        `json:"fuel"` // Fuel budget, defaultFuel if unset
+}
+
+// wasmTracer runs a user-defined tracer module.
+type wasmTracer struct {
+	ctx  context.Context
+	mod  api.Module
+	tctx *tracers.Context
+
+	buffer  api.Function
+	txStart api.Function
+	txEnd   api.Function
+	enter   api.Function
+	exit    api.Function
+	step    api.Function
+	result  api.Function
+
+	stack []uint64          // Reused parameter stack of the module invocations
+	scope tracing.OpContext // Scope of the current step, nil outside steps
+	err   error             // First failure of the module, no more invocations after it
+	done  bool              // Whether the module instance was released
+
+	fuel   api.MutableGlobal // Fuel counter of the module, holding the current chunk
+	budget uint64            // Fuel left besides the current chunk
+
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newWasmTracer instantiates the tracer module given in the config.
+func newWasmTracer(ctx *tracers.Context, cfg json.RawMessage, _ *params.ChainConfig) (*tracers.Tracer, error) {
+	var config config
+	if err := json.Unmarshal(cfg, &config); err != nil {
+		return nil, err
+	}
+	if len(config.Code) == 0 {
+		return nil, errors.New("wasm tracer code missing")
+	}
+	if config.Fuel == 0 {
+		config.Fuel = defaultFuel
+	}
+	compiled, err := compile(config.Code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wasm tracer: %w", err)
+	}
+	t := &wasmTracer{
+		tctx:   ctx,
+		stack:  make([]uint64, 5),
+		budget: config.Fuel,
+	}
+	t.ctx = context.WithValue(context.Background(), tracerKey{}, t)
+
+	// Run the WASI reactor initialisation, if any, but never a command's main
+	modcfg := wazero.NewModuleConfig().WithName("").WithStartFunctions("_initialize")
+	if t.mod, err = engine.InstantiateModule(t.ctx, compiled, modcfg); err != nil {
+		return nil, fmt.Errorf("failed to instantiate wasm tracer: %w", err)
+	}
+	instances.Add(1)
+
+	t.buffer = t.mod.ExportedFunction("buffer")
+	t.txStart = t.mod.ExportedFunction("tx_start")
+	t.txEnd = t.mod.ExportedFunction("tx_end")
+	t.enter = t.mod.ExportedFunction("enter")
+	t.exit = t.mod.ExportedFunction("exit")
+	t.step = t.mod.ExportedFunction("step")
+	t.result = t.mod.ExportedFunction("result")
+	if t.result == nil {
+		t.close()
+		return nil, errNoResult
+	}
+	// Only hook into the events the module handles, stepping is expensive
+	hooks := new(tracing.Hooks)
+	if t.txStart != nil {
+		hooks.OnTxStart = t.OnTxStart
+	}
+	if t.txEnd != nil {
+		hooks.OnTxEnd = t.OnTxEnd
+	}
+	if t.enter != nil {
+		hooks.OnEnter = t.OnEnter
+	}
+	if t.exit != nil {
+		hooks.OnExit = t.OnExit
+	}
+	if t.step != nil {
+		hooks.OnOpcode = t.OnOpcode
+	}
+	return &tracers.Tracer{
+		Hooks:     hooks,
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// close releases the module instance.
+func (t *wasmTracer) close() {
+	if !t.done {
+		t.done = true
+		t.mod.Close(context.Background())
+		instances.Add(-1)
+	}
+}
+
+// consume deducts fuel from the budget of the tracer, failing it if the budget
+// is exhausted.
+func (t *wasmTracer) consume(fuel uint64) bool {
+	if t.budget < fuel {
+		t.budget = 0
+		t.fail(errOutOfFuel)
+		return false
+	}
+	t.budget -= fuel
+	return true
+}
+
+// refuel refills the fuel counter of the module with the next chunk, which has
+// to cover at least the given cost. The module is aborted if the tracer was
+// stopped or if the budget is exhausted.
+func (t *wasmTracer) refuel(m api.Module, cost uint64) {
+	if t.interrupt.Load() {
+		panic(t.reason)
+	}
+	// The counter is looked up from the calling module, as refueling may happen
+	// during its initialisation
+	if t.fuel == nil {
+		t.fuel = m.ExportedGlobal(fuelGlobal).(api.MutableGlobal)
+	}
+	left := t.fuel.Get()
+	if !t.consume(cost - left) {
+		panic(errOutOfFuel)
+	}
+	chunk := min(t.budget, fuelChunk)
+	t.budget -= chunk
+	t.fuel.Set(cost + chunk)
+}
+
+// fail records the first failure of the module.
+func (t *wasmTracer) fail(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+// invoke calls an exported function of the module with the given parameters.
+func (t *wasmTracer) invoke(fn api.Function, params ...uint64) bool {
+	if t.err != nil || t.interrupt.Load() {
+		return false
+	}
+	copy(t.stack, params)
+	if err := fn.CallWithStack(t.ctx, t.stack); err != nil {
+		t.fail(err)
+		return false
+	}
+	return true
+}
+
+// write copies an event payload into the scratch buffer of the module.
+func (t *wasmTracer) write(payload []byte) (uint32, bool) {
+	if t.buffer == nil {
+		t.fail(errors.New("wasm tracer exports no buffer function"))
+		return 0, false
+	}
+	if !t.consume(uint64(len(payload))/32) || !t.invoke(t.buffer, uint64(len(payload))) {
+		return 0, false
+	}
+	ptr := uint32(t.stack[0])
+	if !t.mod.Memory().Write(ptr, payload) {
+		t.fail(fmt.Errorf("wasm tracer buffer out of bounds: %d+%d", ptr, len(payload)))
+		return 0, false
+	}
+	return ptr, true
+}
+
+// word encodes a value as a 32 byte big endian word.
+func word(value *big.Int) []byte {
+	var w [32]byte
+	if value != nil {
+		value.FillBytes(w[:])
+	}
+	return w[:]
+}
+
+func (t *wasmTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	var to common.Address
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+	payload := make([]byte, 0, 104+len(tx.Data()))
+	payload = append(payload, t.tctx.TxHash[:]...)
+	payload = append(payload, from[:]...)
+	payload = append(payload, to[:]...)
+	payload = append(payload, word(tx.Value())...)
+	payload = append(payload, tx.Data()...)
+
+	if ptr, ok := t.write(payload); ok {
+		t.invoke(t.txStart, tx.Gas(), uint64(ptr), uint64(len(payload)))
+	}
+}
+
+func (t *wasmTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	var gasUsed, status uint64
+	if receipt != nil {
+		gasUsed = receipt.GasUsed
+		if err == nil && receipt.Status == types.ReceiptStatusSuccessful {
+			status = 1
+		}
+	}
+	t.invoke(t.txEnd, gasUsed, status)
+}
+
+func (t *wasmTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	payload := make([]byte, 0, 72+len(input))
+	payload = append(payload, from[:]...)
+	payload = append(payload, to[:]...)
+	payload = append(payload, word(value)...)
+	payload = append(payload, input...)
+
+	if ptr, ok := t.write(payload); ok {
+		t.invoke(t.enter, uint64(depth), uint64(typ), gas, uint64(ptr), uint64(len(payload)))
+	}
+}
+
+func (t *wasmTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	var flag uint64
+	if reverted {
+		flag = 1
+	}
+	if ptr, ok := t.write(output); ok {
+		t.invoke(t.exit, uint64(depth), gasUsed, flag, uint64(ptr), uint64(len(output)))
+	}
+}
+
+func (t *wasmTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	t.scope = scope
+	t.invoke(t.step, pc, uint64(op), gas, cost, uint64(depth))
+	t.scope = nil
+}
+
+// GetResult returns the JSON result produced by the module.
+func (t *wasmTracer) GetResult() (json.RawMessage, error) {
+	defer t.close()
+
+	if t.interrupt.Load() {
+		return nil, t.reason
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+	if !t.invoke(t.result) {
+		return nil, t.err
+	}
+	packed := t.stack[0]
+	blob, ok := t.mod.Memory().Read(uint32(packed>>32), uint32(packed))
+	if !ok {
+		return nil, fmt.Errorf("wasm tracer result out of bounds: %d+%d", packed>>32, uint32(packed))
+	}
+	if !json.Valid(blob) {
+		return nil, errInvalidResult
+	}
+	return append(json.RawMessage(nil), blob...), nil
+}
+
+// Stop terminates execution of the tracer at the first opportune moment,
+// interrupting the module if it is running when it runs out of its chunk.
+func (t *wasmTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
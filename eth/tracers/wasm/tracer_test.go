@@ -0,0 +1,207 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package wasm
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+type dummyStatedb struct {
+	state.StateDB
+}
+
+func (*dummyStatedb) GetRefund() uint64 { return 0 }
+
+func newTracer(t *testing.T, file string, fuel uint64) *tracers.Tracer {
+	t.Helper()
+
+	code, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := json.Marshal(map[string]interface{}{"code": "0x" + common.Bytes2Hex(code), "fuel": fuel})
+	tracer, err := tracers.DefaultDirectory.New("wasmTracer", new(tracers.Context), cfg, params.TestChainConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tracer
+}
+
+func runTrace(tracer *tracers.Tracer, code []byte) (json.RawMessage, error) {
+	var (
+		evm      = vm.NewEVM(vm.BlockContext{BlockNumber: big.NewInt(1), BaseFee: big.NewInt(0)}, &dummyStatedb{}, params.TestChainConfig, vm.Config{Tracer: tracer.Hooks})
+		gasLimit = uint64(31000)
+		startGas = uint64(10000)
+		value    = uint256.NewInt(0)
+		contract = vm.NewContract(common.Address{}, common.Address{}, value, startGas, nil)
+	)
+	contract.Code = code
+
+	// The hooks are only set for the events the module handles
+	if tracer.OnTxStart != nil {
+		tracer.OnTxStart(evm.GetVMContext(), types.NewTx(&types.LegacyTx{Gas: gasLimit}), contract.Caller())
+	}
+	if tracer.OnEnter != nil {
+		tracer.OnEnter(0, byte(vm.CALL), contract.Caller(), contract.Address(), []byte{}, startGas, value.ToBig())
+	}
+	ret, err := evm.Interpreter().Run(contract, []byte{}, false)
+	if tracer.OnExit != nil {
+		tracer.OnExit(0, ret, startGas-contract.Gas, err, false)
+	}
+	if tracer.OnTxEnd != nil {
+		tracer.OnTxEnd(&types.Receipt{GasUsed: gasLimit - contract.Gas, Status: types.ReceiptStatusSuccessful}, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tracer.GetResult()
+}
+
+// Tests that the module is driven through the transaction, call and step
+// events, and may inspect the stack while stepping.
+func TestTracer(t *testing.T) {
+	tracer := newTracer(t, "testdata/counter.wasm", 0)
+
+	res, err := runTrace(tracer, []byte{byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x1, byte(vm.STOP)})
+	if err != nil {
+		t.Fatalf("trace failed: %v", err)
+	}
+	want := `{"txs":"00000001","steps":"00000003","calls":"00000001","stack":"00000002"}`
+	if string(res) != want {
+		t.Errorf("result mismatch: have %s, want %s", res, want)
+	}
+}
+
+// Tests that a tracer running out of fuel fails the trace.
+func TestTracerOutOfFuel(t *testing.T) {
+	tracer := newTracer(t, "testdata/counter.wasm", 5)
+
+	code := make([]byte, 0, 64)
+	for i := 0; i < 16; i++ {
+		code = append(code, byte(vm.PUSH1), 0x1, byte(vm.POP))
+	}
+	if _, err := runTrace(tracer, code); !errors.Is(err, errOutOfFuel) {
+		t.Fatalf("error mismatch: have %v, want %v", err, errOutOfFuel)
+	}
+}
+
+// Tests that stopping the tracer interrupts a module that never returns.
+func TestTracerStop(t *testing.T) {
+	tracer := newTracer(t, "testdata/spin.wasm", math.MaxInt64)
+
+	reason := errors.New("stahp")
+	time.AfterFunc(100*time.Millisecond, func() { tracer.Stop(reason) })
+
+	done := make(chan error)
+	go func() {
+		_, err := runTrace(tracer, []byte{byte(vm.STOP)})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, reason) {
+			t.Fatalf("error mismatch: have %v, want %v", err, reason)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("tracer not interrupted")
+	}
+}
+
+// Tests that invalid modules and modules without a result are rejected.
+func TestTracerInvalid(t *testing.T) {
+	for _, code := range []string{"0x", "0x0061736d", "0x0061736d01000000"} {
+		cfg := []byte(`{"code":"` + code + `"}`)
+		if _, err := tracers.DefaultDirectory.New("wasmTracer", new(tracers.Context), cfg, params.TestChainConfig); err == nil {
+			t.Errorf("code %s: expected error", code)
+		}
+	}
+}
+
+// Tests that the fuel is charged per instruction, stopping a module looping
+// within a single invocation.
+func TestTracerMetering(t *testing.T) {
+	tracer := newTracer(t, "testdata/spin.wasm", 3*fuelChunk)
+
+	done := make(chan error)
+	go func() {
+		_, err := runTrace(tracer, []byte{byte(vm.STOP)})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, errOutOfFuel) {
+			t.Fatalf("error mismatch: have %v, want %v", err, errOutOfFuel)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("tracer not metered")
+	}
+}
+
+// Tests that the module instance is released once the result is retrieved,
+// whether the trace failed or not.
+func TestTracerRelease(t *testing.T) {
+	before := instances.Load()
+
+	tracer := newTracer(t, "testdata/counter.wasm", 5)
+	if instances.Load() != before+1 {
+		t.Fatal("module instance not tracked")
+	}
+	code := make([]byte, 0, 64)
+	for i := 0; i < 16; i++ {
+		code = append(code, byte(vm.PUSH1), 0x1, byte(vm.POP))
+	}
+	if _, err := runTrace(tracer, code); err == nil {
+		t.Fatal("trace out of fuel succeeded")
+	}
+	if have := instances.Load(); have != before {
+		t.Fatalf("module instance leaked: have %d instances, want %d", have, before)
+	}
+	// A tracer stopped before running is released as well
+	tracer = newTracer(t, "testdata/counter.wasm", 0)
+	tracer.Stop(errors.New("stahp"))
+	if _, err := tracer.GetResult(); err == nil {
+		t.Fatal("stopped tracer returned a result")
+	}
+	if have := instances.Load(); have != before {
+		t.Fatalf("module instance leaked: have %d instances, want %d", have, before)
+	}
+}
+
+// Tests that modules accessing the fuel counter are rejected.
+func TestMeterGlobalAccess(t *testing.T) {
+	// (module (func (export "f") (global.set 0 (i64.const 1)))), the global
+	// index being out of range before the instrumentation
+	code := common.FromHex("0x0061736d0100000001040160000003020100070501016600000a08010600420124000b")
+	if _, err := meter(code); err == nil || !strings.Contains(err.Error(), "invalid global access") {
+		t.Fatalf("access to the fuel counter not rejected: %v", err)
+	}
+}
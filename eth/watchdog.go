@@ -0,0 +1,111 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/p2p"
+)
+
+// watchdogProbeTimeout is the time allowed for a peer to serve a header probed
+// by the chain head watchdog.
+const watchdogProbeTimeout = 5 * time.Second
+
+// watchdogBackend implements watchdog.Backend on top of the protocol handler.
+type watchdogBackend struct {
+	h *handler
+}
+
+// CurrentHeader returns the local chain head.
+func (b *watchdogBackend) CurrentHeader() *types.Header {
+	return b.h.chain.CurrentHeader()
+}
+
+// ProbePeers requests the header with the given number from all the connected
+// peers and sorts them by whether they served it.
+func (b *watchdogBackend) ProbePeers(number uint64) (ahead []string, behind []string) {
+	var (
+		peers = b.h.peers.all()
+		found = make([]bool, len(peers))
+		wg    sync.WaitGroup
+	)
+	for i, peer := range peers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			found[i] = probeHeader(peer.Peer, number)
+		}()
+	}
+	wg.Wait()
+
+	for i, peer := range peers {
+		if found[i] {
+			ahead = append(ahead, peer.ID())
+		} else {
+			behind = append(behind, peer.ID())
+		}
+	}
+	return ahead, behind
+}
+
+// probeHeader reports whether the peer serves the header with the given number
+// within the probe timeout.
+func probeHeader(peer *eth.Peer, number uint64) bool {
+	resCh := make(chan *eth.Response)
+	req, err := peer.RequestHeadersByNumber(number, 1, 0, false, resCh)
+	if err != nil {
+		return false
+	}
+	defer req.Close()
+
+	timeout := time.NewTimer(watchdogProbeTimeout)
+	defer timeout.Stop()
+
+	select {
+	case res := <-resCh:
+		headers := *res.Res.(*eth.BlockHeadersRequest)
+		res.Done <- nil
+		return len(headers) == 1 && headers[0].Number.Uint64() == number
+	case <-timeout.C:
+		return false
+	}
+}
+
+// DropPeers disconnects the given peers, apart from the trusted and static ones.
+func (b *watchdogBackend) DropPeers(ids []string) int {
+	var dropped int
+	for _, id := range ids {
+		peer := b.h.peers.peer(id)
+		if peer == nil || peer.Peer.Trusted() || peer.Peer.StaticDialed() {
+			continue
+		}
+		peer.Log().Debug("Dropping peer to recover stalled chain head")
+		peer.Peer.Disconnect(p2p.DiscUselessPeer)
+		dropped++
+	}
+	return dropped
+}
+
+// RestartSync aborts the running sync cycle. The next sync target announced by
+// the consensus client restarts it with the current peers.
+func (b *watchdogBackend) RestartSync() {
+	b.h.downloader.Cancel()
+}
@@ -0,0 +1,361 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package watchdog implements a chain head watchdog, which detects when the
+// local head stops advancing while the rest of the network moves on, and tries
+// to recover by rotating peers and restarting the sync instead of silently
+// stalling.
+//
+// The network head is taken from the configured reference nodes if any of them
+// is reachable, otherwise from the connected peers: the node is considered
+// behind if the majority of its peers serve a header the configured lag ahead
+// of the local head.
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	mrand "math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// referenceTimeout is the time allowed for a reference node to report its head.
+const referenceTimeout = 5 * time.Second
+
+var (
+	stateGauge = metrics.NewRegisteredGauge("watchdog/state", nil)
+	lagGauge   = metrics.NewRegisteredGauge("watchdog/lag", nil)
+	stallMeter = metrics.NewRegisteredMeter("watchdog/stalls", nil)
+	dropMeter  = metrics.NewRegisteredMeter("watchdog/drops", nil)
+)
+
+// State is the health of the local chain head as seen by the watchdog.
+type State int
+
+const (
+	Healthy State = iota // The head is within the allowed lag of the network
+	Behind               // The head trails the network, but is still progressing
+	Stalled              // The head trails the network and stopped progressing
+)
+
+// String implements fmt.Stringer.
+func (s State) String() string {
+	switch s {
+	case Healthy:
+		return "healthy"
+	case Behind:
+		return "behind"
+	case Stalled:
+		return "stalled"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// Config contains the settings of the chain head watchdog.
+type Config struct {
+	References []string      // RPC endpoints of the nodes the head is compared against
+	MaxLag     uint64        // Number of blocks the head may trail the network
+	Timeout    time.Duration // Time the head may trail the network without progress
+	Interval   time.Duration // Time between two consecutive head checks
+	Rotate     int           // Number of peers dropped on every recovery attempt
+}
+
+// DefaultConfig contains the default settings of the chain head watchdog.
+var DefaultConfig = Config{
+	MaxLag:   16,
+	Timeout:  10 * time.Minute,
+	Interval: 30 * time.Second,
+	Rotate:   4,
+}
+
+// Backend is the node the watchdog observes and acts upon.
+type Backend interface {
+	// CurrentHeader returns the local chain head.
+	CurrentHeader() *types.Header
+
+	// ProbePeers requests the header with the given number from all connected
+	// peers, and returns the ids of the peers which served it and of the ones
+	// which did not.
+	ProbePeers(number uint64) (ahead []string, behind []string)
+
+	// DropPeers disconnects the given peers to make room for new ones. Trusted
+	// and static peers are kept. It returns the number of dropped peers.
+	DropPeers(ids []string) int
+
+	// RestartSync aborts the running sync cycle, so the next one starts over.
+	RestartSync()
+}
+
+// Status is a snapshot of the watchdog's view of the chain head.
+type Status struct {
+	State        string         `json:"state"`
+	Head         hexutil.Uint64 `json:"head"`
+	Target       hexutil.Uint64 `json:"target"`       // Network head, a lower bound if taken from the peers
+	Source       string         `json:"source"`       // Where the network head was taken from
+	LastProgress time.Time      `json:"lastProgress"` // Time the local head last advanced
+	LastRecovery *time.Time     `json:"lastRecovery"` // Time of the last recovery attempt
+	Recoveries   uint64         `json:"recoveries"`
+}
+
+// Watchdog periodically compares the local chain head against the network and
+// acts on stalls.
+type Watchdog struct {
+	config  Config
+	backend Backend
+	clients []*rpc.Client
+	clock   mclock.Clock
+
+	mu       sync.Mutex
+	state    State
+	head     uint64
+	target   uint64
+	source   string
+	progress mclock.AbsTime // Time the local head last advanced
+	recovery mclock.AbsTime // Time of the last recovery attempt, 0 if none
+	recovers uint64
+
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New creates a watchdog for the given backend. The reference endpoints are
+// dialed right away, but only queried by the started watchdog.
+func New(config Config, backend Backend) (*Watchdog, error) {
+	return newWatchdog(config, backend, mclock.System{})
+}
+
+func newWatchdog(config Config, backend Backend, clock mclock.Clock) (*Watchdog, error) {
+	if config.Interval <= 0 {
+		return nil, fmt.Errorf("invalid watchdog interval %v", config.Interval)
+	}
+	if config.Timeout <= 0 {
+		return nil, fmt.Errorf("invalid watchdog timeout %v", config.Timeout)
+	}
+	w := &Watchdog{
+		config:  config,
+		backend: backend,
+		clock:   clock,
+		closeCh: make(chan struct{}),
+	}
+	for _, url := range config.References {
+		ctx, cancel := context.WithTimeout(context.Background(), referenceTimeout)
+		client, err := rpc.DialContext(ctx, url)
+		cancel()
+		if err != nil {
+			w.closeClients()
+			return nil, fmt.Errorf("failed to dial watchdog reference %s: %v", url, err)
+		}
+		w.clients = append(w.clients, client)
+	}
+	w.head = backend.CurrentHeader().Number.Uint64()
+	w.progress = w.clock.Now()
+	return w, nil
+}
+
+// Start launches the background head checks.
+func (w *Watchdog) Start() {
+	w.mu.Lock()
+	w.progress = w.clock.Now()
+	w.mu.Unlock()
+
+	w.wg.Add(1)
+	go w.loop()
+}
+
+// Stop terminates the background head checks and closes the reference
+// connections.
+func (w *Watchdog) Stop() {
+	close(w.closeCh)
+	w.wg.Wait()
+	w.closeClients()
+}
+
+func (w *Watchdog) closeClients() {
+	for _, client := range w.clients {
+		client.Close()
+	}
+}
+
+// Status returns the current view of the watchdog.
+func (w *Watchdog) Status() *Status {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	status := &Status{
+		State:        w.state.String(),
+		Head:         hexutil.Uint64(w.head),
+		Target:       hexutil.Uint64(w.target),
+		Source:       w.source,
+		LastProgress: w.time(w.progress),
+		Recoveries:   w.recovers,
+	}
+	if w.recovery != 0 {
+		t := w.time(w.recovery)
+		status.LastRecovery = &t
+	}
+	return status
+}
+
+// time converts a monotonic clock reading into wall clock time.
+func (w *Watchdog) time(t mclock.AbsTime) time.Time {
+	return time.Now().Add(-time.Duration(w.clock.Now() - t))
+}
+
+func (w *Watchdog) loop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.check()
+		case <-w.closeCh:
+			return
+		}
+	}
+}
+
+// check compares the local head against the network, updates the state and
+// attempts a recovery if the head is stalled.
+func (w *Watchdog) check() {
+	var (
+		head = w.backend.CurrentHeader().Number.Uint64()
+		now  = w.clock.Now()
+	)
+	target, source, ahead, behind := w.networkHead(head)
+
+	w.mu.Lock()
+	if head > w.head {
+		w.progress = now
+	}
+	w.head, w.target, w.source = head, target, source
+
+	prev := w.state
+	switch {
+	case target <= head+w.config.MaxLag:
+		w.state = Healthy
+	case time.Duration(now-w.progress) < w.config.Timeout:
+		w.state = Behind
+	default:
+		w.state = Stalled
+	}
+	state := w.state
+
+	// Attempt a recovery once the head stalled, and again after every timeout
+	// if the attempts don't help.
+	attempt := state == Stalled && (w.recovery < w.progress || time.Duration(now-w.recovery) >= w.config.Timeout)
+	if attempt {
+		w.recovery = now
+		w.recovers++
+	}
+	w.mu.Unlock()
+
+	var lag uint64
+	if target > head {
+		lag = target - head
+	}
+	stateGauge.Update(int64(state))
+	lagGauge.Update(int64(lag))
+
+	if state != prev {
+		switch state {
+		case Healthy:
+			log.Info("Chain head caught up with the network", "head", head, "target", target, "source", source)
+		case Behind:
+			log.Warn("Chain head is behind the network", "head", head, "target", target, "lag", lag, "source", source)
+		case Stalled:
+			log.Error("Chain head stalled behind the network", "head", head, "target", target, "lag", lag, "source", source,
+				"since", common.PrettyDuration(time.Duration(now-w.progress)))
+		}
+	}
+	if attempt {
+		w.recover(head, target, ahead, behind)
+	}
+}
+
+// networkHead returns the head of the network, taken from the reference nodes
+// if any is reachable, or from the majority of the peers otherwise. For the
+// latter, the ids of the probed peers are returned too. If the network head
+// is unknown, the local head is returned.
+func (w *Watchdog) networkHead(head uint64) (uint64, string, []string, []string) {
+	if number, ok := w.referenceHead(); ok {
+		return number, "reference", nil, nil
+	}
+	// No reference available, probe whether most peers exceed the allowed lag
+	target := head + w.config.MaxLag + 1
+	ahead, behind := w.backend.ProbePeers(target)
+	if len(ahead) > len(behind) {
+		return target, "peers", ahead, behind
+	}
+	return head, "peers", ahead, behind
+}
+
+// referenceHead returns the highest head reported by the reference nodes.
+func (w *Watchdog) referenceHead() (uint64, bool) {
+	var (
+		highest uint64
+		found   bool
+	)
+	for i, client := range w.clients {
+		ctx, cancel := context.WithTimeout(context.Background(), referenceTimeout)
+		var number hexutil.Uint64
+		err := client.CallContext(ctx, &number, "eth_blockNumber")
+		cancel()
+		if err != nil {
+			log.Debug("Failed to query watchdog reference", "url", w.config.References[i], "err", err)
+			continue
+		}
+		highest, found = max(highest, uint64(number)), true
+	}
+	return highest, found
+}
+
+// recover drops some peers to make room for fresh ones, the ones not serving
+// the network head first, and restarts the sync.
+func (w *Watchdog) recover(head uint64, target uint64, ahead []string, behind []string) {
+	stallMeter.Mark(1)
+
+	if ahead == nil && behind == nil {
+		ahead, behind = w.backend.ProbePeers(target)
+	}
+	shuffle := func(ids []string) {
+		mrand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	}
+	shuffle(ahead)
+	shuffle(behind)
+
+	drop := append(behind, ahead...)
+	if len(drop) > w.config.Rotate {
+		drop = drop[:w.config.Rotate]
+	}
+	dropped := w.backend.DropPeers(drop)
+	dropMeter.Mark(int64(dropped))
+
+	w.backend.RestartSync()
+	log.Warn("Attempted chain head recovery", "head", head, "target", target, "dropped", dropped)
+}
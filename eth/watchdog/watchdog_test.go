@@ -0,0 +1,198 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package watchdog
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testBackend is a node with a fixed head and a set of peers at fixed heads.
+type testBackend struct {
+	mu       sync.Mutex
+	head     uint64
+	peers    map[string]uint64
+	dropped  []string
+	restarts int
+}
+
+func (b *testBackend) CurrentHeader() *types.Header {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &types.Header{Number: new(big.Int).SetUint64(b.head)}
+}
+
+func (b *testBackend) ProbePeers(number uint64) (ahead []string, behind []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for id, head := range b.peers {
+		if head >= number {
+			ahead = append(ahead, id)
+		} else {
+			behind = append(behind, id)
+		}
+	}
+	return ahead, behind
+}
+
+func (b *testBackend) DropPeers(ids []string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, id := range ids {
+		delete(b.peers, id)
+		b.dropped = append(b.dropped, id)
+	}
+	return len(ids)
+}
+
+func (b *testBackend) RestartSync() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.restarts++
+}
+
+func (b *testBackend) setHead(head uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.head = head
+}
+
+var testConfig = Config{
+	MaxLag:   10,
+	Timeout:  time.Minute,
+	Interval: time.Second,
+	Rotate:   2,
+}
+
+// Tests that a head trailing the majority of the peers is reported as behind,
+// then as stalled once it stops progressing, and that the recovery drops the
+// lagging peers first and restarts the sync once per timeout.
+func TestWatchdogPeers(t *testing.T) {
+	var (
+		clock   = new(mclock.Simulated)
+		backend = &testBackend{
+			head:  100,
+			peers: map[string]uint64{"a": 200, "b": 200, "c": 200, "d": 50, "e": 105},
+		}
+	)
+	w, err := newWatchdog(testConfig, backend, clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func(state State, recoveries uint64) *Status {
+		t.Helper()
+		w.check()
+		status := w.Status()
+		if status.State != state.String() {
+			t.Fatalf("state mismatch: have %s, want %s", status.State, state)
+		}
+		if status.Recoveries != recoveries {
+			t.Fatalf("recoveries mismatch: have %d, want %d", status.Recoveries, recoveries)
+		}
+		return status
+	}
+	status := check(Behind, 0)
+	if status.Source != "peers" || status.Target != 111 {
+		t.Fatalf("network head mismatch: have %d from %q, want 111 from peers", status.Target, status.Source)
+	}
+	// Progress within the timeout keeps the head behind, but not stalled
+	clock.Run(testConfig.Timeout - time.Second)
+	backend.setHead(101)
+	check(Behind, 0)
+
+	clock.Run(testConfig.Timeout - time.Second)
+	check(Behind, 0)
+
+	// No progress for the timeout stalls the head and triggers a recovery
+	clock.Run(time.Second)
+	check(Stalled, 1)
+	slices.Sort(backend.dropped)
+	if !slices.Equal(backend.dropped, []string{"d", "e"}) {
+		t.Fatalf("dropped peers mismatch: have %v, want [d e]", backend.dropped)
+	}
+	if backend.restarts != 1 {
+		t.Fatalf("sync restarts mismatch: have %d, want 1", backend.restarts)
+	}
+	// The recovery is only retried after another timeout
+	clock.Run(testConfig.Timeout / 2)
+	check(Stalled, 1)
+
+	clock.Run(testConfig.Timeout / 2)
+	check(Stalled, 2)
+	if len(backend.dropped) != 4 || backend.restarts != 2 {
+		t.Fatalf("recovery mismatch: dropped %v, restarts %d", backend.dropped, backend.restarts)
+	}
+	// Catching up with the remaining peer makes the head healthy again
+	backend.setHead(195)
+	check(Healthy, 2)
+}
+
+// blockNumberService serves eth_blockNumber for a reference node.
+type blockNumberService struct {
+	number uint64
+}
+
+func (s *blockNumberService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.number)
+}
+
+// Tests that the network head is taken from the highest reference node, and
+// that unreachable references fall back to the peers.
+func TestWatchdogReferences(t *testing.T) {
+	var urls []string
+	for _, number := range []uint64{150, 120} {
+		server := rpc.NewServer()
+		if err := server.RegisterName("eth", &blockNumberService{number: number}); err != nil {
+			t.Fatal(err)
+		}
+		defer server.Stop()
+
+		http := httptest.NewServer(server)
+		defer http.Close()
+		urls = append(urls, http.URL)
+	}
+	var (
+		backend = &testBackend{head: 100, peers: map[string]uint64{"a": 100}}
+		config  = testConfig
+	)
+	config.References = urls
+	w, err := newWatchdog(config, backend, new(mclock.Simulated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.closeClients()
+
+	w.check()
+	status := w.Status()
+	if status.State != Behind.String() || status.Source != "reference" || status.Target != 150 {
+		t.Fatalf("status mismatch: have %s at %d from %q, want behind at 150 from reference", status.State, status.Target, status.Source)
+	}
+	backend.setHead(140)
+	w.check()
+	if status := w.Status(); status.State != Healthy.String() {
+		t.Fatalf("state mismatch: have %s, want healthy", status.State)
+	}
+}
@@ -16,7 +16,8 @@
 
 // Package remotedb implements the key-value database layer based on a remote geth
 // node. Under the hood, it utilises the `debug_dbGet` method to implement a
-// read-only database.
+// read-only database. The method is only served on the authenticated endpoints
+// and over IPC.
 // There really are no guarantees in this database, since the local geth does not
 // exclusive access, but it can be used for basic diagnostics of a remote node.
 package remotedb
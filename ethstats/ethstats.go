@@ -19,11 +19,14 @@ package ethstats
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
+	"os"
 	"runtime"
 	"strconv"
 	"strings"
@@ -57,8 +60,34 @@ const (
 	chainHeadChanSize = 10
 
 	messageSizeLimit = 15 * 1024 * 1024
+
+	// defaultReportInterval is the interval of full stats reports if unset.
+	defaultReportInterval = 15 * time.Second
 )
 
+// Config contains the settings of the stats reporting service.
+type Config struct {
+	URL string `toml:",omitempty"` // Reporting URL (nodename:secret@host:port)
+
+	// Protocol is the version of the reporting protocol. Version 1 is the legacy
+	// ethstats protocol, version 2 adds token authentication and reports the
+	// metrics payload in addition to the legacy messages.
+	Protocol int `toml:",omitempty"`
+
+	Token          string        `toml:",omitempty"` // Bearer token sent on connect (v2 only)
+	ReportInterval time.Duration `toml:",omitempty"` // Interval of full stats reports
+
+	// TLS settings for connecting to the stats server. The system roots are used
+	// if CACert is empty, ClientCert and ClientKey enable mutual TLS.
+	CACert     string `toml:",omitempty"`
+	ClientCert string `toml:",omitempty"`
+	ClientKey  string `toml:",omitempty"`
+
+	// Metrics is a list of metrics from the default registry to include in the
+	// metrics payload by name (v2 only).
+	Metrics []string `toml:",omitempty"`
+}
+
 // backend encompasses the bare-minimum functionality needed for ethstats reporting
 type backend interface {
 	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
@@ -89,6 +118,12 @@ type Service struct {
 	pass string // Password to authorize access to the monitoring page
 	host string // Remote address of the monitoring service
 
+	protocol int           // Version of the reporting protocol
+	token    string        // Bearer token authenticating the node (v2)
+	interval time.Duration // Interval of full stats reports
+	tls      *tls.Config   // Custom TLS configuration, nil for the defaults
+	metrics  []string      // Names of custom metrics to report (v2)
+
 	pongCh chan struct{} // Pong notifications are fed into this channel
 	histCh chan []uint64 // History request block numbers are fed into this channel
 
@@ -170,21 +205,73 @@ func parseEthstatsURL(url string) (parts []string, err error) {
 	return []string{nodename, pass, host}, nil
 }
 
+// loadTLSConfig assembles the TLS configuration of the stats connection, or nil
+// if no custom certificates are configured.
+func loadTLSConfig(config *Config) (*tls.Config, error) {
+	if config.CACert == "" && config.ClientCert == "" && config.ClientKey == "" {
+		return nil, nil
+	}
+	conf := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config.CACert != "" {
+		pem, err := os.ReadFile(config.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ethstats CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", config.CACert)
+		}
+		conf.RootCAs = pool
+	}
+	if config.ClientCert != "" || config.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ethstats client certificate: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
+
 // New returns a monitoring service ready for stats reporting.
-func New(node *node.Node, backend backend, engine consensus.Engine, url string) error {
-	parts, err := parseEthstatsURL(url)
+func New(node *node.Node, backend backend, engine consensus.Engine, config Config) error {
+	parts, err := parseEthstatsURL(config.URL)
+	if err != nil {
+		return err
+	}
+	protocol := config.Protocol
+	switch protocol {
+	case 0:
+		protocol = 1
+	case 1, 2:
+	default:
+		return fmt.Errorf("unsupported ethstats protocol version %d", protocol)
+	}
+	if protocol < 2 && (config.Token != "" || len(config.Metrics) > 0) {
+		return errors.New("ethstats token and metrics require protocol version 2")
+	}
+	tlsConfig, err := loadTLSConfig(&config)
 	if err != nil {
 		return err
 	}
+	interval := config.ReportInterval
+	if interval <= 0 {
+		interval = defaultReportInterval
+	}
 	ethstats := &Service{
-		backend: backend,
-		engine:  engine,
-		server:  node.Server(),
-		node:    parts[0],
-		pass:    parts[1],
-		host:    parts[2],
-		pongCh:  make(chan struct{}),
-		histCh:  make(chan []uint64, 1),
+		backend:  backend,
+		engine:   engine,
+		server:   node.Server(),
+		node:     parts[0],
+		pass:     parts[1],
+		host:     parts[2],
+		protocol: protocol,
+		token:    config.Token,
+		interval: interval,
+		tls:      tlsConfig,
+		metrics:  config.Metrics,
+		pongCh:   make(chan struct{}),
+		histCh:   make(chan []uint64, 1),
 	}
 
 	node.RegisterLifecycle(ethstats)
@@ -200,7 +287,7 @@ func (s *Service) Start() error {
 	s.txSub = s.backend.SubscribeNewTxsEvent(txEventCh)
 	go s.loop(chainHeadCh, txEventCh)
 
-	log.Info("Stats daemon started")
+	log.Info("Stats daemon started", "protocol", s.protocol, "interval", s.interval)
 	return nil
 }
 
@@ -278,9 +365,12 @@ func (s *Service) loop(chainHeadCh chan core.ChainHeadEvent, txEventCh chan core
 				conn *connWrapper
 				err  error
 			)
-			dialer := websocket.Dialer{HandshakeTimeout: 5 * time.Second}
+			dialer := websocket.Dialer{HandshakeTimeout: 5 * time.Second, TLSClientConfig: s.tls}
 			header := make(http.Header)
 			header.Set("origin", "http://localhost")
+			if s.token != "" {
+				header.Set("Authorization", "Bearer "+s.token)
+			}
 			for _, url := range urls {
 				c, _, e := dialer.Dial(url, header)
 				err = e
@@ -311,7 +401,7 @@ func (s *Service) loop(chainHeadCh chan core.ChainHeadEvent, txEventCh chan core
 				continue
 			}
 			// Keep sending status updates until the connection breaks
-			fullReport := time.NewTicker(15 * time.Second)
+			fullReport := time.NewTicker(s.interval)
 
 			for err == nil {
 				select {
@@ -454,6 +544,9 @@ type nodeInfo struct {
 	OsVer    string `json:"os_v"`
 	Client   string `json:"client"`
 	History  bool   `json:"canUpdateHistory"`
+
+	ProtocolVersion int  `json:"protocolVersion,omitempty"`
+	Metrics         bool `json:"metrics,omitempty"`
 }
 
 // authMsg is the authentication infos needed to login to a monitoring server.
@@ -494,6 +587,11 @@ func (s *Service) login(conn *connWrapper) error {
 		},
 		Secret: s.pass,
 	}
+	if s.protocol >= 2 {
+		auth.Info.Client = "0.2.0"
+		auth.Info.ProtocolVersion = s.protocol
+		auth.Info.Metrics = true
+	}
 	login := map[string][]interface{}{
 		"emit": {"hello", auth},
 	}
@@ -524,6 +622,11 @@ func (s *Service) report(conn *connWrapper) error {
 	if err := s.reportStats(conn); err != nil {
 		return err
 	}
+	if s.protocol >= 2 {
+		if err := s.reportMetrics(conn); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -17,8 +17,16 @@
 package ethstats
 
 import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
 	"strconv"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 func TestParseEthstatsURL(t *testing.T) {
@@ -80,3 +88,50 @@ func TestParseEthstatsURL(t *testing.T) {
 		}
 	}
 }
+
+type testBackend struct {
+	backend // unused methods panic
+
+	head, safe, finalized *types.Header
+}
+
+func (b *testBackend) CurrentHeader() *types.Header { return b.head }
+func (b *testBackend) Stats() (int, int)            { return 3, 1 }
+
+func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	switch number {
+	case rpc.SafeBlockNumber:
+		return b.safe, nil
+	case rpc.FinalizedBlockNumber:
+		return b.finalized, nil
+	}
+	return nil, errors.New("unexpected block number")
+}
+
+func TestAssembleMetrics(t *testing.T) {
+	gauge := metrics.NewRegisteredGauge("ethstats/test/gauge", nil)
+	defer metrics.Unregister("ethstats/test/gauge")
+	gauge.Update(42)
+
+	s := &Service{
+		backend: &testBackend{
+			head: &types.Header{Number: big.NewInt(100)},
+			safe: &types.Header{Number: big.NewInt(90)},
+		},
+		metrics: []string{"ethstats/test/gauge", "ethstats/test/missing"},
+	}
+	stats := s.assembleMetrics()
+	if stats.TxPoolPending != 3 || stats.TxPoolQueued != 1 {
+		t.Errorf("txpool depth mismatch: have %d/%d, want 3/1", stats.TxPoolPending, stats.TxPoolQueued)
+	}
+	if stats.SafeLag == nil || *stats.SafeLag != 10 {
+		t.Errorf("safe lag mismatch: have %v, want 10", stats.SafeLag)
+	}
+	if stats.FinalizedLag != nil {
+		t.Errorf("unexpected finalized lag %d", *stats.FinalizedLag)
+	}
+	want := map[string]interface{}{"ethstats/test/gauge": int64(42)}
+	if !reflect.DeepEqual(stats.Custom, want) {
+		t.Errorf("custom metrics mismatch: have %v, want %v", stats.Custom, want)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethstats
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// payloadBuildTimeMetric is the name of the miner timer tracking the duration
+// of payload building passes.
+const payloadBuildTimeMetric = "miner/payload/buildtime"
+
+// nodeMetrics is the custom metrics payload reported by the v2 protocol.
+type nodeMetrics struct {
+	PayloadBuildTime *float64               `json:"payloadBuildTime,omitempty"` // Mean build time in milliseconds
+	TxPoolPending    int                    `json:"txpoolPending"`
+	TxPoolQueued     int                    `json:"txpoolQueued"`
+	SafeLag          *uint64                `json:"safeLag,omitempty"`      // Blocks between head and safe
+	FinalizedLag     *uint64                `json:"finalizedLag,omitempty"` // Blocks between head and finalized
+	Custom           map[string]interface{} `json:"custom,omitempty"`
+}
+
+// reportMetrics gathers the custom metrics payload and reports it to the stats
+// server.
+func (s *Service) reportMetrics(conn *connWrapper) error {
+	stats := s.assembleMetrics()
+
+	log.Trace("Sending metrics to ethstats")
+	report := map[string][]interface{}{
+		"emit": {"metrics", map[string]interface{}{
+			"id":      s.node,
+			"metrics": stats,
+		}},
+	}
+	return conn.WriteJSON(report)
+}
+
+// assembleMetrics collects the custom metrics payload of the node.
+func (s *Service) assembleMetrics() *nodeMetrics {
+	stats := new(nodeMetrics)
+	stats.TxPoolPending, stats.TxPoolQueued = s.backend.Stats()
+
+	if timer, ok := metrics.DefaultRegistry.Get(payloadBuildTimeMetric).(*metrics.Timer); ok {
+		if snap := timer.Snapshot(); snap.Count() > 0 {
+			mean := snap.Mean() / float64(time.Millisecond)
+			stats.PayloadBuildTime = &mean
+		}
+	}
+	if head := s.backend.CurrentHeader(); head != nil {
+		lag := func(number rpc.BlockNumber) *uint64 {
+			header, _ := s.backend.HeaderByNumber(context.Background(), number)
+			if header == nil || header.Number.Cmp(head.Number) > 0 {
+				return nil
+			}
+			lag := head.Number.Uint64() - header.Number.Uint64()
+			return &lag
+		}
+		stats.SafeLag = lag(rpc.SafeBlockNumber)
+		stats.FinalizedLag = lag(rpc.FinalizedBlockNumber)
+	}
+	for _, name := range s.metrics {
+		if value, ok := metricValue(metrics.DefaultRegistry.Get(name)); ok {
+			if stats.Custom == nil {
+				stats.Custom = make(map[string]interface{})
+			}
+			stats.Custom[name] = value
+		}
+	}
+	return stats
+}
+
+// metricValue reduces a registered metric to a single reportable value: the
+// count of counters, the value of gauges, the one-minute rate of meters and the
+// mean of histograms and timers. Resetting timers are not supported as reading
+// them would clear the data of other reporters.
+func metricValue(metric interface{}) (interface{}, bool) {
+	switch m := metric.(type) {
+	case *metrics.Counter:
+		return m.Snapshot().Count(), true
+	case *metrics.CounterFloat64:
+		return m.Snapshot().Count(), true
+	case *metrics.Gauge:
+		return m.Snapshot().Value(), true
+	case *metrics.GaugeFloat64:
+		return m.Snapshot().Value(), true
+	case *metrics.Meter:
+		return m.Snapshot().Rate1(), true
+	case *metrics.Timer:
+		return m.Snapshot().Mean(), true
+	case metrics.Histogram:
+		return m.Snapshot().Mean(), true
+	}
+	return nil, false
+}
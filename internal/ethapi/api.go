@@ -19,8 +19,10 @@ package ethapi
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	gomath "math"
 	"math/big"
 	"strings"
@@ -35,6 +37,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -66,9 +69,10 @@ func NewEthereumAPI(b Backend) *EthereumAPI {
 	return &EthereumAPI{b}
 }
 
-// GasPrice returns a suggestion for a gas price for legacy transactions.
-func (api *EthereumAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
-	tipcap, err := api.b.SuggestGasTipCap(ctx)
+// GasPrice returns a suggestion for a gas price for legacy transactions, with a
+// high chance of inclusion within the target number of blocks if given.
+func (api *EthereumAPI) GasPrice(ctx context.Context, target *hexutil.Uint64) (*hexutil.Big, error) {
+	tipcap, err := api.suggestTipCap(ctx, target)
 	if err != nil {
 		return nil, err
 	}
@@ -78,15 +82,26 @@ func (api *EthereumAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
 	return (*hexutil.Big)(tipcap), err
 }
 
-// MaxPriorityFeePerGas returns a suggestion for a gas tip cap for dynamic fee transactions.
-func (api *EthereumAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
-	tipcap, err := api.b.SuggestGasTipCap(ctx)
+// MaxPriorityFeePerGas returns a suggestion for a gas tip cap for dynamic fee
+// transactions, with a high chance of inclusion within the target number of
+// blocks if given.
+func (api *EthereumAPI) MaxPriorityFeePerGas(ctx context.Context, target *hexutil.Uint64) (*hexutil.Big, error) {
+	tipcap, err := api.suggestTipCap(ctx, target)
 	if err != nil {
 		return nil, err
 	}
 	return (*hexutil.Big)(tipcap), err
 }
 
+// suggestTipCap returns the tip cap suggestion for an inclusion target in blocks,
+// or the default suggestion if none.
+func (api *EthereumAPI) suggestTipCap(ctx context.Context, target *hexutil.Uint64) (*big.Int, error) {
+	if target == nil {
+		return api.b.SuggestGasTipCap(ctx)
+	}
+	return api.b.SuggestGasTipCapFor(ctx, uint64(*target))
+}
+
 type feeHistoryResult struct {
 	OldestBlock      *hexutil.Big     `json:"oldestBlock"`
 	Reward           [][]*hexutil.Big `json:"reward,omitempty"`
@@ -473,6 +488,120 @@ func (api *BlockChainAPI) GetProof(ctx context.Context, address common.Address,
 	}, statedb.Error()
 }
 
+// maxAccountQueryAddresses is the maximum number of accounts queried by a
+// single eth_getAccountMany call.
+const maxAccountQueryAddresses = 1024
+
+// AccountInfo is the result of eth_getAccount. The code hash and storage root
+// of a missing account are those of an empty account.
+type AccountInfo struct {
+	Address     common.Address `json:"address"`
+	Balance     *hexutil.Big   `json:"balance"`
+	Nonce       hexutil.Uint64 `json:"nonce"`
+	CodeHash    common.Hash    `json:"codeHash"`
+	StorageRoot common.Hash    `json:"storageRoot"`
+	Exists      bool           `json:"exists"` // Whether the account is present in the state
+	Empty       bool           `json:"empty"`  // Whether the account has no nonce, balance nor code (EIP-161)
+}
+
+// GetAccount returns the balance, nonce, code hash and storage root of the
+// given account at the given block in a single call.
+func (api *BlockChainAPI) GetAccount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*AccountInfo, error) {
+	res, err := api.getAccounts(ctx, []common.Address{address}, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return res[0], nil
+}
+
+// GetAccountMany returns the accounts of the given addresses at the given
+// block, with the same semantics as eth_getAccount.
+func (api *BlockChainAPI) GetAccountMany(ctx context.Context, addresses []common.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]*AccountInfo, error) {
+	if len(addresses) > maxAccountQueryAddresses {
+		return nil, fmt.Errorf("too many addresses, %d > %d", len(addresses), maxAccountQueryAddresses)
+	}
+	return api.getAccounts(ctx, addresses, blockNrOrHash)
+}
+
+func (api *BlockChainAPI) getAccounts(ctx context.Context, addresses []common.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]*AccountInfo, error) {
+	header, err := headerByNumberOrHash(ctx, api.b, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if api.b.ChainConfig().IsOptimismPreBedrock(header.Number) {
+		return api.getHistoricalAccounts(ctx, addresses, blockNrOrHash)
+	}
+	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	res := make([]*AccountInfo, len(addresses))
+	for i, address := range addresses {
+		info := &AccountInfo{
+			Address:     address,
+			Balance:     (*hexutil.Big)(state.GetBalance(address).ToBig()),
+			Nonce:       hexutil.Uint64(state.GetNonce(address)),
+			CodeHash:    types.EmptyCodeHash,
+			StorageRoot: types.EmptyRootHash,
+			Exists:      state.Exist(address),
+			Empty:       state.Empty(address),
+		}
+		if info.Exists {
+			info.CodeHash = state.GetCodeHash(address)
+			info.StorageRoot = state.GetStorageRoot(address)
+		}
+		res[i] = info
+	}
+	return res, state.Error()
+}
+
+// getHistoricalAccounts resolves the accounts of a pre-bedrock block from the
+// proofs of the historical backend, which doesn't serve eth_getAccount. The
+// proofs can't tell an empty account from a missing one, so empty accounts
+// without storage are reported as missing.
+func (api *BlockChainAPI) getHistoricalAccounts(ctx context.Context, addresses []common.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]*AccountInfo, error) {
+	if api.b.HistoricalRPCService() == nil {
+		return nil, rpc.ErrNoHistoricalFallback
+	}
+	var (
+		proofs = make([]AccountResult, len(addresses))
+		batch  = make([]rpc.BatchElem, len(addresses))
+	)
+	for i, address := range addresses {
+		batch[i] = rpc.BatchElem{Method: "eth_getProof", Args: []any{address, []string{}, blockNrOrHash}, Result: &proofs[i]}
+	}
+	if err := api.b.HistoricalRPCService().BatchCallContext(ctx, batch); err != nil {
+		return nil, fmt.Errorf("historical backend error: %w", err)
+	}
+	res := make([]*AccountInfo, len(addresses))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("historical backend error: %w", elem.Error)
+		}
+		proof := proofs[i]
+		info := &AccountInfo{
+			Address:     addresses[i],
+			Balance:     proof.Balance,
+			Nonce:       proof.Nonce,
+			CodeHash:    proof.CodeHash,
+			StorageRoot: proof.StorageHash,
+		}
+		if info.Balance == nil {
+			info.Balance = new(hexutil.Big)
+		}
+		if info.CodeHash == (common.Hash{}) {
+			info.CodeHash = types.EmptyCodeHash
+		}
+		if info.StorageRoot == (common.Hash{}) {
+			info.StorageRoot = types.EmptyRootHash
+		}
+		info.Empty = info.Nonce == 0 && info.Balance.ToInt().Sign() == 0 && info.CodeHash == types.EmptyCodeHash
+		info.Exists = !info.Empty || info.StorageRoot != types.EmptyRootHash
+		res[i] = info
+	}
+	return res, nil
+}
+
 // decodeHash parses a hex-encoded 32-byte hash. The input may optionally
 // be prefixed by 0x and can have a byte length up to 32.
 func decodeHash(s string) (h common.Hash, inputLength int, err error) {
@@ -667,6 +796,135 @@ func (api *BlockChainAPI) GetStorageAt(ctx context.Context, address common.Addre
 	return res[:], state.Error()
 }
 
+// maxStorageQuerySlots is the maximum number of storage slots queried by a
+// single eth_getStorageAtMany or eth_getStorageRange call.
+const maxStorageQuerySlots = 1024
+
+// GetStorageAtMany returns the values of the given storage slots of the
+// account at the given block, with the same semantics as eth_getStorageAt.
+func (api *BlockChainAPI) GetStorageAtMany(ctx context.Context, address common.Address, hexKeys []string, blockNrOrHash rpc.BlockNumberOrHash) ([]hexutil.Bytes, error) {
+	if len(hexKeys) > maxStorageQuerySlots {
+		return nil, fmt.Errorf("too many storage keys, %d > %d", len(hexKeys), maxStorageQuerySlots)
+	}
+	header, err := headerByNumberOrHash(ctx, api.b, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if api.b.ChainConfig().IsOptimismPreBedrock(header.Number) {
+		if api.b.HistoricalRPCService() == nil {
+			return nil, rpc.ErrNoHistoricalFallback
+		}
+		var (
+			res   = make([]hexutil.Bytes, len(hexKeys))
+			batch = make([]rpc.BatchElem, len(hexKeys))
+		)
+		for i, hexKey := range hexKeys {
+			batch[i] = rpc.BatchElem{Method: "eth_getStorageAt", Args: []any{address, hexKey, blockNrOrHash}, Result: &res[i]}
+		}
+		if err := api.b.HistoricalRPCService().BatchCallContext(ctx, batch); err != nil {
+			return nil, fmt.Errorf("historical backend error: %w", err)
+		}
+		for _, elem := range batch {
+			if elem.Error != nil {
+				return nil, fmt.Errorf("historical backend error: %w", elem.Error)
+			}
+		}
+		return res, nil
+	}
+	// Deserialize all keys. This prevents state access on invalid input.
+	keys := make([]common.Hash, len(hexKeys))
+	for i, hexKey := range hexKeys {
+		keys[i], _, err = decodeHash(hexKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode storage key: %s", err)
+		}
+	}
+	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	res := make([]hexutil.Bytes, len(keys))
+	for i, key := range keys {
+		value := state.GetState(address, key)
+		res[i] = value[:]
+	}
+	return res, state.Error()
+}
+
+// StorageSlot is a storage slot returned by eth_getStorageRange.
+type StorageSlot struct {
+	HashedKey common.Hash  `json:"hashedKey"`
+	Key       *common.Hash `json:"key"` // Preimage of the hashed key, nil if unknown
+	Value     common.Hash  `json:"value"`
+}
+
+// StorageRange is a range of storage slots returned by eth_getStorageRange.
+type StorageRange struct {
+	Slots   []StorageSlot `json:"slots"`
+	NextKey *common.Hash  `json:"nextKey"` // Hashed key to continue from, nil if the range includes the last slot
+}
+
+// GetStorageRange returns at most limit storage slots of the account at the
+// given block, in the order of their hashed keys, starting at the given hashed
+// key (inclusive). The slots are read from the storage trie of the block, so
+// they are available for the same blocks as the state queried by
+// eth_getStorageAt, regardless of the state scheme.
+func (api *BlockChainAPI) GetStorageRange(ctx context.Context, address common.Address, startKey common.Hash, limit hexutil.Uint64, blockNrOrHash rpc.BlockNumberOrHash) (*StorageRange, error) {
+	if limit == 0 || limit > maxStorageQuerySlots {
+		return nil, fmt.Errorf("invalid storage range limit %d, must be in [1, %d]", limit, maxStorageQuerySlots)
+	}
+	header, err := headerByNumberOrHash(ctx, api.b, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if api.b.ChainConfig().IsOptimismPreBedrock(header.Number) {
+		return nil, rpc.ErrNoHistoricalFallback
+	}
+	statedb, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	result := &StorageRange{Slots: []StorageSlot{}}
+
+	storageRoot := statedb.GetStorageRoot(address)
+	if storageRoot == types.EmptyRootHash || storageRoot == (common.Hash{}) {
+		return result, nil
+	}
+	id := trie.StorageTrieID(header.Root, crypto.Keccak256Hash(address.Bytes()), storageRoot)
+	tr, err := trie.NewStateTrie(id, statedb.Database().TrieDB())
+	if err != nil {
+		return nil, err
+	}
+	nodeIt, err := tr.NodeIterator(startKey.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	it := trie.NewIterator(nodeIt)
+	for len(result.Slots) < int(limit) && it.Next() {
+		_, content, _, err := rlp.Split(it.Value)
+		if err != nil {
+			return nil, err
+		}
+		slot := StorageSlot{
+			HashedKey: common.BytesToHash(it.Key),
+			Value:     common.BytesToHash(content),
+		}
+		if preimage := tr.GetKey(it.Key); preimage != nil {
+			key := common.BytesToHash(preimage)
+			slot.Key = &key
+		}
+		result.Slots = append(result.Slots, slot)
+	}
+	if it.Next() {
+		next := common.BytesToHash(it.Key)
+		result.NextKey = &next
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+	return result, nil
+}
+
 // The HeaderByNumberOrHash method returns a nil error and nil header
 // if the header is not found, but only for nonexistent block numbers. This is
 // different from StateAndHeaderByNumberOrHash. To account for this discrepancy,
@@ -685,6 +943,11 @@ func (api *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rp
 	if block == nil || err != nil {
 		return nil, err
 	}
+	return api.blockReceipts(ctx, block)
+}
+
+// blockReceipts returns the marshalled receipts of all transactions of a block.
+func (api *BlockChainAPI) blockReceipts(ctx context.Context, block *types.Block) ([]map[string]interface{}, error) {
 	receipts, err := api.b.GetReceipts(ctx, block.Hash())
 	if err != nil {
 		return nil, err
@@ -705,6 +968,79 @@ func (api *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rp
 	return result, nil
 }
 
+// maxReceiptsRange is the maximum number of blocks whose receipts are returned
+// by a single eth_getBlockReceiptsRange call.
+const maxReceiptsRange = 10000
+
+// GetBlockReceiptsRange returns the receipts of the blocks in the given inclusive
+// range, as one list per block in the format of eth_getBlockReceipts. The blocks
+// are read one at a time and streamed to the client as they are, so that the
+// response is not held in memory.
+func (api *BlockChainAPI) GetBlockReceiptsRange(ctx context.Context, from, to rpc.BlockNumber) (rpc.StreamWriter, error) {
+	first, err := api.b.HeaderByNumber(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	last, err := api.b.HeaderByNumber(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	if first == nil || last == nil {
+		return nil, errors.New("block not found")
+	}
+	start, end := first.Number.Uint64(), last.Number.Uint64()
+	if start > end {
+		return nil, fmt.Errorf("invalid block range, from %d > to %d", start, end)
+	}
+	if end-start >= maxReceiptsRange {
+		return nil, fmt.Errorf("block range too large, %d > %d", end-start+1, maxReceiptsRange)
+	}
+	return &receiptsRange{api: api, start: start, end: end}, nil
+}
+
+// receiptsRange is the streamed result of eth_getBlockReceiptsRange.
+type receiptsRange struct {
+	api        *BlockChainAPI
+	start, end uint64
+}
+
+// WriteStream implements rpc.StreamWriter.
+func (r *receiptsRange) WriteStream(ctx context.Context, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for number := r.start; number <= r.end; number++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		block, err := r.api.b.BlockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return fmt.Errorf("block #%d not found", number)
+		}
+		receipts, err := r.api.blockReceipts(ctx, block)
+		if err != nil {
+			return err
+		}
+		blob, err := json.Marshal(receipts)
+		if err != nil {
+			return err
+		}
+		if number > r.start {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(blob); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
 // ChainContextBackend provides methods required to implement ChainContext.
 type ChainContextBackend interface {
 	Engine() consensus.Engine
@@ -830,7 +1166,14 @@ func DoCall(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash
 	if state == nil || err != nil {
 		return nil, err
 	}
-	return doCall(ctx, b, args, state, header, overrides, blockOverrides, timeout, globalGasCap)
+	prefetcher := b.CallPrefetcher()
+	prefetcher.Prefetch(ctx, state.Database(), header.Root, &args)
+
+	res, err := doCall(ctx, b, args, state, header, overrides, blockOverrides, timeout, globalGasCap)
+	if err == nil && overrides == nil {
+		prefetcher.Learn(&args, state)
+	}
+	return res, err
 }
 
 // Call executes the given transaction on the state for the given block number.
@@ -886,6 +1229,10 @@ func (api *BlockChainAPI) SimulateV1(ctx context.Context, opts simOpts, blockNrO
 	} else if len(opts.BlockStateCalls) > maxSimulateBlocks {
 		return nil, &clientLimitExceededError{message: "too many blocks"}
 	}
+	limits, err := opts.ResourceLimits.limits()
+	if err != nil {
+		return nil, err
+	}
 	if blockNrOrHash == nil {
 		n := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
 		blockNrOrHash = &n
@@ -908,6 +1255,7 @@ func (api *BlockChainAPI) SimulateV1(ctx context.Context, opts simOpts, blockNrO
 		traceTransfers: opts.TraceTransfers,
 		validate:       opts.Validation,
 		fullTx:         opts.ReturnFullTransactions,
+		limits:         limits,
 	}
 	return sim.execute(ctx, opts.BlockStateCalls)
 }
@@ -922,6 +1270,8 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 	if state == nil || err != nil {
 		return 0, err
 	}
+	b.CallPrefetcher().Prefetch(ctx, state.Database(), header.Root, &args)
+
 	if err := overrides.Apply(state, nil); err != nil {
 		return 0, err
 	}
@@ -1096,6 +1446,30 @@ type RPCTransaction struct {
 	IsSystemTx *bool        `json:"isSystemTx,omitempty"`
 	// deposit-tx post-Canyon only
 	DepositReceiptVersion *hexutil.Uint64 `json:"depositReceiptVersion,omitempty"`
+
+	// pool-tx only, set if the transaction failed the pre-check on admission
+	MayRevert bool `json:"mayRevert,omitempty"`
+
+	// Fields of the other system transaction types
+	systemFields map[string]interface{}
+}
+
+// MarshalJSON marshals the transaction, along with the fields specific to its
+// system transaction type if any.
+func (tx RPCTransaction) MarshalJSON() ([]byte, error) {
+	type rpcTransaction RPCTransaction
+	enc, err := json.Marshal(rpcTransaction(tx))
+	if err != nil || len(tx.systemFields) == 0 {
+		return enc, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(enc, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range tx.systemFields {
+		fields[name] = value
+	}
+	return json.Marshal(fields)
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1207,6 +1581,13 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		}
 		result.AuthorizationList = tx.SetCodeAuthorizations()
 	}
+	if st := tx.SystemTxType(); st != nil {
+		fields := make(map[string]interface{})
+		st.RPCTxFields(tx, receipt, fields)
+		if len(fields) > 0 {
+			result.systemFields = fields
+		}
+	}
 	return result
 }
 
@@ -1234,7 +1615,9 @@ func NewRPCPendingTransaction(tx *types.Transaction, current *types.Header, conf
 		blockNumber = current.Number.Uint64()
 		blockTime = current.Time
 	}
-	return newRPCTransaction(tx, common.Hash{}, blockNumber, blockTime, 0, baseFee, config, nil)
+	result := newRPCTransaction(tx, common.Hash{}, blockNumber, blockTime, 0, baseFee, config, nil)
+	result.MayRevert = tx.MayRevert()
+	return result
 }
 
 // newRPCTransactionFromBlockIndex returns a transaction that will serialize to the RPC representation.
@@ -1244,7 +1627,7 @@ func newRPCTransactionFromBlockIndex(ctx context.Context, b *types.Block, index
 		return nil
 	}
 	tx := txs[index]
-	rcpt := depositTxReceipt(ctx, b.Hash(), index, backend, tx)
+	rcpt := systemTxReceipt(ctx, b.Hash(), index, backend, tx)
 	return newRPCTransaction(tx, b.Hash(), b.NumberU64(), b.Time(), index, b.BaseFee(), config, rcpt)
 }
 
@@ -1252,8 +1635,8 @@ type ReceiptGetter interface {
 	GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error)
 }
 
-func depositTxReceipt(ctx context.Context, blockHash common.Hash, index uint64, backend ReceiptGetter, tx *types.Transaction) *types.Receipt {
-	if tx.Type() != types.DepositTxType {
+func systemTxReceipt(ctx context.Context, blockHash common.Hash, index uint64, backend ReceiptGetter, tx *types.Transaction) *types.Receipt {
+	if tx.SystemTxType() == nil {
 		return nil
 	}
 	receipts, err := backend.GetReceipts(ctx, blockHash)
@@ -1283,10 +1666,22 @@ type accessListResult struct {
 	Accesslist *types.AccessList `json:"accessList"`
 	Error      string            `json:"error,omitempty"`
 	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+
+	// Gas savings estimate of the access list: the gas used by the transaction
+	// without any access list, the intrinsic gas charged for the access list and
+	// the net gas saved by warming up the listed accounts and slots in advance.
+	GasUsedWithoutAccessList hexutil.Uint64 `json:"gasUsedWithoutAccessList"`
+	AccessListGas            hexutil.Uint64 `json:"accessListGas"`
+	GasSaved                 *hexutil.Big   `json:"gasSaved"`
 }
 
+// maxAccessListBatch is the maximum number of transactions accepted by
+// eth_createAccessLists.
+const maxAccessListBatch = 100
+
 // CreateAccessList creates an EIP-2930 type AccessList for the given transaction.
-// Reexec and BlockNrOrHash can be specified to create the accessList on top of a certain state.
+// Reexec and BlockNrOrHash can be specified to create the accessList on top of a certain state,
+// the "pending" tag selects the pending block built by the miner.
 // StateOverrides can be used to create the accessList while taking into account state changes from previous transactions.
 func (api *BlockChainAPI) CreateAccessList(ctx context.Context, args TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, stateOverrides *override.StateOverride) (*accessListResult, error) {
 	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
@@ -1307,16 +1702,73 @@ func (api *BlockChainAPI) CreateAccessList(ctx context.Context, args Transaction
 			return nil, rpc.ErrNoHistoricalFallback
 		}
 	}
-
-	acl, gasUsed, vmerr, err := AccessList(ctx, api.b, bNrOrHash, args, stateOverrides)
+	results, err := api.createAccessLists(ctx, []TransactionArgs{args}, bNrOrHash, stateOverrides)
 	if err != nil {
 		return nil, err
 	}
-	result := &accessListResult{Accesslist: &acl, GasUsed: hexutil.Uint64(gasUsed)}
-	if vmerr != nil {
-		result.Error = vmerr.Error()
+	return results[0], nil
+}
+
+// CreateAccessLists creates the EIP-2930 type AccessLists of a batch of
+// transactions, executed in order on top of the given state: the access list
+// of a transaction is created on the state left behind by the previous ones.
+func (api *BlockChainAPI) CreateAccessLists(ctx context.Context, args []TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, stateOverrides *override.StateOverride) ([]*accessListResult, error) {
+	if len(args) == 0 {
+		return nil, errors.New("empty transaction batch")
 	}
-	return result, nil
+	if len(args) > maxAccessListBatch {
+		return nil, fmt.Errorf("too many transactions: %d > %d", len(args), maxAccessListBatch)
+	}
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	header, err := headerByNumberOrHash(ctx, api.b, bNrOrHash)
+	if err == nil && header != nil && api.b.ChainConfig().IsOptimismPreBedrock(header.Number) {
+		return nil, errors.New("batch access list creation is not supported for pre-bedrock blocks")
+	}
+	return api.createAccessLists(ctx, args, bNrOrHash, stateOverrides)
+}
+
+// createAccessLists creates the access lists of the given transactions, along
+// with their gas savings estimates, executing them one after the other.
+func (api *BlockChainAPI) createAccessLists(ctx context.Context, args []TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, stateOverrides *override.StateOverride) ([]*accessListResult, error) {
+	db, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if db == nil || err != nil {
+		return nil, err
+	}
+	if stateOverrides != nil {
+		if err := stateOverrides.Apply(db, nil); err != nil {
+			return nil, err
+		}
+	}
+	results := make([]*accessListResult, 0, len(args))
+	for i := range args {
+		res, err := createAccessList(ctx, api.b, db, header, args[i])
+		if err != nil {
+			return nil, err
+		}
+		listGas := uint64(len(res.accessList)) * params.TxAccessListAddressGas
+		listGas += uint64(res.accessList.StorageKeys()) * params.TxAccessListStorageKeyGas
+
+		result := &accessListResult{
+			Accesslist:               &res.accessList,
+			GasUsed:                  hexutil.Uint64(res.gasUsed),
+			GasUsedWithoutAccessList: hexutil.Uint64(res.baseGasUsed),
+			AccessListGas:            hexutil.Uint64(listGas),
+			GasSaved:                 (*hexutil.Big)(new(big.Int).Sub(new(big.Int).SetUint64(res.baseGasUsed), new(big.Int).SetUint64(res.gasUsed))),
+		}
+		if res.vmErr != nil {
+			result.Error = res.vmErr.Error()
+		}
+		results = append(results, result)
+
+		// Finalise the transaction, so the next one sees its writes as committed
+		// like it would in a block.
+		db = res.state
+		db.Finalise(true)
+	}
+	return results, nil
 }
 
 // AccessList creates an access list for the given transaction.
@@ -1337,18 +1789,36 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 			return nil, 0, nil, err
 		}
 	}
+	res, err := createAccessList(ctx, b, db, header, args)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return res.accessList, res.gasUsed, res.vmErr, nil
+}
+
+// accessListOutcome is the result of creating the access list of a transaction.
+type accessListOutcome struct {
+	accessList  types.AccessList
+	gasUsed     uint64         // Gas used by the transaction with the access list
+	baseGasUsed uint64         // Gas used by the transaction without any access list
+	vmErr       error          // Execution error of the transaction
+	state       *state.StateDB // State after executing the transaction
+}
 
+// createAccessList creates the access list of the given transaction on top of
+// the given state, which is not modified.
+func createAccessList(ctx context.Context, b Backend, db *state.StateDB, header *types.Header, args TransactionArgs) (*accessListOutcome, error) {
 	// Ensure any missing fields are filled, extract the recipient and input data
-	if err = args.setFeeDefaults(ctx, b, header); err != nil {
-		return nil, 0, nil, err
+	if err := args.setFeeDefaults(ctx, b, header); err != nil {
+		return nil, err
 	}
 	if args.Nonce == nil {
 		nonce := hexutil.Uint64(db.GetNonce(args.from()))
 		args.Nonce = &nonce
 	}
 	blockCtx := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil, b.ChainConfig(), db)
-	if err = args.CallDefaults(b.RPCGasCap(), blockCtx.BaseFee, b.ChainConfig().ChainID); err != nil {
-		return nil, 0, nil, err
+	if err := args.CallDefaults(b.RPCGasCap(), blockCtx.BaseFee, b.ChainConfig().ChainID); err != nil {
+		return nil, err
 	}
 
 	var to common.Address
@@ -1370,7 +1840,7 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 	// Prevent redundant operations if args contain more authorizations than EVM may handle
 	maxAuthorizations := uint64(*args.Gas) / params.CallNewAccountGas
 	if uint64(len(args.AuthorizationList)) > maxAuthorizations {
-		return nil, 0, nil, errors.New("insufficient gas to process all authorizations")
+		return nil, errors.New("insufficient gas to process all authorizations")
 	}
 
 	for _, auth := range args.AuthorizationList {
@@ -1384,28 +1854,18 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 		}
 	}
 
-	// Create an initial tracer
-	prevTracer := logger.NewAccessListTracer(nil, addressesToExclude)
-	if args.AccessList != nil {
-		prevTracer = logger.NewAccessListTracer(*args.AccessList, addressesToExclude)
-	}
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, 0, nil, err
-		}
-		// Retrieve the current access list to expand
-		accessList := prevTracer.AccessList()
-		log.Trace("Creating access list", "input", accessList)
-
-		// Copy the original db so we don't modify it
+	// apply executes the transaction with the given access list on a copy of
+	// the original db, so it's not modified.
+	apply := func(accessList types.AccessList, tracer *logger.AccessListTracer) (*core.ExecutionResult, *state.StateDB, error) {
 		statedb := db.Copy()
 		// Set the accesslist to the last al
 		args.AccessList = &accessList
 		msg := args.ToMessage(header.BaseFee, true, true)
 
-		// Apply the transaction with the access list tracer
-		tracer := logger.NewAccessListTracer(accessList, addressesToExclude)
-		config := vm.Config{Tracer: tracer.Hooks(), NoBaseFee: true}
+		config := vm.Config{NoBaseFee: true}
+		if tracer != nil {
+			config.Tracer = tracer.Hooks()
+		}
 		evm := b.GetEVM(ctx, statedb, header, &config, nil)
 
 		// Lower the basefee to 0 to avoid breaking EVM
@@ -1418,10 +1878,51 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 		}
 		res, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.GasLimit))
 		if err != nil {
-			return nil, 0, nil, fmt.Errorf("failed to apply transaction: %v err: %v", args.ToTransaction(types.LegacyTxType).Hash(), err)
+			return nil, nil, fmt.Errorf("failed to apply transaction: %v err: %v", args.ToTransaction(types.LegacyTxType).Hash(), err)
+		}
+		return res, statedb, nil
+	}
+	// Create an initial tracer
+	var (
+		prevTracer  = logger.NewAccessListTracer(nil, addressesToExclude)
+		baseGasUsed *uint64
+	)
+	if args.AccessList != nil {
+		prevTracer = logger.NewAccessListTracer(*args.AccessList, addressesToExclude)
+	}
+	if len(prevTracer.AccessList()) != 0 {
+		// Measure the gas used without any access list for the savings estimate
+		res, _, err := apply(types.AccessList{}, nil)
+		if err != nil {
+			return nil, err
+		}
+		baseGasUsed = &res.UsedGas
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// Retrieve the current access list to expand
+		accessList := prevTracer.AccessList()
+		log.Trace("Creating access list", "input", accessList)
+
+		// Apply the transaction with the access list tracer
+		tracer := logger.NewAccessListTracer(accessList, addressesToExclude)
+		res, statedb, err := apply(accessList, tracer)
+		if err != nil {
+			return nil, err
+		}
+		if baseGasUsed == nil {
+			baseGasUsed = &res.UsedGas
 		}
 		if tracer.Equal(prevTracer) {
-			return accessList, res.UsedGas, res.Err, nil
+			return &accessListOutcome{
+				accessList:  accessList,
+				gasUsed:     res.UsedGas,
+				baseGasUsed: *baseGasUsed,
+				vmErr:       res.Err,
+				state:       statedb,
+			}, nil
 		}
 		prevTracer = tracer
 	}
@@ -1554,7 +2055,7 @@ func (api *TransactionAPI) GetTransactionByHash(ctx context.Context, hash common
 	if err != nil {
 		return nil, err
 	}
-	rcpt := depositTxReceipt(ctx, blockHash, index, api.b, tx)
+	rcpt := systemTxReceipt(ctx, blockHash, index, api.b, tx)
 	return newRPCTransaction(tx, blockHash, blockNumber, header.Time, index, header.BaseFee, api.b.ChainConfig(), rcpt), nil
 }
 
@@ -1605,6 +2106,116 @@ func (api *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash commo
 	return marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index), api.b.ChainConfig()), nil
 }
 
+// RPCTxFees is the breakdown of the fees paid by a transaction.
+type RPCTxFees struct {
+	EffectiveTip *hexutil.Big    `json:"effectiveTip"`          // Priority fee per gas
+	Tip          *hexutil.Big    `json:"tip"`                   // Priority fee paid to the fee recipient
+	BaseFee      *hexutil.Big    `json:"baseFee"`               // Base fee burned, or paid to the base fee vault on OP chains
+	BlobFee      *hexutil.Big    `json:"blobFee"`               // Blob fee burned
+	L1Fee        *hexutil.Big    `json:"l1Fee,omitempty"`       // L1 data fee of OP chains
+	OperatorFee  *hexutil.Big    `json:"operatorFee,omitempty"` // Operator fee of OP chains, nil if unknown
+	GasRefund    *hexutil.Uint64 `json:"gasRefund"`             // Gas refunded at the end of execution, nil if unknown
+	Refund       *hexutil.Big    `json:"refund"`                // Value of the refunded gas, nil if unknown
+	Total        *hexutil.Big    `json:"total"`                 // Total fee paid by the sender, nil if unknown
+}
+
+// newRPCTxFees returns the fee breakdown of a transaction. The breakdown stored
+// when the block was processed is used if available, otherwise the fees are
+// derived from the receipt, leaving the refund (and the operator fee, whose
+// formula depends on the fork) unknown.
+func newRPCTxFees(receipt *types.Receipt, header *types.Header, tx *types.Transaction, stored *types.TxFees, config *params.ChainConfig) *RPCTxFees {
+	fees := new(RPCTxFees)
+	if tx.SystemTxType() != nil {
+		// Deposits are paid for on L1, other system transactions are free
+		zero := (*hexutil.Big)(new(big.Int))
+		fees.EffectiveTip, fees.Tip, fees.BaseFee, fees.BlobFee, fees.Refund, fees.Total = zero, zero, zero, zero, zero, zero
+		fees.GasRefund = new(hexutil.Uint64)
+		return fees
+	}
+	tip := new(big.Int).Set(receipt.EffectiveGasPrice)
+	if header.BaseFee != nil {
+		tip.Sub(tip, header.BaseFee)
+	}
+	fees.EffectiveTip = (*hexutil.Big)(tip)
+
+	if stored != nil {
+		fees.Tip = (*hexutil.Big)(stored.Tip)
+		fees.BaseFee = (*hexutil.Big)(stored.BaseFee)
+		fees.BlobFee = (*hexutil.Big)(stored.BlobFee)
+		fees.GasRefund = (*hexutil.Uint64)(&stored.GasRefund)
+		fees.Refund = (*hexutil.Big)(stored.Refund)
+		if config.Optimism != nil {
+			fees.L1Fee = (*hexutil.Big)(stored.L1Fee)
+			fees.OperatorFee = (*hexutil.Big)(stored.OperatorFee)
+		}
+		total := new(big.Int).Add(stored.Tip, stored.BaseFee)
+		total.Add(total, stored.BlobFee)
+		total.Add(total, stored.L1Fee)
+		total.Add(total, stored.OperatorFee)
+		fees.Total = (*hexutil.Big)(total)
+		return fees
+	}
+	gasUsed := new(big.Int).SetUint64(receipt.GasUsed)
+	fees.Tip = (*hexutil.Big)(new(big.Int).Mul(gasUsed, tip))
+	baseFee := new(big.Int)
+	if header.BaseFee != nil {
+		baseFee.Mul(gasUsed, header.BaseFee)
+	}
+	fees.BaseFee = (*hexutil.Big)(baseFee)
+	blobFee := new(big.Int)
+	if receipt.BlobGasPrice != nil {
+		blobFee.Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), receipt.BlobGasPrice)
+	}
+	fees.BlobFee = (*hexutil.Big)(blobFee)
+
+	total := new(big.Int).Add((*big.Int)(fees.Tip), baseFee)
+	total.Add(total, blobFee)
+	if config.Optimism != nil {
+		l1Fee := new(big.Int)
+		if receipt.L1Fee != nil {
+			l1Fee.Set(receipt.L1Fee)
+		}
+		fees.L1Fee = (*hexutil.Big)(l1Fee)
+		total.Add(total, l1Fee)
+		if receipt.OperatorFeeScalar != nil {
+			return fees
+		}
+	}
+	fees.Total = (*hexutil.Big)(total)
+	return fees
+}
+
+// GetTransactionReceiptExtended returns the transaction receipt for the given
+// transaction hash, extended with the breakdown of the fees paid by the
+// transaction in the "fees" field.
+func (api *TransactionAPI) GetTransactionReceiptExtended(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	found, tx, blockHash, blockNumber, index := api.b.GetTransaction(hash)
+	if !found {
+		return api.GetTransactionReceipt(ctx, hash)
+	}
+	header, err := api.b.HeaderByHash(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := api.b.GetReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(receipts)) <= index {
+		return nil, nil
+	}
+	receipt := receipts[index]
+
+	var stored *types.TxFees
+	if fees := rawdb.ReadTxFees(api.b.ChainDb(), blockHash, blockNumber); uint64(len(fees)) > index {
+		stored = fees[index]
+	}
+	signer := types.MakeSigner(api.b.ChainConfig(), header.Number, header.Time)
+	fields := marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index), api.b.ChainConfig())
+	fields["fees"] = newRPCTxFees(receipt, header, tx, stored, api.b.ChainConfig())
+	return fields, nil
+}
+
 // marshalReceipt marshals a transaction receipt into a JSON object.
 func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, tx *types.Transaction, txIndex int, chainConfig *params.ChainConfig) map[string]interface{} {
 	from, _ := types.Sender(signer, tx)
@@ -1625,7 +2236,7 @@ func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber u
 		"effectiveGasPrice": (*hexutil.Big)(receipt.EffectiveGasPrice),
 	}
 
-	if chainConfig.Optimism != nil && !tx.IsDepositTx() {
+	if chainConfig.Optimism != nil && tx.SystemTxType() == nil {
 		fields["l1GasPrice"] = (*hexutil.Big)(receipt.L1GasPrice)
 		fields["l1GasUsed"] = (*hexutil.Big)(receipt.L1GasUsed)
 		fields["l1Fee"] = (*hexutil.Big)(receipt.L1Fee)
@@ -1651,11 +2262,8 @@ func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber u
 			fields["operatorFeeConstant"] = hexutil.Uint64(*receipt.OperatorFeeConstant)
 		}
 	}
-	if chainConfig.Optimism != nil && tx.IsDepositTx() && receipt.DepositNonce != nil {
-		fields["depositNonce"] = hexutil.Uint64(*receipt.DepositNonce)
-		if receipt.DepositReceiptVersion != nil {
-			fields["depositReceiptVersion"] = hexutil.Uint64(*receipt.DepositReceiptVersion)
-		}
+	if st := tx.SystemTxType(); chainConfig.Optimism != nil && st != nil {
+		st.RPCReceiptFields(tx, receipt, fields)
 	}
 
 	// Assign receipt status or post state.
@@ -1704,6 +2312,11 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 		// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
 		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
 	}
+	// Label the transaction with the identity of authenticated clients, used
+	// by the pool to enforce the per-tenant quotas.
+	if id := rpc.PeerInfoFromContext(ctx).AuthID; id != "" {
+		tx.SetTenant(id)
+	}
 	if err := b.SendTx(ctx, tx); err != nil {
 		return common.Hash{}, err
 	}
@@ -2072,8 +2685,8 @@ func (api *DebugAPI) ChaindbCompact() error {
 }
 
 // SetHead rewinds the head of the blockchain to a previous block.
-func (api *DebugAPI) SetHead(number hexutil.Uint64) {
-	api.b.SetHead(uint64(number))
+func (api *DebugAPI) SetHead(number hexutil.Uint64) error {
+	return api.b.SetHead(uint64(number))
 }
 
 func (api *DebugAPI) ChainConfig() *params.ChainConfig {
@@ -636,6 +636,9 @@ func (b testBackend) SyncProgress(ctx context.Context) ethereum.SyncProgress {
 func (b testBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
 	return big.NewInt(0), nil
 }
+func (b testBackend) SuggestGasTipCapFor(ctx context.Context, blocks uint64) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
 func (b testBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, []*big.Int, []float64, error) {
 	return nil, nil, nil, nil, nil, nil, nil
 }
@@ -646,8 +649,9 @@ func (b testBackend) ExtRPCEnabled() bool                      { return false }
 func (b testBackend) RPCGasCap() uint64                        { return 10000000 }
 func (b testBackend) RPCEVMTimeout() time.Duration             { return time.Second }
 func (b testBackend) RPCTxFeeCap() float64                     { return 0 }
+func (b testBackend) CallPrefetcher() *CallPrefetcher          { return nil }
 func (b testBackend) UnprotectedAllowed() bool                 { return false }
-func (b testBackend) SetHead(number uint64)                    {}
+func (b testBackend) SetHead(number uint64) error              { return nil }
 func (b testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
 	if number == rpc.LatestBlockNumber {
 		return b.chain.CurrentBlock(), nil
@@ -2725,6 +2729,54 @@ func TestSimulateV1TxSender(t *testing.T) {
 	require.Equal(t, sender2, summary[1].Transactions[0].From, "sender address mismatch")
 }
 
+func TestSimulateV1ResourceLimits(t *testing.T) {
+	t.Parallel()
+	var (
+		sender   = common.Address{0xaa, 0xaa}
+		contract = common.Address{0xcc, 0xcc}
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender: {Balance: big.NewInt(params.Ether)},
+				// mstore(0x1000, sload(0))
+				// sload(1)
+				contract: {Code: common.FromHex("0x600054611000526001545000")},
+			},
+		}
+		api   = NewBlockChainAPI(newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {}))
+		depth = hexutil.Uint64(2)
+	)
+	simulate := func(limits *ResourceLimitsArgs) ([]*simBlockResult, error) {
+		return api.SimulateV1(context.Background(), simOpts{
+			BlockStateCalls: []simBlock{{Calls: []TransactionArgs{{From: &sender, To: &contract}}}},
+			ResourceLimits:  limits,
+		}, nil)
+	}
+	// No usage is reported without limits
+	results, err := simulate(nil)
+	require.NoError(t, err)
+	require.Nil(t, results[0].Calls[0].ResourceUsage)
+
+	// The usage is reported when the limits are set
+	results, err = simulate(&ResourceLimitsArgs{MaxCallDepth: &depth})
+	require.NoError(t, err)
+	require.Equal(t, &ResourceUsage{MaxMemory: 0x1020, MaxCallDepth: 1, SLoads: 2}, results[0].Calls[0].ResourceUsage)
+
+	// Exceeding the memory limit fails the call
+	memory := hexutil.Uint64(0x1000)
+	results, err = simulate(&ResourceLimitsArgs{MaxMemory: &memory})
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusFailed), results[0].Calls[0].Status)
+	require.Equal(t, vm.ErrMemoryLimitExceeded.Error(), results[0].Calls[0].Error.Message)
+
+	// Invalid limits are rejected
+	zero := hexutil.Uint64(0)
+	_, err = simulate(&ResourceLimitsArgs{MaxMemory: &zero})
+	require.ErrorContains(t, err, "maxMemory")
+	_, err = simulate(&ResourceLimitsArgs{MaxCallDepth: &zero})
+	require.ErrorContains(t, err, "maxCallDepth")
+}
+
 func TestSignTransaction(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
@@ -3722,6 +3774,61 @@ func TestRPCGetTransactionReceipt(t *testing.T) {
 	}
 }
 
+func TestRPCGetTransactionReceiptExtended(t *testing.T) {
+	t.Parallel()
+
+	var (
+		backend, txHashes = setupReceiptBackend(t, 6)
+		api               = NewTransactionAPI(backend, new(AddrLocker))
+	)
+	for i, hash := range txHashes {
+		result, err := api.GetTransactionReceiptExtended(context.Background(), hash)
+		if err != nil {
+			t.Fatalf("tx %d: failed to get receipt: %v", i, err)
+		}
+		var (
+			_, _, blockHash, number, _ = backend.GetTransaction(hash)
+			header                     = backend.chain.GetHeaderByHash(blockHash)
+			gasUsed                    = new(big.Int).SetUint64(uint64(result["gasUsed"].(hexutil.Uint64)))
+			fees                       = result["fees"].(*RPCTxFees)
+		)
+		if fees.GasRefund == nil || fees.Refund == nil || fees.Total == nil {
+			t.Fatalf("tx %d: stored fees not reported", i)
+		}
+		tip := new(big.Int).Sub((*big.Int)(result["effectiveGasPrice"].(*hexutil.Big)), header.BaseFee)
+		if fees.EffectiveTip.ToInt().Cmp(tip) != 0 {
+			t.Errorf("tx %d: wrong effective tip: have %v, want %v", i, fees.EffectiveTip, tip)
+		}
+		if want := new(big.Int).Mul(gasUsed, tip); fees.Tip.ToInt().Cmp(want) != 0 {
+			t.Errorf("tx %d: wrong tip: have %v, want %v", i, fees.Tip, want)
+		}
+		if want := new(big.Int).Mul(gasUsed, header.BaseFee); fees.BaseFee.ToInt().Cmp(want) != 0 {
+			t.Errorf("tx %d: wrong base fee: have %v, want %v", i, fees.BaseFee, want)
+		}
+		var blobFee *big.Int
+		if price, ok := result["blobGasPrice"].(*hexutil.Big); ok {
+			blobFee = new(big.Int).Mul(big.NewInt(int64(result["blobGasUsed"].(hexutil.Uint64))), price.ToInt())
+		} else {
+			blobFee = new(big.Int)
+		}
+		if fees.BlobFee.ToInt().Cmp(blobFee) != 0 {
+			t.Errorf("tx %d: wrong blob fee: have %v, want %v", i, fees.BlobFee, blobFee)
+		}
+		// Without the stored breakdown, the fees are derived from the receipt.
+		stored := *fees
+		rawdb.DeleteTxFees(backend.db, blockHash, number)
+		result, _ = api.GetTransactionReceiptExtended(context.Background(), hash)
+		derived := result["fees"].(*RPCTxFees)
+		if derived.GasRefund != nil || derived.Refund != nil {
+			t.Errorf("tx %d: refund reported without stored fees", i)
+		}
+		if derived.Tip.ToInt().Cmp(stored.Tip.ToInt()) != 0 || derived.BaseFee.ToInt().Cmp(stored.BaseFee.ToInt()) != 0 ||
+			derived.BlobFee.ToInt().Cmp(stored.BlobFee.ToInt()) != 0 || derived.Total.ToInt().Cmp(stored.Total.ToInt()) != 0 {
+			t.Errorf("tx %d: derived fees mismatch: have %+v, want %+v", i, derived, stored)
+		}
+	}
+}
+
 func TestRPCGetBlockReceipts(t *testing.T) {
 	t.Parallel()
 
@@ -3820,6 +3927,50 @@ func TestRPCGetBlockReceipts(t *testing.T) {
 	}
 }
 
+func TestRPCGetBlockReceiptsRange(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genBlocks  = 6
+		backend, _ = setupReceiptBackend(t, genBlocks)
+		api        = NewBlockChainAPI(backend)
+		ctx        = context.Background()
+	)
+	stream, err := api.GetBlockReceiptsRange(ctx, 1, rpc.LatestBlockNumber)
+	if err != nil {
+		t.Fatalf("failed to get receipts range: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := stream.WriteStream(ctx, &buf); err != nil {
+		t.Fatalf("failed to stream receipts: %v", err)
+	}
+	var blocks []json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &blocks); err != nil {
+		t.Fatalf("invalid receipts range: %v", err)
+	}
+	if len(blocks) != genBlocks {
+		t.Fatalf("block count mismatch: have %d, want %d", len(blocks), genBlocks)
+	}
+	// Every block must match its eth_getBlockReceipts result
+	for i, have := range blocks {
+		receipts, err := api.GetBlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(i+1)))
+		if err != nil {
+			t.Fatalf("block %d: failed to get receipts: %v", i+1, err)
+		}
+		want, _ := json.Marshal(receipts)
+		if !bytes.Equal(have, want) {
+			t.Errorf("block %d: receipts mismatch:\nhave %s\nwant %s", i+1, have, want)
+		}
+	}
+	// Invalid and oversized ranges are rejected before streaming
+	if _, err := api.GetBlockReceiptsRange(ctx, 3, 2); err == nil {
+		t.Error("inverted range accepted")
+	}
+	if _, err := api.GetBlockReceiptsRange(ctx, 1, rpc.BlockNumber(genBlocks+1)); err == nil {
+		t.Error("range beyond the head accepted")
+	}
+}
+
 func testRPCResponseWithFile(t *testing.T, testid int, result interface{}, rpc string, file string) {
 	data, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
@@ -3913,3 +4064,183 @@ func TestCreateAccessListWithStateOverrides(t *testing.T) {
 	}}
 	require.Equal(t, expected, result.Accesslist)
 }
+
+func TestCreateAccessLists(t *testing.T) {
+	var (
+		from    = common.HexToAddress("0x71562b71999873db5b286df957af199ec94617f7")
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				from: {Balance: big.NewInt(params.Ether)},
+				// Returns the value of the first storage slot
+				common.Address{0xcc}: {Code: []byte{byte(vm.PUSH1), 0x00, byte(vm.SLOAD), byte(vm.STOP)}},
+				// Increments the first storage slot
+				common.Address{0xee}: {Code: []byte{byte(vm.PUSH1), 0x00, byte(vm.SLOAD), byte(vm.PUSH1), 0x01, byte(vm.ADD), byte(vm.PUSH1), 0x00, byte(vm.SSTORE), byte(vm.STOP)}},
+			},
+		}
+		backend = newTestBackend(t, 1, genesis, ethash.NewFaker(), nil)
+		api     = NewBlockChainAPI(backend)
+		gas     = hexutil.Uint64(100000)
+		to      = common.Address{0xcc}
+	)
+	call := TransactionArgs{From: &from, To: &to, Gas: &gas}
+	results, err := api.CreateAccessLists(context.Background(), []TransactionArgs{call, call}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create access lists: %v", err)
+	}
+	require.Len(t, results, 2)
+	for _, res := range results {
+		require.Equal(t, &types.AccessList{{Address: to, StorageKeys: []common.Hash{{}}}}, res.Accesslist)
+		require.Equal(t, hexutil.Uint64(params.TxAccessListAddressGas+params.TxAccessListStorageKeyGas), res.AccessListGas)
+
+		// Listing the recipient costs more than warming up the single slot saves.
+		saved := int64(res.GasUsedWithoutAccessList) - int64(res.GasUsed)
+		require.Equal(t, saved, res.GasSaved.ToInt().Int64())
+		require.Equal(t, int64(params.ColdSloadCostEIP2929-params.WarmStorageReadCostEIP2929)-int64(res.AccessListGas), saved)
+	}
+	// The transactions of a batch are executed in order, the second transfer
+	// cannot be funded anymore.
+	value := (*hexutil.Big)(big.NewInt(params.Ether/2 + 1))
+	transfer := TransactionArgs{From: &from, To: &common.Address{0xdd}, Gas: &gas, Value: value}
+	if _, err := api.CreateAccessLists(context.Background(), []TransactionArgs{transfer}, nil, nil); err != nil {
+		t.Fatalf("failed to create access list of a single transfer: %v", err)
+	}
+	if _, err := api.CreateAccessLists(context.Background(), []TransactionArgs{transfer, transfer}, nil, nil); err == nil {
+		t.Fatal("second transfer of the batch was funded")
+	}
+	// The writes of a transaction are committed before the next one, so the
+	// second increment resets the slot instead of updating a dirty one.
+	counter := common.Address{0xee}
+	increment := TransactionArgs{From: &from, To: &counter, Gas: &gas}
+	results, err = api.CreateAccessLists(context.Background(), []TransactionArgs{increment, increment}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create access lists: %v", err)
+	}
+	require.Len(t, results, 2)
+	require.Empty(t, results[0].Error)
+	require.Empty(t, results[1].Error)
+	require.Equal(t, params.SstoreSetGasEIP2200-(params.SstoreResetGasEIP2200-params.ColdSloadCostEIP2929), uint64(results[0].GasUsed-results[1].GasUsed))
+}
+
+func TestGetStorageAtManyAndRange(t *testing.T) {
+	t.Parallel()
+
+	var (
+		contract = common.HexToAddress("0xc0de")
+		storage  = make(map[common.Hash]common.Hash)
+	)
+	for i := 1; i <= 5; i++ {
+		storage[common.BigToHash(big.NewInt(int64(i)))] = common.BigToHash(big.NewInt(int64(100 + i)))
+	}
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			contract: {Code: []byte{byte(vm.STOP)}, Storage: storage},
+		},
+	}
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, ethash.NewFaker(), nil))
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	// Query a few slots at once, including a missing one
+	values, err := api.GetStorageAtMany(context.Background(), contract, []string{"0x1", "0x5", "0x6"}, latest)
+	if err != nil {
+		t.Fatalf("failed to query storage: %v", err)
+	}
+	want := []common.Hash{storage[common.BigToHash(big.NewInt(1))], storage[common.BigToHash(big.NewInt(5))], {}}
+	for i, value := range values {
+		if common.BytesToHash(value) != want[i] {
+			t.Errorf("value %d mismatch: have %x, want %x", i, value, want[i])
+		}
+	}
+	if _, err := api.GetStorageAtMany(context.Background(), contract, []string{"0xzz"}, latest); err == nil {
+		t.Error("invalid storage key accepted")
+	}
+	// Iterate the whole storage page by page
+	var (
+		slots = make(map[common.Hash]common.Hash)
+		start common.Hash
+		last  *common.Hash
+	)
+	for {
+		res, err := api.GetStorageRange(context.Background(), contract, start, 2, latest)
+		if err != nil {
+			t.Fatalf("failed to query storage range: %v", err)
+		}
+		for _, slot := range res.Slots {
+			if last != nil && slot.HashedKey.Cmp(*last) <= 0 {
+				t.Fatalf("slots out of order: %x after %x", slot.HashedKey, *last)
+			}
+			hashed := slot.HashedKey
+			last = &hashed
+			slots[slot.HashedKey] = slot.Value
+		}
+		if res.NextKey == nil {
+			break
+		}
+		start = *res.NextKey
+	}
+	if len(slots) != len(storage) {
+		t.Fatalf("wrong number of slots: have %d, want %d", len(slots), len(storage))
+	}
+	for key, value := range storage {
+		if have := slots[crypto.Keccak256Hash(key.Bytes())]; have != value {
+			t.Errorf("slot %x mismatch: have %x, want %x", key, have, value)
+		}
+	}
+	// Empty storage yields an empty range
+	res, err := api.GetStorageRange(context.Background(), common.HexToAddress("0xdead"), common.Hash{}, 10, latest)
+	if err != nil || len(res.Slots) != 0 || res.NextKey != nil {
+		t.Fatalf("wrong range of empty storage: %+v, %v", res, err)
+	}
+	if _, err := api.GetStorageRange(context.Background(), contract, common.Hash{}, 0, latest); err == nil {
+		t.Error("zero limit accepted")
+	}
+}
+
+func TestGetAccount(t *testing.T) {
+	t.Parallel()
+
+	var (
+		contract = common.HexToAddress("0xc0de")
+		funded   = common.HexToAddress("0xfeed")
+		missing  = common.HexToAddress("0xdead")
+		code     = []byte{byte(vm.STOP)}
+	)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			contract: {Code: code, Nonce: 1, Storage: map[common.Hash]common.Hash{{0x01}: {0x02}}},
+			funded:   {Balance: big.NewInt(1000)},
+		},
+	}
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, ethash.NewFaker(), nil))
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	res, err := api.GetAccountMany(context.Background(), []common.Address{contract, funded, missing}, latest)
+	if err != nil {
+		t.Fatalf("failed to query accounts: %v", err)
+	}
+	if len(res) != 3 {
+		t.Fatalf("unexpected result count: have %d, want 3", len(res))
+	}
+	if c := res[0]; !c.Exists || c.Empty || c.Nonce != 1 || c.CodeHash != crypto.Keccak256Hash(code) || c.StorageRoot == types.EmptyRootHash {
+		t.Errorf("unexpected contract account: %+v", c)
+	}
+	if f := res[1]; !f.Exists || f.Empty || f.Balance.ToInt().Int64() != 1000 || f.CodeHash != types.EmptyCodeHash || f.StorageRoot != types.EmptyRootHash {
+		t.Errorf("unexpected funded account: %+v", f)
+	}
+	if m := res[2]; m.Exists || !m.Empty || m.Balance.ToInt().Sign() != 0 || m.CodeHash != types.EmptyCodeHash || m.StorageRoot != types.EmptyRootHash {
+		t.Errorf("unexpected missing account: %+v", m)
+	}
+	// The single account variant matches the batch one
+	single, err := api.GetAccount(context.Background(), funded, latest)
+	if err != nil {
+		t.Fatalf("failed to query account: %v", err)
+	}
+	if !reflect.DeepEqual(single, res[1]) {
+		t.Errorf("account mismatch: have %+v, want %+v", single, res[1])
+	}
+	if _, err := api.GetAccountMany(context.Background(), make([]common.Address, maxAccountQueryAddresses+1), latest); err == nil {
+		t.Error("oversized query accepted")
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxCallBundleSize is the maximum number of transactions accepted by
+// eth_callBundle.
+const maxCallBundleSize = 256
+
+// BundleAPI provides the Flashbots compatible bundle simulation API, for the
+// searcher tooling written against it.
+type BundleAPI struct {
+	b Backend
+}
+
+// NewBundleAPI creates a new bundle API instance.
+func NewBundleAPI(b Backend) *BundleAPI {
+	return &BundleAPI{b}
+}
+
+// CallBundleArgs are the arguments of eth_callBundle, in the Flashbots format.
+type CallBundleArgs struct {
+	Txs              []hexutil.Bytes       `json:"txs"`              // Signed transactions of the bundle
+	BlockNumber      rpc.BlockNumber       `json:"blockNumber"`      // Number of the simulated block, the one after the state block if unset
+	StateBlockNumber rpc.BlockNumberOrHash `json:"stateBlockNumber"` // Block whose state the bundle is simulated on
+	Coinbase         *common.Address       `json:"coinbase"`         // Fee recipient of the simulated block, the one of the state block if unset
+	Timestamp        *uint64               `json:"timestamp"`        // Timestamp of the simulated block, a second after the state block if unset
+	Timeout          *int64                `json:"timeout"`          // Simulation timeout in milliseconds, capped by the global RPC EVM timeout
+	GasLimit         *uint64               `json:"gasLimit"`         // Gas limit of the simulated block, the one of the state block if unset
+	Difficulty       *big.Int              `json:"difficulty"`       // Difficulty of the simulated block, the one of the state block if unset
+	BaseFee          *big.Int              `json:"baseFee"`          // Base fee of the simulated block, derived from the state block if unset
+
+	ResourceLimits *ResourceLimitsArgs `json:"resourceLimits"` // Resource limits of the transactions, see eth_simulateV1
+}
+
+// CallBundleTxResult is the outcome of a single transaction of a bundle
+// simulated by eth_callBundle. The wei amounts are decimal strings, as in the
+// Flashbots format.
+type CallBundleTxResult struct {
+	TxHash            common.Hash     `json:"txHash"`
+	FromAddress       common.Address  `json:"fromAddress"`
+	ToAddress         *common.Address `json:"toAddress,omitempty"`
+	GasUsed           uint64          `json:"gasUsed"`
+	GasPrice          string          `json:"gasPrice"`          // Coinbase diff per gas
+	GasFees           string          `json:"gasFees"`           // Priority fees paid to the coinbase
+	CoinbaseDiff      string          `json:"coinbaseDiff"`      // Balance change of the coinbase
+	EthSentToCoinbase string          `json:"ethSentToCoinbase"` // Coinbase diff besides the priority fees
+	Value             hexutil.Bytes   `json:"value,omitempty"`   // Return data, if successful
+	Error             string          `json:"error,omitempty"`   // Execution error, if failed
+	Revert            string          `json:"revert,omitempty"`  // Revert reason, or the hex encoded revert data if not a string
+
+	ResourceUsage *ResourceUsage `json:"resourceUsage,omitempty"` // Resource usage, if resource limits are set
+}
+
+// CallBundleResult is the result of eth_callBundle.
+type CallBundleResult struct {
+	BundleHash        common.Hash          `json:"bundleHash"`
+	BundleGasPrice    string               `json:"bundleGasPrice"`
+	CoinbaseDiff      string               `json:"coinbaseDiff"`
+	GasFees           string               `json:"gasFees"`
+	EthSentToCoinbase string               `json:"ethSentToCoinbase"`
+	StateBlockNumber  uint64               `json:"stateBlockNumber"`
+	TotalGasUsed      uint64               `json:"totalGasUsed"`
+	Results           []CallBundleTxResult `json:"results"`
+}
+
+// CallBundle simulates the given signed transactions in order, in a block on
+// top of the state of the given block, and reports the coinbase payments they
+// make. Transactions reverting are reported, but any transaction which can't
+// be included in a block fails the whole simulation.
+//
+// Note, this function doesn't make any changes in the state/blockchain.
+func (api *BundleAPI) CallBundle(ctx context.Context, args CallBundleArgs) (*CallBundleResult, error) {
+	if len(args.Txs) == 0 {
+		return nil, &invalidParamsError{message: "bundle missing txs"}
+	}
+	if len(args.Txs) > maxCallBundleSize {
+		return nil, &clientLimitExceededError{message: fmt.Sprintf("bundle too large, %d txs, max %d", len(args.Txs), maxCallBundleSize)}
+	}
+	txs := make(types.Transactions, len(args.Txs))
+	for i, blob := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(blob); err != nil {
+			return nil, &invalidParamsError{message: fmt.Sprintf("invalid tx %d: %v", i, err)}
+		}
+		if tx.SystemTxType() != nil {
+			return nil, &invalidParamsError{message: fmt.Sprintf("invalid tx %d: system transaction", i)}
+		}
+		txs[i] = tx
+	}
+	limits, err := args.ResourceLimits.limits()
+	if err != nil {
+		return nil, err
+	}
+	defer func(start time.Time) { log.Debug("Executing bundle call finished", "runtime", time.Since(start)) }(time.Now())
+
+	state, parent, err := api.b.StateAndHeaderByNumberOrHash(ctx, args.StateBlockNumber)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	header := api.makeHeader(parent, &args)
+
+	// The simulation is aborted on timeout, or when the request is cancelled
+	timeout := api.b.RPCEVMTimeout()
+	if args.Timeout != nil {
+		if t := time.Duration(*args.Timeout) * time.Millisecond; timeout == 0 || t < timeout {
+			timeout = t
+		}
+	}
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	// The whole bundle shouldn't consume more gas than the block gas limit, nor
+	// than the RPC gas cap, like the series of calls of eth_simulateV1
+	gasCap := header.GasLimit
+	if rpcCap := api.b.RPCGasCap(); rpcCap != 0 && rpcCap < gasCap {
+		gasCap = rpcCap
+	}
+	var (
+		config       = api.b.ChainConfig()
+		signer       = types.MakeSigner(config, header.Number, header.Time)
+		blockContext = core.NewEVMBlockContext(header, NewChainContext(ctx, api.b), nil, config, state)
+		evm          = api.b.GetEVM(ctx, state, header, &vm.Config{ResourceLimits: limits}, &blockContext)
+		gp           = new(core.GasPool).AddGas(gasCap)
+
+		initial = state.GetBalance(header.Coinbase).ToBig()
+		gasFees = new(big.Int)
+		hashes  = make([]byte, 0, len(txs)*common.HashLength)
+		result  = &CallBundleResult{StateBlockNumber: parent.Number.Uint64(), Results: make([]CallBundleTxResult, 0, len(txs))}
+	)
+	for i, tx := range txs {
+		msg, err := core.TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("err: %w; txhash %s", err, tx.Hash())
+		}
+		before := state.GetBalance(header.Coinbase).ToBig()
+
+		state.SetTxContext(tx.Hash(), i)
+		evm.ResetResourceUsage()
+		res, err := applyMessageWithEVM(ctx, evm, msg, timeout, gp)
+		if err := state.Error(); err != nil {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("err: %w; txhash %s", err, tx.Hash())
+		}
+		state.Finalise(config.IsEIP158(header.Number))
+
+		tip, err := tx.EffectiveGasTip(header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("err: %w; txhash %s", err, tx.Hash())
+		}
+		var (
+			txGasFees    = new(big.Int).Mul(tip, new(big.Int).SetUint64(res.UsedGas))
+			coinbaseDiff = new(big.Int).Sub(state.GetBalance(header.Coinbase).ToBig(), before)
+			txResult     = CallBundleTxResult{
+				TxHash:            tx.Hash(),
+				FromAddress:       msg.From,
+				ToAddress:         tx.To(),
+				GasUsed:           res.UsedGas,
+				GasPrice:          new(big.Int).Div(coinbaseDiff, new(big.Int).SetUint64(max(res.UsedGas, 1))).String(),
+				GasFees:           txGasFees.String(),
+				CoinbaseDiff:      coinbaseDiff.String(),
+				EthSentToCoinbase: new(big.Int).Sub(coinbaseDiff, txGasFees).String(),
+			}
+		)
+		if limits != nil {
+			txResult.ResourceUsage = newResourceUsage(evm.ResourceUsage())
+		}
+		if res.Failed() {
+			txResult.Error = res.Err.Error()
+			if revert := res.Revert(); len(revert) > 0 {
+				if reason, err := abi.UnpackRevert(revert); err == nil {
+					txResult.Revert = reason
+				} else {
+					txResult.Revert = hexutil.Encode(revert)
+				}
+			}
+		} else {
+			txResult.Value = res.Return()
+		}
+		result.Results = append(result.Results, txResult)
+		result.TotalGasUsed += res.UsedGas
+		gasFees.Add(gasFees, txGasFees)
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	coinbaseDiff := new(big.Int).Sub(state.GetBalance(header.Coinbase).ToBig(), initial)
+
+	result.BundleHash = crypto.Keccak256Hash(hashes)
+	result.BundleGasPrice = new(big.Int).Div(coinbaseDiff, new(big.Int).SetUint64(max(result.TotalGasUsed, 1))).String()
+	result.CoinbaseDiff = coinbaseDiff.String()
+	result.GasFees = gasFees.String()
+	result.EthSentToCoinbase = new(big.Int).Sub(coinbaseDiff, gasFees).String()
+	return result, nil
+}
+
+// makeHeader assembles the header of the block a bundle is simulated in, on
+// top of the given parent.
+func (api *BundleAPI) makeHeader(parent *types.Header, args *CallBundleArgs) *types.Header {
+	config := api.b.ChainConfig()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Time:       parent.Time + 1,
+		Coinbase:   parent.Coinbase,
+		GasLimit:   parent.GasLimit,
+		Difficulty: parent.Difficulty,
+	}
+	if args.BlockNumber > 0 {
+		header.Number = big.NewInt(args.BlockNumber.Int64())
+	}
+	if args.Timestamp != nil {
+		header.Time = *args.Timestamp
+	}
+	if args.Coinbase != nil {
+		header.Coinbase = *args.Coinbase
+	}
+	if args.GasLimit != nil {
+		header.GasLimit = *args.GasLimit
+	}
+	if args.Difficulty != nil {
+		header.Difficulty = args.Difficulty
+	}
+	if config.IsLondon(header.Number) {
+		if args.BaseFee != nil {
+			header.BaseFee = args.BaseFee
+		} else {
+			header.BaseFee = eip1559.CalcBaseFee(config, parent, header.Time)
+		}
+	}
+	if config.IsCancun(header.Number, header.Time) {
+		var excess uint64
+		if config.IsCancun(parent.Number, parent.Time) {
+			excess = eip4844.CalcExcessBlobGas(config, parent, header.Time)
+		}
+		header.ExcessBlobGas = &excess
+	}
+	return header
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestCallBundle(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(2)
+		coinbase = common.HexToAddress("0x000000000000000000000000000000000000c014")
+		reverter = common.HexToAddress("0x00000000000000000000000000000000000000ff")
+		burner   = common.HexToAddress("0x00000000000000000000000000000000000000fe")
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+				// PUSH1 0 PUSH1 0 REVERT
+				reverter: {Code: []byte{0x60, 0x00, 0x60, 0x00, 0xfd}},
+				// JUMPDEST PUSH1 0 JUMP
+				burner: {Code: []byte{0x5b, 0x60, 0x00, 0x56}},
+			},
+		}
+		signer = types.LatestSigner(genesis.Config)
+		tip    = big.NewInt(params.GWei)
+	)
+	api := NewBundleAPI(newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	}))
+	sign := func(key int, nonce uint64, to common.Address, value int64, gas uint64) hexutil.Bytes {
+		tx := types.MustSignNewTx(accounts[key].key, signer, &types.DynamicFeeTx{
+			ChainID:   genesis.Config.ChainID,
+			Nonce:     nonce,
+			GasTipCap: tip,
+			GasFeeCap: big.NewInt(100 * params.GWei),
+			Gas:       gas,
+			To:        &to,
+			Value:     big.NewInt(value),
+		})
+		blob, _ := tx.MarshalBinary()
+		return blob
+	}
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	// A payment to the coinbase followed by a reverting call
+	res, err := api.CallBundle(context.Background(), CallBundleArgs{
+		Txs:              []hexutil.Bytes{sign(0, 0, coinbase, 1000, params.TxGas), sign(1, 0, reverter, 0, 50000)},
+		StateBlockNumber: latest,
+		Coinbase:         &coinbase,
+	})
+	if err != nil {
+		t.Fatalf("failed to call bundle: %v", err)
+	}
+	if res.StateBlockNumber != 1 || len(res.Results) != 2 {
+		t.Fatalf("wrong bundle result: %+v", res)
+	}
+	payment := res.Results[0]
+	if payment.FromAddress != accounts[0].addr || payment.GasUsed != params.TxGas || payment.Error != "" {
+		t.Fatalf("wrong payment result: %+v", payment)
+	}
+	fees := new(big.Int).Mul(tip, big.NewInt(int64(params.TxGas)))
+	if payment.GasFees != fees.String() || payment.EthSentToCoinbase != "1000" {
+		t.Fatalf("wrong payment fees: gas fees %s, sent %s", payment.GasFees, payment.EthSentToCoinbase)
+	}
+	if payment.CoinbaseDiff != new(big.Int).Add(fees, big.NewInt(1000)).String() {
+		t.Fatalf("wrong payment coinbase diff: %s", payment.CoinbaseDiff)
+	}
+	reverted := res.Results[1]
+	if reverted.Error != "execution reverted" || reverted.Value != nil {
+		t.Fatalf("wrong reverted call result: %+v", reverted)
+	}
+	if res.TotalGasUsed != payment.GasUsed+reverted.GasUsed || res.EthSentToCoinbase != "1000" {
+		t.Fatalf("wrong bundle totals: %+v", res)
+	}
+	if res.GasFees != new(big.Int).Mul(tip, new(big.Int).SetUint64(res.TotalGasUsed)).String() {
+		t.Fatalf("wrong bundle gas fees: %s", res.GasFees)
+	}
+	// Transactions which can't be included fail the bundle
+	if _, err := api.CallBundle(context.Background(), CallBundleArgs{
+		Txs:              []hexutil.Bytes{sign(0, 1, coinbase, 1000, params.TxGas)},
+		StateBlockNumber: latest,
+	}); err == nil {
+		t.Fatal("bundle with a nonce gap simulated")
+	}
+	if _, err := api.CallBundle(context.Background(), CallBundleArgs{StateBlockNumber: latest}); err == nil {
+		t.Fatal("empty bundle simulated")
+	}
+	// The whole bundle is capped by the RPC gas cap, even in a larger block
+	gasLimit := uint64(100_000_000)
+	if _, err := api.CallBundle(context.Background(), CallBundleArgs{
+		Txs:              []hexutil.Bytes{sign(0, 0, burner, 0, 6_000_000), sign(1, 0, burner, 0, 6_000_000)},
+		StateBlockNumber: latest,
+		GasLimit:         &gasLimit,
+	}); !errors.Is(err, core.ErrGasLimitReached) {
+		t.Fatalf("bundle above the gas cap not refused: %v", err)
+	}
+}
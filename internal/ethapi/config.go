@@ -0,0 +1,309 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/forkid"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// forkSchedule lists the protocol upgrades known to the node in activation
+// order, together with the chain config field scheduling each of them. Block
+// based forks return their activation number, time based ones their timestamp.
+var forkSchedule = []struct {
+	name  string
+	block func(c *params.ChainConfig) *big.Int
+	time  func(c *params.ChainConfig) *uint64
+}{
+	{name: "Homestead", block: func(c *params.ChainConfig) *big.Int { return c.HomesteadBlock }},
+	{name: "TangerineWhistle", block: func(c *params.ChainConfig) *big.Int { return c.EIP150Block }},
+	{name: "SpuriousDragon", block: func(c *params.ChainConfig) *big.Int { return c.EIP158Block }},
+	{name: "Byzantium", block: func(c *params.ChainConfig) *big.Int { return c.ByzantiumBlock }},
+	{name: "Constantinople", block: func(c *params.ChainConfig) *big.Int { return c.ConstantinopleBlock }},
+	{name: "Petersburg", block: func(c *params.ChainConfig) *big.Int { return c.PetersburgBlock }},
+	{name: "Istanbul", block: func(c *params.ChainConfig) *big.Int { return c.IstanbulBlock }},
+	{name: "MuirGlacier", block: func(c *params.ChainConfig) *big.Int { return c.MuirGlacierBlock }},
+	{name: "Berlin", block: func(c *params.ChainConfig) *big.Int { return c.BerlinBlock }},
+	{name: "London", block: func(c *params.ChainConfig) *big.Int { return c.LondonBlock }},
+	{name: "ArrowGlacier", block: func(c *params.ChainConfig) *big.Int { return c.ArrowGlacierBlock }},
+	{name: "GrayGlacier", block: func(c *params.ChainConfig) *big.Int { return c.GrayGlacierBlock }},
+	{name: "Paris"}, // Activated by total difficulty, not by block or time
+	{name: "Bedrock", block: func(c *params.ChainConfig) *big.Int { return c.BedrockBlock }},
+	{name: "Regolith", time: func(c *params.ChainConfig) *uint64 { return c.RegolithTime }},
+	{name: "Shanghai", time: func(c *params.ChainConfig) *uint64 { return c.ShanghaiTime }},
+	{name: "Canyon", time: func(c *params.ChainConfig) *uint64 { return c.CanyonTime }},
+	{name: "Cancun", time: func(c *params.ChainConfig) *uint64 { return c.CancunTime }},
+	{name: "Ecotone", time: func(c *params.ChainConfig) *uint64 { return c.EcotoneTime }},
+	{name: "Fjord", time: func(c *params.ChainConfig) *uint64 { return c.FjordTime }},
+	{name: "Granite", time: func(c *params.ChainConfig) *uint64 { return c.GraniteTime }},
+	{name: "Holocene", time: func(c *params.ChainConfig) *uint64 { return c.HoloceneTime }},
+	{name: "Prague", time: func(c *params.ChainConfig) *uint64 { return c.PragueTime }},
+	{name: "Isthmus", time: func(c *params.ChainConfig) *uint64 { return c.IsthmusTime }},
+	{name: "Jovian", time: func(c *params.ChainConfig) *uint64 { return c.JovianTime }},
+	{name: "Osaka", time: func(c *params.ChainConfig) *uint64 { return c.OsakaTime }},
+	{name: "Interop", time: func(c *params.ChainConfig) *uint64 { return c.InteropTime }},
+	{name: "Verkle", time: func(c *params.ChainConfig) *uint64 { return c.VerkleTime }},
+}
+
+// precompileNames maps the precompiled contract addresses to the names used by
+// the execution specs.
+var precompileNames = map[common.Address]string{
+	common.BytesToAddress([]byte{0x01}):       "ECREC",
+	common.BytesToAddress([]byte{0x02}):       "SHA256",
+	common.BytesToAddress([]byte{0x03}):       "RIPEMD160",
+	common.BytesToAddress([]byte{0x04}):       "ID",
+	common.BytesToAddress([]byte{0x05}):       "MODEXP",
+	common.BytesToAddress([]byte{0x06}):       "BN254_ADD",
+	common.BytesToAddress([]byte{0x07}):       "BN254_MUL",
+	common.BytesToAddress([]byte{0x08}):       "BN254_PAIRING",
+	common.BytesToAddress([]byte{0x09}):       "BLAKE2F",
+	common.BytesToAddress([]byte{0x0a}):       "KZG_POINT_EVALUATION",
+	common.BytesToAddress([]byte{0x0b}):       "BLS12_G1ADD",
+	common.BytesToAddress([]byte{0x0c}):       "BLS12_G1MSM",
+	common.BytesToAddress([]byte{0x0d}):       "BLS12_G2ADD",
+	common.BytesToAddress([]byte{0x0e}):       "BLS12_G2MSM",
+	common.BytesToAddress([]byte{0x0f}):       "BLS12_PAIRING_CHECK",
+	common.BytesToAddress([]byte{0x10}):       "BLS12_MAP_FP_TO_G1",
+	common.BytesToAddress([]byte{0x11}):       "BLS12_MAP_FP2_TO_G2",
+	common.BytesToAddress([]byte{0x01, 0x00}): "P256VERIFY",
+}
+
+// ForkActivation is a protocol upgrade and the point it activates at. Forks not
+// scheduled by block or time (i.e. Paris) have neither field set.
+type ForkActivation struct {
+	Name  string          `json:"name"`
+	Block *hexutil.Big    `json:"block,omitempty"`
+	Time  *hexutil.Uint64 `json:"time,omitempty"`
+}
+
+// ChainRules is the set of protocol rules the chain follows at a given block.
+type ChainRules struct {
+	Number          hexutil.Uint64            `json:"number"`                   // Block number the rules were evaluated at
+	Time            hexutil.Uint64            `json:"time"`                     // Block time the rules were evaluated at
+	ChainID         *hexutil.Big              `json:"chainId"`                  // Chain identifier of EIP-155
+	ForkID          hexutil.Bytes             `json:"forkId"`                   // Fork identifier of EIP-2124
+	Fork            *ForkActivation           `json:"fork"`                     // Latest activated fork
+	Forks           []*ForkActivation         `json:"forks"`                    // Activated forks, in activation order
+	Next            *ForkActivation           `json:"next,omitempty"`           // Next scheduled fork, if any
+	Precompiles     map[string]common.Address `json:"precompiles"`              // Active precompiled contracts by name
+	SystemContracts map[string]common.Address `json:"systemContracts"`          // System contracts the protocol calls into
+	GasSchedule     []string                  `json:"gasSchedule"`              // EIPs in effect that reprice operations
+	L1CostFunction  string                    `json:"l1CostFunction,omitempty"` // Data availability fee formula of OP chains
+}
+
+// ChainConfigResult is the result of eth_config: the rules of the current
+// head, those of the next scheduled fork and those of the last one scheduled.
+type ChainConfigResult struct {
+	Current *ChainRules `json:"current"`
+	Next    *ChainRules `json:"next"`
+	Last    *ChainRules `json:"last"`
+}
+
+// chainRules evaluates the chain config at the given block number and time.
+// The merged flag tells whether the block is past the terminal total difficulty,
+// which the config alone cannot determine.
+func chainRules(config *params.ChainConfig, genesis *types.Block, number uint64, time uint64, merged bool) *ChainRules {
+	num := new(big.Int).SetUint64(number)
+	rules := config.Rules(num, merged, time)
+
+	res := &ChainRules{
+		Number:          hexutil.Uint64(number),
+		Time:            hexutil.Uint64(time),
+		ChainID:         (*hexutil.Big)(config.ChainID),
+		Forks:           []*ForkActivation{},
+		Precompiles:     make(map[string]common.Address),
+		SystemContracts: make(map[string]common.Address),
+		GasSchedule:     []string{},
+	}
+	if genesis != nil {
+		id := forkid.NewID(config, genesis, number, time)
+		res.ForkID = id.Hash[:]
+	}
+	for _, fork := range forkSchedule {
+		activation := &ForkActivation{Name: fork.name}
+		switch {
+		case fork.block != nil:
+			block := fork.block(config)
+			if block == nil {
+				continue
+			}
+			activation.Block = (*hexutil.Big)(block)
+			if block.Cmp(num) > 0 {
+				if res.Next == nil {
+					res.Next = activation
+				}
+				continue
+			}
+		case fork.time != nil:
+			at := fork.time(config)
+			if at == nil {
+				continue
+			}
+			activation.Time = (*hexutil.Uint64)(at)
+			if *at > time {
+				if res.Next == nil {
+					res.Next = activation
+				}
+				continue
+			}
+		default:
+			if config.TerminalTotalDifficulty == nil || !merged {
+				continue
+			}
+		}
+		res.Forks = append(res.Forks, activation)
+		res.Fork = activation
+	}
+	if res.Fork == nil {
+		res.Fork = &ForkActivation{Name: "Frontier", Block: (*hexutil.Big)(new(big.Int))}
+	}
+	for _, addr := range vm.ActivePrecompiles(rules) {
+		name, ok := precompileNames[addr]
+		if !ok {
+			name = addr.Hex()
+		}
+		res.Precompiles[name] = addr
+	}
+	// Collect the system contracts the state transition interacts with
+	if rules.IsCancun {
+		res.SystemContracts["BEACON_ROOTS_ADDRESS"] = params.BeaconRootsAddress
+	}
+	if rules.IsPrague {
+		res.SystemContracts["HISTORY_STORAGE_ADDRESS"] = params.HistoryStorageAddress
+		if !rules.IsOptimismIsthmus {
+			res.SystemContracts["WITHDRAWAL_REQUEST_PREDEPLOY_ADDRESS"] = params.WithdrawalQueueAddress
+			res.SystemContracts["CONSOLIDATION_REQUEST_PREDEPLOY_ADDRESS"] = params.ConsolidationQueueAddress
+			res.SystemContracts["DEPOSIT_CONTRACT_ADDRESS"] = config.DepositContractAddress
+		}
+	}
+	if rules.IsOptimismBedrock {
+		res.SystemContracts["L1_BLOCK_ADDRESS"] = types.L1BlockAddr
+		res.SystemContracts["L2_TO_L1_MESSAGE_PASSER_ADDRESS"] = params.OptimismL2ToL1MessagePasser
+		res.SystemContracts["BASE_FEE_VAULT_ADDRESS"] = params.OptimismBaseFeeRecipient
+		res.SystemContracts["L1_FEE_VAULT_ADDRESS"] = params.OptimismL1FeeRecipient
+		if rules.IsOptimismIsthmus {
+			res.SystemContracts["OPERATOR_FEE_VAULT_ADDRESS"] = params.OptimismOperatorFeeRecipient
+		}
+		if config.IsInterop(time) {
+			res.SystemContracts["CROSS_L2_INBOX_ADDRESS"] = params.InteropCrossL2InboxAddress
+		}
+		switch {
+		case rules.IsOptimismFjord:
+			res.L1CostFunction = "fjord"
+		case config.IsOptimismEcotone(time):
+			res.L1CostFunction = "ecotone"
+		default:
+			res.L1CostFunction = "bedrock"
+		}
+	}
+	// Collect the EIPs altering the gas schedule of the EVM
+	if rules.IsEIP150 {
+		res.GasSchedule = append(res.GasSchedule, "EIP-150")
+	}
+	if rules.IsEIP158 {
+		res.GasSchedule = append(res.GasSchedule, "EIP-160")
+	}
+	if rules.IsIstanbul {
+		res.GasSchedule = append(res.GasSchedule, "EIP-1108", "EIP-1884", "EIP-2028", "EIP-2200")
+	}
+	if rules.IsBerlin {
+		res.GasSchedule = append(res.GasSchedule, "EIP-2565", "EIP-2929", "EIP-2930")
+	}
+	if rules.IsLondon {
+		res.GasSchedule = append(res.GasSchedule, "EIP-3529")
+	}
+	if rules.IsShanghai {
+		res.GasSchedule = append(res.GasSchedule, "EIP-3860")
+	}
+	if rules.IsCancun {
+		res.GasSchedule = append(res.GasSchedule, "EIP-1153", "EIP-4844", "EIP-5656")
+	}
+	if rules.IsPrague {
+		res.GasSchedule = append(res.GasSchedule, "EIP-2537", "EIP-7623", "EIP-7702")
+	}
+	return res
+}
+
+// headerMerged reports whether the header is past the terminal total difficulty.
+func headerMerged(config *params.ChainConfig, header *types.Header) bool {
+	return config.TerminalTotalDifficulty != nil && header.Difficulty.Sign() == 0
+}
+
+// Config returns the protocol rules in effect at the current head, and the ones
+// that will be once the next and the last scheduled forks activate.
+func (api *BlockChainAPI) Config(ctx context.Context) (*ChainConfigResult, error) {
+	genesis, err := api.b.BlockByNumber(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	if genesis == nil {
+		return nil, errors.New("genesis block not found")
+	}
+	var (
+		config = api.b.ChainConfig()
+		head   = api.b.CurrentHeader()
+		merged = headerMerged(config, head)
+		res    = &ChainConfigResult{
+			Current: chainRules(config, genesis, head.Number.Uint64(), head.Time, merged),
+		}
+	)
+	// Walk the scheduled forks, evaluating the rules at each of them. The cursor
+	// only ever moves forward so every step activates at least one more fork,
+	// and mixed block and time based schedules cannot flip-flop between each
+	// other. The iteration count is bounded by the schedule length regardless.
+	number, time := head.Number.Uint64(), head.Time
+	for next, i := res.Current.Next, 0; next != nil && i < len(forkSchedule); i++ {
+		if next.Block != nil {
+			number = next.Block.ToInt().Uint64()
+		} else {
+			time = uint64(*next.Time)
+		}
+		rules := chainRules(config, genesis, number, time, merged)
+		if res.Next == nil {
+			res.Next = rules
+		}
+		res.Last = rules
+		next = rules.Next
+	}
+	return res, nil
+}
+
+// ChainConfigAt returns the protocol rules in effect at the given block.
+func (api *DebugAPI) ChainConfigAt(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*ChainRules, error) {
+	header, err := api.b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.New("header not found")
+	}
+	genesis, err := api.b.BlockByNumber(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	config := api.b.ChainConfig()
+	return chainRules(config, genesis, header.Number.Uint64(), header.Time, headerMerged(config, header)), nil
+}
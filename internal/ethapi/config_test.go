@@ -0,0 +1,149 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that the chain config introspection reports the rules in effect at the
+// head and at the scheduled forks.
+func TestChainConfig(t *testing.T) {
+	t.Parallel()
+
+	config := *params.MergedTestChainConfig
+	pragueTime := uint64(1000)
+	config.PragueTime = &pragueTime
+
+	genesis := &core.Genesis{
+		Config: &config,
+		Alloc:  types.GenesisAlloc{},
+	}
+	backend := newTestBackend(t, 2, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {})
+
+	res, err := NewBlockChainAPI(backend).Config(context.Background())
+	if err != nil {
+		t.Fatalf("failed to retrieve chain config: %v", err)
+	}
+	if res.Current.Fork.Name != "Cancun" {
+		t.Errorf("current fork mismatch: have %s, want Cancun", res.Current.Fork.Name)
+	}
+	if res.Current.Next == nil || res.Current.Next.Name != "Prague" {
+		t.Fatalf("next fork mismatch: have %v, want Prague", res.Current.Next)
+	}
+	if _, ok := res.Current.SystemContracts["BEACON_ROOTS_ADDRESS"]; !ok {
+		t.Error("beacon roots contract missing before Prague")
+	}
+	if _, ok := res.Current.SystemContracts["HISTORY_STORAGE_ADDRESS"]; ok {
+		t.Error("history storage contract reported before Prague")
+	}
+	if _, ok := res.Current.Precompiles["BLS12_G1ADD"]; ok {
+		t.Error("BLS precompile reported before Prague")
+	}
+	if res.Next == nil || res.Next != res.Last {
+		t.Fatalf("next and last rules mismatch: %v != %v", res.Next, res.Last)
+	}
+	if res.Next.Fork.Name != "Prague" || uint64(res.Next.Time) != pragueTime {
+		t.Errorf("next rules mismatch: have %s at %d, want Prague at %d", res.Next.Fork.Name, res.Next.Time, pragueTime)
+	}
+	if addr := res.Next.Precompiles["BLS12_G1ADD"]; addr != common.BytesToAddress([]byte{0x0b}) {
+		t.Errorf("BLS precompile mismatch: have %v", addr)
+	}
+	if addr := res.Next.SystemContracts["HISTORY_STORAGE_ADDRESS"]; addr != params.HistoryStorageAddress {
+		t.Errorf("history storage contract mismatch: have %v", addr)
+	}
+	if res.Next.Next != nil {
+		t.Errorf("unexpected fork after Prague: %v", res.Next.Next)
+	}
+	if string(res.Current.ForkID) == string(res.Next.ForkID) {
+		t.Error("fork id unchanged across Prague")
+	}
+
+	// Historical lookups should evaluate the rules of the requested block. The
+	// genesis is the terminal proof-of-work block, so Paris is not active yet.
+	rules, err := NewDebugAPI(backend).ChainConfigAt(context.Background(), rpc.BlockNumberOrHashWithNumber(0))
+	if err != nil {
+		t.Fatalf("failed to retrieve genesis config: %v", err)
+	}
+	if rules.Number != 0 || len(rules.Forks) != len(res.Current.Forks)-1 {
+		t.Errorf("genesis rules mismatch: number %d, %d forks", rules.Number, len(rules.Forks))
+	}
+	if len(rules.GasSchedule) == 0 {
+		t.Error("gas schedule missing")
+	}
+}
+
+// Tests that a schedule mixing future block and time based forks terminates and
+// reports every fork in activation order.
+func TestChainConfigMixedSchedule(t *testing.T) {
+	t.Parallel()
+
+	config := *params.MergedTestChainConfig
+	config.BedrockBlock = big.NewInt(100)
+	pragueTime := uint64(1000)
+	config.PragueTime = &pragueTime
+
+	genesis := &core.Genesis{
+		Config: &config,
+		Alloc:  types.GenesisAlloc{},
+	}
+	backend := newTestBackend(t, 2, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {})
+
+	var (
+		res  *ChainConfigResult
+		err  error
+		done = make(chan struct{})
+	)
+	go func() {
+		res, err = NewBlockChainAPI(backend).Config(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("chain config walk did not terminate")
+	}
+	if err != nil {
+		t.Fatalf("failed to retrieve chain config: %v", err)
+	}
+	if res.Current.Next == nil || res.Current.Next.Name != "Bedrock" {
+		t.Fatalf("next fork mismatch: have %v, want Bedrock", res.Current.Next)
+	}
+	if res.Next == nil || uint64(res.Next.Number) != 100 || res.Next.Next == nil || res.Next.Next.Name != "Prague" {
+		t.Fatalf("next rules mismatch: have %v, want Bedrock followed by Prague", res.Next)
+	}
+	if res.Last == nil || res.Last.Fork.Name != "Prague" {
+		t.Fatalf("last rules mismatch: have %v, want Prague", res.Last)
+	}
+	if uint64(res.Last.Number) != 100 || uint64(res.Last.Time) != pragueTime {
+		t.Errorf("last rules evaluated at wrong point: number %d, time %d", res.Last.Number, res.Last.Time)
+	}
+	if res.Last.Next != nil {
+		t.Errorf("unexpected fork after Prague: %v", res.Last.Next)
+	}
+}
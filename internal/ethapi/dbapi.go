@@ -19,10 +19,23 @@ package ethapi
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 )
 
+// DbAPI provides read-only access to the raw key-value and ancient stores. It
+// is only served on the authenticated RPC endpoints, as it exposes every byte
+// of the node's storage.
+type DbAPI struct {
+	b Backend
+}
+
+// NewDbAPI creates a new instance of DbAPI.
+func NewDbAPI(b Backend) *DbAPI {
+	return &DbAPI{b: b}
+}
+
 // DbGet returns the raw value of a key stored in the database.
-func (api *DebugAPI) DbGet(key string) (hexutil.Bytes, error) {
+func (api *DbAPI) DbGet(key string) (hexutil.Bytes, error) {
 	blob, err := common.ParseHexOrString(key)
 	if err != nil {
 		return nil, err
@@ -32,12 +45,72 @@ func (api *DebugAPI) DbGet(key string) (hexutil.Bytes, error) {
 
 // DbAncient retrieves an ancient binary blob from the append-only immutable files.
 // It is a mapping to the `AncientReaderOp.Ancient` method
-func (api *DebugAPI) DbAncient(kind string, number uint64) (hexutil.Bytes, error) {
+func (api *DbAPI) DbAncient(kind string, number uint64) (hexutil.Bytes, error) {
 	return api.b.ChainDb().Ancient(kind, number)
 }
 
 // DbAncients returns the ancient item numbers in the ancient store.
 // It is a mapping to the `AncientReaderOp.Ancients` method
-func (api *DebugAPI) DbAncients() (uint64, error) {
+func (api *DbAPI) DbAncients() (uint64, error) {
 	return api.b.ChainDb().Ancients()
 }
+
+// DbTableStats is the storage size of a single ancient table.
+type DbTableStats struct {
+	Name string         `json:"name"`
+	Size hexutil.Uint64 `json:"size"`
+}
+
+// DbAncientStats describes the item range and table sizes of an ancient store.
+type DbAncientStats struct {
+	Name   string         `json:"name"`
+	Head   hexutil.Uint64 `json:"head"`
+	Tail   hexutil.Uint64 `json:"tail"`
+	Items  hexutil.Uint64 `json:"items"`
+	Size   hexutil.Uint64 `json:"size"`
+	Tables []DbTableStats `json:"tables"`
+}
+
+// DbStats is the result of debug_dbStats.
+type DbStats struct {
+	KeyValue string           `json:"keyValue"`
+	Ancients []DbAncientStats `json:"ancients"`
+}
+
+// DbStats returns the statistics of the key-value store as reported by the
+// database engine, together with the item range and per-table sizes of all
+// ancient stores. Unlike `geth db inspect`, it does not iterate the key-value
+// store, so it is cheap enough to be called on a live node.
+func (api *DbAPI) DbStats() (*DbStats, error) {
+	db := api.b.ChainDb()
+	kv, err := db.Stat()
+	if err != nil {
+		return nil, err
+	}
+	stats := &DbStats{KeyValue: kv, Ancients: []DbAncientStats{}}
+
+	// Databases without an ancient store (e.g. in-memory ones) have nothing
+	// more to report.
+	if _, err := db.AncientDatadir(); err != nil {
+		return stats, nil
+	}
+	freezers, err := rawdb.InspectFreezers(db)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range freezers {
+		ancient := DbAncientStats{
+			Name:   f.Name,
+			Head:   hexutil.Uint64(f.Head),
+			Tail:   hexutil.Uint64(f.Tail),
+			Items:  hexutil.Uint64(f.Items),
+			Size:   hexutil.Uint64(f.Size),
+			Tables: make([]DbTableStats, 0, len(f.Tables)),
+		}
+		for _, t := range f.Tables {
+			ancient.Tables = append(ancient.Tables, DbTableStats{Name: t.Name, Size: hexutil.Uint64(t.Size)})
+		}
+		stats.Ancients = append(stats.Ancients, ancient)
+	}
+	return stats, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// prefetchWorkers is the number of concurrent state readers per call.
+	prefetchWorkers = 8
+
+	// maxPrefetchItems is the maximum number of accounts and storage slots
+	// prefetched for a single call.
+	maxPrefetchItems = 1024
+
+	// learnedAccessLists is the number of (to, selector) pairs whose access
+	// lists are retained.
+	learnedAccessLists = 4096
+)
+
+var (
+	prefetchItemsMeter  = metrics.NewRegisteredMeter("rpc/prefetch/items", nil)
+	prefetchLearnedHits = metrics.NewRegisteredMeter("rpc/prefetch/learned/hit", nil)
+	prefetchLearnedMiss = metrics.NewRegisteredMeter("rpc/prefetch/learned/miss", nil)
+)
+
+// callKey identifies the access pattern of a call: the called contract and the
+// method selector.
+type callKey struct {
+	to       common.Address
+	selector [4]byte
+}
+
+// CallPrefetcher warms the state caches before eth_call and eth_estimateGas
+// execution by concurrently loading the accounts and storage slots of the
+// access list supplied with the call. Optionally, it learns the access lists
+// of executed calls per called contract and method, and prefetches them for
+// later calls of the same method.
+type CallPrefetcher struct {
+	learned *lru.Cache[callKey, types.AccessList] // nil if learning is disabled
+}
+
+// NewCallPrefetcher creates a call prefetcher, learning access lists if
+// requested.
+func NewCallPrefetcher(learn bool) *CallPrefetcher {
+	p := new(CallPrefetcher)
+	if learn {
+		p.learned = lru.NewCache[callKey, types.AccessList](learnedAccessLists)
+	}
+	return p
+}
+
+// key returns the access pattern key of the call, or false if the call does
+// not invoke a contract method.
+func (p *CallPrefetcher) key(args *TransactionArgs) (callKey, bool) {
+	data := args.data()
+	if args.To == nil || len(data) < 4 {
+		return callKey{}, false
+	}
+	key := callKey{to: *args.To}
+	copy(key.selector[:], data)
+	return key, true
+}
+
+// Prefetch loads the state accessed by the supplied and the learned access
+// lists of the call from the state with the given root, returning when done
+// or when the context is cancelled. It is a no-op on a nil prefetcher.
+func (p *CallPrefetcher) Prefetch(ctx context.Context, db state.Database, root common.Hash, args *TransactionArgs) {
+	if p == nil {
+		return
+	}
+	var list types.AccessList
+	if args.AccessList != nil {
+		list = append(list, *args.AccessList...)
+	}
+	if p.learned != nil {
+		if key, ok := p.key(args); ok {
+			if learned, ok := p.learned.Get(key); ok {
+				prefetchLearnedHits.Mark(1)
+				list = append(list, learned...)
+			} else {
+				prefetchLearnedMiss.Mark(1)
+			}
+		}
+	}
+	if len(list) > 0 {
+		prefetchAccessList(ctx, db, root, list)
+	}
+}
+
+// Learn records the access list of the executed call, if learning is enabled.
+// It is a no-op on a nil prefetcher.
+func (p *CallPrefetcher) Learn(args *TransactionArgs, statedb *state.StateDB) {
+	if p == nil || p.learned == nil {
+		return
+	}
+	key, ok := p.key(args)
+	if !ok {
+		return
+	}
+	list := statedb.AccessList()
+	if len(list)+list.StorageKeys() > maxPrefetchItems {
+		return
+	}
+	p.learned.Add(key, list)
+}
+
+// prefetchAccessList concurrently loads the accounts, codes and storage slots
+// in the access list, up to maxPrefetchItems of them, using independent state
+// readers. The loaded values are discarded, the point is to populate the
+// shared caches of the state database before the execution needs them.
+func prefetchAccessList(ctx context.Context, db state.Database, root common.Hash, list types.AccessList) {
+	type item struct {
+		addr common.Address
+		slot *common.Hash // nil for the account itself
+	}
+	var (
+		items = make(chan item)
+		wg    sync.WaitGroup
+	)
+	var readers []state.Reader
+	for i := 0; i < prefetchWorkers; i++ {
+		reader, err := db.Reader(root)
+		if err != nil {
+			break
+		}
+		readers = append(readers, reader)
+	}
+	if len(readers) == 0 {
+		return // state unavailable, the call will fail on its own
+	}
+	for _, reader := range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for it := range items {
+				if it.slot != nil {
+					reader.Storage(it.addr, *it.slot)
+					continue
+				}
+				account, err := reader.Account(it.addr)
+				if err == nil && account != nil && common.BytesToHash(account.CodeHash) != types.EmptyCodeHash {
+					reader.Code(it.addr, common.BytesToHash(account.CodeHash))
+				}
+			}
+		}()
+	}
+	var count int
+	send := func(it item) bool {
+		if count >= maxPrefetchItems {
+			return false
+		}
+		select {
+		case items <- it:
+			count++
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+loop:
+	for _, tuple := range list {
+		if !send(item{addr: tuple.Address}) {
+			break
+		}
+		for i := range tuple.StorageKeys {
+			if !send(item{addr: tuple.Address, slot: &tuple.StorageKeys[i]}) {
+				break loop
+			}
+		}
+	}
+	close(items)
+	wg.Wait()
+	prefetchItemsMeter.Mark(int64(count))
+}
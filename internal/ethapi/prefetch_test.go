@@ -0,0 +1,106 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the access lists of executed calls are learned per called
+// contract and method.
+func TestCallPrefetcherLearn(t *testing.T) {
+	var (
+		contract = common.HexToAddress("0xc0ffee")
+		other    = common.HexToAddress("0xbeef")
+		slot     = common.HexToHash("0x01")
+		call     = hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb, 0x01}
+		same     = hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb, 0x02}
+		short    = hexutil.Bytes{0xa9, 0x05}
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddSlotToAccessList(contract, slot)
+	statedb.AddAddressToAccessList(other)
+
+	p := NewCallPrefetcher(true)
+	p.Learn(&TransactionArgs{To: &contract, Input: &call}, statedb)
+	p.Learn(&TransactionArgs{To: &contract, Input: &short}, statedb)
+	p.Learn(&TransactionArgs{Input: &call}, statedb)
+
+	if p.learned.Len() != 1 {
+		t.Fatalf("wrong number of learned access lists: have %d, want 1", p.learned.Len())
+	}
+	key, ok := p.key(&TransactionArgs{To: &contract, Input: &same})
+	if !ok {
+		t.Fatal("no key for contract call")
+	}
+	list, ok := p.learned.Get(key)
+	if !ok {
+		t.Fatal("access list not learned for the same method")
+	}
+	if len(list) != 2 || list.StorageKeys() != 1 {
+		t.Fatalf("wrong learned access list: %v", list)
+	}
+	// A disabled or nil prefetcher does not learn.
+	NewCallPrefetcher(false).Learn(&TransactionArgs{To: &contract, Input: &call}, statedb)
+	(*CallPrefetcher)(nil).Learn(&TransactionArgs{To: &contract, Input: &call}, statedb)
+}
+
+// Tests that prefetching loads the accessed state, honouring the item cap and
+// context cancellation.
+func TestPrefetchAccessList(t *testing.T) {
+	var (
+		db       = state.NewDatabaseForTesting()
+		contract = common.HexToAddress("0xc0ffee")
+		slot     = common.HexToHash("0x01")
+	)
+	statedb, _ := state.New(types.EmptyRootHash, db)
+	statedb.SetBalance(contract, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	statedb.SetCode(contract, []byte{0x60, 0x00})
+	statedb.SetState(contract, slot, common.HexToHash("0x2a"))
+	root, err := statedb.Commit(0, true, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	list := types.AccessList{{Address: contract, StorageKeys: []common.Hash{slot}}}
+	for i := 0; i < maxPrefetchItems; i++ {
+		list = append(list, types.AccessTuple{Address: common.BigToAddress(common.Big1), StorageKeys: []common.Hash{{byte(i)}}})
+	}
+	prefetchAccessList(context.Background(), db, root, list)
+
+	// A cancelled context stops the prefetch, an unknown root skips it.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	prefetchAccessList(ctx, db, root, list)
+	prefetchAccessList(context.Background(), db, common.Hash{0x01}, list)
+
+	// The prefetch must not interfere with the state.
+	statedb, _ = state.New(root, db)
+	if have := statedb.GetState(contract, slot); have != common.HexToHash("0x2a") {
+		t.Fatalf("wrong slot value: %x", have)
+	}
+	args := &TransactionArgs{To: &contract, AccessList: &list}
+	NewCallPrefetcher(true).Prefetch(context.Background(), db, root, args)
+	(*CallPrefetcher)(nil).Prefetch(context.Background(), db, root, args)
+}
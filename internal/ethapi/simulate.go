@@ -61,6 +61,8 @@ type simCallResult struct {
 	GasUsed     hexutil.Uint64 `json:"gasUsed"`
 	Status      hexutil.Uint64 `json:"status"`
 	Error       *callError     `json:"error,omitempty"`
+
+	ResourceUsage *ResourceUsage `json:"resourceUsage,omitempty"`
 }
 
 func (r *simCallResult) MarshalJSON() ([]byte, error) {
@@ -120,6 +122,52 @@ type simOpts struct {
 	TraceTransfers         bool
 	Validation             bool
 	ReturnFullTransactions bool
+	ResourceLimits         *ResourceLimitsArgs
+}
+
+// ResourceLimitsArgs are resource limits tighter than the protocol ones, set
+// on the calls of a simulation to profile their worst-case resource usage.
+// The usage of each call is reported when set, even without limits.
+type ResourceLimitsArgs struct {
+	MaxMemory    *hexutil.Uint64 `json:"maxMemory"`    // Maximum memory of a call frame in bytes
+	MaxCallDepth *hexutil.Uint64 `json:"maxCallDepth"` // Maximum depth of the call frames, 1 for the top level one only
+}
+
+// limits validates the arguments and returns the resource limits of the EVM,
+// nil if the arguments are not set.
+func (args *ResourceLimitsArgs) limits() (*vm.ResourceLimits, error) {
+	if args == nil {
+		return nil, nil
+	}
+	limits := new(vm.ResourceLimits)
+	if args.MaxMemory != nil {
+		if *args.MaxMemory == 0 {
+			return nil, &invalidParamsError{message: "maxMemory must be positive"}
+		}
+		limits.MaxMemory = uint64(*args.MaxMemory)
+	}
+	if args.MaxCallDepth != nil {
+		if *args.MaxCallDepth == 0 || *args.MaxCallDepth > hexutil.Uint64(params.CallCreateDepth) {
+			return nil, &invalidParamsError{message: fmt.Sprintf("maxCallDepth must be in [1, %d]", params.CallCreateDepth)}
+		}
+		limits.MaxCallDepth = int(*args.MaxCallDepth)
+	}
+	return limits, nil
+}
+
+// ResourceUsage is the peak resource usage of a simulated call.
+type ResourceUsage struct {
+	MaxMemory    hexutil.Uint64 `json:"maxMemory"`    // Largest memory of a call frame in bytes
+	MaxCallDepth hexutil.Uint64 `json:"maxCallDepth"` // Deepest call frame, the top level one being 1
+	SLoads       hexutil.Uint64 `json:"sloads"`       // Number of storage reads
+}
+
+func newResourceUsage(usage vm.ResourceUsage) *ResourceUsage {
+	return &ResourceUsage{
+		MaxMemory:    hexutil.Uint64(usage.MaxMemory),
+		MaxCallDepth: hexutil.Uint64(usage.MaxCallDepth),
+		SLoads:       hexutil.Uint64(usage.SLoads),
+	}
 }
 
 // simChainHeadReader implements ChainHeaderReader which is needed as input for FinalizeAndAssemble.
@@ -174,6 +222,7 @@ type simulator struct {
 	traceTransfers bool
 	validate       bool
 	fullTx         bool
+	limits         *vm.ResourceLimits
 }
 
 // execute runs the simulation of a series of blocks.
@@ -260,8 +309,9 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 		// Block hash will be repaired after execution.
 		tracer   = newTracer(sim.traceTransfers, blockContext.BlockNumber.Uint64(), common.Hash{}, common.Hash{}, 0)
 		vmConfig = &vm.Config{
-			NoBaseFee: !sim.validate,
-			Tracer:    tracer.Hooks(),
+			NoBaseFee:      !sim.validate,
+			Tracer:         tracer.Hooks(),
+			ResourceLimits: sim.limits,
 		}
 		// senders is a map of transaction hashes to their senders.
 		// Transaction objects contain only the signature, and we lose track
@@ -302,6 +352,7 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 		sim.state.SetTxContext(txHash, i)
 		// EoA check is always skipped, even in validation mode.
 		msg := call.ToMessage(header.BaseFee, !sim.validate, true)
+		evm.ResetResourceUsage()
 		result, err := applyMessageWithEVM(ctx, evm, msg, timeout, sim.gp)
 		if err != nil {
 			txErr := txValidationError(err)
@@ -319,6 +370,9 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 		blobGasUsed += receipts[i].BlobGasUsed
 		logs := tracer.Logs()
 		callRes := simCallResult{ReturnValue: result.Return(), Logs: logs, GasUsed: hexutil.Uint64(result.UsedGas)}
+		if sim.limits != nil {
+			callRes.ResourceUsage = newResourceUsage(evm.ResourceUsage())
+		}
 		if result.Failed() {
 			callRes.Status = hexutil.Uint64(types.ReceiptStatusFailed)
 			if errors.Is(result.Err, vm.ErrExecutionReverted) {
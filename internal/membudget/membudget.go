@@ -0,0 +1,400 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package membudget implements a node-wide memory budget, tracking the memory
+// of the major consumers against a ceiling and shedding load as it approaches.
+package membudget
+
+import (
+	"maps"
+	"math"
+	"os"
+	"runtime/debug"
+	"runtime/metrics"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// Level is the memory pressure of the process.
+type Level int32
+
+const (
+	LevelNormal Level = iota // Below the soft threshold, no load shed
+	LevelSoft                // Beyond the soft threshold, heavy loads shed
+	LevelHard                // Beyond the hard threshold, all optional loads shed
+)
+
+// String implements fmt.Stringer.
+func (l Level) String() string {
+	switch l {
+	case LevelNormal:
+		return "normal"
+	case LevelSoft:
+		return "soft"
+	default:
+		return "hard"
+	}
+}
+
+// Config contains the settings of the memory budget.
+type Config struct {
+	Limit     uint64        // Memory ceiling of the process in bytes
+	SoftRatio float64       // Share of the ceiling beyond which heavy loads are shed
+	HardRatio float64       // Share of the ceiling beyond which all optional loads are shed
+	LargeSize uint64        // Size from which reservations are heavy
+	Interval  time.Duration // Interval between two samplings of the memory usage
+}
+
+// DefaultConfig contains the default settings of the memory budget, without
+// a ceiling.
+var DefaultConfig = Config{
+	SoftRatio: 0.8,
+	HardRatio: 0.95,
+	LargeSize: 1024 * 1024,
+	Interval:  time.Second,
+}
+
+// hysteresis is the share of the ceiling the usage must drop below a threshold
+// to lower the pressure level, to avoid flapping around it.
+const hysteresis = 0.05
+
+// consumer is a memory consumer whose usage is polled.
+type consumer struct {
+	usage func() uint64
+	gauge *gethmetrics.Gauge
+}
+
+// Budget tracks the memory of the major consumers of the node against a
+// ceiling. The pressure level is derived from the memory used by the process,
+// or by the tracked consumers if more, sampled periodically: transient loads
+// reserving memory through an Account are refused as it rises, and the
+// pressure handlers are notified of the level changes to shrink the long lived
+// consumers. The consumers are reported as gauges under membudget/.
+type Budget struct {
+	config Config
+	level  atomic.Int32
+	used   atomic.Uint64 // Memory used by the process at the last sampling
+	held   atomic.Int64  // Memory held by the reservations of all accounts
+
+	lock      sync.Mutex
+	consumers map[string]*consumer
+	accounts  map[string]*Account
+	handlers  []func(Level)
+
+	readMemory func() uint64 // Sampler of the memory used by the process
+	prevLimit  int64         // Go runtime memory limit before Start
+
+	usedGauge   *gethmetrics.Gauge
+	levelGauge  *gethmetrics.Gauge
+	rejectMeter *gethmetrics.Meter
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a memory budget with the given settings.
+func New(config Config) *Budget {
+	if config.SoftRatio <= 0 || config.SoftRatio >= 1 {
+		config.SoftRatio = DefaultConfig.SoftRatio
+	}
+	if config.HardRatio <= config.SoftRatio || config.HardRatio > 1 {
+		config.HardRatio = math.Max(DefaultConfig.HardRatio, config.SoftRatio)
+	}
+	if config.LargeSize == 0 {
+		config.LargeSize = DefaultConfig.LargeSize
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultConfig.Interval
+	}
+	gethmetrics.GetOrRegisterGauge("membudget/limit", nil).Update(int64(config.Limit))
+
+	return &Budget{
+		config:      config,
+		consumers:   make(map[string]*consumer),
+		accounts:    make(map[string]*Account),
+		readMemory:  processMemory,
+		usedGauge:   gethmetrics.GetOrRegisterGauge("membudget/used", nil),
+		levelGauge:  gethmetrics.GetOrRegisterGauge("membudget/level", nil),
+		rejectMeter: gethmetrics.GetOrRegisterMeter("membudget/rejected", nil),
+		quit:        make(chan struct{}),
+	}
+}
+
+// processMemory returns the memory used by the process: the resident set size
+// reported by the operating system, which includes the allocations made out of
+// the Go heap (e.g. by the database), or the memory mapped by the Go runtime
+// if larger or if the operating system doesn't report it.
+func processMemory() uint64 {
+	return max(residentMemory(), runtimeMemory())
+}
+
+// runtimeMemory returns the memory mapped by the Go runtime and not released
+// to the operating system.
+func runtimeMemory() uint64 {
+	samples := []metrics.Sample{
+		{Name: "/memory/classes/total:bytes"},
+		{Name: "/memory/classes/heap/released:bytes"},
+	}
+	metrics.Read(samples)
+	if samples[0].Value.Kind() != metrics.KindUint64 || samples[1].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return samples[0].Value.Uint64() - samples[1].Value.Uint64()
+}
+
+// residentMemory returns the resident set size of the process, or zero if not
+// available.
+func residentMemory() uint64 {
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return pages * uint64(os.Getpagesize())
+}
+
+// Track registers a long lived consumer, whose memory usage is reported by the
+// given function.
+func (b *Budget) Track(name string, usage func() uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.consumers[name] = &consumer{
+		usage: usage,
+		gauge: gethmetrics.GetOrRegisterGauge("membudget/"+name, nil),
+	}
+}
+
+// Account creates an account of transient loads reserving memory from the
+// budget, such as responses or trace buffers.
+func (b *Budget) Account(name string) *Account {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	account := &Account{
+		budget: b,
+		gauge:  gethmetrics.GetOrRegisterGauge("membudget/"+name, nil),
+	}
+	b.accounts[name] = account
+	return account
+}
+
+// OnPressure registers a handler notified of the pressure level changes.
+func (b *Budget) OnPressure(fn func(Level)) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.handlers = append(b.handlers, fn)
+}
+
+// Level returns the current memory pressure.
+func (b *Budget) Level() Level {
+	return Level(b.level.Load())
+}
+
+// Limit returns the memory ceiling in bytes.
+func (b *Budget) Limit() uint64 {
+	return b.config.Limit
+}
+
+// Usage returns the memory used by every consumer, as last sampled for the long
+// lived ones.
+func (b *Budget) Usage() map[string]uint64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	usage := make(map[string]uint64, len(b.consumers)+len(b.accounts))
+	for name, c := range b.consumers {
+		usage[name] = uint64(c.gauge.Snapshot().Value())
+	}
+	for name, a := range b.accounts {
+		usage[name] = a.Held()
+	}
+	return usage
+}
+
+// Start starts sampling the memory usage. Unless set by the user, the memory
+// limit of the Go runtime is set to the hard threshold, making the garbage
+// collector work harder before load needs to be shed.
+func (b *Budget) Start() {
+	b.prevLimit = -1
+	if debug.SetMemoryLimit(-1) == math.MaxInt64 {
+		b.prevLimit = debug.SetMemoryLimit(int64(float64(b.config.Limit) * b.config.HardRatio))
+	}
+	b.sample()
+
+	b.wg.Add(1)
+	go b.loop()
+}
+
+// Stop terminates sampling the memory usage.
+func (b *Budget) Stop() {
+	close(b.quit)
+	b.wg.Wait()
+
+	if b.prevLimit >= 0 {
+		debug.SetMemoryLimit(b.prevLimit)
+	}
+}
+
+func (b *Budget) loop() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.sample()
+		case <-b.quit:
+			return
+		}
+	}
+}
+
+// sample measures the memory usage, updating the pressure level and notifying
+// the handlers of its changes.
+func (b *Budget) sample() {
+	// The tracked consumers count even if the process sampler misses them, e.g.
+	// if allocated out of the Go heap on a platform without resident set size.
+	var tracked uint64
+	b.lock.Lock()
+	for _, c := range b.consumers {
+		usage := c.usage()
+		c.gauge.Update(int64(usage))
+		tracked += usage
+	}
+	handlers := b.handlers
+	b.lock.Unlock()
+
+	used := max(b.readMemory(), tracked)
+	b.used.Store(used)
+	b.usedGauge.Update(int64(used))
+
+	var (
+		prev  = b.Level()
+		level = b.levelOf(used, prev)
+	)
+	if level == prev {
+		return
+	}
+	b.level.Store(int32(level))
+	b.levelGauge.Update(int64(level))
+
+	ctx := []interface{}{"level", level, "used", common.StorageSize(used), "limit", common.StorageSize(b.config.Limit)}
+	if level > prev {
+		usage := b.Usage()
+		for _, name := range slices.Sorted(maps.Keys(usage)) {
+			ctx = append(ctx, name, common.StorageSize(usage[name]))
+		}
+		log.Warn("Memory pressure increased", ctx...)
+	} else {
+		log.Info("Memory pressure decreased", ctx...)
+	}
+	for _, fn := range handlers {
+		fn(level)
+	}
+	// Return the memory freed by the shrunk consumers to the operating system,
+	// the resident set size would not drop otherwise until the runtime gets to
+	// scavenge it.
+	if level > prev {
+		debug.FreeOSMemory()
+	}
+}
+
+// levelOf returns the pressure level of the given memory usage. The level only
+// decreases once the usage dropped a margin below its threshold.
+func (b *Budget) levelOf(used uint64, prev Level) Level {
+	var (
+		limit = float64(b.config.Limit)
+		ratio = float64(used) / limit
+	)
+	level := LevelNormal
+	switch {
+	case ratio >= b.config.HardRatio:
+		level = LevelHard
+	case ratio >= b.config.SoftRatio:
+		level = LevelSoft
+	}
+	if level < prev {
+		threshold := b.config.SoftRatio
+		if prev == LevelHard {
+			threshold = b.config.HardRatio
+		}
+		if ratio > threshold-hysteresis {
+			level = prev
+		}
+	}
+	return level
+}
+
+// Account is a consumer reserving memory from the budget for transient loads.
+type Account struct {
+	budget *Budget
+	held   atomic.Int64
+	gauge  *gethmetrics.Gauge
+}
+
+// Reserve reserves the given number of bytes, returning false if the load must
+// be shed: any under hard pressure, heavy ones under soft pressure, and those
+// which would exceed the ceiling.
+func (a *Account) Reserve(size uint64) bool {
+	b := a.budget
+	switch b.Level() {
+	case LevelHard:
+		b.rejectMeter.Mark(1)
+		return false
+	case LevelSoft:
+		if size >= b.config.LargeSize {
+			b.rejectMeter.Mark(1)
+			return false
+		}
+	}
+	if held := b.held.Add(int64(size)); b.used.Load()+uint64(held) > b.config.Limit {
+		b.held.Add(-int64(size))
+		b.rejectMeter.Mark(1)
+		return false
+	}
+	a.gauge.Update(a.held.Add(int64(size)))
+	return true
+}
+
+// Release releases a reservation of the given number of bytes.
+func (a *Account) Release(size uint64) {
+	a.budget.held.Add(-int64(size))
+	a.gauge.Update(a.held.Add(-int64(size)))
+}
+
+// Held returns the number of bytes currently reserved by the account.
+func (a *Account) Held() uint64 {
+	return uint64(a.held.Load())
+}
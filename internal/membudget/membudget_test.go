@@ -0,0 +1,142 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package membudget
+
+import (
+	"os"
+	"runtime/debug"
+	"testing"
+)
+
+// Tests that the reservations are shed as the memory pressure rises, and that
+// the handlers are notified of the level changes with hysteresis.
+func TestBudgetPressure(t *testing.T) {
+	var (
+		used   uint64
+		levels []Level
+	)
+	budget := New(Config{Limit: 1000, SoftRatio: 0.8, HardRatio: 0.95, LargeSize: 100})
+	budget.readMemory = func() uint64 { return used }
+	budget.OnPressure(func(level Level) { levels = append(levels, level) })
+	budget.Track("cache", func() uint64 { return 300 })
+	account := budget.Account("rpc")
+
+	// Without pressure, reservations are only refused beyond the ceiling
+	used = 500
+	budget.sample()
+	if !account.Reserve(200) {
+		t.Fatal("reservation refused without pressure")
+	}
+	if account.Reserve(400) {
+		t.Fatal("reservation beyond the ceiling accepted")
+	}
+	account.Release(200)
+
+	// Under soft pressure, only the small reservations are accepted
+	used = 850
+	budget.sample()
+	if account.Reserve(100) {
+		t.Error("heavy reservation accepted under soft pressure")
+	}
+	if !account.Reserve(50) {
+		t.Error("light reservation refused under soft pressure")
+	}
+	account.Release(50)
+
+	// Under hard pressure, nothing is accepted
+	used = 960
+	budget.sample()
+	if account.Reserve(1) {
+		t.Error("reservation accepted under hard pressure")
+	}
+	// The pressure only decreases once the usage drops a margin below
+	used = 920
+	budget.sample()
+	if level := budget.Level(); level != LevelHard {
+		t.Errorf("level decreased within the margin: have %v, want %v", level, LevelHard)
+	}
+	used = 100
+	budget.sample()
+
+	want := []Level{LevelSoft, LevelHard, LevelNormal}
+	if len(levels) != len(want) {
+		t.Fatalf("level changes mismatch: have %v, want %v", levels, want)
+	}
+	for i := range want {
+		if levels[i] != want[i] {
+			t.Fatalf("level changes mismatch: have %v, want %v", levels, want)
+		}
+	}
+	if usage := budget.Usage(); usage["cache"] != 300 || usage["rpc"] != 0 {
+		t.Errorf("usage mismatch: %v", usage)
+	}
+}
+
+// Tests that the tracked consumers count towards the pressure even if the
+// process sampler misses them.
+func TestBudgetTrackedUsage(t *testing.T) {
+	budget := New(Config{Limit: 1000, SoftRatio: 0.8, HardRatio: 0.95})
+	budget.readMemory = func() uint64 { return 100 }
+
+	cache := uint64(900)
+	budget.Track("cache", func() uint64 { return cache })
+	budget.OnPressure(func(level Level) {
+		if level == LevelSoft {
+			cache /= 2
+		}
+	})
+	budget.sample()
+	if level := budget.Level(); level != LevelSoft {
+		t.Fatalf("level mismatch: have %v, want %v", level, LevelSoft)
+	}
+	budget.sample()
+	if level := budget.Level(); level != LevelNormal {
+		t.Fatalf("level mismatch after shrinking: have %v, want %v", level, LevelNormal)
+	}
+}
+
+// Tests that the memory released by a consumer shrinking under pressure is
+// returned to the operating system, lowering the pressure again.
+func TestBudgetShrinkReturnsMemory(t *testing.T) {
+	if residentMemory() == 0 {
+		t.Skip("resident set size not available")
+	}
+	const size = 256 * 1024 * 1024
+
+	debug.FreeOSMemory()
+	base := processMemory()
+
+	buf := make([]byte, size)
+	for i := 0; i < len(buf); i += os.Getpagesize() {
+		buf[i] = 1
+	}
+	budget := New(Config{Limit: base + size, SoftRatio: 0.8, HardRatio: 0.9})
+	budget.Track("buffer", func() uint64 { return uint64(len(buf)) })
+	budget.OnPressure(func(level Level) {
+		if level != LevelNormal {
+			buf = nil
+		}
+	})
+	budget.sample()
+	if level := budget.Level(); level != LevelHard {
+		t.Fatalf("level mismatch: have %v, want %v", level, LevelHard)
+	}
+	budget.sample()
+	if level := budget.Level(); level != LevelNormal {
+		t.Fatalf("memory not returned: level %v, used %d, base %d", level, processMemory(), base)
+	}
+}
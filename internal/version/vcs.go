@@ -18,6 +18,7 @@ package version
 
 import (
 	"runtime/debug"
+	"strings"
 	"time"
 )
 
@@ -75,3 +76,17 @@ func buildInfoVCS(info *debug.BuildInfo) (s VCSInfo, ok bool) {
 	}
 	return
 }
+
+// BuildTags returns the build tags the current executable was compiled with.
+func BuildTags() []string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	for _, v := range buildInfo.Settings {
+		if v.Key == "-tags" && v.Value != "" {
+			return strings.Split(v.Value, ",")
+		}
+	}
+	return nil
+}
@@ -18,15 +18,20 @@
 package web3ext
 
 var Modules = map[string]string{
-	"admin":  AdminJs,
-	"clique": CliqueJs,
-	"debug":  DebugJs,
-	"eth":    EthJs,
-	"miner":  MinerJs,
-	"net":    NetJs,
-	"rpc":    RpcJs,
-	"txpool": TxpoolJs,
-	"dev":    DevJs,
+	"admin":    AdminJs,
+	"clique":   CliqueJs,
+	"debug":    DebugJs,
+	"eth":      EthJs,
+	"miner":    MinerJs,
+	"net":      NetJs,
+	"rpc":      RpcJs,
+	"txpool":   TxpoolJs,
+	"dev":      DevJs,
+	"ext":      ExtJs,
+	"attest":   AttestJs,
+	"explorer": ExplorerJs,
+	"policy":   PolicyJs,
+	"overlay":  OverlayJs,
 }
 
 const CliqueJs = `
@@ -110,6 +115,16 @@ web3._extend({
 			call: 'admin_removeTrustedPeer',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'addJWTSecret',
+			call: 'admin_addJWTSecret',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'removeJWTSecret',
+			call: 'admin_removeJWTSecret',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'exportChain',
 			call: 'admin_exportChain',
@@ -121,6 +136,12 @@ web3._extend({
 			call: 'admin_importChain',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'scheduleFork',
+			call: 'admin_scheduleFork',
+			params: 2,
+			inputFormatter: [null, web3._extend.utils.fromDecimal]
+		}),
 		new web3._extend.Method({
 			name: 'sleepBlocks',
 			call: 'admin_sleepBlocks',
@@ -158,6 +179,62 @@ web3._extend({
 			name: 'stopWS',
 			call: 'admin_stopWS'
 		}),
+		new web3._extend.Method({
+			name: 'shutdown',
+			call: 'admin_shutdown',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'promote',
+			call: 'admin_promote'
+		}),
+		new web3._extend.Method({
+			name: 'pauseChain',
+			call: 'admin_pauseChain',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'resumeChain',
+			call: 'admin_resumeChain'
+		}),
+		new web3._extend.Method({
+			name: 'registerContractABI',
+			call: 'admin_registerContractABI',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'fetchContractABI',
+			call: 'admin_fetchContractABI',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'removeContractABI',
+			call: 'admin_removeContractABI',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'attachLegacyState',
+			call: 'admin_attachLegacyState',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'detachLegacyState',
+			call: 'admin_detachLegacyState'
+		}),
+		new web3._extend.Method({
+			name: 'refreshRemoteConfig',
+			call: 'admin_refreshRemoteConfig'
+		}),
+		new web3._extend.Method({
+			name: 'setSyncPivot',
+			call: 'admin_setSyncPivot',
+			params: 1
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -172,6 +249,22 @@ web3._extend({
 			name: 'datadir',
 			getter: 'admin_datadir'
 		}),
+		new web3._extend.Property({
+			name: 'subscriptions',
+			getter: 'admin_subscriptions'
+		}),
+		new web3._extend.Property({
+			name: 'legacyState',
+			getter: 'admin_legacyState'
+		}),
+		new web3._extend.Property({
+			name: 'remoteConfig',
+			getter: 'admin_remoteConfig'
+		}),
+		new web3._extend.Property({
+			name: 'syncPivot',
+			getter: 'admin_syncPivot'
+		}),
 	]
 });
 `
@@ -228,6 +321,73 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'verifyOrderingPolicy',
+			call: 'debug_verifyOrderingPolicy',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'rebuildPayload',
+			call: 'debug_rebuildPayload',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getBlockAccessList',
+			call: 'debug_getBlockAccessList',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'blockConflictGraph',
+			call: 'debug_blockConflictGraph',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getBalanceChanges',
+			call: 'debug_getBalanceChanges',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'totalSupply',
+			call: 'debug_totalSupply',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'chainConfigAt',
+			call: 'debug_chainConfigAt',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'simulateFork',
+			call: 'debug_simulateFork',
+			params: 3
+		}),
+		new web3._extend.Method({
+			name: 'blockStats',
+			call: 'debug_blockStats',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'stateRent',
+			call: 'debug_stateRent',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'stateRentAccount',
+			call: 'debug_stateRentAccount',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'dumpBlockChunk',
+			call: 'debug_dumpBlockChunk',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'chaindbProperty',
 			call: 'debug_chaindbProperty',
@@ -409,6 +569,12 @@ web3._extend({
 			params: 3,
 			inputFormatter: [null, null, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceCallMany',
+			call: 'debug_traceCallMany',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'preimage',
 			call: 'debug_preimage',
@@ -463,6 +629,38 @@ web3._extend({
 			call: 'debug_dbAncients',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'dbStats',
+			call: 'debug_dbStats',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'validateTransaction',
+			call: 'debug_validateTransaction',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'integrityReport',
+			call: 'debug_integrityReport',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getBadBlockArtifacts',
+			call: 'debug_getBadBlockArtifacts',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'headWatchdog',
+			call: 'debug_headWatchdog',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'crossCheck',
+			call: 'debug_crossCheck',
+			params: 0
+		}),
 		new web3._extend.Method({
 			name: 'setTrieFlushInterval',
 			call: 'debug_setTrieFlushInterval',
@@ -473,6 +671,12 @@ web3._extend({
 			call: 'debug_getTrieFlushInterval',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'rpcUsage',
+			call: 'debug_rpcUsage',
+			params: 1,
+			inputFormatter: [null]
+		}),
 	],
 	properties: []
 });
@@ -524,6 +728,11 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'callBundle',
+			call: 'eth_callBundle',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'getHeaderByNumber',
 			call: 'eth_getHeaderByNumber',
@@ -566,18 +775,53 @@ web3._extend({
 			params: 3,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getAccount',
+			call: 'eth_getAccount',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getAccountMany',
+			call: 'eth_getAccountMany',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getStorageAtMany',
+			call: 'eth_getStorageAtMany',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getStorageRange',
+			call: 'eth_getStorageRange',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'createAccessList',
 			call: 'eth_createAccessList',
 			params: 2,
 			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'createAccessLists',
+			call: 'eth_createAccessLists',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'feeHistory',
 			call: 'eth_feeHistory',
 			params: 3,
 			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'config',
+			call: 'eth_config',
+			params: 0
+		}),
 		new web3._extend.Method({
 			name: 'getLogs',
 			call: 'eth_getLogs',
@@ -600,6 +844,23 @@ web3._extend({
 			call: 'eth_getBlockReceipts',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'getBlockReceiptsRange',
+			call: 'eth_getBlockReceiptsRange',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getTransactionReceiptExtended',
+			call: 'eth_getTransactionReceiptExtended',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getTransactionsBySender',
+			call: 'eth_getTransactionsBySender',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -619,6 +880,10 @@ web3._extend({
 			getter: 'eth_maxPriorityFeePerGas',
 			outputFormatter: web3._extend.utils.toBigNumber
 		}),
+		new web3._extend.Property({
+			name: 'syncingDetails',
+			getter: 'eth_syncingDetails'
+		}),
 	]
 });
 `
@@ -650,6 +915,32 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
 		}),
+		new web3._extend.Method({
+			name: 'simulateBundle',
+			call: 'miner_simulateBundle',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'feeRecipientSchedule',
+			call: 'miner_feeRecipientSchedule'
+		}),
+		new web3._extend.Method({
+			name: 'setFeeRecipientSchedule',
+			call: 'miner_setFeeRecipientSchedule',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'feeRecipientAt',
+			call: 'miner_feeRecipientAt',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'payloadReport',
+			call: 'miner_payloadReport',
+			params: 1
+		}),
 	],
 	properties: []
 });
@@ -704,11 +995,43 @@ web3._extend({
 				return status;
 			}
 		}),
+		new web3._extend.Property({
+			name: 'blobStatus',
+			getter: 'txpool_blobStatus'
+		}),
+		new web3._extend.Property({
+			name: 'replacementRules',
+			getter: 'txpool_replacementRules'
+		}),
+		new web3._extend.Property({
+			name: 'capacityReport',
+			getter: 'txpool_capacityReport'
+		}),
+		new web3._extend.Property({
+			name: 'spamScores',
+			getter: 'txpool_spamScores'
+		}),
+		new web3._extend.Method({
+			name: 'spamScore',
+			call: 'txpool_spamScore',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'contentFrom',
 			call: 'txpool_contentFrom',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'rebroadcast',
+			call: 'txpool_rebroadcast',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'evict',
+			call: 'txpool_evict',
+			params: 1
+		}),
 	]
 });
 `
@@ -728,6 +1051,213 @@ web3._extend({
 			call: 'dev_setFeeRecipient',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'dumpChain',
+			call: 'dev_dumpChain',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'loadChain',
+			call: 'dev_loadChain',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'applyFixture',
+			call: 'dev_applyFixture',
+			params: 1
+		}),
+	],
+});
+`
+
+const ExtJs = `
+web3._extend({
+	property: 'ext',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'getTokenTransfers',
+			call: 'ext_getTokenTransfers',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getInternalTransactions',
+			call: 'ext_getInternalTransactions',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getContractCreation',
+			call: 'ext_getContractCreation',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getCodeChanges',
+			call: 'ext_getCodeChanges',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getDecodedLogs',
+			call: 'ext_getDecodedLogs',
+			params: 1
+		}),
+	],
+});
+`
+
+const AttestJs = `
+web3._extend({
+	property: 'attest',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'submitAttestation',
+			call: 'attest_submitAttestation',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getHeadByNumber',
+			call: 'attest_getHeadByNumber',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getHeadByHash',
+			call: 'attest_getHeadByHash',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getHeaderByNumber',
+			call: 'attest_getHeaderByNumber',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getHeaderByHash',
+			call: 'attest_getHeaderByHash',
+			params: 1
+		}),
+	],
+	properties:
+	[
+		new web3._extend.Property({
+			name: 'head',
+			getter: 'attest_head'
+		}),
+		new web3._extend.Property({
+			name: 'blockNumber',
+			getter: 'attest_blockNumber',
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+	]
+});
+`
+
+const ExplorerJs = `
+web3._extend({
+	property: 'explorer',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'latestBlocks',
+			call: 'explorer_latestBlocks',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'txCount',
+			call: 'explorer_txCount',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'topGasConsumers',
+			call: 'explorer_topGasConsumers',
+			params: 3,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal, null]
+		}),
+		new web3._extend.Method({
+			name: 'addressSummary',
+			call: 'explorer_addressSummary',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+	]
+});
+`
+
+const PolicyJs = `
+web3._extend({
+	property: 'policy',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'add',
+			call: 'policy_add',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'remove',
+			call: 'policy_remove',
+			params: 1
+		}),
 	],
+	properties:
+	[
+		new web3._extend.Property({
+			name: 'status',
+			getter: 'policy_status'
+		}),
+	]
+});
+`
+
+const OverlayJs = `
+web3._extend({
+	property: 'overlay',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'create',
+			call: 'overlay_create',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'delete',
+			call: 'overlay_delete',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'sendTransaction',
+			call: 'overlay_sendTransaction',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputTransactionFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'mine',
+			call: 'overlay_mine',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'head',
+			call: 'overlay_head',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getTransactionReceipt',
+			call: 'overlay_getTransactionReceipt',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'call',
+			call: 'overlay_call',
+			params: 3,
+			inputFormatter: [null, web3._extend.formatters.inputCallFormatter, null]
+		}),
+	]
 });
 `
@@ -0,0 +1,110 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var errNoAccessList = errors.New("no access list for block")
+
+// TxAccessList is the access list of a transaction recorded during the block
+// building.
+type TxAccessList struct {
+	Hash       common.Hash      `json:"hash"`
+	AccessList types.AccessList `json:"accessList"`
+}
+
+// BlockAccessList contains the access lists of the transactions of a locally
+// built block, as recorded while executing them during the block building.
+//
+// The access lists follow the EIP-2930 conventions of eth_createAccessList: the
+// sender, the recipient and the precompiles are left out, being warm anyway.
+type BlockAccessList struct {
+	Hash         common.Hash     `json:"hash"`
+	Number       hexutil.Uint64  `json:"number"`
+	Transactions []*TxAccessList `json:"transactions"`
+}
+
+// accessListTracer creates a tracer recording the access list of the given
+// transaction executed in the environment.
+func (miner *Miner) accessListTracer(env *environment, tx *types.Transaction) *logger.AccessListTracer {
+	from, _ := types.Sender(env.signer, tx)
+	to := crypto.CreateAddress(from, tx.Nonce())
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+	excl := map[common.Address]struct{}{from: {}, to: {}}
+
+	rules := miner.chainConfig().Rules(env.header.Number, env.header.Difficulty.Sign() == 0, env.header.Time)
+	for _, addr := range vm.ActivePrecompiles(rules) {
+		excl[addr] = struct{}{}
+	}
+	return logger.NewAccessListTracer(nil, excl)
+}
+
+// writeAccessLists persists the access lists recorded for the transactions of
+// the locally built block, if the backend provides a database.
+func (miner *Miner) writeAccessLists(block *types.Block, lists []types.AccessList) {
+	b, ok := miner.backend.(BackendWithDatabase)
+	if !ok {
+		return
+	}
+	bal := &BlockAccessList{
+		Hash:         block.Hash(),
+		Number:       hexutil.Uint64(block.NumberU64()),
+		Transactions: make([]*TxAccessList, len(lists)),
+	}
+	for i, tx := range block.Transactions() {
+		bal.Transactions[i] = &TxAccessList{Hash: tx.Hash(), AccessList: lists[i]}
+	}
+	blob, err := json.Marshal(bal)
+	if err != nil {
+		log.Error("Failed to encode block access list", "hash", block.Hash(), "err", err)
+		return
+	}
+	rawdb.WriteBlockAccessList(b.ChainDb(), block.Hash(), blob)
+}
+
+// BlockAccessList retrieves the access lists recorded for the transactions of
+// the locally built block with the given hash.
+func (miner *Miner) BlockAccessList(hash common.Hash) (*BlockAccessList, error) {
+	b, ok := miner.backend.(BackendWithDatabase)
+	if !ok {
+		return nil, errNoPayloadDatabase
+	}
+	blob := rawdb.ReadBlockAccessList(b.ChainDb(), hash)
+	if len(blob) == 0 {
+		return nil, errNoAccessList
+	}
+	bal := new(BlockAccessList)
+	if err := json.Unmarshal(blob, bal); err != nil {
+		return nil, fmt.Errorf("invalid block access list: %w", err)
+	}
+	return bal, nil
+}
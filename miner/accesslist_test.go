@@ -0,0 +1,98 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestBlockAccessList(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	w, b := newTestWorker(t, ethashChainConfig, ethash.NewFaker(), db, 0)
+	w.config.RecordAccessLists = true
+
+	// Deploy a contract whose initcode reads the balance of an account and a
+	// storage slot of its own.
+	var (
+		target = common.HexToAddress("0xdead")
+		code   = append(append([]byte{byte(0x73)}, target.Bytes()...), 0x31, 0x50, 0x60, 0x01, 0x54, 0x50, 0x00)
+		tx     = types.MustSignNewTx(testBankKey, types.LatestSigner(ethashChainConfig), &types.LegacyTx{
+			Nonce:    0,
+			Gas:      100000,
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+			Data:     code,
+		})
+		created = crypto.CreateAddress(testBankAddress, 0)
+	)
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.Transactions = types.Transactions{tx}
+	payload, err := w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	block := payload.full
+
+	// Only the delivered block has its access lists recorded
+	if _, err := w.BlockAccessList(block.Hash()); !errors.Is(err, errNoAccessList) {
+		t.Fatalf("undelivered block access list recorded: %v", err)
+	}
+	payload.Resolve()
+
+	bal, err := w.BlockAccessList(block.Hash())
+	if err != nil {
+		t.Fatalf("failed to read block access list: %v", err)
+	}
+	if bal.Hash != block.Hash() || uint64(bal.Number) != block.NumberU64() || len(bal.Transactions) != 1 {
+		t.Fatalf("wrong block access list: hash %x, number %d, txs %d", bal.Hash, bal.Number, len(bal.Transactions))
+	}
+	if bal.Transactions[0].Hash != tx.Hash() {
+		t.Fatalf("wrong transaction hash: have %x, want %x", bal.Transactions[0].Hash, tx.Hash())
+	}
+	want := types.AccessList{
+		{Address: target, StorageKeys: []common.Hash{}},
+		{Address: created, StorageKeys: []common.Hash{common.BigToHash(common.Big1)}},
+	}
+	have := bal.Transactions[0].AccessList
+	if len(have) != len(want) {
+		t.Fatalf("wrong access list: have %v, want %v", have, want)
+	}
+	for _, tuple := range want {
+		var found bool
+		for _, h := range have {
+			if h.Address == tuple.Address && len(h.StorageKeys) == len(tuple.StorageKeys) && (len(h.StorageKeys) == 0 || h.StorageKeys[0] == tuple.StorageKeys[0]) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("missing access list entry %v: have %v", tuple, have)
+		}
+	}
+	// Access lists are pruned along with their block.
+	rawdb.DeleteBlock(db, block.Hash(), block.NumberU64())
+	if _, err := w.BlockAccessList(block.Hash()); !errors.Is(err, errNoAccessList) {
+		t.Fatalf("wrong error for pruned block: %v", err)
+	}
+}
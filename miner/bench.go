@@ -0,0 +1,124 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BenchRun is the outcome of a single benchmarked block building.
+type BenchRun struct {
+	Elapsed time.Duration // Wall time of the block building
+	Txs     int           // Number of transactions included
+	GasUsed uint64        // Gas used by the built block
+	Hash    common.Hash   // Hash of the built block
+}
+
+// BenchResult is the outcome of benchmarking the block building of a recorded
+// payload.
+type BenchResult struct {
+	Parent     common.Hash // Parent the payload was built on
+	Candidates int         // Number of transactions in the txpool snapshot
+	Runs       []BenchRun  // Measured runs, in execution order
+}
+
+// BenchPayload builds the recorded payload from its txpool snapshot the given
+// number of times, after the requested number of unmeasured warmup runs, and
+// measures each building. Unlike RebuildPayload, the current miner settings are
+// used instead of the recorded ones, so that the effect of tuning them can be
+// compared on identical inputs.
+func (miner *Miner) BenchPayload(record *PayloadRecord, runs, warmup int) (*BenchResult, error) {
+	if runs <= 0 {
+		return nil, errors.New("no benchmark runs requested")
+	}
+	if miner.chain.GetHeaderByHash(record.Parent) == nil {
+		return nil, fmt.Errorf("parent block %#x not found", record.Parent)
+	}
+	result := &BenchResult{
+		Parent:     record.Parent,
+		Candidates: len(record.Pool),
+	}
+	for i := 0; i < warmup+runs; i++ {
+		start := time.Now()
+		r := miner.generateWork(record.params(), false)
+		elapsed := time.Since(start)
+		if r.err != nil {
+			return nil, fmt.Errorf("failed to build block: %w", r.err)
+		}
+		if i < warmup {
+			continue
+		}
+		result.Runs = append(result.Runs, BenchRun{
+			Elapsed: elapsed,
+			Txs:     len(r.block.Transactions()),
+			GasUsed: r.block.GasUsed(),
+			Hash:    r.block.Hash(),
+		})
+	}
+	return result, nil
+}
+
+// Latency returns the p-th percentile (0-100) of the building times, using the
+// nearest-rank method.
+func (r *BenchResult) Latency(p float64) time.Duration {
+	if len(r.Runs) == 0 {
+		return 0
+	}
+	elapsed := make([]time.Duration, len(r.Runs))
+	for i, run := range r.Runs {
+		elapsed[i] = run.Elapsed
+	}
+	slices.Sort(elapsed)
+
+	rank := int(math.Ceil(p / 100 * float64(len(elapsed))))
+	return elapsed[min(max(rank, 1), len(elapsed))-1]
+}
+
+// Throughput returns the gas and transactions processed per second over all
+// the measured runs.
+func (r *BenchResult) Throughput() (gasPerSec float64, txsPerSec float64) {
+	var (
+		elapsed time.Duration
+		gas     uint64
+		txs     int
+	)
+	for _, run := range r.Runs {
+		elapsed += run.Elapsed
+		gas += run.GasUsed
+		txs += run.Txs
+	}
+	if elapsed == 0 {
+		return 0, 0
+	}
+	return float64(gas) / elapsed.Seconds(), float64(txs) / elapsed.Seconds()
+}
+
+// Deterministic reports whether all the measured runs built the same block.
+func (r *BenchResult) Deterministic() bool {
+	for _, run := range r.Runs {
+		if run.Hash != r.Runs[0].Hash {
+			return false
+		}
+	}
+	return true
+}
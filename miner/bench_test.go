@@ -0,0 +1,84 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+func TestBenchPayload(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	w, b := newTestWorker(t, ethashChainConfig, ethash.NewFaker(), db, 0)
+	w.config.RecordPayloads = true
+	b.txPool.Add(genTxs(1, 8), true)
+
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.NoTxPool = false
+	payload, err := w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	payload.WaitFull()
+	payload.ResolveFull()
+	block := payload.full
+
+	record, err := w.PayloadRecord(block.Hash())
+	if err != nil {
+		t.Fatalf("failed to read payload record: %v", err)
+	}
+	if _, err := w.BenchPayload(record, 0, 0); err == nil {
+		t.Fatal("benchmark without runs accepted")
+	}
+	res, err := w.BenchPayload(record, 4, 1)
+	if err != nil {
+		t.Fatalf("failed to benchmark payload: %v", err)
+	}
+	if res.Candidates != 9 || len(res.Runs) != 4 {
+		t.Fatalf("wrong benchmark result: %d candidates, %d runs", res.Candidates, len(res.Runs))
+	}
+	if !res.Deterministic() || res.Runs[0].Hash != block.Hash() || res.Runs[0].Txs != 9 {
+		t.Fatalf("benchmark built a different block: %x, %d txs", res.Runs[0].Hash, res.Runs[0].Txs)
+	}
+	if gas, txs := res.Throughput(); gas <= 0 || txs <= 0 {
+		t.Fatalf("no throughput measured: %v gas/s, %v txs/s", gas, txs)
+	}
+	// The miner settings apply: without any gas, nothing is included.
+	w.SetGasCeil(0)
+	w.config.EffectiveGasCeil = 1
+	if res, err = w.BenchPayload(record, 1, 0); err != nil {
+		t.Fatalf("failed to benchmark payload: %v", err)
+	}
+	if res.Runs[0].Txs != 0 {
+		t.Fatalf("miner settings not applied: %d txs included", res.Runs[0].Txs)
+	}
+}
+
+func TestBenchLatency(t *testing.T) {
+	res := new(BenchResult)
+	for _, ms := range []int{5, 1, 4, 2, 3, 10, 6, 9, 8, 7} {
+		res.Runs = append(res.Runs, BenchRun{Elapsed: time.Duration(ms) * time.Millisecond})
+	}
+	for p, want := range map[float64]time.Duration{0: 1, 10: 1, 50: 5, 90: 9, 95: 10, 100: 10} {
+		if have := res.Latency(p); have != want*time.Millisecond {
+			t.Errorf("p%v: have %v, want %v", p, have, want*time.Millisecond)
+		}
+	}
+}
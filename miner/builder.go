@@ -0,0 +1,339 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	// ErrBuilderPayloadsDisabled is returned when an externally built payload is
+	// submitted while the miner does not accept them.
+	ErrBuilderPayloadsDisabled = errors.New("builder payloads disabled")
+
+	// ErrBuilderUnauthorized is returned when an externally built payload is
+	// submitted by a builder missing from the allow-list.
+	ErrBuilderUnauthorized = errors.New("builder not allowed")
+
+	errBuilderCensoring = errors.New("builder payload omits prioritized transaction")
+
+	errPayloadDelivered = errors.New("payload already delivered")
+	errPayloadNoTxPool  = errors.New("payload restricted to the forced transactions")
+	errPayloadWitness   = errors.New("payload requires a witness")
+)
+
+var (
+	builderAcceptedMeter     = metrics.NewRegisteredMeter("miner/builder/accepted", nil)
+	builderRejectedMeter     = metrics.NewRegisteredMeter("miner/builder/rejected", nil)
+	builderSelectedMeter     = metrics.NewRegisteredMeter("miner/builder/selected", nil)
+	builderUnauthorizedMeter = metrics.NewRegisteredMeter("miner/builder/unauthorized", nil)
+	builderCensoringMeter    = metrics.NewRegisteredMeter("miner/builder/censoring", nil)
+	builderOutbidMeter       = metrics.NewRegisteredMeter("miner/builder/outbid", nil)
+	builderBelowDeltaMeter   = metrics.NewRegisteredMeter("miner/builder/belowdelta", nil)
+)
+
+// Decisions taken on valid builder payloads.
+const (
+	BuilderSelected   = "selected"   // The payload is delivered unless outbid later
+	BuilderOutbid     = "outbid"     // A more valuable payload is known
+	BuilderBelowDelta = "belowdelta" // The payload doesn't pay enough more than the local one
+)
+
+// BuilderPolicy configures the choice between the locally built payloads and
+// the ones submitted by external builders. The local payload is kept as the
+// fallback unless a builder payload beats it by the minimum delta.
+type BuilderPolicy struct {
+	MinDelta  *big.Int // Fees a builder payload has to pay on top of the local one to be selected
+	Builders  []string // Credentials of the builders allowed to submit payloads, any if empty, see rpc.PeerInfo.AuthCredentials
+	Inclusion bool     // Reject builder payloads omitting prioritized transactions of the local payload
+}
+
+// Validate checks the minimum value delta of the builder policy.
+func (p *BuilderPolicy) Validate() error {
+	if p.MinDelta != nil && p.MinDelta.Sign() < 0 {
+		return errors.New("builder payload minimum delta must not be negative")
+	}
+	return nil
+}
+
+// allowed reports whether a builder authenticated with the given credentials may
+// submit payloads. Only verified credentials are matched, never identities the
+// builder merely asserts, as any holder of a shared secret could claim those.
+func (p *BuilderPolicy) allowed(credentials []string) bool {
+	if len(p.Builders) == 0 {
+		return true
+	}
+	for _, credential := range credentials {
+		if slices.Contains(p.Builders, credential) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuilderPayloadResult is the outcome of submitting an externally built payload.
+type BuilderPayloadResult struct {
+	Value    *big.Int // Priority fees paid by the payload, as compared among local payloads
+	Decision string   // Decision taken on the payload, see the Builder* constants
+}
+
+// SubmitBuilderPayload validates a block built by an external builder for the
+// given locally built payload, and selects it for delivery if it pays more fees
+// than the best block built so far. Local updates of the payload keep competing
+// with the selected block on the same basis.
+//
+// The block has to be built on the payload attributes, starting with the forced
+// transactions, and is fully executed on top of its parent. The builder policy,
+// if any, further restricts the selection. The builder is identified by the
+// credentials it authenticated with.
+func (miner *Miner) SubmitBuilderPayload(payload *Payload, block *types.Block, builder []string) (*BuilderPayloadResult, error) {
+	if !miner.config.BuilderPayloads {
+		return nil, ErrBuilderPayloadsDisabled
+	}
+	policy := miner.config.BuilderPolicy
+	if policy == nil {
+		policy = new(BuilderPolicy)
+	}
+	if !policy.allowed(builder) {
+		builderUnauthorizedMeter.Mark(1)
+		log.Info("Rejected builder payload", "id", payload.id, "hash", block.Hash(), "builder", builder, "decision", "unauthorized")
+		return nil, ErrBuilderUnauthorized
+	}
+	value, requests, err := miner.validateBuilderPayload(payload, block, policy)
+	if err != nil {
+		if errors.Is(err, errBuilderCensoring) {
+			builderCensoringMeter.Mark(1)
+		}
+		builderRejectedMeter.Mark(1)
+		log.Info("Rejected builder payload", "id", payload.id, "hash", block.Hash(), "builder", builder, "decision", "invalid", "err", err)
+		return nil, err
+	}
+	builderAcceptedMeter.Mark(1)
+
+	decision, err := payload.submit(block, value, requests, policy.MinDelta)
+	if err != nil {
+		return nil, err
+	}
+	switch decision {
+	case BuilderSelected:
+		builderSelectedMeter.Mark(1)
+	case BuilderOutbid:
+		builderOutbidMeter.Mark(1)
+	case BuilderBelowDelta:
+		builderBelowDeltaMeter.Mark(1)
+	}
+	log.Info("Accepted builder payload", "id", payload.id, "number", block.NumberU64(), "hash", block.Hash(), "builder", builder,
+		"txs", len(block.Transactions()), "gas", block.GasUsed(), "value", value, "decision", decision)
+	return &BuilderPayloadResult{Value: value, Decision: decision}, nil
+}
+
+// validateBuilderPayload checks that the block matches the attributes of the
+// payload and executes it, returning the fees paid by the block and its
+// consensus layer requests.
+func (miner *Miner) validateBuilderPayload(payload *Payload, block *types.Block, policy *BuilderPolicy) (*big.Int, [][]byte, error) {
+	args := payload.args
+	if args == nil || args.NoTxPool {
+		return nil, nil, errPayloadNoTxPool
+	}
+	if payload.witness {
+		return nil, nil, errPayloadWitness
+	}
+	// Derive the header the local payload is built with, the builder has to use
+	// the same block environment.
+	work, err := miner.prepareWork(&generateParams{
+		timestamp:     args.Timestamp,
+		forceTime:     true,
+		parentHash:    args.Parent,
+		coinbase:      args.FeeRecipient,
+		random:        args.Random,
+		withdrawals:   args.Withdrawals,
+		noTxs:         true,
+		gasLimit:      args.GasLimit,
+		eip1559Params: args.EIP1559Params,
+	}, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkBuilderHeader(work.header, block.Header(), miner.chainConfig().Optimism != nil); err != nil {
+		return nil, nil, err
+	}
+	if args.Withdrawals != nil || block.Withdrawals() != nil {
+		if have, want := types.DeriveSha(block.Withdrawals(), trie.NewStackTrie(nil)), types.DeriveSha(args.Withdrawals, trie.NewStackTrie(nil)); have != want {
+			return nil, nil, fmt.Errorf("withdrawals mismatch: have %x, want %x", have, want)
+		}
+	}
+	txs := block.Transactions()
+	if len(txs) < len(args.Transactions) {
+		return nil, nil, fmt.Errorf("missing forced transactions: have %d, want %d", len(txs), len(args.Transactions))
+	}
+	for i, tx := range args.Transactions {
+		if txs[i].Hash() != tx.Hash() {
+			return nil, nil, fmt.Errorf("forced transaction %d mismatch: have %x, want %x", i, txs[i].Hash(), tx.Hash())
+		}
+	}
+	// The builder has no way to deliver the blob sidecars along the block
+	for i, tx := range txs {
+		if tx.Type() == types.BlobTxType {
+			return nil, nil, fmt.Errorf("blob transaction %d not supported", i)
+		}
+	}
+	if policy.Inclusion {
+		if err := miner.checkInclusion(payload, block); err != nil {
+			return nil, nil, err
+		}
+	}
+	// Execute the block on a fresh copy of the parent state and verify the
+	// results against the header.
+	if err := miner.engine.VerifyHeader(miner.chain, block.Header()); err != nil {
+		return nil, nil, err
+	}
+	if err := miner.chain.Validator().ValidateBody(block); err != nil {
+		return nil, nil, err
+	}
+	parent := miner.chain.GetHeaderByHash(block.ParentHash())
+	if parent == nil {
+		return nil, nil, fmt.Errorf("missing parent %v", block.ParentHash())
+	}
+	env, err := miner.makeEnv(parent, block.Header(), work.coinbase, false, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := miner.chain.Processor().Process(block, env.state, vm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := miner.chain.Validator().ValidateState(block, env.state, res, false); err != nil {
+		return nil, nil, err
+	}
+	return totalFees(block, res.Receipts), res.Requests, nil
+}
+
+// checkBuilderHeader checks that the header of an externally built block uses
+// the environment of the locally built payload. On OP Stack chains the
+// extra-data must match too, since verifiers derive the block with the local
+// one.
+func checkBuilderHeader(want, have *types.Header, optimism bool) error {
+	switch {
+	case have.ParentHash != want.ParentHash:
+		return fmt.Errorf("parent mismatch: have %x, want %x", have.ParentHash, want.ParentHash)
+	case have.Coinbase != want.Coinbase:
+		return fmt.Errorf("fee recipient mismatch: have %x, want %x", have.Coinbase, want.Coinbase)
+	case have.Number.Cmp(want.Number) != 0:
+		return fmt.Errorf("number mismatch: have %v, want %v", have.Number, want.Number)
+	case have.Time != want.Time:
+		return fmt.Errorf("timestamp mismatch: have %d, want %d", have.Time, want.Time)
+	case have.MixDigest != want.MixDigest:
+		return fmt.Errorf("random mismatch: have %x, want %x", have.MixDigest, want.MixDigest)
+	case have.GasLimit != want.GasLimit:
+		return fmt.Errorf("gas limit mismatch: have %d, want %d", have.GasLimit, want.GasLimit)
+	case !equalBig(have.BaseFee, want.BaseFee):
+		return fmt.Errorf("base fee mismatch: have %v, want %v", have.BaseFee, want.BaseFee)
+	case optimism && string(have.Extra) != string(want.Extra):
+		return fmt.Errorf("extra-data mismatch: have %x, want %x", have.Extra, want.Extra)
+	}
+	return nil
+}
+
+// checkInclusion checks that the block includes the transactions of the
+// prioritized senders the best local block of the payload includes, if any.
+func (miner *Miner) checkInclusion(payload *Payload, block *types.Block) error {
+	local := payload.localBlock()
+	if local == nil {
+		return nil
+	}
+	miner.confMu.RLock()
+	prio := miner.prio
+	miner.confMu.RUnlock()
+
+	if len(prio) == 0 {
+		return nil
+	}
+	included := make(map[common.Hash]struct{}, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		included[tx.Hash()] = struct{}{}
+	}
+	signer := types.MakeSigner(miner.chainConfig(), local.Number(), local.Time())
+	for _, tx := range local.Transactions() {
+		if _, ok := included[tx.Hash()]; ok {
+			continue
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		if slices.Contains(prio, from) {
+			return fmt.Errorf("%w: %x from %x", errBuilderCensoring, tx.Hash(), from)
+		}
+	}
+	return nil
+}
+
+func equalBig(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+// submit offers an externally built block for the payload, selecting it if it
+// pays more fees than the best block known, and at least the minimum delta more
+// than the best local block. It returns the decision taken on the block.
+func (payload *Payload) submit(block *types.Block, value *big.Int, requests [][]byte, delta *big.Int) (string, error) {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	select {
+	case <-payload.stop:
+		return "", errPayloadDelivered
+	default:
+	}
+	if payload.full != nil && value.Cmp(payload.fullFees) <= 0 {
+		return BuilderOutbid, nil
+	}
+	if payload.local != nil && delta != nil && value.Cmp(new(big.Int).Add(payload.localFees, delta)) < 0 {
+		return BuilderBelowDelta, nil
+	}
+	payload.full = block
+	payload.fullFees = value
+	payload.fullDelta = delta
+	payload.sidecars = nil
+	payload.requests = requests
+	payload.fullWitness = nil
+	payload.record = nil
+	payload.accessLists = nil
+	payload.cond.Broadcast()
+	return BuilderSelected, nil
+}
+
+// localBlock returns the best locally built block of the payload, nil if none
+// was built yet.
+func (payload *Payload) localBlock() *types.Block {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	return payload.local
+}
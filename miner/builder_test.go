@@ -0,0 +1,202 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSubmitBuilderPayload(t *testing.T) {
+	t.Parallel()
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.NoTxPool = false
+	payload, err := w.BuildPayload(args, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	payload.WaitFull()
+
+	// build creates a block on the payload attributes with the given transactions.
+	build := func(timestamp uint64, txs ...*types.Transaction) *types.Block {
+		t.Helper()
+		r := w.generateWork(&generateParams{
+			timestamp:  timestamp,
+			forceTime:  true,
+			parentHash: args.Parent,
+			coinbase:   args.FeeRecipient,
+			noTxs:      true,
+			txs:        txs,
+		}, false)
+		if r.err != nil {
+			t.Fatalf("Failed to build block: %v", r.err)
+		}
+		return r.block
+	}
+	tx := types.MustSignNewTx(testBankKey, types.LatestSigner(params.TestChainConfig), &types.LegacyTx{
+		Nonce:    0,
+		To:       &testUserAddress,
+		Value:    big.NewInt(1000),
+		Gas:      params.TxGas,
+		GasPrice: new(big.Int).Add(big.NewInt(params.InitialBaseFee), big.NewInt(params.GWei)),
+	})
+	block := build(args.Timestamp, tx)
+
+	if _, err := w.SubmitBuilderPayload(payload, block, nil); !errors.Is(err, ErrBuilderPayloadsDisabled) {
+		t.Fatalf("Unexpected submission result, want %v, got %v", ErrBuilderPayloadsDisabled, err)
+	}
+	w.config.BuilderPayloads = true
+
+	// Blocks deviating from the payload attributes are rejected.
+	if _, err := w.SubmitBuilderPayload(payload, build(args.Timestamp+1, tx), nil); err == nil {
+		t.Fatal("Block with wrong timestamp accepted")
+	}
+	// A block paying less than the local one is valid, but not selected.
+	res, err := w.SubmitBuilderPayload(payload, build(args.Timestamp), nil)
+	if err != nil {
+		t.Fatalf("Failed to submit empty block: %v", err)
+	}
+	if res.Decision != BuilderOutbid || res.Value.Sign() != 0 {
+		t.Fatalf("Unexpected empty block result: decision %v, value %v", res.Decision, res.Value)
+	}
+	// A more valuable block is selected for delivery.
+	res, err = w.SubmitBuilderPayload(payload, block, nil)
+	if err != nil {
+		t.Fatalf("Failed to submit block: %v", err)
+	}
+	tip := new(big.Int).Sub(tx.GasPrice(), block.BaseFee())
+	if want := new(big.Int).Mul(tip, big.NewInt(int64(params.TxGas))); res.Decision != BuilderSelected || res.Value.Cmp(want) != 0 {
+		t.Fatalf("Unexpected block result: decision %v, value %v, want %v", res.Decision, res.Value, want)
+	}
+	if hash := payload.ResolveFull().ExecutionPayload.BlockHash; hash != block.Hash() {
+		t.Fatalf("Unexpected delivered payload: have %x, want %x", hash, block.Hash())
+	}
+	// Delivered payloads can't be replaced anymore.
+	if _, err := w.SubmitBuilderPayload(payload, block, nil); !errors.Is(err, errPayloadDelivered) {
+		t.Fatalf("Unexpected submission result, want %v, got %v", errPayloadDelivered, err)
+	}
+}
+
+func TestBuilderPolicy(t *testing.T) {
+	t.Parallel()
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	w.config.BuilderPayloads = true
+	w.config.BuilderPolicy = &BuilderPolicy{
+		MinDelta:  big.NewInt(params.Ether),
+		Builders:  []string{"jwt:builder"},
+		Inclusion: true,
+	}
+	w.SetPrioAddresses([]common.Address{testBankAddress})
+
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.NoTxPool = false
+	payload, err := w.BuildPayload(args, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	payload.WaitFull()
+
+	build := func(txs ...*types.Transaction) *types.Block {
+		t.Helper()
+		r := w.generateWork(&generateParams{
+			timestamp:  args.Timestamp,
+			forceTime:  true,
+			parentHash: args.Parent,
+			coinbase:   args.FeeRecipient,
+			noTxs:      true,
+			txs:        txs,
+		}, false)
+		if r.err != nil {
+			t.Fatalf("Failed to build block: %v", r.err)
+		}
+		return r.block
+	}
+	signer := types.LatestSigner(params.TestChainConfig)
+	transfer := func(nonce uint64) *types.Transaction {
+		return types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &testUserAddress,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: new(big.Int).Add(big.NewInt(params.InitialBaseFee), big.NewInt(params.GWei)),
+		})
+	}
+	block := build(pendingTxs[0], transfer(1))
+
+	if _, err := w.SubmitBuilderPayload(payload, block, []string{"jwt:other", "tls:builder"}); !errors.Is(err, ErrBuilderUnauthorized) {
+		t.Fatalf("Unexpected submission result, want %v, got %v", ErrBuilderUnauthorized, err)
+	}
+	// The local payload includes the transaction of the prioritized sender.
+	if _, err := w.SubmitBuilderPayload(payload, build(transfer(0)), []string{"jwt:builder"}); !errors.Is(err, errBuilderCensoring) {
+		t.Fatalf("Unexpected submission result, want %v, got %v", errBuilderCensoring, err)
+	}
+	// The local payload is kept unless beaten by the minimum delta.
+	res, err := w.SubmitBuilderPayload(payload, block, []string{"jwt:builder"})
+	if err != nil {
+		t.Fatalf("Failed to submit block: %v", err)
+	}
+	if res.Decision != BuilderBelowDelta {
+		t.Fatalf("Unexpected decision, want %v, got %v", BuilderBelowDelta, res.Decision)
+	}
+	w.config.BuilderPolicy.MinDelta = big.NewInt(params.GWei)
+	if res, err = w.SubmitBuilderPayload(payload, block, []string{"jwt:builder"}); err != nil {
+		t.Fatalf("Failed to submit block: %v", err)
+	}
+	if res.Decision != BuilderSelected {
+		t.Fatalf("Unexpected decision, want %v, got %v", BuilderSelected, res.Decision)
+	}
+	// A local rebuild closing the gap below the minimum delta displaces the
+	// selected builder payload, even if it doesn't beat it outright.
+	payload.lock.Lock()
+	local, fees := payload.local, new(big.Int).Sub(payload.fullFees, common.Big1)
+	payload.lock.Unlock()
+
+	payload.update(&newPayloadResult{block: local, fees: fees}, 0)
+
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+	if payload.full != local {
+		t.Fatal("Builder payload kept despite falling below the minimum delta")
+	}
+}
+
+// Tests that builder headers have to match the local extra-data on OP Stack
+// chains only, before Holocene as well.
+func TestCheckBuilderHeaderExtra(t *testing.T) {
+	want := &types.Header{Number: big.NewInt(1)}
+	have := &types.Header{Number: big.NewInt(1), Extra: []byte("builder")}
+
+	if err := checkBuilderHeader(want, have, false); err != nil {
+		t.Fatalf("builder extra-data rejected without Optimism: %v", err)
+	}
+	if err := checkBuilderHeader(want, have, true); err == nil {
+		t.Fatal("builder extra-data accepted on OP Stack chain")
+	}
+	have.Extra = nil
+	if err := checkBuilderHeader(want, have, true); err != nil {
+		t.Fatalf("matching extra-data rejected: %v", err)
+	}
+}
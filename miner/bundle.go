@@ -0,0 +1,153 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var errBundleBlobSidecar = errors.New("blob transaction without sidecar")
+
+// BundleOverrides are the optional block parameters of a bundle simulation,
+// replacing the ones the miner would use for the pending block.
+type BundleOverrides struct {
+	Timestamp    *uint64
+	FeeRecipient *common.Address
+	GasLimit     *uint64
+	Random       *common.Hash
+}
+
+// BundleTxResult is the outcome of simulating a single bundle transaction.
+type BundleTxResult struct {
+	Tx           *types.Transaction
+	Receipt      *types.Receipt // Receipt of the transaction, nil if it could not be included
+	Err          error          // Reason why the transaction could not be included
+	CoinbaseDiff *big.Int       // Balance change of the fee recipient caused by the transaction
+}
+
+// BundleResult is the outcome of a bundle simulation.
+type BundleResult struct {
+	Header       *types.Header     // Header of the simulated block, after the bundle
+	Coinbase     common.Address    // Fee recipient of the simulated block
+	Txs          []*BundleTxResult // Results of the bundle transactions, in order
+	GasUsed      uint64            // Total gas used by the included bundle transactions
+	CoinbaseDiff *big.Int          // Balance change of the fee recipient caused by the bundle
+}
+
+// SimulateBundle runs the given transactions through the block building code
+// path on top of the pending block: the pending block is assembled the same way
+// as for getPending, then the bundle is committed after the pool transactions
+// with the checks applied to any transaction during block building, such as the
+// transaction conditionals, the interop checks and the L1 data fee charging.
+//
+// Transactions failing to be included are reported and skipped, the following
+// ones are still simulated. Nothing is persisted or broadcast.
+func (miner *Miner) SimulateBundle(ctx context.Context, txs types.Transactions, overrides *BundleOverrides) (*BundleResult, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("empty bundle")
+	}
+	parent := miner.chain.CurrentHeader()
+	genParams := &generateParams{
+		timestamp:  uint64(time.Now().Unix()),
+		parentHash: parent.Hash(),
+		coinbase:   miner.config.PendingFeeRecipient,
+		rpcCtx:     ctx,
+	}
+	if overrides != nil {
+		if overrides.Timestamp != nil {
+			genParams.timestamp, genParams.forceTime = *overrides.Timestamp, true
+		}
+		if overrides.FeeRecipient != nil {
+			genParams.coinbase = *overrides.FeeRecipient
+		}
+		if overrides.GasLimit != nil {
+			genParams.gasLimit = overrides.GasLimit
+		}
+		if overrides.Random != nil {
+			genParams.random = *overrides.Random
+		}
+	}
+	// Holocene blocks need the EIP-1559 parameters provided by the rollup node,
+	// carry over the ones of the parent, or the chain defaults before activation.
+	if miner.chainConfig().IsHolocene(genParams.timestamp) {
+		if miner.chainConfig().IsHolocene(parent.Time) {
+			genParams.eip1559Params = eip1559.EncodeHolocene1559Params(eip1559.DecodeHoloceneExtraData(parent.Extra))
+		} else {
+			genParams.eip1559Params = make([]byte, 8)
+		}
+	}
+	work, err := miner.prepareWork(genParams, false)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := work.header.GasLimit
+	if limit := miner.config.EffectiveGasCeil; limit != 0 && limit < gasLimit {
+		gasLimit = limit
+	}
+	work.gasPool = new(core.GasPool).AddGas(gasLimit)
+	misc.EnsureCreate2Deployer(miner.chainConfig(), work.header.Time, work.state)
+
+	// Assemble the pending block the bundle is simulated on top of. Rollups not
+	// computing a pending block serve the latest state as pending.
+	if miner.chainConfig().Optimism == nil || miner.config.RollupComputePendingBlock {
+		interrupt := new(atomic.Int32)
+		timer := time.AfterFunc(max(minRecommitInterruptInterval, miner.config.Recommit), func() {
+			interrupt.Store(commitInterruptTimeout)
+		})
+		err := miner.fillTransactions(interrupt, work)
+		timer.Stop()
+		if err != nil && !errors.Is(err, errBlockInterruptedByTimeout) {
+			return nil, err
+		}
+	}
+	var (
+		coinbase = work.coinbase
+		initial  = work.state.GetBalance(coinbase).ToBig()
+		gasUsed  = work.header.GasUsed
+		result   = &BundleResult{Txs: make([]*BundleTxResult, 0, len(txs))}
+	)
+	for _, tx := range txs {
+		before := work.state.GetBalance(coinbase).ToBig()
+
+		work.state.SetTxContext(tx.Hash(), work.tcount)
+		res := &BundleTxResult{Tx: tx}
+		if tx.Type() == types.BlobTxType && tx.BlobTxSidecar() == nil {
+			res.Err = errBundleBlobSidecar
+		} else if err := miner.commitTransaction(work, tx); err != nil {
+			res.Err = err
+		} else {
+			res.Receipt = work.receipts[len(work.receipts)-1]
+		}
+		res.CoinbaseDiff = new(big.Int).Sub(work.state.GetBalance(coinbase).ToBig(), before)
+		result.Txs = append(result.Txs, res)
+	}
+	result.Header = types.CopyHeader(work.header)
+	result.Coinbase = coinbase
+	result.GasUsed = work.header.GasUsed - gasUsed
+	result.CoinbaseDiff = new(big.Int).Sub(work.state.GetBalance(coinbase).ToBig(), initial)
+	return result, nil
+}
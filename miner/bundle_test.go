@@ -0,0 +1,83 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSimulateBundle(t *testing.T) {
+	var (
+		db        = rawdb.NewMemoryDatabase()
+		miner, be = newTestWorker(t, ethashChainConfig, ethash.NewFaker(), db, 0)
+		signer    = types.LatestSigner(ethashChainConfig)
+		timestamp = be.chain.CurrentHeader().Time + 12
+		overrides = &BundleOverrides{Timestamp: &timestamp, FeeRecipient: &testRecipient}
+	)
+	// The pool transaction with nonce 0 lands in the pending block first, so a
+	// bundle transaction with the same nonce fails and the next one succeeds.
+	stale := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+		Nonce:    0,
+		To:       &testUserAddress,
+		Value:    big.NewInt(1),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+	})
+	res, err := miner.SimulateBundle(context.Background(), types.Transactions{stale, newTxs[0]}, overrides)
+	if err != nil {
+		t.Fatalf("failed to simulate bundle: %v", err)
+	}
+	if res.Header.Time != timestamp || res.Coinbase != testRecipient {
+		t.Fatalf("block overrides not applied: time %d, coinbase %x", res.Header.Time, res.Coinbase)
+	}
+	if len(res.Txs) != 2 {
+		t.Fatalf("wrong number of results: have %d, want 2", len(res.Txs))
+	}
+	if !errors.Is(res.Txs[0].Err, core.ErrNonceTooLow) || res.Txs[0].Receipt != nil {
+		t.Fatalf("stale transaction not rejected: %v", res.Txs[0].Err)
+	}
+	if res.Txs[0].CoinbaseDiff.Sign() != 0 {
+		t.Fatalf("rejected transaction paid the fee recipient: %v", res.Txs[0].CoinbaseDiff)
+	}
+	receipt := res.Txs[1].Receipt
+	if res.Txs[1].Err != nil || receipt == nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("bundle transaction failed: %v", res.Txs[1].Err)
+	}
+	if receipt.TransactionIndex != 1 || receipt.GasUsed != params.TxGas || res.GasUsed != params.TxGas {
+		t.Fatalf("wrong receipt: index %d, gas %d, bundle gas %d", receipt.TransactionIndex, receipt.GasUsed, res.GasUsed)
+	}
+	tip := new(big.Int).Sub(big.NewInt(params.InitialBaseFee), res.Header.BaseFee)
+	if want := tip.Mul(tip, big.NewInt(int64(params.TxGas))); res.Txs[1].CoinbaseDiff.Cmp(want) != 0 || res.CoinbaseDiff.Cmp(want) != 0 {
+		t.Fatalf("wrong coinbase profit: have %v (bundle %v), want %v", res.Txs[1].CoinbaseDiff, res.CoinbaseDiff, want)
+	}
+	// Nothing must be persisted by the simulation.
+	if head := be.chain.CurrentHeader(); head.Number.Sign() != 0 {
+		t.Fatalf("chain head moved to #%d", head.Number)
+	}
+	if _, err := miner.SimulateBundle(context.Background(), nil, nil); err == nil {
+		t.Fatal("empty bundle accepted")
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxFeeRecipients is the maximum number of recipients of a fee recipient
+// schedule.
+const maxFeeRecipients = 256
+
+// FeeRecipientShare is a recipient of a fee recipient schedule along with its
+// weight, the number of consecutive periods it receives the fees of.
+type FeeRecipientShare struct {
+	Address common.Address `json:"address"`
+	Weight  uint64         `json:"weight"`
+}
+
+// FeeRecipientSchedule rotates the fee recipient of the locally built blocks
+// among weighted recipients, instead of using the one requested by the
+// consensus client. The chain is split in periods of Period blocks and each
+// recipient in turn receives the fees of as many periods as its weight.
+//
+// The selection only depends on the block number, so every node configured
+// with the same schedule selects the same recipient. Schedules are not
+// supported on OP Stack chains: the fee recipient is part of the payload
+// attributes, which the rollup node derives from L1 and checks the unsafe
+// blocks against, so the rotation belongs to its attribute building.
+type FeeRecipientSchedule struct {
+	Period     uint64              `json:"period"`
+	Recipients []FeeRecipientShare `json:"recipients"`
+}
+
+// Validate checks the schedule for a positive period and at least one
+// recipient, all of them with a positive weight.
+func (s *FeeRecipientSchedule) Validate() error {
+	if s.Period == 0 {
+		return errors.New("fee recipient period must be positive")
+	}
+	if len(s.Recipients) == 0 {
+		return errors.New("no fee recipients")
+	}
+	if len(s.Recipients) > maxFeeRecipients {
+		return fmt.Errorf("too many fee recipients, %d > %d", len(s.Recipients), maxFeeRecipients)
+	}
+	var total uint64
+	for _, r := range s.Recipients {
+		if r.Weight == 0 {
+			return fmt.Errorf("fee recipient %v has zero weight", r.Address)
+		}
+		if total > math.MaxUint64-r.Weight {
+			return errors.New("fee recipient weights overflow")
+		}
+		total += r.Weight
+	}
+	return nil
+}
+
+// Recipient returns the fee recipient of the block with the given number.
+func (s *FeeRecipientSchedule) Recipient(number uint64) common.Address {
+	var total uint64
+	for _, r := range s.Recipients {
+		total += r.Weight
+	}
+	slot := (number / s.Period) % total
+	for _, r := range s.Recipients {
+		if slot < r.Weight {
+			return r.Address
+		}
+		slot -= r.Weight
+	}
+	return common.Address{} // unreachable for validated schedules
+}
+
+// copy returns a deep copy of the schedule.
+func (s *FeeRecipientSchedule) copy() *FeeRecipientSchedule {
+	if s == nil {
+		return nil
+	}
+	return &FeeRecipientSchedule{
+		Period:     s.Period,
+		Recipients: append([]FeeRecipientShare(nil), s.Recipients...),
+	}
+}
+
+// SetFeeRecipientSchedule replaces the fee recipient schedule of the built
+// blocks, or restores the requested fee recipients if nil.
+func (miner *Miner) SetFeeRecipientSchedule(schedule *FeeRecipientSchedule) error {
+	if schedule != nil {
+		if miner.chainConfig().Optimism != nil {
+			return errors.New("fee recipient schedule is not supported on OP Stack chains")
+		}
+		if err := schedule.Validate(); err != nil {
+			return err
+		}
+	}
+	miner.confMu.Lock()
+	miner.config.FeeRecipients = schedule.copy()
+	miner.confMu.Unlock()
+	return nil
+}
+
+// FeeRecipientSchedule returns the current fee recipient schedule, nil if the
+// requested fee recipients are used.
+func (miner *Miner) FeeRecipientSchedule() *FeeRecipientSchedule {
+	miner.confMu.RLock()
+	defer miner.confMu.RUnlock()
+
+	return miner.config.FeeRecipients.copy()
+}
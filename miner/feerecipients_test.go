@@ -0,0 +1,129 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestFeeRecipientSchedule(t *testing.T) {
+	var (
+		a = common.Address{0xa}
+		b = common.Address{0xb}
+	)
+	schedule := &FeeRecipientSchedule{
+		Period:     2,
+		Recipients: []FeeRecipientShare{{Address: a, Weight: 1}, {Address: b, Weight: 2}},
+	}
+	if err := schedule.Validate(); err != nil {
+		t.Fatalf("valid schedule rejected: %v", err)
+	}
+	// Periods of two blocks, one for a and two for b in turn
+	want := []common.Address{a, a, b, b, b, b, a, a, b, b, b, b, a}
+	for number, recipient := range want {
+		if have := schedule.Recipient(uint64(number)); have != recipient {
+			t.Errorf("block %d: wrong fee recipient: have %v, want %v", number, have, recipient)
+		}
+	}
+	invalid := []*FeeRecipientSchedule{
+		{Period: 0, Recipients: []FeeRecipientShare{{Address: a, Weight: 1}}},
+		{Period: 1},
+		{Period: 1, Recipients: []FeeRecipientShare{{Address: a, Weight: 0}}},
+		{Period: 1, Recipients: []FeeRecipientShare{{Address: a, Weight: math.MaxUint64}, {Address: b, Weight: 1}}},
+	}
+	for i, schedule := range invalid {
+		if err := schedule.Validate(); err == nil {
+			t.Errorf("invalid schedule %d accepted", i)
+		}
+	}
+}
+
+// buildFeeRecipient builds a payload on top of the current head and returns
+// its fee recipient.
+func buildFeeRecipient(t *testing.T, w *Miner, b *testWorkerBackend, params1559 []byte) common.Address {
+	t.Helper()
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), params1559)
+	payload, err := w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	full := payload.ResolveFull()
+	if full == nil {
+		t.Fatal("missing payload")
+	}
+	return full.ExecutionPayload.FeeRecipient
+}
+
+func TestBuildPayloadFeeRecipientSchedule(t *testing.T) {
+	var (
+		db        = rawdb.NewMemoryDatabase()
+		w, b      = newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), db, 0)
+		scheduled = common.Address{0xfe}
+	)
+	build := func() common.Address { return buildFeeRecipient(t, w, b, nil) }
+
+	if have := build(); have != testRecipient {
+		t.Fatalf("wrong fee recipient without schedule: have %v, want %v", have, testRecipient)
+	}
+	schedule := &FeeRecipientSchedule{Period: 1, Recipients: []FeeRecipientShare{{Address: scheduled, Weight: 1}}}
+	if err := w.SetFeeRecipientSchedule(schedule); err != nil {
+		t.Fatalf("failed to set schedule: %v", err)
+	}
+	if have := build(); have != scheduled {
+		t.Fatalf("wrong fee recipient with schedule: have %v, want %v", have, scheduled)
+	}
+	// The returned schedule is a copy
+	w.FeeRecipientSchedule().Recipients[0].Address = common.Address{}
+	if have := w.FeeRecipientSchedule().Recipients[0].Address; have != scheduled {
+		t.Fatalf("schedule modified through copy: have %v", have)
+	}
+	if err := w.SetFeeRecipientSchedule(&FeeRecipientSchedule{Period: 1}); err == nil {
+		t.Fatal("invalid schedule accepted")
+	}
+	if err := w.SetFeeRecipientSchedule(nil); err != nil || w.FeeRecipientSchedule() != nil {
+		t.Fatalf("failed to clear schedule: %v", err)
+	}
+}
+
+// Tests that the fee recipient schedule is ignored on OP Stack chains, where
+// the fee recipient is derived from L1 along with the payload attributes.
+func TestBuildPayloadFeeRecipientScheduleOptimism(t *testing.T) {
+	var (
+		engine   = ethash.NewFaker()
+		b        = newTestWorkerBackend(t, holoceneConfig(), engine, rawdb.NewMemoryDatabase(), 0)
+		config   = testConfig
+		schedule = &FeeRecipientSchedule{Period: 1, Recipients: []FeeRecipientShare{{Address: common.Address{0xfe}, Weight: 1}}}
+	)
+	config.FeeRecipients = schedule
+	w := New(b, config, engine)
+
+	if w.FeeRecipientSchedule() != nil {
+		t.Fatal("configured schedule not ignored")
+	}
+	if have := buildFeeRecipient(t, w, b, []byte{0, 0, 0, 8, 0, 0, 0, 2}); have != testRecipient {
+		t.Fatalf("wrong fee recipient: have %v, want %v", have, testRecipient)
+	}
+	if err := w.SetFeeRecipientSchedule(schedule); err == nil {
+		t.Fatal("schedule accepted on OP Stack chain")
+	}
+}
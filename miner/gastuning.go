@@ -0,0 +1,139 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// gasCeilTuningStep is the fraction of the gas ceiling it is adjusted by
+	// at most once per block.
+	gasCeilTuningStep = 32
+
+	// gasCeilTuningSmoothing is the weight of the history in the moving
+	// average of the build latency.
+	gasCeilTuningSmoothing = 8
+
+	// gasCeilTuningFullness is the fraction of the available gas, in percents,
+	// a block must use for the gas ceiling to be raised.
+	gasCeilTuningFullness = 90
+)
+
+var gasCeilGauge = metrics.NewRegisteredGauge("miner/gasceil", nil)
+
+// DefaultGasCeilTuningLatency is the default target build latency of the gas
+// ceiling tuning.
+const DefaultGasCeilTuningLatency = 500 * time.Millisecond
+
+// GasCeilTuning configures the automatic adjustment of the gas ceiling, based
+// on the latency of the payload builds and the fullness of the built blocks.
+//
+// The ceiling is lowered when the average build latency exceeds the target,
+// and raised when the blocks are full while the builds take less than half of
+// the target. On OP Stack chains the block gas limit is set by the system
+// config, so the effective gas ceiling is tuned instead.
+type GasCeilTuning struct {
+	Min           uint64        // Lowest gas ceiling the tuning may set
+	Max           uint64        // Highest gas ceiling the tuning may set
+	TargetLatency time.Duration // Build latency to stay under
+}
+
+// Validate checks the bounds and the target latency of the tuning.
+func (c *GasCeilTuning) Validate() error {
+	if c.Min == 0 || c.Max < c.Min {
+		return errors.New("gas ceiling tuning bounds must satisfy 0 < min <= max")
+	}
+	if c.TargetLatency <= 0 {
+		return errors.New("gas ceiling tuning target latency must be positive")
+	}
+	return nil
+}
+
+// gasCeilTuner is the controller adjusting the gas ceiling.
+type gasCeilTuner struct {
+	config  GasCeilTuning
+	latency time.Duration // Moving average of the build latency
+	last    uint64        // Number of the last block the ceiling was adjusted after
+	lock    sync.Mutex
+}
+
+func newGasCeilTuner(config GasCeilTuning) *gasCeilTuner {
+	return &gasCeilTuner{config: config}
+}
+
+// adjust records the build of a block and returns the gas ceiling to use from
+// the current one. The ceiling is adjusted at most once per block number.
+func (t *gasCeilTuner) adjust(ceil uint64, number uint64, elapsed time.Duration, gasUsed, gasAvailable uint64) uint64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.latency == 0 {
+		t.latency = elapsed
+	} else {
+		t.latency = (t.latency*(gasCeilTuningSmoothing-1) + elapsed) / gasCeilTuningSmoothing
+	}
+	if number <= t.last {
+		return ceil
+	}
+	t.last = number
+
+	step := max(ceil/gasCeilTuningStep, 1)
+	switch {
+	case t.latency > t.config.TargetLatency:
+		ceil -= min(step, ceil)
+	case t.latency < t.config.TargetLatency/2 && gasUsed*100 >= gasAvailable*gasCeilTuningFullness:
+		ceil += step
+	}
+	return min(max(ceil, t.config.Min), t.config.Max)
+}
+
+// tuneGasCeil feeds the build of a full block into the gas ceiling tuning, if
+// enabled, and applies the resulting ceiling.
+func (miner *Miner) tuneGasCeil(block *types.Block, elapsed time.Duration) {
+	if miner.tuner == nil {
+		return
+	}
+	miner.confMu.Lock()
+	defer miner.confMu.Unlock()
+
+	// The effective ceiling caps the header gas limit if lower, unset means none
+	ceil := &miner.config.GasCeil
+	current, available := *ceil, block.GasLimit()
+	if miner.chainConfig().Optimism != nil {
+		ceil = &miner.config.EffectiveGasCeil
+		current = *ceil
+		if current == 0 || current > block.GasLimit() {
+			current = block.GasLimit()
+		}
+		available = current
+	}
+	next := miner.tuner.adjust(current, block.NumberU64(), elapsed, block.GasUsed(), available)
+	if next == current {
+		return
+	}
+	*ceil = next
+	gasCeilGauge.Update(int64(next))
+	log.Info("Adjusted gas ceiling", "number", block.NumberU64(), "ceil", next, "previous", current, "elapsed", common.PrettyDuration(elapsed))
+}
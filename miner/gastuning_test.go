@@ -0,0 +1,60 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGasCeilTuner(t *testing.T) {
+	tuner := newGasCeilTuner(GasCeilTuning{Min: 30_000_000, Max: 33_000_000, TargetLatency: 400 * time.Millisecond})
+
+	// Fast builds of full blocks raise the ceiling, once per block and up to the maximum
+	ceil := uint64(32_000_000)
+	if ceil = tuner.adjust(ceil, 1, 100*time.Millisecond, 32_000_000, 32_000_000); ceil != 33_000_000 {
+		t.Fatalf("ceiling not raised: have %d, want %d", ceil, 33_000_000)
+	}
+	if next := tuner.adjust(30_000_000, 1, 100*time.Millisecond, 30_000_000, 30_000_000); next != 30_000_000 {
+		t.Fatalf("ceiling adjusted twice for the same block: have %d", next)
+	}
+	// Fast builds of blocks with spare room keep the ceiling
+	if next := tuner.adjust(ceil, 2, 100*time.Millisecond, 10_000_000, ceil); next != ceil {
+		t.Fatalf("ceiling adjusted for a block with spare room: have %d, want %d", next, ceil)
+	}
+	// Slow builds lower the ceiling once the average exceeds the target
+	var blocks int
+	for number := uint64(3); ceil == 33_000_000; number++ {
+		ceil = tuner.adjust(ceil, number, time.Second, ceil, ceil)
+		blocks++
+	}
+	if blocks < 2 {
+		t.Fatalf("ceiling lowered after a single slow build")
+	}
+	if ceil != 33_000_000-33_000_000/gasCeilTuningStep {
+		t.Fatalf("wrong lowered ceiling: have %d", ceil)
+	}
+	for number := uint64(100); number < 200; number++ {
+		ceil = tuner.adjust(ceil, number, time.Second, ceil, ceil)
+	}
+	if ceil != 30_000_000 {
+		t.Fatalf("ceiling not bounded by the minimum: have %d", ceil)
+	}
+	if err := (&GasCeilTuning{Min: 2, Max: 1, TargetLatency: time.Second}).Validate(); err == nil {
+		t.Fatal("inverted bounds accepted")
+	}
+}
@@ -19,9 +19,11 @@ package miner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -33,10 +35,22 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/types/interoptypes"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 )
 
+var (
+	// errDraining is returned when a payload is requested while the miner is
+	// drained for a shutdown.
+	errDraining = errors.New("miner is shutting down")
+
+	// errStandby is returned when a payload including txpool transactions is
+	// requested while the node is a standby, not yet promoted.
+	errStandby = errors.New("miner is on standby")
+)
+
 var (
 	maxDATxSizeGauge    = metrics.NewRegisteredGauge("miner/maxDATxSize", nil)
 	maxDABlockSizeGauge = metrics.NewRegisteredGauge("miner/maxDABlockSize", nil)
@@ -56,6 +70,10 @@ type BackendWithInterop interface {
 	CheckAccessList(ctx context.Context, inboxEntries []common.Hash, minSafety interoptypes.SafetyLevel, executingDescriptor interoptypes.ExecutingDescriptor) error
 }
 
+type BackendWithDatabase interface {
+	ChainDb() ethdb.Database
+}
+
 // Config is the configuration parameters of mining.
 type Config struct {
 	Etherbase           common.Address `toml:"-"`          // Deprecated
@@ -71,6 +89,16 @@ type Config struct {
 	EffectiveGasCeil uint64   // if non-zero, a gas ceiling to apply independent of the header's gaslimit value
 	MaxDATxSize      *big.Int `toml:",omitempty"` // if non-nil, don't include any txs with data availability size larger than this in any built block
 	MaxDABlockSize   *big.Int `toml:",omitempty"` // if non-nil, then don't build a block requiring more than this amount of total data availability
+
+	RecordPayloads       bool // Record the inputs of locally built payloads, allowing to rebuild them for auditing
+	CommitOrderingPolicy bool // Commit to the transaction ordering policy in the block extra-data
+	BuilderPayloads      bool // Accept externally built payloads competing with the locally built ones
+	RecordAccessLists    bool // Record the access lists of the transactions of locally built blocks
+
+	FeeRecipients *FeeRecipientSchedule `toml:",omitempty"` // Schedule overriding the requested fee recipients, nil if disabled
+	GasCeilTuning *GasCeilTuning        `toml:",omitempty"` // Automatic gas ceiling adjustment, nil if disabled
+	SealTiming    *SealTiming           `toml:",omitempty"` // Delay of the sealing of the built payloads, nil if disabled
+	BuilderPolicy *BuilderPolicy        `toml:",omitempty"` // Selection policy of the builder payloads, nil to select on value only
 }
 
 // DefaultConfig contains default settings for miner.
@@ -88,15 +116,23 @@ var DefaultConfig = Config{
 // Miner is the main object which takes care of submitting new work to consensus
 // engine and gathering the sealing result.
 type Miner struct {
-	confMu      sync.RWMutex // The lock used to protect the config fields: GasCeil, GasTip and Extradata
-	config      *Config
-	chainConfig *params.ChainConfig
-	engine      consensus.Engine
-	txpool      *txpool.TxPool
-	prio        []common.Address // A list of senders to prioritize
-	chain       *core.BlockChain
-	pending     *pending
-	pendingMu   sync.Mutex // Lock protects the pending block
+	confMu     sync.RWMutex // The lock used to protect the config fields: GasCeil, GasTip and Extradata
+	config     *Config
+	engine     consensus.Engine
+	txpool     *txpool.TxPool
+	prio       []common.Address      // A list of senders to prioritize
+	addrPolicy *txpool.AddressPolicy // Policy refusing transactions by address, nil if none
+	chain      *core.BlockChain
+	pending    *pending
+	pendingMu  sync.Mutex      // Lock protects the pending block
+	reports    *payloadReports // Revenue breakdown of the recently built payloads
+	tuner      *gasCeilTuner   // Gas ceiling tuning controller, nil if disabled
+
+	drainMu   sync.RWMutex   // The lock used to protect draining and new payloads
+	draining  bool           // Whether new payloads are refused due to a shutdown
+	building  sync.WaitGroup // Payloads being built in the background
+	standby   atomic.Bool    // Whether new payloads are refused until promoted
+	lastBuild atomic.Int64   // Unix nanoseconds of the last payload build request
 
 	backend Backend
 
@@ -106,26 +142,65 @@ type Miner struct {
 
 // New creates a new miner with provided config.
 func New(eth Backend, config Config, engine consensus.Engine) *Miner {
+	if config.CommitOrderingPolicy && eth.BlockChain().Config().Optimism != nil {
+		log.Warn("Ordering policy commitment is not supported on OP Stack chains, the extra-data is reserved")
+	}
+	if config.FeeRecipients != nil {
+		if eth.BlockChain().Config().Optimism != nil {
+			log.Warn("Ignoring fee recipient schedule, the fee recipient of OP Stack chains is derived")
+			config.FeeRecipients = nil
+		} else if err := config.FeeRecipients.Validate(); err != nil {
+			log.Warn("Ignoring invalid fee recipient schedule", "err", err)
+			config.FeeRecipients = nil
+		}
+	}
+	var tuner *gasCeilTuner
+	if config.GasCeilTuning != nil {
+		if err := config.GasCeilTuning.Validate(); err != nil {
+			log.Warn("Ignoring invalid gas ceiling tuning", "err", err)
+		} else {
+			tuner = newGasCeilTuner(*config.GasCeilTuning)
+		}
+	}
+	if config.SealTiming != nil {
+		if err := config.SealTiming.Validate(); err != nil {
+			log.Warn("Ignoring invalid sealing timing", "err", err)
+			config.SealTiming = nil
+		}
+	}
+	if config.BuilderPolicy != nil {
+		if err := config.BuilderPolicy.Validate(); err != nil {
+			log.Warn("Ignoring invalid builder payload policy", "err", err)
+			config.BuilderPolicy = nil
+		}
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Miner{
-		backend:     eth,
-		config:      &config,
-		chainConfig: eth.BlockChain().Config(),
-		engine:      engine,
-		txpool:      eth.TxPool(),
-		chain:       eth.BlockChain(),
-		pending:     &pending{},
+		backend: eth,
+		config:  &config,
+		engine:  engine,
+		txpool:  eth.TxPool(),
+		chain:   eth.BlockChain(),
+		pending: &pending{},
+		reports: newPayloadReports(),
+		tuner:   tuner,
 		// To interrupt background tasks that may be attached to external processes
 		lifeCtxCancel: cancel,
 		lifeCtx:       ctx,
 	}
 }
 
+// chainConfig returns the configuration of the chain the miner builds on. It is
+// not cached, as forks may be scheduled at runtime.
+func (miner *Miner) chainConfig() *params.ChainConfig {
+	return miner.chain.Config()
+}
+
 // Pending returns the currently pending block and associated receipts, logs
 // and statedb. The returned values can be nil in case the pending block is
 // not initialized.
 func (miner *Miner) Pending() (*types.Block, types.Receipts, *state.StateDB) {
-	if miner.chainConfig.Optimism != nil && !miner.config.RollupComputePendingBlock {
+	if miner.chainConfig().Optimism != nil && !miner.config.RollupComputePendingBlock {
 		// For compatibility when not computing a pending block, we serve the latest block as "pending"
 		headHeader := miner.chain.CurrentHeader()
 		headBlock := miner.chain.GetBlock(headHeader.Hash(), headHeader.Number.Uint64())
@@ -163,6 +238,15 @@ func (miner *Miner) SetPrioAddresses(prio []common.Address) {
 	miner.confMu.Unlock()
 }
 
+// SetAddressPolicy sets the policy refusing the inclusion of transactions from
+// or to certain addresses. Transactions forced by the payload attributes are
+// always included.
+func (miner *Miner) SetAddressPolicy(policy *txpool.AddressPolicy) {
+	miner.confMu.Lock()
+	miner.addrPolicy = policy
+	miner.confMu.Unlock()
+}
+
 // SetGasCeil sets the gaslimit to strive for when mining blocks post 1559.
 // For pre-1559 blocks, it sets the ceiling.
 func (miner *Miner) SetGasCeil(ceil uint64) {
@@ -205,9 +289,63 @@ func (miner *Miner) SetMaxDASize(maxTxSize, maxBlockSize *big.Int) {
 
 // BuildPayload builds the payload according to the provided parameters.
 func (miner *Miner) BuildPayload(args *BuildPayloadArgs, witness bool) (*Payload, error) {
+	miner.drainMu.RLock()
+	defer miner.drainMu.RUnlock()
+
+	if miner.draining {
+		return nil, errDraining
+	}
+	// Payloads without a txpool only replay derived transactions, which standby
+	// sequencers and verifiers need to follow the chain.
+	if miner.standby.Load() && !args.NoTxPool {
+		return nil, errStandby
+	}
+	if miner.chain.Paused() {
+		return nil, core.ErrChainPaused
+	}
+	miner.lastBuild.Store(time.Now().UnixNano())
 	return miner.buildPayload(args, witness)
 }
 
+// LastBuild returns the time of the last payload build request, zero if none.
+func (miner *Miner) LastBuild() time.Time {
+	if last := miner.lastBuild.Load(); last != 0 {
+		return time.Unix(0, last)
+	}
+	return time.Time{}
+}
+
+// SetStandby sets whether the miner refuses to build payloads, for the node to
+// follow the chain as a standby until promoted.
+func (miner *Miner) SetStandby(standby bool) {
+	miner.standby.Store(standby)
+}
+
+// Standby returns whether the miner refuses to build payloads.
+func (miner *Miner) Standby() bool {
+	return miner.standby.Load()
+}
+
+// Drain refuses to build new payloads and waits until the payloads being built
+// are delivered or reach their building deadline, or the context is canceled.
+func (miner *Miner) Drain(ctx context.Context) error {
+	miner.drainMu.Lock()
+	miner.draining = true
+	miner.drainMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		miner.building.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // getPending retrieves the pending block based on the current head block.
 // The result might be nil if pending generation is failed.
 func (miner *Miner) getPending() *newPayloadResult {
@@ -222,7 +360,7 @@ func (miner *Miner) getPending() *newPayloadResult {
 		timestamp  = uint64(time.Now().Unix())
 		withdrawal types.Withdrawals
 	)
-	if miner.chainConfig.IsShanghai(new(big.Int).Add(header.Number, big.NewInt(1)), timestamp) {
+	if miner.chainConfig().IsShanghai(new(big.Int).Add(header.Number, big.NewInt(1)), timestamp) {
 		withdrawal = []*types.Withdrawal{}
 	}
 	ret := miner.generateWork(&generateParams{
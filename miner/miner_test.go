@@ -178,7 +178,7 @@ func TestRejectedConditionalTx(t *testing.T) {
 	uint64Ptr := func(num uint64) *uint64 { return &num }
 
 	// add a conditional transaction to be rejected
-	signer := types.LatestSigner(miner.chainConfig)
+	signer := types.LatestSigner(miner.chainConfig())
 	tx := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
 		Nonce:    0,
 		To:       &testUserAddress,
@@ -32,10 +32,14 @@ import (
 	"github.com/ethereum/go-ethereum/core/stateless"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+// payloadBuildTimer measures the duration of successful payload building passes.
+var payloadBuildTimer = metrics.NewRegisteredTimer("miner/payload/buildtime", nil)
+
 // BuildPayloadArgs contains the provided parameters for building payload.
 // Check engine-api specification for more details.
 // https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#payloadattributesv3
@@ -90,6 +94,8 @@ func (args *BuildPayloadArgs) Id() engine.PayloadID {
 // will be set/updated afterwards.
 type Payload struct {
 	id            engine.PayloadID
+	args          *BuildPayloadArgs // Attributes the payload is built with
+	witness       bool              // Whether a witness of the payload is requested
 	empty         *types.Block
 	emptyWitness  *stateless.Witness
 	full          *types.Block
@@ -98,6 +104,12 @@ type Payload struct {
 	emptyRequests [][]byte
 	requests      [][]byte
 	fullFees      *big.Int
+	fullDelta     *big.Int     // Minimum delta the selected builder payload has to beat the local one by, nil if local
+	local         *types.Block // Best locally built full-block, the fallback to builder payloads
+	localFees     *big.Int
+	reports       *payloadReports    // Reports of the accepted full-blocks, nil if not reported
+	record        *PayloadRecord     // Building inputs of the full-block, nil if not recorded or built externally
+	accessLists   []types.AccessList // Access lists of the full-block transactions, nil if not recorded or built externally
 	stop          chan struct{}
 	lock          sync.Mutex
 	cond          *sync.Cond
@@ -108,6 +120,13 @@ type Payload struct {
 
 	rpcCtx    context.Context // context to limit RPC-coupled payload checks
 	rpcCancel context.CancelFunc
+
+	start    time.Time // Time the building of the payload started
+	sealAt   time.Time // Time the payload is sealed at, at the earliest, zero if not delayed
+	sealOnce sync.Once
+
+	deliver     func(block *types.Block, record *PayloadRecord, accessLists []types.AccessList) // Persists the records of the delivered full-block, nil if not recorded
+	deliverOnce sync.Once
 }
 
 // newPayload initializes the payload object.
@@ -124,6 +143,8 @@ func newPayload(lifeCtx context.Context, empty *types.Block, emptyRequests [][]b
 
 		rpcCtx:    rpcCtx,
 		rpcCancel: rpcCancel,
+
+		start: time.Now(),
 	}
 	log.Info("Starting work on payload", "id", payload.id)
 	payload.cond = sync.NewCond(&payload.lock)
@@ -155,15 +176,31 @@ func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
 	}
 	log.Debug("New payload update", "id", payload.id, "elapsed", common.PrettyDuration(elapsed))
 
+	improved := payload.local == nil || r.fees.Cmp(payload.localFees) > 0
+	if improved {
+		payload.local, payload.localFees = r.block, r.fees
+	}
 	// Ensure the newly provided full block has a higher transaction fee.
 	// In post-merge stage, there is no uncle reward anymore and transaction
 	// fee(apart from the mev revenue) is the only indicator for comparison.
-	if payload.full == nil || r.fees.Cmp(payload.fullFees) > 0 {
+	// A selected builder payload also has to keep beating the improved local
+	// block by the minimum delta it was selected with.
+	replace := payload.full == nil || r.fees.Cmp(payload.fullFees) > 0
+	if !replace && improved && payload.fullDelta != nil {
+		replace = payload.fullFees.Cmp(new(big.Int).Add(r.fees, payload.fullDelta)) < 0
+	}
+	if replace {
 		payload.full = r.block
 		payload.fullFees = r.fees
+		payload.fullDelta = nil
 		payload.sidecars = r.sidecars
 		payload.requests = r.requests
 		payload.fullWitness = r.witness
+		payload.record = r.record
+		payload.accessLists = r.accessLists
+		if payload.reports != nil && r.report != nil {
+			payload.reports.add(r.report)
+		}
 
 		feesInEther := new(big.Float).Quo(new(big.Float).SetInt(r.fees), big.NewFloat(params.Ether))
 		log.Info("Updated payload",
@@ -213,6 +250,9 @@ func (payload *Payload) WaitFull() {
 }
 
 func (payload *Payload) resolve(onlyFull bool) *engine.ExecutionPayloadEnvelope {
+	// Let the payload be updated until its target sealing time, if delayed.
+	payload.waitSeal()
+
 	payload.lock.Lock()
 	defer payload.lock.Unlock()
 
@@ -236,6 +276,9 @@ func (payload *Payload) resolve(onlyFull bool) *engine.ExecutionPayloadEnvelope
 	payload.stopBuilding()
 
 	if payload.full != nil {
+		if payload.deliver != nil {
+			payload.deliverOnce.Do(func() { payload.deliver(payload.full, payload.record, payload.accessLists) })
+		}
 		envelope := engine.BlockToExecutableData(payload.full, payload.fullFees, payload.sidecars, payload.requests)
 		if payload.fullWitness != nil {
 			envelope.Witness = new(hexutil.Bytes)
@@ -305,18 +348,23 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 			gasLimit:      args.GasLimit,
 			eip1559Params: args.EIP1559Params,
 			// No RPC requests allowed.
-			rpcCtx: nil,
+			rpcCtx:      nil,
+			accessLists: miner.config.RecordAccessLists,
 		}
 		empty := miner.generateWork(emptyParams, witness)
 		if empty.err != nil {
 			return nil, empty.err
 		}
 		payload := newPayload(miner.lifeCtx, empty.block, empty.requests, empty.witness, args.Id())
+		payload.args, payload.witness = args, witness
 		// make sure to make it appear as full, otherwise it will wait indefinitely for payload building to complete.
 		payload.full = empty.block
 		payload.fullFees = empty.fees
 		payload.fullWitness = empty.witness
 		payload.requests = empty.requests
+		payload.accessLists = empty.accessLists
+		payload.deliver = miner.writePayloadRecords
+		miner.reports.add(miner.newPayloadReport(payload.id, empty, len(args.Transactions)))
 		payload.cond.Broadcast() // unblocks Resolve
 		return payload, nil
 	}
@@ -332,6 +380,8 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 		txs:           args.Transactions,
 		gasLimit:      args.GasLimit,
 		eip1559Params: args.EIP1559Params,
+		record:        true,
+		accessLists:   miner.config.RecordAccessLists,
 	}
 
 	// Since we skip building the empty block when using the tx pool, we need to explicitly
@@ -342,13 +392,22 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 	}
 
 	payload := newPayload(miner.lifeCtx, nil, nil, nil, args.Id())
+	payload.args, payload.witness = args, witness
+	payload.reports = miner.reports
+	payload.deliver = miner.writePayloadRecords
+	if timing := miner.config.SealTiming; timing != nil {
+		payload.sealAt = payload.start.Add(min(timing.offset(), blockTime))
+	}
 	// set shared interrupt
 	fullParams.interrupt = payload.interrupt
 	fullParams.rpcCtx = payload.rpcCtx
 
 	// Spin up a routine for updating the payload in background. This strategy
 	// can maximum the revenue for including transactions with highest fee.
+	miner.building.Add(1)
 	go func() {
+		defer miner.building.Done()
+
 		// Setup the timer for re-building the payload. The initial clock is kept
 		// for triggering process immediately.
 		timer := time.NewTimer(0)
@@ -375,6 +434,11 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 			// getSealingBlock is interrupted by shared interrupt
 			r := miner.generateWork(fullParams, witness)
 			dur := time.Since(start)
+			if r.err == nil {
+				r.report = miner.newPayloadReport(payload.id, r, len(args.Transactions))
+				miner.tuneGasCeil(r.block, dur)
+				payloadBuildTimer.Update(dur)
+			}
 			// update handles error case
 			payload.update(r, dur)
 			if r.err == nil {
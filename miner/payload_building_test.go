@@ -18,7 +18,9 @@ package miner
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -149,6 +151,7 @@ func newTestWorkerBackend(t *testing.T, chainConfig *params.ChainConfig, engine
 
 func (b *testWorkerBackend) BlockChain() *core.BlockChain { return b.chain }
 func (b *testWorkerBackend) TxPool() *txpool.TxPool       { return b.txPool }
+func (b *testWorkerBackend) ChainDb() ethdb.Database      { return b.db }
 
 func newTestWorker(t *testing.T, chainConfig *params.ChainConfig, engine consensus.Engine, db ethdb.Database, blocks int) (*Miner, *testWorkerBackend) {
 	backend := newTestWorkerBackend(t, chainConfig, engine, db, blocks)
@@ -379,6 +382,55 @@ func TestBuildPayloadInvalidHoloceneParams(t *testing.T) {
 	}
 }
 
+func TestDrain(t *testing.T) {
+	t.Parallel()
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.NoTxPool = false
+	payload, err := w.BuildPayload(args, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	// The drain waits for the payload being built.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := w.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Unexpected drain result, want %v, got %v", context.DeadlineExceeded, err)
+	}
+	args.Timestamp++
+	if _, err := w.BuildPayload(args, false); !errors.Is(err, errDraining) {
+		t.Fatalf("Unexpected build result, want %v, got %v", errDraining, err)
+	}
+	// Delivering the payload completes the drain.
+	payload.ResolveFull()
+	if err := w.Drain(context.Background()); err != nil {
+		t.Fatalf("Failed to drain, %v", err)
+	}
+}
+
+func TestStandby(t *testing.T) {
+	t.Parallel()
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	w.SetStandby(true)
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.NoTxPool = false
+	if _, err := w.BuildPayload(args, false); !errors.Is(err, errStandby) {
+		t.Fatalf("Unexpected build result, want %v, got %v", errStandby, err)
+	}
+	// Derivation-only payloads are still built on standby.
+	derived := *args
+	derived.NoTxPool = true
+	if _, err := w.BuildPayload(&derived, false); err != nil {
+		t.Fatalf("Failed to build payload without txpool on standby %v", err)
+	}
+	w.SetStandby(false)
+	if _, err := w.BuildPayload(args, false); err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+}
+
 func genTxs(startNonce, count uint64) types.Transactions {
 	txs := make(types.Transactions, 0, count)
 	signer := types.LatestSigner(params.TestChainConfig)
@@ -0,0 +1,158 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// PolicyPriceTime is the transaction ordering policy implemented by the miner:
+// the transactions of the prioritized senders are included first, then the
+// rest. Within both groups, the transactions are ordered by effective tip,
+// equally priced ones by arrival time, honouring the nonce order of senders.
+const PolicyPriceTime = 1
+
+// policyCommitmentPrefix is the magic prefix of the ordering policy commitment
+// in the block extra-data. The commitment is the prefix, followed by the policy
+// ID and the leading bytes of the policy parameters hash, filling the extra-data.
+var policyCommitmentPrefix = []byte("ord")
+
+var (
+	errPolicyNotCommitted = errors.New("no ordering policy commitment")
+	errPolicyMismatch     = errors.New("ordering policy commitment mismatch")
+)
+
+// OrderingPolicy is a transaction ordering policy along with its parameters.
+type OrderingPolicy struct {
+	ID     uint8            // Identifier of the ordering policy
+	Prio   []common.Address // Senders whose transactions are included first
+	MinTip *big.Int         // Minimum effective tip for a transaction to be included
+}
+
+// ParamsHash returns the hash of the policy parameters.
+func (p *OrderingPolicy) ParamsHash() common.Hash {
+	minTip := p.MinTip
+	if minTip == nil {
+		minTip = new(big.Int)
+	}
+	blob, _ := rlp.EncodeToBytes([]interface{}{p.Prio, minTip}) // cannot fail
+	return crypto.Keccak256Hash(blob)
+}
+
+// Commitment returns the block extra-data committing to the policy.
+func (p *OrderingPolicy) Commitment() []byte {
+	hash := p.ParamsHash()
+
+	extra := make([]byte, 0, params.MaximumExtraDataSize)
+	extra = append(extra, policyCommitmentPrefix...)
+	extra = append(extra, p.ID)
+	return append(extra, hash[:int(params.MaximumExtraDataSize)-len(extra)]...)
+}
+
+// orderingPolicy returns the ordering policy corresponding to the current miner
+// settings. The caller must hold the config lock.
+func (miner *Miner) orderingPolicy() *OrderingPolicy {
+	return &OrderingPolicy{
+		ID:     PolicyPriceTime,
+		Prio:   miner.prio,
+		MinTip: miner.config.GasPrice,
+	}
+}
+
+// VerifyOrderingPolicy checks that the block commits to the given ordering
+// policy and that its transactions adhere to it. Only the properties of the
+// policy which can be verified from the block itself are checked: the minimum
+// tip, the precedence of the prioritized senders and the tip ordering. The
+// arrival times of the transactions, breaking the ties, are not known.
+//
+// The deposit transactions at the start of the block, forced by the payload
+// attributes, are exempt from the policy.
+func VerifyOrderingPolicy(config *params.ChainConfig, block *types.Block, policy *OrderingPolicy) error {
+	if policy.ID != PolicyPriceTime {
+		return fmt.Errorf("unknown ordering policy %d", policy.ID)
+	}
+	extra := block.Extra()
+	if len(extra) != int(params.MaximumExtraDataSize) || !bytes.HasPrefix(extra, policyCommitmentPrefix) {
+		return errPolicyNotCommitted
+	}
+	if !bytes.Equal(extra, policy.Commitment()) {
+		return errPolicyMismatch
+	}
+	var (
+		signer = types.MakeSigner(config, block.Number(), block.Time())
+		txs    = block.Transactions()
+		prio   = make(map[common.Address]bool)
+	)
+	for _, addr := range policy.Prio {
+		prio[addr] = true
+	}
+	start := 0
+	for start < len(txs) && txs[start].SystemTxType() != nil {
+		start++
+	}
+	var (
+		senders = make([]common.Address, len(txs))
+		tips    = make([]*big.Int, len(txs))
+		normal  bool // Whether a non-prioritized transaction was encountered
+	)
+	for i := start; i < len(txs); i++ {
+		from, err := types.Sender(signer, txs[i])
+		if err != nil {
+			return fmt.Errorf("tx %d: invalid sender: %v", i, err)
+		}
+		tip, err := txs[i].EffectiveGasTip(block.BaseFee())
+		if err != nil {
+			return fmt.Errorf("tx %d: %v", i, err)
+		}
+		if policy.MinTip != nil && tip.Cmp(policy.MinTip) < 0 {
+			return fmt.Errorf("tx %d: tip %v below minimum %v", i, tip, policy.MinTip)
+		}
+		if !prio[from] {
+			normal = true
+		} else if normal {
+			return fmt.Errorf("tx %d: prioritized sender %x included after non-prioritized ones", i, from)
+		}
+		senders[i], tips[i] = from, tip
+	}
+	// Whenever a transaction is picked, the next transaction of every other
+	// sender in the same group is a candidate too, none may pay a higher tip.
+	for i := start; i < len(txs); i++ {
+		skip := map[common.Address]bool{senders[i]: true}
+		for j := i + 1; j < len(txs); j++ {
+			if skip[senders[j]] {
+				continue
+			}
+			skip[senders[j]] = true
+			if prio[senders[i]] != prio[senders[j]] {
+				continue
+			}
+			if tips[j].Cmp(tips[i]) > 0 {
+				return fmt.Errorf("tx %d: tip %v included before tx %d with higher tip %v", i, tips[i], j, tips[j])
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestVerifyOrderingPolicy(t *testing.T) {
+	var (
+		keyA, _ = crypto.GenerateKey()
+		keyB, _ = crypto.GenerateKey()
+		addrB   = crypto.PubkeyToAddress(keyB.PublicKey)
+		signer  = types.LatestSigner(params.TestChainConfig)
+		baseFee = big.NewInt(params.GWei)
+		policy  = &OrderingPolicy{ID: PolicyPriceTime, MinTip: big.NewInt(1)}
+	)
+	tx := func(key string, nonce uint64, tip int64) *types.Transaction {
+		k := keyA
+		if key == "B" {
+			k = keyB
+		}
+		return types.MustSignNewTx(k, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        &testUserAddress,
+			Gas:       params.TxGas,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: new(big.Int).Add(baseFee, big.NewInt(tip)),
+		})
+	}
+	block := func(extra []byte, txs ...*types.Transaction) *types.Block {
+		header := &types.Header{Number: big.NewInt(1), BaseFee: baseFee, Extra: extra}
+		return types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs})
+	}
+	prioPolicy := &OrderingPolicy{ID: PolicyPriceTime, Prio: []common.Address{addrB}, MinTip: big.NewInt(1)}
+
+	tests := []struct {
+		name   string
+		policy *OrderingPolicy
+		block  *types.Block
+		valid  bool
+	}{
+		{"ordered", policy, block(policy.Commitment(), tx("A", 0, 3), tx("A", 1, 5), tx("B", 0, 2)), true},
+		{"nonce-gated", policy, block(policy.Commitment(), tx("A", 0, 1), tx("B", 0, 2)), false},
+		{"higher-tip-later", policy, block(policy.Commitment(), tx("A", 0, 3), tx("B", 0, 2), tx("A", 1, 5)), false},
+		{"below-min-tip", policy, block(policy.Commitment(), tx("A", 0, 0)), false},
+		{"prio-first", prioPolicy, block(prioPolicy.Commitment(), tx("B", 0, 1), tx("A", 0, 3)), true},
+		{"prio-late", prioPolicy, block(prioPolicy.Commitment(), tx("A", 0, 3), tx("B", 0, 1)), false},
+		{"not-committed", policy, block(nil, tx("A", 0, 3)), false},
+		{"other-policy", prioPolicy, block(policy.Commitment(), tx("A", 0, 3)), false},
+	}
+	for _, tt := range tests {
+		err := VerifyOrderingPolicy(params.TestChainConfig, tt.block, tt.policy)
+		if tt.valid && err != nil {
+			t.Errorf("%s: valid block rejected: %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: invalid block accepted", tt.name)
+		}
+	}
+	if err := VerifyOrderingPolicy(params.TestChainConfig, block(nil), policy); !errors.Is(err, errPolicyNotCommitted) {
+		t.Errorf("wrong error for uncommitted block: %v", err)
+	}
+}
+
+func TestBuildPayloadPolicyCommitment(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	w, b := newTestWorker(t, ethashChainConfig, ethash.NewFaker(), db, 0)
+	w.config.CommitOrderingPolicy = true
+	b.txPool.Add(genTxs(1, 4), true)
+
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.NoTxPool = false
+	payload, err := w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	payload.WaitFull()
+	payload.ResolveFull()
+
+	policy := w.orderingPolicy()
+	if err := VerifyOrderingPolicy(ethashChainConfig, payload.full, policy); err != nil {
+		t.Fatalf("built block violates the ordering policy: %v", err)
+	}
+	policy.Prio = []common.Address{testUserAddress}
+	if err := VerifyOrderingPolicy(ethashChainConfig, payload.full, policy); !errors.Is(err, errPolicyMismatch) {
+		t.Fatalf("wrong error for different policy: %v", err)
+	}
+}
+
+// Tests that OP Stack payloads never commit to the ordering policy, not even
+// the sequencer's: verifiers derive the blocks with empty extra-data, so a
+// commitment would make the block hashes diverge.
+func TestBuildPayloadPolicyCommitmentOptimism(t *testing.T) {
+	config := holoceneConfig()
+	config.HoloceneTime = nil
+
+	db := rawdb.NewMemoryDatabase()
+	w, b := newTestWorker(t, config, ethash.NewFaker(), db, 0)
+	w.config.CommitOrderingPolicy = true
+	b.txPool.Add(genTxs(1, 4), true)
+
+	for _, noTxPool := range []bool{false, true} {
+		args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+		args.NoTxPool = noTxPool
+		payload, err := w.buildPayload(args, false)
+		if err != nil {
+			t.Fatalf("failed to build payload: %v", err)
+		}
+		if !noTxPool {
+			payload.WaitFull()
+		}
+		payload.ResolveFull()
+
+		if extra := payload.full.Extra(); len(extra) != 0 {
+			t.Fatalf("noTxPool %v: unexpected extra-data %x", noTxPool, extra)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+)
+
+var (
+	errNoPayloadDatabase = errors.New("payload records not supported by backend")
+	errNoPayloadRecord   = errors.New("no payload record for block")
+)
+
+// PooledTx is a pending transaction offered by the txpool to the block building.
+type PooledTx struct {
+	Tx          *types.Transaction            `json:"tx"`
+	Time        time.Time                     `json:"time"` // Time the transaction was first seen, breaking price ties
+	Conditional *types.TransactionConditional `json:"conditional,omitempty"`
+	Rejected    bool                          `json:"rejected,omitempty"` // Whether the transaction was rejected by the conditional or interop checks
+}
+
+// PayloadRecord contains the inputs of a locally built payload: the payload
+// attributes, the miner settings affecting the transaction selection and the
+// pending transactions the txpool offered. It allows the payload to be rebuilt
+// later on, auditing the transaction selection and ordering.
+type PayloadRecord struct {
+	Parent        common.Hash        `json:"parent"`
+	Timestamp     uint64             `json:"timestamp"`
+	FeeRecipient  common.Address     `json:"feeRecipient"`
+	Random        common.Hash        `json:"random"`
+	Withdrawals   types.Withdrawals  `json:"withdrawals"`
+	Transactions  types.Transactions `json:"transactions"`
+	GasLimit      *uint64            `json:"gasLimit,omitempty"`
+	EIP1559Params hexutil.Bytes      `json:"eip1559Params,omitempty"`
+
+	GasCeil          uint64           `json:"gasCeil"`
+	GasPrice         *big.Int         `json:"gasPrice"`
+	ExtraData        hexutil.Bytes    `json:"extraData,omitempty"`
+	EffectiveGasCeil uint64           `json:"effectiveGasCeil"`
+	MaxDABlockSize   *big.Int         `json:"maxDABlockSize,omitempty"`
+	CommitPolicy     bool             `json:"commitPolicy,omitempty"`
+	Prio             []common.Address `json:"prio,omitempty"`
+
+	Pool        []*PooledTx `json:"pool"`
+	Interrupted bool        `json:"interrupted"` // Whether the building was interrupted, e.g. by the payload delivery
+}
+
+// newPayloadRecord creates the record of a payload built with the given params,
+// capturing the current miner settings. The pending transactions are added when
+// they are retrieved from the txpool.
+func (miner *Miner) newPayloadRecord(params *generateParams) *PayloadRecord {
+	miner.confMu.RLock()
+	defer miner.confMu.RUnlock()
+
+	return &PayloadRecord{
+		Parent:           params.parentHash,
+		Timestamp:        params.timestamp,
+		FeeRecipient:     params.coinbase,
+		Random:           params.random,
+		Withdrawals:      params.withdrawals,
+		Transactions:     params.txs,
+		GasLimit:         params.gasLimit,
+		EIP1559Params:    params.eip1559Params,
+		GasCeil:          miner.config.GasCeil,
+		GasPrice:         miner.config.GasPrice,
+		ExtraData:        miner.config.ExtraData,
+		EffectiveGasCeil: miner.config.EffectiveGasCeil,
+		MaxDABlockSize:   miner.config.MaxDABlockSize,
+		CommitPolicy:     miner.config.CommitOrderingPolicy,
+		Prio:             miner.prio,
+	}
+}
+
+// snapshot records the pending transactions offered by the txpool.
+func (r *PayloadRecord) snapshot(pending ...map[common.Address][]*txpool.LazyTransaction) {
+	r.Pool = r.Pool[:0]
+	for _, accounts := range pending {
+		for _, txs := range accounts {
+			for _, ltx := range txs {
+				tx := ltx.Resolve()
+				if tx == nil {
+					continue // evicted in the meantime, cannot be included anyway
+				}
+				r.Pool = append(r.Pool, &PooledTx{Tx: tx, Time: ltx.Time, Conditional: tx.Conditional()})
+			}
+		}
+	}
+}
+
+// pending reconstructs the plain and blob pending transactions offered by the
+// txpool from the recorded snapshot.
+func (r *PayloadRecord) pending(signer types.Signer) (map[common.Address][]*txpool.LazyTransaction, map[common.Address][]*txpool.LazyTransaction) {
+	var (
+		plainTxs = make(map[common.Address][]*txpool.LazyTransaction)
+		blobTxs  = make(map[common.Address][]*txpool.LazyTransaction)
+	)
+	for _, ptx := range r.Pool {
+		tx := ptx.Tx
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			log.Warn("Skipping invalid recorded transaction", "hash", tx.Hash(), "err", err)
+			continue
+		}
+		tx.SetTime(ptx.Time)
+		if ptx.Conditional != nil {
+			tx.SetConditional(ptx.Conditional)
+		}
+		if ptx.Rejected {
+			tx.SetRejected()
+		}
+		ltx := &txpool.LazyTransaction{
+			Hash:      tx.Hash(),
+			Tx:        tx,
+			Time:      ptx.Time,
+			GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+			GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+			Gas:       tx.Gas(),
+			BlobGas:   tx.BlobGas(),
+		}
+		if tx.Type() == types.BlobTxType {
+			blobTxs[from] = append(blobTxs[from], ltx)
+		} else {
+			ltx.DABytes = tx.RollupCostData().EstimatedDASize()
+			plainTxs[from] = append(plainTxs[from], ltx)
+		}
+	}
+	return plainTxs, blobTxs
+}
+
+// params returns the block building parameters rebuilding the recorded payload
+// from the recorded txpool snapshot.
+func (r *PayloadRecord) params() *generateParams {
+	return &generateParams{
+		timestamp:     r.Timestamp,
+		forceTime:     true,
+		parentHash:    r.Parent,
+		coinbase:      r.FeeRecipient,
+		random:        r.Random,
+		withdrawals:   r.Withdrawals,
+		txs:           r.Transactions,
+		gasLimit:      r.GasLimit,
+		eip1559Params: r.EIP1559Params,
+		replay:        r,
+	}
+}
+
+// writePayloadRecords persists the records of a delivered payload block. Only
+// the delivered version of a payload is recorded, the records are pruned along
+// with the block when it leaves the chain history.
+func (miner *Miner) writePayloadRecords(block *types.Block, record *PayloadRecord, accessLists []types.AccessList) {
+	if record != nil {
+		miner.writePayloadRecord(block.Hash(), record)
+	}
+	if accessLists != nil {
+		miner.writeAccessLists(block, accessLists)
+	}
+}
+
+// writePayloadRecord persists the record of the locally built block with the
+// given hash, if the backend provides a database.
+func (miner *Miner) writePayloadRecord(hash common.Hash, record *PayloadRecord) {
+	b, ok := miner.backend.(BackendWithDatabase)
+	if !ok {
+		return
+	}
+	for _, ptx := range record.Pool {
+		ptx.Rejected = ptx.Tx.Rejected()
+	}
+	blob, err := json.Marshal(record)
+	if err != nil {
+		log.Error("Failed to encode payload record", "hash", hash, "err", err)
+		return
+	}
+	rawdb.WritePayloadRecord(b.ChainDb(), hash, blob)
+}
+
+// PayloadRecord retrieves the recorded inputs of the locally built block with
+// the given hash.
+func (miner *Miner) PayloadRecord(hash common.Hash) (*PayloadRecord, error) {
+	b, ok := miner.backend.(BackendWithDatabase)
+	if !ok {
+		return nil, errNoPayloadDatabase
+	}
+	blob := rawdb.ReadPayloadRecord(b.ChainDb(), hash)
+	if len(blob) == 0 {
+		return nil, errNoPayloadRecord
+	}
+	record := new(PayloadRecord)
+	if err := json.Unmarshal(blob, record); err != nil {
+		return nil, fmt.Errorf("invalid payload record: %w", err)
+	}
+	return record, nil
+}
+
+// RebuildResult is the outcome of rebuilding a locally built block.
+type RebuildResult struct {
+	Original    *types.Block  // Block as built originally
+	Rebuilt     *types.Block  // Block as built by the replay
+	Interrupted bool          // Whether the original building was interrupted
+	Divergence  int           // Index of the first differing transaction, -1 if all transactions match
+	Missing     []common.Hash // Transactions of the original block which were not included by the replay
+	Unexpected  []common.Hash // Transactions included by the replay which are not in the original block
+}
+
+// RebuildPayload re-runs the block building for a locally built block using
+// its recorded payload attributes, miner settings and txpool snapshot, and
+// reports any divergence between the original and the rebuilt block.
+//
+// The interop executing message checks are not repeated, their original
+// outcome is used instead. Note, an original building interrupted before the
+// txpool snapshot was exhausted legitimately includes fewer transactions.
+func (miner *Miner) RebuildPayload(hash common.Hash) (*RebuildResult, error) {
+	block := miner.chain.GetBlockByHash(hash)
+	if block == nil {
+		return nil, fmt.Errorf("block %#x not found", hash)
+	}
+	record, err := miner.PayloadRecord(hash)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice := record.GasPrice
+	if gasPrice == nil {
+		gasPrice = new(big.Int)
+	}
+	// Replay with a miner instance configured as the original one was
+	replayer := &Miner{
+		config: &Config{
+			ExtraData:        record.ExtraData,
+			GasCeil:          record.GasCeil,
+			GasPrice:         gasPrice,
+			Recommit:         time.Hour, // replays are not time bound
+			EffectiveGasCeil: record.EffectiveGasCeil,
+			MaxDABlockSize:   record.MaxDABlockSize,
+
+			CommitOrderingPolicy: record.CommitPolicy,
+		},
+		engine:  miner.engine,
+		prio:    record.Prio,
+		chain:   miner.chain,
+		pending: &pending{},
+		backend: miner.backend,
+		lifeCtx: miner.lifeCtx,
+	}
+	r := replayer.generateWork(record.params(), false)
+	if r.err != nil {
+		return nil, fmt.Errorf("failed to rebuild block: %w", r.err)
+	}
+	result := &RebuildResult{
+		Original:    block,
+		Rebuilt:     r.block,
+		Interrupted: record.Interrupted,
+		Divergence:  -1,
+	}
+	var (
+		want = block.Transactions()
+		have = r.block.Transactions()
+	)
+	for i := 0; i < max(len(want), len(have)); i++ {
+		if i >= len(want) || i >= len(have) || want[i].Hash() != have[i].Hash() {
+			result.Divergence = i
+			break
+		}
+	}
+	included := make(map[common.Hash]bool)
+	for _, tx := range have {
+		included[tx.Hash()] = true
+	}
+	for _, tx := range want {
+		if !included[tx.Hash()] {
+			result.Missing = append(result.Missing, tx.Hash())
+		}
+		delete(included, tx.Hash())
+	}
+	for _, tx := range have {
+		if included[tx.Hash()] {
+			result.Unexpected = append(result.Unexpected, tx.Hash())
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestRebuildPayload(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	w, b := newTestWorker(t, ethashChainConfig, ethash.NewFaker(), db, 0)
+	w.config.RecordPayloads = true
+	b.txPool.Add(genTxs(1, 8), true)
+
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.NoTxPool = false
+	payload, err := w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	payload.WaitFull()
+
+	// Only the delivered version of the payload is recorded
+	if _, err := w.PayloadRecord(payload.localBlock().Hash()); !errors.Is(err, errNoPayloadRecord) {
+		t.Fatalf("undelivered payload recorded: %v", err)
+	}
+	payload.ResolveFull()
+	block := payload.full
+	if len(block.Transactions()) != 9 {
+		t.Fatalf("wrong number of transactions: have %d, want 9", len(block.Transactions()))
+	}
+	if _, err := w.RebuildPayload(block.Hash()); err == nil {
+		t.Fatal("rebuilt block unknown to the chain")
+	}
+	if _, err := b.chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatalf("failed to insert block: %v", err)
+	}
+	res, err := w.RebuildPayload(block.Hash())
+	if err != nil {
+		t.Fatalf("failed to rebuild payload: %v", err)
+	}
+	if res.Rebuilt.Hash() != block.Hash() || res.Divergence != -1 || len(res.Missing) != 0 || len(res.Unexpected) != 0 {
+		t.Fatalf("rebuild diverged: hash %x, divergence %d, missing %v, unexpected %v", res.Rebuilt.Hash(), res.Divergence, res.Missing, res.Unexpected)
+	}
+	// Drop a transaction from the recorded pool, the rebuild must report the
+	// transactions of the sender from that nonce on as missing.
+	record, err := w.PayloadRecord(block.Hash())
+	if err != nil {
+		t.Fatalf("failed to read payload record: %v", err)
+	}
+	if len(record.Pool) != 9 {
+		t.Fatalf("wrong pool snapshot size: have %d, want 9", len(record.Pool))
+	}
+	for i, ptx := range record.Pool {
+		if ptx.Tx.Nonce() == 5 {
+			record.Pool = append(record.Pool[:i], record.Pool[i+1:]...)
+			break
+		}
+	}
+	blob, _ := json.Marshal(record)
+	rawdb.WritePayloadRecord(db, block.Hash(), blob)
+
+	res, err = w.RebuildPayload(block.Hash())
+	if err != nil {
+		t.Fatalf("failed to rebuild payload: %v", err)
+	}
+	if res.Divergence != 5 || len(res.Missing) != 4 || len(res.Unexpected) != 0 {
+		t.Fatalf("divergence not reported: divergence %d, missing %v, unexpected %v", res.Divergence, res.Missing, res.Unexpected)
+	}
+	// Records are pruned along with their block.
+	rawdb.DeleteBlock(db, block.Hash(), block.NumberU64())
+	if _, err := w.PayloadRecord(block.Hash()); !errors.Is(err, errNoPayloadRecord) {
+		t.Fatalf("wrong error for pruned block: %v", err)
+	}
+}
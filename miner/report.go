@@ -0,0 +1,166 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxPayloadReports is the number of payload reports kept in memory.
+const maxPayloadReports = 256
+
+// TxRevenue is the revenue attribution of a single transaction of a payload.
+type TxRevenue struct {
+	Hash        common.Hash    `json:"hash"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	PriorityFee *hexutil.Big   `json:"priorityFee"`      // Tip paid to the fee recipient
+	BaseFee     *hexutil.Big   `json:"baseFee"`          // Base fee burnt (or paid to the base fee vault on OP Stack chains)
+	DASize      hexutil.Uint64 `json:"daSize,omitempty"` // Estimated compressed size posted to L1
+	DACost      *hexutil.Big   `json:"daCost,omitempty"` // L1 data fee charged, nil outside of OP Stack chains
+	Forced      bool           `json:"forced,omitempty"` // Included by the payload attributes, earning no revenue
+	Conditional bool           `json:"conditional,omitempty"`
+}
+
+// PayloadReport is the revenue breakdown of a locally built payload.
+// Transactions forced by the payload attributes are listed, but their fees are
+// not accounted as revenue.
+type PayloadReport struct {
+	ID           engine.PayloadID `json:"payloadId"`
+	BlockHash    common.Hash      `json:"blockHash"`
+	Number       hexutil.Uint64   `json:"number"`
+	FeeRecipient common.Address   `json:"feeRecipient"`
+	BaseFee      *hexutil.Big     `json:"baseFeePerGas,omitempty"`
+
+	PriorityFees    *hexutil.Big   `json:"priorityFees"`    // Tips of the non-forced transactions
+	ConditionalFees *hexutil.Big   `json:"conditionalFees"` // Tips of the conditional transactions, part of the priority fees
+	BaseFeeBurn     *hexutil.Big   `json:"baseFeeBurn"`     // Base fee of all transactions
+	DASize          hexutil.Uint64 `json:"daSize"`          // Estimated compressed size of all transactions
+	DACost          *hexutil.Big   `json:"daCost"`          // L1 data fees of all transactions
+	ForcedTxs       hexutil.Uint64 `json:"forcedTxs"`
+	Txs             []*TxRevenue   `json:"transactions"`
+}
+
+// newPayloadReport attributes the revenue of the given built payload, whose
+// first forced transactions were included by the payload attributes.
+func (miner *Miner) newPayloadReport(id engine.PayloadID, r *newPayloadResult, forced int) *PayloadReport {
+	var (
+		header          = r.block.Header()
+		priorityFees    = new(big.Int)
+		conditionalFees = new(big.Int)
+		baseFeeBurn     = new(big.Int)
+		daCost          = new(big.Int)
+		daSize          uint64
+		l1Cost          types.L1CostFunc
+	)
+	if miner.chainConfig().Optimism != nil {
+		l1Cost = types.NewL1CostFunc(miner.chainConfig(), r.stateDB)
+	}
+	report := &PayloadReport{
+		ID:           id,
+		BlockHash:    r.block.Hash(),
+		Number:       hexutil.Uint64(header.Number.Uint64()),
+		FeeRecipient: header.Coinbase,
+		BaseFee:      (*hexutil.Big)(header.BaseFee),
+		ForcedTxs:    hexutil.Uint64(min(forced, len(r.block.Transactions()))),
+		Txs:          make([]*TxRevenue, len(r.block.Transactions())),
+	}
+	for i, tx := range r.block.Transactions() {
+		var (
+			gasUsed = new(big.Int).SetUint64(r.receipts[i].GasUsed)
+			tip     = new(big.Int)
+			burn    = new(big.Int)
+		)
+		if tx.SystemTxType() == nil {
+			tip, _ = tx.EffectiveGasTip(header.BaseFee)
+			tip.Mul(tip, gasUsed)
+			if header.BaseFee != nil {
+				burn.Mul(header.BaseFee, gasUsed)
+			}
+		}
+		revenue := &TxRevenue{
+			Hash:        tx.Hash(),
+			GasUsed:     hexutil.Uint64(r.receipts[i].GasUsed),
+			PriorityFee: (*hexutil.Big)(tip),
+			BaseFee:     (*hexutil.Big)(burn),
+			Forced:      i < forced,
+			Conditional: tx.Conditional() != nil,
+		}
+		if l1Cost != nil && tx.SystemTxType() == nil {
+			rcd := tx.RollupCostData()
+			revenue.DASize = hexutil.Uint64(rcd.EstimatedDASize().Uint64())
+			if cost := l1Cost(rcd, header.Time); cost != nil {
+				revenue.DACost = (*hexutil.Big)(cost)
+				daCost.Add(daCost, cost)
+			}
+			daSize += uint64(revenue.DASize)
+		}
+		baseFeeBurn.Add(baseFeeBurn, burn)
+		if !revenue.Forced {
+			priorityFees.Add(priorityFees, tip)
+			if revenue.Conditional {
+				conditionalFees.Add(conditionalFees, tip)
+			}
+		}
+		report.Txs[i] = revenue
+	}
+	report.PriorityFees = (*hexutil.Big)(priorityFees)
+	report.ConditionalFees = (*hexutil.Big)(conditionalFees)
+	report.BaseFeeBurn = (*hexutil.Big)(baseFeeBurn)
+	report.DASize = hexutil.Uint64(daSize)
+	report.DACost = (*hexutil.Big)(daCost)
+	return report
+}
+
+// payloadReports keeps the reports of the recently built payloads, indexed
+// both by payload ID and by block hash.
+type payloadReports struct {
+	byID   *lru.Cache[engine.PayloadID, *PayloadReport]
+	byHash *lru.Cache[common.Hash, *PayloadReport]
+}
+
+func newPayloadReports() *payloadReports {
+	return &payloadReports{
+		byID:   lru.NewCache[engine.PayloadID, *PayloadReport](maxPayloadReports),
+		byHash: lru.NewCache[common.Hash, *PayloadReport](maxPayloadReports),
+	}
+}
+
+// add stores the report of the latest version of a payload.
+func (r *payloadReports) add(report *PayloadReport) {
+	r.byID.Add(report.ID, report)
+	r.byHash.Add(report.BlockHash, report)
+}
+
+// PayloadReport returns the revenue breakdown of the latest version of the
+// recently built payload with the given ID, or nil if unknown.
+func (miner *Miner) PayloadReport(id engine.PayloadID) *PayloadReport {
+	report, _ := miner.reports.byID.Get(id)
+	return report
+}
+
+// BlockReport returns the revenue breakdown of the recently built payload
+// version with the given block hash, or nil if unknown.
+func (miner *Miner) BlockReport(hash common.Hash) *PayloadReport {
+	report, _ := miner.reports.byHash.Get(hash)
+	return report
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestPayloadReport(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	// Build from the txpool, all fees are revenue
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.NoTxPool = false
+	payload, err := w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	payload.WaitFull()
+	envelope := payload.ResolveFull()
+	block := payload.full
+
+	report := w.PayloadReport(args.Id())
+	if report == nil {
+		t.Fatal("missing payload report")
+	}
+	if w.BlockReport(block.Hash()) != report {
+		t.Fatal("payload report not found by block hash")
+	}
+	if report.BlockHash != block.Hash() || report.FeeRecipient != testRecipient || len(report.Txs) != len(pendingTxs) || report.ForcedTxs != 0 {
+		t.Fatalf("wrong payload report: %+v", report)
+	}
+	if report.PriorityFees.ToInt().Cmp(envelope.BlockValue) != 0 {
+		t.Fatalf("wrong priority fees: have %v, want %v", report.PriorityFees, envelope.BlockValue)
+	}
+	burn := new(big.Int).Mul(block.BaseFee(), new(big.Int).SetUint64(block.GasUsed()))
+	if report.BaseFeeBurn.ToInt().Cmp(burn) != 0 {
+		t.Fatalf("wrong base fee burn: have %v, want %v", report.BaseFeeBurn, burn)
+	}
+	if report.DACost.ToInt().Sign() != 0 || report.Txs[0].DACost != nil {
+		t.Fatalf("data availability cost outside of OP Stack chains: %v", report.DACost)
+	}
+
+	// Force the transactions through the payload attributes, they earn nothing
+	args = newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.Transactions = pendingTxs
+	payload, err = w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	envelope = payload.ResolveFull()
+
+	report = w.PayloadReport(args.Id())
+	if report == nil {
+		t.Fatal("missing forced payload report")
+	}
+	if report.ForcedTxs != hexutil.Uint64(len(pendingTxs)) || !report.Txs[0].Forced || report.PriorityFees.ToInt().Sign() != 0 {
+		t.Fatalf("forced transactions accounted as revenue: %+v", report)
+	}
+	if report.Txs[0].PriorityFee.ToInt().Cmp(envelope.BlockValue) != 0 {
+		t.Fatalf("wrong forced transaction tip: have %v, want %v", report.Txs[0].PriorityFee, envelope.BlockValue)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	sealTargetTimer = metrics.NewRegisteredTimer("miner/seal/target", nil)
+	sealActualTimer = metrics.NewRegisteredTimer("miner/seal/actual", nil)
+	sealLateMeter   = metrics.NewRegisteredMeter("miner/seal/late", nil)
+)
+
+// SealTiming configures the delay of the sealing of locally built payloads.
+//
+// A payload requested before its target sealing time is only sealed at that
+// time, the background building including more transactions meanwhile. The
+// target is the delay, plus a random jitter, into the slot, which starts when
+// the consensus client requests the building. It is capped by the building
+// timeout of the payload, and should be kept below the getPayload deadline of
+// the consensus client.
+type SealTiming struct {
+	Delay  time.Duration // Offset into the slot the payloads are sealed at, at the earliest
+	Jitter time.Duration // Upper bound of the random delay added to the offset
+}
+
+// Validate checks the delay and the jitter of the sealing timing.
+func (c *SealTiming) Validate() error {
+	if c.Delay < 0 || c.Jitter < 0 {
+		return errors.New("sealing delay and jitter must not be negative")
+	}
+	if c.Delay == 0 && c.Jitter == 0 {
+		return errors.New("sealing delay or jitter must be set")
+	}
+	return nil
+}
+
+// offset returns the target sealing offset of a payload into its slot.
+func (c *SealTiming) offset() time.Duration {
+	offset := c.Delay
+	if c.Jitter > 0 {
+		offset += time.Duration(rand.Int63n(int64(c.Jitter) + 1))
+	}
+	return offset
+}
+
+// waitSeal delays the sealing of the payload until its target sealing time, if
+// any, and records the target and actual sealing times.
+func (payload *Payload) waitSeal() {
+	if payload.sealAt.IsZero() {
+		return
+	}
+	wait := time.Until(payload.sealAt)
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-payload.stop:
+		}
+	}
+	payload.sealOnce.Do(func() {
+		target, actual := payload.sealAt.Sub(payload.start), time.Since(payload.start)
+		sealTargetTimer.Update(target)
+		sealActualTimer.Update(actual)
+		if wait <= 0 {
+			sealLateMeter.Mark(1)
+		}
+		log.Debug("Sealing payload", "id", payload.id, "target", common.PrettyDuration(target), "actual", common.PrettyDuration(actual))
+	})
+}
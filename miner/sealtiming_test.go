@@ -0,0 +1,74 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSealTimingOffset(t *testing.T) {
+	timing := &SealTiming{Delay: time.Second, Jitter: 100 * time.Millisecond}
+	for i := 0; i < 100; i++ {
+		if offset := timing.offset(); offset < timing.Delay || offset > timing.Delay+timing.Jitter {
+			t.Fatalf("offset out of bounds: %v", offset)
+		}
+	}
+	if err := (&SealTiming{}).Validate(); err == nil {
+		t.Fatal("empty timing accepted")
+	}
+	if err := (&SealTiming{Delay: time.Second, Jitter: -time.Second}).Validate(); err == nil {
+		t.Fatal("negative jitter accepted")
+	}
+}
+
+func TestSealDelay(t *testing.T) {
+	t.Parallel()
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	w.config.SealTiming = &SealTiming{Delay: 300 * time.Millisecond}
+
+	args := newPayloadArgs(b.chain.CurrentBlock().Hash(), nil)
+	args.NoTxPool = false
+	payload, err := w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	// A payload requested early is sealed at the target time
+	envelope := payload.ResolveFull()
+	if elapsed := time.Since(payload.start); elapsed < w.config.SealTiming.Delay {
+		t.Fatalf("payload sealed early: %v", elapsed)
+	}
+	if envelope == nil || len(envelope.ExecutionPayload.Transactions) != len(pendingTxs) {
+		t.Fatal("payload missing pending transactions")
+	}
+	// The target is capped by the building timeout of the payload
+	w.config.SealTiming = &SealTiming{Delay: math.MaxInt64}
+	args.Timestamp++
+	payload, err = w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	defer payload.stopBuilding()
+	if target := payload.sealAt.Sub(payload.start); target == w.config.SealTiming.Delay {
+		t.Fatalf("target not capped: %v", target)
+	}
+}
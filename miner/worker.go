@@ -36,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types/interoptypes"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
@@ -82,6 +83,11 @@ type environment struct {
 
 	noTxs  bool            // true if we are reproducing a block, and do not have to check interop txs
 	rpcCtx context.Context // context to control block-building RPC work. No RPC allowed if nil.
+
+	record *PayloadRecord // Record of the building inputs, nil if not recorded
+	replay *PayloadRecord // Record the block is rebuilt from, nil if building from the txpool
+
+	accessLists []types.AccessList // Access lists of the included transactions, nil if not recorded
 }
 
 const (
@@ -102,6 +108,10 @@ type newPayloadResult struct {
 	receipts []*types.Receipt       // Receipts collected during construction
 	requests [][]byte               // Consensus layer requests collected during block construction
 	witness  *stateless.Witness     // Witness is an optional stateless proof
+	record   *PayloadRecord         // Record of the building inputs, nil if not recorded
+	report   *PayloadReport         // Revenue breakdown of the payload, nil if not reported
+
+	accessLists []types.AccessList // Access lists of the transactions, nil if not recorded
 }
 
 // generateParams wraps various settings for generating sealing task.
@@ -121,6 +131,11 @@ type generateParams struct {
 	isUpdate      bool               // Optional flag indicating that this is building a discardable update
 
 	rpcCtx context.Context // context to control block-building RPC work. No RPC allowed if nil.
+
+	record bool           // Flag whether to record the building inputs, if enabled in the config
+	replay *PayloadRecord // Optional record to rebuild the block from instead of the txpool
+
+	accessLists bool // Flag whether to record the access lists of the transactions
 }
 
 // generateWork generates a sealing block based on the given parameters.
@@ -143,8 +158,16 @@ func (miner *Miner) generateWork(params *generateParams, witness bool) *newPaylo
 		}
 		work.gasPool = new(core.GasPool).AddGas(gasLimit)
 	}
+	if params.record && miner.config.RecordPayloads {
+		work.record = miner.newPayloadRecord(params)
+		work.record.FeeRecipient = work.coinbase // possibly selected by the schedule
+	}
+	work.replay = params.replay
+	if params.accessLists {
+		work.accessLists = []types.AccessList{}
+	}
 
-	misc.EnsureCreate2Deployer(miner.chainConfig, work.header.Time, work.state)
+	misc.EnsureCreate2Deployer(miner.chainConfig(), work.header.Time, work.state)
 
 	for _, tx := range params.txs {
 		from, _ := types.Sender(work.signer, tx)
@@ -166,6 +189,9 @@ func (miner *Miner) generateWork(params *generateParams, witness bool) *newPaylo
 
 		err := miner.fillTransactions(interrupt, work)
 		timer.Stop() // don't need timeout interruption any more
+		if work.record != nil {
+			work.record.Interrupted = err != nil
+		}
 		if errors.Is(err, errBlockInterruptedByTimeout) {
 			log.Warn("Block building is interrupted", "allowance", common.PrettyDuration(miner.config.Recommit))
 		} else if errors.Is(err, errBlockInterruptedByResolve) {
@@ -182,14 +208,14 @@ func (miner *Miner) generateWork(params *generateParams, witness bool) *newPaylo
 		allLogs = append(allLogs, r.Logs...)
 	}
 
-	isIsthmus := miner.chainConfig.IsIsthmus(work.header.Time)
+	isIsthmus := miner.chainConfig().IsIsthmus(work.header.Time)
 
 	// Collect consensus-layer requests if Prague is enabled.
 	var requests [][]byte
-	if miner.chainConfig.IsPrague(work.header.Number, work.header.Time) && !isIsthmus {
+	if miner.chainConfig().IsPrague(work.header.Number, work.header.Time) && !isIsthmus {
 		requests = [][]byte{}
 		// EIP-6110 deposits
-		if err := core.ParseDepositLogs(&requests, allLogs, miner.chainConfig); err != nil {
+		if err := core.ParseDepositLogs(&requests, allLogs, miner.chainConfig()); err != nil {
 			return &newPayloadResult{err: err}
 		}
 		// EIP-7002
@@ -223,6 +249,9 @@ func (miner *Miner) generateWork(params *generateParams, witness bool) *newPaylo
 		receipts: work.receipts,
 		requests: requests,
 		witness:  work.witness,
+		record:   work.record,
+
+		accessLists: work.accessLists,
 	}
 }
 
@@ -251,28 +280,40 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 		}
 		timestamp = parent.Time + 1
 	}
+	// Select the fee recipient from the schedule if any. On OP Stack chains the
+	// fee recipient is part of the payload attributes derived from L1, so the
+	// requested one is always used.
+	number := new(big.Int).Add(parent.Number, common.Big1)
+	coinbase := genParams.coinbase
+	if schedule := miner.config.FeeRecipients; schedule != nil && miner.chainConfig().Optimism == nil {
+		coinbase = schedule.Recipient(number.Uint64())
+	}
 	// Construct the sealing block header.
 	header := &types.Header{
 		ParentHash: parent.Hash(),
-		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Number:     number,
 		GasLimit:   core.CalcGasLimit(parent.GasLimit, miner.config.GasCeil),
 		Time:       timestamp,
-		Coinbase:   genParams.coinbase,
+		Coinbase:   coinbase,
 	}
 	// Set the extra field.
-	if len(miner.config.ExtraData) != 0 && miner.chainConfig.Optimism == nil {
+	if len(miner.config.ExtraData) != 0 && miner.chainConfig().Optimism == nil {
 		// Optimism chains have their own ExtraData handling rules
 		header.Extra = miner.config.ExtraData
 	}
+	if miner.config.CommitOrderingPolicy && miner.chainConfig().Optimism == nil {
+		// The ordering policy commitment takes precedence over custom extra-data
+		header.Extra = miner.orderingPolicy().Commitment()
+	}
 	// Set the randomness field from the beacon chain if it's available.
 	if genParams.random != (common.Hash{}) {
 		header.MixDigest = genParams.random
 	}
 	// Set baseFee and GasLimit if we are on an EIP-1559 chain
-	if miner.chainConfig.IsLondon(header.Number) {
-		header.BaseFee = eip1559.CalcBaseFee(miner.chainConfig, parent, header.Time)
-		if !miner.chainConfig.IsLondon(parent.Number) {
-			parentGasLimit := parent.GasLimit * miner.chainConfig.ElasticityMultiplier()
+	if miner.chainConfig().IsLondon(header.Number) {
+		header.BaseFee = eip1559.CalcBaseFee(miner.chainConfig(), parent, header.Time)
+		if !miner.chainConfig().IsLondon(parent.Number) {
+			parentGasLimit := parent.GasLimit * miner.chainConfig().ElasticityMultiplier()
 			header.GasLimit = core.CalcGasLimit(parentGasLimit, miner.config.GasCeil)
 		}
 	}
@@ -282,7 +323,7 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 		// configure the gas limit of pending blocks with the miner gas limit config when using optimism
 		header.GasLimit = miner.config.GasCeil
 	}
-	if miner.chainConfig.IsHolocene(header.Time) {
+	if miner.chainConfig().IsHolocene(header.Time) {
 		if err := eip1559.ValidateHolocene1559Params(genParams.eip1559Params); err != nil {
 			return nil, err
 		}
@@ -290,8 +331,8 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 		// constants in the header.
 		d, e := eip1559.DecodeHolocene1559Params(genParams.eip1559Params)
 		if d == 0 {
-			d = miner.chainConfig.BaseFeeChangeDenominator(header.Time)
-			e = miner.chainConfig.ElasticityMultiplier()
+			d = miner.chainConfig().BaseFeeChangeDenominator(header.Time)
+			e = miner.chainConfig().ElasticityMultiplier()
 		}
 		header.Extra = eip1559.EncodeHoloceneExtraData(d, e)
 	} else if genParams.eip1559Params != nil {
@@ -304,18 +345,18 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 		return nil, err
 	}
 	// Apply EIP-4844, EIP-4788.
-	if miner.chainConfig.IsCancun(header.Number, header.Time) {
+	if miner.chainConfig().IsCancun(header.Number, header.Time) {
 		var excessBlobGas uint64
-		if miner.chainConfig.IsCancun(parent.Number, parent.Time) {
-			excessBlobGas = eip4844.CalcExcessBlobGas(miner.chainConfig, parent, timestamp)
+		if miner.chainConfig().IsCancun(parent.Number, parent.Time) {
+			excessBlobGas = eip4844.CalcExcessBlobGas(miner.chainConfig(), parent, timestamp)
 		}
 		header.BlobGasUsed = new(uint64)
 		header.ExcessBlobGas = &excessBlobGas
 	}
 	// Could potentially happen if starting to mine in an odd state.
-	// Note genParams.coinbase can be different with header.Coinbase
+	// Note coinbase can be different with header.Coinbase
 	// since clique algorithm can modify the coinbase field in header.
-	env, err := miner.makeEnv(parent, header, genParams.coinbase, witness, genParams.rpcCtx)
+	env, err := miner.makeEnv(parent, header, coinbase, witness, genParams.rpcCtx)
 	if err != nil {
 		log.Error("Failed to create sealing context", "err", err)
 		return nil, err
@@ -324,7 +365,7 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 	if header.ParentBeaconRoot != nil {
 		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, env.evm)
 	}
-	if miner.chainConfig.IsPrague(header.Number, header.Time) {
+	if miner.chainConfig().IsPrague(header.Number, header.Time) {
 		core.ProcessParentBlockHash(header.ParentHash, env.evm)
 	}
 	return env, nil
@@ -337,7 +378,7 @@ func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase
 	if err != nil {
 		return nil, err
 	}
-	if miner.chainConfig.Optimism != nil { // Allow the miner to reorg its own chain arbitrarily deep
+	if miner.chainConfig().Optimism != nil { // Allow the miner to reorg its own chain arbitrarily deep
 		if historicalBackend, ok := miner.backend.(BackendWithHistoricalState); ok {
 			var release tracers.StateReleaseFunc
 			parentBlock := miner.backend.BlockChain().GetBlockByHash(parent.Hash())
@@ -359,12 +400,12 @@ func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase
 	}
 	// Note the passed coinbase may be different with header.Coinbase.
 	return &environment{
-		signer:   types.MakeSigner(miner.chainConfig, header.Number, header.Time),
+		signer:   types.MakeSigner(miner.chainConfig(), header.Number, header.Time),
 		state:    state,
 		coinbase: coinbase,
 		header:   header,
 		witness:  state.Witness(),
-		evm:      vm.NewEVM(core.NewEVMBlockContext(header, miner.chain, &coinbase, miner.chainConfig, state), state, miner.chainConfig, vm.Config{}),
+		evm:      vm.NewEVM(core.NewEVMBlockContext(header, miner.chain, &coinbase, miner.chainConfig(), state), state, miner.chainConfig(), vm.Config{}),
 		rpcCtx:   rpcCtx,
 	}, nil
 }
@@ -406,7 +447,7 @@ func (miner *Miner) commitBlobTransaction(env *environment, tx *types.Transactio
 	// isn't really a better place right now. The blob gas limit is checked at block validation time
 	// and not during execution. This means core.ApplyTransaction will not return an error if the
 	// tx has too many blobs. So we have to explicitly check it here.
-	maxBlobs := eip4844.MaxBlobsPerBlock(miner.chainConfig, env.header.Time)
+	maxBlobs := eip4844.MaxBlobsPerBlock(miner.chainConfig(), env.header.Time)
 	if env.blobs+len(sc.Blobs) > maxBlobs {
 		return errors.New("max data blobs reached")
 	}
@@ -431,21 +472,35 @@ func (miner *Miner) applyTransaction(env *environment, tx *types.Transaction) (*
 	)
 	if !env.noTxs && miner.chain.Config().IsInterop(env.header.Time) {
 		// avoid execution if the interop check fails
-		if err := miner.checkInterop(env.rpcCtx, tx, env.header.Time); err != nil {
+		if env.replay != nil {
+			// The executing messages can't be re-checked when replaying, rely
+			// on the outcome of the original check instead.
+			if tx.Rejected() {
+				return nil, errors.New("transaction was previously rejected")
+			}
+		} else if err := miner.checkInterop(env.rpcCtx, tx, env.header.Time); err != nil {
 			return nil, err
 		}
 	}
+	var tracer *logger.AccessListTracer
+	if env.accessLists != nil {
+		tracer = miner.accessListTracer(env, tx)
+		env.evm.Config.Tracer = tracer.Hooks()
+		defer func() { env.evm.Config.Tracer = nil }()
+	}
 	receipt, err := core.ApplyTransaction(env.evm, env.gasPool, env.state, env.header, tx, &env.header.GasUsed)
 	if err != nil {
 		env.state.RevertToSnapshot(snap)
 		env.gasPool.SetGas(gp)
+	} else if tracer != nil {
+		env.accessLists = append(env.accessLists, tracer.AccessList())
 	}
 	return receipt, err
 }
 
 func (miner *Miner) checkInterop(ctx context.Context, tx *types.Transaction, logTimestamp uint64) error {
-	if tx.Type() == types.DepositTxType {
-		return nil // deposit-txs are always safe
+	if tx.SystemTxType() != nil {
+		return nil // deposit-txs and other system txs are always safe
 	}
 	if tx.Rejected() {
 		return errors.New("transaction was previously rejected")
@@ -479,6 +534,11 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
 	}
 	blockDABytes := new(big.Int)
+
+	miner.confMu.RLock()
+	policy := miner.addrPolicy
+	miner.confMu.RUnlock()
+
 	for {
 		// Check interruption signal and abort building if it's fired.
 		if interrupt != nil {
@@ -493,7 +553,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		}
 		// If we don't have enough blob space for any further blob transactions,
 		// skip that list altogether
-		if !blobTxs.Empty() && env.blobs >= eip4844.MaxBlobsPerBlock(miner.chainConfig, env.header.Time) {
+		if !blobTxs.Empty() && env.blobs >= eip4844.MaxBlobsPerBlock(miner.chainConfig(), env.header.Time) {
 			log.Trace("Not enough blob space for further blob transactions")
 			blobTxs.Clear()
 			// Fall though to pick up any plain txs
@@ -531,8 +591,8 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		// Most of the blob gas logic here is agnostic as to if the chain supports
 		// blobs or not, however the max check panics when called on a chain without
 		// a defined schedule, so we need to verify it's safe to call.
-		if miner.chainConfig.IsCancun(env.header.Number, env.header.Time) {
-			left := eip4844.MaxBlobsPerBlock(miner.chainConfig, env.header.Time) - env.blobs
+		if miner.chainConfig().IsCancun(env.header.Number, env.header.Time) {
+			left := eip4844.MaxBlobsPerBlock(miner.chainConfig(), env.header.Time) - env.blobs
 			if left < int(ltx.BlobGas/params.BlobTxBlobGasPerBlob) {
 				log.Trace("Not enough blob space left for transaction", "hash", ltx.Hash, "left", left, "needed", ltx.BlobGas/params.BlobTxBlobGasPerBlob)
 				txs.Pop()
@@ -572,8 +632,13 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 
 		// Check whether the tx is replay protected. If we're not in the EIP155 hf
 		// phase, start ignoring the sender until we do.
-		if tx.Protected() && !miner.chainConfig.IsEIP155(env.header.Number) {
-			log.Trace("Ignoring replay protected transaction", "hash", ltx.Hash, "eip155", miner.chainConfig.EIP155Block)
+		if tx.Protected() && !miner.chainConfig().IsEIP155(env.header.Number) {
+			log.Trace("Ignoring replay protected transaction", "hash", ltx.Hash, "eip155", miner.chainConfig().EIP155Block)
+			txs.Pop()
+			continue
+		}
+		// Skip the account if the address policy refuses the transaction
+		if policy != nil && policy.Enforce(txpool.PolicyStageInclusion, tx, from) != nil {
 			txs.Pop()
 			continue
 		}
@@ -637,13 +702,21 @@ func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment)
 		filter.BaseFee = uint256.MustFromBig(env.header.BaseFee)
 	}
 	if env.header.ExcessBlobGas != nil {
-		filter.BlobFee = uint256.MustFromBig(eip4844.CalcBlobFee(miner.chainConfig, env.header))
+		filter.BlobFee = uint256.MustFromBig(eip4844.CalcBlobFee(miner.chainConfig(), env.header))
 	}
-	filter.OnlyPlainTxs, filter.OnlyBlobTxs = true, false
-	pendingPlainTxs := miner.txpool.Pending(filter)
+	var pendingPlainTxs, pendingBlobTxs map[common.Address][]*txpool.LazyTransaction
+	if env.replay != nil {
+		pendingPlainTxs, pendingBlobTxs = env.replay.pending(env.signer)
+	} else {
+		filter.OnlyPlainTxs, filter.OnlyBlobTxs = true, false
+		pendingPlainTxs = miner.txpool.Pending(filter)
 
-	filter.OnlyPlainTxs, filter.OnlyBlobTxs = false, true
-	pendingBlobTxs := miner.txpool.Pending(filter)
+		filter.OnlyPlainTxs, filter.OnlyBlobTxs = false, true
+		pendingBlobTxs = miner.txpool.Pending(filter)
+	}
+	if env.record != nil {
+		env.record.snapshot(pendingPlainTxs, pendingBlobTxs)
+	}
 
 	// Split the pending transactions into locals and remotes.
 	prioPlainTxs, normalPlainTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingPlainTxs
@@ -18,8 +18,11 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/crc32"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -43,6 +46,9 @@ func (n *Node) apis() []rpc.API {
 		}, {
 			Namespace: "debug",
 			Service:   &p2pDebugAPI{n},
+		}, {
+			Namespace: "debug",
+			Service:   &rpcDebugAPI{n},
 		}, {
 			Namespace: "web3",
 			Service:   &web3API{n},
@@ -177,10 +183,12 @@ func (api *adminAPI) StartHTTP(host *string, port *int, cors *string, apis *stri
 	config := httpConfig{
 		CorsAllowedOrigins: api.node.config.HTTPCors,
 		Vhosts:             api.node.config.HTTPVirtualHosts,
+		ModulePolicies:     api.node.config.HTTPModulePolicies,
 		Modules:            api.node.config.HTTPModules,
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			usage:                  api.node.rpcUsage,
 		},
 	}
 	if cors != nil {
@@ -252,13 +260,20 @@ func (api *adminAPI) StartWS(host *string, port *int, allowedOrigins *string, ap
 	}
 
 	// Determine config.
+	subPolicy, err := api.node.config.wsSubscriptionPolicy()
+	if err != nil {
+		return false, err
+	}
 	config := wsConfig{
 		Modules: api.node.config.WSModules,
 		Origins: api.node.config.WSOrigins,
 		// ExposeAll: api.node.config.WSExposeAll,
+		subscriptionBuffer: api.node.config.WSSubscriptionBuffer,
+		subscriptionPolicy: subPolicy,
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			usage:                  api.node.rpcUsage,
 		},
 	}
 	if apis != nil {
@@ -317,11 +332,59 @@ func (api *adminAPI) NodeInfo() (*p2p.NodeInfo, error) {
 	return server.NodeInfo(), nil
 }
 
+// Subscriptions retrieves the subscriptions active on the websocket and IPC
+// endpoints, with the state of their notification queues.
+func (api *adminAPI) Subscriptions() []rpc.SubscriptionInfo {
+	return api.node.rpcSubscriptions()
+}
+
+// AddJWTSecret makes the authenticated endpoints accept the tokens signed with
+// the given secret besides the current ones, to rotate the secret shared with a
+// client without restarting. The secret isn't persisted across restarts.
+func (api *adminAPI) AddJWTSecret(id string, secret hexutil.Bytes) (bool, error) {
+	if len(secret) != 32 {
+		return false, fmt.Errorf("invalid JWT secret length %d, want 32", len(secret))
+	}
+	if api.node.jwtKeys.empty() {
+		return false, errors.New("authenticated endpoints not enabled")
+	}
+	if err := api.node.jwtKeys.add(id, secret); err != nil {
+		return false, err
+	}
+	api.node.log.Info("Added JWT secret", "id", id, "crc32", fmt.Sprintf("%#x", crc32.ChecksumIEEE(secret)))
+	return true, nil
+}
+
+// RemoveJWTSecret stops accepting the tokens signed with the secret of the given
+// identifier on the authenticated endpoints.
+func (api *adminAPI) RemoveJWTSecret(id string) (bool, error) {
+	if err := api.node.jwtKeys.remove(id); err != nil {
+		return false, err
+	}
+	api.node.log.Info("Removed JWT secret", "id", id)
+	return true, nil
+}
+
 // Datadir retrieves the current data directory the node is using.
 func (api *adminAPI) Datadir() string {
 	return api.node.DataDir()
 }
 
+// Shutdown starts a graceful shutdown of the node, allowing the services the
+// given number of seconds, or the configured drain time if omitted, to finish
+// their work before the node is closed. It returns once the shutdown started.
+func (api *adminAPI) Shutdown(drainSeconds *uint64) (bool, error) {
+	drain := api.node.config.ShutdownDrain
+	if drainSeconds != nil {
+		drain = time.Duration(*drainSeconds) * time.Second
+	}
+	if !api.node.shuttingDown.CompareAndSwap(false, true) {
+		return false, ErrShuttingDown
+	}
+	go api.node.shutdown(drain)
+	return true, nil
+}
+
 // web3API offers helper utils
 type web3API struct {
 	stack *Node
@@ -350,3 +413,19 @@ func (s *p2pDebugAPI) DiscoveryV4Table() [][]discover.BucketNode {
 	}
 	return nil
 }
+
+// rpcDebugAPI provides access to the accounting of the RPC endpoints.
+type rpcDebugAPI struct {
+	stack *Node
+}
+
+// RpcUsage returns the execution cost of the calls served over the HTTP and
+// WebSocket endpoints per client and method, accumulated since the given unix
+// timestamp or over the last day if omitted.
+func (s *rpcDebugAPI) RpcUsage(since *uint64) []rpc.UsageStats {
+	var from time.Time
+	if since != nil {
+		from = time.Unix(int64(*since), 0)
+	}
+	return s.stack.rpcUsage.Usage(from)
+}
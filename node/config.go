@@ -23,7 +23,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -102,6 +104,10 @@ type Config struct {
 	// relative), then that specific path is enforced. An empty path disables IPC.
 	IPCPath string
 
+	// IPCPermissions are the file permissions of the IPC socket, in octal. The
+	// socket is only accessible by its owner if empty.
+	IPCPermissions string `toml:",omitempty"`
+
 	// HTTPHost is the host interface on which to start the HTTP RPC server. If this
 	// field is empty, no HTTP API endpoint will be started.
 	HTTPHost string
@@ -125,6 +131,11 @@ type Config struct {
 	// Requests using ip address directly are not affected
 	HTTPVirtualHosts []string `toml:",omitempty"`
 
+	// HTTPModulePolicies overrides HTTPCors and HTTPVirtualHosts for individual
+	// API namespaces served on the HTTP endpoint, keyed by namespace (e.g. "debug").
+	// A request touching several namespaces must satisfy the policy of each.
+	HTTPModulePolicies map[string]HTTPModulePolicy `toml:",omitempty"`
+
 	// HTTPModules is a list of API modules to expose via the HTTP RPC interface.
 	// If the module list is empty, all RPC API endpoints designated public will be
 	// exposed.
@@ -137,6 +148,16 @@ type Config struct {
 	// HTTPPathPrefix specifies a path prefix on which http-rpc is to be served.
 	HTTPPathPrefix string `toml:",omitempty"`
 
+	// HTTPTLSCert and HTTPTLSKey are the certificate and private key files to
+	// serve HTTPS with, on the HTTP RPC endpoint. HTTP/2 is negotiated with the
+	// clients supporting it.
+	HTTPTLSCert string `toml:",omitempty"`
+	HTTPTLSKey  string `toml:",omitempty"`
+
+	// HTTPH2C enables HTTP/2 over cleartext connections (h2c) on the HTTP RPC
+	// endpoint, for the clients connecting with prior knowledge or upgrading.
+	HTTPH2C bool `toml:",omitempty"`
+
 	// AuthAddr is the listening address on which authenticated APIs are provided.
 	AuthAddr string `toml:",omitempty"`
 
@@ -147,6 +168,33 @@ type Config struct {
 	// for the authenticated api. This is by default {'localhost'}.
 	AuthVirtualHosts []string `toml:",omitempty"`
 
+	// AuthModules is the list of namespaces shared with public APIs whose
+	// authenticated-only APIs are served on the authenticated endpoints.
+	// Namespaces consisting of authenticated APIs only are always served.
+	AuthModules []string `toml:",omitempty"`
+
+	// AuthTLSCert and AuthTLSKey are the certificate and private key files to
+	// serve the authenticated api over HTTPS with.
+	AuthTLSCert string `toml:",omitempty"`
+	AuthTLSKey  string `toml:",omitempty"`
+
+	// AuthTLSClientCA is the CA bundle file verifying the TLS client certificates
+	// required by the authenticated api (mutual TLS).
+	AuthTLSClientCA string `toml:",omitempty"`
+
+	// AuthTLSClientPins are the hex-encoded SHA-256 fingerprints of the TLS client
+	// certificates accepted by the authenticated api. Pinned certificates don't
+	// need to be signed by AuthTLSClientCA.
+	AuthTLSClientPins []string `toml:",omitempty"`
+
+	// AuthTLSClientNames are the subject alternative names authorized to access
+	// the authenticated api, one of which the TLS client certificate must have.
+	AuthTLSClientNames []string `toml:",omitempty"`
+
+	// AuthJWTDisabled disables the JWT authentication of the authenticated api,
+	// leaving it to the TLS client certificates.
+	AuthJWTDisabled bool `toml:",omitempty"`
+
 	// WSHost is the host interface on which to start the websocket RPC server. If
 	// this field is empty, no websocket API endpoint will be started.
 	WSHost string
@@ -176,6 +224,17 @@ type Config struct {
 	// private APIs to untrusted users is a major security risk.
 	WSExposeAll bool `toml:",omitempty"`
 
+	// WSSubscriptionBuffer is the number of notifications queued per websocket
+	// subscription for clients reading them slower than they are produced. Zero
+	// disables the queue, notifications are then sent synchronously.
+	WSSubscriptionBuffer int `toml:",omitempty"`
+
+	// WSSubscriptionPolicy is the handling of the notifications of a websocket
+	// subscription whose queue is full: "disconnect" closes the connection,
+	// "drop-oldest" discards the oldest queued notification and "pause" blocks
+	// the producer of the notifications until the client catches up.
+	WSSubscriptionPolicy string `toml:",omitempty"`
+
 	// GraphQLCors is the Cross-Origin Resource Sharing header to send to requesting
 	// clients. Please be aware that CORS is a browser enforced security, it's fully
 	// useless for custom HTTP clients.
@@ -207,10 +266,86 @@ type Config struct {
 	// JWTSecret is the path to the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
 
+	// JWTSecrets are additional jwt secrets accepted by the authenticated
+	// endpoints, as paths to hex-encoded secrets by identifier.
+	JWTSecrets map[string]string `toml:",omitempty"`
+
 	// EnablePersonal enables the deprecated personal namespace.
 	EnablePersonal bool `toml:"-"`
 
 	DBEngine string `toml:",omitempty"`
+
+	// ShutdownDrain is the time allowed for the services to finish their work,
+	// such as an in-flight payload, in a graceful shutdown before the node is
+	// closed.
+	ShutdownDrain time.Duration `toml:",omitempty"`
+
+	// RPCEndpoints are additional RPC endpoints served besides the HTTP, WebSocket
+	// and IPC ones, each with its own modules and access settings.
+	RPCEndpoints []RPCEndpoint `toml:",omitempty"`
+}
+
+// HTTPModulePolicy is the CORS and virtual host configuration of a single API
+// namespace on the HTTP endpoint. A nil list inherits the server-wide setting,
+// while an empty one disallows all cross-origin requests or named hosts.
+type HTTPModulePolicy struct {
+	Cors   []string
+	Vhosts []string
+}
+
+// RPCEndpoint is the configuration of an additional endpoint serving JSON-RPC over
+// HTTP, and optionally WebSocket, on a TCP address or a unix socket.
+type RPCEndpoint struct {
+	// Name identifies the endpoint in logs.
+	Name string
+
+	// Addr is the TCP listening address (host:port) of the endpoint, or the path
+	// of its unix socket prefixed with "unix:".
+	Addr string
+
+	// SocketPermissions are the file permissions of the unix socket, in octal. The
+	// socket is only accessible by its owner if empty.
+	SocketPermissions string `toml:",omitempty"`
+
+	// Modules is the list of API modules served by the endpoint. The modules of
+	// authenticated APIs are only available if JWTSecret is set.
+	Modules []string
+
+	// PathPrefix specifies a path prefix on which JSON-RPC is served.
+	PathPrefix string `toml:",omitempty"`
+
+	// Cors is the list of origins allowed to send cross-origin requests.
+	Cors []string `toml:",omitempty"`
+
+	// VirtualHosts is the list of hostnames allowed on incoming requests,
+	// {"localhost"} if empty.
+	VirtualHosts []string `toml:",omitempty"`
+
+	// WS enables JSON-RPC over WebSocket on the endpoint, for the connections
+	// from WSOrigins.
+	WS        bool     `toml:",omitempty"`
+	WSOrigins []string `toml:",omitempty"`
+
+	// JWTSecret is the path to the hex-encoded JWT secret authenticating the
+	// requests. The endpoint is unauthenticated if empty.
+	JWTSecret string `toml:",omitempty"`
+
+	// Timeouts are the HTTP timeouts of the endpoint, the HTTPTimeouts of the
+	// node are used if unset.
+	Timeouts rpc.HTTPTimeouts `toml:",omitempty"`
+}
+
+// parseSocketPermissions parses the octal file permissions of a unix socket,
+// defaulting to owner-only access.
+func parseSocketPermissions(perm string) (os.FileMode, error) {
+	if perm == "" {
+		return 0600, nil
+	}
+	mode, err := strconv.ParseUint(perm, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid socket permissions %q", perm)
+	}
+	return os.FileMode(mode), nil
 }
 
 // IPCEndpoint resolves an IPC endpoint based on a configured value, taking into
@@ -294,6 +429,14 @@ func (c *Config) ExtRPCEnabled() bool {
 	return c.HTTPHost != "" || c.WSHost != ""
 }
 
+// wsSubscriptionPolicy returns the backpressure policy of websocket subscriptions.
+func (c *Config) wsSubscriptionPolicy() (rpc.BackpressurePolicy, error) {
+	if c.WSSubscriptionPolicy == "" {
+		return rpc.BackpressureDisconnect, nil
+	}
+	return rpc.ParseBackpressurePolicy(c.WSSubscriptionPolicy)
+}
+
 // NodeName returns the devp2p node identifier.
 func (c *Config) NodeName() string {
 	name := c.name()
@@ -21,6 +21,7 @@ import (
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/nat"
@@ -64,6 +65,8 @@ var DefaultConfig = Config{
 	HTTPTimeouts:         rpc.DefaultHTTPTimeouts,
 	WSPort:               DefaultWSPort,
 	WSModules:            []string{"net", "web3"},
+	WSSubscriptionBuffer: 10000,
+	WSSubscriptionPolicy: rpc.BackpressureDisconnect.String(),
 	BatchRequestLimit:    1000,
 	BatchResponseMaxSize: 25 * 1000 * 1000,
 	GraphQLVirtualHosts:  []string{"localhost"},
@@ -72,7 +75,8 @@ var DefaultConfig = Config{
 		MaxPeers:   50,
 		NAT:        nat.Any(),
 	},
-	DBEngine: "", // Use whatever exists, will default to Pebble if non-existent and supported
+	DBEngine:      "", // Use whatever exists, will default to Pebble if non-existent and supported
+	ShutdownDrain: 5 * time.Second,
 }
 
 // DefaultDataDir is the default data directory to use for the databases and other
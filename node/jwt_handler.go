@@ -17,35 +17,136 @@
 package node
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang-jwt/jwt/v4"
 )
 
 const jwtExpiryTimeout = 60 * time.Second
 
+// jwtClaims are the claims of the tokens accepted by the authenticated endpoints.
+// Besides the registered ones, clients may identify themselves with the "id"
+// claim as defined by the engine API authentication spec.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	ID string `json:"id,omitempty"`
+}
+
+// defaultJWTSecretID is the identifier of the JWT secret configured for an
+// authenticated endpoint.
+const defaultJWTSecretID = "default"
+
+// jwtKeyring is the set of secrets accepted by the authenticated endpoints, by
+// identifier. Secrets can be added and removed while the endpoints are serving,
+// so that the secret shared with a client can be rotated without restarting.
+type jwtKeyring struct {
+	mu   sync.RWMutex
+	keys map[string][]byte
+}
+
+func newJWTKeyring() *jwtKeyring {
+	return &jwtKeyring{keys: make(map[string][]byte)}
+}
+
+// add accepts a new secret with the given identifier.
+func (k *jwtKeyring) add(id string, secret []byte) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if id == "" {
+		return errors.New("empty JWT secret identifier")
+	}
+	if _, ok := k.keys[id]; ok {
+		return fmt.Errorf("JWT secret %q already exists", id)
+	}
+	k.keys[id] = secret
+	return nil
+}
+
+// remove stops accepting the secret with the given identifier. The last secret
+// can't be removed, that would lock all clients out.
+func (k *jwtKeyring) remove(id string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.keys[id]; !ok {
+		return fmt.Errorf("unknown JWT secret %q", id)
+	}
+	if len(k.keys) == 1 {
+		return errors.New("can't remove the last JWT secret")
+	}
+	delete(k.keys, id)
+	return nil
+}
+
+// empty reports whether the keyring has no secrets.
+func (k *jwtKeyring) empty() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return len(k.keys) == 0
+}
+
+// parse parses the token into the claims, verifying it against each of the
+// accepted secrets until one matches. The identifier of the matching secret is
+// returned along with the token.
+func (k *jwtKeyring) parse(strToken string, claims *jwtClaims) (*jwt.Token, string, error) {
+	k.mu.RLock()
+	ids := make([]string, 0, len(k.keys))
+	secrets := make([][]byte, 0, len(k.keys))
+	for id, secret := range k.keys {
+		ids = append(ids, id)
+		secrets = append(secrets, secret)
+	}
+	k.mu.RUnlock()
+
+	var (
+		token *jwt.Token
+		err   = errors.New("no JWT secret")
+	)
+	for i, secret := range secrets {
+		*claims = jwtClaims{}
+
+		// We explicitly set only HS256 allowed, and also disables the
+		// claim-check: the RegisteredClaims internally requires 'iat' to
+		// be no later than 'now', but we allow for a bit of drift.
+		token, err = jwt.ParseWithClaims(strToken, claims, func(token *jwt.Token) (interface{}, error) {
+			return secret, nil
+		}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithoutClaimsValidation())
+		if err == nil {
+			return token, ids[i], nil
+		}
+	}
+	return token, "", err
+}
+
 type jwtHandler struct {
-	keyFunc func(token *jwt.Token) (interface{}, error)
-	next    http.Handler
+	keys *jwtKeyring
+	next http.Handler
 }
 
 // newJWTHandler creates a http.Handler with jwt authentication support.
 func newJWTHandler(secret []byte, next http.Handler) http.Handler {
-	return &jwtHandler{
-		keyFunc: func(token *jwt.Token) (interface{}, error) {
-			return secret, nil
-		},
-		next: next,
-	}
+	return newJWTKeyringHandler(singleJWTKeyring(secret), next)
+}
+
+// newJWTKeyringHandler creates a http.Handler with jwt authentication support,
+// accepting the tokens signed with any of the secrets of the keyring.
+func newJWTKeyringHandler(keys *jwtKeyring, next http.Handler) http.Handler {
+	return &jwtHandler{keys: keys, next: next}
 }
 
 // ServeHTTP implements http.Handler
 func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	var (
 		strToken string
-		claims   jwt.RegisteredClaims
+		claims   jwtClaims
 	)
 	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
 		strToken = strings.TrimPrefix(auth, "Bearer ")
@@ -54,12 +155,7 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 		http.Error(out, "missing token", http.StatusUnauthorized)
 		return
 	}
-	// We explicitly set only HS256 allowed, and also disables the
-	// claim-check: the RegisteredClaims internally requires 'iat' to
-	// be no later than 'now', but we allow for a bit of drift.
-	token, err := jwt.ParseWithClaims(strToken, &claims, handler.keyFunc,
-		jwt.WithValidMethods([]string{"HS256"}),
-		jwt.WithoutClaimsValidation())
+	token, keyID, err := handler.keys.parse(strToken, &claims)
 
 	switch {
 	case err != nil:
@@ -75,6 +171,11 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
 		http.Error(out, "future token", http.StatusUnauthorized)
 	default:
+		ctx := rpc.ContextWithAuthCredentials(r.Context(), "jwt:"+keyID)
+		if claims.ID != "" {
+			ctx = rpc.ContextWithAuthID(ctx, claims.ID)
+		}
+		r = r.WithContext(ctx)
 		handler.next.ServeHTTP(out, r)
 	}
 }
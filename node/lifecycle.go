@@ -16,6 +16,8 @@
 
 package node
 
+import "context"
+
 // Lifecycle encompasses the behavior of services that can be started and stopped
 // on the node. Lifecycle management is delegated to the node, but it is the
 // responsibility of the service-specific package to configure and register the
@@ -29,3 +31,17 @@ type Lifecycle interface {
 	// are all terminated.
 	Stop() error
 }
+
+// Drainer is implemented by the lifecycles which have work to finish before
+// being stopped in a graceful shutdown.
+type Drainer interface {
+	// DrainPhases returns the phases to run in order before the node is closed.
+	DrainPhases() []DrainPhase
+}
+
+// DrainPhase is a named step of a graceful shutdown. Run should return once
+// the step is completed, or the context is canceled if it can be abandoned.
+type DrainPhase struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
@@ -21,13 +21,16 @@ import (
 	"errors"
 	"fmt"
 	"hash/crc32"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
@@ -55,16 +58,21 @@ type Node struct {
 	server        *p2p.Server   // Currently running P2P networking layer
 	startStopLock sync.Mutex    // Start/Stop are protected by an additional lock
 	state         int           // Tracks state of node lifecycle
+	shuttingDown  atomic.Bool   // Whether a graceful shutdown was started
 
 	lock          sync.Mutex
-	lifecycles    []Lifecycle // All registered backends, services, and auxiliary services that have a lifecycle
-	rpcAPIs       []rpc.API   // List of APIs currently provided by the node
-	http          *httpServer //
-	ws            *httpServer //
-	httpAuth      *httpServer //
-	wsAuth        *httpServer //
-	ipc           *ipcServer  // Stores information about the ipc http server
-	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	lifecycles    []Lifecycle         // All registered backends, services, and auxiliary services that have a lifecycle
+	rpcAPIs       []rpc.API           // List of APIs currently provided by the node
+	http          *httpServer         //
+	ws            *httpServer         //
+	httpAuth      *httpServer         //
+	wsAuth        *httpServer         //
+	ipc           *ipcServer          // Stores information about the ipc http server
+	endpoints     []*httpServer       // Additional endpoints, in the order of Config.RPCEndpoints
+	jwtKeys       *jwtKeyring         // JWT secrets accepted by the authenticated endpoints
+	inprocHandler *rpc.Server         // In-process RPC request handler to process the API requests
+	rpcUsage      *rpc.UsageTracker   // Execution cost of the calls served over HTTP and WebSocket
+	rpcResponses  rpc.ResponseLimiter // Memory accounting of the HTTP and WebSocket responses, nil if none
 
 	databases map[*closeTrackingDB]struct{} // All open databases
 }
@@ -103,16 +111,21 @@ func New(conf *Config) (*Node, error) {
 	if strings.HasSuffix(conf.Name, ".ipc") {
 		return nil, errors.New(`Config.Name cannot end in ".ipc"`)
 	}
+	if _, err := conf.wsSubscriptionPolicy(); err != nil {
+		return nil, err
+	}
 	server := rpc.NewServer()
 	server.SetBatchLimits(conf.BatchRequestLimit, conf.BatchResponseMaxSize)
 	node := &Node{
 		config:        conf,
 		inprocHandler: server,
+		rpcUsage:      rpc.NewUsageTracker(),
 		eventmux:      new(event.TypeMux),
 		log:           conf.Logger,
 		stop:          make(chan struct{}),
 		server:        &p2p.Server{Config: conf.P2P},
 		databases:     make(map[*closeTrackingDB]struct{}),
+		jwtKeys:       newJWTKeyring(),
 	}
 
 	// Register built-in APIs.
@@ -151,14 +164,67 @@ func New(conf *Config) (*Node, error) {
 
 	// Configure RPC servers.
 	node.http = newHTTPServer(node.log, conf.HTTPTimeouts)
+	if err := node.http.setTransport(conf.HTTPTLSCert, conf.HTTPTLSKey, conf.HTTPH2C); err != nil {
+		return nil, err
+	}
 	node.httpAuth = newHTTPServer(node.log, conf.HTTPTimeouts)
 	node.ws = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
 	node.wsAuth = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
-	node.ipc = newIPCServer(node.log, conf.IPCEndpoint())
+	for _, server := range []*httpServer{node.httpAuth, node.wsAuth} {
+		if err := server.setTransport(conf.AuthTLSCert, conf.AuthTLSKey, false); err != nil {
+			return nil, err
+		}
+		if err := server.setClientAuth(conf.AuthTLSClientCA, conf.AuthTLSClientPins, conf.AuthTLSClientNames); err != nil {
+			return nil, err
+		}
+	}
+	if conf.AuthJWTDisabled && conf.AuthTLSClientCA == "" && len(conf.AuthTLSClientPins) == 0 {
+		return nil, errors.New("disabling JWT authentication requires TLS client authentication")
+	}
+	ipcMode, err := parseSocketPermissions(conf.IPCPermissions)
+	if err != nil {
+		return nil, err
+	}
+	node.ipc = newIPCServer(node.log, conf.IPCEndpoint(), ipcMode)
 
+	for _, endpoint := range conf.RPCEndpoints {
+		server, err := newEndpointServer(node.log, &endpoint, conf.HTTPTimeouts)
+		if err != nil {
+			return nil, err
+		}
+		node.endpoints = append(node.endpoints, server)
+	}
 	return node, nil
 }
 
+// newEndpointServer creates the server of an additional RPC endpoint, listening on
+// its configured address.
+func newEndpointServer(logger log.Logger, config *RPCEndpoint, timeouts rpc.HTTPTimeouts) (*httpServer, error) {
+	if config.Timeouts != (rpc.HTTPTimeouts{}) {
+		timeouts = config.Timeouts
+	}
+	if err := validatePrefix("RPC endpoint "+config.Name, config.PathPrefix); err != nil {
+		return nil, err
+	}
+	server := newHTTPServer(logger.New("endpoint", config.Name), timeouts)
+	if path, ok := strings.CutPrefix(config.Addr, "unix:"); ok {
+		mode, err := parseSocketPermissions(config.SocketPermissions)
+		if err != nil {
+			return nil, fmt.Errorf("RPC endpoint %s: %v", config.Name, err)
+		}
+		return server, server.setSocketAddr(path, mode)
+	}
+	host, port, err := net.SplitHostPort(config.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("RPC endpoint %s: invalid address %q: %v", config.Name, config.Addr, err)
+	}
+	portnum, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, fmt.Errorf("RPC endpoint %s: invalid port %q", config.Name, port)
+	}
+	return server, server.setListenAddr(host, portnum)
+}
+
 // Start starts all registered lifecycles, RPC services and p2p networking.
 // Node can only be started once.
 func (n *Node) Start() error {
@@ -335,14 +401,8 @@ func (n *Node) closeDataDir() {
 // present, it generates a new secret and stores to the given location.
 func ObtainJWTSecret(fileName string) ([]byte, error) {
 	// try reading from file
-	if data, err := os.ReadFile(fileName); err == nil {
-		jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
-		if len(jwtSecret) == 32 {
-			log.Info("Loaded JWT secret file", "path", fileName, "crc32", fmt.Sprintf("%#x", crc32.ChecksumIEEE(jwtSecret)))
-			return jwtSecret, nil
-		}
-		log.Error("Invalid JWT secret", "path", fileName, "length", len(jwtSecret))
-		return nil, errors.New("invalid JWT secret")
+	if _, err := os.Stat(fileName); err == nil {
+		return readJWTSecret(fileName)
 	}
 	// Need to generate one
 	jwtSecret := make([]byte, 32)
@@ -359,6 +419,45 @@ func ObtainJWTSecret(fileName string) ([]byte, error) {
 	return jwtSecret, nil
 }
 
+// readJWTSecret loads a hex-encoded jwt-secret from the given file.
+func readJWTSecret(fileName string) ([]byte, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		log.Error("Invalid JWT secret", "path", fileName, "length", len(jwtSecret))
+		return nil, errors.New("invalid JWT secret")
+	}
+	log.Info("Loaded JWT secret file", "path", fileName, "crc32", fmt.Sprintf("%#x", crc32.ChecksumIEEE(jwtSecret)))
+	return jwtSecret, nil
+}
+
+// authOnlyModules returns the namespaces to serve on the authenticated
+// endpoints on top of the configured ones: those registered as authenticated
+// only, and those explicitly enabled by AuthModules.
+func (n *Node) authOnlyModules() []string {
+	var (
+		modules []string
+		public  = make(map[string]bool)
+	)
+	for _, api := range n.rpcAPIs {
+		if !api.Authenticated {
+			public[api.Namespace] = true
+		}
+	}
+	for _, api := range n.rpcAPIs {
+		if !api.Authenticated || slices.Contains(modules, api.Namespace) {
+			continue
+		}
+		if !public[api.Namespace] || slices.Contains(n.config.AuthModules, api.Namespace) {
+			modules = append(modules, api.Namespace)
+		}
+	}
+	return modules
+}
+
 // obtainJWTSecret loads the jwt-secret, either from the provided config,
 // or from the default location. If neither of those are present, it generates
 // a new secret and stores to the default location.
@@ -371,6 +470,28 @@ func (n *Node) obtainJWTSecret(cliParam string) ([]byte, error) {
 	return ObtainJWTSecret(fileName)
 }
 
+// loadJWTSecrets loads the configured jwt-secrets into the keyring of the
+// authenticated endpoints.
+func (n *Node) loadJWTSecrets() error {
+	jwtSecret, err := n.obtainJWTSecret(n.config.JWTSecret)
+	if err != nil {
+		return err
+	}
+	if err := n.jwtKeys.add(defaultJWTSecretID, jwtSecret); err != nil {
+		return err
+	}
+	for id, path := range n.config.JWTSecrets {
+		secret, err := readJWTSecret(path)
+		if err != nil {
+			return fmt.Errorf("JWT secret %s: %v", id, err)
+		}
+		if err := n.jwtKeys.add(id, secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // startRPC is a helper method to configure all the various RPC endpoints during node
 // startup. It's not meant to be called at any time afterwards as it makes certain
 // assumptions about the state of the node.
@@ -393,6 +514,12 @@ func (n *Node) startRPC() error {
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		usage:                  n.rpcUsage,
+		responses:              n.rpcResponses,
+	}
+	subPolicy, err := n.config.wsSubscriptionPolicy()
+	if err != nil {
+		return err
 	}
 
 	initHttp := func(server *httpServer, port int) error {
@@ -402,6 +529,7 @@ func (n *Node) startRPC() error {
 		if err := server.enableRPC(openAPIs, httpConfig{
 			CorsAllowedOrigins: n.config.HTTPCors,
 			Vhosts:             n.config.HTTPVirtualHosts,
+			ModulePolicies:     n.config.HTTPModulePolicies,
 			Modules:            n.config.HTTPModules,
 			prefix:             n.config.HTTPPathPrefix,
 			rpcEndpointConfig:  rpcConfig,
@@ -418,10 +546,12 @@ func (n *Node) startRPC() error {
 			return err
 		}
 		if err := server.enableWS(openAPIs, wsConfig{
-			Modules:           n.config.WSModules,
-			Origins:           n.config.WSOrigins,
-			prefix:            n.config.WSPathPrefix,
-			rpcEndpointConfig: rpcConfig,
+			Modules:            n.config.WSModules,
+			Origins:            n.config.WSOrigins,
+			prefix:             n.config.WSPathPrefix,
+			subscriptionBuffer: n.config.WSSubscriptionBuffer,
+			subscriptionPolicy: subPolicy,
+			rpcEndpointConfig:  rpcConfig,
 		}); err != nil {
 			return err
 		}
@@ -429,7 +559,7 @@ func (n *Node) startRPC() error {
 		return nil
 	}
 
-	initAuth := func(port int, secret []byte) error {
+	initAuth := func(port int, keys *jwtKeyring) error {
 		authModules := DefaultAuthModules
 		if slices.Contains(n.config.HTTPModules, "miner") {
 			authModules = append(authModules, "miner")
@@ -437,6 +567,19 @@ func (n *Node) startRPC() error {
 		if slices.Contains(n.config.HTTPModules, "debug") {
 			authModules = append(authModules, "debug")
 		}
+		// APIs registered as authenticated are only reachable here. Namespaces
+		// made up of such APIs only are served regardless of the module lists,
+		// those shared with public APIs (e.g. admin) have to be opted into, so
+		// that enabling a single method doesn't expose the whole namespace.
+		wsAuthModules := slices.Clone(DefaultAuthModules)
+		for _, namespace := range n.authOnlyModules() {
+			if !slices.Contains(authModules, namespace) {
+				authModules = append(authModules, namespace)
+			}
+			if !slices.Contains(wsAuthModules, namespace) {
+				wsAuthModules = append(wsAuthModules, namespace)
+			}
+		}
 
 		// Enable auth via HTTP
 		server := n.httpAuth
@@ -444,10 +587,11 @@ func (n *Node) startRPC() error {
 			return err
 		}
 		sharedConfig := rpcEndpointConfig{
-			jwtSecret:              secret,
+			jwtKeys:                keys,
 			batchItemLimit:         engineAPIBatchItemLimit,
 			batchResponseSizeLimit: engineAPIBatchResponseSizeLimit,
 			httpBodyLimit:          engineAPIBodyLimit,
+			usage:                  n.rpcUsage,
 		}
 		err := server.enableRPC(allAPIs, httpConfig{
 			CorsAllowedOrigins: DefaultAuthCors,
@@ -467,10 +611,12 @@ func (n *Node) startRPC() error {
 			return err
 		}
 		if err := server.enableWS(allAPIs, wsConfig{
-			Modules:           DefaultAuthModules,
-			Origins:           DefaultAuthOrigins,
-			prefix:            DefaultAuthPrefix,
-			rpcEndpointConfig: sharedConfig,
+			Modules:            wsAuthModules,
+			Origins:            DefaultAuthOrigins,
+			prefix:             DefaultAuthPrefix,
+			subscriptionBuffer: n.config.WSSubscriptionBuffer,
+			subscriptionPolicy: subPolicy,
+			rpcEndpointConfig:  sharedConfig,
 		}); err != nil {
 			return err
 		}
@@ -494,13 +640,57 @@ func (n *Node) startRPC() error {
 	}
 	// Configure authenticated API
 	if len(openAPIs) != len(allAPIs) {
-		jwtSecret, err := n.obtainJWTSecret(n.config.JWTSecret)
-		if err != nil {
+		var keys *jwtKeyring // nil if authenticated by TLS client certificates only
+		if !n.config.AuthJWTDisabled {
+			if err := n.loadJWTSecrets(); err != nil {
+				return err
+			}
+			keys = n.jwtKeys
+		}
+		if err := initAuth(n.config.AuthPort, keys); err != nil {
 			return err
 		}
-		if err := initAuth(n.config.AuthPort, jwtSecret); err != nil {
+	}
+	// Configure the additional endpoints.
+	for i, config := range n.config.RPCEndpoints {
+		var (
+			server = n.endpoints[i]
+			apis   = openAPIs
+			epConf = rpcConfig
+		)
+		if config.JWTSecret != "" {
+			secret, err := ObtainJWTSecret(config.JWTSecret)
+			if err != nil {
+				return err
+			}
+			apis, epConf.jwtKeys = allAPIs, singleJWTKeyring(secret)
+		}
+		vhosts := config.VirtualHosts
+		if len(vhosts) == 0 {
+			vhosts = DefaultConfig.HTTPVirtualHosts
+		}
+		if err := server.enableRPC(apis, httpConfig{
+			CorsAllowedOrigins: config.Cors,
+			Vhosts:             vhosts,
+			Modules:            config.Modules,
+			prefix:             config.PathPrefix,
+			rpcEndpointConfig:  epConf,
+		}); err != nil {
 			return err
 		}
+		if config.WS {
+			if err := server.enableWS(apis, wsConfig{
+				Modules:            config.Modules,
+				Origins:            config.WSOrigins,
+				prefix:             config.PathPrefix,
+				subscriptionBuffer: n.config.WSSubscriptionBuffer,
+				subscriptionPolicy: subPolicy,
+				rpcEndpointConfig:  epConf,
+			}); err != nil {
+				return err
+			}
+		}
+		servers = append(servers, server)
 	}
 	// Start the servers
 	for _, server := range servers {
@@ -522,11 +712,24 @@ func (n *Node) wsServerForPort(port int, authenticated bool) *httpServer {
 	return wsServer
 }
 
+// rpcSubscriptions returns the active subscriptions of the websocket and IPC
+// endpoints.
+func (n *Node) rpcSubscriptions() []rpc.SubscriptionInfo {
+	var subs []rpc.SubscriptionInfo
+	for _, server := range append([]*httpServer{n.http, n.ws, n.httpAuth, n.wsAuth}, n.endpoints...) {
+		subs = append(subs, server.wsSubscriptions()...)
+	}
+	return append(subs, n.ipc.subscriptions()...)
+}
+
 func (n *Node) stopRPC() {
 	n.http.stop()
 	n.ws.stop()
 	n.httpAuth.stop()
 	n.wsAuth.stop()
+	for _, server := range n.endpoints {
+		server.stop()
+	}
 	n.ipc.stop()
 	n.stopInProc()
 }
@@ -587,7 +790,19 @@ func (n *Node) RegisterAPIs(apis []rpc.API) {
 	n.rpcAPIs = append(n.rpcAPIs, apis...)
 }
 
-// getAPIs return two sets of APIs, both the ones that do not require
+// SetRPCResponseLimiter makes the HTTP and WebSocket endpoints account the
+// responses they send in the given limiter. The authenticated endpoint serving
+// the engine API is exempted.
+func (n *Node) SetRPCResponseLimiter(limiter rpc.ResponseLimiter) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.state != initializingState {
+		panic("can't set the response limiter of a running/stopped node")
+	}
+	n.rpcResponses = limiter
+}
+
 // authentication, and the complete set
 func (n *Node) getAPIs() (unauthenticated, all []rpc.API) {
 	for _, api := range n.rpcAPIs {
@@ -598,6 +813,14 @@ func (n *Node) getAPIs() (unauthenticated, all []rpc.API) {
 	return unauthenticated, n.rpcAPIs
 }
 
+// APIs returns the APIs provided by the node, including the built-in ones.
+func (n *Node) APIs() []rpc.API {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return slices.Clone(n.rpcAPIs)
+}
+
 // RegisterHandler mounts a handler on the given path on the canonical HTTP server.
 //
 // The name of the handler is shown in a log message when the HTTP server starts
@@ -21,8 +21,10 @@ import (
 	crand "crypto/rand"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -235,3 +237,178 @@ func offsetTimeAuth(secret [32]byte, offset time.Duration) rpc.HTTPAuth {
 		return nil
 	}
 }
+
+type authIDRPC struct{}
+
+func (authIDRPC) AuthID(ctx context.Context) string {
+	return rpc.PeerInfoFromContext(ctx).AuthID
+}
+
+func (authIDRPC) AuthCredentials(ctx context.Context) []string {
+	return rpc.PeerInfoFromContext(ctx).AuthCredentials
+}
+
+func idAuth(secret [32]byte, id string) rpc.HTTPAuth {
+	return func(header http.Header) error {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"iat": &jwt.NumericDate{Time: time.Now()},
+			"id":  id,
+		})
+		s, err := token.SignedString(secret[:])
+		if err != nil {
+			return fmt.Errorf("failed to create JWT token: %w", err)
+		}
+		header.Set("Authorization", "Bearer "+s)
+		return nil
+	}
+}
+
+// TestAuthID checks that the "id" claim of the JWT token is reported as the
+// identity of the client to the RPC handlers.
+func TestAuthID(t *testing.T) {
+	var secret [32]byte
+	if _, err := crand.Read(secret[:]); err != nil {
+		t.Fatalf("failed to create jwt secret: %v", err)
+	}
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("test", authIDRPC{}); err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+	defer srv.Stop()
+
+	httpsrv := httptest.NewServer(newJWTHandler(secret[:], srv))
+	defer httpsrv.Close()
+
+	for _, id := range []string{"alice", ""} {
+		cl, err := rpc.DialOptions(context.Background(), httpsrv.URL, rpc.WithHTTPAuth(idAuth(secret, id)))
+		if err != nil {
+			t.Fatalf("failed to dial rpc endpoint: %v", err)
+		}
+		var have string
+		if err := cl.Call(&have, "test_authID"); err != nil {
+			t.Fatalf("failed to call rpc endpoint: %v", err)
+		}
+		cl.Close()
+		if have != id {
+			t.Fatalf("wrong client identity: have %q, want %q", have, id)
+		}
+	}
+}
+
+// TestAuthCredentials checks that the identifier of the JWT secret a token is
+// signed with is reported as a verified credential, whatever the token claims.
+func TestAuthCredentials(t *testing.T) {
+	var first, second [32]byte
+	crand.Read(first[:])
+	crand.Read(second[:])
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("test", authIDRPC{}); err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+	defer srv.Stop()
+
+	keys := newJWTKeyring()
+	keys.add("first", first[:])
+	keys.add("second", second[:])
+	httpsrv := httptest.NewServer(newJWTKeyringHandler(keys, srv))
+	defer httpsrv.Close()
+
+	for _, test := range []struct {
+		secret [32]byte
+		want   string
+	}{
+		{first, "jwt:first"},
+		{second, "jwt:second"},
+	} {
+		cl, err := rpc.DialOptions(context.Background(), httpsrv.URL, rpc.WithHTTPAuth(idAuth(test.secret, "jwt:first")))
+		if err != nil {
+			t.Fatalf("failed to dial rpc endpoint: %v", err)
+		}
+		var have []string
+		if err := cl.Call(&have, "test_authCredentials"); err != nil {
+			t.Fatalf("failed to call rpc endpoint: %v", err)
+		}
+		cl.Close()
+		if len(have) != 1 || have[0] != test.want {
+			t.Fatalf("wrong client credentials: have %q, want %q", have, test.want)
+		}
+	}
+}
+
+// TestJWTRotation checks that the tokens signed with any of the secrets of the
+// keyring are accepted, while secrets are added and removed.
+func TestJWTRotation(t *testing.T) {
+	var oldSecret, newSecret [32]byte
+	crand.Read(oldSecret[:])
+	crand.Read(newSecret[:])
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("test", authIDRPC{}); err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+	defer srv.Stop()
+
+	keys := newJWTKeyring()
+	keys.add(defaultJWTSecretID, oldSecret[:])
+	httpsrv := httptest.NewServer(newJWTKeyringHandler(keys, srv))
+	defer httpsrv.Close()
+
+	call := func(secret [32]byte) error {
+		cl, err := rpc.DialOptions(context.Background(), httpsrv.URL, rpc.WithHTTPAuth(idAuth(secret, "")))
+		if err != nil {
+			t.Fatalf("failed to dial rpc endpoint: %v", err)
+		}
+		defer cl.Close()
+
+		var id string
+		return cl.Call(&id, "test_authID")
+	}
+	if err := call(newSecret); err == nil {
+		t.Fatal("token signed with an unknown secret accepted")
+	}
+	if err := keys.add("next", newSecret[:]); err != nil {
+		t.Fatalf("failed to add secret: %v", err)
+	}
+	if err := keys.add("next", newSecret[:]); err == nil {
+		t.Fatal("duplicate secret identifier accepted")
+	}
+	for _, secret := range [][32]byte{oldSecret, newSecret} {
+		if err := call(secret); err != nil {
+			t.Fatalf("token rejected during rotation: %v", err)
+		}
+	}
+	if err := keys.remove(defaultJWTSecretID); err != nil {
+		t.Fatalf("failed to remove secret: %v", err)
+	}
+	if err := call(oldSecret); err == nil {
+		t.Fatal("token signed with a removed secret accepted")
+	}
+	if err := call(newSecret); err != nil {
+		t.Fatalf("token rejected after rotation: %v", err)
+	}
+	if err := keys.remove("next"); err == nil {
+		t.Fatal("last secret removed")
+	}
+}
+
+// TestAuthOnlyModules checks that namespaces shared with public APIs are only
+// served on the authenticated endpoints when explicitly enabled.
+func TestAuthOnlyModules(t *testing.T) {
+	node, err := New(&Config{AuthModules: []string{"txpool"}})
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	defer node.Close()
+
+	node.RegisterAPIs([]rpc.API{
+		{Namespace: "policy", Service: helloRPC("policy"), Authenticated: true},
+		{Namespace: "admin", Service: helloRPC("admin")},
+		{Namespace: "admin", Service: authIDRPC{}, Authenticated: true},
+		{Namespace: "txpool", Service: helloRPC("txpool")},
+		{Namespace: "txpool", Service: authIDRPC{}, Authenticated: true},
+	})
+	if have, want := node.authOnlyModules(), []string{"policy", "txpool"}; !slices.Equal(have, want) {
+		t.Fatalf("wrong authenticated modules: have %v, want %v", have, want)
+	}
+}
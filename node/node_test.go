@@ -17,15 +17,20 @@
 package node
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -46,6 +51,61 @@ func testNodeConfig() *Config {
 	}
 }
 
+// drainingService is a lifecycle with drain phases recording their execution.
+type drainingService struct {
+	InstrumentedService
+	phases []DrainPhase
+}
+
+func (s *drainingService) DrainPhases() []DrainPhase { return s.phases }
+
+// Tests that a graceful shutdown runs the drain phases in order within the
+// drain time before closing the node.
+func TestNodeShutdown(t *testing.T) {
+	stack, err := New(testNodeConfig())
+	if err != nil {
+		t.Fatalf("failed to create protocol stack: %v", err)
+	}
+	var (
+		ran     []string
+		stopped bool
+		record  = func(name string) func(ctx context.Context) error {
+			return func(ctx context.Context) error {
+				ran = append(ran, name)
+				return nil
+			}
+		}
+	)
+	service := &drainingService{
+		InstrumentedService: InstrumentedService{stopHook: func() { stopped = true }},
+		phases: []DrainPhase{
+			{Name: "first", Run: record("first")},
+			{Name: "blocked", Run: func(ctx context.Context) error {
+				<-ctx.Done()
+				ran = append(ran, "blocked")
+				return ctx.Err()
+			}},
+			{Name: "last", Run: func(ctx context.Context) error {
+				if stopped {
+					t.Error("service stopped before draining")
+				}
+				return record("last")(ctx)
+			}},
+		},
+	}
+	stack.RegisterLifecycle(service)
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start protocol stack: %v", err)
+	}
+	if err := stack.Shutdown(50 * time.Millisecond); err != nil {
+		t.Fatalf("failed to shut down protocol stack: %v", err)
+	}
+	assert.Equal(t, []string{"first", "blocked", "last"}, ran)
+	assert.True(t, stopped)
+	assert.ErrorIs(t, stack.Shutdown(0), ErrShuttingDown)
+	assert.ErrorIs(t, stack.Close(), ErrNodeStopped)
+}
+
 // Tests that an empty protocol stack can be closed more than once.
 func TestNodeCloseMultipleTimes(t *testing.T) {
 	stack, err := New(testNodeConfig())
@@ -465,6 +525,82 @@ func TestWebsocketHTTPOnSeparatePort_WSRequest(t *testing.T) {
 	}
 }
 
+// Tests that additional RPC endpoints serve their own module lists, on TCP
+// addresses and unix sockets created with the configured permissions.
+func TestNodeRPCEndpoints(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix socket permissions are not supported on Windows")
+	}
+	var (
+		dir    = t.TempDir()
+		socket = filepath.Join(dir, "internal.sock")
+		ipc    = filepath.Join(dir, "geth.ipc")
+	)
+	node, err := New(&Config{
+		IPCPath:        ipc,
+		IPCPermissions: "0660",
+		RPCEndpoints: []RPCEndpoint{
+			{Name: "internal", Addr: "unix:" + socket, SocketPermissions: "0660", Modules: []string{"admin", "debug"}},
+			{Name: "public", Addr: "127.0.0.1:0", Modules: []string{"web3"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("could not create node: %v", err)
+	}
+	if err := node.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	defer node.Close()
+
+	for _, path := range []string{socket, ipc} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("socket %s missing: %v", path, err)
+		}
+		if perm := info.Mode().Perm(); perm != 0660 {
+			t.Fatalf("wrong permissions of socket %s: have %o, want %o", path, perm, 0660)
+		}
+	}
+	modules := func(client *rpc.Client) map[string]string {
+		t.Helper()
+		defer client.Close()
+
+		var modules map[string]string
+		if err := client.Call(&modules, "rpc_modules"); err != nil {
+			t.Fatalf("failed to query modules: %v", err)
+		}
+		return modules
+	}
+	unixClient := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, "unix", socket)
+		},
+	}}
+	client, err := rpc.DialHTTPWithClient("http://localhost", unixClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := modules(client); have["admin"] == "" || have["debug"] == "" || have["web3"] != "" {
+		t.Fatalf("wrong modules on the internal endpoint: %v", have)
+	}
+	client, err = rpc.DialHTTP("http://" + node.endpoints[1].listenAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := modules(client); have["web3"] == "" || have["admin"] != "" {
+		t.Fatalf("wrong modules on the public endpoint: %v", have)
+	}
+	// Invalid endpoints are refused
+	for _, endpoint := range []RPCEndpoint{
+		{Name: "noport", Addr: "127.0.0.1"},
+		{Name: "badperm", Addr: "unix:" + socket, SocketPermissions: "rw"},
+	} {
+		if _, err := New(&Config{RPCEndpoints: []RPCEndpoint{endpoint}}); err == nil {
+			t.Fatalf("endpoint %s accepted", endpoint.Name)
+		}
+	}
+}
+
 type rpcPrefixTest struct {
 	httpPrefix, wsPrefix string
 	// These lists paths on which JSON-RPC should be served / not served.
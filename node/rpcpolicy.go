@@ -0,0 +1,218 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// defaultPolicyBodyLimit is the request size limit applied when inspecting the
+// body of a request, matching the default limit of the RPC server.
+const defaultPolicyBodyLimit = 5 * 1024 * 1024
+
+// modulePolicyHandler enforces CORS and virtual host rules that differ between
+// the API namespaces served on a single HTTP endpoint. The namespaces of a request
+// are taken from the method names in its body, and the request is only served if
+// the policy of every one of them accepts its Host and Origin headers.
+//
+// Unlike the plain CORS handler, a cross-origin request from a disallowed origin
+// is rejected instead of being served without CORS headers. Preflight requests
+// carry no body, so they are answered for the union of all allowed origins and
+// the actual request is checked once its methods are known.
+type modulePolicyHandler struct {
+	global    accessPolicy
+	modules   map[string]accessPolicy
+	preflight http.Handler
+	bodyLimit int
+	next      http.Handler
+}
+
+// accessPolicy is the resolved CORS and virtual host configuration of a namespace.
+type accessPolicy struct {
+	origins []string
+	vhosts  map[string]struct{}
+}
+
+func newAccessPolicy(origins, vhosts []string) accessPolicy {
+	p := accessPolicy{vhosts: make(map[string]struct{})}
+	for _, origin := range origins {
+		p.origins = append(p.origins, strings.ToLower(origin))
+	}
+	for _, host := range vhosts {
+		p.vhosts[strings.ToLower(host)] = struct{}{}
+	}
+	return p
+}
+
+// allowOrigin reports whether the policy permits cross-origin requests from the
+// given origin. Allowed origins may contain a single '*' wildcard.
+func (p accessPolicy) allowOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range p.origins {
+		if prefix, suffix, ok := strings.Cut(allowed, "*"); ok {
+			if len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+		} else if origin == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func newModulePolicyHandler(config httpConfig, next http.Handler) http.Handler {
+	h := &modulePolicyHandler{
+		global:    newAccessPolicy(config.CorsAllowedOrigins, config.Vhosts),
+		modules:   make(map[string]accessPolicy),
+		bodyLimit: config.httpBodyLimit,
+		next:      next,
+	}
+	if h.bodyLimit <= 0 {
+		h.bodyLimit = defaultPolicyBodyLimit
+	}
+	origins, vhosts := slices.Clone(config.CorsAllowedOrigins), slices.Clone(config.Vhosts)
+	for name, policy := range config.ModulePolicies {
+		cors, hosts := policy.Cors, policy.Vhosts
+		if cors == nil {
+			cors = config.CorsAllowedOrigins
+		}
+		if hosts == nil {
+			hosts = config.Vhosts
+		}
+		h.modules[name] = newAccessPolicy(cors, hosts)
+		origins = append(origins, cors...)
+		vhosts = append(vhosts, hosts...)
+	}
+	h.preflight = newVHostHandler(vhosts, newCorsHandler(next, origins))
+	return h
+}
+
+// ServeHTTP serves JSON-RPC requests over HTTP, implements http.Handler
+func (h *modulePolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		h.preflight.ServeHTTP(w, r)
+		return
+	}
+	// Resolve the policies of all namespaces in the request, falling back to the
+	// global one if the body holds no calls.
+	policies := []accessPolicy{h.global}
+	if r.Method == http.MethodPost && r.Body != nil {
+		body, err := io.ReadAll(io.LimitReader(r.Body, int64(h.bodyLimit)+1))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(body) > h.bodyLimit {
+			http.Error(w, "content length too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		names, err := requestNamespaces(body)
+		if err != nil {
+			// The body can't be attributed to any namespace, so refuse it outright
+			// instead of guessing which policy would have applied.
+			http.Error(w, "invalid request body", http.StatusForbidden)
+			return
+		}
+		if len(names) > 0 {
+			policies = policies[:0]
+			for _, name := range names {
+				policies = append(policies, h.policy(name))
+			}
+		}
+	}
+	for _, policy := range policies {
+		if !vhostAllowed(policy.vhosts, r.Host) {
+			http.Error(w, "invalid host specified", http.StatusForbidden)
+			return
+		}
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && !sameOrigin(origin, r.Host) {
+		for _, policy := range policies {
+			if !policy.allowOrigin(origin) {
+				http.Error(w, "origin not allowed", http.StatusForbidden)
+				return
+			}
+		}
+		w.Header().Add("Vary", "Origin")
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// policy returns the access policy of the given namespace.
+func (h *modulePolicyHandler) policy(namespace string) accessPolicy {
+	if p, ok := h.modules[namespace]; ok {
+		return p
+	}
+	return h.global
+}
+
+// requestNamespaces returns the distinct API namespaces called by a single or
+// batch JSON-RPC request. The body is decoded exactly like the RPC server does:
+// only the first JSON value is considered, and anything trailing it is ignored.
+// An error is returned if not even that value can be decoded.
+func requestNamespaces(body []byte) ([]string, error) {
+	type call struct {
+		Method string `json:"method"`
+	}
+	var raw json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&raw); err != nil {
+		return nil, err
+	}
+	// Mirror rpc.parseMessage: decoding errors of individual calls are ignored,
+	// leaving the method empty just as the server would see it.
+	var msgs []call
+	if raw = bytes.TrimLeft(raw, " \t\r\n"); len(raw) > 0 && raw[0] == '[' {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.Token() // skip '['
+		for dec.More() {
+			var msg call
+			dec.Decode(&msg)
+			msgs = append(msgs, msg)
+		}
+	} else {
+		var msg call
+		json.Unmarshal(raw, &msg)
+		msgs = append(msgs, msg)
+	}
+	var names []string
+	for _, msg := range msgs {
+		name, _, ok := strings.Cut(msg.Method, "_")
+		if !ok {
+			name = ""
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// sameOrigin reports whether the origin of a request targets the host it was
+// sent to, in which case it is not subject to CORS.
+func sameOrigin(origin string, host string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, host)
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestModulePolicies checks that CORS and vhost rules of individual namespaces are
+// enforced on top of the global ones.
+func TestModulePolicies(t *testing.T) {
+	srv := createAndStartServer(t, &httpConfig{
+		CorsAllowedOrigins: []string{"*"},
+		Vhosts:             []string{"*"},
+		ModulePolicies: map[string]HTTPModulePolicy{
+			"test": {Cors: []string{"https://*.admin.example"}, Vhosts: []string{"localhost"}},
+		},
+	}, false, &wsConfig{}, nil)
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+
+	// Namespaces without a policy follow the global settings.
+	resp := rpcRequest(t, url, testMethod, "origin", "https://evil.example", "host", "remote.example")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "https://evil.example", resp.Header.Get("Access-Control-Allow-Origin"))
+
+	// The test namespace only accepts its own origins and hosts.
+	resp = rpcRequest(t, url, "test_greet", "origin", "https://evil.example")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = rpcRequest(t, url, "test_greet", "origin", "https://ops.admin.example")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "https://ops.admin.example", resp.Header.Get("Access-Control-Allow-Origin"))
+	resp = rpcRequest(t, url, "test_greet", "host", "remote.example")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = rpcRequest(t, url, "test_greet")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Batches must satisfy the policy of every namespace they call.
+	resp = batchRpcRequest(t, url, []string{testMethod, "test_greet"}, "origin", "https://evil.example")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = batchRpcRequest(t, url, []string{testMethod, "test_greet"}, "origin", "https://ops.admin.example")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Trailing garbage after the first call must not hide its namespace, and
+	// bodies that can't be decoded at all are refused.
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"test_greet","params":[]} x`,
+		`{"jsonrpc":"2.0","id":1,"method":"test_greet"`,
+	} {
+		req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Origin", "https://evil.example")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, body)
+	}
+
+	// Preflights are answered for the union of all origins.
+	req, _ := http.NewRequest(http.MethodOptions, url, nil)
+	req.Header.Set("Origin", "https://evil.example")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestRequestNamespaces(t *testing.T) {
+	tests := []struct {
+		body string
+		want []string
+		fail bool
+	}{
+		{body: `{"method":"eth_call"}`, want: []string{"eth"}},
+		{body: ` [{"method":"eth_call"},{"method":"debug_traceCall"},{"method":"eth_chainId"}]`, want: []string{"eth", "debug"}},
+		{body: `{"method":"nonamespace"}`, want: []string{""}},
+		{body: `[]`, want: nil},
+		{body: `{"method":"debug_traceTransaction"} x`, want: []string{"debug"}},
+		{body: `[{"method":"debug_traceTransaction"},1] {`, want: []string{"debug", ""}},
+		{body: `{"method":`, fail: true},
+		{body: ``, fail: true},
+	}
+	for _, tt := range tests {
+		names, err := requestNamespaces([]byte(tt.body))
+		if tt.fail {
+			assert.Error(t, err, tt.body)
+			continue
+		}
+		assert.NoError(t, err, tt.body)
+		assert.Equal(t, tt.want, names, tt.body)
+	}
+}
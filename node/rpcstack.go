@@ -19,11 +19,19 @@ package node
 import (
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -32,8 +40,12 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/klauspost/compress/zstd"
 	"github.com/rs/cors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 // httpConfig is the JSON-RPC/HTTP configuration.
@@ -41,7 +53,8 @@ type httpConfig struct {
 	Modules            []string
 	CorsAllowedOrigins []string
 	Vhosts             []string
-	prefix             string // path prefix on which to mount http handler
+	ModulePolicies     map[string]HTTPModulePolicy // per-namespace overrides of the above
+	prefix             string                      // path prefix on which to mount http handler
 	rpcEndpointConfig
 }
 
@@ -50,14 +63,19 @@ type wsConfig struct {
 	Origins []string
 	Modules []string
 	prefix  string // path prefix on which to mount ws handler
+
+	subscriptionBuffer int                    // notification queue size of subscriptions
+	subscriptionPolicy rpc.BackpressurePolicy // handling of the notifications when the queue is full
 	rpcEndpointConfig
 }
 
 type rpcEndpointConfig struct {
-	jwtSecret              []byte // optional JWT secret
+	jwtKeys                *jwtKeyring // optional JWT secrets
 	batchItemLimit         int
 	batchResponseSizeLimit int
 	httpBodyLimit          int
+	usage                  *rpc.UsageTracker   // optional execution cost tracker
+	responses              rpc.ResponseLimiter // optional response memory accounting
 }
 
 type rpcHandler struct {
@@ -83,10 +101,22 @@ type httpServer struct {
 	wsConfig  wsConfig
 	wsHandler atomic.Value // *rpcHandler
 
-	// These are set by setListenAddr.
-	endpoint string
-	host     string
-	port     int
+	// These are set by setListenAddr and setSocketAddr.
+	endpoint   string
+	host       string
+	port       int
+	socket     string      // unix socket path, listening on host:port if empty
+	socketMode os.FileMode // unix socket file permissions
+
+	// These are set by setTransport.
+	tlsCert string // TLS certificate file, serving HTTPS (and HTTP/2) if set
+	tlsKey  string // TLS private key file
+	h2c     bool   // whether HTTP/2 is served over cleartext connections
+
+	// These are set by setClientAuth.
+	clientCA    string     // CA bundle verifying the client certificates
+	clientPins  [][32]byte // SHA-256 fingerprints of the accepted client certificates
+	clientNames []string   // accepted subject alternative names of the client certificates
 
 	handlerNames map[string]string
 }
@@ -113,11 +143,158 @@ func (h *httpServer) setListenAddr(host string, port int) error {
 		return fmt.Errorf("HTTP server already running on %s", h.endpoint)
 	}
 
-	h.host, h.port = host, port
+	h.host, h.port, h.socket = host, port, ""
 	h.endpoint = net.JoinHostPort(host, fmt.Sprintf("%d", port))
 	return nil
 }
 
+// setSocketAddr configures the server to listen on a unix socket at the given
+// path, created with the given permissions. The address can only be set while
+// the server isn't running.
+func (h *httpServer) setSocketAddr(path string, mode os.FileMode) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.listener != nil && path != h.socket {
+		return fmt.Errorf("HTTP server already running on %s", h.endpoint)
+	}
+	h.host, h.port = "", 0
+	h.socket, h.socketMode = path, mode
+	h.endpoint = "unix:" + path
+	return nil
+}
+
+// setTransport configures the server to serve HTTPS with the given certificate
+// and key files if set, and HTTP/2 over cleartext connections if h2c is set.
+// HTTP/2 is negotiated over TLS connections. The transport can only be set while
+// the server isn't running.
+func (h *httpServer) setTransport(tlsCert, tlsKey string, h2c bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.listener != nil {
+		return fmt.Errorf("HTTP server already running on %s", h.endpoint)
+	}
+	if (tlsCert == "") != (tlsKey == "") {
+		return errors.New("HTTP TLS requires both a certificate and a key")
+	}
+	h.tlsCert, h.tlsKey, h.h2c = tlsCert, tlsKey, h2c
+	return nil
+}
+
+// setClientAuth configures the server to require TLS client certificates, signed
+// by the CA bundle of the given file and/or matching one of the given SHA-256
+// fingerprints. If names are given, the certificates must also have one of them
+// as subject alternative name. Client authentication requires the server to
+// serve HTTPS, and can only be set while the server isn't running.
+func (h *httpServer) setClientAuth(caFile string, pins []string, names []string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.listener != nil {
+		return fmt.Errorf("HTTP server already running on %s", h.endpoint)
+	}
+	if caFile == "" && len(pins) == 0 {
+		if len(names) > 0 {
+			return errors.New("TLS client names require a client CA or pinned certificates")
+		}
+		h.clientCA, h.clientPins, h.clientNames = "", nil, nil
+		return nil
+	}
+	if h.tlsCert == "" {
+		return errors.New("TLS client authentication requires a TLS certificate")
+	}
+	fingerprints := make([][32]byte, len(pins))
+	for i, pin := range pins {
+		fp, err := hex.DecodeString(strings.ReplaceAll(pin, ":", ""))
+		if err != nil || len(fp) != 32 {
+			return fmt.Errorf("invalid TLS client certificate fingerprint %q", pin)
+		}
+		fingerprints[i] = [32]byte(fp)
+	}
+	h.clientCA, h.clientPins, h.clientNames = caFile, fingerprints, names
+	return nil
+}
+
+// configureClientAuth sets up the verification of the client certificates in
+// the TLS configuration of the server, if client authentication is enabled.
+//
+// Certificates are accepted if they are pinned or chain up to the client CA,
+// so the chain is verified manually instead of by the TLS stack, which would
+// reject pinned self-signed certificates.
+func (h *httpServer) configureClientAuth(config *tls.Config) error {
+	if h.clientCA == "" && len(h.clientPins) == 0 {
+		return nil
+	}
+	var roots *x509.CertPool
+	if h.clientCA != "" {
+		bundle, err := os.ReadFile(h.clientCA)
+		if err != nil {
+			return err
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(bundle) {
+			return fmt.Errorf("no certificates in TLS client CA %s", h.clientCA)
+		}
+	}
+	config.ClientAuth = tls.RequireAnyClientCert
+	pins, names := h.clientPins, h.clientNames
+	config.VerifyConnection = func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return errors.New("missing client certificate")
+		}
+		return verifyClientCertificate(state.PeerCertificates, roots, pins, names)
+	}
+	return nil
+}
+
+// verifyClientCertificate checks that the client certificate is either one of
+// the pinned ones or signed by the client CA, and has one of the accepted names,
+// if any are configured. The certificate is the first of the chain, the rest are
+// intermediates offered by the client.
+func verifyClientCertificate(chain []*x509.Certificate, roots *x509.CertPool, pins [][32]byte, names []string) error {
+	cert := chain[0]
+	if !slices.Contains(pins, sha256.Sum256(cert.Raw)) {
+		if roots == nil {
+			return errors.New("client certificate not pinned")
+		}
+		opts := x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: x509.NewCertPool(),
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		for _, intermediate := range chain[1:] {
+			opts.Intermediates.AddCert(intermediate)
+		}
+		if _, err := cert.Verify(opts); err != nil {
+			return fmt.Errorf("client certificate neither pinned nor verified: %w", err)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sans := certificateNames(cert)
+	for _, san := range sans {
+		if slices.Contains(names, san) {
+			return nil
+		}
+	}
+	return fmt.Errorf("client certificate names %v not allowed", sans)
+}
+
+// certificateNames returns the subject alternative names of the certificate.
+func certificateNames(cert *x509.Certificate) []string {
+	sans := slices.Clone(cert.DNSNames)
+	sans = append(sans, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
+
 // listenAddr returns the listening address of the server.
 func (h *httpServer) listenAddr() string {
 	h.mu.Lock()
@@ -147,9 +324,31 @@ func (h *httpServer) start() error {
 		h.server.WriteTimeout = h.timeouts.WriteTimeout
 		h.server.IdleTimeout = h.timeouts.IdleTimeout
 	}
+	scheme, wsScheme := "http", "ws"
+	if h.tlsCert != "" {
+		cert, err := tls.LoadX509KeyPair(h.tlsCert, h.tlsKey)
+		if err != nil {
+			h.disableRPC()
+			h.disableWS()
+			return fmt.Errorf("failed to load HTTP TLS certificate: %v", err)
+		}
+		h.server.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+		if err := h.configureClientAuth(h.server.TLSConfig); err != nil {
+			h.disableRPC()
+			h.disableWS()
+			return fmt.Errorf("failed to set up TLS client authentication: %v", err)
+		}
+		scheme, wsScheme = "https", "wss"
+	}
+	if h.h2c {
+		h.server.Handler = h2c.NewHandler(h, &http2.Server{IdleTimeout: h.server.IdleTimeout})
+	}
 
 	// Start the server.
-	listener, err := net.Listen("tcp", h.endpoint)
+	listener, err := h.listen()
 	if err != nil {
 		// If the server fails to start, we need to clear out the RPC and WS
 		// configuration so they can be configured another time.
@@ -158,10 +357,15 @@ func (h *httpServer) start() error {
 		return err
 	}
 	h.listener = listener
-	go h.server.Serve(listener)
+	if h.server.TLSConfig != nil {
+		// The certificate is already loaded, HTTP/2 is negotiated with ALPN.
+		go h.server.ServeTLS(listener, "", "")
+	} else {
+		go h.server.Serve(listener)
+	}
 
 	if h.wsAllowed() {
-		url := fmt.Sprintf("ws://%v", listener.Addr())
+		url := fmt.Sprintf("%s://%v", wsScheme, listener.Addr())
 		if h.wsConfig.prefix != "" {
 			url += h.wsConfig.prefix
 		}
@@ -173,10 +377,13 @@ func (h *httpServer) start() error {
 	}
 	// Log http endpoint.
 	h.log.Info("HTTP server started",
-		"endpoint", listener.Addr(), "auth", (h.httpConfig.jwtSecret != nil),
+		"endpoint", listener.Addr(), "auth", (h.httpConfig.jwtKeys != nil),
 		"prefix", h.httpConfig.prefix,
 		"cors", strings.Join(h.httpConfig.CorsAllowedOrigins, ","),
 		"vhosts", strings.Join(h.httpConfig.Vhosts, ","),
+		"policies", len(h.httpConfig.ModulePolicies),
+		"tls", h.server.TLSConfig != nil, "h2c", h.h2c,
+		"clientauth", h.server.TLSConfig != nil && h.server.TLSConfig.ClientAuth != tls.NoClientCert,
 	)
 
 	// Log all handlers mounted on server.
@@ -189,7 +396,7 @@ func (h *httpServer) start() error {
 	for _, path := range paths {
 		name := h.handlerNames[path]
 		if !logged[name] {
-			log.Info(name+" enabled", "url", "http://"+listener.Addr().String()+path)
+			log.Info(name+" enabled", "url", scheme+"://"+listener.Addr().String()+path)
 			logged[name] = true
 		}
 	}
@@ -197,6 +404,16 @@ func (h *httpServer) start() error {
 }
 
 func (h *httpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Client certificates are only requested, and then verified during the TLS
+	// handshake, if client authentication is enabled. Report their names as the
+	// credentials of the client.
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		var credentials []string
+		for _, name := range certificateNames(r.TLS.PeerCertificates[0]) {
+			credentials = append(credentials, "tls:"+name)
+		}
+		r = r.WithContext(rpc.ContextWithAuthCredentials(r.Context(), credentials...))
+	}
 	// check if ws request and serve if ws enabled
 	ws := h.wsHandler.Load().(*rpcHandler)
 	if ws != nil && isWebsocket(r) {
@@ -294,6 +511,33 @@ func (h *httpServer) doStop() {
 	h.server, h.listener = nil, nil
 }
 
+// listen opens the listener of the server, on its unix socket if configured or
+// its TCP address otherwise.
+func (h *httpServer) listen() (net.Listener, error) {
+	if h.socket == "" {
+		return net.Listen("tcp", h.endpoint)
+	}
+	return listenUnix(h.socket, h.socketMode)
+}
+
+// listenUnix creates a unix socket with the given file permissions, replacing
+// any leftover socket at the same path.
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0751); err != nil {
+		return nil, err
+	}
+	os.Remove(path)
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
 // enableRPC turns on JSON-RPC over HTTP on the server.
 func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	h.mu.Lock()
@@ -309,12 +553,14 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetUsageTracker(config.usage)
+	srv.SetResponseLimiter(config.responses)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret),
+		Handler: newHTTPHandlerStack(srv, config, config.jwtKeys),
 		server:  srv,
 	})
 	return nil
@@ -344,17 +590,29 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetUsageTracker(config.usage)
+	srv.SetResponseLimiter(config.responses)
+	srv.SetSubscriptionLimits(config.subscriptionBuffer, config.subscriptionPolicy)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: NewWSHandlerStack(srv.WebsocketHandler(config.Origins), config.jwtSecret),
+		Handler: newWSHandlerStack(srv.WebsocketHandler(config.Origins), config.jwtKeys),
 		server:  srv,
 	})
 	return nil
 }
 
+// wsSubscriptions returns the active subscriptions of the JSON-RPC over WebSocket
+// handler, if enabled.
+func (h *httpServer) wsSubscriptions() []rpc.SubscriptionInfo {
+	if ws := h.wsHandler.Load().(*rpcHandler); ws != nil {
+		return ws.server.Subscriptions()
+	}
+	return nil
+}
+
 // stopWS disables JSON-RPC over WebSocket and also stops the server if it only serves WebSocket.
 func (h *httpServer) stopWS() {
 	h.mu.Lock()
@@ -395,19 +653,47 @@ func isWebsocket(r *http.Request) bool {
 
 // NewHTTPHandlerStack returns wrapped http-related handlers
 func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtSecret []byte) http.Handler {
-	// Wrap the CORS-handler within a host-handler
-	handler := newCorsHandler(srv, cors)
-	handler = newVHostHandler(vhosts, handler)
-	if len(jwtSecret) != 0 {
-		handler = newJWTHandler(jwtSecret, handler)
-	}
-	return newGzipHandler(handler)
+	return newHTTPHandlerStack(srv, httpConfig{CorsAllowedOrigins: cors, Vhosts: vhosts}, singleJWTKeyring(jwtSecret))
 }
 
 // NewWSHandlerStack returns a wrapped ws-related handler.
 func NewWSHandlerStack(srv http.Handler, jwtSecret []byte) http.Handler {
-	if len(jwtSecret) != 0 {
-		return newJWTHandler(jwtSecret, srv)
+	return newWSHandlerStack(srv, singleJWTKeyring(jwtSecret))
+}
+
+// singleJWTKeyring returns a keyring accepting the given JWT secret, or nil if
+// the secret is empty.
+func singleJWTKeyring(jwtSecret []byte) *jwtKeyring {
+	if len(jwtSecret) == 0 {
+		return nil
+	}
+	keys := newJWTKeyring()
+	keys.add(defaultJWTSecretID, jwtSecret)
+	return keys
+}
+
+// newHTTPHandlerStack returns wrapped http-related handlers, authenticating the
+// requests with the secrets of the keyring if not nil.
+func newHTTPHandlerStack(srv http.Handler, config httpConfig, jwtKeys *jwtKeyring) http.Handler {
+	var handler http.Handler
+	if len(config.ModulePolicies) > 0 {
+		handler = newModulePolicyHandler(config, srv)
+	} else {
+		// Wrap the CORS-handler within a host-handler
+		handler = newCorsHandler(srv, config.CorsAllowedOrigins)
+		handler = newVHostHandler(config.Vhosts, handler)
+	}
+	if jwtKeys != nil {
+		handler = newJWTKeyringHandler(jwtKeys, handler)
+	}
+	return newCompressionHandler(handler)
+}
+
+// newWSHandlerStack returns a wrapped ws-related handler, authenticating the
+// requests with the secrets of the keyring if not nil.
+func newWSHandlerStack(srv http.Handler, jwtKeys *jwtKeyring) http.Handler {
+	if jwtKeys != nil {
+		return newJWTKeyringHandler(jwtKeys, srv)
 	}
 	return srv
 }
@@ -445,31 +731,43 @@ func newVHostHandler(vhosts []string, next http.Handler) http.Handler {
 
 // ServeHTTP serves JSON-RPC requests over HTTP, implements http.Handler
 func (h *virtualHostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// if r.Host is not set, we can continue serving since a browser would set the Host header
-	if r.Host == "" {
-		h.next.ServeHTTP(w, r)
+	if !vhostAllowed(h.vhosts, r.Host) {
+		http.Error(w, "invalid host specified", http.StatusForbidden)
 		return
 	}
-	host, _, err := net.SplitHostPort(r.Host)
+	h.next.ServeHTTP(w, r)
+}
+
+// vhostAllowed reports whether the Host-header of a request is in the given set
+// of virtual hosts.
+func vhostAllowed(vhosts map[string]struct{}, reqHost string) bool {
+	// if the host is not set, we can continue serving since a browser would set the Host header
+	if reqHost == "" {
+		return true
+	}
+	host, _, err := net.SplitHostPort(reqHost)
 	if err != nil {
 		// Either invalid (too many colons) or no port specified
-		host = r.Host
+		host = reqHost
 	}
 	if ipAddr := net.ParseIP(host); ipAddr != nil {
 		// It's an IP address, we can serve that
-		h.next.ServeHTTP(w, r)
-		return
+		return true
 	}
 	// Not an IP address, but a hostname. Need to validate
-	if _, exist := h.vhosts["*"]; exist {
-		h.next.ServeHTTP(w, r)
-		return
+	if _, exist := vhosts["*"]; exist {
+		return true
 	}
-	if _, exist := h.vhosts[host]; exist {
-		h.next.ServeHTTP(w, r)
-		return
-	}
-	http.Error(w, "invalid host specified", http.StatusForbidden)
+	_, exist := vhosts[host]
+	return exist
+}
+
+// compressor is a pooled stream encoder of a content encoding.
+type compressor interface {
+	io.Writer
+	Reset(w io.Writer)
+	Flush() error
+	Close() error
 }
 
 var gzPool = sync.Pool{
@@ -479,19 +777,98 @@ var gzPool = sync.Pool{
 	},
 }
 
-type gzipResponseWriter struct {
-	resp http.ResponseWriter
+var zstdPool = sync.Pool{
+	New: func() interface{} {
+		// Responses are compressed on the fly, favour speed and avoid the
+		// goroutines of the concurrent encoder.
+		w, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1), zstd.WithLowerEncoderMem(true))
+		return w
+	},
+}
+
+// compressionEncodings are the supported response content encodings, in order
+// of preference.
+var compressionEncodings = []string{"zstd", "gzip"}
 
-	gz            *gzip.Writer
-	contentLength uint64 // total length of the uncompressed response
-	written       uint64 // amount of written bytes from the uncompressed response
-	hasLength     bool   // true if uncompressed response had Content-Length
-	inited        bool   // true after init was called for the first time
+var (
+	compressionRatioHist = metrics.NewRegisteredHistogram("rpc/http/compression/ratio", nil, metrics.NewExpDecaySample(1028, 0.015))
+
+	compressionInMeters = map[string]*metrics.Meter{
+		"gzip": metrics.NewRegisteredMeter("rpc/http/compression/gzip/in", nil),
+		"zstd": metrics.NewRegisteredMeter("rpc/http/compression/zstd/in", nil),
+	}
+	compressionOutMeters = map[string]*metrics.Meter{
+		"gzip": metrics.NewRegisteredMeter("rpc/http/compression/gzip/out", nil),
+		"zstd": metrics.NewRegisteredMeter("rpc/http/compression/zstd/out", nil),
+	}
+)
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n uint64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += uint64(n)
+	return n, err
+}
+
+// negotiateEncoding returns the preferred supported content encoding accepted
+// by the given Accept-Encoding header, or an empty string if there is none.
+func negotiateEncoding(header string) string {
+	var (
+		best    string
+		bestQ   = 0.0
+		wildQ   = -1.0
+		quality = make(map[string]float64)
+	)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(key, "q") {
+				if v, err := strconv.ParseFloat(value, 64); err == nil {
+					q = v
+				}
+			}
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "*" {
+			wildQ = q
+		} else {
+			quality[name] = q
+		}
+	}
+	for _, enc := range compressionEncodings {
+		q, ok := quality[enc]
+		if !ok {
+			q = wildQ
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+type compressResponseWriter struct {
+	resp     http.ResponseWriter
+	encoding string
+
+	enc           compressor
+	out           *countingWriter // counts the compressed bytes written to resp
+	contentLength uint64          // total length of the uncompressed response
+	written       uint64          // amount of written bytes from the uncompressed response
+	hasLength     bool            // true if uncompressed response had Content-Length
+	inited        bool            // true after init was called for the first time
 }
 
 // init runs just before response headers are written. Among other things, this function
 // also decides whether compression will be applied at all.
-func (w *gzipResponseWriter) init() {
+func (w *compressResponseWriter) init() {
 	if w.inited {
 		return
 	}
@@ -509,7 +886,7 @@ func (w *gzipResponseWriter) init() {
 	// Setting Transfer-Encoding to "identity" explicitly disables compression. net/http
 	// also recognizes this header value and uses it to disable "chunked" transfer
 	// encoding, trimming the header from the response. This means downstream handlers can
-	// set this without harm, even if they aren't wrapped by newGzipHandler.
+	// set this without harm, even if they aren't wrapped by newCompressionHandler.
 	//
 	// In go-ethereum, we use this signal to disable compression for certain error
 	// responses which are flushed out close to the write deadline of the response. For
@@ -517,67 +894,95 @@ func (w *gzipResponseWriter) init() {
 	// they require additional output that may not get written in time.
 	passthrough := hdr.Get("transfer-encoding") == "identity"
 	if !passthrough {
-		w.gz = gzPool.Get().(*gzip.Writer)
-		w.gz.Reset(w.resp)
+		switch w.encoding {
+		case "zstd":
+			w.enc = zstdPool.Get().(*zstd.Encoder)
+		default:
+			w.enc = gzPool.Get().(*gzip.Writer)
+		}
+		w.out = &countingWriter{w: w.resp}
+		w.enc.Reset(w.out)
 		hdr.Del("content-length")
-		hdr.Set("content-encoding", "gzip")
+		hdr.Set("content-encoding", w.encoding)
 	}
+	// The response varies with the accepted encodings, whether compressed or not.
+	hdr.Add("vary", "Accept-Encoding")
 }
 
-func (w *gzipResponseWriter) Header() http.Header {
+func (w *compressResponseWriter) Header() http.Header {
 	return w.resp.Header()
 }
 
-func (w *gzipResponseWriter) WriteHeader(status int) {
+// Unwrap returns the wrapped writer, for http.ResponseController.
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.resp
+}
+
+func (w *compressResponseWriter) WriteHeader(status int) {
 	w.init()
 	w.resp.WriteHeader(status)
 }
 
-func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
 	w.init()
 
-	if w.gz == nil {
+	if w.enc == nil {
 		// Compression is disabled.
 		return w.resp.Write(b)
 	}
 
-	n, err := w.gz.Write(b)
+	n, err := w.enc.Write(b)
 	w.written += uint64(n)
 	if w.hasLength && w.written >= w.contentLength {
 		// The HTTP handler has finished writing the entire uncompressed response. Close
-		// the gzip stream to ensure the footer will be seen by the client in case the
-		// response is flushed after this call to write.
-		err = w.gz.Close()
+		// the compressed stream to ensure the footer will be seen by the client in case
+		// the response is flushed after this call to write.
+		err = w.enc.Close()
 	}
 	return n, err
 }
 
-func (w *gzipResponseWriter) Flush() {
-	if w.gz != nil {
-		w.gz.Flush()
+func (w *compressResponseWriter) Flush() {
+	if w.enc != nil {
+		w.enc.Flush()
 	}
 	if f, ok := w.resp.(http.Flusher); ok {
 		f.Flush()
 	}
 }
 
-func (w *gzipResponseWriter) close() {
-	if w.gz == nil {
+func (w *compressResponseWriter) close() {
+	if w.enc == nil {
 		return
 	}
-	w.gz.Close()
-	gzPool.Put(w.gz)
-	w.gz = nil
+	w.enc.Close()
+	w.enc.Reset(io.Discard) // Release the response writer
+	switch enc := w.enc.(type) {
+	case *zstd.Encoder:
+		zstdPool.Put(enc)
+	case *gzip.Writer:
+		gzPool.Put(enc)
+	}
+	w.enc = nil
+
+	if w.written > 0 {
+		compressionInMeters[w.encoding].Mark(int64(w.written))
+		compressionOutMeters[w.encoding].Mark(int64(w.out.n))
+		compressionRatioHist.Update(int64(w.out.n * 100 / w.written))
+	}
 }
 
-func newGzipHandler(next http.Handler) http.Handler {
+// newCompressionHandler compresses the responses in the encoding negotiated with
+// the Accept-Encoding header of the request, gzip or zstd.
+func newCompressionHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		wrapper := &gzipResponseWriter{resp: w}
+		wrapper := &compressResponseWriter{resp: w, encoding: encoding}
 		defer wrapper.close()
 
 		next.ServeHTTP(wrapper, r)
@@ -587,14 +992,15 @@ func newGzipHandler(next http.Handler) http.Handler {
 type ipcServer struct {
 	log      log.Logger
 	endpoint string
+	mode     os.FileMode // socket file permissions, left to the default if zero
 
 	mu       sync.Mutex
 	listener net.Listener
 	srv      *rpc.Server
 }
 
-func newIPCServer(log log.Logger, endpoint string) *ipcServer {
-	return &ipcServer{log: log, endpoint: endpoint}
+func newIPCServer(log log.Logger, endpoint string, mode os.FileMode) *ipcServer {
+	return &ipcServer{log: log, endpoint: endpoint, mode: mode}
 }
 
 // start starts the httpServer's http.Server
@@ -610,11 +1016,30 @@ func (is *ipcServer) start(apis []rpc.API) error {
 		is.log.Warn("IPC opening failed", "url", is.endpoint, "error", err)
 		return err
 	}
+	// Named pipes don't have file permissions on Windows.
+	if is.mode != 0 && runtime.GOOS != "windows" {
+		if err := os.Chmod(is.endpoint, is.mode); err != nil {
+			listener.Close()
+			srv.Stop()
+			return err
+		}
+	}
 	is.log.Info("IPC endpoint opened", "url", is.endpoint)
 	is.listener, is.srv = listener, srv
 	return nil
 }
 
+// subscriptions returns the active subscriptions of the IPC endpoint.
+func (is *ipcServer) subscriptions() []rpc.SubscriptionInfo {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	if is.srv == nil {
+		return nil
+	}
+	return is.srv.Subscriptions()
+}
+
 func (is *ipcServer) stop() error {
 	is.mu.Lock()
 	defer is.mu.Unlock()
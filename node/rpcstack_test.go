@@ -18,11 +18,24 @@ package node
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -33,7 +46,9 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/gorilla/websocket"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
 )
 
 const testMethod = "rpc_modules"
@@ -339,7 +354,7 @@ func TestJWT(t *testing.T) {
 		ss, _ := jwt.NewWithClaims(method, testClaim(input)).SignedString(secret)
 		return ss
 	}
-	cfg := rpcEndpointConfig{jwtSecret: []byte("secret")}
+	cfg := rpcEndpointConfig{jwtKeys: singleJWTKeyring([]byte("secret"))}
 	httpcfg := &httpConfig{rpcEndpointConfig: cfg}
 	wscfg := &wsConfig{Origins: []string{"*"}, rpcEndpointConfig: cfg}
 	srv := createAndStartServer(t, httpcfg, true, wscfg, nil)
@@ -523,7 +538,7 @@ func TestGzipHandler(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			srv := httptest.NewServer(newGzipHandler(test.handler))
+			srv := httptest.NewServer(newCompressionHandler(test.handler))
 			defer srv.Close()
 
 			resp, err := http.Get(srv.URL)
@@ -556,6 +571,334 @@ func TestGzipHandler(t *testing.T) {
 	}
 }
 
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"zstd", "zstd"},
+		{"gzip, deflate, br, zstd", "zstd"},
+		{"gzip;q=1.0, zstd;q=0.5", "gzip"},
+		{"zstd;q=0, gzip", "gzip"},
+		{"zstd;q=0, gzip;q=0", ""},
+		{"*", "zstd"},
+		{"*;q=0.1, gzip", "gzip"},
+		{"*, zstd;q=0", "gzip"},
+		{"GZIP", "gzip"},
+	}
+	for _, test := range tests {
+		if have := negotiateEncoding(test.header); have != test.want {
+			t.Errorf("Accept-Encoding %q: encoding %q, want %q", test.header, have, test.want)
+		}
+	}
+}
+
+func TestZstdHandler(t *testing.T) {
+	response := bytes.Repeat([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000"}`), 1024)
+	srv := httptest.NewServer(newCompressionHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(response)
+	})))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("accept-encoding", "gzip, zstd")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if enc := resp.Header.Get("content-encoding"); enc != "zstd" {
+		t.Fatalf("response content encoding %q, want zstd", enc)
+	}
+	compressed, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(compressed) >= len(response)/10 {
+		t.Fatalf("response not compressed: %d bytes, uncompressed %d", len(compressed), len(response))
+	}
+	dec, err := zstd.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Close()
+	content, err := io.ReadAll(dec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, response) {
+		t.Fatal("wrong response content")
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate for localhost and its
+// key into the given directory, and returns the paths of the files.
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		certFile = filepath.Join(dir, "cert.pem")
+		keyFile  = filepath.Join(dir, "key.pem")
+	)
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestHTTP2(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+
+	tests := []struct {
+		name    string
+		tlsCert string
+		tlsKey  string
+		h2c     bool
+		client  http.RoundTripper
+		proto   int
+	}{
+		{
+			name:    "tls",
+			tlsCert: certFile,
+			tlsKey:  keyFile,
+			client: &http.Transport{
+				TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+				ForceAttemptHTTP2: true,
+			},
+			proto: 2,
+		},
+		{
+			name:    "tls-http1",
+			tlsCert: certFile,
+			tlsKey:  keyFile,
+			client: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+				TLSNextProto:    map[string]func(string, *tls.Conn) http.RoundTripper{},
+			},
+			proto: 1,
+		},
+		{
+			name: "h2c",
+			h2c:  true,
+			client: &http2.Transport{
+				AllowHTTP: true,
+				DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+					return new(net.Dialer).DialContext(ctx, network, addr)
+				},
+			},
+			proto: 2,
+		},
+		{
+			name:   "h2c-http1",
+			h2c:    true,
+			client: &http.Transport{},
+			proto:  1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := newHTTPServer(testlog.Logger(t, log.LvlDebug), rpc.DefaultHTTPTimeouts)
+			assert.NoError(t, srv.enableRPC(apis(), httpConfig{}))
+			assert.NoError(t, srv.setListenAddr("localhost", 0))
+			assert.NoError(t, srv.setTransport(test.tlsCert, test.tlsKey, test.h2c))
+			assert.NoError(t, srv.start())
+			defer srv.stop()
+
+			scheme := "http"
+			if test.tlsCert != "" {
+				scheme = "https"
+			}
+			body := strings.NewReader(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":[]}`, testMethod))
+			req, _ := http.NewRequest(http.MethodPost, scheme+"://"+srv.listenAddr(), body)
+			req.Header.Set("content-type", "application/json")
+
+			resp, err := (&http.Client{Transport: test.client}).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.ProtoMajor != test.proto {
+				t.Fatalf("response protocol %s, want HTTP/%d", resp.Proto, test.proto)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("response status %d", resp.StatusCode)
+			}
+		})
+	}
+	// Certificates without keys are refused
+	srv := newHTTPServer(testlog.Logger(t, log.LvlDebug), rpc.DefaultHTTPTimeouts)
+	if err := srv.setTransport(certFile, "", false); err == nil {
+		t.Fatal("TLS certificate without key accepted")
+	}
+}
+
+// newTestClientCertificate creates a TLS client certificate with the given DNS
+// name, signed by the given CA, or self-signed if the CA is nil.
+func newTestClientCertificate(t *testing.T, name string, ca *tls.Certificate) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  ca == nil,
+	}
+	parent, parentKey := template, any(key)
+	if ca != nil {
+		parent, parentKey = ca.Leaf, ca.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestTLSClientAuth(t *testing.T) {
+	var (
+		dir               = t.TempDir()
+		certFile, keyFile = writeTestCertificate(t, dir)
+		caFile            = filepath.Join(dir, "ca.pem")
+
+		ca       = newTestClientCertificate(t, "ca", nil)
+		signed   = newTestClientCertificate(t, "engine.cl", &ca)
+		other    = newTestClientCertificate(t, "other.cl", &ca)
+		selfSign = newTestClientCertificate(t, "engine.cl", nil)
+		rogue    = newTestClientCertificate(t, "engine.cl", nil)
+	)
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Certificate[0]}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	pin := fmt.Sprintf("%X", sha256.Sum256(selfSign.Certificate[0]))
+
+	tests := []struct {
+		name     string
+		caFile   string
+		pins     []string
+		names    []string
+		accepted []*tls.Certificate
+		rejected []*tls.Certificate
+	}{
+		{
+			name:     "ca",
+			caFile:   caFile,
+			accepted: []*tls.Certificate{&signed, &other},
+			rejected: []*tls.Certificate{&selfSign, nil},
+		},
+		{
+			name:     "pinned",
+			pins:     []string{pin},
+			accepted: []*tls.Certificate{&selfSign},
+			rejected: []*tls.Certificate{&signed, nil},
+		},
+		{
+			name:     "ca-or-pinned",
+			caFile:   caFile,
+			pins:     []string{pin},
+			accepted: []*tls.Certificate{&signed, &other, &selfSign},
+			rejected: []*tls.Certificate{&rogue, nil},
+		},
+		{
+			name:     "names",
+			caFile:   caFile,
+			names:    []string{"engine.cl"},
+			accepted: []*tls.Certificate{&signed},
+			rejected: []*tls.Certificate{&other, &selfSign},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := newHTTPServer(testlog.Logger(t, log.LvlDebug), rpc.DefaultHTTPTimeouts)
+			assert.NoError(t, srv.enableRPC(apis(), httpConfig{}))
+			assert.NoError(t, srv.setListenAddr("localhost", 0))
+			assert.NoError(t, srv.setTransport(certFile, keyFile, false))
+			assert.NoError(t, srv.setClientAuth(test.caFile, test.pins, test.names))
+			assert.NoError(t, srv.start())
+			defer srv.stop()
+
+			call := func(cert *tls.Certificate) error {
+				config := &tls.Config{InsecureSkipVerify: true}
+				if cert != nil {
+					config.Certificates = []tls.Certificate{*cert}
+				}
+				client := &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+				body := strings.NewReader(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":[]}`, testMethod))
+				resp, err := client.Post("https://"+srv.listenAddr(), "application/json", body)
+				if err != nil {
+					return err
+				}
+				resp.Body.Close()
+				return nil
+			}
+			for i, cert := range test.accepted {
+				if err := call(cert); err != nil {
+					t.Errorf("accepted certificate %d rejected: %v", i, err)
+				}
+			}
+			for i, cert := range test.rejected {
+				if err := call(cert); err == nil {
+					t.Errorf("rejected certificate %d accepted", i)
+				}
+			}
+		})
+	}
+	// Client authentication requires TLS and a way to verify the certificates
+	srv := newHTTPServer(testlog.Logger(t, log.LvlDebug), rpc.DefaultHTTPTimeouts)
+	if err := srv.setClientAuth(caFile, nil, nil); err == nil {
+		t.Fatal("client authentication without TLS accepted")
+	}
+	assert.NoError(t, srv.setTransport(certFile, keyFile, false))
+	if err := srv.setClientAuth("", nil, []string{"engine.cl"}); err == nil {
+		t.Fatal("client names without verification accepted")
+	}
+	if err := srv.setClientAuth("", []string{"00"}, nil); err == nil {
+		t.Fatal("invalid fingerprint accepted")
+	}
+}
+
 func TestHTTPWriteTimeout(t *testing.T) {
 	const (
 		timeoutRes = `{"jsonrpc":"2.0","id":1,"error":{"code":-32002,"message":"request timed out"}}`
@@ -0,0 +1,81 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Shutdown gracefully shuts down the node. The drain phases of the registered
+// lifecycles are run in registration order, sharing the given drain time, and
+// the node is closed afterwards. A phase failing or running out of time is
+// logged, but doesn't prevent the following ones from running.
+func (n *Node) Shutdown(drain time.Duration) error {
+	if !n.shuttingDown.CompareAndSwap(false, true) {
+		return ErrShuttingDown
+	}
+	return n.shutdown(drain)
+}
+
+// DrainPhases returns the drain phases of the registered lifecycles, in
+// registration order, or nothing if the node is not running.
+func (n *Node) DrainPhases() []DrainPhase {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	var phases []DrainPhase
+	if n.state == runningState {
+		for _, lifecycle := range n.lifecycles {
+			if drainer, ok := lifecycle.(Drainer); ok {
+				phases = append(phases, drainer.DrainPhases()...)
+			}
+		}
+	}
+	return phases
+}
+
+// shutdown runs the drain phases and closes the node, once the shutdown has
+// been claimed by the caller.
+func (n *Node) shutdown(drain time.Duration) error {
+	phases := n.DrainPhases()
+
+	var (
+		start       = time.Now()
+		total       = len(phases) + 1
+		ctx, cancel = context.WithTimeout(context.Background(), drain)
+	)
+	defer cancel()
+
+	log.Info("Shutting down node", "phases", total, "drain", common.PrettyDuration(drain))
+	for i, phase := range phases {
+		begin := time.Now()
+		log.Info("Shutdown phase started", "phase", i+1, "total", total, "name", phase.Name)
+		if err := phase.Run(ctx); err != nil {
+			log.Warn("Shutdown phase failed", "phase", i+1, "total", total, "name", phase.Name, "elapsed", common.PrettyDuration(time.Since(begin)), "err", err)
+			continue
+		}
+		log.Info("Shutdown phase finished", "phase", i+1, "total", total, "name", phase.Name, "elapsed", common.PrettyDuration(time.Since(begin)))
+	}
+	log.Info("Shutdown phase started", "phase", total, "total", total, "name", "close")
+	err := n.Close()
+	log.Info("Node shut down", "elapsed", common.PrettyDuration(time.Since(start)))
+	return err
+}
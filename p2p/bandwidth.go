@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"golang.org/x/time/rate"
+)
+
+// bandwidthMeterName is the prefix of the per-protocol traffic meters.
+const bandwidthMeterName = "p2p/bandwidth"
+
+var (
+	// bandwidthThrottleTimer measures the time writes are delayed by the caps.
+	bandwidthThrottleTimer = metrics.NewRegisteredTimer(bandwidthMeterName+"/throttle", nil)
+
+	// bandwidthIngressThrottleTimer measures the time reads are delayed by the caps.
+	bandwidthIngressThrottleTimer = metrics.NewRegisteredTimer(bandwidthMeterName+"/throttle/ingress", nil)
+)
+
+// BandwidthStats contains the traffic of a subprotocol. The sizes are message
+// payload sizes, the framing and compression of the transport are not included.
+type BandwidthStats struct {
+	Ingress uint64 `json:"ingress"` // Total bytes received
+	Egress  uint64 `json:"egress"`  // Total bytes sent
+}
+
+// protoTraffic counts the payload bytes transferred on a subprotocol.
+type protoTraffic struct {
+	ingress atomic.Uint64
+	egress  atomic.Uint64
+}
+
+// meterIngress accounts a message received on the given protocol.
+func (rw *protoRW) meterIngress(size uint32) {
+	rw.traffic.ingress.Add(uint64(size))
+	if metrics.Enabled() {
+		metrics.GetOrRegisterMeter(fmt.Sprintf("%s/%s/ingress", bandwidthMeterName, rw.Name), nil).Mark(int64(size))
+	}
+}
+
+// meterEgress accounts a message sent on the given protocol.
+func (rw *protoRW) meterEgress(size uint32) {
+	rw.traffic.egress.Add(uint64(size))
+	if metrics.Enabled() {
+		metrics.GetOrRegisterMeter(fmt.Sprintf("%s/%s/egress", bandwidthMeterName, rw.Name), nil).Mark(int64(size))
+	}
+}
+
+// bandwidthLimits enforces the bandwidth caps of one direction, shared by all
+// peers.
+type bandwidthLimits struct {
+	global *rate.Limiter            // Cap of all subprotocols, nil if unlimited
+	protos map[string]*rate.Limiter // Caps by subprotocol name
+	timer  *metrics.Timer           // Timer measuring the throttling delays
+}
+
+// newBandwidthLimits creates the limiters of the given caps in bytes per second.
+// It returns nil if no caps are set.
+func newBandwidthLimits(global int, protos map[string]int, timer *metrics.Timer) *bandwidthLimits {
+	limits := &bandwidthLimits{protos: make(map[string]*rate.Limiter), timer: timer}
+	if global > 0 {
+		limits.global = rate.NewLimiter(rate.Limit(global), global)
+	}
+	for name, limit := range protos {
+		if limit > 0 {
+			limits.protos[name] = rate.NewLimiter(rate.Limit(limit), limit)
+		}
+	}
+	if limits.global == nil && len(limits.protos) == 0 {
+		return nil
+	}
+	return limits
+}
+
+// wait blocks until transferring size bytes on the given protocol is allowed by
+// the caps, or the peer is closed.
+func (l *bandwidthLimits) wait(proto string, size int, closed <-chan struct{}) error {
+	if l == nil {
+		return nil
+	}
+	start := time.Now()
+	if err := waitLimiter(l.protos[proto], size, closed); err != nil {
+		return err
+	}
+	if err := waitLimiter(l.global, size, closed); err != nil {
+		return err
+	}
+	if elapsed := time.Since(start); elapsed > time.Millisecond {
+		l.timer.Update(elapsed)
+	}
+	return nil
+}
+
+// waitLimiter blocks until size tokens are taken from the limiter. Sizes above
+// the burst of the limiter are taken in multiple chunks.
+func waitLimiter(lim *rate.Limiter, size int, closed <-chan struct{}) error {
+	if lim == nil {
+		return nil
+	}
+	for size > 0 {
+		chunk := min(size, lim.Burst())
+		r := lim.ReserveN(time.Now(), chunk)
+		if delay := r.Delay(); delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-closed:
+				timer.Stop()
+				r.Cancel()
+				return ErrShuttingDown
+			}
+		}
+		size -= chunk
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// This test checks that the traffic of the subprotocols is accounted per peer.
+func TestPeerBandwidthAccounting(t *testing.T) {
+	var (
+		sent = make(chan struct{})
+		done = make(chan struct{})
+	)
+	proto := Protocol{
+		Name:   "a",
+		Length: 5,
+		Run: func(peer *Peer, rw MsgReadWriter) error {
+			if err := ExpectMsg(rw, 2, []uint{1}); err != nil {
+				t.Error(err)
+			}
+			if err := SendItems(rw, 3, make([]byte, 100)); err != nil {
+				t.Error(err)
+			}
+			close(sent)
+			<-done
+			return nil
+		},
+	}
+	closer, rw, peer, _ := testPeer([]Protocol{proto})
+	defer closer()
+
+	Send(rw, baseProtocolLength+2, []uint{1})
+	msg, err := rw.ReadMsg()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.Discard()
+	<-sent
+
+	stats := peer.Info().Bandwidth["a"]
+	close(done)
+	if stats == nil {
+		t.Fatal("missing bandwidth stats")
+	}
+	if stats.Ingress != 2 { // rlp([1])
+		t.Errorf("wrong ingress: have %d, want %d", stats.Ingress, 2)
+	}
+	if stats.Egress != 104 { // rlp([100 bytes])
+		t.Errorf("wrong egress: have %d, want %d", stats.Egress, 104)
+	}
+}
+
+// This test checks that the bandwidth caps delay the writes exceeding them.
+func TestBandwidthLimits(t *testing.T) {
+	if limits := newBandwidthLimits(0, map[string]int{"a": 0}, bandwidthThrottleTimer); limits != nil {
+		t.Fatal("limits created without caps")
+	}
+	var (
+		limits = newBandwidthLimits(0, map[string]int{"a": 1000}, bandwidthThrottleTimer)
+		closed = make(chan struct{})
+	)
+	// The burst is available right away, unlimited protocols are not delayed.
+	start := time.Now()
+	if err := limits.wait("a", 1000, closed); err != nil {
+		t.Fatal(err)
+	}
+	if err := limits.wait("b", 100000, closed); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("unthrottled writes delayed by %v", elapsed)
+	}
+	// Exceeding the cap must wait for the tokens to refill.
+	start = time.Now()
+	if err := limits.wait("a", 200, closed); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("throttled write delayed by %v only", elapsed)
+	}
+	// Closing the peer aborts the wait.
+	close(closed)
+	if err := limits.wait("a", 5000, closed); err != ErrShuttingDown {
+		t.Fatalf("wrong error for closed peer: %v", err)
+	}
+}
+
+// This test checks that the ingress caps delay the delivery of the received
+// messages to the subprotocols.
+func TestPeerIngressLimits(t *testing.T) {
+	var (
+		proto = Protocol{Name: "a", Length: 5}
+		conn  = &conn{node: newNode(uintID(1), ""), caps: []Cap{proto.cap()}}
+		peer  = newPeer(log.Root(), conn, []Protocol{proto})
+		rw    = peer.running["a"]
+	)
+	rw.ingress = newBandwidthLimits(0, map[string]int{"a": 1000}, bandwidthIngressThrottleTimer)
+	go func() {
+		for {
+			select {
+			case <-rw.in:
+			case <-peer.closed:
+				return
+			}
+		}
+	}()
+	deliver := func(size uint32) error {
+		return peer.handle(Msg{Code: baseProtocolLength, Size: size, Payload: bytes.NewReader(make([]byte, size))})
+	}
+	// The burst is delivered right away, exceeding the cap must wait
+	start := time.Now()
+	if err := deliver(1000); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("unthrottled read delayed by %v", elapsed)
+	}
+	start = time.Now()
+	if err := deliver(200); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("throttled read delayed by %v only", elapsed)
+	}
+	// Closing the peer aborts the wait.
+	close(peer.closed)
+	if err := deliver(5000); err != ErrShuttingDown {
+		t.Fatalf("wrong error for closed peer: %v", err)
+	}
+	if ingress := rw.traffic.ingress.Load(); ingress != 6200 {
+		t.Errorf("wrong ingress: have %d, want %d", ingress, 6200)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 //go:generate go run github.com/fjl/gencodec -type Config -field-override configMarshaling -formats toml -out config_toml.go
@@ -60,9 +61,17 @@ type Config struct {
 	// protocol should be started or not.
 	DiscoveryV5 bool `toml:",omitempty"`
 
+	// DiscoveryV5Topics specifies whether the V5 discovery serves the topic
+	// registrations and queries of other nodes.
+	DiscoveryV5Topics bool `toml:",omitempty"`
+
 	// Name sets the node name of this server.
 	Name string `toml:"-"`
 
+	// Identity is an optional RLP item advertised in the devp2p handshake next
+	// to the name, describing the build and chain configuration of the node.
+	Identity rlp.RawValue `toml:"-"`
+
 	// BootstrapNodes are used to establish connectivity
 	// with the rest of the network.
 	BootstrapNodes []*enode.Node
@@ -111,6 +120,29 @@ type Config struct {
 	// Internet.
 	NAT nat.Interface `toml:",omitempty"`
 
+	// If set to a non-nil value, the given mechanism (e.g. STUN) is used
+	// to detect the external IP address whenever the NAT port mapper
+	// fails to provide it.
+	NATFallback nat.Interface `toml:",omitempty"`
+
+	// BandwidthCap is the maximum egress bandwidth of the subprotocol messages
+	// sent to all peers, in bytes per second. Zero means unlimited.
+	BandwidthCap int `toml:",omitempty"`
+
+	// ProtocolBandwidthCaps is the maximum egress bandwidth of the messages of
+	// the given subprotocols sent to all peers, in bytes per second.
+	ProtocolBandwidthCaps map[string]int `toml:",omitempty"`
+
+	// IngressBandwidthCap is the maximum ingress bandwidth of the subprotocol
+	// messages received from all peers, in bytes per second. Zero means
+	// unlimited. Reading from the peers is delayed once the cap is reached.
+	IngressBandwidthCap int `toml:",omitempty"`
+
+	// IngressProtocolBandwidthCaps is the maximum ingress bandwidth of the
+	// messages of the given subprotocols received from all peers, in bytes per
+	// second.
+	IngressProtocolBandwidthCaps map[string]int `toml:",omitempty"`
+
 	// If Dialer is set to a non-nil value, the given Dialer
 	// is used to dial outbound peer connections.
 	Dialer NodeDialer `toml:"-"`
@@ -129,7 +161,8 @@ type Config struct {
 }
 
 type configMarshaling struct {
-	NAT configNAT
+	NAT         configNAT
+	NATFallback configNAT
 }
 
 type configNAT struct {
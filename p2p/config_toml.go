@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 var _ = (*configMarshaling)(nil)
@@ -16,28 +17,35 @@ var _ = (*configMarshaling)(nil)
 // MarshalTOML marshals as TOML.
 func (c Config) MarshalTOML() (interface{}, error) {
 	type Config struct {
-		PrivateKey       *ecdsa.PrivateKey `toml:"-"`
-		MaxPeers         int
-		MaxPendingPeers  int `toml:",omitempty"`
-		DialRatio        int `toml:",omitempty"`
-		NoDiscovery      bool
-		DiscoveryV4      bool   `toml:",omitempty"`
-		DiscoveryV5      bool   `toml:",omitempty"`
-		Name             string `toml:"-"`
-		BootstrapNodes   []*enode.Node
-		BootstrapNodesV5 []*enode.Node `toml:",omitempty"`
-		StaticNodes      []*enode.Node
-		TrustedNodes     []*enode.Node
-		NetRestrict      *netutil.Netlist `toml:",omitempty"`
-		NodeDatabase     string           `toml:",omitempty"`
-		Protocols        []Protocol       `toml:"-" json:"-"`
-		ListenAddr       string
-		DiscAddr         string
-		NAT              nat.Interface `toml:",omitempty"`
-		Dialer           NodeDialer    `toml:"-"`
-		NoDial           bool          `toml:",omitempty"`
-		EnableMsgEvents  bool
-		Logger           log.Logger `toml:"-"`
+		PrivateKey                   *ecdsa.PrivateKey `toml:"-"`
+		MaxPeers                     int
+		MaxPendingPeers              int `toml:",omitempty"`
+		DialRatio                    int `toml:",omitempty"`
+		NoDiscovery                  bool
+		DiscoveryV4                  bool         `toml:",omitempty"`
+		DiscoveryV5                  bool         `toml:",omitempty"`
+		DiscoveryV5Topics            bool         `toml:",omitempty"`
+		Name                         string       `toml:"-"`
+		Identity                     rlp.RawValue `toml:"-"`
+		BootstrapNodes               []*enode.Node
+		BootstrapNodesV5             []*enode.Node `toml:",omitempty"`
+		StaticNodes                  []*enode.Node
+		TrustedNodes                 []*enode.Node
+		NetRestrict                  *netutil.Netlist `toml:",omitempty"`
+		NodeDatabase                 string           `toml:",omitempty"`
+		Protocols                    []Protocol       `toml:"-" json:"-"`
+		ListenAddr                   string
+		DiscAddr                     string
+		NAT                          nat.Interface  `toml:",omitempty"`
+		NATFallback                  nat.Interface  `toml:",omitempty"`
+		BandwidthCap                 int            `toml:",omitempty"`
+		ProtocolBandwidthCaps        map[string]int `toml:",omitempty"`
+		IngressBandwidthCap          int            `toml:",omitempty"`
+		IngressProtocolBandwidthCaps map[string]int `toml:",omitempty"`
+		Dialer                       NodeDialer     `toml:"-"`
+		NoDial                       bool           `toml:",omitempty"`
+		EnableMsgEvents              bool
+		Logger                       log.Logger `toml:"-"`
 	}
 	var enc Config
 	enc.PrivateKey = c.PrivateKey
@@ -47,7 +55,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.NoDiscovery = c.NoDiscovery
 	enc.DiscoveryV4 = c.DiscoveryV4
 	enc.DiscoveryV5 = c.DiscoveryV5
+	enc.DiscoveryV5Topics = c.DiscoveryV5Topics
 	enc.Name = c.Name
+	enc.Identity = c.Identity
 	enc.BootstrapNodes = c.BootstrapNodes
 	enc.BootstrapNodesV5 = c.BootstrapNodesV5
 	enc.StaticNodes = c.StaticNodes
@@ -58,6 +68,11 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.ListenAddr = c.ListenAddr
 	enc.DiscAddr = c.DiscAddr
 	enc.NAT = c.NAT
+	enc.NATFallback = c.NATFallback
+	enc.BandwidthCap = c.BandwidthCap
+	enc.ProtocolBandwidthCaps = c.ProtocolBandwidthCaps
+	enc.IngressBandwidthCap = c.IngressBandwidthCap
+	enc.IngressProtocolBandwidthCaps = c.IngressProtocolBandwidthCaps
 	enc.Dialer = c.Dialer
 	enc.NoDial = c.NoDial
 	enc.EnableMsgEvents = c.EnableMsgEvents
@@ -68,28 +83,35 @@ func (c Config) MarshalTOML() (interface{}, error) {
 // UnmarshalTOML unmarshals from TOML.
 func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	type Config struct {
-		PrivateKey       *ecdsa.PrivateKey `toml:"-"`
-		MaxPeers         *int
-		MaxPendingPeers  *int `toml:",omitempty"`
-		DialRatio        *int `toml:",omitempty"`
-		NoDiscovery      *bool
-		DiscoveryV4      *bool   `toml:",omitempty"`
-		DiscoveryV5      *bool   `toml:",omitempty"`
-		Name             *string `toml:"-"`
-		BootstrapNodes   []*enode.Node
-		BootstrapNodesV5 []*enode.Node `toml:",omitempty"`
-		StaticNodes      []*enode.Node
-		TrustedNodes     []*enode.Node
-		NetRestrict      *netutil.Netlist `toml:",omitempty"`
-		NodeDatabase     *string          `toml:",omitempty"`
-		Protocols        []Protocol       `toml:"-" json:"-"`
-		ListenAddr       *string
-		DiscAddr         *string
-		NAT              *configNAT `toml:",omitempty"`
-		Dialer           NodeDialer `toml:"-"`
-		NoDial           *bool      `toml:",omitempty"`
-		EnableMsgEvents  *bool
-		Logger           log.Logger `toml:"-"`
+		PrivateKey                   *ecdsa.PrivateKey `toml:"-"`
+		MaxPeers                     *int
+		MaxPendingPeers              *int `toml:",omitempty"`
+		DialRatio                    *int `toml:",omitempty"`
+		NoDiscovery                  *bool
+		DiscoveryV4                  *bool         `toml:",omitempty"`
+		DiscoveryV5                  *bool         `toml:",omitempty"`
+		DiscoveryV5Topics            *bool         `toml:",omitempty"`
+		Name                         *string       `toml:"-"`
+		Identity                     *rlp.RawValue `toml:"-"`
+		BootstrapNodes               []*enode.Node
+		BootstrapNodesV5             []*enode.Node `toml:",omitempty"`
+		StaticNodes                  []*enode.Node
+		TrustedNodes                 []*enode.Node
+		NetRestrict                  *netutil.Netlist `toml:",omitempty"`
+		NodeDatabase                 *string          `toml:",omitempty"`
+		Protocols                    []Protocol       `toml:"-" json:"-"`
+		ListenAddr                   *string
+		DiscAddr                     *string
+		NAT                          *configNAT     `toml:",omitempty"`
+		NATFallback                  *configNAT     `toml:",omitempty"`
+		BandwidthCap                 *int           `toml:",omitempty"`
+		ProtocolBandwidthCaps        map[string]int `toml:",omitempty"`
+		IngressBandwidthCap          *int           `toml:",omitempty"`
+		IngressProtocolBandwidthCaps map[string]int `toml:",omitempty"`
+		Dialer                       NodeDialer     `toml:"-"`
+		NoDial                       *bool          `toml:",omitempty"`
+		EnableMsgEvents              *bool
+		Logger                       log.Logger `toml:"-"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -116,9 +138,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.DiscoveryV5 != nil {
 		c.DiscoveryV5 = *dec.DiscoveryV5
 	}
+	if dec.DiscoveryV5Topics != nil {
+		c.DiscoveryV5Topics = *dec.DiscoveryV5Topics
+	}
 	if dec.Name != nil {
 		c.Name = *dec.Name
 	}
+	if dec.Identity != nil {
+		c.Identity = *dec.Identity
+	}
 	if dec.BootstrapNodes != nil {
 		c.BootstrapNodes = dec.BootstrapNodes
 	}
@@ -149,6 +177,21 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.NAT != nil {
 		c.NAT = dec.NAT
 	}
+	if dec.NATFallback != nil {
+		c.NATFallback = dec.NATFallback
+	}
+	if dec.BandwidthCap != nil {
+		c.BandwidthCap = *dec.BandwidthCap
+	}
+	if dec.ProtocolBandwidthCaps != nil {
+		c.ProtocolBandwidthCaps = dec.ProtocolBandwidthCaps
+	}
+	if dec.IngressBandwidthCap != nil {
+		c.IngressBandwidthCap = *dec.IngressBandwidthCap
+	}
+	if dec.IngressProtocolBandwidthCaps != nil {
+		c.IngressProtocolBandwidthCaps = dec.IngressProtocolBandwidthCaps
+	}
 	if dec.Dialer != nil {
 		c.Dialer = dec.Dialer
 	}
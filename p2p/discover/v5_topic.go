@@ -0,0 +1,387 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/time/rate"
+)
+
+// The topic system implements a simple form of topic advertisement on top of
+// TALKREQ. Nodes advertise themselves under a topic by registering at the nodes
+// closest to the topic in the DHT. These store the registrations for a limited
+// time and hand them out when queried for the topic. Only the nodes configured
+// as registrars serve the requests, each source IP at a limited rate.
+const (
+	topicProtocol = "topic"
+
+	topicAdLifetime     = 15 * time.Minute // Time a registration is kept by the registrar
+	topicAdRefresh      = 10 * time.Minute // Interval of renewing the own registrations
+	topicSearchInterval = 30 * time.Second // Minimum interval between search rounds
+	maxTopics           = 64               // Maximum number of topics kept by a registrar
+	maxAdsPerTopic      = 256              // Maximum number of registrations kept per topic
+	maxTopicResults     = 16               // Maximum number of nodes returned by a query
+	topicExpiryInterval = time.Minute      // Interval of dropping the expired registrations
+
+	topicRequestRate  = 1    // Topic requests served per second and source IP
+	topicRequestBurst = 10   // Topic requests served in a burst per source IP
+	maxTopicLimiters  = 4096 // Maximum number of source IPs tracked for rate limiting
+)
+
+const (
+	topicOpRegister = iota
+	topicOpQuery
+)
+
+var errTopicRejected = errors.New("topic request rejected")
+
+var (
+	topicAdsGauge          = metrics.NewRegisteredGauge(moduleName+"/topic/ads", nil)
+	topicRegisterMeter     = metrics.NewRegisteredMeter(moduleName+"/topic/register", nil)
+	topicRegisterFailMeter = metrics.NewRegisteredMeter(moduleName+"/topic/register/fail", nil)
+	topicFoundMeter        = metrics.NewRegisteredMeter(moduleName+"/topic/found", nil)
+	topicThrottledMeter    = metrics.NewRegisteredMeter(moduleName+"/topic/throttled", nil)
+)
+
+// topicRequest is the TALKREQ message of the topic protocol.
+type topicRequest struct {
+	Op    uint
+	Topic enode.ID
+}
+
+// topicResponse is the TALKRESP message of the topic protocol.
+type topicResponse struct {
+	Nodes []*enr.Record
+}
+
+// topicAd is a registration of a node under a topic.
+type topicAd struct {
+	node   *enode.Node
+	expiry mclock.AbsTime
+}
+
+// topicTable stores the registrations of remote nodes.
+type topicTable struct {
+	clock mclock.Clock
+
+	mu       sync.Mutex
+	ads      map[enode.ID]map[enode.ID]*topicAd      // topic -> node ID -> registration
+	limiters lru.BasicLRU[netip.Addr, *rate.Limiter] // Request rate limiters of the recent source IPs
+}
+
+func newTopicTable(clock mclock.Clock) *topicTable {
+	return &topicTable{
+		clock:    clock,
+		ads:      make(map[enode.ID]map[enode.ID]*topicAd),
+		limiters: lru.NewBasicLRU[netip.Addr, *rate.Limiter](maxTopicLimiters),
+	}
+}
+
+// allow reports whether a request from the given source IP is within its rate
+// limit.
+func (tt *topicTable) allow(ip netip.Addr) bool {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	limiter, ok := tt.limiters.Get(ip)
+	if !ok {
+		limiter = rate.NewLimiter(topicRequestRate, topicRequestBurst)
+		tt.limiters.Add(ip, limiter)
+	}
+	return limiter.Allow()
+}
+
+// expireAll drops the expired registrations.
+func (tt *topicTable) expireAll() {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	tt.expire()
+	tt.updateGauge()
+}
+
+// expire drops the expired registrations. The caller must hold the lock.
+func (tt *topicTable) expire() {
+	now := tt.clock.Now()
+	for topic, ads := range tt.ads {
+		for id, ad := range ads {
+			if ad.expiry <= now {
+				delete(ads, id)
+			}
+		}
+		if len(ads) == 0 {
+			delete(tt.ads, topic)
+		}
+	}
+}
+
+// register adds or renews the registration of a node under a topic. It reports
+// whether the registration was accepted.
+func (tt *topicTable) register(topic enode.ID, n *enode.Node) bool {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	tt.expire()
+	defer tt.updateGauge()
+
+	ads := tt.ads[topic]
+	if ads == nil {
+		if len(tt.ads) >= maxTopics {
+			return false
+		}
+		ads = make(map[enode.ID]*topicAd)
+		tt.ads[topic] = ads
+	}
+	if _, ok := ads[n.ID()]; !ok && len(ads) >= maxAdsPerTopic {
+		// Make room by dropping the registration expiring first.
+		var oldest enode.ID
+		for id, ad := range ads {
+			if oldest == (enode.ID{}) || ad.expiry < ads[oldest].expiry {
+				oldest = id
+			}
+		}
+		delete(ads, oldest)
+	}
+	ads[n.ID()] = &topicAd{node: n, expiry: tt.clock.Now().Add(topicAdLifetime)}
+	return true
+}
+
+// query returns a random selection of the nodes registered under a topic,
+// excluding the requester.
+func (tt *topicTable) query(topic enode.ID, exclude enode.ID, limit int) []*enode.Node {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	tt.expire()
+	defer tt.updateGauge()
+
+	nodes := make([]*enode.Node, 0, len(tt.ads[topic]))
+	for id, ad := range tt.ads[topic] {
+		if id != exclude {
+			nodes = append(nodes, ad.node)
+		}
+	}
+	rand.Shuffle(len(nodes), func(i, j int) { nodes[i], nodes[j] = nodes[j], nodes[i] })
+	if len(nodes) > limit {
+		nodes = nodes[:limit]
+	}
+	return nodes
+}
+
+// updateGauge updates the registration count metric. The caller must hold the lock.
+func (tt *topicTable) updateGauge() {
+	var count int
+	for _, ads := range tt.ads {
+		count += len(ads)
+	}
+	topicAdsGauge.Update(int64(count))
+}
+
+// topicExpiryLoop periodically drops the expired topic registrations, so they
+// are released even if no further requests arrive.
+func (t *UDPv5) topicExpiryLoop() {
+	defer t.wg.Done()
+
+	timer := t.clock.NewTimer(topicExpiryInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C():
+			t.topics.expireAll()
+			timer.Reset(topicExpiryInterval)
+		case <-t.closeCtx.Done():
+			return
+		}
+	}
+}
+
+// handleTopicTalk serves the topic protocol requests.
+func (t *UDPv5) handleTopicTalk(n *enode.Node, addr *net.UDPAddr, msg []byte) []byte {
+	if !t.topics.allow(addr.AddrPort().Addr().Unmap()) {
+		topicThrottledMeter.Mark(1)
+		return nil
+	}
+	var req topicRequest
+	if err := rlp.DecodeBytes(msg, &req); err != nil {
+		t.log.Debug("Invalid topic request", "id", n.ID(), "addr", addr, "err", err)
+		return nil
+	}
+	var resp topicResponse
+	switch req.Op {
+	case topicOpRegister:
+		if !t.topics.register(req.Topic, n) {
+			return nil
+		}
+	case topicOpQuery:
+		for _, node := range t.topics.query(req.Topic, n.ID(), maxTopicResults) {
+			resp.Nodes = append(resp.Nodes, node.Record())
+		}
+	default:
+		return nil
+	}
+	blob, _ := rlp.EncodeToBytes(&resp) // cannot fail
+	return blob
+}
+
+// topicRequest sends a topic protocol request to the given node.
+func (t *UDPv5) topicRequest(n *enode.Node, op uint, topic enode.ID) (*topicResponse, error) {
+	req, _ := rlp.EncodeToBytes(&topicRequest{Op: op, Topic: topic}) // cannot fail
+	blob, err := t.TalkRequest(n, topicProtocol, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) == 0 {
+		return nil, errTopicRejected
+	}
+	resp := new(topicResponse)
+	if err := rlp.DecodeBytes(blob, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RegisterTopic starts advertising the local node under the given topic at the
+// nodes closest to it. The registrations are renewed until the returned function
+// is called or the transport is closed.
+func (t *UDPv5) RegisterTopic(topic enode.ID) (stop func()) {
+	ctx, cancel := context.WithCancel(t.closeCtx)
+	go func() {
+		for {
+			t.registerTopic(ctx, topic)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(topicAdRefresh):
+			}
+		}
+	}()
+	return cancel
+}
+
+// registerTopic registers the local node under the topic at the nodes closest
+// to the topic.
+func (t *UDPv5) registerTopic(ctx context.Context, topic enode.ID) {
+	var registered int
+	for _, n := range t.newLookup(ctx, topic).run() {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := t.topicRequest(n, topicOpRegister, topic); err != nil {
+			topicRegisterFailMeter.Mark(1)
+			t.log.Trace("Topic registration failed", "topic", topic, "id", n.ID(), "err", err)
+			continue
+		}
+		topicRegisterMeter.Mark(1)
+		registered++
+	}
+	t.log.Debug("Registered topic", "topic", topic, "registrars", registered)
+}
+
+// searchTopic queries the nodes closest to the topic for the nodes registered
+// under it.
+func (t *UDPv5) searchTopic(ctx context.Context, topic enode.ID) []*enode.Node {
+	var (
+		nodes []*enode.Node
+		seen  = map[enode.ID]bool{t.Self().ID(): true}
+	)
+	for _, n := range t.newLookup(ctx, topic).run() {
+		if ctx.Err() != nil {
+			return nil
+		}
+		resp, err := t.topicRequest(n, topicOpQuery, topic)
+		if err != nil {
+			continue
+		}
+		for _, r := range resp.Nodes {
+			node, err := enode.New(t.validSchemes, r)
+			if err != nil || seen[node.ID()] {
+				continue
+			}
+			seen[node.ID()] = true
+			nodes = append(nodes, node)
+		}
+	}
+	topicFoundMeter.Mark(int64(len(nodes)))
+	return nodes
+}
+
+// TopicNodes returns an iterator of the nodes advertised under the given topic.
+// The search is repeated periodically until the iterator is closed.
+func (t *UDPv5) TopicNodes(topic enode.ID) enode.Iterator {
+	ctx, cancel := context.WithCancel(t.closeCtx)
+	return &topicIterator{t: t, topic: topic, ctx: ctx, cancel: cancel}
+}
+
+// topicIterator iterates over the nodes found by repeated topic searches.
+type topicIterator struct {
+	t      *UDPv5
+	topic  enode.ID
+	ctx    context.Context
+	cancel func()
+
+	buffer    []*enode.Node
+	lastRound time.Time
+}
+
+// Node returns the current node.
+func (it *topicIterator) Node() *enode.Node {
+	if len(it.buffer) == 0 {
+		return nil
+	}
+	return it.buffer[0]
+}
+
+// Next moves to the next node.
+func (it *topicIterator) Next() bool {
+	if len(it.buffer) > 0 {
+		it.buffer = it.buffer[1:]
+	}
+	for len(it.buffer) == 0 {
+		if wait := time.Until(it.lastRound.Add(topicSearchInterval)); wait > 0 {
+			select {
+			case <-it.ctx.Done():
+			case <-time.After(wait):
+			}
+		}
+		if it.ctx.Err() != nil {
+			it.buffer = nil
+			return false
+		}
+		it.lastRound = time.Now()
+		it.buffer = it.t.searchTopic(it.ctx, it.topic)
+	}
+	return true
+}
+
+// Close ends the iterator.
+func (it *topicIterator) Close() {
+	it.cancel()
+}
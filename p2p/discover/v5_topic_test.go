@@ -0,0 +1,143 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// This test checks the expiry and limits of the topic registrations.
+func TestTopicTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		clock = new(mclock.Simulated)
+		tt    = newTopicTable(clock)
+		topic = enode.ID{1}
+		nodes = nodesAtDistance(enode.ID{}, 256, 3)
+	)
+	for _, n := range nodes[:2] {
+		if !tt.register(topic, n) {
+			t.Fatalf("registration of %v rejected", n.ID())
+		}
+	}
+	if res := tt.query(topic, nodes[0].ID(), maxTopicResults); len(res) != 1 || res[0].ID() != nodes[1].ID() {
+		t.Fatalf("wrong query result excluding requester: %v", res)
+	}
+	clock.Run(topicAdLifetime / 2)
+	tt.register(topic, nodes[2])
+
+	clock.Run(topicAdLifetime / 2)
+	if res := tt.query(topic, enode.ID{}, maxTopicResults); len(res) != 1 || res[0].ID() != nodes[2].ID() {
+		t.Fatalf("wrong query result after expiry: %v", res)
+	}
+	// Fill up the topic table, further topics must be rejected.
+	for i := 0; len(tt.ads) < maxTopics; i++ {
+		tt.register(enode.ID{2, byte(i)}, nodes[0])
+	}
+	if tt.register(enode.ID{3}, nodes[0]) {
+		t.Fatal("registration of topic accepted beyond limit")
+	}
+}
+
+// Real sockets, real crypto: this test checks that the nodes registered under a
+// topic are found by searching for it.
+func TestUDPv5_topicE2E(t *testing.T) {
+	t.Parallel()
+
+	const N = 6
+	var nodes []*UDPv5
+	for i := 0; i < N; i++ {
+		cfg := Config{TopicRegistrar: true}
+		if len(nodes) > 0 {
+			cfg.Bootnodes = []*enode.Node{nodes[0].Self()}
+		}
+		node := startLocalhostV5(t, cfg)
+		nodes = append(nodes, node)
+		defer node.Close()
+	}
+	var (
+		topic      = enode.ID{0xaa}
+		advertised = nodes[1:3]
+		searcher   = nodes[N-1]
+	)
+	for _, n := range advertised {
+		n.registerTopic(context.Background(), topic)
+	}
+	found := make(map[enode.ID]bool)
+	for _, n := range searcher.searchTopic(context.Background(), topic) {
+		found[n.ID()] = true
+	}
+	if len(found) != len(advertised) {
+		t.Fatalf("wrong number of nodes found: have %d, want %d", len(found), len(advertised))
+	}
+	for _, n := range advertised {
+		if !found[n.Self().ID()] {
+			t.Errorf("advertised node %v not found", n.Self().ID())
+		}
+	}
+}
+
+// This test checks that the topic requests are rate limited per source IP and
+// that the expired registrations are dropped without further requests.
+func TestTopicTableLimits(t *testing.T) {
+	t.Parallel()
+
+	var (
+		clock = new(mclock.Simulated)
+		tt    = newTopicTable(clock)
+		ip1   = netip.MustParseAddr("10.0.0.1")
+		ip2   = netip.MustParseAddr("10.0.0.2")
+	)
+	for i := 0; i < topicRequestBurst; i++ {
+		if !tt.allow(ip1) {
+			t.Fatalf("request %d within burst rejected", i)
+		}
+	}
+	if tt.allow(ip1) {
+		t.Fatal("request beyond burst allowed")
+	}
+	if !tt.allow(ip2) {
+		t.Fatal("request of other source IP rejected")
+	}
+	tt.register(enode.ID{1}, nodesAtDistance(enode.ID{}, 256, 1)[0])
+	clock.Run(topicAdLifetime)
+	tt.expireAll()
+	if len(tt.ads) != 0 {
+		t.Fatalf("expired registrations kept: %d topics", len(tt.ads))
+	}
+}
+
+// This test checks that nodes not configured as registrars reject the topic
+// requests.
+func TestUDPv5_topicNotRegistrar(t *testing.T) {
+	t.Parallel()
+
+	registrar := startLocalhostV5(t, Config{})
+	defer registrar.Close()
+	client := startLocalhostV5(t, Config{Bootnodes: []*enode.Node{registrar.Self()}})
+	defer client.Close()
+
+	if _, err := client.topicRequest(registrar.Self(), topicOpRegister, enode.ID{1}); err == nil {
+		t.Fatal("topic registration accepted by non-registrar")
+	}
+}
@@ -89,6 +89,9 @@ type UDPv5 struct {
 	// talkreq handler registry
 	talk *talkSystem
 
+	// topic registrations of remote nodes, nil if not a registrar
+	topics *topicTable
+
 	// channels into dispatch
 	packetInCh    chan ReadPacket
 	readNextCh    chan struct{}
@@ -152,6 +155,10 @@ func ListenV5(conn UDPConn, ln *enode.LocalNode, cfg Config) (*UDPv5, error) {
 	t.wg.Add(2)
 	go t.readLoop()
 	go t.dispatch()
+	if t.topics != nil {
+		t.wg.Add(1)
+		go t.topicExpiryLoop()
+	}
 	return t, nil
 }
 
@@ -188,6 +195,10 @@ func newUDPv5(conn UDPConn, ln *enode.LocalNode, cfg Config) (*UDPv5, error) {
 		cancelCloseCtx: cancelCloseCtx,
 	}
 	t.talk = newTalkSystem(t)
+	if cfg.TopicRegistrar {
+		t.topics = newTopicTable(cfg.Clock)
+		t.talk.register(topicProtocol, t.handleTopicTalk)
+	}
 	tab, err := newTable(t, t.db, cfg)
 	if err != nil {
 		return nil, err
@@ -62,7 +62,8 @@ type protoHandshake struct {
 	Name       string
 	Caps       []Cap
 	ListenPort uint64
-	ID         []byte // secp256k1 public key
+	ID         []byte       // secp256k1 public key
+	Identity   rlp.RawValue `rlp:"optional"` // Build and chain identity, opaque to devp2p
 
 	// Ignore additional fields (for forward compatibility).
 	Rest []rlp.RawValue `rlp:"tail"`
@@ -171,6 +172,12 @@ func (p *Peer) Fullname() string {
 	return p.rw.name
 }
 
+// Identity returns the raw build and chain identity that the remote node
+// advertised in the handshake, nil if none.
+func (p *Peer) Identity() rlp.RawValue {
+	return p.rw.identity
+}
+
 // Caps returns the capabilities (supported subprotocols) of the remote peer.
 func (p *Peer) Caps() []Cap {
 	// TODO: maybe return copy
@@ -392,6 +399,10 @@ func (p *Peer) handle(msg Msg) error {
 			metrics.GetOrRegisterMeter(m, nil).Mark(int64(msg.meterSize))
 			metrics.GetOrRegisterMeter(m+"/packets", nil).Mark(1)
 		}
+		proto.meterIngress(msg.Size)
+		if err := proto.ingress.wait(proto.Name, int(msg.Size), p.closed); err != nil {
+			return err
+		}
 		select {
 		case proto.in <- msg:
 			return nil
@@ -450,7 +461,7 @@ outer:
 					offset -= old.Length
 				}
 				// Assign the new match
-				result[cap.Name] = &protoRW{Protocol: proto, offset: offset, in: make(chan Msg), w: rw}
+				result[cap.Name] = &protoRW{Protocol: proto, offset: offset, in: make(chan Msg), w: rw, traffic: new(protoTraffic)}
 				offset += proto.Length
 
 				continue outer
@@ -504,12 +515,19 @@ type protoRW struct {
 	werr   chan<- error    // for write results
 	offset uint64
 	w      MsgWriter
+
+	egress  *bandwidthLimits // egress bandwidth caps, nil if unlimited
+	ingress *bandwidthLimits // ingress bandwidth caps, nil if unlimited
+	traffic *protoTraffic    // payload bytes transferred
 }
 
 func (rw *protoRW) WriteMsg(msg Msg) (err error) {
 	if msg.Code >= rw.Length {
 		return newPeerError(errInvalidMsgCode, "not handled")
 	}
+	if err := rw.egress.wait(rw.Name, int(msg.Size), rw.closed); err != nil {
+		return err
+	}
 	msg.meterCap = rw.cap()
 	msg.meterCode = msg.Code
 
@@ -518,6 +536,9 @@ func (rw *protoRW) WriteMsg(msg Msg) (err error) {
 	select {
 	case <-rw.wstart:
 		err = rw.w.WriteMsg(msg)
+		if err == nil {
+			rw.meterEgress(msg.Size)
+		}
 		// Report write status back to Peer.run. It will initiate
 		// shutdown if the error is non-nil and unblock the next write
 		// otherwise. The calling protocol code should exit for errors
@@ -555,7 +576,8 @@ type PeerInfo struct {
 		Trusted       bool   `json:"trusted"`
 		Static        bool   `json:"static"`
 	} `json:"network"`
-	Protocols map[string]interface{} `json:"protocols"` // Sub-protocol specific metadata fields
+	Protocols map[string]interface{}     `json:"protocols"` // Sub-protocol specific metadata fields
+	Bandwidth map[string]*BandwidthStats `json:"bandwidth"` // Sub-protocol traffic
 }
 
 // Info gathers and returns a collection of metadata known about a peer.
@@ -572,6 +594,7 @@ func (p *Peer) Info() *PeerInfo {
 		Name:      p.Fullname(),
 		Caps:      caps,
 		Protocols: make(map[string]interface{}, len(p.running)),
+		Bandwidth: make(map[string]*BandwidthStats, len(p.running)),
 	}
 	if p.Node().Seq() > 0 {
 		info.ENR = p.Node().String()
@@ -593,6 +616,10 @@ func (p *Peer) Info() *PeerInfo {
 			}
 		}
 		info.Protocols[proto.Name] = protoInfo
+		info.Bandwidth[proto.Name] = &BandwidthStats{
+			Ingress: proto.traffic.ingress.Load(),
+			Egress:  proto.traffic.egress.Load(),
+		}
 	}
 	return info
 }
@@ -40,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 const (
@@ -90,7 +91,7 @@ type Server struct {
 	running bool
 
 	listener     net.Listener
-	ourHandshake *protoHandshake
+	ourHandshake atomic.Pointer[protoHandshake]
 	loopWG       sync.WaitGroup // loop, listenLoop
 	peerFeed     event.Feed
 	log          log.Logger
@@ -105,6 +106,14 @@ type Server struct {
 	// This is read by the NAT port mapping loop.
 	portMappingRegister chan *portMapping
 
+	// Bandwidth caps shared by all peers, nil if unlimited.
+	egressLimits  *bandwidthLimits
+	ingressLimits *bandwidthLimits
+
+	// State of the NAT traversal, updated by the NAT port mapping loop.
+	natMu    sync.Mutex
+	natState *NATInfo
+
 	// Channels into the run loop.
 	quit                    chan struct{}
 	addtrusted              chan *enode.Node
@@ -141,11 +150,12 @@ const (
 type conn struct {
 	fd net.Conn
 	transport
-	node  *enode.Node
-	flags connFlag
-	cont  chan error // The run loop uses cont to signal errors to SetupConn.
-	caps  []Cap      // valid after the protocol handshake
-	name  string     // valid after the protocol handshake
+	node     *enode.Node
+	flags    connFlag
+	cont     chan error   // The run loop uses cont to signal errors to SetupConn.
+	caps     []Cap        // valid after the protocol handshake
+	name     string       // valid after the protocol handshake
+	identity rlp.RawValue // valid after the protocol handshake
 }
 
 type transport interface {
@@ -216,6 +226,20 @@ func (srv *Server) LocalNode() *enode.LocalNode {
 	return srv.localnode
 }
 
+// SetIdentity replaces the identity advertised in the devp2p handshake. Only new
+// connections are affected, established ones keep the identity they exchanged.
+func (srv *Server) SetIdentity(identity rlp.RawValue) {
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	srv.Identity = identity
+	if hs := srv.ourHandshake.Load(); hs != nil {
+		updated := *hs
+		updated.Identity = identity
+		srv.ourHandshake.Store(&updated)
+	}
+}
+
 // Peers returns all connected peers.
 func (srv *Server) Peers() []*Peer {
 	var ps []*Peer
@@ -399,6 +423,8 @@ func (srv *Server) Start() (err error) {
 	srv.removetrusted = make(chan *enode.Node)
 	srv.peerOp = make(chan peerOpFunc)
 	srv.peerOpDone = make(chan struct{})
+	srv.egressLimits = newBandwidthLimits(srv.BandwidthCap, srv.ProtocolBandwidthCaps, bandwidthThrottleTimer)
+	srv.ingressLimits = newBandwidthLimits(srv.IngressBandwidthCap, srv.IngressProtocolBandwidthCaps, bandwidthIngressThrottleTimer)
 
 	if err := srv.setupLocalNode(); err != nil {
 		return err
@@ -423,11 +449,12 @@ func (srv *Server) Start() (err error) {
 func (srv *Server) setupLocalNode() error {
 	// Create the devp2p handshake.
 	pubkey := crypto.FromECDSAPub(&srv.PrivateKey.PublicKey)
-	srv.ourHandshake = &protoHandshake{Version: baseProtocolVersion, Name: srv.Name, ID: pubkey[1:]}
+	hs := &protoHandshake{Version: baseProtocolVersion, Name: srv.Name, ID: pubkey[1:], Identity: srv.Identity}
 	for _, p := range srv.Protocols {
-		srv.ourHandshake.Caps = append(srv.ourHandshake.Caps, p.cap())
+		hs.Caps = append(hs.Caps, p.cap())
 	}
-	slices.SortFunc(srv.ourHandshake.Caps, Cap.Cmp)
+	slices.SortFunc(hs.Caps, Cap.Cmp)
+	srv.ourHandshake.Store(hs)
 
 	// Create the local node.
 	db, err := enode.OpenDB(srv.NodeDatabase)
@@ -487,10 +514,11 @@ func (srv *Server) setupDiscovery() error {
 	}
 	if srv.Config.DiscoveryV5 {
 		cfg := discover.Config{
-			PrivateKey:  srv.PrivateKey,
-			NetRestrict: srv.NetRestrict,
-			Bootnodes:   srv.BootstrapNodesV5,
-			Log:         srv.log,
+			PrivateKey:     srv.PrivateKey,
+			NetRestrict:    srv.NetRestrict,
+			Bootnodes:      srv.BootstrapNodesV5,
+			TopicRegistrar: srv.DiscoveryV5Topics,
+			Log:            srv.log,
 		}
 		srv.discv5, err = discover.ListenV5(sconn, srv.localnode, cfg)
 		if err != nil {
@@ -909,7 +937,7 @@ func (srv *Server) setupConn(c *conn, dialDest *enode.Node) error {
 	}
 
 	// Run the capability negotiation handshake.
-	phs, err := c.doProtoHandshake(srv.ourHandshake)
+	phs, err := c.doProtoHandshake(srv.ourHandshake.Load())
 	if err != nil {
 		clog.Trace("Failed p2p handshake", "err", err)
 		return &protoHandshakeError{err: err}
@@ -918,7 +946,7 @@ func (srv *Server) setupConn(c *conn, dialDest *enode.Node) error {
 		clog.Trace("Wrong devp2p handshake identity", "phsid", hex.EncodeToString(phs.ID))
 		return DiscUnexpectedIdentity
 	}
-	c.caps, c.name = phs.Caps, phs.Name
+	c.caps, c.name, c.identity = phs.Caps, phs.Name, phs.Identity
 	err = srv.checkpoint(c, srv.checkpointAddPeer)
 	if err != nil {
 		clog.Trace("Rejected peer", "err", err)
@@ -951,6 +979,9 @@ func (srv *Server) checkpoint(c *conn, stage chan<- *conn) error {
 
 func (srv *Server) launchPeer(c *conn) *Peer {
 	p := newPeer(srv.log, c, srv.Protocols)
+	for _, proto := range p.running {
+		proto.egress, proto.ingress = srv.egressLimits, srv.ingressLimits
+	}
 	if srv.EnableMsgEvents {
 		// If message events are enabled, pass the peerFeed
 		// to the peer.
@@ -1005,6 +1036,7 @@ type NodeInfo struct {
 		Listener  int `json:"listener"`  // TCP listening port for RLPx
 	} `json:"ports"`
 	ListenAddr string                 `json:"listenAddr"`
+	NAT        *NATInfo               `json:"nat,omitempty"` // State of the NAT traversal, if configured
 	Protocols  map[string]interface{} `json:"protocols"`
 }
 
@@ -1018,6 +1050,7 @@ func (srv *Server) NodeInfo() *NodeInfo {
 		ID:         node.ID().String(),
 		IP:         node.IPAddr().String(),
 		ListenAddr: srv.ListenAddr,
+		NAT:        srv.natInfo(),
 		Protocols:  make(map[string]interface{}),
 	}
 	info.Ports.Discovery = node.UDP()
@@ -17,6 +17,7 @@
 package p2p
 
 import (
+	"maps"
 	"net"
 	"time"
 
@@ -45,6 +46,48 @@ type portMapping struct {
 	nextTime mclock.AbsTime
 }
 
+// NATInfo describes the state of the NAT traversal.
+type NATInfo struct {
+	Interface  string         `json:"interface"`            // NAT port mapping mechanism
+	ExternalIP string         `json:"externalIP,omitempty"` // Last detected external IP address
+	Source     string         `json:"source,omitempty"`     // Mechanism which detected the external IP address
+	Mappings   map[string]int `json:"mappings,omitempty"`   // Mapped external ports by protocol
+	IPChanges  int            `json:"ipChanges"`            // Number of external IP address changes detected
+}
+
+// natInfo returns the current state of the NAT traversal, or nil if no NAT
+// interface is configured.
+func (srv *Server) natInfo() *NATInfo {
+	srv.natMu.Lock()
+	defer srv.natMu.Unlock()
+
+	if srv.natState == nil {
+		return nil
+	}
+	info := *srv.natState
+	info.Mappings = maps.Clone(info.Mappings)
+	return &info
+}
+
+// updateNATInfo applies the given modification to the NAT traversal state.
+func (srv *Server) updateNATInfo(fn func(*NATInfo)) {
+	srv.natMu.Lock()
+	defer srv.natMu.Unlock()
+	fn(srv.natState)
+}
+
+// externalIP detects the external IP address using the NAT interface, falling
+// back to the fallback mechanism if the former fails.
+func (srv *Server) externalIP() (net.IP, nat.Interface, error) {
+	ip, err := srv.NAT.ExternalIP()
+	if err == nil || srv.NATFallback == nil {
+		return ip, srv.NAT, err
+	}
+	log.Debug("Couldn't get external IP, using fallback", "err", err, "interface", srv.NAT, "fallback", srv.NATFallback)
+	ip, err = srv.NATFallback.ExternalIP()
+	return ip, srv.NATFallback, err
+}
+
 // setupPortMapping starts the port mapping loop if necessary.
 // Note: this needs to be called after the LocalNode instance has been set on the server.
 func (srv *Server) setupPortMapping() {
@@ -64,10 +107,12 @@ func (srv *Server) setupPortMapping() {
 		// ExtIP doesn't block, set the IP right away.
 		ip, _ := srv.NAT.ExternalIP()
 		srv.localnode.SetStaticIP(ip)
+		srv.natState = &NATInfo{Interface: srv.NAT.String(), ExternalIP: ip.String(), Source: srv.NAT.String()}
 		srv.loopWG.Add(1)
 		go srv.consumePortMappingRequests()
 
 	default:
+		srv.natState = &NATInfo{Interface: srv.NAT.String(), Mappings: make(map[string]int)}
 		srv.loopWG.Add(1)
 		go srv.portMappingLoop()
 	}
@@ -123,15 +168,29 @@ func (srv *Server) portMappingLoop() {
 
 		case <-extip.C():
 			extip.Schedule(srv.clock.Now().Add(extipRetryInterval))
-			ip, err := srv.NAT.ExternalIP()
+			ip, source, err := srv.externalIP()
 			if err != nil {
 				log.Debug("Couldn't get external IP", "err", err, "interface", srv.NAT)
 			} else if !ip.Equal(lastExtIP) {
-				log.Debug("External IP changed", "ip", ip, "interface", srv.NAT)
+				if lastExtIP != nil {
+					log.Info("External IP changed", "old", lastExtIP, "new", ip, "interface", source)
+				} else {
+					log.Debug("External IP detected", "ip", ip, "interface", source)
+				}
 			} else {
 				continue
 			}
 			// Here, we either failed to get the external IP, or it has changed.
+			srv.updateNATInfo(func(info *NATInfo) {
+				if err != nil {
+					info.ExternalIP, info.Source = "", ""
+					return
+				}
+				if lastExtIP != nil {
+					info.IPChanges++
+				}
+				info.ExternalIP, info.Source = ip.String(), source.String()
+			})
 			lastExtIP = ip
 			srv.localnode.SetStaticIP(ip)
 			// Ensure port mappings are refreshed in case we have moved to a new network.
@@ -172,6 +231,7 @@ func (srv *Server) portMappingLoop() {
 							err := srv.NAT.DeleteMapping(m.protocol, m.extPort, m.port)
 							log.Debug("Couldn't refresh port mapping, trying to delete it:", "err", err)
 							m.extPort = 0
+							srv.updateNATInfo(func(info *NATInfo) { delete(info.Mappings, m.protocol) })
 						}
 					}
 					m.nextTime = srv.clock.Now().Add(portMapRetryInterval)
@@ -184,6 +244,7 @@ func (srv *Server) portMappingLoop() {
 				log = newLogger(m.protocol, int(p), m.port)
 				if int(p) != m.extPort {
 					m.extPort = int(p)
+					srv.updateNATInfo(func(info *NATInfo) { info.Mappings[m.protocol] = m.extPort })
 					if m.port != m.extPort {
 						log.Info("NAT mapped alternative port")
 					} else {
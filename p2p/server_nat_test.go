@@ -17,6 +17,7 @@
 package p2p
 
 import (
+	"errors"
 	"net"
 	"net/netip"
 	"sync/atomic"
@@ -77,8 +78,59 @@ func TestServerPortMapping(t *testing.T) {
 	}
 }
 
+// This test checks that the fallback mechanism is used for detecting the external
+// IP if the NAT interface fails to provide it, and that IP changes are picked up.
+func TestServerNATFallback(t *testing.T) {
+	clock := new(mclock.Simulated)
+	mockNAT := &mockNAT{mappedPort: 30000, noIP: true}
+	fallback := new(mockIPSource)
+	fallback.ip.Store(net.ParseIP("192.0.2.1"))
+	srv := Server{
+		Config: Config{
+			PrivateKey:  newkey(),
+			NoDial:      true,
+			ListenAddr:  ":0",
+			DiscAddr:    ":0",
+			NAT:         mockNAT,
+			NATFallback: fallback,
+			Logger:      testlog.Logger(t, log.LvlTrace),
+			clock:       clock,
+		},
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+
+	waitIP := func(want string) {
+		t.Helper()
+		deadline := clock.Now().Add(2 * extipRetryInterval)
+		for clock.Now() < deadline && srv.LocalNode().Node().IPAddr().String() != want {
+			time.Sleep(10 * time.Millisecond)
+			clock.Run(1 * time.Second)
+		}
+		if ip := srv.LocalNode().Node().IPAddr().String(); ip != want {
+			t.Fatalf("wrong IP in ENR: have %s, want %s", ip, want)
+		}
+	}
+	waitIP("192.0.2.1")
+
+	// Change the external IP, it should be detected on the next check.
+	fallback.ip.Store(net.ParseIP("192.0.2.2"))
+	waitIP("192.0.2.2")
+
+	info := srv.NodeInfo().NAT
+	if info == nil {
+		t.Fatal("missing NAT info")
+	}
+	if info.ExternalIP != "192.0.2.2" || info.Source != "mockIPSource" || info.IPChanges != 1 {
+		t.Errorf("wrong NAT info: %+v", info)
+	}
+}
+
 type mockNAT struct {
 	mappedPort    uint16
+	noIP          bool
 	mapRequests   atomic.Int32
 	unmapRequests atomic.Int32
 	ipRequests    atomic.Int32
@@ -96,9 +148,33 @@ func (m *mockNAT) DeleteMapping(protocol string, extport, intport int) error {
 
 func (m *mockNAT) ExternalIP() (net.IP, error) {
 	m.ipRequests.Add(1)
+	if m.noIP {
+		return nil, errors.New("external IP not available")
+	}
 	return net.ParseIP("192.0.2.0"), nil
 }
 
 func (m *mockNAT) String() string {
 	return "mockNAT"
 }
+
+// mockIPSource is a NAT interface only providing the external IP.
+type mockIPSource struct {
+	ip atomic.Value
+}
+
+func (m *mockIPSource) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) (uint16, error) {
+	return 0, errors.New("not supported")
+}
+
+func (m *mockIPSource) DeleteMapping(protocol string, extport, intport int) error {
+	return errors.New("not supported")
+}
+
+func (m *mockIPSource) ExternalIP() (net.IP, error) {
+	return m.ip.Load().(net.IP), nil
+}
+
+func (m *mockIPSource) String() string {
+	return "mockIPSource"
+}
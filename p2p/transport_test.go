@@ -25,6 +25,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p/pipes"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 func TestProtocolHandshake(t *testing.T) {
@@ -35,7 +36,7 @@ func TestProtocolHandshake(t *testing.T) {
 
 		prv1, _ = crypto.GenerateKey()
 		pub1    = crypto.FromECDSAPub(&prv1.PublicKey)[1:]
-		hs1     = &protoHandshake{Version: 3, ID: pub1, Caps: []Cap{{"c", 1}, {"d", 3}}}
+		hs1     = &protoHandshake{Version: 3, ID: pub1, Caps: []Cap{{"c", 1}, {"d", 3}}, Identity: rlp.RawValue{0x82, 0xc0, 0xde}}
 
 		wg sync.WaitGroup
 	)
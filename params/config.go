@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -485,6 +486,10 @@ type ChainConfig struct {
 
 	// Optimism config, nil if not active
 	Optimism *OptimismConfig `json:"optimism,omitempty"`
+
+	// Per-block invocation caps of precompiles, used to contain experimental
+	// precompiles during incident response
+	PrecompileCaps []PrecompileCap `json:"precompileCaps,omitempty"`
 }
 
 // EthashConfig is the consensus engine configs for proof-of-work based sealing.
@@ -506,6 +511,15 @@ func (c CliqueConfig) String() string {
 	return fmt.Sprintf("clique(period: %d, epoch: %d)", c.Period, c.Epoch)
 }
 
+// PrecompileCap limits the number of invocations of a precompile per block,
+// from the given activation time. Invocations past the limit fail like a
+// failing precompile, consuming all the gas of the call.
+type PrecompileCap struct {
+	Address common.Address `json:"address"`
+	Time    uint64         `json:"time"`  // Activation time of the cap
+	Limit   uint64         `json:"limit"` // Maximum number of invocations per block
+}
+
 // OptimismConfig is the optimism config.
 type OptimismConfig struct {
 	EIP1559Elasticity        uint64  `json:"eip1559Elasticity"`
@@ -805,6 +819,25 @@ func (c *ChainConfig) IsInterop(time uint64) bool {
 	return isTimestampForked(c.InteropTime, time)
 }
 
+// PrecompileInvocationCap returns the maximum number of invocations per block
+// of the precompile at the given address at the given time, if capped. The
+// latest activated cap of the precompile applies.
+func (c *ChainConfig) PrecompileInvocationCap(addr common.Address, time uint64) (uint64, bool) {
+	var active *PrecompileCap
+	for i, pc := range c.PrecompileCaps {
+		if pc.Address != addr || pc.Time > time {
+			continue
+		}
+		if active == nil || pc.Time >= active.Time {
+			active = &c.PrecompileCaps[i]
+		}
+	}
+	if active == nil {
+		return 0, false
+	}
+	return active.Limit, true
+}
+
 // IsOptimism returns whether the node is an optimism node or not.
 func (c *ChainConfig) IsOptimism() bool {
 	return c.Optimism != nil
@@ -1097,9 +1130,34 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int,
 	if isForkTimestampIncompatible(c.InteropTime, newcfg.InteropTime, headTimestamp, genesisTimestamp) {
 		return newTimestampCompatError("Interop fork timestamp", c.InteropTime, newcfg.InteropTime)
 	}
+	if time, ok := precompileCapsIncompatible(c.PrecompileCaps, newcfg.PrecompileCaps, headTimestamp); ok {
+		return newTimestampCompatError("Precompile cap timestamp", &time, &time)
+	}
 	return nil
 }
 
+// precompileCapsIncompatible returns the earliest activation time of the caps
+// differing between the two sets and already active at the head.
+func precompileCapsIncompatible(stored, updated []PrecompileCap, headTimestamp uint64) (uint64, bool) {
+	var (
+		earliest uint64
+		found    bool
+	)
+	check := func(caps, others []PrecompileCap) {
+		for _, pc := range caps {
+			if pc.Time > headTimestamp || slices.Contains(others, pc) {
+				continue
+			}
+			if !found || pc.Time < earliest {
+				earliest, found = pc.Time, true
+			}
+		}
+	}
+	check(stored, updated)
+	check(updated, stored)
+	return earliest, found
+}
+
 // BaseFeeChangeDenominator bounds the amount the base fee can change between blocks.
 // The time parameters is the timestamp of the block to determine if Canyon is active or not
 func (c *ChainConfig) BaseFeeChangeDenominator(time uint64) uint64 {
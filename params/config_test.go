@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -168,6 +169,23 @@ func TestCheckCompatible(t *testing.T) {
 				RewindToTime: 9,
 			},
 		},
+		{
+			stored:        &ChainConfig{PrecompileCaps: []PrecompileCap{{Address: common.Address{0x01}, Time: 10, Limit: 5}}},
+			new:           &ChainConfig{PrecompileCaps: []PrecompileCap{{Address: common.Address{0x01}, Time: 10, Limit: 5}, {Address: common.Address{0x02}, Time: 20, Limit: 1}}},
+			headTimestamp: 15,
+			wantErr:       nil,
+		},
+		{
+			stored:        &ChainConfig{PrecompileCaps: []PrecompileCap{{Address: common.Address{0x01}, Time: 10, Limit: 5}}},
+			new:           &ChainConfig{PrecompileCaps: []PrecompileCap{{Address: common.Address{0x01}, Time: 10, Limit: 1}}},
+			headTimestamp: 15,
+			wantErr: &ConfigCompatError{
+				What:         "Precompile cap timestamp",
+				StoredTime:   newUint64(10),
+				NewTime:      newUint64(10),
+				RewindToTime: 9,
+			},
+		},
 	}
 
 	for i, test := range tests {
@@ -0,0 +1,306 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package proofserver serves header-anchored state and receipt proofs over an
+// authenticated HTTP API, for light verifying clients and bridges which can't
+// rely on the LES protocol.
+//
+// Every response carries the RLP encoded header the proofs are anchored to.
+// Clients are expected to check the header hash against a trusted source and
+// verify the proofs against the state or receipt root of that header.
+package proofserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// maxStorageKeys is the maximum number of storage slots proven by one request.
+const maxStorageKeys = 256
+
+var (
+	errMissingParam = errors.New("missing parameter")
+	errNotFound     = errors.New("not found")
+)
+
+// Config contains the settings of the proof server.
+type Config struct {
+	JWTSecret []byte   // Secret authenticating the clients, mandatory
+	Cors      []string // Allowed cross origin domains
+	Vhosts    []string // Allowed virtual hostnames
+}
+
+// HeaderResult is the response of the header endpoint.
+type HeaderResult struct {
+	Hash   common.Hash   `json:"hash"`
+	Header hexutil.Bytes `json:"header"`
+}
+
+// AccountResult is the response of the account endpoint.
+type AccountResult struct {
+	HeaderResult
+	*ethapi.AccountResult
+}
+
+// CodeResult is the response of the code endpoint.
+type CodeResult struct {
+	HeaderResult
+	*ethapi.AccountResult
+	Code hexutil.Bytes `json:"code"`
+}
+
+// ReceiptResult is the response of the receipt endpoint.
+type ReceiptResult struct {
+	HeaderResult
+	Index   hexutil.Uint64  `json:"index"`
+	Receipt hexutil.Bytes   `json:"receipt"` // Consensus encoding of the receipt
+	Proof   []hexutil.Bytes `json:"proof"`   // Receipt trie nodes, keyed by rlp(index)
+}
+
+// New registers the proof server on the HTTP server of the node.
+func New(stack *node.Node, backend ethapi.Backend, cfg Config) error {
+	if len(cfg.JWTSecret) != 32 {
+		return errors.New("proof server requires a 32 byte jwt secret")
+	}
+	h := &handler{backend: backend, api: ethapi.NewBlockChainAPI(backend)}
+	stack.RegisterHandler("Proof server", "/proofs/", node.NewHTTPHandlerStack(h, cfg.Cors, cfg.Vhosts, cfg.JWTSecret))
+	return nil
+}
+
+type handler struct {
+	backend ethapi.Backend
+	api     *ethapi.BlockChainAPI
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var (
+		query  = r.URL.Query()
+		result interface{}
+		err    error
+	)
+	switch strings.TrimPrefix(r.URL.Path, "/proofs/") {
+	case "header":
+		result, err = h.header(r.Context(), query.Get("block"))
+	case "account":
+		result, err = h.account(r.Context(), query.Get("block"), query.Get("address"), query.Get("storage"))
+	case "code":
+		result, err = h.code(r.Context(), query.Get("block"), query.Get("address"))
+	case "receipt":
+		result, err = h.receipt(r.Context(), query.Get("tx"), query.Get("block"), query.Get("index"))
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// resolveHeader looks up the header the proofs are anchored to. The block is
+// given by number, hash or tag, defaulting to the latest block.
+func (h *handler) resolveHeader(ctx context.Context, block string) (*types.Header, HeaderResult, error) {
+	if block == "" {
+		block = "latest"
+	}
+	var id rpc.BlockNumberOrHash
+	if err := id.UnmarshalJSON([]byte(strconv.Quote(block))); err != nil {
+		return nil, HeaderResult{}, fmt.Errorf("invalid block %q: %v", block, err)
+	}
+	header, err := h.backend.HeaderByNumberOrHash(ctx, id)
+	if err != nil {
+		return nil, HeaderResult{}, err
+	}
+	if header == nil {
+		return nil, HeaderResult{}, fmt.Errorf("block %s %w", block, errNotFound)
+	}
+	enc, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return nil, HeaderResult{}, err
+	}
+	return header, HeaderResult{Hash: header.Hash(), Header: enc}, nil
+}
+
+func (h *handler) header(ctx context.Context, block string) (*HeaderResult, error) {
+	_, res, err := h.resolveHeader(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (h *handler) account(ctx context.Context, block, address, storage string) (*AccountResult, error) {
+	addr, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	if storage != "" {
+		keys = strings.Split(storage, ",")
+	}
+	if len(keys) > maxStorageKeys {
+		return nil, fmt.Errorf("too many storage keys: %d > %d", len(keys), maxStorageKeys)
+	}
+	_, res, err := h.resolveHeader(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	// Prove against the resolved header, so a new head can't slip in between.
+	proof, err := h.api.GetProof(ctx, addr, keys, rpc.BlockNumberOrHashWithHash(res.Hash, false))
+	if err != nil {
+		return nil, err
+	}
+	return &AccountResult{HeaderResult: res, AccountResult: proof}, nil
+}
+
+func (h *handler) code(ctx context.Context, block, address string) (*CodeResult, error) {
+	addr, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	_, res, err := h.resolveHeader(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	id := rpc.BlockNumberOrHashWithHash(res.Hash, false)
+	proof, err := h.api.GetProof(ctx, addr, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	statedb, _, err := h.backend.StateAndHeaderByNumberOrHash(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &CodeResult{HeaderResult: res, AccountResult: proof, Code: statedb.GetCode(addr)}, nil
+}
+
+func (h *handler) receipt(ctx context.Context, tx, block, index string) (*ReceiptResult, error) {
+	var position uint64
+	switch {
+	case tx != "":
+		hash, err := parseHash(tx)
+		if err != nil {
+			return nil, err
+		}
+		found, _, blockHash, _, txIndex := h.backend.GetTransaction(hash)
+		if !found {
+			return nil, fmt.Errorf("transaction %x %w", hash, errNotFound)
+		}
+		block, position = blockHash.Hex(), txIndex
+	case block != "" && index != "":
+		var err error
+		if position, err = hexutil.DecodeUint64(index); err != nil {
+			if position, err = strconv.ParseUint(index, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid index %q", index)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: tx or block and index", errMissingParam)
+	}
+	header, res, err := h.resolveHeader(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := h.backend.GetReceipts(ctx, header.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if position >= uint64(len(receipts)) {
+		return nil, fmt.Errorf("receipt %d of block %x %w", position, res.Hash, errNotFound)
+	}
+	enc, proof, err := receiptProof(receipts, int(position))
+	if err != nil {
+		return nil, err
+	}
+	return &ReceiptResult{HeaderResult: res, Index: hexutil.Uint64(position), Receipt: enc, Proof: proof}, nil
+}
+
+// receiptProof builds the receipt trie of a block and returns the consensus
+// encoding of the receipt at the given index together with its inclusion proof.
+func receiptProof(receipts types.Receipts, index int) ([]byte, []hexutil.Bytes, error) {
+	tr := trie.NewEmpty(nil)
+	for i := range receipts {
+		var buf bytes.Buffer
+		receipts.EncodeIndex(i, &buf)
+		if err := tr.Update(rlp.AppendUint64(nil, uint64(i)), buf.Bytes()); err != nil {
+			return nil, nil, err
+		}
+	}
+	var proof proofList
+	if err := tr.Prove(rlp.AppendUint64(nil, uint64(index)), &proof); err != nil {
+		return nil, nil, err
+	}
+	enc, err := tr.Get(rlp.AppendUint64(nil, uint64(index)))
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, proof, nil
+}
+
+// proofList implements ethdb.KeyValueWriter and collects the proof nodes.
+type proofList []hexutil.Bytes
+
+func (n *proofList) Put(key []byte, value []byte) error {
+	*n = append(*n, common.CopyBytes(value))
+	return nil
+}
+
+func (n *proofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+func parseAddress(s string) (common.Address, error) {
+	if s == "" {
+		return common.Address{}, fmt.Errorf("%w: address", errMissingParam)
+	}
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("invalid address %q", s)
+	}
+	return common.HexToAddress(s), nil
+}
+
+func parseHash(s string) (common.Hash, error) {
+	var hash common.Hash
+	if err := hash.UnmarshalText([]byte(s)); err != nil {
+		return common.Hash{}, fmt.Errorf("invalid hash %q: %v", s, err)
+	}
+	return hash, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package proofserver
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// Tests that the receipt proofs verify against the receipt root of the block.
+func TestReceiptProof(t *testing.T) {
+	// Use enough receipts to cover the special ordering of DeriveSha at 0x80.
+	var receipts types.Receipts
+	for i := 0; i < 200; i++ {
+		receipts = append(receipts, &types.Receipt{
+			Type:              types.DynamicFeeTxType,
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			Logs:              []*types.Log{{Address: common.Address{byte(i)}, Data: []byte{byte(i)}}},
+		})
+	}
+	root := types.DeriveSha(receipts, trie.NewStackTrie(nil))
+
+	for _, index := range []int{0, 1, 0x7f, 0x80, 199} {
+		enc, proof, err := receiptProof(receipts, index)
+		if err != nil {
+			t.Fatalf("receipt %d: failed to prove: %v", index, err)
+		}
+		db := rawdb.NewMemoryDatabase()
+		for _, node := range proof {
+			db.Put(crypto.Keccak256(node), node)
+		}
+		value, err := trie.VerifyProof(root, rlp.AppendUint64(nil, uint64(index)), db)
+		if err != nil {
+			t.Fatalf("receipt %d: invalid proof: %v", index, err)
+		}
+		if !bytes.Equal(value, enc) {
+			t.Fatalf("receipt %d: proven value mismatch", index)
+		}
+		var buf bytes.Buffer
+		receipts.EncodeIndex(index, &buf)
+		if !bytes.Equal(enc, buf.Bytes()) {
+			t.Fatalf("receipt %d: wrong encoding", index)
+		}
+	}
+}
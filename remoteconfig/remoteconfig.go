@@ -0,0 +1,545 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package remoteconfig implements a service polling a signed configuration
+// document, which adjusts a limited set of runtime-tunable node parameters
+// across a fleet without restarts.
+//
+// The document is served as a JSON envelope holding the encoded document and an
+// EIP-191 signature of it by one of the configured signers:
+//
+//	{"document": "{\"sequence\":7,\"chainId\":10,\"expiry\":1767225600,\"settings\":{\"log.verbosity\":4}}", "signature": "0x..."}
+//
+// Documents are bound to the chain of the node and may expire, after which the
+// settings revert to their local values. The last applied document is kept next
+// to the audit log and reapplied on startup, so older documents can't be
+// replayed across restarts.
+//
+// Settings pinned locally always keep their local value. A setting dropped from
+// the document reverts to its local value. Every change, rejection and revert is
+// recorded in a signed audit log.
+package remoteconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// fetchTimeout is the time allowed for retrieving the document.
+	fetchTimeout = 10 * time.Second
+
+	// maxDocumentSize is the size limit of the served envelope.
+	maxDocumentSize = 1024 * 1024
+
+	// lastDocumentFile is the file the last applied envelope is persisted to,
+	// in the directory of the audit log.
+	lastDocumentFile = "remoteconfig-last.json"
+)
+
+var (
+	errUnauthorizedSigner = errors.New("document not signed by an authorized signer")
+	errStaleDocument      = errors.New("document sequence not newer than the applied one")
+	errExpiredDocument    = errors.New("document expired")
+	errChainMismatch      = errors.New("document meant for another chain")
+)
+
+// Config contains the settings of the remote configuration service.
+type Config struct {
+	URL      string           `toml:",omitempty"` // Address the signed document is fetched from
+	Signers  []common.Address `toml:",omitempty"` // Accounts authorized to sign the document
+	Interval time.Duration    `toml:",omitempty"` // Time between two consecutive fetches
+	Pinned   []string         `toml:",omitempty"` // Settings which always keep their local value
+	AuditLog string           `toml:",omitempty"` // Path of the audit log, relative to the data directory
+	ChainID  uint64           `toml:"-"`          // Chain of the node, documents of other chains are rejected
+}
+
+// DefaultConfig contains the default settings of the remote configuration service.
+var DefaultConfig = Config{
+	Interval: time.Minute,
+	AuditLog: "remoteconfig-audit.jsonl",
+}
+
+// Document is the payload of the signed remote configuration.
+type Document struct {
+	Sequence uint64                     `json:"sequence"`         // Increases with every update, older documents are ignored
+	ChainID  uint64                     `json:"chainId"`          // Chain the document is meant for
+	Expiry   uint64                     `json:"expiry,omitempty"` // Unix time the settings revert to local at, never if zero
+	Settings map[string]json.RawMessage `json:"settings"`         // Values of the remotely controlled settings
+}
+
+// expired reports whether the document expired by the given time.
+func (doc *Document) expired(now time.Time) bool {
+	return doc.Expiry != 0 && now.Unix() >= int64(doc.Expiry)
+}
+
+// envelope is the served form of the document.
+type envelope struct {
+	Document  string        `json:"document"`  // JSON encoded Document
+	Signature hexutil.Bytes `json:"signature"` // EIP-191 signature of Document
+}
+
+// Setting is a runtime-tunable parameter which the remote document may control.
+type Setting struct {
+	Name   string                      // Key of the setting in the document
+	Local  interface{}                 // Value configured locally, restored when not set remotely
+	Pinned bool                        // Whether the local value was set explicitly and takes precedence
+	Apply  func(json.RawMessage) error // Validates and applies a value
+}
+
+// NewSetting creates a setting whose values are decoded into T before being
+// passed to apply.
+func NewSetting[T any](name string, local T, pinned bool, apply func(T) error) Setting {
+	return Setting{
+		Name:   name,
+		Local:  local,
+		Pinned: pinned,
+		Apply: func(raw json.RawMessage) error {
+			var value T
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return err
+			}
+			return apply(value)
+		},
+	}
+}
+
+// setting is the tracked state of a Setting.
+type setting struct {
+	Setting
+	local  string // Canonical encoding of the local value
+	value  string // Canonical encoding of the current value
+	remote bool   // Whether the current value was set remotely
+}
+
+// SettingStatus is the current state of a setting.
+type SettingStatus struct {
+	Value  json.RawMessage `json:"value"`
+	Source string          `json:"source"` // Either "local" or "remote"
+	Pinned bool            `json:"pinned"`
+}
+
+// Status is a snapshot of the remote configuration service.
+type Status struct {
+	URL      string                   `json:"url"`
+	Sequence hexutil.Uint64           `json:"sequence"`        // Sequence of the applied document
+	Signer   *common.Address          `json:"signer"`          // Signer of the applied document
+	Fetched  *time.Time               `json:"fetched"`         // Time of the last successful fetch
+	Error    string                   `json:"error,omitempty"` // Error of the last fetch, if it failed
+	Settings map[string]SettingStatus `json:"settings"`
+}
+
+// Service periodically fetches the remote document and applies it.
+type Service struct {
+	config   Config
+	settings map[string]*setting
+	audit    *txpool.AuditLog
+	last     string // Path of the last applied envelope, not persisted if empty
+	client   *http.Client
+
+	mu       sync.Mutex // Protects the fields below, not held while fetching
+	applied  bool
+	sequence uint64
+	expiry   uint64
+	signer   common.Address
+	fetched  time.Time
+	err      error
+
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New creates a remote configuration service controlling the given settings
+// and registers it with the node.
+func New(stack *node.Node, config Config, settings []Setting) error {
+	path := stack.ResolvePath(config.AuditLog)
+	if path == "" {
+		return errors.New("remote configuration audit log requires a data directory")
+	}
+	audit, err := txpool.OpenAuditLog(path, stack.Config().NodeKey())
+	if err != nil {
+		return err
+	}
+	s, err := newService(config, settings, audit, filepath.Join(filepath.Dir(path), lastDocumentFile))
+	if err != nil {
+		audit.Close()
+		return err
+	}
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "admin",
+		Service:   &API{s},
+	}})
+	stack.RegisterLifecycle(s)
+	return nil
+}
+
+func newService(config Config, settings []Setting, audit *txpool.AuditLog, last string) (*Service, error) {
+	if config.URL == "" {
+		return nil, errors.New("no remote configuration URL")
+	}
+	if len(config.Signers) == 0 {
+		return nil, errors.New("no remote configuration signers")
+	}
+	if config.Interval <= 0 {
+		return nil, fmt.Errorf("invalid remote configuration interval %v", config.Interval)
+	}
+	if config.ChainID == 0 {
+		return nil, errors.New("no remote configuration chain")
+	}
+	s := &Service{
+		config:   config,
+		settings: make(map[string]*setting),
+		audit:    audit,
+		last:     last,
+		client:   &http.Client{Timeout: fetchTimeout},
+		closeCh:  make(chan struct{}),
+	}
+	for _, set := range settings {
+		local, err := json.Marshal(set.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid local value of setting %s: %v", set.Name, err)
+		}
+		set.Pinned = set.Pinned || slices.Contains(config.Pinned, set.Name)
+		s.settings[set.Name] = &setting{Setting: set, local: string(local), value: string(local)}
+	}
+	for _, name := range config.Pinned {
+		if _, ok := s.settings[name]; !ok {
+			return nil, fmt.Errorf("unknown pinned setting %s", name)
+		}
+	}
+	if err := s.restore(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// restore reapplies the last applied document, unless it expired meanwhile. Its
+// sequence is kept either way, so older documents are rejected.
+func (s *Service) restore() error {
+	if s.last == "" {
+		return nil
+	}
+	blob, err := os.ReadFile(s.last)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	doc, signer, err := s.verify(blob)
+	if err != nil {
+		log.Warn("Discarding last remote configuration", "path", s.last, "err", err)
+		return nil
+	}
+	if doc.expired(time.Now()) {
+		s.applied, s.sequence, s.expiry, s.signer = true, doc.Sequence, doc.Expiry, signer
+		return nil
+	}
+	s.apply(doc, signer)
+	return nil
+}
+
+// Start implements node.Lifecycle, starting the background fetches.
+func (s *Service) Start() error {
+	s.wg.Add(1)
+	go s.loop()
+
+	log.Info("Remote configuration started", "url", s.config.URL, "signers", len(s.config.Signers), "interval", s.config.Interval)
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the background fetches. Remotely
+// set values are kept for the rest of the process lifetime.
+func (s *Service) Stop() error {
+	close(s.closeCh)
+	s.wg.Wait()
+	return s.audit.Close()
+}
+
+func (s *Service) loop() {
+	defer s.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if err := s.Refresh(); err != nil && !errors.Is(err, errStaleDocument) && !errors.Is(err, errExpiredDocument) {
+				log.Warn("Failed to refresh remote configuration", "url", s.config.URL, "err", err)
+			}
+			timer.Reset(s.config.Interval)
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// Refresh fetches the remote document and applies it if it is newer than the
+// currently applied one. The settings of an expired document are reverted.
+func (s *Service) Refresh() error {
+	var (
+		doc    *Document
+		signer common.Address
+	)
+	blob, err := s.fetch()
+	if err == nil {
+		doc, signer, err = s.verify(blob)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.expiry != 0 && now.Unix() >= int64(s.expiry) {
+		s.record("", fmt.Sprintf("sequence %d: expired", s.sequence))
+		s.apply(&Document{Sequence: s.sequence}, s.signer)
+	}
+	if err != nil {
+		s.err = err
+		return err
+	}
+	s.fetched, s.err = now, nil
+	if s.applied && doc.Sequence <= s.sequence {
+		return errStaleDocument
+	}
+	if doc.expired(now) {
+		return errExpiredDocument
+	}
+	s.apply(doc, signer)
+	s.persist(blob)
+	return nil
+}
+
+// persist saves the envelope of the applied document, to restore it on startup.
+// The caller must hold s.mu.
+func (s *Service) persist(blob []byte) {
+	if s.last == "" {
+		return
+	}
+	tmp := s.last + ".new"
+	if err := os.WriteFile(tmp, blob, 0600); err != nil {
+		log.Error("Failed to persist remote configuration", "path", s.last, "err", err)
+		return
+	}
+	if err := os.Rename(tmp, s.last); err != nil {
+		log.Error("Failed to persist remote configuration", "path", s.last, "err", err)
+	}
+}
+
+// fetch retrieves the envelope of the document.
+func (s *Service) fetch() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", res.Status)
+	}
+	blob, err := io.ReadAll(io.LimitReader(res.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) > maxDocumentSize {
+		return nil, errors.New("document too large")
+	}
+	return blob, nil
+}
+
+// verify checks the signature of the envelope and decodes the document, which
+// must be meant for the chain of the node.
+func (s *Service) verify(blob []byte) (*Document, common.Address, error) {
+	var env envelope
+	if err := json.Unmarshal(blob, &env); err != nil {
+		return nil, common.Address{}, fmt.Errorf("invalid envelope: %v", err)
+	}
+	if len(env.Signature) != crypto.SignatureLength {
+		return nil, common.Address{}, fmt.Errorf("invalid signature length %d", len(env.Signature))
+	}
+	sig := slices.Clone(env.Signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27 // Legacy Ethereum signatures
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(env.Document)), sig)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	signer := crypto.PubkeyToAddress(*pub)
+	if !slices.Contains(s.config.Signers, signer) {
+		return nil, signer, fmt.Errorf("%w: %v", errUnauthorizedSigner, signer)
+	}
+	var doc Document
+	if err := json.Unmarshal([]byte(env.Document), &doc); err != nil {
+		return nil, signer, fmt.Errorf("invalid document: %v", err)
+	}
+	if doc.ChainID != s.config.ChainID {
+		return nil, signer, fmt.Errorf("%w: chain %d, want %d", errChainMismatch, doc.ChainID, s.config.ChainID)
+	}
+	return &doc, signer, nil
+}
+
+// apply brings the settings in line with the document. The caller must hold s.mu.
+func (s *Service) apply(doc *Document, signer common.Address) {
+	s.applied, s.sequence, s.signer = true, doc.Sequence, signer
+
+	var unknown []string
+	for name := range doc.Settings {
+		if _, ok := s.settings[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		s.record(name, fmt.Sprintf("sequence %d: ignored unknown setting %s", doc.Sequence, name))
+	}
+	names := make([]string, 0, len(s.settings))
+	for name := range s.settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		set := s.settings[name]
+		raw, ok := doc.Settings[name]
+		switch {
+		case ok && set.Pinned:
+			if value, err := canonical(raw); err != nil || value != set.value {
+				s.record(name, fmt.Sprintf("sequence %d: kept pinned %s=%s, remote %s", doc.Sequence, name, set.value, raw))
+			}
+		case ok:
+			value, err := canonical(raw)
+			if err == nil && value == set.value {
+				set.remote = true
+				continue
+			}
+			if err == nil {
+				err = set.Apply(json.RawMessage(value))
+			}
+			if err != nil {
+				s.record(name, fmt.Sprintf("sequence %d: rejected %s=%s: %v", doc.Sequence, name, raw, err))
+				continue
+			}
+			s.record(name, fmt.Sprintf("sequence %d: set %s=%s, was %s", doc.Sequence, name, value, set.value))
+			set.value, set.remote = value, true
+
+		case set.remote:
+			if err := set.Apply(json.RawMessage(set.local)); err != nil {
+				s.record(name, fmt.Sprintf("sequence %d: failed to revert %s=%s: %v", doc.Sequence, name, set.local, err))
+				continue
+			}
+			s.record(name, fmt.Sprintf("sequence %d: reverted %s=%s, was %s", doc.Sequence, name, set.local, set.value))
+			set.value, set.remote = set.local, false
+		}
+	}
+}
+
+// record logs a change of the settings and appends it to the audit log.
+func (s *Service) record(name string, detail string) {
+	log.Info("Remote configuration update", "setting", name, "detail", detail)
+	if err := s.audit.Append(txpool.AuditEntry{Event: "remoteconfig", Detail: detail}); err != nil {
+		log.Error("Failed to write remote configuration audit log", "err", err)
+	}
+}
+
+// Status returns the current state of the service.
+func (s *Service) Status() *Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := &Status{
+		URL:      s.config.URL,
+		Sequence: hexutil.Uint64(s.sequence),
+		Settings: make(map[string]SettingStatus, len(s.settings)),
+	}
+	if s.applied {
+		signer := s.signer
+		status.Signer = &signer
+	}
+	if !s.fetched.IsZero() {
+		fetched := s.fetched
+		status.Fetched = &fetched
+	}
+	if s.err != nil {
+		status.Error = s.err.Error()
+	}
+	for name, set := range s.settings {
+		source := "local"
+		if set.remote {
+			source = "remote"
+		}
+		status.Settings[name] = SettingStatus{
+			Value:  json.RawMessage(set.value),
+			Source: source,
+			Pinned: set.Pinned,
+		}
+	}
+	return status
+}
+
+// canonical returns the compact encoding of a JSON value.
+func canonical(raw json.RawMessage) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// API exposes the remote configuration service over RPC.
+type API struct {
+	s *Service
+}
+
+// RemoteConfig returns the state of the remote configuration.
+func (api *API) RemoteConfig() *Status {
+	return api.s.Status()
+}
+
+// RefreshRemoteConfig fetches and applies the remote configuration right away.
+func (api *API) RefreshRemoteConfig() (*Status, error) {
+	if err := api.s.Refresh(); err != nil && !errors.Is(err, errStaleDocument) && !errors.Is(err, errExpiredDocument) {
+		return nil, err
+	}
+	return api.s.Status(), nil
+}
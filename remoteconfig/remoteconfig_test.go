@@ -0,0 +1,269 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remoteconfig
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testServer serves the most recently published envelope.
+type testServer struct {
+	mu   sync.Mutex
+	blob []byte
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Write(s.blob)
+}
+
+func (s *testServer) publish(t *testing.T, doc string, signer *testKey) {
+	sig, err := crypto.Sign(accounts.TextHash([]byte(doc)), signer.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	blob, _ := json.Marshal(&envelope{Document: doc, Signature: sig})
+
+	s.mu.Lock()
+	s.blob = blob
+	s.mu.Unlock()
+}
+
+type testKey struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func newTestKey() *testKey {
+	key, _ := crypto.GenerateKey()
+	return &testKey{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func TestRefresh(t *testing.T) {
+	var (
+		signer   = newTestKey()
+		attacker = newTestKey()
+		server   = new(testServer)
+		srv      = httptest.NewServer(server)
+		path     = filepath.Join(t.TempDir(), "audit.jsonl")
+		nodeKey  = newTestKey()
+
+		level   = 3
+		gascap  = uint64(50_000_000)
+		applied = make(map[string]int)
+	)
+	defer srv.Close()
+
+	audit, err := txpool.OpenAuditLog(path, nodeKey.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := []Setting{
+		NewSetting("log.verbosity", level, false, func(v int) error {
+			if v > 5 {
+				return fmt.Errorf("invalid verbosity %d", v)
+			}
+			level = v
+			applied["log.verbosity"]++
+			return nil
+		}),
+		NewSetting("rpc.gascap", gascap, false, func(v uint64) error {
+			gascap = v
+			applied["rpc.gascap"]++
+			return nil
+		}),
+	}
+	s, err := newService(Config{URL: srv.URL, Signers: []common.Address{signer.addr}, Interval: DefaultConfig.Interval, Pinned: []string{"rpc.gascap"}, ChainID: 1}, settings, audit, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Apply a document, the pinned gas cap must not change.
+	server.publish(t, `{"sequence": 1, "chainId": 1, "settings": {"log.verbosity": 5, "rpc.gascap": 1, "other": true}}`, signer)
+	if err := s.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if level != 5 || gascap != 50_000_000 {
+		t.Fatalf("unexpected settings: verbosity %d, gas cap %d", level, gascap)
+	}
+	status := s.Status()
+	if status.Signer == nil || *status.Signer != signer.addr || status.Settings["log.verbosity"].Source != "remote" || !status.Settings["rpc.gascap"].Pinned {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	// Documents of other signers and older documents must be ignored.
+	server.publish(t, `{"sequence": 2, "chainId": 1, "settings": {"log.verbosity": 1}}`, attacker)
+	if err := s.Refresh(); !errors.Is(err, errUnauthorizedSigner) {
+		t.Fatalf("unexpected error for unauthorized signer: %v", err)
+	}
+	server.publish(t, `{"sequence": 1, "chainId": 1, "settings": {"log.verbosity": 1}}`, signer)
+	if err := s.Refresh(); !errors.Is(err, errStaleDocument) {
+		t.Fatalf("unexpected error for stale document: %v", err)
+	}
+	if level != 5 {
+		t.Fatalf("verbosity changed by rejected document: %d", level)
+	}
+	// Invalid values are rejected, settings dropped from the document revert.
+	server.publish(t, `{"sequence": 2, "chainId": 1, "settings": {"log.verbosity": 9}}`, signer)
+	if err := s.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if level != 5 {
+		t.Fatalf("invalid verbosity applied: %d", level)
+	}
+	server.publish(t, `{"sequence": 3, "chainId": 1, "settings": {}}`, signer)
+	if err := s.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if level != 3 || s.Status().Settings["log.verbosity"].Source != "local" {
+		t.Fatalf("verbosity not reverted: %d", level)
+	}
+	if applied["log.verbosity"] != 2 || applied["rpc.gascap"] != 0 {
+		t.Fatalf("unexpected apply counts: %v", applied)
+	}
+	// The audit trail must hold the ignored, pinned, set, rejected and reverted
+	// changes, signed by the node key.
+	audit.Close()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	count, err := txpool.VerifyAuditLog(f, &nodeKey.key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 5 {
+		t.Fatalf("audit entry count mismatch: have %d, want 5", count)
+	}
+}
+
+// Tests that the last applied document survives restarts, and that documents of
+// other chains or expired ones are rejected.
+func TestRestore(t *testing.T) {
+	var (
+		signer  = newTestKey()
+		server  = new(testServer)
+		srv     = httptest.NewServer(server)
+		dir     = t.TempDir()
+		last    = filepath.Join(dir, lastDocumentFile)
+		nodeKey = newTestKey()
+		level   = 3
+	)
+	defer srv.Close()
+
+	audit, err := txpool.OpenAuditLog(filepath.Join(dir, "audit.jsonl"), nodeKey.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer audit.Close()
+
+	start := func() *Service {
+		level = 3
+		settings := []Setting{
+			NewSetting("log.verbosity", level, false, func(v int) error {
+				level = v
+				return nil
+			}),
+		}
+		s, err := newService(Config{URL: srv.URL, Signers: []common.Address{signer.addr}, Interval: DefaultConfig.Interval, ChainID: 1}, settings, audit, last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	s := start()
+	server.publish(t, `{"sequence": 2, "chainId": 2, "settings": {"log.verbosity": 5}}`, signer)
+	if err := s.Refresh(); !errors.Is(err, errChainMismatch) {
+		t.Fatalf("unexpected error for other chain: %v", err)
+	}
+	server.publish(t, `{"sequence": 2, "chainId": 1, "expiry": 1, "settings": {"log.verbosity": 5}}`, signer)
+	if err := s.Refresh(); !errors.Is(err, errExpiredDocument) {
+		t.Fatalf("unexpected error for expired document: %v", err)
+	}
+	server.publish(t, `{"sequence": 2, "chainId": 1, "settings": {"log.verbosity": 5}}`, signer)
+	if err := s.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	// After a restart the document must be reapplied, older ones rejected.
+	s = start()
+	if level != 5 || s.Status().Sequence != 2 {
+		t.Fatalf("document not restored: verbosity %d, sequence %d", level, s.Status().Sequence)
+	}
+	server.publish(t, `{"sequence": 1, "chainId": 1, "settings": {"log.verbosity": 1}}`, signer)
+	if err := s.Refresh(); !errors.Is(err, errStaleDocument) {
+		t.Fatalf("unexpected error for replayed document: %v", err)
+	}
+	// Settings revert once the applied document expires.
+	s.expiry = uint64(time.Now().Unix())
+	if err := s.Refresh(); !errors.Is(err, errStaleDocument) {
+		t.Fatalf("unexpected error for replayed document: %v", err)
+	}
+	if level != 3 || s.Status().Settings["log.verbosity"].Source != "local" {
+		t.Fatalf("expired settings not reverted: verbosity %d", level)
+	}
+}
+
+// Tests that fetching the document doesn't block the status of the service.
+func TestRefreshUnlocked(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		srv     = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { <-release }))
+		nodeKey = newTestKey()
+	)
+	defer srv.Close()
+	defer close(release)
+
+	audit, err := txpool.OpenAuditLog(filepath.Join(t.TempDir(), "audit.jsonl"), nodeKey.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer audit.Close()
+
+	s, err := newService(Config{URL: srv.URL, Signers: []common.Address{nodeKey.addr}, Interval: DefaultConfig.Interval, ChainID: 1}, nil, audit, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.Refresh()
+
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		s.Status()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("status blocked by the fetch")
+	}
+}
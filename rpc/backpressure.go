@@ -0,0 +1,208 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSubscriptionQueueFull is returned by Notify when the notification queue of
+	// a subscription overflowed and its connection was closed.
+	ErrSubscriptionQueueFull = errors.New("subscription queue full")
+
+	// errSubscriptionClosed is returned by Notify once the subscription ended.
+	errSubscriptionClosed = errors.New("subscription closed")
+)
+
+// BackpressurePolicy defines how notifications are handled when a client doesn't
+// read them as fast as they are produced, and the queue of the subscription is full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureDisconnect closes the connection of the subscription.
+	BackpressureDisconnect BackpressurePolicy = iota
+
+	// BackpressureDropOldest discards the oldest queued notification.
+	BackpressureDropOldest
+
+	// BackpressurePause blocks the producer of the notifications until the client
+	// catches up.
+	BackpressurePause
+)
+
+// String implements fmt.Stringer.
+func (p BackpressurePolicy) String() string {
+	switch p {
+	case BackpressureDisconnect:
+		return "disconnect"
+	case BackpressureDropOldest:
+		return "drop-oldest"
+	case BackpressurePause:
+		return "pause"
+	default:
+		return fmt.Sprintf("BackpressurePolicy(%d)", int(p))
+	}
+}
+
+// ParseBackpressurePolicy parses the name of a backpressure policy.
+func ParseBackpressurePolicy(name string) (BackpressurePolicy, error) {
+	switch name {
+	case "disconnect":
+		return BackpressureDisconnect, nil
+	case "drop-oldest":
+		return BackpressureDropOldest, nil
+	case "pause":
+		return BackpressurePause, nil
+	default:
+		return 0, fmt.Errorf("unknown backpressure policy %q, want disconnect, drop-oldest or pause", name)
+	}
+}
+
+// queuedNotification is a notification waiting to be sent to the client.
+type queuedNotification struct {
+	data any
+	time time.Time
+}
+
+// notificationQueue is the bounded queue of the notifications of a subscription.
+// The notifications are written to the connection by a dedicated goroutine, so
+// that a slow client doesn't stall the producer until the queue is full.
+type notificationQueue struct {
+	limit      int
+	policy     BackpressurePolicy
+	send       func(data any) error
+	disconnect func()
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	items   []queuedNotification
+	peak    int    // highest number of queued notifications
+	dropped uint64 // notifications discarded by the drop-oldest policy
+	closed  bool
+	err     error // reason of the closure, returned by push
+}
+
+func newNotificationQueue(limit int, policy BackpressurePolicy, send func(any) error, disconnect func()) *notificationQueue {
+	q := &notificationQueue{
+		limit:      limit,
+		policy:     policy,
+		send:       send,
+		disconnect: disconnect,
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push queues a notification, applying the backpressure policy if the queue is
+// full.
+func (q *notificationQueue) push(data any) error {
+	q.mu.Lock()
+	for !q.closed && len(q.items) >= q.limit {
+		switch q.policy {
+		case BackpressureDropOldest:
+			q.items[0] = queuedNotification{}
+			q.items = q.items[1:]
+			q.dropped++
+			subscriptionQueuedGauge.Dec(1)
+			subscriptionDroppedMeter.Mark(1)
+
+		case BackpressurePause:
+			q.cond.Wait()
+
+		default:
+			q.closeLocked(ErrSubscriptionQueueFull)
+			q.mu.Unlock()
+
+			subscriptionDisconnectMeter.Mark(1)
+			q.disconnect()
+			return ErrSubscriptionQueueFull
+		}
+	}
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return q.err
+	}
+	q.items = append(q.items, queuedNotification{data: data, time: time.Now()})
+	q.peak = max(q.peak, len(q.items))
+	subscriptionQueuedGauge.Inc(1)
+
+	q.cond.Broadcast()
+	return nil
+}
+
+// loop sends the queued notifications until the queue is closed or sending fails.
+func (q *notificationQueue) loop() {
+	for {
+		q.mu.Lock()
+		for !q.closed && len(q.items) == 0 {
+			q.cond.Wait()
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return
+		}
+		item := q.items[0]
+		q.items[0] = queuedNotification{}
+		q.items = q.items[1:]
+		subscriptionQueuedGauge.Dec(1)
+		q.cond.Broadcast()
+		q.mu.Unlock()
+
+		err := q.send(item.data)
+		subscriptionLagTimer.UpdateSince(item.time)
+		if err != nil {
+			q.close(err)
+			return
+		}
+	}
+}
+
+// close discards the queued notifications and stops the sending goroutine.
+func (q *notificationQueue) close(err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closeLocked(err)
+}
+
+func (q *notificationQueue) closeLocked(err error) {
+	if q.closed {
+		return
+	}
+	subscriptionQueuedGauge.Dec(int64(len(q.items)))
+	q.items = nil
+	q.closed, q.err = true, err
+	q.cond.Broadcast()
+}
+
+// stats returns the number of queued notifications, the highest number of
+// queued notifications so far, the number of dropped notifications and the time
+// the oldest queued notification has been waiting.
+func (q *notificationQueue) stats() (depth int, peak int, dropped uint64, lag time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) > 0 {
+		lag = time.Since(q.items[0].time)
+	}
+	return len(q.items), q.peak, q.dropped, lag
+}
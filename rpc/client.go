@@ -89,6 +89,11 @@ type Client struct {
 	// config fields
 	batchItemLimit       int
 	batchResponseMaxSize int
+	subscriptionBuffer   int
+	subscriptionPolicy   BackpressurePolicy
+	subscriptions        *subscriptionSet
+	usage                *UsageTracker
+	responses            ResponseLimiter
 
 	// writeConn is used for writing to the connection on the caller's goroutine. It should
 	// only be accessed outside of dispatch, with the write lock held. The write lock is
@@ -124,6 +129,11 @@ func (c *Client) newClientConn(conn ServerCodec) *clientConn {
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, conn.peerInfo())
 	handler := newHandler(ctx, conn, c.idgen, c.services, c.batchItemLimit, c.batchResponseMaxSize)
 	handler.recorder = c.recorder
+	handler.subscriptionBuffer = c.subscriptionBuffer
+	handler.subscriptionPolicy = c.subscriptionPolicy
+	handler.subscriptions = c.subscriptions
+	handler.usage = c.usage
+	handler.responses = c.responses
 	return &clientConn{conn, handler}
 }
 
@@ -251,6 +261,11 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 		idgen:                cfg.idgen,
 		batchItemLimit:       cfg.batchItemLimit,
 		batchResponseMaxSize: cfg.batchResponseLimit,
+		subscriptionBuffer:   cfg.subscriptionBuffer,
+		subscriptionPolicy:   cfg.subscriptionPolicy,
+		subscriptions:        cfg.subscriptions,
+		usage:                cfg.usage,
+		responses:            cfg.responses,
 		writeConn:            conn,
 		close:                make(chan struct{}),
 		closing:              make(chan struct{}),
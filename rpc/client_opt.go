@@ -42,6 +42,12 @@ type clientConfig struct {
 	batchItemLimit     int
 	batchResponseLimit int
 
+	subscriptionBuffer int
+	subscriptionPolicy BackpressurePolicy
+	subscriptions      *subscriptionSet
+	usage              *UsageTracker
+	responses          ResponseLimiter
+
 	recorder Recorder
 }
 
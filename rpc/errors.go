@@ -70,6 +70,7 @@ const (
 const (
 	errMsgTimeout          = "request timed out"
 	errMsgResponseTooLarge = "response too large"
+	errMsgMemoryPressure   = "response dropped due to memory pressure"
 	errMsgBatchTooLarge    = "batch too large"
 )
 
@@ -69,6 +69,12 @@ type handler struct {
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
 
+	subscriptionBuffer int                // notification queue size, zero for synchronous sends
+	subscriptionPolicy BackpressurePolicy // handling of the notifications when the queue is full
+	subscriptions      *subscriptionSet   // server wide subscription tracker, may be nil
+	usage              *UsageTracker      // execution cost tracker, may be nil
+	responses          ResponseLimiter    // response memory accounting, may be nil
+
 	// optional, may be nil
 	recorder Recorder
 }
@@ -220,7 +226,7 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 			})
 		}
 
-		responseBytes := 0
+		responseBytes, held := 0, 0
 		for {
 			// No need to handle rest of calls if timed out.
 			if cp.ctx.Err() != nil {
@@ -231,6 +237,18 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 				break
 			}
 			resp := h.handleCallMsg(cp, msg)
+			if resp != nil && resp.stream != nil {
+				// Batch responses are written at once, buffer streamed results
+				// within what's left of the batch size limit
+				limit := maxBufferedStream
+				if h.batchResponseMaxSize != 0 {
+					limit = h.batchResponseMaxSize - responseBytes
+				}
+				resp = resp.materialize(cp.ctx, limit)
+			}
+			var size int
+			resp, size = h.limitResponse(msg, resp)
+			held += size
 			callBuffer.pushResponse(resp)
 			if resp != nil && h.batchResponseMaxSize != 0 {
 				responseBytes += len(resp.Result)
@@ -247,6 +265,9 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 
 		h.addSubscriptions(cp.notifiers)
 		callBuffer.write(cp.ctx, h.conn)
+		if held > 0 {
+			h.responses.ReleaseResponse(held)
+		}
 		for _, n := range cp.notifiers {
 			n.activate()
 		}
@@ -299,7 +320,7 @@ func (h *handler) handleNonBatchCall(cp *callProc, msg *jsonrpcMessage) {
 		})
 	}
 
-	answer := h.handleCallMsg(cp, msg)
+	answer, held := h.limitResponse(msg, h.handleCallMsg(cp, msg))
 	if timer != nil {
 		timer.Stop()
 	}
@@ -309,11 +330,28 @@ func (h *handler) handleNonBatchCall(cp *callProc, msg *jsonrpcMessage) {
 			h.conn.writeJSON(cp.ctx, answer, false)
 		})
 	}
+	if held > 0 {
+		h.responses.ReleaseResponse(held)
+	}
 	for _, n := range cp.notifiers {
 		n.activate()
 	}
 }
 
+// limitResponse accounts the result of the answer to the call in the response
+// limiter, replacing it with an error if refused. It returns the answer to send
+// and the number of bytes to release once sent.
+func (h *handler) limitResponse(msg, answer *jsonrpcMessage) (*jsonrpcMessage, int) {
+	if h.responses == nil || answer == nil || len(answer.Result) == 0 {
+		return answer, 0
+	}
+	size := len(answer.Result)
+	if !h.responses.AcquireResponse(size) {
+		return msg.errorResponse(&internalServerError{errcodeResponseTooLarge, errMsgMemoryPressure}), 0
+	}
+	return answer, size
+}
+
 // close cancels all requests except for inflightReq and waits for
 // call goroutines to shut down.
 func (h *handler) close(err error, inflightReq *requestOp) {
@@ -367,19 +405,42 @@ func (h *handler) addSubscriptions(nn []*Notifier) {
 	for _, n := range nn {
 		if sub := n.takeSubscription(); sub != nil {
 			h.serverSubs[sub.ID] = sub
+			if h.subscriptions != nil {
+				h.subscriptions.add(sub, h.rootCtx)
+			}
 		}
 	}
 }
 
+// removeSubscription stops the delivery of a subscription's notifications and
+// removes it. The caller must hold subLock.
+func (h *handler) removeSubscription(s *Subscription) {
+	s.stop()
+	delete(h.serverSubs, s.ID)
+	if h.subscriptions != nil {
+		h.subscriptions.remove(s)
+	}
+}
+
 // cancelServerSubscriptions removes all subscriptions and closes their error channels.
 func (h *handler) cancelServerSubscriptions(err error) {
 	h.subLock.Lock()
 	defer h.subLock.Unlock()
 
-	for id, s := range h.serverSubs {
+	for _, s := range h.serverSubs {
 		s.err <- err
 		close(s.err)
-		delete(h.serverSubs, id)
+		h.removeSubscription(s)
+	}
+}
+
+// disconnect closes the connection because a subscription's client can't keep up
+// with its notifications. The subscriptions are ended when the read loop notices
+// the closed connection.
+func (h *handler) disconnect() {
+	h.log.Warn("Closing RPC connection with slow subscription client")
+	if codec, ok := h.conn.(ServerCodec); ok {
+		codec.close()
 	}
 }
 
@@ -477,6 +538,10 @@ func (h *handler) handleCallMsg(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessa
 	}
 	out := h.handleCallMsgInner(cp, msg)
 	if recordDone != nil {
+		// The recording needs the result, buffer it if streamed
+		if out != nil && out.stream != nil {
+			out = out.materialize(cp.ctx, maxBufferedStream)
+		}
 		recordDone(cp.ctx, msg, out)
 	}
 	return out
@@ -536,8 +601,20 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	if err != nil {
 		return msg.errorResponse(&invalidParamsError{err.Error()})
 	}
+	// Let the method report its costs if they are accounted.
+	var (
+		ctx   = cp.ctx
+		usage *callUsage
+	)
+	if h.usage != nil && callb != h.unsubscribeCb {
+		usage = new(callUsage)
+		ctx = context.WithValue(ctx, callUsageKey{}, usage)
+	}
 	start := time.Now()
-	answer := h.runMethod(cp.ctx, msg, callb, args)
+	answer := h.runMethod(ctx, msg, callb, args)
+	if usage != nil {
+		h.usage.record(PeerInfoFromContext(cp.ctx), msg.Method, answer.Error != nil, time.Since(start), usage.dbReads(), len(answer.Result))
+	}
 
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
@@ -612,7 +689,7 @@ func (h *handler) unsubscribe(ctx context.Context, id ID) (bool, error) {
 		return false, ErrSubscriptionNotFound
 	}
 	close(s.err)
-	delete(h.serverSubs, id)
+	h.removeSubscription(s)
 	return true, nil
 }
 
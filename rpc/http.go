@@ -252,11 +252,12 @@ type httpServerConn struct {
 	io.Reader
 	io.Writer
 	r *http.Request
+	w http.ResponseWriter
 }
 
 func (s *Server) newHTTPServerConn(r *http.Request, w http.ResponseWriter) ServerCodec {
 	body := io.LimitReader(r.Body, int64(s.httpBodyLimit))
-	conn := &httpServerConn{Reader: body, Writer: w, r: r}
+	conn := &httpServerConn{Reader: body, Writer: w, r: r, w: w}
 
 	encoder := func(v any, isErrorResponse bool) error {
 		if !isErrorResponse {
@@ -292,7 +293,9 @@ func (s *Server) newHTTPServerConn(r *http.Request, w http.ResponseWriter) Serve
 	dec := json.NewDecoder(conn)
 	dec.UseNumber()
 
-	return NewFuncCodec(conn, encoder, dec.Decode)
+	codec := NewFuncCodec(conn, encoder, dec.Decode).(*jsonCodec)
+	codec.stream = func() (io.WriteCloser, error) { return nopWriteCloser{conn}, nil }
+	return codec
 }
 
 // Close does nothing and always returns nil.
@@ -306,6 +309,12 @@ func (t *httpServerConn) RemoteAddr() string {
 // SetWriteDeadline does nothing and always returns nil.
 func (t *httpServerConn) SetWriteDeadline(time.Time) error { return nil }
 
+// SetStreamDeadline moves the write deadline of the response, overriding the
+// write timeout of the server for streamed results.
+func (t *httpServerConn) SetStreamDeadline(deadline time.Time) error {
+	return http.NewResponseController(t.w).SetWriteDeadline(deadline)
+}
+
 // ServeHTTP serves JSON-RPC requests over HTTP.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Permit dumb empty requests for remote health-checks (AWS)
@@ -324,6 +333,8 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	connInfo.HTTP.Host = r.Host
 	connInfo.HTTP.Origin = r.Header.Get("Origin")
 	connInfo.HTTP.UserAgent = r.Header.Get("User-Agent")
+	connInfo.AuthID = authIDFromContext(r.Context())
+	connInfo.AuthCredentials = authCredentialsFromContext(r.Context())
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, connInfo)
 
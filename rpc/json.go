@@ -69,6 +69,8 @@ type jsonrpcMessage struct {
 	Params  json.RawMessage `json:"params,omitempty"`
 	Error   *jsonError      `json:"error,omitempty"`
 	Result  json.RawMessage `json:"result,omitempty"`
+
+	stream StreamWriter // Result streamed to the connection instead of Result, if set
 }
 
 func (msg *jsonrpcMessage) isNotification() bool {
@@ -116,6 +118,9 @@ func (msg *jsonrpcMessage) errorResponse(err error) *jsonrpcMessage {
 }
 
 func (msg *jsonrpcMessage) response(result interface{}) *jsonrpcMessage {
+	if stream, ok := result.(StreamWriter); ok {
+		return &jsonrpcMessage{Version: vsn, ID: msg.ID, stream: stream}
+	}
 	enc, err := json.Marshal(result)
 	if err != nil {
 		return msg.errorResponse(&internalServerError{errcodeMarshalError, err.Error()})
@@ -187,6 +192,7 @@ type jsonCodec struct {
 	decode  decodeFunc       // decoder to allow multiple transports
 	encMu   sync.Mutex       // guards the encoder
 	encode  encodeFunc       // encoder to allow multiple transports
+	stream  streamFunc       // opens writers for streamed results, nil if unsupported
 	conn    deadlineCloser
 }
 
@@ -220,7 +226,9 @@ func NewCodec(conn Conn) ServerCodec {
 	encode := func(v interface{}, isErrorResponse bool) error {
 		return enc.Encode(v)
 	}
-	return NewFuncCodec(conn, encode, dec.Decode)
+	codec := NewFuncCodec(conn, encode, dec.Decode).(*jsonCodec)
+	codec.stream = func() (io.WriteCloser, error) { return nopWriteCloser{conn}, nil }
+	return codec
 }
 
 func (c *jsonCodec) peerInfo() PeerInfo {
@@ -259,6 +267,9 @@ func (c *jsonCodec) writeJSON(ctx context.Context, v interface{}, isErrorRespons
 		deadline = time.Now().Add(defaultWriteTimeout)
 	}
 	c.conn.SetWriteDeadline(deadline)
+	if msg, ok := v.(*jsonrpcMessage); ok && msg.stream != nil {
+		return c.writeStream(ctx, msg)
+	}
 	return c.encode(v, isErrorResponse)
 }
 
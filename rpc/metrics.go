@@ -32,6 +32,15 @@ var (
 	serveTimeHistName = "rpc/duration"
 
 	rpcServingTimer = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	// subscriptionQueuedGauge counts the notifications queued across all subscriptions.
+	subscriptionQueuedGauge = metrics.NewRegisteredGauge("rpc/subscriptions/queued", nil)
+
+	// subscriptionLagTimer measures the time notifications wait in the queue.
+	subscriptionLagTimer = metrics.NewRegisteredTimer("rpc/subscriptions/lag", nil)
+
+	subscriptionDroppedMeter    = metrics.NewRegisteredMeter("rpc/subscriptions/dropped", nil)
+	subscriptionDisconnectMeter = metrics.NewRegisteredMeter("rpc/subscriptions/disconnects", nil)
 )
 
 // updateServeTimeHistogram tracks the serving time of a remote RPC call.
@@ -21,8 +21,11 @@ import (
 	"errors"
 	"io"
 	"net"
+	"slices"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -54,6 +57,11 @@ type Server struct {
 	batchItemLimit     int
 	batchResponseLimit int
 	httpBodyLimit      int
+	subscriptionBuffer int
+	subscriptionPolicy BackpressurePolicy
+	subscriptions      *subscriptionSet
+	usage              *UsageTracker   // optional, may be nil
+	responses          ResponseLimiter // optional, may be nil
 
 	recorder Recorder // optional, may be nil
 }
@@ -64,6 +72,7 @@ func NewServer() *Server {
 		idgen:         randomIDGenerator(),
 		codecs:        make(map[ServerCodec]struct{}),
 		httpBodyLimit: defaultBodyLimit,
+		subscriptions: newSubscriptionSet(),
 	}
 	server.run.Store(true)
 	// Register the default service providing meta information about the RPC service such
@@ -95,6 +104,53 @@ func (s *Server) SetHTTPBodyLimit(limit int) {
 	s.httpBodyLimit = limit
 }
 
+// SetSubscriptionLimits sets the size of the notification queue of subscriptions
+// and the policy applied when a client doesn't read its notifications fast enough
+// and the queue is full. With a zero size, notifications are not queued but sent
+// synchronously by Notify.
+//
+// This method should be called before processing any requests via ServeCodec,
+// ServeListener etc.
+func (s *Server) SetSubscriptionLimits(size int, policy BackpressurePolicy) {
+	s.subscriptionBuffer = size
+	s.subscriptionPolicy = policy
+}
+
+// SetUsageTracker makes the server account the execution cost of the method
+// calls it serves in the given tracker, which may be shared with other servers.
+//
+// This method should be called before processing any requests via ServeCodec,
+// ServeHTTP, ServeListener etc.
+func (s *Server) SetUsageTracker(tracker *UsageTracker) {
+	s.usage = tracker
+}
+
+// ResponseLimiter accounts the memory held by the responses being sent, and may
+// refuse new ones when memory runs low.
+type ResponseLimiter interface {
+	// AcquireResponse accounts a response of the given size until released,
+	// returning false if the response must be dropped instead.
+	AcquireResponse(size int) bool
+
+	// ReleaseResponse releases a response accounted by AcquireResponse.
+	ReleaseResponse(size int)
+}
+
+// SetResponseLimiter makes the server account the responses it sends in the
+// given limiter, which may be shared with other servers. Responses refused by
+// the limiter are replaced by an error.
+//
+// This method should be called before processing any requests via ServeCodec,
+// ServeHTTP, ServeListener etc.
+func (s *Server) SetResponseLimiter(limiter ResponseLimiter) {
+	s.responses = limiter
+}
+
+// Subscriptions returns the subscriptions currently active on the server.
+func (s *Server) Subscriptions() []SubscriptionInfo {
+	return s.subscriptions.list()
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -120,6 +176,11 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		subscriptionBuffer: s.subscriptionBuffer,
+		subscriptionPolicy: s.subscriptionPolicy,
+		subscriptions:      s.subscriptions,
+		usage:              s.usage,
+		responses:          s.responses,
 		recorder:           s.recorder,
 	}
 	c := initClient(codec, &s.services, cfg)
@@ -156,6 +217,8 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit)
 	h.recorder = s.recorder
+	h.usage = s.usage
+	h.responses = s.responses
 	h.allowSubscribe = false
 	defer h.close(io.EOF, nil)
 
@@ -243,10 +306,51 @@ type PeerInfo struct {
 		Origin    string
 		Host      string
 	}
+
+	// AuthID is the identity of the client asserted by the authenticated
+	// endpoint it connected to, empty for unauthenticated connections.
+	AuthID string
+
+	// AuthCredentials are the credentials the client was verified with by the
+	// authenticated endpoint: "jwt:<id>" for the identifier of the JWT secret
+	// its token is signed with, and "tls:<name>" for each subject alternative
+	// name of its verified client certificate. Unlike AuthID, which the client
+	// asserts itself, these can't be claimed without holding the credential.
+	AuthCredentials []string
 }
 
 type peerInfoContextKey struct{}
 
+type authIDContextKey struct{}
+
+// ContextWithAuthID returns a copy of the request context carrying the identity
+// of the authenticated client. It is reported in the PeerInfo of the connection.
+func ContextWithAuthID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, authIDContextKey{}, id)
+}
+
+type authCredentialsContextKey struct{}
+
+// ContextWithAuthCredentials returns a copy of the request context carrying the
+// given verified credentials of the client, in addition to the ones it already
+// carries. They are reported in the PeerInfo of the connection.
+func ContextWithAuthCredentials(ctx context.Context, credentials ...string) context.Context {
+	existing := authCredentialsFromContext(ctx)
+	return context.WithValue(ctx, authCredentialsContextKey{}, append(slices.Clip(existing), credentials...))
+}
+
+// authCredentialsFromContext returns the verified credentials of the client.
+func authCredentialsFromContext(ctx context.Context) []string {
+	credentials, _ := ctx.Value(authCredentialsContextKey{}).([]string)
+	return credentials
+}
+
+// authIDFromContext returns the authenticated client identity of the request.
+func authIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(authIDContextKey{}).(string)
+	return id
+}
+
 // PeerInfoFromContext returns information about the client's network connection.
 // Use this with the context passed to RPC method handler functions.
 //
@@ -255,3 +359,69 @@ func PeerInfoFromContext(ctx context.Context) PeerInfo {
 	info, _ := ctx.Value(peerInfoContextKey{}).(PeerInfo)
 	return info
 }
+
+// SubscriptionInfo describes an active subscription of a server.
+type SubscriptionInfo struct {
+	ID         ID        `json:"id"`
+	Namespace  string    `json:"namespace"`
+	Transport  string    `json:"transport"`
+	RemoteAddr string    `json:"remoteAddr"`
+	Created    time.Time `json:"created"`
+	Sent       uint64    `json:"sent"`          // Notifications sent
+	Dropped    uint64    `json:"dropped"`       // Notifications discarded by the drop-oldest policy
+	QueueDepth int       `json:"queueDepth"`    // Notifications waiting to be sent
+	PeakDepth  int       `json:"peakDepth"`     // Highest queue depth so far
+	Lag        string    `json:"lag,omitempty"` // Time the oldest queued notification has been waiting
+}
+
+// subscriptionSet tracks the active subscriptions across the connections of a server.
+type subscriptionSet struct {
+	mu   sync.Mutex
+	subs map[*Subscription]PeerInfo
+}
+
+func newSubscriptionSet() *subscriptionSet {
+	return &subscriptionSet{subs: make(map[*Subscription]PeerInfo)}
+}
+
+func (set *subscriptionSet) add(sub *Subscription, connCtx context.Context) {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	set.subs[sub] = PeerInfoFromContext(connCtx)
+}
+
+func (set *subscriptionSet) remove(sub *Subscription) {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	delete(set.subs, sub)
+}
+
+// list returns the descriptions of the subscriptions, ordered by creation time.
+func (set *subscriptionSet) list() []SubscriptionInfo {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	infos := make([]SubscriptionInfo, 0, len(set.subs))
+	for sub, peer := range set.subs {
+		info := SubscriptionInfo{
+			ID:         sub.ID,
+			Namespace:  sub.namespace,
+			Transport:  peer.Transport,
+			RemoteAddr: peer.RemoteAddr,
+			Created:    sub.created,
+			Sent:       sub.sent.Load(),
+		}
+		if sub.queue != nil {
+			var lag time.Duration
+			info.QueueDepth, info.PeakDepth, info.Dropped, lag = sub.queue.stats()
+			info.Lag = lag.String()
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Created.Before(infos[j].Created)
+	})
+	return infos
+}
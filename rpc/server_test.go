@@ -24,6 +24,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -48,7 +49,7 @@ func TestServerRegisterName(t *testing.T) {
 		t.Fatalf("Expected service %s to be registered", svcName)
 	}
 
-	wantCallbacks := 14
+	wantCallbacks := 16
 	if len(svc.callbacks) != wantCallbacks {
 		t.Errorf("Expected %d callbacks for service 'service', got %d", wantCallbacks, len(svc.callbacks))
 	}
@@ -202,3 +203,76 @@ func TestServerBatchResponseSizeLimit(t *testing.T) {
 		}
 	}
 }
+
+// testResponseLimiter refuses the responses which would make the held memory
+// exceed its limit.
+type testResponseLimiter struct {
+	lock  sync.Mutex
+	limit int
+	held  int
+}
+
+func (l *testResponseLimiter) AcquireResponse(size int) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.held+size > l.limit {
+		return false
+	}
+	l.held += size
+	return true
+}
+
+func (l *testResponseLimiter) ReleaseResponse(size int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.held -= size
+}
+
+// Tests that the responses refused by the response limiter are replaced by an
+// error, and that the accepted ones are released once sent.
+func TestServerResponseLimiter(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+
+	limiter := &testResponseLimiter{limit: 64}
+	server.SetResponseLimiter(limiter)
+
+	client := DialInProc(server)
+	defer client.Close()
+
+	var res string
+	if err := client.Call(&res, "test_repeat", "x", 10); err != nil {
+		t.Fatalf("small response refused: %v", err)
+	}
+	if err := client.Call(&res, "test_repeat", "x", 100); err == nil || err.Error() != errMsgMemoryPressure {
+		t.Fatalf("large response not refused: %v", err)
+	}
+	batch := []BatchElem{
+		{Method: "test_repeat", Args: []any{"x", 40}, Result: new(string)},
+		{Method: "test_repeat", Args: []any{"x", 40}, Result: new(string)},
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal(err)
+	}
+	if batch[0].Error != nil || batch[1].Error == nil {
+		t.Fatalf("batch limiting mismatch: %v, %v", batch[0].Error, batch[1].Error)
+	}
+	// Responses are released after being written, possibly after delivery
+	for i := 0; ; i++ {
+		limiter.lock.Lock()
+		held := limiter.held
+		limiter.lock.Unlock()
+
+		if held == 0 {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("responses not released: %d bytes held", held)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"time"
+)
+
+const (
+	// streamBufferSize is the size of the buffer coalescing the writes of a
+	// streamed result before they hit the connection.
+	streamBufferSize = 64 * 1024
+
+	// maxBufferedStream is the size limit of a streamed result buffered in
+	// memory, unless a tighter limit applies.
+	maxBufferedStream = 32 * 1024 * 1024
+)
+
+// StreamWriter is implemented by method results too large to be encoded in memory
+// at once. Instead of being marshalled into the response, such a result is written
+// to the connection as it is produced: over HTTP as a chunked response, over
+// WebSocket as a fragmented message and over IPC as a plain stream.
+//
+// The response has already started when WriteStream runs, so a failure can no
+// longer be reported to the client. The response is cut short instead, closing
+// the connection where the transport allows. Results of batch calls and of
+// transports not supporting streaming are buffered and sent as regular
+// responses, reporting failures as usual. Buffered results are limited in size,
+// see maxBufferedStream.
+type StreamWriter interface {
+	WriteStream(ctx context.Context, w io.Writer) error
+}
+
+// streamFunc opens a writer for a single streamed message on a connection.
+type streamFunc = func() (io.WriteCloser, error)
+
+// nopWriteCloser turns a writer into a WriteCloser with a no-op Close.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// streamDeadliner is implemented by connections whose write deadline can only
+// be moved for streamed responses, such as HTTP where it's otherwise left to the
+// server timeouts.
+type streamDeadliner interface {
+	SetStreamDeadline(time.Time) error
+}
+
+// deadlineWriter extends the write deadline of the connection on every write,
+// so that a long stream only times out if the client stops reading.
+type deadlineWriter struct {
+	io.Writer
+	setDeadline func(time.Time) error
+}
+
+func (w *deadlineWriter) Write(p []byte) (int, error) {
+	w.setDeadline(time.Now().Add(defaultWriteTimeout))
+	return w.Writer.Write(p)
+}
+
+// streamBuffer buffers a streamed result, refusing writes past its limit.
+type streamBuffer struct {
+	bytes.Buffer
+	limit    int
+	exceeded bool
+}
+
+func (b *streamBuffer) Write(p []byte) (int, error) {
+	if b.exceeded || b.Len()+len(p) > b.limit {
+		b.exceeded = true
+		return 0, &internalServerError{errcodeResponseTooLarge, errMsgResponseTooLarge}
+	}
+	return b.Buffer.Write(p)
+}
+
+// materialize buffers the streamed result of a response, turning it into a
+// regular one. Results larger than the given limit are replaced by an error.
+func (msg *jsonrpcMessage) materialize(ctx context.Context, limit int) *jsonrpcMessage {
+	buf := &streamBuffer{limit: min(limit, maxBufferedStream)}
+	err := msg.stream.WriteStream(ctx, buf)
+	if buf.exceeded {
+		err = &internalServerError{errcodeResponseTooLarge, errMsgResponseTooLarge}
+	}
+	if err != nil {
+		resp := errorMessage(err)
+		resp.ID = msg.ID
+		return resp
+	}
+	return &jsonrpcMessage{Version: vsn, ID: msg.ID, Result: buf.Bytes()}
+}
+
+// writeStream writes a response with a streamed result to the connection,
+// cutting it short if the result fails midway.
+func (c *jsonCodec) writeStream(ctx context.Context, msg *jsonrpcMessage) error {
+	if c.stream == nil {
+		return c.encode(msg.materialize(ctx, maxBufferedStream), false)
+	}
+	setDeadline := c.conn.SetWriteDeadline
+	if conn, ok := c.conn.(streamDeadliner); ok {
+		setDeadline = conn.SetStreamDeadline
+	}
+	var out io.Writer
+	if deadline, ok := ctx.Deadline(); ok {
+		setDeadline(deadline)
+	}
+	w, err := c.stream()
+	if err != nil {
+		return err
+	}
+	if _, ok := ctx.Deadline(); ok {
+		out = w
+	} else {
+		out = &deadlineWriter{Writer: w, setDeadline: setDeadline}
+	}
+	buf := bufio.NewWriterSize(out, streamBufferSize)
+
+	buf.WriteString(`{"jsonrpc":"` + vsn + `","id":`)
+	buf.Write(msg.ID)
+	buf.WriteString(`,"result":`)
+	if err := msg.stream.WriteStream(ctx, buf); err != nil {
+		c.conn.Close()
+		return err
+	}
+	buf.WriteString("}\n")
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	return w.Close()
+}
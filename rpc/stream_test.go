@@ -0,0 +1,145 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tests that streamed results are delivered over all transports, and in batches.
+func TestStreamWriter(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+	wssrv := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	defer wssrv.Close()
+
+	httpclient, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer httpclient.Close()
+	wsclient, err := DialWebsocket(context.Background(), "ws:"+strings.TrimPrefix(wssrv.URL, "http:"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wsclient.Close()
+	inproc := DialInProc(server)
+	defer inproc.Close()
+
+	// Stream results large enough to span multiple buffers and chunks
+	const n = 100000
+	check := func(t *testing.T, result []int) {
+		t.Helper()
+		if len(result) != n {
+			t.Fatalf("result length mismatch: have %d, want %d", len(result), n)
+		}
+		for i, v := range result {
+			if v != i {
+				t.Fatalf("result %d mismatch: have %d", i, v)
+			}
+		}
+	}
+	for name, client := range map[string]*Client{"http": httpclient, "ws": wsclient, "inproc": inproc} {
+		t.Run(name, func(t *testing.T) {
+			var result []int
+			if err := client.Call(&result, "test_stream", n, false); err != nil {
+				t.Fatalf("call failed: %v", err)
+			}
+			check(t, result)
+
+			var batched []int
+			batch := []BatchElem{{Method: "test_stream", Args: []any{n, false}, Result: &batched}}
+			if err := client.BatchCall(batch); err != nil || batch[0].Error != nil {
+				t.Fatalf("batch call failed: %v %v", err, batch[0].Error)
+			}
+			check(t, batched)
+
+			// Failing within a batch is reported as a regular error
+			batch = []BatchElem{{Method: "test_stream", Args: []any{n, true}, Result: new([]int)}}
+			if err := client.BatchCall(batch); err != nil {
+				t.Fatalf("batch call failed: %v", err)
+			}
+			if batch[0].Error == nil || batch[0].Error.Error() != "stream failed" {
+				t.Fatalf("batch error mismatch: %v", batch[0].Error)
+			}
+		})
+	}
+	// Failing midway aborts the response
+	if err := httpclient.Call(new([]int), "test_stream", n, true); err == nil {
+		t.Fatal("truncated response accepted")
+	}
+}
+
+// Tests that streaming over HTTP outlasts the write timeout of the server, as
+// long as the client keeps reading.
+func TestStreamWriteTimeout(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+
+	httpsrv := httptest.NewUnstartedServer(server)
+	httpsrv.Config.WriteTimeout = 200 * time.Millisecond
+	httpsrv.Start()
+	defer httpsrv.Close()
+
+	client, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var result []int
+	if err := client.Call(&result, "test_slowStream", 10, 50); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if len(result) != 10 {
+		t.Fatalf("result length mismatch: have %d, want 10", len(result))
+	}
+}
+
+// Tests that streamed results buffered for a batch are bounded by the batch
+// response size limit.
+func TestStreamBatchLimit(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	server.SetBatchLimits(100, 1000)
+
+	client := DialInProc(server)
+	defer client.Close()
+
+	batch := []BatchElem{
+		{Method: "test_stream", Args: []any{10, false}, Result: new([]int)},
+		{Method: "test_stream", Args: []any{100000, false}, Result: new([]int)},
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatalf("batch call failed: %v", err)
+	}
+	if batch[0].Error != nil {
+		t.Fatalf("small stream failed: %v", batch[0].Error)
+	}
+	var rpcErr Error
+	if !errors.As(batch[1].Error, &rpcErr) || rpcErr.ErrorCode() != errcodeResponseTooLarge {
+		t.Fatalf("large stream error mismatch: %v", batch[1].Error)
+	}
+}
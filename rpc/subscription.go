@@ -28,6 +28,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -123,12 +124,21 @@ func (n *Notifier) CreateSubscription() *Subscription {
 	} else if n.callReturned {
 		panic("can't create subscription after subscribe call has returned")
 	}
-	n.sub = &Subscription{ID: n.h.idgen(), namespace: n.namespace, err: make(chan error, 1)}
+	sub := &Subscription{ID: n.h.idgen(), namespace: n.namespace, err: make(chan error, 1), created: time.Now()}
+	if n.h.subscriptionBuffer > 0 {
+		send := func(data any) error { return n.send(sub, data) }
+		sub.queue = newNotificationQueue(n.h.subscriptionBuffer, n.h.subscriptionPolicy, send, n.h.disconnect)
+	}
+	n.sub = sub
 	return n.sub
 }
 
 // Notify sends a notification to the client with the given data as payload.
 // If an error occurs the RPC connection is closed and the error is returned.
+//
+// If the server queues notifications, the notification is sent asynchronously
+// and the error is only returned for the notifications after the failing one.
+// When the queue is full, the backpressure policy of the server applies.
 func (n *Notifier) Notify(id ID, data any) error {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -139,6 +149,9 @@ func (n *Notifier) Notify(id ID, data any) error {
 		panic("Notify with wrong ID")
 	}
 	if n.activated {
+		if n.sub.queue != nil {
+			return n.sub.queue.push(data)
+		}
 		return n.send(n.sub, data)
 	}
 	n.buffer = append(n.buffer, data)
@@ -161,11 +174,21 @@ func (n *Notifier) activate() error {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	for _, data := range n.buffer {
-		if err := n.send(n.sub, data); err != nil {
-			return err
+	if n.sub.queue != nil {
+		go n.sub.queue.loop()
+		for _, data := range n.buffer {
+			if err := n.sub.queue.push(data); err != nil {
+				return err
+			}
+		}
+	} else {
+		for _, data := range n.buffer {
+			if err := n.send(n.sub, data); err != nil {
+				return err
+			}
 		}
 	}
+	n.buffer = nil
 	n.activated = true
 	return nil
 }
@@ -186,7 +209,11 @@ func (n *Notifier) send(sub *Subscription, data any) error {
 			defer onDone(ctx, msg, nil)
 		}
 	}
-	return n.h.conn.writeJSON(ctx, msg, false)
+	if err := n.h.conn.writeJSON(ctx, msg, false); err != nil {
+		return err
+	}
+	sub.sent.Add(1)
+	return nil
 }
 
 // A Subscription is created by a notifier and tied to that notifier. The client can use
@@ -195,6 +222,17 @@ type Subscription struct {
 	ID        ID
 	namespace string
 	err       chan error // closed on unsubscribe
+
+	created time.Time
+	queue   *notificationQueue // nil if notifications are sent synchronously
+	sent    atomic.Uint64      // number of notifications sent
+}
+
+// stop ends the delivery of the queued notifications, if any.
+func (s *Subscription) stop() {
+	if s.queue != nil {
+		s.queue.close(errSubscriptionClosed)
+	}
 }
 
 // Err returns a channel that is closed when the client send an unsubscribe request.
@@ -30,6 +30,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 func TestNewID(t *testing.T) {
@@ -282,3 +283,145 @@ func TestNotify(t *testing.T) {
 		t.Errorf("have:\n%v\nwant:\n%v\n", have, want)
 	}
 }
+
+// blockingConn is a connection whose writes block until it is released.
+type blockingConn struct {
+	mockConn
+	writing chan struct{}
+	release chan struct{}
+	written chan any
+}
+
+func newBlockingConn() *blockingConn {
+	return &blockingConn{
+		writing: make(chan struct{}, 100),
+		release: make(chan struct{}),
+		written: make(chan any, 100),
+	}
+}
+
+func (c *blockingConn) writeJSON(ctx context.Context, msg interface{}, isError bool) error {
+	c.writing <- struct{}{}
+	<-c.release
+	c.written <- msg.(*jsonrpcSubscriptionNotification).Params.Result
+	return nil
+}
+
+// newQueuedNotifier creates an active subscription whose notifications are queued
+// with the given policy, in a queue of two notifications. The first notification
+// is stuck in the blocked connection when it returns.
+func newQueuedNotifier(t *testing.T, policy BackpressurePolicy) (*Notifier, *Subscription, *blockingConn) {
+	conn := newBlockingConn()
+	n := &Notifier{
+		h: &handler{
+			conn:               conn,
+			idgen:              sequentialIDGenerator(),
+			log:                log.Root(),
+			subscriptionBuffer: 2,
+			subscriptionPolicy: policy,
+		},
+		namespace: "eth",
+	}
+	sub := n.CreateSubscription()
+	n.takeSubscription()
+	if err := n.activate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Notify(sub.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	<-conn.writing
+	return n, sub, conn
+}
+
+func TestNotifyBackpressure(t *testing.T) {
+	t.Parallel()
+
+	t.Run("drop-oldest", func(t *testing.T) {
+		n, sub, conn := newQueuedNotifier(t, BackpressureDropOldest)
+		for i := 1; i <= 3; i++ {
+			if err := n.Notify(sub.ID, i); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if depth, _, dropped, _ := sub.queue.stats(); depth != 2 || dropped != 1 {
+			t.Fatalf("wrong queue stats: depth %d, dropped %d", depth, dropped)
+		}
+		close(conn.release)
+		for _, want := range []int{0, 2, 3} {
+			if have := <-conn.written; have != want {
+				t.Fatalf("wrong notification: have %v, want %v", have, want)
+			}
+		}
+	})
+	t.Run("pause", func(t *testing.T) {
+		n, sub, conn := newQueuedNotifier(t, BackpressurePause)
+		for i := 1; i <= 2; i++ {
+			if err := n.Notify(sub.ID, i); err != nil {
+				t.Fatal(err)
+			}
+		}
+		done := make(chan error)
+		go func() { done <- n.Notify(sub.ID, 3) }()
+		select {
+		case <-done:
+			t.Fatal("notify didn't block on full queue")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(conn.release)
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i <= 3; i++ {
+			if have := <-conn.written; have != i {
+				t.Fatalf("wrong notification: have %v, want %v", have, i)
+			}
+		}
+	})
+	t.Run("disconnect", func(t *testing.T) {
+		n, sub, conn := newQueuedNotifier(t, BackpressureDisconnect)
+		defer close(conn.release)
+
+		for i := 1; i <= 2; i++ {
+			if err := n.Notify(sub.ID, i); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := n.Notify(sub.ID, 3); err != ErrSubscriptionQueueFull {
+			t.Fatalf("wrong error on full queue: %v", err)
+		}
+		if err := n.Notify(sub.ID, 4); err != ErrSubscriptionQueueFull {
+			t.Fatalf("wrong error after disconnect: %v", err)
+		}
+	})
+}
+
+func TestServerSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	server.SetSubscriptionLimits(16, BackpressureDropOldest)
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	ch := make(chan int)
+	sub, err := client.Subscribe(context.Background(), "nftest", ch, "someSubscription", 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		<-ch
+	}
+	subs := server.Subscriptions()
+	if len(subs) != 1 {
+		t.Fatalf("wrong number of subscriptions: %d", len(subs))
+	}
+	if subs[0].Namespace != "nftest" || subs[0].QueueDepth != 0 || subs[0].Dropped != 0 {
+		t.Fatalf("wrong subscription info: %+v", subs[0])
+	}
+	sub.Unsubscribe()
+	if subs := server.Subscriptions(); len(subs) != 0 {
+		t.Fatalf("subscriptions left after unsubscribe: %+v", subs)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"time"
@@ -107,6 +109,38 @@ func (s *testService) Block(ctx context.Context) error {
 	return errors.New("context canceled in testservice_block")
 }
 
+// streamResult is a streamed list of the first n integers, failing halfway if
+// requested.
+type streamResult struct {
+	n     int
+	fail  bool
+	delay time.Duration // Time taken to produce each integer
+}
+
+func (r streamResult) WriteStream(ctx context.Context, w io.Writer) error {
+	io.WriteString(w, "[")
+	for i := 0; i < r.n; i++ {
+		if r.fail && i == r.n/2 {
+			return errors.New("stream failed")
+		}
+		if i > 0 {
+			io.WriteString(w, ",")
+		}
+		time.Sleep(r.delay)
+		fmt.Fprint(w, i)
+	}
+	io.WriteString(w, "]")
+	return nil
+}
+
+func (s *testService) Stream(n int, fail bool) StreamWriter {
+	return streamResult{n: n, fail: fail}
+}
+
+func (s *testService) SlowStream(n int, delayMs int) StreamWriter {
+	return streamResult{n: n, delay: time.Duration(delayMs) * time.Millisecond}
+}
+
 func (s *testService) Rets() (string, error) {
 	return "", nil
 }
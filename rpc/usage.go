@@ -0,0 +1,240 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// usageSlotLength is the granularity at which the usage is aggregated.
+	usageSlotLength = time.Minute
+
+	// usageSlots is the number of aggregation slots retained, a day worth.
+	usageSlots = 24 * 60
+
+	// usageMaxEntries is the maximum number of client and method pairs tracked in
+	// a slot, the calls of any further clients are accounted to usageOtherClient.
+	usageMaxEntries = 4096
+
+	// usageOtherClient is the client the calls are accounted to once a slot is full.
+	usageOtherClient = "other"
+
+	// usageMetricsName is the prefix of the usage metrics.
+	usageMetricsName = "rpc/usage"
+)
+
+// UsageStats is the execution cost of the calls made to a method by a client.
+type UsageStats struct {
+	Client  string        `json:"client"`
+	Method  string        `json:"method"`
+	Calls   uint64        `json:"calls"`
+	Errors  uint64        `json:"errors"`
+	Time    time.Duration `json:"time"`    // Time spent executing the calls, in nanoseconds
+	DBReads uint64        `json:"dbReads"` // Database reads reported by the method
+	Bytes   uint64        `json:"bytes"`   // Size of the returned results
+}
+
+// add accumulates the given stats.
+func (s *UsageStats) add(other *UsageStats) {
+	s.Calls += other.Calls
+	s.Errors += other.Errors
+	s.Time += other.Time
+	s.DBReads += other.DBReads
+	s.Bytes += other.Bytes
+}
+
+type usageKey struct {
+	client string
+	method string
+}
+
+// usageSlot is the usage aggregated over a slot of time.
+type usageSlot struct {
+	start int64 // Index of the slot since the epoch
+	stats map[usageKey]*UsageStats
+}
+
+// UsageTracker accounts the execution cost of the method calls served by the
+// servers it's attached to, aggregated per method and client over the last day.
+//
+// Clients are identified by the identity asserted to the authenticated endpoints,
+// or by their remote host otherwise. The database reads are those reported by the
+// methods through AddDBReads and TrackDBReads.
+type UsageTracker struct {
+	lock  sync.Mutex
+	slots [usageSlots]usageSlot
+
+	now func() time.Time // Overridable time source for testing
+}
+
+// NewUsageTracker creates an empty usage tracker.
+func NewUsageTracker() *UsageTracker {
+	return &UsageTracker{now: time.Now}
+}
+
+// Usage returns the execution costs accumulated since the given time, or over
+// the whole retention period if zero. The stats are sorted by client and method.
+func (t *UsageTracker) Usage(since time.Time) []UsageStats {
+	var (
+		first = int64(math.MinInt64)
+		total = make(map[usageKey]*UsageStats)
+	)
+	if !since.IsZero() {
+		first = since.UnixNano() / int64(usageSlotLength)
+	}
+	t.lock.Lock()
+	// Skip the slots out of the retention period not overwritten yet
+	first = max(first, t.now().UnixNano()/int64(usageSlotLength)-usageSlots+1)
+	for i := range t.slots {
+		slot := &t.slots[i]
+		if slot.stats == nil || slot.start < first {
+			continue
+		}
+		for key, stats := range slot.stats {
+			if total[key] == nil {
+				total[key] = &UsageStats{Client: key.client, Method: key.method}
+			}
+			total[key].add(stats)
+		}
+	}
+	t.lock.Unlock()
+
+	result := make([]UsageStats, 0, len(total))
+	for _, stats := range total {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Client != result[j].Client {
+			return result[i].Client < result[j].Client
+		}
+		return result[i].Method < result[j].Method
+	})
+	return result
+}
+
+// record accounts a method call served to the given client.
+func (t *UsageTracker) record(info PeerInfo, method string, failed bool, elapsed time.Duration, reads uint64, bytes int) {
+	call := &UsageStats{
+		Calls:   1,
+		Time:    elapsed,
+		DBReads: reads,
+		Bytes:   uint64(bytes),
+	}
+	if failed {
+		call.Errors = 1
+	}
+	key := usageKey{client: usageClient(info), method: method}
+
+	t.lock.Lock()
+	start := t.now().UnixNano() / int64(usageSlotLength)
+	slot := &t.slots[start%usageSlots]
+	if slot.stats == nil || slot.start != start {
+		slot.start, slot.stats = start, make(map[usageKey]*UsageStats)
+	}
+	stats := slot.stats[key]
+	if stats == nil {
+		if len(slot.stats) >= usageMaxEntries {
+			key.client = usageOtherClient
+			stats = slot.stats[key]
+		}
+		if stats == nil {
+			stats = new(UsageStats)
+			slot.stats[key] = stats
+		}
+	}
+	stats.add(call)
+	t.lock.Unlock()
+
+	updateUsageMetrics(fmt.Sprintf("%s/%s", usageMetricsName, method), call)
+	if info.AuthID != "" {
+		updateUsageMetrics(fmt.Sprintf("%s/client/%s", usageMetricsName, info.AuthID), call)
+	}
+}
+
+// updateUsageMetrics adds the cost of a call to the usage counters under the
+// given prefix.
+func updateUsageMetrics(prefix string, call *UsageStats) {
+	metrics.GetOrRegisterCounter(prefix+"/time", nil).Inc(int64(call.Time))
+	metrics.GetOrRegisterCounter(prefix+"/dbreads", nil).Inc(int64(call.DBReads))
+	metrics.GetOrRegisterCounter(prefix+"/bytes", nil).Inc(int64(call.Bytes))
+}
+
+// usageClient returns the key the calls of a client are accounted to.
+func usageClient(info PeerInfo) string {
+	if info.AuthID != "" {
+		return info.AuthID
+	}
+	if host, _, err := net.SplitHostPort(info.RemoteAddr); err == nil {
+		return host
+	}
+	if info.RemoteAddr != "" {
+		return info.RemoteAddr
+	}
+	return info.Transport
+}
+
+type callUsageKey struct{}
+
+// callUsage collects the costs of a method call reported by the method itself.
+type callUsage struct {
+	reads atomic.Uint64
+
+	lock     sync.Mutex
+	counters []func() int
+}
+
+// dbReads returns the database reads reported during the call.
+func (u *callUsage) dbReads() uint64 {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	reads := u.reads.Load()
+	for _, counter := range u.counters {
+		reads += uint64(counter())
+	}
+	return reads
+}
+
+// AddDBReads reports database reads done on behalf of the method call the context
+// belongs to. It is a noop if the call is not accounted.
+func AddDBReads(ctx context.Context, n int) {
+	if usage, ok := ctx.Value(callUsageKey{}).(*callUsage); ok {
+		usage.reads.Add(uint64(n))
+	}
+}
+
+// TrackDBReads registers a counter of the database reads done on behalf of the
+// method call the context belongs to, which is evaluated once the call finished.
+// It is meant for resources used beyond the function handing them out, such as
+// a state database. It is a noop if the call is not accounted.
+func TrackDBReads(ctx context.Context, counter func() int) {
+	if usage, ok := ctx.Value(callUsageKey{}).(*callUsage); ok {
+		usage.lock.Lock()
+		usage.counters = append(usage.counters, counter)
+		usage.lock.Unlock()
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type usageTestService struct{}
+
+func (usageTestService) Read(ctx context.Context, n int) string {
+	AddDBReads(ctx, n)
+	reads := 0
+	TrackDBReads(ctx, func() int { return reads })
+	reads = n // reported after the call
+	return "0123456789"
+}
+
+func (usageTestService) Fail(ctx context.Context) error {
+	AddDBReads(ctx, 1)
+	return fmt.Errorf("failed")
+}
+
+// Tests that the cost of the method calls is accounted per method and client.
+func TestUsageTracker(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server  = NewServer()
+		tracker = NewUsageTracker()
+		now     = time.Unix(1_700_000_000, 0)
+	)
+	tracker.now = func() time.Time { return now }
+	server.SetUsageTracker(tracker)
+	if err := server.RegisterName("usage", usageTestService{}); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	client := DialInProc(server)
+	defer client.Close()
+
+	var res string
+	for i := 0; i < 2; i++ {
+		if err := client.Call(&res, "usage_read", 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.Call(&res, "usage_fail"); err == nil {
+		t.Fatal("expected failure")
+	}
+	// Calls of later slots are only reported if requested
+	now = now.Add(2 * usageSlotLength)
+	if err := client.Call(&res, "usage_read", 5); err != nil {
+		t.Fatal(err)
+	}
+	usage := tracker.Usage(time.Time{})
+	if len(usage) != 2 {
+		t.Fatalf("wrong number of stats: have %d, want 2", len(usage))
+	}
+	fail, read := usage[0], usage[1]
+	if fail.Client != "ipc" || fail.Method != "usage_fail" || fail.Calls != 1 || fail.Errors != 1 || fail.DBReads != 1 {
+		t.Errorf("wrong failure stats: %+v", fail)
+	}
+	if read.Method != "usage_read" || read.Calls != 3 || read.Errors != 0 || read.DBReads != 22 || read.Bytes != 36 || read.Time <= 0 {
+		t.Errorf("wrong read stats: %+v", read)
+	}
+	usage = tracker.Usage(now)
+	if len(usage) != 1 || usage[0].Calls != 1 || usage[0].DBReads != 10 {
+		t.Errorf("wrong recent stats: %+v", usage)
+	}
+	// Calls older than a day are dropped once their slot is reused
+	now = now.Add(usageSlots * usageSlotLength)
+	if err := client.Call(&res, "usage_read", 1); err != nil {
+		t.Fatal(err)
+	}
+	usage = tracker.Usage(time.Time{})
+	if len(usage) != 1 || usage[0].Calls != 1 || usage[0].DBReads != 2 {
+		t.Errorf("wrong stats after retention: %+v", usage)
+	}
+}
+
+// Tests that the clients beyond the limit of a slot are accounted together.
+func TestUsageTrackerOverflow(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewUsageTracker()
+	for i := 0; i < usageMaxEntries+10; i++ {
+		info := PeerInfo{RemoteAddr: fmt.Sprintf("10.0.%d.%d:30303", i/256, i%256)}
+		tracker.record(info, "eth_call", false, time.Millisecond, 0, 1)
+	}
+	usage := tracker.Usage(time.Time{})
+	if len(usage) != usageMaxEntries+1 {
+		t.Fatalf("wrong number of stats: have %d, want %d", len(usage), usageMaxEntries+1)
+	}
+	for _, stats := range usage {
+		if stats.Client == usageOtherClient {
+			if stats.Calls != 10 {
+				t.Fatalf("wrong overflow calls: have %d, want 10", stats.Calls)
+			}
+			return
+		}
+	}
+	t.Fatal("overflow client missing")
+}
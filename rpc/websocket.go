@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"os"
@@ -61,6 +62,8 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			return
 		}
 		codec := newWebsocketCodec(conn, r.Host, r.Header, wsDefaultReadLimit)
+		codec.(*websocketCodec).info.AuthID = authIDFromContext(r.Context())
+		codec.(*websocketCodec).info.AuthCredentials = authCredentialsFromContext(r.Context())
 		s.ServeCodec(codec, 0)
 	})
 }
@@ -306,6 +309,8 @@ func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header, readL
 			RemoteAddr: conn.RemoteAddr().String(),
 		},
 	}
+	wc.jsonCodec.stream = func() (io.WriteCloser, error) { return conn.NextWriter(websocket.TextMessage) }
+
 	// Fill in connection details.
 	wc.info.HTTP.Host = host
 	wc.info.HTTP.Origin = req.Get("Origin")
@@ -19,6 +19,7 @@ package trie
 import (
 	"errors"
 	"fmt"
+	"runtime"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -45,6 +46,10 @@ var ErrAlreadyProcessed = errors.New("already processed")
 // memory if the node was configured with a significant number of peers.
 const maxFetchesPerDepth = 16384
 
+// processNodeWorkers is the number of goroutines used to resolve a batch of
+// trie nodes delivered to ProcessNodes.
+var processNodeWorkers = runtime.NumCPU()
+
 var (
 	// deletionGauge is the metric to track how many trie node deletions
 	// are performed in total during the sync process.
@@ -424,27 +429,62 @@ func (s *Sync) ProcessNode(result NodeSyncResult) error {
 	if req.data != nil {
 		return ErrAlreadyProcessed
 	}
-	// Decode the node data content and update the request
-	node, err := decodeNode(req.hash.Bytes(), result.Data)
+	// Decode the node data content and look up its children
+	res, err := s.resolve(req, result.Data)
 	if err != nil {
 		return err
 	}
-	req.data = result.Data
+	return s.apply(req, result.Data, res)
+}
 
-	// Create and schedule a request for all the children nodes
-	requests, err := s.children(req, node)
-	if err != nil {
-		return err
+// ProcessNodes injects a batch of received trie nodes, returning the outcome of
+// each in the order of the results. It's equivalent to calling ProcessNode on
+// the results one by one, but the nodes are decoded and their children looked
+// up in the database concurrently. Only the scheduler updates are sequential.
+func (s *Sync) ProcessNodes(results []NodeSyncResult) []error {
+	var (
+		errs     = make([]error, len(results))
+		reqs     = make([]*nodeRequest, len(results))
+		resolved = make([]*resolvedNode, len(results))
+		jobs     = make(chan int, len(results))
+		pending  sync.WaitGroup
+	)
+	for i, result := range results {
+		if req := s.nodeReqs[result.Path]; req != nil && req.data == nil {
+			reqs[i] = req
+			jobs <- i
+		}
 	}
-	if len(requests) == 0 && req.deps == 0 {
-		s.commitNodeRequest(req)
-	} else {
-		req.deps += len(requests)
-		for _, child := range requests {
-			s.scheduleNodeRequest(child)
+	close(jobs)
+
+	for i := 0; i < min(processNodeWorkers, len(jobs)); i++ {
+		pending.Add(1)
+		go func() {
+			defer pending.Done()
+			for j := range jobs {
+				resolved[j], errs[j] = s.resolve(reqs[j], results[j].Data)
+			}
+		}()
+	}
+	pending.Wait()
+
+	// Feed the resolved nodes into the scheduler in order. The request set may
+	// have changed by any previous node in the batch, so recheck the request
+	// and resolve it in place if it was not resolved up front.
+	for i, result := range results {
+		req := s.nodeReqs[result.Path]
+		switch {
+		case req == nil:
+			errs[i] = ErrNotRequested
+		case req.data != nil:
+			errs[i] = ErrAlreadyProcessed
+		case req != reqs[i]:
+			errs[i] = s.ProcessNode(result)
+		case errs[i] == nil:
+			errs[i] = s.apply(req, result.Data, resolved[i])
 		}
 	}
-	return nil
+	return errs
 }
 
 // Commit flushes the data stored in the internal membatch out to persistent
@@ -535,23 +575,45 @@ func (s *Sync) scheduleCodeRequest(req *codeRequest) {
 	s.queue.Push(req.hash, prio)
 }
 
-// children retrieves all the missing children of a state trie entry for future
-// retrieval scheduling.
-func (s *Sync) children(req *nodeRequest, object node) ([]*nodeRequest, error) {
-	// Gather all the children of the node, irrelevant whether known or not
-	type childNode struct {
-		path []byte
-		node node
+// childNode is a child of a trie node along with its full path.
+type childNode struct {
+	path []byte
+	node node
+}
+
+// nodeLocation identifies a trie node in the database.
+type nodeLocation struct {
+	owner common.Hash
+	path  []byte
+}
+
+// resolvedNode is a delivered trie node, decoded and cross-referenced with the
+// database. Resolving doesn't touch the scheduler state, so multiple nodes can
+// be resolved concurrently.
+type resolvedNode struct {
+	children []childNode    // All the children of the node, irrelevant whether known or not
+	dangling []nodeLocation // Nodes on disk made unreachable by the node, to be deleted
+	stale    []nodeLocation // Nodes on disk with the wrong hash, to be deleted
+	missing  []*nodeRequest // Children missing locally, to be scheduled for retrieval
+}
+
+// resolve decodes the data of a requested trie node and checks which of its
+// children are already present in the database.
+func (s *Sync) resolve(req *nodeRequest, data []byte) (*resolvedNode, error) {
+	object, err := decodeNode(req.hash.Bytes(), data)
+	if err != nil {
+		return nil, err
 	}
-	var children []childNode
+	res := new(resolvedNode)
 
+	// Gather all the children of the node, irrelevant whether known or not
 	switch node := (object).(type) {
 	case *shortNode:
 		key := node.Key
 		if hasTerm(key) {
 			key = key[:len(key)-1]
 		}
-		children = []childNode{{
+		res.children = []childNode{{
 			node: node.Val,
 			path: append(append([]byte(nil), req.path...), key...),
 		}}
@@ -577,15 +639,18 @@ func (s *Sync) children(req *nodeRequest, object node) ([]*nodeRequest, error) {
 				// While checking for a non-existent item in Pebble can be less efficient
 				// without a bloom filter, the relatively low frequency of lookups makes
 				// the performance impact negligible.
-				var exists bool
+				var (
+					path   = append(append([]byte(nil), inner...), key[:i]...)
+					exists bool
+				)
 				if owner == (common.Hash{}) {
-					exists = rawdb.HasAccountTrieNode(s.database, append(inner, key[:i]...))
+					exists = rawdb.HasAccountTrieNode(s.database, path)
 				} else {
-					exists = rawdb.HasStorageTrieNode(s.database, owner, append(inner, key[:i]...))
+					exists = rawdb.HasStorageTrieNode(s.database, owner, path)
 				}
 				if exists {
-					s.membatch.delNode(owner, append(inner, key[:i]...))
-					log.Debug("Detected dangling node", "owner", owner, "path", append(inner, key[:i]...))
+					res.dangling = append(res.dangling, nodeLocation{owner: owner, path: path})
+					log.Debug("Detected dangling node", "owner", owner, "path", path)
 				}
 			}
 			lookupGauge.Inc(int64(len(key) - 1))
@@ -593,7 +658,7 @@ func (s *Sync) children(req *nodeRequest, object node) ([]*nodeRequest, error) {
 	case *fullNode:
 		for i := 0; i < 17; i++ {
 			if node.Children[i] != nil {
-				children = append(children, childNode{
+				res.children = append(res.children, childNode{
 					node: node.Children[i],
 					path: append(append([]byte(nil), req.path...), byte(i)),
 				})
@@ -602,68 +667,89 @@ func (s *Sync) children(req *nodeRequest, object node) ([]*nodeRequest, error) {
 	default:
 		panic(fmt.Sprintf("unknown node: %+v", node))
 	}
-	// Iterate over the children, and request all unknown ones
+	// Check all the children referencing other nodes concurrently
 	var (
-		missing = make(chan *nodeRequest, len(children))
+		missing = make([]*nodeRequest, len(res.children))
+		stale   = make([]bool, len(res.children))
 		pending sync.WaitGroup
-		batchMu sync.Mutex
 	)
-	for _, child := range children {
-		// Notify any external watcher of a new key/value node
-		if req.callback != nil {
-			if node, ok := (child.node).(valueNode); ok {
-				var paths [][]byte
-				if len(child.path) == 2*common.HashLength {
-					paths = append(paths, hexToKeybytes(child.path))
-				} else if len(child.path) == 4*common.HashLength {
-					paths = append(paths, hexToKeybytes(child.path[:2*common.HashLength]))
-					paths = append(paths, hexToKeybytes(child.path[2*common.HashLength:]))
-				}
-				if err := req.callback(paths, child.path, node, req.hash, req.path); err != nil {
-					return nil, err
-				}
-			}
-		}
-		// If the child references another node, resolve or schedule.
-		// We check all children concurrently.
+	for i, child := range res.children {
 		if node, ok := (child.node).(hashNode); ok {
-			path := child.path
-			hash := common.BytesToHash(node)
 			pending.Add(1)
-			go func() {
+			go func(i int, path []byte, hash common.Hash) {
 				defer pending.Done()
 				owner, inner := ResolvePath(path)
 				exist, inconsistent := s.hasNode(owner, inner, hash)
 				if exist {
 					return
-				} else if inconsistent {
-					// There is a pre-existing node with the wrong hash in DB, remove it.
-					batchMu.Lock()
-					s.membatch.delNode(owner, inner)
-					batchMu.Unlock()
 				}
-				// Locally unknown node, schedule for retrieval
-				missing <- &nodeRequest{
+				// There may be a pre-existing node with the wrong hash in DB,
+				// which needs to be removed. Either way, the node is locally
+				// unknown, schedule for retrieval.
+				stale[i] = inconsistent
+				missing[i] = &nodeRequest{
 					path:     path,
 					hash:     hash,
 					parent:   req,
 					callback: req.callback,
 				}
-			}()
+			}(i, child.path, common.BytesToHash(node))
 		}
 	}
 	pending.Wait()
 
-	requests := make([]*nodeRequest, 0, len(children))
-	for done := false; !done; {
-		select {
-		case miss := <-missing:
-			requests = append(requests, miss)
-		default:
-			done = true
+	for i, req := range missing {
+		if req == nil {
+			continue
+		}
+		if stale[i] {
+			owner, inner := ResolvePath(req.path)
+			res.stale = append(res.stale, nodeLocation{owner: owner, path: inner})
 		}
+		res.missing = append(res.missing, req)
 	}
-	return requests, nil
+	return res, nil
+}
+
+// apply stores the data of a requested trie node resolved earlier, notifies
+// the leaf callback about the values it holds and schedules its missing
+// children for retrieval.
+func (s *Sync) apply(req *nodeRequest, data []byte, res *resolvedNode) error {
+	req.data = data
+
+	for _, loc := range res.dangling {
+		s.membatch.delNode(loc.owner, loc.path)
+	}
+	// Notify any external watcher of a new key/value node
+	if req.callback != nil {
+		for _, child := range res.children {
+			if node, ok := (child.node).(valueNode); ok {
+				var paths [][]byte
+				if len(child.path) == 2*common.HashLength {
+					paths = append(paths, hexToKeybytes(child.path))
+				} else if len(child.path) == 4*common.HashLength {
+					paths = append(paths, hexToKeybytes(child.path[:2*common.HashLength]))
+					paths = append(paths, hexToKeybytes(child.path[2*common.HashLength:]))
+				}
+				if err := req.callback(paths, child.path, node, req.hash, req.path); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, loc := range res.stale {
+		s.membatch.delNode(loc.owner, loc.path)
+	}
+	// Create and schedule a request for all the children nodes
+	if len(res.missing) == 0 && req.deps == 0 {
+		s.commitNodeRequest(req)
+	} else {
+		req.deps += len(res.missing)
+		for _, child := range res.missing {
+			s.scheduleNodeRequest(child)
+		}
+	}
+	return nil
 }
 
 // commitNodeRequest finalizes a retrieval request and stores it into the membatch. If any
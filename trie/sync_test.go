@@ -232,6 +232,64 @@ func testIterativeSync(t *testing.T, count int, bypath bool, scheme string) {
 	checkTrieContents(t, diskdb, srcDb.Scheme(), srcTrie.Hash().Bytes(), srcData, false)
 }
 
+// Tests that the trie scheduler can reconstruct the state if the nodes are
+// processed in batches, and that duplicate or unrequested nodes in a batch are
+// reported the same way as if they were processed one by one.
+func TestBatchedSync(t *testing.T) {
+	testBatchedSync(t, rawdb.HashScheme)
+	testBatchedSync(t, rawdb.PathScheme)
+}
+
+func testBatchedSync(t *testing.T, scheme string) {
+	// Create a random trie to copy
+	_, srcDb, srcTrie, srcData := makeTestTrie(scheme)
+
+	// Create a destination trie and sync with the scheduler
+	diskdb := rawdb.NewMemoryDatabase()
+	sched := NewSync(srcTrie.Hash(), diskdb, nil, srcDb.Scheme())
+
+	reader, err := srcDb.NodeReader(srcTrie.Hash())
+	if err != nil {
+		t.Fatalf("State is not available %x", srcTrie.Hash())
+	}
+	paths, nodes, _ := sched.Missing(10000)
+	for len(paths) > 0 {
+		results := make([]NodeSyncResult, len(paths))
+		for i, path := range paths {
+			owner, inner := ResolvePath([]byte(path))
+			data, err := reader.Node(owner, inner, nodes[i])
+			if err != nil {
+				t.Fatalf("failed to retrieve node data for hash %x: %v", nodes[i], err)
+			}
+			results[i] = NodeSyncResult{path, data}
+		}
+		// Deliver the first node twice and an unrequested one too
+		results = append(results, results[0], NodeSyncResult{"unrequested", results[0].Data})
+
+		errs := sched.ProcessNodes(results)
+		for i, err := range errs[:len(paths)] {
+			if err != nil {
+				t.Fatalf("failed to process result %d: %v", i, err)
+			}
+		}
+		if err := errs[len(paths)]; err != ErrAlreadyProcessed && err != ErrNotRequested {
+			t.Fatalf("duplicate result error mismatch: have %v, want %v or %v", err, ErrAlreadyProcessed, ErrNotRequested)
+		}
+		if err := errs[len(paths)+1]; err != ErrNotRequested {
+			t.Fatalf("unrequested result error mismatch: have %v, want %v", err, ErrNotRequested)
+		}
+		batch := diskdb.NewBatch()
+		if err := sched.Commit(batch); err != nil {
+			t.Fatalf("failed to commit data: %v", err)
+		}
+		batch.Write()
+
+		paths, nodes, _ = sched.Missing(10000)
+	}
+	// Cross check that the two tries are in sync
+	checkTrieContents(t, diskdb, srcDb.Scheme(), srcTrie.Hash().Bytes(), srcData, false)
+}
+
 // Tests that the trie scheduler can correctly reconstruct the state even if only
 // partial results are returned, and the others sent only later.
 func TestIterativeDelayedSync(t *testing.T) {
@@ -229,6 +229,29 @@ func (db *Database) Cap(limit common.StorageSize) error {
 	return hdb.Cap(limit)
 }
 
+// ResizeCleanCache changes the capacity of the clean trie node cache to the
+// given number of bytes.
+func (db *Database) ResizeCleanCache(size int) {
+	switch b := db.backend.(type) {
+	case *hashdb.Database:
+		b.ResizeCleanCache(size)
+	case *pathdb.Database:
+		b.ResizeCleanCache(size)
+	}
+}
+
+// CleanCacheStats returns the number of lookups which hit and missed the clean
+// trie node cache.
+func (db *Database) CleanCacheStats() (hits, misses uint64) {
+	switch b := db.backend.(type) {
+	case *hashdb.Database:
+		return b.CleanCacheStats()
+	case *pathdb.Database:
+		return b.CleanCacheStats()
+	}
+	return 0, 0
+}
+
 // Reference adds a new reference from a parent node to a child node. This function
 // is used to add reference between internal trie node and external node(e.g. storage
 // trie root), all internal trie nodes are referenced together by database itself.
@@ -23,8 +23,8 @@ import (
 	"sync"
 	"time"
 
-	"github.com/VictoriaMetrics/fastcache"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/cachemgr"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -79,7 +79,7 @@ var Defaults = &Config{
 // periodically flush a couple tries to disk, garbage collecting the remainder.
 type Database struct {
 	diskdb  ethdb.Database              // Persistent storage for matured trie nodes
-	cleans  *fastcache.Cache            // GC friendly memory cache of clean node RLPs
+	cleans  *cachemgr.Cache             // GC friendly memory cache of clean node RLPs
 	dirties map[common.Hash]*cachedNode // Data and references relationships of dirty trie nodes
 	oldest  common.Hash                 // Oldest tracked node, flush-list head
 	newest  common.Hash                 // Newest tracked node, flush-list tail
@@ -128,9 +128,9 @@ func New(diskdb ethdb.Database, config *Config) *Database {
 	if config == nil {
 		config = Defaults
 	}
-	var cleans *fastcache.Cache
+	var cleans *cachemgr.Cache
 	if config.CleanCacheSize > 0 {
-		cleans = fastcache.New(config.CleanCacheSize)
+		cleans = cachemgr.NewCache(config.CleanCacheSize)
 	}
 	return &Database{
 		diskdb:  diskdb,
@@ -601,6 +601,23 @@ func (db *Database) Size() (common.StorageSize, common.StorageSize) {
 	return 0, db.dirtiesSize + db.childrenSize + metadataSize
 }
 
+// ResizeCleanCache changes the capacity of the clean node cache to the given
+// number of bytes. It's a noop if the clean cache is disabled.
+func (db *Database) ResizeCleanCache(size int) {
+	if db.cleans != nil {
+		db.cleans.Resize(size)
+	}
+}
+
+// CleanCacheStats returns the number of lookups which hit and missed the clean
+// node cache.
+func (db *Database) CleanCacheStats() (hits, misses uint64) {
+	if db.cleans == nil {
+		return 0, 0
+	}
+	return db.cleans.Stats()
+}
+
 // Close closes the trie database and releases all held resources.
 func (db *Database) Close() error {
 	if db.cleans != nil {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -114,6 +115,8 @@ type Config struct {
 	CleanCacheSize  int    // Maximum memory allowance (in bytes) for caching clean nodes
 	WriteBufferSize int    // Maximum memory allowance (in bytes) for write buffer
 	ReadOnly        bool   // Flag whether the database is opened in read only mode.
+	WriteAheadLog   bool   // Flag whether the diff layers are recorded for crash recovery
+	CompressHistory bool   // Flag whether the state histories are written compressed
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -136,6 +139,12 @@ func (c *Config) fields() []interface{} {
 	list = append(list, "cache", common.StorageSize(c.CleanCacheSize))
 	list = append(list, "buffer", common.StorageSize(c.WriteBufferSize))
 	list = append(list, "history", c.StateHistory)
+	if c.WriteAheadLog {
+		list = append(list, "wal", true)
+	}
+	if c.CompressHistory {
+		list = append(list, "compress-history", true)
+	}
 	return list
 }
 
@@ -197,7 +206,16 @@ type Database struct {
 	diskdb  ethdb.Database               // Persistent storage for matured trie nodes
 	tree    *layerTree                   // The group for all known layers
 	freezer ethdb.ResettableAncientStore // Freezer for storing trie histories, nil possible in tests
+	wal     *writeAheadLog               // Write-ahead log of the diff layers, nil if disabled
 	lock    sync.RWMutex                 // Lock to prevent mutations from happening at the same time
+
+	lastHistory atomic.Pointer[storedHistory] // Last written state history, base of the next compressed one
+}
+
+// storedHistory is a state history along with its id.
+type storedHistory struct {
+	id      uint64
+	history *history
 }
 
 // New attempts to load an already existing layer from a persistent key-value
@@ -229,6 +247,10 @@ func New(diskdb ethdb.Database, config *Config, isVerkle bool) *Database {
 	// and in-memory layer journal.
 	db.tree = newLayerTree(db.loadLayers())
 
+	// Replay the diff layers recorded in the write-ahead log, which are lost
+	// from the journal due to an unclean shutdown.
+	db.replayWAL()
+
 	// Repair the state history, which might not be aligned with the state
 	// in the key-value store due to an unclean shutdown.
 	if err := db.repairHistory(); err != nil {
@@ -318,12 +340,23 @@ func (db *Database) Update(root common.Hash, parentRoot common.Hash, block uint6
 	if err := db.tree.add(root, parentRoot, block, nodes, states); err != nil {
 		return err
 	}
+	if db.wal != nil {
+		if err := db.writeWAL(root, parentRoot); err != nil {
+			return err
+		}
+	}
 	// Keep 128 diff layers in the memory, persistent layer is 129th.
 	// - head layer is paired with HEAD state
 	// - head-1 layer is paired with HEAD-1 state
 	// - head-127 layer(bottom-most diff layer) is paired with HEAD-127 state
 	// - head-128 layer(disk layer) is paired with HEAD-128 state
-	return db.tree.cap(root, maxDiffLayers)
+	if err := db.tree.cap(root, maxDiffLayers); err != nil {
+		return err
+	}
+	if db.wal != nil {
+		return db.truncateWAL()
+	}
+	return nil
 }
 
 // Commit traverses downwards the layer tree from a specified layer with the
@@ -338,7 +371,13 @@ func (db *Database) Commit(root common.Hash, report bool) error {
 	if err := db.modifyAllowed(); err != nil {
 		return err
 	}
-	return db.tree.cap(root, 0)
+	if err := db.tree.cap(root, 0); err != nil {
+		return err
+	}
+	if db.wal != nil {
+		return db.truncateWAL()
+	}
+	return nil
 }
 
 // Disable deactivates the database and invalidates all available state layers
@@ -394,6 +433,9 @@ func (db *Database) Enable(root common.Hash) error {
 	if err := batch.Write(); err != nil {
 		return err
 	}
+	if err := db.clearWAL(); err != nil {
+		return err
+	}
 	// Clean up all state histories in freezer. Theoretically
 	// all root->id mappings should be removed as well. Since
 	// mappings can be huge and might take a while to clear
@@ -402,6 +444,7 @@ func (db *Database) Enable(root common.Hash) error {
 		if err := db.freezer.Reset(); err != nil {
 			return err
 		}
+		db.lastHistory.Store(nil)
 	}
 	// Re-construct a new disk layer backed by persistent state
 	// with **empty clean cache and node buffer**.
@@ -454,10 +497,14 @@ func (db *Database) Recover(root common.Hash) error {
 		db.tree.reset(dl)
 	}
 	rawdb.DeleteTrieJournal(db.diskdb)
+	if err := db.clearWAL(); err != nil {
+		return err
+	}
 	_, err := truncateFromHead(db.diskdb, db.freezer, dl.stateID())
 	if err != nil {
 		return err
 	}
+	db.lastHistory.Store(nil)
 	log.Debug("Recovered state", "root", root, "elapsed", common.PrettyDuration(time.Since(start)))
 	return nil
 }
@@ -529,6 +576,30 @@ func (db *Database) Size() (diffs common.StorageSize, nodes common.StorageSize)
 	return diffs, nodes
 }
 
+// ResizeCleanCache changes the capacity of the clean node cache to the given
+// number of bytes. It's a noop if the clean cache is disabled.
+func (db *Database) ResizeCleanCache(size int) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.config.CleanCacheSize == 0 {
+		return
+	}
+	db.config.CleanCacheSize = size // Capacity of the caches of future disk layers
+	if dl := db.tree.bottom(); dl != nil && dl.nodes != nil {
+		dl.nodes.Resize(size)
+	}
+}
+
+// CleanCacheStats returns the number of lookups which hit and missed the clean
+// node cache. The counters restart if the cache is recreated.
+func (db *Database) CleanCacheStats() (hits, misses uint64) {
+	if dl := db.tree.bottom(); dl != nil && dl.nodes != nil {
+		return dl.nodes.Stats()
+	}
+	return 0, 0
+}
+
 // modifyAllowed returns the indicator if mutation is allowed. This function
 // assumes the db.lock is already held.
 func (db *Database) modifyAllowed() error {
@@ -658,6 +658,68 @@ func TestCorruptedJournal(t *testing.T) {
 	}
 }
 
+func TestWriteAheadLog(t *testing.T) {
+	// Redefine the diff layer depth allowance for faster testing.
+	maxDiffLayers = 4
+	defer func() {
+		maxDiffLayers = 128
+	}()
+
+	for _, bufferSize := range []int{256 * 1024, 0} {
+		config := &Config{
+			CleanCacheSize:  256 * 1024,
+			WriteBufferSize: bufferSize,
+			WriteAheadLog:   true,
+		}
+		tester := newTester(t, 0, false, 0)
+		tester.db.Close()
+		tester.db = New(tester.db.diskdb, config, false)
+
+		for i := 0; i < 12; i++ {
+			parent := types.EmptyRootHash
+			if len(tester.roots) != 0 {
+				parent = tester.roots[len(tester.roots)-1]
+			}
+			root, nodes, states := tester.generate(parent, i > 6)
+			if err := tester.db.Update(root, parent, uint64(i), nodes, states); err != nil {
+				t.Fatalf("Failed to update state changes, err: %v", err)
+			}
+			tester.roots = append(tester.roots, root)
+		}
+		// Simulate a crash by closing the database without journaling, all the
+		// layers above the persistent state should be replayed.
+		tester.db.Close()
+		tester.db = New(tester.db.diskdb, config, false)
+
+		for i := tester.bottomIndex(); i < len(tester.roots); i++ {
+			if err := tester.verifyState(tester.roots[i]); err != nil {
+				t.Fatalf("Invalid state, buffer: %d, index: %d, err: %v", bufferSize, i, err)
+			}
+		}
+		// Reopening with the write-ahead log disabled replays the layers once
+		// more and clears the log.
+		tester.db.Close()
+		tester.db = New(tester.db.diskdb, &Config{WriteBufferSize: bufferSize}, false)
+		if err := tester.verifyState(tester.lastHash()); err != nil {
+			t.Fatalf("Invalid state, buffer: %d, err: %v", bufferSize, err)
+		}
+		var entries int
+		rawdb.IterateTrieWAL(tester.db.diskdb, func(uint64, []byte) error {
+			entries++
+			return nil
+		})
+		if entries != 0 {
+			t.Fatalf("Unexpected write-ahead log entries, buffer: %d, got: %d", bufferSize, entries)
+		}
+		tester.db.Close()
+		tester.db = New(tester.db.diskdb, &Config{WriteBufferSize: bufferSize}, false)
+		if err := tester.verifyState(tester.lastHash()); err == nil {
+			t.Fatal("Unexpected state")
+		}
+		tester.release()
+	}
+}
+
 // TestTailTruncateHistory function is designed to test a specific edge case where,
 // when history objects are removed from the end, it should trigger a state flush
 // if the ID of the new tail object is even higher than the persisted state ID.
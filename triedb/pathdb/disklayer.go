@@ -21,8 +21,8 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/VictoriaMetrics/fastcache"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/cachemgr"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
@@ -30,22 +30,22 @@ import (
 
 // diskLayer is a low level persistent layer built on top of a key-value store.
 type diskLayer struct {
-	root   common.Hash      // Immutable, root hash to which this layer was made for
-	id     uint64           // Immutable, corresponding state id
-	db     *Database        // Path-based trie database
-	nodes  *fastcache.Cache // GC friendly memory cache of clean nodes
-	buffer *buffer          // Dirty buffer to aggregate writes of nodes and states
-	stale  bool             // Signals that the layer became stale (state progressed)
-	lock   sync.RWMutex     // Lock used to protect stale flag
+	root   common.Hash     // Immutable, root hash to which this layer was made for
+	id     uint64          // Immutable, corresponding state id
+	db     *Database       // Path-based trie database
+	nodes  *cachemgr.Cache // GC friendly memory cache of clean nodes
+	buffer *buffer         // Dirty buffer to aggregate writes of nodes and states
+	stale  bool            // Signals that the layer became stale (state progressed)
+	lock   sync.RWMutex    // Lock used to protect stale flag
 }
 
 // newDiskLayer creates a new disk layer based on the passing arguments.
-func newDiskLayer(root common.Hash, id uint64, db *Database, nodes *fastcache.Cache, buffer *buffer) *diskLayer {
+func newDiskLayer(root common.Hash, id uint64, db *Database, nodes *cachemgr.Cache, buffer *buffer) *diskLayer {
 	// Initialize a clean cache if the memory allowance is not zero
 	// or reuse the provided cache if it is not nil (inherited from
 	// the original disk layer).
 	if nodes == nil && db.config.CleanCacheSize != 0 {
-		nodes = fastcache.New(db.config.CleanCacheSize)
+		nodes = cachemgr.NewCache(db.config.CleanCacheSize)
 	}
 	return &diskLayer{
 		root:   root,
@@ -231,10 +231,15 @@ func (dl *diskLayer) commit(bottom *diffLayer, force bool) (*diskLayer, error) {
 		oldest   uint64
 	)
 	if dl.db.freezer != nil {
-		err := writeHistory(dl.db.freezer, bottom)
+		var base *history
+		if dl.db.config.CompressHistory {
+			base = dl.db.historyBase(bottom.stateID() - 1)
+		}
+		h, err := writeHistory(dl.db.freezer, bottom, dl.db.config.CompressHistory, base)
 		if err != nil {
 			return nil, err
 		}
+		dl.db.lastHistory.Store(&storedHistory{id: bottom.stateID(), history: h})
 		// Determine if the persisted history object has exceeded the configured
 		// limitation, set the overflow as true if so.
 		tail, err := dl.db.freezer.Tail()
@@ -71,6 +71,7 @@ const (
 
 	stateHistoryV0 = uint8(0)       // initial version of state history structure
 	stateHistoryV1 = uint8(1)       // use the storage slot raw key as the identifier instead of the key hash
+	stateHistoryV2 = uint8(2)       // deduplicate the data and delta encode it against the previous history
 	historyVersion = stateHistoryV1 // the default state history version
 )
 
@@ -202,15 +203,23 @@ type meta struct {
 	parent  common.Hash // prev-state root before the state transition
 	root    common.Hash // post-state root after the state transition
 	block   uint64      // associated block number
+	delta   bool        // whether the data is delta encoded against the previous history, v2 only
 }
 
 // encode packs the meta object into byte stream.
 func (m *meta) encode() []byte {
-	buf := make([]byte, historyMetaSize)
+	size := historyMetaSize
+	if m.version == stateHistoryV2 {
+		size += 1
+	}
+	buf := make([]byte, size)
 	buf[0] = m.version
 	copy(buf[1:1+common.HashLength], m.parent.Bytes())
 	copy(buf[1+common.HashLength:1+2*common.HashLength], m.root.Bytes())
 	binary.BigEndian.PutUint64(buf[1+2*common.HashLength:historyMetaSize], m.block)
+	if m.delta {
+		buf[historyMetaSize] = 1
+	}
 	return buf[:]
 }
 
@@ -229,6 +238,16 @@ func (m *meta) decode(blob []byte) error {
 		m.root = common.BytesToHash(blob[1+common.HashLength : 1+2*common.HashLength])
 		m.block = binary.BigEndian.Uint64(blob[1+2*common.HashLength : historyMetaSize])
 		return nil
+	case stateHistoryV2:
+		if len(blob) != historyMetaSize+1 {
+			return fmt.Errorf("invalid state history meta, len: %d", len(blob))
+		}
+		m.version = blob[0]
+		m.parent = common.BytesToHash(blob[1 : 1+common.HashLength])
+		m.root = common.BytesToHash(blob[1+common.HashLength : 1+2*common.HashLength])
+		m.block = binary.BigEndian.Uint64(blob[1+2*common.HashLength : historyMetaSize])
+		m.delta = blob[historyMetaSize] != 0
+		return nil
 	default:
 		return fmt.Errorf("unknown version %d", blob[0])
 	}
@@ -348,6 +367,7 @@ type decoder struct {
 	storageData    []byte // the buffer for concatenated storage data
 	accountIndexes []byte // the buffer for concatenated account index
 	storageIndexes []byte // the buffer for concatenated storage index
+	compressed     bool   // whether the entries may reference earlier identical ones (v2)
 
 	lastAccount       *common.Address // the address of last resolved account
 	lastAccountRead   uint32          // the read-cursor position of account data
@@ -394,17 +414,19 @@ func (r *decoder) readAccount(pos int) (accountIndex, []byte, error) {
 			return accountIndex{}, nil, errors.New("account is not in order")
 		}
 	}
-	if index.offset != r.lastAccountRead {
+	last := index.offset + uint32(index.length)
+	if index.offset != r.lastAccountRead && (!r.compressed || last > r.lastAccountRead) {
 		return accountIndex{}, nil, errors.New("account data buffer is gaped")
 	}
-	last := index.offset + uint32(index.length)
 	if uint32(len(r.accountData)) < last {
 		return accountIndex{}, nil, errors.New("account data buffer is corrupted")
 	}
 	data := r.accountData[index.offset:last]
 
 	r.lastAccount = &index.address
-	r.lastAccountRead = last
+	if index.offset == r.lastAccountRead {
+		r.lastAccountRead = last
+	}
 
 	return index, data, nil
 }
@@ -442,19 +464,21 @@ func (r *decoder) readStorage(accIndex accountIndex) ([]common.Hash, map[common.
 				return nil, nil, fmt.Errorf("storage slot is not in order, last: %x, current: %x", *last, index.id)
 			}
 		}
-		if index.offset != r.lastSlotDataRead {
+		sEnd := index.offset + uint32(index.length)
+		if index.offset != r.lastSlotDataRead && (!r.compressed || sEnd > r.lastSlotDataRead) {
 			return nil, nil, errors.New("storage data buffer is gapped")
 		}
-		sEnd := index.offset + uint32(index.length)
 		if uint32(len(r.storageData)) < sEnd {
 			return nil, nil, errors.New("storage data buffer is corrupted")
 		}
-		storage[index.id] = r.storageData[r.lastSlotDataRead:sEnd]
+		storage[index.id] = r.storageData[index.offset:sEnd]
 		list = append(list, index.id)
 
 		last = &index.id
 		r.lastSlotIndexRead = end
-		r.lastSlotDataRead = sEnd
+		if index.offset == r.lastSlotDataRead {
+			r.lastSlotDataRead = sEnd
+		}
 	}
 	return list, storage, nil
 }
@@ -473,6 +497,7 @@ func (h *history) decode(accountData, storageData, accountIndexes, storageIndexe
 			storageData:    storageData,
 			accountIndexes: accountIndexes,
 			storageIndexes: storageIndexes,
+			compressed:     h.meta != nil && h.meta.version == stateHistoryV2,
 		}
 	)
 	if err := r.verify(); err != nil {
@@ -506,6 +531,12 @@ func (h *history) decode(accountData, storageData, accountIndexes, storageIndexe
 
 // readHistory reads and decodes the state history object by the given id.
 func readHistory(reader ethdb.AncientReader, id uint64) (*history, error) {
+	return readHistoryWithBase(reader, id, nil)
+}
+
+// readHistoryWithBase reads and decodes the state history object by the given
+// id. The optional base is the previous history, read if needed and not given.
+func readHistoryWithBase(reader ethdb.AncientReader, id uint64, base *history) (*history, error) {
 	blob := rawdb.ReadStateHistoryMeta(reader, id)
 	if len(blob) == 0 {
 		return nil, fmt.Errorf("state history not found %d", id)
@@ -514,6 +545,15 @@ func readHistory(reader ethdb.AncientReader, id uint64) (*history, error) {
 	if err := m.decode(blob); err != nil {
 		return nil, err
 	}
+	if m.delta && (base == nil || base.meta.root != m.parent) {
+		var err error
+		if base, err = readHistory(reader, id-1); err != nil {
+			return nil, fmt.Errorf("failed to read base of state history %d: %v", id, err)
+		}
+		if base.meta.root != m.parent {
+			return nil, fmt.Errorf("state history %d not based on the previous one", id)
+		}
+	}
 	var (
 		dec            = history{meta: &m}
 		accountData    = rawdb.ReadStateAccountHistory(reader, id)
@@ -524,20 +564,36 @@ func readHistory(reader ethdb.AncientReader, id uint64) (*history, error) {
 	if err := dec.decode(accountData, storageData, accountIndexes, storageIndexes); err != nil {
 		return nil, err
 	}
+	if m.version == stateHistoryV2 {
+		if !m.delta {
+			base = nil
+		}
+		if err := dec.resolve(base); err != nil {
+			return nil, err
+		}
+	}
 	return &dec, nil
 }
 
-// writeHistory persists the state history with the provided state set.
-func writeHistory(writer ethdb.AncientWriter, dl *diffLayer) error {
+// writeHistory persists the state history with the provided state set and
+// returns it. If compress is set, the history is written in the compressed
+// format, delta encoded against the given previous history if any.
+func writeHistory(writer ethdb.AncientWriter, dl *diffLayer, compress bool, base *history) (*history, error) {
 	// Short circuit if state set is not available.
 	if dl.states == nil {
-		return errors.New("state change set is not available")
+		return nil, errors.New("state change set is not available")
 	}
 	var (
 		start   = time.Now()
 		history = newHistory(dl.rootHash(), dl.parentLayer().rootHash(), dl.block, dl.states.accountOrigin, dl.states.storageOrigin, dl.states.rawStorageKey)
+
+		accountData, storageData, accountIndex, storageIndex []byte
 	)
-	accountData, storageData, accountIndex, storageIndex := history.encode()
+	if compress && history.meta.version != stateHistoryV0 {
+		accountData, storageData, accountIndex, storageIndex = history.encodeCompressed(dl.stateID(), base)
+	} else {
+		accountData, storageData, accountIndex, storageIndex = history.encode()
+	}
 	dataSize := common.StorageSize(len(accountData) + len(storageData))
 	indexSize := common.StorageSize(len(accountIndex) + len(storageIndex))
 
@@ -549,7 +605,7 @@ func writeHistory(writer ethdb.AncientWriter, dl *diffLayer) error {
 	historyBuildTimeMeter.UpdateSince(start)
 	log.Debug("Stored state history", "id", dl.stateID(), "block", dl.block, "data", dataSize, "index", indexSize, "elapsed", common.PrettyDuration(time.Since(start)))
 
-	return nil
+	return history, nil
 }
 
 // checkHistories retrieves a batch of meta objects with the specified range
@@ -636,6 +692,18 @@ func truncateFromTail(db ethdb.Batcher, store ethdb.AncientStore, ntail uint64)
 	if otail > ntail || ntail > ohead {
 		return 0, fmt.Errorf("out of range, tail: %d, head: %d, target: %d", otail, ohead, ntail)
 	}
+	// Keep the histories delta encoded against the first remaining one back
+	// to the first one encoded on its own, as they can't be decoded otherwise.
+	for ntail > otail && ntail < ohead {
+		var m meta
+		if err := m.decode(rawdb.ReadStateHistoryMeta(store, ntail+1)); err != nil {
+			return 0, err
+		}
+		if !m.delta {
+			break
+		}
+		ntail--
+	}
 	// Short circuit if nothing to truncate.
 	if otail == ntail {
 		return 0, nil
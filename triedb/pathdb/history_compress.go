@@ -0,0 +1,389 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// The compressed state histories (v2) share the layout of the v1 ones, but
+// each account and storage slot data item is an entry prefixed by its type:
+//
+//   - entryRaw:   the value as is
+//   - entrySame:  nothing, the value is the same as in the previous history
+//   - entryDelta: the value differs from the one in the previous history in
+//     the middle, the lengths of the common prefix and suffix (1 byte each)
+//     are followed by the differing bytes
+//
+// The same accounts and slots are usually modified in adjacent blocks, and
+// their values barely change (e.g. the nonce and balance of an account), so
+// most of the data is encoded against the previous history. Besides, an index
+// may point to an earlier identical entry instead of the data being repeated.
+//
+// A history delta encoded against the previous one can't be decoded without
+// it, so the chains of such histories are bounded by encoding one history on
+// its own every historyKeyframeInterval, and the tail pruning keeps the chain
+// of the first remaining history.
+const (
+	entryRaw   = byte(0) // Entry holding the value as is
+	entrySame  = byte(1) // Entry of a value unchanged from the previous history
+	entryDelta = byte(2) // Entry of a value partially changed from the previous history
+
+	// historyKeyframeInterval is the interval of the compressed histories
+	// encoded on their own, bounding the number of histories to read to
+	// decode one.
+	historyKeyframeInterval = 16
+)
+
+// encodeEntry encodes a value against its previous value, if any.
+func encodeEntry(value []byte, prev []byte, hasPrev bool) []byte {
+	if hasPrev {
+		if bytes.Equal(value, prev) {
+			return []byte{entrySame}
+		}
+		prefix := commonPrefix(value, prev)
+		suffix := commonSuffix(value[prefix:], prev[prefix:])
+		if prefix+suffix > 2 {
+			entry := make([]byte, 0, 3+len(value)-prefix-suffix)
+			entry = append(entry, entryDelta, byte(prefix), byte(suffix))
+			return append(entry, value[prefix:len(value)-suffix]...)
+		}
+	}
+	return append([]byte{entryRaw}, value...)
+}
+
+// decodeEntry decodes an entry against the previous value, if any.
+func decodeEntry(entry []byte, prev []byte, hasPrev bool) ([]byte, error) {
+	if len(entry) == 0 {
+		return nil, errors.New("empty entry")
+	}
+	switch entry[0] {
+	case entryRaw:
+		return entry[1:], nil
+	case entrySame:
+		if !hasPrev {
+			return nil, errors.New("unchanged entry without previous value")
+		}
+		return prev, nil
+	case entryDelta:
+		if len(entry) < 3 {
+			return nil, errors.New("short delta entry")
+		}
+		prefix, suffix := int(entry[1]), int(entry[2])
+		if !hasPrev || prefix+suffix > len(prev) {
+			return nil, errors.New("delta entry without matching previous value")
+		}
+		value := make([]byte, 0, prefix+len(entry)-3+suffix)
+		value = append(value, prev[:prefix]...)
+		value = append(value, entry[3:]...)
+		return append(value, prev[len(prev)-suffix:]...), nil
+	default:
+		return nil, fmt.Errorf("unknown entry type %d", entry[0])
+	}
+}
+
+// commonPrefix returns the length of the common prefix of a and b, up to 255.
+func commonPrefix(a, b []byte) int {
+	n := min(len(a), len(b), 255)
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// commonSuffix returns the length of the common suffix of a and b, up to 255.
+func commonSuffix(a, b []byte) int {
+	n := min(len(a), len(b), 255)
+	for i := 0; i < n; i++ {
+		if a[len(a)-1-i] != b[len(b)-1-i] {
+			return i
+		}
+	}
+	return n
+}
+
+// appendEntry appends the entry to the data unless an identical one is already
+// in it, and returns the offset of the entry.
+func appendEntry(data []byte, entry []byte, offsets map[string]uint32) ([]byte, uint32) {
+	if offset, ok := offsets[string(entry)]; ok {
+		return data, offset
+	}
+	offset := uint32(len(data))
+	offsets[string(entry)] = offset
+	return append(data, entry...), offset
+}
+
+// encodeCompressed serializes the state history with the given id in the
+// compressed format, delta encoded against the previous history if given.
+// Like encode, it returns the concatenated account/storage data and the
+// account/storage indexes.
+func (h *history) encodeCompressed(id uint64, prev *history) ([]byte, []byte, []byte, []byte) {
+	if id%historyKeyframeInterval == 0 || (prev != nil && (prev.meta.version == stateHistoryV0 || prev.meta.root != h.meta.parent)) {
+		prev = nil
+	}
+	h.meta.version = stateHistoryV2
+	h.meta.delta = prev != nil
+
+	var (
+		slotNumber     uint32 // the number of processed slots
+		accountData    []byte // the buffer for concatenated account data
+		storageData    []byte // the buffer for concatenated storage data
+		accountIndexes []byte // the buffer for concatenated account index
+		storageIndexes []byte // the buffer for concatenated storage index
+
+		accountOffsets = make(map[string]uint32)
+		storageOffsets = make(map[string]uint32)
+	)
+	for _, addr := range h.accountList {
+		var (
+			prevAccount []byte
+			hasPrev     bool
+			prevSlots   map[common.Hash][]byte
+		)
+		if prev != nil {
+			prevAccount, hasPrev = prev.accounts[addr]
+			prevSlots = prev.storages[addr]
+		}
+		entry := encodeEntry(h.accounts[addr], prevAccount, hasPrev)
+		accIndex := accountIndex{
+			address: addr,
+			length:  uint8(len(entry)),
+		}
+		accountData, accIndex.offset = appendEntry(accountData, entry, accountOffsets)
+
+		slots, exist := h.storages[addr]
+		if exist {
+			// Encode storage slots in order
+			for _, slotKey := range h.storageList[addr] {
+				prevSlot, hasPrevSlot := prevSlots[slotKey]
+				entry := encodeEntry(slots[slotKey], prevSlot, hasPrevSlot)
+				sIndex := slotIndex{
+					id:     slotKey,
+					length: uint8(len(entry)),
+				}
+				storageData, sIndex.offset = appendEntry(storageData, entry, storageOffsets)
+				storageIndexes = append(storageIndexes, sIndex.encode()...)
+			}
+			// Fill up the storage meta in account index
+			accIndex.storageOffset = slotNumber
+			accIndex.storageSlots = uint32(len(slots))
+			slotNumber += uint32(len(slots))
+		}
+		accountIndexes = append(accountIndexes, accIndex.encode()...)
+	}
+	return accountData, storageData, accountIndexes, storageIndexes
+}
+
+// resolve decodes the entries of a compressed history, against the previous
+// history if the history is delta encoded.
+func (h *history) resolve(prev *history) error {
+	if h.meta.delta && prev == nil {
+		return errors.New("delta encoded state history without previous history")
+	}
+	for addr, entry := range h.accounts {
+		var (
+			prevAccount []byte
+			hasPrev     bool
+		)
+		if prev != nil {
+			prevAccount, hasPrev = prev.accounts[addr]
+		}
+		value, err := decodeEntry(entry, prevAccount, hasPrev)
+		if err != nil {
+			return fmt.Errorf("account %x: %v", addr, err)
+		}
+		h.accounts[addr] = value
+	}
+	for addr, slots := range h.storages {
+		var prevSlots map[common.Hash][]byte
+		if prev != nil {
+			prevSlots = prev.storages[addr]
+		}
+		for key, entry := range slots {
+			prevSlot, hasPrev := prevSlots[key]
+			value, err := decodeEntry(entry, prevSlot, hasPrev)
+			if err != nil {
+				return fmt.Errorf("account %x slot %x: %v", addr, key, err)
+			}
+			slots[key] = value
+		}
+	}
+	return nil
+}
+
+// historyBase returns the state history with the given id to delta encode the
+// next one against, nil if it's not available.
+func (db *Database) historyBase(id uint64) *history {
+	if id == 0 {
+		return nil
+	}
+	if last := db.lastHistory.Load(); last != nil && last.id == id {
+		return last.history
+	}
+	h, err := readHistory(db.freezer, id)
+	if err != nil {
+		return nil
+	}
+	return h
+}
+
+// CompactStats is the outcome of a state history compaction.
+type CompactStats struct {
+	Histories  uint64             // Number of rewritten state histories
+	SizeBefore common.StorageSize // Size of the state histories before the compaction
+	SizeAfter  common.StorageSize // Size of the state histories after the compaction
+}
+
+// CompactHistory rewrites the state histories in the freezer of the given
+// ancient directory in the compressed format. The database must not be in use.
+//
+// The histories are written into a new freezer, which replaces the existing one
+// once complete. An interrupted compaction leaves the existing histories intact,
+// and is resumed from scratch by compacting again.
+func CompactHistory(ancientDir string, verkle bool) (*CompactStats, error) {
+	var (
+		dir  = rawdb.StateFreezerDir(ancientDir, verkle)
+		next = dir + ".compact"
+		old  = dir + ".old"
+	)
+	// Restore the histories if the freezers were not swapped completely
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if _, err := os.Stat(old); err != nil {
+			return nil, fmt.Errorf("no state history in %s", dir)
+		}
+		if err := os.Rename(old, dir); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.RemoveAll(next); err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(old); err != nil {
+		return nil, err
+	}
+	stats := new(CompactStats)
+	if err := compactHistory(dir, next, stats); err != nil {
+		os.RemoveAll(next)
+		return nil, err
+	}
+	// Swap the freezers
+	if err := os.Rename(dir, old); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(next, dir); err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(old); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// compactHistory writes the state histories of the freezer in the directory src
+// into a new freezer in the directory dst, in the compressed format.
+func compactHistory(src, dst string, stats *CompactStats) error {
+	reader, err := rawdb.NewStateFreezerAt(src, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writer, err := rawdb.NewStateFreezerAt(dst, false)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	tail, err := reader.Tail()
+	if err != nil {
+		return err
+	}
+	head, err := reader.Ancients()
+	if err != nil {
+		return err
+	}
+	if err := rawdb.SkipStateHistory(writer, tail+1); err != nil {
+		return err
+	}
+	var (
+		prev   *history
+		start  = time.Now()
+		logged = time.Now()
+	)
+	for id := tail + 1; id <= head; id++ {
+		h, err := readHistoryWithBase(reader, id, prev)
+		if err != nil {
+			return err
+		}
+		// The decoded history is encoded in place, make a copy of the meta
+		m := *h.meta
+		h.meta = &m
+
+		var accountData, storageData, accountIndex, storageIndex []byte
+		if h.meta.version == stateHistoryV0 {
+			accountData, storageData, accountIndex, storageIndex = h.encode()
+		} else {
+			accountData, storageData, accountIndex, storageIndex = h.encodeCompressed(id, prev)
+		}
+		rawdb.WriteStateHistory(writer, id, h.meta.encode(), accountIndex, storageIndex, accountData, storageData)
+		prev = h
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Compacting state histories", "id", id, "head", head, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := writer.Sync(); err != nil {
+		return err
+	}
+	stats.Histories = head - tail
+	if stats.SizeBefore, err = dirSize(src); err != nil {
+		return err
+	}
+	if stats.SizeAfter, err = dirSize(dst); err != nil {
+		return err
+	}
+	return nil
+}
+
+// dirSize returns the total size of the files in the directory.
+func dirSize(dir string) (common.StorageSize, error) {
+	var size common.StorageSize
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += common.StorageSize(info.Size())
+		return nil
+	})
+	return size, err
+}
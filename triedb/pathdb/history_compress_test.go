@@ -0,0 +1,238 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/testrand"
+)
+
+// makeSimilarHistories generates a chain of histories modifying the same
+// accounts and slots, with the values slightly changed from one to the next.
+func makeSimilarHistories(n int) []*history {
+	var (
+		parent             = testrand.Hash()
+		accounts, storages = randomStateSet(5)
+		result             []*history
+	)
+	for i := 0; i < n; i++ {
+		var (
+			root  = testrand.Hash()
+			accs  = make(map[common.Address][]byte)
+			slots = make(map[common.Address]map[common.Hash][]byte)
+		)
+		for addr, blob := range accounts {
+			blob = common.CopyBytes(blob)
+			blob[len(blob)-1] += byte(i % 2) // Changed every other history
+			accs[addr] = blob
+		}
+		for addr, set := range storages {
+			slots[addr] = make(map[common.Hash][]byte)
+			for key, blob := range set {
+				slots[addr][key] = blob
+			}
+			// Set a new slot in each history, to the same value in every account
+			slots[addr][testrand.Hash()] = []byte{0x01}
+		}
+		result = append(result, newHistory(root, parent, uint64(i), accs, slots, true))
+		parent = root
+	}
+	return result
+}
+
+// writeCompressedHistories writes the histories into the freezer in the
+// compressed format, each one against the previous one, and returns the size
+// of the written data.
+func writeCompressedHistories(freezer ethdb.AncientWriter, hs []*history) int {
+	var (
+		prev *history
+		size int
+	)
+	for i, h := range hs {
+		accountData, storageData, accountIndex, storageIndex := h.encodeCompressed(uint64(i+1), prev)
+		rawdb.WriteStateHistory(freezer, uint64(i+1), h.meta.encode(), accountIndex, storageIndex, accountData, storageData)
+		size += len(accountData) + len(storageData)
+		prev = h
+	}
+	return size
+}
+
+// checkDecodedHistory checks the decoded history against the original one.
+func checkDecodedHistory(t *testing.T, id uint64, dec *history, obj *history) {
+	t.Helper()
+
+	if dec.meta.root != obj.meta.root || dec.meta.parent != obj.meta.parent {
+		t.Fatalf("history %d: meta is mismatched", id)
+	}
+	if !compareSet(dec.accounts, obj.accounts) {
+		t.Fatalf("history %d: account data is mismatched", id)
+	}
+	if !compareStorages(dec.storages, obj.storages) {
+		t.Fatalf("history %d: storage data is mismatched", id)
+	}
+	if !compareList(dec.accountList, obj.accountList) {
+		t.Fatalf("history %d: account list is mismatched", id)
+	}
+	if !compareStorageList(dec.storageList, obj.storageList) {
+		t.Fatalf("history %d: storage list is mismatched", id)
+	}
+}
+
+func TestEncodeDecodeEntry(t *testing.T) {
+	value := make([]byte, 64)
+	for i := range value {
+		value[i] = byte(i)
+	}
+	var (
+		head = append(common.CopyBytes(value[:40]), bytes.Repeat([]byte{0xff}, 24)...)
+		tail = append(bytes.Repeat([]byte{0xff}, 10), value[10:]...)
+		mid  = common.CopyBytes(value)
+	)
+	mid[20] = 0xff
+
+	for i, tt := range []struct {
+		prev    []byte
+		hasPrev bool
+		size    int
+	}{
+		{nil, false, 65},      // no previous value
+		{nil, true, 65},       // previous value empty
+		{value, true, 1},      // unchanged
+		{head, true, 27},      // common prefix
+		{tail, true, 13},      // common suffix
+		{mid, true, 4},        // one byte changed
+		{[]byte{1}, true, 65}, // nothing in common
+	} {
+		entry := encodeEntry(value, tt.prev, tt.hasPrev)
+		if len(entry) != tt.size {
+			t.Errorf("test %d: wrong entry size: have %d, want %d", i, len(entry), tt.size)
+		}
+		dec, err := decodeEntry(entry, tt.prev, tt.hasPrev)
+		if err != nil {
+			t.Fatalf("test %d: failed to decode entry: %v", i, err)
+		}
+		if !bytes.Equal(dec, value) {
+			t.Fatalf("test %d: wrong decoded value: have %x, want %x", i, dec, value)
+		}
+	}
+	// Values equal to their empty previous value
+	if dec, err := decodeEntry(encodeEntry(nil, nil, true), nil, true); err != nil || len(dec) != 0 {
+		t.Fatalf("wrong decoded empty value: %x, %v", dec, err)
+	}
+	// Entries relative to a missing previous value are refused
+	if _, err := decodeEntry(encodeEntry(value, mid, true), nil, false); err == nil {
+		t.Fatal("delta entry decoded without previous value")
+	}
+}
+
+func TestCompressedHistories(t *testing.T) {
+	var (
+		hs         = makeSimilarHistories(2*historyKeyframeInterval + 2)
+		db         = rawdb.NewMemoryDatabase()
+		freezer, _ = rawdb.NewStateFreezer(t.TempDir(), false, false)
+		raw        int
+	)
+	defer freezer.Close()
+
+	for _, h := range hs {
+		accountData, storageData, _, _ := h.encode()
+		raw += len(accountData) + len(storageData)
+	}
+	size := writeCompressedHistories(freezer, hs)
+	if size*2 > raw {
+		t.Fatalf("histories not compressed: size %d, uncompressed %d", size, raw)
+	}
+	for i, h := range hs {
+		id := uint64(i + 1)
+		rawdb.WriteStateID(db, h.meta.root, id)
+
+		if want := id != 1 && id%historyKeyframeInterval != 0; h.meta.delta != want {
+			t.Fatalf("history %d: delta %t, want %t", id, h.meta.delta, want)
+		}
+		dec, err := readHistory(freezer, id)
+		if err != nil {
+			t.Fatalf("failed to read history %d: %v", id, err)
+		}
+		checkDecodedHistory(t, id, dec, h)
+	}
+	// The tail is kept back to the first history decodable on its own
+	pruned, err := truncateFromTail(db, freezer, historyKeyframeInterval+2)
+	if err != nil {
+		t.Fatalf("failed to truncate tail: %v", err)
+	}
+	if pruned != historyKeyframeInterval-1 {
+		t.Fatalf("wrong pruned histories: have %d, want %d", pruned, historyKeyframeInterval-1)
+	}
+	for id := uint64(historyKeyframeInterval); id <= uint64(len(hs)); id++ {
+		dec, err := readHistory(freezer, id)
+		if err != nil {
+			t.Fatalf("failed to read history %d: %v", id, err)
+		}
+		checkDecodedHistory(t, id, dec, hs[id-1])
+	}
+}
+
+func TestCompactHistory(t *testing.T) {
+	var (
+		dir        = t.TempDir()
+		hs         = makeSimilarHistories(40)
+		freezer, _ = rawdb.NewStateFreezer(dir, false, false)
+	)
+	for i, h := range hs {
+		accountData, storageData, accountIndex, storageIndex := h.encode()
+		rawdb.WriteStateHistory(freezer, uint64(i+1), h.meta.encode(), accountIndex, storageIndex, accountData, storageData)
+	}
+	if _, err := truncateFromTail(rawdb.NewMemoryDatabase(), freezer, 5); err != nil {
+		t.Fatalf("failed to truncate tail: %v", err)
+	}
+	freezer.Close()
+
+	stats, err := CompactHistory(dir, false)
+	if err != nil {
+		t.Fatalf("failed to compact histories: %v", err)
+	}
+	if stats.Histories != uint64(len(hs)-5) {
+		t.Fatalf("wrong compacted histories: have %d, want %d", stats.Histories, len(hs)-5)
+	}
+	if stats.SizeAfter >= stats.SizeBefore {
+		t.Fatalf("histories not compacted: size before %v, after %v", stats.SizeBefore, stats.SizeAfter)
+	}
+	freezer, _ = rawdb.NewStateFreezer(dir, false, true)
+	defer freezer.Close()
+
+	if tail, _ := freezer.Tail(); tail != 5 {
+		t.Fatalf("wrong tail: have %d, want %d", tail, 5)
+	}
+	if head, _ := freezer.Ancients(); head != uint64(len(hs)) {
+		t.Fatalf("wrong head: have %d, want %d", head, len(hs))
+	}
+	for id := uint64(6); id <= uint64(len(hs)); id++ {
+		dec, err := readHistory(freezer, id)
+		if err != nil {
+			t.Fatalf("failed to read history %d: %v", id, err)
+		}
+		if dec.meta.version != stateHistoryV2 {
+			t.Fatalf("history %d not compacted", id)
+		}
+		checkDecodedHistory(t, id, dec, hs[id-1])
+	}
+}
@@ -66,6 +66,7 @@ func inspectHistory(freezer ethdb.AncientReader, start, end uint64, onHistory fu
 		stats  = &HistoryStats{}
 		init   = time.Now()
 		logged = time.Now()
+		prev   *history
 	)
 	start, end, err := sanitizeRange(start, end, freezer)
 	if err != nil {
@@ -74,10 +75,11 @@ func inspectHistory(freezer ethdb.AncientReader, start, end uint64, onHistory fu
 	for id := start; id <= end; id += 1 {
 		// The entire history object is decoded, although it's unnecessary for
 		// account inspection. TODO(rjl493456442) optimization is worthwhile.
-		h, err := readHistory(freezer, id)
+		h, err := readHistoryWithBase(freezer, id, prev)
 		if err != nil {
 			return nil, err
 		}
+		prev = h
 		if id == start {
 			stats.Start = h.meta.block
 		}
@@ -22,8 +22,8 @@ import (
 	"io"
 	"maps"
 
-	"github.com/VictoriaMetrics/fastcache"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/cachemgr"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -274,7 +274,7 @@ func (s *nodeSet) decode(r *rlp.Stream) error {
 }
 
 // write flushes nodes into the provided database batch as a whole.
-func (s *nodeSet) write(batch ethdb.Batch, clean *fastcache.Cache) int {
+func (s *nodeSet) write(batch ethdb.Batch, clean *cachemgr.Cache) int {
 	nodes := make(map[common.Hash]map[string]*trienode.Node)
 	if len(s.accountNodes) > 0 {
 		nodes[common.Hash{}] = s.accountNodes
@@ -0,0 +1,250 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// walVersion ensures that an incompatible write-ahead log entry is detected
+// and discarded.
+const walVersion uint64 = 0
+
+// writeAheadLog tracks the diff layers recorded in the key-value store since
+// the last flush of the disk layer. Unlike the journal, which is only written
+// at shutdown, each layer is recorded as soon as it is added to the tree, so
+// after a crash the layers above the persistent state can be replayed instead
+// of re-executing the blocks.
+type writeAheadLog struct {
+	next    uint64     // Sequence number of the next entry
+	entries []walEntry // Recorded entries in sequence order
+}
+
+// walEntry is the reference of a recorded layer.
+type walEntry struct {
+	seq uint64 // Sequence number of the entry
+	id  uint64 // State id of the layer
+}
+
+// encodeWALEntry marshals a diff layer along with its parent root into a
+// write-ahead log entry.
+func encodeWALEntry(parentRoot common.Hash, dl *diffLayer) ([]byte, error) {
+	w := new(bytes.Buffer)
+	if err := rlp.Encode(w, walVersion); err != nil {
+		return nil, err
+	}
+	if err := rlp.Encode(w, dl.id); err != nil {
+		return nil, err
+	}
+	if err := rlp.Encode(w, parentRoot); err != nil {
+		return nil, err
+	}
+	if err := rlp.Encode(w, dl.root); err != nil {
+		return nil, err
+	}
+	if err := rlp.Encode(w, dl.block); err != nil {
+		return nil, err
+	}
+	if err := dl.nodes.encode(w); err != nil {
+		return nil, err
+	}
+	if err := dl.states.encode(w); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeWAL records the layer with the given root, just added on top of the
+// given parent, in the write-ahead log.
+func (db *Database) writeWAL(root common.Hash, parentRoot common.Hash) error {
+	dl, ok := db.tree.get(root).(*diffLayer)
+	if !ok {
+		return fmt.Errorf("triedb layer [%#x] missing", root)
+	}
+	blob, err := encodeWALEntry(parentRoot, dl)
+	if err != nil {
+		return err
+	}
+	rawdb.WriteTrieWALEntry(db.diskdb, db.wal.next, blob)
+	db.wal.entries = append(db.wal.entries, walEntry{seq: db.wal.next, id: dl.id})
+	db.wal.next++
+	return nil
+}
+
+// truncateWAL deletes the entries of the layers which are no longer needed
+// for recovery, as their state transitions have been flushed to disk.
+func (db *Database) truncateWAL() error {
+	persisted := rawdb.ReadPersistentStateID(db.diskdb)
+	if len(db.wal.entries) == 0 || db.wal.entries[0].id > persisted {
+		return nil
+	}
+	var (
+		batch = db.diskdb.NewBatch()
+		kept  []walEntry
+	)
+	for _, entry := range db.wal.entries {
+		if entry.id <= persisted {
+			rawdb.DeleteTrieWALEntry(batch, entry.seq)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
+	db.wal.entries = kept
+	return batch.Write()
+}
+
+// walLayer is a decoded write-ahead log entry.
+type walLayer struct {
+	id         uint64
+	parentRoot common.Hash
+	root       common.Hash
+	block      uint64
+	nodes      nodeSet
+	states     StateSetWithOrigin
+}
+
+// decodeWALEntry unmarshals a write-ahead log entry.
+func decodeWALEntry(blob []byte) (*walLayer, error) {
+	r := rlp.NewStream(bytes.NewReader(blob), 0)
+
+	version, err := r.Uint64()
+	if err != nil {
+		return nil, errMissVersion
+	}
+	if version != walVersion {
+		return nil, fmt.Errorf("%w want %d got %d", errUnexpectedVersion, walVersion, version)
+	}
+	var l walLayer
+	if err := r.Decode(&l.id); err != nil {
+		return nil, fmt.Errorf("load state id: %v", err)
+	}
+	if err := r.Decode(&l.parentRoot); err != nil {
+		return nil, fmt.Errorf("load parent root: %v", err)
+	}
+	if err := r.Decode(&l.root); err != nil {
+		return nil, fmt.Errorf("load diff root: %v", err)
+	}
+	if err := r.Decode(&l.block); err != nil {
+		return nil, fmt.Errorf("load block number: %v", err)
+	}
+	if err := l.nodes.decode(r); err != nil {
+		return nil, err
+	}
+	if err := l.states.decode(r); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// replayWAL links the layers recorded in the write-ahead log which are missing
+// from the tree, typically after an unclean shutdown lost the layers kept in
+// memory. The entries which are flushed already, undecodable or can't be
+// linked to the tree are deleted, unless the database is read only.
+//
+// If the write-ahead log is disabled, the recorded layers are still replayed
+// but the log is cleared afterwards.
+func (db *Database) replayWAL() {
+	var (
+		start     = time.Now()
+		persisted = rawdb.ReadPersistentStateID(db.diskdb)
+		wal       = new(writeAheadLog)
+		obsolete  []uint64
+		head      layer
+		replayed  int
+	)
+	err := rawdb.IterateTrieWAL(db.diskdb, func(seq uint64, blob []byte) error {
+		wal.next = seq + 1
+
+		l, err := decodeWALEntry(blob)
+		if err != nil {
+			log.Debug("Discarded trie write-ahead log entry", "seq", seq, "err", err)
+			obsolete = append(obsolete, seq)
+			return nil
+		}
+		if l.id <= persisted {
+			obsolete = append(obsolete, seq)
+			return nil
+		}
+		// The layer is still needed for recovery if it is already in the tree,
+		// e.g. loaded from the journal, or it can be linked to its parent. The
+		// parents are always recorded ahead of their children.
+		if db.tree.get(l.root) == nil {
+			parent := db.tree.get(l.parentRoot)
+			if parent == nil || parent.stateID()+1 != l.id {
+				obsolete = append(obsolete, seq)
+				return nil
+			}
+			dl := newDiffLayer(parent, l.root, l.id, l.block, &l.nodes, &l.states)
+
+			db.tree.lock.Lock()
+			db.tree.layers[l.root] = dl
+			db.tree.lock.Unlock()
+
+			head = dl
+			replayed++
+		}
+		wal.entries = append(wal.entries, walEntry{seq: seq, id: l.id})
+		return nil
+	})
+	if err != nil {
+		log.Crit("Failed to iterate trie write-ahead log", "err", err)
+	}
+	if replayed > 0 {
+		log.Info("Replayed trie write-ahead log", "layers", replayed, "head", head.rootHash(), "id", head.stateID(), "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	if db.readOnly {
+		return
+	}
+	if !db.config.WriteAheadLog {
+		for _, entry := range wal.entries {
+			obsolete = append(obsolete, entry.seq)
+		}
+		wal.entries = nil
+	}
+	if len(obsolete) > 0 {
+		batch := db.diskdb.NewBatch()
+		for _, seq := range obsolete {
+			rawdb.DeleteTrieWALEntry(batch, seq)
+		}
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed to clean trie write-ahead log", "err", err)
+		}
+	}
+	if db.config.WriteAheadLog {
+		db.wal = wal
+	}
+}
+
+// clearWAL deletes all the entries of the write-ahead log, if enabled.
+func (db *Database) clearWAL() error {
+	if db.wal == nil {
+		return nil
+	}
+	batch := db.diskdb.NewBatch()
+	for _, entry := range db.wal.entries {
+		rawdb.DeleteTrieWALEntry(batch, entry.seq)
+	}
+	db.wal.entries = nil
+	return batch.Write()
+}